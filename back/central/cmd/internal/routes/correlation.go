@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+const (
+	headerCorrelationID = "X-Correlation-ID"
+	headerRequestID     = "X-Request-ID"
+)
+
+// CorrelationMiddleware asigna a cada request un correlation ID (el que manda
+// el cliente o uno nuevo) y lo deja en el contexto del request. Lo que se
+// publique a RabbitMQ desde ahi lo lleva en sus headers y el logger lo imprime.
+func CorrelationMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		correlationID := c.GetHeader(headerCorrelationID)
+		if correlationID == "" {
+			correlationID = c.GetHeader(headerRequestID)
+		}
+		if correlationID == "" || len(correlationID) > 128 {
+			correlationID = uuid.New().String()
+		}
+
+		c.Header(headerCorrelationID, correlationID)
+		c.Request = c.Request.WithContext(log.WithCorrelationIDCtx(c.Request.Context(), correlationID))
+		c.Next()
+	}
+}
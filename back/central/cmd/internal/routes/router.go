@@ -9,6 +9,7 @@ import (
 	"github.com/secamc93/probability/back/central/shared/env"
 	"github.com/secamc93/probability/back/central/shared/log"
 	"github.com/secamc93/probability/back/central/shared/metrics"
+	"github.com/secamc93/probability/back/central/shared/tracing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -20,6 +21,11 @@ func BuildRouter(ctx context.Context, logger log.ILogger, environment env.IConfi
 
 	r := gin.New()
 
+	// IP del cliente: solo se cree X-Forwarded-For de los proxies configurados
+	if err := middleware.TrustProxies(r, environment); err != nil {
+		logger.Error(ctx).Err(err).Msg("TRUSTED_PROXIES invalido, se usa la IP de la conexion")
+	}
+
 	// CORS - DEBE IR PRIMERO
 	r.Use(middleware.CorsMiddleware())
 
@@ -29,6 +35,12 @@ func BuildRouter(ctx context.Context, logger log.ILogger, environment env.IConfi
 	// Prometheus metrics middleware
 	r.Use(metrics.PrometheusMiddleware())
 
+	// Tracing: span por request, continua la traza del llamador si la trae
+	r.Use(tracing.GinMiddleware())
+
+	// Correlation ID por request (antes del logging para que lo incluya)
+	r.Use(CorrelationMiddleware())
+
 	// Logging centralizado
 	SetupGinLogging(r, logger)
 
@@ -10,20 +10,35 @@ import (
 	"github.com/secamc93/probability/back/central/services/events"
 	"github.com/secamc93/probability/back/central/services/integrations"
 	"github.com/secamc93/probability/back/central/services/modules"
+	"github.com/secamc93/probability/back/central/shared/audit"
 	"github.com/secamc93/probability/back/central/shared/bedrock"
 	"github.com/secamc93/probability/back/central/shared/db"
 	"github.com/secamc93/probability/back/central/shared/email"
 	"github.com/secamc93/probability/back/central/shared/env"
 	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/outbox"
 	"github.com/secamc93/probability/back/central/shared/rabbitmq"
 	"github.com/secamc93/probability/back/central/shared/redis"
 	"github.com/secamc93/probability/back/central/shared/storage"
+	"github.com/secamc93/probability/back/central/shared/tracing"
 )
 
 func Init(ctx context.Context) error {
 	logger := log.New()
 	environment := env.New(logger)
 
+	// Tracing antes que todo lo demas: DB, Redis y RabbitMQ toman el provider global
+	shutdownTracing, err := tracing.Init(ctx, environment, logger)
+	if err != nil {
+		logger.Error(ctx).Err(err).Msg("Failed to initialize tracing - spans will not be exported")
+	} else {
+		defer func() {
+			if err := shutdownTracing(context.Background()); err != nil {
+				logger.Error(ctx).Err(err).Msg("Failed to flush pending spans")
+			}
+		}()
+	}
+
 	database := db.New(logger, environment)
 	emailService := email.New(environment, logger)
 
@@ -48,6 +63,12 @@ func Init(ctx context.Context) error {
 		}
 	}
 
+	// Relay del outbox: publica los eventos guardados en outbox_events
+	outbox.NewRelay(database, rabbitMQ, logger).Start(ctx)
+
+	// Bitacora de auditoria: escritor asincrono y purga por retencion
+	audit.Start(ctx, database, logger, environment)
+
 	// Initialize Redis
 	redisRegistry := NewRedisRegistry()
 	redisClient := redis.New(logger, environment)
@@ -71,9 +92,11 @@ func Init(ctx context.Context) error {
 	// jwtService := middleware.GetJWTService()
 
 	v1Group := r.Group("/api/v1")
+	// Antes de registrar rutas: audita todo request mutante autenticado
+	v1Group.Use(audit.Middleware(middleware.AuditActor))
 
 	// Initialize Auth Modules
-	authBundle := auth.New(v1Group, database, logger, environment, s3Service, rabbitMQ)
+	authBundle := auth.New(v1Group, database, logger, environment, s3Service, rabbitMQ, redisClient)
 
 	// Initialize unified events module (SSE + RabbitMQ consumer + publisher)
 	events.New(v1Group, logger, rabbitMQ, redisClient)
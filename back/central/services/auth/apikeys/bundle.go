@@ -0,0 +1,32 @@
+package apikeys
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/app"
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/infra/primary/handlers"
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/infra/primary/validator"
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/infra/secondary/repository"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+	"github.com/secamc93/probability/back/central/shared/db"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/ratelimit"
+	"github.com/secamc93/probability/back/central/shared/redis"
+)
+
+// New inicializa las API Keys de los negocios: el CRUD en /api-keys y el
+// validador que usan middleware.APIKey() y middleware.Auto().
+func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, redisClient redis.IRedis) {
+	logger = logger.WithModule("apikeys")
+
+	// El ritmo de cada clave sale de su rate_limit; Config solo fija cuantos
+	// rechazos seguidos la mandan a la lista negra
+	limiter := ratelimit.New(ratelimit.Config{
+		Threshold:   20,
+		RedisPrefix: "apikeys",
+	}, redisClient, logger)
+
+	uc := app.New(repository.New(database), limiter, logger)
+	handlers.New(uc, logger).RegisterRoutes(router)
+
+	middleware.SetAPIKeyValidator(validator.New(uc, logger))
+}
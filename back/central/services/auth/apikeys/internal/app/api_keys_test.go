@@ -0,0 +1,327 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/domain"
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/mocks"
+	"github.com/secamc93/probability/back/central/shared/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var baseTime = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+type reloj struct{ t time.Time }
+
+func (r *reloj) now() time.Time          { return r.t }
+func (r *reloj) avanzar(d time.Duration) { r.t = r.t.Add(d) }
+
+const (
+	negocio      = uint(7)
+	rolAdmin     = uint(2)
+	rolStaff     = uint(4)
+	permLeer     = uint(10)
+	permEditar   = uint(11)
+	permBorrar   = uint(12)
+	ipPermitida  = "10.0.0.5"
+	ipCualquiera = "203.0.113.9"
+)
+
+var admin = domain.Requester{UserID: 3, BusinessID: negocio, RoleID: rolAdmin}
+
+func newAPIKeysUseCase(limiter ratelimit.Limiter) (*UseCase, *mocks.RepositoryMock, *reloj) {
+	repo := mocks.NewRepositoryMock()
+	repo.Permissions[permLeer] = domain.Permission{ID: permLeer, Name: "Read Envios", Resource: "Envios", Action: "Read"}
+	repo.Permissions[permEditar] = domain.Permission{ID: permEditar, Name: "Update Envios", Resource: "Envios", Action: "Update"}
+	repo.Permissions[permBorrar] = domain.Permission{ID: permBorrar, Name: "Delete Envios", Resource: "Envios", Action: "Delete"}
+	repo.RoleLevels[rolAdmin] = 2
+	repo.RoleLevels[rolStaff] = 4
+	repo.RolePermissions[rolAdmin] = []uint{permLeer, permEditar}
+	clock := &reloj{t: baseTime}
+	return &UseCase{repo: repo, limiter: limiter, log: mocks.NewSilentLogger(), now: clock.now}, repo, clock
+}
+
+func crearClave(t *testing.T, uc *UseCase, dto domain.CreateAPIKeyDTO) *domain.IssuedAPIKey {
+	t.Helper()
+	if dto.Requester == (domain.Requester{}) {
+		dto.Requester = admin
+	}
+	if dto.Name == "" {
+		dto.Name = "ERP"
+	}
+	if dto.PermissionIDs == nil {
+		dto.PermissionIDs = []uint{permLeer}
+	}
+	issued, err := uc.CreateAPIKey(context.Background(), dto)
+	require.NoError(t, err)
+	return issued
+}
+
+func validar(uc *UseCase, key, ip string) (*domain.ValidatedAPIKey, error) {
+	return uc.ValidateAPIKey(context.Background(), domain.ValidateAPIKeyDTO{Key: key, ClientIP: ip})
+}
+
+func TestCreateAPIKey_AdminDelNegocio_EntregaLaClaveUnaVezYGuardaSoloElHash(t *testing.T) {
+	uc, repo, _ := newAPIKeysUseCase(nil)
+
+	issued := crearClave(t, uc, domain.CreateAPIKeyDTO{PermissionIDs: []uint{permLeer, permLeer}})
+
+	assert.True(t, strings.HasPrefix(issued.Key, issued.APIKey.KeyPrefix+"_"))
+	assert.Equal(t, negocio, issued.APIKey.BusinessID)
+	assert.Equal(t, defaultRateLimit, issued.APIKey.RateLimit)
+	assert.Len(t, issued.APIKey.Permissions, 1, "los permisos repetidos se asignan una vez")
+
+	guardada := repo.Keys[issued.APIKey.ID]
+	assert.NotContains(t, guardada.KeyHash, issued.Key)
+	assert.Equal(t, hashKey(issued.Key), guardada.KeyHash)
+
+	listadas, err := uc.ListAPIKeys(context.Background(), admin, 0)
+	require.NoError(t, err)
+	require.Len(t, listadas, 1)
+	assert.Equal(t, issued.APIKey.KeyPrefix, listadas[0].KeyPrefix)
+}
+
+func TestCreateAPIKey_RolSinNivelDeAdmin_Rechaza(t *testing.T) {
+	uc, _, _ := newAPIKeysUseCase(nil)
+
+	_, err := uc.CreateAPIKey(context.Background(), domain.CreateAPIKeyDTO{
+		Requester:     domain.Requester{UserID: 4, BusinessID: negocio, RoleID: rolStaff},
+		Name:          "ERP",
+		PermissionIDs: []uint{permLeer},
+	})
+
+	assert.ErrorIs(t, err, domain.ErrNotBusinessAdmin)
+}
+
+func TestCreateAPIKey_PermisosFueraDelRol_Rechaza(t *testing.T) {
+	uc, _, _ := newAPIKeysUseCase(nil)
+
+	_, err := uc.CreateAPIKey(context.Background(), domain.CreateAPIKeyDTO{
+		Requester: admin, Name: "ERP", PermissionIDs: []uint{permLeer, permBorrar},
+	})
+	assert.ErrorIs(t, err, domain.ErrPermissionNotOwned)
+
+	_, err = uc.CreateAPIKey(context.Background(), domain.CreateAPIKeyDTO{
+		Requester: admin, Name: "ERP", PermissionIDs: []uint{999},
+	})
+	assert.ErrorIs(t, err, domain.ErrPermissionNotFound)
+
+	_, err = uc.CreateAPIKey(context.Background(), domain.CreateAPIKeyDTO{Requester: admin, Name: "ERP"})
+	assert.ErrorIs(t, err, domain.ErrPermissionsRequired)
+}
+
+func TestCreateAPIKey_SuperAdmin_EligeNegocioYCualquierPermiso(t *testing.T) {
+	uc, _, _ := newAPIKeysUseCase(nil)
+	super := domain.Requester{UserID: 1}
+
+	_, err := uc.CreateAPIKey(context.Background(), domain.CreateAPIKeyDTO{
+		Requester: super, Name: "ERP", PermissionIDs: []uint{permBorrar},
+	})
+	assert.ErrorIs(t, err, domain.ErrBusinessRequired)
+
+	issued := crearClave(t, uc, domain.CreateAPIKeyDTO{Requester: super, BusinessID: 9, PermissionIDs: []uint{permBorrar}})
+	assert.Equal(t, uint(9), issued.APIKey.BusinessID)
+}
+
+func TestCreateAPIKey_ValidaAllowlistLimiteYExpiracion(t *testing.T) {
+	uc, _, clock := newAPIKeysUseCase(nil)
+	pasado := clock.now().Add(-time.Hour)
+
+	casos := []struct {
+		nombre string
+		dto    domain.CreateAPIKeyDTO
+		err    error
+	}{
+		{"ip invalida", domain.CreateAPIKeyDTO{IPAllowlist: []string{"10.0.0.300"}}, domain.ErrInvalidIPAllowlist},
+		{"cidr invalido", domain.CreateAPIKeyDTO{IPAllowlist: []string{"10.0.0.0/40"}}, domain.ErrInvalidIPAllowlist},
+		{"limite muy alto", domain.CreateAPIKeyDTO{RateLimit: maxRateLimit + 1}, domain.ErrInvalidRateLimit},
+		{"expira en el pasado", domain.CreateAPIKeyDTO{ExpiresAt: &pasado}, domain.ErrInvalidExpiresAt},
+		{"sin nombre", domain.CreateAPIKeyDTO{Name: "   "}, domain.ErrNameRequired},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			dto := tc.dto
+			dto.Requester = admin
+			if dto.Name == "" {
+				dto.Name = "ERP"
+			}
+			dto.PermissionIDs = []uint{permLeer}
+			_, err := uc.CreateAPIKey(context.Background(), dto)
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestValidateAPIKey_ClaveValida_DevuelvePermisosYMarcaUltimoUso(t *testing.T) {
+	uc, repo, clock := newAPIKeysUseCase(nil)
+	issued := crearClave(t, uc, domain.CreateAPIKeyDTO{PermissionIDs: []uint{permLeer, permEditar}})
+
+	got, err := validar(uc, issued.Key, ipCualquiera)
+	require.NoError(t, err)
+	assert.False(t, got.RateLimited)
+	assert.Equal(t, negocio, got.APIKey.BusinessID)
+	assert.ElementsMatch(t, []string{"Envios:Read", "Envios:Update"},
+		[]string{got.APIKey.Permissions[0].Code(), got.APIKey.Permissions[1].Code()})
+	require.NotNil(t, repo.Keys[issued.APIKey.ID].LastUsedAt)
+	assert.Equal(t, ipCualquiera, repo.Keys[issued.APIKey.ID].LastUsedIP)
+
+	_, err = validar(uc, issued.Key, ipCualquiera)
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.Touches, "dentro del mismo minuto no se vuelve a escribir el ultimo uso")
+
+	clock.avanzar(lastUsedResolution)
+	_, err = validar(uc, issued.Key, ipCualquiera)
+	require.NoError(t, err)
+	assert.Equal(t, 2, repo.Touches)
+}
+
+func TestValidateAPIKey_ClaveAlterada_Rechaza(t *testing.T) {
+	uc, _, _ := newAPIKeysUseCase(nil)
+	issued := crearClave(t, uc, domain.CreateAPIKeyDTO{})
+
+	alterada := issued.Key[:len(issued.Key)-1] + "0"
+	if alterada == issued.Key {
+		alterada = issued.Key[:len(issued.Key)-1] + "1"
+	}
+
+	for _, key := range []string{"", "prob_abc", "otra_" + issued.Key, alterada} {
+		_, err := validar(uc, key, ipCualquiera)
+		assert.ErrorIs(t, err, domain.ErrInvalidAPIKey, key)
+	}
+}
+
+func TestValidateAPIKey_Expirada_Rechaza(t *testing.T) {
+	uc, _, clock := newAPIKeysUseCase(nil)
+	expira := clock.now().Add(time.Hour)
+	issued := crearClave(t, uc, domain.CreateAPIKeyDTO{ExpiresAt: &expira})
+
+	_, err := validar(uc, issued.Key, ipCualquiera)
+	require.NoError(t, err)
+
+	clock.avanzar(time.Hour)
+	_, err = validar(uc, issued.Key, ipCualquiera)
+	assert.ErrorIs(t, err, domain.ErrAPIKeyExpired)
+}
+
+func TestValidateAPIKey_AllowlistDeIPsYCIDRs(t *testing.T) {
+	uc, _, _ := newAPIKeysUseCase(nil)
+	issued := crearClave(t, uc, domain.CreateAPIKeyDTO{IPAllowlist: []string{" 192.168.1.0/24 ", ipPermitida}})
+
+	for _, ip := range []string{ipPermitida, "192.168.1.77"} {
+		_, err := validar(uc, issued.Key, ip)
+		assert.NoError(t, err, ip)
+	}
+	for _, ip := range []string{ipCualquiera, "", "no-es-ip"} {
+		_, err := validar(uc, issued.Key, ip)
+		assert.ErrorIs(t, err, domain.ErrIPNotAllowed, ip)
+	}
+}
+
+func TestRotateAPIKey_LaClaveAnteriorValeDuranteLaGracia(t *testing.T) {
+	uc, _, clock := newAPIKeysUseCase(nil)
+	original := crearClave(t, uc, domain.CreateAPIKeyDTO{})
+
+	gracia := 2
+	rotada, err := uc.RotateAPIKey(context.Background(), domain.RotateAPIKeyDTO{
+		Requester: admin, ID: original.APIKey.ID, GraceHours: &gracia,
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, original.Key, rotada.Key)
+	assert.Equal(t, original.APIKey.KeyPrefix, rotada.APIKey.PreviousKeyPrefix)
+
+	for _, key := range []string{original.Key, rotada.Key} {
+		_, err := validar(uc, key, ipCualquiera)
+		assert.NoError(t, err)
+	}
+
+	clock.avanzar(2 * time.Hour)
+	_, err = validar(uc, original.Key, ipCualquiera)
+	assert.ErrorIs(t, err, domain.ErrAPIKeyExpired)
+	_, err = validar(uc, rotada.Key, ipCualquiera)
+	assert.NoError(t, err)
+}
+
+func TestRotateAPIKey_SinGracia_InvalidaLaAnteriorYValidaLimites(t *testing.T) {
+	uc, _, _ := newAPIKeysUseCase(nil)
+	original := crearClave(t, uc, domain.CreateAPIKeyDTO{})
+
+	demasiado := maxGraceHours + 1
+	_, err := uc.RotateAPIKey(context.Background(), domain.RotateAPIKeyDTO{
+		Requester: admin, ID: original.APIKey.ID, GraceHours: &demasiado,
+	})
+	assert.ErrorIs(t, err, domain.ErrInvalidGraceHours)
+
+	cero := 0
+	rotada, err := uc.RotateAPIKey(context.Background(), domain.RotateAPIKeyDTO{
+		Requester: admin, ID: original.APIKey.ID, GraceHours: &cero,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, rotada.APIKey.PreviousKeyPrefix)
+
+	_, err = validar(uc, original.Key, ipCualquiera)
+	assert.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+}
+
+func TestRevokeAPIKey_InvalidaClaveActualYAnterior(t *testing.T) {
+	uc, _, _ := newAPIKeysUseCase(nil)
+	original := crearClave(t, uc, domain.CreateAPIKeyDTO{})
+	rotada, err := uc.RotateAPIKey(context.Background(), domain.RotateAPIKeyDTO{Requester: admin, ID: original.APIKey.ID})
+	require.NoError(t, err)
+
+	revocada, err := uc.RevokeAPIKey(context.Background(), admin, original.APIKey.ID, 0)
+	require.NoError(t, err)
+	assert.True(t, revocada.Revoked)
+	assert.NotNil(t, revocada.RevokedAt)
+
+	_, err = validar(uc, rotada.Key, ipCualquiera)
+	assert.ErrorIs(t, err, domain.ErrAPIKeyRevoked)
+	_, err = validar(uc, original.Key, ipCualquiera)
+	assert.ErrorIs(t, err, domain.ErrInvalidAPIKey)
+
+	_, err = uc.RevokeAPIKey(context.Background(), admin, original.APIKey.ID, 0)
+	assert.ErrorIs(t, err, domain.ErrAPIKeyAlreadyRevoked)
+	_, err = uc.RotateAPIKey(context.Background(), domain.RotateAPIKeyDTO{Requester: admin, ID: original.APIKey.ID})
+	assert.ErrorIs(t, err, domain.ErrAPIKeyAlreadyRevoked)
+}
+
+func TestAPIKeys_OtroNegocioNoVeNiOperaLaClave(t *testing.T) {
+	uc, repo, _ := newAPIKeysUseCase(nil)
+	issued := crearClave(t, uc, domain.CreateAPIKeyDTO{})
+	otro := domain.Requester{UserID: 8, BusinessID: 99, RoleID: rolAdmin}
+	repo.RolePermissions[rolAdmin] = append(repo.RolePermissions[rolAdmin], permBorrar)
+
+	_, err := uc.GetAPIKey(context.Background(), otro, issued.APIKey.ID, 0)
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound)
+	_, err = uc.RevokeAPIKey(context.Background(), otro, issued.APIKey.ID, negocio)
+	assert.ErrorIs(t, err, domain.ErrAPIKeyNotFound, "fuera de super admin se ignora el business_id pedido")
+
+	listadas, err := uc.ListAPIKeys(context.Background(), otro, negocio)
+	require.NoError(t, err)
+	assert.Empty(t, listadas)
+}
+
+func TestValidateAPIKey_SuperaSuLimitePorMinuto(t *testing.T) {
+	limiter := ratelimit.New(ratelimit.Config{Threshold: 20}, nil, mocks.NewSilentLogger())
+	uc, _, _ := newAPIKeysUseCase(limiter)
+	issued := crearClave(t, uc, domain.CreateAPIKeyDTO{RateLimit: 2})
+	otra := crearClave(t, uc, domain.CreateAPIKeyDTO{RateLimit: 2})
+
+	for i := 0; i < 2; i++ {
+		got, err := validar(uc, issued.Key, ipCualquiera)
+		require.NoError(t, err)
+		assert.False(t, got.RateLimited)
+	}
+
+	got, err := validar(uc, issued.Key, ipCualquiera)
+	require.NoError(t, err)
+	assert.True(t, got.RateLimited)
+	assert.Greater(t, got.RetryAfter, time.Duration(0))
+
+	got, err = validar(uc, otra.Key, ipCualquiera)
+	require.NoError(t, err)
+	assert.False(t, got.RateLimited, "cada clave tiene su propio limite")
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/bizscope"
+)
+
+// keysScope: solo los administradores gestionan claves y el super admin
+// debe indicar el negocio
+var keysScope = bizscope.Policy{
+	ErrForbidden:        domain.ErrNotBusinessAdmin,
+	ErrBusinessRequired: domain.ErrBusinessRequired,
+}
+
+// resolveBusiness devuelve el negocio sobre el que opera el solicitante
+func (uc *UseCase) resolveBusiness(ctx context.Context, requester domain.Requester, businessID uint) (uint, error) {
+	actor := bizscope.Actor{
+		BusinessID:   requester.BusinessID,
+		RoleID:       requester.RoleID,
+		IsSuperAdmin: requester.IsSuperAdmin(),
+	}
+	return keysScope.Resolve(ctx, uc.repo.GetRoleLevel, actor, businessID)
+}
+
+// getOwnedAPIKey trae la clave solo si pertenece al negocio
+func (uc *UseCase) getOwnedAPIKey(ctx context.Context, id, businessID uint) (*domain.APIKey, error) {
+	key, err := uc.repo.GetAPIKey(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if key.BusinessID != businessID {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	return key, nil
+}
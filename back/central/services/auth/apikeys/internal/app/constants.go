@@ -0,0 +1,20 @@
+package app
+
+import "time"
+
+const (
+	// Formato de la clave: prob_<prefijo>_<secreto>. El prefijo es publico y
+	// sirve para buscarla; el secreto solo existe en la respuesta de crear o
+	// rotar.
+	keyScheme        = "prob"
+	prefixBytes      = 6
+	secretBytes      = 32
+	defaultRateLimit = 60
+	maxRateLimit     = 6000
+
+	defaultGraceHours = 24
+	maxGraceHours     = 168
+
+	// lastUsedResolution evita escribir last_used_at en cada request
+	lastUsedResolution = time.Minute
+)
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/ratelimit"
+)
+
+// En los metodos que reciben businessID, 0 significa super admin: puede ver y
+// operar las claves de cualquier negocio.
+type IUseCase interface {
+	CreateAPIKey(ctx context.Context, dto domain.CreateAPIKeyDTO) (*domain.IssuedAPIKey, error)
+	ListAPIKeys(ctx context.Context, requester domain.Requester, businessID uint) ([]domain.APIKey, error)
+	GetAPIKey(ctx context.Context, requester domain.Requester, id, businessID uint) (*domain.APIKey, error)
+	RotateAPIKey(ctx context.Context, dto domain.RotateAPIKeyDTO) (*domain.IssuedAPIKey, error)
+	RevokeAPIKey(ctx context.Context, requester domain.Requester, id, businessID uint) (*domain.APIKey, error)
+
+	ValidateAPIKey(ctx context.Context, dto domain.ValidateAPIKeyDTO) (*domain.ValidatedAPIKey, error)
+}
+
+type UseCase struct {
+	repo    domain.IRepository
+	limiter ratelimit.Limiter
+	log     log.ILogger
+	now     func() time.Time
+}
+
+// New arma el caso de uso; limiter puede ser nil y entonces las claves no
+// tienen limite de requests.
+func New(repo domain.IRepository, limiter ratelimit.Limiter, logger log.ILogger) IUseCase {
+	return &UseCase{repo: repo, limiter: limiter, log: logger, now: time.Now}
+}
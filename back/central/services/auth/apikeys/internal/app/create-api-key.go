@@ -0,0 +1,125 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/domain"
+)
+
+// CreateAPIKey emite una clave nueva para el negocio con un subconjunto de
+// los permisos del rol de quien la crea.
+func (uc *UseCase) CreateAPIKey(ctx context.Context, dto domain.CreateAPIKeyDTO) (*domain.IssuedAPIKey, error) {
+	businessID, err := uc.resolveBusiness(ctx, dto.Requester, dto.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(dto.Name)
+	if name == "" {
+		return nil, domain.ErrNameRequired
+	}
+
+	rateLimit := dto.RateLimit
+	if rateLimit == 0 {
+		rateLimit = defaultRateLimit
+	}
+	if rateLimit < 0 || rateLimit > maxRateLimit {
+		return nil, domain.ErrInvalidRateLimit
+	}
+
+	allowlist, ok := normalizeAllowlist(dto.IPAllowlist)
+	if !ok {
+		return nil, domain.ErrInvalidIPAllowlist
+	}
+
+	if dto.ExpiresAt != nil && !dto.ExpiresAt.After(uc.now()) {
+		return nil, domain.ErrInvalidExpiresAt
+	}
+
+	permissionIDs := uniqueIDs(dto.PermissionIDs)
+	if err := uc.checkPermissions(ctx, dto.Requester, permissionIDs); err != nil {
+		return nil, err
+	}
+
+	key, prefix, err := generateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := uc.repo.CreateAPIKey(ctx, &domain.APIKey{
+		BusinessID:  businessID,
+		CreatedByID: dto.Requester.UserID,
+		Name:        name,
+		Description: strings.TrimSpace(dto.Description),
+		KeyPrefix:   prefix,
+		KeyHash:     hashKey(key),
+		ExpiresAt:   dto.ExpiresAt,
+		RateLimit:   rateLimit,
+		IPAllowlist: allowlist,
+	}, permissionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("crear api key: %w", err)
+	}
+
+	uc.log.Info(ctx).
+		Uint("api_key_id", created.ID).
+		Uint("business_id", businessID).
+		Uint("created_by", dto.Requester.UserID).
+		Str("key_prefix", prefix).
+		Msg("API Key creada")
+
+	return &domain.IssuedAPIKey{Key: key, APIKey: created}, nil
+}
+
+// checkPermissions exige que los permisos existan y, salvo para super admin,
+// que el rol del solicitante los tenga: nadie entrega mas de lo que puede.
+func (uc *UseCase) checkPermissions(ctx context.Context, requester domain.Requester, ids []uint) error {
+	if len(ids) == 0 {
+		return domain.ErrPermissionsRequired
+	}
+
+	found, err := uc.repo.GetPermissionsByIDs(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("consultar permisos: %w", err)
+	}
+	if len(found) != len(ids) {
+		return domain.ErrPermissionNotFound
+	}
+
+	if requester.IsSuperAdmin() {
+		return nil
+	}
+
+	owned, err := uc.repo.GetRolePermissionIDs(ctx, requester.RoleID)
+	if err != nil {
+		return fmt.Errorf("consultar permisos del rol %d: %w", requester.RoleID, err)
+	}
+	ownedSet := make(map[uint]struct{}, len(owned))
+	for _, id := range owned {
+		ownedSet[id] = struct{}{}
+	}
+	for _, id := range ids {
+		if _, ok := ownedSet[id]; !ok {
+			return domain.ErrPermissionNotOwned
+		}
+	}
+	return nil
+}
+
+func uniqueIDs(ids []uint) []uint {
+	seen := make(map[uint]struct{}, len(ids))
+	out := make([]uint, 0, len(ids))
+	for _, id := range ids {
+		if id == 0 {
+			continue
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	return out
+}
@@ -0,0 +1,24 @@
+package app
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/domain"
+)
+
+// ListAPIKeys lista las claves del negocio, incluidas las revocadas
+func (uc *UseCase) ListAPIKeys(ctx context.Context, requester domain.Requester, businessID uint) ([]domain.APIKey, error) {
+	businessID, err := uc.resolveBusiness(ctx, requester, businessID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.repo.ListAPIKeys(ctx, businessID)
+}
+
+func (uc *UseCase) GetAPIKey(ctx context.Context, requester domain.Requester, id, businessID uint) (*domain.APIKey, error) {
+	businessID, err := uc.resolveBusiness(ctx, requester, businessID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.getOwnedAPIKey(ctx, id, businessID)
+}
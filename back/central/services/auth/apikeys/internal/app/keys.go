@@ -0,0 +1,88 @@
+package app
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// generateKey devuelve la clave completa y su prefijo publico
+func generateKey() (key, prefix string, err error) {
+	p := make([]byte, prefixBytes)
+	if _, err := rand.Read(p); err != nil {
+		return "", "", fmt.Errorf("generar prefijo de api key: %w", err)
+	}
+	s := make([]byte, secretBytes)
+	if _, err := rand.Read(s); err != nil {
+		return "", "", fmt.Errorf("generar secreto de api key: %w", err)
+	}
+	prefix = keyScheme + "_" + hex.EncodeToString(p)
+	return prefix + "_" + hex.EncodeToString(s), prefix, nil
+}
+
+// parseKeyPrefix extrae el prefijo de una clave con el formato esperado
+func parseKeyPrefix(key string) (string, bool) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != keyScheme ||
+		len(parts[1]) != prefixBytes*2 || len(parts[2]) != secretBytes*2 {
+		return "", false
+	}
+	return parts[0] + "_" + parts[1], true
+}
+
+// hashKey es SHA-256: la clave tiene 256 bits aleatorios, asi que no hace
+// falta un hash lento y se puede validar en cada request.
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func hashMatches(key, hash string) bool {
+	return hash != "" && subtle.ConstantTimeCompare([]byte(hashKey(key)), []byte(hash)) == 1
+}
+
+// normalizeAllowlist valida y limpia la lista de IPs/CIDRs permitidos
+func normalizeAllowlist(entries []string) ([]string, bool) {
+	out := make([]string, 0, len(entries))
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		if strings.Contains(e, "/") {
+			if _, _, err := net.ParseCIDR(e); err != nil {
+				return nil, false
+			}
+		} else if net.ParseIP(e) == nil {
+			return nil, false
+		}
+		out = append(out, e)
+	}
+	return out, true
+}
+
+func ipAllowed(allowlist []string, clientIP string) bool {
+	if len(allowlist) == 0 {
+		return true
+	}
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return false
+	}
+	for _, e := range allowlist {
+		if strings.Contains(e, "/") {
+			if _, network, err := net.ParseCIDR(e); err == nil && network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if allowed := net.ParseIP(e); allowed != nil && allowed.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/domain"
+)
+
+// RevokeAPIKey invalida la clave de inmediato, incluida la anterior que
+// estuviera en periodo de gracia. La clave se conserva para el historial.
+func (uc *UseCase) RevokeAPIKey(ctx context.Context, requester domain.Requester, id, businessID uint) (*domain.APIKey, error) {
+	businessID, err := uc.resolveBusiness(ctx, requester, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := uc.getOwnedAPIKey(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Revoked {
+		return nil, domain.ErrAPIKeyAlreadyRevoked
+	}
+
+	revoked, err := uc.repo.UpdateAPIKey(ctx, id, map[string]any{
+		"revoked":                 true,
+		"revoked_at":              uc.now(),
+		"previous_key_prefix":     nil,
+		"previous_key_hash":       "",
+		"previous_key_expires_at": nil,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("revocar api key %d: %w", id, err)
+	}
+
+	uc.log.Info(ctx).
+		Uint("api_key_id", id).
+		Uint("business_id", businessID).
+		Uint("revoked_by", requester.UserID).
+		Msg("API Key revocada")
+
+	return revoked, nil
+}
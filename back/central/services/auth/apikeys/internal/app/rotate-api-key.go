@@ -0,0 +1,71 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/domain"
+)
+
+// RotateAPIKey emite un secreto nuevo para la clave. La clave anterior sigue
+// valiendo durante el periodo de gracia para que la integracion alcance a
+// cambiarla; si ya habia una clave anterior en gracia, queda invalidada.
+func (uc *UseCase) RotateAPIKey(ctx context.Context, dto domain.RotateAPIKeyDTO) (*domain.IssuedAPIKey, error) {
+	businessID, err := uc.resolveBusiness(ctx, dto.Requester, dto.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+
+	graceHours := defaultGraceHours
+	if dto.GraceHours != nil {
+		graceHours = *dto.GraceHours
+	}
+	if graceHours < 0 || graceHours > maxGraceHours {
+		return nil, domain.ErrInvalidGraceHours
+	}
+
+	current, err := uc.getOwnedAPIKey(ctx, dto.ID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Revoked {
+		return nil, domain.ErrAPIKeyAlreadyRevoked
+	}
+
+	key, prefix, err := generateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	updates := map[string]any{
+		"key_prefix": prefix,
+		"key_hash":   hashKey(key),
+		"rotated_at": now,
+	}
+	if graceHours > 0 {
+		graceUntil := now.Add(time.Duration(graceHours) * time.Hour)
+		updates["previous_key_prefix"] = current.KeyPrefix
+		updates["previous_key_hash"] = current.KeyHash
+		updates["previous_key_expires_at"] = graceUntil
+	} else {
+		updates["previous_key_prefix"] = nil
+		updates["previous_key_hash"] = ""
+		updates["previous_key_expires_at"] = nil
+	}
+
+	rotated, err := uc.repo.UpdateAPIKey(ctx, current.ID, updates)
+	if err != nil {
+		return nil, fmt.Errorf("rotar api key %d: %w", current.ID, err)
+	}
+
+	uc.log.Info(ctx).
+		Uint("api_key_id", current.ID).
+		Uint("business_id", businessID).
+		Int("grace_hours", graceHours).
+		Str("key_prefix", prefix).
+		Msg("API Key rotada")
+
+	return &domain.IssuedAPIKey{Key: key, APIKey: rotated}, nil
+}
@@ -0,0 +1,69 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/ratelimit"
+)
+
+// ValidateAPIKey autentica una clave en un request: formato, hash (actual o
+// anterior en gracia), revocacion, expiracion, IP permitida y limite por
+// minuto. Los errores son de autenticacion y se responden como 401.
+func (uc *UseCase) ValidateAPIKey(ctx context.Context, dto domain.ValidateAPIKeyDTO) (*domain.ValidatedAPIKey, error) {
+	prefix, ok := parseKeyPrefix(dto.Key)
+	if !ok {
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	key, err := uc.repo.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, domain.ErrAPIKeyNotFound) {
+			return nil, domain.ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("buscar api key: %w", err)
+	}
+
+	now := uc.now()
+	switch {
+	case key.KeyPrefix == prefix && hashMatches(dto.Key, key.KeyHash):
+	case key.PreviousKeyPrefix == prefix && hashMatches(dto.Key, key.PreviousKeyHash):
+		if key.PreviousKeyExpiresAt == nil || !now.Before(*key.PreviousKeyExpiresAt) {
+			return nil, domain.ErrAPIKeyExpired
+		}
+	default:
+		return nil, domain.ErrInvalidAPIKey
+	}
+
+	if key.Revoked {
+		return nil, domain.ErrAPIKeyRevoked
+	}
+	if key.ExpiresAt != nil && !now.Before(*key.ExpiresAt) {
+		return nil, domain.ErrAPIKeyExpired
+	}
+	if !ipAllowed(key.IPAllowlist, dto.ClientIP) {
+		uc.log.Warn(ctx).
+			Uint("api_key_id", key.ID).
+			Str("client_ip", dto.ClientIP).
+			Msg("API Key usada desde una IP no permitida")
+		return nil, domain.ErrIPNotAllowed
+	}
+
+	if uc.limiter != nil {
+		d := uc.limiter.CheckRate(ctx, "apikey:"+strconv.FormatUint(uint64(key.ID), 10), ratelimit.PerMinute(key.RateLimit))
+		if !d.Allowed {
+			return &domain.ValidatedAPIKey{APIKey: key, RateLimited: true, RetryAfter: d.RetryAfter}, nil
+		}
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		if err := uc.repo.TouchLastUsed(ctx, key.ID, now, dto.ClientIP); err != nil {
+			uc.log.Error(ctx).Err(err).Uint("api_key_id", key.ID).Msg("Error al actualizar ultimo uso de API Key")
+		}
+	}
+
+	return &domain.ValidatedAPIKey{APIKey: key}, nil
+}
@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+// Requester es quien administra las claves. BusinessID 0 es super admin y
+// puede operar las claves de cualquier negocio.
+type Requester struct {
+	UserID     uint
+	BusinessID uint
+	RoleID     uint
+}
+
+func (r Requester) IsSuperAdmin() bool {
+	return r.BusinessID == 0
+}
+
+type CreateAPIKeyDTO struct {
+	Requester     Requester
+	BusinessID    uint
+	Name          string
+	Description   string
+	PermissionIDs []uint
+	IPAllowlist   []string
+	ExpiresAt     *time.Time
+	RateLimit     int
+}
+
+type RotateAPIKeyDTO struct {
+	Requester  Requester
+	ID         uint
+	BusinessID uint
+	// GraceHours es cuanto sigue valiendo la clave anterior; nil usa el
+	// valor por defecto y 0 la invalida de inmediato
+	GraceHours *int
+}
+
+// IssuedAPIKey es la respuesta de crear o rotar: Key es la clave completa y
+// solo se entrega esta vez
+type IssuedAPIKey struct {
+	Key    string
+	APIKey *APIKey
+}
+
+type ValidateAPIKeyDTO struct {
+	Key      string
+	ClientIP string
+}
+
+// ValidatedAPIKey es una clave valida. RateLimited indica que supero su
+// limite y debe esperar RetryAfter.
+type ValidatedAPIKey struct {
+	APIKey      *APIKey
+	RateLimited bool
+	RetryAfter  time.Duration
+}
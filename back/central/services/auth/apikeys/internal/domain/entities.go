@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// APIKey es una clave de API de un negocio. Nunca guarda el secreto: solo el
+// prefijo publico con el que se busca y el hash de la clave completa.
+type APIKey struct {
+	ID          uint
+	BusinessID  uint
+	CreatedByID uint
+	Name        string
+	Description string
+	KeyPrefix   string
+	KeyHash     string
+
+	// Clave anterior, valida hasta PreviousKeyExpiresAt despues de rotar
+	PreviousKeyPrefix    string
+	PreviousKeyHash      string
+	PreviousKeyExpiresAt *time.Time
+	RotatedAt            *time.Time
+
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	LastUsedIP string
+	Revoked    bool
+	RevokedAt  *time.Time
+
+	RateLimit   int      // requests por minuto
+	IPAllowlist []string // IPs o CIDRs; vacia = cualquier IP
+	Permissions []Permission
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Permission es un permiso del modelo resources/actions asignado a la clave
+type Permission struct {
+	ID       uint
+	Name     string
+	Resource string
+	Action   string
+}
+
+// Code es el permiso como lo compara el middleware: "Recurso:Accion"
+func (p Permission) Code() string {
+	return p.Resource + ":" + p.Action
+}
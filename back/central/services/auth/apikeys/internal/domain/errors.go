@@ -0,0 +1,24 @@
+package domain
+
+import "errors"
+
+var (
+	ErrAPIKeyNotFound       = errors.New("api key no encontrada")
+	ErrBusinessRequired     = errors.New("business_id es obligatorio")
+	ErrNameRequired         = errors.New("el nombre de la api key es obligatorio")
+	ErrPermissionsRequired  = errors.New("la api key debe tener al menos un permiso")
+	ErrPermissionNotFound   = errors.New("uno o mas permisos no existen")
+	ErrPermissionNotOwned   = errors.New("no puedes asignar permisos que tu rol no tiene")
+	ErrNotBusinessAdmin     = errors.New("solo los administradores del negocio pueden gestionar api keys")
+	ErrInvalidIPAllowlist   = errors.New("ip_allowlist solo acepta IPs o CIDRs validos")
+	ErrInvalidRateLimit     = errors.New("rate_limit debe estar entre 1 y 6000 requests por minuto")
+	ErrInvalidExpiresAt     = errors.New("expires_at debe ser una fecha futura")
+	ErrInvalidGraceHours    = errors.New("grace_hours debe estar entre 0 y 168")
+	ErrAPIKeyAlreadyRevoked = errors.New("la api key ya fue revocada")
+
+	// Errores de validacion de la clave en cada request
+	ErrInvalidAPIKey = errors.New("API Key inválida")
+	ErrAPIKeyRevoked = errors.New("API Key revocada")
+	ErrAPIKeyExpired = errors.New("API Key expirada")
+	ErrIPNotAllowed  = errors.New("IP no autorizada para esta API Key")
+)
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+type IRepository interface {
+	CreateAPIKey(ctx context.Context, key *APIKey, permissionIDs []uint) (*APIKey, error)
+	GetAPIKey(ctx context.Context, id uint) (*APIKey, error)
+	// GetAPIKeyByPrefix busca por el prefijo actual o por el de la clave
+	// anterior que sigue en periodo de gracia
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (*APIKey, error)
+	ListAPIKeys(ctx context.Context, businessID uint) ([]APIKey, error)
+	UpdateAPIKey(ctx context.Context, id uint, updates map[string]any) (*APIKey, error)
+	TouchLastUsed(ctx context.Context, id uint, at time.Time, ip string) error
+
+	GetPermissionsByIDs(ctx context.Context, ids []uint) ([]Permission, error)
+	GetRolePermissionIDs(ctx context.Context, roleID uint) ([]uint, error)
+	// GetRoleLevel devuelve el nivel jerarquico del rol (1=super, 2=admin,
+	// 3=manager, 4=staff)
+	GetRoleLevel(ctx context.Context, roleID uint) (int, error)
+}
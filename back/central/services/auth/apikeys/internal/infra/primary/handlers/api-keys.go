@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/domain"
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/infra/primary/handlers/response"
+)
+
+func (h *Handlers) ListAPIKeys(c *gin.Context) {
+	list, err := h.uc.ListAPIKeys(c.Request.Context(), h.requester(c), h.businessScope(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": response.FromAPIKeys(list)})
+}
+
+// CreateAPIKey responde la clave completa una unica vez
+func (h *Handlers) CreateAPIKey(c *gin.Context) {
+	var req request.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	businessID := h.businessScope(c)
+	if req.BusinessID != 0 {
+		businessID = req.BusinessID
+	}
+
+	issued, err := h.uc.CreateAPIKey(c.Request.Context(), domain.CreateAPIKeyDTO{
+		Requester:     h.requester(c),
+		BusinessID:    businessID,
+		Name:          req.Name,
+		Description:   req.Description,
+		PermissionIDs: req.PermissionIDs,
+		IPAllowlist:   req.IPAllowlist,
+		ExpiresAt:     req.ExpiresAt,
+		RateLimit:     req.RateLimit,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response.FromIssuedAPIKey(issued))
+}
+
+func (h *Handlers) GetAPIKey(c *gin.Context) {
+	id, ok := h.parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	key, err := h.uc.GetAPIKey(c.Request.Context(), h.requester(c), id, h.businessScope(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response.FromAPIKey(key))
+}
+
+// RotateAPIKey responde la clave nueva una unica vez; la anterior sigue
+// valiendo durante grace_hours
+func (h *Handlers) RotateAPIKey(c *gin.Context) {
+	id, ok := h.parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.RotateAPIKeyRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	issued, err := h.uc.RotateAPIKey(c.Request.Context(), domain.RotateAPIKeyDTO{
+		Requester:  h.requester(c),
+		ID:         id,
+		BusinessID: h.businessScope(c),
+		GraceHours: req.GraceHours,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response.FromIssuedAPIKey(issued))
+}
+
+func (h *Handlers) RevokeAPIKey(c *gin.Context) {
+	id, ok := h.parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	key, err := h.uc.RevokeAPIKey(c.Request.Context(), h.requester(c), id, h.businessScope(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response.FromAPIKey(key))
+}
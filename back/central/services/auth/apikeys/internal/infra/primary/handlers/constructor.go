@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/app"
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/domain"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+type IHandlers interface {
+	RegisterRoutes(router *gin.RouterGroup)
+}
+
+type Handlers struct {
+	uc  app.IUseCase
+	log log.ILogger
+}
+
+func New(uc app.IUseCase, logger log.ILogger) IHandlers {
+	return &Handlers{uc: uc, log: logger}
+}
+
+func (h *Handlers) parseUintParam(c *gin.Context, key string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(key), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *Handlers) requester(c *gin.Context) domain.Requester {
+	userID, _ := middleware.GetUserID(c)
+	businessID, _ := middleware.GetBusinessID(c)
+	roleID, _ := middleware.GetRoleID(c)
+	return domain.Requester{UserID: userID, BusinessID: businessID, RoleID: roleID}
+}
+
+// businessScope es el ?business_id con el que el super admin elige negocio;
+// para los demas el caso de uso usa el negocio del token.
+func (h *Handlers) businessScope(c *gin.Context) uint {
+	if v, err := strconv.ParseUint(c.Query("business_id"), 10, 64); err == nil {
+		return uint(v)
+	}
+	return 0
+}
+
+func (h *Handlers) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAPIKeyNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrNotBusinessAdmin), errors.Is(err, domain.ErrPermissionNotOwned):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrAPIKeyAlreadyRevoked):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrBusinessRequired), errors.Is(err, domain.ErrNameRequired),
+		errors.Is(err, domain.ErrPermissionsRequired), errors.Is(err, domain.ErrPermissionNotFound),
+		errors.Is(err, domain.ErrInvalidIPAllowlist), errors.Is(err, domain.ErrInvalidRateLimit),
+		errors.Is(err, domain.ErrInvalidExpiresAt), errors.Is(err, domain.ErrInvalidGraceHours):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.log.Error(c.Request.Context()).Err(err).Msg("Error en api keys")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "error interno del servidor"})
+	}
+}
@@ -0,0 +1,24 @@
+package request
+
+import "time"
+
+type CreateAPIKeyRequest struct {
+	Name        string `json:"name" binding:"required"`
+	Description string `json:"description"`
+	// Permisos (ids de la tabla permission) que tendra la clave; deben estar
+	// en el rol de quien la crea
+	PermissionIDs []uint `json:"permission_ids" binding:"required,min=1"`
+	// IPs o CIDRs desde los que se puede usar; vacio = cualquiera
+	IPAllowlist []string   `json:"ip_allowlist"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	// Requests por minuto (60 por defecto)
+	RateLimit int `json:"rate_limit"`
+	// Solo super admin: negocio dueno de la clave
+	BusinessID uint `json:"business_id"`
+}
+
+type RotateAPIKeyRequest struct {
+	// Horas que la clave anterior sigue valiendo (24 por defecto, 0 la
+	// invalida de inmediato)
+	GraceHours *int `json:"grace_hours"`
+}
@@ -0,0 +1,85 @@
+package response
+
+import (
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/domain"
+)
+
+type PermissionResponse struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Resource string `json:"resource"`
+	Action   string `json:"action"`
+}
+
+type APIKeyResponse struct {
+	ID          uint   `json:"id"`
+	BusinessID  uint   `json:"business_id"`
+	CreatedByID uint   `json:"created_by_id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Key solo viaja completa al crear y al rotar
+	Key                  string               `json:"key,omitempty"`
+	KeyPrefix            string               `json:"key_prefix"`
+	PreviousKeyPrefix    string               `json:"previous_key_prefix,omitempty"`
+	PreviousKeyExpiresAt *time.Time           `json:"previous_key_expires_at"`
+	RotatedAt            *time.Time           `json:"rotated_at"`
+	ExpiresAt            *time.Time           `json:"expires_at"`
+	LastUsedAt           *time.Time           `json:"last_used_at"`
+	LastUsedIP           string               `json:"last_used_ip"`
+	Revoked              bool                 `json:"revoked"`
+	RevokedAt            *time.Time           `json:"revoked_at"`
+	RateLimit            int                  `json:"rate_limit"`
+	IPAllowlist          []string             `json:"ip_allowlist"`
+	Permissions          []PermissionResponse `json:"permissions"`
+	CreatedAt            time.Time            `json:"created_at"`
+	UpdatedAt            time.Time            `json:"updated_at"`
+}
+
+func FromAPIKey(k *domain.APIKey) APIKeyResponse {
+	out := APIKeyResponse{
+		ID:          k.ID,
+		BusinessID:  k.BusinessID,
+		CreatedByID: k.CreatedByID,
+		Name:        k.Name,
+		Description: k.Description,
+		KeyPrefix:   k.KeyPrefix,
+		RotatedAt:   k.RotatedAt,
+		ExpiresAt:   k.ExpiresAt,
+		LastUsedAt:  k.LastUsedAt,
+		LastUsedIP:  k.LastUsedIP,
+		Revoked:     k.Revoked,
+		RevokedAt:   k.RevokedAt,
+		RateLimit:   k.RateLimit,
+		IPAllowlist: k.IPAllowlist,
+		Permissions: make([]PermissionResponse, 0, len(k.Permissions)),
+		CreatedAt:   k.CreatedAt,
+		UpdatedAt:   k.UpdatedAt,
+	}
+	if out.IPAllowlist == nil {
+		out.IPAllowlist = []string{}
+	}
+	if k.PreviousKeyPrefix != "" {
+		out.PreviousKeyPrefix = k.PreviousKeyPrefix
+		out.PreviousKeyExpiresAt = k.PreviousKeyExpiresAt
+	}
+	for _, p := range k.Permissions {
+		out.Permissions = append(out.Permissions, PermissionResponse{ID: p.ID, Name: p.Name, Resource: p.Resource, Action: p.Action})
+	}
+	return out
+}
+
+func FromAPIKeys(list []domain.APIKey) []APIKeyResponse {
+	out := make([]APIKeyResponse, 0, len(list))
+	for i := range list {
+		out = append(out, FromAPIKey(&list[i]))
+	}
+	return out
+}
+
+func FromIssuedAPIKey(issued *domain.IssuedAPIKey) APIKeyResponse {
+	out := FromAPIKey(issued.APIKey)
+	out.Key = issued.Key
+	return out
+}
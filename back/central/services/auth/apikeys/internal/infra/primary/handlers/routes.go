@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+)
+
+// RegisterRoutes registra /api-keys. Solo con sesion JWT: una API Key no
+// puede emitir ni rotar otras claves.
+func (h *Handlers) RegisterRoutes(router *gin.RouterGroup) {
+	g := router.Group("/api-keys", middleware.JWT(), middleware.RequireJWT())
+	{
+		g.GET("", h.ListAPIKeys)
+		g.POST("", h.CreateAPIKey)
+		g.GET("/:id", h.GetAPIKey)
+		g.POST("/:id/rotate", h.RotateAPIKey)
+		g.POST("/:id/revoke", h.RevokeAPIKey)
+	}
+}
@@ -0,0 +1,63 @@
+package validator
+
+import (
+	"context"
+	"errors"
+
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/app"
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/domain"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+var errValidationFailed = errors.New("no se pudo validar la API Key")
+
+// Validator adapta el caso de uso al validador que usan middleware.APIKey()
+// y middleware.Auto().
+type Validator struct {
+	uc  app.IUseCase
+	log log.ILogger
+}
+
+func New(uc app.IUseCase, logger log.ILogger) middleware.IAPIKeyValidator {
+	return &Validator{uc: uc, log: logger}
+}
+
+func (v *Validator) ValidateAPIKey(ctx context.Context, request middleware.ValidateAPIKeyRequest) (*middleware.ValidateAPIKeyResponse, error) {
+	result, err := v.uc.ValidateAPIKey(ctx, domain.ValidateAPIKeyDTO{
+		Key:      request.APIKey,
+		ClientIP: request.ClientIP,
+	})
+	if err != nil {
+		if isAuthError(err) {
+			return nil, err
+		}
+		// Un fallo de base de datos no se muestra al cliente
+		v.log.Error(ctx).Err(err).Msg("Error al validar API Key")
+		return nil, errValidationFailed
+	}
+
+	key := result.APIKey
+	permissions := make([]string, 0, len(key.Permissions))
+	for _, p := range key.Permissions {
+		permissions = append(permissions, p.Code())
+	}
+
+	return &middleware.ValidateAPIKeyResponse{
+		Success:     !result.RateLimited,
+		Message:     "API Key válida",
+		UserID:      key.CreatedByID,
+		BusinessID:  key.BusinessID,
+		APIKeyID:    key.ID,
+		Permissions: permissions,
+		RateLimited: result.RateLimited,
+		RetryAfter:  result.RetryAfter,
+	}, nil
+}
+
+func isAuthError(err error) bool {
+	return errors.Is(err, domain.ErrInvalidAPIKey) ||
+		errors.Is(err, domain.ErrAPIKeyRevoked) ||
+		errors.Is(err, domain.ErrAPIKeyExpired) ||
+		errors.Is(err, domain.ErrIPNotAllowed)
+}
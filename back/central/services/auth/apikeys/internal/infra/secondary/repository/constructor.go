@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/db"
+)
+
+type Repository struct {
+	database db.IDatabase
+}
+
+func New(database db.IDatabase) domain.IRepository {
+	return &Repository{database: database}
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/domain"
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func toDomainAPIKey(m *models.APIKey) *domain.APIKey {
+	key := &domain.APIKey{
+		ID:                   m.ID,
+		BusinessID:           m.BusinessID,
+		CreatedByID:          m.CreatedByID,
+		Name:                 m.Name,
+		Description:          m.Description,
+		KeyPrefix:            m.KeyPrefix,
+		KeyHash:              m.KeyHash,
+		PreviousKeyHash:      m.PreviousKeyHash,
+		PreviousKeyExpiresAt: m.PreviousKeyExpiresAt,
+		RotatedAt:            m.RotatedAt,
+		ExpiresAt:            m.ExpiresAt,
+		LastUsedAt:           m.LastUsedAt,
+		LastUsedIP:           m.LastUsedIP,
+		Revoked:              m.Revoked,
+		RevokedAt:            m.RevokedAt,
+		RateLimit:            m.RateLimit,
+		IPAllowlist:          splitAllowlist(m.IPWhitelist),
+		CreatedAt:            m.CreatedAt,
+		UpdatedAt:            m.UpdatedAt,
+	}
+	if m.PreviousKeyPrefix != nil {
+		key.PreviousKeyPrefix = *m.PreviousKeyPrefix
+	}
+	key.Permissions = make([]domain.Permission, 0, len(m.Permissions))
+	for i := range m.Permissions {
+		key.Permissions = append(key.Permissions, toDomainPermission(&m.Permissions[i]))
+	}
+	return key
+}
+
+func toDomainPermission(m *models.Permission) domain.Permission {
+	return domain.Permission{
+		ID:       m.ID,
+		Name:     m.Name,
+		Resource: m.Resource.Name,
+		Action:   m.Action.Name,
+	}
+}
+
+func toModelAPIKey(key *domain.APIKey, permissionIDs []uint) *models.APIKey {
+	m := &models.APIKey{
+		BusinessID:  key.BusinessID,
+		CreatedByID: key.CreatedByID,
+		Name:        key.Name,
+		Description: key.Description,
+		KeyPrefix:   key.KeyPrefix,
+		KeyHash:     key.KeyHash,
+		ExpiresAt:   key.ExpiresAt,
+		RateLimit:   key.RateLimit,
+		IPWhitelist: strings.Join(key.IPAllowlist, ","),
+	}
+	for _, id := range permissionIDs {
+		p := models.Permission{}
+		p.ID = id
+		m.Permissions = append(m.Permissions, p)
+	}
+	return m
+}
+
+func splitAllowlist(raw string) []string {
+	if strings.TrimSpace(raw) == "" {
+		return nil
+	}
+	parts := strings.Split(raw, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/domain"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+)
+
+func (r *Repository) withPermissions(ctx context.Context) *gorm.DB {
+	return r.database.Conn(ctx).
+		Preload("Permissions.Resource").
+		Preload("Permissions.Action")
+}
+
+func (r *Repository) CreateAPIKey(ctx context.Context, key *domain.APIKey, permissionIDs []uint) (*domain.APIKey, error) {
+	m := toModelAPIKey(key, permissionIDs)
+	// Omit("Permissions.*") crea solo las filas de api_key_permissions, sin
+	// tocar los permisos
+	if err := r.database.Conn(ctx).Omit("Permissions.*").Create(m).Error; err != nil {
+		return nil, err
+	}
+	return r.GetAPIKey(ctx, m.ID)
+}
+
+func (r *Repository) GetAPIKey(ctx context.Context, id uint) (*domain.APIKey, error) {
+	var m models.APIKey
+	if err := r.withPermissions(ctx).First(&m, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return toDomainAPIKey(&m), nil
+}
+
+func (r *Repository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (*domain.APIKey, error) {
+	var m models.APIKey
+	err := r.withPermissions(ctx).
+		Where("key_prefix = ? OR previous_key_prefix = ?", prefix, prefix).
+		First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+	return toDomainAPIKey(&m), nil
+}
+
+func (r *Repository) ListAPIKeys(ctx context.Context, businessID uint) ([]domain.APIKey, error) {
+	var rows []models.APIKey
+	if err := r.withPermissions(ctx).
+		Where("business_id = ?", businessID).
+		Order("revoked ASC, created_at DESC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.APIKey, 0, len(rows))
+	for i := range rows {
+		out = append(out, *toDomainAPIKey(&rows[i]))
+	}
+	return out, nil
+}
+
+func (r *Repository) UpdateAPIKey(ctx context.Context, id uint, updates map[string]any) (*domain.APIKey, error) {
+	res := r.database.Conn(ctx).Model(&models.APIKey{}).Where("id = ?", id).Updates(updates)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	return r.GetAPIKey(ctx, id)
+}
+
+func (r *Repository) TouchLastUsed(ctx context.Context, id uint, at time.Time, ip string) error {
+	return r.database.Conn(ctx).
+		Model(&models.APIKey{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]any{"last_used_at": at, "last_used_ip": ip}).Error
+}
+
+func (r *Repository) GetPermissionsByIDs(ctx context.Context, ids []uint) ([]domain.Permission, error) {
+	var rows []models.Permission
+	if err := r.database.Conn(ctx).
+		Preload("Resource").
+		Preload("Action").
+		Where("id IN ?", ids).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.Permission, 0, len(rows))
+	for i := range rows {
+		out = append(out, toDomainPermission(&rows[i]))
+	}
+	return out, nil
+}
+
+func (r *Repository) GetRolePermissionIDs(ctx context.Context, roleID uint) ([]uint, error) {
+	var ids []uint
+	err := r.database.Conn(ctx).
+		Table("role_permissions").
+		Where("role_id = ?", roleID).
+		Pluck("permission_id", &ids).Error
+	return ids, err
+}
+
+func (r *Repository) GetRoleLevel(ctx context.Context, roleID uint) (int, error) {
+	var role models.Role
+	if err := r.database.Conn(ctx).Select("id", "level").First(&role, roleID).Error; err != nil {
+		return 0, err
+	}
+	return role.Level, nil
+}
@@ -0,0 +1,159 @@
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/secamc93/probability/back/central/services/auth/apikeys/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+// RepositoryMock guarda claves, permisos y roles en memoria con las mismas
+// reglas de busqueda del repositorio real (prefijo actual o anterior).
+type RepositoryMock struct {
+	mu              sync.Mutex
+	Keys            map[uint]*domain.APIKey
+	Permissions     map[uint]domain.Permission
+	RolePermissions map[uint][]uint
+	RoleLevels      map[uint]int
+	Touches         int
+	nextID          uint
+}
+
+func NewRepositoryMock() *RepositoryMock {
+	return &RepositoryMock{
+		Keys:            map[uint]*domain.APIKey{},
+		Permissions:     map[uint]domain.Permission{},
+		RolePermissions: map[uint][]uint{},
+		RoleLevels:      map[uint]int{},
+	}
+}
+
+func (m *RepositoryMock) CreateAPIKey(_ context.Context, key *domain.APIKey, permissionIDs []uint) (*domain.APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextID++
+	cp := *key
+	cp.ID = m.nextID
+	cp.Permissions = nil
+	for _, id := range permissionIDs {
+		cp.Permissions = append(cp.Permissions, m.Permissions[id])
+	}
+	m.Keys[cp.ID] = &cp
+	out := cp
+	return &out, nil
+}
+
+func (m *RepositoryMock) GetAPIKey(_ context.Context, id uint) (*domain.APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k, ok := m.Keys[id]
+	if !ok {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	out := *k
+	return &out, nil
+}
+
+func (m *RepositoryMock) GetAPIKeyByPrefix(_ context.Context, prefix string) (*domain.APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, k := range m.Keys {
+		if k.KeyPrefix == prefix || (k.PreviousKeyPrefix != "" && k.PreviousKeyPrefix == prefix) {
+			out := *k
+			return &out, nil
+		}
+	}
+	return nil, domain.ErrAPIKeyNotFound
+}
+
+func (m *RepositoryMock) ListAPIKeys(_ context.Context, businessID uint) ([]domain.APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []domain.APIKey
+	for _, k := range m.Keys {
+		if k.BusinessID == businessID {
+			out = append(out, *k)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (m *RepositoryMock) UpdateAPIKey(_ context.Context, id uint, updates map[string]any) (*domain.APIKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k, ok := m.Keys[id]
+	if !ok {
+		return nil, domain.ErrAPIKeyNotFound
+	}
+	for field, v := range updates {
+		switch field {
+		case "key_prefix":
+			k.KeyPrefix = v.(string)
+		case "key_hash":
+			k.KeyHash = v.(string)
+		case "previous_key_prefix":
+			k.PreviousKeyPrefix, _ = v.(string)
+		case "previous_key_hash":
+			k.PreviousKeyHash = v.(string)
+		case "previous_key_expires_at":
+			k.PreviousKeyExpiresAt = timePtr(v)
+		case "rotated_at":
+			k.RotatedAt = timePtr(v)
+		case "revoked":
+			k.Revoked = v.(bool)
+		case "revoked_at":
+			k.RevokedAt = timePtr(v)
+		}
+	}
+	out := *k
+	return &out, nil
+}
+
+func (m *RepositoryMock) TouchLastUsed(_ context.Context, id uint, at time.Time, ip string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if k, ok := m.Keys[id]; ok {
+		k.LastUsedAt = &at
+		k.LastUsedIP = ip
+		m.Touches++
+	}
+	return nil
+}
+
+func (m *RepositoryMock) GetPermissionsByIDs(_ context.Context, ids []uint) ([]domain.Permission, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []domain.Permission
+	for _, id := range ids {
+		if p, ok := m.Permissions[id]; ok {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (m *RepositoryMock) GetRolePermissionIDs(_ context.Context, roleID uint) ([]uint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.RolePermissions[roleID], nil
+}
+
+func (m *RepositoryMock) GetRoleLevel(_ context.Context, roleID uint) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.RoleLevels[roleID], nil
+}
+
+func timePtr(v any) *time.Time {
+	if t, ok := v.(time.Time); ok {
+		return &t
+	}
+	return nil
+}
+
+func NewSilentLogger() log.ILogger { return log.NewFromZerolog(zerolog.Nop()) }
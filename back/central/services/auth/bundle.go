@@ -3,6 +3,7 @@ package auth
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/secamc93/probability/back/central/services/auth/actions"
+	"github.com/secamc93/probability/back/central/services/auth/apikeys"
 	business "github.com/secamc93/probability/back/central/services/auth/bussines"
 	"github.com/secamc93/probability/back/central/services/auth/demo"
 	"github.com/secamc93/probability/back/central/services/auth/login"
@@ -14,18 +15,19 @@ import (
 	"github.com/secamc93/probability/back/central/shared/env"
 	"github.com/secamc93/probability/back/central/shared/log"
 	"github.com/secamc93/probability/back/central/shared/rabbitmq"
+	"github.com/secamc93/probability/back/central/shared/redis"
 	"github.com/secamc93/probability/back/central/shared/storage"
 )
 
 // New inicializa todos los módulos de autenticación y autorización
 // Este bundle coordina la inicialización de todos los submódulos de auth
-// (login, permissions, roles, users, business, actions, resources)
+// (login, permissions, roles, users, business, actions, resources, api keys)
 type Bundle struct {
 	Demo     *demo.Bundle
 	Business *business.Bundle
 }
 
-func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, environment env.IConfig, s3Service storage.IS3Service, queue rabbitmq.IQueue) *Bundle {
+func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, environment env.IConfig, s3Service storage.IS3Service, queue rabbitmq.IQueue, redisClient redis.IRedis) *Bundle {
 	// Inicializar módulo de login
 	login.New(router, database, logger, environment, queue)
 
@@ -50,5 +52,8 @@ func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, env
 	// Inicializar módulo de resources
 	resources.New(database, logger, router)
 
+	// Inicializar módulo de API keys de los negocios
+	apikeys.New(router, database, logger, redisClient)
+
 	return &Bundle{Demo: demoBundle, Business: businessBundle}
 }
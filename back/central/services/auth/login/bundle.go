@@ -4,13 +4,18 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/secamc93/probability/back/central/services/auth/login/internal/app"
 	authhandler "github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/handlers"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/validator"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/secondary/dns"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/secondary/oidc"
 	otpqueue "github.com/secamc93/probability/back/central/services/auth/login/internal/infra/secondary/queue"
 	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/secondary/repository"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
 	"github.com/secamc93/probability/back/central/shared/db"
 	"github.com/secamc93/probability/back/central/shared/email"
 	"github.com/secamc93/probability/back/central/shared/env"
 	"github.com/secamc93/probability/back/central/shared/jwt"
 	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/netguard"
 	"github.com/secamc93/probability/back/central/shared/rabbitmq"
 )
 
@@ -23,7 +28,7 @@ func New(
 	queue rabbitmq.IQueue,
 ) {
 	// 1. Inicializar Repositorio
-	repo := repository.New(db, logger)
+	repo := repository.New(db, logger, cfg.Get("ENCRYPTION_KEY"))
 
 	// 2. Inicializar Servicio JWT
 	jwtService := jwt.New(cfg.Get("JWT_SECRET"))
@@ -32,12 +37,19 @@ func New(
 
 	otpPublisher := otpqueue.New(queue, logger)
 
+	// Los issuers los configura cada negocio: nada de la red interna
+	guard := netguard.Guard{AllowLoopback: cfg.Get("SSO_ALLOW_LOOPBACK") == "true"}
+	oidcClient := oidc.New(guard, logger)
+
 	// 3. Inicializar Caso de Uso
-	authUC := app.New(repo, jwtService, emailService, otpPublisher, logger, cfg)
+	authUC := app.New(repo, jwtService, emailService, otpPublisher, oidcClient, dns.New(), guard, logger, cfg)
 
 	// 4. Inicializar Handler
 	authH := authhandler.New(authUC, logger)
 
 	// 5. Registrar Rutas
 	authH.RegisterRoutes(router, authH, logger)
+
+	// 6. Rechazar en middleware.JWT() los tokens de sesiones revocadas
+	middleware.SetSessionValidator(validator.NewSession(authUC, logger))
 }
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+	"errors"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/bizscope"
+)
+
+var (
+	// userManagerScope: solo los administradores gestionan el 2FA y las
+	// sesiones de otros usuarios de su negocio
+	userManagerScope = bizscope.Policy{
+		ErrForbidden:        domain.ErrForbiddenUserManagement,
+		ErrBusinessRequired: domain.ErrForbiddenUserManagement,
+	}
+
+	// businessSettingsScope: solo los administradores gestionan la
+	// configuración del negocio; el super admin debe indicar cuál
+	businessSettingsScope = bizscope.Policy{
+		ErrForbidden:        domain.ErrForbiddenBusinessSettings,
+		ErrBusinessRequired: domain.ErrBusinessRequired,
+	}
+)
+
+// authorizeUserManagement deja gestionar a otro usuario al super admin y a
+// los administradores del negocio donde ese usuario trabaja, siempre que su
+// rol alli no sea de mayor jerarquia que el del actor. Al personal de
+// plataforma solo lo gestiona el super admin.
+func (uc *AuthUseCase) authorizeUserManagement(ctx context.Context, actor domain.AuthActor, targetUserID uint) error {
+	target, err := uc.repository.GetUserByID(ctx, targetUserID)
+	if err != nil {
+		return err
+	}
+	if target == nil {
+		return domain.ErrUserNotFound
+	}
+	if actor.IsSuperAdmin {
+		return nil
+	}
+
+	var actorLevel int
+	actorRoleLevel := func(ctx context.Context, roleID uint) (int, error) {
+		level, err := uc.roleLevel(ctx, roleID)
+		actorLevel = level
+		return level, err
+	}
+	businessID, err := userManagerScope.Resolve(ctx, actorRoleLevel, scopeActor(actor), 0)
+	if err != nil {
+		return err
+	}
+
+	targetRoles, err := uc.repository.GetUserRoles(ctx, targetUserID)
+	if err != nil {
+		return err
+	}
+	for _, role := range targetRoles {
+		if role.ScopeCode == "platform" {
+			return domain.ErrForbiddenUserManagement
+		}
+	}
+
+	relation, err := uc.repository.GetBusinessStaffRelation(ctx, targetUserID, &businessID)
+	if err != nil {
+		return err
+	}
+	if relation == nil {
+		return domain.ErrForbiddenUserManagement
+	}
+	if relation.RoleID == nil {
+		return nil
+	}
+	// Nivel menor es mayor jerarquia (1=super, 2=admin)
+	targetLevel, err := uc.roleLevel(ctx, *relation.RoleID)
+	if errors.Is(err, bizscope.ErrRoleNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if targetLevel < actorLevel {
+		return domain.ErrForbiddenUserManagement
+	}
+	return nil
+}
+
+// resolveManagedBusiness devuelve el negocio cuya configuración se gestiona:
+// el que indique el super admin, o el del token si el actor es administrador
+func (uc *AuthUseCase) resolveManagedBusiness(ctx context.Context, actor domain.AuthActor, businessID uint) (uint, error) {
+	return businessSettingsScope.Resolve(ctx, uc.roleLevel, scopeActor(actor), businessID)
+}
+
+// roleLevel adapta GetRoleByID, que devuelve nil si el rol no existe
+func (uc *AuthUseCase) roleLevel(ctx context.Context, roleID uint) (int, error) {
+	role, err := uc.repository.GetRoleByID(ctx, roleID)
+	if err != nil {
+		return 0, err
+	}
+	if role == nil {
+		return 0, bizscope.ErrRoleNotFound
+	}
+	return role.Level, nil
+}
+
+func scopeActor(actor domain.AuthActor) bizscope.Actor {
+	return bizscope.Actor{
+		BusinessID:   actor.BusinessID,
+		RoleID:       actor.RoleID,
+		IsSuperAdmin: actor.IsSuperAdmin,
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
 	"github.com/secamc93/probability/back/central/shared/env"
 	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/netguard"
 )
 
 type Iapp interface {
@@ -17,10 +18,36 @@ type Iapp interface {
 	ForgotPassword(ctx context.Context, request domain.ForgotPasswordRequest) (*domain.ForgotPasswordResponse, error)
 	VerifyOTP(ctx context.Context, request domain.VerifyOTPRequest) (*domain.VerifyOTPResponse, error)
 	ResetPassword(ctx context.Context, request domain.ResetPasswordRequest) (*domain.ResetPasswordResponse, error)
-}
 
-type IAuthUseCase interface {
-	ValidateAPIKey(ctx context.Context, request domain.ValidateAPIKeyRequest) (*domain.ValidateAPIKeyResponse, error)
+	// Segundo factor dentro del login
+	SetupTwoFactorLogin(ctx context.Context, twoFactorToken string) (*domain.TwoFactorSetup, error)
+	VerifyTwoFactorLogin(ctx context.Context, request domain.VerifyTwoFactorLoginRequest) (*domain.LoginResponse, error)
+
+	// Segundo factor desde el perfil
+	GetTwoFactorStatus(ctx context.Context, userID uint) (*domain.TwoFactorStatus, error)
+	SetupTwoFactor(ctx context.Context, userID uint) (*domain.TwoFactorSetup, error)
+	EnableTwoFactor(ctx context.Context, userID uint, code string) ([]string, error)
+	DisableTwoFactor(ctx context.Context, userID uint, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uint, code string) ([]string, error)
+	ResetUserTwoFactor(ctx context.Context, actor domain.AuthActor, targetUserID uint) error
+
+	// Sesiones
+	ListSessions(ctx context.Context, actor domain.AuthActor) ([]domain.UserSession, error)
+	RevokeSession(ctx context.Context, actor domain.AuthActor, sessionID string) error
+	RevokeOtherSessions(ctx context.Context, actor domain.AuthActor) (int, error)
+	Logout(ctx context.Context, actor domain.AuthActor) error
+	ListUserSessions(ctx context.Context, actor domain.AuthActor, targetUserID uint) ([]domain.UserSession, error)
+	RevokeUserSessions(ctx context.Context, actor domain.AuthActor, targetUserID uint) (int, error)
+	ValidateSession(ctx context.Context, sessionID string, userID uint, ipAddress string) error
+
+	// SSO (OIDC) por negocio
+	DiscoverSSO(ctx context.Context, request domain.SSODiscoverRequest) (*domain.SSODiscovery, error)
+	StartSSO(ctx context.Context, businessCode string) (string, error)
+	CompleteSSOLogin(ctx context.Context, request domain.SSOCallbackRequest) (*domain.LoginResponse, error)
+	GetSSOConfig(ctx context.Context, actor domain.AuthActor, businessID uint) (*domain.BusinessSSOConfig, error)
+	UpsertSSOConfig(ctx context.Context, actor domain.AuthActor, request domain.UpsertSSOConfigRequest) (*domain.BusinessSSOConfig, error)
+	DeleteSSOConfig(ctx context.Context, actor domain.AuthActor, businessID uint) error
+	VerifySSODomain(ctx context.Context, actor domain.AuthActor, businessID uint, emailDomain string) (*domain.SSODomain, error)
 }
 
 type AuthUseCase struct {
@@ -28,17 +55,25 @@ type AuthUseCase struct {
 	jwtService   domain.IJWTService
 	emailSender  domain.IEmailSender
 	otpPublisher domain.IOTPEventPublisher
+	oidc         domain.IOIDCClient
+	dns          domain.IDNSResolver
+	guard        netguard.Guard
 	log          log.ILogger
 	env          env.IConfig
+	sessions     *sessionCache
 }
 
-func New(repository domain.IAuthRepository, jwtService domain.IJWTService, emailSender domain.IEmailSender, otpPublisher domain.IOTPEventPublisher, log log.ILogger, env env.IConfig) Iapp {
+func New(repository domain.IAuthRepository, jwtService domain.IJWTService, emailSender domain.IEmailSender, otpPublisher domain.IOTPEventPublisher, oidc domain.IOIDCClient, dns domain.IDNSResolver, guard netguard.Guard, log log.ILogger, env env.IConfig) Iapp {
 	return &AuthUseCase{
 		repository:   repository,
 		jwtService:   jwtService,
 		emailSender:  emailSender,
 		otpPublisher: otpPublisher,
+		oidc:         oidc,
+		dns:          dns,
+		guard:        guard,
 		log:          log,
 		env:          env,
+		sessions:     newSessionCache(),
 	}
 }
@@ -62,10 +62,47 @@ func (uc *AuthUseCase) Login(ctx context.Context, request domain.LoginRequest) (
 		return nil, fmt.Errorf("error interno del servidor")
 	}
 
+	// El super admin conserva la contraseña para poder corregir un SSO mal configurado
+	if !isSuperAdmin(roles) {
+		disabled, err := uc.repository.IsPasswordLoginDisabled(ctx, userAuth.ID)
+		if err != nil {
+			uc.log.Error().Err(err).Uint("user_id", userAuth.ID).Msg("Error consultando si el negocio exige SSO")
+			return nil, fmt.Errorf("error interno del servidor")
+		}
+		if disabled {
+			uc.log.Warn().Uint("user_id", userAuth.ID).Msg("Login con contraseña deshabilitado por SSO del negocio")
+			return nil, domain.ErrPasswordLoginDisabled
+		}
+	}
+
+	challenge, err := uc.twoFactorChallenge(ctx, userAuth, roles)
+	if err != nil || challenge != nil {
+		return challenge, err
+	}
+
+	return uc.completeLogin(ctx, userAuth, roles, request.Client, 0)
+}
+
+// completeLogin registra la sesión, emite el token y arma la respuesta una
+// vez validadas las credenciales y, si aplica, el segundo factor. El token
+// queda en preferredBusinessID (login por SSO) o, con 0, en el primer negocio.
+// Con preferredBusinessID el token solo puede ser de ese negocio: sin relación
+// con él o con rol de plataforma se rechaza en vez de caer en otro alcance.
+func (uc *AuthUseCase) completeLogin(ctx context.Context, userAuth *domain.UserAuthInfo, roles []domain.Role, client domain.ClientInfo, preferredBusinessID uint) (*domain.LoginResponse, error) {
 	businesses, err := uc.repository.GetUserBusinesses(ctx, userAuth.ID)
 	if err != nil {
 		uc.log.Error().Err(err).Uint("user_id", userAuth.ID).Msg("Error al obtener businesses del usuario")
 	}
+	businesses = preferBusiness(businesses, preferredBusinessID)
+	if preferredBusinessID != 0 {
+		if isSuperAdmin(roles) {
+			return nil, domain.ErrSSOPlatformUser
+		}
+		if len(businesses) == 0 || businesses[0].ID != preferredBusinessID {
+			uc.log.Warn().Uint("user_id", userAuth.ID).Uint("business_id", preferredBusinessID).Msg("Usuario sin relación con el negocio del login")
+			return nil, domain.ErrSSOAccountConflict
+		}
+	}
 
 	avatarURL := userAuth.AvatarURL
 	if avatarURL != "" && !strings.HasPrefix(avatarURL, "http") {
@@ -214,7 +251,13 @@ func (uc *AuthUseCase) Login(ctx context.Context, request domain.LoginRequest) (
 		subscriptionStatus = "active"
 	}
 
-	token, err := uc.jwtService.GenerateToken(userAuth.ID, businessID, businessTypeID, roleID, subscriptionStatus)
+	sessionID, err := uc.startSession(ctx, userAuth.ID, businessID, client)
+	if err != nil {
+		uc.log.Error().Err(err).Uint("user_id", userAuth.ID).Msg("Error al registrar la sesión")
+		return nil, fmt.Errorf("error interno del servidor")
+	}
+
+	token, err := uc.jwtService.GenerateSessionToken(userAuth.ID, businessID, businessTypeID, roleID, subscriptionStatus, sessionID)
 	if err != nil {
 		uc.log.Error().Err(err).Uint("user_id", userAuth.ID).Msg("Error al generar token JWT")
 		return nil, fmt.Errorf("error interno del servidor")
@@ -331,6 +374,7 @@ func (uc *AuthUseCase) Login(ctx context.Context, request domain.LoginRequest) (
 		Businesses:            businessesList,
 		Scope:                 userScope,
 		IsSuperAdmin:          isSuperAdmin,
+		SessionID:             sessionID,
 	}
 
 	uc.log.Info().
@@ -350,3 +394,16 @@ func isSuperAdmin(roles []domain.Role) bool {
 	}
 	return false
 }
+
+// preferBusiness pone primero el negocio indicado, que es el que va al token
+func preferBusiness(businesses []domain.BusinessInfoEntity, businessID uint) []domain.BusinessInfoEntity {
+	for i, business := range businesses {
+		if business.ID == businessID && i > 0 {
+			ordered := make([]domain.BusinessInfoEntity, 0, len(businesses))
+			ordered = append(ordered, business)
+			ordered = append(ordered, businesses[:i]...)
+			return append(ordered, businesses[i+1:]...)
+		}
+	}
+	return businesses
+}
@@ -3,11 +3,13 @@ package app
 import (
 	"context"
 	"errors"
+	"net"
 	"testing"
 	"time"
 
 	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
 	"github.com/secamc93/probability/back/central/services/auth/login/internal/mocks"
+	"github.com/secamc93/probability/back/central/shared/netguard"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -20,6 +22,18 @@ func (c *configStub) Get(key string) string {
 	return c.valores[key]
 }
 
+// resolverDePrueba evita DNS real: localhost e interno.test.com resuelven a
+// direcciones internas y cualquier otro host a una IP publica
+func resolverDePrueba(ctx context.Context, host string) ([]net.IP, error) {
+	switch host {
+	case "localhost":
+		return []net.IP{net.ParseIP("127.0.0.1")}, nil
+	case "interno.test.com":
+		return []net.IP{net.ParseIP("10.0.0.5")}, nil
+	}
+	return []net.IP{net.ParseIP("93.184.216.34")}, nil
+}
+
 func buildLoginUseCase(repo *mocks.AuthRepositoryMock, jwt *mocks.JWTServiceMock, valores map[string]string) *AuthUseCase {
 	if jwt == nil {
 		jwt = &mocks.JWTServiceMock{}
@@ -30,6 +44,7 @@ func buildLoginUseCase(repo *mocks.AuthRepositoryMock, jwt *mocks.JWTServiceMock
 	return &AuthUseCase{
 		repository: repo,
 		jwtService: jwt,
+		guard:      netguard.Guard{LookupIP: resolverDePrueba},
 		log:        mocks.NewSilentLogger(),
 		env:        &configStub{valores: valores},
 	}
@@ -136,7 +151,7 @@ func TestLogin_ContrasenaInvalida_Rechaza(t *testing.T) {
 		},
 	}
 	jwt := &mocks.JWTServiceMock{
-		GenerateTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus string) (string, error) {
+		GenerateSessionTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error) {
 			tokenGenerado = true
 			return "token", nil
 		},
@@ -235,7 +250,7 @@ func TestLogin_SuperAdmin_TokenConBusinessCero(t *testing.T) {
 		},
 	}
 	jwt := &mocks.JWTServiceMock{
-		GenerateTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus string) (string, error) {
+		GenerateSessionTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error) {
 			tokenBusinessID, tokenRoleID, tokenSubscripcion = businessID, roleID, subscriptionStatus
 			return "token-super", nil
 		},
@@ -274,7 +289,7 @@ func TestLogin_UsuarioDeNegocio_TokenConSuPrimerNegocio(t *testing.T) {
 		},
 	}
 	jwt := &mocks.JWTServiceMock{
-		GenerateTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus string) (string, error) {
+		GenerateSessionTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error) {
 			tokenBusinessID, tokenTipoNegocio, tokenRoleID = businessID, businessTypeID, roleID
 			return "token", nil
 		},
@@ -312,7 +327,7 @@ func TestLogin_SinRolEnElNegocio_CaeAlPrimerRol(t *testing.T) {
 		},
 	}
 	jwt := &mocks.JWTServiceMock{
-		GenerateTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus string) (string, error) {
+		GenerateSessionTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error) {
 			tokenRoleID = roleID
 			return "token", nil
 		},
@@ -342,7 +357,7 @@ func TestLogin_UsuarioSinNegocios_TokenConBusinessCero(t *testing.T) {
 		},
 	}
 	jwt := &mocks.JWTServiceMock{
-		GenerateTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus string) (string, error) {
+		GenerateSessionTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error) {
 			tokenBusinessID = businessID
 			return "token", nil
 		},
@@ -375,7 +390,7 @@ func TestLogin_SuscripcionVacia_CaeAActive(t *testing.T) {
 		},
 	}
 	jwt := &mocks.JWTServiceMock{
-		GenerateTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus string) (string, error) {
+		GenerateSessionTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error) {
 			tokenSubscripcion = subscriptionStatus
 			return "token", nil
 		},
@@ -407,7 +422,7 @@ func TestLogin_SuscripcionVencida_SePropagaAlToken(t *testing.T) {
 		},
 	}
 	jwt := &mocks.JWTServiceMock{
-		GenerateTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus string) (string, error) {
+		GenerateSessionTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error) {
 			tokenSubscripcion = subscriptionStatus
 			return "token", nil
 		},
@@ -589,7 +604,7 @@ func TestLogin_FallaGenerarToken_RetornaErrorInterno(t *testing.T) {
 		},
 	}
 	jwt := &mocks.JWTServiceMock{
-		GenerateTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus string) (string, error) {
+		GenerateSessionTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error) {
 			return "", errors.New("clave jwt invalida")
 		},
 	}
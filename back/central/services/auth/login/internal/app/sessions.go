@@ -0,0 +1,278 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/jwt"
+)
+
+const (
+	// Cuánto confía cada instancia en su última lectura de una sesión. Una
+	// revocación hecha en otra instancia tarda como máximo esto en aplicarse.
+	sessionCacheTTL = 30 * time.Second
+	// last_seen_at se actualiza como mucho una vez por intervalo
+	sessionTouchInterval = time.Minute
+	sessionCacheMaxSize  = 10000
+
+	sessionRevokedLogout         = "logout"
+	sessionRevokedByUser         = "user_revoked"
+	sessionRevokedOthers         = "user_revoked_others"
+	sessionRevokedByAdmin        = "admin_revoked"
+	sessionRevokedTwoFactorReset = "two_factor_reset"
+)
+
+// startSession registra la sesión que va en el claim "sid" del token
+func (uc *AuthUseCase) startSession(ctx context.Context, userID, businessID uint, client domain.ClientInfo) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	session := &domain.UserSession{
+		SessionID:  hex.EncodeToString(buf),
+		UserID:     userID,
+		BusinessID: businessID,
+		UserAgent:  truncate(client.UserAgent, 500),
+		Device:     describeDevice(client.UserAgent),
+		IPAddress:  truncate(client.IPAddress, 64),
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(jwt.TokenTTL),
+	}
+	if err := uc.repository.CreateSession(ctx, session); err != nil {
+		return "", err
+	}
+	return session.SessionID, nil
+}
+
+// ListSessions retorna las sesiones activas del usuario marcando la actual
+func (uc *AuthUseCase) ListSessions(ctx context.Context, actor domain.AuthActor) ([]domain.UserSession, error) {
+	sessions, err := uc.repository.ListActiveSessions(ctx, actor.UserID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].SessionID == actor.SessionID
+	}
+	return sessions, nil
+}
+
+// RevokeSession cierra una sesión propia
+func (uc *AuthUseCase) RevokeSession(ctx context.Context, actor domain.AuthActor, sessionID string) error {
+	session, err := uc.repository.GetSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if session == nil || session.UserID != actor.UserID || session.RevokedAt != nil {
+		return domain.ErrSessionNotFound
+	}
+
+	if err := uc.repository.RevokeSession(ctx, sessionID, actor.UserID, sessionRevokedByUser); err != nil {
+		return err
+	}
+	uc.sessions.evict(sessionID)
+	return nil
+}
+
+// RevokeOtherSessions cierra todas las sesiones del usuario menos la actual
+func (uc *AuthUseCase) RevokeOtherSessions(ctx context.Context, actor domain.AuthActor) (int, error) {
+	revoked, err := uc.repository.RevokeUserSessions(ctx, actor.UserID, actor.SessionID, actor.UserID, sessionRevokedOthers)
+	if err != nil {
+		return 0, err
+	}
+	uc.sessions.evict(revoked...)
+
+	uc.log.Info().Uint("user_id", actor.UserID).Int("sessions_revoked", len(revoked)).Msg("Sesiones cerradas en otros dispositivos")
+	return len(revoked), nil
+}
+
+// Logout cierra la sesión actual. Los tokens sin "sid" no tienen registro y
+// solo se borra la cookie.
+func (uc *AuthUseCase) Logout(ctx context.Context, actor domain.AuthActor) error {
+	if actor.SessionID == "" {
+		return nil
+	}
+	if err := uc.repository.RevokeSession(ctx, actor.SessionID, actor.UserID, sessionRevokedLogout); err != nil {
+		return err
+	}
+	uc.sessions.evict(actor.SessionID)
+	return nil
+}
+
+// ListUserSessions retorna las sesiones activas de otro usuario
+func (uc *AuthUseCase) ListUserSessions(ctx context.Context, actor domain.AuthActor, targetUserID uint) ([]domain.UserSession, error) {
+	if err := uc.authorizeUserManagement(ctx, actor, targetUserID); err != nil {
+		return nil, err
+	}
+	return uc.repository.ListActiveSessions(ctx, targetUserID, time.Now())
+}
+
+// RevokeUserSessions cierra todas las sesiones de otro usuario
+func (uc *AuthUseCase) RevokeUserSessions(ctx context.Context, actor domain.AuthActor, targetUserID uint) (int, error) {
+	if err := uc.authorizeUserManagement(ctx, actor, targetUserID); err != nil {
+		return 0, err
+	}
+
+	revoked, err := uc.repository.RevokeUserSessions(ctx, targetUserID, "", actor.UserID, sessionRevokedByAdmin)
+	if err != nil {
+		return 0, err
+	}
+	uc.sessions.evict(revoked...)
+
+	uc.log.Warn().
+		Uint("user_id", targetUserID).
+		Uint("revoked_by", actor.UserID).
+		Int("sessions_revoked", len(revoked)).
+		Msg("Sesiones del usuario cerradas por un administrador")
+	return len(revoked), nil
+}
+
+// ValidateSession confirma que la sesión del token sigue activa. Lo llama
+// middleware.JWT() en cada request, por eso lee de un cache corto.
+func (uc *AuthUseCase) ValidateSession(ctx context.Context, sessionID string, userID uint, ipAddress string) error {
+	now := time.Now()
+	if active, found := uc.sessions.get(sessionID, now); found {
+		if !active {
+			return domain.ErrSessionRevoked
+		}
+		return nil
+	}
+
+	session, err := uc.repository.GetSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	active := session != nil &&
+		session.UserID == userID &&
+		session.RevokedAt == nil &&
+		now.Before(session.ExpiresAt)
+	uc.sessions.set(sessionID, active, now)
+	if !active {
+		return domain.ErrSessionRevoked
+	}
+
+	if now.Sub(session.LastSeenAt) >= sessionTouchInterval {
+		if err := uc.repository.TouchSession(ctx, sessionID, truncate(ipAddress, 64), now); err != nil {
+			uc.log.Warn().Err(err).Str("session_id", sessionID).Msg("Error actualizando ultimo uso de la sesion")
+		}
+	}
+	return nil
+}
+
+// describeDevice resume el User-Agent en algo legible para el listado
+func describeDevice(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+	if ua == "" {
+		return "Desconocido"
+	}
+
+	browser := "Navegador"
+	switch {
+	case strings.Contains(ua, "edg/"):
+		browser = "Edge"
+	case strings.Contains(ua, "opr/") || strings.Contains(ua, "opera"):
+		browser = "Opera"
+	case strings.Contains(ua, "chrome/") || strings.Contains(ua, "crios/"):
+		browser = "Chrome"
+	case strings.Contains(ua, "firefox/") || strings.Contains(ua, "fxios/"):
+		browser = "Firefox"
+	case strings.Contains(ua, "safari/"):
+		browser = "Safari"
+	case strings.Contains(ua, "dart") || strings.Contains(ua, "okhttp") || strings.Contains(ua, "cfnetwork"):
+		browser = "App"
+	}
+
+	platform := ""
+	switch {
+	case strings.Contains(ua, "android"):
+		platform = "Android"
+	case strings.Contains(ua, "iphone") || strings.Contains(ua, "ipad") || strings.Contains(ua, "ios"):
+		platform = "iOS"
+	case strings.Contains(ua, "windows"):
+		platform = "Windows"
+	case strings.Contains(ua, "mac os") || strings.Contains(ua, "macintosh"):
+		platform = "macOS"
+	case strings.Contains(ua, "linux"):
+		platform = "Linux"
+	}
+
+	if platform == "" {
+		return browser
+	}
+	return browser + " en " + platform
+}
+
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	return value[:max]
+}
+
+// sessionCache guarda por unos segundos si una sesión está activa. Un nil
+// no guarda nada (así lo usan los tests).
+type sessionCache struct {
+	mu      sync.Mutex
+	entries map[string]sessionCacheEntry
+}
+
+type sessionCacheEntry struct {
+	active    bool
+	expiresAt time.Time
+}
+
+func newSessionCache() *sessionCache {
+	return &sessionCache{entries: make(map[string]sessionCacheEntry)}
+}
+
+func (c *sessionCache) get(sessionID string, now time.Time) (bool, bool) {
+	if c == nil {
+		return false, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[sessionID]
+	if !ok || now.After(entry.expiresAt) {
+		return false, false
+	}
+	return entry.active, true
+}
+
+func (c *sessionCache) set(sessionID string, active bool, now time.Time) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= sessionCacheMaxSize {
+		for id, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, id)
+			}
+		}
+		if len(c.entries) >= sessionCacheMaxSize {
+			c.entries = make(map[string]sessionCacheEntry)
+		}
+	}
+	c.entries[sessionID] = sessionCacheEntry{active: active, expiresAt: now.Add(sessionCacheTTL)}
+}
+
+// evict saca del cache las sesiones cerradas en esta instancia, para que el
+// siguiente request lea la revocación sin esperar a que venza
+func (c *sessionCache) evict(sessionIDs ...string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range sessionIDs {
+		delete(c.entries, id)
+	}
+}
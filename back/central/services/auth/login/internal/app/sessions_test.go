@@ -0,0 +1,284 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sesionActiva(sessionID string, userID uint) *domain.UserSession {
+	return &domain.UserSession{
+		SessionID:  sessionID,
+		UserID:     userID,
+		LastSeenAt: time.Now(),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+}
+
+func TestValidateSession_Activa_UsaElCacheEnLaSegundaLlamada(t *testing.T) {
+	lecturas := 0
+	repo := &mocks.AuthRepositoryMock{
+		GetSessionFn: func(ctx context.Context, sessionID string) (*domain.UserSession, error) {
+			lecturas++
+			return sesionActiva(sessionID, 42), nil
+		},
+	}
+	uc := buildLoginUseCase(repo, nil, nil)
+	uc.sessions = newSessionCache()
+
+	require.NoError(t, uc.ValidateSession(context.Background(), "sid-1", 42, "10.0.0.1"))
+	require.NoError(t, uc.ValidateSession(context.Background(), "sid-1", 42, "10.0.0.1"))
+	assert.Equal(t, 1, lecturas)
+}
+
+func TestValidateSession_Revocada_Rechaza(t *testing.T) {
+	revocada := sesionActiva("sid-1", 42)
+	ahora := time.Now()
+	revocada.RevokedAt = &ahora
+	repo := &mocks.AuthRepositoryMock{
+		GetSessionFn: func(ctx context.Context, sessionID string) (*domain.UserSession, error) {
+			return revocada, nil
+		},
+	}
+	uc := buildLoginUseCase(repo, nil, nil)
+
+	err := uc.ValidateSession(context.Background(), "sid-1", 42, "")
+
+	assert.ErrorIs(t, err, domain.ErrSessionRevoked)
+}
+
+func TestValidateSession_DeOtroUsuarioOInexistente_Rechaza(t *testing.T) {
+	repo := &mocks.AuthRepositoryMock{
+		GetSessionFn: func(ctx context.Context, sessionID string) (*domain.UserSession, error) {
+			if sessionID == "sid-ajena" {
+				return sesionActiva(sessionID, 99), nil
+			}
+			return nil, nil
+		},
+	}
+	uc := buildLoginUseCase(repo, nil, nil)
+
+	assert.ErrorIs(t, uc.ValidateSession(context.Background(), "sid-ajena", 42, ""), domain.ErrSessionRevoked)
+	assert.ErrorIs(t, uc.ValidateSession(context.Background(), "sid-borrada", 42, ""), domain.ErrSessionRevoked)
+}
+
+func TestValidateSession_ActualizaUltimoUsoSoloSiPasoElIntervalo(t *testing.T) {
+	toques := 0
+	vieja := sesionActiva("sid-vieja", 42)
+	vieja.LastSeenAt = time.Now().Add(-2 * sessionTouchInterval)
+	repo := &mocks.AuthRepositoryMock{
+		GetSessionFn: func(ctx context.Context, sessionID string) (*domain.UserSession, error) {
+			if sessionID == "sid-vieja" {
+				return vieja, nil
+			}
+			return sesionActiva(sessionID, 42), nil
+		},
+		TouchSessionFn: func(ctx context.Context, sessionID string, ipAddress string, seenAt time.Time) error {
+			toques++
+			assert.Equal(t, "sid-vieja", sessionID)
+			return nil
+		},
+	}
+	uc := buildLoginUseCase(repo, nil, nil)
+
+	require.NoError(t, uc.ValidateSession(context.Background(), "sid-reciente", 42, ""))
+	require.NoError(t, uc.ValidateSession(context.Background(), "sid-vieja", 42, ""))
+	assert.Equal(t, 1, toques)
+}
+
+func TestRevokeSession_LimpiaElCacheDeLaInstancia(t *testing.T) {
+	sesion := sesionActiva("sid-1", 42)
+	repo := &mocks.AuthRepositoryMock{
+		GetSessionFn: func(ctx context.Context, sessionID string) (*domain.UserSession, error) {
+			return sesion, nil
+		},
+		RevokeSessionFn: func(ctx context.Context, sessionID string, revokedByID uint, reason string) error {
+			ahora := time.Now()
+			sesion.RevokedAt = &ahora
+			return nil
+		},
+	}
+	uc := buildLoginUseCase(repo, nil, nil)
+	uc.sessions = newSessionCache()
+
+	require.NoError(t, uc.ValidateSession(context.Background(), "sid-1", 42, ""))
+	require.NoError(t, uc.RevokeSession(context.Background(), domain.AuthActor{UserID: 42}, "sid-1"))
+
+	assert.ErrorIs(t, uc.ValidateSession(context.Background(), "sid-1", 42, ""), domain.ErrSessionRevoked,
+		"la revocacion aplica de inmediato en la instancia que la hizo")
+}
+
+func TestRevokeSession_DeOtroUsuario_NoExiste(t *testing.T) {
+	repo := &mocks.AuthRepositoryMock{
+		GetSessionFn: func(ctx context.Context, sessionID string) (*domain.UserSession, error) {
+			return sesionActiva(sessionID, 99), nil
+		},
+		RevokeSessionFn: func(ctx context.Context, sessionID string, revokedByID uint, reason string) error {
+			t.Fatal("no debe revocar sesiones ajenas")
+			return nil
+		},
+	}
+	uc := buildLoginUseCase(repo, nil, nil)
+
+	err := uc.RevokeSession(context.Background(), domain.AuthActor{UserID: 42}, "sid-1")
+
+	assert.ErrorIs(t, err, domain.ErrSessionNotFound)
+}
+
+func TestRevokeOtherSessions_ConservaLaActual(t *testing.T) {
+	var exceptuada string
+	repo := &mocks.AuthRepositoryMock{
+		RevokeUserSessionsFn: func(ctx context.Context, userID uint, exceptSessionID string, revokedByID uint, reason string) ([]string, error) {
+			exceptuada = exceptSessionID
+			return []string{"sid-2", "sid-3"}, nil
+		},
+	}
+	uc := buildLoginUseCase(repo, nil, nil)
+
+	revocadas, err := uc.RevokeOtherSessions(context.Background(), domain.AuthActor{UserID: 42, SessionID: "sid-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, revocadas)
+	assert.Equal(t, "sid-1", exceptuada)
+}
+
+func TestListSessions_MarcaLaActual(t *testing.T) {
+	repo := &mocks.AuthRepositoryMock{
+		ListActiveSessionsFn: func(ctx context.Context, userID uint, now time.Time) ([]domain.UserSession, error) {
+			return []domain.UserSession{*sesionActiva("sid-1", 42), *sesionActiva("sid-2", 42)}, nil
+		},
+	}
+	uc := buildLoginUseCase(repo, nil, nil)
+
+	got, err := uc.ListSessions(context.Background(), domain.AuthActor{UserID: 42, SessionID: "sid-2"})
+
+	require.NoError(t, err)
+	assert.False(t, got[0].Current)
+	assert.True(t, got[1].Current)
+}
+
+func repoGestionUsuarios(nivelRol int, relacion bool) *mocks.AuthRepositoryMock {
+	return repoGestionUsuariosConObjetivo(nivelRol, relacion, 0, "business")
+}
+
+// repoGestionUsuariosConObjetivo da al usuario objetivo (77) el rol 9 en el
+// negocio con nivelObjetivo, y un rol en el scope indicado
+func repoGestionUsuariosConObjetivo(nivelRol int, relacion bool, nivelObjetivo int, scopeObjetivo string) *mocks.AuthRepositoryMock {
+	return &mocks.AuthRepositoryMock{
+		GetUserByIDFn: func(ctx context.Context, userID uint) (*domain.UserAuthInfo, error) {
+			return usuarioActivo(userID, "x"), nil
+		},
+		GetRoleByIDFn: func(ctx context.Context, id uint) (*domain.Role, error) {
+			if id == 9 {
+				return &domain.Role{ID: id, Level: nivelObjetivo}, nil
+			}
+			return &domain.Role{ID: id, Level: nivelRol}, nil
+		},
+		GetUserRolesFn: func(ctx context.Context, userID uint) ([]domain.Role, error) {
+			return []domain.Role{{ID: 9, Level: nivelObjetivo, ScopeCode: scopeObjetivo}}, nil
+		},
+		GetBusinessStaffRelationFn: func(ctx context.Context, userID uint, businessID *uint) (*domain.BusinessStaffRelation, error) {
+			if !relacion {
+				return nil, nil
+			}
+			relation := &domain.BusinessStaffRelation{UserID: userID, BusinessID: businessID}
+			if nivelObjetivo > 0 {
+				rolID := uint(9)
+				relation.RoleID = &rolID
+			}
+			return relation, nil
+		},
+	}
+}
+
+func TestAuthorizeUserManagement(t *testing.T) {
+	admin := domain.AuthActor{UserID: 1, BusinessID: 5, RoleID: 2}
+
+	cases := []struct {
+		nombre   string
+		actor    domain.AuthActor
+		nivel    int
+		relacion bool
+		esperado error
+	}{
+		{"admin del negocio del usuario", admin, 2, true, nil},
+		{"super admin sin negocio", domain.AuthActor{UserID: 1, IsSuperAdmin: true}, 1, false, nil},
+		{"rol staff no gestiona", admin, 4, true, domain.ErrForbiddenUserManagement},
+		{"usuario de otro negocio", admin, 2, false, domain.ErrForbiddenUserManagement},
+		{"sin negocio en el token", domain.AuthActor{UserID: 1, RoleID: 2}, 2, true, domain.ErrForbiddenUserManagement},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.nombre, func(t *testing.T) {
+			uc := buildLoginUseCase(repoGestionUsuarios(tc.nivel, tc.relacion), nil, nil)
+			err := uc.authorizeUserManagement(context.Background(), tc.actor, 77)
+			if tc.esperado == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.esperado)
+			}
+		})
+	}
+}
+
+func TestAuthorizeUserManagement_JerarquiaDelObjetivo(t *testing.T) {
+	admin := domain.AuthActor{UserID: 1, BusinessID: 5, RoleID: 2}
+
+	cases := []struct {
+		nombre   string
+		nivel    int
+		scope    string
+		esperado error
+	}{
+		{"objetivo de menor jerarquia", 4, "business", nil},
+		{"objetivo del mismo nivel", 2, "business", nil},
+		{"objetivo de mayor jerarquia", 1, "business", domain.ErrForbiddenUserManagement},
+		{"objetivo con rol de plataforma", 4, "platform", domain.ErrForbiddenUserManagement},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.nombre, func(t *testing.T) {
+			uc := buildLoginUseCase(repoGestionUsuariosConObjetivo(2, true, tc.nivel, tc.scope), nil, nil)
+			err := uc.authorizeUserManagement(context.Background(), admin, 77)
+			if tc.esperado == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.esperado)
+			}
+		})
+	}
+}
+
+func TestResetUserTwoFactor_BorraYCierraSesiones(t *testing.T) {
+	borrado := false
+	var motivo string
+	repo := repoGestionUsuarios(2, true)
+	repo.DeleteTwoFactorFn = func(ctx context.Context, userID uint) error {
+		borrado = true
+		return nil
+	}
+	repo.RevokeUserSessionsFn = func(ctx context.Context, userID uint, exceptSessionID string, revokedByID uint, reason string) ([]string, error) {
+		motivo = reason
+		assert.Empty(t, exceptSessionID)
+		return []string{"sid-1"}, nil
+	}
+	uc := buildLoginUseCase(repo, nil, nil)
+
+	err := uc.ResetUserTwoFactor(context.Background(), domain.AuthActor{UserID: 1, BusinessID: 5, RoleID: 2}, 77)
+
+	require.NoError(t, err)
+	assert.True(t, borrado)
+	assert.Equal(t, sessionRevokedTwoFactorReset, motivo)
+}
+
+func TestDescribeDevice(t *testing.T) {
+	assert.Equal(t, "Safari en iOS", describeDevice("Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 Version/17.0 Mobile/15E148 Safari/604.1"))
+	assert.Equal(t, "Edge en Windows", describeDevice("Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/120.0 Safari/537.36 Edg/120.0"))
+	assert.Equal(t, "App", describeDevice("Dart/3.2 (dart:io)"))
+	assert.Equal(t, "Desconocido", describeDevice(""))
+}
@@ -0,0 +1,254 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/netguard"
+)
+
+var defaultSSOScopes = []string{"openid", "email", "profile"}
+
+// GetSSOConfig trae la configuración SSO del negocio que gestiona el actor
+func (uc *AuthUseCase) GetSSOConfig(ctx context.Context, actor domain.AuthActor, businessID uint) (*domain.BusinessSSOConfig, error) {
+	businessID, err := uc.resolveManagedBusiness(ctx, actor, businessID)
+	if err != nil {
+		return nil, err
+	}
+	config, err := uc.repository.GetSSOConfig(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil {
+		return nil, domain.ErrSSONotConfigured
+	}
+	if config.Domains, err = uc.ssoDomains(ctx, businessID); err != nil {
+		return nil, err
+	}
+	config.RedirectURI = uc.ssoRedirectURL()
+	return config, nil
+}
+
+// UpsertSSOConfig valida y guarda la configuración OIDC del negocio. Al
+// activarla se consulta el descubrimiento del IdP para fallar aquí y no en el
+// primer login, y se exige que cada dominio de email esté verificado: se
+// guarda primero apagada, se publica el TXT y se verifica (VerifySSODomain).
+func (uc *AuthUseCase) UpsertSSOConfig(ctx context.Context, actor domain.AuthActor, request domain.UpsertSSOConfigRequest) (*domain.BusinessSSOConfig, error) {
+	businessID, err := uc.resolveManagedBusiness(ctx, actor, request.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+	business, err := uc.repository.GetBusinessByID(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if business == nil {
+		return nil, fmt.Errorf("%w: el negocio %d no existe", domain.ErrSSOInvalidConfig, businessID)
+	}
+	current, err := uc.repository.GetSSOConfig(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &domain.BusinessSSOConfig{
+		BusinessID:           businessID,
+		Enabled:              request.Enabled,
+		ProviderName:         strings.TrimSpace(request.ProviderName),
+		Issuer:               strings.TrimRight(strings.TrimSpace(request.Issuer), "/"),
+		ClientID:             strings.TrimSpace(request.ClientID),
+		Scopes:               normalizeScopes(request.Scopes),
+		RoleClaim:            strings.TrimSpace(request.RoleClaim),
+		DefaultRoleID:        request.DefaultRoleID,
+		EmailDomains:         normalizeEmailDomains(request.EmailDomains),
+		AutoProvision:        request.AutoProvision,
+		DisablePasswordLogin: request.DisablePasswordLogin,
+	}
+	switch {
+	case request.ClientSecret != nil:
+		config.ClientSecret = strings.TrimSpace(*request.ClientSecret)
+	case current != nil:
+		config.ClientSecret = current.ClientSecret
+	}
+	if config.DefaultRoleID != nil && *config.DefaultRoleID == 0 {
+		config.DefaultRoleID = nil
+	}
+	for _, mapping := range request.RoleMappings {
+		config.RoleMappings = append(config.RoleMappings, domain.SSORoleMapping{
+			ClaimValue: strings.TrimSpace(mapping.ClaimValue),
+			RoleID:     mapping.RoleID,
+		})
+	}
+
+	if err := uc.validateSSOConfig(ctx, business, config); err != nil {
+		return nil, err
+	}
+	claims, err := uc.claimSSODomains(ctx, businessID, config.EmailDomains)
+	if err != nil {
+		return nil, err
+	}
+	if config.Enabled {
+		for _, claim := range claims {
+			if claim.VerifiedAt == nil {
+				return nil, fmt.Errorf("%w: publica el TXT %s con %s", domain.ErrSSODomainNotVerified, claim.TXTName, claim.TXTValue)
+			}
+		}
+	}
+
+	if config.Enabled {
+		if _, err := uc.oidc.Discover(ctx, config.Issuer); err != nil {
+			uc.log.Warn().Err(err).Uint("business_id", businessID).Msg("[SSO] El issuer no responde al descubrimiento")
+			return nil, fmt.Errorf("%w: %s/.well-known/openid-configuration", domain.ErrSSOProviderUnavailable, config.Issuer)
+		}
+	}
+
+	if err := uc.repository.UpsertSSOConfig(ctx, config); err != nil {
+		return nil, err
+	}
+	if err := uc.repository.ReplaceSSODomains(ctx, businessID, claims); err != nil {
+		return nil, err
+	}
+	config.Domains = claims
+	uc.log.Info().
+		Uint("business_id", businessID).
+		Uint("actor_id", actor.UserID).
+		Bool("enabled", config.Enabled).
+		Bool("disable_password_login", config.DisablePasswordLogin).
+		Msg("[SSO] Configuracion guardada")
+	config.RedirectURI = uc.ssoRedirectURL()
+	return config, nil
+}
+
+// DeleteSSOConfig borra la configuración; el negocio vuelve a entrar con contraseña
+func (uc *AuthUseCase) DeleteSSOConfig(ctx context.Context, actor domain.AuthActor, businessID uint) error {
+	businessID, err := uc.resolveManagedBusiness(ctx, actor, businessID)
+	if err != nil {
+		return err
+	}
+	current, err := uc.repository.GetSSOConfig(ctx, businessID)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return domain.ErrSSONotConfigured
+	}
+	if err := uc.repository.DeleteSSOConfig(ctx, businessID); err != nil {
+		return err
+	}
+	// Sin configuración el negocio suelta sus dominios
+	return uc.repository.ReplaceSSODomains(ctx, businessID, nil)
+}
+
+func (uc *AuthUseCase) validateSSOConfig(ctx context.Context, business *domain.BusinessInfo, config *domain.BusinessSSOConfig) error {
+	if err := uc.validateIssuer(ctx, config.Issuer); err != nil {
+		return err
+	}
+	if config.ClientID == "" {
+		return fmt.Errorf("%w: client_id es requerido", domain.ErrSSOInvalidConfig)
+	}
+	if len(config.RoleMappings) > 0 && config.RoleClaim == "" {
+		return fmt.Errorf("%w: role_claim es requerido para mapear roles", domain.ErrSSOInvalidConfig)
+	}
+	if config.Enabled && len(config.RoleMappings) == 0 && config.DefaultRoleID == nil {
+		return fmt.Errorf("%w: define role_mappings o default_role_id", domain.ErrSSOInvalidConfig)
+	}
+	if config.DisablePasswordLogin && !config.Enabled {
+		return fmt.Errorf("%w: solo se puede deshabilitar la contraseña con el SSO activo", domain.ErrSSOInvalidConfig)
+	}
+
+	roleIDs := make([]uint, 0, len(config.RoleMappings)+1)
+	for _, mapping := range config.RoleMappings {
+		if mapping.ClaimValue == "" {
+			return fmt.Errorf("%w: claim_value vacío en role_mappings", domain.ErrSSOInvalidConfig)
+		}
+		roleIDs = append(roleIDs, mapping.RoleID)
+	}
+	if config.DefaultRoleID != nil {
+		roleIDs = append(roleIDs, *config.DefaultRoleID)
+	}
+	for _, roleID := range roleIDs {
+		if err := uc.validateSSORole(ctx, business, roleID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateSSORole exige un rol de negocio (nunca de plataforma) que aplique
+// al tipo de negocio
+func (uc *AuthUseCase) validateSSORole(ctx context.Context, business *domain.BusinessInfo, roleID uint) error {
+	if roleID == 0 {
+		return domain.ErrSSOInvalidRole
+	}
+	role, err := uc.repository.GetRoleByID(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	if role == nil || role.ScopeCode == "platform" {
+		return fmt.Errorf("%w (role_id %d)", domain.ErrSSOInvalidRole, roleID)
+	}
+	if role.BusinessTypeID != 0 && role.BusinessTypeID != business.BusinessTypeID {
+		return fmt.Errorf("%w (role_id %d)", domain.ErrSSOInvalidRole, roleID)
+	}
+	return nil
+}
+
+// validateIssuer exige https salvo en localhost, donde corre el IdP de
+// pruebas, y que el host no resuelva a la red interna: el servidor consulta
+// el descubrimiento, el token y las llaves del issuer
+func (uc *AuthUseCase) validateIssuer(ctx context.Context, issuer string) error {
+	parsed, err := url.Parse(issuer)
+	if err != nil || parsed.Host == "" {
+		return fmt.Errorf("%w: issuer debe ser una URL", domain.ErrSSOInvalidConfig)
+	}
+	host := parsed.Hostname()
+	ip := net.ParseIP(host)
+	if parsed.Scheme != "https" && !(parsed.Scheme == "http" && (host == "localhost" || (ip != nil && ip.IsLoopback()))) {
+		return fmt.Errorf("%w: issuer debe usar https", domain.ErrSSOInvalidConfig)
+	}
+	if err := uc.guard.CheckHost(ctx, host); err != nil {
+		if errors.Is(err, netguard.ErrBlockedAddress) {
+			return fmt.Errorf("%w: issuer apunta a una direccion interna", domain.ErrSSOInvalidConfig)
+		}
+		return fmt.Errorf("%w: issuer no resuelve: %v", domain.ErrSSOInvalidConfig, err)
+	}
+	return nil
+}
+
+func normalizeScopes(scopes []string) []string {
+	if len(scopes) == 0 {
+		return append([]string(nil), defaultSSOScopes...)
+	}
+	normalized := []string{"openid"}
+	seen := map[string]bool{"openid": true}
+	for _, scope := range scopes {
+		for _, s := range strings.Fields(scope) {
+			if !seen[s] {
+				seen[s] = true
+				normalized = append(normalized, s)
+			}
+		}
+	}
+	return normalized
+}
+
+func normalizeEmailDomain(d string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(d), "@"))
+}
+
+func normalizeEmailDomains(domains []string) []string {
+	normalized := make([]string, 0, len(domains))
+	seen := map[string]bool{}
+	for _, d := range domains {
+		d = normalizeEmailDomain(d)
+		if d != "" && !seen[d] {
+			seen[d] = true
+			normalized = append(normalized, d)
+		}
+	}
+	return normalized
+}
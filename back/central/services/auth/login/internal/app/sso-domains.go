@@ -0,0 +1,150 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+)
+
+const (
+	// El negocio publica ssoTXTValuePrefix+token en el TXT ssoTXTNamePrefix+dominio
+	ssoTXTNamePrefix  = "_probability-sso."
+	ssoTXTValuePrefix = "probability-sso-verification="
+)
+
+// publicEmailDomains son proveedores de correo abiertos: nadie los controla
+// como empresa, así que no pueden apuntar al IdP de un negocio
+var publicEmailDomains = map[string]bool{
+	"gmail.com": true, "googlemail.com": true,
+	"outlook.com": true, "outlook.es": true, "hotmail.com": true, "hotmail.es": true,
+	"hotmail.co": true, "live.com": true, "live.com.mx": true, "msn.com": true,
+	"yahoo.com": true, "yahoo.es": true, "yahoo.com.co": true, "yahoo.com.mx": true, "ymail.com": true,
+	"icloud.com": true, "me.com": true, "mac.com": true,
+	"aol.com": true, "protonmail.com": true, "proton.me": true,
+	"gmx.com": true, "gmx.net": true, "mail.com": true, "zoho.com": true,
+	"yandex.com": true, "tutanota.com": true,
+}
+
+// VerifySSODomain busca el registro TXT del dominio y, si trae el token del
+// negocio, lo marca como verificado
+func (uc *AuthUseCase) VerifySSODomain(ctx context.Context, actor domain.AuthActor, businessID uint, emailDomain string) (*domain.SSODomain, error) {
+	businessID, err := uc.resolveManagedBusiness(ctx, actor, businessID)
+	if err != nil {
+		return nil, err
+	}
+	emailDomain = normalizeEmailDomain(emailDomain)
+
+	claims, err := uc.repository.ListSSODomains(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	var claim *domain.SSODomain
+	for i := range claims {
+		if claims[i].Domain == emailDomain {
+			claim = &claims[i]
+		}
+	}
+	if claim == nil {
+		return nil, domain.ErrSSODomainNotFound
+	}
+	withTXTRecord(claim)
+	if claim.VerifiedAt != nil {
+		return claim, nil
+	}
+
+	records, err := uc.dns.LookupTXT(ctx, claim.TXTName)
+	if err != nil {
+		uc.log.Warn().Err(err).Str("domain", emailDomain).Msg("[SSO] No se pudo consultar el TXT de verificacion")
+		return nil, domain.ErrSSODomainVerifyFailed
+	}
+	found := false
+	for _, record := range records {
+		if strings.TrimSpace(record) == claim.TXTValue {
+			found = true
+		}
+	}
+	if !found {
+		return nil, domain.ErrSSODomainVerifyFailed
+	}
+
+	now := time.Now()
+	if err := uc.repository.MarkSSODomainVerified(ctx, businessID, emailDomain, now); err != nil {
+		return nil, err
+	}
+	claim.VerifiedAt = &now
+	uc.log.Info().Uint("business_id", businessID).Uint("actor_id", actor.UserID).Str("domain", emailDomain).Msg("[SSO] Dominio verificado")
+	return claim, nil
+}
+
+// claimSSODomains valida los dominios de la configuración contra los ya
+// reclamados: rechaza correo público y dominios verificados por otro negocio,
+// conserva el token de los que ya estaban y genera uno para los nuevos.
+func (uc *AuthUseCase) claimSSODomains(ctx context.Context, businessID uint, emailDomains []string) ([]domain.SSODomain, error) {
+	current, err := uc.repository.ListSSODomains(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	byDomain := make(map[string]domain.SSODomain, len(current))
+	for _, d := range current {
+		byDomain[d.Domain] = d
+	}
+
+	claims := make([]domain.SSODomain, 0, len(emailDomains))
+	for _, d := range emailDomains {
+		if publicEmailDomains[d] {
+			return nil, fmt.Errorf("%w: %s", domain.ErrSSOPublicEmailDomain, d)
+		}
+		if !strings.Contains(d, ".") || strings.ContainsAny(d, " /@:") {
+			return nil, fmt.Errorf("%w: dominio inválido %q", domain.ErrSSOInvalidConfig, d)
+		}
+		taken, err := uc.repository.SSODomainVerifiedByOther(ctx, d, businessID)
+		if err != nil {
+			return nil, err
+		}
+		if taken {
+			return nil, fmt.Errorf("%w: %s", domain.ErrSSODomainTaken, d)
+		}
+
+		claim, ok := byDomain[d]
+		if !ok {
+			token, err := newDomainToken()
+			if err != nil {
+				return nil, err
+			}
+			claim = domain.SSODomain{Domain: d, VerificationToken: token}
+		}
+		withTXTRecord(&claim)
+		claims = append(claims, claim)
+	}
+	return claims, nil
+}
+
+// ssoDomains trae el estado de verificación de los dominios del negocio
+func (uc *AuthUseCase) ssoDomains(ctx context.Context, businessID uint) ([]domain.SSODomain, error) {
+	claims, err := uc.repository.ListSSODomains(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range claims {
+		withTXTRecord(&claims[i])
+	}
+	return claims, nil
+}
+
+func withTXTRecord(claim *domain.SSODomain) {
+	claim.TXTName = ssoTXTNamePrefix + claim.Domain
+	claim.TXTValue = ssoTXTValuePrefix + claim.VerificationToken
+}
+
+func newDomainToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,224 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// resolveSSOUser encuentra (o crea) el usuario de la identidad del IdP y le
+// deja en el negocio el rol que indica el mapeo de claims.
+//
+// Un usuario que ya existe con ese email solo se vincula si ya trabaja en el
+// negocio: así el IdP de un negocio no puede tomar cuentas de otro. Nunca se
+// vincula a alguien con un rol de plataforma: el IdP de un negocio no puede
+// abrir una sesión de super admin (ni saltarse su segundo factor).
+func (uc *AuthUseCase) resolveSSOUser(ctx context.Context, config *domain.BusinessSSOConfig, identity *domain.OIDCIdentity) (*domain.UserAuthInfo, error) {
+	email := strings.ToLower(strings.TrimSpace(identity.Email))
+	if emailDomainOf(email) == "" {
+		return nil, domain.ErrSSOEmailMissing
+	}
+	// Sin el claim email_verified el IdP no responde por el correo, y con él se
+	// crea o vincula la cuenta
+	if identity.EmailVerified == nil || !*identity.EmailVerified {
+		return nil, domain.ErrSSOEmailNotVerified
+	}
+	if !emailDomainAllowed(config.EmailDomains, email) {
+		return nil, domain.ErrSSOEmailDomainNotAllowed
+	}
+
+	roleID := ssoRole(config, identity.Claims)
+	if roleID == 0 {
+		uc.log.Warn().Uint("business_id", config.BusinessID).Str("email", email).Msg("[SSO] Ningun claim coincide con el mapeo de roles")
+		return nil, domain.ErrSSONoRoleMapped
+	}
+
+	link, err := uc.repository.GetSSOIdentity(ctx, config.BusinessID, identity.Issuer, identity.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *domain.UserAuthInfo
+	provisioned := false
+	if link != nil {
+		user, err = uc.repository.GetUserByID(ctx, link.UserID)
+	} else {
+		user, err = uc.repository.GetUserByEmail(ctx, email)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		if link != nil {
+			return nil, domain.ErrUserNotFound
+		}
+		if !config.AutoProvision {
+			return nil, domain.ErrSSOUserNotProvisioned
+		}
+		user, err = uc.provisionSSOUser(ctx, config, identity, email, roleID)
+		if err != nil {
+			return nil, err
+		}
+		provisioned = true
+	}
+
+	if !user.IsActive {
+		return nil, domain.ErrUserInactive
+	}
+
+	if !provisioned {
+		roles, err := uc.repository.GetUserRoles(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if isSuperAdmin(roles) {
+			uc.log.Warn().Uint("user_id", user.ID).Uint("business_id", config.BusinessID).Msg("[SSO] Usuario de plataforma rechazado")
+			return nil, domain.ErrSSOPlatformUser
+		}
+
+		if err := uc.syncSSORole(ctx, config, user.ID, roleID, link != nil); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.repository.SaveSSOIdentity(ctx, &domain.SSOIdentity{
+		UserID:     user.ID,
+		BusinessID: config.BusinessID,
+		Issuer:     identity.Issuer,
+		Subject:    identity.Subject,
+		Email:      email,
+	}, time.Now()); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// syncSSORole aplica en cada login el rol que el IdP le da al usuario
+func (uc *AuthUseCase) syncSSORole(ctx context.Context, config *domain.BusinessSSOConfig, userID, roleID uint, linked bool) error {
+	businessID := config.BusinessID
+	relation, err := uc.repository.GetBusinessStaffRelation(ctx, userID, &businessID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case relation == nil && !linked:
+		return domain.ErrSSOAccountConflict
+	case relation == nil && !config.AutoProvision:
+		return domain.ErrSSOUserNotProvisioned
+	case relation != nil && relation.RoleID != nil && *relation.RoleID == roleID:
+		return nil
+	}
+
+	uc.log.Info().Uint("user_id", userID).Uint("business_id", businessID).Uint("role_id", roleID).Msg("[SSO] Rol sincronizado desde el IdP")
+	return uc.repository.AssignBusinessRole(ctx, userID, businessID, roleID)
+}
+
+// provisionSSOUser crea el usuario en su primer login. La contraseña es
+// aleatoria y nadie la conoce: entra por SSO o la restablece si el negocio
+// vuelve a permitir contraseñas.
+func (uc *AuthUseCase) provisionSSOUser(ctx context.Context, config *domain.BusinessSSOConfig, identity *domain.OIDCIdentity, email string, roleID uint) (*domain.UserAuthInfo, error) {
+	password, err := randomURLToken(32)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(identity.Name)
+	if name == "" {
+		name = email[:strings.Index(email, "@")]
+	}
+
+	userID, err := uc.repository.ProvisionSSOUser(ctx, domain.SSOProvisionUser{
+		Name:         truncate(name, 255),
+		Email:        email,
+		PasswordHash: string(hash),
+		BusinessID:   config.BusinessID,
+		RoleID:       roleID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error al crear el usuario: %w", err)
+	}
+	uc.log.Info().Uint("user_id", userID).Uint("business_id", config.BusinessID).Msg("[SSO] Usuario aprovisionado en su primer login")
+
+	user, err := uc.repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// ssoRole toma el primer mapeo (en el orden configurado) cuyo valor venga en
+// el claim de roles; sin coincidencias usa el rol por defecto
+func ssoRole(config *domain.BusinessSSOConfig, claims map[string]any) uint {
+	values := claimValues(claims, config.RoleClaim)
+	for _, mapping := range config.RoleMappings {
+		for _, value := range values {
+			if strings.EqualFold(value, mapping.ClaimValue) {
+				return mapping.RoleID
+			}
+		}
+	}
+	if config.DefaultRoleID != nil {
+		return *config.DefaultRoleID
+	}
+	return 0
+}
+
+// claimValues lee un claim de texto o lista. Acepta rutas con punto para
+// claims anidados, p.ej. realm_access.roles de Keycloak.
+func claimValues(claims map[string]any, path string) []string {
+	if path == "" {
+		return nil
+	}
+
+	var current any = claims
+	for _, part := range strings.Split(path, ".") {
+		obj, ok := current.(map[string]any)
+		if !ok {
+			return nil
+		}
+		current = obj[part]
+	}
+
+	switch v := current.(type) {
+	case string:
+		return []string{v}
+	case []string:
+		return v
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func emailDomainAllowed(domains []string, email string) bool {
+	if len(domains) == 0 {
+		return true
+	}
+	emailDomain := emailDomainOf(email)
+	for _, allowed := range domains {
+		if strings.EqualFold(allowed, emailDomain) {
+			return true
+		}
+	}
+	return false
+}
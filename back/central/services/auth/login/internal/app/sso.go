@@ -0,0 +1,258 @@
+package app
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+)
+
+const (
+	// Tiempo para volver del IdP con el code antes de que el state expire
+	ssoStateTTL = 10 * time.Minute
+	// Página del frontend que recibe el code cuando no hay SSO_REDIRECT_URL
+	ssoCallbackPath = "/auth/sso/callback"
+)
+
+// DiscoverSSO le indica a la pantalla de login si el negocio (por código o
+// por el dominio del email) entra con su proveedor corporativo
+func (uc *AuthUseCase) DiscoverSSO(ctx context.Context, request domain.SSODiscoverRequest) (*domain.SSODiscovery, error) {
+	var (
+		config   *domain.BusinessSSOConfig
+		business *domain.BusinessInfo
+		err      error
+	)
+
+	if code := strings.TrimSpace(request.BusinessCode); code != "" {
+		business, err = uc.repository.GetBusinessByCode(ctx, code)
+		if err != nil {
+			return nil, err
+		}
+		if business == nil {
+			return nil, domain.ErrSSONotConfigured
+		}
+		config, err = uc.repository.GetSSOConfig(ctx, business.ID)
+	} else {
+		emailDomain := emailDomainOf(request.Email)
+		if emailDomain == "" {
+			return nil, domain.ErrSSONotConfigured
+		}
+		config, err = uc.repository.FindSSOConfigByEmailDomain(ctx, emailDomain)
+		if err == nil && config != nil {
+			business, err = uc.repository.GetBusinessByID(ctx, config.BusinessID)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if config == nil || !config.Enabled || business == nil {
+		return nil, domain.ErrSSONotConfigured
+	}
+
+	return &domain.SSODiscovery{
+		BusinessCode:          business.Code,
+		BusinessName:          business.Name,
+		ProviderName:          config.ProviderName,
+		PasswordLoginDisabled: config.DisablePasswordLogin,
+	}, nil
+}
+
+// StartSSO arma la URL de autorización del IdP (authorization code + PKCE) y
+// guarda el state, el nonce y el code_verifier hasta que el usuario vuelva
+func (uc *AuthUseCase) StartSSO(ctx context.Context, businessCode string) (string, error) {
+	business, err := uc.repository.GetBusinessByCode(ctx, strings.TrimSpace(businessCode))
+	if err != nil {
+		return "", err
+	}
+	if business == nil {
+		return "", domain.ErrSSONotConfigured
+	}
+	config, err := uc.enabledSSOConfig(ctx, business.ID)
+	if err != nil {
+		return "", err
+	}
+
+	redirectURI := uc.ssoRedirectURL()
+	if redirectURI == "" {
+		return "", domain.ErrSSORedirectNotConfigured
+	}
+
+	provider, err := uc.oidc.Discover(ctx, config.Issuer)
+	if err != nil {
+		uc.log.Error().Err(err).Uint("business_id", business.ID).Msg("[SSO] Error en el descubrimiento OIDC")
+		return "", domain.ErrSSOProviderUnavailable
+	}
+
+	state, err := randomURLToken(32)
+	if err != nil {
+		return "", err
+	}
+	nonce, err := randomURLToken(32)
+	if err != nil {
+		return "", err
+	}
+	verifier, err := randomURLToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	if err := uc.repository.CreateSSOLoginState(ctx, &domain.SSOLoginState{
+		StateHash:    hashSSOState(state),
+		BusinessID:   business.ID,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+		RedirectURI:  redirectURI,
+		ExpiresAt:    time.Now().Add(ssoStateTTL),
+	}); err != nil {
+		uc.log.Error().Err(err).Uint("business_id", business.ID).Msg("[SSO] Error guardando el state")
+		return "", fmt.Errorf("error interno del servidor")
+	}
+
+	authURL, err := url.Parse(provider.AuthorizationEndpoint)
+	if err != nil {
+		return "", domain.ErrSSOProviderUnavailable
+	}
+	query := authURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", config.ClientID)
+	query.Set("redirect_uri", redirectURI)
+	query.Set("scope", strings.Join(config.Scopes, " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", pkceChallenge(verifier))
+	query.Set("code_challenge_method", "S256")
+	authURL.RawQuery = query.Encode()
+
+	uc.log.Info().Uint("business_id", business.ID).Msg("[SSO] Login corporativo iniciado")
+	return authURL.String(), nil
+}
+
+// CompleteSSOLogin canjea el code, verifica el id_token y entrega nuestro JWT
+// como cualquier login. No pide el TOTP propio: el segundo factor lo exige el
+// IdP del negocio.
+func (uc *AuthUseCase) CompleteSSOLogin(ctx context.Context, request domain.SSOCallbackRequest) (*domain.LoginResponse, error) {
+	if request.State == "" || request.Code == "" {
+		return nil, domain.ErrSSOStateInvalid
+	}
+
+	state, err := uc.repository.ConsumeSSOLoginState(ctx, hashSSOState(request.State), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, domain.ErrSSOStateInvalid
+	}
+
+	config, err := uc.enabledSSOConfig(ctx, state.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+
+	provider, err := uc.oidc.Discover(ctx, config.Issuer)
+	if err != nil {
+		uc.log.Error().Err(err).Uint("business_id", state.BusinessID).Msg("[SSO] Error en el descubrimiento OIDC")
+		return nil, domain.ErrSSOProviderUnavailable
+	}
+
+	rawIDToken, err := uc.oidc.ExchangeCode(ctx, provider, domain.OIDCCodeExchange{
+		ClientID:     config.ClientID,
+		ClientSecret: config.ClientSecret,
+		Code:         request.Code,
+		CodeVerifier: state.CodeVerifier,
+		RedirectURI:  state.RedirectURI,
+	})
+	if err != nil {
+		uc.log.Error().Err(err).Uint("business_id", state.BusinessID).Msg("[SSO] Error canjeando el code")
+		return nil, domain.ErrSSOProviderUnavailable
+	}
+
+	identity, err := uc.oidc.VerifyIDToken(ctx, provider, rawIDToken, config.ClientID)
+	if err != nil {
+		uc.log.Warn().Err(err).Uint("business_id", state.BusinessID).Msg("[SSO] id_token rechazado")
+		return nil, domain.ErrSSOIdentityInvalid
+	}
+	if identity.Subject == "" || subtle.ConstantTimeCompare([]byte(identity.Nonce), []byte(state.Nonce)) != 1 {
+		uc.log.Warn().Uint("business_id", state.BusinessID).Msg("[SSO] id_token sin sub o con nonce distinto")
+		return nil, domain.ErrSSOIdentityInvalid
+	}
+
+	userAuth, err := uc.resolveSSOUser(ctx, config, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := uc.repository.GetUserRoles(ctx, userAuth.ID)
+	if err != nil {
+		uc.log.Error().Err(err).Uint("user_id", userAuth.ID).Msg("Error al obtener roles del usuario")
+		return nil, fmt.Errorf("error interno del servidor")
+	}
+
+	response, err := uc.completeLogin(ctx, userAuth, roles, request.Client, config.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+	// La contraseña la gestiona el IdP: no hay cambio de contraseña pendiente
+	response.RequirePasswordChange = false
+
+	uc.log.Info().Uint("user_id", userAuth.ID).Uint("business_id", config.BusinessID).Msg("[SSO] Login corporativo exitoso")
+	return response, nil
+}
+
+func (uc *AuthUseCase) enabledSSOConfig(ctx context.Context, businessID uint) (*domain.BusinessSSOConfig, error) {
+	config, err := uc.repository.GetSSOConfig(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if config == nil || !config.Enabled {
+		return nil, domain.ErrSSONotConfigured
+	}
+	return config, nil
+}
+
+// ssoRedirectURL es la página del frontend registrada en el IdP como
+// redirect_uri; esa página envía el code y el state a /auth/sso/callback
+func (uc *AuthUseCase) ssoRedirectURL() string {
+	if redirect := strings.TrimSpace(uc.env.Get("SSO_REDIRECT_URL")); redirect != "" {
+		return redirect
+	}
+	base := strings.TrimRight(strings.TrimSpace(uc.env.Get("FRONTEND_BASE_URL")), "/")
+	if base == "" {
+		return ""
+	}
+	return base + ssoCallbackPath
+}
+
+func randomURLToken(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// pkceChallenge es el code_challenge S256 del RFC 7636
+func pkceChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func hashSSOState(state string) string {
+	sum := sha256.Sum256([]byte(state))
+	return hex.EncodeToString(sum[:])
+}
+
+func emailDomainOf(email string) string {
+	at := strings.LastIndex(email, "@")
+	if at < 0 || at == len(email)-1 {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(email[at+1:]))
+}
@@ -0,0 +1,742 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const issuerDePrueba = "https://idp.test.com"
+
+func configSSO() *domain.BusinessSSOConfig {
+	defaultRole := uint(6)
+	return &domain.BusinessSSOConfig{
+		ID:            1,
+		BusinessID:    36,
+		Enabled:       true,
+		ProviderName:  "Okta",
+		Issuer:        issuerDePrueba,
+		ClientID:      "cliente",
+		ClientSecret:  "secreto",
+		Scopes:        []string{"openid", "email", "profile"},
+		RoleClaim:     "groups",
+		RoleMappings:  []domain.SSORoleMapping{{ClaimValue: "admins", RoleID: 2}, {ClaimValue: "ventas", RoleID: 5}},
+		DefaultRoleID: &defaultRole,
+		AutoProvision: true,
+	}
+}
+
+func identidadSSO(nonce string) *domain.OIDCIdentity {
+	verificado := true
+	return &domain.OIDCIdentity{
+		Issuer:        issuerDePrueba,
+		Subject:       "sub-123",
+		Email:         "Ana@Empresa.com",
+		EmailVerified: &verificado,
+		Name:          "Ana Pérez",
+		Nonce:         nonce,
+		Claims:        map[string]any{"groups": []any{"ventas"}},
+	}
+}
+
+func stateSSO() *domain.SSOLoginState {
+	return &domain.SSOLoginState{
+		BusinessID:   36,
+		Nonce:        "nonce-1",
+		CodeVerifier: "verifier-1",
+		RedirectURI:  "https://app.test.com/auth/sso/callback",
+		ExpiresAt:    time.Now().Add(time.Minute),
+	}
+}
+
+// repoSSO arma un repositorio con un state válido y la config del negocio 36
+func repoSSO() *mocks.AuthRepositoryMock {
+	return &mocks.AuthRepositoryMock{
+		ConsumeSSOLoginStateFn: func(ctx context.Context, stateHash string, now time.Time) (*domain.SSOLoginState, error) {
+			return stateSSO(), nil
+		},
+		GetSSOConfigFn: func(ctx context.Context, businessID uint) (*domain.BusinessSSOConfig, error) {
+			return configSSO(), nil
+		},
+		GetUserRolesFn: func(ctx context.Context, userID uint) ([]domain.Role, error) {
+			return []domain.Role{rolNegocio()}, nil
+		},
+		GetUserBusinessesFn: func(ctx context.Context, userID uint) ([]domain.BusinessInfoEntity, error) {
+			return []domain.BusinessInfoEntity{negocio(99), negocio(36)}, nil
+		},
+	}
+}
+
+func buildSSOUseCase(repo *mocks.AuthRepositoryMock, oidc *mocks.OIDCClientMock, jwt *mocks.JWTServiceMock) *AuthUseCase {
+	uc := buildLoginUseCase(repo, jwt, map[string]string{"FRONTEND_BASE_URL": "https://app.test.com/"})
+	if oidc == nil {
+		oidc = &mocks.OIDCClientMock{}
+	}
+	uc.oidc = oidc
+	uc.dns = &mocks.DNSResolverMock{}
+	return uc
+}
+
+// dominioVerificado es un dominio ya verificado por el negocio de prueba
+func dominioVerificado(d string) domain.SSODomain {
+	verificado := time.Now().Add(-time.Hour)
+	return domain.SSODomain{Domain: d, VerificationToken: "token-" + d, VerifiedAt: &verificado}
+}
+
+func oidcConIdentidad(identity *domain.OIDCIdentity) *mocks.OIDCClientMock {
+	return &mocks.OIDCClientMock{
+		VerifyIDTokenFn: func(ctx context.Context, provider *domain.OIDCProviderMetadata, rawIDToken, clientID string) (*domain.OIDCIdentity, error) {
+			return identity, nil
+		},
+	}
+}
+
+func TestStartSSO_ArmaURLConPKCEYGuardaElState(t *testing.T) {
+	var guardado *domain.SSOLoginState
+	repo := &mocks.AuthRepositoryMock{
+		GetBusinessByCodeFn: func(ctx context.Context, code string) (*domain.BusinessInfo, error) {
+			return &domain.BusinessInfo{ID: 36, Code: code}, nil
+		},
+		GetSSOConfigFn: func(ctx context.Context, businessID uint) (*domain.BusinessSSOConfig, error) {
+			return configSSO(), nil
+		},
+		CreateSSOLoginStateFn: func(ctx context.Context, state *domain.SSOLoginState) error {
+			guardado = state
+			return nil
+		},
+	}
+	uc := buildSSOUseCase(repo, nil, nil)
+
+	got, err := uc.StartSSO(context.Background(), " MT ")
+
+	require.NoError(t, err)
+	parsed, err := url.Parse(got)
+	require.NoError(t, err)
+	assert.Equal(t, issuerDePrueba+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+
+	q := parsed.Query()
+	assert.Equal(t, "code", q.Get("response_type"))
+	assert.Equal(t, "cliente", q.Get("client_id"))
+	assert.Equal(t, "openid email profile", q.Get("scope"))
+	assert.Equal(t, "https://app.test.com/auth/sso/callback", q.Get("redirect_uri"))
+	assert.Equal(t, "S256", q.Get("code_challenge_method"))
+
+	require.NotNil(t, guardado)
+	assert.Equal(t, uint(36), guardado.BusinessID)
+	assert.Equal(t, hashSSOState(q.Get("state")), guardado.StateHash, "solo se guarda el hash del state")
+	assert.Equal(t, guardado.Nonce, q.Get("nonce"))
+	assert.Equal(t, pkceChallenge(guardado.CodeVerifier), q.Get("code_challenge"))
+	assert.NotContains(t, got, guardado.CodeVerifier, "el code_verifier nunca sale hacia el IdP")
+}
+
+func TestStartSSO_RedirectURLExplicitaTienePrioridad(t *testing.T) {
+	repo := &mocks.AuthRepositoryMock{
+		GetBusinessByCodeFn: func(ctx context.Context, code string) (*domain.BusinessInfo, error) {
+			return &domain.BusinessInfo{ID: 36}, nil
+		},
+		GetSSOConfigFn: func(ctx context.Context, businessID uint) (*domain.BusinessSSOConfig, error) {
+			return configSSO(), nil
+		},
+	}
+	uc := buildSSOUseCase(repo, nil, nil)
+	uc.env = &configStub{valores: map[string]string{
+		"FRONTEND_BASE_URL": "https://app.test.com",
+		"SSO_REDIRECT_URL":  "https://sso.test.com/callback",
+	}}
+
+	got, err := uc.StartSSO(context.Background(), "MT")
+
+	require.NoError(t, err)
+	parsed, _ := url.Parse(got)
+	assert.Equal(t, "https://sso.test.com/callback", parsed.Query().Get("redirect_uri"))
+}
+
+func TestStartSSO_NegocioSinSSOActivo_RetornaNoConfigurado(t *testing.T) {
+	repo := &mocks.AuthRepositoryMock{
+		GetBusinessByCodeFn: func(ctx context.Context, code string) (*domain.BusinessInfo, error) {
+			return &domain.BusinessInfo{ID: 36}, nil
+		},
+		GetSSOConfigFn: func(ctx context.Context, businessID uint) (*domain.BusinessSSOConfig, error) {
+			config := configSSO()
+			config.Enabled = false
+			return config, nil
+		},
+	}
+	uc := buildSSOUseCase(repo, nil, nil)
+
+	_, err := uc.StartSSO(context.Background(), "MT")
+
+	assert.ErrorIs(t, err, domain.ErrSSONotConfigured)
+}
+
+func TestCompleteSSOLogin_PrimerLogin_AprovisionaYEmiteTokenDelNegocio(t *testing.T) {
+	var (
+		canje           domain.OIDCCodeExchange
+		aprovisionado   domain.SSOProvisionUser
+		vinculo         *domain.SSOIdentity
+		tokenBusinessID uint
+	)
+	repo := repoSSO()
+	repo.GetUserByEmailFn = func(ctx context.Context, email string) (*domain.UserAuthInfo, error) {
+		return nil, nil
+	}
+	repo.ProvisionSSOUserFn = func(ctx context.Context, user domain.SSOProvisionUser) (uint, error) {
+		aprovisionado = user
+		return 77, nil
+	}
+	repo.GetUserByIDFn = func(ctx context.Context, userID uint) (*domain.UserAuthInfo, error) {
+		return &domain.UserAuthInfo{ID: userID, Email: "ana@empresa.com", IsActive: true}, nil
+	}
+	repo.SaveSSOIdentityFn = func(ctx context.Context, identity *domain.SSOIdentity, loginAt time.Time) error {
+		vinculo = identity
+		return nil
+	}
+	oidc := oidcConIdentidad(identidadSSO("nonce-1"))
+	oidc.ExchangeCodeFn = func(ctx context.Context, provider *domain.OIDCProviderMetadata, exchange domain.OIDCCodeExchange) (string, error) {
+		canje = exchange
+		return "id-token", nil
+	}
+	jwt := &mocks.JWTServiceMock{
+		GenerateSessionTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error) {
+			tokenBusinessID = businessID
+			return "token", nil
+		},
+	}
+	uc := buildSSOUseCase(repo, oidc, jwt)
+
+	got, err := uc.CompleteSSOLogin(context.Background(), domain.SSOCallbackRequest{State: "state", Code: "code"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "token", got.Token)
+	assert.False(t, got.RequirePasswordChange, "la contraseña la gestiona el IdP")
+	assert.Equal(t, uint(36), tokenBusinessID, "el token apunta al negocio del SSO aunque no sea el primero")
+
+	assert.Equal(t, "code", canje.Code)
+	assert.Equal(t, "verifier-1", canje.CodeVerifier)
+	assert.Equal(t, "https://app.test.com/auth/sso/callback", canje.RedirectURI)
+	assert.Equal(t, "secreto", canje.ClientSecret)
+
+	assert.Equal(t, "ana@empresa.com", aprovisionado.Email, "el email se normaliza")
+	assert.Equal(t, "Ana Pérez", aprovisionado.Name)
+	assert.Equal(t, uint(36), aprovisionado.BusinessID)
+	assert.Equal(t, uint(5), aprovisionado.RoleID, "el grupo ventas mapea al rol 5")
+	assert.True(t, strings.HasPrefix(aprovisionado.PasswordHash, "$2"), "se guarda un hash bcrypt de una contraseña aleatoria")
+
+	require.NotNil(t, vinculo)
+	assert.Equal(t, uint(77), vinculo.UserID)
+	assert.Equal(t, "sub-123", vinculo.Subject)
+}
+
+func TestCompleteSSOLogin_StateInvalido_Rechaza(t *testing.T) {
+	repo := repoSSO()
+	repo.ConsumeSSOLoginStateFn = func(ctx context.Context, stateHash string, now time.Time) (*domain.SSOLoginState, error) {
+		return nil, nil
+	}
+	canjeo := false
+	oidc := &mocks.OIDCClientMock{
+		ExchangeCodeFn: func(ctx context.Context, provider *domain.OIDCProviderMetadata, exchange domain.OIDCCodeExchange) (string, error) {
+			canjeo = true
+			return "", nil
+		},
+	}
+	uc := buildSSOUseCase(repo, oidc, nil)
+
+	_, err := uc.CompleteSSOLogin(context.Background(), domain.SSOCallbackRequest{State: "usado", Code: "code"})
+
+	assert.ErrorIs(t, err, domain.ErrSSOStateInvalid)
+	assert.False(t, canjeo, "sin state válido no se canjea el code")
+}
+
+func TestCompleteSSOLogin_NonceDistinto_Rechaza(t *testing.T) {
+	uc := buildSSOUseCase(repoSSO(), oidcConIdentidad(identidadSSO("otro-nonce")), nil)
+
+	_, err := uc.CompleteSSOLogin(context.Background(), domain.SSOCallbackRequest{State: "state", Code: "code"})
+
+	assert.ErrorIs(t, err, domain.ErrSSOIdentityInvalid)
+}
+
+func TestCompleteSSOLogin_FallaElCanje_ProveedorNoDisponible(t *testing.T) {
+	oidc := &mocks.OIDCClientMock{
+		ExchangeCodeFn: func(ctx context.Context, provider *domain.OIDCProviderMetadata, exchange domain.OIDCCodeExchange) (string, error) {
+			return "", errors.New("invalid_grant")
+		},
+	}
+	uc := buildSSOUseCase(repoSSO(), oidc, nil)
+
+	_, err := uc.CompleteSSOLogin(context.Background(), domain.SSOCallbackRequest{State: "state", Code: "code"})
+
+	assert.ErrorIs(t, err, domain.ErrSSOProviderUnavailable)
+}
+
+func TestCompleteSSOLogin_EmailNoVerificado_Rechaza(t *testing.T) {
+	identidad := identidadSSO("nonce-1")
+	noVerificado := false
+	identidad.EmailVerified = &noVerificado
+	uc := buildSSOUseCase(repoSSO(), oidcConIdentidad(identidad), nil)
+
+	_, err := uc.CompleteSSOLogin(context.Background(), domain.SSOCallbackRequest{State: "state", Code: "code"})
+
+	assert.ErrorIs(t, err, domain.ErrSSOEmailNotVerified)
+}
+
+func TestCompleteSSOLogin_SinClaimEmailVerified_Rechaza(t *testing.T) {
+	identidad := identidadSSO("nonce-1")
+	identidad.EmailVerified = nil
+	uc := buildSSOUseCase(repoSSO(), oidcConIdentidad(identidad), nil)
+
+	_, err := uc.CompleteSSOLogin(context.Background(), domain.SSOCallbackRequest{State: "state", Code: "code"})
+
+	assert.ErrorIs(t, err, domain.ErrSSOEmailNotVerified)
+}
+
+func TestCompleteSSOLogin_DominioNoPermitido_Rechaza(t *testing.T) {
+	repo := repoSSO()
+	repo.GetSSOConfigFn = func(ctx context.Context, businessID uint) (*domain.BusinessSSOConfig, error) {
+		config := configSSO()
+		config.EmailDomains = []string{"otra.com"}
+		return config, nil
+	}
+	uc := buildSSOUseCase(repo, oidcConIdentidad(identidadSSO("nonce-1")), nil)
+
+	_, err := uc.CompleteSSOLogin(context.Background(), domain.SSOCallbackRequest{State: "state", Code: "code"})
+
+	assert.ErrorIs(t, err, domain.ErrSSOEmailDomainNotAllowed)
+}
+
+func TestCompleteSSOLogin_UsuarioDeOtroNegocio_NoSeVincula(t *testing.T) {
+	asignado := false
+	repo := repoSSO()
+	repo.GetUserByEmailFn = func(ctx context.Context, email string) (*domain.UserAuthInfo, error) {
+		return &domain.UserAuthInfo{ID: 8, Email: email, IsActive: true}, nil
+	}
+	repo.GetBusinessStaffRelationFn = func(ctx context.Context, userID uint, businessID *uint) (*domain.BusinessStaffRelation, error) {
+		return nil, nil
+	}
+	repo.AssignBusinessRoleFn = func(ctx context.Context, userID, businessID, roleID uint) error {
+		asignado = true
+		return nil
+	}
+	uc := buildSSOUseCase(repo, oidcConIdentidad(identidadSSO("nonce-1")), nil)
+
+	_, err := uc.CompleteSSOLogin(context.Background(), domain.SSOCallbackRequest{State: "state", Code: "code"})
+
+	assert.ErrorIs(t, err, domain.ErrSSOAccountConflict)
+	assert.False(t, asignado, "el IdP de un negocio no puede tomar la cuenta de otro")
+}
+
+func TestCompleteSSOLogin_UsuarioDePlataforma_NoSeVincula(t *testing.T) {
+	vinculado, emitido := false, false
+	rolActual := uint(2)
+	repo := repoSSO()
+	repo.GetUserByEmailFn = func(ctx context.Context, email string) (*domain.UserAuthInfo, error) {
+		return &domain.UserAuthInfo{ID: 1, Email: email, IsActive: true}, nil
+	}
+	// Super admin que además es staff del negocio del SSO
+	repo.GetUserRolesFn = func(ctx context.Context, userID uint) ([]domain.Role, error) {
+		return []domain.Role{rolPlataforma(), rolNegocio()}, nil
+	}
+	repo.GetBusinessStaffRelationFn = func(ctx context.Context, userID uint, businessID *uint) (*domain.BusinessStaffRelation, error) {
+		return &domain.BusinessStaffRelation{UserID: userID, BusinessID: businessID, RoleID: &rolActual}, nil
+	}
+	repo.SaveSSOIdentityFn = func(ctx context.Context, identity *domain.SSOIdentity, loginAt time.Time) error {
+		vinculado = true
+		return nil
+	}
+	jwt := &mocks.JWTServiceMock{
+		GenerateSessionTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error) {
+			emitido = true
+			return "token", nil
+		},
+	}
+	uc := buildSSOUseCase(repo, oidcConIdentidad(identidadSSO("nonce-1")), jwt)
+
+	_, err := uc.CompleteSSOLogin(context.Background(), domain.SSOCallbackRequest{State: "state", Code: "code"})
+
+	assert.ErrorIs(t, err, domain.ErrSSOPlatformUser)
+	assert.False(t, vinculado)
+	assert.False(t, emitido, "el IdP de un negocio no emite tokens de plataforma")
+}
+
+func TestCompleteLogin_NegocioPreferidoAjeno_NoEmiteToken(t *testing.T) {
+	emitido := false
+	repo := repoSSO()
+	repo.GetUserBusinessesFn = func(ctx context.Context, userID uint) ([]domain.BusinessInfoEntity, error) {
+		return []domain.BusinessInfoEntity{negocio(99)}, nil
+	}
+	jwt := &mocks.JWTServiceMock{
+		GenerateSessionTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error) {
+			emitido = true
+			return "token", nil
+		},
+	}
+	uc := buildSSOUseCase(repo, nil, jwt)
+	user := &domain.UserAuthInfo{ID: 8, Email: "ana@empresa.com", IsActive: true}
+
+	_, err := uc.completeLogin(context.Background(), user, []domain.Role{rolNegocio()}, domain.ClientInfo{}, 36)
+	assert.ErrorIs(t, err, domain.ErrSSOAccountConflict)
+
+	_, err = uc.completeLogin(context.Background(), user, []domain.Role{rolPlataforma()}, domain.ClientInfo{}, 36)
+	assert.ErrorIs(t, err, domain.ErrSSOPlatformUser)
+	assert.False(t, emitido, "el token nunca cae en otro negocio ni en alcance de plataforma")
+}
+
+func TestCompleteSSOLogin_UsuarioDelNegocio_SincronizaElRol(t *testing.T) {
+	var rolAsignado uint
+	rolActual := uint(2)
+	repo := repoSSO()
+	repo.GetUserByEmailFn = func(ctx context.Context, email string) (*domain.UserAuthInfo, error) {
+		return &domain.UserAuthInfo{ID: 8, Email: email, IsActive: true}, nil
+	}
+	repo.GetBusinessStaffRelationFn = func(ctx context.Context, userID uint, businessID *uint) (*domain.BusinessStaffRelation, error) {
+		return &domain.BusinessStaffRelation{UserID: userID, BusinessID: businessID, RoleID: &rolActual}, nil
+	}
+	repo.AssignBusinessRoleFn = func(ctx context.Context, userID, businessID, roleID uint) error {
+		rolAsignado = roleID
+		return nil
+	}
+	uc := buildSSOUseCase(repo, oidcConIdentidad(identidadSSO("nonce-1")), nil)
+
+	_, err := uc.CompleteSSOLogin(context.Background(), domain.SSOCallbackRequest{State: "state", Code: "code"})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(5), rolAsignado, "el rol del negocio sigue al grupo del IdP")
+}
+
+func TestCompleteSSOLogin_SinAutoProvision_NoCreaUsuarios(t *testing.T) {
+	repo := repoSSO()
+	repo.GetSSOConfigFn = func(ctx context.Context, businessID uint) (*domain.BusinessSSOConfig, error) {
+		config := configSSO()
+		config.AutoProvision = false
+		return config, nil
+	}
+	repo.GetUserByEmailFn = func(ctx context.Context, email string) (*domain.UserAuthInfo, error) {
+		return nil, nil
+	}
+	uc := buildSSOUseCase(repo, oidcConIdentidad(identidadSSO("nonce-1")), nil)
+
+	_, err := uc.CompleteSSOLogin(context.Background(), domain.SSOCallbackRequest{State: "state", Code: "code"})
+
+	assert.ErrorIs(t, err, domain.ErrSSOUserNotProvisioned)
+}
+
+func TestSSORole_PrimerMapeoQueCoincideYRolPorDefecto(t *testing.T) {
+	config := configSSO()
+
+	assert.Equal(t, uint(2), ssoRole(config, map[string]any{"groups": []any{"ventas", "ADMINS"}}),
+		"gana el primer mapeo configurado, sin importar mayúsculas")
+	assert.Equal(t, uint(6), ssoRole(config, map[string]any{"groups": []any{"soporte"}}))
+
+	config.DefaultRoleID = nil
+	assert.Zero(t, ssoRole(config, map[string]any{}))
+}
+
+func TestClaimValues_RutasAnidadasYTextos(t *testing.T) {
+	claims := map[string]any{
+		"role":         "admins",
+		"realm_access": map[string]any{"roles": []any{"ventas", 3, "soporte"}},
+	}
+
+	assert.Equal(t, []string{"admins"}, claimValues(claims, "role"))
+	assert.Equal(t, []string{"ventas", "soporte"}, claimValues(claims, "realm_access.roles"))
+	assert.Nil(t, claimValues(claims, "role.sub"))
+	assert.Nil(t, claimValues(claims, ""))
+}
+
+func TestLogin_NegocioConContrasenaDeshabilitada_ExigeSSO(t *testing.T) {
+	generado := false
+	repo := &mocks.AuthRepositoryMock{
+		GetUserByEmailFn: func(ctx context.Context, email string) (*domain.UserAuthInfo, error) {
+			return usuarioActivo(7, "correcta"), nil
+		},
+		GetUserRolesFn: func(ctx context.Context, userID uint) ([]domain.Role, error) {
+			return []domain.Role{rolNegocio()}, nil
+		},
+		IsPasswordLoginDisabledFn: func(ctx context.Context, userID uint) (bool, error) {
+			return true, nil
+		},
+	}
+	jwt := &mocks.JWTServiceMock{
+		GenerateSessionTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error) {
+			generado = true
+			return "token", nil
+		},
+	}
+	uc := buildLoginUseCase(repo, jwt, nil)
+
+	_, err := uc.Login(context.Background(), domain.LoginRequest{Email: "ana@test.com", Password: "correcta"})
+
+	assert.ErrorIs(t, err, domain.ErrPasswordLoginDisabled)
+	assert.False(t, generado)
+}
+
+func TestLogin_SuperAdminConservaLaContrasena(t *testing.T) {
+	consultado := false
+	repo := &mocks.AuthRepositoryMock{
+		GetUserByEmailFn: func(ctx context.Context, email string) (*domain.UserAuthInfo, error) {
+			return usuarioActivo(1, "correcta"), nil
+		},
+		GetUserRolesFn: func(ctx context.Context, userID uint) ([]domain.Role, error) {
+			return []domain.Role{rolPlataforma()}, nil
+		},
+		IsPasswordLoginDisabledFn: func(ctx context.Context, userID uint) (bool, error) {
+			consultado = true
+			return true, nil
+		},
+	}
+	uc := buildLoginUseCase(repo, nil, nil)
+
+	_, err := uc.Login(context.Background(), domain.LoginRequest{Email: "root@test.com", Password: "correcta"})
+
+	require.NoError(t, err)
+	assert.False(t, consultado)
+}
+
+func TestUpsertSSOConfig_Validaciones(t *testing.T) {
+	admin := domain.AuthActor{UserID: 3, BusinessID: 36, RoleID: 2}
+	casos := []struct {
+		nombre   string
+		mutar    func(r *domain.UpsertSSOConfigRequest)
+		esperado error
+	}{
+		{"issuer sin https", func(r *domain.UpsertSSOConfigRequest) { r.Issuer = "http://idp.test.com" }, domain.ErrSSOInvalidConfig},
+		{"issuer en IP privada", func(r *domain.UpsertSSOConfigRequest) { r.Issuer = "https://10.0.0.5/" }, domain.ErrSSOInvalidConfig},
+		{"issuer en metadata de la nube", func(r *domain.UpsertSSOConfigRequest) { r.Issuer = "https://169.254.169.254" }, domain.ErrSSOInvalidConfig},
+		{"issuer que resuelve a la red interna", func(r *domain.UpsertSSOConfigRequest) { r.Issuer = "https://interno.test.com" }, domain.ErrSSOInvalidConfig},
+		{"issuer en localhost sin permiso", func(r *domain.UpsertSSOConfigRequest) { r.Issuer = "http://localhost:9091" }, domain.ErrSSOInvalidConfig},
+		{"sin client_id", func(r *domain.UpsertSSOConfigRequest) { r.ClientID = " " }, domain.ErrSSOInvalidConfig},
+		{"mapeo sin claim", func(r *domain.UpsertSSOConfigRequest) { r.RoleClaim = "" }, domain.ErrSSOInvalidConfig},
+		{"contraseña off con SSO apagado", func(r *domain.UpsertSSOConfigRequest) {
+			r.Enabled = false
+			r.DisablePasswordLogin = true
+		}, domain.ErrSSOInvalidConfig},
+		{"rol de plataforma", func(r *domain.UpsertSSOConfigRequest) {
+			r.RoleMappings = []domain.SSORoleMapping{{ClaimValue: "root", RoleID: 1}}
+		}, domain.ErrSSOInvalidRole},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			guardado := false
+			repo := &mocks.AuthRepositoryMock{
+				GetRoleByIDFn: func(ctx context.Context, id uint) (*domain.Role, error) {
+					if id == 1 {
+						return &domain.Role{ID: 1, ScopeCode: "platform"}, nil
+					}
+					return &domain.Role{ID: id, Level: 2, ScopeCode: "business", BusinessTypeID: 3}, nil
+				},
+				GetBusinessByIDFn: func(ctx context.Context, businessID uint) (*domain.BusinessInfo, error) {
+					return &domain.BusinessInfo{ID: businessID, BusinessTypeID: 3}, nil
+				},
+				UpsertSSOConfigFn: func(ctx context.Context, config *domain.BusinessSSOConfig) error {
+					guardado = true
+					return nil
+				},
+			}
+			uc := buildSSOUseCase(repo, nil, nil)
+
+			request := domain.UpsertSSOConfigRequest{
+				Enabled:       true,
+				Issuer:        issuerDePrueba,
+				ClientID:      "cliente",
+				RoleClaim:     "groups",
+				RoleMappings:  []domain.SSORoleMapping{{ClaimValue: "ventas", RoleID: 5}},
+				AutoProvision: true,
+			}
+			tc.mutar(&request)
+
+			_, err := uc.UpsertSSOConfig(context.Background(), admin, request)
+
+			assert.ErrorIs(t, err, tc.esperado)
+			assert.False(t, guardado)
+		})
+	}
+}
+
+func TestUpsertSSOConfig_SinSecretoConservaElGuardado(t *testing.T) {
+	var guardada *domain.BusinessSSOConfig
+	var reclamados []domain.SSODomain
+	repo := &mocks.AuthRepositoryMock{
+		ListSSODomainsFn: func(ctx context.Context, businessID uint) ([]domain.SSODomain, error) {
+			return []domain.SSODomain{dominioVerificado("empresa.com")}, nil
+		},
+		ReplaceSSODomainsFn: func(ctx context.Context, businessID uint, domains []domain.SSODomain) error {
+			reclamados = domains
+			return nil
+		},
+		GetRoleByIDFn: func(ctx context.Context, id uint) (*domain.Role, error) {
+			return &domain.Role{ID: id, Level: 2, ScopeCode: "business"}, nil
+		},
+		GetBusinessByIDFn: func(ctx context.Context, businessID uint) (*domain.BusinessInfo, error) {
+			return &domain.BusinessInfo{ID: businessID, BusinessTypeID: 3}, nil
+		},
+		GetSSOConfigFn: func(ctx context.Context, businessID uint) (*domain.BusinessSSOConfig, error) {
+			return configSSO(), nil
+		},
+		UpsertSSOConfigFn: func(ctx context.Context, config *domain.BusinessSSOConfig) error {
+			guardada = config
+			return nil
+		},
+	}
+	uc := buildSSOUseCase(repo, nil, nil)
+
+	got, err := uc.UpsertSSOConfig(context.Background(), domain.AuthActor{UserID: 3, BusinessID: 36, RoleID: 2},
+		domain.UpsertSSOConfigRequest{
+			Enabled:       true,
+			Issuer:        issuerDePrueba + "/",
+			ClientID:      "cliente",
+			Scopes:        []string{"email groups"},
+			DefaultRoleID: uintPtr(6),
+			EmailDomains:  []string{"@Empresa.com", "empresa.com"},
+		})
+
+	require.NoError(t, err)
+	require.NotNil(t, guardada)
+	assert.Equal(t, "secreto", guardada.ClientSecret)
+	assert.Equal(t, issuerDePrueba, guardada.Issuer, "se quita el slash final")
+	assert.Equal(t, []string{"openid", "email", "groups"}, guardada.Scopes)
+	assert.Equal(t, []string{"empresa.com"}, guardada.EmailDomains)
+	assert.Equal(t, uint(36), guardada.BusinessID, "el admin solo configura su negocio")
+	assert.Equal(t, "https://app.test.com/auth/sso/callback", got.RedirectURI)
+	require.Len(t, reclamados, 1)
+	assert.Equal(t, "token-empresa.com", reclamados[0].VerificationToken, "se conserva el token ya verificado")
+}
+
+// repoUpsertSSO acepta cualquier rol y negocio; solo cambia lo de dominios
+func repoUpsertSSO() *mocks.AuthRepositoryMock {
+	return &mocks.AuthRepositoryMock{
+		GetRoleByIDFn: func(ctx context.Context, id uint) (*domain.Role, error) {
+			return &domain.Role{ID: id, Level: 2, ScopeCode: "business"}, nil
+		},
+		GetBusinessByIDFn: func(ctx context.Context, businessID uint) (*domain.BusinessInfo, error) {
+			return &domain.BusinessInfo{ID: businessID, BusinessTypeID: 3}, nil
+		},
+	}
+}
+
+func requestConDominios(enabled bool, domains ...string) domain.UpsertSSOConfigRequest {
+	return domain.UpsertSSOConfigRequest{
+		Enabled:       enabled,
+		Issuer:        issuerDePrueba,
+		ClientID:      "cliente",
+		DefaultRoleID: uintPtr(6),
+		EmailDomains:  domains,
+	}
+}
+
+func TestUpsertSSOConfig_RechazaCorreoPublico(t *testing.T) {
+	guardado := false
+	repo := repoUpsertSSO()
+	repo.UpsertSSOConfigFn = func(ctx context.Context, config *domain.BusinessSSOConfig) error {
+		guardado = true
+		return nil
+	}
+	uc := buildSSOUseCase(repo, nil, nil)
+
+	_, err := uc.UpsertSSOConfig(context.Background(), domain.AuthActor{UserID: 3, BusinessID: 36, RoleID: 2},
+		requestConDominios(false, "empresa.com", "Gmail.com"))
+
+	assert.ErrorIs(t, err, domain.ErrSSOPublicEmailDomain)
+	assert.False(t, guardado)
+}
+
+func TestUpsertSSOConfig_DominioVerificadoPorOtroNegocio(t *testing.T) {
+	guardado := false
+	repo := repoUpsertSSO()
+	repo.SSODomainVerifiedByOtherFn = func(ctx context.Context, emailDomain string, businessID uint) (bool, error) {
+		return emailDomain == "competencia.com" && businessID != 99, nil
+	}
+	repo.UpsertSSOConfigFn = func(ctx context.Context, config *domain.BusinessSSOConfig) error {
+		guardado = true
+		return nil
+	}
+	uc := buildSSOUseCase(repo, nil, nil)
+
+	_, err := uc.UpsertSSOConfig(context.Background(), domain.AuthActor{UserID: 3, BusinessID: 36, RoleID: 2},
+		requestConDominios(false, "competencia.com"))
+
+	assert.ErrorIs(t, err, domain.ErrSSODomainTaken)
+	assert.False(t, guardado)
+}
+
+func TestUpsertSSOConfig_ActivarExigeDominiosVerificados(t *testing.T) {
+	repo := repoUpsertSSO()
+	repo.ListSSODomainsFn = func(ctx context.Context, businessID uint) ([]domain.SSODomain, error) {
+		return []domain.SSODomain{dominioVerificado("empresa.com")}, nil
+	}
+	var reclamados []domain.SSODomain
+	repo.ReplaceSSODomainsFn = func(ctx context.Context, businessID uint, domains []domain.SSODomain) error {
+		reclamados = domains
+		return nil
+	}
+	uc := buildSSOUseCase(repo, nil, nil)
+	admin := domain.AuthActor{UserID: 3, BusinessID: 36, RoleID: 2}
+
+	_, err := uc.UpsertSSOConfig(context.Background(), admin, requestConDominios(true, "empresa.com", "filial.com"))
+	assert.ErrorIs(t, err, domain.ErrSSODomainNotVerified)
+	assert.Nil(t, reclamados)
+
+	// Apagado se guarda y devuelve el TXT a publicar para el dominio nuevo
+	got, err := uc.UpsertSSOConfig(context.Background(), admin, requestConDominios(false, "empresa.com", "filial.com"))
+	require.NoError(t, err)
+	require.Len(t, got.Domains, 2)
+	assert.NotNil(t, got.Domains[0].VerifiedAt)
+	assert.Nil(t, got.Domains[1].VerifiedAt)
+	assert.Equal(t, "_probability-sso.filial.com", got.Domains[1].TXTName)
+	assert.Equal(t, "probability-sso-verification="+got.Domains[1].VerificationToken, got.Domains[1].TXTValue)
+	assert.Len(t, got.Domains[1].VerificationToken, 32)
+	assert.Equal(t, got.Domains, reclamados)
+}
+
+func TestVerifySSODomain_ConElTXTPublicadoMarcaVerificado(t *testing.T) {
+	var marcado string
+	repo := repoUpsertSSO()
+	repo.ListSSODomainsFn = func(ctx context.Context, businessID uint) ([]domain.SSODomain, error) {
+		return []domain.SSODomain{{Domain: "filial.com", VerificationToken: "abc"}}, nil
+	}
+	repo.MarkSSODomainVerifiedFn = func(ctx context.Context, businessID uint, emailDomain string, verifiedAt time.Time) error {
+		marcado = emailDomain
+		return nil
+	}
+	uc := buildSSOUseCase(repo, nil, nil)
+	uc.dns = &mocks.DNSResolverMock{Records: map[string][]string{
+		"_probability-sso.filial.com": {"v=spf1 -all", "probability-sso-verification=abc"},
+	}}
+
+	got, err := uc.VerifySSODomain(context.Background(), domain.AuthActor{UserID: 3, BusinessID: 36, RoleID: 2}, 0, "@Filial.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, "filial.com", marcado)
+	assert.NotNil(t, got.VerifiedAt)
+}
+
+func TestVerifySSODomain_TXTDistintoNoVerifica(t *testing.T) {
+	marcado := false
+	repo := repoUpsertSSO()
+	repo.ListSSODomainsFn = func(ctx context.Context, businessID uint) ([]domain.SSODomain, error) {
+		return []domain.SSODomain{{Domain: "filial.com", VerificationToken: "abc"}}, nil
+	}
+	repo.MarkSSODomainVerifiedFn = func(ctx context.Context, businessID uint, emailDomain string, verifiedAt time.Time) error {
+		marcado = true
+		return nil
+	}
+	uc := buildSSOUseCase(repo, nil, nil)
+	uc.dns = &mocks.DNSResolverMock{Records: map[string][]string{
+		"_probability-sso.filial.com": {"probability-sso-verification=otro"},
+	}}
+	admin := domain.AuthActor{UserID: 3, BusinessID: 36, RoleID: 2}
+
+	_, err := uc.VerifySSODomain(context.Background(), admin, 0, "filial.com")
+	assert.ErrorIs(t, err, domain.ErrSSODomainVerifyFailed)
+
+	_, err = uc.VerifySSODomain(context.Background(), admin, 0, "otro.com")
+	assert.ErrorIs(t, err, domain.ErrSSODomainNotFound)
+	assert.False(t, marcado)
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+)
+
+const (
+	// Tiempo para ingresar el codigo despues de validar la contrasena
+	twoFactorChallengeTTL = 5 * time.Minute
+	twoFactorChallengeTag = "2fa"
+)
+
+// issueTwoFactorChallenge firma el token que prueba que la contrasena ya se
+// valido. No sirve como sesion: solo lo aceptan los endpoints /auth/login/2fa.
+func (uc *AuthUseCase) issueTwoFactorChallenge(userID uint, now time.Time) (string, error) {
+	key, err := uc.challengeKey()
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, 8)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	payload := fmt.Sprintf("%s:%d:%d:%s", twoFactorChallengeTag, userID, now.Add(twoFactorChallengeTTL).Unix(), hex.EncodeToString(nonce))
+	encoded := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return encoded + "." + signChallenge(key, encoded), nil
+}
+
+func (uc *AuthUseCase) parseTwoFactorChallenge(token string, now time.Time) (uint, error) {
+	key, err := uc.challengeKey()
+	if err != nil {
+		return 0, err
+	}
+
+	encoded, signature, ok := strings.Cut(strings.TrimSpace(token), ".")
+	if !ok || subtle.ConstantTimeCompare([]byte(signChallenge(key, encoded)), []byte(signature)) != 1 {
+		return 0, domain.ErrTwoFactorChallengeInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return 0, domain.ErrTwoFactorChallengeInvalid
+	}
+	parts := strings.Split(string(payload), ":")
+	if len(parts) != 4 || parts[0] != twoFactorChallengeTag {
+		return 0, domain.ErrTwoFactorChallengeInvalid
+	}
+	userID, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil || userID == 0 {
+		return 0, domain.ErrTwoFactorChallengeInvalid
+	}
+	expiresAt, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || now.Unix() > expiresAt {
+		return 0, domain.ErrTwoFactorChallengeInvalid
+	}
+	return uint(userID), nil
+}
+
+func (uc *AuthUseCase) challengeKey() ([]byte, error) {
+	secret := uc.env.Get("JWT_SECRET")
+	if secret == "" {
+		return nil, errors.New("JWT_SECRET no configurado")
+	}
+	return []byte(secret + ":" + twoFactorChallengeTag), nil
+}
+
+func signChallenge(key []byte, encoded string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+)
+
+// twoFactorChallenge decide si el login necesita segundo factor. Retorna la
+// respuesta parcial con el token del desafío, o nil si el login puede seguir.
+func (uc *AuthUseCase) twoFactorChallenge(ctx context.Context, userAuth *domain.UserAuthInfo, roles []domain.Role) (*domain.LoginResponse, error) {
+	tf, err := uc.repository.GetUserTwoFactor(ctx, userAuth.ID)
+	if err != nil {
+		uc.log.Error().Err(err).Uint("user_id", userAuth.ID).Msg("Error al consultar el segundo factor")
+		return nil, fmt.Errorf("error interno del servidor")
+	}
+
+	enabled := tf != nil && tf.Enabled
+	if !enabled && !requiresTwoFactor(roles) {
+		return nil, nil
+	}
+
+	token, err := uc.issueTwoFactorChallenge(userAuth.ID, time.Now())
+	if err != nil {
+		uc.log.Error().Err(err).Uint("user_id", userAuth.ID).Msg("Error al generar el desafío de segundo factor")
+		return nil, fmt.Errorf("error interno del servidor")
+	}
+
+	uc.log.Info().
+		Uint("user_id", userAuth.ID).
+		Bool("setup_required", !enabled).
+		Msg("Login pendiente de segundo factor")
+
+	return &domain.LoginResponse{
+		User: domain.UserInfo{
+			ID:    userAuth.ID,
+			Name:  userAuth.Name,
+			Email: userAuth.Email,
+		},
+		TwoFactorRequired:      true,
+		TwoFactorSetupRequired: !enabled,
+		TwoFactorToken:         token,
+	}, nil
+}
+
+// SetupTwoFactorLogin genera el QR para el usuario cuyo rol exige 2FA y que
+// aún no lo tiene, dentro del mismo login
+func (uc *AuthUseCase) SetupTwoFactorLogin(ctx context.Context, twoFactorToken string) (*domain.TwoFactorSetup, error) {
+	user, err := uc.challengeUser(ctx, twoFactorToken)
+	if err != nil {
+		return nil, err
+	}
+
+	tf, err := uc.repository.GetUserTwoFactor(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if tf != nil && tf.Enabled {
+		return nil, domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	return uc.newEnrollment(ctx, user)
+}
+
+// VerifyTwoFactorLogin completa el login con el código de la app o un código
+// de recuperación. Si el enrolamiento estaba pendiente, el código lo confirma
+// y la respuesta trae los códigos de recuperación.
+func (uc *AuthUseCase) VerifyTwoFactorLogin(ctx context.Context, request domain.VerifyTwoFactorLoginRequest) (*domain.LoginResponse, error) {
+	user, err := uc.challengeUser(ctx, request.TwoFactorToken)
+	if err != nil {
+		return nil, err
+	}
+
+	tf, err := uc.repository.GetUserTwoFactor(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if tf == nil {
+		return nil, domain.ErrTwoFactorSetupNotStarted
+	}
+
+	var recoveryCodes []string
+	if tf.Enabled {
+		err = uc.verifySecondFactor(ctx, tf, request.Code)
+	} else {
+		recoveryCodes, err = uc.confirmEnrollment(ctx, tf, request.Code)
+	}
+	if err != nil {
+		uc.log.Warn().Err(err).Uint("user_id", user.ID).Msg("Segundo factor rechazado en el login")
+		return nil, err
+	}
+
+	roles, err := uc.repository.GetUserRoles(ctx, user.ID)
+	if err != nil {
+		uc.log.Error().Err(err).Uint("user_id", user.ID).Msg("Error al obtener roles del usuario")
+		return nil, fmt.Errorf("error interno del servidor")
+	}
+
+	response, err := uc.completeLogin(ctx, user, roles, request.Client, 0)
+	if err != nil {
+		return nil, err
+	}
+	response.RecoveryCodes = recoveryCodes
+	return response, nil
+}
+
+// challengeUser valida el token del desafío y que el usuario siga activo
+func (uc *AuthUseCase) challengeUser(ctx context.Context, twoFactorToken string) (*domain.UserAuthInfo, error) {
+	userID, err := uc.parseTwoFactorChallenge(twoFactorToken, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || !user.IsActive {
+		return nil, domain.ErrTwoFactorChallengeInvalid
+	}
+	return user, nil
+}
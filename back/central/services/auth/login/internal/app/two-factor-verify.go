@@ -0,0 +1,124 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/skip2/go-qrcode"
+)
+
+const (
+	maxTwoFactorAttempts = 5
+	twoFactorLockout     = 15 * time.Minute
+)
+
+// verifySecondFactor valida un codigo de la app o un codigo de recuperacion
+// contra un 2FA activo. Cada codigo TOTP sirve una sola vez.
+func (uc *AuthUseCase) verifySecondFactor(ctx context.Context, tf *domain.UserTwoFactor, code string) error {
+	now := time.Now()
+	if tf.LockedUntil != nil && now.Before(*tf.LockedUntil) {
+		return domain.ErrTwoFactorLocked
+	}
+
+	if isTOTPCode(code) {
+		if step, ok := matchTOTP(tf.Secret, code, now); ok {
+			accepted, err := uc.repository.MarkTwoFactorStepUsed(ctx, tf.UserID, step)
+			if err != nil {
+				return err
+			}
+			if accepted {
+				return nil
+			}
+		}
+	} else if normalizeRecoveryCode(code) != "" {
+		used, err := uc.repository.UseRecoveryCode(ctx, tf.UserID, hashRecoveryCode(tf.UserID, code))
+		if err != nil {
+			return err
+		}
+		if used {
+			uc.log.Warn().Uint("user_id", tf.UserID).Msg("Segundo factor validado con codigo de recuperacion")
+			return nil
+		}
+	}
+
+	uc.registerTwoFactorFailure(ctx, tf.UserID, now)
+	return domain.ErrTwoFactorInvalidCode
+}
+
+// confirmEnrollment activa un enrolamiento pendiente con el primer codigo de
+// la app y retorna los codigos de recuperacion, que solo se muestran aqui.
+func (uc *AuthUseCase) confirmEnrollment(ctx context.Context, tf *domain.UserTwoFactor, code string) ([]string, error) {
+	now := time.Now()
+	if tf.LockedUntil != nil && now.Before(*tf.LockedUntil) {
+		return nil, domain.ErrTwoFactorLocked
+	}
+
+	step, ok := matchTOTP(tf.Secret, code, now)
+	if !ok {
+		uc.registerTwoFactorFailure(ctx, tf.UserID, now)
+		return nil, domain.ErrTwoFactorInvalidCode
+	}
+
+	if err := uc.repository.EnableTwoFactor(ctx, tf.UserID, step, now); err != nil {
+		return nil, err
+	}
+
+	codes, err := uc.replaceRecoveryCodes(ctx, tf.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log.Info().Uint("user_id", tf.UserID).Msg("Verificacion en dos pasos activada")
+	return codes, nil
+}
+
+func (uc *AuthUseCase) replaceRecoveryCodes(ctx context.Context, userID uint) ([]string, error) {
+	codes, hashes, err := generateRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.repository.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (uc *AuthUseCase) registerTwoFactorFailure(ctx context.Context, userID uint, now time.Time) {
+	if err := uc.repository.RegisterTwoFactorFailure(ctx, userID, maxTwoFactorAttempts, now.Add(twoFactorLockout)); err != nil {
+		uc.log.Warn().Err(err).Uint("user_id", userID).Msg("Error registrando intento fallido de segundo factor")
+	}
+}
+
+// newEnrollment genera un secreto pendiente y el QR para escanearlo
+func (uc *AuthUseCase) newEnrollment(ctx context.Context, user *domain.UserAuthInfo) (*domain.TwoFactorSetup, error) {
+	secret, err := generateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.repository.UpsertPendingTwoFactor(ctx, user.ID, secret); err != nil {
+		return nil, err
+	}
+
+	otpURL := totpURL(user.Email, secret)
+	png, err := qrcode.Encode(otpURL, qrcode.Medium, 256)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TwoFactorSetup{
+		Secret:     secret,
+		OTPAuthURL: otpURL,
+		QRCodePNG:  png,
+	}, nil
+}
+
+// requiresTwoFactor indica si alguno de los roles del usuario exige 2FA
+func requiresTwoFactor(roles []domain.Role) bool {
+	for _, role := range roles {
+		if role.RequireTwoFactor {
+			return true
+		}
+	}
+	return false
+}
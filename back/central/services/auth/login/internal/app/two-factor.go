@@ -0,0 +1,140 @@
+package app
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+)
+
+func (uc *AuthUseCase) GetTwoFactorStatus(ctx context.Context, userID uint) (*domain.TwoFactorStatus, error) {
+	tf, err := uc.repository.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := uc.repository.GetUserRoles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &domain.TwoFactorStatus{RequiredByRole: requiresTwoFactor(roles)}
+	if tf != nil && tf.Enabled {
+		status.Enabled = true
+		status.EnabledAt = tf.EnabledAt
+		if status.RecoveryCodesLeft, err = uc.repository.CountUnusedRecoveryCodes(ctx, userID); err != nil {
+			return nil, err
+		}
+	}
+	return status, nil
+}
+
+// SetupTwoFactor inicia el enrolamiento desde el perfil. Queda pendiente
+// hasta que EnableTwoFactor lo confirme con un código.
+func (uc *AuthUseCase) SetupTwoFactor(ctx context.Context, userID uint) (*domain.TwoFactorSetup, error) {
+	user, err := uc.repository.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, domain.ErrUserNotFound
+	}
+
+	tf, err := uc.repository.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf != nil && tf.Enabled {
+		return nil, domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	return uc.newEnrollment(ctx, user)
+}
+
+// EnableTwoFactor confirma el enrolamiento y retorna los códigos de recuperación
+func (uc *AuthUseCase) EnableTwoFactor(ctx context.Context, userID uint, code string) ([]string, error) {
+	tf, err := uc.repository.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf == nil {
+		return nil, domain.ErrTwoFactorSetupNotStarted
+	}
+	if tf.Enabled {
+		return nil, domain.ErrTwoFactorAlreadyEnabled
+	}
+	return uc.confirmEnrollment(ctx, tf, code)
+}
+
+// DisableTwoFactor desactiva el 2FA propio con un código válido. No aplica
+// si algún rol del usuario lo exige.
+func (uc *AuthUseCase) DisableTwoFactor(ctx context.Context, userID uint, code string) error {
+	tf, err := uc.enabledTwoFactor(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	roles, err := uc.repository.GetUserRoles(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if requiresTwoFactor(roles) {
+		return domain.ErrTwoFactorRequiredByRole
+	}
+
+	if err := uc.verifySecondFactor(ctx, tf, code); err != nil {
+		return err
+	}
+	if err := uc.repository.DeleteTwoFactor(ctx, userID); err != nil {
+		return err
+	}
+
+	uc.log.Info().Uint("user_id", userID).Msg("Verificacion en dos pasos desactivada")
+	return nil
+}
+
+// RegenerateRecoveryCodes invalida los códigos anteriores y emite nuevos
+func (uc *AuthUseCase) RegenerateRecoveryCodes(ctx context.Context, userID uint, code string) ([]string, error) {
+	tf, err := uc.enabledTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.verifySecondFactor(ctx, tf, code); err != nil {
+		return nil, err
+	}
+	return uc.replaceRecoveryCodes(ctx, userID)
+}
+
+// ResetUserTwoFactor quita el 2FA de otro usuario (perdió el teléfono y los
+// códigos) y cierra sus sesiones. En su próximo login, si su rol lo exige,
+// deberá enrolarse de nuevo.
+func (uc *AuthUseCase) ResetUserTwoFactor(ctx context.Context, actor domain.AuthActor, targetUserID uint) error {
+	if err := uc.authorizeUserManagement(ctx, actor, targetUserID); err != nil {
+		return err
+	}
+	if err := uc.repository.DeleteTwoFactor(ctx, targetUserID); err != nil {
+		return err
+	}
+
+	revoked, err := uc.repository.RevokeUserSessions(ctx, targetUserID, "", actor.UserID, sessionRevokedTwoFactorReset)
+	if err != nil {
+		return err
+	}
+	uc.sessions.evict(revoked...)
+
+	uc.log.Warn().
+		Uint("user_id", targetUserID).
+		Uint("reset_by", actor.UserID).
+		Int("sessions_revoked", len(revoked)).
+		Msg("Verificacion en dos pasos restablecida por un administrador")
+	return nil
+}
+
+func (uc *AuthUseCase) enabledTwoFactor(ctx context.Context, userID uint) (*domain.UserTwoFactor, error) {
+	tf, err := uc.repository.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if tf == nil || !tf.Enabled {
+		return nil, domain.ErrTwoFactorNotEnabled
+	}
+	return tf, nil
+}
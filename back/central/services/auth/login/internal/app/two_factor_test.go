@@ -0,0 +1,426 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Secreto del anexo B de la RFC 6238 ("12345678901234567890" en base32)
+const secretoRFC = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+var configConSecreto = map[string]string{"JWT_SECRET": "secreto-de-prueba"}
+
+func codigoActual(t *testing.T, secreto string) string {
+	t.Helper()
+	code, err := totpCode(secreto, totpStep(time.Now()))
+	require.NoError(t, err)
+	return code
+}
+
+func dosFactoresActivo(userID uint, secreto string) *domain.UserTwoFactor {
+	return &domain.UserTwoFactor{ID: 1, UserID: userID, Secret: secreto, Enabled: true}
+}
+
+func TestTOTPCode_VectoresDeLaRFC(t *testing.T) {
+	// T=59 -> paso 1 y T=1111111109 -> paso 37037036 (8 digitos en la RFC, aqui los ultimos 6)
+	code, err := totpCode(secretoRFC, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "287082", code)
+
+	code, err = totpCode(secretoRFC, 1111111109/30)
+	require.NoError(t, err)
+	assert.Equal(t, "081804", code)
+}
+
+func TestMatchTOTP_ToleraUnPasoDeDesfase(t *testing.T) {
+	ahora := time.Unix(1111111109, 0)
+	anterior, _ := totpCode(secretoRFC, totpStep(ahora)-1)
+	viejo, _ := totpCode(secretoRFC, totpStep(ahora)-3)
+
+	step, ok := matchTOTP(secretoRFC, anterior, ahora)
+	assert.True(t, ok)
+	assert.Equal(t, totpStep(ahora)-1, step)
+
+	_, ok = matchTOTP(secretoRFC, viejo, ahora)
+	assert.False(t, ok, "un codigo de hace 90 segundos ya no sirve")
+
+	_, ok = matchTOTP(secretoRFC, "12345", ahora)
+	assert.False(t, ok)
+}
+
+func TestTOTPURL_IncluyeEmisorYSecreto(t *testing.T) {
+	url := totpURL("ana@test.com", secretoRFC)
+
+	assert.True(t, strings.HasPrefix(url, "otpauth://totp/Probability:ana@test.com?"))
+	assert.Contains(t, url, "secret="+secretoRFC)
+	assert.Contains(t, url, "issuer=Probability")
+}
+
+func TestGenerateRecoveryCodes_HashIgnoraGuionesYMayusculas(t *testing.T) {
+	codes, hashes, err := generateRecoveryCodes(9)
+	require.NoError(t, err)
+	require.Len(t, codes, recoveryCodeCount)
+	require.Len(t, hashes, recoveryCodeCount)
+
+	assert.Equal(t, hashes[0], hashRecoveryCode(9, strings.ToUpper(strings.ReplaceAll(codes[0], "-", " "))))
+	assert.NotEqual(t, hashes[0], hashRecoveryCode(10, codes[0]), "el hash depende del usuario")
+}
+
+func TestTwoFactorChallenge_IdaYVuelta(t *testing.T) {
+	uc := buildLoginUseCase(&mocks.AuthRepositoryMock{}, nil, configConSecreto)
+	ahora := time.Now()
+
+	token, err := uc.issueTwoFactorChallenge(42, ahora)
+	require.NoError(t, err)
+
+	userID, err := uc.parseTwoFactorChallenge(token, ahora.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, uint(42), userID)
+
+	_, err = uc.parseTwoFactorChallenge(token, ahora.Add(twoFactorChallengeTTL+time.Second))
+	assert.ErrorIs(t, err, domain.ErrTwoFactorChallengeInvalid, "vencido")
+
+	_, err = uc.parseTwoFactorChallenge(token+"x", ahora)
+	assert.ErrorIs(t, err, domain.ErrTwoFactorChallengeInvalid, "firma alterada")
+}
+
+func TestTwoFactorChallenge_SinJWTSecret_NoFirma(t *testing.T) {
+	uc := buildLoginUseCase(&mocks.AuthRepositoryMock{}, nil, nil)
+
+	_, err := uc.issueTwoFactorChallenge(42, time.Now())
+	assert.Error(t, err)
+}
+
+func TestLogin_ConDosFactoresActivo_RetornaDesafioSinToken(t *testing.T) {
+	sesiones := 0
+	repo := &mocks.AuthRepositoryMock{
+		GetUserByEmailFn: func(ctx context.Context, email string) (*domain.UserAuthInfo, error) {
+			return usuarioActivo(42, "correcta"), nil
+		},
+		GetUserRolesFn: func(ctx context.Context, userID uint) ([]domain.Role, error) {
+			return []domain.Role{rolNegocio()}, nil
+		},
+		GetUserTwoFactorFn: func(ctx context.Context, userID uint) (*domain.UserTwoFactor, error) {
+			return dosFactoresActivo(userID, secretoRFC), nil
+		},
+		CreateSessionFn: func(ctx context.Context, session *domain.UserSession) error {
+			sesiones++
+			return nil
+		},
+	}
+	uc := buildLoginUseCase(repo, nil, configConSecreto)
+
+	got, err := uc.Login(context.Background(), domain.LoginRequest{Email: "ana@test.com", Password: "correcta"})
+
+	require.NoError(t, err)
+	assert.True(t, got.TwoFactorRequired)
+	assert.False(t, got.TwoFactorSetupRequired)
+	assert.NotEmpty(t, got.TwoFactorToken)
+	assert.Empty(t, got.Token)
+	assert.Zero(t, sesiones, "no se registra sesion hasta validar el segundo factor")
+}
+
+func TestLogin_RolExigeDosFactoresSinEnrolar_PideEnrolamiento(t *testing.T) {
+	rol := rolNegocio()
+	rol.RequireTwoFactor = true
+	repo := &mocks.AuthRepositoryMock{
+		GetUserByEmailFn: func(ctx context.Context, email string) (*domain.UserAuthInfo, error) {
+			return usuarioActivo(42, "correcta"), nil
+		},
+		GetUserRolesFn: func(ctx context.Context, userID uint) ([]domain.Role, error) {
+			return []domain.Role{rol}, nil
+		},
+	}
+	uc := buildLoginUseCase(repo, nil, configConSecreto)
+
+	got, err := uc.Login(context.Background(), domain.LoginRequest{Email: "ana@test.com", Password: "correcta"})
+
+	require.NoError(t, err)
+	assert.True(t, got.TwoFactorRequired)
+	assert.True(t, got.TwoFactorSetupRequired)
+	assert.Empty(t, got.Token)
+}
+
+func TestLogin_SinDosFactores_RegistraSesionYLaPoneEnElToken(t *testing.T) {
+	var registrada *domain.UserSession
+	var sidDelToken string
+	repo := &mocks.AuthRepositoryMock{
+		GetUserByEmailFn: func(ctx context.Context, email string) (*domain.UserAuthInfo, error) {
+			return usuarioActivo(42, "correcta"), nil
+		},
+		GetUserRolesFn: func(ctx context.Context, userID uint) ([]domain.Role, error) {
+			return []domain.Role{rolNegocio()}, nil
+		},
+		CreateSessionFn: func(ctx context.Context, session *domain.UserSession) error {
+			registrada = session
+			return nil
+		},
+	}
+	jwt := &mocks.JWTServiceMock{
+		GenerateSessionTokenFn: func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error) {
+			sidDelToken = sessionID
+			return "token", nil
+		},
+	}
+	uc := buildLoginUseCase(repo, jwt, nil)
+
+	got, err := uc.Login(context.Background(), domain.LoginRequest{
+		Email:    "ana@test.com",
+		Password: "correcta",
+		Client:   domain.ClientInfo{IPAddress: "10.0.0.1", UserAgent: "Mozilla/5.0 (Windows NT 10.0) Chrome/120.0"},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, registrada)
+	assert.Equal(t, "token", got.Token)
+	assert.Equal(t, registrada.SessionID, sidDelToken)
+	assert.Equal(t, registrada.SessionID, got.SessionID)
+	assert.Equal(t, "Chrome en Windows", registrada.Device)
+	assert.Equal(t, "10.0.0.1", registrada.IPAddress)
+}
+
+func TestLogin_FallaRegistrarSesion_RetornaErrorInterno(t *testing.T) {
+	repo := &mocks.AuthRepositoryMock{
+		GetUserByEmailFn: func(ctx context.Context, email string) (*domain.UserAuthInfo, error) {
+			return usuarioActivo(42, "correcta"), nil
+		},
+		CreateSessionFn: func(ctx context.Context, session *domain.UserSession) error {
+			return assert.AnError
+		},
+	}
+	uc := buildLoginUseCase(repo, nil, nil)
+
+	got, err := uc.Login(context.Background(), domain.LoginRequest{Email: "ana@test.com", Password: "correcta"})
+
+	assert.Nil(t, got)
+	assert.EqualError(t, err, "error interno del servidor")
+}
+
+func repoDesafio(tf *domain.UserTwoFactor) *mocks.AuthRepositoryMock {
+	return &mocks.AuthRepositoryMock{
+		GetUserByIDFn: func(ctx context.Context, userID uint) (*domain.UserAuthInfo, error) {
+			return usuarioActivo(userID, "correcta"), nil
+		},
+		GetUserTwoFactorFn: func(ctx context.Context, userID uint) (*domain.UserTwoFactor, error) {
+			return tf, nil
+		},
+	}
+}
+
+func TestVerifyTwoFactorLogin_CodigoValido_CompletaElLogin(t *testing.T) {
+	repo := repoDesafio(dosFactoresActivo(42, secretoRFC))
+	uc := buildLoginUseCase(repo, nil, configConSecreto)
+	token, _ := uc.issueTwoFactorChallenge(42, time.Now())
+
+	got, err := uc.VerifyTwoFactorLogin(context.Background(), domain.VerifyTwoFactorLoginRequest{
+		TwoFactorToken: token,
+		Code:           codigoActual(t, secretoRFC),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "token-de-prueba", got.Token)
+	assert.False(t, got.TwoFactorRequired)
+	assert.Empty(t, got.RecoveryCodes)
+}
+
+func TestVerifyTwoFactorLogin_CodigoYaUsado_Rechaza(t *testing.T) {
+	fallos := 0
+	repo := repoDesafio(dosFactoresActivo(42, secretoRFC))
+	repo.MarkTwoFactorStepUsedFn = func(ctx context.Context, userID uint, step int64) (bool, error) {
+		return false, nil
+	}
+	repo.RegisterTwoFactorFailureFn = func(ctx context.Context, userID uint, maxAttempts int, lockUntil time.Time) error {
+		fallos++
+		return nil
+	}
+	uc := buildLoginUseCase(repo, nil, configConSecreto)
+	token, _ := uc.issueTwoFactorChallenge(42, time.Now())
+
+	got, err := uc.VerifyTwoFactorLogin(context.Background(), domain.VerifyTwoFactorLoginRequest{
+		TwoFactorToken: token,
+		Code:           codigoActual(t, secretoRFC),
+	})
+
+	assert.Nil(t, got)
+	assert.ErrorIs(t, err, domain.ErrTwoFactorInvalidCode)
+	assert.Equal(t, 1, fallos)
+}
+
+func TestVerifyTwoFactorLogin_Bloqueado_NoRevisaElCodigo(t *testing.T) {
+	tf := dosFactoresActivo(42, secretoRFC)
+	hasta := time.Now().Add(time.Minute)
+	tf.LockedUntil = &hasta
+	uc := buildLoginUseCase(repoDesafio(tf), nil, configConSecreto)
+	token, _ := uc.issueTwoFactorChallenge(42, time.Now())
+
+	_, err := uc.VerifyTwoFactorLogin(context.Background(), domain.VerifyTwoFactorLoginRequest{
+		TwoFactorToken: token,
+		Code:           codigoActual(t, secretoRFC),
+	})
+
+	assert.ErrorIs(t, err, domain.ErrTwoFactorLocked)
+}
+
+func TestVerifyTwoFactorLogin_CodigoDeRecuperacion(t *testing.T) {
+	var hashUsado string
+	repo := repoDesafio(dosFactoresActivo(42, secretoRFC))
+	repo.UseRecoveryCodeFn = func(ctx context.Context, userID uint, codeHash string) (bool, error) {
+		hashUsado = codeHash
+		return true, nil
+	}
+	uc := buildLoginUseCase(repo, nil, configConSecreto)
+	token, _ := uc.issueTwoFactorChallenge(42, time.Now())
+
+	got, err := uc.VerifyTwoFactorLogin(context.Background(), domain.VerifyTwoFactorLoginRequest{
+		TwoFactorToken: token,
+		Code:           "abcde-12345",
+	})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, got.Token)
+	assert.Equal(t, hashRecoveryCode(42, "abcde12345"), hashUsado)
+}
+
+func TestVerifyTwoFactorLogin_EnrolamientoPendiente_ActivaYEntregaCodigos(t *testing.T) {
+	var guardados []string
+	activado := false
+	repo := repoDesafio(&domain.UserTwoFactor{ID: 1, UserID: 42, Secret: secretoRFC})
+	repo.EnableTwoFactorFn = func(ctx context.Context, userID uint, step int64, enabledAt time.Time) error {
+		activado = true
+		return nil
+	}
+	repo.ReplaceRecoveryCodesFn = func(ctx context.Context, userID uint, codeHashes []string) error {
+		guardados = codeHashes
+		return nil
+	}
+	uc := buildLoginUseCase(repo, nil, configConSecreto)
+	token, _ := uc.issueTwoFactorChallenge(42, time.Now())
+
+	got, err := uc.VerifyTwoFactorLogin(context.Background(), domain.VerifyTwoFactorLoginRequest{
+		TwoFactorToken: token,
+		Code:           codigoActual(t, secretoRFC),
+	})
+
+	require.NoError(t, err)
+	assert.True(t, activado)
+	assert.Len(t, got.RecoveryCodes, recoveryCodeCount)
+	assert.Len(t, guardados, recoveryCodeCount)
+	assert.NotEmpty(t, got.Token)
+}
+
+func TestVerifyTwoFactorLogin_SinEnrolamiento_PideGenerarQR(t *testing.T) {
+	uc := buildLoginUseCase(repoDesafio(nil), nil, configConSecreto)
+	token, _ := uc.issueTwoFactorChallenge(42, time.Now())
+
+	_, err := uc.VerifyTwoFactorLogin(context.Background(), domain.VerifyTwoFactorLoginRequest{
+		TwoFactorToken: token,
+		Code:           "123456",
+	})
+
+	assert.ErrorIs(t, err, domain.ErrTwoFactorSetupNotStarted)
+}
+
+func TestSetupTwoFactorLogin_GeneraSecretoYQR(t *testing.T) {
+	var secretoGuardado string
+	repo := repoDesafio(nil)
+	repo.UpsertPendingTwoFactorFn = func(ctx context.Context, userID uint, secret string) error {
+		secretoGuardado = secret
+		return nil
+	}
+	uc := buildLoginUseCase(repo, nil, configConSecreto)
+	token, _ := uc.issueTwoFactorChallenge(42, time.Now())
+
+	got, err := uc.SetupTwoFactorLogin(context.Background(), token)
+
+	require.NoError(t, err)
+	assert.Equal(t, secretoGuardado, got.Secret)
+	assert.Contains(t, got.OTPAuthURL, got.Secret)
+	assert.NotEmpty(t, got.QRCodePNG)
+}
+
+func TestSetupTwoFactorLogin_YaActivo_NoReemplazaElSecreto(t *testing.T) {
+	uc := buildLoginUseCase(repoDesafio(dosFactoresActivo(42, secretoRFC)), nil, configConSecreto)
+	token, _ := uc.issueTwoFactorChallenge(42, time.Now())
+
+	_, err := uc.SetupTwoFactorLogin(context.Background(), token)
+
+	assert.ErrorIs(t, err, domain.ErrTwoFactorAlreadyEnabled)
+}
+
+func TestDisableTwoFactor_RolLoExige_NoSeDesactiva(t *testing.T) {
+	rol := rolNegocio()
+	rol.RequireTwoFactor = true
+	repo := &mocks.AuthRepositoryMock{
+		GetUserTwoFactorFn: func(ctx context.Context, userID uint) (*domain.UserTwoFactor, error) {
+			return dosFactoresActivo(userID, secretoRFC), nil
+		},
+		GetUserRolesFn: func(ctx context.Context, userID uint) ([]domain.Role, error) {
+			return []domain.Role{rol}, nil
+		},
+		DeleteTwoFactorFn: func(ctx context.Context, userID uint) error {
+			t.Fatal("no debe borrar el segundo factor")
+			return nil
+		},
+	}
+	uc := buildLoginUseCase(repo, nil, nil)
+
+	err := uc.DisableTwoFactor(context.Background(), 42, codigoActual(t, secretoRFC))
+
+	assert.ErrorIs(t, err, domain.ErrTwoFactorRequiredByRole)
+}
+
+func TestDisableTwoFactor_ConCodigoValido_BorraElSegundoFactor(t *testing.T) {
+	borrado := false
+	repo := &mocks.AuthRepositoryMock{
+		GetUserTwoFactorFn: func(ctx context.Context, userID uint) (*domain.UserTwoFactor, error) {
+			return dosFactoresActivo(userID, secretoRFC), nil
+		},
+		DeleteTwoFactorFn: func(ctx context.Context, userID uint) error {
+			borrado = true
+			return nil
+		},
+	}
+	uc := buildLoginUseCase(repo, nil, nil)
+
+	require.NoError(t, uc.DisableTwoFactor(context.Background(), 42, codigoActual(t, secretoRFC)))
+	assert.True(t, borrado)
+}
+
+func TestRegenerateRecoveryCodes_SinDosFactores_Rechaza(t *testing.T) {
+	uc := buildLoginUseCase(&mocks.AuthRepositoryMock{}, nil, nil)
+
+	_, err := uc.RegenerateRecoveryCodes(context.Background(), 42, "123456")
+
+	assert.ErrorIs(t, err, domain.ErrTwoFactorNotEnabled)
+}
+
+func TestGetTwoFactorStatus_CuentaCodigosRestantes(t *testing.T) {
+	rol := rolNegocio()
+	rol.RequireTwoFactor = true
+	repo := &mocks.AuthRepositoryMock{
+		GetUserTwoFactorFn: func(ctx context.Context, userID uint) (*domain.UserTwoFactor, error) {
+			return dosFactoresActivo(userID, secretoRFC), nil
+		},
+		GetUserRolesFn: func(ctx context.Context, userID uint) ([]domain.Role, error) {
+			return []domain.Role{rol}, nil
+		},
+		CountUnusedRecoveryCodesFn: func(ctx context.Context, userID uint) (int64, error) {
+			return 7, nil
+		},
+	}
+	uc := buildLoginUseCase(repo, nil, nil)
+
+	got, err := uc.GetTwoFactorStatus(context.Background(), 42)
+
+	require.NoError(t, err)
+	assert.True(t, got.Enabled)
+	assert.True(t, got.RequiredByRole)
+	assert.Equal(t, int64(7), got.RecoveryCodesLeft)
+}
@@ -9,6 +9,13 @@ import (
 type LoginRequest struct {
 	Email    string
 	Password string
+	Client   ClientInfo
+}
+
+// ClientInfo describe el dispositivo que inicia sesión (queda en la sesión)
+type ClientInfo struct {
+	IPAddress string
+	UserAgent string
 }
 
 type LoginResponse struct {
@@ -20,6 +27,14 @@ type LoginResponse struct {
 	Businesses            []BusinessInfo
 	Scope                 string // Scope del usuario (platform, business, etc.)
 	IsSuperAdmin          bool   // Indica si es super admin (scope platform o scope_id 1)
+	SessionID             string
+
+	// Con TwoFactorRequired no se emite Token: el cliente completa el login
+	// en /auth/login/2fa con TwoFactorToken
+	TwoFactorRequired      bool
+	TwoFactorSetupRequired bool // El rol exige 2FA y el usuario aún no lo enrola
+	TwoFactorToken         string
+	RecoveryCodes          []string // Solo cuando el enrolamiento se confirma en el login
 }
 
 type UserInfo struct {
@@ -106,6 +121,7 @@ type Role struct {
 	Description      string
 	Level            int
 	IsSystem         bool
+	RequireTwoFactor bool
 	ScopeID          uint
 	ScopeName        string
 	ScopeCode        string
@@ -128,32 +144,6 @@ type Permission struct {
 	BusinessTypeName string
 }
 
-type APIKey struct {
-	ID          uint
-	UserID      uint
-	BusinessID  uint
-	CreatedByID uint
-	Name        string
-	Description string
-	KeyHash     string
-	Revoked     bool
-	RevokedAt   *time.Time
-	LastUsedAt  *time.Time
-	RateLimit   int
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-}
-
-type APIKeyInfo struct {
-	ID          uint
-	UserID      uint
-	BusinessID  uint
-	Name        string
-	Description string
-	RateLimit   int
-	CreatedAt   time.Time
-}
-
 type BusinessStaffRelation struct {
 	UserID     uint
 	BusinessID *uint
@@ -263,30 +253,206 @@ type GeneratePasswordResponse struct {
 	Password string
 	Message  string
 }
-type ValidateAPIKeyRequest struct {
-	APIKey string
+
+type JWTClaims = jwt.JWTClaims
+
+// UserTwoFactor es el segundo factor TOTP del usuario, con el secreto ya descifrado
+type UserTwoFactor struct {
+	ID             uint
+	UserID         uint
+	Secret         string
+	Enabled        bool
+	EnabledAt      *time.Time
+	LastUsedStep   int64
+	FailedAttempts int
+	LockedUntil    *time.Time
+}
+
+type TwoFactorStatus struct {
+	Enabled           bool
+	EnabledAt         *time.Time
+	RequiredByRole    bool
+	RecoveryCodesLeft int64
+}
+
+// TwoFactorSetup es lo que el usuario escanea en su app autenticadora
+type TwoFactorSetup struct {
+	Secret     string
+	OTPAuthURL string
+	QRCodePNG  []byte
+}
+
+// VerifyTwoFactorLoginRequest completa un login que quedó esperando el
+// segundo factor. Code acepta un código TOTP o un código de recuperación.
+type VerifyTwoFactorLoginRequest struct {
+	TwoFactorToken string
+	Code           string
+	Client         ClientInfo
+}
+
+// AuthActor es quien gestiona su propio 2FA y sus sesiones, o las de otro usuario
+type AuthActor struct {
+	UserID       uint
+	BusinessID   uint
+	RoleID       uint
+	IsSuperAdmin bool
+	SessionID    string
+}
+
+type UserSession struct {
+	ID            uint
+	SessionID     string
+	UserID        uint
+	BusinessID    uint
+	UserAgent     string
+	Device        string
+	IPAddress     string
+	CreatedAt     time.Time
+	LastSeenAt    time.Time
+	ExpiresAt     time.Time
+	RevokedAt     *time.Time
+	RevokedReason string
+	Current       bool
 }
-type ValidateAPIKeyResponse struct {
-	Success    bool
-	Message    string
+
+// SSORoleMapping asigna RoleID cuando el claim de roles del IdP trae ClaimValue
+type SSORoleMapping struct {
+	ClaimValue string
+	RoleID     uint
+}
+
+// BusinessSSOConfig es la configuración OIDC de un negocio, con el client
+// secret ya descifrado
+type BusinessSSOConfig struct {
+	ID                   uint
+	BusinessID           uint
+	Enabled              bool
+	ProviderName         string
+	Issuer               string
+	ClientID             string
+	ClientSecret         string
+	Scopes               []string
+	RoleClaim            string
+	RoleMappings         []SSORoleMapping
+	DefaultRoleID        *uint
+	EmailDomains         []string
+	AutoProvision        bool
+	DisablePasswordLogin bool
+	UpdatedAt            time.Time
+	// RedirectURI no se guarda: es la que el negocio registra en su IdP
+	RedirectURI string
+	// Domains es el estado de verificación de cada dominio de EmailDomains
+	Domains []SSODomain
+}
+
+// SSODomain es un dominio de email reclamado por el negocio. Se verifica
+// publicando TXTValue en el registro TXT TXTName; hasta entonces no activa el
+// SSO ni descubre el negocio desde el email.
+type SSODomain struct {
+	Domain            string
+	VerificationToken string
+	VerifiedAt        *time.Time
+	TXTName           string
+	TXTValue          string
+}
+
+// UpsertSSOConfigRequest crea o reemplaza la configuración SSO de un negocio.
+// ClientSecret nil conserva el secreto guardado.
+type UpsertSSOConfigRequest struct {
+	BusinessID           uint
+	Enabled              bool
+	ProviderName         string
+	Issuer               string
+	ClientID             string
+	ClientSecret         *string
+	Scopes               []string
+	RoleClaim            string
+	RoleMappings         []SSORoleMapping
+	DefaultRoleID        *uint
+	EmailDomains         []string
+	AutoProvision        bool
+	DisablePasswordLogin bool
+}
+
+// SSODiscoverRequest busca el SSO de un negocio por su código o por el
+// dominio del email que el usuario escribió en el login
+type SSODiscoverRequest struct {
+	Email        string
+	BusinessCode string
+}
+
+// SSODiscovery le indica a la pantalla de login cómo entra el negocio
+type SSODiscovery struct {
+	BusinessCode          string
+	BusinessName          string
+	ProviderName          string
+	PasswordLoginDisabled bool
+}
+
+// SSOLoginState es un login OIDC en curso, guardado hasta que vuelve el IdP
+type SSOLoginState struct {
+	StateHash    string
+	BusinessID   uint
+	Nonce        string
+	CodeVerifier string
+	RedirectURI  string
+	ExpiresAt    time.Time
+}
+
+// SSOCallbackRequest trae lo que el IdP devolvió a la página de callback
+type SSOCallbackRequest struct {
+	State  string
+	Code   string
+	Client ClientInfo
+}
+
+// OIDCProviderMetadata son los endpoints del documento de descubrimiento del IdP
+type OIDCProviderMetadata struct {
+	Issuer                string
+	AuthorizationEndpoint string
+	TokenEndpoint         string
+	JWKSURI               string
+	// TokenAuthMethods es token_endpoint_auth_methods_supported; vacío
+	// equivale a client_secret_basic
+	TokenAuthMethods []string
+}
+
+// OIDCCodeExchange canjea el authorization code por tokens (con PKCE)
+type OIDCCodeExchange struct {
+	ClientID     string
+	ClientSecret string
+	Code         string
+	CodeVerifier string
+	RedirectURI  string
+}
+
+// OIDCIdentity son los claims de un id_token con firma, issuer, audiencia y
+// vencimiento ya verificados
+type OIDCIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified *bool
+	Name          string
+	Nonce         string
+	Claims        map[string]any
+}
+
+// SSOIdentity vincula un usuario con su sub en el IdP de un negocio
+type SSOIdentity struct {
+	ID         uint
 	UserID     uint
-	Email      string
 	BusinessID uint
-	Roles      []string
-	APIKeyID   uint
+	Issuer     string
+	Subject    string
+	Email      string
 }
 
-type JWTClaims = jwt.JWTClaims
-type GenerateAPIKeyRequest struct {
-	UserID      uint
-	BusinessID  uint
-	Name        string
-	Description string
-	RequesterID uint
-}
-type GenerateAPIKeyResponse struct {
-	Success    bool
-	Message    string
-	APIKey     string
-	APIKeyInfo APIKeyInfo
+// SSOProvisionUser es el usuario que se crea en su primer login por SSO
+type SSOProvisionUser struct {
+	Name         string
+	Email        string
+	PasswordHash string
+	BusinessID   uint
+	RoleID       uint
 }
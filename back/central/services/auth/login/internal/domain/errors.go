@@ -21,4 +21,39 @@ var (
 
 	ErrInvalidCredentials    = errors.New("credenciales inválidas")
 	ErrEmailPasswordRequired = errors.New("email y contraseña son requeridos")
+
+	ErrTwoFactorInvalidCode       = errors.New("código de verificación inválido")
+	ErrTwoFactorLocked            = errors.New("demasiados intentos fallidos, intenta de nuevo en unos minutos")
+	ErrTwoFactorChallengeInvalid  = errors.New("la verificación en dos pasos expiró, inicia sesión de nuevo")
+	ErrTwoFactorNotEnabled        = errors.New("la verificación en dos pasos no está activa")
+	ErrTwoFactorAlreadyEnabled    = errors.New("la verificación en dos pasos ya está activa")
+	ErrTwoFactorSetupNotStarted   = errors.New("primero genera el código QR de la verificación en dos pasos")
+	ErrTwoFactorRequiredByRole    = errors.New("tu rol exige la verificación en dos pasos")
+	ErrTwoFactorSecretUnavailable = errors.New("no se pudo leer el secreto de la verificación en dos pasos")
+	ErrSessionNotFound            = errors.New("sesión no encontrada")
+	ErrSessionRevoked             = errors.New("la sesión fue cerrada, inicia sesión de nuevo")
+	ErrForbiddenUserManagement    = errors.New("no tienes permiso para gestionar este usuario")
+
+	ErrSSONotConfigured          = errors.New("el negocio no tiene inicio de sesión corporativo (SSO) activo")
+	ErrSSOStateInvalid           = errors.New("el inicio de sesión corporativo expiró, inténtalo de nuevo")
+	ErrSSOIdentityInvalid        = errors.New("el proveedor de identidad devolvió una identidad inválida")
+	ErrSSOProviderUnavailable    = errors.New("no se pudo contactar al proveedor de identidad")
+	ErrSSOEmailMissing           = errors.New("el proveedor de identidad no envió el email del usuario")
+	ErrSSOEmailNotVerified       = errors.New("el email del usuario no está verificado en el proveedor de identidad")
+	ErrSSOEmailDomainNotAllowed  = errors.New("el dominio del email no está permitido para este negocio")
+	ErrSSONoRoleMapped           = errors.New("tu usuario no tiene un rol asignado para este negocio en el proveedor de identidad")
+	ErrSSOUserNotProvisioned     = errors.New("tu usuario no existe en este negocio, pide acceso a un administrador")
+	ErrSSOPlatformUser           = errors.New("los usuarios de plataforma no pueden iniciar sesión por SSO, usa tu contraseña")
+	ErrSSOAccountConflict        = errors.New("ya existe un usuario con ese email fuera de este negocio, pide a un administrador que lo agregue")
+	ErrSSORedirectNotConfigured  = errors.New("falta configurar SSO_REDIRECT_URL o FRONTEND_BASE_URL")
+	ErrSSOInvalidConfig          = errors.New("configuración SSO inválida")
+	ErrSSOInvalidRole            = errors.New("el rol del mapeo SSO no existe o no aplica a este negocio")
+	ErrSSOPublicEmailDomain      = errors.New("los dominios de correo público (gmail.com, outlook.com...) no se pueden usar para SSO")
+	ErrSSODomainTaken            = errors.New("el dominio ya está verificado por otro negocio")
+	ErrSSODomainNotVerified      = errors.New("verifica los dominios de email antes de activar el SSO")
+	ErrSSODomainNotFound         = errors.New("el dominio no está en la configuración SSO del negocio")
+	ErrSSODomainVerifyFailed     = errors.New("no se encontró el registro TXT de verificación del dominio")
+	ErrPasswordLoginDisabled     = errors.New("este negocio inicia sesión con su proveedor corporativo (SSO)")
+	ErrBusinessRequired          = errors.New("business_id es requerido")
+	ErrForbiddenBusinessSettings = errors.New("no tienes permiso para gestionar la configuración de este negocio")
 )
@@ -25,9 +25,54 @@ type IAuthRepository interface {
 	GetBusinessConfiguredResourcesIDs(ctx context.Context, businessID uint) ([]uint, error)
 	GetBusinessByID(ctx context.Context, businessID uint) (*BusinessInfo, error)
 	GetRoleByID(ctx context.Context, id uint) (*Role, error)
+
+	// Segundo factor (TOTP) y códigos de recuperación
+	GetUserTwoFactor(ctx context.Context, userID uint) (*UserTwoFactor, error)
+	UpsertPendingTwoFactor(ctx context.Context, userID uint, secret string) error
+	EnableTwoFactor(ctx context.Context, userID uint, step int64, enabledAt time.Time) error
+	MarkTwoFactorStepUsed(ctx context.Context, userID uint, step int64) (bool, error)
+	RegisterTwoFactorFailure(ctx context.Context, userID uint, maxAttempts int, lockUntil time.Time) error
+	DeleteTwoFactor(ctx context.Context, userID uint) error
+	ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID uint, codeHash string) (bool, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID uint) (int64, error)
+
+	// Registro de sesiones (un registro por JWT emitido)
+	CreateSession(ctx context.Context, session *UserSession) error
+	GetSession(ctx context.Context, sessionID string) (*UserSession, error)
+	ListActiveSessions(ctx context.Context, userID uint, now time.Time) ([]UserSession, error)
+	TouchSession(ctx context.Context, sessionID string, ipAddress string, seenAt time.Time) error
+	RevokeSession(ctx context.Context, sessionID string, revokedByID uint, reason string) error
+	RevokeUserSessions(ctx context.Context, userID uint, exceptSessionID string, revokedByID uint, reason string) ([]string, error)
+
+	// SSO (OIDC) por negocio
+	GetBusinessByCode(ctx context.Context, code string) (*BusinessInfo, error)
+	GetSSOConfig(ctx context.Context, businessID uint) (*BusinessSSOConfig, error)
+	FindSSOConfigByEmailDomain(ctx context.Context, emailDomain string) (*BusinessSSOConfig, error)
+	UpsertSSOConfig(ctx context.Context, config *BusinessSSOConfig) error
+	DeleteSSOConfig(ctx context.Context, businessID uint) error
+	IsPasswordLoginDisabled(ctx context.Context, userID uint) (bool, error)
+	CreateSSOLoginState(ctx context.Context, state *SSOLoginState) error
+	ConsumeSSOLoginState(ctx context.Context, stateHash string, now time.Time) (*SSOLoginState, error)
+	GetSSOIdentity(ctx context.Context, businessID uint, issuer, subject string) (*SSOIdentity, error)
+	SaveSSOIdentity(ctx context.Context, identity *SSOIdentity, loginAt time.Time) error
+	ProvisionSSOUser(ctx context.Context, user SSOProvisionUser) (uint, error)
+	AssignBusinessRole(ctx context.Context, userID, businessID, roleID uint) error
+
+	// Dominios de email reclamados para SSO
+	ListSSODomains(ctx context.Context, businessID uint) ([]SSODomain, error)
+	// SSODomainVerifiedByOther es true si otro negocio ya verificó el dominio
+	SSODomainVerifiedByOther(ctx context.Context, emailDomain string, businessID uint) (bool, error)
+	// ReplaceSSODomains deja reclamados exactamente esos dominios: conserva
+	// token y verificación de los que ya estaban
+	ReplaceSSODomains(ctx context.Context, businessID uint, domains []SSODomain) error
+	// MarkSSODomainVerified responde ErrSSODomainTaken si otro negocio lo
+	// verificó primero
+	MarkSSODomainVerified(ctx context.Context, businessID uint, emailDomain string, verifiedAt time.Time) error
 }
 type IJWTService interface {
 	GenerateToken(userID, businessID, businessTypeID, roleID uint, subscriptionStatus string) (string, error)
+	GenerateSessionToken(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error)
 	ValidateToken(tokenString string) (*JWTClaims, error)
 	RefreshToken(tokenString string) (string, error)
 }
@@ -39,3 +84,16 @@ type IEmailSender interface {
 type IOTPEventPublisher interface {
 	PublishPasswordResetOTP(ctx context.Context, event PasswordResetOTPEvent) error
 }
+
+// IDNSResolver consulta los registros TXT con los que un negocio prueba que
+// controla un dominio de email
+type IDNSResolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// IOIDCClient habla con el proveedor de identidad (IdP) de un negocio
+type IOIDCClient interface {
+	Discover(ctx context.Context, issuer string) (*OIDCProviderMetadata, error)
+	ExchangeCode(ctx context.Context, provider *OIDCProviderMetadata, exchange OIDCCodeExchange) (string, error)
+	VerifyIDToken(ctx context.Context, provider *OIDCProviderMetadata, rawIDToken, clientID string) (*OIDCIdentity, error)
+}
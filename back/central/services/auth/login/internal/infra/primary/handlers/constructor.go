@@ -18,6 +18,27 @@ type IAuthHandler interface {
 	ForgotPasswordHandler(c *gin.Context)
 	VerifyOTPHandler(c *gin.Context)
 	ResetPasswordHandler(c *gin.Context)
+	SetupTwoFactorLoginHandler(c *gin.Context)
+	VerifyTwoFactorLoginHandler(c *gin.Context)
+	GetTwoFactorStatusHandler(c *gin.Context)
+	SetupTwoFactorHandler(c *gin.Context)
+	EnableTwoFactorHandler(c *gin.Context)
+	DisableTwoFactorHandler(c *gin.Context)
+	RegenerateRecoveryCodesHandler(c *gin.Context)
+	ResetUserTwoFactorHandler(c *gin.Context)
+	ListSessionsHandler(c *gin.Context)
+	RevokeSessionHandler(c *gin.Context)
+	RevokeOtherSessionsHandler(c *gin.Context)
+	LogoutHandler(c *gin.Context)
+	ListUserSessionsHandler(c *gin.Context)
+	RevokeUserSessionsHandler(c *gin.Context)
+	DiscoverSSOHandler(c *gin.Context)
+	StartSSOHandler(c *gin.Context)
+	SSOCallbackHandler(c *gin.Context)
+	GetSSOConfigHandler(c *gin.Context)
+	UpsertSSOConfigHandler(c *gin.Context)
+	DeleteSSOConfigHandler(c *gin.Context)
+	VerifySSODomainHandler(c *gin.Context)
 	RegisterRoutes(v1Group *gin.RouterGroup, handler IAuthHandler, logger log.ILogger)
 }
 
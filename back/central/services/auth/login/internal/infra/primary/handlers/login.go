@@ -2,9 +2,7 @@ package authhandler
 
 import (
 	"errors"
-	"fmt"
 	"net/http"
-	"os"
 
 	"github.com/gin-gonic/gin"
 	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
@@ -31,6 +29,7 @@ func (h *AuthHandler) LoginHandler(c *gin.Context) {
 	domainRequest := domain.LoginRequest{
 		Email:    loginRequest.Email,
 		Password: loginRequest.Password,
+		Client:   clientInfo(c),
 	}
 
 	domainResponse, err := h.usecase.Login(ctx, domainRequest)
@@ -49,6 +48,16 @@ func (h *AuthHandler) LoginHandler(c *gin.Context) {
 			return
 		}
 
+		// El negocio exige su proveedor corporativo: el frontend redirige al SSO
+		if errors.Is(err, domain.ErrPasswordLoginDisabled) {
+			c.JSON(http.StatusForbidden, gin.H{
+				"error": domain.ErrPasswordLoginDisabled.Error(),
+				"code":  "SSO_REQUIRED",
+				"email": loginRequest.Email,
+			})
+			return
+		}
+
 		switch {
 		case errors.Is(err, domain.ErrInvalidCredentials):
 			statusCode = http.StatusUnauthorized
@@ -70,41 +79,20 @@ func (h *AuthHandler) LoginHandler(c *gin.Context) {
 		return
 	}
 
-	loginResponse := mapper.ToLoginResponse(domainResponse)
-
-	clientType := c.GetHeader("X-Client-Type")
-	isMobileClient := clientType == "mobile" || clientType == "api"
-
-	if !isMobileClient {
-		cookieDomain := os.Getenv("SESSION_COOKIE_DOMAIN")
-		if cookieDomain == "" {
-			cookieDomain = ".probabilityia.com.co"
-		}
-
-		var cookieValue string
-		if cookieDomain == "none" {
-			cookieValue = fmt.Sprintf(
-				"%s=%s; Max-Age=%d; Path=%s; HttpOnly; SameSite=Lax",
-				"session_token",
-				domainResponse.Token,
-				7*24*60*60,
-				"/",
-			)
-		} else {
-			cookieValue = fmt.Sprintf(
-				"%s=%s; Max-Age=%d; Path=%s; Domain=%s; Secure; HttpOnly; SameSite=None; Partitioned",
-				"session_token",
-				domainResponse.Token,
-				7*24*60*60,
-				"/",
-				cookieDomain,
-			)
-		}
-		c.Header("Set-Cookie", cookieValue)
-
-		loginResponse.Token = ""
+	if domainResponse.TwoFactorRequired {
+		h.logger.Info(ctx).
+			Uint("user_id", domainResponse.User.ID).
+			Bool("setup_required", domainResponse.TwoFactorSetupRequired).
+			Msg("Login pendiente de segundo factor")
+		c.JSON(http.StatusOK, response.LoginSuccessResponse{
+			Success: true,
+			Data:    *mapper.ToLoginResponse(domainResponse),
+		})
+		return
 	}
 
+	loginResponse, clientType := h.issueSession(c, domainResponse)
+
 	h.logger.Info(ctx).
 		Str("email", loginRequest.Email).
 		Uint("user_id", domainResponse.User.ID).
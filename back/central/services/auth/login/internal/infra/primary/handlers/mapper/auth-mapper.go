@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"encoding/base64"
+
 	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
 	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/handlers/response"
 )
@@ -53,11 +55,15 @@ func ToLoginResponse(domainResponse *domain.LoginResponse) *response.LoginRespon
 			IsActive:    domainResponse.User.IsActive,
 			LastLoginAt: domainResponse.User.LastLoginAt,
 		},
-		Token:                 domainResponse.Token,
-		RequirePasswordChange: domainResponse.RequirePasswordChange,
-		Businesses:            businesses,
-		Scope:                 domainResponse.Scope,
-		IsSuperAdmin:          domainResponse.IsSuperAdmin,
+		Token:                  domainResponse.Token,
+		RequirePasswordChange:  domainResponse.RequirePasswordChange,
+		Businesses:             businesses,
+		Scope:                  domainResponse.Scope,
+		IsSuperAdmin:           domainResponse.IsSuperAdmin,
+		TwoFactorRequired:      domainResponse.TwoFactorRequired,
+		TwoFactorSetupRequired: domainResponse.TwoFactorSetupRequired,
+		TwoFactorToken:         domainResponse.TwoFactorToken,
+		RecoveryCodes:          domainResponse.RecoveryCodes,
 	}
 }
 
@@ -150,3 +156,31 @@ func groupPermissionsByResource(permissions []domain.PermissionInfo) []response.
 
 	return resources
 }
+
+// ToTwoFactorSetupResponse convierte el enrolamiento a response con el QR en base64
+func ToTwoFactorSetupResponse(setup *domain.TwoFactorSetup) response.TwoFactorSetupResponse {
+	return response.TwoFactorSetupResponse{
+		Secret:     setup.Secret,
+		OTPAuthURL: setup.OTPAuthURL,
+		QRCode:     "data:image/png;base64," + base64.StdEncoding.EncodeToString(setup.QRCodePNG),
+	}
+}
+
+// ToSessionsResponse convierte las sesiones del dominio a response
+func ToSessionsResponse(sessions []domain.UserSession) []response.SessionResponse {
+	result := make([]response.SessionResponse, 0, len(sessions))
+	for _, s := range sessions {
+		result = append(result, response.SessionResponse{
+			ID:         s.SessionID,
+			Device:     s.Device,
+			UserAgent:  s.UserAgent,
+			IPAddress:  s.IPAddress,
+			BusinessID: s.BusinessID,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+			ExpiresAt:  s.ExpiresAt,
+			Current:    s.Current,
+		})
+	}
+	return result
+}
@@ -0,0 +1,88 @@
+package mapper
+
+import (
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/handlers/response"
+)
+
+func ToUpsertSSOConfigRequest(req request.UpsertSSOConfigRequest, businessID uint) domain.UpsertSSOConfigRequest {
+	mappings := make([]domain.SSORoleMapping, 0, len(req.RoleMappings))
+	for _, m := range req.RoleMappings {
+		mappings = append(mappings, domain.SSORoleMapping{ClaimValue: m.ClaimValue, RoleID: m.RoleID})
+	}
+	autoProvision := true
+	if req.AutoProvision != nil {
+		autoProvision = *req.AutoProvision
+	}
+
+	return domain.UpsertSSOConfigRequest{
+		BusinessID:           businessID,
+		Enabled:              req.Enabled,
+		ProviderName:         req.ProviderName,
+		Issuer:               req.Issuer,
+		ClientID:             req.ClientID,
+		ClientSecret:         req.ClientSecret,
+		Scopes:               req.Scopes,
+		RoleClaim:            req.RoleClaim,
+		RoleMappings:         mappings,
+		DefaultRoleID:        req.DefaultRoleID,
+		EmailDomains:         req.EmailDomains,
+		AutoProvision:        autoProvision,
+		DisablePasswordLogin: req.DisablePasswordLogin,
+	}
+}
+
+func ToSSOConfigResponse(config *domain.BusinessSSOConfig) response.SSOConfigResponse {
+	mappings := make([]response.SSORoleMappingResponse, 0, len(config.RoleMappings))
+	for _, m := range config.RoleMappings {
+		mappings = append(mappings, response.SSORoleMappingResponse{ClaimValue: m.ClaimValue, RoleID: m.RoleID})
+	}
+	domains := config.EmailDomains
+	if domains == nil {
+		domains = []string{}
+	}
+
+	claims := make([]response.SSODomainResponse, 0, len(config.Domains))
+	for _, d := range config.Domains {
+		claims = append(claims, ToSSODomainResponse(&d))
+	}
+
+	return response.SSOConfigResponse{
+		BusinessID:           config.BusinessID,
+		Enabled:              config.Enabled,
+		ProviderName:         config.ProviderName,
+		Issuer:               config.Issuer,
+		ClientID:             config.ClientID,
+		HasClientSecret:      config.ClientSecret != "",
+		Scopes:               config.Scopes,
+		RoleClaim:            config.RoleClaim,
+		RoleMappings:         mappings,
+		DefaultRoleID:        config.DefaultRoleID,
+		EmailDomains:         domains,
+		Domains:              claims,
+		AutoProvision:        config.AutoProvision,
+		DisablePasswordLogin: config.DisablePasswordLogin,
+		RedirectURI:          config.RedirectURI,
+		UpdatedAt:            config.UpdatedAt,
+	}
+}
+
+func ToSSODomainResponse(claim *domain.SSODomain) response.SSODomainResponse {
+	return response.SSODomainResponse{
+		Domain:     claim.Domain,
+		Verified:   claim.VerifiedAt != nil,
+		VerifiedAt: claim.VerifiedAt,
+		TXTName:    claim.TXTName,
+		TXTValue:   claim.TXTValue,
+	}
+}
+
+func ToSSODiscoveryResponse(discovery *domain.SSODiscovery) response.SSODiscoveryResponse {
+	return response.SSODiscoveryResponse{
+		BusinessCode:          discovery.BusinessCode,
+		BusinessName:          discovery.BusinessName,
+		ProviderName:          discovery.ProviderName,
+		PasswordLoginDisabled: discovery.PasswordLoginDisabled,
+	}
+}
@@ -0,0 +1,46 @@
+package request
+
+// SSODiscoverRequest identifica el negocio por su código o por el email
+type SSODiscoverRequest struct {
+	Email        string `json:"email"`
+	BusinessCode string `json:"business_code"`
+}
+
+// SSOStartRequest inicia el login corporativo del negocio
+type SSOStartRequest struct {
+	BusinessCode string `json:"business_code" binding:"required"`
+}
+
+// SSOCallbackRequest es lo que la página de callback recibió del IdP
+type SSOCallbackRequest struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+}
+
+// SSORoleMappingRequest asigna role_id cuando el claim trae claim_value
+type SSORoleMappingRequest struct {
+	ClaimValue string `json:"claim_value" binding:"required"`
+	RoleID     uint   `json:"role_id" binding:"required"`
+}
+
+// UpsertSSOConfigRequest reemplaza la configuración OIDC del negocio.
+// Sin client_secret se conserva el guardado.
+type UpsertSSOConfigRequest struct {
+	Enabled              bool                    `json:"enabled"`
+	ProviderName         string                  `json:"provider_name"`
+	Issuer               string                  `json:"issuer" binding:"required"`
+	ClientID             string                  `json:"client_id" binding:"required"`
+	ClientSecret         *string                 `json:"client_secret"`
+	Scopes               []string                `json:"scopes"`
+	RoleClaim            string                  `json:"role_claim"`
+	RoleMappings         []SSORoleMappingRequest `json:"role_mappings" binding:"dive"`
+	DefaultRoleID        *uint                   `json:"default_role_id"`
+	EmailDomains         []string                `json:"email_domains"`
+	AutoProvision        *bool                   `json:"auto_provision"`
+	DisablePasswordLogin bool                    `json:"disable_password_login"`
+}
+
+// SSODomainVerifyRequest pide revisar el TXT de un dominio de email
+type SSODomainVerifyRequest struct {
+	Domain string `json:"domain" binding:"required"`
+}
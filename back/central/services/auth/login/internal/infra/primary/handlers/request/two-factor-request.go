@@ -0,0 +1,18 @@
+package request
+
+// TwoFactorLoginSetupRequest pide el QR durante un login que exige enrolar 2FA
+type TwoFactorLoginSetupRequest struct {
+	TwoFactorToken string `json:"two_factor_token" binding:"required"`
+}
+
+// TwoFactorLoginVerifyRequest completa el login con el código de la app o
+// un código de recuperación
+type TwoFactorLoginVerifyRequest struct {
+	TwoFactorToken string `json:"two_factor_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+// TwoFactorCodeRequest confirma una operación sobre el 2FA propio
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
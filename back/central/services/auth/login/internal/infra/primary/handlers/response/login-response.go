@@ -10,6 +10,12 @@ type LoginResponse struct {
 	Businesses            []BusinessInfo `json:"businesses"`
 	Scope                 string         `json:"scope"`          // Scope del usuario (platform, business, etc.)
 	IsSuperAdmin          bool           `json:"is_super_admin"` // Indica si es super admin (scope platform o scope_id 1)
+
+	// Login pendiente de segundo factor: se completa en /auth/login/2fa/verify
+	TwoFactorRequired      bool     `json:"two_factor_required"`
+	TwoFactorSetupRequired bool     `json:"two_factor_setup_required,omitempty"`
+	TwoFactorToken         string   `json:"two_factor_token,omitempty"`
+	RecoveryCodes          []string `json:"recovery_codes,omitempty"` // Solo al confirmar el enrolamiento
 }
 
 // UserInfo representa la información del usuario en la respuesta
@@ -0,0 +1,52 @@
+package response
+
+import "time"
+
+// SSODiscoveryResponse le indica a la pantalla de login que muestre el
+// botón del proveedor corporativo
+type SSODiscoveryResponse struct {
+	BusinessCode          string `json:"business_code"`
+	BusinessName          string `json:"business_name"`
+	ProviderName          string `json:"provider_name"`
+	PasswordLoginDisabled bool   `json:"password_login_disabled"`
+}
+
+// SSOStartResponse trae la URL del IdP a la que el navegador debe ir
+type SSOStartResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+// SSODomainResponse dice si el dominio ya está verificado y qué TXT publicar
+type SSODomainResponse struct {
+	Domain     string     `json:"domain"`
+	Verified   bool       `json:"verified"`
+	VerifiedAt *time.Time `json:"verified_at"`
+	TXTName    string     `json:"txt_name"`
+	TXTValue   string     `json:"txt_value"`
+}
+
+type SSORoleMappingResponse struct {
+	ClaimValue string `json:"claim_value"`
+	RoleID     uint   `json:"role_id"`
+}
+
+// SSOConfigResponse es la configuración OIDC del negocio; el client secret
+// nunca sale, solo si está configurado
+type SSOConfigResponse struct {
+	BusinessID           uint                     `json:"business_id"`
+	Enabled              bool                     `json:"enabled"`
+	ProviderName         string                   `json:"provider_name"`
+	Issuer               string                   `json:"issuer"`
+	ClientID             string                   `json:"client_id"`
+	HasClientSecret      bool                     `json:"has_client_secret"`
+	Scopes               []string                 `json:"scopes"`
+	RoleClaim            string                   `json:"role_claim"`
+	RoleMappings         []SSORoleMappingResponse `json:"role_mappings"`
+	DefaultRoleID        *uint                    `json:"default_role_id"`
+	EmailDomains         []string                 `json:"email_domains"`
+	Domains              []SSODomainResponse      `json:"domains"`
+	AutoProvision        bool                     `json:"auto_provision"`
+	DisablePasswordLogin bool                     `json:"disable_password_login"`
+	RedirectURI          string                   `json:"redirect_uri"`
+	UpdatedAt            time.Time                `json:"updated_at"`
+}
@@ -0,0 +1,37 @@
+package response
+
+import "time"
+
+// TwoFactorStatusResponse es el estado del 2FA del usuario autenticado
+type TwoFactorStatusResponse struct {
+	Enabled           bool       `json:"enabled"`
+	EnabledAt         *time.Time `json:"enabled_at,omitempty"`
+	RequiredByRole    bool       `json:"required_by_role"`
+	RecoveryCodesLeft int64      `json:"recovery_codes_left"`
+}
+
+// TwoFactorSetupResponse trae lo necesario para agregar la cuenta a la app
+// autenticadora: el QR (PNG en base64) o el secreto para ingresarlo a mano
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURL string `json:"otpauth_url"`
+	QRCode     string `json:"qr_code"`
+}
+
+// RecoveryCodesResponse muestra los códigos de recuperación una sola vez
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// SessionResponse es una sesión activa del usuario
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	BusinessID uint      `json:"business_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
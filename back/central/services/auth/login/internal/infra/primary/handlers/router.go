@@ -18,5 +18,41 @@ func (h *AuthHandler) RegisterRoutes(v1Group *gin.RouterGroup, handler IAuthHand
 		authGroup.POST("/forgot-password", handler.ForgotPasswordHandler)
 		authGroup.POST("/verify-otp", handler.VerifyOTPHandler)
 		authGroup.POST("/reset-password", handler.ResetPasswordHandler)
+
+		// Segundo paso del login: se autentican con el two_factor_token
+		authGroup.POST("/login/2fa/setup", handler.SetupTwoFactorLoginHandler)
+		authGroup.POST("/login/2fa/verify", handler.VerifyTwoFactorLoginHandler)
+
+		twoFactor := authGroup.Group("/2fa", middleware.JWT(), middleware.RequireJWT())
+		twoFactor.GET("", handler.GetTwoFactorStatusHandler)
+		twoFactor.POST("/setup", handler.SetupTwoFactorHandler)
+		twoFactor.POST("/enable", handler.EnableTwoFactorHandler)
+		twoFactor.POST("/disable", handler.DisableTwoFactorHandler)
+		twoFactor.POST("/recovery-codes", handler.RegenerateRecoveryCodesHandler)
+
+		sessions := authGroup.Group("/sessions", middleware.JWT(), middleware.RequireJWT())
+		sessions.GET("", handler.ListSessionsHandler)
+		sessions.POST("/revoke-others", handler.RevokeOtherSessionsHandler)
+		sessions.DELETE("/:id", handler.RevokeSessionHandler)
+
+		authGroup.POST("/logout", middleware.JWT(), handler.LogoutHandler)
+
+		// Login corporativo (OIDC): el frontend recibe el code del IdP y lo
+		// envía a /sso/callback junto con el state
+		authGroup.POST("/sso/discover", handler.DiscoverSSOHandler)
+		authGroup.POST("/sso/start", handler.StartSSOHandler)
+		authGroup.POST("/sso/callback", handler.SSOCallbackHandler)
+
+		ssoConfig := authGroup.Group("/sso/config", middleware.JWT(), middleware.RequireJWT())
+		ssoConfig.GET("", handler.GetSSOConfigHandler)
+		ssoConfig.PUT("", handler.UpsertSSOConfigHandler)
+		ssoConfig.DELETE("", handler.DeleteSSOConfigHandler)
+		ssoConfig.POST("/domains/verify", handler.VerifySSODomainHandler)
+
+		// Administradores: 2FA y sesiones de otros usuarios de su negocio
+		users := authGroup.Group("/users/:id", middleware.JWT(), middleware.RequireJWT())
+		users.POST("/2fa/reset", handler.ResetUserTwoFactorHandler)
+		users.GET("/sessions", handler.ListUserSessionsHandler)
+		users.POST("/sessions/revoke", handler.RevokeUserSessionsHandler)
 	}
 }
@@ -0,0 +1,83 @@
+package authhandler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/handlers/response"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+)
+
+// handleSecurityError traduce los errores de 2FA y sesiones a HTTP
+func (h *AuthHandler) handleSecurityError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	message := "Error interno del servidor"
+
+	switch {
+	case errors.Is(err, domain.ErrTwoFactorInvalidCode),
+		errors.Is(err, domain.ErrTwoFactorChallengeInvalid):
+		status = http.StatusUnauthorized
+		message = err.Error()
+	case errors.Is(err, domain.ErrTwoFactorLocked):
+		status = http.StatusTooManyRequests
+		message = err.Error()
+	case errors.Is(err, domain.ErrTwoFactorNotEnabled),
+		errors.Is(err, domain.ErrTwoFactorSetupNotStarted):
+		status = http.StatusBadRequest
+		message = err.Error()
+	case errors.Is(err, domain.ErrTwoFactorAlreadyEnabled):
+		status = http.StatusConflict
+		message = err.Error()
+	case errors.Is(err, domain.ErrTwoFactorRequiredByRole),
+		errors.Is(err, domain.ErrForbiddenUserManagement):
+		status = http.StatusForbidden
+		message = err.Error()
+	case errors.Is(err, domain.ErrUserNotFound),
+		errors.Is(err, domain.ErrSessionNotFound):
+		status = http.StatusNotFound
+		message = err.Error()
+	default:
+		h.logger.Error(c.Request.Context()).Err(err).Msg("Error en verificacion en dos pasos o sesiones")
+	}
+
+	c.JSON(status, response.LoginErrorResponse{Error: message})
+}
+
+// actorFromContext arma quien hace la operación a partir del JWT
+func actorFromContext(c *gin.Context) (domain.AuthActor, bool) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok || userID == 0 {
+		return domain.AuthActor{}, false
+	}
+	businessID, _ := middleware.GetBusinessID(c)
+	roleID, _ := middleware.GetRoleID(c)
+	sessionID, _ := middleware.GetSessionID(c)
+
+	return domain.AuthActor{
+		UserID:       userID,
+		BusinessID:   businessID,
+		RoleID:       roleID,
+		IsSuperAdmin: middleware.IsSuperAdmin(c),
+		SessionID:    sessionID,
+	}, true
+}
+
+func (h *AuthHandler) requireActor(c *gin.Context) (domain.AuthActor, bool) {
+	actor, ok := actorFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, response.LoginErrorResponse{Error: "Usuario no autenticado"})
+	}
+	return actor, ok
+}
+
+func parseTargetUserID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, response.LoginErrorResponse{Error: "ID de usuario inválido"})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,77 @@
+package authhandler
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/handlers/mapper"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/handlers/response"
+)
+
+const sessionCookieMaxAge = 7 * 24 * 60 * 60
+
+// issueSession entrega el token de un login completo: en cookie HttpOnly para
+// el navegador, o en el body para clientes móviles y de API
+func (h *AuthHandler) issueSession(c *gin.Context, domainResponse *domain.LoginResponse) (*response.LoginResponse, string) {
+	loginResponse := mapper.ToLoginResponse(domainResponse)
+
+	clientType := c.GetHeader("X-Client-Type")
+	isMobileClient := clientType == "mobile" || clientType == "api"
+
+	if !isMobileClient {
+		c.Header("Set-Cookie", sessionCookie(domainResponse.Token, sessionCookieMaxAge))
+		loginResponse.Token = ""
+	}
+
+	return loginResponse, clientType
+}
+
+// clearSessionCookie borra la cookie de sesión al cerrar sesión
+func clearSessionCookie(c *gin.Context) {
+	c.Header("Set-Cookie", sessionCookie("", -1))
+}
+
+func sessionCookie(value string, maxAge int) string {
+	cookieDomain := os.Getenv("SESSION_COOKIE_DOMAIN")
+	if cookieDomain == "" {
+		cookieDomain = ".probabilityia.com.co"
+	}
+
+	if cookieDomain == "none" {
+		return fmt.Sprintf(
+			"%s=%s; Max-Age=%d; Path=%s; HttpOnly; SameSite=Lax",
+			"session_token",
+			value,
+			maxAge,
+			"/",
+		)
+	}
+	return fmt.Sprintf(
+		"%s=%s; Max-Age=%d; Path=%s; Domain=%s; Secure; HttpOnly; SameSite=None; Partitioned",
+		"session_token",
+		value,
+		maxAge,
+		"/",
+		cookieDomain,
+	)
+}
+
+// clientInfo toma el dispositivo que queda registrado en la sesión
+func clientInfo(c *gin.Context) domain.ClientInfo {
+	return domain.ClientInfo{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
+// writeLoginSuccess responde un login completo (con o sin segundo factor)
+func (h *AuthHandler) writeLoginSuccess(c *gin.Context, domainResponse *domain.LoginResponse) {
+	loginResponse, _ := h.issueSession(c, domainResponse)
+	c.JSON(http.StatusOK, response.LoginSuccessResponse{
+		Success: true,
+		Data:    *loginResponse,
+	})
+}
@@ -0,0 +1,141 @@
+package authhandler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/handlers/mapper"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+// ListSessionsHandler lista las sesiones activas del usuario autenticado
+func (h *AuthHandler) ListSessionsHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "ListSessionsHandler")
+	actor, ok := h.requireActor(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.usecase.ListSessions(ctx, actor)
+	if err != nil {
+		h.handleSecurityError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    mapper.ToSessionsResponse(sessions),
+	})
+}
+
+// RevokeSessionHandler cierra una sesión propia en otro dispositivo
+func (h *AuthHandler) RevokeSessionHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "RevokeSessionHandler")
+	actor, ok := h.requireActor(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.RevokeSession(ctx, actor, c.Param("id")); err != nil {
+		h.handleSecurityError(c, err)
+		return
+	}
+
+	if c.Param("id") == actor.SessionID {
+		clearSessionCookie(c)
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Sesión cerrada",
+	})
+}
+
+// RevokeOtherSessionsHandler cierra todas las sesiones menos la actual
+func (h *AuthHandler) RevokeOtherSessionsHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "RevokeOtherSessionsHandler")
+	actor, ok := h.requireActor(c)
+	if !ok {
+		return
+	}
+
+	revoked, err := h.usecase.RevokeOtherSessions(ctx, actor)
+	if err != nil {
+		h.handleSecurityError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Sesiones cerradas en los demás dispositivos",
+		"data":    gin.H{"revoked": revoked},
+	})
+}
+
+// LogoutHandler cierra la sesión actual y borra la cookie
+func (h *AuthHandler) LogoutHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "LogoutHandler")
+	actor, ok := h.requireActor(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.Logout(ctx, actor); err != nil {
+		h.handleSecurityError(c, err)
+		return
+	}
+
+	clearSessionCookie(c)
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Sesión cerrada",
+	})
+}
+
+// ListUserSessionsHandler lista las sesiones activas de otro usuario (admin)
+func (h *AuthHandler) ListUserSessionsHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "ListUserSessionsHandler")
+	actor, ok := h.requireActor(c)
+	if !ok {
+		return
+	}
+	targetUserID, ok := parseTargetUserID(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.usecase.ListUserSessions(ctx, actor, targetUserID)
+	if err != nil {
+		h.handleSecurityError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    mapper.ToSessionsResponse(sessions),
+	})
+}
+
+// RevokeUserSessionsHandler fuerza el cierre de todas las sesiones de otro usuario (admin)
+func (h *AuthHandler) RevokeUserSessionsHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "RevokeUserSessionsHandler")
+	actor, ok := h.requireActor(c)
+	if !ok {
+		return
+	}
+	targetUserID, ok := parseTargetUserID(c)
+	if !ok {
+		return
+	}
+
+	revoked, err := h.usecase.RevokeUserSessions(ctx, actor, targetUserID)
+	if err != nil {
+		h.handleSecurityError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Sesiones del usuario cerradas",
+		"data":    gin.H{"revoked": revoked},
+	})
+}
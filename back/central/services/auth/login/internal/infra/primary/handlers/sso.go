@@ -0,0 +1,234 @@
+package authhandler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/handlers/mapper"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/handlers/response"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+// DiscoverSSOHandler indica si el negocio del email (o del código) entra con SSO
+func (h *AuthHandler) DiscoverSSOHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "DiscoverSSOHandler")
+
+	var req request.SSODiscoverRequest
+	if err := c.ShouldBindJSON(&req); err != nil || (req.Email == "" && req.BusinessCode == "") {
+		c.JSON(http.StatusBadRequest, response.LoginErrorResponse{Error: "email o business_code es requerido"})
+		return
+	}
+
+	discovery, err := h.usecase.DiscoverSSO(ctx, domain.SSODiscoverRequest{Email: req.Email, BusinessCode: req.BusinessCode})
+	if err != nil {
+		h.handleSSOError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    mapper.ToSSODiscoveryResponse(discovery),
+	})
+}
+
+// StartSSOHandler devuelve la URL del proveedor de identidad del negocio
+func (h *AuthHandler) StartSSOHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "StartSSOHandler")
+
+	var req request.SSOStartRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.LoginBadRequestResponse{Error: "Datos de entrada inválidos", Details: err.Error()})
+		return
+	}
+
+	authorizationURL, err := h.usecase.StartSSO(ctx, req.BusinessCode)
+	if err != nil {
+		h.handleSSOError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    response.SSOStartResponse{AuthorizationURL: authorizationURL},
+	})
+}
+
+// SSOCallbackHandler completa el login con el code y el state que el IdP
+// entregó a la página de callback del frontend
+func (h *AuthHandler) SSOCallbackHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "SSOCallbackHandler")
+
+	var req request.SSOCallbackRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.LoginBadRequestResponse{Error: "Datos de entrada inválidos", Details: err.Error()})
+		return
+	}
+
+	domainResponse, err := h.usecase.CompleteSSOLogin(ctx, domain.SSOCallbackRequest{
+		State:  req.State,
+		Code:   req.Code,
+		Client: clientInfo(c),
+	})
+	if err != nil {
+		h.handleSSOError(c, err)
+		return
+	}
+
+	h.writeLoginSuccess(c, domainResponse)
+}
+
+// GetSSOConfigHandler muestra la configuración SSO del negocio
+func (h *AuthHandler) GetSSOConfigHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "GetSSOConfigHandler")
+	actor, ok := h.requireActor(c)
+	if !ok {
+		return
+	}
+
+	config, err := h.usecase.GetSSOConfig(ctx, actor, businessIDQuery(c))
+	if err != nil {
+		h.handleSSOError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    mapper.ToSSOConfigResponse(config),
+	})
+}
+
+// UpsertSSOConfigHandler crea o reemplaza la configuración SSO del negocio
+func (h *AuthHandler) UpsertSSOConfigHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "UpsertSSOConfigHandler")
+	actor, ok := h.requireActor(c)
+	if !ok {
+		return
+	}
+
+	var req request.UpsertSSOConfigRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.LoginBadRequestResponse{Error: "Datos de entrada inválidos", Details: err.Error()})
+		return
+	}
+
+	config, err := h.usecase.UpsertSSOConfig(ctx, actor, mapper.ToUpsertSSOConfigRequest(req, businessIDQuery(c)))
+	if err != nil {
+		h.handleSSOError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    mapper.ToSSOConfigResponse(config),
+	})
+}
+
+// DeleteSSOConfigHandler quita el SSO; el negocio vuelve a entrar con contraseña
+func (h *AuthHandler) DeleteSSOConfigHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "DeleteSSOConfigHandler")
+	actor, ok := h.requireActor(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.DeleteSSOConfig(ctx, actor, businessIDQuery(c)); err != nil {
+		h.handleSSOError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Configuración SSO eliminada",
+	})
+}
+
+// VerifySSODomainHandler revisa el TXT del dominio; verificado, el dominio
+// queda reservado para este negocio y ya se puede activar el SSO
+func (h *AuthHandler) VerifySSODomainHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "VerifySSODomainHandler")
+	actor, ok := h.requireActor(c)
+	if !ok {
+		return
+	}
+
+	var req request.SSODomainVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.LoginBadRequestResponse{Error: "Datos de entrada inválidos", Details: err.Error()})
+		return
+	}
+
+	claim, err := h.usecase.VerifySSODomain(ctx, actor, businessIDQuery(c), req.Domain)
+	if err != nil {
+		h.handleSSOError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    mapper.ToSSODomainResponse(claim),
+	})
+}
+
+// businessIDQuery es el ?business_id con el que el super admin elige negocio;
+// para los demás el caso de uso usa el negocio del token
+func businessIDQuery(c *gin.Context) uint {
+	if v, err := strconv.ParseUint(c.Query("business_id"), 10, 64); err == nil {
+		return uint(v)
+	}
+	return 0
+}
+
+// handleSSOError traduce los errores del login corporativo a HTTP
+func (h *AuthHandler) handleSSOError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	message := "Error interno del servidor"
+
+	switch {
+	case errors.Is(err, domain.ErrSSOStateInvalid),
+		errors.Is(err, domain.ErrSSOIdentityInvalid):
+		status = http.StatusUnauthorized
+		message = err.Error()
+	case errors.Is(err, domain.ErrSSOEmailMissing),
+		errors.Is(err, domain.ErrSSOEmailNotVerified),
+		errors.Is(err, domain.ErrSSOEmailDomainNotAllowed),
+		errors.Is(err, domain.ErrSSONoRoleMapped),
+		errors.Is(err, domain.ErrSSOUserNotProvisioned),
+		errors.Is(err, domain.ErrSSOAccountConflict),
+		errors.Is(err, domain.ErrSSOPlatformUser),
+		errors.Is(err, domain.ErrUserInactive),
+		errors.Is(err, domain.ErrForbiddenBusinessSettings):
+		status = http.StatusForbidden
+		message = err.Error()
+	case errors.Is(err, domain.ErrSSONotConfigured),
+		errors.Is(err, domain.ErrSSODomainNotFound),
+		errors.Is(err, domain.ErrUserNotFound):
+		status = http.StatusNotFound
+		message = err.Error()
+	case errors.Is(err, domain.ErrSSOInvalidConfig),
+		errors.Is(err, domain.ErrSSOInvalidRole),
+		errors.Is(err, domain.ErrSSOPublicEmailDomain),
+		errors.Is(err, domain.ErrSSODomainNotVerified),
+		errors.Is(err, domain.ErrSSODomainVerifyFailed),
+		errors.Is(err, domain.ErrBusinessRequired):
+		status = http.StatusBadRequest
+		message = err.Error()
+	case errors.Is(err, domain.ErrSSODomainTaken):
+		status = http.StatusConflict
+		message = err.Error()
+	case errors.Is(err, domain.ErrSSOProviderUnavailable):
+		status = http.StatusBadGateway
+		message = err.Error()
+	case errors.Is(err, domain.ErrSSORedirectNotConfigured):
+		h.logger.Error(c.Request.Context()).Err(err).Msg("SSO sin redirect_uri configurada")
+		status = http.StatusServiceUnavailable
+		message = err.Error()
+	default:
+		h.logger.Error(c.Request.Context()).Err(err).Msg("Error en login corporativo (SSO)")
+	}
+
+	c.JSON(status, response.LoginErrorResponse{Error: message})
+}
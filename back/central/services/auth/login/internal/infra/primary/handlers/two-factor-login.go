@@ -0,0 +1,70 @@
+package authhandler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/handlers/mapper"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/handlers/response"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+// SetupTwoFactorLoginHandler genera el QR cuando el rol exige 2FA y el
+// usuario aún no lo enrola. Se autentica con el two_factor_token del login.
+func (h *AuthHandler) SetupTwoFactorLoginHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "SetupTwoFactorLoginHandler")
+
+	var req request.TwoFactorLoginSetupRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.LoginBadRequestResponse{
+			Error:   "Datos de entrada inválidos",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	setup, err := h.usecase.SetupTwoFactorLogin(ctx, req.TwoFactorToken)
+	if err != nil {
+		h.handleSecurityError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    mapper.ToTwoFactorSetupResponse(setup),
+	})
+}
+
+// VerifyTwoFactorLoginHandler completa el login con el segundo factor y
+// entrega la sesión igual que /auth/login
+func (h *AuthHandler) VerifyTwoFactorLoginHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "VerifyTwoFactorLoginHandler")
+
+	var req request.TwoFactorLoginVerifyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.LoginBadRequestResponse{
+			Error:   "Datos de entrada inválidos",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	domainResponse, err := h.usecase.VerifyTwoFactorLogin(ctx, domain.VerifyTwoFactorLoginRequest{
+		TwoFactorToken: req.TwoFactorToken,
+		Code:           req.Code,
+		Client:         clientInfo(c),
+	})
+	if err != nil {
+		h.handleSecurityError(c, err)
+		return
+	}
+
+	h.logger.Info(ctx).
+		Uint("user_id", domainResponse.User.ID).
+		Bool("enrolled", len(domainResponse.RecoveryCodes) > 0).
+		Msg("Login exitoso con segundo factor")
+
+	h.writeLoginSuccess(c, domainResponse)
+}
@@ -0,0 +1,145 @@
+package authhandler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/handlers/mapper"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/infra/primary/handlers/response"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+// GetTwoFactorStatusHandler retorna el estado del 2FA del usuario autenticado
+func (h *AuthHandler) GetTwoFactorStatusHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "GetTwoFactorStatusHandler")
+	actor, ok := h.requireActor(c)
+	if !ok {
+		return
+	}
+
+	status, err := h.usecase.GetTwoFactorStatus(ctx, actor.UserID)
+	if err != nil {
+		h.handleSecurityError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": response.TwoFactorStatusResponse{
+			Enabled:           status.Enabled,
+			EnabledAt:         status.EnabledAt,
+			RequiredByRole:    status.RequiredByRole,
+			RecoveryCodesLeft: status.RecoveryCodesLeft,
+		},
+	})
+}
+
+// SetupTwoFactorHandler genera el secreto y el QR; queda pendiente hasta enable
+func (h *AuthHandler) SetupTwoFactorHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "SetupTwoFactorHandler")
+	actor, ok := h.requireActor(c)
+	if !ok {
+		return
+	}
+
+	setup, err := h.usecase.SetupTwoFactor(ctx, actor.UserID)
+	if err != nil {
+		h.handleSecurityError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    mapper.ToTwoFactorSetupResponse(setup),
+	})
+}
+
+// EnableTwoFactorHandler confirma el enrolamiento con el primer código
+func (h *AuthHandler) EnableTwoFactorHandler(c *gin.Context) {
+	h.withTwoFactorCode(c, "EnableTwoFactorHandler", func(c *gin.Context, userID uint, code string) {
+		codes, err := h.usecase.EnableTwoFactor(c.Request.Context(), userID, code)
+		if err != nil {
+			h.handleSecurityError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"message": "Verificación en dos pasos activada. Guarda los códigos de recuperación.",
+			"data":    response.RecoveryCodesResponse{RecoveryCodes: codes},
+		})
+	})
+}
+
+// DisableTwoFactorHandler desactiva el 2FA propio
+func (h *AuthHandler) DisableTwoFactorHandler(c *gin.Context) {
+	h.withTwoFactorCode(c, "DisableTwoFactorHandler", func(c *gin.Context, userID uint, code string) {
+		if err := h.usecase.DisableTwoFactor(c.Request.Context(), userID, code); err != nil {
+			h.handleSecurityError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"message": "Verificación en dos pasos desactivada",
+		})
+	})
+}
+
+// RegenerateRecoveryCodesHandler reemplaza los códigos de recuperación
+func (h *AuthHandler) RegenerateRecoveryCodesHandler(c *gin.Context) {
+	h.withTwoFactorCode(c, "RegenerateRecoveryCodesHandler", func(c *gin.Context, userID uint, code string) {
+		codes, err := h.usecase.RegenerateRecoveryCodes(c.Request.Context(), userID, code)
+		if err != nil {
+			h.handleSecurityError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"success": true,
+			"data":    response.RecoveryCodesResponse{RecoveryCodes: codes},
+		})
+	})
+}
+
+// ResetUserTwoFactorHandler quita el 2FA de otro usuario y cierra sus sesiones
+func (h *AuthHandler) ResetUserTwoFactorHandler(c *gin.Context) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), "ResetUserTwoFactorHandler")
+	actor, ok := h.requireActor(c)
+	if !ok {
+		return
+	}
+	targetUserID, ok := parseTargetUserID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.ResetUserTwoFactor(ctx, actor, targetUserID); err != nil {
+		h.handleSecurityError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Verificación en dos pasos restablecida",
+	})
+}
+
+func (h *AuthHandler) withTwoFactorCode(c *gin.Context, function string, next func(c *gin.Context, userID uint, code string)) {
+	ctx := log.WithFunctionCtx(c.Request.Context(), function)
+	c.Request = c.Request.WithContext(ctx)
+
+	actor, ok := h.requireActor(c)
+	if !ok {
+		return
+	}
+
+	var req request.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, response.LoginBadRequestResponse{
+			Error:   "Datos de entrada inválidos",
+			Details: err.Error(),
+		})
+		return
+	}
+
+	next(c, actor.UserID, req.Code)
+}
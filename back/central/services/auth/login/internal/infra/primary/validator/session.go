@@ -0,0 +1,34 @@
+package validator
+
+import (
+	"context"
+	"errors"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/app"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+var errSessionValidationFailed = errors.New("no se pudo validar la sesión")
+
+// SessionValidator adapta el registro de sesiones al validador que usa
+// middleware.JWT() para rechazar tokens de sesiones revocadas.
+type SessionValidator struct {
+	uc  app.Iapp
+	log log.ILogger
+}
+
+func NewSession(uc app.Iapp, logger log.ILogger) middleware.ISessionValidator {
+	return &SessionValidator{uc: uc, log: logger}
+}
+
+func (v *SessionValidator) ValidateSession(ctx context.Context, request middleware.ValidateSessionRequest) error {
+	err := v.uc.ValidateSession(ctx, request.SessionID, request.UserID, request.ClientIP)
+	if err == nil || errors.Is(err, domain.ErrSessionRevoked) {
+		return err
+	}
+	// Un fallo de base de datos no se muestra al cliente
+	v.log.Error(ctx).Err(err).Str("session_id", request.SessionID).Msg("Error al validar sesion")
+	return errSessionValidationFailed
+}
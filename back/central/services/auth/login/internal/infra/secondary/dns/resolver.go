@@ -0,0 +1,21 @@
+package dns
+
+import (
+	"context"
+	"net"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+)
+
+type resolver struct {
+	net *net.Resolver
+}
+
+// New consulta el DNS con el resolver del sistema
+func New() domain.IDNSResolver {
+	return &resolver{net: net.DefaultResolver}
+}
+
+func (r *resolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	return r.net.LookupTXT(ctx, name)
+}
@@ -0,0 +1,239 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/netguard"
+)
+
+const (
+	httpTimeout  = 10 * time.Second
+	discoveryTTL = time.Hour
+	clockSkew    = time.Minute
+	maxBodyBytes = 1 << 20
+)
+
+// Algoritmos aceptados en el id_token; HS* queda fuera porque el secreto lo
+// comparten todos los clientes del IdP
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+type cachedProvider struct {
+	metadata  *domain.OIDCProviderMetadata
+	fetchedAt time.Time
+}
+
+type client struct {
+	http   *http.Client
+	logger log.ILogger
+
+	mu        sync.Mutex
+	providers map[string]cachedProvider
+	keySets   map[string]*keySet
+}
+
+// New crea el cliente OIDC. Cachea el descubrimiento y las llaves (JWKS) de
+// cada IdP para no consultarlos en cada login. El issuer lo elige el negocio,
+// asi que las conexiones pasan por guard y no llegan a la red interna.
+func New(guard netguard.Guard, logger log.ILogger) domain.IOIDCClient {
+	return &client{
+		http:      &http.Client{Timeout: httpTimeout, Transport: guard.Transport()},
+		logger:    logger,
+		providers: make(map[string]cachedProvider),
+		keySets:   make(map[string]*keySet),
+	}
+}
+
+type discoveryDocument struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	TokenAuthMethods      []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+func (c *client) Discover(ctx context.Context, issuer string) (*domain.OIDCProviderMetadata, error) {
+	issuer = strings.TrimRight(strings.TrimSpace(issuer), "/")
+
+	c.mu.Lock()
+	cached, ok := c.providers[issuer]
+	c.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < discoveryTTL {
+		return cached.metadata, nil
+	}
+
+	var doc discoveryDocument
+	if err := c.getJSON(ctx, issuer+"/.well-known/openid-configuration", &doc); err != nil {
+		return nil, fmt.Errorf("descubrimiento OIDC de %s: %w", issuer, err)
+	}
+	if strings.TrimRight(doc.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("el issuer del descubrimiento (%s) no coincide con %s", doc.Issuer, issuer)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, fmt.Errorf("descubrimiento OIDC de %s incompleto", issuer)
+	}
+
+	metadata := &domain.OIDCProviderMetadata{
+		Issuer:                doc.Issuer,
+		AuthorizationEndpoint: doc.AuthorizationEndpoint,
+		TokenEndpoint:         doc.TokenEndpoint,
+		JWKSURI:               doc.JWKSURI,
+		TokenAuthMethods:      doc.TokenAuthMethods,
+	}
+
+	c.mu.Lock()
+	c.providers[issuer] = cachedProvider{metadata: metadata, fetchedAt: time.Now()}
+	c.mu.Unlock()
+	return metadata, nil
+}
+
+type tokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (c *client) ExchangeCode(ctx context.Context, provider *domain.OIDCProviderMetadata, exchange domain.OIDCCodeExchange) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", exchange.Code)
+	form.Set("redirect_uri", exchange.RedirectURI)
+	form.Set("code_verifier", exchange.CodeVerifier)
+	form.Set("client_id", exchange.ClientID)
+
+	useBasic := exchange.ClientSecret != "" && !secretPostOnly(provider.TokenAuthMethods)
+	if exchange.ClientSecret != "" && !useBasic {
+		form.Set("client_secret", exchange.ClientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, provider.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if useBasic {
+		req.SetBasicAuth(url.QueryEscape(exchange.ClientID), url.QueryEscape(exchange.ClientSecret))
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("canje del code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body tokenResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxBodyBytes)).Decode(&body); err != nil {
+		return "", fmt.Errorf("canje del code: respuesta %d ilegible: %w", resp.StatusCode, err)
+	}
+	if resp.StatusCode != http.StatusOK || body.Error != "" {
+		return "", fmt.Errorf("canje del code: %d %s %s", resp.StatusCode, body.Error, body.ErrorDescription)
+	}
+	if body.IDToken == "" {
+		return "", fmt.Errorf("canje del code: el IdP no devolvio id_token")
+	}
+	return body.IDToken, nil
+}
+
+// secretPostOnly indica si el IdP solo acepta el secreto en el body; si no
+// declara métodos se usa client_secret_basic, el default del estándar
+func secretPostOnly(methods []string) bool {
+	post, basic := false, false
+	for _, m := range methods {
+		switch m {
+		case "client_secret_post":
+			post = true
+		case "client_secret_basic":
+			basic = true
+		}
+	}
+	return post && !basic
+}
+
+func (c *client) VerifyIDToken(ctx context.Context, provider *domain.OIDCProviderMetadata, rawIDToken, clientID string) (*domain.OIDCIdentity, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims,
+		func(t *jwt.Token) (interface{}, error) {
+			kid, _ := t.Header["kid"].(string)
+			return c.publicKey(ctx, provider.JWKSURI, kid)
+		},
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(provider.Issuer),
+		jwt.WithAudience(clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("id_token invalido: %w", err)
+	}
+
+	// Con varias audiencias el id_token debe ir emitido a nombre nuestro
+	if aud, _ := claims.GetAudience(); len(aud) > 1 {
+		if azp, _ := claims["azp"].(string); azp != clientID {
+			return nil, fmt.Errorf("id_token invalido: azp %q distinto del client_id", azp)
+		}
+	}
+
+	identity := &domain.OIDCIdentity{
+		Issuer:  stringClaim(claims, "iss"),
+		Subject: stringClaim(claims, "sub"),
+		Email:   stringClaim(claims, "email"),
+		Name:    displayName(claims),
+		Nonce:   stringClaim(claims, "nonce"),
+		Claims:  claims,
+	}
+	switch v := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = &v
+	case string:
+		verified := strings.EqualFold(v, "true")
+		identity.EmailVerified = &verified
+	}
+	return identity, nil
+}
+
+func stringClaim(claims jwt.MapClaims, key string) string {
+	v, _ := claims[key].(string)
+	return strings.TrimSpace(v)
+}
+
+func displayName(claims jwt.MapClaims) string {
+	if name := stringClaim(claims, "name"); name != "" {
+		return name
+	}
+	full := strings.TrimSpace(stringClaim(claims, "given_name") + " " + stringClaim(claims, "family_name"))
+	if full != "" {
+		return full
+	}
+	return stringClaim(claims, "preferred_username")
+}
+
+func (c *client) getJSON(ctx context.Context, endpoint string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: status %d", endpoint, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxBodyBytes)).Decode(out)
+}
@@ -0,0 +1,234 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/mocks"
+	"github.com/secamc93/probability/back/central/shared/netguard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// idpDePrueba es un IdP mínimo: descubrimiento, JWKS y token endpoint
+type idpDePrueba struct {
+	server     *httptest.Server
+	key        *rsa.PrivateKey
+	authMethod []string
+	tokenForm  map[string]string
+	basicUser  string
+	basicPass  string
+	idToken    string
+}
+
+func nuevoIdP(t *testing.T) *idpDePrueba {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &idpDePrueba{key: key, tokenForm: map[string]string{}}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                idp.server.URL,
+			"authorization_endpoint":                idp.server.URL + "/authorize",
+			"token_endpoint":                        idp.server.URL + "/token",
+			"jwks_uri":                              idp.server.URL + "/jwks",
+			"token_endpoint_auth_methods_supported": idp.authMethod,
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "k1",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		for k := range r.PostForm {
+			idp.tokenForm[k] = r.PostForm.Get(k)
+		}
+		idp.basicUser, idp.basicPass, _ = r.BasicAuth()
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": idp.idToken})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (idp *idpDePrueba) firmar(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "k1"
+	signed, err := token.SignedString(idp.key)
+	require.NoError(t, err)
+	return signed
+}
+
+func (idp *idpDePrueba) claims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":            idp.server.URL,
+		"sub":            "sub-1",
+		"aud":            "cliente",
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"nonce":          "n-1",
+		"email":          "ana@empresa.com",
+		"email_verified": "true",
+		"given_name":     "Ana",
+		"family_name":    "Pérez",
+		"groups":         []string{"ventas"},
+	}
+}
+
+func TestDiscover_ValidaElIssuerYCachea(t *testing.T) {
+	idp := nuevoIdP(t)
+	c := New(netguard.Guard{AllowLoopback: true}, mocks.NewSilentLogger())
+
+	got, err := c.Discover(context.Background(), idp.server.URL+"/")
+
+	require.NoError(t, err)
+	assert.Equal(t, idp.server.URL+"/token", got.TokenEndpoint)
+
+	idp.server.Close()
+	again, err := c.Discover(context.Background(), idp.server.URL)
+	require.NoError(t, err, "el descubrimiento queda en caché")
+	assert.Same(t, got, again)
+}
+
+func TestDiscover_IssuerInterno_NoConecta(t *testing.T) {
+	idp := nuevoIdP(t)
+	c := New(netguard.Guard{}, mocks.NewSilentLogger())
+
+	_, err := c.Discover(context.Background(), idp.server.URL)
+
+	assert.ErrorIs(t, err, netguard.ErrBlockedAddress)
+}
+
+func TestDiscover_IssuerDistinto_Rechaza(t *testing.T) {
+	idp := nuevoIdP(t)
+	c := New(netguard.Guard{AllowLoopback: true}, mocks.NewSilentLogger())
+
+	_, err := c.Discover(context.Background(), idp.server.URL+"/tenant")
+
+	assert.Error(t, err)
+}
+
+func TestExchangeCode_EnviaPKCEYSecretoPorBasic(t *testing.T) {
+	idp := nuevoIdP(t)
+	idp.idToken = "raw"
+	c := New(netguard.Guard{AllowLoopback: true}, mocks.NewSilentLogger())
+	provider, err := c.Discover(context.Background(), idp.server.URL)
+	require.NoError(t, err)
+
+	got, err := c.ExchangeCode(context.Background(), provider, domain.OIDCCodeExchange{
+		ClientID: "cliente", ClientSecret: "s3cr3t&", Code: "code", CodeVerifier: "verifier", RedirectURI: "https://app/cb",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "raw", got)
+	assert.Equal(t, "verifier", idp.tokenForm["code_verifier"])
+	assert.Equal(t, "authorization_code", idp.tokenForm["grant_type"])
+	assert.Empty(t, idp.tokenForm["client_secret"], "con basic el secreto no va en el body")
+	assert.Equal(t, "cliente", idp.basicUser)
+	assert.Equal(t, "s3cr3t%26", idp.basicPass, "el secreto va codificado como pide la RFC 6749")
+}
+
+func TestExchangeCode_IdPSoloPost_SecretoEnElBody(t *testing.T) {
+	idp := nuevoIdP(t)
+	idp.idToken = "raw"
+	idp.authMethod = []string{"client_secret_post"}
+	c := New(netguard.Guard{AllowLoopback: true}, mocks.NewSilentLogger())
+	provider, err := c.Discover(context.Background(), idp.server.URL)
+	require.NoError(t, err)
+
+	_, err = c.ExchangeCode(context.Background(), provider, domain.OIDCCodeExchange{ClientID: "cliente", ClientSecret: "s3cr3t", Code: "code"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", idp.tokenForm["client_secret"])
+	assert.Empty(t, idp.basicUser)
+}
+
+func TestVerifyIDToken_TokenValido_ExtraeLaIdentidad(t *testing.T) {
+	idp := nuevoIdP(t)
+	c := New(netguard.Guard{AllowLoopback: true}, mocks.NewSilentLogger())
+	provider, err := c.Discover(context.Background(), idp.server.URL)
+	require.NoError(t, err)
+
+	got, err := c.VerifyIDToken(context.Background(), provider, idp.firmar(t, idp.claims()), "cliente")
+
+	require.NoError(t, err)
+	assert.Equal(t, "sub-1", got.Subject)
+	assert.Equal(t, "n-1", got.Nonce)
+	assert.Equal(t, "Ana Pérez", got.Name)
+	require.NotNil(t, got.EmailVerified)
+	assert.True(t, *got.EmailVerified, "email_verified como texto también se acepta")
+}
+
+func TestVerifyIDToken_Rechazos(t *testing.T) {
+	idp := nuevoIdP(t)
+	c := New(netguard.Guard{AllowLoopback: true}, mocks.NewSilentLogger())
+	provider, err := c.Discover(context.Background(), idp.server.URL)
+	require.NoError(t, err)
+
+	otraLlave, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	casos := map[string]func() string{
+		"otra audiencia": func() string {
+			claims := idp.claims()
+			claims["aud"] = "otro-cliente"
+			return idp.firmar(t, claims)
+		},
+		"otro issuer": func() string {
+			claims := idp.claims()
+			claims["iss"] = "https://evil.test.com"
+			return idp.firmar(t, claims)
+		},
+		"expirado": func() string {
+			claims := idp.claims()
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+			return idp.firmar(t, claims)
+		},
+		"sin exp": func() string {
+			claims := idp.claims()
+			delete(claims, "exp")
+			return idp.firmar(t, claims)
+		},
+		"varias audiencias sin azp": func() string {
+			claims := idp.claims()
+			claims["aud"] = []string{"cliente", "otro"}
+			return idp.firmar(t, claims)
+		},
+		"firmado con otra llave": func() string {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, idp.claims())
+			token.Header["kid"] = "k1"
+			signed, _ := token.SignedString(otraLlave)
+			return signed
+		},
+		"HS256 con el client_id": func() string {
+			signed, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, idp.claims()).SignedString([]byte("cliente"))
+			return signed
+		},
+	}
+
+	for nombre, token := range casos {
+		t.Run(nombre, func(t *testing.T) {
+			_, err := c.VerifyIDToken(context.Background(), provider, token(), "cliente")
+			assert.Error(t, err)
+		})
+	}
+}
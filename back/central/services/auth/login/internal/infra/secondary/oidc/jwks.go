@@ -0,0 +1,157 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	jwksTTL = time.Hour
+	// jwksMinRefresh limita cuántas veces se vuelve a pedir el JWKS cuando
+	// llega un kid desconocido (rotación de llaves del IdP)
+	jwksMinRefresh = 5 * time.Minute
+)
+
+type keySet struct {
+	keys      map[string]interface{}
+	fetchedAt time.Time
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// publicKey busca la llave del kid en el JWKS del IdP, recargándolo si venció
+// o si el kid es nuevo
+func (c *client) publicKey(ctx context.Context, jwksURI, kid string) (interface{}, error) {
+	c.mu.Lock()
+	set := c.keySets[jwksURI]
+	c.mu.Unlock()
+
+	if set != nil && time.Since(set.fetchedAt) < jwksTTL {
+		if key, ok := set.lookup(kid); ok {
+			return key, nil
+		}
+		if time.Since(set.fetchedAt) < jwksMinRefresh {
+			return nil, fmt.Errorf("llave %q no encontrada en el JWKS", kid)
+		}
+	}
+
+	fresh, err := c.fetchKeySet(ctx, jwksURI)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.keySets[jwksURI] = fresh
+	c.mu.Unlock()
+
+	if key, ok := fresh.lookup(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("llave %q no encontrada en el JWKS", kid)
+}
+
+// lookup sin kid solo sirve si el IdP publica una única llave
+func (s *keySet) lookup(kid string) (interface{}, bool) {
+	if kid == "" {
+		if len(s.keys) != 1 {
+			return nil, false
+		}
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+func (c *client) fetchKeySet(ctx context.Context, jwksURI string) (*keySet, error) {
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := c.getJSON(ctx, jwksURI, &doc); err != nil {
+		return nil, fmt.Errorf("JWKS: %w", err)
+	}
+
+	set := &keySet{keys: make(map[string]interface{}), fetchedAt: time.Now()}
+	for _, jwk := range doc.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			c.logger.Warn(ctx).Str("kid", jwk.Kid).Err(err).Msg("[OIDC] Llave del JWKS ignorada")
+			continue
+		}
+		set.keys[jwk.Kid] = key
+	}
+	if len(set.keys) == 0 {
+		return nil, fmt.Errorf("JWKS sin llaves de firma utilizables")
+	}
+	return set, nil
+}
+
+func (k jsonWebKey) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() {
+			return nil, fmt.Errorf("exponente RSA invalido")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("curva %q no soportada", k.Crv)
+		}
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		if !curve.IsOnCurve(x, y) {
+			return nil, fmt.Errorf("punto EC fuera de la curva")
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("tipo de llave %q no soportado", k.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("base64url invalido: %w", err)
+	}
+	return new(big.Int).SetBytes(raw), nil
+}
@@ -55,16 +55,17 @@ func (r *Repository) GetUserRoles(ctx context.Context, userID uint) ([]domain.Ro
 
 	for _, role := range userRoles {
 		roles = append(roles, domain.Role{
-			ID:          role.Role.ID,
-			Name:        role.Role.Name,
-			Description: role.Role.Description,
-			Level:       role.Role.Level,
-			IsSystem:    role.Role.IsSystem,
-			ScopeID:     role.Role.ScopeID,
-			ScopeName:   role.Role.Scope.Name,
-			ScopeCode:   role.Role.Scope.Code,
-			CreatedAt:   role.Role.CreatedAt,
-			UpdatedAt:   role.Role.UpdatedAt,
+			ID:               role.Role.ID,
+			Name:             role.Role.Name,
+			Description:      role.Role.Description,
+			Level:            role.Role.Level,
+			IsSystem:         role.Role.IsSystem,
+			RequireTwoFactor: role.Role.RequireTwoFactor,
+			ScopeID:          role.Role.ScopeID,
+			ScopeName:        role.Role.Scope.Name,
+			ScopeCode:        role.Role.Scope.Code,
+			CreatedAt:        role.Role.CreatedAt,
+			UpdatedAt:        role.Role.UpdatedAt,
 		})
 	}
 
@@ -136,6 +137,7 @@ func (r *Repository) GetUserRoleByBusiness(ctx context.Context, userID uint, bus
 		Description:      selectedRole.Description,
 		Level:            selectedRole.Level,
 		IsSystem:         selectedRole.IsSystem,
+		RequireTwoFactor: selectedRole.RequireTwoFactor,
 		ScopeID:          selectedRole.ScopeID,
 		ScopeName:        selectedRole.Scope.Name,
 		ScopeCode:        selectedRole.Scope.Code,
@@ -482,6 +484,7 @@ func (r *Repository) GetRoleByID(ctx context.Context, id uint) (*domain.Role, er
 		Description:      roleModel.Description,
 		Level:            roleModel.Level,
 		IsSystem:         roleModel.IsSystem,
+		RequireTwoFactor: roleModel.RequireTwoFactor,
 		ScopeID:          roleModel.ScopeID,
 		ScopeName:        roleModel.Scope.Name,
 		ScopeCode:        roleModel.Scope.Code,
@@ -9,11 +9,13 @@ import (
 type Repository struct {
 	database db.IDatabase
 	logger   log.ILogger
+	encKey   []byte // Cifra el secreto TOTP de cada usuario
 }
 
-func New(db db.IDatabase, logger log.ILogger) domain.IAuthRepository {
+func New(db db.IDatabase, logger log.ILogger, encryptionKey string) domain.IAuthRepository {
 	return &Repository{
 		database: db,
 		logger:   logger,
+		encKey:   parseEncryptionKey(encryptionKey),
 	}
 }
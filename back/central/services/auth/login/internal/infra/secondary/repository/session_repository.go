@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+)
+
+func (r *Repository) CreateSession(ctx context.Context, session *domain.UserSession) error {
+	model := &models.UserSession{
+		SessionID:  session.SessionID,
+		UserID:     session.UserID,
+		BusinessID: session.BusinessID,
+		UserAgent:  session.UserAgent,
+		Device:     session.Device,
+		IPAddress:  session.IPAddress,
+		LastSeenAt: session.LastSeenAt,
+		ExpiresAt:  session.ExpiresAt,
+	}
+	if err := r.database.Conn(ctx).Create(model).Error; err != nil {
+		r.logger.Error().Uint("user_id", session.UserID).Err(err).Msg("Error registrando sesion")
+		return err
+	}
+	session.ID = model.ID
+	session.CreatedAt = model.CreatedAt
+	return nil
+}
+
+func (r *Repository) GetSession(ctx context.Context, sessionID string) (*domain.UserSession, error) {
+	var model models.UserSession
+	if err := r.database.Conn(ctx).Where("session_id = ?", sessionID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error().Str("session_id", sessionID).Err(err).Msg("Error obteniendo sesion")
+		return nil, err
+	}
+	session := toDomainSession(model)
+	return &session, nil
+}
+
+func (r *Repository) ListActiveSessions(ctx context.Context, userID uint, now time.Time) ([]domain.UserSession, error) {
+	var rows []models.UserSession
+	if err := r.database.Conn(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC").
+		Find(&rows).Error; err != nil {
+		r.logger.Error().Uint("user_id", userID).Err(err).Msg("Error listando sesiones")
+		return nil, err
+	}
+
+	sessions := make([]domain.UserSession, 0, len(rows))
+	for _, row := range rows {
+		sessions = append(sessions, toDomainSession(row))
+	}
+	return sessions, nil
+}
+
+func (r *Repository) TouchSession(ctx context.Context, sessionID string, ipAddress string, seenAt time.Time) error {
+	updates := map[string]any{"last_seen_at": seenAt}
+	if ipAddress != "" {
+		updates["ip_address"] = ipAddress
+	}
+	return r.database.Conn(ctx).
+		Model(&models.UserSession{}).
+		Where("session_id = ? AND revoked_at IS NULL", sessionID).
+		Updates(updates).Error
+}
+
+func (r *Repository) RevokeSession(ctx context.Context, sessionID string, revokedByID uint, reason string) error {
+	if err := r.database.Conn(ctx).
+		Model(&models.UserSession{}).
+		Where("session_id = ? AND revoked_at IS NULL", sessionID).
+		Updates(revokeUpdates(revokedByID, reason)).Error; err != nil {
+		r.logger.Error().Str("session_id", sessionID).Err(err).Msg("Error revocando sesion")
+		return err
+	}
+	return nil
+}
+
+// RevokeUserSessions cierra las sesiones activas del usuario menos
+// exceptSessionID (vacio = todas) y retorna los ids revocados.
+func (r *Repository) RevokeUserSessions(ctx context.Context, userID uint, exceptSessionID string, revokedByID uint, reason string) ([]string, error) {
+	var sessionIDs []string
+	err := r.database.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		query := tx.Model(&models.UserSession{}).
+			Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now())
+		if exceptSessionID != "" {
+			query = query.Where("session_id <> ?", exceptSessionID)
+		}
+		if err := query.Pluck("session_id", &sessionIDs).Error; err != nil {
+			return err
+		}
+		if len(sessionIDs) == 0 {
+			return nil
+		}
+		return tx.Model(&models.UserSession{}).
+			Where("session_id IN ?", sessionIDs).
+			Updates(revokeUpdates(revokedByID, reason)).Error
+	})
+	if err != nil {
+		r.logger.Error().Uint("user_id", userID).Err(err).Msg("Error revocando sesiones del usuario")
+		return nil, err
+	}
+	return sessionIDs, nil
+}
+
+func revokeUpdates(revokedByID uint, reason string) map[string]any {
+	updates := map[string]any{
+		"revoked_at":     time.Now(),
+		"revoked_reason": reason,
+	}
+	if revokedByID != 0 {
+		updates["revoked_by_id"] = revokedByID
+	}
+	return updates
+}
+
+func toDomainSession(model models.UserSession) domain.UserSession {
+	return domain.UserSession{
+		ID:            model.ID,
+		SessionID:     model.SessionID,
+		UserID:        model.UserID,
+		BusinessID:    model.BusinessID,
+		UserAgent:     model.UserAgent,
+		Device:        model.Device,
+		IPAddress:     model.IPAddress,
+		CreatedAt:     model.CreatedAt,
+		LastSeenAt:    model.LastSeenAt,
+		ExpiresAt:     model.ExpiresAt,
+		RevokedAt:     model.RevokedAt,
+		RevokedReason: model.RevokedReason,
+	}
+}
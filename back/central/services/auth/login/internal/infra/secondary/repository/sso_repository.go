@@ -0,0 +1,401 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ssoRoleMapping struct {
+	ClaimValue string `json:"claim_value"`
+	RoleID     uint   `json:"role_id"`
+}
+
+func (r *Repository) GetBusinessByCode(ctx context.Context, code string) (*domain.BusinessInfo, error) {
+	var business models.Business
+	if err := r.database.Conn(ctx).Select("id").Where("code = ?", code).First(&business).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error().Str("business_code", code).Err(err).Msg("Error al obtener business por codigo")
+		return nil, err
+	}
+	return r.GetBusinessByID(ctx, business.ID)
+}
+
+func (r *Repository) GetSSOConfig(ctx context.Context, businessID uint) (*domain.BusinessSSOConfig, error) {
+	var model models.BusinessSSOConfig
+	if err := r.database.Conn(ctx).Where("business_id = ?", businessID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error().Uint("business_id", businessID).Err(err).Msg("Error obteniendo configuracion SSO")
+		return nil, err
+	}
+	return r.toDomainSSOConfig(model)
+}
+
+func (r *Repository) FindSSOConfigByEmailDomain(ctx context.Context, emailDomain string) (*domain.BusinessSSOConfig, error) {
+	needle, err := json.Marshal([]string{strings.ToLower(emailDomain)})
+	if err != nil {
+		return nil, err
+	}
+
+	// Solo un dominio verificado descubre el negocio; el indice unico parcial
+	// de business_sso_domains garantiza un solo candidato
+	var model models.BusinessSSOConfig
+	if err := r.database.Conn(ctx).
+		Joins("JOIN business_sso_domains d ON d.business_id = business_sso_configs.business_id AND d.deleted_at IS NULL").
+		Where("d.domain = ? AND d.verified_at IS NOT NULL", strings.ToLower(emailDomain)).
+		Where("business_sso_configs.enabled = ? AND business_sso_configs.email_domains @> ?::jsonb", true, string(needle)).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error().Str("email_domain", emailDomain).Err(err).Msg("Error buscando SSO por dominio")
+		return nil, err
+	}
+	return r.toDomainSSOConfig(model)
+}
+
+func (r *Repository) UpsertSSOConfig(ctx context.Context, config *domain.BusinessSSOConfig) error {
+	secret := ""
+	if config.ClientSecret != "" {
+		encrypted, err := r.encryptSecret(config.ClientSecret)
+		if err != nil {
+			return err
+		}
+		secret = encrypted
+	}
+
+	mappings := make([]ssoRoleMapping, 0, len(config.RoleMappings))
+	for _, m := range config.RoleMappings {
+		mappings = append(mappings, ssoRoleMapping{ClaimValue: m.ClaimValue, RoleID: m.RoleID})
+	}
+	mappingsJSON, err := json.Marshal(mappings)
+	if err != nil {
+		return err
+	}
+	domains := config.EmailDomains
+	if domains == nil {
+		domains = []string{}
+	}
+	domainsJSON, err := json.Marshal(domains)
+	if err != nil {
+		return err
+	}
+
+	model := &models.BusinessSSOConfig{
+		BusinessID:            config.BusinessID,
+		Enabled:               config.Enabled,
+		ProviderName:          config.ProviderName,
+		Issuer:                config.Issuer,
+		ClientID:              config.ClientID,
+		ClientSecretEncrypted: secret,
+		Scopes:                strings.Join(config.Scopes, " "),
+		RoleClaim:             config.RoleClaim,
+		RoleMappings:          datatypes.JSON(mappingsJSON),
+		DefaultRoleID:         config.DefaultRoleID,
+		EmailDomains:          datatypes.JSON(domainsJSON),
+		AutoProvision:         config.AutoProvision,
+		DisablePasswordLogin:  config.DisablePasswordLogin,
+	}
+
+	// Los booleanos en false deben escribirse igual: por eso Assign con mapa
+	err = r.database.Conn(ctx).
+		Where(models.BusinessSSOConfig{BusinessID: config.BusinessID}).
+		Assign(map[string]any{
+			"enabled":                 model.Enabled,
+			"provider_name":           model.ProviderName,
+			"issuer":                  model.Issuer,
+			"client_id":               model.ClientID,
+			"client_secret_encrypted": model.ClientSecretEncrypted,
+			"scopes":                  model.Scopes,
+			"role_claim":              model.RoleClaim,
+			"role_mappings":           model.RoleMappings,
+			"default_role_id":         model.DefaultRoleID,
+			"email_domains":           model.EmailDomains,
+			"auto_provision":          model.AutoProvision,
+			"disable_password_login":  model.DisablePasswordLogin,
+		}).
+		FirstOrCreate(model).Error
+	if err != nil {
+		r.logger.Error().Uint("business_id", config.BusinessID).Err(err).Msg("Error guardando configuracion SSO")
+		return err
+	}
+	config.ID = model.ID
+	config.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func (r *Repository) DeleteSSOConfig(ctx context.Context, businessID uint) error {
+	return r.database.Conn(ctx).Unscoped().Where("business_id = ?", businessID).Delete(&models.BusinessSSOConfig{}).Error
+}
+
+// IsPasswordLoginDisabled es true si alguno de los negocios del usuario
+// obliga a entrar por SSO
+func (r *Repository) IsPasswordLoginDisabled(ctx context.Context, userID uint) (bool, error) {
+	var count int64
+	err := r.database.Conn(ctx).
+		Table("business_staff bs").
+		Joins("JOIN business_sso_configs sso ON sso.business_id = bs.business_id AND sso.deleted_at IS NULL").
+		Where("bs.user_id = ? AND bs.deleted_at IS NULL", userID).
+		Where("sso.enabled = ? AND sso.disable_password_login = ?", true, true).
+		Count(&count).Error
+	if err != nil {
+		r.logger.Error().Uint("user_id", userID).Err(err).Msg("Error consultando si el login con password esta deshabilitado")
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *Repository) CreateSSOLoginState(ctx context.Context, state *domain.SSOLoginState) error {
+	return r.database.Conn(ctx).Create(&models.SSOLoginState{
+		StateHash:    state.StateHash,
+		BusinessID:   state.BusinessID,
+		Nonce:        state.Nonce,
+		CodeVerifier: state.CodeVerifier,
+		RedirectURI:  state.RedirectURI,
+		ExpiresAt:    state.ExpiresAt,
+	}).Error
+}
+
+// ConsumeSSOLoginState marca el state como usado y lo devuelve; nil si no
+// existe, ya se usó o venció
+func (r *Repository) ConsumeSSOLoginState(ctx context.Context, stateHash string, now time.Time) (*domain.SSOLoginState, error) {
+	db := r.database.Conn(ctx)
+	result := db.Model(&models.SSOLoginState{}).
+		Where("state_hash = ? AND used_at IS NULL AND expires_at > ?", stateHash, now).
+		Update("used_at", now)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	var model models.SSOLoginState
+	if err := db.Where("state_hash = ?", stateHash).First(&model).Error; err != nil {
+		return nil, err
+	}
+	return &domain.SSOLoginState{
+		StateHash:    model.StateHash,
+		BusinessID:   model.BusinessID,
+		Nonce:        model.Nonce,
+		CodeVerifier: model.CodeVerifier,
+		RedirectURI:  model.RedirectURI,
+		ExpiresAt:    model.ExpiresAt,
+	}, nil
+}
+
+func (r *Repository) GetSSOIdentity(ctx context.Context, businessID uint, issuer, subject string) (*domain.SSOIdentity, error) {
+	var model models.UserSSOIdentity
+	if err := r.database.Conn(ctx).
+		Where("business_id = ? AND issuer = ? AND subject = ?", businessID, issuer, subject).
+		First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &domain.SSOIdentity{
+		ID:         model.ID,
+		UserID:     model.UserID,
+		BusinessID: model.BusinessID,
+		Issuer:     model.Issuer,
+		Subject:    model.Subject,
+		Email:      model.Email,
+	}, nil
+}
+
+// SaveSSOIdentity crea el vínculo o actualiza el email y el último login
+func (r *Repository) SaveSSOIdentity(ctx context.Context, identity *domain.SSOIdentity, loginAt time.Time) error {
+	model := &models.UserSSOIdentity{
+		UserID:      identity.UserID,
+		BusinessID:  identity.BusinessID,
+		Issuer:      identity.Issuer,
+		Subject:     identity.Subject,
+		Email:       identity.Email,
+		LastLoginAt: &loginAt,
+	}
+	err := r.database.Conn(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "business_id"}, {Name: "issuer"}, {Name: "subject"}},
+		DoUpdates: clause.AssignmentColumns([]string{"email", "last_login_at", "updated_at"}),
+	}).Create(model).Error
+	if err != nil {
+		r.logger.Error().Uint("user_id", identity.UserID).Err(err).Msg("Error guardando identidad SSO")
+		return err
+	}
+	identity.ID = model.ID
+	return nil
+}
+
+// ProvisionSSOUser crea el usuario con su rol en el negocio, igual que el
+// alta desde auth/users (business_staff, user_businesses y user_roles)
+func (r *Repository) ProvisionSSOUser(ctx context.Context, user domain.SSOProvisionUser) (uint, error) {
+	var userID uint
+	err := r.database.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		model := &models.User{
+			Name:     user.Name,
+			Email:    user.Email,
+			Password: user.PasswordHash,
+			IsActive: true,
+		}
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		userID = model.ID
+		return assignBusinessRole(tx, model.ID, user.BusinessID, user.RoleID)
+	})
+	if err != nil {
+		r.logger.Error().Str("email", user.Email).Uint("business_id", user.BusinessID).Err(err).Msg("Error aprovisionando usuario SSO")
+		return 0, err
+	}
+	return userID, nil
+}
+
+func (r *Repository) AssignBusinessRole(ctx context.Context, userID, businessID, roleID uint) error {
+	return r.database.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		return assignBusinessRole(tx, userID, businessID, roleID)
+	})
+}
+
+func assignBusinessRole(tx *gorm.DB, userID, businessID, roleID uint) error {
+	bid, rid := businessID, roleID
+	staff := models.BusinessStaff{UserID: userID, BusinessID: &bid, RoleID: &rid}
+	if err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "business_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role_id", "updated_at", "deleted_at"}),
+	}).Create(&staff).Error; err != nil {
+		return err
+	}
+	if err := tx.Table("user_businesses").Clauses(clause.OnConflict{DoNothing: true}).
+		Create(map[string]interface{}{"user_id": userID, "business_id": businessID}).Error; err != nil {
+		return err
+	}
+	return tx.Table("user_roles").Clauses(clause.OnConflict{DoNothing: true}).
+		Create(map[string]interface{}{"user_id": userID, "role_id": roleID}).Error
+}
+
+func (r *Repository) ListSSODomains(ctx context.Context, businessID uint) ([]domain.SSODomain, error) {
+	var rows []models.BusinessSSODomain
+	if err := r.database.Conn(ctx).
+		Where("business_id = ?", businessID).
+		Order("domain").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]domain.SSODomain, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, domain.SSODomain{
+			Domain:            row.Domain,
+			VerificationToken: row.VerificationToken,
+			VerifiedAt:        row.VerifiedAt,
+		})
+	}
+	return out, nil
+}
+
+func (r *Repository) SSODomainVerifiedByOther(ctx context.Context, emailDomain string, businessID uint) (bool, error) {
+	var count int64
+	err := r.database.Conn(ctx).Model(&models.BusinessSSODomain{}).
+		Where("domain = ? AND business_id <> ? AND verified_at IS NOT NULL", emailDomain, businessID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *Repository) ReplaceSSODomains(ctx context.Context, businessID uint, domains []domain.SSODomain) error {
+	return r.database.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		names := make([]string, 0, len(domains))
+		for _, d := range domains {
+			names = append(names, d.Domain)
+		}
+		// Borrado real: un dominio soltado debe poder reclamarlo otro negocio
+		remove := tx.Unscoped().Where("business_id = ?", businessID)
+		if len(names) > 0 {
+			remove = remove.Where("domain NOT IN ?", names)
+		}
+		if err := remove.Delete(&models.BusinessSSODomain{}).Error; err != nil {
+			return err
+		}
+		for _, d := range domains {
+			row := &models.BusinessSSODomain{
+				BusinessID:        businessID,
+				Domain:            d.Domain,
+				VerificationToken: d.VerificationToken,
+			}
+			if err := tx.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "business_id"}, {Name: "domain"}},
+				DoNothing: true,
+			}).Create(row).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *Repository) MarkSSODomainVerified(ctx context.Context, businessID uint, emailDomain string, verifiedAt time.Time) error {
+	err := r.database.Conn(ctx).Model(&models.BusinessSSODomain{}).
+		Where("business_id = ? AND domain = ?", businessID, emailDomain).
+		Update("verified_at", verifiedAt).Error
+	if err != nil && isUniqueViolation(err) {
+		return domain.ErrSSODomainTaken
+	}
+	return err
+}
+
+func isUniqueViolation(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "duplicate key") || strings.Contains(msg, "unique constraint")
+}
+
+func (r *Repository) toDomainSSOConfig(model models.BusinessSSOConfig) (*domain.BusinessSSOConfig, error) {
+	config := &domain.BusinessSSOConfig{
+		ID:                   model.ID,
+		BusinessID:           model.BusinessID,
+		Enabled:              model.Enabled,
+		ProviderName:         model.ProviderName,
+		Issuer:               model.Issuer,
+		ClientID:             model.ClientID,
+		Scopes:               strings.Fields(model.Scopes),
+		RoleClaim:            model.RoleClaim,
+		DefaultRoleID:        model.DefaultRoleID,
+		AutoProvision:        model.AutoProvision,
+		DisablePasswordLogin: model.DisablePasswordLogin,
+		UpdatedAt:            model.UpdatedAt,
+	}
+
+	if model.ClientSecretEncrypted != "" {
+		secret, err := r.decryptSecret(model.ClientSecretEncrypted)
+		if err != nil {
+			r.logger.Error().Uint("business_id", model.BusinessID).Err(err).Msg("No se pudo descifrar el client secret SSO")
+			return nil, err
+		}
+		config.ClientSecret = secret
+	}
+
+	if len(model.RoleMappings) > 0 {
+		var mappings []ssoRoleMapping
+		if err := json.Unmarshal(model.RoleMappings, &mappings); err != nil {
+			return nil, err
+		}
+		for _, m := range mappings {
+			config.RoleMappings = append(config.RoleMappings, domain.SSORoleMapping{ClaimValue: m.ClaimValue, RoleID: m.RoleID})
+		}
+	}
+	if len(model.EmailDomains) > 0 {
+		if err := json.Unmarshal(model.EmailDomains, &config.EmailDomains); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
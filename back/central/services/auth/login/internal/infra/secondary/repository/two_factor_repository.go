@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/auth/login/internal/domain"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+)
+
+func (r *Repository) GetUserTwoFactor(ctx context.Context, userID uint) (*domain.UserTwoFactor, error) {
+	var tf models.UserTwoFactor
+	if err := r.database.Conn(ctx).Where("user_id = ?", userID).First(&tf).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Error().Uint("user_id", userID).Err(err).Msg("Error obteniendo segundo factor del usuario")
+		return nil, err
+	}
+
+	secret, err := r.decryptSecret(tf.SecretEncrypted)
+	if err != nil {
+		r.logger.Error().Uint("user_id", userID).Err(err).Msg("Error descifrando secreto TOTP")
+		return nil, fmt.Errorf("%w: %v", domain.ErrTwoFactorSecretUnavailable, err)
+	}
+
+	return &domain.UserTwoFactor{
+		ID:             tf.ID,
+		UserID:         tf.UserID,
+		Secret:         secret,
+		Enabled:        tf.Enabled,
+		EnabledAt:      tf.EnabledAt,
+		LastUsedStep:   tf.LastUsedStep,
+		FailedAttempts: tf.FailedAttempts,
+		LockedUntil:    tf.LockedUntil,
+	}, nil
+}
+
+// UpsertPendingTwoFactor guarda un secreto nuevo sin activar. Si el usuario
+// ya tenia un enrolamiento pendiente lo reemplaza.
+func (r *Repository) UpsertPendingTwoFactor(ctx context.Context, userID uint, secret string) error {
+	encrypted, err := r.encryptSecret(secret)
+	if err != nil {
+		r.logger.Error().Uint("user_id", userID).Err(err).Msg("Error cifrando secreto TOTP")
+		return err
+	}
+
+	return r.database.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.UserTwoFactor{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.UserTwoFactor{
+			UserID:          userID,
+			SecretEncrypted: encrypted,
+		}).Error
+	})
+}
+
+func (r *Repository) EnableTwoFactor(ctx context.Context, userID uint, step int64, enabledAt time.Time) error {
+	result := r.database.Conn(ctx).
+		Model(&models.UserTwoFactor{}).
+		Where("user_id = ? AND enabled = ?", userID, false).
+		Updates(map[string]any{
+			"enabled":         true,
+			"enabled_at":      enabledAt,
+			"last_used_step":  step,
+			"failed_attempts": 0,
+			"locked_until":    nil,
+		})
+	if result.Error != nil {
+		r.logger.Error().Uint("user_id", userID).Err(result.Error).Msg("Error activando segundo factor")
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrTwoFactorSetupNotStarted
+	}
+	return nil
+}
+
+// MarkTwoFactorStepUsed acepta el paso solo si es posterior al ultimo usado,
+// asi un mismo codigo no sirve dos veces aunque lleguen en paralelo.
+func (r *Repository) MarkTwoFactorStepUsed(ctx context.Context, userID uint, step int64) (bool, error) {
+	result := r.database.Conn(ctx).
+		Model(&models.UserTwoFactor{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Updates(map[string]any{
+			"last_used_step":  step,
+			"failed_attempts": 0,
+			"locked_until":    nil,
+		})
+	if result.Error != nil {
+		r.logger.Error().Uint("user_id", userID).Err(result.Error).Msg("Error registrando uso del codigo TOTP")
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *Repository) RegisterTwoFactorFailure(ctx context.Context, userID uint, maxAttempts int, lockUntil time.Time) error {
+	if err := r.database.Conn(ctx).
+		Model(&models.UserTwoFactor{}).
+		Where("user_id = ?", userID).
+		Updates(map[string]any{
+			"failed_attempts": gorm.Expr("failed_attempts + 1"),
+			"locked_until":    gorm.Expr("CASE WHEN failed_attempts + 1 >= ? THEN ? ELSE locked_until END", maxAttempts, lockUntil),
+		}).Error; err != nil {
+		r.logger.Error().Uint("user_id", userID).Err(err).Msg("Error registrando intento fallido de segundo factor")
+		return err
+	}
+	return nil
+}
+
+// DeleteTwoFactor borra el segundo factor y sus codigos de recuperacion
+func (r *Repository) DeleteTwoFactor(ctx context.Context, userID uint) error {
+	return r.database.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.UserRecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("user_id = ?", userID).Delete(&models.UserTwoFactor{}).Error
+	})
+}
+
+func (r *Repository) ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string) error {
+	codes := make([]models.UserRecoveryCode, 0, len(codeHashes))
+	for _, hash := range codeHashes {
+		codes = append(codes, models.UserRecoveryCode{UserID: userID, CodeHash: hash})
+	}
+
+	return r.database.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.UserRecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+func (r *Repository) UseRecoveryCode(ctx context.Context, userID uint, codeHash string) (bool, error) {
+	result := r.database.Conn(ctx).
+		Model(&models.UserRecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		r.logger.Error().Uint("user_id", userID).Err(result.Error).Msg("Error usando codigo de recuperacion")
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *Repository) CountUnusedRecoveryCodes(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	if err := r.database.Conn(ctx).
+		Model(&models.UserRecoveryCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
@@ -10,25 +10,56 @@ import (
 )
 
 type AuthRepositoryMock struct {
-	GetUserByEmailFn                     func(ctx context.Context, email string) (*domain.UserAuthInfo, error)
-	HasPendingEmailVerificationFn        func(ctx context.Context, userID uint) (bool, error)
-	GetUserByIDFn                        func(ctx context.Context, userID uint) (*domain.UserAuthInfo, error)
-	CreatePasswordResetTokenFn           func(ctx context.Context, userID uint, tokenHash string, channel string, expiresAt time.Time) error
-	InvalidateUserPasswordResetTokensFn  func(ctx context.Context, userID uint) error
-	GetValidPasswordResetTokenFn         func(ctx context.Context, tokenHash string) (*domain.PasswordResetTokenInfo, error)
-	GetActiveOTPTokenFn                  func(ctx context.Context, userID uint) (*domain.PasswordResetTokenInfo, error)
+	GetUserByEmailFn                      func(ctx context.Context, email string) (*domain.UserAuthInfo, error)
+	HasPendingEmailVerificationFn         func(ctx context.Context, userID uint) (bool, error)
+	GetUserByIDFn                         func(ctx context.Context, userID uint) (*domain.UserAuthInfo, error)
+	CreatePasswordResetTokenFn            func(ctx context.Context, userID uint, tokenHash string, channel string, expiresAt time.Time) error
+	InvalidateUserPasswordResetTokensFn   func(ctx context.Context, userID uint) error
+	GetValidPasswordResetTokenFn          func(ctx context.Context, tokenHash string) (*domain.PasswordResetTokenInfo, error)
+	GetActiveOTPTokenFn                   func(ctx context.Context, userID uint) (*domain.PasswordResetTokenInfo, error)
 	IncrementPasswordResetTokenAttemptsFn func(ctx context.Context, tokenID uint) error
-	MarkPasswordResetTokenUsedFn         func(ctx context.Context, tokenID uint) error
-	GetUserRolesFn                       func(ctx context.Context, userID uint) ([]domain.Role, error)
-	GetRolePermissionsFn                 func(ctx context.Context, roleID uint) ([]domain.Permission, error)
-	UpdateLastLoginFn                    func(ctx context.Context, userID uint) error
-	ChangePasswordFn                     func(ctx context.Context, userID uint, newPassword string) error
-	GetUserBusinessesFn                  func(ctx context.Context, userID uint) ([]domain.BusinessInfoEntity, error)
-	GetUserRoleByBusinessFn              func(ctx context.Context, userID uint, businessID uint) (*domain.Role, error)
-	GetBusinessStaffRelationFn           func(ctx context.Context, userID uint, businessID *uint) (*domain.BusinessStaffRelation, error)
-	GetBusinessConfiguredResourcesIDsFn  func(ctx context.Context, businessID uint) ([]uint, error)
-	GetBusinessByIDFn                    func(ctx context.Context, businessID uint) (*domain.BusinessInfo, error)
-	GetRoleByIDFn                        func(ctx context.Context, id uint) (*domain.Role, error)
+	MarkPasswordResetTokenUsedFn          func(ctx context.Context, tokenID uint) error
+	GetUserRolesFn                        func(ctx context.Context, userID uint) ([]domain.Role, error)
+	GetRolePermissionsFn                  func(ctx context.Context, roleID uint) ([]domain.Permission, error)
+	UpdateLastLoginFn                     func(ctx context.Context, userID uint) error
+	ChangePasswordFn                      func(ctx context.Context, userID uint, newPassword string) error
+	GetUserBusinessesFn                   func(ctx context.Context, userID uint) ([]domain.BusinessInfoEntity, error)
+	GetUserRoleByBusinessFn               func(ctx context.Context, userID uint, businessID uint) (*domain.Role, error)
+	GetBusinessStaffRelationFn            func(ctx context.Context, userID uint, businessID *uint) (*domain.BusinessStaffRelation, error)
+	GetBusinessConfiguredResourcesIDsFn   func(ctx context.Context, businessID uint) ([]uint, error)
+	GetBusinessByIDFn                     func(ctx context.Context, businessID uint) (*domain.BusinessInfo, error)
+	GetRoleByIDFn                         func(ctx context.Context, id uint) (*domain.Role, error)
+	GetUserTwoFactorFn                    func(ctx context.Context, userID uint) (*domain.UserTwoFactor, error)
+	UpsertPendingTwoFactorFn              func(ctx context.Context, userID uint, secret string) error
+	EnableTwoFactorFn                     func(ctx context.Context, userID uint, step int64, enabledAt time.Time) error
+	MarkTwoFactorStepUsedFn               func(ctx context.Context, userID uint, step int64) (bool, error)
+	RegisterTwoFactorFailureFn            func(ctx context.Context, userID uint, maxAttempts int, lockUntil time.Time) error
+	DeleteTwoFactorFn                     func(ctx context.Context, userID uint) error
+	ReplaceRecoveryCodesFn                func(ctx context.Context, userID uint, codeHashes []string) error
+	UseRecoveryCodeFn                     func(ctx context.Context, userID uint, codeHash string) (bool, error)
+	CountUnusedRecoveryCodesFn            func(ctx context.Context, userID uint) (int64, error)
+	CreateSessionFn                       func(ctx context.Context, session *domain.UserSession) error
+	GetSessionFn                          func(ctx context.Context, sessionID string) (*domain.UserSession, error)
+	ListActiveSessionsFn                  func(ctx context.Context, userID uint, now time.Time) ([]domain.UserSession, error)
+	TouchSessionFn                        func(ctx context.Context, sessionID string, ipAddress string, seenAt time.Time) error
+	RevokeSessionFn                       func(ctx context.Context, sessionID string, revokedByID uint, reason string) error
+	RevokeUserSessionsFn                  func(ctx context.Context, userID uint, exceptSessionID string, revokedByID uint, reason string) ([]string, error)
+	GetBusinessByCodeFn                   func(ctx context.Context, code string) (*domain.BusinessInfo, error)
+	GetSSOConfigFn                        func(ctx context.Context, businessID uint) (*domain.BusinessSSOConfig, error)
+	FindSSOConfigByEmailDomainFn          func(ctx context.Context, emailDomain string) (*domain.BusinessSSOConfig, error)
+	UpsertSSOConfigFn                     func(ctx context.Context, config *domain.BusinessSSOConfig) error
+	DeleteSSOConfigFn                     func(ctx context.Context, businessID uint) error
+	IsPasswordLoginDisabledFn             func(ctx context.Context, userID uint) (bool, error)
+	CreateSSOLoginStateFn                 func(ctx context.Context, state *domain.SSOLoginState) error
+	ConsumeSSOLoginStateFn                func(ctx context.Context, stateHash string, now time.Time) (*domain.SSOLoginState, error)
+	GetSSOIdentityFn                      func(ctx context.Context, businessID uint, issuer, subject string) (*domain.SSOIdentity, error)
+	SaveSSOIdentityFn                     func(ctx context.Context, identity *domain.SSOIdentity, loginAt time.Time) error
+	ProvisionSSOUserFn                    func(ctx context.Context, user domain.SSOProvisionUser) (uint, error)
+	AssignBusinessRoleFn                  func(ctx context.Context, userID, businessID, roleID uint) error
+	ListSSODomainsFn                      func(ctx context.Context, businessID uint) ([]domain.SSODomain, error)
+	SSODomainVerifiedByOtherFn            func(ctx context.Context, emailDomain string, businessID uint) (bool, error)
+	ReplaceSSODomainsFn                   func(ctx context.Context, businessID uint, domains []domain.SSODomain) error
+	MarkSSODomainVerifiedFn               func(ctx context.Context, businessID uint, emailDomain string, verifiedAt time.Time) error
 }
 
 var _ domain.IAuthRepository = (*AuthRepositoryMock)(nil)
@@ -166,10 +197,200 @@ func (m *AuthRepositoryMock) GetRoleByID(ctx context.Context, id uint) (*domain.
 	return nil, nil
 }
 
+func (m *AuthRepositoryMock) GetUserTwoFactor(ctx context.Context, userID uint) (*domain.UserTwoFactor, error) {
+	if m.GetUserTwoFactorFn != nil {
+		return m.GetUserTwoFactorFn(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *AuthRepositoryMock) UpsertPendingTwoFactor(ctx context.Context, userID uint, secret string) error {
+	if m.UpsertPendingTwoFactorFn != nil {
+		return m.UpsertPendingTwoFactorFn(ctx, userID, secret)
+	}
+	return nil
+}
+
+func (m *AuthRepositoryMock) EnableTwoFactor(ctx context.Context, userID uint, step int64, enabledAt time.Time) error {
+	if m.EnableTwoFactorFn != nil {
+		return m.EnableTwoFactorFn(ctx, userID, step, enabledAt)
+	}
+	return nil
+}
+
+func (m *AuthRepositoryMock) MarkTwoFactorStepUsed(ctx context.Context, userID uint, step int64) (bool, error) {
+	if m.MarkTwoFactorStepUsedFn != nil {
+		return m.MarkTwoFactorStepUsedFn(ctx, userID, step)
+	}
+	return true, nil
+}
+
+func (m *AuthRepositoryMock) RegisterTwoFactorFailure(ctx context.Context, userID uint, maxAttempts int, lockUntil time.Time) error {
+	if m.RegisterTwoFactorFailureFn != nil {
+		return m.RegisterTwoFactorFailureFn(ctx, userID, maxAttempts, lockUntil)
+	}
+	return nil
+}
+
+func (m *AuthRepositoryMock) DeleteTwoFactor(ctx context.Context, userID uint) error {
+	if m.DeleteTwoFactorFn != nil {
+		return m.DeleteTwoFactorFn(ctx, userID)
+	}
+	return nil
+}
+
+func (m *AuthRepositoryMock) ReplaceRecoveryCodes(ctx context.Context, userID uint, codeHashes []string) error {
+	if m.ReplaceRecoveryCodesFn != nil {
+		return m.ReplaceRecoveryCodesFn(ctx, userID, codeHashes)
+	}
+	return nil
+}
+
+func (m *AuthRepositoryMock) UseRecoveryCode(ctx context.Context, userID uint, codeHash string) (bool, error) {
+	if m.UseRecoveryCodeFn != nil {
+		return m.UseRecoveryCodeFn(ctx, userID, codeHash)
+	}
+	return false, nil
+}
+
+func (m *AuthRepositoryMock) CountUnusedRecoveryCodes(ctx context.Context, userID uint) (int64, error) {
+	if m.CountUnusedRecoveryCodesFn != nil {
+		return m.CountUnusedRecoveryCodesFn(ctx, userID)
+	}
+	return 0, nil
+}
+
+func (m *AuthRepositoryMock) CreateSession(ctx context.Context, session *domain.UserSession) error {
+	if m.CreateSessionFn != nil {
+		return m.CreateSessionFn(ctx, session)
+	}
+	return nil
+}
+
+func (m *AuthRepositoryMock) GetSession(ctx context.Context, sessionID string) (*domain.UserSession, error) {
+	if m.GetSessionFn != nil {
+		return m.GetSessionFn(ctx, sessionID)
+	}
+	return nil, nil
+}
+
+func (m *AuthRepositoryMock) ListActiveSessions(ctx context.Context, userID uint, now time.Time) ([]domain.UserSession, error) {
+	if m.ListActiveSessionsFn != nil {
+		return m.ListActiveSessionsFn(ctx, userID, now)
+	}
+	return nil, nil
+}
+
+func (m *AuthRepositoryMock) TouchSession(ctx context.Context, sessionID string, ipAddress string, seenAt time.Time) error {
+	if m.TouchSessionFn != nil {
+		return m.TouchSessionFn(ctx, sessionID, ipAddress, seenAt)
+	}
+	return nil
+}
+
+func (m *AuthRepositoryMock) RevokeSession(ctx context.Context, sessionID string, revokedByID uint, reason string) error {
+	if m.RevokeSessionFn != nil {
+		return m.RevokeSessionFn(ctx, sessionID, revokedByID, reason)
+	}
+	return nil
+}
+
+func (m *AuthRepositoryMock) RevokeUserSessions(ctx context.Context, userID uint, exceptSessionID string, revokedByID uint, reason string) ([]string, error) {
+	if m.RevokeUserSessionsFn != nil {
+		return m.RevokeUserSessionsFn(ctx, userID, exceptSessionID, revokedByID, reason)
+	}
+	return nil, nil
+}
+
+func (m *AuthRepositoryMock) GetBusinessByCode(ctx context.Context, code string) (*domain.BusinessInfo, error) {
+	if m.GetBusinessByCodeFn != nil {
+		return m.GetBusinessByCodeFn(ctx, code)
+	}
+	return nil, nil
+}
+
+func (m *AuthRepositoryMock) GetSSOConfig(ctx context.Context, businessID uint) (*domain.BusinessSSOConfig, error) {
+	if m.GetSSOConfigFn != nil {
+		return m.GetSSOConfigFn(ctx, businessID)
+	}
+	return nil, nil
+}
+
+func (m *AuthRepositoryMock) FindSSOConfigByEmailDomain(ctx context.Context, emailDomain string) (*domain.BusinessSSOConfig, error) {
+	if m.FindSSOConfigByEmailDomainFn != nil {
+		return m.FindSSOConfigByEmailDomainFn(ctx, emailDomain)
+	}
+	return nil, nil
+}
+
+func (m *AuthRepositoryMock) UpsertSSOConfig(ctx context.Context, config *domain.BusinessSSOConfig) error {
+	if m.UpsertSSOConfigFn != nil {
+		return m.UpsertSSOConfigFn(ctx, config)
+	}
+	return nil
+}
+
+func (m *AuthRepositoryMock) DeleteSSOConfig(ctx context.Context, businessID uint) error {
+	if m.DeleteSSOConfigFn != nil {
+		return m.DeleteSSOConfigFn(ctx, businessID)
+	}
+	return nil
+}
+
+func (m *AuthRepositoryMock) IsPasswordLoginDisabled(ctx context.Context, userID uint) (bool, error) {
+	if m.IsPasswordLoginDisabledFn != nil {
+		return m.IsPasswordLoginDisabledFn(ctx, userID)
+	}
+	return false, nil
+}
+
+func (m *AuthRepositoryMock) CreateSSOLoginState(ctx context.Context, state *domain.SSOLoginState) error {
+	if m.CreateSSOLoginStateFn != nil {
+		return m.CreateSSOLoginStateFn(ctx, state)
+	}
+	return nil
+}
+
+func (m *AuthRepositoryMock) ConsumeSSOLoginState(ctx context.Context, stateHash string, now time.Time) (*domain.SSOLoginState, error) {
+	if m.ConsumeSSOLoginStateFn != nil {
+		return m.ConsumeSSOLoginStateFn(ctx, stateHash, now)
+	}
+	return nil, nil
+}
+
+func (m *AuthRepositoryMock) GetSSOIdentity(ctx context.Context, businessID uint, issuer, subject string) (*domain.SSOIdentity, error) {
+	if m.GetSSOIdentityFn != nil {
+		return m.GetSSOIdentityFn(ctx, businessID, issuer, subject)
+	}
+	return nil, nil
+}
+
+func (m *AuthRepositoryMock) SaveSSOIdentity(ctx context.Context, identity *domain.SSOIdentity, loginAt time.Time) error {
+	if m.SaveSSOIdentityFn != nil {
+		return m.SaveSSOIdentityFn(ctx, identity, loginAt)
+	}
+	return nil
+}
+
+func (m *AuthRepositoryMock) ProvisionSSOUser(ctx context.Context, user domain.SSOProvisionUser) (uint, error) {
+	if m.ProvisionSSOUserFn != nil {
+		return m.ProvisionSSOUserFn(ctx, user)
+	}
+	return 0, nil
+}
+
+func (m *AuthRepositoryMock) AssignBusinessRole(ctx context.Context, userID, businessID, roleID uint) error {
+	if m.AssignBusinessRoleFn != nil {
+		return m.AssignBusinessRoleFn(ctx, userID, businessID, roleID)
+	}
+	return nil
+}
+
 type JWTServiceMock struct {
-	GenerateTokenFn func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus string) (string, error)
-	ValidateTokenFn func(tokenString string) (*domain.JWTClaims, error)
-	RefreshTokenFn  func(tokenString string) (string, error)
+	GenerateTokenFn        func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus string) (string, error)
+	GenerateSessionTokenFn func(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error)
+	ValidateTokenFn        func(tokenString string) (*domain.JWTClaims, error)
+	RefreshTokenFn         func(tokenString string) (string, error)
 }
 
 var _ domain.IJWTService = (*JWTServiceMock)(nil)
@@ -181,6 +402,13 @@ func (m *JWTServiceMock) GenerateToken(userID, businessID, businessTypeID, roleI
 	return "token-de-prueba", nil
 }
 
+func (m *JWTServiceMock) GenerateSessionToken(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error) {
+	if m.GenerateSessionTokenFn != nil {
+		return m.GenerateSessionTokenFn(userID, businessID, businessTypeID, roleID, subscriptionStatus, sessionID)
+	}
+	return "token-de-prueba", nil
+}
+
 func (m *JWTServiceMock) ValidateToken(tokenString string) (*domain.JWTClaims, error) {
 	if m.ValidateTokenFn != nil {
 		return m.ValidateTokenFn(tokenString)
@@ -195,6 +423,79 @@ func (m *JWTServiceMock) RefreshToken(tokenString string) (string, error) {
 	return "", nil
 }
 
+func (m *AuthRepositoryMock) ListSSODomains(ctx context.Context, businessID uint) ([]domain.SSODomain, error) {
+	if m.ListSSODomainsFn != nil {
+		return m.ListSSODomainsFn(ctx, businessID)
+	}
+	return nil, nil
+}
+
+func (m *AuthRepositoryMock) SSODomainVerifiedByOther(ctx context.Context, emailDomain string, businessID uint) (bool, error) {
+	if m.SSODomainVerifiedByOtherFn != nil {
+		return m.SSODomainVerifiedByOtherFn(ctx, emailDomain, businessID)
+	}
+	return false, nil
+}
+
+func (m *AuthRepositoryMock) ReplaceSSODomains(ctx context.Context, businessID uint, domains []domain.SSODomain) error {
+	if m.ReplaceSSODomainsFn != nil {
+		return m.ReplaceSSODomainsFn(ctx, businessID, domains)
+	}
+	return nil
+}
+
+func (m *AuthRepositoryMock) MarkSSODomainVerified(ctx context.Context, businessID uint, emailDomain string, verifiedAt time.Time) error {
+	if m.MarkSSODomainVerifiedFn != nil {
+		return m.MarkSSODomainVerifiedFn(ctx, businessID, emailDomain, verifiedAt)
+	}
+	return nil
+}
+
+// DNSResolverMock responde los registros TXT de Records
+type DNSResolverMock struct {
+	Records map[string][]string
+}
+
+var _ domain.IDNSResolver = (*DNSResolverMock)(nil)
+
+func (m *DNSResolverMock) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	return m.Records[name], nil
+}
+
+type OIDCClientMock struct {
+	DiscoverFn      func(ctx context.Context, issuer string) (*domain.OIDCProviderMetadata, error)
+	ExchangeCodeFn  func(ctx context.Context, provider *domain.OIDCProviderMetadata, exchange domain.OIDCCodeExchange) (string, error)
+	VerifyIDTokenFn func(ctx context.Context, provider *domain.OIDCProviderMetadata, rawIDToken, clientID string) (*domain.OIDCIdentity, error)
+}
+
+var _ domain.IOIDCClient = (*OIDCClientMock)(nil)
+
+func (m *OIDCClientMock) Discover(ctx context.Context, issuer string) (*domain.OIDCProviderMetadata, error) {
+	if m.DiscoverFn != nil {
+		return m.DiscoverFn(ctx, issuer)
+	}
+	return &domain.OIDCProviderMetadata{
+		Issuer:                issuer,
+		AuthorizationEndpoint: issuer + "/authorize",
+		TokenEndpoint:         issuer + "/token",
+		JWKSURI:               issuer + "/jwks",
+	}, nil
+}
+
+func (m *OIDCClientMock) ExchangeCode(ctx context.Context, provider *domain.OIDCProviderMetadata, exchange domain.OIDCCodeExchange) (string, error) {
+	if m.ExchangeCodeFn != nil {
+		return m.ExchangeCodeFn(ctx, provider, exchange)
+	}
+	return "id-token-de-prueba", nil
+}
+
+func (m *OIDCClientMock) VerifyIDToken(ctx context.Context, provider *domain.OIDCProviderMetadata, rawIDToken, clientID string) (*domain.OIDCIdentity, error) {
+	if m.VerifyIDTokenFn != nil {
+		return m.VerifyIDTokenFn(ctx, provider, rawIDToken, clientID)
+	}
+	return nil, nil
+}
+
 type EmailSenderMock struct {
 	SendHTMLFn func(ctx context.Context, to, subject, html string) error
 }
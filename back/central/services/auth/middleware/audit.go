@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/audit"
+)
+
+// AuditActor resuelve el actor de la bitacora de auditoria desde la
+// autenticacion del request. Con API Key el usuario es quien creo la clave.
+// El super admin opera sobre el negocio que indique ?business_id o el
+// parametro :business_id; sin ellos la accion es de plataforma.
+func AuditActor(c *gin.Context) audit.Actor {
+	authInfo, exists := GetAuthInfo(c)
+	if !exists || authInfo.UserID == 0 && authInfo.APIKeyID == 0 {
+		return audit.Actor{}
+	}
+
+	actor := audit.Actor{Type: audit.ActorUser, Label: authInfo.Email}
+	if authInfo.UserID != 0 {
+		userID := authInfo.UserID
+		actor.UserID = &userID
+	}
+	if authInfo.Type == domain.AuthTypeAPIKey {
+		actor.Type = audit.ActorAPIKey
+		apiKeyID := authInfo.APIKeyID
+		actor.APIKeyID = &apiKeyID
+		if actor.Label == "" {
+			actor.Label = "api_key:" + strconv.FormatUint(uint64(apiKeyID), 10)
+		}
+	}
+
+	businessID := authInfo.BusinessID
+	if businessID == 0 {
+		businessID = targetBusiness(c)
+	}
+	actor.BusinessID = &businessID
+	return actor
+}
+
+func targetBusiness(c *gin.Context) uint {
+	raw := c.Query("business_id")
+	if raw == "" {
+		raw = c.Param("business_id")
+	}
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return uint(id)
+}
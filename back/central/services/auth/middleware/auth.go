@@ -2,6 +2,7 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/secamc93/probability/back/central/services/auth/middleware/internal/app"
@@ -17,6 +18,15 @@ import (
 type AuthType = domain.AuthType
 type AuthInfo = domain.AuthInfo
 type AuthError = domain.AuthError
+type ValidateAPIKeyRequest = domain.ValidateAPIKeyRequest
+type ValidateAPIKeyResponse = domain.ValidateAPIKeyResponse
+
+// IAPIKeyValidator valida las API Keys que llegan en X-API-Key
+type IAPIKeyValidator = domain.IAuthUseCase
+
+// ISessionValidator valida la sesion (claim "sid") de los JWT
+type ISessionValidator = domain.ISessionValidator
+type ValidateSessionRequest = domain.ValidateSessionRequest
 
 const (
 	AuthTypeUnknown = domain.AuthTypeUnknown
@@ -60,6 +70,21 @@ func Configure(jwtService domain.IJWTService, authUseCase domain.IAuthUseCase, l
 	initialized = true
 }
 
+// SetAPIKeyValidator conecta el validador de API Keys a APIKey() y Auto().
+// Lo llama el modulo de api keys al arrancar.
+func SetAPIKeyValidator(validator IAPIKeyValidator) {
+	ensureInitialized()
+	defaultAuthUseCase = validator
+	defaultMiddleware.SetAuthUseCase(validator)
+}
+
+// SetSessionValidator conecta el registro de sesiones a JWT(), para rechazar
+// los tokens de sesiones revocadas. Lo llama el modulo de login al arrancar.
+func SetSessionValidator(validator ISessionValidator) {
+	ensureInitialized()
+	defaultMiddleware.SetSessionValidator(validator)
+}
+
 func ensureInitialized() {
 	if !initialized {
 		panic("auth middleware not configured: call middleware.Configure(...) during service bootstrap")
@@ -143,6 +168,24 @@ func GetAPIKey(c *gin.Context) (string, bool) {
 	return authInfo.APIKey, true
 }
 
+func GetAPIKeyID(c *gin.Context) (uint, bool) {
+	authInfo, exists := GetAuthInfo(c)
+	if !exists || authInfo.Type != domain.AuthTypeAPIKey {
+		return 0, false
+	}
+	return authInfo.APIKeyID, true
+}
+
+// GetSessionID retorna el id de sesion del JWT; vacio para API Keys y tokens
+// emitidos antes del registro de sesiones.
+func GetSessionID(c *gin.Context) (string, bool) {
+	authInfo, exists := GetAuthInfo(c)
+	if !exists || authInfo.Type != domain.AuthTypeJWT || authInfo.SessionID == "" {
+		return "", false
+	}
+	return authInfo.SessionID, true
+}
+
 func GetUserID(c *gin.Context) (uint, bool) {
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -377,6 +420,33 @@ func RequireAuthType(authType domain.AuthType) gin.HandlerFunc {
 	}
 }
 
+// RequirePermission exige que una API Key tenga el permiso resource:action
+// (ej. "Envios", "Read"). Las sesiones JWT pasan: sus permisos salen del rol
+// y se validan como hasta ahora.
+func RequirePermission(resource, action string) gin.HandlerFunc {
+	required := strings.ToLower(resource + ":" + action)
+	return func(c *gin.Context) {
+		authInfo, exists := GetAuthInfo(c)
+		if !exists || authInfo.Type != domain.AuthTypeAPIKey {
+			c.Next()
+			return
+		}
+
+		for _, permission := range authInfo.Permissions {
+			if strings.ToLower(permission) == required {
+				c.Next()
+				return
+			}
+		}
+
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "Acceso denegado: la API Key no tiene el permiso " + resource + ":" + action,
+			"code":  "API_KEY_PERMISSION_REQUIRED",
+		})
+		c.Abort()
+	}
+}
+
 func RequireJWT() gin.HandlerFunc {
 	return RequireAuthType(domain.AuthTypeJWT)
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/secamc93/probability/back/central/services/auth/middleware/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/audit"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -435,3 +436,76 @@ func TestRequireAnyRole_SinRolesRequeridos_Rechaza(t *testing.T) {
 	assert.Equal(t, http.StatusForbidden, w.Code,
 		"sin roles requeridos ninguno coincide, se rechaza")
 }
+
+func TestGetAPIKeyID_SoloConAutenticacionPorAPIKey(t *testing.T) {
+	c, _ := contextoDePrueba()
+	c.Set("auth_info", &domain.AuthInfo{Type: domain.AuthTypeJWT, APIKeyID: 9})
+
+	_, ok := GetAPIKeyID(c)
+	assert.False(t, ok)
+
+	c.Set("auth_info", &domain.AuthInfo{Type: domain.AuthTypeAPIKey, APIKeyID: 9})
+	id, ok := GetAPIKeyID(c)
+	require.True(t, ok)
+	assert.Equal(t, uint(9), id)
+}
+
+func TestRequirePermission_JWT_Permite(t *testing.T) {
+	c, _ := contextoDePrueba()
+	c.Set("auth_info", &domain.AuthInfo{Type: domain.AuthTypeJWT})
+
+	RequirePermission("Envios", "Read")(c)
+
+	assert.False(t, c.IsAborted(), "los permisos de una sesion JWT salen del rol")
+}
+
+func TestRequirePermission_APIKeyConElPermiso_Permite(t *testing.T) {
+	c, _ := contextoDePrueba()
+	c.Set("auth_info", &domain.AuthInfo{Type: domain.AuthTypeAPIKey, Permissions: []string{"Envios:Read"}})
+
+	RequirePermission("envios", "read")(c)
+
+	assert.False(t, c.IsAborted())
+}
+
+func TestRequirePermission_APIKeySinElPermiso_Rechaza(t *testing.T) {
+	c, w := contextoDePrueba()
+	c.Set("auth_info", &domain.AuthInfo{Type: domain.AuthTypeAPIKey, Permissions: []string{"Envios:Read"}})
+
+	RequirePermission("Envios", "Update")(c)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.True(t, c.IsAborted())
+}
+
+func TestAuditActor_SinAutenticacionEsAnonimo(t *testing.T) {
+	c, _ := contextoDePrueba()
+
+	assert.Equal(t, audit.Actor{}, AuditActor(c))
+}
+
+func TestAuditActor_APIKeyConCreador(t *testing.T) {
+	c, _ := contextoDePrueba()
+	c.Set("auth_info", &domain.AuthInfo{Type: domain.AuthTypeAPIKey, UserID: 4, APIKeyID: 12, BusinessID: 36})
+
+	got := AuditActor(c)
+
+	assert.Equal(t, audit.ActorAPIKey, got.Type)
+	require.NotNil(t, got.APIKeyID)
+	assert.Equal(t, uint(12), *got.APIKeyID)
+	assert.Equal(t, uint(4), *got.UserID)
+	assert.Equal(t, uint(36), *got.BusinessID)
+	assert.Equal(t, "api_key:12", got.Label)
+}
+
+func TestAuditActor_SuperAdminTomaElNegocioDelQuery(t *testing.T) {
+	c, _ := contextoDePrueba()
+	c.Request = httptest.NewRequest(http.MethodPut, "/?business_id=26", nil)
+	c.Set("auth_info", &domain.AuthInfo{Type: domain.AuthTypeJWT, UserID: 1, Email: "root@probability.co"})
+
+	got := AuditActor(c)
+
+	assert.Equal(t, audit.ActorUser, got.Type)
+	assert.Equal(t, "root@probability.co", got.Label)
+	assert.Equal(t, uint(26), *got.BusinessID)
+}
@@ -48,6 +48,7 @@ func (s *AuthService) ValidateToken(token string) (*domain.AuthInfo, error) {
 		BusinessID:          claims.BusinessID,
 		BusinessTypeID:      claims.BusinessTypeID,
 		RoleID:              claims.RoleID,
+		SessionID:           claims.SessionID,
 		JWTClaims:           claims,
 		BusinessTokenClaims: businessTokenClaims,
 	}, nil
@@ -1,11 +1,14 @@
 package domain
 
+import "time"
+
 type JWTClaims struct {
 	UserID             uint
 	BusinessID         uint
 	BusinessTypeID     uint
 	RoleID             uint
 	SubscriptionStatus string
+	SessionID          string
 }
 type BusinessTokenClaims struct {
 	UserID         uint
@@ -13,8 +16,17 @@ type BusinessTokenClaims struct {
 	BusinessTypeID uint
 	RoleID         uint
 }
+
+// ValidateSessionRequest identifica la sesion de un JWT que trae claim "sid"
+type ValidateSessionRequest struct {
+	SessionID string
+	UserID    uint
+	ClientIP  string
+	UserAgent string
+}
 type ValidateAPIKeyRequest struct {
-	APIKey string
+	APIKey   string
+	ClientIP string
 }
 type ValidateAPIKeyResponse struct {
 	Success    bool
@@ -24,4 +36,9 @@ type ValidateAPIKeyResponse struct {
 	BusinessID uint
 	Roles      []string
 	APIKeyID   uint
+	// Permisos de la clave como "Recurso:Accion"
+	Permissions []string
+	// RateLimited indica que la clave es valida pero supero su limite
+	RateLimited bool
+	RetryAfter  time.Duration
 }
@@ -19,6 +19,9 @@ type AuthInfo struct {
 	Scope               string // "platform" o "business"
 	ScopeID             uint
 	APIKey              string
+	APIKeyID            uint
+	SessionID           string   // Solo para JWT emitidos con registro de sesion
+	Permissions         []string // Solo para API Key: permisos "Recurso:Accion" de la clave
 	JWTClaims           *JWTClaims
 	BusinessTokenClaims *BusinessTokenClaims
 }
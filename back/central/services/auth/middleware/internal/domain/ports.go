@@ -9,6 +9,12 @@ type IJWTService interface {
 	RefreshToken(tokenString string) (string, error)
 }
 
+// ISessionValidator confirma que la sesion de un JWT sigue activa. Devuelve
+// error si fue revocada o expiro.
+type ISessionValidator interface {
+	ValidateSession(ctx context.Context, request ValidateSessionRequest) error
+}
+
 type IAuthUseCase interface {
 	ValidateAPIKey(ctx context.Context, request ValidateAPIKeyRequest) (*ValidateAPIKeyResponse, error)
 }
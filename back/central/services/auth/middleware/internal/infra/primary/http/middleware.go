@@ -2,6 +2,7 @@ package http
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/secamc93/probability/back/central/services/auth/middleware/internal/app"
@@ -10,9 +11,10 @@ import (
 )
 
 type Middleware struct {
-	authService *app.AuthService
-	authUseCase domain.IAuthUseCase
-	logger      log.ILogger
+	authService      *app.AuthService
+	authUseCase      domain.IAuthUseCase
+	sessionValidator domain.ISessionValidator
+	logger           log.ILogger
 }
 
 func NewMiddleware(authService *app.AuthService, authUseCase domain.IAuthUseCase, logger log.ILogger) *Middleware {
@@ -54,10 +56,29 @@ func (m *Middleware) AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		// Los tokens sin "sid" son anteriores al registro de sesiones y se
+		// aceptan hasta que expiren
+		if m.sessionValidator != nil && authInfo.SessionID != "" {
+			if err := m.sessionValidator.ValidateSession(c.Request.Context(), domain.ValidateSessionRequest{
+				SessionID: authInfo.SessionID,
+				UserID:    authInfo.UserID,
+				ClientIP:  c.ClientIP(),
+				UserAgent: c.Request.UserAgent(),
+			}); err != nil {
+				m.logger.Warn().Err(err).Uint("user_id", authInfo.UserID).Msg("Sesión revocada o expirada")
+				c.JSON(http.StatusUnauthorized, gin.H{
+					"error": err.Error(),
+				})
+				c.Abort()
+				return
+			}
+		}
+
 		c.Set("auth_info", authInfo)
 		c.Set("auth_type", authInfo.Type)
 		c.Set("user_id", authInfo.UserID)
 		c.Set("user_email", authInfo.Email)
+		c.Set("session_id", authInfo.SessionID)
 		c.Set("business_id", authInfo.BusinessID)
 		c.Set("business_type_id", authInfo.BusinessTypeID)
 		c.Set("role_id", authInfo.RoleID)
@@ -87,8 +108,30 @@ func (m *Middleware) BusinessTokenAuthMiddleware() gin.HandlerFunc {
 	return m.AuthMiddleware()
 }
 
+// SetAuthUseCase conecta el validador de API Keys. Los handlers ya armados
+// con APIKeyMiddleware lo leen en cada request, asi que puede llegar despues
+// de registrar las rutas.
+func (m *Middleware) SetAuthUseCase(authUseCase domain.IAuthUseCase) {
+	m.authUseCase = authUseCase
+}
+
+// SetSessionValidator conecta el registro de sesiones. Igual que el de API
+// Keys, se lee en cada request.
+func (m *Middleware) SetSessionValidator(validator domain.ISessionValidator) {
+	m.sessionValidator = validator
+}
+
 func (m *Middleware) APIKeyMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
+		if m.authUseCase == nil {
+			m.logger.Error().Msg("Validador de API Keys no configurado")
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Autenticación por API Key no disponible",
+			})
+			c.Abort()
+			return
+		}
+
 		apiKey := extractAPIKey(c)
 		if apiKey == "" {
 			m.logger.Error().Msg("API Key requerida")
@@ -100,7 +143,8 @@ func (m *Middleware) APIKeyMiddleware() gin.HandlerFunc {
 		}
 
 		request := domain.ValidateAPIKeyRequest{
-			APIKey: apiKey,
+			APIKey:   apiKey,
+			ClientIP: c.ClientIP(),
 		}
 
 		response, err := m.authUseCase.ValidateAPIKey(c.Request.Context(), request)
@@ -113,6 +157,20 @@ func (m *Middleware) APIKeyMiddleware() gin.HandlerFunc {
 			return
 		}
 
+		if response.RateLimited {
+			retry := int(response.RetryAfter.Seconds())
+			if retry < 1 {
+				retry = 1
+			}
+			m.logger.Warn().Uint("api_key_id", response.APIKeyID).Msg("API Key supero su limite de requests")
+			c.Header("Retry-After", strconv.Itoa(retry))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":       "rate_limited",
+				"retry_after": retry,
+			})
+			return
+		}
+
 		if !response.Success {
 			m.logger.Error().Str("message", response.Message).Msg("API Key inválida")
 			c.JSON(http.StatusUnauthorized, gin.H{
@@ -123,12 +181,16 @@ func (m *Middleware) APIKeyMiddleware() gin.HandlerFunc {
 		}
 
 		authInfo := &domain.AuthInfo{
-			Type:       domain.AuthTypeAPIKey,
-			UserID:     response.UserID,
-			Email:      response.Email,
-			Roles:      response.Roles,
-			BusinessID: response.BusinessID,
-			APIKey:     apiKey,
+			Type:        domain.AuthTypeAPIKey,
+			UserID:      response.UserID,
+			Email:       response.Email,
+			Roles:       response.Roles,
+			BusinessID:  response.BusinessID,
+			Scope:       "business",
+			ScopeID:     response.BusinessID,
+			APIKey:      apiKey,
+			APIKeyID:    response.APIKeyID,
+			Permissions: response.Permissions,
 		}
 
 		c.Set("auth_info", authInfo)
@@ -137,44 +199,42 @@ func (m *Middleware) APIKeyMiddleware() gin.HandlerFunc {
 		c.Set("user_email", authInfo.Email)
 		c.Set("user_roles", authInfo.Roles)
 		c.Set("business_id", authInfo.BusinessID)
+		c.Set("api_key_id", authInfo.APIKeyID)
+		c.Set("is_super_admin", false)
 		c.Set("jwt_claims", nil)
 
 		m.logger.Debug().
 			Str("auth_type", string(authInfo.Type)).
-			Uint("user_id", authInfo.UserID).
-			Msg("Usuario autenticado con API Key")
+			Uint("api_key_id", authInfo.APIKeyID).
+			Uint("business_id", authInfo.BusinessID).
+			Msg("Request autenticado con API Key")
 
 		c.Next()
 	}
 }
 
+// AutoAuthMiddleware acepta sesión JWT (cookie o Authorization) o API Key.
+// La API Key solo se usa si no viene sesión, para que un navegador con
+// cookie no termine autenticado como la integración.
 func (m *Middleware) AutoAuthMiddleware() gin.HandlerFunc {
+	jwtAuth := m.AuthMiddleware()
+	apiKeyAuth := m.APIKeyMiddleware()
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		apiKey := extractAPIKey(c)
-
-		if authHeader != "" {
-			m.AuthMiddleware()(c)
-			if c.IsAborted() {
-				return
-			}
-			c.Next()
-			return
-		}
-		if apiKey != "" {
-			m.APIKeyMiddleware()(c)
-			if c.IsAborted() {
-				return
-			}
-			c.Next()
-			return
+		cookieToken, _ := c.Cookie("session_token")
+		hasSession := cookieToken != "" || c.GetHeader("Authorization") != ""
+
+		switch {
+		case hasSession:
+			jwtAuth(c)
+		case extractAPIKey(c) != "":
+			apiKeyAuth(c)
+		default:
+			m.logger.Error().Msg("No se encontró método de autenticación")
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"error": "Se requiere autenticación (JWT o API Key)",
+			})
+			c.Abort()
 		}
-
-		m.logger.Error().Msg("No se encontró método de autenticación")
-		c.JSON(http.StatusUnauthorized, gin.H{
-			"error": "Se requiere autenticación (JWT o API Key)",
-		})
-		c.Abort()
 	}
 }
 
@@ -28,6 +28,7 @@ func (a *Adapter) ValidateToken(tokenString string) (*domain.JWTClaims, error) {
 		BusinessTypeID:     claims.BusinessTypeID,
 		RoleID:             claims.RoleID,
 		SubscriptionStatus: claims.SubscriptionStatus,
+		SessionID:          claims.SessionID,
 	}, nil
 }
 
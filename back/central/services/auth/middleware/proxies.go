@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/shared/env"
+)
+
+// TrustProxies fija en que proxies confia gin para leer X-Forwarded-For y
+// X-Real-IP. Por defecto gin confia en todos, y entonces cualquiera elige su
+// c.ClientIP() con un header, saltandose la lista de IPs de las API Keys y
+// el registro de sesiones. Sin TRUSTED_PROXIES no se confia en ninguno y la
+// IP es la de la conexion; si la lista no es valida tampoco.
+func TrustProxies(r *gin.Engine, cfg env.IConfig) error {
+	var proxies []string
+	for _, p := range strings.Split(cfg.Get("TRUSTED_PROXIES"), ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			proxies = append(proxies, p)
+		}
+	}
+	r.TrustedPlatform = ""
+	if err := r.SetTrustedProxies(proxies); err != nil {
+		_ = r.SetTrustedProxies(nil)
+		return err
+	}
+	return nil
+}
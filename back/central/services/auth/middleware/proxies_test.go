@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/shared/testkit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// claveConIPs acepta la clave solo desde la IP de la oficina, como una API
+// Key con lista de IPs permitidas
+type claveConIPs struct{}
+
+func (claveConIPs) ValidateAPIKey(ctx context.Context, request ValidateAPIKeyRequest) (*ValidateAPIKeyResponse, error) {
+	if request.ClientIP != "203.0.113.7" {
+		return &ValidateAPIKeyResponse{Success: false, Message: "IP no permitida"}, nil
+	}
+	return &ValidateAPIKeyResponse{Success: true, UserID: 3, BusinessID: 10, APIKeyID: 1}, nil
+}
+
+func routerConProxies(t *testing.T, proxies string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	InitFromEnv(testkit.NewConfig("JWT_SECRET", "secreto-de-prueba"), testkit.NewSilentLogger())
+	SetAPIKeyValidator(claveConIPs{})
+
+	engine := gin.New()
+	require.NoError(t, TrustProxies(engine, testkit.NewConfig("TRUSTED_PROXIES", proxies)))
+	engine.GET("/recurso", APIKey(), func(c *gin.Context) { c.Status(http.StatusOK) })
+	return engine
+}
+
+func llamarDesde(engine *gin.Engine, remoteAddr, forwardedFor string) int {
+	req := httptest.NewRequest(http.MethodGet, "/recurso", nil)
+	req.RemoteAddr = remoteAddr
+	req.Header.Set("X-API-Key", "pk_oficina")
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestTrustProxies_XForwardedForFalsoNoPasaLaListaDeIPs(t *testing.T) {
+	engine := routerConProxies(t, "")
+
+	code := llamarDesde(engine, "198.51.100.20:5000", "203.0.113.7")
+
+	assert.Equal(t, http.StatusUnauthorized, code, "el header lo controla el cliente, no el proxy")
+	assert.Equal(t, http.StatusOK, llamarDesde(engine, "203.0.113.7:5000", ""))
+}
+
+func TestTrustProxies_ProxyConfiguradoSiReenviaLaIP(t *testing.T) {
+	engine := routerConProxies(t, "10.0.0.0/8")
+
+	assert.Equal(t, http.StatusOK, llamarDesde(engine, "10.0.0.2:5000", "203.0.113.7"))
+	assert.Equal(t, http.StatusUnauthorized, llamarDesde(engine, "198.51.100.20:5000", "203.0.113.7"))
+}
+
+func TestTrustProxies_ListaInvalidaNoConfiaEnNinguno(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+
+	err := TrustProxies(engine, testkit.NewConfig("TRUSTED_PROXIES", "no-es-una-ip"))
+
+	assert.Error(t, err)
+	engine.GET("/ip", func(c *gin.Context) { c.String(http.StatusOK, c.ClientIP()) })
+	req := httptest.NewRequest(http.MethodGet, "/ip", nil)
+	req.RemoteAddr = "198.51.100.20:5000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	assert.Equal(t, "198.51.100.20", w.Body.String())
+}
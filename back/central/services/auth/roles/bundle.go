@@ -12,14 +12,14 @@ import (
 // New inicializa el módulo de roles
 func New(
 	router *gin.RouterGroup,
-	db db.IDatabase,
+	database db.IDatabase,
 	logger log.ILogger,
 ) {
 	// 1. Inicializar Repositorio
-	repo := repository.New(db, logger)
+	repo := repository.New(database, logger)
 
 	// 2. Inicializar Caso de Uso
-	roleUC := app.New(repo, logger)
+	roleUC := app.New(repo, db.NewTransactor(database), logger)
 
 	// 3. Inicializar Handler
 	roleH := rolehandler.New(roleUC, logger)
@@ -3,6 +3,10 @@ package app
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/secamc93/probability/back/central/shared/audit"
 )
 
 // AssignPermissionsToRole asigna permisos a un rol
@@ -29,8 +33,26 @@ func (uc *RoleUseCase) AssignPermissionsToRole(ctx context.Context, roleID uint,
 		return fmt.Errorf("rol no encontrado")
 	}
 
+	before := uc.permissionIDs(ctx, roleID)
+
+	after := append([]uint(nil), permissionIDs...)
+	sort.Slice(after, func(i, j int) bool { return after[i] < after[j] })
+	added, removed := permissionDelta(before, after)
+
 	// Asignar permisos usando el repositorio
-	err = uc.repository.AssignPermissionsToRole(ctx, roleID, permissionIDs)
+	err = uc.inTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repository.AssignPermissionsToRole(ctx, roleID, permissionIDs); err != nil {
+			return err
+		}
+		return audit.Record(ctx, audit.Entry{
+			Resource:   "role",
+			ResourceID: strconv.FormatUint(uint64(roleID), 10),
+			Action:     "permissions_assigned",
+			Before:     map[string]any{"permission_ids": before},
+			After:      map[string]any{"permission_ids": after},
+			Metadata:   map[string]any{"role_name": role.Name, "added": added, "removed": removed},
+		})
+	})
 	if err != nil {
 		uc.log.Error().
 			Err(err).
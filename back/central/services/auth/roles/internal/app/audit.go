@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+	"sort"
+
+	"github.com/secamc93/probability/back/central/services/auth/roles/internal/domain"
+)
+
+// permissionIDs devuelve los permisos actuales del rol para la auditoria. Si
+// la consulta falla no bloquea el cambio: el diff queda sin el antes.
+func (uc *RoleUseCase) permissionIDs(ctx context.Context, roleID uint) []uint {
+	permissions, err := uc.repository.GetRolePermissions(ctx, roleID)
+	if err != nil {
+		uc.log.Warn(ctx).Err(err).Uint("role_id", roleID).Msg("No se pudieron leer los permisos previos del rol para auditoria")
+		return nil
+	}
+	ids := make([]uint, 0, len(permissions))
+	for _, p := range permissions {
+		ids = append(ids, p.ID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// permissionDelta compara dos listas de permisos
+func permissionDelta(before, after []uint) (added, removed []uint) {
+	inBefore := make(map[uint]bool, len(before))
+	for _, id := range before {
+		inBefore[id] = true
+	}
+	inAfter := make(map[uint]bool, len(after))
+	for _, id := range after {
+		inAfter[id] = true
+		if !inBefore[id] {
+			added = append(added, id)
+		}
+	}
+	for _, id := range before {
+		if !inAfter[id] {
+			removed = append(removed, id)
+		}
+	}
+	return added, removed
+}
+
+// roleSnapshot son los campos del rol que se auditan al crearlo o editarlo
+func roleSnapshot(role *domain.Role) map[string]any {
+	if role == nil {
+		return nil
+	}
+	return map[string]any{
+		"name":               role.Name,
+		"description":        role.Description,
+		"level":              role.Level,
+		"is_system":          role.IsSystem,
+		"require_two_factor": role.RequireTwoFactor,
+		"scope_id":           role.ScopeID,
+		"business_type_id":   role.BusinessTypeID,
+	}
+}
@@ -3,8 +3,10 @@ package app
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/secamc93/probability/back/central/services/auth/roles/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/audit"
 	"github.com/secamc93/probability/back/central/shared/log"
 )
 
@@ -24,17 +26,28 @@ type IUseCaseRole interface {
 // RoleUseCase implementa los casos de uso para roles
 type RoleUseCase struct {
 	repository domain.IRoleRepository
+	transactor domain.ITransactor
 	log        log.ILogger
 }
 
 // NewRoleUseCase crea una nueva instancia del caso de uso de roles
-func New(repository domain.IRoleRepository, log log.ILogger) IUseCaseRole {
+func New(repository domain.IRoleRepository, transactor domain.ITransactor, log log.ILogger) IUseCaseRole {
 	return &RoleUseCase{
 		repository: repository,
+		transactor: transactor,
 		log:        log,
 	}
 }
 
+// inTransaction corre fn en la transaccion del transactor; sin transactor
+// (tests) corre fn directo.
+func (uc *RoleUseCase) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if uc.transactor == nil {
+		return fn(ctx)
+	}
+	return uc.transactor.InTransaction(ctx, fn)
+}
+
 // CreateRole crea un nuevo rol
 func (uc *RoleUseCase) CreateRole(ctx context.Context, roleDTO domain.CreateRoleDTO) (*domain.Role, error) {
 	uc.log.Info().
@@ -44,7 +57,19 @@ func (uc *RoleUseCase) CreateRole(ctx context.Context, roleDTO domain.CreateRole
 		Msg("Creando nuevo rol")
 
 	// Crear el rol usando el repositorio
-	role, err := uc.repository.CreateRole(ctx, roleDTO)
+	var role *domain.Role
+	err := uc.inTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if role, err = uc.repository.CreateRole(ctx, roleDTO); err != nil {
+			return err
+		}
+		return audit.Record(ctx, audit.Entry{
+			Resource:   "role",
+			ResourceID: strconv.FormatUint(uint64(role.ID), 10),
+			Action:     "created",
+			After:      roleSnapshot(role),
+		})
+	})
 	if err != nil {
 		uc.log.Error().
 			Err(err).
@@ -105,7 +130,20 @@ func (uc *RoleUseCase) UpdateRole(ctx context.Context, id uint, roleDTO domain.U
 	}
 
 	// Actualizar el rol usando el repositorio
-	role, err := uc.repository.UpdateRole(ctx, id, roleDTO)
+	var role *domain.Role
+	err = uc.inTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		if role, err = uc.repository.UpdateRole(ctx, id, roleDTO); err != nil {
+			return err
+		}
+		return audit.Record(ctx, audit.Entry{
+			Resource:   "role",
+			ResourceID: strconv.FormatUint(uint64(id), 10),
+			Action:     "updated",
+			Before:     roleSnapshot(existingRole),
+			After:      roleSnapshot(role),
+		})
+	})
 	if err != nil {
 		uc.log.Error().
 			Err(err).
@@ -68,6 +68,7 @@ func entityToRoleDTO(role domain.Role) domain.RoleDTO {
 		Description:      role.Description,
 		Level:            role.Level,
 		IsSystem:         role.IsSystem,
+		RequireTwoFactor: role.RequireTwoFactor,
 		ScopeID:          role.ScopeID,
 		ScopeName:        role.ScopeName,
 		ScopeCode:        role.ScopeCode,
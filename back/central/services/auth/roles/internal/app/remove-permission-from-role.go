@@ -3,6 +3,9 @@ package app
 import (
 	"context"
 	"fmt"
+	"strconv"
+
+	"github.com/secamc93/probability/back/central/shared/audit"
 )
 
 // RemovePermissionFromRole elimina un permiso específico de un rol
@@ -30,7 +33,17 @@ func (uc *RoleUseCase) RemovePermissionFromRole(ctx context.Context, roleID uint
 	}
 
 	// Eliminar permiso usando el repositorio
-	err = uc.repository.RemovePermissionFromRole(ctx, roleID, permissionID)
+	err = uc.inTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repository.RemovePermissionFromRole(ctx, roleID, permissionID); err != nil {
+			return err
+		}
+		return audit.Record(ctx, audit.Entry{
+			Resource:   "role",
+			ResourceID: strconv.FormatUint(uint64(roleID), 10),
+			Action:     "permission_removed",
+			Metadata:   map[string]any{"role_name": role.Name, "removed": []uint{permissionID}},
+		})
+	})
 	if err != nil {
 		uc.log.Error().
 			Err(err).
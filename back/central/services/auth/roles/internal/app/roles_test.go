@@ -15,7 +15,7 @@ func newRolesUseCase(repo *mocks.RoleRepositoryMock) IUseCaseRole {
 	if repo == nil {
 		repo = &mocks.RoleRepositoryMock{}
 	}
-	return New(repo, mocks.NewSilentLogger())
+	return New(repo, nil, mocks.NewSilentLogger())
 }
 
 func strPtr(v string) *string { return &v }
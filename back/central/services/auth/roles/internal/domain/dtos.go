@@ -8,6 +8,7 @@ type Role struct {
 	Description      string
 	Level            int
 	IsSystem         bool
+	RequireTwoFactor bool // Los usuarios con este rol deben usar 2FA
 	ScopeID          uint
 	ScopeName        string
 	ScopeCode        string
@@ -24,6 +25,7 @@ type RoleDTO struct {
 	Description      string
 	Level            int
 	IsSystem         bool
+	RequireTwoFactor bool // Los usuarios con este rol deben usar 2FA
 	ScopeID          uint
 	ScopeName        string // Nombre del scope para mostrar
 	ScopeCode        string // Código del scope para mostrar
@@ -32,29 +34,32 @@ type RoleDTO struct {
 }
 
 type CreateRoleDTO struct {
-	Name           string
-	Description    string
-	Level          int
-	IsSystem       bool
-	ScopeID        uint
-	BusinessTypeID uint
+	Name             string
+	Description      string
+	Level            int
+	IsSystem         bool
+	RequireTwoFactor bool
+	ScopeID          uint
+	BusinessTypeID   uint
 }
 
 type UpdateRoleDTO struct {
-	Name           *string
-	Description    *string
-	Level          *int
-	IsSystem       *bool
-	ScopeID        *uint
-	BusinessTypeID *uint
+	Name             *string
+	Description      *string
+	Level            *int
+	IsSystem         *bool
+	RequireTwoFactor *bool
+	ScopeID          *uint
+	BusinessTypeID   *uint
 }
 
 type RoleFilters struct {
-	Name           *string
-	Level          *int
-	IsSystem       *bool
-	ScopeID        *uint
-	BusinessTypeID *uint
+	Name             *string
+	Level            *int
+	IsSystem         *bool
+	RequireTwoFactor *bool
+	ScopeID          *uint
+	BusinessTypeID   *uint
 }
 
 type Permission struct {
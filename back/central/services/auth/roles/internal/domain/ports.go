@@ -15,3 +15,9 @@ type IRoleRepository interface {
 	GetSystemRoles(ctx context.Context) ([]Role, error)
 	RemovePermissionFromRole(ctx context.Context, roleID uint, permissionID uint) error
 }
+
+// ITransactor abre una transaccion: lo que se escriba con el ctx que recibe fn
+// (el rol, sus permisos y su entrada de auditoria) se confirma o revierte junto.
+type ITransactor interface {
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -9,12 +9,13 @@ import (
 // ToCreateRoleDTO convierte el request a DTO de dominio
 func ToCreateRoleDTO(req request.CreateRoleRequest) domain.CreateRoleDTO {
 	return domain.CreateRoleDTO{
-		Name:           req.Name,
-		Description:    req.Description,
-		Level:          req.Level,
-		IsSystem:       req.IsSystem,
-		ScopeID:        req.ScopeID,
-		BusinessTypeID: req.BusinessTypeID,
+		Name:             req.Name,
+		Description:      req.Description,
+		Level:            req.Level,
+		IsSystem:         req.IsSystem,
+		RequireTwoFactor: req.RequireTwoFactor,
+		ScopeID:          req.ScopeID,
+		BusinessTypeID:   req.BusinessTypeID,
 	}
 }
 
@@ -24,15 +25,16 @@ func ToCreateRoleResponse(role *domain.Role) response.CreateRoleResponse {
 		Success: true,
 		Message: "Rol creado exitosamente",
 		Data: response.RoleData{
-			ID:             role.ID,
-			Name:           role.Name,
-			Description:    role.Description,
-			Level:          role.Level,
-			IsSystem:       role.IsSystem,
-			ScopeID:        role.ScopeID,
-			BusinessTypeID: role.BusinessTypeID,
-			CreatedAt:      role.CreatedAt,
-			UpdatedAt:      role.UpdatedAt,
+			ID:               role.ID,
+			Name:             role.Name,
+			Description:      role.Description,
+			Level:            role.Level,
+			IsSystem:         role.IsSystem,
+			RequireTwoFactor: role.RequireTwoFactor,
+			ScopeID:          role.ScopeID,
+			BusinessTypeID:   role.BusinessTypeID,
+			CreatedAt:        role.CreatedAt,
+			UpdatedAt:        role.UpdatedAt,
 		},
 	}
 }
@@ -40,12 +42,13 @@ func ToCreateRoleResponse(role *domain.Role) response.CreateRoleResponse {
 // ToUpdateRoleDTO convierte el request de actualización a DTO de dominio
 func ToUpdateRoleDTO(req request.UpdateRoleRequest) domain.UpdateRoleDTO {
 	return domain.UpdateRoleDTO{
-		Name:           req.Name,
-		Description:    req.Description,
-		Level:          req.Level,
-		IsSystem:       req.IsSystem,
-		ScopeID:        req.ScopeID,
-		BusinessTypeID: req.BusinessTypeID,
+		Name:             req.Name,
+		Description:      req.Description,
+		Level:            req.Level,
+		IsSystem:         req.IsSystem,
+		RequireTwoFactor: req.RequireTwoFactor,
+		ScopeID:          req.ScopeID,
+		BusinessTypeID:   req.BusinessTypeID,
 	}
 }
 
@@ -55,15 +58,16 @@ func ToUpdateRoleResponse(role *domain.Role) response.UpdateRoleResponse {
 		Success: true,
 		Message: "Rol actualizado exitosamente",
 		Data: response.RoleData{
-			ID:             role.ID,
-			Name:           role.Name,
-			Description:    role.Description,
-			Level:          role.Level,
-			IsSystem:       role.IsSystem,
-			ScopeID:        role.ScopeID,
-			BusinessTypeID: role.BusinessTypeID,
-			CreatedAt:      role.CreatedAt,
-			UpdatedAt:      role.UpdatedAt,
+			ID:               role.ID,
+			Name:             role.Name,
+			Description:      role.Description,
+			Level:            role.Level,
+			IsSystem:         role.IsSystem,
+			RequireTwoFactor: role.RequireTwoFactor,
+			ScopeID:          role.ScopeID,
+			BusinessTypeID:   role.BusinessTypeID,
+			CreatedAt:        role.CreatedAt,
+			UpdatedAt:        role.UpdatedAt,
 		},
 	}
 }
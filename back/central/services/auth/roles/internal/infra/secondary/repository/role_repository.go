@@ -12,12 +12,13 @@ import (
 func (r *Repository) CreateRole(ctx context.Context, roleDTO domain.CreateRoleDTO) (*domain.Role, error) {
 	// Crear el modelo de GORM
 	role := models.Role{
-		Name:           roleDTO.Name,
-		Description:    roleDTO.Description,
-		Level:          roleDTO.Level,
-		IsSystem:       roleDTO.IsSystem,
-		ScopeID:        roleDTO.ScopeID,
-		BusinessTypeID: &roleDTO.BusinessTypeID, // Convertir a puntero
+		Name:             roleDTO.Name,
+		Description:      roleDTO.Description,
+		Level:            roleDTO.Level,
+		IsSystem:         roleDTO.IsSystem,
+		RequireTwoFactor: roleDTO.RequireTwoFactor,
+		ScopeID:          roleDTO.ScopeID,
+		BusinessTypeID:   &roleDTO.BusinessTypeID, // Convertir a puntero
 	}
 
 	// Insertar en la base de datos
@@ -32,15 +33,16 @@ func (r *Repository) CreateRole(ctx context.Context, roleDTO domain.CreateRoleDT
 
 	// Convertir a entidad de dominio
 	domainRole := &domain.Role{
-		ID:             role.ID,
-		Name:           role.Name,
-		Description:    role.Description,
-		Level:          role.Level,
-		IsSystem:       role.IsSystem,
-		ScopeID:        role.ScopeID,
-		BusinessTypeID: *role.BusinessTypeID, // Convertir de puntero a valor
-		CreatedAt:      role.CreatedAt,
-		UpdatedAt:      role.UpdatedAt,
+		ID:               role.ID,
+		Name:             role.Name,
+		Description:      role.Description,
+		Level:            role.Level,
+		IsSystem:         role.IsSystem,
+		RequireTwoFactor: role.RequireTwoFactor,
+		ScopeID:          role.ScopeID,
+		BusinessTypeID:   *role.BusinessTypeID, // Convertir de puntero a valor
+		CreatedAt:        role.CreatedAt,
+		UpdatedAt:        role.UpdatedAt,
 	}
 
 	r.logger.Info().
@@ -87,6 +89,7 @@ func (r *Repository) GetRoleByID(ctx context.Context, roleID uint) (*domain.Role
 		Description:      role.Description,
 		Level:            role.Level,
 		IsSystem:         role.IsSystem,
+		RequireTwoFactor: role.RequireTwoFactor,
 		ScopeID:          role.ScopeID,
 		ScopeName:        scopeName,
 		ScopeCode:        scopeCode,
@@ -138,6 +141,7 @@ func (r *Repository) GetRoles(ctx context.Context) ([]domain.Role, error) {
 			Description:      role.Description,
 			Level:            role.Level,
 			IsSystem:         role.IsSystem,
+			RequireTwoFactor: role.RequireTwoFactor,
 			ScopeID:          role.ScopeID,
 			ScopeName:        scopeName,
 			ScopeCode:        scopeCode,
@@ -189,6 +193,7 @@ func (r *Repository) GetRolesByLevel(ctx context.Context, level int) ([]domain.R
 			Description:      role.Description,
 			Level:            role.Level,
 			IsSystem:         role.IsSystem,
+			RequireTwoFactor: role.RequireTwoFactor,
 			ScopeID:          role.ScopeID,
 			ScopeName:        scopeName,
 			ScopeCode:        scopeCode,
@@ -240,6 +245,7 @@ func (r *Repository) GetRolesByScopeID(ctx context.Context, scopeID uint) ([]dom
 			Description:      role.Description,
 			Level:            role.Level,
 			IsSystem:         role.IsSystem,
+			RequireTwoFactor: role.RequireTwoFactor,
 			ScopeID:          role.ScopeID,
 			ScopeName:        scopeName,
 			ScopeCode:        scopeCode,
@@ -291,6 +297,7 @@ func (r *Repository) GetSystemRoles(ctx context.Context) ([]domain.Role, error)
 			Description:      role.Description,
 			Level:            role.Level,
 			IsSystem:         role.IsSystem,
+			RequireTwoFactor: role.RequireTwoFactor,
 			ScopeID:          role.ScopeID,
 			ScopeName:        scopeName,
 			ScopeCode:        scopeCode,
@@ -349,6 +356,9 @@ func (r *Repository) UpdateRole(ctx context.Context, id uint, roleDTO domain.Upd
 	if roleDTO.IsSystem != nil {
 		updates["is_system"] = *roleDTO.IsSystem
 	}
+	if roleDTO.RequireTwoFactor != nil {
+		updates["require_two_factor"] = *roleDTO.RequireTwoFactor
+	}
 	if roleDTO.ScopeID != nil {
 		updates["scope_id"] = *roleDTO.ScopeID
 	}
@@ -371,17 +381,18 @@ func (r *Repository) UpdateRole(ctx context.Context, id uint, roleDTO domain.Upd
 		}
 
 		return &domain.Role{
-			ID:             existingRole.ID,
-			Name:           existingRole.Name,
-			Description:    existingRole.Description,
-			Level:          existingRole.Level,
-			IsSystem:       existingRole.IsSystem,
-			ScopeID:        existingRole.ScopeID,
-			ScopeName:      scopeName,
-			ScopeCode:      scopeCode,
-			BusinessTypeID: businessTypeID,
-			CreatedAt:      existingRole.CreatedAt,
-			UpdatedAt:      existingRole.UpdatedAt,
+			ID:               existingRole.ID,
+			Name:             existingRole.Name,
+			Description:      existingRole.Description,
+			Level:            existingRole.Level,
+			IsSystem:         existingRole.IsSystem,
+			RequireTwoFactor: existingRole.RequireTwoFactor,
+			ScopeID:          existingRole.ScopeID,
+			ScopeName:        scopeName,
+			ScopeCode:        scopeCode,
+			BusinessTypeID:   businessTypeID,
+			CreatedAt:        existingRole.CreatedAt,
+			UpdatedAt:        existingRole.UpdatedAt,
 		}, nil
 	}
 
@@ -432,6 +443,7 @@ func (r *Repository) UpdateRole(ctx context.Context, id uint, roleDTO domain.Upd
 		Description:      updatedRole.Description,
 		Level:            updatedRole.Level,
 		IsSystem:         updatedRole.IsSystem,
+		RequireTwoFactor: updatedRole.RequireTwoFactor,
 		ScopeID:          updatedRole.ScopeID,
 		ScopeName:        scopeName,
 		ScopeCode:        scopeCode,
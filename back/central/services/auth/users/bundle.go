@@ -15,16 +15,16 @@ import (
 // New inicializa el módulo de users
 func New(
 	router *gin.RouterGroup,
-	db db.IDatabase,
+	database db.IDatabase,
 	logger log.ILogger,
 	cfg env.IConfig,
 	s3 storage.IS3Service,
 ) {
 	// 1. Inicializar Repositorio
-	repo := repository.New(db, logger)
+	repo := repository.New(database, logger)
 
 	// 2. Inicializar Caso de Uso
-	userUC := app.New(repo, logger, s3, cfg)
+	userUC := app.New(repo, db.NewTransactor(database), logger, s3, cfg)
 
 	// 3. Inicializar Handler
 	userH := handlers.New(userUC, logger)
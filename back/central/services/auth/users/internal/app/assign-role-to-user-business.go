@@ -3,8 +3,10 @@ package app
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/secamc93/probability/back/central/services/auth/users/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/audit"
 )
 
 // AssignRoleToUserBusiness asigna o actualiza roles de un usuario en múltiples businesses
@@ -26,13 +28,32 @@ func (uc *UserUseCase) AssignRoleToUserBusiness(ctx context.Context, userID uint
 	// - Que todos los roles existen
 	// - Que cada rol es del mismo tipo de business que su business asociado
 	// - Que el usuario está asociado a cada business en business_staff
-	if err := uc.repository.AssignRoleToUserBusiness(ctx, userID, assignments); err != nil {
+	err := uc.inTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repository.AssignRoleToUserBusiness(ctx, userID, assignments); err != nil {
+			return fmt.Errorf("error al asignar roles: %w", err)
+		}
+		// Una entrada por negocio: cada una va a la bitacora de ese negocio
+		for _, a := range assignments {
+			businessID := a.BusinessID
+			if err := audit.Record(ctx, audit.Entry{
+				BusinessID: &businessID,
+				Resource:   "user",
+				ResourceID: strconv.FormatUint(uint64(userID), 10),
+				Action:     "role_assigned",
+				After:      map[string]any{"role_id": a.RoleID},
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
 		uc.log.Error().
 			Err(err).
 			Uint("user_id", userID).
 			Int("assignments_count", len(assignments)).
 			Msg("Error al asignar roles a usuario en businesses")
-		return fmt.Errorf("error al asignar roles: %w", err)
+		return err
 	}
 
 	uc.log.Info().
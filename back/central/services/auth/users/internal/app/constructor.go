@@ -25,17 +25,28 @@ func (uc *UserUseCase) GetUserBusinesses(ctx context.Context, userID uint) ([]do
 // UserUseCase implementa los casos de uso para usuarios
 type UserUseCase struct {
 	repository domain.IUserRepository
+	transactor domain.ITransactor
 	log        log.ILogger
 	s3         domain.IS3Service
 	env        env.IConfig
 }
 
 // NewUserUseCase crea una nueva instancia del caso de uso de usuarios
-func New(repository domain.IUserRepository, log log.ILogger, s3 domain.IS3Service, env env.IConfig) Iapp {
+func New(repository domain.IUserRepository, transactor domain.ITransactor, log log.ILogger, s3 domain.IS3Service, env env.IConfig) Iapp {
 	return &UserUseCase{
 		repository: repository,
+		transactor: transactor,
 		log:        log,
 		s3:         s3,
 		env:        env,
 	}
 }
+
+// inTransaction corre fn en la transaccion del transactor; sin transactor
+// (tests) corre fn directo.
+func (uc *UserUseCase) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if uc.transactor == nil {
+		return fn(ctx)
+	}
+	return uc.transactor.InTransaction(ctx, fn)
+}
@@ -21,7 +21,7 @@ func newUseCase(repo *mocks.UserRepositoryMock, s3 *mocks.S3ServiceMock, cfg map
 	if s3 == nil {
 		s3 = &mocks.S3ServiceMock{}
 	}
-	return New(repo, mocks.NewSilentLogger(), s3, mocks.NewConfigMock(cfg))
+	return New(repo, nil, mocks.NewSilentLogger(), s3, mocks.NewConfigMock(cfg))
 }
 
 func uintPtr(v uint) *uint { return &v }
@@ -225,22 +225,6 @@ type Permission struct {
 	BusinessTypeName string
 }
 
-type APIKey struct {
-	ID          uint
-	UserID      uint
-	BusinessID  uint
-	CreatedByID uint
-	Name        string
-	Description string
-	KeyHash     string
-	LastUsedAt  *time.Time
-	Revoked     bool
-	RevokedAt   *time.Time
-	RateLimit   int
-	IPWhitelist string
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-}
 type Resource struct {
 	ID               uint
 	Name             string
@@ -251,17 +235,6 @@ type Resource struct {
 	UpdatedAt        time.Time
 	DeletedAt        *time.Time
 }
-type APIKeyInfo struct {
-	ID          uint
-	UserID      uint
-	BusinessID  uint
-	Name        string
-	Description string
-	LastUsedAt  *time.Time
-	Revoked     bool
-	RateLimit   int
-	CreatedAt   time.Time
-}
 type BusinessStaffRelation struct {
 	UserID     uint
 	BusinessID *uint               // NULL para super usuarios
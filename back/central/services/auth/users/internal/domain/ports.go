@@ -35,3 +35,9 @@ type IS3Service interface {
 	GetFileURL(ctx context.Context, filename string) (string, error)
 	UploadImage(ctx context.Context, file *multipart.FileHeader, folder string) (string, error)
 }
+
+// ITransactor abre una transaccion: lo que se escriba con el ctx que recibe fn
+// (las asignaciones y su entrada de auditoria) se confirma o revierte junto.
+type ITransactor interface {
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -504,92 +504,6 @@ func (r *Repository) AssignRolesToUser(ctx context.Context, userID uint, roleIDs
 	})
 }
 
-func (r *Repository) CreateAPIKey(ctx context.Context, apiKey domain.APIKey, keyHash string) (uint, error) {
-	dbAPIKey := mappers.CreateAPIKeyModel(apiKey, keyHash)
-
-	if err := r.database.Conn(ctx).Model(&models.APIKey{}).Create(&dbAPIKey).Error; err != nil {
-		r.logger.Error().Err(err).
-			Uint("user_id", apiKey.UserID).
-			Uint("business_id", apiKey.BusinessID).
-			Msg("Error al crear API Key")
-		return 0, err
-	}
-
-	return dbAPIKey.Model.ID, nil
-}
-
-func (r *Repository) ValidateAPIKey(ctx context.Context, apiKey string) (*domain.APIKey, error) {
-	var dbAPIKeys []models.APIKey
-
-	err := r.database.Conn(ctx).
-		Model(&models.APIKey{}).
-		Where("revoked = ?", false).
-		Find(&dbAPIKeys).Error
-
-	if err != nil {
-		r.logger.Error().Err(err).Msg("Error al buscar API Keys")
-		return nil, err
-	}
-
-	for _, dbAPIKey := range dbAPIKeys {
-		if apiKey == dbAPIKey.KeyHash {
-			if err := r.UpdateAPIKeyLastUsed(ctx, dbAPIKey.Model.ID); err != nil {
-				r.logger.Warn().Uint("api_key_id", dbAPIKey.Model.ID).Err(err).Msg("Error al actualizar último uso")
-			}
-
-			entity := mappers.ToAPIKeyEntity(dbAPIKey)
-			return &entity, nil
-		}
-	}
-
-	return nil, nil
-}
-
-func (r *Repository) UpdateAPIKeyLastUsed(ctx context.Context, apiKeyID uint) error {
-	now := time.Now()
-	if err := r.database.Conn(ctx).
-		Model(&models.APIKey{}).
-		Where("id = ?", apiKeyID).
-		Update("last_used_at", now).Error; err != nil {
-		r.logger.Error().Uint("api_key_id", apiKeyID).Err(err).Msg("Error al actualizar último uso de API Key")
-		return err
-	}
-
-	return nil
-}
-
-func (r *Repository) GetAPIKeysByUser(ctx context.Context, userID uint) ([]domain.APIKeyInfo, error) {
-	var dbAPIKeys []models.APIKey
-
-	err := r.database.Conn(ctx).
-		Model(&models.APIKey{}).
-		Where("user_id = ?", userID).
-		Find(&dbAPIKeys).Error
-
-	if err != nil {
-		r.logger.Error().Uint("user_id", userID).Err(err).Msg("Error al obtener API Keys del usuario")
-		return nil, err
-	}
-
-	apiKeys := mappers.ToAPIKeyInfoEntitySlice(dbAPIKeys)
-	return apiKeys, nil
-}
-
-func (r *Repository) RevokeAPIKey(ctx context.Context, apiKeyID uint) error {
-	now := time.Now()
-	if err := r.database.Conn(ctx).
-		Model(&models.APIKey{}).
-		Where("id = ?", apiKeyID).
-		Updates(map[string]interface{}{
-			"revoked":    true,
-			"revoked_at": now,
-			"updated_at": now,
-		}).Error; err != nil {
-		return err
-	}
-	return nil
-}
-
 // GetBusinessConfiguredResourcesIDs obtiene los IDs de recursos ACTIVOS configurados para un business específico
 func (r *Repository) GetBusinessConfiguredResourcesIDs(ctx context.Context, businessID uint) ([]uint, error) {
 	var resourcesIDs []uint
@@ -49,6 +49,7 @@ func (d *EventDispatcher) HandleEvent(ctx context.Context, event entities.Event)
 
 	// Para cada config habilitada -> validar condiciones -> rutear por canal
 	ssePublished := false
+	webhookPublished := false
 	for _, config := range configs {
 		// Validar condiciones (OrderStatusCodes)
 		if !d.validateConditions(event, config) {
@@ -93,6 +94,24 @@ func (d *EventDispatcher) HandleEvent(ctx context.Context, event entities.Event)
 					Msg("Evento ruteado a Email")
 			}
 
+		case dtos.NotificationTypeWebhook:
+			// Varias configs webhook pueden cubrir el mismo evento; el modulo
+			// webhooks lo reparte a todos los endpoints del negocio
+			if webhookPublished {
+				continue
+			}
+			if err := d.channelPublisher.PublishToWebhook(ctx, event, config); err != nil {
+				d.logger.Error(ctx).
+					Err(err).
+					Uint("config_id", config.ID).
+					Msg("Error publicando a Webhook")
+			} else {
+				webhookPublished = true
+				d.logger.Info(ctx).
+					Uint("config_id", config.ID).
+					Msg("Evento ruteado a Webhook")
+			}
+
 		default:
 			d.logger.Warn(ctx).
 				Uint("notification_type_id", config.NotificationTypeID).
@@ -0,0 +1,74 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/secamc93/probability/back/central/services/events/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/events/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+type fakeSSE struct{ published int }
+
+func (f *fakeSSE) AddConnection(uint, *entities.SSEConnectionFilter, http.ResponseWriter) string {
+	return ""
+}
+func (f *fakeSSE) RemoveConnection(string)                                {}
+func (f *fakeSSE) PublishEvent(entities.Event)                            { f.published++ }
+func (f *fakeSSE) GetConnectionCount(uint) int                            { return 0 }
+func (f *fakeSSE) GetConnectionInfo(uint) map[string]interface{}          { return nil }
+func (f *fakeSSE) GetRecentEventsByBusiness(uint, int64) []entities.Event { return nil }
+func (f *fakeSSE) HasRecentEvents(uint) bool                              { return false }
+func (f *fakeSSE) Stop()                                                  {}
+
+type fakeConfigCache struct {
+	configs []entities.CachedNotificationConfig
+}
+
+func (f *fakeConfigCache) GetActiveConfigsByIntegrationAndTrigger(context.Context, uint, string) ([]entities.CachedNotificationConfig, error) {
+	return f.configs, nil
+}
+
+type fakeChannels struct{ webhooks []uint }
+
+func (f *fakeChannels) PublishToWhatsApp(context.Context, entities.Event, entities.CachedNotificationConfig) error {
+	return nil
+}
+func (f *fakeChannels) PublishToEmail(context.Context, entities.Event, entities.CachedNotificationConfig) error {
+	return nil
+}
+func (f *fakeChannels) PublishToWebhook(_ context.Context, _ entities.Event, config entities.CachedNotificationConfig) error {
+	f.webhooks = append(f.webhooks, config.ID)
+	return nil
+}
+
+func TestHandleEventWebhookSePublicaUnaVezPorEvento(t *testing.T) {
+	sse := &fakeSSE{}
+	channels := &fakeChannels{}
+	cache := &fakeConfigCache{configs: []entities.CachedNotificationConfig{
+		{ID: 1, NotificationTypeID: dtos.NotificationTypeWebhook, OrderStatusCodes: []string{"cancelled"}},
+		{ID: 2, NotificationTypeID: dtos.NotificationTypeWebhook, OrderStatusCodes: []string{"shipped"}},
+		{ID: 3, NotificationTypeID: dtos.NotificationTypeWebhook},
+	}}
+	dispatcher := New(sse, cache, channels, log.NewFromZerolog(zerolog.Nop()))
+
+	event := entities.Event{
+		ID:         "evt-1",
+		Type:       dtos.OrderStatusChanged,
+		BusinessID: 7,
+		Data:       map[string]interface{}{"current_status": "shipped"},
+	}
+	if err := dispatcher.HandleEvent(context.Background(), event); err != nil {
+		t.Fatalf("HandleEvent() error = %v", err)
+	}
+
+	if len(channels.webhooks) != 1 || channels.webhooks[0] != 2 {
+		t.Errorf("webhooks publicados = %v, se esperaba solo la config 2", channels.webhooks)
+	}
+	if sse.published != 1 {
+		t.Errorf("SSE publicados = %d, se esperaba el broadcast por defecto", sse.published)
+	}
+}
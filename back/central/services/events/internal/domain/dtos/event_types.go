@@ -115,3 +115,6 @@ const NotificationTypeWhatsApp = 2
 
 // NotificationTypeEmail es el ID del tipo de notificación Email
 const NotificationTypeEmail = 3
+
+// NotificationTypeWebhook es el ID del tipo de notificación Webhook
+const NotificationTypeWebhook = 5
@@ -24,10 +24,11 @@ type INotificationConfigCache interface {
 	GetActiveConfigsByIntegrationAndTrigger(ctx context.Context, integrationID uint, trigger string) ([]entities.CachedNotificationConfig, error)
 }
 
-// IChannelPublisher define el puerto para publicar eventos a canales específicos (WhatsApp, Email)
+// IChannelPublisher define el puerto para publicar eventos a canales específicos (WhatsApp, Email, Webhook)
 type IChannelPublisher interface {
 	PublishToWhatsApp(ctx context.Context, event entities.Event, config entities.CachedNotificationConfig) error
 	PublishToEmail(ctx context.Context, event entities.Event, config entities.CachedNotificationConfig) error
+	PublishToWebhook(ctx context.Context, event entities.Event, config entities.CachedNotificationConfig) error
 }
 
 // IEventDispatcher define el puerto para el dispatcher de eventos (capa de aplicación)
@@ -77,9 +77,9 @@ func (c *OrderEventConsumer) Start(ctx context.Context) error {
 		Str("queue", rabbitmq.QueueOrdersToEvents).
 		Msg("Iniciando consumer de eventos de ordenes (fanout -> events dispatcher)")
 
-	return c.rabbitMQ.Consume(ctx, rabbitmq.QueueOrdersToEvents, func(body []byte) error {
-		return c.handleMessage(ctx, body)
-	})
+	return rabbitmq.ConsumeDeliveries(ctx, c.rabbitMQ, rabbitmq.QueueOrdersToEvents, func(msgCtx context.Context, d rabbitmq.Delivery) error {
+		return c.handleMessage(msgCtx, d.Body)
+	}, 1)
 }
 
 func (c *OrderEventConsumer) handleMessage(ctx context.Context, body []byte) error {
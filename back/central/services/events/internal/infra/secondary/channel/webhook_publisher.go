@@ -0,0 +1,75 @@
+package channel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/secamc93/probability/back/central/services/events/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/events/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/shared/rabbitmq"
+)
+
+// PublishToWebhook encola el evento para el modulo webhooks, que lo firma y
+// lo entrega a los endpoints activos del negocio.
+func (p *channelPublisher) PublishToWebhook(ctx context.Context, event entities.Event, config entities.CachedNotificationConfig) error {
+	if event.BusinessID == 0 {
+		p.logger.Warn(ctx).
+			Str("event_id", event.ID).
+			Uint("config_id", config.ID).
+			Msg("Evento sin business_id, saltando publicación a Webhook")
+		return nil
+	}
+
+	// El id del evento es la llave de idempotencia del receptor: sin el no
+	// hay forma de reconocer un reintento
+	eventID := event.ID
+	if eventID == "" {
+		eventID = uuid.NewString()
+	}
+	timestamp := event.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	payload := map[string]interface{}{
+		"event_id":       eventID,
+		"event_type":     event.Type,
+		"category":       event.Category,
+		"business_id":    event.BusinessID,
+		"integration_id": event.IntegrationID,
+		"config_id":      config.ID,
+		"timestamp":      timestamp.UTC().Format(time.RFC3339),
+		"data":           event.Data,
+	}
+
+	jsonBytes, err := json.Marshal(payload)
+	if err != nil {
+		p.logger.Error(ctx).
+			Err(err).
+			Str("event_id", event.ID).
+			Msg("Error serializando payload para Webhook queue")
+		return fmt.Errorf("%w: Webhook payload: %v", domainerrors.ErrSerializeFailed, err)
+	}
+
+	if err := p.rabbitMQ.Publish(ctx, rabbitmq.QueueEventsWebhookDeliveries, jsonBytes); err != nil {
+		p.logger.Error(ctx).
+			Err(err).
+			Str("event_id", event.ID).
+			Str("queue", rabbitmq.QueueEventsWebhookDeliveries).
+			Msg("Error publicando a Webhook queue")
+		return fmt.Errorf("%w: Webhook queue: %v", domainerrors.ErrPublishFailed, err)
+	}
+
+	p.logger.Info(ctx).
+		Str("event_id", eventID).
+		Str("event_type", event.Type).
+		Uint("business_id", event.BusinessID).
+		Uint("config_id", config.ID).
+		Str("queue", rabbitmq.QueueEventsWebhookDeliveries).
+		Msg("Evento encolado para Webhook")
+
+	return nil
+}
@@ -39,7 +39,7 @@ func New(router *gin.RouterGroup, db db.IDatabase, logger log.ILogger, config en
 	messaging.New(config, logger, rabbitMQ, redisClient, integrationCore, emailService, router)
 
 	// E-commerce: todos los proveedores de e-commerce
-	ecommerce.New(router, logger, config, rabbitMQ, db, integrationCore)
+	ecommerce.New(router, logger, config, rabbitMQ, db, s3, integrationCore)
 
 	// Resultado de la ultima sincronizacion por integracion (inventario / productos)
 	syncruns.New(router, db, logger, rabbitMQ)
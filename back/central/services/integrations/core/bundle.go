@@ -61,6 +61,7 @@ const (
 	IntegrationTypeJumpseller   = domain.IntegrationTypeJumpseller
 	IntegrationTypeShipit       = domain.IntegrationTypeShipit
 	IntegrationTypeTikTok       = domain.IntegrationTypeTikTok
+	IntegrationTypeDian         = domain.IntegrationTypeDian
 )
 
 type IIntegrationService interface {
@@ -100,7 +101,7 @@ type integrationCore struct {
 	encryption domain.IEncryptionService
 }
 
-func New(router *gin.RouterGroup, db db.IDatabase, redisClient redis.IRedis, logger log.ILogger, config env.IConfig, s3 storage.IS3Service, rabbitMQ rabbitmq.IQueue) IIntegrationCore {
+func New(router *gin.RouterGroup, database db.IDatabase, redisClient redis.IRedis, logger log.ILogger, config env.IConfig, s3 storage.IS3Service, rabbitMQ rabbitmq.IQueue) IIntegrationCore {
 	encryptionService := encryption.New(config, logger)
 
 	integrationCache := cache.New(redisClient, logger)
@@ -112,9 +113,9 @@ func New(router *gin.RouterGroup, db db.IDatabase, redisClient redis.IRedis, log
 	redisClient.RegisterCachePrefix("integration:idx:*")
 	redisClient.RegisterCachePrefix("integration:platform_creds:*")
 
-	repo := repository.New(db, logger, encryptionService, integrationCache)
+	repo := repository.New(database, logger, encryptionService, integrationCache)
 
-	integrationUseCase := usecaseintegrations.New(repo, encryptionService, integrationCache, logger, config, rabbitMQ)
+	integrationUseCase := usecaseintegrations.New(repo, db.NewTransactor(database), encryptionService, integrationCache, logger, config, rabbitMQ)
 	integrationTypeUseCase := usecaseintegrationtype.New(repo, s3, integrationCache, logger, config, encryptionService)
 
 	handlerIntegrations := handlerintegrations.New(integrationUseCase, logger, config)
@@ -0,0 +1,37 @@
+package usecaseintegrations
+
+import (
+	"sort"
+
+	"github.com/secamc93/probability/back/central/services/integrations/core/internal/domain"
+)
+
+// integrationSnapshot son los campos de la integracion que se auditan. Las
+// credenciales no entran: se guardan cifradas y solo se registra que llaves
+// se reemplazaron.
+func integrationSnapshot(integration *domain.Integration) map[string]any {
+	if integration == nil {
+		return nil
+	}
+	return map[string]any{
+		"name":                integration.Name,
+		"code":                integration.Code,
+		"integration_type_id": integration.IntegrationTypeID,
+		"store_id":            integration.StoreID,
+		"is_active":           integration.IsActive,
+		"is_default":          integration.IsDefault,
+		"is_testing":          integration.IsTesting,
+		"description":         integration.Description,
+		"config":              integration.Config,
+	}
+}
+
+// credentialKeys devuelve los nombres (nunca los valores) de las credenciales
+func credentialKeys(credentials map[string]interface{}) []string {
+	keys := make([]string, 0, len(credentials))
+	for k := range credentials {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -1,6 +1,8 @@
 package usecaseintegrations
 
 import (
+	"context"
+
 	"github.com/secamc93/probability/back/central/services/integrations/core/internal/domain"
 	"github.com/secamc93/probability/back/central/shared/env"
 	"github.com/secamc93/probability/back/central/shared/log"
@@ -9,6 +11,7 @@ import (
 
 type IntegrationUseCase struct {
 	repo                  domain.IRepository
+	transactor            domain.ITransactor
 	encryption            domain.IEncryptionService
 	cache                 domain.IIntegrationCache
 	providerReg           *providerRegistry
@@ -20,9 +23,10 @@ type IntegrationUseCase struct {
 }
 
 // New crea una nueva instancia del caso de uso de integraciones
-func New(repo domain.IRepository, encryption domain.IEncryptionService, cache domain.IIntegrationCache, logger log.ILogger, config env.IConfig, queue rabbitmq.IQueue) *IntegrationUseCase {
+func New(repo domain.IRepository, transactor domain.ITransactor, encryption domain.IEncryptionService, cache domain.IIntegrationCache, logger log.ILogger, config env.IConfig, queue rabbitmq.IQueue) *IntegrationUseCase {
 	return &IntegrationUseCase{
 		repo:        repo,
+		transactor:  transactor,
 		encryption:  encryption,
 		cache:       cache,
 		providerReg: newProviderRegistry(),
@@ -33,6 +37,15 @@ func New(repo domain.IRepository, encryption domain.IEncryptionService, cache do
 	}
 }
 
+// inTransaction corre fn en la transaccion del transactor; sin transactor
+// (tests) corre fn directo.
+func (uc *IntegrationUseCase) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if uc.transactor == nil {
+		return fn(ctx)
+	}
+	return uc.transactor.InTransaction(ctx, fn)
+}
+
 func (uc *IntegrationUseCase) RegisterObserver(observer domain.IntegrationCreatedObserver) {
 	uc.observers = append(uc.observers, observer)
 }
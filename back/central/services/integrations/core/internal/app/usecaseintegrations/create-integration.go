@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/secamc93/probability/back/central/services/integrations/core/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/audit"
 	"github.com/secamc93/probability/back/central/shared/log"
 	"gorm.io/datatypes"
 )
@@ -179,7 +180,20 @@ func (uc *IntegrationUseCase) CreateIntegration(ctx context.Context, dto domain.
 		CreatedByID:       dto.CreatedByID,
 	}
 
-	if err := uc.repo.CreateIntegration(ctx, integration); err != nil {
+	err = uc.inTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.CreateIntegration(ctx, integration); err != nil {
+			return err
+		}
+		return audit.Record(ctx, audit.Entry{
+			BusinessID: integration.BusinessID,
+			Resource:   "integration",
+			ResourceID: fmt.Sprintf("%d", integration.ID),
+			Action:     "created",
+			After:      integrationSnapshot(integration),
+			Metadata:   map[string]any{"provided_keys": credentialKeys(dto.Credentials)},
+		})
+	})
+	if err != nil {
 		if isUniqueStoreIDViolation(err) {
 			uc.log.Warn(ctx).Str("store_id", dto.StoreID).Msg("La base rechazo una cuenta de canal duplicada")
 			return nil, domain.ErrIntegrationStoreIDInUse
@@ -21,7 +21,7 @@ func newTestUseCase(
 ) *IntegrationUseCase {
 	q := new(mocks.QueueMock)
 	q.On("Publish", mock.Anything, mock.Anything, mock.Anything).Maybe().Return(nil)
-	return New(repo, enc, cache, logger, cfg, q)
+	return New(repo, nil, enc, cache, logger, cfg, q)
 }
 
 // configurarLoggerPermisivo configura el logger para que acepte cualquier llamada
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/secamc93/probability/back/central/services/integrations/core/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/audit"
 	"github.com/secamc93/probability/back/central/shared/log"
 )
 
@@ -30,7 +31,19 @@ func (uc *IntegrationUseCase) DeleteIntegration(ctx context.Context, id uint, re
 		return domain.ErrIntegrationCannotDeleteWhatsApp
 	}
 
-	if err := uc.repo.DeleteIntegration(ctx, id); err != nil {
+	err = uc.inTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.DeleteIntegration(ctx, id); err != nil {
+			return err
+		}
+		return audit.Record(ctx, audit.Entry{
+			BusinessID: integration.BusinessID,
+			Resource:   "integration",
+			ResourceID: fmt.Sprintf("%d", id),
+			Action:     "deleted",
+			Before:     integrationSnapshot(integration),
+		})
+	})
+	if err != nil {
 		uc.log.Error(ctx).Err(err).Uint("id", id).Msg("Error al eliminar integración")
 		return fmt.Errorf("error al eliminar integración: %w", err)
 	}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/secamc93/probability/back/central/services/integrations/core/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/audit"
 	"github.com/secamc93/probability/back/central/shared/log"
 	"gorm.io/datatypes"
 )
@@ -20,6 +21,7 @@ func (uc *IntegrationUseCase) UpdateIntegration(ctx context.Context, id uint, dt
 
 	oldCode := existing.Code
 	oldStoreID := existing.StoreID
+	before := integrationSnapshot(existing)
 	if dto.Name != nil {
 		existing.Name = *dto.Name
 	}
@@ -96,7 +98,25 @@ func (uc *IntegrationUseCase) UpdateIntegration(ctx context.Context, id uint, dt
 		}
 	}
 
-	if err := uc.repo.UpdateIntegration(ctx, id, existing); err != nil {
+	err = uc.inTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.UpdateIntegration(ctx, id, existing); err != nil {
+			return err
+		}
+		entry := audit.Entry{
+			BusinessID: existing.BusinessID,
+			Resource:   "integration",
+			ResourceID: fmt.Sprintf("%d", id),
+			Action:     "updated",
+			Before:     before,
+			After:      integrationSnapshot(existing),
+		}
+		if dto.Credentials != nil {
+			entry.Action = "credentials_updated"
+			entry.Metadata = map[string]any{"replaced_keys": credentialKeys(*dto.Credentials)}
+		}
+		return audit.Record(ctx, entry)
+	})
+	if err != nil {
 		if isUniqueStoreIDViolation(err) {
 			uc.log.Warn(ctx).Uint("id", id).Msg("La base rechazo una cuenta de canal duplicada")
 			return nil, domain.ErrIntegrationStoreIDInUse
@@ -46,6 +46,12 @@ type IRepository interface {
 	UpdateProductMatchRules(ctx context.Context, id uint, rules datatypes.JSON) error
 }
 
+// ITransactor abre una transaccion: lo que se escriba con el ctx que recibe fn
+// (la integracion y su entrada de auditoria) se confirma o revierte junto.
+type ITransactor interface {
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type IEncryptionService interface {
 	EncryptCredentials(ctx context.Context, credentials map[string]interface{}) ([]byte, error)
 	DecryptCredentials(ctx context.Context, encryptedData []byte) (map[string]interface{}, error)
@@ -30,6 +30,7 @@ const (
 	IntegrationTypeJumpseller   = 33
 	IntegrationTypeShipit       = 34
 	IntegrationTypeTikTok       = 35
+	IntegrationTypeDian         = 36
 )
 
 const (
@@ -88,6 +89,8 @@ func IntegrationTypeCodeAsInt(code string) int {
 		return IntegrationTypeShipit
 	case "tiktok", "tiktok_shop":
 		return IntegrationTypeTikTok
+	case "dian":
+		return IntegrationTypeDian
 	case "email":
 		return IntegrationTypeEmail
 	case "tienda":
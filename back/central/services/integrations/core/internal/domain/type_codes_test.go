@@ -81,6 +81,11 @@ func TestIntegrationTypeCodeAsInt_CodigosConocidos(t *testing.T) {
 			code:     "siigo",
 			expected: IntegrationTypeSiigo,
 		},
+		{
+			name:     "dian retorna 36",
+			code:     "dian",
+			expected: IntegrationTypeDian,
+		},
 	}
 
 	for _, tt := range tests {
@@ -18,6 +18,7 @@ import (
 	"github.com/secamc93/probability/back/central/shared/env"
 	"github.com/secamc93/probability/back/central/shared/log"
 	"github.com/secamc93/probability/back/central/shared/rabbitmq"
+	"github.com/secamc93/probability/back/central/shared/storage"
 )
 
 func New(
@@ -26,6 +27,7 @@ func New(
 	config env.IConfig,
 	rabbitMQ rabbitmq.IQueue,
 	database db.IDatabase,
+	s3 storage.IS3Service,
 	integrationCore core.IIntegrationCore,
 ) {
 	shopify.New(router, logger, config, integrationCore, rabbitMQ, database)
@@ -48,7 +50,7 @@ func New(
 	amazonProvider := amazon.New(router, logger, config, rabbitMQ, integrationCore)
 	integrationCore.RegisterIntegration(core.IntegrationTypeAmazon, amazonProvider)
 
-	falabellaProvider := falabella.New(router, logger, config, rabbitMQ, integrationCore)
+	falabellaProvider := falabella.New(router, logger, config, rabbitMQ, database, s3, integrationCore)
 	integrationCore.RegisterIntegration(core.IntegrationTypeFalabella, falabellaProvider)
 
 	exitoProvider := exito.New(router, logger, config, rabbitMQ, integrationCore)
@@ -6,13 +6,19 @@ import (
 	"github.com/gin-gonic/gin"
 	integrationcore "github.com/secamc93/probability/back/central/services/integrations/core"
 	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/app/usecases"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
 	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/infra/primary/handlers"
+	falabellaqueue "github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/infra/primary/queue"
 	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/infra/secondary/client"
 	falabellacore "github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/infra/secondary/core"
 	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/infra/secondary/queue"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/infra/secondary/repository"
+	falabellastorage "github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/infra/secondary/storage"
+	"github.com/secamc93/probability/back/central/shared/db"
 	"github.com/secamc93/probability/back/central/shared/env"
 	"github.com/secamc93/probability/back/central/shared/log"
 	"github.com/secamc93/probability/back/central/shared/rabbitmq"
+	"github.com/secamc93/probability/back/central/shared/storage"
 )
 
 // New inicializa el módulo de Falabella y retorna el provider para registrar en integrationCore.
@@ -22,6 +28,8 @@ func New(
 	logger log.ILogger,
 	config env.IConfig,
 	rabbitMQ rabbitmq.IQueue,
+	database db.IDatabase,
+	s3 storage.IS3Service,
 	coreIntegration integrationcore.IIntegrationCore,
 ) integrationcore.IIntegrationContract {
 	logger = logger.WithModule("falabella")
@@ -29,6 +37,13 @@ func New(
 	// 1. Infraestructura secundaria
 	httpClient := client.New()
 	integrationService := falabellacore.NewIntegrationService(coreIntegration)
+	orderRepo := repository.New(database, logger)
+
+	// Etiquetas de despacho en S3 (sin S3 se marca la orden pero no se guarda el PDF)
+	var labelStorage domain.ILabelStorage
+	if s3 != nil {
+		labelStorage = falabellastorage.New(s3)
+	}
 
 	// Publisher de órdenes a RabbitMQ (con fallback no-op si no hay conexión)
 	var orderPublisher = queue.NewNoOpPublisher(logger)
@@ -40,12 +55,18 @@ func New(
 	}
 
 	// 2. Casos de uso
-	uc := usecases.New(httpClient, integrationService, orderPublisher, logger)
+	uc := usecases.New(httpClient, integrationService, orderPublisher, orderRepo, labelStorage, rabbitMQ, logger)
 
 	// 3. Handlers HTTP
 	handler := handlers.New(uc, logger)
 	handler.RegisterRoutes(router, logger)
 
-	// 4. Retornar provider para que el bundle padre lo registre en el core
+	// 4. Consumer del push de stock desde el modulo de inventario
+	if rabbitMQ != nil {
+		pushConsumer := falabellaqueue.NewInventoryPushConsumer(rabbitMQ, uc, logger)
+		pushConsumer.Start(context.Background())
+	}
+
+	// 5. Retornar provider para que el bundle padre lo registre en el core
 	return falabellacore.New(uc)
 }
@@ -2,22 +2,52 @@ package usecases
 
 import (
 	"context"
+	"time"
 
 	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
 	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/rabbitmq"
 )
 
 // IFalabellaUseCase define las operaciones de negocio de Falabella.
 type IFalabellaUseCase interface {
 	// TestConnection verifica que las credenciales de una integración sean válidas.
 	TestConnection(ctx context.Context, config map[string]interface{}, credentials map[string]interface{}) error
+
+	// SyncOrders importa las órdenes de los últimos 30 días.
+	SyncOrders(ctx context.Context, integrationID string) error
+
+	// SyncOrdersWithParams importa órdenes con filtros de fecha y estado.
+	SyncOrdersWithParams(ctx context.Context, integrationID string, params interface{}) error
+
+	// ProcessWebhook relee la orden notificada (o el feed) y la publica.
+	ProcessWebhook(ctx context.Context, event *domain.WebhookEvent) error
+
+	// ReadyToShip marca los ítems pendientes de una orden listos para despacho,
+	// descarga la etiqueta y la guarda como guía del envío.
+	ReadyToShip(ctx context.Context, orderID string, businessID uint) (*domain.ReadyToShipResult, error)
+
+	// UpdateInventory envía el stock de un SKU con un feed ProductUpdate.
+	UpdateInventory(ctx context.Context, integrationID string, productExternalID string, quantity int) error
+
+	// UpdatePrice envía el precio de un SKU con un feed ProductUpdate y retorna el FeedID.
+	UpdatePrice(ctx context.Context, businessID uint, integrationID string, sku string, price float64) (string, error)
+
+	// GetFeedStatus consulta el estado de un feed enviado.
+	GetFeedStatus(ctx context.Context, businessID uint, integrationID string, feedID string) (*domain.FeedStatus, error)
 }
 
 type falabellaUseCase struct {
 	client    domain.IFalabellaClient
 	service   domain.IIntegrationService
 	publisher domain.OrderPublisher
+	orderRepo domain.IOrderRepository
+	labels    domain.ILabelStorage
+	rabbit    rabbitmq.IQueue
 	logger    log.ILogger
+
+	feedPollInterval time.Duration
+	feedPollAttempts int
 }
 
 // New crea el use case de Falabella con todas sus dependencias.
@@ -25,12 +55,20 @@ func New(
 	client domain.IFalabellaClient,
 	service domain.IIntegrationService,
 	publisher domain.OrderPublisher,
+	orderRepo domain.IOrderRepository,
+	labels domain.ILabelStorage,
+	rabbit rabbitmq.IQueue,
 	logger log.ILogger,
 ) IFalabellaUseCase {
 	return &falabellaUseCase{
-		client:    client,
-		service:   service,
-		publisher: publisher,
-		logger:    logger.WithModule("falabella"),
+		client:           client,
+		service:          service,
+		publisher:        publisher,
+		orderRepo:        orderRepo,
+		labels:           labels,
+		rabbit:           rabbit,
+		logger:           logger.WithModule("falabella"),
+		feedPollInterval: 10 * time.Second,
+		feedPollAttempts: 30,
 	}
 }
@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+)
+
+// GetFeedStatus consulta el estado de un feed enviado por la integración.
+func (uc *falabellaUseCase) GetFeedStatus(ctx context.Context, businessID uint, integrationID string, feedID string) (*domain.FeedStatus, error) {
+	if feedID == "" {
+		return nil, domain.ErrFeedNotFound
+	}
+
+	integration, creds, err := uc.connection(ctx, integrationID)
+	if err != nil {
+		return nil, err
+	}
+	if !ownedBy(integration, businessID) {
+		return nil, domain.ErrIntegrationNotOwned
+	}
+	return uc.client.FeedStatus(ctx, creds, feedID)
+}
+
+// pollFeed consulta FeedStatus hasta que el feed termina o se agotan los
+// intentos, y emite integration.feed.completed / integration.feed.failed.
+func (uc *falabellaUseCase) pollFeed(ctx context.Context, integration *domain.Integration, creds domain.Credentials, feedID string) {
+	for attempt := 1; attempt <= uc.feedPollAttempts; attempt++ {
+		time.Sleep(uc.feedPollInterval)
+
+		status, err := uc.client.FeedStatus(ctx, creds, feedID)
+		if err != nil {
+			uc.logger.Warn(ctx).Err(err).
+				Str("feed_id", feedID).
+				Int("attempt", attempt).
+				Msg("Error consultando estado del feed de Falabella")
+			continue
+		}
+		if status.Done() {
+			uc.emitFeedResult(ctx, integration, status)
+			return
+		}
+	}
+
+	uc.logger.Warn(ctx).
+		Str("feed_id", feedID).
+		Uint("integration_id", integration.ID).
+		Msg("Feed de Falabella sin terminar tras los reintentos")
+	uc.emitEvent(ctx, integration, "integration.feed.failed", map[string]interface{}{
+		"feed_id": feedID,
+		"error":   "feed still processing",
+	})
+}
+
+func (uc *falabellaUseCase) emitFeedResult(ctx context.Context, integration *domain.Integration, status *domain.FeedStatus) {
+	data := map[string]interface{}{
+		"feed_id":           status.FeedID,
+		"action":            status.Action,
+		"status":            status.Status,
+		"total_records":     status.TotalRecords,
+		"processed_records": status.ProcessedRecords,
+		"failed_records":    status.FailedRecords,
+	}
+
+	if status.Status == domain.FeedStatusCanceled || status.FailedRecords > 0 {
+		errs := make([]map[string]interface{}, 0, len(status.Errors))
+		for _, e := range status.Errors {
+			errs = append(errs, map[string]interface{}{
+				"code":    e.Code,
+				"message": e.Message,
+				"sku":     e.SellerSKU,
+			})
+		}
+		data["errors"] = errs
+
+		uc.logger.Warn(ctx).
+			Str("feed_id", status.FeedID).
+			Str("status", status.Status).
+			Int("failed_records", status.FailedRecords).
+			Msg("Feed de Falabella con errores")
+		uc.emitEvent(ctx, integration, "integration.feed.failed", data)
+		return
+	}
+
+	uc.logger.Info(ctx).
+		Str("feed_id", status.FeedID).
+		Int("processed_records", status.ProcessedRecords).
+		Msg("Feed de Falabella procesado")
+	uc.emitEvent(ctx, integration, "integration.feed.completed", data)
+}
@@ -0,0 +1,306 @@
+package mapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/canonical"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+)
+
+// MapFalabellaOrderToProbability convierte una orden de Seller Center (con sus
+// ítems ya cargados) al DTO canónico de Probability.
+func MapFalabellaOrderToProbability(order *domain.FalabellaOrder, rawJSON []byte) *canonical.ProbabilityOrderDTO {
+	now := time.Now()
+
+	var subtotal, tax, discount, shippingCost, paid float64
+	currency := ""
+	for _, item := range order.Items {
+		subtotal += item.ItemPrice
+		tax += item.TaxAmount
+		discount += item.VoucherAmount
+		shippingCost += item.ShippingAmount
+		paid += item.PaidPrice
+		if currency == "" {
+			currency = item.Currency
+		}
+	}
+	total := order.Price
+	if total <= 0 {
+		total = paid + shippingCost
+	}
+
+	status := orderStatus(order)
+	cancelled := status == "cancelled"
+
+	shipping := order.AddressShipping
+	if strings.TrimSpace(shipping.Address1) == "" && strings.TrimSpace(shipping.City) == "" {
+		shipping = order.AddressBilling
+	}
+
+	customerName := strings.TrimSpace(order.CustomerFirstName + " " + order.CustomerLastName)
+	if customerName == "" {
+		customerName = strings.TrimSpace(shipping.FirstName + " " + shipping.LastName)
+	}
+	customerEmail := order.AddressBilling.Email
+	if customerEmail == "" {
+		customerEmail = shipping.Email
+	}
+	customerPhone := shipping.Phone
+	if customerPhone == "" {
+		customerPhone = order.AddressBilling.Phone
+	}
+
+	var notes *string
+	if order.Remarks != "" {
+		notes = &order.Remarks
+	}
+
+	var coupon *string
+	if order.VoucherCode != "" {
+		coupon = &order.VoucherCode
+	}
+
+	dto := &canonical.ProbabilityOrderDTO{
+		IntegrationType: "falabella",
+		Platform:        "falabella",
+		ExternalID:      fmt.Sprintf("%d", order.OrderID),
+		OrderNumber:     order.OrderNumber,
+		Subtotal:        subtotal,
+		Tax:             tax,
+		Discount:        discount,
+		ShippingCost:    shippingCost,
+		FreeShipping:    shippingCost <= 0,
+		TotalAmount:     total,
+		Currency:        currency,
+		CustomerName:    customerName,
+		CustomerEmail:   customerEmail,
+		CustomerPhone:   customerPhone,
+		CustomerDNI:     order.NationalID,
+		Status:          status,
+		OriginalStatus:  strings.Join(order.Statuses, ","),
+		Notes:           notes,
+		Coupon:          coupon,
+		OccurredAt:      order.CreatedAt,
+		ImportedAt:      now,
+	}
+
+	dto.OrderItems = mapItems(order.Items, currency)
+
+	// Addresses
+	dto.Addresses = []canonical.ProbabilityAddressDTO{
+		mapAddress("billing", order.AddressBilling),
+		mapAddress("shipping", shipping),
+	}
+
+	// Payment: el marketplace cobra al comprador; la orden llega pagada
+	if order.PaymentMethod != "" {
+		paymentStatus := "completed"
+		paidAt := &order.CreatedAt
+		if cancelled {
+			paymentStatus = "cancelled"
+			paidAt = nil
+		}
+		gateway := order.PaymentMethod
+		dto.Payments = append(dto.Payments, canonical.ProbabilityPaymentDTO{
+			PaymentMethodID: paymentMethodMarketplace,
+			Amount:          total,
+			Currency:        currency,
+			Status:          paymentStatus,
+			PaidAt:          paidAt,
+			Gateway:         &gateway,
+		})
+	}
+
+	// Shipment: la transportadora la asigna Falabella por ítem
+	if provider := shipmentProvider(order.Items); provider != "" {
+		carrier := provider
+		shCost := shippingCost
+		shipment := canonical.ProbabilityShipmentDTO{
+			Carrier:      &carrier,
+			Status:       mapShipmentStatus(status),
+			ShippingCost: &shCost,
+		}
+		if tracking := trackingCode(order.Items); tracking != "" {
+			shipment.TrackingNumber = &tracking
+		}
+		dto.Shipments = append(dto.Shipments, shipment)
+
+		details := map[string]interface{}{
+			"shipping_lines": []map[string]interface{}{{
+				"title":  provider,
+				"price":  fmt.Sprintf("%.2f", shippingCost),
+				"source": "falabella",
+				"code":   "",
+			}},
+		}
+		if sd, err := json.Marshal(details); err == nil {
+			dto.ShippingDetails = sd
+		}
+	}
+
+	// Channel metadata with raw data
+	if rawJSON != nil {
+		dto.ChannelMetadata = &canonical.ProbabilityChannelMetadataDTO{
+			ChannelSource: "falabella",
+			RawData:       rawJSON,
+			Version:       "V1",
+			ReceivedAt:    now,
+			IsLatest:      true,
+			SyncStatus:    "synced",
+		}
+	}
+
+	dto.Invoiceable = strings.EqualFold(currency, "COP") && !cancelled
+
+	return dto
+}
+
+// mapItems agrupa por SKU: Seller Center crea un ítem por unidad vendida.
+func mapItems(items []domain.FalabellaOrderItem, currency string) []canonical.ProbabilityOrderItemDTO {
+	out := make([]canonical.ProbabilityOrderItemDTO, 0, len(items))
+	index := make(map[string]int, len(items))
+	for _, item := range items {
+		if isCancelled(item.Status) {
+			continue
+		}
+		key := item.SKU
+		if key == "" {
+			key = item.ShopSKU
+		}
+
+		if i, ok := index[key]; ok {
+			out[i].Quantity++
+			out[i].TotalPrice += item.PaidPrice
+			out[i].Discount += item.VoucherAmount
+			out[i].Tax += item.TaxAmount
+			continue
+		}
+
+		productID := key
+		dto := canonical.ProbabilityOrderItemDTO{
+			ProductID:    &productID,
+			ProductSKU:   key,
+			ProductName:  item.Name,
+			ProductTitle: item.Name,
+			Quantity:     1,
+			UnitPrice:    item.ItemPrice,
+			TotalPrice:   item.PaidPrice,
+			Currency:     currency,
+			Discount:     item.VoucherAmount,
+			Tax:          item.TaxAmount,
+		}
+		if item.ShopSKU != "" {
+			shopSKU := item.ShopSKU
+			dto.VariantID = &shopSKU
+		}
+		index[key] = len(out)
+		out = append(out, dto)
+	}
+	return out
+}
+
+func mapAddress(kind string, a domain.FalabellaAddress) canonical.ProbabilityAddressDTO {
+	city := a.City
+	if city == "" {
+		city = a.Ward
+	}
+	return canonical.ProbabilityAddressDTO{
+		Type:       kind,
+		FirstName:  a.FirstName,
+		LastName:   a.LastName,
+		Phone:      a.Phone,
+		Street:     a.Address1,
+		Street2:    a.Address2,
+		City:       city,
+		State:      a.Region,
+		Country:    a.Country,
+		PostalCode: a.PostCode,
+	}
+}
+
+// orderStatus toma el estado más relevante de la orden. Una orden con ítems en
+// varios estados lista todos en Statuses; los ítems cancelados no definen el
+// estado mientras quede alguno activo.
+func orderStatus(order *domain.FalabellaOrder) string {
+	statuses := order.Statuses
+	if len(statuses) == 0 {
+		for _, item := range order.Items {
+			statuses = append(statuses, item.Status)
+		}
+	}
+	for _, s := range statuses {
+		if !isCancelled(s) {
+			return MapFalabellaStatus(s)
+		}
+	}
+	if len(statuses) == 0 {
+		return "pending"
+	}
+	return "cancelled"
+}
+
+func isCancelled(status string) bool {
+	return strings.EqualFold(status, "canceled")
+}
+
+func shipmentProvider(items []domain.FalabellaOrderItem) string {
+	for _, item := range items {
+		if item.ShipmentProvider != "" {
+			return item.ShipmentProvider
+		}
+	}
+	return ""
+}
+
+func trackingCode(items []domain.FalabellaOrderItem) string {
+	for _, item := range items {
+		if item.TrackingCode != "" {
+			return item.TrackingCode
+		}
+	}
+	return ""
+}
+
+// paymentMethodMarketplace: id de "Tarjeta de Crédito" en el catalogo seed
+// payment_methods; Falabella liquida al vendedor, no hay recaudo en destino.
+const paymentMethodMarketplace uint = 1
+
+// MapFalabellaStatus mapea el estado de ítem de Seller Center al estado canónico de Probability.
+func MapFalabellaStatus(status string) string {
+	s := strings.ToLower(status)
+	switch {
+	case s == "pending":
+		return "pending"
+	case s == "ready_to_ship":
+		return "processing"
+	case s == "shipped":
+		return "shipped"
+	case s == "delivered":
+		return "completed"
+	case s == "canceled":
+		return "cancelled"
+	case s == "failed":
+		return "failed"
+	case s == "returned" || strings.HasPrefix(s, "return_"):
+		return "refunded"
+	default:
+		return "pending"
+	}
+}
+
+// mapShipmentStatus mapea el estado canónico de la orden a un estado de envío.
+func mapShipmentStatus(status string) string {
+	switch status {
+	case "shipped":
+		return "in_transit"
+	case "completed":
+		return "delivered"
+	case "cancelled", "refunded":
+		return "cancelled"
+	default:
+		return "pending"
+	}
+}
@@ -0,0 +1,100 @@
+package mapper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+)
+
+func sampleOrder() *domain.FalabellaOrder {
+	return &domain.FalabellaOrder{
+		OrderID:           9001,
+		OrderNumber:       "3050001",
+		CustomerFirstName: "Ana",
+		CustomerLastName:  "Ruiz",
+		NationalID:        "1020304050",
+		PaymentMethod:     "CreditCard",
+		Price:             210000,
+		CreatedAt:         time.Date(2026, 5, 2, 10, 0, 0, 0, time.UTC),
+		AddressShipping: domain.FalabellaAddress{
+			FirstName: "Ana", LastName: "Ruiz", Phone: "3001234567",
+			Address1: "Cra 7 # 10-20", City: "Bogota", Region: "Cundinamarca", Country: "Colombia",
+		},
+		AddressBilling: domain.FalabellaAddress{Email: "ana@test.com"},
+		Statuses:       []string{"pending"},
+		Items: []domain.FalabellaOrderItem{
+			{OrderItemID: 1, SKU: "SKU-1", ShopSKU: "FAL-1", Name: "Camisa", ItemPrice: 100000, PaidPrice: 95000, VoucherAmount: 5000, Currency: "COP", ShippingAmount: 5000, Status: "pending", ShipmentProvider: "Blue Express"},
+			{OrderItemID: 2, SKU: "SKU-1", ShopSKU: "FAL-1", Name: "Camisa", ItemPrice: 100000, PaidPrice: 95000, VoucherAmount: 5000, Currency: "COP", ShippingAmount: 5000, Status: "pending", ShipmentProvider: "Blue Express"},
+			{OrderItemID: 3, SKU: "SKU-2", Name: "Gorra", ItemPrice: 20000, PaidPrice: 20000, Currency: "COP", Status: "canceled"},
+		},
+	}
+}
+
+func TestMapFalabellaOrder_GroupsUnitsBySKU(t *testing.T) {
+	dto := MapFalabellaOrderToProbability(sampleOrder(), []byte(`{}`))
+
+	if dto.ExternalID != "9001" || dto.OrderNumber != "3050001" {
+		t.Errorf("identificadores inesperados: %s / %s", dto.ExternalID, dto.OrderNumber)
+	}
+	if len(dto.OrderItems) != 1 {
+		t.Fatalf("esperaba 1 item agrupado (el cancelado se omite), recibí %d", len(dto.OrderItems))
+	}
+	item := dto.OrderItems[0]
+	if item.Quantity != 2 || item.TotalPrice != 190000 || item.Discount != 10000 {
+		t.Errorf("item agrupado inesperado: qty=%d total=%.0f desc=%.0f", item.Quantity, item.TotalPrice, item.Discount)
+	}
+	if item.ProductID == nil || *item.ProductID != "SKU-1" {
+		t.Errorf("el ProductID debe ser el SellerSku")
+	}
+	if dto.ShippingCost != 10000 || dto.TotalAmount != 210000 || dto.Currency != "COP" {
+		t.Errorf("totales inesperados: envio=%.0f total=%.0f moneda=%s", dto.ShippingCost, dto.TotalAmount, dto.Currency)
+	}
+	if dto.Status != "pending" || !dto.Invoiceable {
+		t.Errorf("estado/facturable inesperados: %s %v", dto.Status, dto.Invoiceable)
+	}
+	if len(dto.Shipments) != 1 || *dto.Shipments[0].Carrier != "Blue Express" {
+		t.Errorf("esperaba envío con la transportadora de Falabella")
+	}
+	if dto.CustomerEmail != "ana@test.com" || dto.CustomerDNI != "1020304050" {
+		t.Errorf("datos de cliente inesperados: %s %s", dto.CustomerEmail, dto.CustomerDNI)
+	}
+	if dto.ChannelMetadata == nil || dto.ChannelMetadata.ChannelSource != "falabella" {
+		t.Errorf("esperaba channel metadata de falabella")
+	}
+}
+
+func TestMapFalabellaOrder_AllCanceled(t *testing.T) {
+	order := sampleOrder()
+	order.Statuses = []string{"canceled"}
+
+	dto := MapFalabellaOrderToProbability(order, nil)
+	if dto.Status != "cancelled" {
+		t.Errorf("esperaba cancelled, recibí %s", dto.Status)
+	}
+	if dto.Invoiceable {
+		t.Errorf("una orden cancelada no es facturable")
+	}
+	if dto.Payments[0].Status != "cancelled" || dto.Payments[0].PaidAt != nil {
+		t.Errorf("el pago de una orden cancelada no debe quedar completado")
+	}
+}
+
+func TestMapFalabellaStatus(t *testing.T) {
+	cases := map[string]string{
+		"pending":                    "pending",
+		"ready_to_ship":              "processing",
+		"shipped":                    "shipped",
+		"delivered":                  "completed",
+		"canceled":                   "cancelled",
+		"failed":                     "failed",
+		"returned":                   "refunded",
+		"return_shipped_by_customer": "refunded",
+		"desconocido":                "pending",
+	}
+	for in, want := range cases {
+		if got := MapFalabellaStatus(in); got != want {
+			t.Errorf("MapFalabellaStatus(%q) = %q, esperaba %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+)
+
+// ProcessWebhook procesa una notificación de Seller Center. Los eventos de
+// orden releen la orden y sus ítems por API y la publican a la cola canónica;
+// los de feed consultan FeedStatus y emiten el resultado. Seller Center no
+// firma los webhooks, por eso nunca se confía en el contenido del payload.
+func (uc *falabellaUseCase) ProcessWebhook(ctx context.Context, event *domain.WebhookEvent) error {
+	if isFeedEvent(event.Event) {
+		return uc.processFeedWebhook(ctx, event)
+	}
+	if event.OrderID == 0 {
+		return domain.ErrWebhookMissingOrder
+	}
+
+	integration, creds, err := uc.connection(ctx, event.IntegrationID)
+	if err != nil {
+		return err
+	}
+
+	order, rawJSON, err := uc.client.GetOrder(ctx, creds, event.OrderID)
+	if err != nil {
+		uc.logger.Error(ctx).Err(err).
+			Int64("order_id", event.OrderID).
+			Str("event", event.Event).
+			Msg("Failed to fetch Falabella order detail")
+		return fmt.Errorf("fetching order: %w", err)
+	}
+
+	if err := uc.publishOrder(ctx, integration, creds, order, rawJSON); err != nil {
+		uc.logger.Error(ctx).Err(err).
+			Str("order_number", order.OrderNumber).
+			Str("event", event.Event).
+			Msg("Failed to publish Falabella webhook order")
+		return fmt.Errorf("publishing webhook order: %w", err)
+	}
+
+	uc.logger.Info(ctx).
+		Str("order_number", order.OrderNumber).
+		Str("event", event.Event).
+		Uint("integration_id", integration.ID).
+		Msg("Falabella order published successfully via webhook")
+
+	return nil
+}
+
+func (uc *falabellaUseCase) processFeedWebhook(ctx context.Context, event *domain.WebhookEvent) error {
+	if event.FeedID == "" {
+		return domain.ErrFeedNotFound
+	}
+
+	integration, creds, err := uc.connection(ctx, event.IntegrationID)
+	if err != nil {
+		return err
+	}
+
+	status, err := uc.client.FeedStatus(ctx, creds, event.FeedID)
+	if err != nil {
+		return fmt.Errorf("fetching feed status: %w", err)
+	}
+	if status.Done() {
+		uc.emitFeedResult(ctx, integration, status)
+	}
+	return nil
+}
+
+func isFeedEvent(event string) bool {
+	return strings.HasPrefix(strings.ToLower(event), "onfeed")
+}
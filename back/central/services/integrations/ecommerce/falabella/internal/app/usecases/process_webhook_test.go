@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/mocks"
+)
+
+func TestProcessWebhook_RefetchesAndPublishesOrder(t *testing.T) {
+	publisher := &mocks.OrderPublisherMock{}
+	client := &mocks.FalabellaClientMock{
+		GetOrderFn: func(ctx context.Context, creds domain.Credentials, orderID int64) (*domain.FalabellaOrder, []byte, error) {
+			return &domain.FalabellaOrder{OrderID: orderID, OrderNumber: "3050001", Statuses: []string{"ready_to_ship"}}, []byte(`{}`), nil
+		},
+		GetOrderItemsFn: func(ctx context.Context, creds domain.Credentials, orderID int64) ([]domain.FalabellaOrderItem, error) {
+			return []domain.FalabellaOrderItem{{OrderItemID: 1, SKU: "SKU-1", Currency: "COP", Status: "ready_to_ship"}}, nil
+		},
+	}
+	uc := newTestUseCase(client, publisher, nil, nil)
+
+	err := uc.ProcessWebhook(context.Background(), &domain.WebhookEvent{IntegrationID: "1", Event: "onOrderItemsStatusChanged", OrderID: 9001})
+	if err != nil {
+		t.Fatalf("esperaba sin error, recibí: %v", err)
+	}
+	if len(publisher.Published) != 1 {
+		t.Fatalf("esperaba 1 orden publicada, recibí %d", len(publisher.Published))
+	}
+	dto := publisher.Published[0]
+	if dto.ExternalID != "9001" || dto.Status != "processing" || dto.IntegrationID != 1 {
+		t.Errorf("orden publicada inesperada: %s %s %d", dto.ExternalID, dto.Status, dto.IntegrationID)
+	}
+}
+
+func TestProcessWebhook_MissingOrder(t *testing.T) {
+	uc := newTestUseCase(&mocks.FalabellaClientMock{}, &mocks.OrderPublisherMock{}, nil, nil)
+
+	err := uc.ProcessWebhook(context.Background(), &domain.WebhookEvent{IntegrationID: "1", Event: "onOrderCreated"})
+	if !errors.Is(err, domain.ErrWebhookMissingOrder) {
+		t.Fatalf("esperaba ErrWebhookMissingOrder, recibí: %v", err)
+	}
+}
+
+func TestProcessWebhook_FeedEventQueriesStatus(t *testing.T) {
+	queried := ""
+	client := &mocks.FalabellaClientMock{
+		FeedStatusFn: func(ctx context.Context, creds domain.Credentials, feedID string) (*domain.FeedStatus, error) {
+			queried = feedID
+			return &domain.FeedStatus{FeedID: feedID, Status: domain.FeedStatusFinished}, nil
+		},
+	}
+	publisher := &mocks.OrderPublisherMock{}
+	uc := newTestUseCase(client, publisher, nil, nil)
+
+	err := uc.ProcessWebhook(context.Background(), &domain.WebhookEvent{IntegrationID: "1", Event: "onFeedCompleted", FeedID: "feed-1"})
+	if err != nil {
+		t.Fatalf("esperaba sin error, recibí: %v", err)
+	}
+	if queried != "feed-1" || len(publisher.Published) != 0 {
+		t.Errorf("un evento de feed no debe publicar órdenes")
+	}
+}
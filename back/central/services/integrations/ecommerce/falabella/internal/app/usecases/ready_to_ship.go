@@ -0,0 +1,182 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+)
+
+const (
+	defaultDeliveryType = "dropship"
+	documentShipping    = "shippingParcel"
+)
+
+// ReadyToShip marca como listos para despacho los ítems pendientes de la
+// orden, descarga la etiqueta (shippingParcel) y la guarda como guía del envío
+// igual que las guías de transportadoras: URL en S3 + tracking en envío y orden.
+func (uc *falabellaUseCase) ReadyToShip(ctx context.Context, orderID string, businessID uint) (*domain.ReadyToShipResult, error) {
+	if uc.orderRepo == nil {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	ref, err := uc.orderRepo.GetOrderRef(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("looking up order %s: %w", orderID, err)
+	}
+	if ref == nil {
+		return nil, domain.ErrOrderNotFound
+	}
+	if businessID != 0 && ref.BusinessID != businessID {
+		return nil, domain.ErrOrderNotOwned
+	}
+
+	externalID, err := strconv.ParseInt(ref.ExternalID, 10, 64)
+	if err != nil || externalID <= 0 {
+		return nil, domain.ErrOrderNotFound
+	}
+
+	integrationID := strconv.FormatUint(uint64(ref.IntegrationID), 10)
+	integration, creds, err := uc.connection(ctx, integrationID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := uc.client.GetOrderItems(ctx, creds, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching order items: %w", err)
+	}
+
+	var itemIDs []int64
+	provider, _ := extractString(integration.Config, "shipping_provider")
+	for _, item := range items {
+		if !strings.EqualFold(item.Status, "pending") {
+			continue
+		}
+		itemIDs = append(itemIDs, item.OrderItemID)
+		if provider == "" {
+			provider = item.ShipmentProvider
+		}
+	}
+	if len(itemIDs) == 0 {
+		return nil, domain.ErrNoItemsToShip
+	}
+
+	deliveryType, err := extractString(integration.Config, "delivery_type")
+	if err != nil {
+		deliveryType = defaultDeliveryType
+	}
+
+	if err := uc.client.SetStatusToReadyToShip(ctx, creds, domain.ReadyToShipInput{
+		OrderItemIDs:     itemIDs,
+		DeliveryType:     deliveryType,
+		ShippingProvider: provider,
+	}); err != nil {
+		uc.logger.Error(ctx).Err(err).
+			Str("order_id", orderID).
+			Int64("falabella_order_id", externalID).
+			Msg("Error marcando orden de Falabella lista para despacho")
+		return nil, err
+	}
+
+	result := &domain.ReadyToShipResult{
+		OrderID:      ref.OrderID,
+		ExternalID:   ref.ExternalID,
+		OrderItemIDs: itemIDs,
+		Carrier:      provider,
+	}
+
+	// El tracking y el paquete los asigna Falabella al pasar a ready_to_ship
+	if shipped, err := uc.client.GetOrderItems(ctx, creds, externalID); err == nil {
+		for _, item := range shipped {
+			if !containsID(itemIDs, item.OrderItemID) {
+				continue
+			}
+			if result.TrackingNumber == "" {
+				result.TrackingNumber = item.TrackingCode
+			}
+			if result.PackageID == "" {
+				result.PackageID = item.PackageID
+			}
+			if item.ShipmentProvider != "" {
+				result.Carrier = item.ShipmentProvider
+			}
+		}
+	}
+
+	result.GuideURL = uc.storeLabel(ctx, creds, ref, itemIDs)
+
+	if err := uc.orderRepo.SaveGuide(ctx, ref, domain.GuideData{
+		GuideURL:       result.GuideURL,
+		TrackingNumber: result.TrackingNumber,
+		Carrier:        result.Carrier,
+		PackageID:      result.PackageID,
+	}); err != nil {
+		return nil, fmt.Errorf("saving guide: %w", err)
+	}
+
+	uc.logger.Info(ctx).
+		Str("order_id", orderID).
+		Str("tracking_number", result.TrackingNumber).
+		Str("carrier", result.Carrier).
+		Int("items", len(itemIDs)).
+		Msg("Orden de Falabella lista para despacho")
+
+	return result, nil
+}
+
+// storeLabel descarga la etiqueta y la sube a S3. Si el documento aún no está
+// disponible se continúa sin guía: el tracking ya quedó asignado.
+func (uc *falabellaUseCase) storeLabel(ctx context.Context, creds domain.Credentials, ref *domain.OrderRef, itemIDs []int64) string {
+	doc, err := uc.client.GetDocument(ctx, creds, itemIDs, documentShipping)
+	if err != nil {
+		uc.logger.Warn(ctx).Err(err).
+			Str("order_id", ref.OrderID).
+			Msg("Etiqueta de Falabella no disponible")
+		return ""
+	}
+	if uc.labels == nil {
+		uc.logger.Warn(ctx).
+			Str("order_id", ref.OrderID).
+			Msg("Storage no disponible, etiqueta de Falabella no guardada")
+		return ""
+	}
+
+	name := ref.OrderNumber
+	if name == "" {
+		name = ref.ExternalID
+	}
+	key := fmt.Sprintf("guides/falabella/%d/%s.%s", ref.BusinessID, name, documentExtension(doc.MimeType))
+
+	url, err := uc.labels.UploadLabel(ctx, key, doc.Content)
+	if err != nil {
+		uc.logger.Error(ctx).Err(err).
+			Str("order_id", ref.OrderID).
+			Str("key", key).
+			Msg("Error subiendo etiqueta de Falabella")
+		return ""
+	}
+	return url
+}
+
+func documentExtension(mimeType string) string {
+	switch {
+	case strings.Contains(mimeType, "html"):
+		return "html"
+	case strings.Contains(mimeType, "zpl"), strings.Contains(mimeType, "plain"):
+		return "txt"
+	default:
+		return "pdf"
+	}
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,110 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/mocks"
+)
+
+func orderRepoWithRef() *mocks.OrderRepositoryMock {
+	shipmentID := uint(55)
+	return &mocks.OrderRepositoryMock{
+		GetOrderRefFn: func(ctx context.Context, orderID string) (*domain.OrderRef, error) {
+			return &domain.OrderRef{
+				OrderID: orderID, BusinessID: 7, IntegrationID: 1,
+				ExternalID: "9001", OrderNumber: "3050001", ShipmentID: &shipmentID,
+			}, nil
+		},
+	}
+}
+
+func TestReadyToShip_MarksPendingItemsAndStoresLabel(t *testing.T) {
+	shipped := false
+	var input domain.ReadyToShipInput
+	client := &mocks.FalabellaClientMock{
+		GetOrderItemsFn: func(ctx context.Context, creds domain.Credentials, orderID int64) ([]domain.FalabellaOrderItem, error) {
+			if shipped {
+				return []domain.FalabellaOrderItem{
+					{OrderItemID: 1, Status: "ready_to_ship", ShipmentProvider: "Blue Express", TrackingCode: "TRK-1", PackageID: "PKG-1"},
+					{OrderItemID: 2, Status: "canceled"},
+				}, nil
+			}
+			return []domain.FalabellaOrderItem{
+				{OrderItemID: 1, Status: "pending", ShipmentProvider: "Blue Express"},
+				{OrderItemID: 2, Status: "canceled"},
+			}, nil
+		},
+		SetStatusToReadyToShipFn: func(ctx context.Context, creds domain.Credentials, in domain.ReadyToShipInput) error {
+			input = in
+			shipped = true
+			return nil
+		},
+		GetDocumentFn: func(ctx context.Context, creds domain.Credentials, ids []int64, documentType string) (*domain.Document, error) {
+			return &domain.Document{DocumentType: documentType, MimeType: "application/pdf", Content: []byte("%PDF")}, nil
+		},
+	}
+	repo := orderRepoWithRef()
+	labels := &mocks.LabelStorageMock{}
+	uc := newTestUseCase(client, &mocks.OrderPublisherMock{}, repo, labels)
+
+	result, err := uc.ReadyToShip(context.Background(), "order-uuid", 7)
+	if err != nil {
+		t.Fatalf("esperaba sin error, recibí: %v", err)
+	}
+	if len(input.OrderItemIDs) != 1 || input.OrderItemIDs[0] != 1 || input.DeliveryType != "dropship" {
+		t.Errorf("input inesperado: %+v", input)
+	}
+	if result.TrackingNumber != "TRK-1" || result.PackageID != "PKG-1" || result.Carrier != "Blue Express" {
+		t.Errorf("resultado inesperado: %+v", result)
+	}
+	if len(labels.Keys) != 1 || labels.Keys[0] != "guides/falabella/7/3050001.pdf" {
+		t.Errorf("llave de etiqueta inesperada: %v", labels.Keys)
+	}
+	if len(repo.Saved) != 1 || repo.Saved[0].GuideURL != "https://cdn.test/guides/falabella/7/3050001.pdf" {
+		t.Errorf("guía guardada inesperada: %+v", repo.Saved)
+	}
+}
+
+func TestReadyToShip_OtherBusiness(t *testing.T) {
+	uc := newTestUseCase(&mocks.FalabellaClientMock{}, &mocks.OrderPublisherMock{}, orderRepoWithRef(), nil)
+
+	_, err := uc.ReadyToShip(context.Background(), "order-uuid", 8)
+	if !errors.Is(err, domain.ErrOrderNotOwned) {
+		t.Fatalf("esperaba ErrOrderNotOwned, recibí: %v", err)
+	}
+}
+
+func TestReadyToShip_NoPendingItems(t *testing.T) {
+	client := &mocks.FalabellaClientMock{
+		GetOrderItemsFn: func(ctx context.Context, creds domain.Credentials, orderID int64) ([]domain.FalabellaOrderItem, error) {
+			return []domain.FalabellaOrderItem{{OrderItemID: 1, Status: "shipped"}}, nil
+		},
+	}
+	uc := newTestUseCase(client, &mocks.OrderPublisherMock{}, orderRepoWithRef(), nil)
+
+	_, err := uc.ReadyToShip(context.Background(), "order-uuid", 7)
+	if !errors.Is(err, domain.ErrNoItemsToShip) {
+		t.Fatalf("esperaba ErrNoItemsToShip, recibí: %v", err)
+	}
+}
+
+func TestReadyToShip_LabelNotReadyStillSavesTracking(t *testing.T) {
+	client := &mocks.FalabellaClientMock{
+		GetOrderItemsFn: func(ctx context.Context, creds domain.Credentials, orderID int64) ([]domain.FalabellaOrderItem, error) {
+			return []domain.FalabellaOrderItem{{OrderItemID: 1, Status: "pending", TrackingCode: "TRK-9"}}, nil
+		},
+	}
+	repo := orderRepoWithRef()
+	uc := newTestUseCase(client, &mocks.OrderPublisherMock{}, repo, &mocks.LabelStorageMock{})
+
+	result, err := uc.ReadyToShip(context.Background(), "order-uuid", 7)
+	if err != nil {
+		t.Fatalf("esperaba sin error, recibí: %v", err)
+	}
+	if result.GuideURL != "" || len(repo.Saved) != 1 || repo.Saved[0].TrackingNumber != "TRK-9" {
+		t.Errorf("esperaba tracking guardado sin etiqueta: %+v", repo.Saved)
+	}
+}
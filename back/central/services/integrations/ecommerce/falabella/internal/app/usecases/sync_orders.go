@@ -0,0 +1,182 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/app/usecases/mapper"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/rabbitmq"
+)
+
+// ordersPageSize es el máximo de Limit que acepta GetOrders.
+const ordersPageSize = 100
+
+func (uc *falabellaUseCase) emitEvent(ctx context.Context, integration *domain.Integration, eventType string, data map[string]interface{}) {
+	if uc.rabbit == nil {
+		return
+	}
+	var bID uint
+	if integration.BusinessID != nil {
+		bID = *integration.BusinessID
+	}
+	_ = rabbitmq.PublishEvent(ctx, uc.rabbit, rabbitmq.EventEnvelope{
+		Type:          eventType,
+		Category:      "integration",
+		BusinessID:    bID,
+		IntegrationID: integration.ID,
+		Data:          data,
+	})
+}
+
+// SyncOrders sincroniza órdenes de Falabella de los últimos 30 días.
+func (uc *falabellaUseCase) SyncOrders(ctx context.Context, integrationID string) error {
+	after := time.Now().AddDate(0, 0, -30)
+	params := map[string]interface{}{
+		"created_at_min": after.Format(time.RFC3339),
+	}
+	return uc.SyncOrdersWithParams(ctx, integrationID, params)
+}
+
+// SyncOrdersWithParams sincroniza órdenes con parámetros personalizados.
+// Soporta: created_at_min, created_at_max, updated_at_min (RFC3339) y status.
+func (uc *falabellaUseCase) SyncOrdersWithParams(ctx context.Context, integrationID string, params interface{}) error {
+	integration, creds, err := uc.connection(ctx, integrationID)
+	if err != nil {
+		return err
+	}
+
+	queryParams := buildQueryParams(params)
+
+	uc.logger.Info(ctx).
+		Str("integration_id", integrationID).
+		Str("user_id", creds.UserID).
+		Msg("Starting Falabella order sync")
+
+	go uc.syncOrdersAsync(context.Background(), integration, creds, queryParams)
+
+	return nil
+}
+
+func (uc *falabellaUseCase) syncOrdersAsync(ctx context.Context, integration *domain.Integration, creds domain.Credentials, params *domain.GetOrdersParams) {
+	start := time.Now()
+	uc.emitEvent(ctx, integration, "integration.sync.started", map[string]interface{}{})
+
+	totalSynced, err := uc.importOrders(ctx, integration, creds, params)
+	if err != nil {
+		uc.emitEvent(ctx, integration, "integration.sync.failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	uc.logger.Info(ctx).
+		Int("total_synced", totalSynced).
+		Uint("integration_id", integration.ID).
+		Msg("Falabella order sync completed")
+
+	uc.emitEvent(ctx, integration, "integration.sync.completed", map[string]interface{}{
+		"total_fetched": totalSynced,
+		"duration":      time.Since(start).Round(time.Millisecond).String(),
+	})
+}
+
+// importOrders recorre GetOrders por Offset y publica cada orden con sus
+// ítems. GetOrders no trae ítems, se piden por orden con GetOrderItems.
+func (uc *falabellaUseCase) importOrders(ctx context.Context, integration *domain.Integration, creds domain.Credentials, params *domain.GetOrdersParams) (int, error) {
+	if params.Limit == 0 {
+		params.Limit = ordersPageSize
+	}
+
+	totalSynced := 0
+	for offset := 0; ; offset += params.Limit {
+		params.Offset = offset
+
+		result, rawOrders, err := uc.client.GetOrders(ctx, creds, params)
+		if err != nil {
+			uc.logger.Error(ctx).Err(err).
+				Int("offset", offset).
+				Msg("Error fetching Falabella orders page")
+			return totalSynced, err
+		}
+
+		if len(result.Orders) == 0 {
+			break
+		}
+
+		for i := range result.Orders {
+			order := result.Orders[i]
+			var rawJSON []byte
+			if i < len(rawOrders) {
+				rawJSON = rawOrders[i]
+			}
+
+			if err := uc.publishOrder(ctx, integration, creds, &order, rawJSON); err != nil {
+				uc.logger.Error(ctx).Err(err).
+					Str("order_number", order.OrderNumber).
+					Msg("Error publishing Falabella order")
+				continue
+			}
+			totalSynced++
+		}
+
+		uc.logger.Info(ctx).
+			Int("offset", offset).
+			Int("orders_in_page", len(result.Orders)).
+			Int("total_count", result.TotalCount).
+			Msg("Falabella orders page synced")
+
+		if offset+len(result.Orders) >= result.TotalCount {
+			break
+		}
+	}
+
+	return totalSynced, nil
+}
+
+// publishOrder carga los ítems de la orden, la mapea y la publica a la cola canónica.
+func (uc *falabellaUseCase) publishOrder(ctx context.Context, integration *domain.Integration, creds domain.Credentials, order *domain.FalabellaOrder, rawJSON []byte) error {
+	items, err := uc.client.GetOrderItems(ctx, creds, order.OrderID)
+	if err != nil {
+		return fmt.Errorf("fetching order items: %w", err)
+	}
+	order.Items = items
+
+	dto := mapper.MapFalabellaOrderToProbability(order, rawJSON)
+	dto.IntegrationID = integration.ID
+	dto.BusinessID = integration.BusinessID
+
+	return uc.publisher.Publish(ctx, dto)
+}
+
+// buildQueryParams construye los filtros de consulta a partir de un mapa genérico.
+func buildQueryParams(params interface{}) *domain.GetOrdersParams {
+	qp := &domain.GetOrdersParams{}
+
+	m, ok := params.(map[string]interface{})
+	if !ok {
+		return qp
+	}
+
+	parse := func(key string) *time.Time {
+		v, ok := m[key].(string)
+		if !ok || v == "" {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil
+		}
+		return &t
+	}
+
+	qp.CreatedAfter = parse("created_at_min")
+	qp.CreatedBefore = parse("created_at_max")
+	qp.UpdatedAfter = parse("updated_at_min")
+	if v, ok := m["status"].(string); ok && v != "" {
+		qp.Status = v
+	}
+
+	return qp
+}
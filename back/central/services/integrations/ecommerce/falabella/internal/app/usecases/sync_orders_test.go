@@ -0,0 +1,118 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/mocks"
+)
+
+func newTestUseCase(client domain.IFalabellaClient, publisher domain.OrderPublisher, repo domain.IOrderRepository, labels domain.ILabelStorage) *falabellaUseCase {
+	uc := New(client, &mocks.IntegrationServiceMock{}, publisher, repo, labels, nil, mocks.NewLoggerMock()).(*falabellaUseCase)
+	uc.feedPollInterval = time.Millisecond
+	uc.feedPollAttempts = 3
+	return uc
+}
+
+func TestImportOrders_PaginatesByOffset(t *testing.T) {
+	publisher := &mocks.OrderPublisherMock{}
+	var offsets []int
+	itemCalls := 0
+	client := &mocks.FalabellaClientMock{
+		GetOrdersFn: func(ctx context.Context, creds domain.Credentials, params *domain.GetOrdersParams) (*domain.GetOrdersResult, [][]byte, error) {
+			offsets = append(offsets, params.Offset)
+			n := 2
+			if params.Offset >= 2 {
+				n = 1
+			}
+			orders := make([]domain.FalabellaOrder, n)
+			for i := range orders {
+				orders[i] = domain.FalabellaOrder{OrderID: int64(params.Offset + i + 1), Statuses: []string{"pending"}}
+			}
+			return &domain.GetOrdersResult{Orders: orders, TotalCount: 3}, nil, nil
+		},
+		GetOrderItemsFn: func(ctx context.Context, creds domain.Credentials, orderID int64) ([]domain.FalabellaOrderItem, error) {
+			itemCalls++
+			return []domain.FalabellaOrderItem{{OrderItemID: orderID * 10, SKU: "SKU-1", Currency: "COP", Status: "pending"}}, nil
+		},
+	}
+	uc := newTestUseCase(client, publisher, nil, nil)
+
+	total, err := uc.importOrders(context.Background(), &domain.Integration{ID: 1}, domain.Credentials{}, &domain.GetOrdersParams{Limit: 2})
+	if err != nil {
+		t.Fatalf("esperaba sin error, recibí: %v", err)
+	}
+	if total != 3 || len(publisher.Published) != 3 {
+		t.Errorf("esperaba 3 órdenes publicadas, recibí %d", len(publisher.Published))
+	}
+	if len(offsets) != 2 || offsets[1] != 2 {
+		t.Errorf("esperaba offsets [0 2], recibí %v", offsets)
+	}
+	if itemCalls != 3 {
+		t.Errorf("esperaba GetOrderItems por orden, recibí %d llamadas", itemCalls)
+	}
+	if len(publisher.Published[0].OrderItems) != 1 {
+		t.Errorf("la orden publicada debe incluir sus ítems")
+	}
+}
+
+func TestImportOrders_ClientError(t *testing.T) {
+	client := &mocks.FalabellaClientMock{
+		GetOrdersFn: func(ctx context.Context, creds domain.Credentials, params *domain.GetOrdersParams) (*domain.GetOrdersResult, [][]byte, error) {
+			return nil, nil, domain.ErrInvalidCredentials
+		},
+	}
+	uc := newTestUseCase(client, &mocks.OrderPublisherMock{}, nil, nil)
+
+	_, err := uc.importOrders(context.Background(), &domain.Integration{ID: 1}, domain.Credentials{}, &domain.GetOrdersParams{})
+	if !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Fatalf("esperaba ErrInvalidCredentials, recibí: %v", err)
+	}
+}
+
+func TestConnection_UsesTestBaseURL(t *testing.T) {
+	service := &mocks.IntegrationServiceMock{
+		GetIntegrationByIDFn: func(ctx context.Context, integrationID string) (*domain.Integration, error) {
+			return &domain.Integration{
+				ID:          1,
+				IsTesting:   true,
+				BaseURLTest: "http://localhost:9104",
+				Config:      map[string]interface{}{"user_id": "seller@test.com", "api_url": "https://sellercenter-api.falabella.com"},
+			}, nil
+		},
+	}
+	uc := New(&mocks.FalabellaClientMock{}, service, &mocks.OrderPublisherMock{}, nil, nil, nil, mocks.NewLoggerMock()).(*falabellaUseCase)
+
+	_, creds, err := uc.connection(context.Background(), "1")
+	if err != nil {
+		t.Fatalf("esperaba sin error, recibí: %v", err)
+	}
+	if creds.BaseURL != "http://localhost:9104" || creds.UserID != "seller@test.com" || creds.APIKey != "test_api_key" {
+		t.Errorf("credenciales inesperadas: %+v", creds)
+	}
+}
+
+func TestBuildQueryParams(t *testing.T) {
+	qp := buildQueryParams(map[string]interface{}{
+		"created_at_min": "2026-03-01T00:00:00Z",
+		"created_at_max": "2026-03-31T23:59:59Z",
+		"status":         "pending",
+		"updated_at_min": "no-es-fecha",
+	})
+
+	if qp.CreatedAfter == nil || !qp.CreatedAfter.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("CreatedAfter inesperado: %v", qp.CreatedAfter)
+	}
+	if qp.CreatedBefore == nil {
+		t.Errorf("CreatedBefore esperado")
+	}
+	if qp.UpdatedAfter != nil {
+		t.Errorf("una fecha inválida debe ignorarse")
+	}
+	if qp.Status != "pending" {
+		t.Errorf("Status esperado 'pending', recibí '%s'", qp.Status)
+	}
+}
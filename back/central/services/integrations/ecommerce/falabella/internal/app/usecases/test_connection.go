@@ -8,7 +8,7 @@ import (
 )
 
 // TestConnection verifica que las credenciales de Falabella Seller Center sean válidas.
-// Extrae user_id (config) y api_key (credentials).
+// Extrae user_id y api_url opcional (config) y api_key (credentials).
 func (uc *falabellaUseCase) TestConnection(ctx context.Context, config map[string]interface{}, credentials map[string]interface{}) error {
 	userID, err := extractString(config, "user_id")
 	if err != nil {
@@ -20,7 +20,10 @@ func (uc *falabellaUseCase) TestConnection(ctx context.Context, config map[strin
 		return domain.ErrMissingAPIKey
 	}
 
-	if err := uc.client.TestConnection(ctx, apiKey, userID); err != nil {
+	baseURL, _ := extractString(config, "api_url")
+	creds := domain.Credentials{BaseURL: baseURL, UserID: userID, APIKey: apiKey}
+
+	if err := uc.client.TestConnection(ctx, creds); err != nil {
 		uc.logger.Error(ctx).Err(err).Msg("Falabella test connection failed")
 		return fmt.Errorf("falabella: test connection failed: %w", err)
 	}
@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+)
+
+func resolveEffectiveBaseURL(integration *domain.Integration, baseURL string) string {
+	if integration != nil && integration.IsTesting && integration.BaseURLTest != "" {
+		return integration.BaseURLTest
+	}
+	return baseURL
+}
+
+// connection resuelve la integración y las credenciales de firma: user_id y
+// api_url (config) y api_key (credentials). Sin api_url se usa producción.
+func (uc *falabellaUseCase) connection(ctx context.Context, integrationID string) (*domain.Integration, domain.Credentials, error) {
+	integration, err := uc.service.GetIntegrationByID(ctx, integrationID)
+	if err != nil {
+		return nil, domain.Credentials{}, fmt.Errorf("getting integration: %w", err)
+	}
+	if integration == nil {
+		return nil, domain.Credentials{}, domain.ErrIntegrationNotFound
+	}
+
+	userID, err := extractString(integration.Config, "user_id")
+	if err != nil {
+		return nil, domain.Credentials{}, domain.ErrMissingUserID
+	}
+	baseURL, _ := extractString(integration.Config, "api_url")
+
+	apiKey, err := uc.service.DecryptCredential(ctx, integrationID, "api_key")
+	if err != nil {
+		return nil, domain.Credentials{}, fmt.Errorf("decrypting api_key: %w", err)
+	}
+	if apiKey == "" {
+		return nil, domain.Credentials{}, domain.ErrMissingAPIKey
+	}
+
+	return integration, domain.Credentials{
+		BaseURL: resolveEffectiveBaseURL(integration, baseURL),
+		UserID:  userID,
+		APIKey:  apiKey,
+	}, nil
+}
+
+// ownedBy valida que la integración sea del negocio; businessID 0 es super admin.
+func ownedBy(integration *domain.Integration, businessID uint) bool {
+	if businessID == 0 {
+		return true
+	}
+	return integration.BusinessID != nil && *integration.BusinessID == businessID
+}
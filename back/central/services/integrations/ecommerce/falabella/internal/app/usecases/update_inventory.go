@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+)
+
+// UpdateInventory envía el stock del SKU con un feed ProductUpdate.
+// productExternalID es el SellerSku. El feed se procesa en segundo plano en
+// Seller Center; el resultado se sigue con pollFeed.
+func (uc *falabellaUseCase) UpdateInventory(ctx context.Context, integrationID string, productExternalID string, quantity int) error {
+	integration, creds, err := uc.connection(ctx, integrationID)
+	if err != nil {
+		return err
+	}
+
+	if enabled, _ := integration.Config["inventory_sync_enabled"].(bool); !enabled {
+		uc.logger.Info(ctx).
+			Str("integration_id", integrationID).
+			Msg("Sync de inventario desactivado para la integracion Falabella, push omitido")
+		return nil
+	}
+
+	if quantity < 0 {
+		quantity = 0
+	}
+
+	feedID, err := uc.client.ProductUpdate(ctx, creds, []domain.ProductUpdate{{
+		SellerSKU: productExternalID,
+		Quantity:  &quantity,
+	}})
+	if err != nil {
+		uc.logger.Error(ctx).
+			Err(err).
+			Str("integration_id", integrationID).
+			Str("sku", productExternalID).
+			Int("quantity", quantity).
+			Msg("Error al enviar feed de stock a Falabella")
+		return err
+	}
+
+	uc.logger.Info(ctx).
+		Str("integration_id", integrationID).
+		Str("sku", productExternalID).
+		Str("feed_id", feedID).
+		Int("quantity", quantity).
+		Msg("Feed de stock enviado a Falabella")
+
+	go uc.pollFeed(context.Background(), integration, creds, feedID)
+
+	return nil
+}
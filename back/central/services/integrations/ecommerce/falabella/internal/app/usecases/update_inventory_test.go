@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/mocks"
+)
+
+func enabledInventoryService() *mocks.IntegrationServiceMock {
+	businessID := uint(7)
+	return &mocks.IntegrationServiceMock{
+		GetIntegrationByIDFn: func(ctx context.Context, integrationID string) (*domain.Integration, error) {
+			return &domain.Integration{
+				ID:         1,
+				BusinessID: &businessID,
+				Config:     map[string]interface{}{"user_id": "seller@test.com", "inventory_sync_enabled": true},
+			}, nil
+		},
+	}
+}
+
+func TestUpdateInventory_SendsFeedAndPollsStatus(t *testing.T) {
+	var mu sync.Mutex
+	var sent []domain.ProductUpdate
+	polled := make(chan string, 1)
+	client := &mocks.FalabellaClientMock{
+		ProductUpdateFn: func(ctx context.Context, creds domain.Credentials, products []domain.ProductUpdate) (string, error) {
+			mu.Lock()
+			sent = products
+			mu.Unlock()
+			return "feed-1", nil
+		},
+		FeedStatusFn: func(ctx context.Context, creds domain.Credentials, feedID string) (*domain.FeedStatus, error) {
+			polled <- feedID
+			return &domain.FeedStatus{FeedID: feedID, Status: domain.FeedStatusFinished}, nil
+		},
+	}
+	uc := newTestUseCase(client, &mocks.OrderPublisherMock{}, nil, nil)
+	uc.service = enabledInventoryService()
+
+	if err := uc.UpdateInventory(context.Background(), "1", "SKU-1", -3); err != nil {
+		t.Fatalf("esperaba sin error, recibí: %v", err)
+	}
+
+	mu.Lock()
+	if len(sent) != 1 || sent[0].SellerSKU != "SKU-1" || sent[0].Quantity == nil || *sent[0].Quantity != 0 || sent[0].Price != nil {
+		t.Errorf("feed inesperado: %+v", sent)
+	}
+	mu.Unlock()
+
+	select {
+	case id := <-polled:
+		if id != "feed-1" {
+			t.Errorf("esperaba consultar feed-1, recibí %s", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("esperaba que se consultara el estado del feed")
+	}
+}
+
+func TestUpdateInventory_DisabledSkipsPush(t *testing.T) {
+	called := false
+	client := &mocks.FalabellaClientMock{
+		ProductUpdateFn: func(ctx context.Context, creds domain.Credentials, products []domain.ProductUpdate) (string, error) {
+			called = true
+			return "", nil
+		},
+	}
+	uc := newTestUseCase(client, &mocks.OrderPublisherMock{}, nil, nil)
+
+	if err := uc.UpdateInventory(context.Background(), "1", "SKU-1", 5); err != nil {
+		t.Fatalf("esperaba sin error, recibí: %v", err)
+	}
+	if called {
+		t.Errorf("sin inventory_sync_enabled no debe enviarse el feed")
+	}
+}
+
+func TestUpdatePrice_OtherBusiness(t *testing.T) {
+	uc := newTestUseCase(&mocks.FalabellaClientMock{}, &mocks.OrderPublisherMock{}, nil, nil)
+	uc.service = enabledInventoryService()
+
+	_, err := uc.UpdatePrice(context.Background(), 8, "1", "SKU-1", 99900)
+	if !errors.Is(err, domain.ErrIntegrationNotOwned) {
+		t.Fatalf("esperaba ErrIntegrationNotOwned, recibí: %v", err)
+	}
+}
+
+func TestUpdatePrice_SendsPriceFeed(t *testing.T) {
+	var sent []domain.ProductUpdate
+	client := &mocks.FalabellaClientMock{
+		ProductUpdateFn: func(ctx context.Context, creds domain.Credentials, products []domain.ProductUpdate) (string, error) {
+			sent = products
+			return "feed-2", nil
+		},
+	}
+	uc := newTestUseCase(client, &mocks.OrderPublisherMock{}, nil, nil)
+
+	feedID, err := uc.UpdatePrice(context.Background(), 7, "1", "SKU-1", 99900)
+	if err != nil {
+		t.Fatalf("esperaba sin error, recibí: %v", err)
+	}
+	if feedID != "feed-2" || len(sent) != 1 || sent[0].Price == nil || *sent[0].Price != 99900 || sent[0].Quantity != nil {
+		t.Errorf("feed de precio inesperado: %s %+v", feedID, sent)
+	}
+}
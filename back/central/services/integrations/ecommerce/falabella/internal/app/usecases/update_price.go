@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+)
+
+// UpdatePrice envía el precio del SKU con un feed ProductUpdate y retorna el
+// FeedID para consultar el resultado. businessID 0 (super admin) no valida dueño.
+func (uc *falabellaUseCase) UpdatePrice(ctx context.Context, businessID uint, integrationID string, sku string, price float64) (string, error) {
+	if sku == "" || price <= 0 {
+		return "", fmt.Errorf("falabella: sku y precio mayor a cero son requeridos")
+	}
+
+	integration, creds, err := uc.connection(ctx, integrationID)
+	if err != nil {
+		return "", err
+	}
+	if !ownedBy(integration, businessID) {
+		return "", domain.ErrIntegrationNotOwned
+	}
+
+	feedID, err := uc.client.ProductUpdate(ctx, creds, []domain.ProductUpdate{{
+		SellerSKU: sku,
+		Price:     &price,
+	}})
+	if err != nil {
+		uc.logger.Error(ctx).
+			Err(err).
+			Str("integration_id", integrationID).
+			Str("sku", sku).
+			Float64("price", price).
+			Msg("Error al enviar feed de precio a Falabella")
+		return "", err
+	}
+
+	uc.logger.Info(ctx).
+		Str("integration_id", integrationID).
+		Str("sku", sku).
+		Str("feed_id", feedID).
+		Float64("price", price).
+		Msg("Feed de precio enviado a Falabella")
+
+	go uc.pollFeed(context.Background(), integration, creds, feedID)
+
+	return feedID, nil
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // Integration representa los datos de una integración de Falabella
 // tal como se obtienen del core de integraciones.
 type Integration struct {
@@ -9,4 +11,162 @@ type Integration struct {
 	StoreID         string
 	IntegrationType int
 	Config          map[string]interface{}
+	IsTesting       bool
+	BaseURLTest     string
+}
+
+// Credentials agrupa lo necesario para firmar una llamada a Seller Center.
+type Credentials struct {
+	BaseURL string
+	UserID  string
+	APIKey  string
+}
+
+// FalabellaOrder representa una orden de Seller Center (GetOrders/GetOrder).
+// Estructura de dominio pura — sin tags JSON.
+type FalabellaOrder struct {
+	OrderID           int64
+	OrderNumber       string
+	CustomerFirstName string
+	CustomerLastName  string
+	NationalID        string
+	PaymentMethod     string
+	Remarks           string
+	Price             float64
+	VoucherCode       string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	AddressBilling    FalabellaAddress
+	AddressShipping   FalabellaAddress
+	Statuses          []string
+	Items             []FalabellaOrderItem
+}
+
+// FalabellaAddress representa la dirección de facturación o envío.
+type FalabellaAddress struct {
+	FirstName string
+	LastName  string
+	Phone     string
+	Address1  string
+	Address2  string
+	City      string
+	Ward      string
+	Region    string
+	PostCode  string
+	Country   string
+	Email     string
+}
+
+// FalabellaOrderItem es una unidad vendida: Seller Center crea un ítem por
+// unidad, por eso el mapper agrupa por SKU.
+type FalabellaOrderItem struct {
+	OrderItemID      int64
+	OrderID          int64
+	Name             string
+	SKU              string
+	ShopSKU          string
+	ItemPrice        float64
+	PaidPrice        float64
+	Currency         string
+	TaxAmount        float64
+	ShippingAmount   float64
+	VoucherAmount    float64
+	Status           string
+	ShipmentProvider string
+	ShippingType     string
+	TrackingCode     string
+	PackageID        string
+}
+
+// ProductUpdate es una línea del feed ProductUpdate (stock y/o precio).
+type ProductUpdate struct {
+	SellerSKU string
+	Quantity  *int
+	Price     *float64
+}
+
+// Feed estados de Seller Center.
+const (
+	FeedStatusQueued     = "Queued"
+	FeedStatusProcessing = "Processing"
+	FeedStatusFinished   = "Finished"
+	FeedStatusCanceled   = "Canceled"
+)
+
+// FeedStatus es el resultado de FeedStatus para un feed.
+type FeedStatus struct {
+	FeedID           string
+	Status           string
+	Action           string
+	TotalRecords     int
+	ProcessedRecords int
+	FailedRecords    int
+	Errors           []FeedError
+}
+
+// Done indica si el feed ya no va a cambiar.
+func (f *FeedStatus) Done() bool {
+	return f.Status == FeedStatusFinished || f.Status == FeedStatusCanceled
+}
+
+// FeedError es un error de validación de una línea del feed.
+type FeedError struct {
+	Code      string
+	Message   string
+	SellerSKU string
+}
+
+// Document es un documento de GetDocument (etiqueta de envío, factura).
+type Document struct {
+	DocumentType string
+	MimeType     string
+	Content      []byte
+}
+
+// ReadyToShipInput son los datos de SetStatusToReadyToShip.
+type ReadyToShipInput struct {
+	OrderItemIDs     []int64
+	DeliveryType     string
+	ShippingProvider string
+	TrackingNumber   string
+	PackageID        string
+}
+
+// OrderRef relaciona una orden de Probability con la orden de Falabella
+// y su envío.
+type OrderRef struct {
+	OrderID       string
+	BusinessID    uint
+	IntegrationID uint
+	ExternalID    string
+	OrderNumber   string
+	ShipmentID    *uint
+}
+
+// GuideData es lo que se guarda en el envío y la orden tras generar la guía.
+type GuideData struct {
+	GuideURL       string
+	TrackingNumber string
+	Carrier        string
+	PackageID      string
+}
+
+// ReadyToShipResult es la respuesta de marcar una orden lista para despacho.
+type ReadyToShipResult struct {
+	OrderID        string
+	ExternalID     string
+	OrderItemIDs   []int64
+	TrackingNumber string
+	Carrier        string
+	PackageID      string
+	GuideURL       string
+}
+
+// WebhookEvent es la notificación de Seller Center. Solo trae la referencia:
+// la orden se vuelve a leer por API.
+type WebhookEvent struct {
+	IntegrationID string
+	Event         string
+	OrderID       int64
+	FeedID        string
 }
@@ -7,4 +7,12 @@ var (
 	ErrInvalidCredentials  = errors.New("falabella: invalid credentials")
 	ErrMissingAPIKey       = errors.New("falabella: missing api_key in credentials")
 	ErrMissingUserID       = errors.New("falabella: missing user_id in config")
+	ErrOrderNotFound       = errors.New("falabella: order not found")
+	ErrNoItemsToShip       = errors.New("falabella: order has no items pending to ship")
+	ErrOrderNotOwned       = errors.New("falabella: order does not belong to business")
+	ErrIntegrationNotOwned = errors.New("falabella: integration does not belong to business")
+	ErrDocumentNotReady    = errors.New("falabella: shipping document not available")
+	ErrFeedNotFound        = errors.New("falabella: feed not found")
+	ErrWebhookMissingOrder = errors.New("falabella: webhook without order reference")
+	ErrRateLimited         = errors.New("falabella: rate limited")
 )
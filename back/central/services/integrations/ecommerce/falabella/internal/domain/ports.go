@@ -7,10 +7,25 @@ import (
 )
 
 // IFalabellaClient define las operaciones del cliente HTTP de Falabella Seller Center.
+// Todas las llamadas se firman con HMAC-SHA256 (api_key sobre los parámetros).
 // Implementado en infra/secondary/client.
 type IFalabellaClient interface {
 	// TestConnection verifica que las credenciales sean válidas
-	TestConnection(ctx context.Context, apiKey, userID string) error
+	TestConnection(ctx context.Context, creds Credentials) error
+	// GetOrders obtiene una página de órdenes (sin ítems) y el JSON crudo de cada una
+	GetOrders(ctx context.Context, creds Credentials, params *GetOrdersParams) (*GetOrdersResult, [][]byte, error)
+	// GetOrder obtiene una orden por OrderId
+	GetOrder(ctx context.Context, creds Credentials, orderID int64) (*FalabellaOrder, []byte, error)
+	// GetOrderItems obtiene los ítems de una orden
+	GetOrderItems(ctx context.Context, creds Credentials, orderID int64) ([]FalabellaOrderItem, error)
+	// SetStatusToReadyToShip marca ítems listos para despacho
+	SetStatusToReadyToShip(ctx context.Context, creds Credentials, input ReadyToShipInput) error
+	// GetDocument descarga un documento (ej: shippingParcel) de los ítems
+	GetDocument(ctx context.Context, creds Credentials, orderItemIDs []int64, documentType string) (*Document, error)
+	// ProductUpdate envía un feed de stock/precio y retorna el FeedID
+	ProductUpdate(ctx context.Context, creds Credentials, products []ProductUpdate) (string, error)
+	// FeedStatus consulta el estado de un feed
+	FeedStatus(ctx context.Context, creds Credentials, feedID string) (*FeedStatus, error)
 }
 
 // IIntegrationService define las operaciones del core de integraciones
@@ -26,3 +41,15 @@ type IIntegrationService interface {
 type OrderPublisher interface {
 	Publish(ctx context.Context, order *canonical.ProbabilityOrderDTO) error
 }
+
+// IOrderRepository resuelve órdenes de Falabella en Probability y guarda la
+// guía generada en el envío y la orden.
+type IOrderRepository interface {
+	GetOrderRef(ctx context.Context, orderID string) (*OrderRef, error)
+	SaveGuide(ctx context.Context, ref *OrderRef, guide GuideData) error
+}
+
+// ILabelStorage sube el PDF de la etiqueta y retorna la URL pública.
+type ILabelStorage interface {
+	UploadLabel(ctx context.Context, key string, content []byte) (string, error)
+}
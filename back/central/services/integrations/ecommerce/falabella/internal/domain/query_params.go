@@ -0,0 +1,19 @@
+package domain
+
+import "time"
+
+// GetOrdersParams filtros de GetOrders. Limit máximo 100.
+type GetOrdersParams struct {
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	Status        string
+	Limit         int
+	Offset        int
+}
+
+// GetOrdersResult página de órdenes con el total reportado por el Head.
+type GetOrdersResult struct {
+	Orders     []FalabellaOrder
+	TotalCount int
+}
@@ -1,7 +1,11 @@
 package handlers
 
 import (
+	"net/http"
+	"strconv"
+
 	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
 	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/app/usecases"
 	"github.com/secamc93/probability/back/central/shared/log"
 )
@@ -10,6 +14,12 @@ import (
 type IHandler interface {
 	// HandleWebhook recibe webhooks de Falabella Seller Center.
 	HandleWebhook(c *gin.Context)
+	// ReadyToShip marca una orden lista para despacho y guarda su etiqueta.
+	ReadyToShip(c *gin.Context)
+	// UpdatePrice envía un feed de precio para un SKU.
+	UpdatePrice(c *gin.Context)
+	// GetFeedStatus consulta el estado de un feed.
+	GetFeedStatus(c *gin.Context)
 	// RegisterRoutes registra las rutas en el router.
 	RegisterRoutes(router *gin.RouterGroup, logger log.ILogger)
 }
@@ -33,4 +43,42 @@ func (h *falabellaHandler) RegisterRoutes(router *gin.RouterGroup, logger log.IL
 	{
 		falabella.POST("/webhook", h.HandleWebhook)
 	}
+
+	api := router.Group("/integrations/falabella")
+	{
+		api.POST("/orders/:order_id/ready-to-ship", middleware.JWT(), h.ReadyToShip)
+		api.PUT("/:integration_id/products/:sku/price", middleware.JWT(), h.UpdatePrice)
+		api.GET("/:integration_id/feeds/:feed_id", middleware.JWT(), h.GetFeedStatus)
+	}
+}
+
+func (h *falabellaHandler) resolveBusinessID(c *gin.Context, bodyBusinessID *uint) (uint, bool) {
+	businessID, ok := middleware.GetBusinessIDFromContext(c)
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "error": "contexto de negocio no encontrado"})
+		return 0, false
+	}
+	if businessID == 0 {
+		if bodyBusinessID == nil || *bodyBusinessID == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "business_id es requerido para super admin"})
+			return 0, false
+		}
+		businessID = *bodyBusinessID
+	}
+	return businessID, true
+}
+
+// queryBusinessID lee el business_id opcional del query (super admin).
+func queryBusinessID(c *gin.Context) (*uint, bool) {
+	raw := c.Query("business_id")
+	if raw == "" {
+		return nil, true
+	}
+	parsed, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil || parsed == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "business_id invalido"})
+		return nil, false
+	}
+	value := uint(parsed)
+	return &value, true
 }
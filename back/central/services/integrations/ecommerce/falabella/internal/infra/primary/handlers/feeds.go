@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type updatePriceRequest struct {
+	Price      float64 `json:"price" binding:"required,gt=0"`
+	BusinessID *uint   `json:"business_id"`
+}
+
+func (h *falabellaHandler) UpdatePrice(c *gin.Context) {
+	var req updatePriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "price mayor a cero es requerido"})
+		return
+	}
+
+	businessID, ok := h.resolveBusinessID(c, req.BusinessID)
+	if !ok {
+		return
+	}
+
+	feedID, err := h.useCase.UpdatePrice(c.Request.Context(), businessID, c.Param("integration_id"), c.Param("sku"), req.Price)
+	if err != nil {
+		status, message := errorResponse(err)
+		c.JSON(status, gin.H{"success": false, "error": message})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"success": true, "data": gin.H{"feed_id": feedID}})
+}
+
+func (h *falabellaHandler) GetFeedStatus(c *gin.Context) {
+	bodyBusinessID, ok := queryBusinessID(c)
+	if !ok {
+		return
+	}
+	businessID, ok := h.resolveBusinessID(c, bodyBusinessID)
+	if !ok {
+		return
+	}
+
+	status, err := h.useCase.GetFeedStatus(c.Request.Context(), businessID, c.Param("integration_id"), c.Param("feed_id"))
+	if err != nil {
+		code, message := errorResponse(err)
+		c.JSON(code, gin.H{"success": false, "error": message})
+		return
+	}
+
+	errs := make([]gin.H, 0, len(status.Errors))
+	for _, e := range status.Errors {
+		errs = append(errs, gin.H{"code": e.Code, "message": e.Message, "sku": e.SellerSKU})
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"feed_id":           status.FeedID,
+			"status":            status.Status,
+			"action":            status.Action,
+			"total_records":     status.TotalRecords,
+			"processed_records": status.ProcessedRecords,
+			"failed_records":    status.FailedRecords,
+			"done":              status.Done(),
+			"errors":            errs,
+		},
+	})
+}
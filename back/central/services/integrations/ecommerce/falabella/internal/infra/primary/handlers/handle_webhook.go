@@ -1,24 +1,65 @@
 package handlers
 
 import (
+	"context"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/infra/primary/handlers/request"
 )
 
 // HandleWebhook recibe webhooks de eventos de Falabella Seller Center.
-// Falabella envía un POST con el payload del evento en el body.
-//
-// TODO: implementar procesamiento de webhooks de Falabella Seller Center
+// Seller Center no firma las notificaciones: el payload solo se usa como
+// referencia y la orden o el feed se releen por API antes de publicarlos.
 func (h *falabellaHandler) HandleWebhook(c *gin.Context) {
-	// TODO: implementar procesamiento de webhooks de Falabella
-	// 1. Validar la autenticación del webhook
-	// 2. Leer el tipo de evento del payload
-	// 3. Deserializar el payload de la orden
-	// 4. Publicar evento a la cola de órdenes
-	h.logger.Info(c.Request.Context()).
-		Str("content_type", c.GetHeader("Content-Type")).
+	ctx := c.Request.Context()
+
+	rawBody, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		h.logger.Error(ctx).Err(err).Msg("Failed to read Falabella webhook body")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	integrationID := c.Query("integration_id")
+	if integrationID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "integration_id es requerido"})
+		return
+	}
+
+	event, err := request.ParseWebhook(rawBody)
+	if err != nil {
+		h.logger.Warn(ctx).Err(err).
+			Str("integration_id", integrationID).
+			Msg("Falabella webhook payload invalido")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	event.IntegrationID = integrationID
+
+	h.logger.Info(ctx).
+		Str("event", event.Event).
+		Int64("order_id", event.OrderID).
+		Str("feed_id", event.FeedID).
+		Str("integration_id", integrationID).
 		Msg("Falabella webhook received")
 
 	c.Status(http.StatusOK)
+
+	go h.processWebhookAsync(event)
+}
+
+func (h *falabellaHandler) processWebhookAsync(event *domain.WebhookEvent) {
+	ctx := context.Background()
+
+	if err := h.useCase.ProcessWebhook(ctx, event); err != nil {
+		h.logger.Error(ctx).Err(err).
+			Str("event", event.Event).
+			Int64("order_id", event.OrderID).
+			Str("feed_id", event.FeedID).
+			Str("integration_id", event.IntegrationID).
+			Msg("Failed to process Falabella webhook")
+	}
 }
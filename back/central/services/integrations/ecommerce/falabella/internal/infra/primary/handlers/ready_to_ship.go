@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+)
+
+func (h *falabellaHandler) ReadyToShip(c *gin.Context) {
+	orderID := c.Param("order_id")
+	if orderID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "order_id es requerido"})
+		return
+	}
+
+	bodyBusinessID, ok := queryBusinessID(c)
+	if !ok {
+		return
+	}
+	businessID, ok := h.resolveBusinessID(c, bodyBusinessID)
+	if !ok {
+		return
+	}
+
+	result, err := h.useCase.ReadyToShip(c.Request.Context(), orderID, businessID)
+	if err != nil {
+		status, message := errorResponse(err)
+		c.JSON(status, gin.H{"success": false, "error": message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"order_id":        result.OrderID,
+			"external_id":     result.ExternalID,
+			"order_item_ids":  result.OrderItemIDs,
+			"tracking_number": result.TrackingNumber,
+			"carrier":         result.Carrier,
+			"package_id":      result.PackageID,
+			"guide_url":       result.GuideURL,
+		},
+	})
+}
+
+func errorResponse(err error) (int, string) {
+	switch {
+	case errors.Is(err, domain.ErrOrderNotFound):
+		return http.StatusNotFound, "orden de Falabella no encontrada"
+	case errors.Is(err, domain.ErrOrderNotOwned), errors.Is(err, domain.ErrIntegrationNotOwned):
+		return http.StatusForbidden, "el recurso no pertenece al negocio"
+	case errors.Is(err, domain.ErrNoItemsToShip):
+		return http.StatusConflict, "la orden no tiene items pendientes por despachar"
+	case errors.Is(err, domain.ErrIntegrationNotFound):
+		return http.StatusNotFound, "integracion de Falabella no encontrada"
+	case errors.Is(err, domain.ErrFeedNotFound):
+		return http.StatusNotFound, "feed no encontrado"
+	case errors.Is(err, domain.ErrInvalidCredentials), errors.Is(err, domain.ErrMissingAPIKey), errors.Is(err, domain.ErrMissingUserID):
+		return http.StatusBadGateway, "no fue posible autenticar con Falabella Seller Center"
+	case errors.Is(err, domain.ErrRateLimited):
+		return http.StatusTooManyRequests, "Falabella esta limitando las solicitudes, intenta de nuevo"
+	default:
+		return http.StatusInternalServerError, err.Error()
+	}
+}
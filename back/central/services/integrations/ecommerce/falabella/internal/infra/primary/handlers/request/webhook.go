@@ -0,0 +1,67 @@
+package request
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+)
+
+// flexID acepta el id como número o como string ("123").
+type flexID int64
+
+func (f *flexID) UnmarshalJSON(b []byte) error {
+	var n int64
+	if err := json.Unmarshal(b, &n); err == nil {
+		*f = flexID(n)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*f = 0
+		return nil
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	*f = flexID(n)
+	return nil
+}
+
+// WebhookPayload es el cuerpo que envía Seller Center:
+// {"event":"onOrderCreated","payload":{"OrderId":123}} o, para feeds,
+// {"event":"onFeedCompleted","payload":{"Feed":"<uuid>"}}.
+type WebhookPayload struct {
+	Event   string `json:"event"`
+	Payload struct {
+		OrderID flexID `json:"OrderId"`
+		Feed    string `json:"Feed"`
+		FeedID  string `json:"FeedId"`
+	} `json:"payload"`
+}
+
+// ParseWebhook convierte el cuerpo del webhook al evento de dominio.
+func ParseWebhook(body []byte) (*domain.WebhookEvent, error) {
+	var p WebhookPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, err
+	}
+
+	event := &domain.WebhookEvent{
+		Event:   p.Event,
+		OrderID: int64(p.Payload.OrderID),
+		FeedID:  p.Payload.Feed,
+	}
+	if event.FeedID == "" {
+		event.FeedID = p.Payload.FeedID
+	}
+
+	if event.OrderID == 0 && event.FeedID == "" {
+		return nil, domain.ErrWebhookMissingOrder
+	}
+	return event, nil
+}
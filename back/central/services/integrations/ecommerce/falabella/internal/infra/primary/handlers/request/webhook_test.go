@@ -0,0 +1,37 @@
+package request
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+)
+
+func TestParseWebhook(t *testing.T) {
+	cases := []struct {
+		name    string
+		body    string
+		orderID int64
+		feedID  string
+	}{
+		{"orden numérica", `{"event":"onOrderCreated","payload":{"OrderId":9001}}`, 9001, ""},
+		{"orden como string", `{"event":"onOrderItemsStatusChanged","payload":{"OrderId":"9002","OrderItemIds":["1"],"NewStatus":"shipped"}}`, 9002, ""},
+		{"feed", `{"event":"onFeedCompleted","payload":{"Feed":"a1b2"}}`, 0, "a1b2"},
+	}
+	for _, tc := range cases {
+		event, err := ParseWebhook([]byte(tc.body))
+		if err != nil {
+			t.Fatalf("%s: esperaba sin error, recibí: %v", tc.name, err)
+		}
+		if event.OrderID != tc.orderID || event.FeedID != tc.feedID {
+			t.Errorf("%s: evento inesperado: %+v", tc.name, event)
+		}
+	}
+}
+
+func TestParseWebhook_MissingReference(t *testing.T) {
+	_, err := ParseWebhook([]byte(`{"event":"onOrderCreated","payload":{}}`))
+	if !errors.Is(err, domain.ErrWebhookMissingOrder) {
+		t.Fatalf("esperaba ErrWebhookMissingOrder, recibí: %v", err)
+	}
+}
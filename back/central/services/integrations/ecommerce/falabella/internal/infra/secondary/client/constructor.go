@@ -1,36 +1,119 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/infra/secondary/client/response"
 )
 
+// DefaultBaseURL es el endpoint de producción de Seller Center.
+const DefaultBaseURL = "https://sellercenter-api.falabella.com"
+
+const apiVersion = "1.0"
+
 // FalabellaClient implementa domain.IFalabellaClient usando la Falabella Seller Center API.
 type FalabellaClient struct {
 	httpClient *http.Client
+	now        func() time.Time
 }
 
 // New crea un nuevo cliente HTTP para Falabella Seller Center.
 func New() domain.IFalabellaClient {
 	return &FalabellaClient{
-		httpClient: &http.Client{},
+		httpClient: &http.Client{Timeout: 60 * time.Second},
+		now:        time.Now,
 	}
 }
 
-// TestConnection verifica las credenciales llamando a la Falabella Seller Center API.
-// TODO: implementar llamada real a Falabella Seller Center API
-func (c *FalabellaClient) TestConnection(ctx context.Context, apiKey, userID string) error {
-	// TODO: Falabella Seller Center API
-	// La API de Falabella usa firma HMAC con apiKey + userID + timestamp + action
-	// Endpoint de prueba: GetOrder o similar para verificar credenciales
-	if apiKey == "" {
+// TestConnection verifica las credenciales con una consulta mínima de órdenes.
+// Seller Center responde E007 si la firma no corresponde al usuario.
+func (c *FalabellaClient) TestConnection(ctx context.Context, creds domain.Credentials) error {
+	if creds.APIKey == "" {
 		return domain.ErrMissingAPIKey
 	}
-	if userID == "" {
+	if creds.UserID == "" {
 		return domain.ErrMissingUserID
 	}
 
-	return nil
+	params := url.Values{}
+	params.Set("Limit", "1")
+	_, err := c.call(ctx, creds, http.MethodGet, "GetOrders", params, nil)
+	return err
+}
+
+// call firma y ejecuta una acción de Seller Center. Retorna el Body del
+// SuccessResponse junto con su Head; los ErrorResponse se traducen a error.
+func (c *FalabellaClient) call(ctx context.Context, creds domain.Credentials, method, action string, params url.Values, body []byte) (*response.Success, error) {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("Action", action)
+	params.Set("Format", "JSON")
+	params.Set("Timestamp", c.now().UTC().Format(time.RFC3339))
+	params.Set("UserID", creds.UserID)
+	params.Set("Version", apiVersion)
+	params.Set("Signature", Sign(params, creds.APIKey))
+
+	baseURL := creds.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	endpoint := strings.TrimRight(baseURL, "/") + "/?" + encodeParams(params)
+
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return nil, fmt.Errorf("falabella client: creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", creds.UserID+"/Go/probability")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/xml")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("falabella client: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, 32<<20))
+	if err != nil {
+		return nil, fmt.Errorf("falabella client: reading response: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return nil, domain.ErrRateLimited
+	}
+
+	var envelope response.Envelope
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return nil, fmt.Errorf("falabella client: unexpected response (status %d): %s", resp.StatusCode, truncate(raw))
+	}
+	if envelope.ErrorResponse != nil {
+		return nil, envelope.ErrorResponse.Head.Err(action)
+	}
+	if envelope.SuccessResponse == nil {
+		return nil, fmt.Errorf("falabella client: empty response (status %d): %s", resp.StatusCode, truncate(raw))
+	}
+	return envelope.SuccessResponse, nil
+}
+
+func truncate(body []byte) string {
+	if len(body) > 512 {
+		body = body[:512]
+	}
+	return string(body)
 }
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/infra/secondary/client/response"
+)
+
+// ProductUpdate envía el feed de stock/precio. Seller Center lo procesa en
+// segundo plano; el RequestId del Head es el FeedID a consultar.
+func (c *FalabellaClient) ProductUpdate(ctx context.Context, creds domain.Credentials, products []domain.ProductUpdate) (string, error) {
+	body, err := response.NewProductUpdateRequest(products)
+	if err != nil {
+		return "", fmt.Errorf("falabella client: building feed: %w", err)
+	}
+
+	success, err := c.call(ctx, creds, http.MethodPost, "ProductUpdate", nil, body)
+	if err != nil {
+		return "", err
+	}
+	if success.Head.RequestID == "" {
+		return "", fmt.Errorf("falabella client: ProductUpdate without feed id")
+	}
+	return success.Head.RequestID, nil
+}
+
+// FeedStatus consulta el estado de procesamiento de un feed.
+func (c *FalabellaClient) FeedStatus(ctx context.Context, creds domain.Credentials, feedID string) (*domain.FeedStatus, error) {
+	params := url.Values{}
+	params.Set("FeedID", feedID)
+
+	success, err := c.call(ctx, creds, http.MethodGet, "FeedStatus", params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed response.FeedStatusBody
+	if err := json.Unmarshal(success.Body, &parsed); err != nil {
+		return nil, fmt.Errorf("falabella client: parsing feed status: %w", err)
+	}
+	if parsed.FeedDetail.Feed == "" {
+		return nil, domain.ErrFeedNotFound
+	}
+	return parsed.ToDomain(), nil
+}
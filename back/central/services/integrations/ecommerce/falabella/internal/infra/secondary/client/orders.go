@@ -0,0 +1,170 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/infra/secondary/client/response"
+)
+
+// GetOrders obtiene una página de órdenes ordenada por fecha de creación.
+// Retorna las órdenes tipadas (sin ítems), los bytes crudos por orden y error.
+func (c *FalabellaClient) GetOrders(ctx context.Context, creds domain.Credentials, p *domain.GetOrdersParams) (*domain.GetOrdersResult, [][]byte, error) {
+	params := url.Values{}
+	if p == nil {
+		p = &domain.GetOrdersParams{}
+	}
+	if p.CreatedAfter != nil {
+		params.Set("CreatedAfter", response.FormatTime(*p.CreatedAfter))
+	}
+	if p.CreatedBefore != nil {
+		params.Set("CreatedBefore", response.FormatTime(*p.CreatedBefore))
+	}
+	if p.UpdatedAfter != nil {
+		params.Set("UpdatedAfter", response.FormatTime(*p.UpdatedAfter))
+	}
+	if p.Status != "" {
+		params.Set("Status", p.Status)
+	}
+	if p.Limit > 0 {
+		params.Set("Limit", strconv.Itoa(p.Limit))
+	}
+	params.Set("Offset", strconv.Itoa(p.Offset))
+	params.Set("SortBy", "created_at")
+	params.Set("SortDirection", "ASC")
+
+	success, err := c.call(ctx, creds, http.MethodGet, "GetOrders", params, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orders, raws, err := parseOrders(success.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	total := int(success.Head.TotalCount)
+	if total == 0 {
+		total = p.Offset + len(orders)
+	}
+	return &domain.GetOrdersResult{Orders: orders, TotalCount: total}, raws, nil
+}
+
+// GetOrder obtiene una orden por OrderId.
+func (c *FalabellaClient) GetOrder(ctx context.Context, creds domain.Credentials, orderID int64) (*domain.FalabellaOrder, []byte, error) {
+	params := url.Values{}
+	params.Set("OrderId", strconv.FormatInt(orderID, 10))
+
+	success, err := c.call(ctx, creds, http.MethodGet, "GetOrder", params, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orders, raws, err := parseOrders(success.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(orders) == 0 {
+		return nil, nil, domain.ErrOrderNotFound
+	}
+	return &orders[0], raws[0], nil
+}
+
+func parseOrders(body json.RawMessage) ([]domain.FalabellaOrder, [][]byte, error) {
+	var parsed response.OrdersBody
+	if len(body) > 0 && string(body) != `""` {
+		if err := json.Unmarshal(body, &parsed); err != nil {
+			return nil, nil, fmt.Errorf("falabella client: parsing orders: %w", err)
+		}
+	}
+
+	orders := make([]domain.FalabellaOrder, 0, len(parsed.Orders.Order))
+	raws := make([][]byte, 0, len(parsed.Orders.Order))
+	for _, raw := range parsed.Orders.Order {
+		var orderResp response.OrderResponse
+		if err := json.Unmarshal(raw, &orderResp); err != nil {
+			continue // skip malformed orders
+		}
+		orders = append(orders, orderResp.ToDomain())
+		raws = append(raws, []byte(raw))
+	}
+	return orders, raws, nil
+}
+
+// GetOrderItems obtiene los ítems (uno por unidad) de una orden.
+func (c *FalabellaClient) GetOrderItems(ctx context.Context, creds domain.Credentials, orderID int64) ([]domain.FalabellaOrderItem, error) {
+	params := url.Values{}
+	params.Set("OrderId", strconv.FormatInt(orderID, 10))
+
+	success, err := c.call(ctx, creds, http.MethodGet, "GetOrderItems", params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed response.OrderItemsBody
+	if err := json.Unmarshal(success.Body, &parsed); err != nil {
+		return nil, fmt.Errorf("falabella client: parsing order items: %w", err)
+	}
+
+	items := make([]domain.FalabellaOrderItem, 0, len(parsed.OrderItems.OrderItem))
+	for _, it := range parsed.OrderItems.OrderItem {
+		items = append(items, it.ToDomain())
+	}
+	return items, nil
+}
+
+// SetStatusToReadyToShip marca los ítems listos para despacho.
+func (c *FalabellaClient) SetStatusToReadyToShip(ctx context.Context, creds domain.Credentials, input domain.ReadyToShipInput) error {
+	params := url.Values{}
+	params.Set("OrderItemIds", response.FormatIDs(input.OrderItemIDs))
+	params.Set("DeliveryType", input.DeliveryType)
+	if input.ShippingProvider != "" {
+		params.Set("ShippingProvider", input.ShippingProvider)
+	}
+	if input.TrackingNumber != "" {
+		params.Set("TrackingNumber", input.TrackingNumber)
+	}
+	if input.PackageID != "" {
+		params.Set("PackageId", input.PackageID)
+	}
+
+	_, err := c.call(ctx, creds, http.MethodPost, "SetStatusToReadyToShip", params, nil)
+	return err
+}
+
+// GetDocument descarga un documento de los ítems. File viene en base64.
+func (c *FalabellaClient) GetDocument(ctx context.Context, creds domain.Credentials, orderItemIDs []int64, documentType string) (*domain.Document, error) {
+	params := url.Values{}
+	params.Set("OrderItemIds", response.FormatIDs(orderItemIDs))
+	params.Set("DocumentType", documentType)
+
+	success, err := c.call(ctx, creds, http.MethodGet, "GetDocument", params, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed response.DocumentBody
+	if err := json.Unmarshal(success.Body, &parsed); err != nil {
+		return nil, fmt.Errorf("falabella client: parsing document: %w", err)
+	}
+	if len(parsed.Documents.Document) == 0 || parsed.Documents.Document[0].File == "" {
+		return nil, domain.ErrDocumentNotReady
+	}
+
+	doc := parsed.Documents.Document[0]
+	content, err := base64.StdEncoding.DecodeString(doc.File)
+	if err != nil {
+		return nil, fmt.Errorf("falabella client: decoding document: %w", err)
+	}
+	return &domain.Document{
+		DocumentType: doc.DocumentType,
+		MimeType:     doc.MimeType,
+		Content:      content,
+	}, nil
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+)
+
+func TestGetOrders_SingleOrderObjectAndSignature(t *testing.T) {
+	creds := domain.Credentials{UserID: "seller@test.com", APIKey: "secreto"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("Action") != "GetOrders" {
+			t.Errorf("acción inesperada: %s", q.Get("Action"))
+		}
+		if q.Get("Signature") != Sign(q, creds.APIKey) {
+			t.Errorf("firma inválida")
+		}
+		// Seller Center devuelve un objeto, no una lista, cuando hay una sola orden
+		_, _ = w.Write([]byte(`{"SuccessResponse":{"Head":{"RequestId":"","TotalCount":"1"},"Body":{"Orders":{"Order":{"OrderId":"9001","OrderNumber":3050001,"Price":"210000.00","CreatedAt":"2026-05-02 10:00:00","Statuses":{"Status":"pending"}}}}}}`))
+	}))
+	defer server.Close()
+	creds.BaseURL = server.URL
+
+	result, raws, err := New().GetOrders(context.Background(), creds, &domain.GetOrdersParams{Limit: 100})
+	if err != nil {
+		t.Fatalf("esperaba sin error, recibí: %v", err)
+	}
+	if len(result.Orders) != 1 || len(raws) != 1 || result.TotalCount != 1 {
+		t.Fatalf("esperaba 1 orden, recibí %d (total %d)", len(result.Orders), result.TotalCount)
+	}
+	order := result.Orders[0]
+	if order.OrderID != 9001 || order.OrderNumber != "3050001" || order.Price != 210000 {
+		t.Errorf("orden inesperada: %+v", order)
+	}
+	if len(order.Statuses) != 1 || order.Statuses[0] != "pending" {
+		t.Errorf("estados inesperados: %v", order.Statuses)
+	}
+}
+
+func TestCall_ErrorResponseMapsInvalidCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ErrorResponse":{"Head":{"RequestAction":"GetOrders","ErrorType":"Sender","ErrorCode":"7","ErrorMessage":"E7: Login failed. Signature mismatching"}}}`))
+	}))
+	defer server.Close()
+
+	err := New().TestConnection(context.Background(), domain.Credentials{BaseURL: server.URL, UserID: "u", APIKey: "k"})
+	if !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Fatalf("esperaba ErrInvalidCredentials, recibí: %v", err)
+	}
+}
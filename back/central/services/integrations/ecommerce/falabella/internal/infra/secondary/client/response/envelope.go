@@ -0,0 +1,136 @@
+package response
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+)
+
+// Envelope es la respuesta de Seller Center: SuccessResponse o ErrorResponse.
+type Envelope struct {
+	SuccessResponse *Success       `json:"SuccessResponse"`
+	ErrorResponse   *ErrorResponse `json:"ErrorResponse"`
+}
+
+type Success struct {
+	Head Head            `json:"Head"`
+	Body json.RawMessage `json:"Body"`
+}
+
+type Head struct {
+	RequestID     string  `json:"RequestId"`
+	RequestAction string  `json:"RequestAction"`
+	ResponseType  string  `json:"ResponseType"`
+	Timestamp     string  `json:"Timestamp"`
+	TotalCount    FlexInt `json:"TotalCount"`
+}
+
+type ErrorResponse struct {
+	Head ErrorHead `json:"Head"`
+}
+
+type ErrorHead struct {
+	RequestAction string  `json:"RequestAction"`
+	ErrorType     string  `json:"ErrorType"`
+	ErrorCode     FlexInt `json:"ErrorCode"`
+	ErrorMessage  string  `json:"ErrorMessage"`
+}
+
+// Err traduce el código de error de Seller Center a errores de dominio.
+func (h ErrorHead) Err(action string) error {
+	switch int(h.ErrorCode) {
+	case 7, 9:
+		// E007 Login failed. Signature mismatching / E009 Access Denied
+		return fmt.Errorf("%w: %s", domain.ErrInvalidCredentials, h.ErrorMessage)
+	case 16:
+		// E016 Invalid Order ID
+		return fmt.Errorf("%w: %s", domain.ErrOrderNotFound, h.ErrorMessage)
+	case 429:
+		return domain.ErrRateLimited
+	}
+	return fmt.Errorf("falabella client: %s failed (E%03d): %s", action, int(h.ErrorCode), h.ErrorMessage)
+}
+
+// FlexInt acepta números como número o como string ("12").
+type FlexInt int
+
+func (f *FlexInt) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		*f = 0
+		return nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	*f = FlexInt(n)
+	return nil
+}
+
+// FlexFloat acepta montos como número o string ("129900.00").
+type FlexFloat float64
+
+func (f *FlexFloat) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "" || s == "null" {
+		*f = 0
+		return nil
+	}
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*f = FlexFloat(n)
+	return nil
+}
+
+// OneOrMany acepta un objeto suelto o una lista: Seller Center serializa las
+// colecciones de un solo elemento como objeto.
+type OneOrMany[T any] []T
+
+func (o *OneOrMany[T]) UnmarshalJSON(b []byte) error {
+	trimmed := strings.TrimSpace(string(b))
+	switch {
+	case trimmed == "" || trimmed == "null" || trimmed == `""`:
+		*o = nil
+		return nil
+	case strings.HasPrefix(trimmed, "["):
+		var many []T
+		if err := json.Unmarshal(b, &many); err != nil {
+			return err
+		}
+		*o = many
+		return nil
+	}
+	var one T
+	if err := json.Unmarshal(b, &one); err != nil {
+		return err
+	}
+	*o = []T{one}
+	return nil
+}
+
+// FlexString acepta identificadores como string o número (OrderNumber).
+type FlexString string
+
+func (f *FlexString) UnmarshalJSON(b []byte) error {
+	s := strings.TrimSpace(string(b))
+	if s == "null" {
+		*f = ""
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		var v string
+		if err := json.Unmarshal(b, &v); err != nil {
+			return err
+		}
+		*f = FlexString(v)
+		return nil
+	}
+	*f = FlexString(s)
+	return nil
+}
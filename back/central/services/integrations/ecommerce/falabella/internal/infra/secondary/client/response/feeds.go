@@ -0,0 +1,81 @@
+package response
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+)
+
+// ProductUpdateRequest es el XML del feed ProductUpdate.
+type ProductUpdateRequest struct {
+	XMLName  xml.Name             `xml:"Request"`
+	Products []ProductUpdateEntry `xml:"Product"`
+}
+
+type ProductUpdateEntry struct {
+	SellerSku string `xml:"SellerSku"`
+	Quantity  string `xml:"Quantity,omitempty"`
+	Price     string `xml:"Price,omitempty"`
+}
+
+// NewProductUpdateRequest arma el XML a partir de las líneas de dominio.
+func NewProductUpdateRequest(products []domain.ProductUpdate) ([]byte, error) {
+	req := ProductUpdateRequest{Products: make([]ProductUpdateEntry, 0, len(products))}
+	for _, p := range products {
+		entry := ProductUpdateEntry{SellerSku: p.SellerSKU}
+		if p.Quantity != nil {
+			entry.Quantity = strconv.Itoa(*p.Quantity)
+		}
+		if p.Price != nil {
+			entry.Price = strconv.FormatFloat(*p.Price, 'f', 2, 64)
+		}
+		req.Products = append(req.Products, entry)
+	}
+	body, err := xml.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
+
+// FeedStatusBody es el Body de FeedStatus.
+type FeedStatusBody struct {
+	FeedDetail struct {
+		Feed             string  `json:"Feed"`
+		Status           string  `json:"Status"`
+		Action           string  `json:"Action"`
+		TotalRecords     FlexInt `json:"TotalRecords"`
+		ProcessedRecords FlexInt `json:"ProcessedRecords"`
+		FailedRecords    FlexInt `json:"FailedRecords"`
+		FeedErrors       struct {
+			Error OneOrMany[FeedErrorResponse] `json:"Error"`
+		} `json:"FeedErrors"`
+	} `json:"FeedDetail"`
+}
+
+type FeedErrorResponse struct {
+	Code      FlexInt `json:"Code"`
+	Message   string  `json:"Message"`
+	SellerSku string  `json:"SellerSku"`
+}
+
+func (b *FeedStatusBody) ToDomain() *domain.FeedStatus {
+	d := b.FeedDetail
+	status := &domain.FeedStatus{
+		FeedID:           d.Feed,
+		Status:           d.Status,
+		Action:           d.Action,
+		TotalRecords:     int(d.TotalRecords),
+		ProcessedRecords: int(d.ProcessedRecords),
+		FailedRecords:    int(d.FailedRecords),
+	}
+	for _, e := range d.FeedErrors.Error {
+		status.Errors = append(status.Errors, domain.FeedError{
+			Code:      strconv.Itoa(int(e.Code)),
+			Message:   e.Message,
+			SellerSKU: e.SellerSku,
+		})
+	}
+	return status
+}
@@ -0,0 +1,183 @@
+package response
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+)
+
+// timeLayout es el formato de fecha de Seller Center (hora local de la cuenta).
+const timeLayout = "2006-01-02 15:04:05"
+
+// OrdersBody es el Body de GetOrders / GetOrder.
+type OrdersBody struct {
+	Orders struct {
+		Order OneOrMany[json.RawMessage] `json:"Order"`
+	} `json:"Orders"`
+}
+
+type OrderResponse struct {
+	OrderID                    FlexInt         `json:"OrderId"`
+	OrderNumber                FlexString      `json:"OrderNumber"`
+	CustomerFirstName          string          `json:"CustomerFirstName"`
+	CustomerLastName           string          `json:"CustomerLastName"`
+	NationalRegistrationNumber string          `json:"NationalRegistrationNumber"`
+	PaymentMethod              string          `json:"PaymentMethod"`
+	Remarks                    string          `json:"Remarks"`
+	Price                      FlexFloat       `json:"Price"`
+	VoucherCode                string          `json:"VoucherCode"`
+	CreatedAt                  string          `json:"CreatedAt"`
+	UpdatedAt                  string          `json:"UpdatedAt"`
+	AddressBilling             AddressResponse `json:"AddressBilling"`
+	AddressShipping            AddressResponse `json:"AddressShipping"`
+	Statuses                   struct {
+		Status OneOrMany[string] `json:"Status"`
+	} `json:"Statuses"`
+}
+
+type AddressResponse struct {
+	FirstName     string `json:"FirstName"`
+	LastName      string `json:"LastName"`
+	Phone         string `json:"Phone"`
+	Phone2        string `json:"Phone2"`
+	Address1      string `json:"Address1"`
+	Address2      string `json:"Address2"`
+	CustomerEmail string `json:"CustomerEmail"`
+	City          string `json:"City"`
+	Ward          string `json:"Ward"`
+	Region        string `json:"Region"`
+	PostCode      string `json:"PostCode"`
+	Country       string `json:"Country"`
+}
+
+// ToDomain convierte la respuesta a la entidad de dominio.
+func (r *OrderResponse) ToDomain() domain.FalabellaOrder {
+	return domain.FalabellaOrder{
+		OrderID:           int64(r.OrderID),
+		OrderNumber:       string(r.OrderNumber),
+		CustomerFirstName: r.CustomerFirstName,
+		CustomerLastName:  r.CustomerLastName,
+		NationalID:        r.NationalRegistrationNumber,
+		PaymentMethod:     r.PaymentMethod,
+		Remarks:           r.Remarks,
+		Price:             float64(r.Price),
+		VoucherCode:       r.VoucherCode,
+		CreatedAt:         ParseTime(r.CreatedAt),
+		UpdatedAt:         ParseTime(r.UpdatedAt),
+		AddressBilling:    r.AddressBilling.toDomain(),
+		AddressShipping:   r.AddressShipping.toDomain(),
+		Statuses:          []string(r.Statuses.Status),
+	}
+}
+
+func (a AddressResponse) toDomain() domain.FalabellaAddress {
+	phone := a.Phone
+	if phone == "" {
+		phone = a.Phone2
+	}
+	return domain.FalabellaAddress{
+		FirstName: a.FirstName,
+		LastName:  a.LastName,
+		Phone:     phone,
+		Address1:  a.Address1,
+		Address2:  a.Address2,
+		City:      a.City,
+		Ward:      a.Ward,
+		Region:    a.Region,
+		PostCode:  a.PostCode,
+		Country:   a.Country,
+		Email:     a.CustomerEmail,
+	}
+}
+
+// OrderItemsBody es el Body de GetOrderItems.
+type OrderItemsBody struct {
+	OrderItems struct {
+		OrderItem OneOrMany[OrderItemResponse] `json:"OrderItem"`
+	} `json:"OrderItems"`
+}
+
+type OrderItemResponse struct {
+	OrderItemID      FlexInt   `json:"OrderItemId"`
+	OrderID          FlexInt   `json:"OrderId"`
+	Name             string    `json:"Name"`
+	Sku              string    `json:"Sku"`
+	ShopSku          string    `json:"ShopSku"`
+	ItemPrice        FlexFloat `json:"ItemPrice"`
+	PaidPrice        FlexFloat `json:"PaidPrice"`
+	Currency         string    `json:"Currency"`
+	TaxAmount        FlexFloat `json:"TaxAmount"`
+	ShippingAmount   FlexFloat `json:"ShippingAmount"`
+	VoucherAmount    FlexFloat `json:"VoucherAmount"`
+	Status           string    `json:"Status"`
+	ShipmentProvider string    `json:"ShipmentProvider"`
+	ShippingType     string    `json:"ShippingType"`
+	TrackingCode     string    `json:"TrackingCode"`
+	PackageID        string    `json:"PackageId"`
+}
+
+func (r *OrderItemResponse) ToDomain() domain.FalabellaOrderItem {
+	return domain.FalabellaOrderItem{
+		OrderItemID:      int64(r.OrderItemID),
+		OrderID:          int64(r.OrderID),
+		Name:             r.Name,
+		SKU:              r.Sku,
+		ShopSKU:          r.ShopSku,
+		ItemPrice:        float64(r.ItemPrice),
+		PaidPrice:        float64(r.PaidPrice),
+		Currency:         r.Currency,
+		TaxAmount:        float64(r.TaxAmount),
+		ShippingAmount:   float64(r.ShippingAmount),
+		VoucherAmount:    float64(r.VoucherAmount),
+		Status:           r.Status,
+		ShipmentProvider: r.ShipmentProvider,
+		ShippingType:     r.ShippingType,
+		TrackingCode:     r.TrackingCode,
+		PackageID:        r.PackageID,
+	}
+}
+
+// DocumentBody es el Body de GetDocument.
+type DocumentBody struct {
+	Documents struct {
+		Document OneOrMany[DocumentResponse] `json:"Document"`
+	} `json:"Documents"`
+}
+
+type DocumentResponse struct {
+	DocumentType string `json:"DocumentType"`
+	MimeType     string `json:"MimeType"`
+	File         string `json:"File"`
+}
+
+// ParseTime interpreta las fechas de Seller Center; acepta también RFC3339.
+func ParseTime(s string) time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}
+	}
+	if t, err := time.Parse(timeLayout, s); err == nil {
+		return t
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+// FormatTime formatea filtros de fecha (CreatedAfter, UpdatedAfter...) en ISO 8601.
+func FormatTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+// FormatIDs serializa OrderItemIds como lista JSON ("[1,2]").
+func FormatIDs(ids []int64) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.FormatInt(id, 10)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
+}
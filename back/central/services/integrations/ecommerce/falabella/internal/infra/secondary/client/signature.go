@@ -0,0 +1,56 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Sign calcula la firma de Seller Center: HMAC-SHA256 en hex, con el api_key
+// como llave, de los parámetros ordenados por nombre y codificados RFC 3986
+// (espacios como %20). El propio parámetro Signature no se firma.
+func Sign(params url.Values, apiKey string) string {
+	mac := hmac.New(sha256.New, []byte(apiKey))
+	mac.Write([]byte(canonicalString(params)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func canonicalString(params url.Values) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		if k == "Signature" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, rawURLEncode(k)+"="+rawURLEncode(params.Get(k)))
+	}
+	return strings.Join(parts, "&")
+}
+
+// encodeParams arma el query string con la misma codificación de la firma.
+func encodeParams(params url.Values) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, rawURLEncode(k)+"="+rawURLEncode(params.Get(k)))
+	}
+	return strings.Join(parts, "&")
+}
+
+// rawURLEncode equivale a rawurlencode de PHP, que es lo que valida Seller Center.
+func rawURLEncode(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"testing"
+)
+
+func TestSign_SortsAndEncodesParams(t *testing.T) {
+	params := url.Values{}
+	params.Set("UserID", "look@me.com")
+	params.Set("Version", "1.0")
+	params.Set("Action", "GetOrders")
+	params.Set("Format", "JSON")
+	params.Set("Timestamp", "2026-05-02T10:00:00+00:00")
+	params.Set("Signature", "ignorada")
+
+	want := "Action=GetOrders&Format=JSON&Timestamp=2026-05-02T10%3A00%3A00%2B00%3A00&UserID=look%40me.com&Version=1.0"
+	if got := canonicalString(params); got != want {
+		t.Fatalf("cadena canónica inesperada:\n%s\n%s", got, want)
+	}
+
+	mac := hmac.New(sha256.New, []byte("secreto"))
+	mac.Write([]byte(want))
+	if got := Sign(params, "secreto"); got != hex.EncodeToString(mac.Sum(nil)) {
+		t.Errorf("firma inesperada: %s", got)
+	}
+}
+
+func TestRawURLEncode_SpacesAsPercent20(t *testing.T) {
+	if got := rawURLEncode("a b+c"); got != "a%20b%2Bc" {
+		t.Errorf("esperaba a%%20b%%2Bc, recibí %s", got)
+	}
+}
@@ -25,18 +25,34 @@ func (f *FalabellaCore) TestConnection(ctx context.Context, config map[string]in
 	return f.useCase.TestConnection(ctx, config, credentials)
 }
 
+// SyncOrdersByIntegrationID sincroniza órdenes de Falabella (últimos 30 días).
+func (f *FalabellaCore) SyncOrdersByIntegrationID(ctx context.Context, integrationID string) error {
+	return f.useCase.SyncOrders(ctx, integrationID)
+}
+
+// SyncOrdersByIntegrationIDWithParams sincroniza órdenes con parámetros personalizados.
+func (f *FalabellaCore) SyncOrdersByIntegrationIDWithParams(ctx context.Context, integrationID string, params interface{}) error {
+	return f.useCase.SyncOrdersWithParams(ctx, integrationID, params)
+}
+
+func (f *FalabellaCore) UpdateInventory(ctx context.Context, integrationID string, productExternalID string, quantity int) error {
+	return f.useCase.UpdateInventory(ctx, integrationID, productExternalID, quantity)
+}
+
 // GetWebhookURL retorna la URL para los webhooks de Falabella Seller Center.
 func (f *FalabellaCore) GetWebhookURL(ctx context.Context, baseURL string, integrationID uint) (*integrationcore.WebhookInfo, error) {
-	webhookURL := fmt.Sprintf("%s/integrations/falabella/webhook", baseURL)
+	webhookURL := fmt.Sprintf("%s/api/v1/falabella/webhook?integration_id=%d", baseURL, integrationID)
 
 	return &integrationcore.WebhookInfo{
 		URL:    webhookURL,
 		Method: "POST",
-		Description: "Configura este webhook en Falabella Seller Center para recibir " +
-			"notificaciones de órdenes en tiempo real.",
+		Description: "Configura este webhook en Falabella Seller Center (Mi cuenta > Integraciones > Webhooks) " +
+			"para recibir notificaciones de órdenes y feeds en tiempo real. " +
+			"La orden se vuelve a consultar por API, el contenido del webhook no se usa directamente.",
 		Events: []string{
-			"order.created",
-			"order.updated",
+			"onOrderCreated",
+			"onOrderItemsStatusChanged",
+			"onFeedCompleted",
 		},
 	}, nil
 }
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/db"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+type OrderRepo struct {
+	db  db.IDatabase
+	log log.ILogger
+}
+
+func New(database db.IDatabase, logger log.ILogger) domain.IOrderRepository {
+	return &OrderRepo{
+		db:  database,
+		log: logger.WithModule("falabella.order_repo"),
+	}
+}
+
+func (r *OrderRepo) GetOrderRef(ctx context.Context, orderID string) (*domain.OrderRef, error) {
+	var row struct {
+		ID            string
+		BusinessID    uint
+		IntegrationID uint
+		ExternalID    string
+		OrderNumber   string
+		ShipmentID    *uint
+	}
+	err := r.db.Conn(ctx).
+		Table("orders AS o").
+		Select("o.id, o.business_id, o.integration_id, o.external_id, o.order_number, "+
+			"(SELECT s.id FROM shipments s WHERE s.order_id = o.id AND s.deleted_at IS NULL ORDER BY s.created_at DESC LIMIT 1) AS shipment_id").
+		Where("o.id = ? AND o.integration_type = ? AND o.deleted_at IS NULL", orderID, "falabella").
+		Limit(1).
+		Scan(&row).Error
+	if err != nil {
+		return nil, err
+	}
+	if row.ID == "" {
+		return nil, nil
+	}
+	return &domain.OrderRef{
+		OrderID:       row.ID,
+		BusinessID:    row.BusinessID,
+		IntegrationID: row.IntegrationID,
+		ExternalID:    row.ExternalID,
+		OrderNumber:   row.OrderNumber,
+		ShipmentID:    row.ShipmentID,
+	}, nil
+}
+
+// SaveGuide guarda la guía igual que el resto de transportadoras: en el envío
+// (guide_url, guide_id, tracking_number, carrier) y en la orden (guide_link,
+// guide_id, tracking_number).
+func (r *OrderRepo) SaveGuide(ctx context.Context, ref *domain.OrderRef, guide domain.GuideData) error {
+	conn := r.db.Conn(ctx)
+	now := time.Now()
+
+	if ref.ShipmentID != nil {
+		if err := conn.Table("shipments").
+			Where("id = ? AND deleted_at IS NULL", *ref.ShipmentID).
+			Updates(map[string]interface{}{
+				"guide_url":       guide.GuideURL,
+				"guide_id":        guide.PackageID,
+				"tracking_number": guide.TrackingNumber,
+				"carrier":         guide.Carrier,
+				"updated_at":      now,
+			}).Error; err != nil {
+			return err
+		}
+	}
+
+	return conn.Table("orders").
+		Where("id = ? AND deleted_at IS NULL", ref.OrderID).
+		Updates(map[string]interface{}{
+			"guide_link":      guide.GuideURL,
+			"guide_id":        guide.PackageID,
+			"tracking_number": guide.TrackingNumber,
+			"updated_at":      now,
+		}).Error
+}
@@ -0,0 +1,22 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+	sharedstorage "github.com/secamc93/probability/back/central/shared/storage"
+)
+
+type LabelStorage struct {
+	s3 sharedstorage.IS3Service
+}
+
+func New(s3 sharedstorage.IS3Service) domain.ILabelStorage {
+	return &LabelStorage{s3: s3}
+}
+
+func (s *LabelStorage) UploadLabel(ctx context.Context, key string, content []byte) (string, error) {
+	reader := bytes.NewReader(content)
+	return s.s3.UploadFile(ctx, reader, key)
+}
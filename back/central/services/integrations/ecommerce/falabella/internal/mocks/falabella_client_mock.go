@@ -0,0 +1,78 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+)
+
+// FalabellaClientMock mock de domain.IFalabellaClient para tests unitarios.
+type FalabellaClientMock struct {
+	TestConnectionFn         func(ctx context.Context, creds domain.Credentials) error
+	GetOrdersFn              func(ctx context.Context, creds domain.Credentials, params *domain.GetOrdersParams) (*domain.GetOrdersResult, [][]byte, error)
+	GetOrderFn               func(ctx context.Context, creds domain.Credentials, orderID int64) (*domain.FalabellaOrder, []byte, error)
+	GetOrderItemsFn          func(ctx context.Context, creds domain.Credentials, orderID int64) ([]domain.FalabellaOrderItem, error)
+	SetStatusToReadyToShipFn func(ctx context.Context, creds domain.Credentials, input domain.ReadyToShipInput) error
+	GetDocumentFn            func(ctx context.Context, creds domain.Credentials, orderItemIDs []int64, documentType string) (*domain.Document, error)
+	ProductUpdateFn          func(ctx context.Context, creds domain.Credentials, products []domain.ProductUpdate) (string, error)
+	FeedStatusFn             func(ctx context.Context, creds domain.Credentials, feedID string) (*domain.FeedStatus, error)
+}
+
+// Verificar en tiempo de compilación que implementa la interfaz.
+var _ domain.IFalabellaClient = (*FalabellaClientMock)(nil)
+
+func (m *FalabellaClientMock) TestConnection(ctx context.Context, creds domain.Credentials) error {
+	if m.TestConnectionFn != nil {
+		return m.TestConnectionFn(ctx, creds)
+	}
+	return nil
+}
+
+func (m *FalabellaClientMock) GetOrders(ctx context.Context, creds domain.Credentials, params *domain.GetOrdersParams) (*domain.GetOrdersResult, [][]byte, error) {
+	if m.GetOrdersFn != nil {
+		return m.GetOrdersFn(ctx, creds, params)
+	}
+	return &domain.GetOrdersResult{}, nil, nil
+}
+
+func (m *FalabellaClientMock) GetOrder(ctx context.Context, creds domain.Credentials, orderID int64) (*domain.FalabellaOrder, []byte, error) {
+	if m.GetOrderFn != nil {
+		return m.GetOrderFn(ctx, creds, orderID)
+	}
+	return nil, nil, domain.ErrOrderNotFound
+}
+
+func (m *FalabellaClientMock) GetOrderItems(ctx context.Context, creds domain.Credentials, orderID int64) ([]domain.FalabellaOrderItem, error) {
+	if m.GetOrderItemsFn != nil {
+		return m.GetOrderItemsFn(ctx, creds, orderID)
+	}
+	return nil, nil
+}
+
+func (m *FalabellaClientMock) SetStatusToReadyToShip(ctx context.Context, creds domain.Credentials, input domain.ReadyToShipInput) error {
+	if m.SetStatusToReadyToShipFn != nil {
+		return m.SetStatusToReadyToShipFn(ctx, creds, input)
+	}
+	return nil
+}
+
+func (m *FalabellaClientMock) GetDocument(ctx context.Context, creds domain.Credentials, orderItemIDs []int64, documentType string) (*domain.Document, error) {
+	if m.GetDocumentFn != nil {
+		return m.GetDocumentFn(ctx, creds, orderItemIDs, documentType)
+	}
+	return nil, domain.ErrDocumentNotReady
+}
+
+func (m *FalabellaClientMock) ProductUpdate(ctx context.Context, creds domain.Credentials, products []domain.ProductUpdate) (string, error) {
+	if m.ProductUpdateFn != nil {
+		return m.ProductUpdateFn(ctx, creds, products)
+	}
+	return "feed-test", nil
+}
+
+func (m *FalabellaClientMock) FeedStatus(ctx context.Context, creds domain.Credentials, feedID string) (*domain.FeedStatus, error) {
+	if m.FeedStatusFn != nil {
+		return m.FeedStatusFn(ctx, creds, feedID)
+	}
+	return &domain.FeedStatus{FeedID: feedID, Status: domain.FeedStatusFinished}, nil
+}
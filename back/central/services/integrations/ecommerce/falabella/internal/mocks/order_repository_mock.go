@@ -0,0 +1,50 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/falabella/internal/domain"
+)
+
+// OrderRepositoryMock mock de domain.IOrderRepository para tests unitarios.
+type OrderRepositoryMock struct {
+	GetOrderRefFn func(ctx context.Context, orderID string) (*domain.OrderRef, error)
+	SaveGuideFn   func(ctx context.Context, ref *domain.OrderRef, guide domain.GuideData) error
+	// Saved almacena las guías guardadas durante el test.
+	Saved []domain.GuideData
+}
+
+// Verificar en tiempo de compilación que implementa la interfaz.
+var _ domain.IOrderRepository = (*OrderRepositoryMock)(nil)
+
+func (m *OrderRepositoryMock) GetOrderRef(ctx context.Context, orderID string) (*domain.OrderRef, error) {
+	if m.GetOrderRefFn != nil {
+		return m.GetOrderRefFn(ctx, orderID)
+	}
+	return nil, nil
+}
+
+func (m *OrderRepositoryMock) SaveGuide(ctx context.Context, ref *domain.OrderRef, guide domain.GuideData) error {
+	if m.SaveGuideFn != nil {
+		return m.SaveGuideFn(ctx, ref, guide)
+	}
+	m.Saved = append(m.Saved, guide)
+	return nil
+}
+
+// LabelStorageMock mock de domain.ILabelStorage para tests unitarios.
+type LabelStorageMock struct {
+	UploadLabelFn func(ctx context.Context, key string, content []byte) (string, error)
+	// Keys almacena las llaves subidas durante el test.
+	Keys []string
+}
+
+var _ domain.ILabelStorage = (*LabelStorageMock)(nil)
+
+func (m *LabelStorageMock) UploadLabel(ctx context.Context, key string, content []byte) (string, error) {
+	if m.UploadLabelFn != nil {
+		return m.UploadLabelFn(ctx, key, content)
+	}
+	m.Keys = append(m.Keys, key)
+	return "https://cdn.test/" + key, nil
+}
@@ -7,6 +7,7 @@ import (
 	integrationcore "github.com/secamc93/probability/back/central/services/integrations/core"
 	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/app/usecases"
 	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/infra/primary/handlers"
+	magentoqueue "github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/infra/primary/queue"
 	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/infra/secondary/client"
 	magentocore "github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/infra/secondary/core"
 	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/infra/secondary/queue"
@@ -40,12 +41,18 @@ func New(
 	}
 
 	// 2. Casos de uso
-	uc := usecases.New(httpClient, integrationService, orderPublisher, logger)
+	uc := usecases.New(httpClient, integrationService, orderPublisher, rabbitMQ, logger)
 
 	// 3. Handlers HTTP
 	handler := handlers.New(uc, logger)
 	handler.RegisterRoutes(router, logger)
 
-	// 4. Retornar provider para que el bundle padre lo registre en el core
+	// 4. Consumer del push de stock MSI desde el modulo de inventario
+	if rabbitMQ != nil {
+		pushConsumer := magentoqueue.NewInventoryPushConsumer(rabbitMQ, uc, logger)
+		pushConsumer.Start(context.Background())
+	}
+
+	// 5. Retornar provider para que el bundle padre lo registre en el core
 	return magentocore.New(uc)
 }
@@ -5,18 +5,35 @@ import (
 
 	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
 	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/rabbitmq"
 )
 
 // IMagentoUseCase define las operaciones de negocio de Magento.
 type IMagentoUseCase interface {
 	// TestConnection verifica que las credenciales de una integración sean válidas.
 	TestConnection(ctx context.Context, config map[string]interface{}, credentials map[string]interface{}) error
+
+	// SyncOrders importa las órdenes de los últimos 30 días.
+	SyncOrders(ctx context.Context, integrationID string) error
+
+	// SyncOrdersWithParams importa órdenes con filtros de fecha y estado.
+	SyncOrdersWithParams(ctx context.Context, integrationID string, params interface{}) error
+
+	// AuthenticateWebhook valida la firma HMAC del webhook con el secret de la integración.
+	AuthenticateWebhook(ctx context.Context, integrationID, signature string, rawBody []byte) error
+
+	// ProcessWebhook relee la orden notificada y la publica a la cola canónica.
+	ProcessWebhook(ctx context.Context, event *domain.WebhookEvent) error
+
+	// UpdateInventory empuja el stock de un SKU a la fuente MSI configurada.
+	UpdateInventory(ctx context.Context, integrationID string, productExternalID string, quantity int) error
 }
 
 type magentoUseCase struct {
 	client    domain.IMagentoClient
 	service   domain.IIntegrationService
 	publisher domain.OrderPublisher
+	rabbit    rabbitmq.IQueue
 	logger    log.ILogger
 }
 
@@ -25,12 +42,14 @@ func New(
 	client domain.IMagentoClient,
 	service domain.IIntegrationService,
 	publisher domain.OrderPublisher,
+	rabbit rabbitmq.IQueue,
 	logger log.ILogger,
 ) IMagentoUseCase {
 	return &magentoUseCase{
 		client:    client,
 		service:   service,
 		publisher: publisher,
+		rabbit:    rabbit,
 		logger:    logger.WithModule("magento"),
 	}
 }
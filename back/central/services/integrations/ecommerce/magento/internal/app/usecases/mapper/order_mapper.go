@@ -0,0 +1,270 @@
+package mapper
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/canonical"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
+)
+
+// MapMagentoOrderToProbability convierte una orden de Magento al DTO canónico de Probability.
+func MapMagentoOrderToProbability(order *domain.MagentoOrder, rawJSON []byte) *canonical.ProbabilityOrderDTO {
+	now := time.Now()
+	discount := math.Abs(order.DiscountAmount)
+	freeShipping := order.ShippingAmount <= 0 && order.ShippingMethod != ""
+
+	billing := order.BillingAddress
+	if billing == nil {
+		billing = &domain.MagentoAddress{}
+	}
+	shipping := order.ShippingAddress
+	if shipping == nil || (len(shipping.Street) == 0 && strings.TrimSpace(shipping.City) == "") {
+		shipping = billing
+	}
+
+	customerName := strings.TrimSpace(order.CustomerFirst + " " + order.CustomerLast)
+	if customerName == "" {
+		customerName = strings.TrimSpace(billing.FirstName + " " + billing.LastName)
+	}
+	customerEmail := order.CustomerEmail
+	if customerEmail == "" {
+		customerEmail = billing.Email
+	}
+
+	var notes *string
+	if order.CustomerNote != "" {
+		notes = &order.CustomerNote
+	}
+
+	var coupon *string
+	if order.CouponCode != "" {
+		coupon = &order.CouponCode
+	}
+
+	dto := &canonical.ProbabilityOrderDTO{
+		IntegrationType: "magento",
+		Platform:        "magento",
+		ExternalID:      fmt.Sprintf("%d", order.EntityID),
+		OrderNumber:     order.IncrementID,
+		Subtotal:        order.Subtotal,
+		Tax:             order.TaxAmount,
+		Discount:        discount,
+		ShippingCost:    order.ShippingAmount,
+		FreeShipping:    freeShipping,
+		TotalAmount:     order.GrandTotal,
+		Currency:        order.Currency,
+		CustomerName:    customerName,
+		CustomerEmail:   customerEmail,
+		CustomerPhone:   billing.Telephone,
+		Status:          MapMagentoStatus(order.State),
+		OriginalStatus:  order.Status,
+		Notes:           notes,
+		Coupon:          coupon,
+		OccurredAt:      order.CreatedAt,
+		ImportedAt:      now,
+	}
+
+	// Order items: los hijos de un configurable/bundle repiten el producto del padre
+	dto.OrderItems = make([]canonical.ProbabilityOrderItemDTO, 0, len(order.Items))
+	for _, item := range order.Items {
+		if item.ParentItemID != 0 {
+			continue
+		}
+
+		// MSI identifica los productos por SKU; es el external_product_id de Magento
+		productID := item.SKU
+		if productID == "" {
+			productID = fmt.Sprintf("%d", item.ProductID)
+		}
+
+		dto.OrderItems = append(dto.OrderItems, canonical.ProbabilityOrderItemDTO{
+			ProductID:    &productID,
+			ProductSKU:   item.SKU,
+			ProductName:  item.Name,
+			ProductTitle: item.Name,
+			Quantity:     int(math.Round(item.QtyOrdered)),
+			UnitPrice:    item.Price,
+			TotalPrice:   item.RowTotal - math.Abs(item.DiscountAmount),
+			Currency:     order.Currency,
+			Tax:          item.TaxAmount,
+		})
+	}
+
+	// Addresses
+	dto.Addresses = []canonical.ProbabilityAddressDTO{
+		mapAddress("billing", billing),
+		mapAddress("shipping", shipping),
+	}
+
+	// Payment
+	if order.Payment.Method != "" {
+		paymentStatus := "pending"
+		var paidAt *time.Time
+		if isPaid(order) {
+			paymentStatus = "completed"
+			paidAt = &order.UpdatedAt
+		}
+
+		gateway := order.Payment.Method
+		paymentMethodID := mapMagentoPaymentMethod(order.Payment.Method)
+		payment := canonical.ProbabilityPaymentDTO{
+			PaymentMethodID: paymentMethodID,
+			Amount:          order.GrandTotal,
+			Currency:        order.Currency,
+			Status:          paymentStatus,
+			PaidAt:          paidAt,
+			Gateway:         &gateway,
+		}
+		if order.Payment.LastTransID != "" {
+			payment.TransactionID = &order.Payment.LastTransID
+		}
+		dto.Payments = append(dto.Payments, payment)
+
+		if paymentMethodID == paymentMethodCOD {
+			codTotal := order.GrandTotal
+			dto.CodTotal = &codTotal
+		}
+	}
+
+	// Shipment from shipping method
+	if order.ShippingMethod != "" {
+		carrier := order.ShippingTitle
+		if carrier == "" {
+			carrier = order.ShippingMethod
+		}
+		carrierCode := order.ShippingMethod
+		shCost := order.ShippingAmount
+
+		dto.Shipments = append(dto.Shipments, canonical.ProbabilityShipmentDTO{
+			Carrier:      &carrier,
+			CarrierCode:  &carrierCode,
+			Status:       mapShipmentStatus(order.State),
+			ShippingCost: &shCost,
+		})
+
+		details := map[string]interface{}{
+			"shipping_lines": []map[string]interface{}{{
+				"title":  carrier,
+				"price":  fmt.Sprintf("%.2f", order.ShippingAmount),
+				"source": order.ShippingMethod,
+				"code":   "",
+			}},
+		}
+		if sd, err := json.Marshal(details); err == nil {
+			dto.ShippingDetails = sd
+		}
+	}
+
+	// Channel metadata with raw data
+	if rawJSON != nil {
+		dto.ChannelMetadata = &canonical.ProbabilityChannelMetadataDTO{
+			ChannelSource: "magento",
+			RawData:       rawJSON,
+			Version:       "V1",
+			ReceivedAt:    now,
+			IsLatest:      true,
+			SyncStatus:    "synced",
+		}
+	}
+
+	dto.Invoiceable = strings.EqualFold(order.Currency, "COP")
+
+	return dto
+}
+
+func mapAddress(kind string, a *domain.MagentoAddress) canonical.ProbabilityAddressDTO {
+	street, street2 := "", ""
+	if len(a.Street) > 0 {
+		street = a.Street[0]
+		street2 = strings.Join(a.Street[1:], ", ")
+	}
+	return canonical.ProbabilityAddressDTO{
+		Type:       kind,
+		FirstName:  a.FirstName,
+		LastName:   a.LastName,
+		Company:    a.Company,
+		Phone:      a.Telephone,
+		Street:     street,
+		Street2:    street2,
+		City:       a.City,
+		State:      a.Region,
+		Country:    a.CountryID,
+		PostalCode: a.Postcode,
+	}
+}
+
+// isPaid: Magento no expone fecha de pago; la orden esta pagada cuando el
+// total pagado cubre el total o ya fue facturada (processing/complete).
+func isPaid(order *domain.MagentoOrder) bool {
+	if order.GrandTotal > 0 && order.TotalPaid >= order.GrandTotal {
+		return true
+	}
+	return order.State == "processing" || order.State == "complete"
+}
+
+// IDs del catalogo seed payment_methods (migration/shared).
+const (
+	paymentMethodCreditCard   uint = 1
+	paymentMethodPaypal       uint = 3
+	paymentMethodBankTransfer uint = 4
+	paymentMethodCash         uint = 5
+	paymentMethodCOD          uint = 6
+	paymentMethodMercadoPago  uint = 7
+	paymentMethodStripe       uint = 8
+)
+
+// mapMagentoPaymentMethod mapea el código del método de pago de Magento al catalogo payment_methods.
+func mapMagentoPaymentMethod(method string) uint {
+	m := strings.ToLower(method)
+	switch {
+	case m == "cashondelivery" || strings.Contains(m, "contra"):
+		return paymentMethodCOD
+	case m == "banktransfer" || strings.Contains(m, "transfer"):
+		return paymentMethodBankTransfer
+	case m == "checkmo" || strings.Contains(m, "cash"):
+		return paymentMethodCash
+	case strings.Contains(m, "paypal"):
+		return paymentMethodPaypal
+	case strings.Contains(m, "stripe"):
+		return paymentMethodStripe
+	case strings.Contains(m, "mercadopago"):
+		return paymentMethodMercadoPago
+	default:
+		return paymentMethodCreditCard
+	}
+}
+
+// MapMagentoStatus mapea el state de la orden (no el status, que es
+// configurable por tienda) al estado canónico de Probability.
+func MapMagentoStatus(state string) string {
+	switch state {
+	case "new", "pending_payment", "processing":
+		return "pending"
+	case "holded", "payment_review":
+		return "on_hold"
+	case "complete":
+		return "completed"
+	case "closed":
+		return "refunded"
+	case "canceled":
+		return "cancelled"
+	default:
+		return "pending"
+	}
+}
+
+// mapShipmentStatus mapea el state de la orden a un estado de envío.
+func mapShipmentStatus(state string) string {
+	switch state {
+	case "complete":
+		return "shipped"
+	case "canceled", "closed":
+		return "cancelled"
+	default:
+		return "pending"
+	}
+}
@@ -0,0 +1,134 @@
+package mapper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
+)
+
+func sampleOrder() *domain.MagentoOrder {
+	created := time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC)
+	return &domain.MagentoOrder{
+		EntityID:       501,
+		IncrementID:    "000000501",
+		State:          "processing",
+		Status:         "processing",
+		CreatedAt:      created,
+		UpdatedAt:      created.Add(time.Hour),
+		Currency:       "COP",
+		GrandTotal:     215000,
+		Subtotal:       200000,
+		TaxAmount:      0,
+		DiscountAmount: -10000,
+		ShippingAmount: 25000,
+		TotalPaid:      215000,
+		CouponCode:     "BIENVENIDA",
+		CustomerEmail:  "ana@example.com",
+		CustomerFirst:  "Ana",
+		CustomerLast:   "Rojas",
+		ShippingMethod: "flatrate_flatrate",
+		ShippingTitle:  "Flat Rate - Fixed",
+		BillingAddress: &domain.MagentoAddress{
+			FirstName: "Ana",
+			LastName:  "Rojas",
+			Street:    []string{"Calle 10 #20-30", "Apto 402"},
+			City:      "Bogota",
+			Region:    "Cundinamarca",
+			CountryID: "CO",
+			Telephone: "+573001112233",
+		},
+		Payment: domain.MagentoPayment{Method: "checkmo", LastTransID: "tx-77"},
+		Items: []domain.MagentoOrderItem{
+			{ItemID: 1, ProductID: 10, ProductType: "configurable", SKU: "CAMISA-M", Name: "Camisa", QtyOrdered: 2, Price: 100000, RowTotal: 200000, DiscountAmount: 10000},
+			{ItemID: 2, ParentItemID: 1, ProductID: 11, ProductType: "simple", SKU: "CAMISA-M", Name: "Camisa M", QtyOrdered: 2},
+		},
+	}
+}
+
+func TestMapMagentoOrderToProbability_FullOrder(t *testing.T) {
+	dto := MapMagentoOrderToProbability(sampleOrder(), []byte(`{"entity_id":501}`))
+
+	if dto.ExternalID != "501" || dto.OrderNumber != "000000501" {
+		t.Errorf("ids inesperados: %s / %s", dto.ExternalID, dto.OrderNumber)
+	}
+	if dto.IntegrationType != "magento" {
+		t.Errorf("IntegrationType esperado 'magento', recibí '%s'", dto.IntegrationType)
+	}
+	if dto.Discount != 10000 {
+		t.Errorf("Discount esperado 10000 (positivo), recibí %.2f", dto.Discount)
+	}
+	if dto.Status != "pending" {
+		t.Errorf("Status esperado 'pending', recibí '%s'", dto.Status)
+	}
+	if dto.CustomerName != "Ana Rojas" {
+		t.Errorf("CustomerName esperado 'Ana Rojas', recibí '%s'", dto.CustomerName)
+	}
+	if len(dto.OrderItems) != 1 {
+		t.Fatalf("esperaba 1 item (sin el hijo del configurable), recibí %d", len(dto.OrderItems))
+	}
+	item := dto.OrderItems[0]
+	if item.ProductID == nil || *item.ProductID != "CAMISA-M" {
+		t.Errorf("ProductID esperado el SKU 'CAMISA-M'")
+	}
+	if item.TotalPrice != 190000 {
+		t.Errorf("TotalPrice esperado 190000, recibí %.2f", item.TotalPrice)
+	}
+	if len(dto.Addresses) != 2 || dto.Addresses[1].City != "Bogota" {
+		t.Errorf("la dirección de envío debe caer a la de facturación")
+	}
+	if len(dto.Payments) != 1 || dto.Payments[0].Status != "completed" || dto.Payments[0].PaidAt == nil {
+		t.Errorf("el pago debe quedar completado")
+	}
+	if dto.Payments[0].TransactionID == nil || *dto.Payments[0].TransactionID != "tx-77" {
+		t.Errorf("TransactionID esperado 'tx-77'")
+	}
+	if len(dto.Shipments) != 1 || *dto.Shipments[0].Carrier != "Flat Rate - Fixed" {
+		t.Errorf("shipment esperado desde el método de envío")
+	}
+	if dto.ChannelMetadata == nil || dto.ChannelMetadata.ChannelSource != "magento" {
+		t.Errorf("ChannelMetadata esperado con el raw de Magento")
+	}
+	if !dto.Invoiceable {
+		t.Errorf("una orden en COP debe ser facturable")
+	}
+}
+
+func TestMapMagentoOrderToProbability_CashOnDelivery(t *testing.T) {
+	order := sampleOrder()
+	order.State = "new"
+	order.Status = "pending"
+	order.TotalPaid = 0
+	order.Payment = domain.MagentoPayment{Method: "cashondelivery"}
+
+	dto := MapMagentoOrderToProbability(order, nil)
+
+	if dto.CodTotal == nil || *dto.CodTotal != order.GrandTotal {
+		t.Errorf("CodTotal esperado %.2f", order.GrandTotal)
+	}
+	if dto.Payments[0].Status != "pending" {
+		t.Errorf("el pago contraentrega debe quedar pendiente, recibí '%s'", dto.Payments[0].Status)
+	}
+	if dto.ChannelMetadata != nil {
+		t.Errorf("sin raw no debe haber ChannelMetadata")
+	}
+}
+
+func TestMapMagentoStatus(t *testing.T) {
+	cases := map[string]string{
+		"new":             "pending",
+		"pending_payment": "pending",
+		"processing":      "pending",
+		"holded":          "on_hold",
+		"payment_review":  "on_hold",
+		"complete":        "completed",
+		"closed":          "refunded",
+		"canceled":        "cancelled",
+		"desconocido":     "pending",
+	}
+	for state, want := range cases {
+		if got := MapMagentoStatus(state); got != want {
+			t.Errorf("MapMagentoStatus(%q) = %q, esperado %q", state, got, want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/app/usecases/mapper"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
+)
+
+// AuthenticateWebhook valida X-Magento-Signature (HMAC-SHA256 en hex del
+// cuerpo) con la credencial webhook_secret. Sin secret configurado se acepta:
+// el webhook solo dispara la relectura de la orden por REST, nunca se confía
+// en su contenido.
+func (uc *magentoUseCase) AuthenticateWebhook(ctx context.Context, integrationID, signature string, rawBody []byte) error {
+	if integrationID == "" {
+		return domain.ErrIntegrationNotFound
+	}
+
+	secret, err := uc.service.DecryptCredential(ctx, integrationID, "webhook_secret")
+	if err != nil || secret == "" {
+		uc.logger.Debug(ctx).
+			Str("integration_id", integrationID).
+			Msg("Magento webhook sin webhook_secret configurado, se omite la firma")
+		return nil
+	}
+
+	if !verifyWebhookHMAC(rawBody, signature, secret) {
+		return domain.ErrWebhookInvalidSignature
+	}
+	return nil
+}
+
+// ProcessWebhook procesa la notificación del observer/webhook de Magento:
+// lee la orden por entity_id y la publica a la cola canónica.
+func (uc *magentoUseCase) ProcessWebhook(ctx context.Context, event *domain.WebhookEvent) error {
+	if event.OrderID == 0 {
+		return domain.ErrWebhookMissingOrder
+	}
+
+	integration, storeURL, accessToken, err := uc.connection(ctx, event.IntegrationID)
+	if err != nil {
+		return err
+	}
+
+	order, rawJSON, err := uc.client.GetOrder(ctx, storeURL, accessToken, event.OrderID)
+	if err != nil {
+		uc.logger.Error(ctx).Err(err).
+			Int64("order_id", event.OrderID).
+			Str("event", event.Event).
+			Msg("Failed to fetch Magento order detail")
+		return fmt.Errorf("fetching order: %w", err)
+	}
+
+	dto := mapper.MapMagentoOrderToProbability(order, rawJSON)
+	dto.IntegrationID = integration.ID
+	dto.BusinessID = integration.BusinessID
+
+	if err := uc.publisher.Publish(ctx, dto); err != nil {
+		uc.logger.Error(ctx).Err(err).
+			Str("increment_id", order.IncrementID).
+			Str("event", event.Event).
+			Msg("Failed to publish Magento webhook order")
+		return fmt.Errorf("publishing webhook order: %w", err)
+	}
+
+	uc.logger.Info(ctx).
+		Str("increment_id", order.IncrementID).
+		Str("state", order.State).
+		Str("event", event.Event).
+		Uint("integration_id", integration.ID).
+		Msg("Magento order published successfully via webhook")
+
+	return nil
+}
+
+func verifyWebhookHMAC(body []byte, signature, secret string) bool {
+	signature = strings.TrimPrefix(strings.TrimSpace(signature), "sha256=")
+	if signature == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(strings.ToLower(signature)))
+}
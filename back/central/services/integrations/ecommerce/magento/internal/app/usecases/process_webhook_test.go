@@ -0,0 +1,101 @@
+package usecases
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/mocks"
+)
+
+func sign(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func serviceWithSecret(secret string) *mocks.IntegrationServiceMock {
+	return &mocks.IntegrationServiceMock{
+		DecryptCredentialFn: func(ctx context.Context, integrationID, fieldName string) (string, error) {
+			switch fieldName {
+			case "access_token":
+				return "token", nil
+			case "webhook_secret":
+				return secret, nil
+			}
+			return "", nil
+		},
+	}
+}
+
+func TestAuthenticateWebhook_ValidSignature(t *testing.T) {
+	body := []byte(`{"event":"sales_order_save_after","order_id":501}`)
+	uc := New(&mocks.MagentoClientMock{}, serviceWithSecret("s3cr3t"), &mocks.OrderPublisherMock{}, nil, mocks.NewLoggerMock())
+
+	if err := uc.AuthenticateWebhook(context.Background(), "1", sign(body, "s3cr3t"), body); err != nil {
+		t.Fatalf("esperaba firma válida, recibí: %v", err)
+	}
+}
+
+func TestAuthenticateWebhook_InvalidSignature(t *testing.T) {
+	body := []byte(`{"order_id":501}`)
+	uc := New(&mocks.MagentoClientMock{}, serviceWithSecret("s3cr3t"), &mocks.OrderPublisherMock{}, nil, mocks.NewLoggerMock())
+
+	err := uc.AuthenticateWebhook(context.Background(), "1", sign(body, "otro"), body)
+	if !errors.Is(err, domain.ErrWebhookInvalidSignature) {
+		t.Fatalf("esperaba ErrWebhookInvalidSignature, recibí: %v", err)
+	}
+
+	err = uc.AuthenticateWebhook(context.Background(), "1", "", body)
+	if !errors.Is(err, domain.ErrWebhookInvalidSignature) {
+		t.Fatalf("sin firma y con secret configurado debe rechazar, recibí: %v", err)
+	}
+}
+
+func TestAuthenticateWebhook_NoSecretConfigured(t *testing.T) {
+	uc := New(&mocks.MagentoClientMock{}, &mocks.IntegrationServiceMock{}, &mocks.OrderPublisherMock{}, nil, mocks.NewLoggerMock())
+
+	if err := uc.AuthenticateWebhook(context.Background(), "1", "", []byte(`{}`)); err != nil {
+		t.Fatalf("sin webhook_secret se acepta el webhook, recibí: %v", err)
+	}
+}
+
+func TestProcessWebhook_FetchesAndPublishesOrder(t *testing.T) {
+	publisher := &mocks.OrderPublisherMock{}
+	var requestedID int64
+	client := &mocks.MagentoClientMock{
+		GetOrderFn: func(ctx context.Context, storeURL, accessToken string, orderID int64) (*domain.MagentoOrder, []byte, error) {
+			requestedID = orderID
+			return &domain.MagentoOrder{EntityID: orderID, IncrementID: "000000501", State: "complete", Currency: "COP"}, []byte(`{}`), nil
+		},
+	}
+	uc := New(client, &mocks.IntegrationServiceMock{}, publisher, nil, mocks.NewLoggerMock())
+
+	err := uc.ProcessWebhook(context.Background(), &domain.WebhookEvent{IntegrationID: "1", Event: "sales_order_save_after", OrderID: 501})
+	if err != nil {
+		t.Fatalf("esperaba sin error, recibí: %v", err)
+	}
+	if requestedID != 501 {
+		t.Errorf("esperaba releer la orden 501, recibí %d", requestedID)
+	}
+	if len(publisher.Published) != 1 {
+		t.Fatalf("esperaba 1 orden publicada, recibí %d", len(publisher.Published))
+	}
+	dto := publisher.Published[0]
+	if dto.IntegrationID != 1 || dto.Status != "completed" {
+		t.Errorf("orden publicada inesperada: integration=%d status=%s", dto.IntegrationID, dto.Status)
+	}
+}
+
+func TestProcessWebhook_MissingOrderID(t *testing.T) {
+	uc := New(&mocks.MagentoClientMock{}, &mocks.IntegrationServiceMock{}, &mocks.OrderPublisherMock{}, nil, mocks.NewLoggerMock())
+
+	err := uc.ProcessWebhook(context.Background(), &domain.WebhookEvent{IntegrationID: "1"})
+	if !errors.Is(err, domain.ErrWebhookMissingOrder) {
+		t.Fatalf("esperaba ErrWebhookMissingOrder, recibí: %v", err)
+	}
+}
@@ -0,0 +1,170 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/app/usecases/mapper"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/rabbitmq"
+)
+
+const ordersPageSize = 100
+
+func (uc *magentoUseCase) emitOrderSyncEvent(ctx context.Context, integration *domain.Integration, eventType string, data map[string]interface{}) {
+	if uc.rabbit == nil {
+		return
+	}
+	var bID uint
+	if integration.BusinessID != nil {
+		bID = *integration.BusinessID
+	}
+	_ = rabbitmq.PublishEvent(ctx, uc.rabbit, rabbitmq.EventEnvelope{
+		Type:          eventType,
+		Category:      "integration",
+		BusinessID:    bID,
+		IntegrationID: integration.ID,
+		Data:          data,
+	})
+}
+
+// SyncOrders sincroniza órdenes de Magento de los últimos 30 días.
+func (uc *magentoUseCase) SyncOrders(ctx context.Context, integrationID string) error {
+	after := time.Now().AddDate(0, 0, -30)
+	params := map[string]interface{}{
+		"created_at_min": after.Format(time.RFC3339),
+	}
+	return uc.SyncOrdersWithParams(ctx, integrationID, params)
+}
+
+// SyncOrdersWithParams sincroniza órdenes con parámetros personalizados.
+// Soporta: created_at_min, created_at_max, updated_at_min (RFC3339) y status.
+func (uc *magentoUseCase) SyncOrdersWithParams(ctx context.Context, integrationID string, params interface{}) error {
+	integration, storeURL, accessToken, err := uc.connection(ctx, integrationID)
+	if err != nil {
+		return err
+	}
+
+	queryParams := buildQueryParams(params)
+
+	uc.logger.Info(ctx).
+		Str("integration_id", integrationID).
+		Str("store_url", storeURL).
+		Msg("Starting Magento order sync")
+
+	go uc.syncOrdersAsync(context.Background(), integration, storeURL, accessToken, queryParams)
+
+	return nil
+}
+
+func (uc *magentoUseCase) syncOrdersAsync(ctx context.Context, integration *domain.Integration, storeURL, accessToken string, params *domain.GetOrdersParams) {
+	start := time.Now()
+	uc.emitOrderSyncEvent(ctx, integration, "integration.sync.started", map[string]interface{}{})
+
+	totalSynced, err := uc.importOrders(ctx, integration, storeURL, accessToken, params)
+	if err != nil {
+		uc.emitOrderSyncEvent(ctx, integration, "integration.sync.failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+
+	uc.logger.Info(ctx).
+		Int("total_synced", totalSynced).
+		Uint("integration_id", integration.ID).
+		Msg("Magento order sync completed")
+
+	uc.emitOrderSyncEvent(ctx, integration, "integration.sync.completed", map[string]interface{}{
+		"total_fetched": totalSynced,
+		"duration":      time.Since(start).Round(time.Millisecond).String(),
+	})
+}
+
+// importOrders recorre las páginas de searchCriteria y publica cada orden.
+// Magento repite la última página si currentPage se pasa del total, por eso
+// el corte se hace con total_count.
+func (uc *magentoUseCase) importOrders(ctx context.Context, integration *domain.Integration, storeURL, accessToken string, params *domain.GetOrdersParams) (int, error) {
+	if params.PageSize == 0 {
+		params.PageSize = ordersPageSize
+	}
+
+	totalSynced := 0
+	for page := 1; ; page++ {
+		params.Page = page
+
+		result, rawOrders, err := uc.client.GetOrders(ctx, storeURL, accessToken, params)
+		if err != nil {
+			uc.logger.Error(ctx).Err(err).
+				Int("page", page).
+				Msg("Error fetching Magento orders page")
+			return totalSynced, err
+		}
+
+		if len(result.Orders) == 0 {
+			break
+		}
+
+		for i, order := range result.Orders {
+			var rawJSON []byte
+			if i < len(rawOrders) {
+				rawJSON = rawOrders[i]
+			}
+
+			dto := mapper.MapMagentoOrderToProbability(&order, rawJSON)
+			dto.IntegrationID = integration.ID
+			dto.BusinessID = integration.BusinessID
+
+			if err := uc.publisher.Publish(ctx, dto); err != nil {
+				uc.logger.Error(ctx).Err(err).
+					Str("increment_id", order.IncrementID).
+					Msg("Error publishing Magento order")
+				continue
+			}
+			totalSynced++
+		}
+
+		totalPages := (result.TotalCount + params.PageSize - 1) / params.PageSize
+		uc.logger.Info(ctx).
+			Int("page", page).
+			Int("orders_in_page", len(result.Orders)).
+			Int("total_pages", totalPages).
+			Msg("Magento orders page synced")
+
+		if page >= totalPages {
+			break
+		}
+	}
+
+	return totalSynced, nil
+}
+
+// buildQueryParams construye los filtros de consulta a partir de un mapa genérico.
+func buildQueryParams(params interface{}) *domain.GetOrdersParams {
+	qp := &domain.GetOrdersParams{}
+
+	m, ok := params.(map[string]interface{})
+	if !ok {
+		return qp
+	}
+
+	parse := func(key string) *time.Time {
+		v, ok := m[key].(string)
+		if !ok || v == "" {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil
+		}
+		return &t
+	}
+
+	qp.CreatedFrom = parse("created_at_min")
+	qp.CreatedTo = parse("created_at_max")
+	qp.UpdatedFrom = parse("updated_at_min")
+	if v, ok := m["status"].(string); ok && v != "" {
+		qp.Status = v
+	}
+
+	return qp
+}
@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/mocks"
+)
+
+func TestImportOrders_PaginatesUntilTotalCount(t *testing.T) {
+	publisher := &mocks.OrderPublisherMock{}
+	var pages []int
+	client := &mocks.MagentoClientMock{
+		GetOrdersFn: func(ctx context.Context, storeURL, accessToken string, params *domain.GetOrdersParams) (*domain.GetOrdersResult, [][]byte, error) {
+			pages = append(pages, params.Page)
+			// Magento repite la última página si currentPage se pasa del total
+			n := 2
+			if params.Page >= 2 {
+				n = 1
+			}
+			orders := make([]domain.MagentoOrder, n)
+			for i := range orders {
+				orders[i] = domain.MagentoOrder{EntityID: int64(params.Page*10 + i), State: "new"}
+			}
+			return &domain.GetOrdersResult{Orders: orders, TotalCount: 3}, nil, nil
+		},
+	}
+	uc := New(client, &mocks.IntegrationServiceMock{}, publisher, nil, mocks.NewLoggerMock()).(*magentoUseCase)
+
+	total, err := uc.importOrders(context.Background(), &domain.Integration{ID: 1}, "https://test-store.com", "token", &domain.GetOrdersParams{PageSize: 2})
+	if err != nil {
+		t.Fatalf("esperaba sin error, recibí: %v", err)
+	}
+	if total != 3 || len(publisher.Published) != 3 {
+		t.Errorf("esperaba 3 órdenes publicadas, recibí %d", len(publisher.Published))
+	}
+	if len(pages) != 2 {
+		t.Errorf("esperaba 2 páginas consultadas, recibí %v", pages)
+	}
+}
+
+func TestImportOrders_ClientError(t *testing.T) {
+	client := &mocks.MagentoClientMock{
+		GetOrdersFn: func(ctx context.Context, storeURL, accessToken string, params *domain.GetOrdersParams) (*domain.GetOrdersResult, [][]byte, error) {
+			return nil, nil, domain.ErrInvalidCredentials
+		},
+	}
+	uc := New(client, &mocks.IntegrationServiceMock{}, &mocks.OrderPublisherMock{}, nil, mocks.NewLoggerMock()).(*magentoUseCase)
+
+	_, err := uc.importOrders(context.Background(), &domain.Integration{ID: 1}, "https://test-store.com", "token", &domain.GetOrdersParams{})
+	if !errors.Is(err, domain.ErrInvalidCredentials) {
+		t.Fatalf("esperaba ErrInvalidCredentials, recibí: %v", err)
+	}
+}
+
+func TestBuildQueryParams(t *testing.T) {
+	qp := buildQueryParams(map[string]interface{}{
+		"created_at_min": "2026-03-01T00:00:00Z",
+		"created_at_max": "2026-03-31T23:59:59Z",
+		"status":         "processing",
+		"updated_at_min": "no-es-fecha",
+	})
+
+	if qp.CreatedFrom == nil || !qp.CreatedFrom.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("CreatedFrom inesperado: %v", qp.CreatedFrom)
+	}
+	if qp.CreatedTo == nil {
+		t.Errorf("CreatedTo esperado")
+	}
+	if qp.UpdatedFrom != nil {
+		t.Errorf("una fecha inválida debe ignorarse")
+	}
+	if qp.Status != "processing" {
+		t.Errorf("Status esperado 'processing', recibí '%s'", qp.Status)
+	}
+}
+
+func TestSyncOrders_MissingStoreURL(t *testing.T) {
+	service := &mocks.IntegrationServiceMock{
+		GetIntegrationByIDFn: func(ctx context.Context, integrationID string) (*domain.Integration, error) {
+			return &domain.Integration{ID: 1, Config: map[string]interface{}{}}, nil
+		},
+	}
+	uc := New(&mocks.MagentoClientMock{}, service, &mocks.OrderPublisherMock{}, nil, mocks.NewLoggerMock())
+
+	if err := uc.SyncOrders(context.Background(), "1"); !errors.Is(err, domain.ErrMissingStoreURL) {
+		t.Fatalf("esperaba ErrMissingStoreURL, recibí: %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
+)
+
+func resolveEffectiveStoreURL(integration *domain.Integration, storeURL string) string {
+	if integration != nil && integration.IsTesting && integration.BaseURLTest != "" {
+		return integration.BaseURLTest
+	}
+	return storeURL
+}
+
+// connection resuelve la integración, la URL efectiva de la tienda y el
+// token de integración.
+func (uc *magentoUseCase) connection(ctx context.Context, integrationID string) (*domain.Integration, string, string, error) {
+	integration, err := uc.service.GetIntegrationByID(ctx, integrationID)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("getting integration: %w", err)
+	}
+	if integration == nil {
+		return nil, "", "", domain.ErrIntegrationNotFound
+	}
+
+	storeURL, err := extractString(integration.Config, "store_url")
+	if err != nil {
+		return nil, "", "", domain.ErrMissingStoreURL
+	}
+	storeURL = resolveEffectiveStoreURL(integration, storeURL)
+
+	accessToken, err := uc.service.DecryptCredential(ctx, integrationID, "access_token")
+	if err != nil {
+		return nil, "", "", fmt.Errorf("decrypting access_token: %w", err)
+	}
+	if accessToken == "" {
+		return nil, "", "", domain.ErrMissingAccessToken
+	}
+
+	return integration, storeURL, accessToken, nil
+}
@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
+)
+
+const defaultSourceCode = "default"
+
+// UpdateInventory actualiza el stock del SKU en la fuente MSI configurada
+// (config source_code, "default" si no se indica). productExternalID es el SKU.
+func (uc *magentoUseCase) UpdateInventory(ctx context.Context, integrationID string, productExternalID string, quantity int) error {
+	integration, storeURL, accessToken, err := uc.connection(ctx, integrationID)
+	if err != nil {
+		return err
+	}
+
+	if enabled, _ := integration.Config["inventory_sync_enabled"].(bool); !enabled {
+		uc.logger.Info(ctx).
+			Str("integration_id", integrationID).
+			Msg("Sync de inventario desactivado para la integracion Magento, push omitido")
+		return nil
+	}
+
+	sourceCode, err := extractString(integration.Config, "source_code")
+	if err != nil {
+		sourceCode = defaultSourceCode
+	}
+	if quantity < 0 {
+		quantity = 0
+	}
+
+	item := domain.SourceItem{
+		SKU:        productExternalID,
+		SourceCode: sourceCode,
+		Quantity:   quantity,
+		InStock:    quantity > 0,
+	}
+	if err := uc.client.SaveSourceItems(ctx, storeURL, accessToken, []domain.SourceItem{item}); err != nil {
+		uc.logger.Error(ctx).
+			Err(err).
+			Str("integration_id", integrationID).
+			Str("sku", productExternalID).
+			Str("source_code", sourceCode).
+			Int("quantity", quantity).
+			Msg("Error al actualizar stock en Magento")
+		return err
+	}
+
+	uc.logger.Info(ctx).
+		Str("integration_id", integrationID).
+		Str("sku", productExternalID).
+		Str("source_code", sourceCode).
+		Int("quantity", quantity).
+		Msg("Stock actualizado en Magento")
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/mocks"
+)
+
+func serviceWithConfig(config map[string]interface{}) *mocks.IntegrationServiceMock {
+	return &mocks.IntegrationServiceMock{
+		GetIntegrationByIDFn: func(ctx context.Context, integrationID string) (*domain.Integration, error) {
+			return &domain.Integration{ID: 1, Config: config}, nil
+		},
+	}
+}
+
+func TestUpdateInventory_PushesSourceItem(t *testing.T) {
+	var saved []domain.SourceItem
+	client := &mocks.MagentoClientMock{
+		SaveSourceItemsFn: func(ctx context.Context, storeURL, accessToken string, items []domain.SourceItem) error {
+			saved = items
+			return nil
+		},
+	}
+	service := serviceWithConfig(map[string]interface{}{
+		"store_url":              "https://test-store.com",
+		"inventory_sync_enabled": true,
+		"source_code":            "bodega_bogota",
+	})
+	uc := New(client, service, &mocks.OrderPublisherMock{}, nil, mocks.NewLoggerMock())
+
+	if err := uc.UpdateInventory(context.Background(), "1", "CAMISA-M", 7); err != nil {
+		t.Fatalf("esperaba sin error, recibí: %v", err)
+	}
+	if len(saved) != 1 {
+		t.Fatalf("esperaba 1 source item, recibí %d", len(saved))
+	}
+	item := saved[0]
+	if item.SKU != "CAMISA-M" || item.SourceCode != "bodega_bogota" || item.Quantity != 7 || !item.InStock {
+		t.Errorf("source item inesperado: %+v", item)
+	}
+}
+
+func TestUpdateInventory_DefaultSourceAndOutOfStock(t *testing.T) {
+	var saved []domain.SourceItem
+	client := &mocks.MagentoClientMock{
+		SaveSourceItemsFn: func(ctx context.Context, storeURL, accessToken string, items []domain.SourceItem) error {
+			saved = items
+			return nil
+		},
+	}
+	service := serviceWithConfig(map[string]interface{}{
+		"store_url":              "https://test-store.com",
+		"inventory_sync_enabled": true,
+	})
+	uc := New(client, service, &mocks.OrderPublisherMock{}, nil, mocks.NewLoggerMock())
+
+	if err := uc.UpdateInventory(context.Background(), "1", "CAMISA-M", -3); err != nil {
+		t.Fatalf("esperaba sin error, recibí: %v", err)
+	}
+	if saved[0].SourceCode != "default" || saved[0].Quantity != 0 || saved[0].InStock {
+		t.Errorf("source item inesperado: %+v", saved[0])
+	}
+}
+
+func TestUpdateInventory_SyncDisabled(t *testing.T) {
+	called := false
+	client := &mocks.MagentoClientMock{
+		SaveSourceItemsFn: func(ctx context.Context, storeURL, accessToken string, items []domain.SourceItem) error {
+			called = true
+			return nil
+		},
+	}
+	uc := New(client, &mocks.IntegrationServiceMock{}, &mocks.OrderPublisherMock{}, nil, mocks.NewLoggerMock())
+
+	if err := uc.UpdateInventory(context.Background(), "1", "CAMISA-M", 5); err != nil {
+		t.Fatalf("esperaba sin error, recibí: %v", err)
+	}
+	if called {
+		t.Errorf("con inventory_sync_enabled desactivado no debe empujar stock")
+	}
+}
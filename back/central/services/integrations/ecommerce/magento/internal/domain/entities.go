@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 // Integration representa los datos de una integración de Magento
 // tal como se obtienen del core de integraciones.
 type Integration struct {
@@ -9,4 +11,92 @@ type Integration struct {
 	StoreID         string
 	IntegrationType int
 	Config          map[string]interface{}
+	IsTesting       bool
+	BaseURLTest     string
+}
+
+// MagentoOrder representa una orden de Magento 2 (REST V1 /orders).
+// Estructura de dominio pura — sin tags JSON.
+type MagentoOrder struct {
+	EntityID        int64
+	IncrementID     string
+	State           string
+	Status          string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	Currency        string
+	GrandTotal      float64
+	Subtotal        float64
+	TaxAmount       float64
+	DiscountAmount  float64
+	ShippingAmount  float64
+	TotalPaid       float64
+	CouponCode      string
+	CustomerNote    string
+	CustomerEmail   string
+	CustomerFirst   string
+	CustomerLast    string
+	CustomerIsGuest bool
+	ShippingMethod  string
+	ShippingTitle   string
+	BillingAddress  *MagentoAddress
+	ShippingAddress *MagentoAddress
+	Payment         MagentoPayment
+	Items           []MagentoOrderItem
+}
+
+// MagentoAddress representa una dirección de facturación o envío.
+type MagentoAddress struct {
+	FirstName  string
+	LastName   string
+	Company    string
+	Street     []string
+	City       string
+	Region     string
+	RegionCode string
+	Postcode   string
+	CountryID  string
+	Telephone  string
+	Email      string
+}
+
+// MagentoPayment representa el pago de la orden.
+type MagentoPayment struct {
+	Method      string
+	AmountPaid  float64
+	LastTransID string
+}
+
+// MagentoOrderItem representa un ítem de la orden. Los productos
+// configurables traen el ítem padre (con precio) y el hijo simple con
+// ParentItemID; solo se importa el padre.
+type MagentoOrderItem struct {
+	ItemID         int64
+	ParentItemID   int64
+	ProductID      int64
+	ProductType    string
+	SKU            string
+	Name           string
+	QtyOrdered     float64
+	Price          float64
+	RowTotal       float64
+	TaxAmount      float64
+	DiscountAmount float64
+}
+
+// SourceItem es el stock de un SKU en una fuente MSI.
+type SourceItem struct {
+	SKU        string
+	SourceCode string
+	Quantity   int
+	InStock    bool
+}
+
+// WebhookEvent es la notificación que envía el observer/webhook de Magento.
+// Solo trae la referencia: la orden se vuelve a leer por REST.
+type WebhookEvent struct {
+	IntegrationID string
+	Event         string
+	OrderID       int64
+	IncrementID   string
 }
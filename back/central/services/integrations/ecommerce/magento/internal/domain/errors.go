@@ -3,8 +3,11 @@ package domain
 import "errors"
 
 var (
-	ErrIntegrationNotFound = errors.New("magento: integration not found")
-	ErrInvalidCredentials  = errors.New("magento: invalid credentials")
-	ErrMissingAccessToken  = errors.New("magento: missing access_token in credentials")
-	ErrMissingStoreURL     = errors.New("magento: missing store_url in config")
+	ErrIntegrationNotFound     = errors.New("magento: integration not found")
+	ErrInvalidCredentials      = errors.New("magento: invalid credentials")
+	ErrMissingAccessToken      = errors.New("magento: missing access_token in credentials")
+	ErrMissingStoreURL         = errors.New("magento: missing store_url in config")
+	ErrOrderNotFound           = errors.New("magento: order not found")
+	ErrWebhookInvalidSignature = errors.New("magento: invalid webhook signature")
+	ErrWebhookMissingOrder     = errors.New("magento: webhook without order reference")
 )
@@ -11,6 +11,12 @@ import (
 type IMagentoClient interface {
 	// TestConnection verifica que las credenciales sean válidas
 	TestConnection(ctx context.Context, storeURL, accessToken string) error
+	// GetOrders retorna una página de órdenes y el JSON crudo de cada una
+	GetOrders(ctx context.Context, storeURL, accessToken string, params *GetOrdersParams) (*GetOrdersResult, [][]byte, error)
+	// GetOrder retorna una orden por entity_id
+	GetOrder(ctx context.Context, storeURL, accessToken string, orderID int64) (*MagentoOrder, []byte, error)
+	// SaveSourceItems actualiza el stock de SKUs por fuente (MSI)
+	SaveSourceItems(ctx context.Context, storeURL, accessToken string, items []SourceItem) error
 }
 
 // IIntegrationService define las operaciones del core de integraciones
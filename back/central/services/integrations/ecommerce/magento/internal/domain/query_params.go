@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// GetOrdersParams define los filtros de searchCriteria para consultar órdenes.
+type GetOrdersParams struct {
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	UpdatedFrom *time.Time
+	Status      string
+	Page        int
+	PageSize    int
+}
+
+// GetOrdersResult contiene una página de órdenes. Magento no expone el total
+// de páginas: se calcula con TotalCount y el tamaño de página.
+type GetOrdersResult struct {
+	Orders     []MagentoOrder
+	TotalCount int
+}
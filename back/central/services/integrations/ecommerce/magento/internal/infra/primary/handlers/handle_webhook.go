@@ -1,25 +1,79 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/infra/primary/handlers/request"
 )
 
-// HandleWebhook recibe webhooks de eventos de Magento.
-// Magento puede enviar webhooks via extensiones o integrations API.
+// HandleWebhook recibe notificaciones de órdenes de Magento (observer de
+// sales_order_save_after o extensión de webhooks). El payload solo se usa
+// como referencia: la orden se relee por REST antes de publicarla.
 //
-// Referencia: https://developer.adobe.com/commerce/php/development/components/message-queues/
+// Referencia: https://developer.adobe.com/commerce/extensibility/webhooks/
 func (h *magentoHandler) HandleWebhook(c *gin.Context) {
-	// TODO: implementar procesamiento de webhooks de Magento
-	// 1. Validar la autenticación del webhook (token o HMAC)
-	// 2. Leer el tipo de evento del payload
-	// 3. Deserializar el payload de la orden
-	// 4. Publicar evento a la cola de órdenes
-	h.logger.Info(c.Request.Context()).
-		Str("content_type", c.GetHeader("Content-Type")).
-		Str("user_agent", c.GetHeader("User-Agent")).
+	ctx := c.Request.Context()
+
+	rawBody, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		h.logger.Error(ctx).Err(err).Msg("Failed to read Magento webhook body")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	integrationID := c.Query("integration_id")
+	if integrationID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "integration_id es requerido"})
+		return
+	}
+
+	signature := c.GetHeader("X-Magento-Signature")
+	if err := h.useCase.AuthenticateWebhook(ctx, integrationID, signature, rawBody); err != nil {
+		if errors.Is(err, domain.ErrWebhookInvalidSignature) {
+			h.logger.Warn(ctx).
+				Str("integration_id", integrationID).
+				Msg("Magento webhook invalid HMAC signature")
+			c.Status(http.StatusUnauthorized)
+			return
+		}
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	event, err := request.ParseWebhook(rawBody)
+	if err != nil {
+		h.logger.Warn(ctx).Err(err).
+			Str("integration_id", integrationID).
+			Msg("Magento webhook payload invalido")
+		c.Status(http.StatusBadRequest)
+		return
+	}
+	event.IntegrationID = integrationID
+
+	h.logger.Info(ctx).
+		Str("event", event.Event).
+		Int64("order_id", event.OrderID).
+		Str("integration_id", integrationID).
 		Msg("Magento webhook received")
 
 	c.Status(http.StatusOK)
+
+	go h.processWebhookAsync(event)
+}
+
+func (h *magentoHandler) processWebhookAsync(event *domain.WebhookEvent) {
+	ctx := context.Background()
+
+	if err := h.useCase.ProcessWebhook(ctx, event); err != nil {
+		h.logger.Error(ctx).Err(err).
+			Str("event", event.Event).
+			Int64("order_id", event.OrderID).
+			Str("integration_id", event.IntegrationID).
+			Msg("Failed to process Magento webhook order")
+	}
 }
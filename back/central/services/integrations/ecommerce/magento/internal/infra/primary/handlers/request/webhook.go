@@ -0,0 +1,87 @@
+package request
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
+)
+
+// flexID acepta el id como número o como string ("123").
+type flexID int64
+
+func (f *flexID) UnmarshalJSON(b []byte) error {
+	var n int64
+	if err := json.Unmarshal(b, &n); err == nil {
+		*f = flexID(n)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*f = 0
+		return nil
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	*f = flexID(n)
+	return nil
+}
+
+type webhookOrder struct {
+	EntityID    flexID `json:"entity_id"`
+	IncrementID string `json:"increment_id"`
+}
+
+// WebhookPayload cubre los formatos habituales: observer propio
+// ({"event","order_id"}), la orden completa ({"entity_id",...}) y los
+// webhooks de Adobe Commerce ({"data":{"order":{...}}}).
+type WebhookPayload struct {
+	Event       string        `json:"event"`
+	OrderID     flexID        `json:"order_id"`
+	EntityID    flexID        `json:"entity_id"`
+	IncrementID string        `json:"increment_id"`
+	Order       *webhookOrder `json:"order"`
+	Data        *struct {
+		Order *webhookOrder `json:"order"`
+	} `json:"data"`
+}
+
+// ParseWebhook convierte el cuerpo del webhook al evento de dominio.
+func ParseWebhook(body []byte) (*domain.WebhookEvent, error) {
+	var p WebhookPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, err
+	}
+
+	event := &domain.WebhookEvent{
+		Event:       p.Event,
+		OrderID:     int64(p.OrderID),
+		IncrementID: p.IncrementID,
+	}
+	if event.OrderID == 0 {
+		event.OrderID = int64(p.EntityID)
+	}
+
+	nested := p.Order
+	if nested == nil && p.Data != nil {
+		nested = p.Data.Order
+	}
+	if nested != nil {
+		if event.OrderID == 0 {
+			event.OrderID = int64(nested.EntityID)
+		}
+		if event.IncrementID == "" {
+			event.IncrementID = nested.IncrementID
+		}
+	}
+
+	if event.OrderID == 0 {
+		return nil, domain.ErrWebhookMissingOrder
+	}
+	return event, nil
+}
@@ -0,0 +1,34 @@
+package request
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
+)
+
+func TestParseWebhook_Formats(t *testing.T) {
+	cases := map[string]string{
+		"observer":      `{"event":"sales_order_save_after","order_id":501,"increment_id":"000000501"}`,
+		"order_id_text": `{"event":"sales_order_save_after","order_id":"501"}`,
+		"full_order":    `{"entity_id":501,"increment_id":"000000501","state":"new"}`,
+		"adobe_data":    `{"event":"observer.sales_order_save_after","data":{"order":{"entity_id":"501","increment_id":"000000501"}}}`,
+	}
+	for name, body := range cases {
+		event, err := ParseWebhook([]byte(body))
+		if err != nil {
+			t.Errorf("%s: esperaba sin error, recibí: %v", name, err)
+			continue
+		}
+		if event.OrderID != 501 {
+			t.Errorf("%s: OrderID esperado 501, recibí %d", name, event.OrderID)
+		}
+	}
+}
+
+func TestParseWebhook_MissingOrder(t *testing.T) {
+	_, err := ParseWebhook([]byte(`{"event":"sales_order_save_after"}`))
+	if !errors.Is(err, domain.ErrWebhookMissingOrder) {
+		t.Fatalf("esperaba ErrWebhookMissingOrder, recibí: %v", err)
+	}
+}
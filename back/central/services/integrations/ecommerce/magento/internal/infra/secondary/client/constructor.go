@@ -1,10 +1,14 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
 )
@@ -17,36 +21,77 @@ type MagentoClient struct {
 // New crea un nuevo cliente HTTP para Magento.
 func New() domain.IMagentoClient {
 	return &MagentoClient{
-		httpClient: &http.Client{},
+		httpClient: &http.Client{Timeout: 60 * time.Second},
 	}
 }
 
 // TestConnection verifica las credenciales llamando a GET {storeURL}/rest/V1/store/storeConfigs.
 func (c *MagentoClient) TestConnection(ctx context.Context, storeURL, accessToken string) error {
-	storeURL = strings.TrimRight(storeURL, "/")
-	endpoint := fmt.Sprintf("%s/rest/V1/store/storeConfigs", storeURL)
+	status, _, err := c.do(ctx, http.MethodGet, restURL(storeURL, "/store/storeConfigs"), accessToken, nil)
+	if err != nil {
+		return err
+	}
+
+	if status == http.StatusUnauthorized || status == http.StatusForbidden {
+		return domain.ErrInvalidCredentials
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if status != http.StatusOK {
+		return fmt.Errorf("magento client: unexpected status %d", status)
+	}
+
+	return nil
+}
+
+// restURL arma la URL de la REST API V1 con el token de integración (store por defecto).
+func restURL(storeURL, path string) string {
+	return strings.TrimRight(storeURL, "/") + "/rest/V1" + path
+}
+
+// do ejecuta la petición con el token Bearer de la integración y retorna el
+// estado y el cuerpo. Los estados de error los interpreta quien llama.
+func (c *MagentoClient) do(ctx context.Context, method, endpoint, accessToken string, payload interface{}) (int, []byte, error) {
+	var body io.Reader
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return 0, nil, fmt.Errorf("magento client: marshaling payload: %w", err)
+		}
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
 	if err != nil {
-		return fmt.Errorf("magento client: creating request: %w", err)
+		return 0, nil, fmt.Errorf("magento client: creating request: %w", err)
 	}
 
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("magento client: request failed: %w", err)
+		return 0, nil, fmt.Errorf("magento client: request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
-	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
-		return domain.ErrInvalidCredentials
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 32<<20))
+	if err != nil {
+		return resp.StatusCode, nil, fmt.Errorf("magento client: reading response: %w", err)
 	}
+	return resp.StatusCode, respBody, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("magento client: unexpected status %d", resp.StatusCode)
+// apiErrorMessage extrae el mensaje de error de Magento ({"message": "..."}).
+func apiErrorMessage(body []byte) string {
+	var apiErr struct {
+		Message string `json:"message"`
 	}
-
-	return nil
+	if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Message != "" {
+		return apiErr.Message
+	}
+	if len(body) > 512 {
+		body = body[:512]
+	}
+	return string(body)
 }
@@ -0,0 +1,130 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/infra/secondary/client/response"
+)
+
+// GetOrders obtiene una página de órdenes con searchCriteria.
+// Retorna las órdenes tipadas, los bytes crudos por orden (para ChannelMetadata.RawData), y error.
+func (c *MagentoClient) GetOrders(ctx context.Context, storeURL, accessToken string, params *domain.GetOrdersParams) (*domain.GetOrdersResult, [][]byte, error) {
+	endpoint := restURL(storeURL, "/orders") + "?" + buildSearchCriteria(params)
+
+	status, body, err := c.do(ctx, http.MethodGet, endpoint, accessToken, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if status == http.StatusUnauthorized || status == http.StatusForbidden {
+		return nil, nil, domain.ErrInvalidCredentials
+	}
+
+	if status != http.StatusOK {
+		return nil, nil, fmt.Errorf("magento client: unexpected status %d: %s", status, apiErrorMessage(body))
+	}
+
+	var page struct {
+		response.OrderSearchResponse
+		Items []json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, nil, fmt.Errorf("magento client: parsing response: %w", err)
+	}
+
+	orders := make([]domain.MagentoOrder, 0, len(page.Items))
+	rawBytes := make([][]byte, 0, len(page.Items))
+	for _, raw := range page.Items {
+		var orderResp response.MagentoOrderResponse
+		if err := json.Unmarshal(raw, &orderResp); err != nil {
+			continue // skip malformed orders
+		}
+		orders = append(orders, orderResp.ToDomain())
+		rawBytes = append(rawBytes, []byte(raw))
+	}
+
+	return &domain.GetOrdersResult{
+		Orders:     orders,
+		TotalCount: page.TotalCount,
+	}, rawBytes, nil
+}
+
+// GetOrder obtiene una orden por entity_id.
+func (c *MagentoClient) GetOrder(ctx context.Context, storeURL, accessToken string, orderID int64) (*domain.MagentoOrder, []byte, error) {
+	endpoint := restURL(storeURL, "/orders/"+strconv.FormatInt(orderID, 10))
+
+	status, body, err := c.do(ctx, http.MethodGet, endpoint, accessToken, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return nil, nil, domain.ErrInvalidCredentials
+	case status == http.StatusNotFound:
+		return nil, nil, domain.ErrOrderNotFound
+	case status != http.StatusOK:
+		return nil, nil, fmt.Errorf("magento client: unexpected status %d: %s", status, apiErrorMessage(body))
+	}
+
+	var orderResp response.MagentoOrderResponse
+	if err := json.Unmarshal(body, &orderResp); err != nil {
+		return nil, nil, fmt.Errorf("magento client: parsing response: %w", err)
+	}
+
+	order := orderResp.ToDomain()
+	return &order, body, nil
+}
+
+// buildSearchCriteria traduce los filtros a searchCriteria. Cada filtro va en
+// su propio filter_group para que Magento los combine con AND.
+func buildSearchCriteria(p *domain.GetOrdersParams) string {
+	q := url.Values{}
+	if p == nil {
+		p = &domain.GetOrdersParams{}
+	}
+
+	group := 0
+	addFilter := func(field, value, condition string) {
+		prefix := fmt.Sprintf("searchCriteria[filter_groups][%d][filters][0]", group)
+		q.Set(prefix+"[field]", field)
+		q.Set(prefix+"[value]", value)
+		q.Set(prefix+"[condition_type]", condition)
+		group++
+	}
+
+	if p.CreatedFrom != nil {
+		addFilter("created_at", response.FormatMagentoTime(*p.CreatedFrom), "gteq")
+	}
+	if p.CreatedTo != nil {
+		addFilter("created_at", response.FormatMagentoTime(*p.CreatedTo), "lteq")
+	}
+	if p.UpdatedFrom != nil {
+		addFilter("updated_at", response.FormatMagentoTime(*p.UpdatedFrom), "gteq")
+	}
+	if p.Status != "" {
+		addFilter("status", p.Status, "eq")
+	}
+
+	sortField := "created_at"
+	if p.UpdatedFrom != nil {
+		sortField = "updated_at"
+	}
+	q.Set("searchCriteria[sortOrders][0][field]", sortField)
+	q.Set("searchCriteria[sortOrders][0][direction]", "ASC")
+
+	if p.PageSize > 0 {
+		q.Set("searchCriteria[pageSize]", strconv.Itoa(p.PageSize))
+	}
+	if p.Page > 0 {
+		q.Set("searchCriteria[currentPage]", strconv.Itoa(p.Page))
+	}
+
+	return q.Encode()
+}
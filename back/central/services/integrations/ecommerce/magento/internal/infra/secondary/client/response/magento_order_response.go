@@ -0,0 +1,203 @@
+package response
+
+import (
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
+)
+
+// magentoTimeLayout es el formato de fecha de la REST API (UTC, sin zona).
+const magentoTimeLayout = "2006-01-02 15:04:05"
+
+// OrderSearchResponse es la respuesta de GET /V1/orders con searchCriteria.
+type OrderSearchResponse struct {
+	TotalCount int `json:"total_count"`
+}
+
+// MagentoOrderResponse es la respuesta JSON de una orden de la REST API V1.
+type MagentoOrderResponse struct {
+	EntityID            int64                    `json:"entity_id"`
+	IncrementID         string                   `json:"increment_id"`
+	State               string                   `json:"state"`
+	Status              string                   `json:"status"`
+	CreatedAt           string                   `json:"created_at"`
+	UpdatedAt           string                   `json:"updated_at"`
+	OrderCurrencyCode   string                   `json:"order_currency_code"`
+	GrandTotal          float64                  `json:"grand_total"`
+	Subtotal            float64                  `json:"subtotal"`
+	TaxAmount           float64                  `json:"tax_amount"`
+	DiscountAmount      float64                  `json:"discount_amount"`
+	ShippingAmount      float64                  `json:"shipping_amount"`
+	TotalPaid           float64                  `json:"total_paid"`
+	CouponCode          string                   `json:"coupon_code"`
+	CustomerNote        string                   `json:"customer_note"`
+	CustomerEmail       string                   `json:"customer_email"`
+	CustomerFirstname   string                   `json:"customer_firstname"`
+	CustomerLastname    string                   `json:"customer_lastname"`
+	CustomerIsGuest     flexBool                 `json:"customer_is_guest"`
+	ShippingDescription string                   `json:"shipping_description"`
+	BillingAddress      *MagentoAddressResponse  `json:"billing_address"`
+	Payment             MagentoPaymentResponse   `json:"payment"`
+	Items               []MagentoItemResponse    `json:"items"`
+	ExtensionAttributes MagentoOrderExtAttribute `json:"extension_attributes"`
+}
+
+type MagentoAddressResponse struct {
+	Firstname  string   `json:"firstname"`
+	Lastname   string   `json:"lastname"`
+	Company    string   `json:"company"`
+	Street     []string `json:"street"`
+	City       string   `json:"city"`
+	Region     string   `json:"region"`
+	RegionCode string   `json:"region_code"`
+	Postcode   string   `json:"postcode"`
+	CountryID  string   `json:"country_id"`
+	Telephone  string   `json:"telephone"`
+	Email      string   `json:"email"`
+}
+
+type MagentoPaymentResponse struct {
+	Method      string  `json:"method"`
+	AmountPaid  float64 `json:"amount_paid"`
+	LastTransID string  `json:"last_trans_id"`
+}
+
+type MagentoItemResponse struct {
+	ItemID         int64   `json:"item_id"`
+	ParentItemID   *int64  `json:"parent_item_id"`
+	ProductID      int64   `json:"product_id"`
+	ProductType    string  `json:"product_type"`
+	SKU            string  `json:"sku"`
+	Name           string  `json:"name"`
+	QtyOrdered     float64 `json:"qty_ordered"`
+	Price          float64 `json:"price"`
+	RowTotal       float64 `json:"row_total"`
+	TaxAmount      float64 `json:"tax_amount"`
+	DiscountAmount float64 `json:"discount_amount"`
+}
+
+type MagentoOrderExtAttribute struct {
+	ShippingAssignments []MagentoShippingAssignment `json:"shipping_assignments"`
+}
+
+type MagentoShippingAssignment struct {
+	Shipping struct {
+		Address *MagentoAddressResponse `json:"address"`
+		Method  string                  `json:"method"`
+	} `json:"shipping"`
+}
+
+// flexBool acepta 0/1 o true/false: Magento serializa los flags como enteros.
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	switch strings.Trim(string(data), `"`) {
+	case "1", "true":
+		*b = true
+	default:
+		*b = false
+	}
+	return nil
+}
+
+// ToDomain convierte la respuesta a la entidad de dominio.
+func (r *MagentoOrderResponse) ToDomain() domain.MagentoOrder {
+	order := domain.MagentoOrder{
+		EntityID:        r.EntityID,
+		IncrementID:     r.IncrementID,
+		State:           r.State,
+		Status:          r.Status,
+		CreatedAt:       parseMagentoTime(r.CreatedAt),
+		UpdatedAt:       parseMagentoTime(r.UpdatedAt),
+		Currency:        r.OrderCurrencyCode,
+		GrandTotal:      r.GrandTotal,
+		Subtotal:        r.Subtotal,
+		TaxAmount:       r.TaxAmount,
+		DiscountAmount:  r.DiscountAmount,
+		ShippingAmount:  r.ShippingAmount,
+		TotalPaid:       r.TotalPaid,
+		CouponCode:      r.CouponCode,
+		CustomerNote:    r.CustomerNote,
+		CustomerEmail:   r.CustomerEmail,
+		CustomerFirst:   r.CustomerFirstname,
+		CustomerLast:    r.CustomerLastname,
+		CustomerIsGuest: bool(r.CustomerIsGuest),
+		ShippingTitle:   r.ShippingDescription,
+		BillingAddress:  r.BillingAddress.toDomain(),
+		Payment: domain.MagentoPayment{
+			Method:      r.Payment.Method,
+			AmountPaid:  r.Payment.AmountPaid,
+			LastTransID: r.Payment.LastTransID,
+		},
+	}
+
+	for _, sa := range r.ExtensionAttributes.ShippingAssignments {
+		if sa.Shipping.Address != nil && order.ShippingAddress == nil {
+			order.ShippingAddress = sa.Shipping.Address.toDomain()
+		}
+		if sa.Shipping.Method != "" && order.ShippingMethod == "" {
+			order.ShippingMethod = sa.Shipping.Method
+		}
+	}
+
+	order.Items = make([]domain.MagentoOrderItem, 0, len(r.Items))
+	for _, it := range r.Items {
+		var parentID int64
+		if it.ParentItemID != nil {
+			parentID = *it.ParentItemID
+		}
+		order.Items = append(order.Items, domain.MagentoOrderItem{
+			ItemID:         it.ItemID,
+			ParentItemID:   parentID,
+			ProductID:      it.ProductID,
+			ProductType:    it.ProductType,
+			SKU:            it.SKU,
+			Name:           it.Name,
+			QtyOrdered:     it.QtyOrdered,
+			Price:          it.Price,
+			RowTotal:       it.RowTotal,
+			TaxAmount:      it.TaxAmount,
+			DiscountAmount: it.DiscountAmount,
+		})
+	}
+
+	return order
+}
+
+func (a *MagentoAddressResponse) toDomain() *domain.MagentoAddress {
+	if a == nil {
+		return nil
+	}
+	return &domain.MagentoAddress{
+		FirstName:  a.Firstname,
+		LastName:   a.Lastname,
+		Company:    a.Company,
+		Street:     a.Street,
+		City:       a.City,
+		Region:     a.Region,
+		RegionCode: a.RegionCode,
+		Postcode:   a.Postcode,
+		CountryID:  a.CountryID,
+		Telephone:  a.Telephone,
+		Email:      a.Email,
+	}
+}
+
+func parseMagentoTime(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	if t, err := time.Parse(magentoTimeLayout, s); err == nil {
+		return t
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t
+	}
+	return time.Time{}
+}
+
+// FormatMagentoTime formatea una fecha para los filtros de searchCriteria.
+func FormatMagentoTime(t time.Time) string {
+	return t.UTC().Format(magentoTimeLayout)
+}
@@ -0,0 +1,50 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
+)
+
+type sourceItemPayload struct {
+	SKU        string `json:"sku"`
+	SourceCode string `json:"source_code"`
+	Quantity   int    `json:"quantity"`
+	Status     int    `json:"status"`
+}
+
+// SaveSourceItems actualiza el stock por fuente con POST /V1/inventory/source-items (MSI).
+func (c *MagentoClient) SaveSourceItems(ctx context.Context, storeURL, accessToken string, items []domain.SourceItem) error {
+	payload := struct {
+		SourceItems []sourceItemPayload `json:"sourceItems"`
+	}{SourceItems: make([]sourceItemPayload, 0, len(items))}
+
+	for _, it := range items {
+		status := 0
+		if it.InStock {
+			status = 1
+		}
+		payload.SourceItems = append(payload.SourceItems, sourceItemPayload{
+			SKU:        it.SKU,
+			SourceCode: it.SourceCode,
+			Quantity:   it.Quantity,
+			Status:     status,
+		})
+	}
+
+	status, body, err := c.do(ctx, http.MethodPost, restURL(storeURL, "/inventory/source-items"), accessToken, payload)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return domain.ErrInvalidCredentials
+	case status != http.StatusOK:
+		return fmt.Errorf("magento client: estado inesperado %d al actualizar stock: %s", status, apiErrorMessage(body))
+	}
+
+	return nil
+}
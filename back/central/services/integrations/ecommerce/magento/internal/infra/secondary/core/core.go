@@ -25,18 +25,36 @@ func (m *MagentoCore) TestConnection(ctx context.Context, config map[string]inte
 	return m.useCase.TestConnection(ctx, config, credentials)
 }
 
+// SyncOrdersByIntegrationID sincroniza órdenes de Magento (últimos 30 días).
+func (m *MagentoCore) SyncOrdersByIntegrationID(ctx context.Context, integrationID string) error {
+	return m.useCase.SyncOrders(ctx, integrationID)
+}
+
+// SyncOrdersByIntegrationIDWithParams sincroniza órdenes con parámetros personalizados.
+func (m *MagentoCore) SyncOrdersByIntegrationIDWithParams(ctx context.Context, integrationID string, params interface{}) error {
+	return m.useCase.SyncOrdersWithParams(ctx, integrationID, params)
+}
+
+func (m *MagentoCore) UpdateInventory(ctx context.Context, integrationID string, productExternalID string, quantity int) error {
+	return m.useCase.UpdateInventory(ctx, integrationID, productExternalID, quantity)
+}
+
 // GetWebhookURL retorna la URL para los webhooks de Magento.
 func (m *MagentoCore) GetWebhookURL(ctx context.Context, baseURL string, integrationID uint) (*integrationcore.WebhookInfo, error) {
-	webhookURL := fmt.Sprintf("%s/integrations/magento/webhook", baseURL)
+	webhookURL := fmt.Sprintf("%s/api/v1/magento/webhook?integration_id=%d", baseURL, integrationID)
 
 	return &integrationcore.WebhookInfo{
 		URL:    webhookURL,
 		Method: "POST",
-		Description: "Configura este webhook en Magento > Sistema > Integraciones o mediante una extensión de webhooks. " +
-			"Suscríbete a los eventos de órdenes para recibir notificaciones en tiempo real.",
+		Description: "Configura este webhook en Magento mediante un observer o una extensión de webhooks. " +
+			"El cuerpo debe incluir el entity_id de la orden (order_id, entity_id o data.order.entity_id). " +
+			"Si la integración tiene webhook_secret, firma el cuerpo con HMAC-SHA256 en hex en el header X-Magento-Signature.",
 		Events: []string{
-			"sales_order_save_after",
 			"sales_order_place_after",
+			"sales_order_save_after",
+			"sales_order_invoice_pay",
+			"sales_order_shipment_save_after",
+			"order_cancel_after",
 		},
 	}, nil
 }
@@ -0,0 +1,46 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/integrations/ecommerce/magento/internal/domain"
+)
+
+// MagentoClientMock mock de domain.IMagentoClient para tests unitarios.
+type MagentoClientMock struct {
+	TestConnectionFn  func(ctx context.Context, storeURL, accessToken string) error
+	GetOrdersFn       func(ctx context.Context, storeURL, accessToken string, params *domain.GetOrdersParams) (*domain.GetOrdersResult, [][]byte, error)
+	GetOrderFn        func(ctx context.Context, storeURL, accessToken string, orderID int64) (*domain.MagentoOrder, []byte, error)
+	SaveSourceItemsFn func(ctx context.Context, storeURL, accessToken string, items []domain.SourceItem) error
+}
+
+// Verificar en tiempo de compilación que implementa la interfaz.
+var _ domain.IMagentoClient = (*MagentoClientMock)(nil)
+
+func (m *MagentoClientMock) TestConnection(ctx context.Context, storeURL, accessToken string) error {
+	if m.TestConnectionFn != nil {
+		return m.TestConnectionFn(ctx, storeURL, accessToken)
+	}
+	return nil
+}
+
+func (m *MagentoClientMock) GetOrders(ctx context.Context, storeURL, accessToken string, params *domain.GetOrdersParams) (*domain.GetOrdersResult, [][]byte, error) {
+	if m.GetOrdersFn != nil {
+		return m.GetOrdersFn(ctx, storeURL, accessToken, params)
+	}
+	return &domain.GetOrdersResult{}, nil, nil
+}
+
+func (m *MagentoClientMock) GetOrder(ctx context.Context, storeURL, accessToken string, orderID int64) (*domain.MagentoOrder, []byte, error) {
+	if m.GetOrderFn != nil {
+		return m.GetOrderFn(ctx, storeURL, accessToken, orderID)
+	}
+	return nil, nil, domain.ErrOrderNotFound
+}
+
+func (m *MagentoClientMock) SaveSourceItems(ctx context.Context, storeURL, accessToken string, items []domain.SourceItem) error {
+	if m.SaveSourceItemsFn != nil {
+		return m.SaveSourceItemsFn(ctx, storeURL, accessToken, items)
+	}
+	return nil
+}
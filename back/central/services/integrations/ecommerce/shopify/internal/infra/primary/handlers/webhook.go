@@ -102,12 +102,12 @@ func (h *ShopifyHandler) WebhookHandler(c *gin.Context) {
 		Message: "Recibido",
 	})
 
-	h.enqueueWebhook(headers.Topic, headers.ShopDomain, bodyBytes, isTest)
+	// Sin cancelacion: el request ya respondio, pero el correlation ID del
+	// webhook debe seguir a la orden por todos los consumers.
+	h.enqueueWebhook(context.WithoutCancel(c.Request.Context()), headers.Topic, headers.ShopDomain, bodyBytes, isTest)
 }
 
-func (h *ShopifyHandler) enqueueWebhook(topic string, shopDomain string, bodyBytes []byte, isTest bool) {
-	ctx := context.Background()
-
+func (h *ShopifyHandler) enqueueWebhook(ctx context.Context, topic string, shopDomain string, bodyBytes []byte, isTest bool) {
 	if h.rabbit != nil {
 		msg := shopifyqueue.WebhookMessage{
 			Topic:      topic,
@@ -43,15 +43,15 @@ func (c *WebhookConsumer) Start(ctx context.Context) {
 	}
 
 	go func() {
-		err := c.queue.Consume(ctx, rabbitmq.QueueWebhooksShopifyReceived, func(body []byte) error {
+		err := rabbitmq.ConsumeDeliveries(ctx, c.queue, rabbitmq.QueueWebhooksShopifyReceived, func(msgCtx context.Context, d rabbitmq.Delivery) error {
 			var msg WebhookMessage
-			if err := json.Unmarshal(body, &msg); err != nil {
-				c.logger.Error(ctx).Err(err).Msg("Mensaje de webhook Shopify invalido, descartado")
+			if err := json.Unmarshal(d.Body, &msg); err != nil {
+				c.logger.Error(msgCtx).Err(err).Msg("Mensaje de webhook Shopify invalido, descartado")
 				return nil
 			}
-			DispatchWebhook(context.Background(), c.useCase, c.logger, msg.Topic, msg.ShopDomain, msg.Body, msg.IsTest)
+			DispatchWebhook(context.WithoutCancel(msgCtx), c.useCase, c.logger, msg.Topic, msg.ShopDomain, msg.Body, msg.IsTest)
 			return nil
-		})
+		}, 1)
 		if err != nil {
 			c.logger.Error(ctx).Err(err).Msg("Error al consumir la cola de webhooks Shopify")
 		}
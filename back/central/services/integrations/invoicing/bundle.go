@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/secamc93/probability/back/central/services/integrations/core"
 	"github.com/secamc93/probability/back/central/services/integrations/invoicing/alegra"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian"
 	"github.com/secamc93/probability/back/central/services/integrations/invoicing/factus"
 	"github.com/secamc93/probability/back/central/services/integrations/invoicing/helisa"
 	"github.com/secamc93/probability/back/central/services/integrations/invoicing/router"
@@ -51,6 +52,10 @@ func New(
 	helisaBundle := helisa.New(logger, rabbitMQ, integrationCore)
 	integrationCore.RegisterIntegration(core.IntegrationTypeHelisa, helisaBundle)
 
+	// DIAN directo (type_id=36)
+	dianBundle := dian.New(logger, rabbitMQ, integrationCore, database)
+	integrationCore.RegisterIntegration(core.IntegrationTypeDian, dianBundle)
+
 	// Router: consume invoicing.requests y enruta al proveedor correcto.
 	// Se inicializa al final para que las colas de proveedores ya estén declaradas.
 	router.New(logger, rabbitMQ)
@@ -0,0 +1,70 @@
+package dian
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/integrations/core"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/app"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/primary/consumer"
+	diancore "github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/core"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/queue"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/repository"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/ubl"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/webservice"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/xades"
+	"github.com/secamc93/probability/back/central/shared/db"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/rabbitmq"
+)
+
+// New crea una nueva instancia del módulo DIAN (facturación electrónica directa,
+// sin proveedor tecnológico intermedio)
+func New(
+	logger log.ILogger,
+	rabbit rabbitmq.IQueue,
+	coreIntegration core.IIntegrationService,
+	database db.IDatabase,
+) *diancore.DianCore {
+	logger = logger.WithModule("dian")
+
+	// 1. Adapters secundarios: XML UBL + firma, web service SOAP, certificados y consecutivos
+	builder := ubl.New()
+	webService := webservice.New(logger)
+	certificates := xades.NewCertificateLoader()
+	documents := repository.New(database, logger)
+	logger.Info(context.Background()).Msg("✅ DIAN UBL builder, web service client and document repository initialized")
+
+	// 2. Use Case — contiene toda la lógica de negocio
+	useCase := app.New(builder, webService, certificates, documents, coreIntegration, logger)
+	logger.Info(context.Background()).Msg("✅ DIAN use case initialized")
+
+	// 3. Response Publisher (RabbitMQ)
+	responsePublisher := queue.New(rabbit, logger)
+	logger.Info(context.Background()).Msg("✅ DIAN response publisher initialized")
+
+	// 4. Invoice Request Consumer (escucha "invoicing.dian.requests")
+	if rabbit != nil {
+		invoiceRequestConsumer := consumer.New(
+			rabbit,
+			useCase,
+			responsePublisher,
+			logger,
+		)
+		logger.Info(context.Background()).Msg("✅ DIAN invoice request consumer initialized")
+
+		go func() {
+			ctx := context.Background()
+			logger.Info(ctx).Msg("🚀 Starting DIAN invoice request consumer in background...")
+			if err := invoiceRequestConsumer.Start(ctx); err != nil {
+				logger.Error(ctx).Err(err).Msg("❌ DIAN invoice request consumer failed to start or stopped with error")
+			}
+		}()
+	} else {
+		logger.Warn(context.Background()).
+			Msg("❌ RabbitMQ no disponible, consumer de facturación (DIAN) deshabilitado")
+	}
+
+	logger.Info(context.Background()).Msg("✅ DIAN bundle initialized (UBL 2.1 + XAdES-EPES + RabbitMQ async consumer)")
+
+	return diancore.New(useCase)
+}
@@ -0,0 +1,37 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+)
+
+// CheckStatus consulta en la DIAN la validación de una factura que quedó
+// pendiente tras enviarse al set de pruebas de habilitación
+func (uc *invoicingUseCase) CheckStatus(ctx context.Context, integrationID, invoiceID uint) (*dtos.ProcessInvoiceResult, error) {
+	settings, err := uc.loadSettings(ctx, integrationID, nil)
+	if err != nil {
+		return &dtos.ProcessInvoiceResult{}, err
+	}
+
+	sourceID := strconv.FormatUint(uint64(invoiceID), 10)
+	stored, err := uc.repository.GetDocument(ctx, integrationID, entities.DocumentKindInvoice, sourceID)
+	if err != nil {
+		return &dtos.ProcessInvoiceResult{}, err
+	}
+	if stored == nil {
+		return &dtos.ProcessInvoiceResult{}, fmt.Errorf("dian: la factura %d no tiene documento electrónico", invoiceID)
+	}
+
+	switch stored.Status {
+	case entities.DocumentStatusAccepted:
+		return resultFromStored(settings, stored), nil
+	case entities.DocumentStatusPending:
+		return uc.poll(ctx, settings, stored)
+	default:
+		return resultFromStored(settings, stored), fmt.Errorf("dian: el documento %s no está pendiente de validación (estado %s)", stored.Number, stored.Status)
+	}
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/core"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+// invoicingUseCase es el use case de facturación electrónica directa con la DIAN
+type invoicingUseCase struct {
+	builder         ports.IDocumentBuilder
+	webService      ports.IDianWebService
+	certificates    ports.ICertificateLoader
+	repository      ports.IDocumentRepository
+	integrationCore core.IIntegrationService
+	log             log.ILogger
+	now             func() time.Time
+	sleep           func(time.Duration)
+}
+
+// New crea el use case de facturación DIAN
+func New(
+	builder ports.IDocumentBuilder,
+	webService ports.IDianWebService,
+	certificates ports.ICertificateLoader,
+	repository ports.IDocumentRepository,
+	integrationCore core.IIntegrationService,
+	logger log.ILogger,
+) ports.IInvoiceUseCase {
+	return &invoicingUseCase{
+		builder:         builder,
+		webService:      webService,
+		certificates:    certificates,
+		repository:      repository,
+		integrationCore: integrationCore,
+		log:             logger.WithModule("dian.usecase"),
+		now:             time.Now,
+		sleep:           time.Sleep,
+	}
+}
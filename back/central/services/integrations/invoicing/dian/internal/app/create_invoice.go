@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	dianErrors "github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/errors"
+)
+
+// CreateInvoice emite la factura electrónica directamente ante la DIAN:
+//  1. Obtiene la integración, descifra credenciales y carga el certificado
+//  2. Si la factura ya tiene documento, lo retoma (aceptado, pendiente o reintento)
+//  3. Si no, reserva el consecutivo de la resolución
+//  4. Construye el UBL, calcula el CUFE, firma y envía
+//
+// El consumer (adapter primario) solo deserializa el mensaje y delega aquí.
+func (uc *invoicingUseCase) CreateInvoice(ctx context.Context, req *dtos.ProcessInvoiceRequest) (*dtos.ProcessInvoiceResult, error) {
+	uc.log.Info(ctx).
+		Uint("invoice_id", req.InvoiceID).
+		Str("order_id", req.OrderID).
+		Uint("integration_id", req.IntegrationID).
+		Msg("Processing DIAN invoice request")
+
+	if len(req.Items) == 0 {
+		return &dtos.ProcessInvoiceResult{}, fmt.Errorf("%w: items", dianErrors.ErrMissingRequiredField)
+	}
+
+	settings, err := uc.loadSettings(ctx, req.IntegrationID, req.Config)
+	if err != nil {
+		return &dtos.ProcessInvoiceResult{}, err
+	}
+
+	sourceID := strconv.FormatUint(uint64(req.InvoiceID), 10)
+	stored, err := uc.repository.GetDocument(ctx, req.IntegrationID, entities.DocumentKindInvoice, sourceID)
+	if err != nil {
+		return &dtos.ProcessInvoiceResult{}, err
+	}
+
+	switch {
+	case stored == nil:
+		stored, err = uc.reserve(ctx, req.IntegrationID, req.InvoiceID, entities.DocumentKindInvoice, sourceID,
+			settings.Numbering.Prefix, settings.Numbering.RangeFrom, settings.Numbering.RangeTo)
+		if err != nil {
+			return &dtos.ProcessInvoiceResult{}, err
+		}
+	case stored.IsAccepted():
+		uc.log.Info(ctx).Uint("invoice_id", req.InvoiceID).Str("number", stored.Number).Msg("Invoice already accepted by DIAN")
+		return resultFromStored(settings, stored), nil
+	case stored.Status == entities.DocumentStatusPending:
+		return uc.poll(ctx, settings, stored)
+	}
+
+	doc := invoiceDocument(req, settings, stored.Consecutive)
+	useTestSet := settings.Environment == entities.EnvironmentTesting && settings.TestSetID != ""
+	return uc.submit(ctx, settings, doc, stored, useTestSet)
+}
@@ -0,0 +1,350 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/core"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	dianErrors "github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/mocks"
+)
+
+// Helpers para construir fixtures reutilizables
+
+var testNow = time.Date(2026, 10, 18, 10, 0, 0, 0, time.FixedZone("COT", -5*60*60))
+
+type testDeps struct {
+	builder    *mocks.DocumentBuilderMock
+	webService *mocks.DianWebServiceMock
+	repository *mocks.DocumentRepositoryMock
+	core       *mocks.IntegrationCoreMock
+}
+
+func buildIntegrationConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"software_id":            "56f2ae4e-9812-4fad-9255-08fcfcd5ccb0",
+		"issuer_nit":             "900123456",
+		"issuer_dv":              "8",
+		"issuer_name":            "Comercializadora Ejemplo SAS",
+		"issuer_city_code":       "11001",
+		"issuer_city_name":       "Bogotá, D.C.",
+		"issuer_department_code": "11",
+		"issuer_department_name": "Bogotá",
+		"issuer_address":         "Calle 100 # 10-20",
+		"resolution_number":      "18760000001",
+		"resolution_start_date":  "2019-01-19",
+		"resolution_end_date":    "2030-01-19",
+		"prefix":                 "SETP",
+		"range_from":             float64(990000000),
+		"range_to":               float64(995000000),
+	}
+}
+
+func newTestUseCase(isTesting bool, config map[string]interface{}) (*invoicingUseCase, *testDeps) {
+	deps := &testDeps{
+		builder:    &mocks.DocumentBuilderMock{},
+		webService: &mocks.DianWebServiceMock{},
+		repository: mocks.NewDocumentRepositoryMock(),
+		core: &mocks.IntegrationCoreMock{
+			GetIntegrationByIDFn: func(_ context.Context, _ string) (*core.PublicIntegration, error) {
+				return &core.PublicIntegration{ID: 3, IntegrationType: 36, Config: config, IsTesting: isTesting}, nil
+			},
+		},
+	}
+	uc := New(deps.builder, deps.webService, &mocks.CertificateLoaderMock{}, deps.repository, deps.core, mocks.NewLoggerMock()).(*invoicingUseCase)
+	uc.now = func() time.Time { return testNow }
+	uc.sleep = func(time.Duration) {}
+	return uc, deps
+}
+
+func buildProcessInvoiceRequest() *dtos.ProcessInvoiceRequest {
+	rate := 0.19
+	return &dtos.ProcessInvoiceRequest{
+		InvoiceID:        42,
+		Operation:        "create",
+		CorrelationID:    "corr-abc-123",
+		IntegrationID:    3,
+		OrderID:          "order-999",
+		OrderNumber:      "#1001",
+		Total:            129000,
+		ShippingCost:     10000,
+		ShippingCostBase: 8403.36,
+		Currency:         "COP",
+		Customer: dtos.CustomerData{
+			Name:  "Juan Perez",
+			Email: "juan@example.com",
+			DNI:   "1020304050",
+		},
+		Items: []dtos.ItemData{
+			{SKU: "SKU-01", Name: "Producto A", Quantity: 2, UnitPrice: 59500, UnitPriceBase: 50000, TaxRate: &rate},
+		},
+	}
+}
+
+// CreateInvoice — camino feliz
+
+func TestCreateInvoice_Produccion_EmiteValidaYArmaAttachedDocument(t *testing.T) {
+	uc, deps := newTestUseCase(false, buildIntegrationConfig())
+
+	result, err := uc.CreateInvoice(context.Background(), buildProcessInvoiceRequest())
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+
+	if result.InvoiceNumber != "SETP990000000" {
+		t.Errorf("InvoiceNumber = %s, se esperaba SETP990000000", result.InvoiceNumber)
+	}
+	if len(result.CUFE) != 96 || result.ExternalID != result.CUFE {
+		t.Errorf("CUFE inválido: %q", result.CUFE)
+	}
+	if !strings.HasPrefix(result.QRCode, "https://catalogo-vpfe.dian.gov.co/") {
+		t.Errorf("QR de producción inesperado: %s", result.QRCode)
+	}
+	if _, ok := result.Document["attached_document"]; !ok {
+		t.Error("la respuesta no trae el AttachedDocument")
+	}
+
+	doc := deps.builder.Signed[0]
+	if doc.Environment != entities.EnvironmentProduction || !doc.IssuedAt.Equal(testNow) {
+		t.Errorf("documento con ambiente %s y fecha %v", doc.Environment, doc.IssuedAt)
+	}
+	if len(doc.Lines) != 2 || doc.Lines[0].TaxPercent != 19 || doc.Lines[1].Code != "ENVIO" || doc.Lines[1].TaxPercent != 19 {
+		t.Errorf("líneas inesperadas: %+v", doc.Lines)
+	}
+	if doc.Customer.ID != "1020304050" || doc.Customer.IDType != entities.IDTypeCedula {
+		t.Errorf("adquiriente inesperado: %+v", doc.Customer)
+	}
+
+	stored, _ := deps.repository.GetDocument(context.Background(), 3, entities.DocumentKindInvoice, "42")
+	if stored == nil || !stored.IsAccepted() || len(stored.AttachedDocument) == 0 {
+		t.Fatalf("documento no registrado como aceptado: %+v", stored)
+	}
+}
+
+func TestCreateInvoice_FacturaYaAceptada_NoReenvia(t *testing.T) {
+	uc, deps := newTestUseCase(false, buildIntegrationConfig())
+	req := buildProcessInvoiceRequest()
+
+	first, err := uc.CreateInvoice(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := uc.CreateInvoice(context.Background(), req)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+
+	if len(deps.webService.SentZips) != 1 {
+		t.Errorf("se enviaron %d documentos, se esperaba 1", len(deps.webService.SentZips))
+	}
+	if second.CUFE != first.CUFE || second.InvoiceNumber != first.InvoiceNumber {
+		t.Error("el reintento no retornó el documento ya emitido")
+	}
+}
+
+// CreateInvoice — reintentos
+
+func TestCreateInvoice_ErrorDeRed_ReenviaElMismoDocumento(t *testing.T) {
+	uc, deps := newTestUseCase(false, buildIntegrationConfig())
+	calls := 0
+	deps.webService.SendBillSyncFn = func(_ context.Context, _ string, _ *entities.Certificate, _ string, _ []byte) (*dtos.SendResult, error) {
+		calls++
+		if calls == 1 {
+			return &dtos.SendResult{AuditData: &dtos.AuditData{RequestURL: "https://vpfe.dian.gov.co"}}, dianErrors.ErrWebService
+		}
+		return &dtos.SendResult{IsValid: true}, nil
+	}
+	req := buildProcessInvoiceRequest()
+
+	result, err := uc.CreateInvoice(context.Background(), req)
+	if !errors.Is(err, dianErrors.ErrWebService) {
+		t.Fatalf("se esperaba ErrWebService, got %v", err)
+	}
+	if result.AuditData == nil {
+		t.Error("el error no conserva el AuditData")
+	}
+
+	uc.now = func() time.Time { return testNow.Add(time.Hour) }
+	retried, err := uc.CreateInvoice(context.Background(), req)
+	if err != nil {
+		t.Fatalf("error inesperado en el reintento: %v", err)
+	}
+
+	if len(deps.builder.Signed) != 1 {
+		t.Errorf("se firmó %d veces, el reintento debe reusar la firma", len(deps.builder.Signed))
+	}
+	if string(deps.webService.SentZips[0]) != string(deps.webService.SentZips[1]) {
+		t.Error("el reintento no envió el mismo ZIP")
+	}
+	if retried.InvoiceNumber != "SETP990000000" {
+		t.Errorf("el reintento tomó otro consecutivo: %s", retried.InvoiceNumber)
+	}
+}
+
+func TestCreateInvoice_Rechazada_ReemiteConElMismoConsecutivo(t *testing.T) {
+	uc, deps := newTestUseCase(false, buildIntegrationConfig())
+	deps.webService.SendBillSyncFn = func(_ context.Context, _ string, _ *entities.Certificate, _ string, _ []byte) (*dtos.SendResult, error) {
+		return &dtos.SendResult{StatusCode: "99", Errors: []string{"Regla: FAD06, Rechazo: Valor total incorrecto"}}, nil
+	}
+	req := buildProcessInvoiceRequest()
+
+	_, err := uc.CreateInvoice(context.Background(), req)
+	if !errors.Is(err, dianErrors.ErrDocumentRejected) || !strings.Contains(err.Error(), "FAD06") {
+		t.Fatalf("se esperaba ErrDocumentRejected con el detalle, got %v", err)
+	}
+
+	deps.webService.SendBillSyncFn = nil
+	uc.now = func() time.Time { return testNow.Add(time.Hour) }
+	result, err := uc.CreateInvoice(context.Background(), req)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+
+	if len(deps.builder.Signed) != 2 {
+		t.Errorf("el documento rechazado debe volver a firmarse")
+	}
+	if !deps.builder.Signed[1].IssuedAt.Equal(testNow.Add(time.Hour)) {
+		t.Error("la reemisión debe llevar la fecha actual")
+	}
+	if result.InvoiceNumber != "SETP990000000" {
+		t.Errorf("la reemisión tomó otro consecutivo: %s", result.InvoiceNumber)
+	}
+}
+
+func TestCreateInvoice_RechazoPorDuplicado_RecuperaLaValidacion(t *testing.T) {
+	uc, deps := newTestUseCase(false, buildIntegrationConfig())
+	deps.webService.SendBillSyncFn = func(_ context.Context, _ string, _ *entities.Certificate, _ string, _ []byte) (*dtos.SendResult, error) {
+		return &dtos.SendResult{StatusCode: "99", Errors: []string{"Regla: 90, Rechazo: Documento procesado anteriormente."}}, nil
+	}
+	var queried string
+	deps.webService.GetStatusFn = func(_ context.Context, _ string, _ *entities.Certificate, key string) (*dtos.SendResult, error) {
+		queried = key
+		return &dtos.SendResult{IsValid: true, ApplicationResponse: []byte("<ApplicationResponse/>")}, nil
+	}
+
+	result, err := uc.CreateInvoice(context.Background(), buildProcessInvoiceRequest())
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if queried != result.CUFE {
+		t.Errorf("GetStatus consultó %q, se esperaba el CUFE", queried)
+	}
+}
+
+// CreateInvoice — habilitación
+
+func TestCreateInvoice_SetDePruebas_QuedaPendienteHastaCheckStatus(t *testing.T) {
+	config := buildIntegrationConfig()
+	config["test_set_id"] = "b3a1c2d4-set"
+	uc, deps := newTestUseCase(true, config)
+
+	result, err := uc.CreateInvoice(context.Background(), buildProcessInvoiceRequest())
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if !result.Pending || result.ZipKey != "zip-key-1" {
+		t.Fatalf("se esperaba documento pendiente con ZipKey, got %+v", result)
+	}
+	if deps.builder.Signed[0].Environment != entities.EnvironmentTesting {
+		t.Error("el documento debe emitirse en ambiente de pruebas")
+	}
+
+	pending, err := uc.CheckStatus(context.Background(), 3, 42)
+	if err != nil || !pending.Pending {
+		t.Fatalf("se esperaba seguir pendiente, got %+v, %v", pending, err)
+	}
+
+	deps.webService.GetStatusZipFn = func(_ context.Context, _ string, _ *entities.Certificate, zipKey string) (*dtos.SendResult, error) {
+		return &dtos.SendResult{IsValid: true, StatusCode: "00", ApplicationResponse: []byte("<ApplicationResponse/>")}, nil
+	}
+	accepted, err := uc.CheckStatus(context.Background(), 3, 42)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if accepted.Pending || accepted.Document["status"] != string(entities.DocumentStatusAccepted) {
+		t.Errorf("se esperaba documento aceptado, got %+v", accepted)
+	}
+}
+
+// CreateInvoice — validaciones
+
+func TestCreateInvoice_SinIdentificacion_FacturaAConsumidorFinal(t *testing.T) {
+	uc, deps := newTestUseCase(false, buildIntegrationConfig())
+	req := buildProcessInvoiceRequest()
+	req.Customer.DNI = ""
+
+	if _, err := uc.CreateInvoice(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+
+	customer := deps.builder.Signed[0].Customer
+	if customer.ID != entities.FinalConsumerID || customer.TaxLevelCode != "R-99-PN" {
+		t.Errorf("adquiriente inesperado: %+v", customer)
+	}
+}
+
+func TestCreateInvoice_NITConGuion_UsaTipoNITYDigito(t *testing.T) {
+	uc, deps := newTestUseCase(false, buildIntegrationConfig())
+	req := buildProcessInvoiceRequest()
+	req.Customer.DNI = "860.034.313-7"
+
+	if _, err := uc.CreateInvoice(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+
+	customer := deps.builder.Signed[0].Customer
+	if customer.IDType != entities.IDTypeNIT || customer.ID != "860034313" || customer.DV != "7" || customer.OrganizationType != "1" {
+		t.Errorf("adquiriente inesperado: %+v", customer)
+	}
+}
+
+func TestCreateInvoice_FaltaConfiguracion_RetornaError(t *testing.T) {
+	config := buildIntegrationConfig()
+	delete(config, "resolution_number")
+	uc, deps := newTestUseCase(false, config)
+
+	_, err := uc.CreateInvoice(context.Background(), buildProcessInvoiceRequest())
+	if !errors.Is(err, dianErrors.ErrMissingRequiredField) || !strings.Contains(err.Error(), "resolution_number") {
+		t.Fatalf("se esperaba ErrMissingRequiredField, got %v", err)
+	}
+	if len(deps.repository.Counters) != 0 {
+		t.Error("no se debe reservar consecutivo sin configuración")
+	}
+}
+
+func TestCreateInvoice_CertificadoVencido_RetornaError(t *testing.T) {
+	uc, _ := newTestUseCase(false, buildIntegrationConfig())
+	uc.certificates = &mocks.CertificateLoaderMock{
+		LoadPKCS12Fn: func(_ []byte, _ string) (*entities.Certificate, error) {
+			return mocks.NewCertificate(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)), nil
+		},
+	}
+
+	_, err := uc.CreateInvoice(context.Background(), buildProcessInvoiceRequest())
+	if !errors.Is(err, dianErrors.ErrCertificateExpired) {
+		t.Fatalf("se esperaba ErrCertificateExpired, got %v", err)
+	}
+}
+
+func TestCreateInvoice_RangoAgotado_RetornaError(t *testing.T) {
+	config := buildIntegrationConfig()
+	config["range_from"] = "1"
+	config["range_to"] = "1"
+	uc, _ := newTestUseCase(false, config)
+
+	if _, err := uc.CreateInvoice(context.Background(), buildProcessInvoiceRequest()); err != nil {
+		t.Fatal(err)
+	}
+	req := buildProcessInvoiceRequest()
+	req.InvoiceID = 43
+
+	_, err := uc.CreateInvoice(context.Background(), req)
+	if !errors.Is(err, dianErrors.ErrNumberingExhausted) {
+		t.Fatalf("se esperaba ErrNumberingExhausted, got %v", err)
+	}
+}
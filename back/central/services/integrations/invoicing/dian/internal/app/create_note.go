@@ -0,0 +1,181 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	dianErrors "github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/errors"
+)
+
+// defaultNoteTaxRate tarifa de IVA de la nota cuando la configuración no trae tax_rate
+const defaultNoteTaxRate = 0.19
+
+// creditNoteConcepts tipo de nota del módulo de facturación → concepto de
+// corrección de la tabla 13.2.4 del anexo técnico
+var creditNoteConcepts = map[string]string{
+	"partial_refund": "1", // Devolución parcial de los bienes
+	"full_refund":    "2", // Anulación de factura electrónica
+	"cancellation":   "2",
+	"correction":     "4", // Ajuste de precio
+}
+
+// noteStatusDelays esperas entre consultas de una nota enviada al set de pruebas
+var noteStatusDelays = []time.Duration{2 * time.Second, 5 * time.Second, 10 * time.Second}
+
+// debitNoteConcept concepto por defecto de las notas débito: cambio del valor
+const debitNoteConcept = "3"
+
+// CreateNote emite una nota crédito o débito sobre una factura. La referencia
+// (número, CUFE, fecha y adquiriente) se toma del documento DIAN de la factura;
+// si la factura la emitió otro proveedor se usa la que envía el módulo
+// (invoice_number, invoice_cufe, invoice_issued_at).
+//
+// La nota lleva una sola línea por el valor total, con el IVA de tax_rate.
+func (uc *invoicingUseCase) CreateNote(ctx context.Context, req *dtos.ProcessNoteRequest) (*dtos.ProcessInvoiceResult, error) {
+	idField := "credit_note_id"
+	if req.Kind == entities.DocumentKindDebitNote {
+		idField = "debit_note_id"
+	}
+	sourceID := configString(req.Config, idField)
+	if sourceID == "" {
+		return &dtos.ProcessInvoiceResult{}, fmt.Errorf("%w: %s", dianErrors.ErrMissingRequiredField, idField)
+	}
+	amount := configFloat(req.Config, "amount")
+	if amount <= 0 {
+		return &dtos.ProcessInvoiceResult{}, fmt.Errorf("%w: amount", dianErrors.ErrMissingRequiredField)
+	}
+
+	uc.log.Info(ctx).
+		Uint("invoice_id", req.InvoiceID).
+		Str("kind", string(req.Kind)).
+		Str("source_id", sourceID).
+		Float64("amount", amount).
+		Msg("Processing DIAN note request")
+
+	settings, err := uc.loadSettings(ctx, req.IntegrationID, req.Config)
+	if err != nil {
+		return &dtos.ProcessInvoiceResult{}, err
+	}
+
+	reference, customer, err := uc.noteReference(ctx, req, settings)
+	if err != nil {
+		return &dtos.ProcessInvoiceResult{}, err
+	}
+
+	stored, err := uc.repository.GetDocument(ctx, req.IntegrationID, req.Kind, sourceID)
+	if err != nil {
+		return &dtos.ProcessInvoiceResult{}, err
+	}
+
+	prefix := settings.CreditNotePrefix
+	if req.Kind == entities.DocumentKindDebitNote {
+		prefix = settings.DebitNotePrefix
+	}
+
+	switch {
+	case stored == nil:
+		stored, err = uc.reserve(ctx, req.IntegrationID, req.InvoiceID, req.Kind, sourceID, prefix, 1, 0)
+		if err != nil {
+			return &dtos.ProcessInvoiceResult{}, err
+		}
+	case stored.IsAccepted():
+		return resultFromStored(settings, stored), nil
+	case stored.Status == entities.DocumentStatusPending:
+		return uc.poll(ctx, settings, stored)
+	}
+
+	rate := defaultNoteTaxRate
+	if configString(req.Config, "tax_rate") != "" {
+		rate = configFloat(req.Config, "tax_rate")
+	}
+
+	reason := configString(req.Config, "reason")
+	if reason == "" {
+		reason = "Nota sobre la factura " + reference.Number
+	}
+
+	concept := debitNoteConcept
+	if req.Kind == entities.DocumentKindCreditNote {
+		concept = creditNoteConcepts[configString(req.Config, "note_type")]
+		if concept == "" {
+			concept = creditNoteConcepts["full_refund"]
+		}
+	}
+
+	doc := &entities.Document{
+		Kind:             req.Kind,
+		Environment:      settings.Environment,
+		Numbering:        entities.Numbering{Prefix: prefix, Number: stored.Consecutive},
+		Software:         settings.Software,
+		Currency:         currency(configString(req.Config, "currency")),
+		Supplier:         settings.Supplier,
+		Customer:         customer,
+		PaymentFormID:    paymentFormCash,
+		PaymentMeansCode: settings.PaymentMeansCode,
+		Lines: []entities.Line{{
+			Code:        prefix,
+			Description: reason,
+			Quantity:    1,
+			UnitCode:    unitCodeUnit,
+			UnitPrice:   entities.Round(amount / (1 + rate)),
+			TaxPercent:  entities.Round(rate * 100),
+		}},
+		Reference:         reference,
+		DiscrepancyCode:   concept,
+		DiscrepancyReason: reason,
+	}
+
+	useTestSet := settings.Environment == entities.EnvironmentTesting && settings.TestSetID != ""
+	result, err := uc.submit(ctx, settings, doc, stored, useTestSet)
+	if err != nil || !result.Pending {
+		return result, err
+	}
+
+	// El módulo de facturación no consulta notas pendientes: en el set de
+	// pruebas se espera la validación aquí mismo
+	for _, delay := range noteStatusDelays {
+		uc.sleep(delay)
+		result, err = uc.poll(ctx, settings, stored)
+		if err != nil || !result.Pending {
+			return result, err
+		}
+	}
+	return result, fmt.Errorf("dian: la nota %s sigue en validación (ZipKey %s)", stored.Number, stored.ZipKey)
+}
+
+// noteReference obtiene la factura referenciada y su adquiriente
+func (uc *invoicingUseCase) noteReference(ctx context.Context, req *dtos.ProcessNoteRequest, settings *issuerSettings) (*entities.BillingReference, entities.Party, error) {
+	invoiceSourceID := strconv.FormatUint(uint64(req.InvoiceID), 10)
+	invoice, err := uc.repository.GetDocument(ctx, req.IntegrationID, entities.DocumentKindInvoice, invoiceSourceID)
+	if err != nil {
+		return nil, entities.Party{}, err
+	}
+
+	if invoice != nil && invoice.IsAccepted() {
+		header, err := uc.builder.ReadDocument(invoice.SignedXML)
+		if err != nil {
+			return nil, entities.Party{}, err
+		}
+		reference := &entities.BillingReference{Number: invoice.Number, CUFE: invoice.UUID, IssueDate: invoice.IssuedAt}
+		return reference, header.Customer, nil
+	}
+
+	cufe := configString(req.Config, "invoice_cufe")
+	number := configString(req.Config, "invoice_number")
+	issuedAt, err := time.Parse(time.RFC3339, configString(req.Config, "invoice_issued_at"))
+	if cufe == "" || number == "" || err != nil {
+		return nil, entities.Party{}, dianErrors.ErrMissingBillingReference
+	}
+
+	customer := customerParty(dtos.CustomerData{
+		Name:  configString(req.Config, "customer_name"),
+		Email: configString(req.Config, "customer_email"),
+		DNI:   configString(req.Config, "customer_dni"),
+	}, settings.CustomerIDType)
+
+	return &entities.BillingReference{Number: number, CUFE: cufe, IssueDate: issuedAt}, customer, nil
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	dianErrors "github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/errors"
+)
+
+func buildProcessNoteRequest() *dtos.ProcessNoteRequest {
+	return &dtos.ProcessNoteRequest{
+		InvoiceID:     42,
+		Kind:          entities.DocumentKindCreditNote,
+		CorrelationID: "corr-nc-1",
+		IntegrationID: 3,
+		Config: map[string]interface{}{
+			"credit_note_id": float64(7),
+			"amount":         float64(119000),
+			"reason":         "Devolución del pedido",
+			"note_type":      "partial_refund",
+		},
+	}
+}
+
+func TestCreateNote_NotaCredito_ReferenciaLaFacturaEmitida(t *testing.T) {
+	uc, deps := newTestUseCase(false, buildIntegrationConfig())
+	invoice, err := uc.CreateInvoice(context.Background(), buildProcessInvoiceRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	deps.builder.ReadDocumentFn = func(_ []byte) (*entities.Document, error) {
+		return &entities.Document{Customer: entities.Party{ID: "1020304050", Name: "Juan Perez"}}, nil
+	}
+
+	result, err := uc.CreateNote(context.Background(), buildProcessNoteRequest())
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+
+	if result.InvoiceNumber != "NC1" {
+		t.Errorf("InvoiceNumber = %s, se esperaba NC1", result.InvoiceNumber)
+	}
+	note := deps.builder.Signed[len(deps.builder.Signed)-1]
+	if note.Reference == nil || note.Reference.CUFE != invoice.CUFE || note.Reference.Number != invoice.InvoiceNumber {
+		t.Fatalf("referencia inesperada: %+v", note.Reference)
+	}
+	if note.Customer.ID != "1020304050" {
+		t.Errorf("la nota debe ir al adquiriente de la factura: %+v", note.Customer)
+	}
+	if note.DiscrepancyCode != "1" {
+		t.Errorf("concepto = %s, se esperaba 1 (devolución parcial)", note.DiscrepancyCode)
+	}
+	if totals := note.Totals(); totals.Payable != 119000 {
+		t.Errorf("total de la nota = %.2f, se esperaba 119000", totals.Payable)
+	}
+}
+
+func TestCreateNote_FacturaDeOtroProveedor_UsaReferenciaDelMensaje(t *testing.T) {
+	uc, deps := newTestUseCase(false, buildIntegrationConfig())
+	req := buildProcessNoteRequest()
+	req.Config["invoice_number"] = "FE1500"
+	req.Config["invoice_cufe"] = "a1b2c3"
+	req.Config["invoice_issued_at"] = "2026-09-01T10:00:00-05:00"
+	req.Config["customer_dni"] = "1020304050"
+	req.Config["customer_name"] = "Juan Perez"
+
+	if _, err := uc.CreateNote(context.Background(), req); err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+
+	note := deps.builder.Signed[0]
+	if note.Reference.Number != "FE1500" || note.Reference.CUFE != "a1b2c3" {
+		t.Errorf("referencia inesperada: %+v", note.Reference)
+	}
+	if note.Customer.Name != "Juan Perez" {
+		t.Errorf("adquiriente inesperado: %+v", note.Customer)
+	}
+}
+
+func TestCreateNote_SinCUFEDeLaFactura_RetornaError(t *testing.T) {
+	uc, deps := newTestUseCase(false, buildIntegrationConfig())
+
+	_, err := uc.CreateNote(context.Background(), buildProcessNoteRequest())
+	if !errors.Is(err, dianErrors.ErrMissingBillingReference) {
+		t.Fatalf("se esperaba ErrMissingBillingReference, got %v", err)
+	}
+	if len(deps.repository.Counters) != 0 {
+		t.Error("no se debe reservar consecutivo sin referencia")
+	}
+}
+
+func TestCreateNote_SetDePruebas_EsperaLaValidacion(t *testing.T) {
+	config := buildIntegrationConfig()
+	config["test_set_id"] = "b3a1c2d4-set"
+	uc, deps := newTestUseCase(true, config)
+	if _, err := uc.CreateInvoice(context.Background(), buildProcessInvoiceRequest()); err != nil {
+		t.Fatal(err)
+	}
+
+	polls := 0
+	deps.webService.GetStatusZipFn = func(_ context.Context, _ string, _ *entities.Certificate, _ string) (*dtos.SendResult, error) {
+		polls++
+		if polls < 2 {
+			return &dtos.SendResult{StatusCode: "98"}, nil
+		}
+		return &dtos.SendResult{IsValid: true}, nil
+	}
+	// La factura quedó pendiente; se da por validada para poder referenciarla
+	key := deps.repository.Key(3, entities.DocumentKindInvoice, "42")
+	invoice := deps.repository.Documents[key]
+	invoice.Status = entities.DocumentStatusAccepted
+	deps.repository.Documents[key] = invoice
+
+	result, err := uc.CreateNote(context.Background(), buildProcessNoteRequest())
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if result.Pending || polls != 2 {
+		t.Errorf("se esperaba validación tras 2 consultas, got pending=%v polls=%d", result.Pending, polls)
+	}
+}
@@ -0,0 +1,140 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+)
+
+// unitCodeUnit unidad de medida "unidad" (tabla 13.3.6)
+const unitCodeUnit = "94"
+
+// paymentFormCash forma de pago de contado
+const paymentFormCash = "1"
+
+// invoiceDocument convierte la solicitud del módulo de facturación en la
+// factura UBL. Los precios de los items ya vienen sin impuestos (unit_price_base)
+// y la tarifa de IVA como fracción.
+func invoiceDocument(req *dtos.ProcessInvoiceRequest, settings *issuerSettings, consecutive int64) *entities.Document {
+	numbering := settings.Numbering
+	numbering.Number = consecutive
+
+	doc := &entities.Document{
+		Kind:             entities.DocumentKindInvoice,
+		Environment:      settings.Environment,
+		Numbering:        numbering,
+		Software:         settings.Software,
+		Currency:         currency(req.Currency),
+		Supplier:         settings.Supplier,
+		Customer:         customerParty(req.Customer, settings.CustomerIDType),
+		PaymentFormID:    paymentFormCash,
+		PaymentMeansCode: settings.PaymentMeansCode,
+	}
+	if req.OrderNumber != "" {
+		doc.Note = "Pedido " + req.OrderNumber
+	}
+
+	for _, item := range req.Items {
+		rate := 0.0
+		if item.TaxRate != nil {
+			rate = *item.TaxRate
+		}
+		base := item.UnitPriceBase
+		if base == 0 {
+			base = item.UnitPrice / (1 + rate)
+		}
+
+		code := item.SKU
+		if code == "" && item.ProductID != nil {
+			code = *item.ProductID
+		}
+		description := item.Name
+		if description == "" && item.Description != nil {
+			description = *item.Description
+		}
+
+		doc.Lines = append(doc.Lines, entities.Line{
+			Code:        code,
+			Description: description,
+			Quantity:    float64(item.Quantity),
+			UnitCode:    unitCodeUnit,
+			UnitPrice:   entities.Round(base),
+			Discount:    entities.Round(item.Discount),
+			TaxPercent:  entities.Round(rate * 100),
+		})
+	}
+
+	if req.ShippingCost > 0 {
+		base := req.ShippingCostBase
+		if base == 0 {
+			base = req.ShippingCost
+		}
+		doc.Lines = append(doc.Lines, entities.Line{
+			Code:        "ENVIO",
+			Description: "Envío",
+			Quantity:    1,
+			UnitCode:    unitCodeUnit,
+			UnitPrice:   entities.Round(base),
+			TaxPercent:  entities.Round((req.ShippingCost/base - 1) * 100),
+		})
+	}
+
+	return doc
+}
+
+// customerParty adquiriente del documento. Sin identificación se factura a
+// consumidor final; un DNI con guion (900123456-7) se toma como NIT con su
+// dígito de verificación.
+func customerParty(c dtos.CustomerData, idType string) entities.Party {
+	dni := strings.ReplaceAll(strings.TrimSpace(c.DNI), ".", "")
+	if dni == "" || dni == entities.FinalConsumerID {
+		return entities.Party{
+			Name:             "Consumidor final",
+			IDType:           entities.IDTypeCedula,
+			ID:               entities.FinalConsumerID,
+			OrganizationType: "2",
+			TaxLevelCode:     "R-99-PN",
+			TaxSchemeID:      "ZZ",
+			TaxSchemeName:    "No aplica",
+			Email:            c.Email,
+		}
+	}
+
+	party := entities.Party{
+		Name:             strings.TrimSpace(c.Name),
+		IDType:           idType,
+		ID:               dni,
+		OrganizationType: "2",
+		TaxLevelCode:     "R-99-PN",
+		TaxSchemeID:      "ZZ",
+		TaxSchemeName:    "No aplica",
+		Address:          entities.Address{Line: c.Address},
+		Email:            c.Email,
+		Phone:            c.Phone,
+	}
+
+	if nit, dv, ok := strings.Cut(dni, "-"); ok {
+		party.IDType = entities.IDTypeNIT
+		party.ID = nit
+		party.DV = dv
+	}
+	if party.IDType == entities.IDTypeNIT {
+		party.OrganizationType = "1"
+		if party.DV == "" {
+			party.DV = entities.VerificationDigit(party.ID)
+		}
+	}
+	if party.Name == "" {
+		party.Name = party.ID
+	}
+
+	return party
+}
+
+func currency(value string) string {
+	if value == "" {
+		return "COP"
+	}
+	return value
+}
@@ -0,0 +1,216 @@
+package app
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	dianErrors "github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/errors"
+)
+
+// issuerSettings configuración del facturador ya combinada y validada
+type issuerSettings struct {
+	Environment      string
+	Software         entities.Software
+	Supplier         entities.Party
+	Numbering        entities.Numbering // Resolución de facturación; Number se asigna al emitir
+	CreditNotePrefix string
+	DebitNotePrefix  string
+	TestSetID        string
+	PaymentMeansCode string
+	CustomerIDType   string
+	Certificate      *entities.Certificate
+}
+
+// loadSettings obtiene la integración, descifra las credenciales y combina la
+// configuración: primero la de la integración, luego la del request (prioridad)
+func (uc *invoicingUseCase) loadSettings(ctx context.Context, integrationID uint, requestConfig map[string]interface{}) (*issuerSettings, error) {
+	integrationIDStr := fmt.Sprintf("%d", integrationID)
+
+	integration, err := uc.integrationCore.GetIntegrationByID(ctx, integrationIDStr)
+	if err != nil {
+		uc.log.Error(ctx).Err(err).Str("integration_id", integrationIDStr).Msg("Failed to get integration")
+		return nil, fmt.Errorf("dian: integration not found (%s): %w", integrationIDStr, err)
+	}
+
+	config := make(map[string]interface{})
+	for k, v := range integration.Config {
+		config[k] = v
+	}
+	for k, v := range requestConfig {
+		config[k] = v
+	}
+
+	credentials := make(map[string]interface{})
+	for _, field := range []string{"certificate", "certificate_password", "software_pin", "technical_key"} {
+		value, err := uc.integrationCore.DecryptCredential(ctx, integrationIDStr, field)
+		if err != nil {
+			uc.log.Error(ctx).Err(err).Str("field", field).Msg("Failed to decrypt credential")
+			return nil, fmt.Errorf("dian: failed to decrypt %s: %w", field, err)
+		}
+		credentials[field] = value
+	}
+
+	testing := integration.IsTesting
+	if v, ok := config["is_testing"].(bool); ok {
+		testing = v
+	}
+
+	return uc.buildSettings(config, credentials, testing)
+}
+
+// buildSettings valida la configuración y carga el certificado de firma.
+// Se comparte con TestConnection, que recibe config y credenciales sin cifrar.
+func (uc *invoicingUseCase) buildSettings(config, credentials map[string]interface{}, testing bool) (*issuerSettings, error) {
+	required := []string{
+		"software_id", "issuer_nit", "issuer_dv", "issuer_name",
+		"issuer_city_code", "issuer_department_code", "issuer_address",
+		"resolution_number", "resolution_start_date", "resolution_end_date",
+		"range_from", "range_to",
+	}
+	for _, field := range required {
+		if configString(config, field) == "" {
+			return nil, fmt.Errorf("%w: %s", dianErrors.ErrMissingRequiredField, field)
+		}
+	}
+	for _, field := range []string{"certificate", "software_pin", "technical_key"} {
+		if configString(credentials, field) == "" {
+			return nil, fmt.Errorf("%w: %s", dianErrors.ErrMissingRequiredField, field)
+		}
+	}
+
+	startDate, err := configDate(config, "resolution_start_date")
+	if err != nil {
+		return nil, err
+	}
+	endDate, err := configDate(config, "resolution_end_date")
+	if err != nil {
+		return nil, err
+	}
+	rangeFrom, err := configInt(config, "range_from")
+	if err != nil {
+		return nil, err
+	}
+	rangeTo, err := configInt(config, "range_to")
+	if err != nil {
+		return nil, err
+	}
+	if rangeFrom <= 0 || rangeTo < rangeFrom {
+		return nil, fmt.Errorf("dian: rango de numeración inválido %d-%d", rangeFrom, rangeTo)
+	}
+
+	p12, err := base64.StdEncoding.DecodeString(configString(credentials, "certificate"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: el certificado debe estar en base64: %v", dianErrors.ErrInvalidCertificate, err)
+	}
+	cert, err := uc.certificates.LoadPKCS12(p12, configString(credentials, "certificate_password"))
+	if err != nil {
+		return nil, err
+	}
+	if now := uc.now(); now.After(cert.Leaf.NotAfter) || now.Before(cert.Leaf.NotBefore) {
+		return nil, fmt.Errorf("%w: vigente del %s al %s", dianErrors.ErrCertificateExpired,
+			cert.Leaf.NotBefore.Format("2006-01-02"), cert.Leaf.NotAfter.Format("2006-01-02"))
+	}
+
+	environment := entities.EnvironmentProduction
+	if testing {
+		environment = entities.EnvironmentTesting
+	}
+
+	nit := configString(config, "issuer_nit")
+	dv := configString(config, "issuer_dv")
+
+	return &issuerSettings{
+		Environment: environment,
+		Software: entities.Software{
+			ID:          configString(config, "software_id"),
+			PIN:         configString(credentials, "software_pin"),
+			ProviderNIT: nit,
+			ProviderDV:  dv,
+		},
+		Supplier: entities.Party{
+			Name:             configString(config, "issuer_name"),
+			TradeName:        configString(config, "issuer_trade_name"),
+			IDType:           entities.IDTypeNIT,
+			ID:               nit,
+			DV:               dv,
+			OrganizationType: configStringOr(config, "organization_type", "1"),
+			TaxLevelCode:     configStringOr(config, "tax_level_code", "O-13"),
+			TaxSchemeID:      "01",
+			TaxSchemeName:    "IVA",
+			Address: entities.Address{
+				CityCode:       configString(config, "issuer_city_code"),
+				CityName:       configString(config, "issuer_city_name"),
+				DepartmentCode: configString(config, "issuer_department_code"),
+				DepartmentName: configString(config, "issuer_department_name"),
+				Line:           configString(config, "issuer_address"),
+				PostalCode:     configString(config, "issuer_postal_code"),
+			},
+			Email: configString(config, "issuer_email"),
+			Phone: configString(config, "issuer_phone"),
+		},
+		Numbering: entities.Numbering{
+			Prefix:           configString(config, "prefix"),
+			ResolutionNumber: configString(config, "resolution_number"),
+			StartDate:        startDate,
+			EndDate:          endDate,
+			RangeFrom:        rangeFrom,
+			RangeTo:          rangeTo,
+			TechnicalKey:     configString(credentials, "technical_key"),
+		},
+		CreditNotePrefix: configStringOr(config, "credit_note_prefix", "NC"),
+		DebitNotePrefix:  configStringOr(config, "debit_note_prefix", "ND"),
+		TestSetID:        configString(config, "test_set_id"),
+		PaymentMeansCode: configStringOr(config, "payment_means_code", "10"),
+		CustomerIDType:   configStringOr(config, "customer_id_type", entities.IDTypeCedula),
+		Certificate:      cert,
+	}, nil
+}
+
+func configString(config map[string]interface{}, key string) string {
+	switch v := config[key].(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint:
+		return strconv.FormatUint(uint64(v), 10)
+	}
+	return ""
+}
+
+func configStringOr(config map[string]interface{}, key, fallback string) string {
+	if v := configString(config, key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func configInt(config map[string]interface{}, key string) (int64, error) {
+	value, err := strconv.ParseInt(configString(config, key), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("dian: %s debe ser un número entero", key)
+	}
+	return value, nil
+}
+
+func configFloat(config map[string]interface{}, key string) float64 {
+	value, _ := strconv.ParseFloat(configString(config, key), 64)
+	return value
+}
+
+func configDate(config map[string]interface{}, key string) (time.Time, error) {
+	value, err := time.Parse("2006-01-02", configString(config, key))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("dian: %s debe tener formato AAAA-MM-DD", key)
+	}
+	return value, nil
+}
@@ -0,0 +1,266 @@
+package app
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	dianErrors "github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/errors"
+)
+
+// duplicateRule regla con la que la DIAN rechaza un documento que ya validó
+const duplicateRule = "Regla: 90"
+
+// reserve toma el consecutivo y registra el documento antes de firmarlo,
+// para que un reintento reuse el mismo número en vez de dejar huecos
+func (uc *invoicingUseCase) reserve(ctx context.Context, integrationID, invoiceID uint, kind entities.DocumentKind, sourceID, prefix string, from, to int64) (*entities.StoredDocument, error) {
+	consecutive, err := uc.repository.ReserveConsecutive(ctx, integrationID, prefix, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := &entities.StoredDocument{
+		IntegrationID: integrationID,
+		InvoiceID:     invoiceID,
+		SourceID:      sourceID,
+		Kind:          kind,
+		Prefix:        prefix,
+		Consecutive:   consecutive,
+		Number:        entities.Numbering{Prefix: prefix, Number: consecutive}.FullNumber(),
+		Status:        entities.DocumentStatusSigned,
+	}
+	if err := uc.repository.SaveDocument(ctx, stored); err != nil {
+		return nil, err
+	}
+
+	uc.log.Info(ctx).
+		Uint("invoice_id", invoiceID).
+		Str("kind", string(kind)).
+		Str("number", stored.Number).
+		Msg("DIAN consecutive reserved")
+
+	return stored, nil
+}
+
+// submit firma el documento (o reusa la firma de un envío que no obtuvo respuesta),
+// lo empaqueta y lo envía. Al set de pruebas se envía de forma asíncrona y el
+// resultado queda pendiente hasta CheckStatus.
+func (uc *invoicingUseCase) submit(ctx context.Context, settings *issuerSettings, doc *entities.Document, stored *entities.StoredDocument, useTestSet bool) (*dtos.ProcessInvoiceResult, error) {
+	// Un documento firmado que no obtuvo respuesta se reenvía idéntico: mismo
+	// CUFE, misma fecha. Uno rechazado se vuelve a emitir con la fecha actual.
+	resend := stored.Status == entities.DocumentStatusSigned && len(stored.SignedXML) > 0
+	doc.IssuedAt = uc.now()
+	if resend {
+		doc.IssuedAt = stored.IssuedAt
+	}
+	doc.AssignUUID()
+
+	signedXML := stored.SignedXML
+	if !resend || stored.UUID != doc.UUID {
+		var err error
+		signedXML, err = uc.builder.BuildSignedDocument(doc, settings.Certificate)
+		if err != nil {
+			return &dtos.ProcessInvoiceResult{}, fmt.Errorf("dian: building %s: %w", stored.Number, err)
+		}
+
+		stored.UUID = doc.UUID
+		stored.IssuedAt = doc.IssuedAt
+		stored.SignedXML = signedXML
+		stored.Status = entities.DocumentStatusSigned
+		stored.ZipKey = ""
+		stored.StatusMessage = ""
+		stored.Errors = nil
+		if err := uc.repository.SaveDocument(ctx, stored); err != nil {
+			return &dtos.ProcessInvoiceResult{}, err
+		}
+	}
+
+	zipName, zip, err := uc.builder.Package(doc, signedXML)
+	if err != nil {
+		return &dtos.ProcessInvoiceResult{}, err
+	}
+
+	uc.log.Info(ctx).
+		Str("number", stored.Number).
+		Str("uuid", stored.UUID).
+		Str("zip", zipName).
+		Bool("test_set", useTestSet).
+		Msg("Sending document to DIAN")
+
+	if useTestSet {
+		res, err := uc.webService.SendTestSetAsync(ctx, settings.Environment, settings.Certificate, zipName, zip, settings.TestSetID)
+		if err != nil {
+			return failure(res, err)
+		}
+		if res.ZipKey == "" {
+			return uc.reject(ctx, settings, stored, res)
+		}
+
+		stored.Status = entities.DocumentStatusPending
+		stored.ZipKey = res.ZipKey
+		if err := uc.repository.SaveDocument(ctx, stored); err != nil {
+			return failure(res, err)
+		}
+		return uc.pending(settings, stored, res), nil
+	}
+
+	res, err := uc.webService.SendBillSync(ctx, settings.Environment, settings.Certificate, zipName, zip)
+	if err != nil {
+		return failure(res, err)
+	}
+	return uc.applyValidation(ctx, settings, stored, res)
+}
+
+// poll consulta un envío pendiente del set de pruebas
+func (uc *invoicingUseCase) poll(ctx context.Context, settings *issuerSettings, stored *entities.StoredDocument) (*dtos.ProcessInvoiceResult, error) {
+	res, err := uc.webService.GetStatusZip(ctx, settings.Environment, settings.Certificate, stored.ZipKey)
+	if err != nil {
+		return failure(res, err)
+	}
+	return uc.applyValidation(ctx, settings, stored, res)
+}
+
+// applyValidation registra la respuesta de validación de la DIAN
+func (uc *invoicingUseCase) applyValidation(ctx context.Context, settings *issuerSettings, stored *entities.StoredDocument, res *dtos.SendResult) (*dtos.ProcessInvoiceResult, error) {
+	// El reenvío de un documento que sí alcanzó a validarse se rechaza por
+	// duplicado; la ApplicationResponse original se recupera por el CUFE.
+	if !res.IsValid && isDuplicate(res) {
+		status, err := uc.webService.GetStatus(ctx, settings.Environment, settings.Certificate, stored.UUID)
+		if err == nil && status.IsValid {
+			res = status
+		}
+	}
+
+	switch {
+	case res.IsValid:
+		return uc.accept(ctx, settings, stored, res)
+	case stored.Status == entities.DocumentStatusPending && res.IsProcessing():
+		return uc.pending(settings, stored, res), nil
+	default:
+		return uc.reject(ctx, settings, stored, res)
+	}
+}
+
+func (uc *invoicingUseCase) accept(ctx context.Context, settings *issuerSettings, stored *entities.StoredDocument, res *dtos.SendResult) (*dtos.ProcessInvoiceResult, error) {
+	stored.Status = entities.DocumentStatusAccepted
+	stored.StatusMessage = res.StatusMessage
+	stored.Errors = res.Errors // Notificaciones: no impiden la validación
+	stored.ApplicationResponse = res.ApplicationResponse
+
+	if len(res.ApplicationResponse) > 0 {
+		attached, err := uc.builder.BuildAttachedDocument(stored.SignedXML, res.ApplicationResponse, settings.Certificate)
+		if err != nil {
+			// El documento ya es válido ante la DIAN; el contenedor se puede regenerar
+			uc.log.Warn(ctx).Err(err).Str("number", stored.Number).Msg("Failed to build AttachedDocument")
+		} else {
+			stored.AttachedDocument = attached
+		}
+	}
+
+	if err := uc.repository.SaveDocument(ctx, stored); err != nil {
+		return failure(res, err)
+	}
+
+	uc.log.Info(ctx).
+		Str("number", stored.Number).
+		Str("uuid", stored.UUID).
+		Int("notifications", len(res.Errors)).
+		Msg("Document accepted by DIAN")
+
+	result := resultFromStored(settings, stored)
+	result.AuditData = res.AuditData
+	return result, nil
+}
+
+func (uc *invoicingUseCase) reject(ctx context.Context, settings *issuerSettings, stored *entities.StoredDocument, res *dtos.SendResult) (*dtos.ProcessInvoiceResult, error) {
+	stored.Status = entities.DocumentStatusRejected
+	stored.StatusMessage = res.StatusMessage
+	if stored.StatusMessage == "" {
+		stored.StatusMessage = res.StatusDescription
+	}
+	stored.Errors = res.Errors
+	if err := uc.repository.SaveDocument(ctx, stored); err != nil {
+		return failure(res, err)
+	}
+
+	uc.log.Warn(ctx).
+		Str("number", stored.Number).
+		Str("status_code", res.StatusCode).
+		Strs("errors", res.Errors).
+		Msg("Document rejected by DIAN")
+
+	detail := strings.Join(res.Errors, "; ")
+	if detail == "" {
+		detail = stored.StatusMessage
+	}
+	result := resultFromStored(settings, stored)
+	result.AuditData = res.AuditData
+	return result, fmt.Errorf("%w: %s", dianErrors.ErrDocumentRejected, detail)
+}
+
+func (uc *invoicingUseCase) pending(settings *issuerSettings, stored *entities.StoredDocument, res *dtos.SendResult) *dtos.ProcessInvoiceResult {
+	result := resultFromStored(settings, stored)
+	result.Pending = true
+	result.AuditData = res.AuditData
+	return result
+}
+
+// failure conserva el AuditData del intento fallido para el consumer
+func failure(res *dtos.SendResult, err error) (*dtos.ProcessInvoiceResult, error) {
+	result := &dtos.ProcessInvoiceResult{}
+	if res != nil {
+		result.AuditData = res.AuditData
+	}
+	return result, err
+}
+
+func isDuplicate(res *dtos.SendResult) bool {
+	for _, e := range res.Errors {
+		if strings.Contains(e, duplicateRule+",") {
+			return true
+		}
+	}
+	return false
+}
+
+// resultFromStored arma la respuesta a partir del documento registrado
+func resultFromStored(settings *issuerSettings, stored *entities.StoredDocument) *dtos.ProcessInvoiceResult {
+	qr := (&entities.Document{Environment: settings.Environment, UUID: stored.UUID}).QRCodeURL()
+
+	document := map[string]interface{}{
+		"kind":        string(stored.Kind),
+		"number":      stored.Number,
+		"uuid":        stored.UUID,
+		"status":      string(stored.Status),
+		"environment": settings.Environment,
+	}
+	if stored.ZipKey != "" {
+		document["zip_key"] = stored.ZipKey
+	}
+	if stored.StatusMessage != "" {
+		document["status_message"] = stored.StatusMessage
+	}
+	if len(stored.Errors) > 0 {
+		document["messages"] = stored.Errors
+	}
+	if len(stored.AttachedDocument) > 0 {
+		document["attached_document"] = base64.StdEncoding.EncodeToString(stored.AttachedDocument)
+	}
+
+	result := &dtos.ProcessInvoiceResult{
+		InvoiceNumber: stored.Number,
+		ExternalID:    stored.UUID,
+		CUFE:          stored.UUID,
+		QRCode:        qr,
+		ZipKey:        stored.ZipKey,
+		Document:      document,
+	}
+	if !stored.IssuedAt.IsZero() {
+		result.IssuedAt = stored.IssuedAt.Format(time.RFC3339)
+	}
+	return result
+}
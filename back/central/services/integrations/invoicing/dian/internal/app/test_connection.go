@@ -0,0 +1,27 @@
+package app
+
+import (
+	"context"
+)
+
+// TestConnection valida la configuración del facturador y que el certificado
+// de firma abra con su contraseña y esté vigente. La DIAN no ofrece un servicio
+// de autenticación: la prueba real es el set de pruebas de habilitación.
+// Implementa ports.IInvoiceUseCase.TestConnection.
+func (uc *invoicingUseCase) TestConnection(ctx context.Context, config map[string]interface{}, credentials map[string]interface{}) error {
+	uc.log.Info(ctx).Msg("🧪 Testing DIAN issuer configuration")
+
+	testing, _ := config["is_testing"].(bool)
+	settings, err := uc.buildSettings(config, credentials, testing)
+	if err != nil {
+		uc.log.Error(ctx).Err(err).Msg("❌ DIAN configuration test failed")
+		return err
+	}
+
+	uc.log.Info(ctx).
+		Str("issuer_nit", settings.Supplier.ID).
+		Str("certificate_subject", settings.Certificate.Leaf.Subject.CommonName).
+		Time("certificate_expires_at", settings.Certificate.Leaf.NotAfter).
+		Msg("✅ DIAN configuration test successful")
+	return nil
+}
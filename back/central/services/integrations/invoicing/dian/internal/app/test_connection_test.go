@@ -0,0 +1,48 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	dianErrors "github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/errors"
+)
+
+func buildCredentials() map[string]interface{} {
+	return map[string]interface{}{
+		"certificate":          "cDEy",
+		"certificate_password": "dian-pruebas",
+		"software_pin":         "75315",
+		"technical_key":        "fc8eac422eba16e22ffd8c6f94b3f40a6e38162c",
+	}
+}
+
+func TestTestConnection_ConfiguracionCompleta(t *testing.T) {
+	uc, _ := newTestUseCase(false, nil)
+
+	if err := uc.TestConnection(context.Background(), buildIntegrationConfig(), buildCredentials()); err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+}
+
+func TestTestConnection_SinPIN_RetornaError(t *testing.T) {
+	uc, _ := newTestUseCase(false, nil)
+	credentials := buildCredentials()
+	delete(credentials, "software_pin")
+
+	err := uc.TestConnection(context.Background(), buildIntegrationConfig(), credentials)
+	if !errors.Is(err, dianErrors.ErrMissingRequiredField) {
+		t.Fatalf("se esperaba ErrMissingRequiredField, got %v", err)
+	}
+}
+
+func TestTestConnection_CertificadoSinBase64_RetornaError(t *testing.T) {
+	uc, _ := newTestUseCase(false, nil)
+	credentials := buildCredentials()
+	credentials["certificate"] = "no es base64!"
+
+	err := uc.TestConnection(context.Background(), buildIntegrationConfig(), credentials)
+	if !errors.Is(err, dianErrors.ErrInvalidCertificate) {
+		t.Fatalf("se esperaba ErrInvalidCertificate, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package dtos
+
+import "github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+
+// CustomerData datos del adquiriente recibidos del módulo de facturación
+type CustomerData struct {
+	Name    string
+	Email   string
+	Phone   string
+	DNI     string
+	Address string
+}
+
+// ItemData item de la factura recibido del módulo de facturación
+type ItemData struct {
+	ProductID     *string
+	SKU           string
+	Name          string
+	Description   *string
+	Quantity      int
+	UnitPrice     float64
+	UnitPriceBase float64 // Precio sin impuestos
+	TotalPrice    float64
+	Tax           float64
+	TaxRate       *float64 // Fracción: 0.19 = 19%
+	Discount      float64
+}
+
+// ProcessInvoiceRequest es el input del caso de uso para emitir una factura.
+// No contiene credenciales — el use case las obtiene y descifra desde la base de datos.
+type ProcessInvoiceRequest struct {
+	InvoiceID        uint
+	Operation        string
+	CorrelationID    string
+	IntegrationID    uint
+	Customer         CustomerData
+	Items            []ItemData
+	Total            float64
+	Subtotal         float64
+	Tax              float64
+	Discount         float64
+	ShippingCost     float64
+	ShippingCostBase float64
+	Currency         string
+	OrderID          string
+	OrderNumber      string
+	Config           map[string]interface{}
+}
+
+// ProcessNoteRequest es el input del caso de uso para emitir una nota crédito o débito.
+// Los datos de la nota viajan en Config (credit_note_id, amount, reason, invoice_cufe...).
+type ProcessNoteRequest struct {
+	InvoiceID     uint
+	Kind          entities.DocumentKind
+	CorrelationID string
+	IntegrationID uint
+	Config        map[string]interface{}
+}
+
+// ProcessInvoiceResult es el output del caso de uso.
+// Se retorna siempre (incluso en error) para propagar el AuditData hacia el consumer.
+type ProcessInvoiceResult struct {
+	InvoiceNumber string
+	ExternalID    string
+	CUFE          string
+	QRCode        string
+	IssuedAt      string
+	Pending       bool // Enviado al set de pruebas: la DIAN valida de forma asíncrona
+	ZipKey        string
+	Document      map[string]interface{}
+	AuditData     *AuditData
+}
+
+// AuditData contiene datos de auditoría del request/response SOAP
+type AuditData struct {
+	RequestURL     string
+	RequestPayload interface{}
+	ResponseStatus int
+	ResponseBody   string
+}
+
+// SendResult respuesta de los servicios SendBillSync, SendTestSetAsync y GetStatusZip
+type SendResult struct {
+	IsValid             bool
+	StatusCode          string
+	StatusDescription   string
+	StatusMessage       string
+	Errors              []string
+	ZipKey              string
+	DocumentKey         string
+	ApplicationResponse []byte
+	AuditData           *AuditData
+}
+
+// IsProcessing indica que la DIAN aún no termina de validar el ZIP (GetStatusZip)
+func (r *SendResult) IsProcessing() bool {
+	return !r.IsValid && (r.StatusCode == "" || r.StatusCode == "98")
+}
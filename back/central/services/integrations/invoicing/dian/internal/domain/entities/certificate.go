@@ -0,0 +1,13 @@
+package entities
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+)
+
+// Certificate certificado de firma digital del facturador, extraído del PKCS#12
+type Certificate struct {
+	Leaf       *x509.Certificate
+	Chain      []*x509.Certificate // Intermedios y raíz, en el orden del contenedor
+	PrivateKey *rsa.PrivateKey
+}
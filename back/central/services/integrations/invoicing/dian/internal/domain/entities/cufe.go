@@ -0,0 +1,84 @@
+package entities
+
+import (
+	"crypto/sha512"
+	"encoding/hex"
+	"time"
+)
+
+// colombiaTZ zona horaria en la que la DIAN espera fecha y hora de emisión
+var colombiaTZ = time.FixedZone("COT", -5*60*60)
+
+// Códigos de impuesto que entran en el CUFE/CUDE (IVA, INC, ICA)
+const (
+	taxCodeIVA = "01"
+	taxCodeINC = "04"
+	taxCodeICA = "03"
+)
+
+// IssueDate fecha de emisión en formato del anexo (AAAA-MM-DD, hora de Colombia)
+func (d *Document) IssueDate() string {
+	return d.IssuedAt.In(colombiaTZ).Format("2006-01-02")
+}
+
+// IssueTime hora de emisión con zona horaria (HH:MM:SS-05:00)
+func (d *Document) IssueTime() string {
+	return d.IssuedAt.In(colombiaTZ).Format("15:04:05-07:00")
+}
+
+// UUIDSchemeName CUFE-SHA384 para facturas, CUDE-SHA384 para notas
+func (d *Document) UUIDSchemeName() string {
+	if d.Kind.IsNote() {
+		return "CUDE-SHA384"
+	}
+	return "CUFE-SHA384"
+}
+
+// UUIDSeed cadena que se cifra para obtener el CUFE o el CUDE (anexo 11.2 y 11.3).
+// Para facturas la llave es la clave técnica de la resolución; para notas, el PIN del software.
+func (d *Document) UUIDSeed() string {
+	totals := d.Totals()
+
+	key := d.Numbering.TechnicalKey
+	if d.Kind.IsNote() {
+		key = d.Software.PIN
+	}
+
+	return d.Numbering.FullNumber() +
+		d.IssueDate() +
+		d.IssueTime() +
+		FormatAmount(totals.LineExtension) +
+		taxCodeIVA + FormatAmount(totals.IVA) +
+		taxCodeINC + FormatAmount(0) +
+		taxCodeICA + FormatAmount(0) +
+		FormatAmount(totals.Payable) +
+		d.Supplier.ID +
+		d.Customer.ID +
+		key +
+		d.Environment
+}
+
+// AssignUUID calcula el CUFE/CUDE y lo deja en el documento
+func (d *Document) AssignUUID() string {
+	d.UUID = sha384Hex(d.UUIDSeed())
+	return d.UUID
+}
+
+// SoftwareSecurityCode huella del software para el número de documento (anexo 11.4)
+func (d *Document) SoftwareSecurityCode() string {
+	return sha384Hex(d.Software.ID + d.Software.PIN + d.Numbering.FullNumber())
+}
+
+// QRCodeURL URL de consulta del documento en el catálogo de la DIAN
+func (d *Document) QRCodeURL() string {
+	host := "https://catalogo-vpfe.dian.gov.co"
+	if d.Environment == EnvironmentTesting {
+		host = "https://catalogo-vpfe-hab.dian.gov.co"
+	}
+	return host + "/document/searchqr?documentkey=" + d.UUID
+}
+
+func sha384Hex(s string) string {
+	sum := sha512.Sum384([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,129 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+// annexInvoice reproduce el ejemplo de CUFE del anexo técnico de factura electrónica
+func annexInvoice() *Document {
+	return &Document{
+		Kind:        DocumentKindInvoice,
+		Environment: EnvironmentProduction,
+		Numbering: Numbering{
+			Number:       323200000129,
+			TechnicalKey: "693ff6f2a553c3646a063436fd4dd9ded0311471",
+		},
+		IssuedAt: time.Date(2019, 1, 16, 15, 53, 10, 0, time.UTC),
+		Supplier: Party{ID: "700085371"},
+		Customer: Party{ID: "800199436"},
+		Lines: []Line{
+			{Description: "Servicio", Quantity: 1, UnitPrice: 1500000, TaxPercent: 19},
+		},
+	}
+}
+
+func TestUUIDSeed_Factura_ConcatenaCamposDelAnexo(t *testing.T) {
+	doc := annexInvoice()
+
+	want := "323200000129" + "2019-01-16" + "10:53:10-05:00" + "1500000.00" +
+		"01" + "285000.00" + "04" + "0.00" + "03" + "0.00" + "1785000.00" +
+		"700085371" + "800199436" + "693ff6f2a553c3646a063436fd4dd9ded0311471" + "1"
+
+	if got := doc.UUIDSeed(); got != want {
+		t.Fatalf("semilla del CUFE inesperada:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestAssignUUID_Factura_CoincideConVectorDelAnexo(t *testing.T) {
+	doc := annexInvoice()
+
+	want := "8bb918b19ba22a694f1da11c643b5e9de39adf60311cf179179e9b33381030bcd4c3c3f156c506ed5908f9276f5bd9b4"
+	if got := doc.AssignUUID(); got != want {
+		t.Fatalf("CUFE inesperado:\n got: %s\nwant: %s", got, want)
+	}
+	if doc.UUID != want {
+		t.Fatalf("el CUFE no quedó asignado en el documento")
+	}
+	if doc.UUIDSchemeName() != "CUFE-SHA384" {
+		t.Fatalf("scheme inesperado: %s", doc.UUIDSchemeName())
+	}
+}
+
+func TestAssignUUID_NotaCredito_UsaPINDelSoftware(t *testing.T) {
+	doc := annexInvoice()
+	doc.Kind = DocumentKindCreditNote
+	doc.Environment = EnvironmentTesting
+	doc.Numbering = Numbering{Prefix: "NC", Number: 101, TechnicalKey: "no-se-usa-en-notas"}
+	doc.Software = Software{ID: "56f2ae4e-9812-4fad-9255-08fcfcd5ccb0", PIN: "75315"}
+
+	want := "93bc0a55f7b8199620a9bdd289a4c408a844c121c9c94cb2adc3916df2f77aa838f989a93769f84028958406a865749e"
+	if got := doc.AssignUUID(); got != want {
+		t.Fatalf("CUDE inesperado:\n got: %s\nwant: %s", got, want)
+	}
+	if doc.UUIDSchemeName() != "CUDE-SHA384" {
+		t.Fatalf("scheme inesperado: %s", doc.UUIDSchemeName())
+	}
+}
+
+func TestSoftwareSecurityCode_ConcatenaSoftwarePINYNumero(t *testing.T) {
+	doc := &Document{
+		Numbering: Numbering{Prefix: "SETP", Number: 990000002},
+		Software:  Software{ID: "56f2ae4e-9812-4fad-9255-08fcfcd5ccb0", PIN: "75315"},
+	}
+
+	want := "42a1a42f8ef88eca6c3eb4f76e7fbd0fab243c73bafb26785c0f757fe0af4d1714c952a4247282eea8ae82e6f09902d9"
+	if got := doc.SoftwareSecurityCode(); got != want {
+		t.Fatalf("código de seguridad inesperado:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestTotals_AgrupaIVAPorTarifaYRedondea(t *testing.T) {
+	doc := &Document{Lines: []Line{
+		{Quantity: 3, UnitPrice: 10000.333, TaxPercent: 19},
+		{Quantity: 1, UnitPrice: 5000, Discount: 500, TaxPercent: 19},
+		{Quantity: 2, UnitPrice: 2500, TaxPercent: 0},
+	}}
+
+	totals := doc.Totals()
+
+	if totals.LineExtension != 39501.00 {
+		t.Errorf("LineExtension = %.2f, esperaba 39501.00", totals.LineExtension)
+	}
+	if len(totals.Subtotals) != 2 {
+		t.Fatalf("esperaba 2 subtotales de IVA, recibí %d", len(totals.Subtotals))
+	}
+	if totals.Subtotals[0].Taxable != 34501.00 || totals.Subtotals[0].Amount != 6555.19 {
+		t.Errorf("subtotal 19%% inesperado: %+v", totals.Subtotals[0])
+	}
+	if totals.TaxExclusive != 34501.00 {
+		t.Errorf("TaxExclusive = %.2f, esperaba 34501.00", totals.TaxExclusive)
+	}
+	if totals.Payable != 46056.19 {
+		t.Errorf("Payable = %.2f, esperaba 46056.19", totals.Payable)
+	}
+}
+
+func TestQRCodeURL_Habilitacion_UsaCatalogoDePruebas(t *testing.T) {
+	doc := &Document{Environment: EnvironmentTesting, UUID: "abc"}
+
+	want := "https://catalogo-vpfe-hab.dian.gov.co/document/searchqr?documentkey=abc"
+	if got := doc.QRCodeURL(); got != want {
+		t.Fatalf("QR inesperado: %s", got)
+	}
+}
+
+func TestVerificationDigit_NITsConocidos(t *testing.T) {
+	cases := map[string]string{
+		"800197268": "4", // DIAN
+		"860034313": "7",
+		"900123456": "8",
+		"90012345A": "",
+		"":          "",
+	}
+	for nit, want := range cases {
+		if got := VerificationDigit(nit); got != want {
+			t.Errorf("VerificationDigit(%q) = %q, se esperaba %q", nit, got, want)
+		}
+	}
+}
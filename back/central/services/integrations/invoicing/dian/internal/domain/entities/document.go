@@ -0,0 +1,234 @@
+package entities
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// DocumentKind tipo de documento electrónico que se emite ante la DIAN
+type DocumentKind string
+
+const (
+	DocumentKindInvoice    DocumentKind = "invoice"
+	DocumentKindCreditNote DocumentKind = "credit_note"
+	DocumentKindDebitNote  DocumentKind = "debit_note"
+)
+
+// TypeCode código del tipo de documento según la tabla 13.1.3 del anexo técnico
+func (k DocumentKind) TypeCode() string {
+	switch k {
+	case DocumentKindCreditNote:
+		return "91"
+	case DocumentKindDebitNote:
+		return "92"
+	default:
+		return "01"
+	}
+}
+
+// FilePrefix prefijo del nombre de archivo XML según la sección 6.1 del anexo técnico
+func (k DocumentKind) FilePrefix() string {
+	switch k {
+	case DocumentKindCreditNote:
+		return "nc"
+	case DocumentKindDebitNote:
+		return "nd"
+	default:
+		return "fv"
+	}
+}
+
+// IsNote indica si el documento es una nota (lleva CUDE y referencia a la factura)
+func (k DocumentKind) IsNote() bool {
+	return k == DocumentKindCreditNote || k == DocumentKindDebitNote
+}
+
+// Ambientes de la DIAN (ProfileExecutionID)
+const (
+	EnvironmentProduction = "1"
+	EnvironmentTesting    = "2"
+)
+
+// Tipos de documento de identificación más usados (tabla 13.2.1)
+const (
+	IDTypeCedula = "13"
+	IDTypeNIT    = "31"
+)
+
+// Identificación genérica de consumidor final aceptada por la DIAN
+const FinalConsumerID = "222222222222"
+
+// NIT de la DIAN como proveedor de autorización
+const DianNIT = "800197268"
+
+// Address dirección de una de las partes (códigos DANE)
+type Address struct {
+	CityCode       string // Código DANE del municipio (5 dígitos)
+	CityName       string
+	DepartmentCode string // Código DANE del departamento (2 dígitos)
+	DepartmentName string
+	Line           string
+	PostalCode     string
+	CountryCode    string // ISO 3166-1 alfa-2, por defecto CO
+}
+
+// Party emisor o adquiriente del documento
+type Party struct {
+	Name             string
+	TradeName        string
+	IDType           string // Tabla 13.2.1: 31 NIT, 13 cédula...
+	ID               string // Número sin dígito de verificación
+	DV               string // Dígito de verificación (solo NIT)
+	OrganizationType string // 1 persona jurídica, 2 persona natural
+	TaxLevelCode     string // Responsabilidades fiscales: O-13, O-15, R-99-PN...
+	TaxSchemeID      string // 01 IVA, ZZ no aplica
+	TaxSchemeName    string
+	Address          Address
+	Email            string
+	Phone            string
+}
+
+// Line línea del documento. Los montos van sin impuestos.
+type Line struct {
+	Code        string
+	Description string
+	Quantity    float64
+	UnitCode    string // Tabla 13.3.6, 94 = unidad
+	UnitPrice   float64
+	Discount    float64
+	TaxPercent  float64 // Tarifa de IVA en porcentaje (19, 5, 0)
+}
+
+// Amount base gravable de la línea
+func (l Line) Amount() float64 {
+	return Round(l.Quantity*l.UnitPrice - l.Discount)
+}
+
+// TaxAmount IVA de la línea
+func (l Line) TaxAmount() float64 {
+	return Round(l.Amount() * l.TaxPercent / 100)
+}
+
+// Numbering numeración autorizada por la DIAN para el documento
+type Numbering struct {
+	Prefix           string
+	Number           int64
+	ResolutionNumber string
+	StartDate        time.Time
+	EndDate          time.Time
+	RangeFrom        int64
+	RangeTo          int64
+	TechnicalKey     string // Clave técnica de la resolución (ClTec), solo facturas
+}
+
+// FullNumber número completo del documento: prefijo + consecutivo
+func (n Numbering) FullNumber() string {
+	return n.Prefix + strconv.FormatInt(n.Number, 10)
+}
+
+// Software datos del software propio registrado ante la DIAN
+type Software struct {
+	ID          string
+	PIN         string
+	ProviderNIT string
+	ProviderDV  string
+}
+
+// BillingReference factura a la que hace referencia una nota
+type BillingReference struct {
+	Number    string
+	CUFE      string
+	IssueDate time.Time
+}
+
+// Document documento electrónico (factura, nota crédito o nota débito)
+// listo para serializar en UBL 2.1
+type Document struct {
+	Kind        DocumentKind
+	Environment string
+	Numbering   Numbering
+	Software    Software
+	IssuedAt    time.Time
+	Currency    string
+	Note        string
+
+	Supplier Party
+	Customer Party
+
+	PaymentFormID    string // 1 contado, 2 crédito
+	PaymentMeansCode string // Tabla 13.3.4.2, 10 = efectivo
+
+	Lines []Line
+
+	// Solo notas
+	Reference         *BillingReference
+	DiscrepancyCode   string
+	DiscrepancyReason string
+
+	// UUID es el CUFE (facturas) o CUDE (notas); lo calcula AssignUUID
+	UUID string
+}
+
+// TaxSubtotal agrupación de IVA por tarifa
+type TaxSubtotal struct {
+	Percent float64
+	Taxable float64
+	Amount  float64
+}
+
+// Totals totales monetarios del documento
+type Totals struct {
+	LineExtension float64
+	TaxExclusive  float64
+	TaxInclusive  float64
+	Payable       float64
+	IVA           float64
+	Subtotals     []TaxSubtotal
+}
+
+// Totals calcula los totales a partir de las líneas, agrupando el IVA por tarifa
+// en el orden en que aparece cada tarifa
+func (d *Document) Totals() Totals {
+	var t Totals
+	index := map[float64]int{}
+	for _, line := range d.Lines {
+		amount := line.Amount()
+		t.LineExtension += amount
+
+		i, ok := index[line.TaxPercent]
+		if !ok {
+			i = len(t.Subtotals)
+			index[line.TaxPercent] = i
+			t.Subtotals = append(t.Subtotals, TaxSubtotal{Percent: line.TaxPercent})
+		}
+		t.Subtotals[i].Taxable += amount
+		t.Subtotals[i].Amount += line.TaxAmount()
+	}
+
+	for i := range t.Subtotals {
+		t.Subtotals[i].Taxable = Round(t.Subtotals[i].Taxable)
+		t.Subtotals[i].Amount = Round(t.Subtotals[i].Amount)
+		t.IVA += t.Subtotals[i].Amount
+		if t.Subtotals[i].Percent > 0 {
+			t.TaxExclusive += t.Subtotals[i].Taxable
+		}
+	}
+
+	t.LineExtension = Round(t.LineExtension)
+	t.TaxExclusive = Round(t.TaxExclusive)
+	t.IVA = Round(t.IVA)
+	t.TaxInclusive = Round(t.LineExtension + t.IVA)
+	t.Payable = t.TaxInclusive
+	return t
+}
+
+// Round redondea a dos decimales, la precisión que exige el anexo técnico
+func Round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// FormatAmount formatea un monto con dos decimales y punto decimal
+func FormatAmount(v float64) string {
+	return strconv.FormatFloat(Round(v), 'f', 2, 64)
+}
@@ -0,0 +1,28 @@
+package entities
+
+import "strconv"
+
+// nitWeights pesos del módulo 11 que usa la DIAN para el dígito de verificación,
+// aplicados desde el último dígito del NIT
+var nitWeights = []int{3, 7, 13, 17, 19, 23, 29, 37, 41, 43, 47, 53, 59, 67, 71}
+
+// VerificationDigit calcula el dígito de verificación de un NIT.
+// Retorna vacío si el NIT no es numérico o es más largo de lo que admite el RUT.
+func VerificationDigit(nit string) string {
+	if nit == "" || len(nit) > len(nitWeights) {
+		return ""
+	}
+	sum := 0
+	for i := 0; i < len(nit); i++ {
+		c := nit[len(nit)-1-i]
+		if c < '0' || c > '9' {
+			return ""
+		}
+		sum += int(c-'0') * nitWeights[i]
+	}
+	r := sum % 11
+	if r > 1 {
+		return strconv.Itoa(11 - r)
+	}
+	return strconv.Itoa(r)
+}
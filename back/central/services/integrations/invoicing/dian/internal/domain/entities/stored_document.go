@@ -0,0 +1,45 @@
+package entities
+
+import "time"
+
+// DocumentStatus estado del documento frente a la DIAN
+type DocumentStatus string
+
+const (
+	DocumentStatusSigned   DocumentStatus = "signed"   // Firmado, aún no aceptado
+	DocumentStatusPending  DocumentStatus = "pending"  // Enviado al set de pruebas, esperando validación
+	DocumentStatusAccepted DocumentStatus = "accepted" // Validado por la DIAN
+	DocumentStatusRejected DocumentStatus = "rejected" // Rechazado; el consecutivo se reutiliza al reintentar
+)
+
+// StoredDocument documento emitido y su rastro ante la DIAN.
+// Se guarda antes de enviar para que un reintento reuse el mismo consecutivo.
+type StoredDocument struct {
+	ID            uint
+	IntegrationID uint
+	InvoiceID     uint
+	SourceID      string // ID de la factura o de la nota en el módulo de facturación
+	Kind          DocumentKind
+	Prefix        string
+	Consecutive   int64
+	Number        string
+	UUID          string
+	IssuedAt      time.Time
+	Status        DocumentStatus
+	ZipKey        string
+
+	SignedXML           []byte
+	ApplicationResponse []byte
+	AttachedDocument    []byte
+
+	StatusMessage string
+	Errors        []string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// IsAccepted indica si la DIAN ya validó el documento
+func (d *StoredDocument) IsAccepted() bool {
+	return d.Status == DocumentStatusAccepted
+}
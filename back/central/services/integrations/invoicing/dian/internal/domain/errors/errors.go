@@ -0,0 +1,26 @@
+package errors
+
+import "errors"
+
+var (
+	// ErrMissingRequiredField indica que falta un campo requerido en la configuración
+	ErrMissingRequiredField = errors.New("missing required field for dian")
+
+	// ErrInvalidCertificate indica que el PKCS#12 no se pudo leer o no trae llave RSA
+	ErrInvalidCertificate = errors.New("invalid dian signing certificate")
+
+	// ErrCertificateExpired indica que el certificado de firma está vencido
+	ErrCertificateExpired = errors.New("dian signing certificate expired")
+
+	// ErrNumberingExhausted indica que se agotó el rango de la resolución de numeración
+	ErrNumberingExhausted = errors.New("dian numbering range exhausted")
+
+	// ErrDocumentRejected indica que la DIAN rechazó el documento
+	ErrDocumentRejected = errors.New("document rejected by dian")
+
+	// ErrWebService indica una falla de comunicación con el web service de la DIAN
+	ErrWebService = errors.New("dian web service call failed")
+
+	// ErrMissingBillingReference indica que una nota no trae la factura que ajusta
+	ErrMissingBillingReference = errors.New("dian note requires the referenced invoice cufe")
+)
@@ -0,0 +1,82 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+)
+
+// CERTIFICADO DE FIRMA (Secondary Port)
+
+// ICertificateLoader lee el PKCS#12 del facturador
+type ICertificateLoader interface {
+	LoadPKCS12(data []byte, password string) (*entities.Certificate, error)
+}
+
+// GENERADOR UBL (Secondary Port)
+
+// IDocumentBuilder serializa, firma y empaqueta los documentos electrónicos
+type IDocumentBuilder interface {
+	// BuildSignedDocument genera el XML UBL 2.1 firmado con XAdES-EPES
+	BuildSignedDocument(doc *entities.Document, cert *entities.Certificate) ([]byte, error)
+
+	// BuildAttachedDocument genera el contenedor firmado que se entrega al adquiriente
+	BuildAttachedDocument(signedXML, applicationResponse []byte, cert *entities.Certificate) ([]byte, error)
+
+	// ReadDocument recupera el encabezado y las partes de un documento ya firmado
+	ReadDocument(signedXML []byte) (*entities.Document, error)
+
+	// Package empaqueta el XML firmado en el ZIP que recibe la DIAN; retorna el nombre del ZIP
+	Package(doc *entities.Document, signedXML []byte) (string, []byte, error)
+}
+
+// WEB SERVICE DE LA DIAN (Secondary Port)
+
+// IDianWebService define las operaciones SOAP de WcfDianCustomerServices
+type IDianWebService interface {
+	// SendBillSync envía el ZIP y espera la validación (producción y habilitación ya aprobada)
+	SendBillSync(ctx context.Context, environment string, cert *entities.Certificate, fileName string, zip []byte) (*dtos.SendResult, error)
+
+	// SendTestSetAsync envía el ZIP al set de pruebas de habilitación; retorna el ZipKey
+	SendTestSetAsync(ctx context.Context, environment string, cert *entities.Certificate, fileName string, zip []byte, testSetID string) (*dtos.SendResult, error)
+
+	// GetStatusZip consulta el resultado de un envío asíncrono
+	GetStatusZip(ctx context.Context, environment string, cert *entities.Certificate, zipKey string) (*dtos.SendResult, error)
+
+	// GetStatus consulta un documento por su CUFE/CUDE (reenvíos rechazados por duplicado)
+	GetStatus(ctx context.Context, environment string, cert *entities.Certificate, documentKey string) (*dtos.SendResult, error)
+}
+
+// REPOSITORIO (Secondary Port)
+
+// IDocumentRepository persiste la numeración y los documentos emitidos
+type IDocumentRepository interface {
+	// ReserveConsecutive toma el siguiente consecutivo del prefijo dentro del rango autorizado.
+	// Con to <= 0 el rango no tiene tope (notas crédito y débito).
+	ReserveConsecutive(ctx context.Context, integrationID uint, prefix string, from, to int64) (int64, error)
+
+	// GetDocument retorna el documento emitido para una factura o nota; nil si no existe
+	GetDocument(ctx context.Context, integrationID uint, kind entities.DocumentKind, sourceID string) (*entities.StoredDocument, error)
+
+	// SaveDocument crea o actualiza el documento
+	SaveDocument(ctx context.Context, doc *entities.StoredDocument) error
+}
+
+// USE CASE DE FACTURACIÓN (Primary Port)
+
+// IInvoiceUseCase define el caso de uso de facturación electrónica directa con la DIAN
+type IInvoiceUseCase interface {
+	// CreateInvoice emite (o retoma) la factura electrónica de una solicitud
+	CreateInvoice(ctx context.Context, req *dtos.ProcessInvoiceRequest) (*dtos.ProcessInvoiceResult, error)
+
+	// CreateNote emite una nota crédito o débito sobre una factura ya validada
+	CreateNote(ctx context.Context, req *dtos.ProcessNoteRequest) (*dtos.ProcessInvoiceResult, error)
+
+	// CheckStatus consulta la validación pendiente de una factura enviada al set de pruebas
+	CheckStatus(ctx context.Context, integrationID, invoiceID uint) (*dtos.ProcessInvoiceResult, error)
+
+	// TestConnection valida el certificado y la configuración del facturador.
+	// Llamado desde el contrato global IIntegrationContract.
+	TestConnection(ctx context.Context, config map[string]interface{}, credentials map[string]interface{}) error
+}
@@ -0,0 +1,357 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	dianDtos "github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/queue"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/rabbitmq"
+)
+
+// DTOs locales — structs de deserialización del mensaje RabbitMQ
+// (Regla de aislamiento: no importar entre módulos)
+
+type invoiceCustomerData struct {
+	Name    string `json:"name"`
+	Email   string `json:"email"`
+	Phone   string `json:"phone"`
+	DNI     string `json:"dni"`
+	Address string `json:"address,omitempty"`
+}
+
+type invoiceItemData struct {
+	ProductID     *string  `json:"product_id"`
+	SKU           string   `json:"sku"`
+	Name          string   `json:"name"`
+	Description   *string  `json:"description"`
+	Quantity      int      `json:"quantity"`
+	UnitPrice     float64  `json:"unit_price"`
+	UnitPriceBase float64  `json:"unit_price_base"`
+	TotalPrice    float64  `json:"total_price"`
+	Tax           float64  `json:"tax"`
+	TaxRate       *float64 `json:"tax_rate"`
+	Discount      float64  `json:"discount"`
+}
+
+type invoiceData struct {
+	IntegrationID    uint                   `json:"integration_id"`
+	Customer         invoiceCustomerData    `json:"customer"`
+	Items            []invoiceItemData      `json:"items"`
+	Total            float64                `json:"total"`
+	Subtotal         float64                `json:"subtotal"`
+	Tax              float64                `json:"tax"`
+	Discount         float64                `json:"discount"`
+	ShippingCost     float64                `json:"shipping_cost"`
+	ShippingCostBase float64                `json:"shipping_cost_base"`
+	Currency         string                 `json:"currency"`
+	OrderID          string                 `json:"order_id"`
+	OrderNumber      string                 `json:"order_number,omitempty"`
+	Config           map[string]interface{} `json:"config"`
+}
+
+// InvoiceRequestMessage es el mensaje recibido desde el Invoicing Module
+type InvoiceRequestMessage struct {
+	InvoiceID     uint        `json:"invoice_id"`
+	Provider      string      `json:"provider"`
+	Operation     string      `json:"operation"`
+	InvoiceData   invoiceData `json:"invoice_data"`
+	CorrelationID string      `json:"correlation_id"`
+	Timestamp     time.Time   `json:"timestamp"`
+}
+
+// InvoiceRequestConsumer consume solicitudes de facturación desde RabbitMQ
+// y delega toda la lógica de negocio al use case.
+type InvoiceRequestConsumer struct {
+	rabbit            rabbitmq.IQueue
+	useCase           ports.IInvoiceUseCase
+	responsePublisher *queue.ResponsePublisher
+	log               log.ILogger
+}
+
+// New crea una nueva instancia del consumer.
+// Solo recibe el use case — no adapters secundarios directamente.
+func New(
+	rabbit rabbitmq.IQueue,
+	useCase ports.IInvoiceUseCase,
+	responsePublisher *queue.ResponsePublisher,
+	logger log.ILogger,
+) *InvoiceRequestConsumer {
+	return &InvoiceRequestConsumer{
+		rabbit:            rabbit,
+		useCase:           useCase,
+		responsePublisher: responsePublisher,
+		log:               logger.WithModule("dian.invoice_request_consumer"),
+	}
+}
+
+const (
+	QueueDianRequests = rabbitmq.QueueInvoicingDianRequests
+)
+
+// Start inicia el consumer
+func (c *InvoiceRequestConsumer) Start(ctx context.Context) error {
+	if c.rabbit == nil {
+		c.log.Warn(ctx).Msg("RabbitMQ client is nil, consumer cannot start")
+		return fmt.Errorf("rabbitmq client is nil")
+	}
+
+	c.log.Info(ctx).
+		Str("queue", QueueDianRequests).
+		Msg("Starting DIAN invoice request consumer")
+
+	if err := c.rabbit.DeclareQueue(QueueDianRequests, true); err != nil {
+		c.log.Error(ctx).Err(err).Msg("Failed to declare queue")
+		return err
+	}
+
+	if err := c.rabbit.Consume(ctx, QueueDianRequests, c.handleInvoiceRequest); err != nil {
+		c.log.Error(ctx).Err(err).Msg("Failed to start consuming")
+		return err
+	}
+
+	c.log.Info(ctx).
+		Str("queue", QueueDianRequests).
+		Msg("DIAN consumer started successfully")
+
+	return nil
+}
+
+// handleInvoiceRequest deserializa el mensaje y despacha al handler correcto
+func (c *InvoiceRequestConsumer) handleInvoiceRequest(message []byte) error {
+	ctx := context.Background()
+	startTime := time.Now()
+
+	var request InvoiceRequestMessage
+	if err := json.Unmarshal(message, &request); err != nil {
+		c.log.Error(ctx).
+			Err(err).
+			Str("body", string(message)).
+			Msg("Failed to unmarshal request")
+		return err
+	}
+
+	c.log.Info(ctx).
+		Uint("invoice_id", request.InvoiceID).
+		Str("operation", request.Operation).
+		Str("correlation_id", request.CorrelationID).
+		Msg("Received DIAN invoice request")
+
+	var response *queue.InvoiceResponseMessage
+	switch {
+	case request.InvoiceData.IntegrationID == 0:
+		c.log.Error(ctx).Msg("integration_id is 0 in invoice_data")
+		response = c.createErrorResponse(&request, "missing_integration_id", "integration_id is 0", startTime, nil)
+	case request.Operation == "create" || request.Operation == "retry":
+		response = c.processCreateInvoice(ctx, &request, startTime)
+	case request.Operation == "check_status":
+		result, err := c.useCase.CheckStatus(ctx, request.InvoiceData.IntegrationID, request.InvoiceID)
+		response = c.buildResponse(ctx, &request, result, err, startTime)
+	case request.Operation == "credit_note":
+		response = c.processNote(ctx, &request, entities.DocumentKindCreditNote, startTime)
+	case request.Operation == "debit_note":
+		response = c.processNote(ctx, &request, entities.DocumentKindDebitNote, startTime)
+	default:
+		c.log.Warn(ctx).Str("operation", request.Operation).Msg("Unknown operation")
+		response = c.createErrorResponse(&request, "unknown_operation", "Unknown operation: "+request.Operation, startTime, nil)
+	}
+
+	if err := c.responsePublisher.PublishResponse(ctx, response); err != nil {
+		c.log.Error(ctx).
+			Err(err).
+			Uint("invoice_id", request.InvoiceID).
+			Msg("Failed to publish response")
+		return err
+	}
+
+	return nil
+}
+
+// processCreateInvoice construye el DTO de dominio y delega al use case.
+// No contiene lógica de negocio — solo traducción del mensaje al dominio.
+func (c *InvoiceRequestConsumer) processCreateInvoice(
+	ctx context.Context,
+	request *InvoiceRequestMessage,
+	startTime time.Time,
+) *queue.InvoiceResponseMessage {
+	req := &dianDtos.ProcessInvoiceRequest{
+		InvoiceID:     request.InvoiceID,
+		Operation:     request.Operation,
+		CorrelationID: request.CorrelationID,
+		IntegrationID: request.InvoiceData.IntegrationID,
+		Customer: dianDtos.CustomerData{
+			Name:    request.InvoiceData.Customer.Name,
+			Email:   request.InvoiceData.Customer.Email,
+			Phone:   request.InvoiceData.Customer.Phone,
+			DNI:     request.InvoiceData.Customer.DNI,
+			Address: request.InvoiceData.Customer.Address,
+		},
+		Items:            mapItemsToDomain(request.InvoiceData.Items),
+		Total:            request.InvoiceData.Total,
+		Subtotal:         request.InvoiceData.Subtotal,
+		Tax:              request.InvoiceData.Tax,
+		Discount:         request.InvoiceData.Discount,
+		ShippingCost:     request.InvoiceData.ShippingCost,
+		ShippingCostBase: request.InvoiceData.ShippingCostBase,
+		Currency:         request.InvoiceData.Currency,
+		OrderID:          request.InvoiceData.OrderID,
+		OrderNumber:      request.InvoiceData.OrderNumber,
+		Config:           request.InvoiceData.Config,
+	}
+
+	result, err := c.useCase.CreateInvoice(ctx, req)
+	return c.buildResponse(ctx, request, result, err, startTime)
+}
+
+// processNote delega la emisión de una nota crédito o débito al use case
+func (c *InvoiceRequestConsumer) processNote(
+	ctx context.Context,
+	request *InvoiceRequestMessage,
+	kind entities.DocumentKind,
+	startTime time.Time,
+) *queue.InvoiceResponseMessage {
+	result, err := c.useCase.CreateNote(ctx, &dianDtos.ProcessNoteRequest{
+		InvoiceID:     request.InvoiceID,
+		Kind:          kind,
+		CorrelationID: request.CorrelationID,
+		IntegrationID: request.InvoiceData.IntegrationID,
+		Config:        request.InvoiceData.Config,
+	})
+	return c.buildResponse(ctx, request, result, err, startTime)
+}
+
+// buildResponse traduce el resultado del use case a la respuesta de la cola:
+// error, pendiente de validación (set de pruebas) o éxito
+func (c *InvoiceRequestConsumer) buildResponse(
+	ctx context.Context,
+	request *InvoiceRequestMessage,
+	result *dianDtos.ProcessInvoiceResult,
+	err error,
+	startTime time.Time,
+) *queue.InvoiceResponseMessage {
+	var auditData *dianDtos.AuditData
+	if result != nil {
+		auditData = result.AuditData
+	}
+
+	if err != nil {
+		c.log.Error(ctx).
+			Err(err).
+			Uint("invoice_id", request.InvoiceID).
+			Str("operation", request.Operation).
+			Msg("Use case returned error")
+		return c.createErrorResponse(request, "processing_error", err.Error(), startTime, auditData)
+	}
+
+	resp := &queue.InvoiceResponseMessage{
+		InvoiceID:      request.InvoiceID,
+		Provider:       "dian",
+		Operation:      request.Operation,
+		Status:         "success",
+		InvoiceNumber:  result.InvoiceNumber,
+		ExternalID:     result.ExternalID,
+		CUFE:           result.CUFE,
+		InvoiceURL:     result.QRCode,
+		DocumentJSON:   result.Document,
+		CorrelationID:  request.CorrelationID,
+		Timestamp:      time.Now(),
+		ProcessingTime: time.Since(startTime).Milliseconds(),
+	}
+
+	if result.Pending {
+		resp.Status = "pending_validation"
+		resp.Error = "Documento enviado al set de pruebas de la DIAN (ZipKey " + result.ZipKey + "), pendiente de validación"
+	}
+
+	if result.IssuedAt != "" {
+		if parsed, parseErr := time.Parse(time.RFC3339, result.IssuedAt); parseErr == nil {
+			resp.IssuedAt = &parsed
+		}
+	}
+
+	if auditData != nil {
+		resp.AuditRequestURL = auditData.RequestURL
+		resp.AuditRequestPayload = toMapPayload(auditData.RequestPayload)
+		resp.AuditResponseStatus = auditData.ResponseStatus
+		resp.AuditResponseBody = auditData.ResponseBody
+	}
+
+	return resp
+}
+
+// mapItemsToDomain convierte items del mensaje RabbitMQ a DTOs de dominio
+func mapItemsToDomain(items []invoiceItemData) []dianDtos.ItemData {
+	result := make([]dianDtos.ItemData, 0, len(items))
+	for _, item := range items {
+		result = append(result, dianDtos.ItemData{
+			ProductID:     item.ProductID,
+			SKU:           item.SKU,
+			Name:          item.Name,
+			Description:   item.Description,
+			Quantity:      item.Quantity,
+			UnitPrice:     item.UnitPrice,
+			UnitPriceBase: item.UnitPriceBase,
+			TotalPrice:    item.TotalPrice,
+			Tax:           item.Tax,
+			TaxRate:       item.TaxRate,
+			Discount:      item.Discount,
+		})
+	}
+	return result
+}
+
+// createErrorResponse construye una respuesta de error para la queue de respuestas
+func (c *InvoiceRequestConsumer) createErrorResponse(
+	request *InvoiceRequestMessage,
+	errorCode string,
+	errorMsg string,
+	startTime time.Time,
+	auditData *dianDtos.AuditData,
+) *queue.InvoiceResponseMessage {
+	processingTime := time.Since(startTime).Milliseconds()
+
+	resp := &queue.InvoiceResponseMessage{
+		InvoiceID:      request.InvoiceID,
+		Provider:       "dian",
+		Operation:      request.Operation,
+		Status:         "error",
+		Error:          errorMsg,
+		ErrorCode:      errorCode,
+		CorrelationID:  request.CorrelationID,
+		Timestamp:      time.Now(),
+		ProcessingTime: processingTime,
+	}
+
+	if auditData != nil {
+		resp.AuditRequestURL = auditData.RequestURL
+		resp.AuditRequestPayload = toMapPayload(auditData.RequestPayload)
+		resp.AuditResponseStatus = auditData.ResponseStatus
+		resp.AuditResponseBody = auditData.ResponseBody
+	}
+
+	return resp
+}
+
+// toMapPayload convierte cualquier valor a map[string]interface{} via JSON
+func toMapPayload(v interface{}) map[string]interface{} {
+	if v == nil {
+		return nil
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		return m
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil
+	}
+	return result
+}
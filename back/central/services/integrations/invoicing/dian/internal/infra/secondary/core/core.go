@@ -0,0 +1,22 @@
+package core
+
+import (
+	"context"
+
+	integrationcore "github.com/secamc93/probability/back/central/services/integrations/core"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/ports"
+)
+
+// DianCore adapta el use case de facturación DIAN al contrato core.IIntegrationContract.
+type DianCore struct {
+	integrationcore.BaseIntegration
+	useCase ports.IInvoiceUseCase
+}
+
+func New(useCase ports.IInvoiceUseCase) *DianCore {
+	return &DianCore{useCase: useCase}
+}
+
+func (d *DianCore) TestConnection(ctx context.Context, config, credentials map[string]interface{}) error {
+	return d.useCase.TestConnection(ctx, config, credentials)
+}
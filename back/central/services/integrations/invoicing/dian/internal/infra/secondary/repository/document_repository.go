@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	dianErrors "github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/shared/db"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+)
+
+// DocumentRepository persiste la numeración y los documentos emitidos ante la DIAN
+type DocumentRepository struct {
+	db  db.IDatabase
+	log log.ILogger
+}
+
+// New crea el repositorio de documentos DIAN
+func New(database db.IDatabase, logger log.ILogger) ports.IDocumentRepository {
+	return &DocumentRepository{
+		db:  database,
+		log: logger.WithModule("dian.repository"),
+	}
+}
+
+// reserveConsecutiveSQL incrementa el contador del prefijo en una sola sentencia.
+// El primer documento toma range_from; si la resolución cambia a un rango mayor,
+// GREATEST salta al nuevo inicio. Sin fila de retorno, el rango está agotado.
+const reserveConsecutiveSQL = `
+INSERT INTO dian_numbering_counters (integration_id, prefix, last_number, updated_at)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (integration_id, prefix) DO UPDATE
+SET last_number = GREATEST(dian_numbering_counters.last_number + 1, EXCLUDED.last_number),
+    updated_at = EXCLUDED.updated_at
+WHERE dian_numbering_counters.last_number < ?
+RETURNING last_number`
+
+func (r *DocumentRepository) ReserveConsecutive(ctx context.Context, integrationID uint, prefix string, from, to int64) (int64, error) {
+	if to <= 0 {
+		to = math.MaxInt64
+	}
+
+	var next []int64
+	if err := r.db.Conn(ctx).
+		Raw(reserveConsecutiveSQL, integrationID, prefix, from, time.Now(), to).
+		Scan(&next).Error; err != nil {
+		return 0, err
+	}
+	if len(next) == 0 || next[0] > to {
+		r.log.Warn(ctx).Uint("integration_id", integrationID).Str("prefix", prefix).Int64("range_to", to).Msg("DIAN numbering range exhausted")
+		return 0, dianErrors.ErrNumberingExhausted
+	}
+	return next[0], nil
+}
+
+func (r *DocumentRepository) GetDocument(ctx context.Context, integrationID uint, kind entities.DocumentKind, sourceID string) (*entities.StoredDocument, error) {
+	var model models.DianDocument
+	err := r.db.Conn(ctx).
+		Where("integration_id = ? AND kind = ? AND source_id = ?", integrationID, string(kind), sourceID).
+		First(&model).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toEntity(&model), nil
+}
+
+func (r *DocumentRepository) SaveDocument(ctx context.Context, doc *entities.StoredDocument) error {
+	model := toModel(doc)
+	if err := r.db.Conn(ctx).Save(model).Error; err != nil {
+		return err
+	}
+	doc.ID = model.ID
+	doc.CreatedAt = model.CreatedAt
+	doc.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+func toModel(doc *entities.StoredDocument) *models.DianDocument {
+	model := &models.DianDocument{
+		ID:                  doc.ID,
+		IntegrationID:       doc.IntegrationID,
+		InvoiceID:           doc.InvoiceID,
+		Kind:                string(doc.Kind),
+		SourceID:            doc.SourceID,
+		Prefix:              doc.Prefix,
+		Consecutive:         doc.Consecutive,
+		Number:              doc.Number,
+		UUID:                doc.UUID,
+		Status:              string(doc.Status),
+		ZipKey:              doc.ZipKey,
+		SignedXML:           string(doc.SignedXML),
+		ApplicationResponse: string(doc.ApplicationResponse),
+		AttachedDocument:    string(doc.AttachedDocument),
+		StatusMessage:       doc.StatusMessage,
+		CreatedAt:           doc.CreatedAt,
+	}
+	if !doc.IssuedAt.IsZero() {
+		issuedAt := doc.IssuedAt
+		model.IssuedAt = &issuedAt
+	}
+	if len(doc.Errors) > 0 {
+		model.Errors, _ = json.Marshal(doc.Errors)
+	}
+	return model
+}
+
+func toEntity(model *models.DianDocument) *entities.StoredDocument {
+	doc := &entities.StoredDocument{
+		ID:            model.ID,
+		IntegrationID: model.IntegrationID,
+		InvoiceID:     model.InvoiceID,
+		SourceID:      model.SourceID,
+		Kind:          entities.DocumentKind(model.Kind),
+		Prefix:        model.Prefix,
+		Consecutive:   model.Consecutive,
+		Number:        model.Number,
+		UUID:          model.UUID,
+		Status:        entities.DocumentStatus(model.Status),
+		ZipKey:        model.ZipKey,
+		StatusMessage: model.StatusMessage,
+		CreatedAt:     model.CreatedAt,
+		UpdatedAt:     model.UpdatedAt,
+	}
+	if model.IssuedAt != nil {
+		doc.IssuedAt = *model.IssuedAt
+	}
+	if model.SignedXML != "" {
+		doc.SignedXML = []byte(model.SignedXML)
+	}
+	if model.ApplicationResponse != "" {
+		doc.ApplicationResponse = []byte(model.ApplicationResponse)
+	}
+	if model.AttachedDocument != "" {
+		doc.AttachedDocument = []byte(model.AttachedDocument)
+	}
+	if len(model.Errors) > 0 {
+		_ = json.Unmarshal(model.Errors, &doc.Errors)
+	}
+	return doc
+}
@@ -0,0 +1,69 @@
+package ubl
+
+import (
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/xmltree"
+)
+
+// buildAttachedDocument arma el contenedor AttachedDocument que se entrega al
+// adquiriente: el documento firmado y la ApplicationResponse de la DIAN que lo
+// valida. Retorna la raíz y el ext:ExtensionContent para la firma.
+func buildAttachedDocument(doc *entities.Document, signedXML, applicationResponse []byte, issuedAt time.Time) (*xmltree.Node, *xmltree.Node) {
+	root := newRoot("AttachedDocument", nsAttachedDocument, xmltree.A("xmlns:ccts", nsCCTS))
+	signatureContainer := root.Child("ext:UBLExtensions").Child("ext:UBLExtension").Child("ext:ExtensionContent")
+
+	issued := issuedAt.In(doc.IssuedAt.Location())
+	root.Elem("cbc:UBLVersionID", ublVersion)
+	root.Elem("cbc:CustomizationID", "Documentos adjuntos")
+	root.Elem("cbc:ProfileID", specFor(doc.Kind).profileID)
+	root.Elem("cbc:ProfileExecutionID", doc.Environment)
+	root.Elem("cbc:ID", doc.Numbering.FullNumber())
+	root.Elem("cbc:IssueDate", issued.Format("2006-01-02"))
+	root.Elem("cbc:IssueTime", issued.Format("15:04:05-07:00"))
+	root.Elem("cbc:DocumentType", "Contenedor de Factura Electrónica")
+	root.Elem("cbc:ParentDocumentID", doc.Numbering.FullNumber())
+
+	root.Child("cac:SenderParty").Add(partyTaxScheme(doc.Supplier))
+	root.Child("cac:ReceiverParty").Add(partyTaxScheme(doc.Customer))
+
+	root.Child("cac:Attachment").Add(externalReference(signedXML))
+
+	line := root.Child("cac:ParentDocumentLineReference")
+	line.Elem("cbc:LineID", "1")
+	reference := line.Child("cac:DocumentReference")
+	reference.Elem("cbc:ID", doc.Numbering.FullNumber())
+	reference.Elem("cbc:UUID", doc.UUID, xmltree.A("schemeName", doc.UUIDSchemeName()))
+	reference.Elem("cbc:IssueDate", doc.IssueDate())
+	reference.Elem("cbc:DocumentType", "ApplicationResponse")
+	reference.Child("cac:Attachment").Add(externalReference(applicationResponse))
+	verification := reference.Child("cac:ResultOfVerification")
+	verification.Elem("cbc:ValidatorID", dianValidator)
+	verification.Elem("cbc:ValidationResultCode", "02")
+	verification.Elem("cbc:ValidationDate", issued.Format("2006-01-02"))
+	verification.Elem("cbc:ValidationTime", issued.Format("15:04:05-07:00"))
+
+	return root, signatureContainer
+}
+
+func partyTaxScheme(p entities.Party) *xmltree.Node {
+	node := xmltree.New("cac:PartyTaxScheme")
+	node.Elem("cbc:RegistrationName", p.Name)
+	node.Elem("cbc:CompanyID", p.ID, companyScheme(p)...)
+	node.Elem("cbc:TaxLevelCode", p.TaxLevelCode, xmltree.A("listName", "48"))
+	scheme := node.Child("cac:TaxScheme")
+	scheme.Elem("cbc:ID", p.TaxSchemeID)
+	scheme.Elem("cbc:Name", p.TaxSchemeName)
+	return node
+}
+
+// externalReference embebe un XML como texto. La forma canónica escapa el
+// contenido en vez de usar CDATA; para un lector XML ambos son equivalentes.
+func externalReference(content []byte) *xmltree.Node {
+	node := xmltree.New("cac:ExternalReference")
+	node.Elem("cbc:MimeCode", "text/xml")
+	node.Elem("cbc:EncodingCode", "UTF-8")
+	node.Elem("cbc:Description", string(content))
+	return node
+}
@@ -0,0 +1,82 @@
+package ubl
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/xades"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/xmltree"
+)
+
+// Builder genera los XML firmados y los paquetes ZIP que se envían a la DIAN
+type Builder struct {
+	signer         *xades.Signer
+	now            func() time.Time
+	newSignatureID func() string
+}
+
+// New crea el generador de documentos UBL
+func New() *Builder {
+	return &Builder{
+		signer:         xades.NewSigner(),
+		now:            time.Now,
+		newSignatureID: func() string { return "xmldsig-" + uuid.New().String() },
+	}
+}
+
+// BuildSignedDocument serializa la factura o nota en UBL 2.1 y la firma con XAdES-EPES.
+// La firma se verifica antes de retornar: un XML que la DIAN rechazaría por firma
+// nunca sale del proceso.
+func (b *Builder) BuildSignedDocument(doc *entities.Document, cert *entities.Certificate) ([]byte, error) {
+	if doc.UUID == "" {
+		return nil, fmt.Errorf("ubl: el documento %s no tiene CUFE/CUDE", doc.Numbering.FullNumber())
+	}
+
+	root, container := buildDocument(doc)
+	return b.sign(root, container, cert)
+}
+
+// BuildAttachedDocument arma y firma el AttachedDocument con el documento y la
+// ApplicationResponse que lo valida. Los datos del encabezado se leen del propio
+// documento firmado.
+func (b *Builder) BuildAttachedDocument(signedXML, applicationResponse []byte, cert *entities.Certificate) ([]byte, error) {
+	doc, err := readDocument(signedXML)
+	if err != nil {
+		return nil, err
+	}
+	root, container := buildAttachedDocument(doc, signedXML, applicationResponse, b.now())
+	return b.sign(root, container, cert)
+}
+
+// ReadDocument recupera el encabezado y las partes de un documento ya firmado
+func (b *Builder) ReadDocument(signedXML []byte) (*entities.Document, error) {
+	return readDocument(signedXML)
+}
+
+// Package empaqueta el XML firmado en el ZIP que reciben SendBillSync y SendTestSetAsync
+func (b *Builder) Package(doc *entities.Document, signedXML []byte) (string, []byte, error) {
+	name := FileName(doc.Kind.FilePrefix(), doc)
+	content, err := zipFile(name+".xml", signedXML)
+	if err != nil {
+		return "", nil, fmt.Errorf("ubl: empaquetando %s: %w", name, err)
+	}
+	return FileName("z", doc) + ".zip", content, nil
+}
+
+func (b *Builder) sign(root, container *xmltree.Node, cert *entities.Certificate) ([]byte, error) {
+	if _, err := b.signer.Sign(root, container, cert, xades.Options{
+		SignatureID: b.newSignatureID(),
+		SigningTime: b.now(),
+		Role:        xades.RoleSupplier,
+	}); err != nil {
+		return nil, err
+	}
+
+	document := xmltree.Document(root)
+	if _, err := xades.Verify(document); err != nil {
+		return nil, fmt.Errorf("ubl: la firma generada no verifica: %w", err)
+	}
+	return document, nil
+}
@@ -0,0 +1,291 @@
+package ubl
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/xades"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/xmltree"
+)
+
+var cot = time.FixedZone("COT", -5*60*60)
+
+func testBuilder() *Builder {
+	b := New()
+	b.now = func() time.Time { return time.Date(2026, 10, 18, 10, 0, 5, 0, cot) }
+	b.newSignatureID = func() string { return "xmldsig-prueba" }
+	return b
+}
+
+func testCertificate(t *testing.T) *entities.Certificate {
+	t.Helper()
+	data, err := os.ReadFile("testdata/certificado_pruebas.p12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := xades.NewCertificateLoader().LoadPKCS12(data, "dian-pruebas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func testInvoice() *entities.Document {
+	doc := &entities.Document{
+		Kind:        entities.DocumentKindInvoice,
+		Environment: entities.EnvironmentTesting,
+		Numbering: entities.Numbering{
+			Prefix:           "SETP",
+			Number:           990000001,
+			ResolutionNumber: "18760000001",
+			StartDate:        time.Date(2019, 1, 19, 0, 0, 0, 0, cot),
+			EndDate:          time.Date(2030, 1, 19, 0, 0, 0, 0, cot),
+			RangeFrom:        990000000,
+			RangeTo:          995000000,
+			TechnicalKey:     "fc8eac422eba16e22ffd8c6f94b3f40a6e38162c",
+		},
+		Software: entities.Software{ID: "56f2ae4e-9812-4fad-9255-08fcfcd5ccb0", PIN: "75315", ProviderNIT: "900123456", ProviderDV: "7"},
+		IssuedAt: time.Date(2026, 10, 18, 10, 0, 0, 0, cot),
+		Supplier: entities.Party{
+			Name: "Comercializadora Ejemplo SAS", IDType: entities.IDTypeNIT, ID: "900123456", DV: "7",
+			OrganizationType: "1", TaxLevelCode: "O-13", TaxSchemeID: "01", TaxSchemeName: "IVA",
+			Address: entities.Address{CityCode: "11001", CityName: "Bogotá, D.C.", DepartmentCode: "11", DepartmentName: "Bogotá", Line: "Calle 100 # 10-20"},
+			Email:   "facturacion@ejemplo.co",
+		},
+		Customer: entities.Party{
+			Name: "Consumidor final", IDType: entities.IDTypeCedula, ID: entities.FinalConsumerID,
+			OrganizationType: "2", TaxLevelCode: "R-99-PN", TaxSchemeID: "ZZ", TaxSchemeName: "No aplica",
+		},
+		PaymentFormID:    "1",
+		PaymentMeansCode: "10",
+		Lines: []entities.Line{
+			{Code: "SKU-1", Description: "Camiseta & gorra", Quantity: 2, UnitPrice: 50000, Discount: 10000, TaxPercent: 19},
+			{Code: "ENVIO", Description: "Envío", Quantity: 1, UnitPrice: 8403.36, TaxPercent: 19},
+		},
+	}
+	doc.AssignUUID()
+	return doc
+}
+
+func testCreditNote() *entities.Document {
+	invoice := testInvoice()
+	doc := testInvoice()
+	doc.Kind = entities.DocumentKindCreditNote
+	doc.Numbering = entities.Numbering{Prefix: "NC", Number: 1}
+	doc.Reference = &entities.BillingReference{Number: invoice.Numbering.FullNumber(), CUFE: invoice.UUID, IssueDate: invoice.IssuedAt}
+	doc.DiscrepancyCode = "2"
+	doc.DiscrepancyReason = "Anulación de factura electrónica"
+	doc.AssignUUID()
+	return doc
+}
+
+func text(t *testing.T, root *xmltree.Node, name string) string {
+	t.Helper()
+	n := root.Find(name)
+	if n == nil {
+		t.Fatalf("no existe %s", name)
+	}
+	return n.Text
+}
+
+func TestBuildSignedDocument_Factura_GeneraUBLFirmadoYVerificable(t *testing.T) {
+	doc := testInvoice()
+
+	signed, err := testBuilder().BuildSignedDocument(doc, testCertificate(t))
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+
+	if _, err := xades.Verify(signed); err != nil {
+		t.Fatalf("la firma no verifica: %v", err)
+	}
+
+	root, err := xmltree.Parse(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.Name != "Invoice" || root.AttrValue("xmlns") != nsInvoice {
+		t.Fatalf("raíz inesperada: %s", root.Name)
+	}
+	if got := text(t, root, "cbc:UUID"); got != doc.UUID {
+		t.Errorf("UUID = %s, esperaba el CUFE %s", got, doc.UUID)
+	}
+	if got := root.Find("cbc:UUID").AttrValue("schemeName"); got != "CUFE-SHA384" {
+		t.Errorf("schemeName = %s", got)
+	}
+	if got := text(t, root, "cbc:ID"); got != "SETP990000001" {
+		t.Errorf("ID = %s", got)
+	}
+	if got := text(t, root, "sts:SoftwareSecurityCode"); got != doc.SoftwareSecurityCode() {
+		t.Errorf("SoftwareSecurityCode = %s", got)
+	}
+	if got := text(t, root, "sts:InvoiceAuthorization"); got != "18760000001" {
+		t.Errorf("InvoiceAuthorization = %s", got)
+	}
+	if got := text(t, root, "cbc:IssueTime"); got != "10:00:00-05:00" {
+		t.Errorf("IssueTime = %s", got)
+	}
+	if got := text(t, root, "cbc:InvoiceTypeCode"); got != "01" {
+		t.Errorf("InvoiceTypeCode = %s", got)
+	}
+
+	monetary := root.Find("cac:LegalMonetaryTotal")
+	if got := text(t, monetary, "cbc:LineExtensionAmount"); got != "98403.36" {
+		t.Errorf("LineExtensionAmount = %s", got)
+	}
+	if got := text(t, monetary, "cbc:PayableAmount"); got != "117100.00" {
+		t.Errorf("PayableAmount = %s", got)
+	}
+	if got := text(t, root.Find("cac:AllowanceCharge"), "cbc:Amount"); got != "10000.00" {
+		t.Errorf("descuento de línea = %s", got)
+	}
+	if !strings.Contains(string(signed), "Camiseta &amp; gorra") {
+		t.Error("la descripción debe ir escapada")
+	}
+}
+
+func TestBuildSignedDocument_NotaCredito_ReferenciaLaFacturaYUsaCUDE(t *testing.T) {
+	doc := testCreditNote()
+
+	signed, err := testBuilder().BuildSignedDocument(doc, testCertificate(t))
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if _, err := xades.Verify(signed); err != nil {
+		t.Fatalf("la firma no verifica: %v", err)
+	}
+
+	root, err := xmltree.Parse(signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.Name != "CreditNote" {
+		t.Fatalf("raíz inesperada: %s", root.Name)
+	}
+	if got := text(t, root, "cbc:CreditNoteTypeCode"); got != "91" {
+		t.Errorf("CreditNoteTypeCode = %s", got)
+	}
+	if got := root.Find("cbc:UUID").AttrValue("schemeName"); got != "CUDE-SHA384" {
+		t.Errorf("schemeName = %s", got)
+	}
+	reference := root.Find("cac:InvoiceDocumentReference")
+	if reference == nil || text(t, reference, "cbc:UUID") != doc.Reference.CUFE {
+		t.Error("la nota debe referenciar el CUFE de la factura")
+	}
+	if root.Find("sts:InvoiceControl") != nil {
+		t.Error("las notas no llevan control de numeración")
+	}
+	if root.Find("cac:CreditNoteLine") == nil {
+		t.Error("las líneas de una nota crédito son cac:CreditNoteLine")
+	}
+}
+
+func TestBuildSignedDocument_SinCUFE_RetornaError(t *testing.T) {
+	doc := testInvoice()
+	doc.UUID = ""
+
+	if _, err := testBuilder().BuildSignedDocument(doc, testCertificate(t)); err == nil {
+		t.Fatal("esperaba error sin CUFE")
+	}
+}
+
+func TestPackage_NombraSegunAnexoYComprimeElXML(t *testing.T) {
+	doc := testInvoice()
+	signed := []byte("<Invoice></Invoice>")
+
+	name, content, err := testBuilder().Package(doc, signed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 990000001 = 0x3b023381
+	if name != "z0900123456000263b023381.zip" {
+		t.Errorf("nombre inesperado: %s", name)
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.File) != 1 || r.File[0].Name != "fv0900123456000263b023381.xml" {
+		t.Fatalf("contenido inesperado del ZIP")
+	}
+	f, _ := r.File[0].Open()
+	defer f.Close()
+	got, _ := io.ReadAll(f)
+	if !bytes.Equal(got, signed) {
+		t.Error("el ZIP no contiene el XML firmado")
+	}
+}
+
+func TestBuildAttachedDocument_EmbebeDocumentosSinRomperSusFirmas(t *testing.T) {
+	doc := testInvoice()
+	cert := testCertificate(t)
+	b := testBuilder()
+
+	signed, err := b.BuildSignedDocument(doc, cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	applicationResponse := []byte(`<ApplicationResponse><cbc:ResponseCode>02</cbc:ResponseCode></ApplicationResponse>`)
+
+	attached, err := b.BuildAttachedDocument(signed, applicationResponse, cert)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if _, err := xades.Verify(attached); err != nil {
+		t.Fatalf("la firma del AttachedDocument no verifica: %v", err)
+	}
+
+	root, err := xmltree.Parse(attached)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if root.Name != "AttachedDocument" {
+		t.Fatalf("raíz inesperada: %s", root.Name)
+	}
+	embedded := text(t, root.Find("cac:Attachment"), "cbc:Description")
+	if embedded != string(signed) {
+		t.Fatal("el documento embebido no es idéntico al firmado")
+	}
+	if _, err := xades.Verify([]byte(embedded)); err != nil {
+		t.Fatalf("la firma del documento embebido no verifica: %v", err)
+	}
+	if got := text(t, root.Find("cac:ParentDocumentLineReference"), "cbc:UUID"); got != doc.UUID {
+		t.Errorf("UUID de la referencia = %s", got)
+	}
+	if got := text(t, root.Find("cac:ReceiverParty"), "cbc:CompanyID"); got != doc.Customer.ID {
+		t.Errorf("adquiriente = %s", got)
+	}
+}
+
+func TestReadDocument_RecuperaElEncabezadoDelXMLFirmado(t *testing.T) {
+	doc := testCreditNote()
+	signed, err := testBuilder().BuildSignedDocument(doc, testCertificate(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := readDocument(signed)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if got.Kind != doc.Kind || got.UUID != doc.UUID || got.Numbering.FullNumber() != doc.Numbering.FullNumber() {
+		t.Fatalf("encabezado inesperado: %+v", got)
+	}
+	if !got.IssuedAt.Equal(doc.IssuedAt) {
+		t.Errorf("IssuedAt = %v, se esperaba %v", got.IssuedAt, doc.IssuedAt)
+	}
+	if got.Supplier.ID != doc.Supplier.ID || got.Supplier.DV != doc.Supplier.DV || got.Supplier.TaxLevelCode != doc.Supplier.TaxLevelCode {
+		t.Errorf("emisor = %+v", got.Supplier)
+	}
+	if got.Customer.ID != doc.Customer.ID || got.Customer.IDType != doc.Customer.IDType || got.Customer.TaxSchemeID != "ZZ" {
+		t.Errorf("adquiriente = %+v", got.Customer)
+	}
+}
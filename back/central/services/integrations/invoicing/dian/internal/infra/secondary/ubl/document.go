@@ -0,0 +1,281 @@
+package ubl
+
+import (
+	"strconv"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/xmltree"
+)
+
+// buildDocument arma el árbol UBL 2.1 de la factura o nota en el orden que exige
+// el XSD. Retorna la raíz y el ext:ExtensionContent reservado para la firma.
+// El documento debe traer el CUFE/CUDE ya calculado.
+func buildDocument(doc *entities.Document) (*xmltree.Node, *xmltree.Node) {
+	spec := specFor(doc.Kind)
+	totals := doc.Totals()
+	currency := doc.Currency
+	if currency == "" {
+		currency = "COP"
+	}
+
+	root := newRoot(spec.element, spec.namespace,
+		xmltree.A("xmlns:sts", nsSTS),
+		xmltree.A("xmlns:xsi", nsXSI),
+		xmltree.A("xsi:schemaLocation", spec.namespace+" "+spec.schema),
+	)
+
+	extensions := root.Child("ext:UBLExtensions")
+	extensions.Child("ext:UBLExtension").Child("ext:ExtensionContent").Add(dianExtensions(doc))
+	signatureContainer := extensions.Child("ext:UBLExtension").Child("ext:ExtensionContent")
+
+	root.Elem("cbc:UBLVersionID", ublVersion)
+	root.Elem("cbc:CustomizationID", spec.customizationID)
+	root.Elem("cbc:ProfileID", spec.profileID)
+	root.Elem("cbc:ProfileExecutionID", doc.Environment)
+	root.Elem("cbc:ID", doc.Numbering.FullNumber())
+	root.Elem("cbc:UUID", doc.UUID, xmltree.A("schemeID", doc.Environment), xmltree.A("schemeName", doc.UUIDSchemeName()))
+	root.Elem("cbc:IssueDate", doc.IssueDate())
+	root.Elem("cbc:IssueTime", doc.IssueTime())
+	switch doc.Kind {
+	case entities.DocumentKindInvoice:
+		root.Elem("cbc:InvoiceTypeCode", doc.Kind.TypeCode())
+	case entities.DocumentKindCreditNote:
+		root.Elem("cbc:CreditNoteTypeCode", doc.Kind.TypeCode())
+	}
+	if doc.Note != "" {
+		root.Elem("cbc:Note", doc.Note)
+	}
+	root.Elem("cbc:DocumentCurrencyCode", currency)
+	root.Elem("cbc:LineCountNumeric", strconv.Itoa(len(doc.Lines)))
+
+	if doc.Kind.IsNote() && doc.Reference != nil {
+		discrepancy := root.Child("cac:DiscrepancyResponse")
+		discrepancy.Elem("cbc:ReferenceID", doc.Reference.Number)
+		discrepancy.Elem("cbc:ResponseCode", doc.DiscrepancyCode)
+		discrepancy.Elem("cbc:Description", doc.DiscrepancyReason)
+
+		reference := root.Child("cac:BillingReference").Child("cac:InvoiceDocumentReference")
+		reference.Elem("cbc:ID", doc.Reference.Number)
+		reference.Elem("cbc:UUID", doc.Reference.CUFE, xmltree.A("schemeName", "CUFE-SHA384"))
+		reference.Elem("cbc:IssueDate", doc.Reference.IssueDate.Format("2006-01-02"))
+	}
+
+	supplier := root.Child("cac:AccountingSupplierParty")
+	supplier.Elem("cbc:AdditionalAccountID", doc.Supplier.OrganizationType)
+	supplier.Add(party(doc.Supplier, doc.Numbering.Prefix, false))
+
+	customer := root.Child("cac:AccountingCustomerParty")
+	customer.Elem("cbc:AdditionalAccountID", doc.Customer.OrganizationType)
+	customer.Add(party(doc.Customer, "", true))
+
+	paymentMeans := root.Child("cac:PaymentMeans")
+	paymentMeans.Elem("cbc:ID", doc.PaymentFormID)
+	paymentMeans.Elem("cbc:PaymentMeansCode", doc.PaymentMeansCode)
+	paymentMeans.Elem("cbc:PaymentDueDate", doc.IssueDate())
+
+	if len(totals.Subtotals) > 0 {
+		root.Add(taxTotal(totals.IVA, totals.Subtotals, currency))
+	}
+
+	monetary := root.Child(spec.totalElement)
+	amount(monetary, "cbc:LineExtensionAmount", totals.LineExtension, currency)
+	amount(monetary, "cbc:TaxExclusiveAmount", totals.TaxExclusive, currency)
+	amount(monetary, "cbc:TaxInclusiveAmount", totals.TaxInclusive, currency)
+	amount(monetary, "cbc:PayableAmount", totals.Payable, currency)
+
+	for i, line := range doc.Lines {
+		root.Add(documentLine(spec, i+1, line, currency))
+	}
+
+	return root, signatureContainer
+}
+
+// dianExtensions sts:DianExtensions: control de numeración (solo facturas),
+// software, código de seguridad y QR
+func dianExtensions(doc *entities.Document) *xmltree.Node {
+	ext := xmltree.New("sts:DianExtensions")
+
+	if doc.Kind == entities.DocumentKindInvoice {
+		control := ext.Child("sts:InvoiceControl")
+		control.Elem("sts:InvoiceAuthorization", doc.Numbering.ResolutionNumber)
+		period := control.Child("sts:AuthorizationPeriod")
+		period.Elem("cbc:StartDate", doc.Numbering.StartDate.Format("2006-01-02"))
+		period.Elem("cbc:EndDate", doc.Numbering.EndDate.Format("2006-01-02"))
+		authorized := control.Child("sts:AuthorizedInvoices")
+		if doc.Numbering.Prefix != "" {
+			authorized.Elem("sts:Prefix", doc.Numbering.Prefix)
+		}
+		authorized.Elem("sts:From", strconv.FormatInt(doc.Numbering.RangeFrom, 10))
+		authorized.Elem("sts:To", strconv.FormatInt(doc.Numbering.RangeTo, 10))
+	}
+
+	ext.Child("sts:InvoiceSource").Elem("cbc:IdentificationCode", "CO",
+		xmltree.A("listAgencyID", "6"),
+		xmltree.A("listAgencyName", "United Nations Economic Commission for Europe"),
+		xmltree.A("listSchemeURI", "urn:oasis:names:specification:ubl:codelist:gc:CountryIdentificationCode-2.1"),
+	)
+
+	provider := ext.Child("sts:SoftwareProvider")
+	provider.Elem("sts:ProviderID", doc.Software.ProviderNIT,
+		dianScheme(xmltree.A("schemeID", doc.Software.ProviderDV), xmltree.A("schemeName", entities.IDTypeNIT))...)
+	provider.Elem("sts:SoftwareID", doc.Software.ID, dianScheme()...)
+
+	ext.Elem("sts:SoftwareSecurityCode", doc.SoftwareSecurityCode(), dianScheme()...)
+
+	ext.Child("sts:AuthorizationProvider").Elem("sts:AuthorizationProviderID", entities.DianNIT,
+		dianScheme(xmltree.A("schemeID", "4"), xmltree.A("schemeName", entities.IDTypeNIT))...)
+
+	ext.Elem("sts:QRCode", doc.QRCodeURL())
+	return ext
+}
+
+// party cac:Party del emisor o del adquiriente. El emisor lleva el prefijo de la
+// numeración como esquema de registro; el adquiriente, su identificación.
+func party(p entities.Party, registrationPrefix string, isCustomer bool) *xmltree.Node {
+	node := xmltree.New("cac:Party")
+
+	if isCustomer {
+		attrs := []xmltree.Attr{xmltree.A("schemeName", p.IDType)}
+		if p.IDType == entities.IDTypeNIT {
+			attrs = append(attrs, xmltree.A("schemeID", p.DV))
+		}
+		node.Child("cac:PartyIdentification").Elem("cbc:ID", p.ID, attrs...)
+	}
+
+	name := p.TradeName
+	if name == "" {
+		name = p.Name
+	}
+	node.Child("cac:PartyName").Elem("cbc:Name", name)
+
+	hasAddress := p.Address.CityCode != ""
+	if hasAddress {
+		node.Child("cac:PhysicalLocation").Add(address("cac:Address", p.Address))
+	}
+
+	taxScheme := node.Child("cac:PartyTaxScheme")
+	taxScheme.Elem("cbc:RegistrationName", p.Name)
+	taxScheme.Elem("cbc:CompanyID", p.ID, companyScheme(p)...)
+	taxScheme.Elem("cbc:TaxLevelCode", p.TaxLevelCode, xmltree.A("listName", "48"))
+	if hasAddress {
+		taxScheme.Add(address("cac:RegistrationAddress", p.Address))
+	}
+	scheme := taxScheme.Child("cac:TaxScheme")
+	scheme.Elem("cbc:ID", p.TaxSchemeID)
+	scheme.Elem("cbc:Name", p.TaxSchemeName)
+
+	legal := node.Child("cac:PartyLegalEntity")
+	legal.Elem("cbc:RegistrationName", p.Name)
+	legal.Elem("cbc:CompanyID", p.ID, companyScheme(p)...)
+	if !isCustomer && registrationPrefix != "" {
+		legal.Child("cac:CorporateRegistrationScheme").Elem("cbc:ID", registrationPrefix)
+	}
+
+	if p.Phone != "" || p.Email != "" {
+		contact := node.Child("cac:Contact")
+		if p.Phone != "" {
+			contact.Elem("cbc:Telephone", p.Phone)
+		}
+		if p.Email != "" {
+			contact.Elem("cbc:ElectronicMail", p.Email)
+		}
+	}
+
+	return node
+}
+
+func companyScheme(p entities.Party) []xmltree.Attr {
+	extra := []xmltree.Attr{xmltree.A("schemeName", p.IDType)}
+	if p.IDType == entities.IDTypeNIT {
+		extra = append(extra, xmltree.A("schemeID", p.DV))
+	}
+	return dianScheme(extra...)
+}
+
+func address(name string, a entities.Address) *xmltree.Node {
+	node := xmltree.New(name)
+	node.Elem("cbc:ID", a.CityCode)
+	node.Elem("cbc:CityName", a.CityName)
+	if a.PostalCode != "" {
+		node.Elem("cbc:PostalZone", a.PostalCode)
+	}
+	node.Elem("cbc:CountrySubentity", a.DepartmentName)
+	node.Elem("cbc:CountrySubentityCode", a.DepartmentCode)
+	node.Child("cac:AddressLine").Elem("cbc:Line", a.Line)
+
+	country := a.CountryCode
+	if country == "" {
+		country = "CO"
+	}
+	countryNode := node.Child("cac:Country")
+	countryNode.Elem("cbc:IdentificationCode", country)
+	countryNode.Elem("cbc:Name", "Colombia", xmltree.A("languageID", "es"))
+	return node
+}
+
+// taxTotal cac:TaxTotal de IVA con un subtotal por tarifa
+func taxTotal(total float64, subtotals []entities.TaxSubtotal, currency string) *xmltree.Node {
+	node := xmltree.New("cac:TaxTotal")
+	amount(node, "cbc:TaxAmount", total, currency)
+	for _, sub := range subtotals {
+		subtotal := node.Child("cac:TaxSubtotal")
+		amount(subtotal, "cbc:TaxableAmount", sub.Taxable, currency)
+		amount(subtotal, "cbc:TaxAmount", sub.Amount, currency)
+		category := subtotal.Child("cac:TaxCategory")
+		category.Elem("cbc:Percent", entities.FormatAmount(sub.Percent))
+		scheme := category.Child("cac:TaxScheme")
+		scheme.Elem("cbc:ID", "01")
+		scheme.Elem("cbc:Name", "IVA")
+	}
+	return node
+}
+
+func documentLine(spec rootSpec, number int, line entities.Line, currency string) *xmltree.Node {
+	unitCode := line.UnitCode
+	if unitCode == "" {
+		unitCode = "94"
+	}
+
+	node := xmltree.New(spec.lineElement)
+	node.Elem("cbc:ID", strconv.Itoa(number))
+	node.Elem(spec.quantityElement, entities.FormatAmount(line.Quantity), xmltree.A("unitCode", unitCode))
+	amount(node, "cbc:LineExtensionAmount", line.Amount(), currency)
+
+	if line.Discount > 0 {
+		base := entities.Round(line.Quantity * line.UnitPrice)
+		allowance := node.Child("cac:AllowanceCharge")
+		allowance.Elem("cbc:ID", "1")
+		allowance.Elem("cbc:ChargeIndicator", "false")
+		allowance.Elem("cbc:AllowanceChargeReason", "Descuento")
+		if base > 0 {
+			allowance.Elem("cbc:MultiplierFactorNumeric", entities.FormatAmount(line.Discount*100/base))
+		}
+		amount(allowance, "cbc:Amount", line.Discount, currency)
+		amount(allowance, "cbc:BaseAmount", base, currency)
+	}
+
+	node.Add(taxTotal(line.TaxAmount(), []entities.TaxSubtotal{{
+		Percent: line.TaxPercent,
+		Taxable: line.Amount(),
+		Amount:  line.TaxAmount(),
+	}}, currency))
+
+	item := node.Child("cac:Item")
+	item.Elem("cbc:Description", line.Description)
+	if line.Code != "" {
+		item.Child("cac:StandardItemIdentification").Elem("cbc:ID", line.Code,
+			xmltree.A("schemeID", "999"),
+			xmltree.A("schemeName", "Estándar de adopción del contribuyente"),
+		)
+	}
+
+	price := node.Child("cac:Price")
+	amount(price, "cbc:PriceAmount", line.UnitPrice, currency)
+	price.Elem("cbc:BaseQuantity", entities.FormatAmount(1), xmltree.A("unitCode", unitCode))
+
+	return node
+}
+
+func amount(parent *xmltree.Node, name string, value float64, currency string) {
+	parent.Elem(name, entities.FormatAmount(value), xmltree.A("currencyID", currency))
+}
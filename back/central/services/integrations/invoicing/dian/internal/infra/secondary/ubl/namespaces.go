@@ -0,0 +1,99 @@
+package ubl
+
+import (
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/xades"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/xmltree"
+)
+
+const (
+	nsCAC  = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	nsCBC  = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+	nsEXT  = "urn:oasis:names:specification:ubl:schema:xsd:CommonExtensionComponents-2"
+	nsSTS  = "dian:gov:co:facturaelectronica:Structures-2-1"
+	nsXSI  = "http://www.w3.org/2001/XMLSchema-instance"
+	nsCCTS = "urn:un:unece:uncefact:data:specification:CoreComponentTypeSchemaModule:2"
+
+	nsInvoice          = "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"
+	nsCreditNote       = "urn:oasis:names:specification:ubl:schema:xsd:CreditNote-2"
+	nsDebitNote        = "urn:oasis:names:specification:ubl:schema:xsd:DebitNote-2"
+	nsAttachedDocument = "urn:oasis:names:specification:ubl:schema:xsd:AttachedDocument-2"
+
+	ublVersion = "UBL 2.1"
+
+	dianAgencyID   = "195"
+	dianAgencyName = "CO, DIAN (Dirección de Impuestos y Aduanas Nacionales)"
+	dianValidator  = "Unidad Especial Dirección de Impuestos y Aduanas Nacionales"
+)
+
+// rootSpec elemento raíz, espacio de nombres y perfil de cada tipo de documento
+type rootSpec struct {
+	element         string
+	namespace       string
+	schema          string
+	customizationID string
+	profileID       string
+	lineElement     string
+	quantityElement string
+	totalElement    string
+}
+
+func specFor(kind entities.DocumentKind) rootSpec {
+	switch kind {
+	case entities.DocumentKindCreditNote:
+		return rootSpec{
+			element:         "CreditNote",
+			namespace:       nsCreditNote,
+			schema:          "http://docs.oasis-open.org/ubl/os-UBL-2.1/xsd/maindoc/UBL-CreditNote-2.1.xsd",
+			customizationID: "20", // Nota crédito que referencia una factura electrónica
+			profileID:       "DIAN 2.1: Nota Crédito de Factura Electrónica de Venta",
+			lineElement:     "cac:CreditNoteLine",
+			quantityElement: "cbc:CreditedQuantity",
+			totalElement:    "cac:LegalMonetaryTotal",
+		}
+	case entities.DocumentKindDebitNote:
+		return rootSpec{
+			element:         "DebitNote",
+			namespace:       nsDebitNote,
+			schema:          "http://docs.oasis-open.org/ubl/os-UBL-2.1/xsd/maindoc/UBL-DebitNote-2.1.xsd",
+			customizationID: "30", // Nota débito que referencia una factura electrónica
+			profileID:       "DIAN 2.1: Nota Débito de Factura Electrónica de Venta",
+			lineElement:     "cac:DebitNoteLine",
+			quantityElement: "cbc:DebitedQuantity",
+			totalElement:    "cac:RequestedMonetaryTotal",
+		}
+	default:
+		return rootSpec{
+			element:         "Invoice",
+			namespace:       nsInvoice,
+			schema:          "http://docs.oasis-open.org/ubl/os-UBL-2.1/xsd/maindoc/UBL-Invoice-2.1.xsd",
+			customizationID: "10", // Operación estándar
+			profileID:       "DIAN 2.1: Factura Electrónica de Venta",
+			lineElement:     "cac:InvoiceLine",
+			quantityElement: "cbc:InvoicedQuantity",
+			totalElement:    "cac:LegalMonetaryTotal",
+		}
+	}
+}
+
+// newRoot crea el elemento raíz con todos los espacios de nombres que usa la DIAN
+func newRoot(element, namespace string, extra ...xmltree.Attr) *xmltree.Node {
+	attrs := []xmltree.Attr{
+		xmltree.A("xmlns", namespace),
+		xmltree.A("xmlns:cac", nsCAC),
+		xmltree.A("xmlns:cbc", nsCBC),
+		xmltree.A("xmlns:ds", xades.NamespaceDS),
+		xmltree.A("xmlns:ext", nsEXT),
+		xmltree.A("xmlns:xades", xades.NamespaceXAdES),
+		xmltree.A("xmlns:xades141", xades.NamespaceXAdES141),
+	}
+	return xmltree.New(element, append(attrs, extra...)...)
+}
+
+// dianScheme atributos de agencia que la DIAN exige en los identificadores tributarios
+func dianScheme(extra ...xmltree.Attr) []xmltree.Attr {
+	return append([]xmltree.Attr{
+		xmltree.A("schemeAgencyID", dianAgencyID),
+		xmltree.A("schemeAgencyName", dianAgencyName),
+	}, extra...)
+}
@@ -0,0 +1,100 @@
+package ubl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/xmltree"
+)
+
+// readDocument recupera de un documento firmado los datos que necesita el
+// AttachedDocument (tipo, número, CUFE/CUDE, fecha y partes). Permite armar el
+// contenedor cuando la validación llega después, por GetStatusZip.
+func readDocument(signedXML []byte) (*entities.Document, error) {
+	root, err := xmltree.Parse(signedXML)
+	if err != nil {
+		return nil, fmt.Errorf("ubl: %w", err)
+	}
+
+	doc := &entities.Document{}
+	switch root.Name {
+	case "Invoice":
+		doc.Kind = entities.DocumentKindInvoice
+	case "CreditNote":
+		doc.Kind = entities.DocumentKindCreditNote
+	case "DebitNote":
+		doc.Kind = entities.DocumentKindDebitNote
+	default:
+		return nil, fmt.Errorf("ubl: %s no es un documento electrónico", root.Name)
+	}
+
+	doc.Environment = childText(root, "cbc:ProfileExecutionID")
+	doc.UUID = childText(root, "cbc:UUID")
+
+	issuedAt, err := time.Parse("2006-01-02T15:04:05-07:00", childText(root, "cbc:IssueDate")+"T"+childText(root, "cbc:IssueTime"))
+	if err != nil {
+		return nil, fmt.Errorf("ubl: fecha de emisión inválida: %w", err)
+	}
+	doc.IssuedAt = issuedAt
+
+	supplier := root.Find("cac:AccountingSupplierParty")
+	customer := root.Find("cac:AccountingCustomerParty")
+	if supplier == nil || customer == nil {
+		return nil, fmt.Errorf("ubl: el documento no trae emisor o adquiriente")
+	}
+	doc.Supplier = readParty(supplier)
+	doc.Customer = readParty(customer)
+
+	fullNumber := childText(root, "cbc:ID")
+	if scheme := supplier.Find("cac:CorporateRegistrationScheme"); scheme != nil {
+		doc.Numbering.Prefix = childText(scheme, "cbc:ID")
+	}
+	number, err := strconv.ParseInt(strings.TrimPrefix(fullNumber, doc.Numbering.Prefix), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("ubl: número de documento inválido %q", fullNumber)
+	}
+	doc.Numbering.Number = number
+
+	return doc, nil
+}
+
+// readParty lee el esquema tributario de AccountingSupplierParty o AccountingCustomerParty
+func readParty(node *xmltree.Node) entities.Party {
+	p := entities.Party{OrganizationType: childText(node, "cbc:AdditionalAccountID")}
+	taxScheme := node.Find("cac:PartyTaxScheme")
+	if taxScheme == nil {
+		return p
+	}
+	p.Name = childText(taxScheme, "cbc:RegistrationName")
+	p.TaxLevelCode = childText(taxScheme, "cbc:TaxLevelCode")
+	if company := findChild(taxScheme, "cbc:CompanyID"); company != nil {
+		p.ID = company.Text
+		p.IDType = company.AttrValue("schemeName")
+		p.DV = company.AttrValue("schemeID")
+	}
+	if scheme := findChild(taxScheme, "cac:TaxScheme"); scheme != nil {
+		p.TaxSchemeID = childText(scheme, "cbc:ID")
+		p.TaxSchemeName = childText(scheme, "cbc:Name")
+	}
+	return p
+}
+
+// findChild busca solo entre los hijos directos
+func findChild(n *xmltree.Node, name string) *xmltree.Node {
+	for _, child := range n.Children {
+		if child.Name == name {
+			return child
+		}
+	}
+	return nil
+}
+
+func childText(n *xmltree.Node, name string) string {
+	if child := findChild(n, name); child != nil {
+		return child.Text
+	}
+	return ""
+}
@@ -0,0 +1,41 @@
+package ubl
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+)
+
+// ownSoftwareCode código de proveedor tecnológico en el nombre de archivo:
+// 000 cuando el facturador usa software propio
+const ownSoftwareCode = "000"
+
+// FileName nombre del XML según la sección 6.1 del anexo técnico:
+// prefijo + NIT (10 dígitos) + código de software + año (2 dígitos) + consecutivo hexadecimal (8 dígitos)
+func FileName(prefix string, doc *entities.Document) string {
+	nit := doc.Supplier.ID
+	if len(nit) < 10 {
+		nit = strings.Repeat("0", 10-len(nit)) + nit
+	}
+	return fmt.Sprintf("%s%s%s%s%08x", prefix, nit, ownSoftwareCode, doc.IssuedAt.Format("06"), doc.Numbering.Number)
+}
+
+// zipFile empaqueta un único archivo en un ZIP, como lo reciben los servicios de la DIAN
+func zipFile(name string, content []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	f, err := w.Create(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(content); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
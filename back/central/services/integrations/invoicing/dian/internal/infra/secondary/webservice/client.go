@@ -0,0 +1,158 @@
+package webservice
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	dianErrors "github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/shared/httpclient"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+// Endpoints de WcfDianCustomerServices por ambiente
+const (
+	EndpointProduction = "https://vpfe.dian.gov.co/WcfDianCustomerServices.svc"
+	EndpointTesting    = "https://vpfe-hab.dian.gov.co/WcfDianCustomerServices.svc"
+
+	actionPrefix = "http://wcf.dian.colombia/IWcfDianCustomerServices/"
+)
+
+// Client implementa IDianWebService sobre SOAP 1.2 con WS-Security
+type Client struct {
+	httpClient *httpclient.Client
+	now        func() time.Time
+	newID      func() string
+	log        log.ILogger
+}
+
+// New crea el cliente del web service de la DIAN
+func New(logger log.ILogger) ports.IDianWebService {
+	httpClient := httpclient.New(httpclient.HTTPClientConfig{
+		Timeout: 60 * time.Second,
+	}, logger)
+
+	// Un reenvío automático de SendBillSync llega a la DIAN como documento
+	// duplicado (regla 90); los reintentos los controla la cola de facturación.
+	httpClient.GetRestyClient().SetRetryCount(0)
+
+	return &Client{
+		httpClient: httpClient,
+		now:        time.Now,
+		newID:      newID,
+		log:        logger.WithModule("dian.webservice"),
+	}
+}
+
+// Endpoint retorna la URL del web service según el ambiente (1 producción, 2 pruebas)
+func Endpoint(environment string) string {
+	if environment == entities.EnvironmentProduction {
+		return EndpointProduction
+	}
+	return EndpointTesting
+}
+
+// SendBillSync envía el ZIP y espera la respuesta de validación
+func (c *Client) SendBillSync(ctx context.Context, environment string, cert *entities.Certificate, fileName string, zip []byte) (*dtos.SendResult, error) {
+	return c.call(ctx, environment, cert, "SendBillSync", []param{
+		{"fileName", fileName},
+		{"contentFile", base64.StdEncoding.EncodeToString(zip)},
+	})
+}
+
+// SendTestSetAsync envía el ZIP al set de pruebas de habilitación
+func (c *Client) SendTestSetAsync(ctx context.Context, environment string, cert *entities.Certificate, fileName string, zip []byte, testSetID string) (*dtos.SendResult, error) {
+	return c.call(ctx, environment, cert, "SendTestSetAsync", []param{
+		{"fileName", fileName},
+		{"contentFile", base64.StdEncoding.EncodeToString(zip)},
+		{"testSetId", testSetID},
+	})
+}
+
+// GetStatusZip consulta el resultado de un envío asíncrono por su ZipKey
+func (c *Client) GetStatusZip(ctx context.Context, environment string, cert *entities.Certificate, zipKey string) (*dtos.SendResult, error) {
+	return c.call(ctx, environment, cert, "GetStatusZip", []param{
+		{"trackId", zipKey},
+	})
+}
+
+// GetStatus consulta el estado de un documento por su CUFE/CUDE
+func (c *Client) GetStatus(ctx context.Context, environment string, cert *entities.Certificate, documentKey string) (*dtos.SendResult, error) {
+	return c.call(ctx, environment, cert, "GetStatus", []param{
+		{"trackId", documentKey},
+	})
+}
+
+func (c *Client) call(ctx context.Context, environment string, cert *entities.Certificate, operation string, params []param) (*dtos.SendResult, error) {
+	endpoint := Endpoint(environment)
+	action := actionPrefix + operation
+
+	body, err := buildEnvelope(envelopeInput{
+		Endpoint:  endpoint,
+		Action:    action,
+		Operation: operation,
+		Params:    params,
+		Cert:      cert,
+		Now:       c.now(),
+		ID:        c.newID(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	auditData := &dtos.AuditData{
+		RequestURL:     endpoint,
+		RequestPayload: map[string]interface{}{"operation": operation, "params": auditParams(params)},
+	}
+
+	c.log.Info(ctx).
+		Str("operation", operation).
+		Str("environment", environment).
+		Msg("📡 Calling DIAN web service")
+
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", fmt.Sprintf(`application/soap+xml;charset=UTF-8;action="%s"`, action)).
+		SetBody(body).
+		Post(endpoint)
+	if err != nil {
+		c.log.Error(ctx).Err(err).Str("operation", operation).Msg("❌ DIAN web service request failed - network error")
+		return &dtos.SendResult{AuditData: auditData}, fmt.Errorf("%w: %s: %v", dianErrors.ErrWebService, operation, err)
+	}
+
+	auditData.ResponseStatus = resp.StatusCode()
+	auditData.ResponseBody = string(resp.Body())
+
+	result, err := parseResponse(operation, resp.Body())
+	if err != nil {
+		c.log.Error(ctx).Err(err).Int("status", resp.StatusCode()).Str("operation", operation).Msg("❌ DIAN web service returned an unexpected response")
+		return &dtos.SendResult{AuditData: auditData}, fmt.Errorf("%w: %s: %v", dianErrors.ErrWebService, operation, err)
+	}
+	result.AuditData = auditData
+
+	c.log.Info(ctx).
+		Str("operation", operation).
+		Bool("is_valid", result.IsValid).
+		Str("status_code", result.StatusCode).
+		Str("zip_key", result.ZipKey).
+		Msg("✅ DIAN web service responded")
+
+	return result, nil
+}
+
+// auditParams omite el contenido del ZIP en la auditoría
+func auditParams(params []param) map[string]string {
+	out := make(map[string]string, len(params))
+	for _, p := range params {
+		if p.name == "contentFile" {
+			out[p.name] = fmt.Sprintf("<%d bytes base64>", len(p.value))
+			continue
+		}
+		out[p.name] = p.value
+	}
+	return out
+}
@@ -0,0 +1,150 @@
+package webservice
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/xades"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/xmltree"
+)
+
+func testCertificate(t *testing.T) *entities.Certificate {
+	t.Helper()
+	data, err := os.ReadFile("testdata/certificado_pruebas.p12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := xades.NewCertificateLoader().LoadPKCS12(data, "dian-pruebas")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert
+}
+
+func TestBuildEnvelope_FirmaElEncabezadoTo(t *testing.T) {
+	cert := testCertificate(t)
+	body, err := buildEnvelope(envelopeInput{
+		Endpoint:  EndpointTesting,
+		Action:    actionPrefix + "GetStatusZip",
+		Operation: "GetStatusZip",
+		Params:    []param{{"trackId", "a1b2c3"}},
+		Cert:      cert,
+		Now:       time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC),
+		ID:        "prueba",
+	})
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+
+	root, err := xmltree.Parse(body)
+	if err != nil {
+		t.Fatalf("el sobre no es XML válido: %v", err)
+	}
+	if got := root.Find("wsu:Expires").Text; got != "2026-10-18T15:01:00.000Z" {
+		t.Fatalf("Expires = %s", got)
+	}
+	if got := root.Find("wcf:trackId").Text; got != "a1b2c3" {
+		t.Fatalf("trackId = %s", got)
+	}
+
+	to := root.FindByAttr("wsu:Id", "id-prueba")
+	if to == nil || to.Text != EndpointTesting {
+		t.Fatalf("falta wsa:To firmado")
+	}
+	sum := sha256.Sum256(xmltree.ExclusiveCanonicalSubtree(root, to))
+	if got := root.Find("ds:DigestValue").Text; got != base64.StdEncoding.EncodeToString(sum[:]) {
+		t.Fatalf("el resumen de wsa:To no coincide")
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(root.Find("ds:SignatureValue").Text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hashed := sha256.Sum256(xmltree.ExclusiveCanonicalSubtree(root, root.Find("ds:SignedInfo")))
+	if err := rsa.VerifyPKCS1v15(&cert.PrivateKey.PublicKey, crypto.SHA256, hashed[:], signature); err != nil {
+		t.Fatalf("firma inválida: %v", err)
+	}
+
+	token, _ := base64.StdEncoding.DecodeString(root.Find("wsse:BinarySecurityToken").Text)
+	if !bytes.Equal(token, cert.Leaf.Raw) {
+		t.Fatalf("el BinarySecurityToken no es el certificado del facturador")
+	}
+}
+
+func TestBuildEnvelope_SinCertificado(t *testing.T) {
+	if _, err := buildEnvelope(envelopeInput{Endpoint: EndpointTesting, Operation: "GetStatusZip"}); err == nil {
+		t.Fatalf("se esperaba error sin certificado")
+	}
+}
+
+func TestParseResponse_SendBillSyncValido(t *testing.T) {
+	appResponse := base64.StdEncoding.EncodeToString([]byte("<ApplicationResponse/>"))
+	body := []byte(`<s:Envelope xmlns:s="http://www.w3.org/2003/05/soap-envelope"><s:Body>
+<SendBillSyncResponse xmlns="http://wcf.dian.colombia"><SendBillSyncResult xmlns:b="http://schemas.datacontract.org/2004/07/DianResponse">
+<b:ErrorMessage xmlns:c="http://schemas.microsoft.com/2003/10/Serialization/Arrays"><c:string>Regla: FAJ44b, Notificación: Nit o Documento de Identificación informado No está registrado en el RUT</c:string></b:ErrorMessage>
+<b:IsValid>true</b:IsValid><b:StatusCode>00</b:StatusCode><b:StatusDescription>Procesado Correctamente.</b:StatusDescription>
+<b:StatusMessage>La Factura electrónica SETP990000002, ha sido autorizada.</b:StatusMessage>
+<b:XmlBase64Bytes>` + appResponse + `</b:XmlBase64Bytes><b:XmlDocumentKey>8bb918b1</b:XmlDocumentKey>
+</SendBillSyncResult></SendBillSyncResponse></s:Body></s:Envelope>`)
+
+	result, err := parseResponse("SendBillSync", body)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if !result.IsValid || result.StatusCode != "00" || result.DocumentKey != "8bb918b1" {
+		t.Fatalf("resultado inesperado: %+v", result)
+	}
+	if string(result.ApplicationResponse) != "<ApplicationResponse/>" {
+		t.Fatalf("ApplicationResponse = %s", result.ApplicationResponse)
+	}
+	if len(result.Errors) != 1 {
+		t.Fatalf("se esperaba una notificación, got %v", result.Errors)
+	}
+}
+
+func TestParseResponse_SendTestSetAsyncRetornaZipKey(t *testing.T) {
+	body := []byte(`<s:Envelope xmlns:s="http://www.w3.org/2003/05/soap-envelope"><s:Body>
+<SendTestSetAsyncResponse xmlns="http://wcf.dian.colombia"><SendTestSetAsyncResult xmlns:b="http://schemas.datacontract.org/2004/07/UploadDocumentResponse">
+<b:ErrorMessageList/><b:ZipKey>3a9f1c2e-0b7d-4e51-9a3c-6d2b8f0e4a11</b:ZipKey>
+</SendTestSetAsyncResult></SendTestSetAsyncResponse></s:Body></s:Envelope>`)
+
+	result, err := parseResponse("SendTestSetAsync", body)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if result.ZipKey != "3a9f1c2e-0b7d-4e51-9a3c-6d2b8f0e4a11" || len(result.Errors) != 0 {
+		t.Fatalf("resultado inesperado: %+v", result)
+	}
+}
+
+func TestParseResponse_GetStatusZipEnProceso(t *testing.T) {
+	body := []byte(`<s:Envelope xmlns:s="http://www.w3.org/2003/05/soap-envelope"><s:Body>
+<GetStatusZipResponse xmlns="http://wcf.dian.colombia"><GetStatusZipResult xmlns:b="http://schemas.datacontract.org/2004/07/DianResponse">
+<b:DianResponse><b:IsValid>false</b:IsValid><b:StatusCode>98</b:StatusCode><b:StatusDescription>En Proceso de Validación</b:StatusDescription></b:DianResponse>
+</GetStatusZipResult></GetStatusZipResponse></s:Body></s:Envelope>`)
+
+	result, err := parseResponse("GetStatusZip", body)
+	if err != nil {
+		t.Fatalf("error inesperado: %v", err)
+	}
+	if !result.IsProcessing() {
+		t.Fatalf("se esperaba documento en proceso: %+v", result)
+	}
+}
+
+func TestParseResponse_Fault(t *testing.T) {
+	body := []byte(`<s:Envelope xmlns:s="http://www.w3.org/2003/05/soap-envelope"><s:Body><s:Fault>
+<s:Code><s:Value>s:Sender</s:Value></s:Code><s:Reason><s:Text xml:lang="es-CO">An error occurred when verifying security for the message.</s:Text></s:Reason>
+</s:Fault></s:Body></s:Envelope>`)
+
+	if _, err := parseResponse("SendBillSync", body); err == nil {
+		t.Fatalf("se esperaba error por SOAP fault")
+	}
+}
@@ -0,0 +1,124 @@
+package webservice
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/xades"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/xmltree"
+)
+
+const (
+	nsSOAP = "http://www.w3.org/2003/05/soap-envelope"
+	nsWCF  = "http://wcf.dian.colombia"
+	nsWSA  = "http://www.w3.org/2005/08/addressing"
+	nsWSSE = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-wssecurity-secext-1.0.xsd"
+	nsWSU  = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-wssecurity-utility-1.0.xsd"
+
+	tokenEncoding = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-soap-message-security-1.0#Base64Binary"
+	tokenType     = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-x509-token-profile-1.0#X509v3"
+
+	timestampLayout = "2006-01-02T15:04:05.000Z"
+	// timestampTTL vigencia del mensaje; la DIAN rechaza marcas de tiempo vencidas
+	timestampTTL = 60 * time.Second
+)
+
+type param struct {
+	name  string
+	value string
+}
+
+type envelopeInput struct {
+	Endpoint  string
+	Action    string
+	Operation string
+	Params    []param
+	Cert      *entities.Certificate
+	Now       time.Time
+	ID        string
+}
+
+// buildEnvelope arma el sobre SOAP 1.2 firmado con WS-Security: el
+// BinarySecurityToken lleva el certificado del facturador y la firma cubre
+// el encabezado wsa:To, que es lo que exige WcfDianCustomerServices.
+func buildEnvelope(in envelopeInput) ([]byte, error) {
+	if in.Cert == nil || in.Cert.Leaf == nil || in.Cert.PrivateKey == nil {
+		return nil, fmt.Errorf("webservice: certificado incompleto")
+	}
+
+	root := xmltree.New("soap:Envelope",
+		xmltree.A("xmlns:soap", nsSOAP),
+		xmltree.A("xmlns:wcf", nsWCF),
+	)
+	header := root.Child("soap:Header", xmltree.A("xmlns:wsa", nsWSA))
+
+	security := header.Child("wsse:Security",
+		xmltree.A("xmlns:wsse", nsWSSE),
+		xmltree.A("xmlns:wsu", nsWSU),
+	)
+	timestamp := security.Child("wsu:Timestamp", xmltree.A("wsu:Id", "TS-"+in.ID))
+	timestamp.Elem("wsu:Created", in.Now.UTC().Format(timestampLayout))
+	timestamp.Elem("wsu:Expires", in.Now.Add(timestampTTL).UTC().Format(timestampLayout))
+
+	security.Elem("wsse:BinarySecurityToken", base64.StdEncoding.EncodeToString(in.Cert.Leaf.Raw),
+		xmltree.A("EncodingType", tokenEncoding),
+		xmltree.A("ValueType", tokenType),
+		xmltree.A("wsu:Id", "X509-"+in.ID),
+	)
+
+	signature := security.Child("ds:Signature",
+		xmltree.A("xmlns:ds", xades.NamespaceDS),
+		xmltree.A("Id", "SIG-"+in.ID),
+	)
+
+	header.Elem("wsa:Action", in.Action)
+	to := header.Elem("wsa:To", in.Endpoint,
+		xmltree.A("xmlns:wsu", nsWSU),
+		xmltree.A("wsu:Id", "id-"+in.ID),
+	)
+
+	operation := root.Child("soap:Body").Child("wcf:" + in.Operation)
+	for _, p := range in.Params {
+		operation.Elem("wcf:"+p.name, p.value)
+	}
+
+	signedInfo := signature.Child("ds:SignedInfo")
+	signedInfo.Child("ds:CanonicalizationMethod", xmltree.A("Algorithm", xades.AlgorithmExcC14N))
+	signedInfo.Child("ds:SignatureMethod", xmltree.A("Algorithm", xades.AlgorithmRSASHA256))
+	reference := signedInfo.Child("ds:Reference", xmltree.A("URI", "#id-"+in.ID))
+	reference.Child("ds:Transforms").Child("ds:Transform", xmltree.A("Algorithm", xades.AlgorithmExcC14N))
+	reference.Child("ds:DigestMethod", xmltree.A("Algorithm", xades.AlgorithmSHA256))
+	toDigest := sha256.Sum256(xmltree.ExclusiveCanonicalSubtree(root, to))
+	reference.Elem("ds:DigestValue", base64.StdEncoding.EncodeToString(toDigest[:]))
+
+	signatureValue := signature.Child("ds:SignatureValue")
+
+	tokenReference := signature.Child("ds:KeyInfo", xmltree.A("Id", "KI-"+in.ID)).
+		Child("wsse:SecurityTokenReference", xmltree.A("wsu:Id", "STR-"+in.ID))
+	tokenReference.Child("wsse:Reference",
+		xmltree.A("URI", "#X509-"+in.ID),
+		xmltree.A("ValueType", tokenType),
+	)
+
+	hashed := sha256.Sum256(xmltree.ExclusiveCanonicalSubtree(root, signedInfo))
+	value, err := rsa.SignPKCS1v15(rand.Reader, in.Cert.PrivateKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return nil, fmt.Errorf("webservice: firmando el encabezado: %w", err)
+	}
+	signatureValue.Text = base64.StdEncoding.EncodeToString(value)
+
+	return xmltree.Canonical(root), nil
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,113 @@
+package webservice
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/dtos"
+)
+
+// Las etiquetas solo usan el nombre local: la DIAN mezcla prefijos entre
+// versiones del servicio y encoding/xml los ignora si no se especifican.
+
+type soapEnvelope struct {
+	Body struct {
+		Fault *struct {
+			Reason string `xml:"Reason>Text"`
+		} `xml:"Fault"`
+		SendBillSync *struct {
+			Result dianResponse `xml:"SendBillSyncResult"`
+		} `xml:"SendBillSyncResponse"`
+		SendTestSetAsync *struct {
+			Result uploadDocumentResponse `xml:"SendTestSetAsyncResult"`
+		} `xml:"SendTestSetAsyncResponse"`
+		GetStatus *struct {
+			Result dianResponse `xml:"GetStatusResult"`
+		} `xml:"GetStatusResponse"`
+		GetStatusZip *struct {
+			Responses []dianResponse `xml:"GetStatusZipResult>DianResponse"`
+		} `xml:"GetStatusZipResponse"`
+	} `xml:"Body"`
+}
+
+// dianResponse resultado de la validación de un documento
+type dianResponse struct {
+	ErrorMessages     []string `xml:"ErrorMessage>string"`
+	IsValid           bool     `xml:"IsValid"`
+	StatusCode        string   `xml:"StatusCode"`
+	StatusDescription string   `xml:"StatusDescription"`
+	StatusMessage     string   `xml:"StatusMessage"`
+	XMLBase64Bytes    string   `xml:"XmlBase64Bytes"`
+	XMLDocumentKey    string   `xml:"XmlDocumentKey"`
+}
+
+// uploadDocumentResponse acuse de recibo de un envío asíncrono
+type uploadDocumentResponse struct {
+	ZipKey   string `xml:"ZipKey"`
+	Messages []struct {
+		ProcessedMessage string `xml:"ProcessedMessage"`
+		Success          string `xml:"Success"`
+	} `xml:"ErrorMessageList>XmlParamsResponseTrackId"`
+}
+
+// parseResponse interpreta la respuesta SOAP de la operación invocada
+func parseResponse(operation string, body []byte) (*dtos.SendResult, error) {
+	var env soapEnvelope
+	if err := xml.Unmarshal(body, &env); err != nil {
+		return nil, fmt.Errorf("respuesta SOAP inválida: %w", err)
+	}
+
+	if env.Body.Fault != nil {
+		return nil, fmt.Errorf("SOAP fault: %s", strings.TrimSpace(env.Body.Fault.Reason))
+	}
+
+	switch {
+	case env.Body.SendBillSync != nil:
+		return env.Body.SendBillSync.Result.toResult()
+	case env.Body.GetStatus != nil:
+		return env.Body.GetStatus.Result.toResult()
+	case env.Body.GetStatusZip != nil:
+		if len(env.Body.GetStatusZip.Responses) == 0 {
+			return &dtos.SendResult{}, nil
+		}
+		return env.Body.GetStatusZip.Responses[0].toResult()
+	case env.Body.SendTestSetAsync != nil:
+		upload := env.Body.SendTestSetAsync.Result
+		result := &dtos.SendResult{ZipKey: strings.TrimSpace(upload.ZipKey)}
+		for _, m := range upload.Messages {
+			if m.ProcessedMessage != "" {
+				result.Errors = append(result.Errors, m.ProcessedMessage)
+			}
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("la respuesta no contiene el resultado de %s", operation)
+}
+
+func (r dianResponse) toResult() (*dtos.SendResult, error) {
+	result := &dtos.SendResult{
+		IsValid:           r.IsValid,
+		StatusCode:        strings.TrimSpace(r.StatusCode),
+		StatusDescription: strings.TrimSpace(r.StatusDescription),
+		StatusMessage:     strings.TrimSpace(r.StatusMessage),
+		DocumentKey:       strings.TrimSpace(r.XMLDocumentKey),
+	}
+	for _, m := range r.ErrorMessages {
+		if m = strings.TrimSpace(m); m != "" {
+			result.Errors = append(result.Errors, m)
+		}
+	}
+
+	if encoded := strings.TrimSpace(r.XMLBase64Bytes); encoded != "" {
+		decoded, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("ApplicationResponse mal codificado: %w", err)
+		}
+		result.ApplicationResponse = decoded
+	}
+
+	return result, nil
+}
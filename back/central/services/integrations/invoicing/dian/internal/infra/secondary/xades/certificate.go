@@ -0,0 +1,69 @@
+package xades
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	dianErrors "github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/errors"
+	"golang.org/x/crypto/pkcs12"
+)
+
+// CertificateLoader lee el contenedor PKCS#12 (.p12/.pfx) del facturador
+type CertificateLoader struct{}
+
+// NewCertificateLoader crea el lector de certificados
+func NewCertificateLoader() *CertificateLoader {
+	return &CertificateLoader{}
+}
+
+// LoadPKCS12 extrae la llave privada RSA, el certificado que le corresponde y la
+// cadena. Los contenedores de las entidades certificadoras traen la cadena
+// completa, por eso se recorren todos los bags en vez de usar pkcs12.Decode.
+func (l *CertificateLoader) LoadPKCS12(data []byte, password string) (*entities.Certificate, error) {
+	blocks, err := pkcs12.ToPEM(data, password)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", dianErrors.ErrInvalidCertificate, err)
+	}
+
+	var key *rsa.PrivateKey
+	var certs []*x509.Certificate
+	for _, block := range blocks {
+		switch block.Type {
+		case "PRIVATE KEY":
+			// pkcs12.ToPEM entrega las llaves RSA en PKCS#1
+			k, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("%w: la llave privada no es RSA: %v", dianErrors.ErrInvalidCertificate, err)
+			}
+			key = k
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", dianErrors.ErrInvalidCertificate, err)
+			}
+			certs = append(certs, cert)
+		}
+	}
+
+	if key == nil {
+		return nil, fmt.Errorf("%w: el contenedor no trae llave privada", dianErrors.ErrInvalidCertificate)
+	}
+
+	result := &entities.Certificate{PrivateKey: key}
+	for _, cert := range certs {
+		pub, ok := cert.PublicKey.(*rsa.PublicKey)
+		if ok && result.Leaf == nil && pub.Equal(&key.PublicKey) {
+			result.Leaf = cert
+			continue
+		}
+		result.Chain = append(result.Chain, cert)
+	}
+
+	if result.Leaf == nil {
+		return nil, fmt.Errorf("%w: ningún certificado corresponde a la llave privada", dianErrors.ErrInvalidCertificate)
+	}
+
+	return result, nil
+}
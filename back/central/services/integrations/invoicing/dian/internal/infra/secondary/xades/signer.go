@@ -0,0 +1,155 @@
+package xades
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/xmltree"
+)
+
+// Espacios de nombres y algoritmos de la política de firma DIAN v2
+const (
+	NamespaceDS       = "http://www.w3.org/2000/09/xmldsig#"
+	NamespaceXAdES    = "http://uri.etsi.org/01903/v1.3.2#"
+	NamespaceXAdES141 = "http://uri.etsi.org/01903/v1.4.1#"
+
+	AlgorithmC14N      = "http://www.w3.org/TR/2001/REC-xml-c14n-20010315"
+	AlgorithmExcC14N   = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	AlgorithmEnveloped = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+	AlgorithmRSASHA256 = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+	AlgorithmSHA256    = "http://www.w3.org/2001/04/xmlenc#sha256"
+
+	signedPropertiesType = "http://uri.etsi.org/01903#SignedProperties"
+
+	PolicyIdentifier  = "https://facturaelectronica.dian.gov.co/politicadefirma/v2/politicadefirmav2.pdf"
+	PolicyDescription = "Política de firma para facturas electrónicas de la República de Colombia."
+	// PolicyDigest SHA-256 en base64 del PDF de la política de firma v2
+	PolicyDigest = "dMoMvtcG5aIzgYo0tIsSQeVJBDnUnfSOfBpxXrmor0Y="
+
+	// Roles de la política: el facturador firma como supplier
+	RoleSupplier   = "supplier"
+	RoleThirdParty = "third party"
+)
+
+// signingTimeLayout hora de firma con milisegundos y zona de Colombia
+const signingTimeLayout = "2006-01-02T15:04:05.000-07:00"
+
+var colombiaTZ = time.FixedZone("COT", -5*60*60)
+
+// Options parámetros de una firma
+type Options struct {
+	SignatureID string // Id del ds:Signature; de él se derivan los Id de las referencias
+	SigningTime time.Time
+	Role        string
+}
+
+// Signer firma documentos UBL con XAdES-EPES según la política de firma DIAN v2
+type Signer struct{}
+
+// NewSigner crea el firmador
+func NewSigner() *Signer {
+	return &Signer{}
+}
+
+// Sign agrega un ds:Signature enveloped dentro de container (el ext:ExtensionContent
+// reservado para la firma) y lo retorna. Las tres referencias son las que pide la
+// política: el documento completo, el KeyInfo y las SignedProperties.
+func (s *Signer) Sign(root, container *xmltree.Node, cert *entities.Certificate, opts Options) (*xmltree.Node, error) {
+	if cert == nil || cert.Leaf == nil || cert.PrivateKey == nil {
+		return nil, fmt.Errorf("xades: certificado incompleto")
+	}
+	if opts.SignatureID == "" {
+		return nil, fmt.Errorf("xades: SignatureID es requerido")
+	}
+	if opts.Role == "" {
+		opts.Role = RoleSupplier
+	}
+	id := opts.SignatureID
+
+	signature := container.Child("ds:Signature", xmltree.A("xmlns:ds", NamespaceDS), xmltree.A("Id", id))
+	signedInfo := signature.Child("ds:SignedInfo")
+	signatureValue := signature.Child("ds:SignatureValue", xmltree.A("Id", id+"-sigvalue"))
+
+	keyInfo := signature.Child("ds:KeyInfo", xmltree.A("Id", id+"-keyinfo"))
+	keyInfo.Child("ds:X509Data").Elem("ds:X509Certificate", base64.StdEncoding.EncodeToString(cert.Leaf.Raw))
+
+	qualifying := signature.Child("ds:Object").Child("xades:QualifyingProperties",
+		xmltree.A("xmlns:xades", NamespaceXAdES),
+		xmltree.A("Target", "#"+id),
+	)
+	signedProperties := qualifying.Child("xades:SignedProperties", xmltree.A("Id", id+"-signedprops"))
+	buildSignedSignatureProperties(signedProperties.Child("xades:SignedSignatureProperties"), cert, opts)
+
+	documentDigest := digest(xmltree.CanonicalExcluding(root, signature))
+	keyInfoDigest := digest(xmltree.CanonicalSubtree(root, keyInfo))
+	signedPropertiesDigest := digest(xmltree.CanonicalSubtree(root, signedProperties))
+
+	signedInfo.Child("ds:CanonicalizationMethod", xmltree.A("Algorithm", AlgorithmC14N))
+	signedInfo.Child("ds:SignatureMethod", xmltree.A("Algorithm", AlgorithmRSASHA256))
+
+	documentRef := signedInfo.Child("ds:Reference", xmltree.A("Id", id+"-ref0"), xmltree.A("URI", ""))
+	documentRef.Child("ds:Transforms").Child("ds:Transform", xmltree.A("Algorithm", AlgorithmEnveloped))
+	addDigest(documentRef, documentDigest)
+
+	addDigest(signedInfo.Child("ds:Reference", xmltree.A("URI", "#"+id+"-keyinfo")), keyInfoDigest)
+
+	addDigest(signedInfo.Child("ds:Reference",
+		xmltree.A("Type", signedPropertiesType),
+		xmltree.A("URI", "#"+id+"-signedprops"),
+	), signedPropertiesDigest)
+
+	hashed := sha256.Sum256(xmltree.CanonicalSubtree(root, signedInfo))
+	value, err := rsa.SignPKCS1v15(rand.Reader, cert.PrivateKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return nil, fmt.Errorf("xades: firmando SignedInfo: %w", err)
+	}
+	signatureValue.Text = base64.StdEncoding.EncodeToString(value)
+
+	return signature, nil
+}
+
+func buildSignedSignatureProperties(props *xmltree.Node, cert *entities.Certificate, opts Options) {
+	props.Elem("xades:SigningTime", opts.SigningTime.In(colombiaTZ).Format(signingTimeLayout))
+
+	signingCertificate := props.Child("xades:SigningCertificate")
+	addCert(signingCertificate, cert.Leaf.Raw, cert.Leaf.Issuer.String(), cert.Leaf.SerialNumber.String())
+	for _, c := range cert.Chain {
+		addCert(signingCertificate, c.Raw, c.Issuer.String(), c.SerialNumber.String())
+	}
+
+	policyID := props.Child("xades:SignaturePolicyIdentifier").Child("xades:SignaturePolicyId")
+	sigPolicyID := policyID.Child("xades:SigPolicyId")
+	sigPolicyID.Elem("xades:Identifier", PolicyIdentifier)
+	sigPolicyID.Elem("xades:Description", PolicyDescription)
+	policyHash := policyID.Child("xades:SigPolicyHash")
+	policyHash.Child("ds:DigestMethod", xmltree.A("Algorithm", AlgorithmSHA256))
+	policyHash.Elem("ds:DigestValue", PolicyDigest)
+
+	props.Child("xades:SignerRole").Child("xades:ClaimedRoles").Elem("xades:ClaimedRole", opts.Role)
+}
+
+func addCert(parent *xmltree.Node, raw []byte, issuer, serial string) {
+	c := parent.Child("xades:Cert")
+	certDigest := c.Child("xades:CertDigest")
+	certDigest.Child("ds:DigestMethod", xmltree.A("Algorithm", AlgorithmSHA256))
+	certDigest.Elem("ds:DigestValue", digest(raw))
+	issuerSerial := c.Child("xades:IssuerSerial")
+	issuerSerial.Elem("ds:X509IssuerName", issuer)
+	issuerSerial.Elem("ds:X509SerialNumber", serial)
+}
+
+func addDigest(reference *xmltree.Node, value string) {
+	reference.Child("ds:DigestMethod", xmltree.A("Algorithm", AlgorithmSHA256))
+	reference.Elem("ds:DigestValue", value)
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
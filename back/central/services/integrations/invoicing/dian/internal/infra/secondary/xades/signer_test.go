@@ -0,0 +1,177 @@
+package xades
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	dianErrors "github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/xmltree"
+)
+
+// testdata/certificado_pruebas.p12: certificado RSA 2048 emitido por una CA de
+// pruebas (cadena incluida), exportado con `openssl pkcs12 -export -legacy`.
+const fixturePassword = "dian-pruebas"
+
+// Vectores calculados fuera de Go: el XML firmado se canonicalizó con
+// `xmllint --c14n` (sin el ds:Signature para la referencia al documento) y se
+// resumió con SHA-256; el SignatureValue se verificó con `openssl dgst -verify`.
+const (
+	vectorDocumentDigest = "BqLUkJfoZUImyVx1q9TuYAzGLy+D9Dvh7WpcWAC+SYs="
+	vectorKeyInfoDigest  = "H4sDJ7UXekFKNc2qy7tvBFA74aXfjgfJsnwXE29MIKo="
+)
+
+func loadFixture(t *testing.T) *entities.Certificate {
+	t.Helper()
+	data, err := os.ReadFile("testdata/certificado_pruebas.p12")
+	if err != nil {
+		t.Fatalf("leyendo fixture: %v", err)
+	}
+	cert, err := NewCertificateLoader().LoadPKCS12(data, fixturePassword)
+	if err != nil {
+		t.Fatalf("cargando PKCS#12: %v", err)
+	}
+	return cert
+}
+
+func signFixture(t *testing.T) ([]byte, *entities.Certificate) {
+	t.Helper()
+	cert := loadFixture(t)
+
+	root := xmltree.New("Invoice",
+		xmltree.A("xmlns", "urn:oasis:names:specification:ubl:schema:xsd:Invoice-2"),
+		xmltree.A("xmlns:cbc", "urn:cbc"),
+		xmltree.A("xmlns:ext", "urn:ext"),
+		xmltree.A("xmlns:ds", NamespaceDS),
+	)
+	content := root.Child("ext:UBLExtensions").Child("ext:UBLExtension").Child("ext:ExtensionContent")
+	root.Elem("cbc:ID", "SETP990000001")
+	root.Elem("cbc:Note", "Año & <ñ>")
+
+	_, err := NewSigner().Sign(root, content, cert, Options{
+		SignatureID: "xmldsig-prueba",
+		SigningTime: time.Date(2026, 10, 18, 15, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("firmando: %v", err)
+	}
+	return xmltree.Document(root), cert
+}
+
+func digestValue(t *testing.T, doc []byte, uri string) string {
+	t.Helper()
+	root, err := xmltree.Parse(doc)
+	if err != nil {
+		t.Fatalf("parseando: %v", err)
+	}
+	ref := root.FindByAttr("URI", uri)
+	if ref == nil {
+		t.Fatalf("no existe la referencia %q", uri)
+	}
+	return ref.Find("ds:DigestValue").Text
+}
+
+func TestLoadPKCS12_SeparaCertificadoFirmanteYCadena(t *testing.T) {
+	cert := loadFixture(t)
+
+	if cert.Leaf.Subject.CommonName != "Comercializadora Ejemplo SAS" {
+		t.Errorf("certificado firmante inesperado: %s", cert.Leaf.Subject.CommonName)
+	}
+	if len(cert.Chain) != 1 || cert.Chain[0].Subject.CommonName != "CA Pruebas Facturacion" {
+		t.Errorf("cadena inesperada: %d certificados", len(cert.Chain))
+	}
+}
+
+func TestLoadPKCS12_ClaveIncorrecta_RetornaErrInvalidCertificate(t *testing.T) {
+	data, err := os.ReadFile("testdata/certificado_pruebas.p12")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewCertificateLoader().LoadPKCS12(data, "otra-clave")
+
+	if !errors.Is(err, dianErrors.ErrInvalidCertificate) {
+		t.Fatalf("esperaba ErrInvalidCertificate, recibí %v", err)
+	}
+}
+
+func TestSign_ResumenesCoincidenConVectoresExternos(t *testing.T) {
+	doc, _ := signFixture(t)
+
+	if got := digestValue(t, doc, ""); got != vectorDocumentDigest {
+		t.Errorf("resumen del documento = %s, esperaba %s", got, vectorDocumentDigest)
+	}
+	if got := digestValue(t, doc, "#xmldsig-prueba-keyinfo"); got != vectorKeyInfoDigest {
+		t.Errorf("resumen del KeyInfo = %s, esperaba %s", got, vectorKeyInfoDigest)
+	}
+}
+
+func TestSign_EsDeterministicaParaLaMismaEntrada(t *testing.T) {
+	first, _ := signFixture(t)
+	second, _ := signFixture(t)
+
+	if !bytes.Equal(first, second) {
+		t.Fatal("RSA PKCS#1 v1.5 es determinística: dos firmas iguales deben producir el mismo XML")
+	}
+}
+
+func TestSign_PropiedadesFirmadasSiguenLaPoliticaDIAN(t *testing.T) {
+	doc, cert := signFixture(t)
+	xml := string(doc)
+
+	leafDigest := sha256.Sum256(cert.Leaf.Raw)
+	expected := []string{
+		"<xades:SigningTime>2026-10-18T10:00:00.000-05:00</xades:SigningTime>",
+		"<xades:Identifier>" + PolicyIdentifier + "</xades:Identifier>",
+		"<ds:DigestValue>" + PolicyDigest + "</ds:DigestValue>",
+		"<ds:DigestValue>" + base64.StdEncoding.EncodeToString(leafDigest[:]) + "</ds:DigestValue>",
+		"<ds:X509SerialNumber>7777</ds:X509SerialNumber>",
+		"<xades:ClaimedRole>supplier</xades:ClaimedRole>",
+		`<xades:QualifyingProperties xmlns:xades="` + NamespaceXAdES + `" Target="#xmldsig-prueba">`,
+	}
+	for _, fragment := range expected {
+		if !strings.Contains(xml, fragment) {
+			t.Errorf("falta en la firma: %s", fragment)
+		}
+	}
+	if strings.Count(xml, "<xades:Cert>") != 2 {
+		t.Errorf("SigningCertificate debe incluir el firmante y la cadena")
+	}
+}
+
+func TestVerify_DocumentoFirmado_RetornaElCertificado(t *testing.T) {
+	doc, cert := signFixture(t)
+
+	got, err := Verify(doc)
+
+	if err != nil {
+		t.Fatalf("esperaba firma válida, recibí: %v", err)
+	}
+	if !got.Equal(cert.Leaf) {
+		t.Fatal("el certificado del KeyInfo no es el firmante")
+	}
+}
+
+func TestVerify_DocumentoAlterado_Falla(t *testing.T) {
+	doc, _ := signFixture(t)
+	tampered := bytes.Replace(doc, []byte("SETP990000001"), []byte("SETP990000002"), 1)
+
+	if _, err := Verify(tampered); err == nil {
+		t.Fatal("esperaba error al verificar un documento alterado")
+	}
+}
+
+func TestVerify_FirmaAlterada_Falla(t *testing.T) {
+	doc, _ := signFixture(t)
+	tampered := bytes.Replace(doc, []byte("2026-10-18T10:00:00.000"), []byte("2026-10-18T10:00:01.000"), 1)
+
+	if _, err := Verify(tampered); err == nil {
+		t.Fatal("esperaba error al verificar propiedades firmadas alteradas")
+	}
+}
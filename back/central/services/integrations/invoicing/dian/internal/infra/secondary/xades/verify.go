@@ -0,0 +1,92 @@
+package xades
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/infra/secondary/xmltree"
+)
+
+// Verify valida un documento firmado por Sign: recalcula el resumen de cada
+// referencia, verifica el SignatureValue con el certificado del KeyInfo y
+// retorna ese certificado. Solo soporta lo que produce Sign (C14N inclusivo,
+// RSA-SHA256 y referencias al documento o por Id).
+func Verify(document []byte) (*x509.Certificate, error) {
+	root, err := xmltree.Parse(document)
+	if err != nil {
+		return nil, err
+	}
+
+	signature := root.Find("ds:Signature")
+	if signature == nil {
+		return nil, fmt.Errorf("xades: el documento no está firmado")
+	}
+	signedInfo := signature.Find("ds:SignedInfo")
+	if signedInfo == nil {
+		return nil, fmt.Errorf("xades: falta ds:SignedInfo")
+	}
+
+	for _, ref := range signedInfo.Children {
+		if ref.Name != "ds:Reference" {
+			continue
+		}
+		uri := ref.AttrValue("URI")
+
+		var canonical []byte
+		if uri == "" {
+			canonical = xmltree.CanonicalExcluding(root, signature)
+		} else {
+			target := root.FindByAttr("Id", strings.TrimPrefix(uri, "#"))
+			if target == nil {
+				return nil, fmt.Errorf("xades: la referencia %s no existe", uri)
+			}
+			canonical = xmltree.CanonicalSubtree(root, target)
+		}
+
+		want := ""
+		if value := ref.Find("ds:DigestValue"); value != nil {
+			want = value.Text
+		}
+		if got := digest(canonical); got != want {
+			return nil, fmt.Errorf("xades: el resumen de la referencia %q no coincide", uri)
+		}
+	}
+
+	certNode := signature.Find("ds:X509Certificate")
+	if certNode == nil {
+		return nil, fmt.Errorf("xades: falta ds:X509Certificate")
+	}
+	der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(certNode.Text))
+	if err != nil {
+		return nil, fmt.Errorf("xades: certificado mal codificado: %w", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("xades: %w", err)
+	}
+	pub, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("xades: el certificado no tiene llave RSA")
+	}
+
+	valueNode := signature.Find("ds:SignatureValue")
+	if valueNode == nil {
+		return nil, fmt.Errorf("xades: falta ds:SignatureValue")
+	}
+	value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(valueNode.Text))
+	if err != nil {
+		return nil, fmt.Errorf("xades: SignatureValue mal codificado: %w", err)
+	}
+
+	hashed := sha256.Sum256(xmltree.CanonicalSubtree(root, signedInfo))
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, hashed[:], value); err != nil {
+		return nil, fmt.Errorf("xades: firma inválida: %w", err)
+	}
+
+	return cert, nil
+}
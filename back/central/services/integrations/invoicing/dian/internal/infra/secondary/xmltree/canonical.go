@@ -0,0 +1,209 @@
+package xmltree
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+)
+
+// El árbol se serializa siempre en forma canónica (C14N 1.0 sin comentarios).
+// Así el XML que se entrega es exactamente el que se resume al firmar y
+// cualquier verificador que canonicalice obtiene los mismos bytes.
+
+const xmlDeclaration = `<?xml version="1.0" encoding="UTF-8" standalone="no"?>`
+
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// Document serializa el documento completo con declaración XML
+func Document(root *Node) []byte {
+	return append([]byte(xmlDeclaration), Canonical(root)...)
+}
+
+// Canonical forma canónica inclusiva del documento completo
+func Canonical(root *Node) []byte {
+	return CanonicalExcluding(root, nil)
+}
+
+// CanonicalExcluding forma canónica inclusiva del documento sin el subárbol skip.
+// Es el resultado de la transformación enveloped-signature seguida de C14N.
+func CanonicalExcluding(root, skip *Node) []byte {
+	w := &writer{skip: skip}
+	w.write(root, map[string]string{}, map[string]string{})
+	return w.buf.Bytes()
+}
+
+// CanonicalSubtree forma canónica inclusiva del subárbol target, que hereda
+// todas las declaraciones de espacio de nombres de sus ancestros en root
+func CanonicalSubtree(root, target *Node) []byte {
+	w := &writer{}
+	w.write(target, ancestorScope(root, target), map[string]string{})
+	return w.buf.Bytes()
+}
+
+// ExclusiveCanonicalSubtree forma canónica exclusiva (exc-c14n) del subárbol
+// target: solo se declaran los prefijos que cada elemento usa
+func ExclusiveCanonicalSubtree(root, target *Node) []byte {
+	w := &writer{exclusive: true}
+	w.write(target, ancestorScope(root, target), map[string]string{})
+	return w.buf.Bytes()
+}
+
+func ancestorScope(root, target *Node) map[string]string {
+	scope := map[string]string{}
+	path := root.path(target)
+	for i := 0; i < len(path)-1; i++ {
+		for _, a := range path[i].Attrs {
+			if prefix, ok := nsDecl(a); ok {
+				scope[prefix] = a.Value
+			}
+		}
+	}
+	return scope
+}
+
+type writer struct {
+	buf       bytes.Buffer
+	exclusive bool
+	skip      *Node
+}
+
+func (w *writer) write(n *Node, parentScope, rendered map[string]string) {
+	if n == w.skip {
+		return
+	}
+
+	scope := make(map[string]string, len(parentScope)+2)
+	for p, uri := range parentScope {
+		scope[p] = uri
+	}
+	var attrs []Attr
+	for _, a := range n.Attrs {
+		if prefix, ok := nsDecl(a); ok {
+			scope[prefix] = a.Value
+			continue
+		}
+		attrs = append(attrs, a)
+	}
+
+	var candidates []string
+	if w.exclusive {
+		candidates = visiblePrefixes(n, attrs)
+	} else {
+		for p := range scope {
+			candidates = append(candidates, p)
+		}
+	}
+
+	var nsOut []Attr
+	nextRendered := rendered
+	for _, p := range candidates {
+		uri := scope[p]
+		prev, seen := nextRendered[p]
+		if p == "" && uri == "" {
+			// xmlns="" solo se emite si anula un espacio por defecto ya emitido
+			if !seen || prev == "" {
+				continue
+			}
+		} else if seen && prev == uri {
+			continue
+		}
+		if len(nsOut) == 0 {
+			nextRendered = make(map[string]string, len(rendered)+len(candidates))
+			for k, v := range rendered {
+				nextRendered[k] = v
+			}
+		}
+		nextRendered[p] = uri
+		name := "xmlns"
+		if p != "" {
+			name += ":" + p
+		}
+		nsOut = append(nsOut, Attr{Name: name, Value: uri})
+	}
+
+	sort.Slice(nsOut, func(i, j int) bool { return nsOut[i].Name < nsOut[j].Name })
+	sort.SliceStable(attrs, func(i, j int) bool {
+		ui, li := attrSortKey(attrs[i], scope)
+		uj, lj := attrSortKey(attrs[j], scope)
+		if ui != uj {
+			return ui < uj
+		}
+		return li < lj
+	})
+
+	w.buf.WriteByte('<')
+	w.buf.WriteString(n.Name)
+	for _, a := range nsOut {
+		w.writeAttr(a)
+	}
+	for _, a := range attrs {
+		w.writeAttr(a)
+	}
+	w.buf.WriteByte('>')
+
+	if len(n.Children) > 0 {
+		for _, child := range n.Children {
+			w.write(child, scope, nextRendered)
+		}
+	} else {
+		w.buf.WriteString(escapeText(n.Text))
+	}
+
+	w.buf.WriteString("</")
+	w.buf.WriteString(n.Name)
+	w.buf.WriteByte('>')
+}
+
+func (w *writer) writeAttr(a Attr) {
+	w.buf.WriteByte(' ')
+	w.buf.WriteString(a.Name)
+	w.buf.WriteString(`="`)
+	w.buf.WriteString(escapeAttr(a.Value))
+	w.buf.WriteByte('"')
+}
+
+// visiblePrefixes prefijos usados por el elemento y sus atributos (exc-c14n)
+func visiblePrefixes(n *Node, attrs []Attr) []string {
+	prefix, _ := splitName(n.Name)
+	used := []string{prefix}
+	for _, a := range attrs {
+		if p, _ := splitName(a.Name); p != "" && p != "xml" {
+			used = append(used, p)
+		}
+	}
+	return used
+}
+
+// attrSortKey los atributos se ordenan por URI de espacio de nombres y luego por
+// nombre local; los que no tienen prefijo (URI vacía) van primero
+func attrSortKey(a Attr, scope map[string]string) (string, string) {
+	prefix, local := splitName(a.Name)
+	switch prefix {
+	case "":
+		return "", local
+	case "xml":
+		return xmlNamespace, local
+	default:
+		return scope[prefix], local
+	}
+}
+
+var textEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	"\r", "&#xD;",
+)
+
+var attrEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	`"`, "&quot;",
+	"\t", "&#x9;",
+	"\n", "&#xA;",
+	"\r", "&#xD;",
+)
+
+func escapeText(s string) string { return textEscaper.Replace(s) }
+
+func escapeAttr(s string) string { return attrEscaper.Replace(s) }
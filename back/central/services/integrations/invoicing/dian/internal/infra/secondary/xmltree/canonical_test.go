@@ -0,0 +1,76 @@
+package xmltree
+
+import "testing"
+
+// sampleTree documento con atributos desordenados, caracteres a escapar y una
+// redeclaración redundante de espacio de nombres
+func sampleTree() (root, signature *Node) {
+	root = New("Invoice",
+		A("b", "2"),
+		A("xmlns:ds", "urn:ds"),
+		A("xmlns", "urn:inv"),
+		A("a", `1&<"`),
+		A("xmlns:cbc", "urn:cbc"),
+	)
+	root.Elem("cbc:ID", "A&B>", A("cbc:z", "1"), A("schemeName", "x"), A("cbc:a", "2"))
+	root.Child("cbc:Note")
+	signature = root.Child("ds:Signature", A("xmlns:ds", "urn:ds"))
+	signature.Child("ds:X")
+	return root, signature
+}
+
+// Salida de `xmllint --c14n` para el mismo documento, sin los espacios entre elementos
+const sampleCanonical = `<Invoice xmlns="urn:inv" xmlns:cbc="urn:cbc" xmlns:ds="urn:ds" a="1&amp;&lt;&quot;" b="2">` +
+	`<cbc:ID schemeName="x" cbc:a="2" cbc:z="1">A&amp;B&gt;</cbc:ID>` +
+	`<cbc:Note></cbc:Note>` +
+	`<ds:Signature><ds:X></ds:X></ds:Signature>` +
+	`</Invoice>`
+
+func TestCanonical_CoincideConXmllint(t *testing.T) {
+	root, _ := sampleTree()
+
+	if got := string(Canonical(root)); got != sampleCanonical {
+		t.Fatalf("forma canónica inesperada:\n got: %s\nwant: %s", got, sampleCanonical)
+	}
+}
+
+func TestCanonicalExcluding_QuitaElSubarbolFirmado(t *testing.T) {
+	root, signature := sampleTree()
+
+	want := `<Invoice xmlns="urn:inv" xmlns:cbc="urn:cbc" xmlns:ds="urn:ds" a="1&amp;&lt;&quot;" b="2">` +
+		`<cbc:ID schemeName="x" cbc:a="2" cbc:z="1">A&amp;B&gt;</cbc:ID>` +
+		`<cbc:Note></cbc:Note>` +
+		`</Invoice>`
+	if got := string(CanonicalExcluding(root, signature)); got != want {
+		t.Fatalf("forma canónica inesperada:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestCanonicalSubtree_HeredaTodosLosEspaciosDeNombres(t *testing.T) {
+	root, signature := sampleTree()
+
+	want := `<ds:Signature xmlns="urn:inv" xmlns:cbc="urn:cbc" xmlns:ds="urn:ds"><ds:X></ds:X></ds:Signature>`
+	if got := string(CanonicalSubtree(root, signature)); got != want {
+		t.Fatalf("forma canónica inesperada:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestExclusiveCanonicalSubtree_SoloDeclaraPrefijosUsados(t *testing.T) {
+	root := New("soap:Envelope", A("xmlns:soap", "urn:soap"), A("xmlns:wsu", "urn:wsu"), A("xmlns:wsa", "urn:wsa"))
+	header := root.Child("soap:Header")
+	to := header.Elem("wsa:To", "https://dian", A("wsu:Id", "ID-1"))
+
+	want := `<wsa:To xmlns:wsa="urn:wsa" xmlns:wsu="urn:wsu" wsu:Id="ID-1">https://dian</wsa:To>`
+	if got := string(ExclusiveCanonicalSubtree(root, to)); got != want {
+		t.Fatalf("forma canónica exclusiva inesperada:\n got: %s\nwant: %s", got, want)
+	}
+}
+
+func TestDocument_AgregaDeclaracionXML(t *testing.T) {
+	root, _ := sampleTree()
+
+	want := xmlDeclaration + sampleCanonical
+	if got := string(Document(root)); got != want {
+		t.Fatalf("documento inesperado: %s", got)
+	}
+}
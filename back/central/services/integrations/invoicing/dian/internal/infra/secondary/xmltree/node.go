@@ -0,0 +1,118 @@
+package xmltree
+
+import "strings"
+
+// Attr atributo con nombre calificado ("prefijo:local" o "local").
+// Las declaraciones de espacio de nombres son atributos "xmlns" o "xmlns:p".
+type Attr struct {
+	Name  string
+	Value string
+}
+
+// Node elemento XML. Un nodo tiene texto o hijos, nunca ambos:
+// UBL no usa contenido mixto y así la forma canónica queda sin ambigüedad.
+type Node struct {
+	Name     string
+	Attrs    []Attr
+	Children []*Node
+	Text     string
+}
+
+// New crea un elemento con sus atributos
+func New(name string, attrs ...Attr) *Node {
+	return &Node{Name: name, Attrs: attrs}
+}
+
+// A atajo para construir atributos
+func A(name, value string) Attr {
+	return Attr{Name: name, Value: value}
+}
+
+// Add agrega hijos y retorna el nodo para encadenar
+func (n *Node) Add(children ...*Node) *Node {
+	for _, child := range children {
+		if child != nil {
+			n.Children = append(n.Children, child)
+		}
+	}
+	return n
+}
+
+// Elem agrega un hijo con texto y lo retorna
+func (n *Node) Elem(name, text string, attrs ...Attr) *Node {
+	child := &Node{Name: name, Attrs: attrs, Text: text}
+	n.Children = append(n.Children, child)
+	return child
+}
+
+// Child agrega un hijo vacío y lo retorna
+func (n *Node) Child(name string, attrs ...Attr) *Node {
+	child := New(name, attrs...)
+	n.Children = append(n.Children, child)
+	return child
+}
+
+// SetAttr fija un atributo, reemplazándolo si ya existe
+func (n *Node) SetAttr(name, value string) {
+	for i := range n.Attrs {
+		if n.Attrs[i].Name == name {
+			n.Attrs[i].Value = value
+			return
+		}
+	}
+	n.Attrs = append(n.Attrs, Attr{Name: name, Value: value})
+}
+
+// AttrValue retorna el valor de un atributo o vacío
+func (n *Node) AttrValue(name string) string {
+	for _, a := range n.Attrs {
+		if a.Name == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// Find busca en profundidad el primer descendiente (o el propio nodo) con ese nombre
+func (n *Node) Find(name string) *Node {
+	if n.Name == name {
+		return n
+	}
+	for _, child := range n.Children {
+		if found := child.Find(name); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// path retorna la cadena de ancestros desde n hasta target (ambos incluidos)
+func (n *Node) path(target *Node) []*Node {
+	if n == target {
+		return []*Node{n}
+	}
+	for _, child := range n.Children {
+		if p := child.path(target); p != nil {
+			return append([]*Node{n}, p...)
+		}
+	}
+	return nil
+}
+
+func splitName(name string) (prefix, local string) {
+	if i := strings.IndexByte(name, ':'); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return "", name
+}
+
+// nsDecl retorna el prefijo declarado si el atributo es una declaración de espacio de nombres
+func nsDecl(a Attr) (prefix string, ok bool) {
+	if a.Name == "xmlns" {
+		return "", true
+	}
+	if strings.HasPrefix(a.Name, "xmlns:") {
+		return a.Name[len("xmlns:"):], true
+	}
+	return "", false
+}
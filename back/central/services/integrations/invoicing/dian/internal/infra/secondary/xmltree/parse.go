@@ -0,0 +1,84 @@
+package xmltree
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Parse lee un documento XML conservando los prefijos tal como vienen, que es
+// lo que se necesita para volver a canonicalizarlo y verificar firmas.
+// El texto de los elementos con hijos (espacios de indentación) se descarta.
+func Parse(data []byte) (*Node, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = true
+
+	var root *Node
+	var stack []*Node
+	var text strings.Builder
+
+	for {
+		tok, err := dec.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("xmltree: %w", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &Node{Name: qualified(t.Name)}
+			for _, a := range t.Attr {
+				n.Attrs = append(n.Attrs, Attr{Name: qualified(a.Name), Value: a.Value})
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, n)
+			} else if root == nil {
+				root = n
+			}
+			stack = append(stack, n)
+			text.Reset()
+		case xml.CharData:
+			text.Write(t)
+		case xml.EndElement:
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("xmltree: cierre inesperado de %s", qualified(t.Name))
+			}
+			n := stack[len(stack)-1]
+			if len(n.Children) == 0 {
+				n.Text = text.String()
+			}
+			stack = stack[:len(stack)-1]
+			text.Reset()
+		}
+	}
+
+	if root == nil {
+		return nil, fmt.Errorf("xmltree: documento vacío")
+	}
+	return root, nil
+}
+
+// FindByAttr busca el primer nodo con el atributo name=value
+func (n *Node) FindByAttr(name, value string) *Node {
+	if n.AttrValue(name) == value {
+		return n
+	}
+	for _, child := range n.Children {
+		if found := child.FindByAttr(name, value); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+func qualified(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
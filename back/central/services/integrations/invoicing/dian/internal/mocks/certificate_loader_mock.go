@@ -0,0 +1,42 @@
+package mocks
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/ports"
+)
+
+// CertificateLoaderMock mock de ports.ICertificateLoader para tests unitarios.
+// Por defecto retorna un certificado vigente entre 2026 y 2028, sin llave real:
+// el DocumentBuilderMock no firma.
+type CertificateLoaderMock struct {
+	LoadPKCS12Fn func(data []byte, password string) (*entities.Certificate, error)
+}
+
+// Verificar en tiempo de compilación que implementa la interfaz.
+var _ ports.ICertificateLoader = (*CertificateLoaderMock)(nil)
+
+func (m *CertificateLoaderMock) LoadPKCS12(data []byte, password string) (*entities.Certificate, error) {
+	if m.LoadPKCS12Fn != nil {
+		return m.LoadPKCS12Fn(data, password)
+	}
+	return NewCertificate(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2028, 1, 1, 0, 0, 0, 0, time.UTC)), nil
+}
+
+// NewCertificate crea un certificado de prueba con la vigencia indicada
+func NewCertificate(notBefore, notAfter time.Time) *entities.Certificate {
+	return &entities.Certificate{
+		Leaf: &x509.Certificate{
+			SerialNumber: big.NewInt(7777),
+			Subject:      pkix.Name{CommonName: "Comercializadora Ejemplo SAS"},
+			NotBefore:    notBefore,
+			NotAfter:     notAfter,
+		},
+		PrivateKey: &rsa.PrivateKey{},
+	}
+}
@@ -0,0 +1,53 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/ports"
+)
+
+// DianWebServiceMock mock de ports.IDianWebService para tests unitarios.
+// Sin Fn configurado, SendBillSync valida el documento.
+type DianWebServiceMock struct {
+	SendBillSyncFn     func(ctx context.Context, environment string, cert *entities.Certificate, fileName string, zip []byte) (*dtos.SendResult, error)
+	SendTestSetAsyncFn func(ctx context.Context, environment string, cert *entities.Certificate, fileName string, zip []byte, testSetID string) (*dtos.SendResult, error)
+	GetStatusZipFn     func(ctx context.Context, environment string, cert *entities.Certificate, zipKey string) (*dtos.SendResult, error)
+	GetStatusFn        func(ctx context.Context, environment string, cert *entities.Certificate, documentKey string) (*dtos.SendResult, error)
+
+	SentZips [][]byte
+}
+
+// Verificar en tiempo de compilación que implementa la interfaz.
+var _ ports.IDianWebService = (*DianWebServiceMock)(nil)
+
+func (m *DianWebServiceMock) SendBillSync(ctx context.Context, environment string, cert *entities.Certificate, fileName string, zip []byte) (*dtos.SendResult, error) {
+	m.SentZips = append(m.SentZips, zip)
+	if m.SendBillSyncFn != nil {
+		return m.SendBillSyncFn(ctx, environment, cert, fileName, zip)
+	}
+	return &dtos.SendResult{IsValid: true, StatusCode: "00", ApplicationResponse: []byte("<ApplicationResponse/>")}, nil
+}
+
+func (m *DianWebServiceMock) SendTestSetAsync(ctx context.Context, environment string, cert *entities.Certificate, fileName string, zip []byte, testSetID string) (*dtos.SendResult, error) {
+	m.SentZips = append(m.SentZips, zip)
+	if m.SendTestSetAsyncFn != nil {
+		return m.SendTestSetAsyncFn(ctx, environment, cert, fileName, zip, testSetID)
+	}
+	return &dtos.SendResult{ZipKey: "zip-key-1"}, nil
+}
+
+func (m *DianWebServiceMock) GetStatusZip(ctx context.Context, environment string, cert *entities.Certificate, zipKey string) (*dtos.SendResult, error) {
+	if m.GetStatusZipFn != nil {
+		return m.GetStatusZipFn(ctx, environment, cert, zipKey)
+	}
+	return &dtos.SendResult{StatusCode: "98"}, nil
+}
+
+func (m *DianWebServiceMock) GetStatus(ctx context.Context, environment string, cert *entities.Certificate, documentKey string) (*dtos.SendResult, error) {
+	if m.GetStatusFn != nil {
+		return m.GetStatusFn(ctx, environment, cert, documentKey)
+	}
+	return &dtos.SendResult{}, nil
+}
@@ -0,0 +1,49 @@
+package mocks
+
+import (
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/ports"
+)
+
+// DocumentBuilderMock mock de ports.IDocumentBuilder para tests unitarios.
+// Por defecto "firma" el documento como <número|CUFE> y registra lo que recibe.
+type DocumentBuilderMock struct {
+	BuildSignedDocumentFn   func(doc *entities.Document, cert *entities.Certificate) ([]byte, error)
+	BuildAttachedDocumentFn func(signedXML, applicationResponse []byte, cert *entities.Certificate) ([]byte, error)
+	ReadDocumentFn          func(signedXML []byte) (*entities.Document, error)
+	PackageFn               func(doc *entities.Document, signedXML []byte) (string, []byte, error)
+
+	Signed []*entities.Document
+}
+
+// Verificar en tiempo de compilación que implementa la interfaz.
+var _ ports.IDocumentBuilder = (*DocumentBuilderMock)(nil)
+
+func (m *DocumentBuilderMock) BuildSignedDocument(doc *entities.Document, cert *entities.Certificate) ([]byte, error) {
+	m.Signed = append(m.Signed, doc)
+	if m.BuildSignedDocumentFn != nil {
+		return m.BuildSignedDocumentFn(doc, cert)
+	}
+	return []byte("<" + doc.Numbering.FullNumber() + "|" + doc.UUID + ">"), nil
+}
+
+func (m *DocumentBuilderMock) BuildAttachedDocument(signedXML, applicationResponse []byte, cert *entities.Certificate) ([]byte, error) {
+	if m.BuildAttachedDocumentFn != nil {
+		return m.BuildAttachedDocumentFn(signedXML, applicationResponse, cert)
+	}
+	return []byte("<AttachedDocument/>"), nil
+}
+
+func (m *DocumentBuilderMock) ReadDocument(signedXML []byte) (*entities.Document, error) {
+	if m.ReadDocumentFn != nil {
+		return m.ReadDocumentFn(signedXML)
+	}
+	return &entities.Document{}, nil
+}
+
+func (m *DocumentBuilderMock) Package(doc *entities.Document, signedXML []byte) (string, []byte, error) {
+	if m.PackageFn != nil {
+		return m.PackageFn(doc, signedXML)
+	}
+	return "z" + doc.Numbering.FullNumber() + ".zip", signedXML, nil
+}
@@ -0,0 +1,72 @@
+package mocks
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/entities"
+	dianErrors "github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/integrations/invoicing/dian/internal/domain/ports"
+)
+
+// DocumentRepositoryMock repositorio en memoria de ports.IDocumentRepository
+// para tests unitarios. Guarda copias para que el test vea lo persistido y no
+// el puntero que sigue modificando el use case.
+type DocumentRepositoryMock struct {
+	Documents map[string]entities.StoredDocument
+	Counters  map[string]int64
+	Saves     int
+
+	SaveDocumentFn func(ctx context.Context, doc *entities.StoredDocument) error
+}
+
+// Verificar en tiempo de compilación que implementa la interfaz.
+var _ ports.IDocumentRepository = (*DocumentRepositoryMock)(nil)
+
+// NewDocumentRepositoryMock crea el repositorio vacío
+func NewDocumentRepositoryMock() *DocumentRepositoryMock {
+	return &DocumentRepositoryMock{
+		Documents: map[string]entities.StoredDocument{},
+		Counters:  map[string]int64{},
+	}
+}
+
+// Key clave de un documento en el mapa
+func (m *DocumentRepositoryMock) Key(integrationID uint, kind entities.DocumentKind, sourceID string) string {
+	return fmt.Sprintf("%d/%s/%s", integrationID, kind, sourceID)
+}
+
+func (m *DocumentRepositoryMock) ReserveConsecutive(_ context.Context, integrationID uint, prefix string, from, to int64) (int64, error) {
+	key := fmt.Sprintf("%d/%s", integrationID, prefix)
+	next := m.Counters[key] + 1
+	if next < from {
+		next = from
+	}
+	if to > 0 && next > to {
+		return 0, dianErrors.ErrNumberingExhausted
+	}
+	m.Counters[key] = next
+	return next, nil
+}
+
+func (m *DocumentRepositoryMock) GetDocument(_ context.Context, integrationID uint, kind entities.DocumentKind, sourceID string) (*entities.StoredDocument, error) {
+	doc, ok := m.Documents[m.Key(integrationID, kind, sourceID)]
+	if !ok {
+		return nil, nil
+	}
+	return &doc, nil
+}
+
+func (m *DocumentRepositoryMock) SaveDocument(ctx context.Context, doc *entities.StoredDocument) error {
+	if m.SaveDocumentFn != nil {
+		if err := m.SaveDocumentFn(ctx, doc); err != nil {
+			return err
+		}
+	}
+	m.Saves++
+	if doc.ID == 0 {
+		doc.ID = uint(len(m.Documents) + 1)
+	}
+	m.Documents[m.Key(doc.IntegrationID, doc.Kind, doc.SourceID)] = *doc
+	return nil
+}
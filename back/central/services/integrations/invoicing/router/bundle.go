@@ -22,6 +22,7 @@ const (
 	QueueAlegraRequests      = rabbitmq.QueueInvoicingAlegraRequests
 	QueueWorldOfficeRequests = rabbitmq.QueueInvoicingWorldOfficeRequests
 	QueueHelisaRequests      = rabbitmq.QueueInvoicingHelisaRequests
+	QueueDianRequests        = rabbitmq.QueueInvoicingDianRequests
 )
 
 // invoiceRequestHeader contiene solo los campos necesarios para enrutar el mensaje.
@@ -96,6 +97,7 @@ func (b *Bundle) startRouter(ctx context.Context) error {
 		QueueAlegraRequests,
 		QueueWorldOfficeRequests,
 		QueueHelisaRequests,
+		QueueDianRequests,
 	}
 	for _, q := range providerQueues {
 		if err := b.rabbit.DeclareQueue(q, true); err != nil {
@@ -180,6 +182,8 @@ func (b *Bundle) getProviderQueue(provider string) string {
 		return QueueWorldOfficeRequests
 	case "helisa":
 		return QueueHelisaRequests
+	case "dian":
+		return QueueDianRequests
 	default:
 		return ""
 	}
@@ -77,11 +77,12 @@ type GenerateResponse struct {
 }
 
 type GenerateData struct {
-	TrackingNumber   string `json:"tracker"`
-	LabelURL         string `json:"url"`
-	MyGuideReference string `json:"myGuideReference"`
-	Carrier          string `json:"carrier"`
-	IDOrder          int64  `json:"idOrder"`
+	TrackingNumber   string   `json:"tracker"`
+	LabelURL         string   `json:"url"`
+	MyGuideReference string   `json:"myGuideReference"`
+	Carrier          string   `json:"carrier"`
+	IDOrder          int64    `json:"idOrder"`
+	PackageTrackers  []string `json:"packageTrackers,omitempty"`
 }
 
 type TrackingResponse struct {
@@ -56,11 +56,15 @@ func (c *Client) Generate(baseURL, apiKey string, req domain.QuoteRequest, meta
 	var raw struct {
 		Status string `json:"status"`
 		Data   struct {
-			Tracker          interface{} `json:"tracker"`
-			IDOrder          interface{} `json:"idOrder"`
-			URL              string      `json:"url"`
-			Carrier          string      `json:"carrier"`
-			MyGuideReference string      `json:"myGuideReference"`
+			Tracker          interface{}   `json:"tracker"`
+			IDOrder          interface{}   `json:"idOrder"`
+			URL              string        `json:"url"`
+			Carrier          string        `json:"carrier"`
+			MyGuideReference string        `json:"myGuideReference"`
+			Trackers         []interface{} `json:"trackers"`
+			Packages         []struct {
+				Tracker interface{} `json:"tracker"`
+			} `json:"packages"`
 		} `json:"data"`
 	}
 
@@ -70,15 +74,16 @@ func (c *Client) Generate(baseURL, apiKey string, req domain.QuoteRequest, meta
 	}
 
 	// Convertir tracker a string
-	var trackingNumber string
-	switch v := raw.Data.Tracker.(type) {
-	case string:
-		trackingNumber = v
-	case float64:
-		trackingNumber = fmt.Sprintf("%.0f", v)
-	default:
-		if v != nil {
-			trackingNumber = fmt.Sprintf("%v", v)
+	trackingNumber := trackerString(raw.Data.Tracker)
+
+	// Envíos multi-bulto: algunas transportadoras devuelven una guía por paquete
+	var packageTrackers []string
+	for _, t := range raw.Data.Trackers {
+		packageTrackers = append(packageTrackers, trackerString(t))
+	}
+	if len(packageTrackers) == 0 {
+		for _, p := range raw.Data.Packages {
+			packageTrackers = append(packageTrackers, trackerString(p.Tracker))
 		}
 	}
 
@@ -103,6 +108,7 @@ func (c *Client) Generate(baseURL, apiKey string, req domain.QuoteRequest, meta
 			MyGuideReference: raw.Data.MyGuideReference,
 			IDOrder:          idOrder,
 			Carrier:          raw.Data.Carrier,
+			PackageTrackers:  packageTrackers,
 		},
 	}
 
@@ -113,3 +119,16 @@ func (c *Client) Generate(baseURL, apiKey string, req domain.QuoteRequest, meta
 
 	return apiResp, nil
 }
+
+func trackerString(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return fmt.Sprintf("%.0f", t)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
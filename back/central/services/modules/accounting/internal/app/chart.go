@@ -0,0 +1,232 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/errors"
+)
+
+// Longitudes de codigo del PUC: clase, grupo, cuenta, subcuenta y auxiliares.
+var accountCodeLengths = []int{1, 2, 4, 6, 8, 10}
+
+// ListAccounts devuelve el plan de cuentas efectivo de un negocio: la
+// plantilla PUC con las cuentas propias del negocio encima.
+func (uc *UseCase) ListAccounts(ctx context.Context, businessID uint) ([]entities.Account, error) {
+	chart, err := uc.chartFor(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]entities.Account, 0, len(chart))
+	for _, a := range chart {
+		out = append(out, a)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out, nil
+}
+
+// CreateAccount agrega una cuenta al plan de un negocio (o a la plantilla
+// con BusinessID 0). Un negocio puede reusar un codigo de la plantilla para
+// renombrarlo en su plan.
+func (uc *UseCase) CreateAccount(ctx context.Context, dto dtos.CreateAccountDTO) (*entities.Account, error) {
+	code := strings.TrimSpace(dto.Code)
+	level := accountLevel(code)
+	if level == 0 {
+		return nil, domainerrors.ErrInvalidAccountCode
+	}
+	name := strings.TrimSpace(dto.Name)
+	if name == "" {
+		return nil, domainerrors.ErrNameRequired
+	}
+	nature := strings.ToUpper(strings.TrimSpace(dto.Nature))
+	if nature != "" && nature != entities.NatureDebit && nature != entities.NatureCredit {
+		return nil, domainerrors.ErrInvalidNature
+	}
+
+	chart, err := uc.chartFor(ctx, dto.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+	if current, ok := chart[code]; ok && current.BusinessID == dto.BusinessID {
+		return nil, domainerrors.ErrDuplicateCode
+	}
+	parent := accountParent(code)
+	if parent != "" {
+		p, ok := chart[parent]
+		if !ok {
+			return nil, domainerrors.ErrAccountParentMissing
+		}
+		if nature == "" {
+			nature = p.Nature
+		}
+	}
+	if nature == "" {
+		nature = defaultNature(code)
+	}
+
+	account := &entities.Account{
+		BusinessID: dto.BusinessID,
+		Code:       code,
+		Name:       name,
+		Nature:     nature,
+		ParentCode: parent,
+		Level:      level,
+		IsPostable: len(code) >= 6,
+		IsActive:   true,
+	}
+	if err := uc.repo.CreateAccount(ctx, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func (uc *UseCase) UpdateAccount(ctx context.Context, dto dtos.UpdateAccountDTO) (*entities.Account, error) {
+	name := strings.TrimSpace(dto.Name)
+	if name == "" {
+		return nil, domainerrors.ErrNameRequired
+	}
+	account, err := uc.repo.GetAccountByID(ctx, dto.ID)
+	if err != nil {
+		return nil, err
+	}
+	account.Name = name
+	account.IsActive = dto.IsActive
+	if err := uc.repo.UpdateAccount(ctx, account); err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+func (uc *UseCase) ListPostingRules(ctx context.Context) ([]entities.PostingRule, error) {
+	return uc.repo.ListPostingRules(ctx)
+}
+
+// SavePostingRule crea o reemplaza la regla de un origen. Todas las cuentas
+// deben ser auxiliares activas del plan del negocio.
+func (uc *UseCase) SavePostingRule(ctx context.Context, dto dtos.SavePostingRuleDTO) (*entities.PostingRule, error) {
+	rule := &entities.PostingRule{
+		BusinessID:         dto.BusinessID,
+		SourceType:         strings.ToUpper(strings.TrimSpace(dto.SourceType)),
+		Description:        strings.TrimSpace(dto.Description),
+		DebitAccount:       strings.TrimSpace(dto.DebitAccount),
+		CreditAccount:      strings.TrimSpace(dto.CreditAccount),
+		TaxAccount:         strings.TrimSpace(dto.TaxAccount),
+		WithholdingAccount: strings.TrimSpace(dto.WithholdingAccount),
+		OtherTaxAccount:    strings.TrimSpace(dto.OtherTaxAccount),
+		IsActive:           dto.IsActive,
+	}
+	if rule.SourceType == "" {
+		return nil, domainerrors.ErrCodeRequired
+	}
+	if rule.DebitAccount == "" || rule.CreditAccount == "" {
+		return nil, domainerrors.ErrRuleAccountsRequired
+	}
+	chart, err := uc.chartFor(ctx, rule.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range []string{rule.DebitAccount, rule.CreditAccount, rule.TaxAccount, rule.WithholdingAccount, rule.OtherTaxAccount} {
+		if code == "" {
+			continue
+		}
+		if _, err := postableAccount(chart, code); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.repo.SavePostingRule(ctx, rule); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (uc *UseCase) chartFor(ctx context.Context, businessID uint) (map[string]entities.Account, error) {
+	accounts, err := uc.repo.ListAccounts(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	return effectiveChart(accounts), nil
+}
+
+// effectiveChart deja una cuenta por codigo; la del negocio gana sobre la de
+// la plantilla.
+func effectiveChart(accounts []entities.Account) map[string]entities.Account {
+	chart := make(map[string]entities.Account, len(accounts))
+	for _, a := range accounts {
+		if _, ok := chart[a.Code]; ok && a.BusinessID == 0 {
+			continue
+		}
+		chart[a.Code] = a
+	}
+	return chart
+}
+
+func postableAccount(chart map[string]entities.Account, code string) (entities.Account, error) {
+	a, ok := chart[code]
+	if !ok {
+		return a, fmt.Errorf("%w: %s", domainerrors.ErrAccountNotFound, code)
+	}
+	if !a.IsPostable || !a.IsActive {
+		return a, fmt.Errorf("%w: %s", domainerrors.ErrAccountNotPostable, code)
+	}
+	return a, nil
+}
+
+// accountLevel devuelve 1 (clase) a 6, o 0 si el codigo no es valido.
+func accountLevel(code string) int {
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return 0
+		}
+	}
+	for i, n := range accountCodeLengths {
+		if len(code) == n {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func accountParent(code string) string {
+	level := accountLevel(code)
+	if level <= 1 {
+		return ""
+	}
+	return code[:accountCodeLengths[level-2]]
+}
+
+// accountPath devuelve la cuenta y todas sus mayores, de la clase hacia abajo.
+func accountPath(code string) []string {
+	path := make([]string, 0, len(accountCodeLengths))
+	for _, n := range accountCodeLengths {
+		if n > len(code) {
+			break
+		}
+		path = append(path, code[:n])
+	}
+	if len(path) == 0 || path[len(path)-1] != code {
+		path = append(path, code)
+	}
+	return path
+}
+
+// defaultNature aplica la naturaleza por clase: 1, 5, 6, 7 y 8 son debito.
+func defaultNature(code string) string {
+	if code == "" {
+		return entities.NatureDebit
+	}
+	switch code[0] {
+	case '2', '3', '4', '9':
+		return entities.NatureCredit
+	}
+	return entities.NatureDebit
+}
+
+// isResultAccount indica si la cuenta es de resultados (clases 4 a 7), que
+// arrancan de cero cada ano.
+func isResultAccount(code string) bool {
+	return code != "" && code[0] >= '4' && code[0] <= '7'
+}
@@ -44,6 +44,23 @@ type IUseCase interface {
 	GetDianConfig(ctx context.Context) (*dtos.DianConfigStatus, error)
 	SaveDianConfig(ctx context.Context, dto dtos.DianConfigDTO) (*dtos.DianConfigStatus, error)
 	EmitInvoiceDian(ctx context.Context, dto dtos.EmitInvoiceDianDTO) (*entities.Invoice, error)
+
+	ListAccounts(ctx context.Context, businessID uint) ([]entities.Account, error)
+	CreateAccount(ctx context.Context, dto dtos.CreateAccountDTO) (*entities.Account, error)
+	UpdateAccount(ctx context.Context, dto dtos.UpdateAccountDTO) (*entities.Account, error)
+	ListPostingRules(ctx context.Context) ([]entities.PostingRule, error)
+	SavePostingRule(ctx context.Context, dto dtos.SavePostingRuleDTO) (*entities.PostingRule, error)
+	ListJournalEntries(ctx context.Context, params dtos.ListJournalParams) ([]entities.JournalEntry, int64, error)
+	GetJournalEntry(ctx context.Context, id uint) (*entities.JournalEntry, error)
+	CreateJournalEntry(ctx context.Context, dto dtos.CreateJournalDTO) (*entities.JournalEntry, error)
+	TrialBalance(ctx context.Context, params dtos.LedgerParams) (*dtos.TrialBalance, error)
+	GeneralLedger(ctx context.Context, params dtos.LedgerParams) (*dtos.GeneralLedger, error)
+	IncomeStatement(ctx context.Context, params dtos.LedgerParams) (*dtos.IncomeStatement, error)
+	BalanceSheet(ctx context.Context, params dtos.LedgerParams) (*dtos.BalanceSheet, error)
+	LedgerExport(ctx context.Context, params dtos.LedgerParams) ([]dtos.LedgerLineRow, error)
+	ListPeriods(ctx context.Context, year int) ([]entities.Period, error)
+	ClosePeriod(ctx context.Context, dto dtos.ClosePeriodDTO) (*entities.Period, error)
+	ReopenPeriod(ctx context.Context, year, month int) (*entities.Period, error)
 }
 
 type UseCase struct {
@@ -27,6 +27,9 @@ func (uc *UseCase) CreateManualEntry(ctx context.Context, dto dtos.CreateEntryDT
 	if dto.Amount <= 0 {
 		return nil, domainerrors.ErrInvalidAmount
 	}
+	if err := uc.ensurePeriodOpen(ctx, dto.EntryDate); err != nil {
+		return nil, err
+	}
 	concept, err := uc.repo.GetConceptByID(ctx, dto.ConceptID)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,444 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/errors"
+)
+
+const ledgerBatchSize = 500
+
+func (uc *UseCase) ListJournalEntries(ctx context.Context, params dtos.ListJournalParams) ([]entities.JournalEntry, int64, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 10
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100
+	}
+	params.AccountCode = strings.TrimSpace(params.AccountCode)
+	params.SourceType = strings.ToUpper(strings.TrimSpace(params.SourceType))
+	return uc.repo.ListJournalEntries(ctx, params)
+}
+
+func (uc *UseCase) GetJournalEntry(ctx context.Context, id uint) (*entities.JournalEntry, error) {
+	return uc.repo.GetJournalEntry(ctx, id)
+}
+
+// CreateJournalEntry registra un comprobante manual (ajustes, cierres,
+// causaciones). Debe cuadrar y caer en un periodo abierto.
+func (uc *UseCase) CreateJournalEntry(ctx context.Context, dto dtos.CreateJournalDTO) (*entities.JournalEntry, error) {
+	if len(dto.Lines) < 2 {
+		return nil, domainerrors.ErrJournalInvalidLine
+	}
+	if err := uc.ensurePeriodOpen(ctx, dto.EntryDate); err != nil {
+		return nil, err
+	}
+	businessID := uint(0)
+	if dto.BusinessID != nil {
+		businessID = *dto.BusinessID
+	}
+	chart, err := uc.chartFor(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	je := &entities.JournalEntry{
+		BusinessID:  dto.BusinessID,
+		EntryDate:   dto.EntryDate,
+		Description: strings.TrimSpace(dto.Description),
+		SourceType:  entities.SourceManual,
+		IsAutomatic: false,
+	}
+	if dto.UserID != 0 {
+		userID := dto.UserID
+		je.CreatedBy = &userID
+	}
+	for _, l := range dto.Lines {
+		code := strings.TrimSpace(l.AccountCode)
+		if code == "" || l.Debit < 0 || l.Credit < 0 || (l.Debit > 0) == (l.Credit > 0) {
+			return nil, domainerrors.ErrJournalInvalidLine
+		}
+		account, err := postableAccount(chart, code)
+		if err != nil {
+			return nil, err
+		}
+		lineBusiness := l.BusinessID
+		if lineBusiness == nil {
+			lineBusiness = dto.BusinessID
+		}
+		je.Lines = append(je.Lines, entities.JournalLine{
+			AccountCode: code,
+			AccountName: account.Name,
+			BusinessID:  lineBusiness,
+			Debit:       round2(l.Debit),
+			Credit:      round2(l.Credit),
+			Description: strings.TrimSpace(l.Description),
+		})
+	}
+	if err := totalize(je); err != nil {
+		return nil, err
+	}
+	if _, err := uc.repo.CreateJournalEntry(ctx, je); err != nil {
+		return nil, err
+	}
+	return uc.repo.GetJournalEntry(ctx, je.ID)
+}
+
+// postLedger contabiliza en partida doble los movimientos que aun no tienen
+// comprobante y reversa los comprobantes cuyo movimiento se borro. Lo que no
+// se puede contabilizar (sin regla, periodo cerrado) queda pendiente para la
+// siguiente sincronizacion.
+func (uc *UseCase) postLedger(ctx context.Context, result *dtos.SyncResult) {
+	poster, err := uc.newLedgerPoster(ctx)
+	if err != nil {
+		uc.log.Error(ctx).Err(err).Msg("Accounting ledger: cannot load posting rules")
+		return
+	}
+
+	afterID := uint(0)
+	for {
+		entries, err := uc.repo.FindUnpostedEntries(ctx, afterID, ledgerBatchSize)
+		if err != nil {
+			uc.log.Error(ctx).Err(err).Msg("Accounting ledger: cannot list unposted entries")
+			break
+		}
+		for i := range entries {
+			afterID = entries[i].ID
+			je, err := poster.entryJournal(ctx, &entries[i])
+			if err == nil {
+				var inserted bool
+				if inserted, err = uc.repo.CreateJournalEntry(ctx, je); err == nil && inserted {
+					result.Posted++
+				}
+			}
+			if err != nil {
+				result.PostingFailed++
+				uc.log.Error(ctx).Err(err).Uint("entry_id", entries[i].ID).
+					Str("source_type", entries[i].SourceType).
+					Msg("Accounting ledger: entry not posted")
+			}
+		}
+		if len(entries) < ledgerBatchSize {
+			break
+		}
+	}
+
+	afterID = 0
+	for {
+		candidates, err := uc.repo.FindPendingReversals(ctx, afterID, ledgerBatchSize)
+		if err != nil {
+			uc.log.Error(ctx).Err(err).Msg("Accounting ledger: cannot list pending reversals")
+			break
+		}
+		for i := range candidates {
+			afterID = candidates[i].Journal.ID
+			je, err := poster.reversalJournal(ctx, &candidates[i])
+			if err == nil {
+				var inserted bool
+				if inserted, err = uc.repo.CreateJournalEntry(ctx, je); err == nil && inserted {
+					result.Reversed++
+				}
+			}
+			if err != nil {
+				result.PostingFailed++
+				uc.log.Error(ctx).Err(err).Uint("journal_entry_id", candidates[i].Journal.ID).
+					Msg("Accounting ledger: journal entry not reversed")
+			}
+		}
+		if len(candidates) < ledgerBatchSize {
+			break
+		}
+	}
+
+	if result.Posted > 0 || result.Reversed > 0 {
+		uc.log.Info(ctx).Int("posted", result.Posted).Int("reversed", result.Reversed).Msg("Accounting ledger: journal entries created")
+	}
+}
+
+// ledgerPoster guarda lo que se consulta una vez por sincronizacion.
+type ledgerPoster struct {
+	uc       *UseCase
+	rules    []entities.PostingRule
+	taxKinds map[string]string
+	charts   map[uint]map[string]entities.Account
+	closed   map[string]bool
+}
+
+func (uc *UseCase) newLedgerPoster(ctx context.Context) (*ledgerPoster, error) {
+	rules, err := uc.repo.ListPostingRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+	taxes, err := uc.repo.ListTaxes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	kinds := make(map[string]string, len(taxes))
+	for _, t := range taxes {
+		kinds[t.Code] = t.Kind
+	}
+	return &ledgerPoster{
+		uc:       uc,
+		rules:    rules,
+		taxKinds: kinds,
+		charts:   map[uint]map[string]entities.Account{},
+		closed:   map[string]bool{},
+	}, nil
+}
+
+func (p *ledgerPoster) chart(ctx context.Context, businessID *uint) (map[string]entities.Account, error) {
+	id := uint(0)
+	if businessID != nil {
+		id = *businessID
+	}
+	if chart, ok := p.charts[id]; ok {
+		return chart, nil
+	}
+	chart, err := p.uc.chartFor(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	p.charts[id] = chart
+	return chart, nil
+}
+
+func (p *ledgerPoster) ensureOpen(ctx context.Context, date time.Time) error {
+	key := date.Format("2006-01")
+	closed, ok := p.closed[key]
+	if !ok {
+		period, err := p.uc.repo.GetPeriod(ctx, date.Year(), int(date.Month()))
+		if err != nil {
+			return err
+		}
+		closed = period != nil && period.Status == entities.PeriodClosed
+		p.closed[key] = closed
+	}
+	if closed {
+		return fmt.Errorf("%w: %s", domainerrors.ErrPeriodClosed, key)
+	}
+	return nil
+}
+
+// rule busca la regla del primer origen que tenga; la del negocio gana sobre
+// la global.
+func (p *ledgerPoster) rule(businessID *uint, keys ...string) (*entities.PostingRule, error) {
+	for _, key := range keys {
+		var global *entities.PostingRule
+		for i := range p.rules {
+			r := &p.rules[i]
+			if !r.IsActive || r.SourceType != key {
+				continue
+			}
+			if businessID != nil && r.BusinessID == *businessID {
+				return r, nil
+			}
+			if r.BusinessID == 0 {
+				global = r
+			}
+		}
+		if global != nil {
+			return global, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", domainerrors.ErrPostingRuleNotFound, strings.Join(keys, ", "))
+}
+
+// entryRuleKeys: los automaticos usan su origen; los manuales la regla del
+// concepto (p. ej. COLLABORATOR) o la generica por tipo.
+func entryRuleKeys(e *entities.Entry) []string {
+	if e.SourceType != entities.SourceManual {
+		return []string{e.SourceType}
+	}
+	fallback := entities.RuleManualIncome
+	if e.Kind == entities.KindExpense {
+		fallback = entities.RuleManualExpense
+	}
+	if e.ConceptCode == "" {
+		return []string{fallback}
+	}
+	return []string{e.ConceptCode, fallback}
+}
+
+func (p *ledgerPoster) entryJournal(ctx context.Context, e *entities.Entry) (*entities.JournalEntry, error) {
+	if err := p.ensureOpen(ctx, e.EntryDate); err != nil {
+		return nil, err
+	}
+	rule, err := p.rule(e.BusinessID, entryRuleKeys(e)...)
+	if err != nil {
+		return nil, err
+	}
+	chart, err := p.chart(ctx, e.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := postingLines(e, e.Kind, rule, p.taxKinds, chart)
+	if err != nil {
+		return nil, err
+	}
+	entryID := e.ID
+	je := &entities.JournalEntry{
+		BusinessID:        e.BusinessID,
+		EntryDate:         e.EntryDate,
+		Description:       e.Description,
+		SourceType:        e.SourceType,
+		SourceID:          e.SourceID,
+		AccountingEntryID: &entryID,
+		IsAutomatic:       true,
+		Lines:             lines,
+	}
+	if err := totalize(je); err != nil {
+		return nil, err
+	}
+	return je, nil
+}
+
+// reversalJournal anula un comprobante con fecha del borrado. Una factura
+// anulada se contabiliza como nota credito con su propia regla; lo demas se
+// reversa espejando las lineas.
+func (p *ledgerPoster) reversalJournal(ctx context.Context, c *dtos.ReversalCandidate) (*entities.JournalEntry, error) {
+	date := time.Date(c.DeletedAt.Year(), c.DeletedAt.Month(), c.DeletedAt.Day(), 0, 0, 0, 0, time.UTC)
+	if err := p.ensureOpen(ctx, date); err != nil {
+		return nil, err
+	}
+	originalID := c.Journal.ID
+	je := &entities.JournalEntry{
+		BusinessID:        c.Journal.BusinessID,
+		EntryDate:         date,
+		SourceID:          c.Journal.SourceID,
+		AccountingEntryID: c.Journal.AccountingEntryID,
+		ReversalOfID:      &originalID,
+		IsAutomatic:       true,
+	}
+
+	if c.Entry.SourceType == entities.SourceInvoice {
+		rule, err := p.rule(c.Entry.BusinessID, entities.SourceCreditNote)
+		if err != nil {
+			return nil, err
+		}
+		chart, err := p.chart(ctx, c.Entry.BusinessID)
+		if err != nil {
+			return nil, err
+		}
+		lines, err := postingLines(&c.Entry, entities.KindExpense, rule, p.taxKinds, chart)
+		if err != nil {
+			return nil, err
+		}
+		je.SourceType = entities.SourceCreditNote
+		je.Description = "Nota credito " + c.Entry.Description
+		je.Lines = lines
+	} else {
+		je.SourceType = entities.SourceReversal
+		je.Description = "Reverso: " + c.Journal.Description
+		for _, l := range c.Journal.Lines {
+			je.Lines = append(je.Lines, entities.JournalLine{
+				AccountCode: l.AccountCode,
+				AccountName: l.AccountName,
+				BusinessID:  l.BusinessID,
+				Debit:       l.Credit,
+				Credit:      l.Debit,
+				Description: l.Description,
+			})
+		}
+	}
+	if err := totalize(je); err != nil {
+		return nil, err
+	}
+	return je, nil
+}
+
+// postingLines arma las lineas de un movimiento segun su regla. Con base B,
+// impuestos cobrados C (IVA), retenciones W y otros impuestos O (4x1000):
+//
+//	INCOME:  Db debito B+C-W-O, Db retencion W, Db otros O | Cr credito B, Cr impuesto C
+//	EXPENSE: Db debito B, Db impuesto C, Db otros O | Cr credito B+C-W+O, Cr retencion W
+func postingLines(e *entities.Entry, kind string, rule *entities.PostingRule, taxKinds map[string]string, chart map[string]entities.Account) ([]entities.JournalLine, error) {
+	var charges, withholdings, others float64
+	for _, t := range e.TaxDetail {
+		switch taxKinds[t.TaxCode] {
+		case entities.TaxKindWithholding:
+			withholdings += t.Amount
+		case entities.TaxKindOther:
+			others += t.Amount
+		default:
+			charges += t.Amount
+		}
+	}
+	if (charges != 0 && rule.TaxAccount == "") ||
+		(withholdings != 0 && rule.WithholdingAccount == "") ||
+		(others != 0 && rule.OtherTaxAccount == "") {
+		return nil, fmt.Errorf("%w: %s", domainerrors.ErrRuleMissingAccount, rule.SourceType)
+	}
+
+	base := e.Amount
+	b := &lineBuilder{businessID: e.BusinessID, description: e.Description}
+	if kind == entities.KindExpense {
+		b.add(rule.DebitAccount, base, true)
+		b.add(rule.TaxAccount, charges, true)
+		b.add(rule.OtherTaxAccount, others, true)
+		b.add(rule.CreditAccount, base+charges-withholdings+others, false)
+		b.add(rule.WithholdingAccount, withholdings, false)
+	} else {
+		b.add(rule.DebitAccount, base+charges-withholdings-others, true)
+		b.add(rule.WithholdingAccount, withholdings, true)
+		b.add(rule.OtherTaxAccount, others, true)
+		b.add(rule.CreditAccount, base, false)
+		b.add(rule.TaxAccount, charges, false)
+	}
+
+	for i := range b.lines {
+		account, err := postableAccount(chart, b.lines[i].AccountCode)
+		if err != nil {
+			return nil, err
+		}
+		b.lines[i].AccountName = account.Name
+	}
+	return b.lines, nil
+}
+
+type lineBuilder struct {
+	businessID  *uint
+	description string
+	lines       []entities.JournalLine
+}
+
+// add omite los valores en cero y pasa los negativos al lado contrario.
+func (b *lineBuilder) add(code string, amount float64, debit bool) {
+	amount = round2(amount)
+	if amount == 0 {
+		return
+	}
+	if amount < 0 {
+		amount = -amount
+		debit = !debit
+	}
+	line := entities.JournalLine{AccountCode: code, BusinessID: b.businessID, Description: b.description}
+	if debit {
+		line.Debit = amount
+	} else {
+		line.Credit = amount
+	}
+	b.lines = append(b.lines, line)
+}
+
+// totalize suma el comprobante y rechaza los que no cuadran o estan vacios.
+func totalize(je *entities.JournalEntry) error {
+	var debit, credit float64
+	for _, l := range je.Lines {
+		debit += l.Debit
+		credit += l.Credit
+	}
+	je.TotalDebit = round2(debit)
+	je.TotalCredit = round2(credit)
+	if len(je.Lines) == 0 || je.TotalDebit == 0 || je.TotalDebit != je.TotalCredit {
+		return domainerrors.ErrJournalUnbalanced
+	}
+	return nil
+}
@@ -0,0 +1,456 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pucDePrueba() []entities.Account {
+	codes := map[string]string{
+		"1":      "Activo",
+		"11":     "Disponible",
+		"1110":   "Bancos",
+		"111005": "Moneda nacional",
+		"1305":   "Clientes",
+		"130505": "Nacionales",
+		"1355":   "Anticipo de impuestos",
+		"135515": "Retencion en la fuente",
+		"2408":   "IVA por pagar",
+		"240805": "IVA generado",
+		"240810": "IVA descontable",
+		"4":      "Ingresos",
+		"41":     "Operacionales",
+		"4145":   "Transporte",
+		"414595": "Actividades conexas",
+		"5":      "Gastos",
+		"5195":   "Diversos",
+		"519595": "Otros",
+		"5305":   "Financieros",
+		"530595": "Otros financieros",
+	}
+	out := make([]entities.Account, 0, len(codes))
+	for code, name := range codes {
+		out = append(out, entities.Account{
+			Code:       code,
+			Name:       name,
+			Nature:     defaultNature(code),
+			ParentCode: accountParent(code),
+			Level:      accountLevel(code),
+			IsPostable: len(code) >= 6,
+			IsActive:   true,
+		})
+	}
+	return out
+}
+
+func reglaDePrueba(sourceType string) entities.PostingRule {
+	return entities.PostingRule{
+		SourceType:         sourceType,
+		DebitAccount:       "130505",
+		CreditAccount:      "414595",
+		TaxAccount:         "240805",
+		WithholdingAccount: "135515",
+		OtherTaxAccount:    "530595",
+		IsActive:           true,
+	}
+}
+
+func impuestosDePrueba() map[string]string {
+	return map[string]string{
+		"IVA19":  entities.TaxKindCharge,
+		"RETE4":  entities.TaxKindWithholding,
+		"GMF4X1": entities.TaxKindOther,
+	}
+}
+
+func lineasPorCuenta(lines []entities.JournalLine) map[string][2]float64 {
+	out := map[string][2]float64{}
+	for _, l := range lines {
+		v := out[l.AccountCode]
+		out[l.AccountCode] = [2]float64{v[0] + l.Debit, v[1] + l.Credit}
+	}
+	return out
+}
+
+func TestPostingLines_IngresoConIVAYRetencion_Cuadra(t *testing.T) {
+	e := &entities.Entry{
+		Amount: 100000,
+		TaxDetail: []entities.TaxLine{
+			{TaxCode: "IVA19", Amount: 19000},
+			{TaxCode: "RETE4", Amount: 4000},
+		},
+	}
+	rule := reglaDePrueba("GUIDE_MARGIN")
+
+	lines, err := postingLines(e, entities.KindIncome, &rule, impuestosDePrueba(), effectiveChart(pucDePrueba()))
+	require.NoError(t, err)
+
+	got := lineasPorCuenta(lines)
+	assert.Equal(t, [2]float64{115000, 0}, got["130505"])
+	assert.Equal(t, [2]float64{4000, 0}, got["135515"])
+	assert.Equal(t, [2]float64{0, 100000}, got["414595"])
+	assert.Equal(t, [2]float64{0, 19000}, got["240805"])
+	assert.NotContains(t, got, "530595", "sin otros impuestos no hay linea en cero")
+}
+
+func TestPostingLines_GastoConOtrosImpuestos_Cuadra(t *testing.T) {
+	e := &entities.Entry{
+		Amount:    50000,
+		TaxDetail: []entities.TaxLine{{TaxCode: "GMF4X1", Amount: 200}},
+	}
+	rule := entities.PostingRule{
+		SourceType:      "MANUAL_EXPENSE",
+		DebitAccount:    "519595",
+		CreditAccount:   "111005",
+		OtherTaxAccount: "530595",
+		IsActive:        true,
+	}
+
+	lines, err := postingLines(e, entities.KindExpense, &rule, impuestosDePrueba(), effectiveChart(pucDePrueba()))
+	require.NoError(t, err)
+
+	je := &entities.JournalEntry{Lines: lines}
+	require.NoError(t, totalize(je))
+	assert.Equal(t, 50200.0, je.TotalDebit)
+	got := lineasPorCuenta(lines)
+	assert.Equal(t, [2]float64{0, 50200}, got["111005"])
+	assert.Equal(t, [2]float64{200, 0}, got["530595"])
+}
+
+func TestPostingLines_ImpuestoSinCuentaEnLaRegla_Falla(t *testing.T) {
+	e := &entities.Entry{
+		Amount:    100000,
+		TaxDetail: []entities.TaxLine{{TaxCode: "IVA19", Amount: 19000}},
+	}
+	rule := reglaDePrueba("SUBSCRIPTION")
+	rule.TaxAccount = ""
+
+	_, err := postingLines(e, entities.KindIncome, &rule, impuestosDePrueba(), effectiveChart(pucDePrueba()))
+	assert.ErrorIs(t, err, domainerrors.ErrRuleMissingAccount)
+}
+
+func TestPostingLines_CuentaMayor_NoEsAuxiliar(t *testing.T) {
+	rule := reglaDePrueba("GUIDE_MARGIN")
+	rule.CreditAccount = "4145"
+
+	_, err := postingLines(&entities.Entry{Amount: 1000}, entities.KindIncome, &rule, impuestosDePrueba(), effectiveChart(pucDePrueba()))
+	assert.ErrorIs(t, err, domainerrors.ErrAccountNotPostable)
+}
+
+func TestLedgerPoster_ReglaDelNegocioGanaSobreLaGlobal(t *testing.T) {
+	global := reglaDePrueba("GUIDE_MARGIN")
+	propia := reglaDePrueba("GUIDE_MARGIN")
+	propia.BusinessID = 7
+	propia.DebitAccount = "111005"
+	p := &ledgerPoster{rules: []entities.PostingRule{global, propia}}
+
+	businessID := uint(7)
+	rule, err := p.rule(&businessID, "GUIDE_MARGIN")
+	require.NoError(t, err)
+	assert.Equal(t, "111005", rule.DebitAccount)
+
+	otro := uint(8)
+	rule, err = p.rule(&otro, "GUIDE_MARGIN")
+	require.NoError(t, err)
+	assert.Equal(t, "130505", rule.DebitAccount)
+
+	_, err = p.rule(nil, "WALLET_RECHARGE")
+	assert.ErrorIs(t, err, domainerrors.ErrPostingRuleNotFound)
+}
+
+func TestEntryRuleKeys_ManualUsaConceptoYLuegoLaGenerica(t *testing.T) {
+	assert.Equal(t, []string{"COD_PAYOUT"}, entryRuleKeys(&entities.Entry{SourceType: "COD_PAYOUT", ConceptCode: "X"}))
+	assert.Equal(t,
+		[]string{"COLLABORATOR", entities.RuleManualExpense},
+		entryRuleKeys(&entities.Entry{SourceType: entities.SourceManual, ConceptCode: "COLLABORATOR", Kind: entities.KindExpense}),
+	)
+	assert.Equal(t,
+		[]string{entities.RuleManualIncome},
+		entryRuleKeys(&entities.Entry{SourceType: entities.SourceManual, Kind: entities.KindIncome}),
+	)
+}
+
+func TestCreateJournalEntry_Descuadrado_Rechaza(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		ListAccountsFn: func(ctx context.Context, businessID uint) ([]entities.Account, error) {
+			return pucDePrueba(), nil
+		},
+	}
+	uc := newAccountingUseCase(repo, nil, nil, nil)
+
+	_, err := uc.CreateJournalEntry(context.Background(), dtos.CreateJournalDTO{
+		EntryDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+		Lines: []dtos.JournalLineDTO{
+			{AccountCode: "519595", Debit: 1000},
+			{AccountCode: "111005", Credit: 900},
+		},
+	})
+	assert.ErrorIs(t, err, domainerrors.ErrJournalUnbalanced)
+	assert.Empty(t, repo.CreatedJournals)
+}
+
+func TestCreateJournalEntry_LineaConDebitoYCredito_Rechaza(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		ListAccountsFn: func(ctx context.Context, businessID uint) ([]entities.Account, error) {
+			return pucDePrueba(), nil
+		},
+	}
+	uc := newAccountingUseCase(repo, nil, nil, nil)
+
+	_, err := uc.CreateJournalEntry(context.Background(), dtos.CreateJournalDTO{
+		EntryDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+		Lines: []dtos.JournalLineDTO{
+			{AccountCode: "519595", Debit: 1000, Credit: 1000},
+			{AccountCode: "111005", Credit: 1000},
+		},
+	})
+	assert.ErrorIs(t, err, domainerrors.ErrJournalInvalidLine)
+}
+
+func TestCreateJournalEntry_PeriodoCerrado_Rechaza(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		GetPeriodFn: func(ctx context.Context, year, month int) (*entities.Period, error) {
+			return &entities.Period{Year: year, Month: month, Status: entities.PeriodClosed}, nil
+		},
+	}
+	uc := newAccountingUseCase(repo, nil, nil, nil)
+
+	_, err := uc.CreateJournalEntry(context.Background(), dtos.CreateJournalDTO{
+		EntryDate: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+		Lines: []dtos.JournalLineDTO{
+			{AccountCode: "519595", Debit: 1000},
+			{AccountCode: "111005", Credit: 1000},
+		},
+	})
+	assert.ErrorIs(t, err, domainerrors.ErrPeriodClosed)
+}
+
+func TestCreateJournalEntry_Cuadrado_SeGuardaManual(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		ListAccountsFn: func(ctx context.Context, businessID uint) ([]entities.Account, error) {
+			return pucDePrueba(), nil
+		},
+	}
+	uc := newAccountingUseCase(repo, nil, nil, nil)
+
+	_, err := uc.CreateJournalEntry(context.Background(), dtos.CreateJournalDTO{
+		EntryDate:   time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+		Description: "  Ajuste  ",
+		UserID:      3,
+		Lines: []dtos.JournalLineDTO{
+			{AccountCode: "519595", Debit: 1000.004},
+			{AccountCode: "111005", Credit: 1000},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, repo.CreatedJournals, 1)
+	je := repo.CreatedJournals[0]
+	assert.Equal(t, entities.SourceManual, je.SourceType)
+	assert.False(t, je.IsAutomatic)
+	assert.Equal(t, "Ajuste", je.Description)
+	assert.Equal(t, 1000.0, je.TotalDebit)
+	require.NotNil(t, je.CreatedBy)
+	assert.Equal(t, uint(3), *je.CreatedBy)
+	assert.Equal(t, "Otros", je.Lines[0].AccountName)
+}
+
+func TestPostLedger_ContabilizaYCuentaLosFallidos(t *testing.T) {
+	marzo := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	febrero := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	repo := &mocks.RepositoryMock{
+		ListAccountsFn: func(ctx context.Context, businessID uint) ([]entities.Account, error) {
+			return pucDePrueba(), nil
+		},
+		ListPostingRulesFn: func(ctx context.Context) ([]entities.PostingRule, error) {
+			return []entities.PostingRule{reglaDePrueba("GUIDE_MARGIN")}, nil
+		},
+		GetPeriodFn: func(ctx context.Context, year, month int) (*entities.Period, error) {
+			if month == 2 {
+				return &entities.Period{Year: year, Month: month, Status: entities.PeriodClosed}, nil
+			}
+			return nil, nil
+		},
+		FindUnpostedEntriesFn: func(ctx context.Context, afterID uint, limit int) ([]entities.Entry, error) {
+			return []entities.Entry{
+				{ID: 1, SourceType: "GUIDE_MARGIN", Kind: entities.KindIncome, Amount: 5000, EntryDate: marzo},
+				{ID: 2, SourceType: "GUIDE_MARGIN", Kind: entities.KindIncome, Amount: 5000, EntryDate: febrero},
+				{ID: 3, SourceType: "WALLET_RECHARGE", Kind: entities.KindIncome, Amount: 5000, EntryDate: marzo},
+			}, nil
+		},
+	}
+	uc := newAccountingUseCase(repo, nil, nil, nil).(*UseCase)
+
+	result := &dtos.SyncResult{}
+	uc.postLedger(context.Background(), result)
+
+	assert.Equal(t, 1, result.Posted)
+	assert.Equal(t, 2, result.PostingFailed, "periodo cerrado y origen sin regla quedan pendientes")
+	require.Len(t, repo.CreatedJournals, 1)
+	je := repo.CreatedJournals[0]
+	require.NotNil(t, je.AccountingEntryID)
+	assert.Equal(t, uint(1), *je.AccountingEntryID)
+	assert.True(t, je.IsAutomatic)
+}
+
+func TestReversalJournal_FacturaAnulada_EsNotaCredito(t *testing.T) {
+	rule := entities.PostingRule{
+		SourceType:    entities.SourceCreditNote,
+		DebitAccount:  "414595",
+		CreditAccount: "130505",
+		TaxAccount:    "240805",
+		IsActive:      true,
+	}
+	repo := &mocks.RepositoryMock{
+		ListAccountsFn: func(ctx context.Context, businessID uint) ([]entities.Account, error) {
+			return pucDePrueba(), nil
+		},
+	}
+	p := &ledgerPoster{
+		uc:       newAccountingUseCase(repo, nil, nil, nil).(*UseCase),
+		rules:    []entities.PostingRule{rule},
+		taxKinds: impuestosDePrueba(),
+		charts:   map[uint]map[string]entities.Account{},
+		closed:   map[string]bool{},
+	}
+	entryID := uint(40)
+	candidate := &dtos.ReversalCandidate{
+		Journal: entities.JournalEntry{ID: 9, AccountingEntryID: &entryID, SourceID: "FV-12"},
+		Entry: entities.Entry{
+			ID:          40,
+			SourceType:  entities.SourceInvoice,
+			Kind:        entities.KindIncome,
+			Amount:      100000,
+			Description: "FV-12",
+			TaxDetail:   []entities.TaxLine{{TaxCode: "IVA19", Amount: 19000}},
+		},
+		DeletedAt: time.Date(2026, 4, 2, 15, 30, 0, 0, time.Local),
+	}
+
+	je, err := p.reversalJournal(context.Background(), candidate)
+	require.NoError(t, err)
+	assert.Equal(t, entities.SourceCreditNote, je.SourceType)
+	require.NotNil(t, je.ReversalOfID)
+	assert.Equal(t, uint(9), *je.ReversalOfID)
+	assert.Equal(t, "2026-04-02", je.EntryDate.Format("2006-01-02"))
+	got := lineasPorCuenta(je.Lines)
+	assert.Equal(t, [2]float64{100000, 0}, got["414595"])
+	assert.Equal(t, [2]float64{19000, 0}, got["240805"])
+	assert.Equal(t, [2]float64{0, 119000}, got["130505"])
+}
+
+func TestReversalJournal_OtrosOrigenes_EspejanLasLineas(t *testing.T) {
+	p := &ledgerPoster{closed: map[string]bool{"2026-04": false}}
+	candidate := &dtos.ReversalCandidate{
+		Journal: entities.JournalEntry{
+			ID:          5,
+			Description: "Gasto",
+			Lines: []entities.JournalLine{
+				{AccountCode: "519595", Debit: 800},
+				{AccountCode: "111005", Credit: 800},
+			},
+		},
+		Entry:     entities.Entry{SourceType: entities.SourceManual},
+		DeletedAt: time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC),
+	}
+
+	je, err := p.reversalJournal(context.Background(), candidate)
+	require.NoError(t, err)
+	assert.Equal(t, entities.SourceReversal, je.SourceType)
+	got := lineasPorCuenta(je.Lines)
+	assert.Equal(t, [2]float64{0, 800}, got["519595"])
+	assert.Equal(t, [2]float64{800, 0}, got["111005"])
+}
+
+func TestClosePeriod_ConMovimientosSinContabilizar_Rechaza(t *testing.T) {
+	var desde, hasta time.Time
+	repo := &mocks.RepositoryMock{
+		CountUnpostedEntriesFn: func(ctx context.Context, from, to time.Time) (int64, error) {
+			desde, hasta = from, to
+			return 2, nil
+		},
+	}
+	uc := newAccountingUseCase(repo, nil, nil, nil)
+
+	_, err := uc.ClosePeriod(context.Background(), dtos.ClosePeriodDTO{Year: 2026, Month: 2})
+	assert.ErrorIs(t, err, domainerrors.ErrPeriodHasPending)
+	assert.Equal(t, "2026-02-01", desde.Format("2006-01-02"))
+	assert.Equal(t, "2026-02-28", hasta.Format("2006-01-02"))
+	assert.Empty(t, repo.SavedPeriods)
+}
+
+func TestClosePeriod_YReabrir(t *testing.T) {
+	repo := &mocks.RepositoryMock{}
+	uc := newAccountingUseCase(repo, nil, nil, nil)
+
+	period, err := uc.ClosePeriod(context.Background(), dtos.ClosePeriodDTO{Year: 2026, Month: 1, UserID: 4})
+	require.NoError(t, err)
+	assert.Equal(t, entities.PeriodClosed, period.Status)
+	require.NotNil(t, period.ClosedBy)
+	assert.Equal(t, uint(4), *period.ClosedBy)
+
+	repo.GetPeriodFn = func(ctx context.Context, year, month int) (*entities.Period, error) {
+		return &entities.Period{ID: 1, Year: year, Month: month, Status: entities.PeriodClosed}, nil
+	}
+	_, err = uc.ClosePeriod(context.Background(), dtos.ClosePeriodDTO{Year: 2026, Month: 1})
+	assert.ErrorIs(t, err, domainerrors.ErrPeriodAlreadyClosed)
+
+	period, err = uc.ReopenPeriod(context.Background(), 2026, 1)
+	require.NoError(t, err)
+	assert.Equal(t, entities.PeriodOpen, period.Status)
+	assert.Nil(t, period.ClosedAt)
+
+	_, err = uc.ClosePeriod(context.Background(), dtos.ClosePeriodDTO{Year: 2026, Month: 13})
+	assert.ErrorIs(t, err, domainerrors.ErrInvalidPeriodMonth)
+}
+
+func TestTrialBalance_AcumulaEnLasCuentasMayores(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		ListAccountsFn: func(ctx context.Context, businessID uint) ([]entities.Account, error) {
+			return pucDePrueba(), nil
+		},
+		AccountMovementsFn: func(ctx context.Context, params dtos.LedgerParams) ([]dtos.AccountMovement, error) {
+			return []dtos.AccountMovement{
+				{AccountCode: "130505", OpeningDebit: 1000, Debit: 11900},
+				{AccountCode: "414595", OpeningCredit: 1000, YearOpeningCredit: 400, Credit: 10000},
+				{AccountCode: "240805", Credit: 1900},
+			}, nil
+		},
+	}
+	uc := newAccountingUseCase(repo, nil, nil, nil)
+
+	tb, err := uc.TrialBalance(context.Background(), dtos.LedgerParams{
+		From: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	assert.True(t, tb.Balanced)
+	assert.Equal(t, 11900.0, tb.TotalDebit)
+
+	rows := map[string]dtos.TrialBalanceRow{}
+	for _, r := range tb.Rows {
+		rows[r.Code] = r
+	}
+	assert.Equal(t, 12900.0, rows["1"].ClosingBalance)
+	assert.Equal(t, 1000.0, rows["13"].OpeningBalance)
+	assert.Equal(t, 400.0, rows["414595"].OpeningBalance, "resultados abren con lo del ano")
+	assert.Equal(t, 10400.0, rows["4"].ClosingBalance)
+	assert.Equal(t, "Ingresos", rows["4"].Name)
+}
+
+func TestTrialBalance_RangoInvertido_Rechaza(t *testing.T) {
+	uc := newAccountingUseCase(nil, nil, nil, nil)
+
+	_, err := uc.TrialBalance(context.Background(), dtos.LedgerParams{
+		From: time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.ErrorIs(t, err, domainerrors.ErrInvalidPeriod)
+}
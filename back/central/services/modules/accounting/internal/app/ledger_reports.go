@@ -0,0 +1,356 @@
+package app
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/errors"
+)
+
+type ledgerBalance struct {
+	openingDebit  float64
+	openingCredit float64
+	debit         float64
+	credit        float64
+}
+
+// TrialBalance es el balance de prueba del rango: saldo inicial, debitos,
+// creditos y saldo final de cada cuenta y de sus mayores. Las cuentas de
+// resultados arrancan el 1 de enero del ano de From.
+func (uc *UseCase) TrialBalance(ctx context.Context, params dtos.LedgerParams) (*dtos.TrialBalance, error) {
+	if !validRange(params.From, params.To) {
+		return nil, domainerrors.ErrInvalidPeriod
+	}
+	movements, err := uc.repo.AccountMovements(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	chart, err := uc.reportChart(ctx, params.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &dtos.TrialBalance{
+		From: params.From.Format("2006-01-02"),
+		To:   params.To.Format("2006-01-02"),
+		Rows: []dtos.TrialBalanceRow{},
+	}
+	for _, m := range movements {
+		out.TotalDebit += m.Debit
+		out.TotalCredit += m.Credit
+	}
+	out.TotalDebit = round2(out.TotalDebit)
+	out.TotalCredit = round2(out.TotalCredit)
+	out.Balanced = out.TotalDebit == out.TotalCredit
+
+	balances := rollupMovements(movements, true)
+	names := movementNames(movements)
+	for _, code := range sortedCodes(balances) {
+		b := balances[code]
+		nature := natureOf(chart, code)
+		opening := signedBalance(nature, b.openingDebit, b.openingCredit)
+		out.Rows = append(out.Rows, dtos.TrialBalanceRow{
+			Code:           code,
+			Name:           nameOf(chart, names, code),
+			Level:          accountLevel(code),
+			Nature:         nature,
+			OpeningBalance: round2(opening),
+			Debit:          round2(b.debit),
+			Credit:         round2(b.credit),
+			ClosingBalance: round2(opening + signedBalance(nature, b.debit, b.credit)),
+		})
+	}
+	return out, nil
+}
+
+// GeneralLedger es el libro mayor: cada cuenta auxiliar con su saldo
+// inicial y las lineas del rango con el saldo corrido.
+func (uc *UseCase) GeneralLedger(ctx context.Context, params dtos.LedgerParams) (*dtos.GeneralLedger, error) {
+	if !validRange(params.From, params.To) {
+		return nil, domainerrors.ErrInvalidPeriod
+	}
+	params.AccountCode = strings.TrimSpace(params.AccountCode)
+	movements, err := uc.repo.AccountMovements(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := uc.repo.LedgerLines(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	chart, err := uc.reportChart(ctx, params.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+	names := movementNames(movements)
+
+	accounts := map[string]*dtos.GeneralLedgerAccount{}
+	account := func(code string) *dtos.GeneralLedgerAccount {
+		if a, ok := accounts[code]; ok {
+			return a
+		}
+		a := &dtos.GeneralLedgerAccount{
+			Code:   code,
+			Name:   nameOf(chart, names, code),
+			Nature: natureOf(chart, code),
+			Lines:  []dtos.GeneralLedgerLine{},
+		}
+		accounts[code] = a
+		return a
+	}
+	for _, m := range movements {
+		a := account(m.AccountCode)
+		od, oc := openingOf(m, true)
+		a.OpeningBalance = round2(signedBalance(a.Nature, od, oc))
+		a.ClosingBalance = a.OpeningBalance
+	}
+	for _, l := range lines {
+		a := account(l.AccountCode)
+		a.Debit += l.Debit
+		a.Credit += l.Credit
+		a.ClosingBalance = round2(a.ClosingBalance + signedBalance(a.Nature, l.Debit, l.Credit))
+		a.Lines = append(a.Lines, dtos.GeneralLedgerLine{
+			JournalEntryID: l.JournalEntryID,
+			Date:           l.EntryDate.Format("2006-01-02"),
+			Description:    l.Description,
+			SourceType:     l.SourceType,
+			SourceID:       l.SourceID,
+			BusinessName:   l.BusinessName,
+			Debit:          l.Debit,
+			Credit:         l.Credit,
+			Balance:        a.ClosingBalance,
+		})
+	}
+
+	out := &dtos.GeneralLedger{
+		From:     params.From.Format("2006-01-02"),
+		To:       params.To.Format("2006-01-02"),
+		Accounts: make([]dtos.GeneralLedgerAccount, 0, len(accounts)),
+	}
+	for _, a := range accounts {
+		a.Debit = round2(a.Debit)
+		a.Credit = round2(a.Credit)
+		out.Accounts = append(out.Accounts, *a)
+	}
+	sort.Slice(out.Accounts, func(i, j int) bool { return out.Accounts[i].Code < out.Accounts[j].Code })
+	return out, nil
+}
+
+// IncomeStatement es el estado de resultados del rango: ingresos (clase 4),
+// gastos (clase 5) y costos (clases 6 y 7).
+func (uc *UseCase) IncomeStatement(ctx context.Context, params dtos.LedgerParams) (*dtos.IncomeStatement, error) {
+	if !validRange(params.From, params.To) {
+		return nil, domainerrors.ErrInvalidPeriod
+	}
+	movements, err := uc.repo.AccountMovements(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	chart, err := uc.reportChart(ctx, params.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &dtos.IncomeStatement{
+		From:     params.From.Format("2006-01-02"),
+		To:       params.To.Format("2006-01-02"),
+		Income:   []dtos.StatementRow{},
+		Expenses: []dtos.StatementRow{},
+		Costs:    []dtos.StatementRow{},
+	}
+	for _, m := range movements {
+		switch m.AccountCode[0] {
+		case '4':
+			out.TotalIncome += m.Credit - m.Debit
+		case '5':
+			out.TotalExpenses += m.Debit - m.Credit
+		case '6', '7':
+			out.TotalCosts += m.Debit - m.Credit
+		}
+	}
+	out.TotalIncome = round2(out.TotalIncome)
+	out.TotalExpenses = round2(out.TotalExpenses)
+	out.TotalCosts = round2(out.TotalCosts)
+	out.NetIncome = round2(out.TotalIncome - out.TotalExpenses - out.TotalCosts)
+
+	balances := rollupMovements(movements, false)
+	names := movementNames(movements)
+	for _, code := range sortedCodes(balances) {
+		b := balances[code]
+		row := dtos.StatementRow{Code: code, Name: nameOf(chart, names, code), Level: accountLevel(code)}
+		switch code[0] {
+		case '4':
+			row.Amount = round2(b.credit - b.debit)
+			out.Income = append(out.Income, row)
+		case '5':
+			row.Amount = round2(b.debit - b.credit)
+			out.Expenses = append(out.Expenses, row)
+		case '6', '7':
+			row.Amount = round2(b.debit - b.credit)
+			out.Costs = append(out.Costs, row)
+		}
+	}
+	return out, nil
+}
+
+// BalanceSheet es el balance general al corte To. Mientras no se registre
+// el cierre anual, el resultado del ano y el de anos anteriores se
+// calculan de las cuentas de resultados y se suman al patrimonio.
+func (uc *UseCase) BalanceSheet(ctx context.Context, params dtos.LedgerParams) (*dtos.BalanceSheet, error) {
+	if params.To.IsZero() {
+		return nil, domainerrors.ErrInvalidPeriod
+	}
+	params.From = time.Date(params.To.Year(), 1, 1, 0, 0, 0, 0, params.To.Location())
+	movements, err := uc.repo.AccountMovements(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	chart, err := uc.reportChart(ctx, params.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &dtos.BalanceSheet{
+		AsOf:        params.To.Format("2006-01-02"),
+		Assets:      []dtos.StatementRow{},
+		Liabilities: []dtos.StatementRow{},
+		Equity:      []dtos.StatementRow{},
+	}
+	for _, m := range movements {
+		debit := m.OpeningDebit + m.Debit
+		credit := m.OpeningCredit + m.Credit
+		switch {
+		case m.AccountCode[0] == '1':
+			out.TotalAssets += debit - credit
+		case m.AccountCode[0] == '2':
+			out.TotalLiabilities += credit - debit
+		case m.AccountCode[0] == '3':
+			out.TotalEquity += credit - debit
+		case isResultAccount(m.AccountCode):
+			out.PriorResults += m.OpeningCredit - m.OpeningDebit
+			out.PeriodResult += m.Credit - m.Debit
+		}
+	}
+	out.PriorResults = round2(out.PriorResults)
+	out.PeriodResult = round2(out.PeriodResult)
+	out.TotalAssets = round2(out.TotalAssets)
+	out.TotalLiabilities = round2(out.TotalLiabilities)
+	out.TotalEquity = round2(out.TotalEquity + out.PriorResults + out.PeriodResult)
+	out.Balanced = out.TotalAssets == round2(out.TotalLiabilities+out.TotalEquity)
+
+	balances := rollupMovements(movements, false)
+	names := movementNames(movements)
+	for _, code := range sortedCodes(balances) {
+		b := balances[code]
+		debit := b.openingDebit + b.debit
+		credit := b.openingCredit + b.credit
+		row := dtos.StatementRow{Code: code, Name: nameOf(chart, names, code), Level: accountLevel(code)}
+		switch code[0] {
+		case '1':
+			row.Amount = round2(debit - credit)
+			out.Assets = append(out.Assets, row)
+		case '2':
+			row.Amount = round2(credit - debit)
+			out.Liabilities = append(out.Liabilities, row)
+		case '3':
+			row.Amount = round2(credit - debit)
+			out.Equity = append(out.Equity, row)
+		}
+	}
+	return out, nil
+}
+
+// LedgerExport devuelve todas las lineas del rango para el contador.
+func (uc *UseCase) LedgerExport(ctx context.Context, params dtos.LedgerParams) ([]dtos.LedgerLineRow, error) {
+	if !validRange(params.From, params.To) {
+		return nil, domainerrors.ErrInvalidPeriod
+	}
+	params.AccountCode = strings.TrimSpace(params.AccountCode)
+	return uc.repo.LedgerLines(ctx, params)
+}
+
+func (uc *UseCase) reportChart(ctx context.Context, businessID *uint) (map[string]entities.Account, error) {
+	id := uint(0)
+	if businessID != nil {
+		id = *businessID
+	}
+	return uc.chartFor(ctx, id)
+}
+
+// rollupMovements suma cada cuenta auxiliar en todas sus mayores (clase,
+// grupo, cuenta, subcuenta).
+func rollupMovements(movements []dtos.AccountMovement, yearOpening bool) map[string]*ledgerBalance {
+	out := map[string]*ledgerBalance{}
+	for _, m := range movements {
+		od, oc := openingOf(m, yearOpening)
+		for _, code := range accountPath(m.AccountCode) {
+			b, ok := out[code]
+			if !ok {
+				b = &ledgerBalance{}
+				out[code] = b
+			}
+			b.openingDebit += od
+			b.openingCredit += oc
+			b.debit += m.Debit
+			b.credit += m.Credit
+		}
+	}
+	return out
+}
+
+// openingOf: con yearOpening las cuentas de resultados solo arrastran lo
+// del mismo ano.
+func openingOf(m dtos.AccountMovement, yearOpening bool) (float64, float64) {
+	if yearOpening && isResultAccount(m.AccountCode) {
+		return m.YearOpeningDebit, m.YearOpeningCredit
+	}
+	return m.OpeningDebit, m.OpeningCredit
+}
+
+func signedBalance(nature string, debit, credit float64) float64 {
+	if nature == entities.NatureCredit {
+		return credit - debit
+	}
+	return debit - credit
+}
+
+func natureOf(chart map[string]entities.Account, code string) string {
+	if a, ok := chart[code]; ok && a.Nature != "" {
+		return a.Nature
+	}
+	return defaultNature(code)
+}
+
+// nameOf usa el plan de cuentas y, si la cuenta ya no esta, el nombre con
+// que se contabilizo.
+func nameOf(chart map[string]entities.Account, names map[string]string, code string) string {
+	if a, ok := chart[code]; ok {
+		return a.Name
+	}
+	return names[code]
+}
+
+func movementNames(movements []dtos.AccountMovement) map[string]string {
+	names := make(map[string]string, len(movements))
+	for _, m := range movements {
+		names[m.AccountCode] = m.AccountName
+	}
+	return names
+}
+
+func sortedCodes(balances map[string]*ledgerBalance) []string {
+	codes := make([]string, 0, len(balances))
+	for code := range balances {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func validRange(from, to time.Time) bool {
+	return !from.IsZero() && !to.IsZero() && !to.Before(from)
+}
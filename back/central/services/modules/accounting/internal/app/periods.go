@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/errors"
+)
+
+// ListPeriods devuelve los 12 meses del ano; los que no tienen registro
+// estan abiertos.
+func (uc *UseCase) ListPeriods(ctx context.Context, year int) ([]entities.Period, error) {
+	if year < 1 {
+		return nil, domainerrors.ErrInvalidPeriodMonth
+	}
+	stored, err := uc.repo.ListPeriods(ctx, year)
+	if err != nil {
+		return nil, err
+	}
+	byMonth := make(map[int]entities.Period, len(stored))
+	for _, p := range stored {
+		byMonth[p.Month] = p
+	}
+	out := make([]entities.Period, 12)
+	for m := 1; m <= 12; m++ {
+		p, ok := byMonth[m]
+		if !ok {
+			p = entities.Period{Year: year, Month: m, Status: entities.PeriodOpen}
+		}
+		out[m-1] = p
+	}
+	return out, nil
+}
+
+// ClosePeriod bloquea el mes: no se aceptan comprobantes ni movimientos
+// manuales con fecha dentro de el. Solo se cierra si todo lo del mes ya
+// esta contabilizado.
+func (uc *UseCase) ClosePeriod(ctx context.Context, dto dtos.ClosePeriodDTO) (*entities.Period, error) {
+	if dto.Year < 1 || dto.Month < 1 || dto.Month > 12 {
+		return nil, domainerrors.ErrInvalidPeriodMonth
+	}
+	period, err := uc.repo.GetPeriod(ctx, dto.Year, dto.Month)
+	if err != nil {
+		return nil, err
+	}
+	if period != nil && period.Status == entities.PeriodClosed {
+		return nil, domainerrors.ErrPeriodAlreadyClosed
+	}
+	from := time.Date(dto.Year, time.Month(dto.Month), 1, 0, 0, 0, 0, time.UTC)
+	pending, err := uc.repo.CountUnpostedEntries(ctx, from, from.AddDate(0, 1, -1))
+	if err != nil {
+		return nil, err
+	}
+	if pending > 0 {
+		return nil, domainerrors.ErrPeriodHasPending
+	}
+
+	if period == nil {
+		period = &entities.Period{Year: dto.Year, Month: dto.Month}
+	}
+	now := time.Now()
+	period.Status = entities.PeriodClosed
+	period.ClosedAt = &now
+	period.ClosedBy = nil
+	if dto.UserID != 0 {
+		userID := dto.UserID
+		period.ClosedBy = &userID
+	}
+	if err := uc.repo.SavePeriod(ctx, period); err != nil {
+		return nil, err
+	}
+	uc.log.Info(ctx).Int("year", dto.Year).Int("month", dto.Month).Msg("Accounting period closed")
+	return period, nil
+}
+
+func (uc *UseCase) ReopenPeriod(ctx context.Context, year, month int) (*entities.Period, error) {
+	if year < 1 || month < 1 || month > 12 {
+		return nil, domainerrors.ErrInvalidPeriodMonth
+	}
+	period, err := uc.repo.GetPeriod(ctx, year, month)
+	if err != nil {
+		return nil, err
+	}
+	if period == nil || period.Status != entities.PeriodClosed {
+		return nil, domainerrors.ErrPeriodNotClosed
+	}
+	period.Status = entities.PeriodOpen
+	period.ClosedAt = nil
+	period.ClosedBy = nil
+	if err := uc.repo.SavePeriod(ctx, period); err != nil {
+		return nil, err
+	}
+	uc.log.Info(ctx).Int("year", year).Int("month", month).Msg("Accounting period reopened")
+	return period, nil
+}
+
+func (uc *UseCase) ensurePeriodOpen(ctx context.Context, date time.Time) error {
+	period, err := uc.repo.GetPeriod(ctx, date.Year(), int(date.Month()))
+	if err != nil {
+		return err
+	}
+	if period != nil && period.Status == entities.PeriodClosed {
+		return domainerrors.ErrPeriodClosed
+	}
+	return nil
+}
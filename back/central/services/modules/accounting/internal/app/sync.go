@@ -31,6 +31,7 @@ func (uc *UseCase) Sync(ctx context.Context) (*dtos.SyncResult, error) {
 			uc.log.Info(ctx).Str("source_type", concept.SourceType).Int("created", created).Msg("Accounting sync: entries created")
 		}
 	}
+	uc.postLedger(ctx, result)
 	return result, nil
 }
 
@@ -72,8 +72,11 @@ type SyncCandidate struct {
 }
 
 type SyncResult struct {
-	Created  int            `json:"created"`
-	BySource map[string]int `json:"by_source"`
+	Created       int            `json:"created"`
+	BySource      map[string]int `json:"by_source"`
+	Posted        int            `json:"posted"`
+	Reversed      int            `json:"reversed"`
+	PostingFailed int            `json:"posting_failed"`
 }
 
 type ReportParams struct {
@@ -0,0 +1,193 @@
+package dtos
+
+import (
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/entities"
+)
+
+type CreateAccountDTO struct {
+	BusinessID uint
+	Code       string
+	Name       string
+	Nature     string
+}
+
+type UpdateAccountDTO struct {
+	ID       uint
+	Name     string
+	IsActive bool
+}
+
+type SavePostingRuleDTO struct {
+	BusinessID         uint
+	SourceType         string
+	Description        string
+	DebitAccount       string
+	CreditAccount      string
+	TaxAccount         string
+	WithholdingAccount string
+	OtherTaxAccount    string
+	IsActive           bool
+}
+
+type JournalLineDTO struct {
+	AccountCode string
+	BusinessID  *uint
+	Debit       float64
+	Credit      float64
+	Description string
+}
+
+type CreateJournalDTO struct {
+	BusinessID  *uint
+	EntryDate   time.Time
+	Description string
+	Lines       []JournalLineDTO
+	UserID      uint
+}
+
+type ListJournalParams struct {
+	From        *time.Time
+	To          *time.Time
+	BusinessID  *uint
+	AccountCode string
+	SourceType  string
+	Page        int
+	PageSize    int
+}
+
+func (p ListJournalParams) Offset() int {
+	return (p.Page - 1) * p.PageSize
+}
+
+// LedgerParams filtra los reportes contables. BusinessID es el tercero.
+type LedgerParams struct {
+	From        time.Time
+	To          time.Time
+	BusinessID  *uint
+	AccountCode string
+}
+
+// ReversalCandidate es un comprobante cuyo movimiento de origen se borro
+// (factura anulada, movimiento manual eliminado) y aun no se reversa.
+type ReversalCandidate struct {
+	Journal   entities.JournalEntry
+	Entry     entities.Entry
+	DeletedAt time.Time
+}
+
+// AccountMovement son los saldos por cuenta auxiliar: todo lo anterior a
+// From (apertura), lo anterior a From dentro de su mismo ano (apertura de las
+// cuentas de resultados) y lo del rango.
+type AccountMovement struct {
+	AccountCode       string
+	AccountName       string
+	OpeningDebit      float64
+	OpeningCredit     float64
+	YearOpeningDebit  float64
+	YearOpeningCredit float64
+	Debit             float64
+	Credit            float64
+}
+
+type LedgerLineRow struct {
+	JournalEntryID uint
+	EntryDate      time.Time
+	AccountCode    string
+	AccountName    string
+	BusinessID     *uint
+	BusinessName   string
+	SourceType     string
+	SourceID       string
+	Description    string
+	Debit          float64
+	Credit         float64
+}
+
+type TrialBalanceRow struct {
+	Code           string  `json:"code"`
+	Name           string  `json:"name"`
+	Level          int     `json:"level"`
+	Nature         string  `json:"nature"`
+	OpeningBalance float64 `json:"opening_balance"`
+	Debit          float64 `json:"debit"`
+	Credit         float64 `json:"credit"`
+	ClosingBalance float64 `json:"closing_balance"`
+}
+
+type TrialBalance struct {
+	From        string            `json:"from"`
+	To          string            `json:"to"`
+	Rows        []TrialBalanceRow `json:"rows"`
+	TotalDebit  float64           `json:"total_debit"`
+	TotalCredit float64           `json:"total_credit"`
+	Balanced    bool              `json:"balanced"`
+}
+
+type GeneralLedgerLine struct {
+	JournalEntryID uint    `json:"journal_entry_id"`
+	Date           string  `json:"date"`
+	Description    string  `json:"description"`
+	SourceType     string  `json:"source_type"`
+	SourceID       string  `json:"source_id"`
+	BusinessName   string  `json:"business_name"`
+	Debit          float64 `json:"debit"`
+	Credit         float64 `json:"credit"`
+	Balance        float64 `json:"balance"`
+}
+
+type GeneralLedgerAccount struct {
+	Code           string              `json:"code"`
+	Name           string              `json:"name"`
+	Nature         string              `json:"nature"`
+	OpeningBalance float64             `json:"opening_balance"`
+	Debit          float64             `json:"debit"`
+	Credit         float64             `json:"credit"`
+	ClosingBalance float64             `json:"closing_balance"`
+	Lines          []GeneralLedgerLine `json:"lines"`
+}
+
+type GeneralLedger struct {
+	From     string                 `json:"from"`
+	To       string                 `json:"to"`
+	Accounts []GeneralLedgerAccount `json:"accounts"`
+}
+
+type StatementRow struct {
+	Code   string  `json:"code"`
+	Name   string  `json:"name"`
+	Level  int     `json:"level"`
+	Amount float64 `json:"amount"`
+}
+
+type IncomeStatement struct {
+	From          string         `json:"from"`
+	To            string         `json:"to"`
+	Income        []StatementRow `json:"income"`
+	Expenses      []StatementRow `json:"expenses"`
+	Costs         []StatementRow `json:"costs"`
+	TotalIncome   float64        `json:"total_income"`
+	TotalExpenses float64        `json:"total_expenses"`
+	TotalCosts    float64        `json:"total_costs"`
+	NetIncome     float64        `json:"net_income"`
+}
+
+type BalanceSheet struct {
+	AsOf             string         `json:"as_of"`
+	Assets           []StatementRow `json:"assets"`
+	Liabilities      []StatementRow `json:"liabilities"`
+	Equity           []StatementRow `json:"equity"`
+	TotalAssets      float64        `json:"total_assets"`
+	TotalLiabilities float64        `json:"total_liabilities"`
+	TotalEquity      float64        `json:"total_equity"`
+	PriorResults     float64        `json:"prior_results"`
+	PeriodResult     float64        `json:"period_result"`
+	Balanced         bool           `json:"balanced"`
+}
+
+type ClosePeriodDTO struct {
+	Year   int
+	Month  int
+	UserID uint
+}
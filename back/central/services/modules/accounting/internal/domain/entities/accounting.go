@@ -10,11 +10,15 @@ const (
 	TaxKindWithholding = "WITHHOLDING"
 	TaxKindOther       = "OTHER"
 
-	SourceGuideMargin    = "GUIDE_MARGIN"
-	SourceSubscription   = "SUBSCRIPTION"
-	SourceWalletRecharge = "WALLET_RECHARGE"
-	SourceCodPayout      = "COD_PAYOUT"
-	SourceManual         = "MANUAL"
+	SourceGuideMargin        = "GUIDE_MARGIN"
+	SourceSubscription       = "SUBSCRIPTION"
+	SourceWalletRecharge     = "WALLET_RECHARGE"
+	SourceCodPayout          = "COD_PAYOUT"
+	SourceCOGS               = "COGS"
+	SourceCOGSReturn         = "COGS_RETURN"
+	SourceInventoryShrinkage = "INVENTORY_SHRINKAGE"
+	SourceInventorySurplus   = "INVENTORY_SURPLUS"
+	SourceManual             = "MANUAL"
 )
 
 type Concept struct {
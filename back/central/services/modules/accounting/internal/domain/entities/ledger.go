@@ -0,0 +1,86 @@
+package entities
+
+import "time"
+
+const (
+	NatureDebit  = "DEBIT"
+	NatureCredit = "CREDIT"
+
+	PeriodOpen   = "OPEN"
+	PeriodClosed = "CLOSED"
+
+	// Origenes propios de los comprobantes (los demas se heredan del movimiento)
+	SourceCreditNote = "CREDIT_NOTE"
+	SourceReversal   = "REVERSAL"
+
+	// Reglas de respaldo para los movimientos manuales sin regla propia
+	RuleManualIncome  = "MANUAL_INCOME"
+	RuleManualExpense = "MANUAL_EXPENSE"
+)
+
+// Account es una cuenta del PUC. BusinessID 0 es la plantilla global.
+type Account struct {
+	ID         uint
+	BusinessID uint
+	Code       string
+	Name       string
+	Nature     string
+	ParentCode string
+	Level      int
+	IsPostable bool
+	IsActive   bool
+}
+
+// PostingRule define las cuentas de un origen. TaxAccount recibe los
+// impuestos CHARGE, WithholdingAccount las retenciones y OtherTaxAccount los
+// impuestos OTHER (4x1000).
+type PostingRule struct {
+	ID                 uint
+	BusinessID         uint
+	SourceType         string
+	Description        string
+	DebitAccount       string
+	CreditAccount      string
+	TaxAccount         string
+	WithholdingAccount string
+	OtherTaxAccount    string
+	IsActive           bool
+}
+
+type Period struct {
+	ID       uint
+	Year     int
+	Month    int
+	Status   string
+	ClosedAt *time.Time
+	ClosedBy *uint
+}
+
+type JournalEntry struct {
+	ID                uint
+	BusinessID        *uint
+	BusinessName      string
+	EntryDate         time.Time
+	Description       string
+	SourceType        string
+	SourceID          string
+	AccountingEntryID *uint
+	ReversalOfID      *uint
+	IsAutomatic       bool
+	TotalDebit        float64
+	TotalCredit       float64
+	CreatedBy         *uint
+	Lines             []JournalLine
+	CreatedAt         time.Time
+}
+
+type JournalLine struct {
+	ID             uint
+	JournalEntryID uint
+	AccountCode    string
+	AccountName    string
+	BusinessID     *uint
+	Debit          float64
+	Credit         float64
+	Description    string
+}
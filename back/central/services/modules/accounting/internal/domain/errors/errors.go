@@ -33,4 +33,21 @@ var (
 	ErrDianDocumentRequired = errors.New("el documento de identificacion del cliente (NIT/CC) es requerido para emitir")
 	ErrServiceNotFound      = errors.New("servicio no encontrado en el catalogo")
 	ErrDianCredentials      = errors.New("credenciales de Factus incompletas: client_id, client_secret, username y password son requeridos")
+
+	ErrAccountNotFound      = errors.New("cuenta contable no encontrada")
+	ErrInvalidAccountCode   = errors.New("el codigo de cuenta debe ser numerico de 1, 2, 4, 6, 8 o 10 digitos")
+	ErrAccountParentMissing = errors.New("la cuenta padre no existe en el plan de cuentas")
+	ErrAccountNotPostable   = errors.New("la cuenta no es auxiliar o esta inactiva")
+	ErrInvalidNature        = errors.New("nature debe ser DEBIT o CREDIT")
+	ErrPostingRuleNotFound  = errors.New("no hay regla de contabilizacion para el origen")
+	ErrRuleAccountsRequired = errors.New("la regla requiere cuenta debito y cuenta credito")
+	ErrRuleMissingAccount   = errors.New("la regla de contabilizacion no tiene cuenta para los impuestos del movimiento")
+	ErrJournalUnbalanced    = errors.New("el comprobante no cuadra: debitos y creditos deben sumar lo mismo")
+	ErrJournalInvalidLine   = errors.New("cada linea requiere cuenta y un valor debito o credito mayor a cero, no ambos")
+	ErrJournalNotFound      = errors.New("comprobante contable no encontrado")
+	ErrPeriodClosed         = errors.New("el periodo contable esta cerrado")
+	ErrPeriodAlreadyClosed  = errors.New("el periodo contable ya esta cerrado")
+	ErrPeriodNotClosed      = errors.New("el periodo contable no esta cerrado")
+	ErrPeriodHasPending     = errors.New("hay movimientos del periodo sin contabilizar: sincroniza antes de cerrar")
+	ErrInvalidPeriodMonth   = errors.New("year y month (1-12) son requeridos")
 )
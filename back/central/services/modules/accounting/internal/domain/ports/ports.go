@@ -67,4 +67,24 @@ type IRepository interface {
 	CreateService(ctx context.Context, dto dtos.SaveServiceDTO) (*entities.Service, error)
 	UpdateService(ctx context.Context, dto dtos.SaveServiceDTO) (*entities.Service, error)
 	DeleteService(ctx context.Context, id uint) error
+
+	ListAccounts(ctx context.Context, businessID uint) ([]entities.Account, error)
+	GetAccountByID(ctx context.Context, id uint) (*entities.Account, error)
+	CreateAccount(ctx context.Context, a *entities.Account) error
+	UpdateAccount(ctx context.Context, a *entities.Account) error
+	ListPostingRules(ctx context.Context) ([]entities.PostingRule, error)
+	SavePostingRule(ctx context.Context, rule *entities.PostingRule) error
+
+	FindUnpostedEntries(ctx context.Context, afterID uint, limit int) ([]entities.Entry, error)
+	FindPendingReversals(ctx context.Context, afterID uint, limit int) ([]dtos.ReversalCandidate, error)
+	CountUnpostedEntries(ctx context.Context, from, to time.Time) (int64, error)
+	CreateJournalEntry(ctx context.Context, je *entities.JournalEntry) (bool, error)
+	GetJournalEntry(ctx context.Context, id uint) (*entities.JournalEntry, error)
+	ListJournalEntries(ctx context.Context, params dtos.ListJournalParams) ([]entities.JournalEntry, int64, error)
+	AccountMovements(ctx context.Context, params dtos.LedgerParams) ([]dtos.AccountMovement, error)
+	LedgerLines(ctx context.Context, params dtos.LedgerParams) ([]dtos.LedgerLineRow, error)
+
+	ListPeriods(ctx context.Context, year int) ([]entities.Period, error)
+	GetPeriod(ctx context.Context, year, month int) (*entities.Period, error)
+	SavePeriod(ctx context.Context, p *entities.Period) error
 }
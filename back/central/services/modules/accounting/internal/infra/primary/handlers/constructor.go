@@ -36,6 +36,22 @@ type IHandlers interface {
 	GetClientProfile(c *gin.Context)
 	GetDianConfig(c *gin.Context)
 	SaveDianConfig(c *gin.Context)
+	ListAccounts(c *gin.Context)
+	CreateAccount(c *gin.Context)
+	UpdateAccount(c *gin.Context)
+	ListPostingRules(c *gin.Context)
+	SavePostingRule(c *gin.Context)
+	ListJournalEntries(c *gin.Context)
+	GetJournalEntry(c *gin.Context)
+	CreateJournalEntry(c *gin.Context)
+	TrialBalance(c *gin.Context)
+	GeneralLedger(c *gin.Context)
+	IncomeStatement(c *gin.Context)
+	BalanceSheet(c *gin.Context)
+	LedgerExport(c *gin.Context)
+	ListPeriods(c *gin.Context)
+	ClosePeriod(c *gin.Context)
+	ReopenPeriod(c *gin.Context)
 	RegisterRoutes(router *gin.RouterGroup)
 }
 
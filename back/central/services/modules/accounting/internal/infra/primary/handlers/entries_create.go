@@ -39,6 +39,8 @@ func (h *Handlers) CreateEntry(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"success": false, "error": err.Error()})
 		case errors.Is(err, domainerrors.ErrInvalidAmount), errors.Is(err, domainerrors.ErrConceptInactive):
 			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+		case errors.Is(err, domainerrors.ErrPeriodClosed):
+			c.JSON(http.StatusConflict, gin.H{"success": false, "error": err.Error()})
 		default:
 			c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": err.Error()})
 		}
@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/infra/primary/handlers/response"
+)
+
+// ListAccounts devuelve el plan de cuentas; con business_id, el del negocio
+// sobre la plantilla PUC.
+func (h *Handlers) ListAccounts(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	businessID, _ := strconv.ParseUint(c.DefaultQuery("business_id", "0"), 10, 64)
+	accounts, err := h.uc.ListAccounts(c.Request.Context(), uint(businessID))
+	if err != nil {
+		h.ledgerError(c, err)
+		return
+	}
+	data := make([]response.AccountResponse, len(accounts))
+	for i := range accounts {
+		data[i] = response.FromAccount(&accounts[i])
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": data})
+}
+
+func (h *Handlers) CreateAccount(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	var req request.CreateAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+		return
+	}
+	account, err := h.uc.CreateAccount(c.Request.Context(), dtos.CreateAccountDTO{
+		BusinessID: req.BusinessID,
+		Code:       req.Code,
+		Name:       req.Name,
+		Nature:     req.Nature,
+	})
+	if err != nil {
+		h.ledgerError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": response.FromAccount(account)})
+}
+
+func (h *Handlers) UpdateAccount(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "id invalido"})
+		return
+	}
+	var req request.UpdateAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+		return
+	}
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+	account, err := h.uc.UpdateAccount(c.Request.Context(), dtos.UpdateAccountDTO{
+		ID:       uint(id),
+		Name:     req.Name,
+		IsActive: isActive,
+	})
+	if err != nil {
+		h.ledgerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": response.FromAccount(account)})
+}
+
+func (h *Handlers) ListPostingRules(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	rules, err := h.uc.ListPostingRules(c.Request.Context())
+	if err != nil {
+		h.ledgerError(c, err)
+		return
+	}
+	data := make([]response.PostingRuleResponse, len(rules))
+	for i := range rules {
+		data[i] = response.FromPostingRule(&rules[i])
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": data})
+}
+
+func (h *Handlers) SavePostingRule(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	var req request.SavePostingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+		return
+	}
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+	rule, err := h.uc.SavePostingRule(c.Request.Context(), dtos.SavePostingRuleDTO{
+		BusinessID:         req.BusinessID,
+		SourceType:         req.SourceType,
+		Description:        req.Description,
+		DebitAccount:       req.DebitAccount,
+		CreditAccount:      req.CreditAccount,
+		TaxAccount:         req.TaxAccount,
+		WithholdingAccount: req.WithholdingAccount,
+		OtherTaxAccount:    req.OtherTaxAccount,
+		IsActive:           isActive,
+	})
+	if err != nil {
+		h.ledgerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": response.FromPostingRule(rule)})
+}
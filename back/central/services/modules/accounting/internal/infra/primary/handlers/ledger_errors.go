@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/errors"
+)
+
+func (h *Handlers) ledgerError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domainerrors.ErrAccountNotFound),
+		errors.Is(err, domainerrors.ErrJournalNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "error": err.Error()})
+	case errors.Is(err, domainerrors.ErrDuplicateCode),
+		errors.Is(err, domainerrors.ErrPeriodClosed),
+		errors.Is(err, domainerrors.ErrPeriodAlreadyClosed),
+		errors.Is(err, domainerrors.ErrPeriodNotClosed),
+		errors.Is(err, domainerrors.ErrPeriodHasPending):
+		c.JSON(http.StatusConflict, gin.H{"success": false, "error": err.Error()})
+	case errors.Is(err, domainerrors.ErrInvalidAccountCode),
+		errors.Is(err, domainerrors.ErrAccountParentMissing),
+		errors.Is(err, domainerrors.ErrAccountNotPostable),
+		errors.Is(err, domainerrors.ErrInvalidNature),
+		errors.Is(err, domainerrors.ErrNameRequired),
+		errors.Is(err, domainerrors.ErrCodeRequired),
+		errors.Is(err, domainerrors.ErrRuleAccountsRequired),
+		errors.Is(err, domainerrors.ErrJournalUnbalanced),
+		errors.Is(err, domainerrors.ErrJournalInvalidLine),
+		errors.Is(err, domainerrors.ErrInvalidPeriod),
+		errors.Is(err, domainerrors.ErrInvalidPeriodMonth):
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": err.Error()})
+	}
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/dtos"
+	"github.com/xuri/excelize/v2"
+)
+
+var ledgerExportHeaders = []string{
+	"fecha", "comprobante", "origen", "documento", "cuenta", "nombre_cuenta",
+	"tercero_id", "tercero", "debito", "credito", "descripcion",
+}
+
+// LedgerExport descarga el libro diario del rango en CSV o XLSX para el
+// contador.
+func (h *Handlers) LedgerExport(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	params, ok := ledgerParams(c)
+	if !ok {
+		return
+	}
+	format := c.DefaultQuery("format", "xlsx")
+	if format != "xlsx" && format != "csv" {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "format debe ser csv o xlsx"})
+		return
+	}
+
+	rows, err := h.uc.LedgerExport(c.Request.Context(), params)
+	if err != nil {
+		h.ledgerError(c, err)
+		return
+	}
+
+	filename := "libro-diario-" + params.From.Format("2006-01-02") + "-" + params.To.Format("2006-01-02") + "." + format
+
+	if format == "csv" {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		_ = w.Write(ledgerExportHeaders)
+		for _, r := range rows {
+			_ = w.Write(ledgerExportRecord(r))
+		}
+		w.Flush()
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+		return
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheet := "Libro diario"
+	f.SetSheetName("Sheet1", sheet)
+	for col, head := range ledgerExportHeaders {
+		cell, _ := excelize.CoordinatesToCellName(col+1, 1)
+		f.SetCellValue(sheet, cell, head)
+	}
+	for i, r := range rows {
+		values := []interface{}{
+			r.EntryDate.Format("2006-01-02"), r.JournalEntryID, r.SourceType, r.SourceID,
+			r.AccountCode, r.AccountName, nil, r.BusinessName, r.Debit, r.Credit, r.Description,
+		}
+		if r.BusinessID != nil {
+			values[6] = *r.BusinessID
+		}
+		for col, v := range values {
+			if v == nil {
+				continue
+			}
+			cell, _ := excelize.CoordinatesToCellName(col+1, i+2)
+			f.SetCellValue(sheet, cell, v)
+		}
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": err.Error()})
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", buf.Bytes())
+}
+
+func ledgerExportRecord(r dtos.LedgerLineRow) []string {
+	money := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	}
+	businessID := ""
+	if r.BusinessID != nil {
+		businessID = strconv.FormatUint(uint64(*r.BusinessID), 10)
+	}
+	return []string{
+		r.EntryDate.Format("2006-01-02"), strconv.FormatUint(uint64(r.JournalEntryID), 10), r.SourceType, r.SourceID,
+		r.AccountCode, r.AccountName, businessID, r.BusinessName, money(r.Debit), money(r.Credit), r.Description,
+	}
+}
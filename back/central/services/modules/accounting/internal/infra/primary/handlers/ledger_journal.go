@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/infra/primary/handlers/response"
+)
+
+func (h *Handlers) ListJournalEntries(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	params := dtos.ListJournalParams{
+		Page:        page,
+		PageSize:    pageSize,
+		AccountCode: c.Query("account_code"),
+		SourceType:  c.Query("source_type"),
+	}
+	if v := c.Query("from"); v != "" {
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			params.From = &t
+		}
+	}
+	if v := c.Query("to"); v != "" {
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			params.To = &t
+		}
+	}
+	params.BusinessID = queryBusinessID(c)
+
+	items, total, err := h.uc.ListJournalEntries(c.Request.Context(), params)
+	if err != nil {
+		h.ledgerError(c, err)
+		return
+	}
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 {
+		params.PageSize = 10
+	}
+	if params.PageSize > 100 {
+		params.PageSize = 100
+	}
+	data := make([]response.JournalEntryResponse, len(items))
+	for i := range items {
+		data[i] = response.FromJournalEntry(&items[i])
+	}
+	totalPages := int(total) / params.PageSize
+	if int(total)%params.PageSize != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, response.JournalListResponse{
+		Success:    true,
+		Data:       data,
+		Total:      total,
+		Page:       params.Page,
+		PageSize:   params.PageSize,
+		TotalPages: totalPages,
+	})
+}
+
+func (h *Handlers) GetJournalEntry(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "id invalido"})
+		return
+	}
+	je, err := h.uc.GetJournalEntry(c.Request.Context(), uint(id))
+	if err != nil {
+		h.ledgerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": response.FromJournalEntry(je)})
+}
+
+func (h *Handlers) CreateJournalEntry(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	var req request.CreateJournalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+		return
+	}
+	entryDate, err := time.Parse("2006-01-02", req.EntryDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "entry_date debe tener formato YYYY-MM-DD"})
+		return
+	}
+	lines := make([]dtos.JournalLineDTO, len(req.Lines))
+	for i, l := range req.Lines {
+		lines[i] = dtos.JournalLineDTO{
+			AccountCode: l.AccountCode,
+			BusinessID:  l.BusinessID,
+			Debit:       l.Debit,
+			Credit:      l.Credit,
+			Description: l.Description,
+		}
+	}
+	userID, _ := middleware.GetUserID(c)
+	je, err := h.uc.CreateJournalEntry(c.Request.Context(), dtos.CreateJournalDTO{
+		BusinessID:  req.BusinessID,
+		EntryDate:   entryDate,
+		Description: req.Description,
+		Lines:       lines,
+		UserID:      userID,
+	})
+	if err != nil {
+		h.ledgerError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": response.FromJournalEntry(je)})
+}
+
+func queryBusinessID(c *gin.Context) *uint {
+	v := c.Query("business_id")
+	if v == "" {
+		return nil
+	}
+	id, err := strconv.ParseUint(v, 10, 64)
+	if err != nil || id == 0 {
+		return nil
+	}
+	businessID := uint(id)
+	return &businessID
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/infra/primary/handlers/response"
+)
+
+func (h *Handlers) ListPeriods(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	year, err := strconv.Atoi(c.DefaultQuery("year", strconv.Itoa(time.Now().Year())))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "year invalido"})
+		return
+	}
+	periods, err := h.uc.ListPeriods(c.Request.Context(), year)
+	if err != nil {
+		h.ledgerError(c, err)
+		return
+	}
+	data := make([]response.PeriodResponse, len(periods))
+	for i := range periods {
+		data[i] = response.FromPeriod(&periods[i])
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": data})
+}
+
+func (h *Handlers) ClosePeriod(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	var req request.PeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+		return
+	}
+	userID, _ := middleware.GetUserID(c)
+	period, err := h.uc.ClosePeriod(c.Request.Context(), dtos.ClosePeriodDTO{
+		Year:   req.Year,
+		Month:  req.Month,
+		UserID: userID,
+	})
+	if err != nil {
+		h.ledgerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": response.FromPeriod(period)})
+}
+
+func (h *Handlers) ReopenPeriod(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	var req request.PeriodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+		return
+	}
+	period, err := h.uc.ReopenPeriod(c.Request.Context(), req.Year, req.Month)
+	if err != nil {
+		h.ledgerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": response.FromPeriod(period)})
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/dtos"
+)
+
+func ledgerParams(c *gin.Context) (dtos.LedgerParams, bool) {
+	from, err := time.Parse("2006-01-02", c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "from es requerido (YYYY-MM-DD)"})
+		return dtos.LedgerParams{}, false
+	}
+	to, err := time.Parse("2006-01-02", c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "to es requerido (YYYY-MM-DD)"})
+		return dtos.LedgerParams{}, false
+	}
+	return dtos.LedgerParams{
+		From:        from,
+		To:          to,
+		BusinessID:  queryBusinessID(c),
+		AccountCode: c.Query("account_code"),
+	}, true
+}
+
+func (h *Handlers) TrialBalance(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	params, ok := ledgerParams(c)
+	if !ok {
+		return
+	}
+	report, err := h.uc.TrialBalance(c.Request.Context(), params)
+	if err != nil {
+		h.ledgerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": report})
+}
+
+func (h *Handlers) GeneralLedger(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	params, ok := ledgerParams(c)
+	if !ok {
+		return
+	}
+	report, err := h.uc.GeneralLedger(c.Request.Context(), params)
+	if err != nil {
+		h.ledgerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": report})
+}
+
+func (h *Handlers) IncomeStatement(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	params, ok := ledgerParams(c)
+	if !ok {
+		return
+	}
+	report, err := h.uc.IncomeStatement(c.Request.Context(), params)
+	if err != nil {
+		h.ledgerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": report})
+}
+
+// BalanceSheet recibe solo as_of: el balance es un corte a una fecha.
+func (h *Handlers) BalanceSheet(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	asOf, err := time.Parse("2006-01-02", c.Query("as_of"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "as_of es requerido (YYYY-MM-DD)"})
+		return
+	}
+	report, err := h.uc.BalanceSheet(c.Request.Context(), dtos.LedgerParams{
+		To:         asOf,
+		BusinessID: queryBusinessID(c),
+	})
+	if err != nil {
+		h.ledgerError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": report})
+}
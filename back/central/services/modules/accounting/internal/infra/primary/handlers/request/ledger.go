@@ -0,0 +1,45 @@
+package request
+
+type CreateAccountRequest struct {
+	BusinessID uint   `json:"business_id"`
+	Code       string `json:"code" binding:"required"`
+	Name       string `json:"name" binding:"required"`
+	Nature     string `json:"nature"`
+}
+
+type UpdateAccountRequest struct {
+	Name     string `json:"name" binding:"required"`
+	IsActive *bool  `json:"is_active"`
+}
+
+type SavePostingRuleRequest struct {
+	BusinessID         uint   `json:"business_id"`
+	SourceType         string `json:"source_type" binding:"required"`
+	Description        string `json:"description"`
+	DebitAccount       string `json:"debit_account" binding:"required"`
+	CreditAccount      string `json:"credit_account" binding:"required"`
+	TaxAccount         string `json:"tax_account"`
+	WithholdingAccount string `json:"withholding_account"`
+	OtherTaxAccount    string `json:"other_tax_account"`
+	IsActive           *bool  `json:"is_active"`
+}
+
+type JournalLineRequest struct {
+	AccountCode string  `json:"account_code" binding:"required"`
+	BusinessID  *uint   `json:"related_business_id"`
+	Debit       float64 `json:"debit"`
+	Credit      float64 `json:"credit"`
+	Description string  `json:"description"`
+}
+
+type CreateJournalRequest struct {
+	BusinessID  *uint                `json:"related_business_id"`
+	EntryDate   string               `json:"entry_date" binding:"required"`
+	Description string               `json:"description"`
+	Lines       []JournalLineRequest `json:"lines" binding:"required,min=2,dive"`
+}
+
+type PeriodRequest struct {
+	Year  int `json:"year" binding:"required"`
+	Month int `json:"month" binding:"required"`
+}
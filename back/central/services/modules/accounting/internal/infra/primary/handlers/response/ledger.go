@@ -0,0 +1,144 @@
+package response
+
+import (
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/entities"
+)
+
+type AccountResponse struct {
+	ID         uint   `json:"id"`
+	BusinessID uint   `json:"business_id"`
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	Nature     string `json:"nature"`
+	ParentCode string `json:"parent_code"`
+	Level      int    `json:"level"`
+	IsPostable bool   `json:"is_postable"`
+	IsActive   bool   `json:"is_active"`
+}
+
+func FromAccount(e *entities.Account) AccountResponse {
+	return AccountResponse{
+		ID:         e.ID,
+		BusinessID: e.BusinessID,
+		Code:       e.Code,
+		Name:       e.Name,
+		Nature:     e.Nature,
+		ParentCode: e.ParentCode,
+		Level:      e.Level,
+		IsPostable: e.IsPostable,
+		IsActive:   e.IsActive,
+	}
+}
+
+type PostingRuleResponse struct {
+	ID                 uint   `json:"id"`
+	BusinessID         uint   `json:"business_id"`
+	SourceType         string `json:"source_type"`
+	Description        string `json:"description"`
+	DebitAccount       string `json:"debit_account"`
+	CreditAccount      string `json:"credit_account"`
+	TaxAccount         string `json:"tax_account"`
+	WithholdingAccount string `json:"withholding_account"`
+	OtherTaxAccount    string `json:"other_tax_account"`
+	IsActive           bool   `json:"is_active"`
+}
+
+func FromPostingRule(e *entities.PostingRule) PostingRuleResponse {
+	return PostingRuleResponse{
+		ID:                 e.ID,
+		BusinessID:         e.BusinessID,
+		SourceType:         e.SourceType,
+		Description:        e.Description,
+		DebitAccount:       e.DebitAccount,
+		CreditAccount:      e.CreditAccount,
+		TaxAccount:         e.TaxAccount,
+		WithholdingAccount: e.WithholdingAccount,
+		OtherTaxAccount:    e.OtherTaxAccount,
+		IsActive:           e.IsActive,
+	}
+}
+
+type JournalLineResponse struct {
+	ID          uint    `json:"id"`
+	AccountCode string  `json:"account_code"`
+	AccountName string  `json:"account_name"`
+	BusinessID  *uint   `json:"related_business_id"`
+	Debit       float64 `json:"debit"`
+	Credit      float64 `json:"credit"`
+	Description string  `json:"description"`
+}
+
+type JournalEntryResponse struct {
+	ID                uint                  `json:"id"`
+	BusinessID        *uint                 `json:"related_business_id"`
+	BusinessName      string                `json:"business_name"`
+	EntryDate         string                `json:"entry_date"`
+	Description       string                `json:"description"`
+	SourceType        string                `json:"source_type"`
+	SourceID          string                `json:"source_id"`
+	AccountingEntryID *uint                 `json:"accounting_entry_id"`
+	ReversalOfID      *uint                 `json:"reversal_of_id"`
+	IsAutomatic       bool                  `json:"is_automatic"`
+	TotalDebit        float64               `json:"total_debit"`
+	TotalCredit       float64               `json:"total_credit"`
+	Lines             []JournalLineResponse `json:"lines"`
+}
+
+func FromJournalEntry(e *entities.JournalEntry) JournalEntryResponse {
+	lines := make([]JournalLineResponse, len(e.Lines))
+	for i, l := range e.Lines {
+		lines[i] = JournalLineResponse{
+			ID:          l.ID,
+			AccountCode: l.AccountCode,
+			AccountName: l.AccountName,
+			BusinessID:  l.BusinessID,
+			Debit:       l.Debit,
+			Credit:      l.Credit,
+			Description: l.Description,
+		}
+	}
+	return JournalEntryResponse{
+		ID:                e.ID,
+		BusinessID:        e.BusinessID,
+		BusinessName:      e.BusinessName,
+		EntryDate:         e.EntryDate.Format("2006-01-02"),
+		Description:       e.Description,
+		SourceType:        e.SourceType,
+		SourceID:          e.SourceID,
+		AccountingEntryID: e.AccountingEntryID,
+		ReversalOfID:      e.ReversalOfID,
+		IsAutomatic:       e.IsAutomatic,
+		TotalDebit:        e.TotalDebit,
+		TotalCredit:       e.TotalCredit,
+		Lines:             lines,
+	}
+}
+
+type JournalListResponse struct {
+	Success    bool                   `json:"success"`
+	Data       []JournalEntryResponse `json:"data"`
+	Total      int64                  `json:"total"`
+	Page       int                    `json:"page"`
+	PageSize   int                    `json:"page_size"`
+	TotalPages int                    `json:"total_pages"`
+}
+
+type PeriodResponse struct {
+	Year     int        `json:"year"`
+	Month    int        `json:"month"`
+	Status   string     `json:"status"`
+	ClosedAt *time.Time `json:"closed_at"`
+	ClosedBy *uint      `json:"closed_by"`
+}
+
+func FromPeriod(e *entities.Period) PeriodResponse {
+	return PeriodResponse{
+		Year:     e.Year,
+		Month:    e.Month,
+		Status:   e.Status,
+		ClosedAt: e.ClosedAt,
+		ClosedBy: e.ClosedBy,
+	}
+}
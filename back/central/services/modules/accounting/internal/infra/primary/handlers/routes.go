@@ -37,5 +37,21 @@ func (h *Handlers) RegisterRoutes(router *gin.RouterGroup) {
 		g.GET("/client-profile", h.GetClientProfile)
 		g.GET("/dian-config", h.GetDianConfig)
 		g.PUT("/dian-config", h.SaveDianConfig)
+		g.GET("/accounts", h.ListAccounts)
+		g.POST("/accounts", h.CreateAccount)
+		g.PUT("/accounts/:id", h.UpdateAccount)
+		g.GET("/posting-rules", h.ListPostingRules)
+		g.PUT("/posting-rules", h.SavePostingRule)
+		g.GET("/journal", h.ListJournalEntries)
+		g.POST("/journal", h.CreateJournalEntry)
+		g.GET("/journal/:id", h.GetJournalEntry)
+		g.GET("/ledger/trial-balance", h.TrialBalance)
+		g.GET("/ledger/general", h.GeneralLedger)
+		g.GET("/ledger/income-statement", h.IncomeStatement)
+		g.GET("/ledger/balance-sheet", h.BalanceSheet)
+		g.GET("/ledger/export", h.LedgerExport)
+		g.GET("/periods", h.ListPeriods)
+		g.POST("/periods/close", h.ClosePeriod)
+		g.POST("/periods/reopen", h.ReopenPeriod)
 	}
 }
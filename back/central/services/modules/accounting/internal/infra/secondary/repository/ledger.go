@@ -0,0 +1,519 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/accounting/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/accounting/internal/infra/secondary/repository/mappers"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func accountToEntity(m *models.AccountingAccount) entities.Account {
+	return entities.Account{
+		ID:         m.ID,
+		BusinessID: m.BusinessID,
+		Code:       m.Code,
+		Name:       m.Name,
+		Nature:     m.Nature,
+		ParentCode: m.ParentCode,
+		Level:      m.Level,
+		IsPostable: m.IsPostable,
+		IsActive:   m.IsActive,
+	}
+}
+
+func ruleToEntity(m *models.AccountingPostingRule) entities.PostingRule {
+	return entities.PostingRule{
+		ID:                 m.ID,
+		BusinessID:         m.BusinessID,
+		SourceType:         m.SourceType,
+		Description:        m.Description,
+		DebitAccount:       m.DebitAccount,
+		CreditAccount:      m.CreditAccount,
+		TaxAccount:         m.TaxAccount,
+		WithholdingAccount: m.WithholdingAccount,
+		OtherTaxAccount:    m.OtherTaxAccount,
+		IsActive:           m.IsActive,
+	}
+}
+
+func periodToEntity(m *models.AccountingPeriod) entities.Period {
+	return entities.Period{
+		ID:       m.ID,
+		Year:     m.Year,
+		Month:    m.Month,
+		Status:   m.Status,
+		ClosedAt: m.ClosedAt,
+		ClosedBy: m.ClosedBy,
+	}
+}
+
+func journalToEntity(m *models.AccountingJournalEntry, businessName string) entities.JournalEntry {
+	je := entities.JournalEntry{
+		ID:                m.ID,
+		BusinessID:        m.BusinessID,
+		BusinessName:      businessName,
+		EntryDate:         m.EntryDate,
+		Description:       m.Description,
+		SourceType:        m.SourceType,
+		SourceID:          m.SourceID,
+		AccountingEntryID: m.AccountingEntryID,
+		ReversalOfID:      m.ReversalOfID,
+		IsAutomatic:       m.IsAutomatic,
+		TotalDebit:        m.TotalDebit,
+		TotalCredit:       m.TotalCredit,
+		CreatedBy:         m.CreatedBy,
+		CreatedAt:         m.CreatedAt,
+		Lines:             make([]entities.JournalLine, len(m.Lines)),
+	}
+	for i, l := range m.Lines {
+		je.Lines[i] = entities.JournalLine{
+			ID:             l.ID,
+			JournalEntryID: l.JournalEntryID,
+			AccountCode:    l.AccountCode,
+			AccountName:    l.AccountName,
+			BusinessID:     l.BusinessID,
+			Debit:          l.Debit,
+			Credit:         l.Credit,
+			Description:    l.Description,
+		}
+	}
+	return je
+}
+
+func (r *Repository) ListAccounts(ctx context.Context, businessID uint) ([]entities.Account, error) {
+	var rows []models.AccountingAccount
+	err := r.db.Conn(ctx).Where("business_id IN ?", []uint{0, businessID}).
+		Order("code ASC, business_id ASC").Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]entities.Account, len(rows))
+	for i := range rows {
+		out[i] = accountToEntity(&rows[i])
+	}
+	return out, nil
+}
+
+func (r *Repository) GetAccountByID(ctx context.Context, id uint) (*entities.Account, error) {
+	var model models.AccountingAccount
+	if err := r.db.Conn(ctx).First(&model, id).Error; err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrAccountNotFound
+		}
+		return nil, err
+	}
+	a := accountToEntity(&model)
+	return &a, nil
+}
+
+func (r *Repository) CreateAccount(ctx context.Context, a *entities.Account) error {
+	model := &models.AccountingAccount{
+		BusinessID: a.BusinessID,
+		Code:       a.Code,
+		Name:       a.Name,
+		Nature:     a.Nature,
+		ParentCode: a.ParentCode,
+		Level:      a.Level,
+		IsPostable: a.IsPostable,
+		IsActive:   a.IsActive,
+	}
+	if err := r.db.Conn(ctx).Create(model).Error; err != nil {
+		if isUniqueViolation(err) {
+			return domainerrors.ErrDuplicateCode
+		}
+		return err
+	}
+	a.ID = model.ID
+	return nil
+}
+
+func (r *Repository) UpdateAccount(ctx context.Context, a *entities.Account) error {
+	return r.db.Conn(ctx).Model(&models.AccountingAccount{}).Where("id = ?", a.ID).
+		Updates(map[string]interface{}{"name": a.Name, "is_active": a.IsActive}).Error
+}
+
+func (r *Repository) ListPostingRules(ctx context.Context) ([]entities.PostingRule, error) {
+	var rows []models.AccountingPostingRule
+	if err := r.db.Conn(ctx).Order("source_type ASC, business_id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]entities.PostingRule, len(rows))
+	for i := range rows {
+		out[i] = ruleToEntity(&rows[i])
+	}
+	return out, nil
+}
+
+func (r *Repository) SavePostingRule(ctx context.Context, rule *entities.PostingRule) error {
+	model := &models.AccountingPostingRule{
+		BusinessID:         rule.BusinessID,
+		SourceType:         rule.SourceType,
+		Description:        rule.Description,
+		DebitAccount:       rule.DebitAccount,
+		CreditAccount:      rule.CreditAccount,
+		TaxAccount:         rule.TaxAccount,
+		WithholdingAccount: rule.WithholdingAccount,
+		OtherTaxAccount:    rule.OtherTaxAccount,
+		IsActive:           rule.IsActive,
+	}
+	err := r.db.Conn(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "business_id"}, {Name: "source_type"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"description", "debit_account", "credit_account", "tax_account",
+			"withholding_account", "other_tax_account", "is_active", "updated_at",
+		}),
+	}).Create(model).Error
+	if err != nil {
+		return err
+	}
+	rule.ID = model.ID
+	return nil
+}
+
+const unpostedEntryCondition = `NOT EXISTS (
+    SELECT 1 FROM accounting_journal_entries je
+    WHERE je.accounting_entry_id = accounting_entries.id AND je.reversal_of_id IS NULL AND je.deleted_at IS NULL
+)`
+
+func (r *Repository) FindUnpostedEntries(ctx context.Context, afterID uint, limit int) ([]entities.Entry, error) {
+	var rows []entryRow
+	err := r.db.Conn(ctx).Model(&models.AccountingEntry{}).
+		Select("accounting_entries.*, c.code AS concept_code, c.name AS concept_name, '' AS business_name").
+		Joins("JOIN accounting_concepts c ON c.id = accounting_entries.concept_id").
+		Where("accounting_entries.deleted_at IS NULL AND accounting_entries.id > ?", afterID).
+		Where(unpostedEntryCondition).
+		Order("accounting_entries.id ASC").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]entities.Entry, len(rows))
+	for i := range rows {
+		out[i] = *mappers.EntryToEntity(&rows[i].AccountingEntry, rows[i].ConceptCode, rows[i].ConceptName, rows[i].BusinessName)
+	}
+	return out, nil
+}
+
+func (r *Repository) CountUnpostedEntries(ctx context.Context, from, to time.Time) (int64, error) {
+	var count int64
+	err := r.db.Conn(ctx).Model(&models.AccountingEntry{}).
+		Where("accounting_entries.deleted_at IS NULL").
+		Where("accounting_entries.entry_date >= ? AND accounting_entries.entry_date <= ?", from.Format("2006-01-02"), to.Format("2006-01-02")).
+		Where(unpostedEntryCondition).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *Repository) FindPendingReversals(ctx context.Context, afterID uint, limit int) ([]dtos.ReversalCandidate, error) {
+	var pending []struct {
+		JournalID uint
+		EntryID   uint
+		DeletedAt time.Time
+	}
+	err := r.db.Conn(ctx).Raw(`
+SELECT je.id AS journal_id, e.id AS entry_id, e.deleted_at
+FROM accounting_journal_entries je
+JOIN accounting_entries e ON e.id = je.accounting_entry_id
+WHERE je.deleted_at IS NULL AND je.reversal_of_id IS NULL AND je.id > ?
+  AND e.deleted_at IS NOT NULL
+  AND NOT EXISTS (
+      SELECT 1 FROM accounting_journal_entries rv
+      WHERE rv.reversal_of_id = je.id AND rv.deleted_at IS NULL
+  )
+ORDER BY je.id ASC
+LIMIT ?
+`, afterID, limit).Scan(&pending).Error
+	if err != nil || len(pending) == 0 {
+		return nil, err
+	}
+
+	journalIDs := make([]uint, len(pending))
+	entryIDs := make([]uint, len(pending))
+	for i, p := range pending {
+		journalIDs[i] = p.JournalID
+		entryIDs[i] = p.EntryID
+	}
+	var journals []models.AccountingJournalEntry
+	if err := r.db.Conn(ctx).Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Where("id IN ?", journalIDs).Find(&journals).Error; err != nil {
+		return nil, err
+	}
+	var entryModels []models.AccountingEntry
+	if err := r.db.Conn(ctx).Unscoped().Where("id IN ?", entryIDs).Find(&entryModels).Error; err != nil {
+		return nil, err
+	}
+	journalByID := make(map[uint]*models.AccountingJournalEntry, len(journals))
+	for i := range journals {
+		journalByID[journals[i].ID] = &journals[i]
+	}
+	entryByID := make(map[uint]*models.AccountingEntry, len(entryModels))
+	for i := range entryModels {
+		entryByID[entryModels[i].ID] = &entryModels[i]
+	}
+
+	out := make([]dtos.ReversalCandidate, 0, len(pending))
+	for _, p := range pending {
+		j, okJ := journalByID[p.JournalID]
+		e, okE := entryByID[p.EntryID]
+		if !okJ || !okE {
+			continue
+		}
+		out = append(out, dtos.ReversalCandidate{
+			Journal:   journalToEntity(j, ""),
+			Entry:     *mappers.EntryToEntity(e, "", "", ""),
+			DeletedAt: p.DeletedAt,
+		})
+	}
+	return out, nil
+}
+
+func (r *Repository) CreateJournalEntry(ctx context.Context, je *entities.JournalEntry) (bool, error) {
+	model := &models.AccountingJournalEntry{
+		BusinessID:        je.BusinessID,
+		EntryDate:         je.EntryDate,
+		Description:       je.Description,
+		SourceType:        je.SourceType,
+		SourceID:          je.SourceID,
+		AccountingEntryID: je.AccountingEntryID,
+		ReversalOfID:      je.ReversalOfID,
+		IsAutomatic:       je.IsAutomatic,
+		TotalDebit:        je.TotalDebit,
+		TotalCredit:       je.TotalCredit,
+		CreatedBy:         je.CreatedBy,
+		Lines:             make([]models.AccountingJournalLine, len(je.Lines)),
+	}
+	for i, l := range je.Lines {
+		model.Lines[i] = models.AccountingJournalLine{
+			AccountCode: l.AccountCode,
+			AccountName: l.AccountName,
+			BusinessID:  l.BusinessID,
+			Debit:       l.Debit,
+			Credit:      l.Credit,
+			Description: l.Description,
+		}
+	}
+	if err := r.db.Conn(ctx).Create(model).Error; err != nil {
+		if isUniqueViolation(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	je.ID = model.ID
+	return true, nil
+}
+
+func (r *Repository) GetJournalEntry(ctx context.Context, id uint) (*entities.JournalEntry, error) {
+	var model models.AccountingJournalEntry
+	err := r.db.Conn(ctx).Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		First(&model, id).Error
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrJournalNotFound
+		}
+		return nil, err
+	}
+	names, err := r.businessNames(ctx, []models.AccountingJournalEntry{model})
+	if err != nil {
+		return nil, err
+	}
+	je := journalToEntity(&model, businessNameOf(names, model.BusinessID))
+	return &je, nil
+}
+
+func (r *Repository) journalQuery(ctx context.Context, params dtos.ListJournalParams) *gorm.DB {
+	q := r.db.Conn(ctx).Model(&models.AccountingJournalEntry{}).
+		Where("accounting_journal_entries.deleted_at IS NULL")
+	if params.From != nil {
+		q = q.Where("accounting_journal_entries.entry_date >= ?", params.From.Format("2006-01-02"))
+	}
+	if params.To != nil {
+		q = q.Where("accounting_journal_entries.entry_date <= ?", params.To.Format("2006-01-02"))
+	}
+	if params.BusinessID != nil {
+		q = q.Where("accounting_journal_entries.business_id = ?", *params.BusinessID)
+	}
+	if params.SourceType != "" {
+		q = q.Where("accounting_journal_entries.source_type = ?", params.SourceType)
+	}
+	if params.AccountCode != "" {
+		q = q.Where(`EXISTS (
+    SELECT 1 FROM accounting_journal_lines l
+    WHERE l.journal_entry_id = accounting_journal_entries.id AND l.deleted_at IS NULL AND l.account_code LIKE ?
+)`, params.AccountCode+"%")
+	}
+	return q
+}
+
+func (r *Repository) ListJournalEntries(ctx context.Context, params dtos.ListJournalParams) ([]entities.JournalEntry, int64, error) {
+	var total int64
+	if err := r.journalQuery(ctx, params).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []models.AccountingJournalEntry
+	err := r.journalQuery(ctx, params).
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Order("accounting_journal_entries.entry_date DESC, accounting_journal_entries.id DESC").
+		Offset(params.Offset()).Limit(params.PageSize).
+		Find(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	names, err := r.businessNames(ctx, rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	out := make([]entities.JournalEntry, len(rows))
+	for i := range rows {
+		out[i] = journalToEntity(&rows[i], businessNameOf(names, rows[i].BusinessID))
+	}
+	return out, total, nil
+}
+
+func (r *Repository) businessNames(ctx context.Context, rows []models.AccountingJournalEntry) (map[uint]string, error) {
+	ids := make([]uint, 0, len(rows))
+	for _, row := range rows {
+		if row.BusinessID != nil {
+			ids = append(ids, *row.BusinessID)
+		}
+	}
+	names := map[uint]string{}
+	if len(ids) == 0 {
+		return names, nil
+	}
+	var found []struct {
+		ID   uint
+		Name string
+	}
+	if err := r.db.Conn(ctx).Table("business").Select("id, name").Where("id IN ?", ids).Scan(&found).Error; err != nil {
+		return nil, err
+	}
+	for _, f := range found {
+		names[f.ID] = f.Name
+	}
+	return names, nil
+}
+
+func businessNameOf(names map[uint]string, id *uint) string {
+	if id == nil {
+		return ""
+	}
+	return names[*id]
+}
+
+// ledgerFilters arma el WHERE comun de los reportes sobre lineas y
+// comprobantes vivos.
+func ledgerFilters(params dtos.LedgerParams, args map[string]interface{}) string {
+	where := `l.deleted_at IS NULL AND je.deleted_at IS NULL AND l.account_code <> '' AND je.entry_date <= @to`
+	args["to"] = params.To.Format("2006-01-02")
+	if params.BusinessID != nil {
+		where += ` AND l.business_id = @business_id`
+		args["business_id"] = *params.BusinessID
+	}
+	if params.AccountCode != "" {
+		where += ` AND l.account_code LIKE @account_code`
+		args["account_code"] = params.AccountCode + "%"
+	}
+	return where
+}
+
+func (r *Repository) AccountMovements(ctx context.Context, params dtos.LedgerParams) ([]dtos.AccountMovement, error) {
+	args := map[string]interface{}{
+		"from":       params.From.Format("2006-01-02"),
+		"year_start": time.Date(params.From.Year(), 1, 1, 0, 0, 0, 0, time.UTC).Format("2006-01-02"),
+	}
+	where := ledgerFilters(params, args)
+	rows := []dtos.AccountMovement{}
+	err := r.db.Conn(ctx).Raw(`
+SELECT l.account_code,
+       MAX(l.account_name) AS account_name,
+       COALESCE(SUM(CASE WHEN je.entry_date < @from THEN l.debit END), 0) AS opening_debit,
+       COALESCE(SUM(CASE WHEN je.entry_date < @from THEN l.credit END), 0) AS opening_credit,
+       COALESCE(SUM(CASE WHEN je.entry_date < @from AND je.entry_date >= @year_start THEN l.debit END), 0) AS year_opening_debit,
+       COALESCE(SUM(CASE WHEN je.entry_date < @from AND je.entry_date >= @year_start THEN l.credit END), 0) AS year_opening_credit,
+       COALESCE(SUM(CASE WHEN je.entry_date >= @from THEN l.debit END), 0) AS debit,
+       COALESCE(SUM(CASE WHEN je.entry_date >= @from THEN l.credit END), 0) AS credit
+FROM accounting_journal_lines l
+JOIN accounting_journal_entries je ON je.id = l.journal_entry_id
+WHERE `+where+`
+GROUP BY l.account_code
+ORDER BY l.account_code ASC
+`, args).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *Repository) LedgerLines(ctx context.Context, params dtos.LedgerParams) ([]dtos.LedgerLineRow, error) {
+	args := map[string]interface{}{"from": params.From.Format("2006-01-02")}
+	where := ledgerFilters(params, args)
+	rows := []dtos.LedgerLineRow{}
+	err := r.db.Conn(ctx).Raw(`
+SELECT l.journal_entry_id, je.entry_date, l.account_code, l.account_name, l.business_id,
+       COALESCE(b.name, '') AS business_name, je.source_type, je.source_id,
+       COALESCE(NULLIF(l.description, ''), je.description) AS description,
+       l.debit, l.credit
+FROM accounting_journal_lines l
+JOIN accounting_journal_entries je ON je.id = l.journal_entry_id
+LEFT JOIN business b ON b.id = l.business_id
+WHERE `+where+` AND je.entry_date >= @from
+ORDER BY je.entry_date ASC, je.id ASC, l.id ASC
+`, args).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *Repository) ListPeriods(ctx context.Context, year int) ([]entities.Period, error) {
+	var rows []models.AccountingPeriod
+	if err := r.db.Conn(ctx).Where("year = ?", year).Order("month ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]entities.Period, len(rows))
+	for i := range rows {
+		out[i] = periodToEntity(&rows[i])
+	}
+	return out, nil
+}
+
+// GetPeriod devuelve nil si el mes nunca se ha cerrado.
+func (r *Repository) GetPeriod(ctx context.Context, year, month int) (*entities.Period, error) {
+	var model models.AccountingPeriod
+	err := r.db.Conn(ctx).Where("year = ? AND month = ?", year, month).First(&model).Error
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	p := periodToEntity(&model)
+	return &p, nil
+}
+
+func (r *Repository) SavePeriod(ctx context.Context, p *entities.Period) error {
+	model := &models.AccountingPeriod{
+		Year:     p.Year,
+		Month:    p.Month,
+		Status:   p.Status,
+		ClosedAt: p.ClosedAt,
+		ClosedBy: p.ClosedBy,
+	}
+	if p.ID == 0 {
+		if err := r.db.Conn(ctx).Create(model).Error; err != nil {
+			return err
+		}
+		p.ID = model.ID
+		return nil
+	}
+	return r.db.Conn(ctx).Model(&models.AccountingPeriod{}).Where("id = ?", p.ID).
+		Updates(map[string]interface{}{"status": p.Status, "closed_at": p.ClosedAt, "closed_by": p.ClosedBy}).Error
+}
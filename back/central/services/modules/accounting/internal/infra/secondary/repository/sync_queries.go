@@ -81,6 +81,58 @@ WHERE c.status = 'confirmed' AND c.deleted_at IS NULL AND c.total_net > 0
       WHERE ae.source_type = 'COD_PAYOUT' AND ae.source_id = c.id::text AND ae.deleted_at IS NULL
   )
 ORDER BY c.created_at ASC
+LIMIT ?`
+	case entities.SourceCOGS:
+		sql = `
+SELECT olc.id::text AS source_id,
+       NULLIF(olc.business_id, 0) AS business_id,
+       olc.total_cost AS amount,
+       olc.created_at AS entry_date,
+       CONCAT('Costo de venta orden ', olc.order_id) AS description
+FROM order_line_costs olc
+WHERE olc.deleted_at IS NULL AND olc.quantity > 0 AND olc.total_cost > 0
+  AND NOT EXISTS (
+      SELECT 1 FROM accounting_entries ae
+      WHERE ae.source_type = 'COGS' AND ae.source_id = olc.id::text AND ae.deleted_at IS NULL
+  )
+ORDER BY olc.created_at ASC
+LIMIT ?`
+	case entities.SourceCOGSReturn:
+		sql = `
+SELECT olc.id::text AS source_id,
+       NULLIF(olc.business_id, 0) AS business_id,
+       -olc.total_cost AS amount,
+       olc.created_at AS entry_date,
+       CONCAT('Devolucion orden ', olc.order_id) AS description
+FROM order_line_costs olc
+WHERE olc.deleted_at IS NULL AND olc.quantity < 0 AND olc.total_cost < 0
+  AND NOT EXISTS (
+      SELECT 1 FROM accounting_entries ae
+      WHERE ae.source_type = 'COGS_RETURN' AND ae.source_id = olc.id::text AND ae.deleted_at IS NULL
+  )
+ORDER BY olc.created_at ASC
+LIMIT ?`
+	case entities.SourceInventoryShrinkage, entities.SourceInventorySurplus:
+		// Ajustes manuales y de conteo valorizados: los que bajan el inventario
+		// son faltantes y los que lo suben sobrantes
+		sign := "<"
+		if sourceType == entities.SourceInventorySurplus {
+			sign = ">"
+		}
+		sql = `
+SELECT sm.id::text AS source_id,
+       NULLIF(sm.business_id, 0) AS business_id,
+       ABS(sm.total_cost) AS amount,
+       sm.created_at AS entry_date,
+       CONCAT('Ajuste de inventario ', sm.reason) AS description
+FROM stock_movements sm
+WHERE sm.deleted_at IS NULL AND sm.reference_type IN ('manual', 'count_adjustment')
+  AND sm.total_cost ` + sign + ` 0
+  AND NOT EXISTS (
+      SELECT 1 FROM accounting_entries ae
+      WHERE ae.source_type = '` + sourceType + `' AND ae.source_id = sm.id::text AND ae.deleted_at IS NULL
+  )
+ORDER BY sm.created_at ASC
 LIMIT ?`
 	default:
 		return nil, nil
@@ -56,7 +56,27 @@ type RepositoryMock struct {
 	UpdateServiceFn     func(ctx context.Context, dto dtos.SaveServiceDTO) (*entities.Service, error)
 	DeleteServiceFn     func(ctx context.Context, id uint) error
 
+	ListAccountsFn         func(ctx context.Context, businessID uint) ([]entities.Account, error)
+	GetAccountByIDFn       func(ctx context.Context, id uint) (*entities.Account, error)
+	CreateAccountFn        func(ctx context.Context, a *entities.Account) error
+	UpdateAccountFn        func(ctx context.Context, a *entities.Account) error
+	ListPostingRulesFn     func(ctx context.Context) ([]entities.PostingRule, error)
+	SavePostingRuleFn      func(ctx context.Context, rule *entities.PostingRule) error
+	FindUnpostedEntriesFn  func(ctx context.Context, afterID uint, limit int) ([]entities.Entry, error)
+	FindPendingReversalsFn func(ctx context.Context, afterID uint, limit int) ([]dtos.ReversalCandidate, error)
+	CountUnpostedEntriesFn func(ctx context.Context, from, to time.Time) (int64, error)
+	CreateJournalEntryFn   func(ctx context.Context, je *entities.JournalEntry) (bool, error)
+	GetJournalEntryFn      func(ctx context.Context, id uint) (*entities.JournalEntry, error)
+	ListJournalEntriesFn   func(ctx context.Context, params dtos.ListJournalParams) ([]entities.JournalEntry, int64, error)
+	AccountMovementsFn     func(ctx context.Context, params dtos.LedgerParams) ([]dtos.AccountMovement, error)
+	LedgerLinesFn          func(ctx context.Context, params dtos.LedgerParams) ([]dtos.LedgerLineRow, error)
+	ListPeriodsFn          func(ctx context.Context, year int) ([]entities.Period, error)
+	GetPeriodFn            func(ctx context.Context, year, month int) (*entities.Period, error)
+	SavePeriodFn           func(ctx context.Context, p *entities.Period) error
+
 	CreatedEntries      []*entities.Entry
+	CreatedJournals     []*entities.JournalEntry
+	SavedPeriods        []entities.Period
 	DeletedEntrySources []string
 	StatusCalls         []StatusCall
 }
@@ -327,6 +347,127 @@ func (m *RepositoryMock) DeleteService(ctx context.Context, id uint) error {
 	return nil
 }
 
+func (m *RepositoryMock) ListAccounts(ctx context.Context, businessID uint) ([]entities.Account, error) {
+	if m.ListAccountsFn != nil {
+		return m.ListAccountsFn(ctx, businessID)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) GetAccountByID(ctx context.Context, id uint) (*entities.Account, error) {
+	if m.GetAccountByIDFn != nil {
+		return m.GetAccountByIDFn(ctx, id)
+	}
+	return &entities.Account{ID: id, IsActive: true}, nil
+}
+
+func (m *RepositoryMock) CreateAccount(ctx context.Context, a *entities.Account) error {
+	if m.CreateAccountFn != nil {
+		return m.CreateAccountFn(ctx, a)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) UpdateAccount(ctx context.Context, a *entities.Account) error {
+	if m.UpdateAccountFn != nil {
+		return m.UpdateAccountFn(ctx, a)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) ListPostingRules(ctx context.Context) ([]entities.PostingRule, error) {
+	if m.ListPostingRulesFn != nil {
+		return m.ListPostingRulesFn(ctx)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) SavePostingRule(ctx context.Context, rule *entities.PostingRule) error {
+	if m.SavePostingRuleFn != nil {
+		return m.SavePostingRuleFn(ctx, rule)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) FindUnpostedEntries(ctx context.Context, afterID uint, limit int) ([]entities.Entry, error) {
+	if m.FindUnpostedEntriesFn != nil {
+		return m.FindUnpostedEntriesFn(ctx, afterID, limit)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) FindPendingReversals(ctx context.Context, afterID uint, limit int) ([]dtos.ReversalCandidate, error) {
+	if m.FindPendingReversalsFn != nil {
+		return m.FindPendingReversalsFn(ctx, afterID, limit)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) CountUnpostedEntries(ctx context.Context, from, to time.Time) (int64, error) {
+	if m.CountUnpostedEntriesFn != nil {
+		return m.CountUnpostedEntriesFn(ctx, from, to)
+	}
+	return 0, nil
+}
+
+func (m *RepositoryMock) CreateJournalEntry(ctx context.Context, je *entities.JournalEntry) (bool, error) {
+	m.CreatedJournals = append(m.CreatedJournals, je)
+	if m.CreateJournalEntryFn != nil {
+		return m.CreateJournalEntryFn(ctx, je)
+	}
+	return true, nil
+}
+
+func (m *RepositoryMock) GetJournalEntry(ctx context.Context, id uint) (*entities.JournalEntry, error) {
+	if m.GetJournalEntryFn != nil {
+		return m.GetJournalEntryFn(ctx, id)
+	}
+	return &entities.JournalEntry{ID: id}, nil
+}
+
+func (m *RepositoryMock) ListJournalEntries(ctx context.Context, params dtos.ListJournalParams) ([]entities.JournalEntry, int64, error) {
+	if m.ListJournalEntriesFn != nil {
+		return m.ListJournalEntriesFn(ctx, params)
+	}
+	return nil, 0, nil
+}
+
+func (m *RepositoryMock) AccountMovements(ctx context.Context, params dtos.LedgerParams) ([]dtos.AccountMovement, error) {
+	if m.AccountMovementsFn != nil {
+		return m.AccountMovementsFn(ctx, params)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) LedgerLines(ctx context.Context, params dtos.LedgerParams) ([]dtos.LedgerLineRow, error) {
+	if m.LedgerLinesFn != nil {
+		return m.LedgerLinesFn(ctx, params)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) ListPeriods(ctx context.Context, year int) ([]entities.Period, error) {
+	if m.ListPeriodsFn != nil {
+		return m.ListPeriodsFn(ctx, year)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) GetPeriod(ctx context.Context, year, month int) (*entities.Period, error) {
+	if m.GetPeriodFn != nil {
+		return m.GetPeriodFn(ctx, year, month)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) SavePeriod(ctx context.Context, p *entities.Period) error {
+	m.SavedPeriods = append(m.SavedPeriods, *p)
+	if m.SavePeriodFn != nil {
+		return m.SavePeriodFn(ctx, p)
+	}
+	return nil
+}
+
 type EmailCall struct {
 	To      string
 	Subject string
@@ -0,0 +1,19 @@
+package audit
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/app"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/infra/primary/handlers"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/infra/secondary/repository"
+	"github.com/secamc93/probability/back/central/shared/db"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+// New expone la consulta de la bitacora central de auditoria: busqueda,
+// detalle, exportacion y verificacion de la cadena. La escritura vive en
+// shared/audit.
+func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger) {
+	logger = logger.WithModule("audit")
+	uc := app.New(repository.New(database), logger)
+	handlers.New(uc, logger).RegisterRoutes(router)
+}
@@ -0,0 +1,219 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/mocks"
+	"github.com/secamc93/probability/back/central/shared/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var baseTime = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+var (
+	superAdmin    = dtos.Requester{UserID: 1}
+	adminNegocio  = dtos.Requester{UserID: 5, BusinessID: 7, RoleID: 2}
+	vendedor      = dtos.Requester{UserID: 6, BusinessID: 7, RoleID: 4}
+	sinRol        = dtos.Requester{UserID: 8, BusinessID: 7}
+	rolesDePrueba = map[uint]int{2: 2, 4: 4}
+)
+
+func uintPtr(v uint) *uint { return &v }
+
+func newAuditUseCase() (*UseCase, *mocks.RepositoryMock) {
+	repo := mocks.NewRepositoryMock()
+	repo.RoleLevels = rolesDePrueba
+	return &UseCase{repo: repo, log: mocks.NewSilentLogger()}, repo
+}
+
+// encadenar agrega n entradas a la cadena del negocio como lo haria el
+// escritor de shared/audit, arrancando despues de prevSeq/prevHash.
+func encadenar(repo *mocks.RepositoryMock, businessID uint, n int, prevSeq uint64, prevHash string) {
+	for i := 0; i < n; i++ {
+		e := entities.AuditLog{
+			ID:         uint64(len(repo.Logs) + 1),
+			ChainID:    businessID,
+			ChainSeq:   prevSeq + 1,
+			ActorType:  audit.ActorUser,
+			Source:     audit.SourceDomain,
+			Resource:   "wallet",
+			ResourceID: "w-1",
+			Action:     "admin_adjust",
+			Changes:    json.RawMessage(`{"balance": {"before": 100, "after": 150}}`),
+			OccurredAt: baseTime.Add(time.Duration(len(repo.Logs)) * time.Minute),
+			PrevHash:   prevHash,
+		}
+		if businessID != 0 {
+			e.BusinessID = uintPtr(businessID)
+		}
+		e.Hash = audit.Hash(hashFields(&e))
+		repo.Logs = append(repo.Logs, e)
+		prevSeq, prevHash = e.ChainSeq, e.Hash
+	}
+}
+
+func TestList_AdminDeNegocioSoloVeSuNegocio(t *testing.T) {
+	uc, repo := newAuditUseCase()
+
+	_, _, err := uc.List(context.Background(), adminNegocio, dtos.ListParams{Filter: dtos.Filter{BusinessID: 99}})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), repo.LastList.BusinessID, "el ?business_id solo aplica al super admin")
+	assert.Equal(t, 1, repo.LastList.Page)
+	assert.Equal(t, defaultPageSize, repo.LastList.PageSize)
+}
+
+func TestList_SuperAdminEligeNegocioOTodos(t *testing.T) {
+	uc, repo := newAuditUseCase()
+
+	_, _, err := uc.List(context.Background(), superAdmin, dtos.ListParams{Filter: dtos.Filter{BusinessID: 99}, PageSize: 5000})
+	require.NoError(t, err)
+	assert.Equal(t, uint(99), repo.LastList.BusinessID)
+	assert.Equal(t, maxPageSize, repo.LastList.PageSize)
+
+	_, _, err = uc.List(context.Background(), superAdmin, dtos.ListParams{})
+	require.NoError(t, err)
+	assert.Equal(t, uint(0), repo.LastList.BusinessID)
+}
+
+func TestList_SinRolDeAdminEsRechazado(t *testing.T) {
+	uc, _ := newAuditUseCase()
+
+	for nombre, requester := range map[string]dtos.Requester{"vendedor": vendedor, "sin rol": sinRol} {
+		t.Run(nombre, func(t *testing.T) {
+			_, _, err := uc.List(context.Background(), requester, dtos.ListParams{})
+			assert.ErrorIs(t, err, dom.ErrNotAuditReader)
+		})
+	}
+}
+
+func TestGet_EntradaDeOtroNegocioNoExiste(t *testing.T) {
+	uc, repo := newAuditUseCase()
+	encadenar(repo, 8, 1, 0, audit.GenesisHash)
+
+	_, err := uc.Get(context.Background(), adminNegocio, 1)
+	assert.ErrorIs(t, err, dom.ErrLogNotFound)
+
+	got, err := uc.Get(context.Background(), superAdmin, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint(8), *got.BusinessID)
+}
+
+func TestExport_ForzaElNegocioDelSolicitante(t *testing.T) {
+	uc, repo := newAuditUseCase()
+	encadenar(repo, 7, 2, 0, audit.GenesisHash)
+	encadenar(repo, 8, 3, 0, audit.GenesisHash)
+
+	var exportadas []entities.AuditLog
+	err := uc.Export(context.Background(), adminNegocio, dtos.Filter{BusinessID: 8}, func(batch []entities.AuditLog) error {
+		exportadas = append(exportadas, batch...)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Len(t, exportadas, 2)
+	assert.Equal(t, uint(7), repo.LastFilter.BusinessID)
+}
+
+func TestExport_VendedorNoExporta(t *testing.T) {
+	uc, _ := newAuditUseCase()
+
+	err := uc.Export(context.Background(), vendedor, dtos.Filter{}, func([]entities.AuditLog) error {
+		t.Fatal("no debe entregar filas")
+		return nil
+	})
+
+	assert.ErrorIs(t, err, dom.ErrNotAuditReader)
+}
+
+func TestVerifyChain_CadenaIntacta(t *testing.T) {
+	uc, repo := newAuditUseCase()
+	encadenar(repo, 7, 5, 0, audit.GenesisHash)
+
+	got, err := uc.VerifyChain(context.Background(), adminNegocio, 0)
+
+	require.NoError(t, err)
+	assert.True(t, got.Valid)
+	assert.Equal(t, int64(5), got.Checked)
+	assert.Equal(t, "genesis", got.Anchor)
+	assert.Equal(t, uint64(1), got.FirstSeq)
+	assert.Equal(t, uint64(5), got.LastSeq)
+	assert.Equal(t, repo.Logs[4].Hash, got.LastHash)
+}
+
+func TestVerifyChain_ContenidoAlterado(t *testing.T) {
+	uc, repo := newAuditUseCase()
+	encadenar(repo, 7, 3, 0, audit.GenesisHash)
+	repo.Logs[1].Changes = json.RawMessage(`{"balance": {"before": 100, "after": 999999}}`)
+
+	got, err := uc.VerifyChain(context.Background(), adminNegocio, 0)
+
+	require.NoError(t, err)
+	assert.False(t, got.Valid)
+	require.Len(t, got.Problems, 1)
+	assert.Equal(t, entities.ChainProblem{Kind: entities.ProblemHash, Seq: 2, LogID: 2}, got.Problems[0])
+}
+
+func TestVerifyChain_FilaBorrada(t *testing.T) {
+	uc, repo := newAuditUseCase()
+	encadenar(repo, 7, 4, 0, audit.GenesisHash)
+	repo.Logs = append(repo.Logs[:1], repo.Logs[2:]...)
+
+	got, err := uc.VerifyChain(context.Background(), adminNegocio, 0)
+
+	require.NoError(t, err)
+	assert.False(t, got.Valid)
+	kinds := []string{}
+	for _, p := range got.Problems {
+		kinds = append(kinds, p.Kind)
+	}
+	assert.Equal(t, []string{entities.ProblemGap, entities.ProblemPrevHash}, kinds)
+}
+
+func TestVerifyChain_PurgaConCheckpointSigueValida(t *testing.T) {
+	uc, repo := newAuditUseCase()
+	encadenar(repo, 0, 6, 0, audit.GenesisHash)
+	purgadas := repo.Logs[:3]
+	repo.Checkpoints = []entities.Checkpoint{{ChainID: 0, UpToSeq: 3, Hash: purgadas[2].Hash, Purged: 3}}
+	repo.Logs = repo.Logs[3:]
+
+	got, err := uc.VerifyChain(context.Background(), superAdmin, 0)
+
+	require.NoError(t, err)
+	assert.True(t, got.Valid, "%+v", got.Problems)
+	assert.Equal(t, "checkpoint", got.Anchor)
+	assert.Equal(t, uint64(4), got.FirstSeq)
+	assert.Equal(t, int64(3), got.Checked)
+}
+
+func TestVerifyChain_BorradoSinCheckpoint(t *testing.T) {
+	uc, repo := newAuditUseCase()
+	encadenar(repo, 7, 4, 0, audit.GenesisHash)
+	repo.Logs = repo.Logs[2:]
+
+	got, err := uc.VerifyChain(context.Background(), adminNegocio, 0)
+
+	require.NoError(t, err)
+	assert.False(t, got.Valid)
+	assert.Equal(t, entities.ProblemAnchor, got.Problems[0].Kind)
+}
+
+func TestVerifyChain_NoIncluyeOtrosNegocios(t *testing.T) {
+	uc, repo := newAuditUseCase()
+	encadenar(repo, 7, 2, 0, audit.GenesisHash)
+	encadenar(repo, 8, 2, 0, audit.GenesisHash)
+
+	got, err := uc.VerifyChain(context.Background(), superAdmin, 8)
+
+	require.NoError(t, err)
+	assert.True(t, got.Valid)
+	assert.Equal(t, uint(8), got.ChainID)
+	assert.Equal(t, int64(2), got.Checked)
+}
@@ -0,0 +1,31 @@
+package app
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+// La bitacora la leen el super admin (todos los negocios) y los
+// administradores de cada negocio (solo el suyo).
+type IUseCase interface {
+	List(ctx context.Context, requester dtos.Requester, params dtos.ListParams) ([]entities.AuditLog, int64, error)
+	Get(ctx context.Context, requester dtos.Requester, id uint64) (*entities.AuditLog, error)
+	// Export entrega las entradas del filtro en lotes, hasta MaxExportRows
+	Export(ctx context.Context, requester dtos.Requester, filter dtos.Filter, fn func([]entities.AuditLog) error) error
+	// VerifyChain recalcula la cadena de un negocio (0 = plataforma, solo
+	// super admin).
+	VerifyChain(ctx context.Context, requester dtos.Requester, businessID uint) (*entities.ChainVerification, error)
+}
+
+type UseCase struct {
+	repo ports.IRepository
+	log  log.ILogger
+}
+
+func New(repo ports.IRepository, logger log.ILogger) IUseCase {
+	return &UseCase{repo: repo, log: logger}
+}
@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/shared/bizscope"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+
+	// MaxExportRows acota una exportacion; para mas, partir por fechas
+	MaxExportRows = 100000
+)
+
+// readerScope: solo los administradores leen la bitacora de su negocio; el
+// super admin puede pedir cualquiera o todos (0)
+var readerScope = bizscope.Policy{
+	AllowAllBusinesses: true,
+	ErrForbidden:       dom.ErrNotAuditReader,
+}
+
+// resolveBusiness devuelve el negocio cuya bitacora puede ver el solicitante
+func (uc *UseCase) resolveBusiness(ctx context.Context, requester dtos.Requester, businessID uint) (uint, error) {
+	actor := bizscope.Actor{
+		BusinessID:   requester.BusinessID,
+		RoleID:       requester.RoleID,
+		IsSuperAdmin: requester.IsSuperAdmin(),
+	}
+	return readerScope.Resolve(ctx, uc.repo.GetRoleLevel, actor, businessID)
+}
+
+func (uc *UseCase) List(ctx context.Context, requester dtos.Requester, params dtos.ListParams) ([]entities.AuditLog, int64, error) {
+	businessID, err := uc.resolveBusiness(ctx, requester, params.BusinessID)
+	if err != nil {
+		return nil, 0, err
+	}
+	params.BusinessID = businessID
+	params.Page, params.PageSize = NormalizePage(params.Page, params.PageSize)
+	return uc.repo.List(ctx, params)
+}
+
+// NormalizePage aplica la pagina 1, el tamano por defecto y el maximo
+func NormalizePage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return page, pageSize
+}
+
+func (uc *UseCase) Get(ctx context.Context, requester dtos.Requester, id uint64) (*entities.AuditLog, error) {
+	businessID, err := uc.resolveBusiness(ctx, requester, 0)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := uc.repo.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if businessID != 0 && (entry.BusinessID == nil || *entry.BusinessID != businessID) {
+		return nil, dom.ErrLogNotFound
+	}
+	return entry, nil
+}
+
+func (uc *UseCase) Export(ctx context.Context, requester dtos.Requester, filter dtos.Filter, fn func([]entities.AuditLog) error) error {
+	businessID, err := uc.resolveBusiness(ctx, requester, filter.BusinessID)
+	if err != nil {
+		return err
+	}
+	filter.BusinessID = businessID
+	return uc.repo.Stream(ctx, filter, MaxExportRows, fn)
+}
@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/shared/audit"
+)
+
+const (
+	verifyPageSize = 1000
+	// maxProblems acota el reporte; la cadena ya es invalida con uno
+	maxProblems = 50
+)
+
+// VerifyChain recorre la cadena desde el genesis o desde el ultimo
+// checkpoint de retencion y comprueba que cada fila siga a la anterior
+// (seq consecutivo y PrevHash) y que su contenido corresponda a su hash.
+// Cualquier UPDATE, DELETE o INSERT fuera del escritor rompe alguna de las
+// tres.
+func (uc *UseCase) VerifyChain(ctx context.Context, requester dtos.Requester, businessID uint) (*entities.ChainVerification, error) {
+	chainID, err := uc.resolveBusiness(ctx, requester, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entities.ChainVerification{ChainID: chainID, Anchor: "genesis", LastHash: audit.GenesisHash}
+	checkpoint, err := uc.repo.LatestCheckpoint(ctx, chainID)
+	if err != nil {
+		return nil, err
+	}
+	if checkpoint != nil {
+		result.Anchor = "checkpoint"
+		result.LastSeq = checkpoint.UpToSeq
+		result.LastHash = checkpoint.Hash
+	}
+
+	expectedSeq := result.LastSeq + 1
+	prevHash := result.LastHash
+	for {
+		rows, err := uc.repo.ChainPage(ctx, chainID, result.LastSeq, verifyPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for i := range rows {
+			row := &rows[i]
+			if result.Checked == 0 {
+				result.FirstSeq = row.ChainSeq
+			}
+			result.Checked++
+
+			if row.ChainSeq != expectedSeq {
+				kind := entities.ProblemGap
+				if result.Checked == 1 {
+					kind = entities.ProblemAnchor
+				}
+				addProblem(result, kind, row)
+			}
+			if row.PrevHash != prevHash {
+				addProblem(result, entities.ProblemPrevHash, row)
+			}
+			if audit.Hash(hashFields(row)) != row.Hash {
+				addProblem(result, entities.ProblemHash, row)
+			}
+
+			expectedSeq = row.ChainSeq + 1
+			prevHash = row.Hash
+			result.LastSeq = row.ChainSeq
+			result.LastHash = row.Hash
+		}
+		if len(rows) < verifyPageSize {
+			break
+		}
+	}
+
+	result.Valid = len(result.Problems) == 0
+	return result, nil
+}
+
+func addProblem(result *entities.ChainVerification, kind string, row *entities.AuditLog) {
+	if len(result.Problems) < maxProblems {
+		result.Problems = append(result.Problems, entities.ChainProblem{Kind: kind, Seq: row.ChainSeq, LogID: row.ID})
+	}
+}
+
+func hashFields(row *entities.AuditLog) audit.Fields {
+	return audit.Fields{
+		ChainID:       row.ChainID,
+		ChainSeq:      row.ChainSeq,
+		BusinessID:    row.BusinessID,
+		ActorType:     row.ActorType,
+		ActorUserID:   row.ActorUserID,
+		ActorLabel:    row.ActorLabel,
+		APIKeyID:      row.APIKeyID,
+		Source:        row.Source,
+		Method:        row.Method,
+		Path:          row.Path,
+		StatusCode:    row.StatusCode,
+		Resource:      row.Resource,
+		ResourceID:    row.ResourceID,
+		Action:        row.Action,
+		Changes:       row.Changes,
+		RequestBody:   row.RequestBody,
+		Metadata:      row.Metadata,
+		IP:            row.IP,
+		UserAgent:     row.UserAgent,
+		CorrelationID: row.CorrelationID,
+		OccurredAt:    row.OccurredAt,
+		PrevHash:      row.PrevHash,
+	}
+}
@@ -0,0 +1,36 @@
+package dtos
+
+import "time"
+
+// Requester es quien consulta la bitacora. BusinessID 0 es super admin.
+type Requester struct {
+	UserID     uint
+	BusinessID uint
+	RoleID     uint
+}
+
+func (r Requester) IsSuperAdmin() bool {
+	return r.BusinessID == 0
+}
+
+// Filter acota las entradas. BusinessID 0 solo lo puede pedir el super admin
+// y significa todos los negocios. To es exclusivo.
+type Filter struct {
+	BusinessID    uint
+	ActorUserID   uint
+	Resource      string
+	ResourceID    string
+	Action        string
+	Source        string
+	Method        string
+	StatusCode    int
+	CorrelationID string
+	From          *time.Time
+	To            *time.Time
+}
+
+type ListParams struct {
+	Filter
+	Page     int
+	PageSize int
+}
@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Tipos de problema al verificar una cadena
+const (
+	ProblemGap      = "gap"       // falta un eslabon (seq no consecutivo)
+	ProblemPrevHash = "prev_hash" // la fila no apunta al hash de la anterior
+	ProblemHash     = "hash"      // el contenido no corresponde a su hash
+	ProblemAnchor   = "anchor"    // la primera fila no continua ningun checkpoint
+)
+
+type AuditLog struct {
+	ID         uint64
+	ChainID    uint
+	ChainSeq   uint64
+	BusinessID *uint
+
+	ActorType   string
+	ActorUserID *uint
+	ActorLabel  string
+	APIKeyID    *uint
+
+	Source        string
+	Method        string
+	Path          string
+	StatusCode    int
+	Resource      string
+	ResourceID    string
+	Action        string
+	Changes       json.RawMessage
+	RequestBody   json.RawMessage
+	Metadata      json.RawMessage
+	IP            string
+	UserAgent     string
+	CorrelationID string
+
+	OccurredAt time.Time
+	PrevHash   string
+	Hash       string
+}
+
+// Checkpoint es el ultimo eslabon borrado por una purga de retencion
+type Checkpoint struct {
+	ChainID   uint
+	UpToSeq   uint64
+	Hash      string
+	Purged    int64
+	CreatedAt time.Time
+}
+
+// ChainVerification es el resultado de recorrer una cadena recalculando sus
+// hashes. Anchor es "genesis" o "checkpoint" segun desde donde arranco.
+type ChainVerification struct {
+	ChainID  uint
+	Valid    bool
+	Checked  int64
+	Anchor   string
+	FirstSeq uint64
+	LastSeq  uint64
+	LastHash string
+	Problems []ChainProblem
+}
+
+type ChainProblem struct {
+	Kind  string
+	Seq   uint64
+	LogID uint64
+}
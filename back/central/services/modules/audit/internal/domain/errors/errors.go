@@ -0,0 +1,8 @@
+package errors
+
+import "errors"
+
+var (
+	ErrLogNotFound    = errors.New("audit log not found")
+	ErrNotAuditReader = errors.New("only business administrators can read the audit log")
+)
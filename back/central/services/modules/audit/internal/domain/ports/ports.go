@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/entities"
+)
+
+type IRepository interface {
+	List(ctx context.Context, params dtos.ListParams) ([]entities.AuditLog, int64, error)
+	Get(ctx context.Context, id uint64) (*entities.AuditLog, error)
+	// Stream recorre las entradas del filtro en lotes por id ascendente, hasta
+	// limit filas; corta si fn devuelve error.
+	Stream(ctx context.Context, filter dtos.Filter, limit int, fn func([]entities.AuditLog) error) error
+
+	// LatestCheckpoint devuelve nil si la cadena nunca se purgo
+	LatestCheckpoint(ctx context.Context, chainID uint) (*entities.Checkpoint, error)
+	// ChainPage trae las filas de la cadena con seq mayor a afterSeq, en orden
+	ChainPage(ctx context.Context, chainID uint, afterSeq uint64, limit int) ([]entities.AuditLog, error)
+
+	GetRoleLevel(ctx context.Context, roleID uint) (int, error)
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/app"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/dtos"
+	dom "github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+type IHandlers interface {
+	RegisterRoutes(router *gin.RouterGroup)
+}
+
+type Handlers struct {
+	uc  app.IUseCase
+	log log.ILogger
+}
+
+func New(uc app.IUseCase, logger log.ILogger) IHandlers {
+	return &Handlers{uc: uc, log: logger}
+}
+
+func (h *Handlers) requester(c *gin.Context) dtos.Requester {
+	userID, _ := middleware.GetUserID(c)
+	businessID, _ := middleware.GetBusinessID(c)
+	roleID, _ := middleware.GetRoleID(c)
+	return dtos.Requester{UserID: userID, BusinessID: businessID, RoleID: roleID}
+}
+
+// businessScope es el ?business_id con el que el super admin elige negocio;
+// para los demas el caso de uso usa el negocio del token.
+func (h *Handlers) businessScope(c *gin.Context) uint {
+	v, _ := strconv.ParseUint(c.Query("business_id"), 10, 64)
+	return uint(v)
+}
+
+func (h *Handlers) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, dom.ErrLogNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, dom.ErrNotAuditReader):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	default:
+		h.log.Error(c.Request.Context()).Err(err).Msg("Error consultando la bitacora de auditoria")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+	}
+}
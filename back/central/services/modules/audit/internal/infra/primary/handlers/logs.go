@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/app"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/infra/primary/handlers/response"
+)
+
+func (h *Handlers) filter(c *gin.Context, q request.FilterQuery) (dtos.Filter, bool) {
+	from, to, err := q.Range()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return dtos.Filter{}, false
+	}
+	return dtos.Filter{
+		BusinessID:    h.businessScope(c),
+		ActorUserID:   q.ActorUserID,
+		Resource:      q.Resource,
+		ResourceID:    q.ResourceID,
+		Action:        q.Action,
+		Source:        q.Source,
+		Method:        strings.ToUpper(q.Method),
+		StatusCode:    q.StatusCode,
+		CorrelationID: q.CorrelationID,
+		From:          from,
+		To:            to,
+	}, true
+}
+
+func (h *Handlers) List(c *gin.Context) {
+	var q request.ListQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter, ok := h.filter(c, q.FilterQuery)
+	if !ok {
+		return
+	}
+
+	page, pageSize := app.NormalizePage(q.Page, q.PageSize)
+	params := dtos.ListParams{Filter: filter, Page: page, PageSize: pageSize}
+	list, total, err := h.uc.List(c.Request.Context(), h.requester(c), params)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data":      response.FromAuditLogs(list),
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+func (h *Handlers) Get(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	entry, err := h.uc.Get(c.Request.Context(), h.requester(c), id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response.FromAuditLog(entry))
+}
+
+// Export descarga las entradas del filtro en CSV o, con ?format=json, una
+// entrada JSON por linea. Se escribe a medida que se lee.
+func (h *Handlers) Export(c *gin.Context) {
+	var q request.ExportQuery
+	if err := c.ShouldBindQuery(&q); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	format := strings.ToLower(q.Format)
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv or json"})
+		return
+	}
+	filter, ok := h.filter(c, q.FilterQuery)
+	if !ok {
+		return
+	}
+
+	// Los headers se escriben con el primer lote: si el permiso falla aun se
+	// puede responder el error como JSON.
+	var csvWriter *csv.Writer
+	var jsonEncoder *json.Encoder
+	started := false
+	start := func() {
+		started = true
+		name := fmt.Sprintf("audit-logs-%s", time.Now().UTC().Format("20060102-150405"))
+		if format == "csv" {
+			c.Header("Content-Type", "text/csv; charset=utf-8")
+			c.Header("Content-Disposition", `attachment; filename="`+name+`.csv"`)
+			c.Status(http.StatusOK)
+			csvWriter = csv.NewWriter(c.Writer)
+			_ = csvWriter.Write(response.CSVHeader)
+			return
+		}
+		c.Header("Content-Type", "application/x-ndjson")
+		c.Header("Content-Disposition", `attachment; filename="`+name+`.jsonl"`)
+		c.Status(http.StatusOK)
+		jsonEncoder = json.NewEncoder(c.Writer)
+	}
+
+	err := h.uc.Export(c.Request.Context(), h.requester(c), filter, func(batch []entities.AuditLog) error {
+		if !started {
+			start()
+		}
+		for i := range batch {
+			if csvWriter != nil {
+				if err := csvWriter.Write(response.CSVRecord(&batch[i])); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := jsonEncoder.Encode(response.FromAuditLog(&batch[i])); err != nil {
+				return err
+			}
+		}
+		if csvWriter != nil {
+			csvWriter.Flush()
+			return csvWriter.Error()
+		}
+		return nil
+	})
+	if err != nil && !started {
+		h.handleError(c, err)
+		return
+	}
+	if err != nil {
+		// La respuesta ya salio a medias; el cliente la recibe cortada
+		h.log.Error(c.Request.Context()).Err(err).Msg("Exportacion de auditoria interrumpida")
+		return
+	}
+	if !started {
+		start()
+	}
+	if csvWriter != nil {
+		csvWriter.Flush()
+	}
+}
+
+// Verify recalcula la cadena de hashes del negocio. El super admin elige
+// con ?business_id; sin el verifica la cadena de plataforma.
+func (h *Handlers) Verify(c *gin.Context) {
+	result, err := h.uc.VerifyChain(c.Request.Context(), h.requester(c), h.businessScope(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response.FromVerification(result))
+}
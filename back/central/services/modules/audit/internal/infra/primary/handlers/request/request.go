@@ -0,0 +1,68 @@
+package request
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// FilterQuery son los filtros por query string de listado y exportacion
+// (business_id lo lee el handler: solo aplica al super admin).
+// from/to aceptan RFC3339 o una fecha (2026-10-18); to es exclusivo y una
+// fecha sola incluye todo ese dia.
+type FilterQuery struct {
+	ActorUserID   uint   `form:"actor_user_id"`
+	Resource      string `form:"resource"`
+	ResourceID    string `form:"resource_id"`
+	Action        string `form:"action"`
+	Source        string `form:"source"`
+	Method        string `form:"method"`
+	StatusCode    int    `form:"status"`
+	CorrelationID string `form:"correlation_id"`
+	From          string `form:"from"`
+	To            string `form:"to"`
+}
+
+type ListQuery struct {
+	FilterQuery
+	Page     int `form:"page"`
+	PageSize int `form:"page_size"`
+}
+
+type ExportQuery struct {
+	FilterQuery
+	// csv (por defecto) o json (una entrada por linea)
+	Format string `form:"format"`
+}
+
+// Range interpreta from y to
+func (q FilterQuery) Range() (*time.Time, *time.Time, error) {
+	from, _, err := parseTime(q.From)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid from: %w", err)
+	}
+	to, dateOnly, err := parseTime(q.To)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid to: %w", err)
+	}
+	if to != nil && dateOnly {
+		next := to.AddDate(0, 0, 1)
+		to = &next
+	}
+	return from, to, nil
+}
+
+func parseTime(raw string) (*time.Time, bool, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, false, nil
+	}
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &t, false, nil
+	}
+	t, err := time.Parse(time.DateOnly, raw)
+	if err != nil {
+		return nil, false, fmt.Errorf("use RFC3339 or YYYY-MM-DD")
+	}
+	return &t, true, nil
+}
@@ -0,0 +1,164 @@
+package response
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/entities"
+)
+
+type AuditLogResponse struct {
+	ID            uint64          `json:"id"`
+	BusinessID    *uint           `json:"business_id"`
+	ActorType     string          `json:"actor_type"`
+	ActorUserID   *uint           `json:"actor_user_id"`
+	ActorLabel    string          `json:"actor_label"`
+	APIKeyID      *uint           `json:"api_key_id"`
+	Source        string          `json:"source"`
+	Method        string          `json:"method"`
+	Path          string          `json:"path"`
+	StatusCode    int             `json:"status_code"`
+	Resource      string          `json:"resource"`
+	ResourceID    string          `json:"resource_id"`
+	Action        string          `json:"action"`
+	Changes       json.RawMessage `json:"changes"`
+	RequestBody   json.RawMessage `json:"request_body,omitempty"`
+	Metadata      json.RawMessage `json:"metadata"`
+	IP            string          `json:"ip"`
+	UserAgent     string          `json:"user_agent"`
+	CorrelationID string          `json:"correlation_id"`
+	OccurredAt    time.Time       `json:"occurred_at"`
+	ChainSeq      uint64          `json:"chain_seq"`
+	Hash          string          `json:"hash"`
+}
+
+func FromAuditLog(e *entities.AuditLog) AuditLogResponse {
+	return AuditLogResponse{
+		ID:            e.ID,
+		BusinessID:    e.BusinessID,
+		ActorType:     e.ActorType,
+		ActorUserID:   e.ActorUserID,
+		ActorLabel:    e.ActorLabel,
+		APIKeyID:      e.APIKeyID,
+		Source:        e.Source,
+		Method:        e.Method,
+		Path:          e.Path,
+		StatusCode:    e.StatusCode,
+		Resource:      e.Resource,
+		ResourceID:    e.ResourceID,
+		Action:        e.Action,
+		Changes:       jsonOrNull(e.Changes),
+		RequestBody:   e.RequestBody,
+		Metadata:      jsonOrNull(e.Metadata),
+		IP:            e.IP,
+		UserAgent:     e.UserAgent,
+		CorrelationID: e.CorrelationID,
+		OccurredAt:    e.OccurredAt,
+		ChainSeq:      e.ChainSeq,
+		Hash:          e.Hash,
+	}
+}
+
+func FromAuditLogs(list []entities.AuditLog) []AuditLogResponse {
+	out := make([]AuditLogResponse, 0, len(list))
+	for i := range list {
+		out = append(out, FromAuditLog(&list[i]))
+	}
+	return out
+}
+
+func jsonOrNull(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 {
+		return json.RawMessage("null")
+	}
+	return raw
+}
+
+// CSVHeader son las columnas de la exportacion
+var CSVHeader = []string{
+	"id", "occurred_at", "business_id", "actor_type", "actor_user_id", "actor_label", "api_key_id",
+	"source", "method", "path", "status_code", "resource", "resource_id", "action",
+	"changes", "metadata", "ip", "user_agent", "correlation_id", "chain_seq", "hash",
+}
+
+func CSVRecord(e *entities.AuditLog) []string {
+	record := []string{
+		strconv.FormatUint(e.ID, 10),
+		e.OccurredAt.UTC().Format(time.RFC3339Nano),
+		optUint(e.BusinessID),
+		e.ActorType,
+		optUint(e.ActorUserID),
+		e.ActorLabel,
+		optUint(e.APIKeyID),
+		e.Source,
+		e.Method,
+		e.Path,
+		strconv.Itoa(e.StatusCode),
+		e.Resource,
+		e.ResourceID,
+		e.Action,
+		string(e.Changes),
+		string(e.Metadata),
+		e.IP,
+		e.UserAgent,
+		e.CorrelationID,
+		strconv.FormatUint(e.ChainSeq, 10),
+		e.Hash,
+	}
+	for i := range record {
+		record[i] = csvSafe(record[i])
+	}
+	return record
+}
+
+// csvSafe evita que una hoja de calculo interprete como formula un valor que
+// vino del cliente (label, path, user agent...)
+func csvSafe(v string) string {
+	if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
+
+func optUint(v *uint) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*v), 10)
+}
+
+type ChainProblemResponse struct {
+	Kind  string `json:"kind"`
+	Seq   uint64 `json:"seq"`
+	LogID uint64 `json:"log_id"`
+}
+
+type ChainVerificationResponse struct {
+	BusinessID uint                   `json:"business_id"`
+	Valid      bool                   `json:"valid"`
+	Checked    int64                  `json:"checked"`
+	Anchor     string                 `json:"anchor"`
+	FirstSeq   uint64                 `json:"first_seq"`
+	LastSeq    uint64                 `json:"last_seq"`
+	LastHash   string                 `json:"last_hash"`
+	Problems   []ChainProblemResponse `json:"problems"`
+}
+
+func FromVerification(v *entities.ChainVerification) ChainVerificationResponse {
+	problems := make([]ChainProblemResponse, 0, len(v.Problems))
+	for _, p := range v.Problems {
+		problems = append(problems, ChainProblemResponse{Kind: p.Kind, Seq: p.Seq, LogID: p.LogID})
+	}
+	return ChainVerificationResponse{
+		BusinessID: v.ChainID,
+		Valid:      v.Valid,
+		Checked:    v.Checked,
+		Anchor:     v.Anchor,
+		FirstSeq:   v.FirstSeq,
+		LastSeq:    v.LastSeq,
+		LastHash:   v.LastHash,
+		Problems:   problems,
+	}
+}
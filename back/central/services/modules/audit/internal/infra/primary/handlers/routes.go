@@ -0,0 +1,16 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+)
+
+func (h *Handlers) RegisterRoutes(router *gin.RouterGroup) {
+	g := router.Group("/audit-logs", middleware.JWT(), middleware.RequireJWT())
+	{
+		g.GET("", h.List)
+		g.GET("/export", h.Export)
+		g.GET("/verify", h.Verify)
+		g.GET("/:id", h.Get)
+	}
+}
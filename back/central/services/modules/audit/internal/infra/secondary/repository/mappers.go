@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"encoding/json"
+
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/entities"
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func toEntity(m *models.AuditLog) entities.AuditLog {
+	return entities.AuditLog{
+		ID:            m.ID,
+		ChainID:       m.ChainID,
+		ChainSeq:      m.ChainSeq,
+		BusinessID:    m.BusinessID,
+		ActorType:     m.ActorType,
+		ActorUserID:   m.ActorUserID,
+		ActorLabel:    m.ActorLabel,
+		APIKeyID:      m.APIKeyID,
+		Source:        m.Source,
+		Method:        m.Method,
+		Path:          m.Path,
+		StatusCode:    m.StatusCode,
+		Resource:      m.Resource,
+		ResourceID:    m.ResourceID,
+		Action:        m.Action,
+		Changes:       json.RawMessage(m.Changes),
+		RequestBody:   json.RawMessage(m.RequestBody),
+		Metadata:      json.RawMessage(m.Metadata),
+		IP:            m.IP,
+		UserAgent:     m.UserAgent,
+		CorrelationID: m.CorrelationID,
+		OccurredAt:    m.OccurredAt,
+		PrevHash:      m.PrevHash,
+		Hash:          m.Hash,
+	}
+}
+
+func toEntities(rows []models.AuditLog) []entities.AuditLog {
+	out := make([]entities.AuditLog, 0, len(rows))
+	for i := range rows {
+		out = append(out, toEntity(&rows[i]))
+	}
+	return out
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/shared/db"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+)
+
+const streamBatchSize = 500
+
+type Repository struct {
+	db db.IDatabase
+}
+
+func New(database db.IDatabase) ports.IRepository {
+	return &Repository{db: database}
+}
+
+func (r *Repository) filtered(ctx context.Context, f dtos.Filter) *gorm.DB {
+	q := r.db.Conn(ctx).Model(&models.AuditLog{})
+	if f.BusinessID != 0 {
+		q = q.Where("business_id = ?", f.BusinessID)
+	}
+	if f.ActorUserID != 0 {
+		q = q.Where("actor_user_id = ?", f.ActorUserID)
+	}
+	if f.Resource != "" {
+		q = q.Where("resource = ?", f.Resource)
+	}
+	if f.ResourceID != "" {
+		q = q.Where("resource_id = ?", f.ResourceID)
+	}
+	if f.Action != "" {
+		q = q.Where("action = ?", f.Action)
+	}
+	if f.Source != "" {
+		q = q.Where("source = ?", f.Source)
+	}
+	if f.Method != "" {
+		q = q.Where("method = ?", f.Method)
+	}
+	if f.StatusCode != 0 {
+		q = q.Where("status_code = ?", f.StatusCode)
+	}
+	if f.CorrelationID != "" {
+		q = q.Where("correlation_id = ?", f.CorrelationID)
+	}
+	if f.From != nil {
+		q = q.Where("occurred_at >= ?", *f.From)
+	}
+	if f.To != nil {
+		q = q.Where("occurred_at < ?", *f.To)
+	}
+	return q
+}
+
+func (r *Repository) List(ctx context.Context, params dtos.ListParams) ([]entities.AuditLog, int64, error) {
+	q := r.filtered(ctx, params.Filter)
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// El listado no trae el body del request; se lee en el detalle
+	var rows []models.AuditLog
+	err := q.
+		Omit("request_body").
+		Order("occurred_at DESC, id DESC").
+		Offset((params.Page - 1) * params.PageSize).
+		Limit(params.PageSize).
+		Find(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return toEntities(rows), total, nil
+}
+
+func (r *Repository) Get(ctx context.Context, id uint64) (*entities.AuditLog, error) {
+	var m models.AuditLog
+	if err := r.db.Conn(ctx).Where("id = ?", id).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, dom.ErrLogNotFound
+		}
+		return nil, err
+	}
+	entry := toEntity(&m)
+	return &entry, nil
+}
+
+func (r *Repository) Stream(ctx context.Context, filter dtos.Filter, limit int, fn func([]entities.AuditLog) error) error {
+	var lastID uint64
+	remaining := limit
+	for remaining > 0 {
+		size := streamBatchSize
+		if remaining < size {
+			size = remaining
+		}
+		var rows []models.AuditLog
+		if err := r.filtered(ctx, filter).
+			Where("id > ?", lastID).
+			Order("id ASC").
+			Limit(size).
+			Find(&rows).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		if err := fn(toEntities(rows)); err != nil {
+			return err
+		}
+		lastID = rows[len(rows)-1].ID
+		remaining -= len(rows)
+		if len(rows) < size {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *Repository) LatestCheckpoint(ctx context.Context, chainID uint) (*entities.Checkpoint, error) {
+	var m models.AuditLogCheckpoint
+	err := r.db.Conn(ctx).
+		Where("chain_id = ?", chainID).
+		Order("up_to_seq DESC").
+		Limit(1).
+		Take(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entities.Checkpoint{
+		ChainID:   m.ChainID,
+		UpToSeq:   m.UpToSeq,
+		Hash:      m.Hash,
+		Purged:    m.Purged,
+		CreatedAt: m.CreatedAt,
+	}, nil
+}
+
+func (r *Repository) ChainPage(ctx context.Context, chainID uint, afterSeq uint64, limit int) ([]entities.AuditLog, error) {
+	var rows []models.AuditLog
+	if err := r.db.Conn(ctx).
+		Where("chain_id = ? AND chain_seq > ?", chainID, afterSeq).
+		Order("chain_seq ASC").
+		Limit(limit).
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return toEntities(rows), nil
+}
+
+func (r *Repository) GetRoleLevel(ctx context.Context, roleID uint) (int, error) {
+	var role models.Role
+	if err := r.db.Conn(ctx).Select("id", "level").First(&role, roleID).Error; err != nil {
+		return 0, err
+	}
+	return role.Level, nil
+}
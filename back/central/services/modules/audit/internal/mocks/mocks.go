@@ -0,0 +1,113 @@
+package mocks
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/rs/zerolog"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/audit/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+// RepositoryMock guarda las entradas en memoria. Filtra por negocio, recurso
+// y accion, que es lo que importa al caso de uso.
+type RepositoryMock struct {
+	Logs        []entities.AuditLog
+	Checkpoints []entities.Checkpoint
+	RoleLevels  map[uint]int
+	LastList    dtos.ListParams
+	LastFilter  dtos.Filter
+}
+
+func NewRepositoryMock() *RepositoryMock {
+	return &RepositoryMock{RoleLevels: map[uint]int{}}
+}
+
+func matches(f dtos.Filter, e *entities.AuditLog) bool {
+	if f.BusinessID != 0 && (e.BusinessID == nil || *e.BusinessID != f.BusinessID) {
+		return false
+	}
+	if f.Resource != "" && e.Resource != f.Resource {
+		return false
+	}
+	if f.Action != "" && e.Action != f.Action {
+		return false
+	}
+	return true
+}
+
+func (m *RepositoryMock) List(_ context.Context, params dtos.ListParams) ([]entities.AuditLog, int64, error) {
+	m.LastList = params
+	var out []entities.AuditLog
+	for i := range m.Logs {
+		if matches(params.Filter, &m.Logs[i]) {
+			out = append(out, m.Logs[i])
+		}
+	}
+	return out, int64(len(out)), nil
+}
+
+func (m *RepositoryMock) Get(_ context.Context, id uint64) (*entities.AuditLog, error) {
+	for i := range m.Logs {
+		if m.Logs[i].ID == id {
+			cp := m.Logs[i]
+			return &cp, nil
+		}
+	}
+	return nil, dom.ErrLogNotFound
+}
+
+func (m *RepositoryMock) Stream(_ context.Context, filter dtos.Filter, limit int, fn func([]entities.AuditLog) error) error {
+	m.LastFilter = filter
+	var batch []entities.AuditLog
+	for i := range m.Logs {
+		if len(batch) == limit {
+			break
+		}
+		if matches(filter, &m.Logs[i]) {
+			batch = append(batch, m.Logs[i])
+		}
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+	return fn(batch)
+}
+
+func (m *RepositoryMock) LatestCheckpoint(_ context.Context, chainID uint) (*entities.Checkpoint, error) {
+	var latest *entities.Checkpoint
+	for i := range m.Checkpoints {
+		cp := &m.Checkpoints[i]
+		if cp.ChainID == chainID && (latest == nil || cp.UpToSeq > latest.UpToSeq) {
+			latest = cp
+		}
+	}
+	return latest, nil
+}
+
+func (m *RepositoryMock) ChainPage(_ context.Context, chainID uint, afterSeq uint64, limit int) ([]entities.AuditLog, error) {
+	var out []entities.AuditLog
+	for _, e := range m.Logs {
+		if e.ChainID == chainID && e.ChainSeq > afterSeq {
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ChainSeq < out[j].ChainSeq })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *RepositoryMock) GetRoleLevel(_ context.Context, roleID uint) (int, error) {
+	level, ok := m.RoleLevels[roleID]
+	if !ok {
+		return 0, errors.New("role not found")
+	}
+	return level, nil
+}
+
+func NewSilentLogger() log.ILogger { return log.NewFromZerolog(zerolog.Nop()) }
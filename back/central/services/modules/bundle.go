@@ -10,6 +10,7 @@ import (
 	"github.com/secamc93/probability/back/central/services/modules/ai"
 	"github.com/secamc93/probability/back/central/services/modules/ai_sales"
 	"github.com/secamc93/probability/back/central/services/modules/announcements"
+	"github.com/secamc93/probability/back/central/services/modules/audit"
 	"github.com/secamc93/probability/back/central/services/modules/codreport"
 	"github.com/secamc93/probability/back/central/services/modules/commercial"
 	"github.com/secamc93/probability/back/central/services/modules/customers"
@@ -30,6 +31,7 @@ import (
 	"github.com/secamc93/probability/back/central/services/modules/probability"
 	"github.com/secamc93/probability/back/central/services/modules/products"
 	"github.com/secamc93/probability/back/central/services/modules/publicsite"
+	"github.com/secamc93/probability/back/central/services/modules/returns"
 	"github.com/secamc93/probability/back/central/services/modules/routes"
 	"github.com/secamc93/probability/back/central/services/modules/shipments"
 	"github.com/secamc93/probability/back/central/services/modules/shipping_margins"
@@ -39,6 +41,7 @@ import (
 	"github.com/secamc93/probability/back/central/services/modules/tickets"
 	"github.com/secamc93/probability/back/central/services/modules/vehicles"
 	"github.com/secamc93/probability/back/central/services/modules/warehouses"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks"
 	"github.com/secamc93/probability/back/central/services/modules/websiteconfig"
 	"github.com/secamc93/probability/back/central/services/modules/woostore"
 	"github.com/secamc93/probability/back/central/shared/bedrock"
@@ -69,7 +72,8 @@ func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, env
 	products.New(router, database, logger, environment, rabbitMQ, s3)
 	customers.New(router, database, logger, rabbitMQ)
 	pricing.New(router, database, logger)
-	shipmentsBundle := shipments.New(router, database, logger, environment, rabbitMQ, redisClient, s3)
+	payBundle := pay.New(router, database, logger, environment, rabbitMQ, redisClient, integrationCore)
+	shipmentsBundle := shipments.New(router, database, logger, environment, rabbitMQ, redisClient, s3, payBundle)
 	codreport.New(router, database, logger)
 	woostore.New(router, environment, logger)
 	shippingMarginsBundle := shipping_margins.New(router, database, logger, redisClient)
@@ -86,23 +90,25 @@ func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, env
 		})
 	}
 	notification_config.New(router, database, redisClient, logger, rabbitMQ)
+	webhooks.New(router, database, logger, environment, rabbitMQ)
+	audit.New(router, database, logger)
 	notification_backfill.New(database, rabbitMQ, logger, environment, ordersBundle.SendGuideNotificationUC, ordersBundle.RequestConfirmationUC).RegisterRoutes(router)
 	ai.New(router, logger)
 	dashboard.New(router, database, redisClient, logger)
-	payBundle := pay.New(router, database, logger, environment, rabbitMQ, redisClient, integrationCore)
 	subscriptionsBundle := subscriptions.New(router, database, logger, payBundle, announcementsBundle)
 	integrationCore.SetEcommerceLimitChecker(subscriptionsBundle.UseCase.EcommerceChannelLimit)
 	shipmentsBundle.SetSubscriptionOverageChecker(subscriptionsBundle.UseCase.CheckShipmentOverage)
-	invoicing.New(router, database, logger, environment, rabbitMQ, redisClient, subscriptions.RequireModuleAccess(subscriptionsBundle.UseCase, "invoicing"))
+	invoicingBundle := invoicing.New(router, database, logger, environment, rabbitMQ, redisClient, subscriptions.RequireModuleAccess(subscriptionsBundle.UseCase, "invoicing"))
 	warehouses.New(router, database)
 	commercial.New(router, database, logger)
-	inventory.New(router, database, logger, environment, rabbitMQ, redisClient, subscriptions.RequireModuleAccess(subscriptionsBundle.UseCase, "inventory"))
+	inventoryBundle := inventory.New(router, database, logger, environment, rabbitMQ, redisClient, subscriptions.RequireModuleAccess(subscriptionsBundle.UseCase, "inventory"))
 	drivers.New(router, database)
 	vehicles.New(router, database)
 	routes.New(router, database)
 	geozones.New(router, database, logger, redisClient, rabbitMQ)
 	storefront.New(router, database, logger, rabbitMQ, environment)
-	publicsite.New(router, database, logger, environment, payBundle, s3)
+	returnsBundle := returns.New(router, database, logger, rabbitMQ, inventoryBundle, payBundle, invoicingBundle, shipmentsBundle)
+	publicsite.New(router, database, logger, environment, payBundle, returnsBundle, s3)
 
 	marketingleads.New(router, database, logger, nil)
 	siigoreferrals.New(router, database, logger)
@@ -11,7 +11,7 @@ import (
 
 func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger) {
 	repo := repository.New(database)
-	uc := app.New(repo, logger)
+	uc := app.New(repo, db.NewTransactor(database), logger)
 	h := handlers.New(uc, logger)
 	h.RegisterRoutes(router)
 }
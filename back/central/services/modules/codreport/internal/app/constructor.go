@@ -21,15 +21,32 @@ type Iapp interface {
 	CarrierConfigs(ctx context.Context, businessID uint) ([]entities.CarrierConfig, error)
 	SaveCarrierConfig(ctx context.Context, d dtos.SaveCarrierConfigDTO) (*entities.CarrierConfig, error)
 	UpdateCarrierFee(ctx context.Context, d dtos.UpdateCarrierFeeDTO) (*dtos.UpdateCarrierFeeResult, error)
+	SettlementProfiles(ctx context.Context, businessID uint) ([]entities.SettlementProfile, error)
+	SaveSettlementProfile(ctx context.Context, d dtos.SaveSettlementProfileDTO) (*entities.SettlementProfile, error)
+	ImportSettlement(ctx context.Context, d dtos.ImportSettlementDTO) (*entities.Settlement, []entities.SettlementLine, error)
+	ListSettlements(ctx context.Context, businessID uint) ([]entities.Settlement, error)
+	SettlementLines(ctx context.Context, businessID uint, settlementID uint, status string) ([]entities.SettlementLine, error)
+	ConfirmSettlement(ctx context.Context, d dtos.ConfirmSettlementDTO) (*entities.Settlement, error)
+	DeleteSettlement(ctx context.Context, businessID uint, settlementID uint) error
 }
 
 type UseCase struct {
-	repo ports.IRepository
-	log  log.ILogger
+	repo       ports.IRepository
+	transactor ports.ITransactor
+	log        log.ILogger
 }
 
-func New(repo ports.IRepository, logger log.ILogger) Iapp {
-	return &UseCase{repo: repo, log: logger}
+func New(repo ports.IRepository, transactor ports.ITransactor, logger log.ILogger) Iapp {
+	return &UseCase{repo: repo, transactor: transactor, log: logger}
+}
+
+// inTransaction corre fn en la transaccion del transactor; sin transactor
+// (tests) corre fn directo.
+func (uc *UseCase) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if uc.transactor == nil {
+		return fn(ctx)
+	}
+	return uc.transactor.InTransaction(ctx, fn)
 }
 
 func (uc *UseCase) discountMap(_ context.Context, _ uint) map[string]float64 {
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 
 	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain"
 	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain/dtos"
 	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/shared/audit"
 )
 
 func (uc *UseCase) ListCuts(ctx context.Context, businessID uint, isAdmin bool) ([]entities.PaymentCut, error) {
@@ -50,7 +52,17 @@ func (uc *UseCase) SelectableOrders(ctx context.Context, f dtos.SelectableOrders
 }
 
 func (uc *UseCase) DeleteCut(ctx context.Context, businessID uint, cutID uint) error {
-	return uc.repo.DeleteCut(ctx, businessID, cutID)
+	return uc.inTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.DeleteCut(ctx, businessID, cutID); err != nil {
+			return err
+		}
+		return audit.Record(ctx, audit.Entry{
+			BusinessID: &businessID,
+			Resource:   "cod_cut",
+			ResourceID: strconv.FormatUint(uint64(cutID), 10),
+			Action:     "deleted",
+		})
+	})
 }
 
 func (uc *UseCase) CutOrders(ctx context.Context, businessID uint, cutID uint) ([]entities.CodOrder, error) {
@@ -127,5 +139,17 @@ func (uc *UseCase) ConfirmCut(ctx context.Context, businessID uint, cutID uint,
 	if userName == "" {
 		userName = uc.repo.UserName(ctx, userID)
 	}
-	return uc.repo.ConfirmDraftCut(ctx, businessID, cutID, userID, userName)
+	return uc.inTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.ConfirmDraftCut(ctx, businessID, cutID, userID, userName); err != nil {
+			return err
+		}
+		return audit.Record(ctx, audit.Entry{
+			BusinessID: &businessID,
+			Resource:   "cod_cut",
+			ResourceID: strconv.FormatUint(uint64(cutID), 10),
+			Action:     "confirmed",
+			Before:     map[string]any{"status": "draft"},
+			After:      map[string]any{"status": "confirmed", "confirmed_by": userName},
+		})
+	})
 }
@@ -19,6 +19,16 @@ type repoMock struct {
 	feePrevia       float64
 	feeLlamadas     int
 	feeErr          error
+
+	settlementProfile *entities.SettlementProfile
+	settlementOrders  []entities.SettlementOrder
+	pendingPayouts    []entities.CodOrder
+	settlement        *entities.Settlement
+	settlementLines   []entities.SettlementLine
+	createdLines      []entities.SettlementLine
+	applied           *dtos.ApplySettlementInput
+	appliedCuts       []uint
+	cutTotals         []entities.PaymentCut
 }
 
 func (m *repoMock) ListCodOrders(ctx context.Context, f dtos.OrdersFilter) ([]entities.CodOrder, int64, error) {
@@ -58,7 +68,8 @@ func (m *repoMock) UpsertCutOrders(_ context.Context, _ entities.PaymentCut, _ [
 func (m *repoMock) PaidAggregatesForCut(_ context.Context, _ uint) ([]entities.CarrierAggregate, error) {
 	return nil, nil
 }
-func (m *repoMock) UpdateCutTotals(_ context.Context, _ entities.PaymentCut) error {
+func (m *repoMock) UpdateCutTotals(_ context.Context, cut entities.PaymentCut) error {
+	m.cutTotals = append(m.cutTotals, cut)
 	return nil
 }
 func (m *repoMock) UserName(_ context.Context, _ uint) string { return "" }
@@ -93,6 +104,48 @@ func (m *repoMock) ShipmentCarrierFee(_ context.Context, _ uint, _ uint) (float6
 	return m.feePrevia, "VIG-0083", nil
 }
 
+func (m *repoMock) SettlementProfiles(_ context.Context, _ uint) ([]entities.SettlementProfile, error) {
+	return nil, nil
+}
+func (m *repoMock) SettlementProfile(_ context.Context, _ uint, _ string) (*entities.SettlementProfile, error) {
+	return m.settlementProfile, nil
+}
+func (m *repoMock) SaveSettlementProfile(_ context.Context, _ dtos.SaveSettlementProfileDTO) (*entities.SettlementProfile, error) {
+	return &entities.SettlementProfile{}, nil
+}
+func (m *repoMock) SettlementOrdersByGuides(_ context.Context, _ uint, _ []string) ([]entities.SettlementOrder, error) {
+	return m.settlementOrders, nil
+}
+func (m *repoMock) PendingCarrierPayouts(_ context.Context, _ uint, _ string, _, _ time.Time) ([]entities.CodOrder, error) {
+	return m.pendingPayouts, nil
+}
+func (m *repoMock) CreateSettlement(_ context.Context, s *entities.Settlement, lines []entities.SettlementLine) error {
+	s.ID = 1
+	s.Status = domain.SettlementPending
+	m.settlement = s
+	m.createdLines = lines
+	return nil
+}
+func (m *repoMock) Settlements(_ context.Context, _ uint) ([]entities.Settlement, error) {
+	return nil, nil
+}
+func (m *repoMock) Settlement(_ context.Context, _ uint, _ uint) (*entities.Settlement, error) {
+	if m.settlement == nil {
+		return nil, domain.ErrSettlementNotFound
+	}
+	return m.settlement, nil
+}
+func (m *repoMock) SettlementLines(_ context.Context, _ uint, _ uint) ([]entities.SettlementLine, error) {
+	return m.settlementLines, nil
+}
+func (m *repoMock) ApplySettlement(_ context.Context, in dtos.ApplySettlementInput) ([]uint, error) {
+	m.applied = &in
+	return m.appliedCuts, nil
+}
+func (m *repoMock) DeleteSettlement(_ context.Context, _ uint, _ uint) error {
+	return nil
+}
+
 func TestListOrders_ForwardsHasGuideFilter(t *testing.T) {
 	guide := true
 	var captured dtos.OrdersFilter
@@ -102,7 +155,7 @@ func TestListOrders_ForwardsHasGuideFilter(t *testing.T) {
 			return []entities.CodOrder{}, 0, nil
 		},
 	}
-	uc := New(repo, log.New())
+	uc := New(repo, nil, log.New())
 
 	_, _, err := uc.ListOrders(context.Background(), dtos.OrdersFilter{BusinessID: 10, HasGuide: &guide})
 	if err != nil {
@@ -122,7 +175,7 @@ func TestListOrders_PreservesHasGuide(t *testing.T) {
 			}, 2, nil
 		},
 	}
-	uc := New(repo, log.New())
+	uc := New(repo, nil, log.New())
 
 	orders, total, err := uc.ListOrders(context.Background(), dtos.OrdersFilter{BusinessID: 10})
 	if err != nil {
@@ -152,7 +205,7 @@ func TestListOrders_SoloPagadaCuentaComoRecaudada(t *testing.T) {
 			}, 6, nil
 		},
 	}
-	uc := New(repo, log.New())
+	uc := New(repo, nil, log.New())
 
 	orders, _, err := uc.ListOrders(context.Background(), dtos.OrdersFilter{BusinessID: 10})
 	if err != nil {
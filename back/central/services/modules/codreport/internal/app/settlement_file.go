@@ -0,0 +1,77 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// readSettlementRows devuelve las celdas de la liquidacion como texto. En XLSX
+// se leen los valores crudos (numeros con punto decimal, fechas como serial)
+// para no depender del formato de celda que use cada transportadora.
+func readSettlementRows(filename string, content []byte, delimiter string) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv", ".txt":
+		return readSettlementCSV(content, delimiter)
+	case ".xlsx", ".xlsm":
+		return readSettlementXLSX(content)
+	default:
+		return nil, errors.New("formato no soportado: usa CSV o XLSX")
+	}
+}
+
+func readSettlementCSV(content []byte, delimiter string) ([][]string, error) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	comma := ','
+	switch {
+	case delimiter == `\t` || delimiter == "tab":
+		comma = '\t'
+	case delimiter != "":
+		comma = []rune(delimiter)[0]
+	default:
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		if scanner.Scan() {
+			line := scanner.Text()
+			if strings.Count(line, ";") > strings.Count(line, ",") {
+				comma = ';'
+			} else if strings.Count(line, "\t") > strings.Count(line, ",") {
+				comma = '\t'
+			}
+		}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = comma
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("error de formato CSV: %w", err)
+	}
+	return rows, nil
+}
+
+func readSettlementXLSX(content []byte) ([][]string, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo abrir el archivo Excel: %w", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("el archivo Excel no tiene hojas")
+	}
+	rows, err := f.GetRows(sheets[0], excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, fmt.Errorf("no se pudo leer la hoja %s: %w", sheets[0], err)
+	}
+	return rows, nil
+}
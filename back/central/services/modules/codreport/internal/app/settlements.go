@@ -0,0 +1,255 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain"
+	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain/entities"
+)
+
+func (uc *UseCase) SettlementProfiles(ctx context.Context, businessID uint) ([]entities.SettlementProfile, error) {
+	return uc.repo.SettlementProfiles(ctx, businessID)
+}
+
+func (uc *UseCase) SaveSettlementProfile(ctx context.Context, d dtos.SaveSettlementProfileDTO) (*entities.SettlementProfile, error) {
+	d.CarrierName = strings.ToUpper(strings.TrimSpace(d.CarrierName))
+	d.GuideColumn = strings.TrimSpace(d.GuideColumn)
+	d.AmountColumn = strings.TrimSpace(d.AmountColumn)
+	if d.CarrierName == "" {
+		return nil, errors.New("la transportadora es requerida")
+	}
+	if d.GuideColumn == "" || d.AmountColumn == "" {
+		return nil, errors.New("las columnas de guia y valor son requeridas")
+	}
+	if d.AmountBasis == "" {
+		d.AmountBasis = domain.AmountBasisGross
+	}
+	if d.AmountBasis != domain.AmountBasisGross && d.AmountBasis != domain.AmountBasisNet {
+		return nil, fmt.Errorf("base de valor invalida: %s (gross o net)", d.AmountBasis)
+	}
+	if d.HeaderRow < 1 {
+		d.HeaderRow = 1
+	}
+	if d.DecimalSeparator == "" {
+		d.DecimalSeparator = ","
+	}
+	if d.DecimalSeparator != "," && d.DecimalSeparator != "." {
+		return nil, errors.New("el separador decimal debe ser coma o punto")
+	}
+	if d.Tolerance < 0 {
+		return nil, errors.New("la tolerancia no puede ser negativa")
+	}
+	return uc.repo.SaveSettlementProfile(ctx, d)
+}
+
+// ImportSettlement lee la liquidacion con el perfil de la transportadora y la
+// cruza por guia contra las ordenes COD. El periodo, si no se indica, sale de
+// las fechas de pago del archivo; sin periodo no hay contra que buscar las
+// ordenes que faltan en la liquidacion.
+func (uc *UseCase) ImportSettlement(ctx context.Context, d dtos.ImportSettlementDTO) (*entities.Settlement, []entities.SettlementLine, error) {
+	carrier := strings.ToUpper(strings.TrimSpace(d.CarrierName))
+	if carrier == "" {
+		return nil, nil, errors.New("la transportadora es requerida")
+	}
+	profile, err := uc.repo.SettlementProfile(ctx, d.BusinessID, carrier)
+	if err != nil {
+		return nil, nil, err
+	}
+	if profile == nil {
+		return nil, nil, fmt.Errorf("no hay perfil de liquidacion configurado para %s", carrier)
+	}
+
+	cells, err := readSettlementRows(d.FileName, d.Content, profile.Delimiter)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := domain.ParseStatement(cells, *profile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	start, end := d.PeriodStart, d.PeriodEnd
+	if start.IsZero() || end.IsZero() {
+		s, e, ok := domain.StatementPeriod(rows)
+		if !ok {
+			return nil, nil, errors.New("el archivo no trae fechas de pago: indica el periodo de la liquidacion")
+		}
+		start, end = s, e
+	}
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	end = time.Date(end.Year(), end.Month(), end.Day(), 23, 59, 59, 0, time.UTC)
+
+	guides := make([]string, len(rows))
+	for i := range rows {
+		guides[i] = rows[i].GuideNumber
+	}
+	orders, err := uc.repo.SettlementOrdersByGuides(ctx, d.BusinessID, guides)
+	if err != nil {
+		return nil, nil, err
+	}
+	pending, err := uc.repo.PendingCarrierPayouts(ctx, d.BusinessID, carrier, start, end)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dm := uc.discountMap(ctx, d.BusinessID)
+	for i := range orders {
+		_, orders[i].Net = domain.ApplyDiscount(orders[i].CodTotal, dm[orders[i].Carrier])
+	}
+	for i := range pending {
+		_, pending[i].Net = domain.ApplyDiscount(pending[i].CodTotal, dm[pending[i].Carrier])
+	}
+
+	lines := domain.MatchStatement(rows, orders, pending, *profile)
+	settlement := &entities.Settlement{
+		BusinessID:  d.BusinessID,
+		CarrierName: carrier,
+		FileName:    d.FileName,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		UploadedBy:  d.UserID,
+	}
+	domain.SummarizeSettlement(settlement, lines)
+
+	if err := uc.repo.CreateSettlement(ctx, settlement, lines); err != nil {
+		return nil, nil, err
+	}
+
+	uc.log.Info(ctx).
+		Uint("business_id", d.BusinessID).
+		Uint("settlement_id", settlement.ID).
+		Str("carrier", carrier).
+		Int("matched", settlement.MatchedCount).
+		Int("amount_mismatch", settlement.MismatchCount).
+		Int("missing", settlement.MissingCount).
+		Int("unknown_guide", settlement.UnknownCount).
+		Msg("Liquidacion COD importada")
+
+	return settlement, lines, nil
+}
+
+func (uc *UseCase) ListSettlements(ctx context.Context, businessID uint) ([]entities.Settlement, error) {
+	return uc.repo.Settlements(ctx, businessID)
+}
+
+func (uc *UseCase) SettlementLines(ctx context.Context, businessID uint, settlementID uint, status string) ([]entities.SettlementLine, error) {
+	lines, err := uc.repo.SettlementLines(ctx, businessID, settlementID)
+	if err != nil {
+		return nil, err
+	}
+	if status == "" {
+		return lines, nil
+	}
+	filtered := []entities.SettlementLine{}
+	for i := range lines {
+		if lines[i].Status == status {
+			filtered = append(filtered, lines[i])
+		}
+	}
+	return filtered, nil
+}
+
+// ConfirmSettlement paga las lineas confirmadas. Sin lineas explicitas se
+// confirman las que cuadran; las de diferencia de valor solo entran si se
+// eligen una por una. Los cortes que quedan confirmados los toma la
+// sincronizacion contable como COD_PAYOUT.
+func (uc *UseCase) ConfirmSettlement(ctx context.Context, d dtos.ConfirmSettlementDTO) (*entities.Settlement, error) {
+	settlement, err := uc.repo.Settlement(ctx, d.BusinessID, d.SettlementID)
+	if err != nil {
+		return nil, err
+	}
+	if settlement.Status != domain.SettlementPending {
+		return nil, domain.ErrSettlementClosed
+	}
+
+	lines, err := uc.repo.SettlementLines(ctx, d.BusinessID, d.SettlementID)
+	if err != nil {
+		return nil, err
+	}
+
+	requested := make(map[uint]bool, len(d.LineIDs))
+	for _, id := range d.LineIDs {
+		requested[id] = true
+	}
+	var lineIDs []uint
+	var orderIDs []string
+	for i := range lines {
+		l := lines[i]
+		if !domain.ConfirmableLine(l) {
+			continue
+		}
+		if len(requested) > 0 && !requested[l.ID] {
+			continue
+		}
+		if len(requested) == 0 && l.Status != domain.LineMatched {
+			continue
+		}
+		lineIDs = append(lineIDs, l.ID)
+		orderIDs = append(orderIDs, l.OrderID)
+	}
+	if len(lineIDs) == 0 {
+		return nil, domain.ErrSettlementNoLines
+	}
+
+	userName := d.UserName
+	if userName == "" {
+		userName = uc.repo.UserName(ctx, d.UserID)
+	}
+
+	cutIDs, err := uc.repo.ApplySettlement(ctx, dtos.ApplySettlementInput{
+		BusinessID:   d.BusinessID,
+		SettlementID: d.SettlementID,
+		CarrierName:  settlement.CarrierName,
+		PeriodStart:  time.Date(settlement.PeriodStart.Year(), settlement.PeriodStart.Month(), settlement.PeriodStart.Day(), 0, 0, 0, 0, time.UTC),
+		PeriodEnd:    time.Date(settlement.PeriodEnd.Year(), settlement.PeriodEnd.Month(), settlement.PeriodEnd.Day(), 0, 0, 0, 0, time.UTC),
+		LineIDs:      lineIDs,
+		OrderIDs:     orderIDs,
+		UserID:       d.UserID,
+		UserName:     userName,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dm := uc.discountMap(ctx, d.BusinessID)
+	for _, cutID := range cutIDs {
+		if err := uc.refreshCutTotals(ctx, cutID, dm); err != nil {
+			uc.log.Error(ctx).Err(err).Uint("cut_id", cutID).Msg("No se pudieron recalcular los totales del corte")
+		}
+	}
+
+	uc.log.Info(ctx).
+		Uint("business_id", d.BusinessID).
+		Uint("settlement_id", d.SettlementID).
+		Int("lines", len(lineIDs)).
+		Uint("user_id", d.UserID).
+		Msg("Liquidacion COD confirmada")
+
+	return uc.repo.Settlement(ctx, d.BusinessID, d.SettlementID)
+}
+
+func (uc *UseCase) DeleteSettlement(ctx context.Context, businessID uint, settlementID uint) error {
+	return uc.repo.DeleteSettlement(ctx, businessID, settlementID)
+}
+
+func (uc *UseCase) refreshCutTotals(ctx context.Context, cutID uint, dm map[string]float64) error {
+	aggs, err := uc.repo.PaidAggregatesForCut(ctx, cutID)
+	if err != nil {
+		return err
+	}
+	domain.EnrichCarrierAggregates(aggs, dm)
+	oc, tc, td, tn := domain.SumAggregates(aggs)
+	return uc.repo.UpdateCutTotals(ctx, entities.PaymentCut{
+		ID:             cutID,
+		OrdersCount:    oc,
+		TotalCollected: tc,
+		TotalDiscount:  td,
+		TotalNet:       tn,
+		ByCarrier:      aggs,
+	})
+}
@@ -0,0 +1,127 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain"
+	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+func TestImportSettlement_CSVConPerfil(t *testing.T) {
+	repo := &repoMock{
+		settlementProfile: &entities.SettlementProfile{
+			CarrierName:      "INTERRAPIDISIMO",
+			GuideColumn:      "Guia",
+			AmountColumn:     "Valor",
+			DateColumn:       "Fecha",
+			AmountBasis:      domain.AmountBasisGross,
+			DecimalSeparator: ",",
+			HeaderRow:        1,
+		},
+		settlementOrders: []entities.SettlementOrder{
+			{CodOrder: entities.CodOrder{OrderID: "o1", CodTotal: 120000, Carrier: "INTERRAPIDISIMO"}, Guides: []string{"240001"}},
+		},
+		pendingPayouts: []entities.CodOrder{
+			{OrderID: "o1", CodTotal: 120000},
+			{OrderID: "o2", CodTotal: 60000, GuideNumber: "240002"},
+		},
+	}
+	uc := New(repo, nil, log.New())
+
+	settlement, lines, err := uc.ImportSettlement(context.Background(), dtos.ImportSettlementDTO{
+		BusinessID:  10,
+		CarrierName: "interrapidisimo",
+		FileName:    "liquidacion.csv",
+		Content:     []byte("Guia;Valor;Fecha\n240001;120.000;2026-10-14\n777;9.000;2026-10-15\n"),
+	})
+	if err != nil {
+		t.Fatalf("se esperaba nil error, se obtuvo: %v", err)
+	}
+	if len(lines) != 3 || len(repo.createdLines) != 3 {
+		t.Fatalf("lineas: esperado 3 (matched, unknown y missing), obtenido %d", len(lines))
+	}
+	if settlement.MatchedCount != 1 || settlement.UnknownCount != 1 || settlement.MissingCount != 1 {
+		t.Errorf("conteos: obtenido %+v", settlement)
+	}
+	if settlement.CarrierName != "INTERRAPIDISIMO" {
+		t.Errorf("CarrierName: esperado en mayusculas, obtenido %s", settlement.CarrierName)
+	}
+}
+
+func TestImportSettlement_SinPerfil(t *testing.T) {
+	uc := New(&repoMock{}, nil, log.New())
+
+	_, _, err := uc.ImportSettlement(context.Background(), dtos.ImportSettlementDTO{
+		BusinessID:  10,
+		CarrierName: "COORDINADORA",
+		FileName:    "x.csv",
+		Content:     []byte("guia,valor\n1,100\n"),
+	})
+	if err == nil {
+		t.Fatal("se esperaba error: la transportadora no tiene perfil de liquidacion")
+	}
+}
+
+func TestConfirmSettlement_PorDefectoSoloLasQueCuadran(t *testing.T) {
+	repo := &repoMock{
+		settlement: &entities.Settlement{ID: 5, CarrierName: "SERVIENTREGA", Status: domain.SettlementPending},
+		settlementLines: []entities.SettlementLine{
+			{ID: 1, OrderID: "o1", Status: domain.LineMatched},
+			{ID: 2, OrderID: "o2", Status: domain.LineAmountMismatch},
+			{ID: 3, OrderID: "o3", Status: domain.LineMatched, AlreadyPaid: true},
+			{ID: 4, OrderID: "o4", Status: domain.LineMissing},
+			{ID: 5, Status: domain.LineUnknownGuide},
+		},
+		appliedCuts: []uint{7, 8},
+	}
+	uc := New(repo, nil, log.New())
+
+	if _, err := uc.ConfirmSettlement(context.Background(), dtos.ConfirmSettlementDTO{BusinessID: 10, SettlementID: 5}); err != nil {
+		t.Fatalf("se esperaba nil error, se obtuvo: %v", err)
+	}
+	if repo.applied == nil || len(repo.applied.LineIDs) != 1 || repo.applied.OrderIDs[0] != "o1" {
+		t.Fatalf("lineas aplicadas: esperado solo la linea 1, obtenido %+v", repo.applied)
+	}
+	if repo.applied.CarrierName != "SERVIENTREGA" {
+		t.Errorf("CarrierName: esperado SERVIENTREGA, obtenido %s", repo.applied.CarrierName)
+	}
+	if len(repo.cutTotals) != 2 || repo.cutTotals[0].ID != 7 || repo.cutTotals[1].ID != 8 {
+		t.Errorf("se esperaban recalcular los totales de los cortes 7 y 8, obtenido %+v", repo.cutTotals)
+	}
+}
+
+func TestConfirmSettlement_LineasElegidasIncluyenDiferencias(t *testing.T) {
+	repo := &repoMock{
+		settlement: &entities.Settlement{ID: 5, Status: domain.SettlementPending},
+		settlementLines: []entities.SettlementLine{
+			{ID: 1, OrderID: "o1", Status: domain.LineMatched},
+			{ID: 2, OrderID: "o2", Status: domain.LineAmountMismatch},
+			{ID: 4, OrderID: "o4", Status: domain.LineMissing},
+		},
+	}
+	uc := New(repo, nil, log.New())
+
+	if _, err := uc.ConfirmSettlement(context.Background(), dtos.ConfirmSettlementDTO{BusinessID: 10, SettlementID: 5, LineIDs: []uint{2, 4}}); err != nil {
+		t.Fatalf("se esperaba nil error, se obtuvo: %v", err)
+	}
+	if len(repo.applied.LineIDs) != 1 || repo.applied.LineIDs[0] != 2 {
+		t.Errorf("esperado solo la linea 2 (la faltante no se puede pagar), obtenido %v", repo.applied.LineIDs)
+	}
+}
+
+func TestConfirmSettlement_YaConfirmada(t *testing.T) {
+	repo := &repoMock{settlement: &entities.Settlement{ID: 5, Status: domain.SettlementConfirmed}}
+	uc := New(repo, nil, log.New())
+
+	_, err := uc.ConfirmSettlement(context.Background(), dtos.ConfirmSettlementDTO{BusinessID: 10, SettlementID: 5})
+	if !errors.Is(err, domain.ErrSettlementClosed) {
+		t.Fatalf("esperado ErrSettlementClosed, obtenido %v", err)
+	}
+	if repo.applied != nil {
+		t.Error("no se debe aplicar una liquidacion ya confirmada")
+	}
+}
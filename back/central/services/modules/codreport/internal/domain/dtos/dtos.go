@@ -59,3 +59,49 @@ type UpdateCarrierFeeResult struct {
 	PreviousFee float64
 	NewFee      float64
 }
+
+type SaveSettlementProfileDTO struct {
+	BusinessID       uint
+	CarrierName      string
+	GuideColumn      string
+	AmountColumn     string
+	AmountBasis      string
+	FeeColumn        string
+	DateColumn       string
+	ReferenceColumn  string
+	HeaderRow        int
+	Delimiter        string
+	DecimalSeparator string
+	DateFormat       string
+	Tolerance        float64
+}
+
+type ImportSettlementDTO struct {
+	BusinessID  uint
+	CarrierName string
+	FileName    string
+	Content     []byte
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	UserID      uint
+}
+
+type ConfirmSettlementDTO struct {
+	BusinessID   uint
+	SettlementID uint
+	LineIDs      []uint
+	UserID       uint
+	UserName     string
+}
+
+type ApplySettlementInput struct {
+	BusinessID   uint
+	SettlementID uint
+	CarrierName  string
+	PeriodStart  time.Time
+	PeriodEnd    time.Time
+	LineIDs      []uint
+	OrderIDs     []string
+	UserID       uint
+	UserName     string
+}
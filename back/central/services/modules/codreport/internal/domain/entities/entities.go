@@ -118,3 +118,77 @@ type PayoutOrder struct {
 	Carrier   string
 	CodAmount float64
 }
+
+type SettlementProfile struct {
+	ID               uint
+	BusinessID       uint
+	CarrierName      string
+	GuideColumn      string
+	AmountColumn     string
+	AmountBasis      string
+	FeeColumn        string
+	DateColumn       string
+	ReferenceColumn  string
+	HeaderRow        int
+	Delimiter        string
+	DecimalSeparator string
+	DateFormat       string
+	Tolerance        float64
+}
+
+type StatementRow struct {
+	RowNumber   int
+	GuideNumber string
+	Amount      float64
+	Fee         float64
+	PaidDate    *time.Time
+	Reference   string
+	Rows        int
+}
+
+type SettlementOrder struct {
+	CodOrder
+	Guides []string
+}
+
+type Settlement struct {
+	ID              uint
+	BusinessID      uint
+	CarrierName     string
+	FileName        string
+	PeriodStart     time.Time
+	PeriodEnd       time.Time
+	Status          string
+	LinesCount      int
+	MatchedCount    int
+	MismatchCount   int
+	MissingCount    int
+	UnknownCount    int
+	StatementTotal  float64
+	ExpectedTotal   float64
+	CutID           *uint
+	UploadedBy      uint
+	ConfirmedBy     uint
+	ConfirmedByName string
+	ConfirmedAt     *time.Time
+	CreatedAt       time.Time
+}
+
+type SettlementLine struct {
+	ID              uint
+	SettlementID    uint
+	RowNumber       int
+	GuideNumber     string
+	OrderID         string
+	OrderNumber     string
+	Status          string
+	StatementAmount float64
+	ExpectedAmount  float64
+	Difference      float64
+	CarrierFee      float64
+	PaidDate        *time.Time
+	Reference       string
+	Note            string
+	AlreadyPaid     bool
+	Confirmed       bool
+}
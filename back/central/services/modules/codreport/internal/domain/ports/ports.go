@@ -31,4 +31,21 @@ type IRepository interface {
 	DeleteCut(ctx context.Context, businessID uint, cutID uint) error
 	UpdateShipmentCarrierFee(ctx context.Context, businessID uint, shipmentID uint, fee float64) error
 	ShipmentCarrierFee(ctx context.Context, businessID uint, shipmentID uint) (float64, string, error)
+	SettlementProfiles(ctx context.Context, businessID uint) ([]entities.SettlementProfile, error)
+	SettlementProfile(ctx context.Context, businessID uint, carrier string) (*entities.SettlementProfile, error)
+	SaveSettlementProfile(ctx context.Context, d dtos.SaveSettlementProfileDTO) (*entities.SettlementProfile, error)
+	SettlementOrdersByGuides(ctx context.Context, businessID uint, guides []string) ([]entities.SettlementOrder, error)
+	PendingCarrierPayouts(ctx context.Context, businessID uint, carrier string, start, end time.Time) ([]entities.CodOrder, error)
+	CreateSettlement(ctx context.Context, s *entities.Settlement, lines []entities.SettlementLine) error
+	Settlements(ctx context.Context, businessID uint) ([]entities.Settlement, error)
+	Settlement(ctx context.Context, businessID uint, settlementID uint) (*entities.Settlement, error)
+	SettlementLines(ctx context.Context, businessID uint, settlementID uint) ([]entities.SettlementLine, error)
+	ApplySettlement(ctx context.Context, in dtos.ApplySettlementInput) ([]uint, error)
+	DeleteSettlement(ctx context.Context, businessID uint, settlementID uint) error
+}
+
+// ITransactor abre una transaccion: lo que se escriba con el ctx que recibe fn
+// (el corte y su entrada de auditoria) se confirma o revierte junto.
+type ITransactor interface {
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
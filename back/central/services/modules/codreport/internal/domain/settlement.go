@@ -0,0 +1,399 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain/entities"
+)
+
+const (
+	SettlementPending   = "pending"
+	SettlementConfirmed = "confirmed"
+
+	LineMatched        = "matched"
+	LineAmountMismatch = "amount_mismatch"
+	LineMissing        = "missing_from_statement"
+	LineUnknownGuide   = "unknown_guide"
+
+	AmountBasisGross = "gross"
+	AmountBasisNet   = "net"
+)
+
+var (
+	ErrSettlementNoRows   = errors.New("el archivo no trae guias para conciliar")
+	ErrSettlementNoLines  = errors.New("no hay lineas para confirmar en la liquidacion")
+	ErrSettlementNotFound = errors.New("liquidacion no encontrada")
+	ErrSettlementClosed   = errors.New("la liquidacion ya fue confirmada")
+)
+
+var plainNumber = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+var headerReplacer = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+	"_", " ", ".", " ", ":", " ",
+)
+
+// NormalizeGuide deja la guia en mayusculas y sin espacios. Excel suele
+// anteponer un apostrofe a las guias numericas para no perder los ceros.
+func NormalizeGuide(v string) string {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "'")
+	return strings.ToUpper(strings.Join(strings.Fields(v), ""))
+}
+
+func NormalizeHeader(v string) string {
+	v = headerReplacer.Replace(strings.ToLower(strings.TrimSpace(v)))
+	return strings.Join(strings.Fields(v), " ")
+}
+
+// ParseAmount lee un valor con el separador decimal del perfil. Los numeros
+// crudos de XLSX ("150000.5") se leen tal cual aunque el perfil use coma: un
+// punto seguido de exactamente tres digitos si se toma como separador de miles.
+func ParseAmount(v, decimalSep string) (float64, error) {
+	s := strings.TrimSpace(v)
+	if s == "" {
+		return 0, nil
+	}
+	negative := strings.HasPrefix(s, "-") || (strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")"))
+	s = strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '.' || r == ',' {
+			return r
+		}
+		return -1
+	}, s)
+	if s == "" {
+		return 0, fmt.Errorf("valor %q invalido", v)
+	}
+
+	if plainNumber.MatchString(s) {
+		dot := strings.LastIndex(s, ".")
+		thousandsLike := decimalSep != "." && dot >= 0 && len(s)-dot-1 == 3
+		if !thousandsLike {
+			f, err := strconv.ParseFloat(s, 64)
+			if negative {
+				f = -f
+			}
+			return f, err
+		}
+	}
+
+	thousands := "."
+	if decimalSep == "." {
+		thousands = ","
+	}
+	s = strings.ReplaceAll(s, thousands, "")
+	if decimalSep != "." {
+		s = strings.ReplaceAll(s, ",", ".")
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("valor %q invalido", v)
+	}
+	if negative {
+		f = -f
+	}
+	return f, nil
+}
+
+var statementDateLayouts = []string{
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"02/01/2006",
+	"2/1/2006",
+	"02/01/2006 15:04",
+	"02/01/2006 15:04:05",
+	"02-01-2006",
+	"2006/01/02",
+	time.RFC3339,
+}
+
+// ParseStatementDate prueba el formato del perfil y luego los comunes. Un
+// numero es el serial de fecha de Excel (dias desde 1899-12-30).
+func ParseStatementDate(v, layout string) *time.Time {
+	s := strings.TrimSpace(v)
+	if s == "" {
+		return nil
+	}
+	if serial, err := strconv.ParseFloat(s, 64); err == nil && serial > 20000 && serial < 80000 {
+		t := time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC).AddDate(0, 0, int(serial))
+		return &t
+	}
+	layouts := statementDateLayouts
+	if layout != "" {
+		layouts = append([]string{layout}, statementDateLayouts...)
+	}
+	for _, l := range layouts {
+		if t, err := time.Parse(l, s); err == nil {
+			t = t.UTC()
+			return &t
+		}
+	}
+	return nil
+}
+
+// ParseStatement lee las filas del archivo con el perfil de la transportadora.
+// Las filas sin guia (subtotales, notas al pie) se saltan y las guias repetidas
+// se suman en una sola.
+func ParseStatement(rows [][]string, p entities.SettlementProfile) ([]entities.StatementRow, error) {
+	headerIdx := p.HeaderRow - 1
+	if headerIdx < 0 {
+		headerIdx = 0
+	}
+	if len(rows) <= headerIdx {
+		return nil, fmt.Errorf("el archivo no tiene la fila de encabezados %d", headerIdx+1)
+	}
+
+	cols := make(map[string]int, len(rows[headerIdx]))
+	for i, h := range rows[headerIdx] {
+		key := NormalizeHeader(h)
+		if _, dup := cols[key]; !dup && key != "" {
+			cols[key] = i
+		}
+	}
+	column := func(name string, required bool) (int, error) {
+		if strings.TrimSpace(name) == "" {
+			if required {
+				return -1, errors.New("el perfil no tiene configuradas las columnas de guia y valor")
+			}
+			return -1, nil
+		}
+		idx, ok := cols[NormalizeHeader(name)]
+		if !ok {
+			return -1, fmt.Errorf("la columna %q no esta en el archivo", name)
+		}
+		return idx, nil
+	}
+
+	guideCol, err := column(p.GuideColumn, true)
+	if err != nil {
+		return nil, err
+	}
+	amountCol, err := column(p.AmountColumn, true)
+	if err != nil {
+		return nil, err
+	}
+	feeCol, err := column(p.FeeColumn, false)
+	if err != nil {
+		return nil, err
+	}
+	dateCol, err := column(p.DateColumn, false)
+	if err != nil {
+		return nil, err
+	}
+	refCol, err := column(p.ReferenceColumn, false)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []entities.StatementRow{}
+	index := map[string]int{}
+	for i := headerIdx + 1; i < len(rows); i++ {
+		record := rows[i]
+		cell := func(idx int) string {
+			if idx < 0 || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+		rowNumber := i + 1
+
+		guide := NormalizeGuide(cell(guideCol))
+		if guide == "" {
+			continue
+		}
+		amount, err := ParseAmount(cell(amountCol), p.DecimalSeparator)
+		if err != nil {
+			return nil, fmt.Errorf("fila %d: %w", rowNumber, err)
+		}
+		fee, err := ParseAmount(cell(feeCol), p.DecimalSeparator)
+		if err != nil {
+			return nil, fmt.Errorf("fila %d: %w", rowNumber, err)
+		}
+		paidDate := ParseStatementDate(cell(dateCol), p.DateFormat)
+		reference := cell(refCol)
+
+		if j, ok := index[guide]; ok {
+			out[j].Amount += amount
+			out[j].Fee += fee
+			out[j].Rows++
+			if paidDate != nil && (out[j].PaidDate == nil || paidDate.After(*out[j].PaidDate)) {
+				out[j].PaidDate = paidDate
+			}
+			if out[j].Reference == "" {
+				out[j].Reference = reference
+			}
+			continue
+		}
+		index[guide] = len(out)
+		out = append(out, entities.StatementRow{
+			RowNumber:   rowNumber,
+			GuideNumber: guide,
+			Amount:      amount,
+			Fee:         fee,
+			PaidDate:    paidDate,
+			Reference:   reference,
+			Rows:        1,
+		})
+	}
+	if len(out) == 0 {
+		return nil, ErrSettlementNoRows
+	}
+	return out, nil
+}
+
+// StatementPeriod primera y ultima fecha de pago del archivo
+func StatementPeriod(rows []entities.StatementRow) (start, end time.Time, ok bool) {
+	for _, r := range rows {
+		if r.PaidDate == nil {
+			continue
+		}
+		if !ok || r.PaidDate.Before(start) {
+			start = *r.PaidDate
+		}
+		if !ok || r.PaidDate.After(end) {
+			end = *r.PaidDate
+		}
+		ok = true
+	}
+	return start, end, ok
+}
+
+// ExpectedPayout lo que la transportadora deberia reportar por la orden: el
+// recaudo bruto, o el neto menos su comision si la liquidacion viene neta
+func ExpectedPayout(o entities.CodOrder, basis string) float64 {
+	if basis == AmountBasisNet {
+		return round2(o.Net - o.CodCarrierFee)
+	}
+	return round2(o.CodTotal)
+}
+
+// MatchStatement cruza la liquidacion contra las ordenes encontradas por guia.
+// Las ordenes pendientes de pago del periodo que no vinieron en el archivo
+// quedan como missing_from_statement.
+func MatchStatement(rows []entities.StatementRow, orders []entities.SettlementOrder, pending []entities.CodOrder, p entities.SettlementProfile) []entities.SettlementLine {
+	byGuide := map[string]*entities.SettlementOrder{}
+	for i := range orders {
+		for _, g := range orders[i].Guides {
+			key := NormalizeGuide(g)
+			if _, dup := byGuide[key]; key != "" && !dup {
+				byGuide[key] = &orders[i]
+			}
+		}
+	}
+
+	lines := make([]entities.SettlementLine, 0, len(rows)+len(pending))
+	lineByOrder := map[string]int{}
+	for _, row := range rows {
+		o, ok := byGuide[row.GuideNumber]
+		if !ok {
+			lines = append(lines, entities.SettlementLine{
+				RowNumber:       row.RowNumber,
+				GuideNumber:     row.GuideNumber,
+				Status:          LineUnknownGuide,
+				StatementAmount: round2(row.Amount),
+				Difference:      round2(row.Amount),
+				CarrierFee:      round2(row.Fee),
+				PaidDate:        row.PaidDate,
+				Reference:       row.Reference,
+			})
+			continue
+		}
+
+		// Una orden con varias guias (multi-bulto) puede venir en varias filas
+		if j, dup := lineByOrder[o.OrderID]; dup {
+			lines[j].StatementAmount = round2(lines[j].StatementAmount + row.Amount)
+			lines[j].CarrierFee = round2(lines[j].CarrierFee + row.Fee)
+			if lines[j].Note == "" {
+				lines[j].Note = "la orden viene en varias guias de la liquidacion"
+			}
+			continue
+		}
+
+		line := entities.SettlementLine{
+			RowNumber:       row.RowNumber,
+			GuideNumber:     row.GuideNumber,
+			OrderID:         o.OrderID,
+			OrderNumber:     o.OrderNumber,
+			StatementAmount: round2(row.Amount),
+			ExpectedAmount:  ExpectedPayout(o.CodOrder, p.AmountBasis),
+			CarrierFee:      round2(row.Fee),
+			PaidDate:        row.PaidDate,
+			Reference:       row.Reference,
+		}
+		switch {
+		case o.Paid:
+			line.AlreadyPaid = true
+			line.Note = "la orden ya esta en un corte confirmado"
+		case p.CarrierName != "" && o.Carrier != "" && o.Carrier != p.CarrierName:
+			line.Note = fmt.Sprintf("la guia es de %s", o.Carrier)
+		}
+		lineByOrder[o.OrderID] = len(lines)
+		lines = append(lines, line)
+	}
+
+	for _, j := range lineByOrder {
+		lines[j].Difference = round2(lines[j].StatementAmount - lines[j].ExpectedAmount)
+		if math.Abs(lines[j].Difference) <= p.Tolerance+0.005 {
+			lines[j].Status = LineMatched
+		} else {
+			lines[j].Status = LineAmountMismatch
+		}
+	}
+
+	for _, o := range pending {
+		if _, ok := lineByOrder[o.OrderID]; ok {
+			continue
+		}
+		expected := ExpectedPayout(o, p.AmountBasis)
+		lines = append(lines, entities.SettlementLine{
+			GuideNumber:    o.GuideNumber,
+			OrderID:        o.OrderID,
+			OrderNumber:    o.OrderNumber,
+			Status:         LineMissing,
+			ExpectedAmount: expected,
+			Difference:     -expected,
+		})
+	}
+	return lines
+}
+
+// SummarizeSettlement conteos por estado y totales de la liquidacion
+func SummarizeSettlement(s *entities.Settlement, lines []entities.SettlementLine) {
+	s.LinesCount = len(lines)
+	s.MatchedCount, s.MismatchCount, s.MissingCount, s.UnknownCount = 0, 0, 0, 0
+	s.StatementTotal, s.ExpectedTotal = 0, 0
+	for _, l := range lines {
+		switch l.Status {
+		case LineMatched:
+			s.MatchedCount++
+		case LineAmountMismatch:
+			s.MismatchCount++
+		case LineMissing:
+			s.MissingCount++
+		case LineUnknownGuide:
+			s.UnknownCount++
+		}
+		s.StatementTotal += l.StatementAmount
+		s.ExpectedTotal += l.ExpectedAmount
+	}
+	s.StatementTotal = round2(s.StatementTotal)
+	s.ExpectedTotal = round2(s.ExpectedTotal)
+}
+
+// ConfirmableLine solo se pagan guias cruzadas con una orden que aun no esta
+// en un corte confirmado
+func ConfirmableLine(l entities.SettlementLine) bool {
+	if l.Confirmed || l.AlreadyPaid || l.OrderID == "" {
+		return false
+	}
+	return l.Status == LineMatched || l.Status == LineAmountMismatch
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
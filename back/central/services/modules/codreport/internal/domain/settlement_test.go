@@ -0,0 +1,155 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain"
+	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain/entities"
+)
+
+func TestParseAmount(t *testing.T) {
+	cases := []struct {
+		raw  string
+		sep  string
+		want float64
+	}{
+		{"150000", ",", 150000},
+		{"150.000", ",", 150000},
+		{"1.500.000", ",", 1500000},
+		{"$ 150.000,50", ",", 150000.5},
+		{"150000.5", ",", 150000.5},
+		{"(12.300)", ",", -12300},
+		{"150,000.50", ".", 150000.5},
+		{"150.000", ".", 150},
+		{"", ",", 0},
+	}
+	for _, tc := range cases {
+		got, err := domain.ParseAmount(tc.raw, tc.sep)
+		if err != nil {
+			t.Errorf("ParseAmount(%q, %q): error inesperado %v", tc.raw, tc.sep, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("ParseAmount(%q, %q): esperado %v, obtenido %v", tc.raw, tc.sep, tc.want, got)
+		}
+	}
+}
+
+func TestParseStatement_PerfilYGuiasRepetidas(t *testing.T) {
+	profile := entities.SettlementProfile{
+		GuideColumn:      "Número de Guía",
+		AmountColumn:     "Valor Recaudo",
+		DateColumn:       "Fecha Pago",
+		HeaderRow:        2,
+		DecimalSeparator: ",",
+	}
+	rows := [][]string{
+		{"Liquidacion semana 41"},
+		{"NUMERO DE GUIA", "valor_recaudo", "fecha pago"},
+		{"'0123 456", "80.000", "14/10/2026"},
+		{"999", "20.000", "15/10/2026"},
+		{"0123456", "5.000", "16/10/2026"},
+		{"", "105.000", ""},
+	}
+
+	got, err := domain.ParseStatement(rows, profile)
+	if err != nil {
+		t.Fatalf("se esperaba nil error, se obtuvo: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("filas: esperado 2 (la guia repetida se suma y el total se salta), obtenido %d", len(got))
+	}
+	if got[0].GuideNumber != "0123456" || got[0].Amount != 85000 || got[0].Rows != 2 {
+		t.Errorf("guia repetida: obtenido %+v", got[0])
+	}
+	if got[0].RowNumber != 3 {
+		t.Errorf("RowNumber: esperado 3 (primera aparicion), obtenido %d", got[0].RowNumber)
+	}
+	if got[0].PaidDate == nil || got[0].PaidDate.Format("2006-01-02") != "2026-10-16" {
+		t.Errorf("PaidDate: esperado la fecha mas reciente, obtenido %v", got[0].PaidDate)
+	}
+
+	start, end, ok := domain.StatementPeriod(got)
+	if !ok || start.Format("2006-01-02") != "2026-10-15" || end.Format("2006-01-02") != "2026-10-16" {
+		t.Errorf("StatementPeriod: obtenido %v a %v (ok=%v)", start, end, ok)
+	}
+}
+
+func TestParseStatement_ColumnaFaltante(t *testing.T) {
+	profile := entities.SettlementProfile{GuideColumn: "guia", AmountColumn: "valor neto", HeaderRow: 1}
+	_, err := domain.ParseStatement([][]string{{"guia", "valor"}, {"1", "100"}}, profile)
+	if err == nil {
+		t.Fatal("se esperaba error por columna de valor inexistente")
+	}
+}
+
+func TestMatchStatement_Estados(t *testing.T) {
+	profile := entities.SettlementProfile{CarrierName: "SERVIENTREGA", AmountBasis: domain.AmountBasisGross, Tolerance: 100}
+	rows := []entities.StatementRow{
+		{RowNumber: 2, GuideNumber: "G1", Amount: 50050},
+		{RowNumber: 3, GuideNumber: "G2", Amount: 70000},
+		{RowNumber: 4, GuideNumber: "NOEXISTE", Amount: 10000},
+		{RowNumber: 5, GuideNumber: "PKG-3B", Amount: 15000},
+		{RowNumber: 6, GuideNumber: "PKG-3A", Amount: 15000},
+	}
+	orders := []entities.SettlementOrder{
+		{CodOrder: entities.CodOrder{OrderID: "o1", CodTotal: 50000, Carrier: "SERVIENTREGA"}, Guides: []string{"g1"}},
+		{CodOrder: entities.CodOrder{OrderID: "o2", CodTotal: 80000, Carrier: "SERVIENTREGA"}, Guides: []string{"G2"}},
+		{CodOrder: entities.CodOrder{OrderID: "o3", CodTotal: 30000, Carrier: "SERVIENTREGA"}, Guides: []string{"MASTER3", "PKG-3A", "PKG-3B"}},
+	}
+	pending := []entities.CodOrder{
+		{OrderID: "o1", CodTotal: 50000, GuideNumber: "G1"},
+		{OrderID: "o4", CodTotal: 45000, GuideNumber: "G4"},
+	}
+
+	lines := domain.MatchStatement(rows, orders, pending, profile)
+
+	byOrder := map[string]entities.SettlementLine{}
+	var unknown []entities.SettlementLine
+	for _, l := range lines {
+		if l.OrderID == "" {
+			unknown = append(unknown, l)
+			continue
+		}
+		byOrder[l.OrderID] = l
+	}
+
+	if byOrder["o1"].Status != domain.LineMatched {
+		t.Errorf("o1: diferencia de 50 dentro de la tolerancia, esperado matched, obtenido %s", byOrder["o1"].Status)
+	}
+	if byOrder["o2"].Status != domain.LineAmountMismatch || byOrder["o2"].Difference != -10000 {
+		t.Errorf("o2: esperado amount_mismatch con -10000, obtenido %s %v", byOrder["o2"].Status, byOrder["o2"].Difference)
+	}
+	if byOrder["o3"].Status != domain.LineMatched || byOrder["o3"].StatementAmount != 30000 {
+		t.Errorf("o3: las dos guias del envio multi-bulto suman a la orden, obtenido %s %v", byOrder["o3"].Status, byOrder["o3"].StatementAmount)
+	}
+	if byOrder["o4"].Status != domain.LineMissing || byOrder["o4"].ExpectedAmount != 45000 {
+		t.Errorf("o4: esperado missing_from_statement, obtenido %+v", byOrder["o4"])
+	}
+	if len(unknown) != 1 || unknown[0].Status != domain.LineUnknownGuide || unknown[0].GuideNumber != "NOEXISTE" {
+		t.Errorf("guia desconocida: obtenido %+v", unknown)
+	}
+
+	var s entities.Settlement
+	domain.SummarizeSettlement(&s, lines)
+	if s.MatchedCount != 2 || s.MismatchCount != 1 || s.MissingCount != 1 || s.UnknownCount != 1 {
+		t.Errorf("conteos: obtenido %+v", s)
+	}
+}
+
+func TestMatchStatement_BaseNetaYOrdenYaPagada(t *testing.T) {
+	profile := entities.SettlementProfile{AmountBasis: domain.AmountBasisNet}
+	rows := []entities.StatementRow{{RowNumber: 2, GuideNumber: "G1", Amount: 94000}}
+	orders := []entities.SettlementOrder{
+		{CodOrder: entities.CodOrder{OrderID: "o1", CodTotal: 100000, Net: 100000, CodCarrierFee: 6000, Paid: true}, Guides: []string{"G1"}},
+	}
+
+	lines := domain.MatchStatement(rows, orders, nil, profile)
+
+	if len(lines) != 1 || lines[0].Status != domain.LineMatched {
+		t.Fatalf("esperado matched contra el neto menos la comision, obtenido %+v", lines)
+	}
+	if !lines[0].AlreadyPaid || domain.ConfirmableLine(lines[0]) {
+		t.Error("una orden ya consignada no se puede volver a confirmar")
+	}
+}
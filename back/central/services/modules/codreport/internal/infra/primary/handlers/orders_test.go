@@ -66,6 +66,34 @@ func (m *ucMock) UpdateCarrierFee(_ context.Context, d dtos.UpdateCarrierFeeDTO)
 	return &dtos.UpdateCarrierFeeResult{OrderNumber: "VIG-0083", PreviousFee: 6236, NewFee: d.Fee}, nil
 }
 
+func (m *ucMock) SettlementProfiles(_ context.Context, _ uint) ([]entities.SettlementProfile, error) {
+	return nil, nil
+}
+
+func (m *ucMock) SaveSettlementProfile(_ context.Context, _ dtos.SaveSettlementProfileDTO) (*entities.SettlementProfile, error) {
+	return &entities.SettlementProfile{}, nil
+}
+
+func (m *ucMock) ImportSettlement(_ context.Context, _ dtos.ImportSettlementDTO) (*entities.Settlement, []entities.SettlementLine, error) {
+	return &entities.Settlement{}, nil, nil
+}
+
+func (m *ucMock) ListSettlements(_ context.Context, _ uint) ([]entities.Settlement, error) {
+	return nil, nil
+}
+
+func (m *ucMock) SettlementLines(_ context.Context, _ uint, _ uint, _ string) ([]entities.SettlementLine, error) {
+	return nil, nil
+}
+
+func (m *ucMock) ConfirmSettlement(_ context.Context, _ dtos.ConfirmSettlementDTO) (*entities.Settlement, error) {
+	return &entities.Settlement{}, nil
+}
+
+func (m *ucMock) DeleteSettlement(_ context.Context, _ uint, _ uint) error {
+	return nil
+}
+
 func newOrdersRequest(uc *ucMock, query string) *httptest.ResponseRecorder {
 	gin.SetMode(gin.TestMode)
 	h := handlers.New(uc, log.New())
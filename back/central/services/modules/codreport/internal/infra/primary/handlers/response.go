@@ -244,3 +244,139 @@ func mapCarrierConfigs(in []entities.CarrierConfig) []carrierConfigResponse {
 	}
 	return out
 }
+
+type settlementProfileResponse struct {
+	ID               uint    `json:"id"`
+	CarrierName      string  `json:"carrier_name"`
+	GuideColumn      string  `json:"guide_column"`
+	AmountColumn     string  `json:"amount_column"`
+	AmountBasis      string  `json:"amount_basis"`
+	FeeColumn        string  `json:"fee_column"`
+	DateColumn       string  `json:"date_column"`
+	ReferenceColumn  string  `json:"reference_column"`
+	HeaderRow        int     `json:"header_row"`
+	Delimiter        string  `json:"delimiter"`
+	DecimalSeparator string  `json:"decimal_separator"`
+	DateFormat       string  `json:"date_format"`
+	Tolerance        float64 `json:"tolerance"`
+}
+
+type settlementResponse struct {
+	ID              uint       `json:"id"`
+	CarrierName     string     `json:"carrier_name"`
+	FileName        string     `json:"file_name"`
+	PeriodStart     time.Time  `json:"period_start"`
+	PeriodEnd       time.Time  `json:"period_end"`
+	Status          string     `json:"status"`
+	LinesCount      int        `json:"lines_count"`
+	MatchedCount    int        `json:"matched_count"`
+	MismatchCount   int        `json:"amount_mismatch_count"`
+	MissingCount    int        `json:"missing_count"`
+	UnknownCount    int        `json:"unknown_guide_count"`
+	StatementTotal  float64    `json:"statement_total"`
+	ExpectedTotal   float64    `json:"expected_total"`
+	CutID           *uint      `json:"cut_id"`
+	ConfirmedBy     uint       `json:"confirmed_by"`
+	ConfirmedByName string     `json:"confirmed_by_name"`
+	ConfirmedAt     *time.Time `json:"confirmed_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+type settlementLineResponse struct {
+	ID              uint       `json:"id"`
+	RowNumber       int        `json:"row_number"`
+	GuideNumber     string     `json:"guide_number"`
+	OrderID         string     `json:"order_id"`
+	OrderNumber     string     `json:"order_number"`
+	Status          string     `json:"status"`
+	StatementAmount float64    `json:"statement_amount"`
+	ExpectedAmount  float64    `json:"expected_amount"`
+	Difference      float64    `json:"difference"`
+	CarrierFee      float64    `json:"carrier_fee"`
+	PaidDate        *time.Time `json:"paid_date"`
+	Reference       string     `json:"reference"`
+	Note            string     `json:"note"`
+	AlreadyPaid     bool       `json:"already_paid"`
+	Confirmed       bool       `json:"confirmed"`
+}
+
+func mapSettlementProfile(p *entities.SettlementProfile) settlementProfileResponse {
+	return settlementProfileResponse{
+		ID:               p.ID,
+		CarrierName:      p.CarrierName,
+		GuideColumn:      p.GuideColumn,
+		AmountColumn:     p.AmountColumn,
+		AmountBasis:      p.AmountBasis,
+		FeeColumn:        p.FeeColumn,
+		DateColumn:       p.DateColumn,
+		ReferenceColumn:  p.ReferenceColumn,
+		HeaderRow:        p.HeaderRow,
+		Delimiter:        p.Delimiter,
+		DecimalSeparator: p.DecimalSeparator,
+		DateFormat:       p.DateFormat,
+		Tolerance:        p.Tolerance,
+	}
+}
+
+func mapSettlementProfiles(in []entities.SettlementProfile) []settlementProfileResponse {
+	out := make([]settlementProfileResponse, len(in))
+	for i := range in {
+		out[i] = mapSettlementProfile(&in[i])
+	}
+	return out
+}
+
+func mapSettlement(s *entities.Settlement) settlementResponse {
+	return settlementResponse{
+		ID:              s.ID,
+		CarrierName:     s.CarrierName,
+		FileName:        s.FileName,
+		PeriodStart:     s.PeriodStart,
+		PeriodEnd:       s.PeriodEnd,
+		Status:          s.Status,
+		LinesCount:      s.LinesCount,
+		MatchedCount:    s.MatchedCount,
+		MismatchCount:   s.MismatchCount,
+		MissingCount:    s.MissingCount,
+		UnknownCount:    s.UnknownCount,
+		StatementTotal:  s.StatementTotal,
+		ExpectedTotal:   s.ExpectedTotal,
+		CutID:           s.CutID,
+		ConfirmedBy:     s.ConfirmedBy,
+		ConfirmedByName: s.ConfirmedByName,
+		ConfirmedAt:     s.ConfirmedAt,
+		CreatedAt:       s.CreatedAt,
+	}
+}
+
+func mapSettlements(in []entities.Settlement) []settlementResponse {
+	out := make([]settlementResponse, len(in))
+	for i := range in {
+		out[i] = mapSettlement(&in[i])
+	}
+	return out
+}
+
+func mapSettlementLines(in []entities.SettlementLine) []settlementLineResponse {
+	out := make([]settlementLineResponse, len(in))
+	for i := range in {
+		out[i] = settlementLineResponse{
+			ID:              in[i].ID,
+			RowNumber:       in[i].RowNumber,
+			GuideNumber:     in[i].GuideNumber,
+			OrderID:         in[i].OrderID,
+			OrderNumber:     in[i].OrderNumber,
+			Status:          in[i].Status,
+			StatementAmount: in[i].StatementAmount,
+			ExpectedAmount:  in[i].ExpectedAmount,
+			Difference:      in[i].Difference,
+			CarrierFee:      in[i].CarrierFee,
+			PaidDate:        in[i].PaidDate,
+			Reference:       in[i].Reference,
+			Note:            in[i].Note,
+			AlreadyPaid:     in[i].AlreadyPaid,
+			Confirmed:       in[i].Confirmed,
+		}
+	}
+	return out
+}
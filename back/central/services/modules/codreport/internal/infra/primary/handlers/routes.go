@@ -19,5 +19,12 @@ func (h *Handlers) RegisterRoutes(router *gin.RouterGroup) {
 		g.GET("/carrier-config", h.CarrierConfigs)
 		g.PUT("/carrier-config", h.SaveCarrierConfig)
 		g.PUT("/orders/carrier-fee", h.UpdateCarrierFee)
+		g.GET("/settlement-profiles", h.SettlementProfiles)
+		g.PUT("/settlement-profiles", h.SaveSettlementProfile)
+		g.GET("/settlements", h.ListSettlements)
+		g.POST("/settlements", h.ImportSettlement)
+		g.GET("/settlements/lines", h.SettlementLines)
+		g.POST("/settlements/confirm", h.ConfirmSettlement)
+		g.DELETE("/settlements", h.DeleteSettlement)
 	}
 }
@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain"
+	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain/dtos"
+)
+
+const maxSettlementFileSize = 10 << 20
+
+type saveSettlementProfileRequest struct {
+	CarrierName      string  `json:"carrier_name"`
+	GuideColumn      string  `json:"guide_column"`
+	AmountColumn     string  `json:"amount_column"`
+	AmountBasis      string  `json:"amount_basis"`
+	FeeColumn        string  `json:"fee_column"`
+	DateColumn       string  `json:"date_column"`
+	ReferenceColumn  string  `json:"reference_column"`
+	HeaderRow        int     `json:"header_row"`
+	Delimiter        string  `json:"delimiter"`
+	DecimalSeparator string  `json:"decimal_separator"`
+	DateFormat       string  `json:"date_format"`
+	Tolerance        float64 `json:"tolerance"`
+}
+
+type confirmSettlementRequest struct {
+	LineIDs []uint `json:"line_ids"`
+}
+
+func (h *Handlers) SettlementProfiles(c *gin.Context) {
+	businessID, err := resolveBusinessID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	profiles, err := h.uc.SettlementProfiles(c.Request.Context(), businessID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Error al obtener los perfiles de liquidacion",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": mapSettlementProfiles(profiles)})
+}
+
+func (h *Handlers) SaveSettlementProfile(c *gin.Context) {
+	if !isAdminUser(c) {
+		c.JSON(http.StatusForbidden, gin.H{"success": false, "message": "Solo un administrador puede configurar perfiles de liquidacion"})
+		return
+	}
+
+	businessID, err := resolveBusinessID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	var req saveSettlementProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Cuerpo de la solicitud invalido"})
+		return
+	}
+
+	profile, err := h.uc.SaveSettlementProfile(c.Request.Context(), dtos.SaveSettlementProfileDTO{
+		BusinessID:       businessID,
+		CarrierName:      req.CarrierName,
+		GuideColumn:      req.GuideColumn,
+		AmountColumn:     req.AmountColumn,
+		AmountBasis:      req.AmountBasis,
+		FeeColumn:        req.FeeColumn,
+		DateColumn:       req.DateColumn,
+		ReferenceColumn:  req.ReferenceColumn,
+		HeaderRow:        req.HeaderRow,
+		Delimiter:        req.Delimiter,
+		DecimalSeparator: req.DecimalSeparator,
+		DateFormat:       req.DateFormat,
+		Tolerance:        req.Tolerance,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"message": "No se pudo guardar el perfil de liquidacion",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Perfil de liquidacion guardado exitosamente",
+		"data":    mapSettlementProfile(profile),
+	})
+}
+
+// ImportSettlement recibe el archivo de liquidacion (multipart: file, carrier y
+// opcionalmente period_start / period_end) y devuelve las lineas conciliadas
+func (h *Handlers) ImportSettlement(c *gin.Context) {
+	if !isAdminUser(c) {
+		c.JSON(http.StatusForbidden, gin.H{"success": false, "message": "Solo un administrador puede cargar liquidaciones"})
+		return
+	}
+
+	businessID, err := resolveBusinessID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "No se recibio un archivo valido"})
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxSettlementFileSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "No se pudo leer el archivo"})
+		return
+	}
+	if len(content) > maxSettlementFileSize {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "El archivo supera el tamano maximo de 10 MB"})
+		return
+	}
+
+	var start, end time.Time
+	if ps, pe := strings.TrimSpace(c.PostForm("period_start")), strings.TrimSpace(c.PostForm("period_end")); ps != "" || pe != "" {
+		var err1, err2 error
+		start, err1 = time.Parse("2006-01-02", ps)
+		end, err2 = time.Parse("2006-01-02", pe)
+		if err1 != nil || err2 != nil || end.Before(start) {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Fechas del periodo invalidas"})
+			return
+		}
+	}
+
+	userID, _ := middleware.GetUserID(c)
+
+	settlement, lines, err := h.uc.ImportSettlement(c.Request.Context(), dtos.ImportSettlementDTO{
+		BusinessID:  businessID,
+		CarrierName: c.PostForm("carrier"),
+		FileName:    header.Filename,
+		Content:     content,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		UserID:      userID,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"message": "No se pudo procesar la liquidacion",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Liquidacion cargada exitosamente",
+		"data": gin.H{
+			"settlement": mapSettlement(settlement),
+			"lines":      mapSettlementLines(lines),
+		},
+	})
+}
+
+func (h *Handlers) ListSettlements(c *gin.Context) {
+	businessID, err := resolveBusinessID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	settlements, err := h.uc.ListSettlements(c.Request.Context(), businessID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Error al obtener las liquidaciones",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": mapSettlements(settlements)})
+}
+
+func (h *Handlers) SettlementLines(c *gin.Context) {
+	businessID, err := resolveBusinessID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	settlementID, err := strconv.ParseUint(c.Query("settlement_id"), 10, 64)
+	if err != nil || settlementID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "settlement_id invalido"})
+		return
+	}
+
+	lines, err := h.uc.SettlementLines(c.Request.Context(), businessID, uint(settlementID), strings.TrimSpace(c.Query("status")))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Error al obtener las lineas de la liquidacion",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": mapSettlementLines(lines)})
+}
+
+func (h *Handlers) ConfirmSettlement(c *gin.Context) {
+	if !isAdminUser(c) {
+		c.JSON(http.StatusForbidden, gin.H{"success": false, "message": "Solo un administrador puede confirmar liquidaciones"})
+		return
+	}
+
+	businessID, err := resolveBusinessID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	settlementID, err := strconv.ParseUint(c.Query("settlement_id"), 10, 64)
+	if err != nil || settlementID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "settlement_id invalido"})
+		return
+	}
+
+	var req confirmSettlementRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Cuerpo de la solicitud invalido"})
+			return
+		}
+	}
+
+	userID, _ := middleware.GetUserID(c)
+
+	settlement, err := h.uc.ConfirmSettlement(c.Request.Context(), dtos.ConfirmSettlementDTO{
+		BusinessID:   businessID,
+		SettlementID: uint(settlementID),
+		LineIDs:      req.LineIDs,
+		UserID:       userID,
+	})
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, domain.ErrSettlementNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, domain.ErrSettlementClosed), errors.Is(err, domain.ErrSettlementNoLines):
+			status = http.StatusConflict
+		}
+		c.JSON(status, gin.H{
+			"success": false,
+			"message": "Error al confirmar la liquidacion",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"message": "Liquidacion confirmada exitosamente",
+		"data":    mapSettlement(settlement),
+	})
+}
+
+func (h *Handlers) DeleteSettlement(c *gin.Context) {
+	if !isAdminUser(c) {
+		c.JSON(http.StatusForbidden, gin.H{"success": false, "message": "Solo un administrador puede eliminar liquidaciones"})
+		return
+	}
+
+	businessID, err := resolveBusinessID(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	settlementID, err := strconv.ParseUint(c.Query("settlement_id"), 10, 64)
+	if err != nil || settlementID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "settlement_id invalido"})
+		return
+	}
+
+	if err := h.uc.DeleteSettlement(c.Request.Context(), businessID, uint(settlementID)); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"success": false,
+			"message": "No se pudo eliminar la liquidacion",
+			"error":   err.Error(),
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Liquidacion eliminada exitosamente"})
+}
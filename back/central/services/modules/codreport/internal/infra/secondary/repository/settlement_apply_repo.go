@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain"
+	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain/dtos"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type settlementLinkRow struct {
+	OrderID   string
+	CutID     uint
+	CutStatus string
+}
+
+// ApplySettlement paga las ordenes de las lineas confirmadas. Un borrador que
+// queda cubierto completo por la liquidacion se confirma tal cual; de los que
+// no, las ordenes pagadas se pasan al corte de la liquidacion junto con las
+// que no estaban en ningun corte. Devuelve los cortes tocados para recalcular
+// sus totales.
+func (r *Repository) ApplySettlement(ctx context.Context, in dtos.ApplySettlementInput) ([]uint, error) {
+	now := time.Now().UTC()
+	touched := []uint{}
+
+	err := r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		var settlement models.CodSettlement
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND business_id = ?", in.SettlementID, in.BusinessID).
+			First(&settlement).Error; err != nil {
+			return domain.ErrSettlementNotFound
+		}
+		if settlement.Status != domain.SettlementPending {
+			return domain.ErrSettlementClosed
+		}
+
+		var links []settlementLinkRow
+		if len(in.OrderIDs) > 0 {
+			if err := tx.Raw(`
+SELECT cpo.order_id, cpo.cod_payment_cut_id AS cut_id, c.status AS cut_status
+FROM cod_payment_cut_order cpo
+JOIN cod_payment_cut c ON c.id = cpo.cod_payment_cut_id AND c.deleted_at IS NULL
+WHERE cpo.order_id IN ? AND cpo.business_id = ? AND cpo.deleted_at IS NULL`, in.OrderIDs, in.BusinessID).
+				Scan(&links).Error; err != nil {
+				return err
+			}
+		}
+
+		linked := map[string]bool{}
+		draftOrders := map[uint][]string{}
+		for _, l := range links {
+			linked[l.OrderID] = true
+			if l.CutStatus == "draft" {
+				draftOrders[l.CutID] = append(draftOrders[l.CutID], l.OrderID)
+			}
+		}
+
+		var moved []string
+		for cutID, orderIDs := range draftOrders {
+			var total int64
+			if err := tx.Model(&models.CodPaymentCutOrder{}).
+				Where("cod_payment_cut_id = ?", cutID).
+				Count(&total).Error; err != nil {
+				return err
+			}
+			if int(total) > len(orderIDs) {
+				moved = append(moved, orderIDs...)
+				touched = append(touched, cutID)
+				continue
+			}
+			if err := tx.Model(&models.CodPaymentCutOrder{}).
+				Where("cod_payment_cut_id = ?", cutID).
+				Update("paid_at", now).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.CodPaymentCut{}).
+				Where("id = ? AND status = ?", cutID, "draft").
+				Updates(map[string]any{
+					"status":            "confirmed",
+					"confirmed_by":      in.UserID,
+					"confirmed_by_name": in.UserName,
+					"confirmed_at":      now,
+				}).Error; err != nil {
+				return err
+			}
+			touched = append(touched, cutID)
+		}
+
+		var unlinked []string
+		for _, id := range in.OrderIDs {
+			if !linked[id] {
+				unlinked = append(unlinked, id)
+			}
+		}
+
+		if len(moved) > 0 || len(unlinked) > 0 {
+			cutID, err := settlementCut(tx, in, now)
+			if err != nil {
+				return err
+			}
+			settlement.CodPaymentCutID = &cutID
+			touched = append(touched, cutID)
+
+			if len(moved) > 0 {
+				if err := tx.Model(&models.CodPaymentCutOrder{}).
+					Where("order_id IN ? AND business_id = ?", moved, in.BusinessID).
+					Updates(map[string]any{"cod_payment_cut_id": cutID, "paid_at": now}).Error; err != nil {
+					return err
+				}
+			}
+			if len(unlinked) > 0 {
+				if err := tx.Exec(`
+INSERT INTO cod_payment_cut_order (created_at, updated_at, cod_payment_cut_id, business_id, order_id, carrier, cod_amount, paid_at)
+SELECT ?, ?, ?, o.business_id, o.id, ?, o.cod_total, ?
+FROM orders o
+WHERE o.id IN ? AND o.business_id = ? AND o.deleted_at IS NULL
+ON CONFLICT (order_id) DO NOTHING`, now, now, cutID, strings.ToUpper(strings.TrimSpace(in.CarrierName)), now, unlinked, in.BusinessID).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(in.OrderIDs) > 0 {
+			if err := tx.Model(&models.Order{}).
+				Where("id IN ? AND business_id = ? AND is_paid = ?", in.OrderIDs, in.BusinessID, false).
+				Updates(map[string]any{"is_paid": true, "paid_at": now}).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.CodSettlementLine{}).
+			Where("id IN ? AND cod_settlement_id = ?", in.LineIDs, in.SettlementID).
+			Update("confirmed", true).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&settlement).Updates(map[string]any{
+			"status":             domain.SettlementConfirmed,
+			"cod_payment_cut_id": settlement.CodPaymentCutID,
+			"confirmed_by":       in.UserID,
+			"confirmed_by_name":  in.UserName,
+			"confirmed_at":       now,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return touched, nil
+}
+
+// settlementCut corte confirmado del periodo de la liquidacion. Si ya existe
+// un borrador para ese periodo con ordenes fuera de la liquidacion no se puede
+// confirmar por debajo: se pide resolverlo primero.
+func settlementCut(tx *gorm.DB, in dtos.ApplySettlementInput, now time.Time) (uint, error) {
+	var existing models.CodPaymentCut
+	err := tx.Where("business_id = ? AND period_start = ? AND period_end = ?", in.BusinessID, in.PeriodStart, in.PeriodEnd).
+		First(&existing).Error
+	if err == nil {
+		if existing.Status != "confirmed" {
+			return 0, fmt.Errorf("ya existe un corte en borrador del %s al %s; confirmalo o eliminalo antes de confirmar la liquidacion",
+				in.PeriodStart.Format("2006-01-02"), in.PeriodEnd.Format("2006-01-02"))
+		}
+		return existing.ID, nil
+	}
+
+	row := models.CodPaymentCut{
+		BusinessID:       in.BusinessID,
+		PeriodStart:      in.PeriodStart,
+		PeriodEnd:        in.PeriodEnd,
+		Status:           "confirmed",
+		CarrierBreakdown: "[]",
+		ConfirmedBy:      in.UserID,
+		ConfirmedByName:  in.UserName,
+		ConfirmedAt:      &now,
+	}
+	if err := tx.Omit("Business").Create(&row).Error; err != nil {
+		return 0, err
+	}
+	return row.ID, nil
+}
@@ -0,0 +1,403 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain"
+	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/codreport/internal/domain/entities"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+)
+
+const settlementGuideBatch = 1000
+
+func mapSettlementProfile(row *models.CodSettlementProfile) *entities.SettlementProfile {
+	return &entities.SettlementProfile{
+		ID:               row.ID,
+		BusinessID:       row.BusinessID,
+		CarrierName:      row.CarrierName,
+		GuideColumn:      row.GuideColumn,
+		AmountColumn:     row.AmountColumn,
+		AmountBasis:      row.AmountBasis,
+		FeeColumn:        row.FeeColumn,
+		DateColumn:       row.DateColumn,
+		ReferenceColumn:  row.ReferenceColumn,
+		HeaderRow:        row.HeaderRow,
+		Delimiter:        row.Delimiter,
+		DecimalSeparator: row.DecimalSeparator,
+		DateFormat:       row.DateFormat,
+		Tolerance:        row.Tolerance,
+	}
+}
+
+func (r *Repository) SettlementProfiles(ctx context.Context, businessID uint) ([]entities.SettlementProfile, error) {
+	var rows []models.CodSettlementProfile
+	err := r.db.Conn(ctx).
+		Where("business_id = ?", businessID).
+		Order("carrier_name ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]entities.SettlementProfile, len(rows))
+	for i := range rows {
+		out[i] = *mapSettlementProfile(&rows[i])
+	}
+	return out, nil
+}
+
+func (r *Repository) SettlementProfile(ctx context.Context, businessID uint, carrier string) (*entities.SettlementProfile, error) {
+	var row models.CodSettlementProfile
+	err := r.db.Conn(ctx).
+		Where("business_id = ? AND carrier_name = ?", businessID, strings.ToUpper(strings.TrimSpace(carrier))).
+		First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mapSettlementProfile(&row), nil
+}
+
+func (r *Repository) SaveSettlementProfile(ctx context.Context, d dtos.SaveSettlementProfileDTO) (*entities.SettlementProfile, error) {
+	name := strings.ToUpper(strings.TrimSpace(d.CarrierName))
+	var row models.CodSettlementProfile
+	err := r.db.Conn(ctx).
+		Where("business_id = ? AND carrier_name = ?", d.BusinessID, name).
+		First(&row).Error
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	row.BusinessID = d.BusinessID
+	row.CarrierName = name
+	row.GuideColumn = d.GuideColumn
+	row.AmountColumn = d.AmountColumn
+	row.AmountBasis = d.AmountBasis
+	row.FeeColumn = d.FeeColumn
+	row.DateColumn = d.DateColumn
+	row.ReferenceColumn = d.ReferenceColumn
+	row.HeaderRow = d.HeaderRow
+	row.Delimiter = d.Delimiter
+	row.DecimalSeparator = d.DecimalSeparator
+	row.DateFormat = d.DateFormat
+	row.Tolerance = d.Tolerance
+
+	if err := r.db.Conn(ctx).Save(&row).Error; err != nil {
+		return nil, err
+	}
+	return mapSettlementProfile(&row), nil
+}
+
+type settlementOrderRow struct {
+	codOrderRow
+	ShipmentGuide    string
+	ShipmentTracking string
+	OrderTracking    string
+	PackageGuides    string
+}
+
+const normalizedGuide = `UPPER(REPLACE(COALESCE(%s,''),' ',''))`
+
+func (r *Repository) SettlementOrdersByGuides(ctx context.Context, businessID uint, guides []string) ([]entities.SettlementOrder, error) {
+	out := []entities.SettlementOrder{}
+	for start := 0; start < len(guides); start += settlementGuideBatch {
+		end := start + settlementGuideBatch
+		if end > len(guides) {
+			end = len(guides)
+		}
+		batch := guides[start:end]
+
+		sql := fmt.Sprintf(`
+SELECT o.id AS order_id, o.order_number, o.customer_name, o.cod_total, o.currency, o.created_at,
+	s.id AS shipment_id,
+	UPPER(TRIM(COALESCE(NULLIF(s.carrier,''),'SIN TRANSPORTADORA'))) AS carrier,
+	COALESCE(s.shipping_cost,0) AS shipping_cost,
+	COALESCE(s.cod_carrier_fee,0) AS cod_carrier_fee,
+	s.status, s.delivered_at,
+	%[2]s AS paid,
+	`+guideNumberExpr+` AS guide_number,
+	COALESCE(s.guide_id,'') AS shipment_guide,
+	COALESCE(s.tracking_number,'') AS shipment_tracking,
+	COALESCE(o.tracking_number,'') AS order_tracking,
+	COALESCE((SELECT STRING_AGG(sp.tracking_number, ',') FROM shipment_packages sp WHERE sp.shipment_id = s.id AND COALESCE(sp.tracking_number,'') <> ''),'') AS package_guides
+FROM orders o %[1]s
+WHERE o.deleted_at IS NULL AND o.cod_total > 0 AND o.business_id = ?
+	AND (%[3]s IN ? OR %[4]s IN ? OR %[5]s IN ?
+		OR EXISTS (SELECT 1 FROM shipment_packages sp WHERE sp.shipment_id = s.id AND %[6]s IN ?))
+ORDER BY o.created_at DESC`,
+			latestShipmentJoin, paidExpr,
+			fmt.Sprintf(normalizedGuide, "s.guide_id"),
+			fmt.Sprintf(normalizedGuide, "s.tracking_number"),
+			fmt.Sprintf(normalizedGuide, "o.tracking_number"),
+			fmt.Sprintf(normalizedGuide, "sp.tracking_number"))
+
+		var rows []settlementOrderRow
+		if err := r.db.Conn(ctx).Raw(sql, businessID, batch, batch, batch, batch).Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+		for i := range rows {
+			guides := []string{rows[i].ShipmentGuide, rows[i].ShipmentTracking, rows[i].OrderTracking}
+			if rows[i].PackageGuides != "" {
+				guides = append(guides, strings.Split(rows[i].PackageGuides, ",")...)
+			}
+			out = append(out, entities.SettlementOrder{
+				CodOrder: entities.CodOrder{
+					OrderID:       rows[i].OrderID,
+					OrderNumber:   rows[i].OrderNumber,
+					ShipmentID:    rows[i].ShipmentID,
+					GuideNumber:   rows[i].GuideNumber,
+					CustomerName:  rows[i].CustomerName,
+					Carrier:       rows[i].Carrier,
+					CodTotal:      rows[i].CodTotal,
+					CodCarrierFee: rows[i].CodCarrierFee,
+					ShippingCost:  rows[i].ShippingCost,
+					Currency:      rows[i].Currency,
+					Status:        rows[i].Status,
+					Paid:          rows[i].Paid,
+					CreatedAt:     rows[i].CreatedAt,
+					DeliveredAt:   rows[i].DeliveredAt,
+				},
+				Guides: guides,
+			})
+		}
+	}
+	return out, nil
+}
+
+func (r *Repository) PendingCarrierPayouts(ctx context.Context, businessID uint, carrier string, start, end time.Time) ([]entities.CodOrder, error) {
+	sql := fmt.Sprintf(`
+SELECT o.id AS order_id, o.order_number, o.customer_name, o.cod_total, o.currency, o.created_at,
+	s.id AS shipment_id,
+	UPPER(TRIM(COALESCE(NULLIF(s.carrier,''),'SIN TRANSPORTADORA'))) AS carrier,
+	COALESCE(s.shipping_cost,0) AS shipping_cost,
+	COALESCE(s.cod_carrier_fee,0) AS cod_carrier_fee,
+	s.status, s.delivered_at,
+	`+guideNumberExpr+` AS guide_number
+FROM orders o %s
+WHERE o.deleted_at IS NULL AND o.cod_total > 0 AND o.business_id = ?
+	AND s.status = 'delivered'
+	AND UPPER(TRIM(COALESCE(NULLIF(s.carrier,''),'SIN TRANSPORTADORA'))) = ?
+	AND COALESCE(s.delivered_at, s.updated_at) BETWEEN ? AND ?
+	AND NOT %s
+ORDER BY COALESCE(s.delivered_at, o.created_at) ASC`, latestShipmentJoin, paidExpr)
+
+	var rows []codOrderRow
+	if err := r.db.Conn(ctx).Raw(sql, businessID, strings.ToUpper(strings.TrimSpace(carrier)), start, end).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]entities.CodOrder, len(rows))
+	for i := range rows {
+		out[i] = entities.CodOrder{
+			OrderID:       rows[i].OrderID,
+			OrderNumber:   rows[i].OrderNumber,
+			ShipmentID:    rows[i].ShipmentID,
+			GuideNumber:   rows[i].GuideNumber,
+			CustomerName:  rows[i].CustomerName,
+			Carrier:       rows[i].Carrier,
+			CodTotal:      rows[i].CodTotal,
+			CodCarrierFee: rows[i].CodCarrierFee,
+			ShippingCost:  rows[i].ShippingCost,
+			Currency:      rows[i].Currency,
+			Status:        rows[i].Status,
+			CreatedAt:     rows[i].CreatedAt,
+			DeliveredAt:   rows[i].DeliveredAt,
+		}
+	}
+	return out, nil
+}
+
+func (r *Repository) CreateSettlement(ctx context.Context, s *entities.Settlement, lines []entities.SettlementLine) error {
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		row := models.CodSettlement{
+			BusinessID:     s.BusinessID,
+			CarrierName:    s.CarrierName,
+			FileName:       s.FileName,
+			PeriodStart:    s.PeriodStart,
+			PeriodEnd:      s.PeriodEnd,
+			Status:         domain.SettlementPending,
+			LinesCount:     s.LinesCount,
+			MatchedCount:   s.MatchedCount,
+			MismatchCount:  s.MismatchCount,
+			MissingCount:   s.MissingCount,
+			UnknownCount:   s.UnknownCount,
+			StatementTotal: s.StatementTotal,
+			ExpectedTotal:  s.ExpectedTotal,
+			UploadedBy:     s.UploadedBy,
+		}
+		if err := tx.Omit("Business").Create(&row).Error; err != nil {
+			return err
+		}
+
+		if len(lines) > 0 {
+			rows := make([]models.CodSettlementLine, len(lines))
+			for i, l := range lines {
+				rows[i] = models.CodSettlementLine{
+					CodSettlementID: row.ID,
+					BusinessID:      s.BusinessID,
+					RowNumber:       l.RowNumber,
+					GuideNumber:     truncate(l.GuideNumber, 128),
+					Status:          l.Status,
+					StatementAmount: l.StatementAmount,
+					ExpectedAmount:  l.ExpectedAmount,
+					Difference:      l.Difference,
+					CarrierFee:      l.CarrierFee,
+					PaidDate:        l.PaidDate,
+					Reference:       truncate(l.Reference, 255),
+					Note:            truncate(l.Note, 255),
+					AlreadyPaid:     l.AlreadyPaid,
+				}
+				if l.OrderID != "" {
+					orderID := l.OrderID
+					rows[i].OrderID = &orderID
+				}
+			}
+			if err := tx.Omit("CodSettlement").CreateInBatches(&rows, 500).Error; err != nil {
+				return err
+			}
+			for i := range rows {
+				lines[i].ID = rows[i].ID
+				lines[i].SettlementID = row.ID
+			}
+		}
+
+		s.ID = row.ID
+		s.Status = row.Status
+		s.CreatedAt = row.CreatedAt
+		return nil
+	})
+}
+
+func mapSettlement(row *models.CodSettlement) entities.Settlement {
+	return entities.Settlement{
+		ID:              row.ID,
+		BusinessID:      row.BusinessID,
+		CarrierName:     row.CarrierName,
+		FileName:        row.FileName,
+		PeriodStart:     row.PeriodStart,
+		PeriodEnd:       row.PeriodEnd,
+		Status:          row.Status,
+		LinesCount:      row.LinesCount,
+		MatchedCount:    row.MatchedCount,
+		MismatchCount:   row.MismatchCount,
+		MissingCount:    row.MissingCount,
+		UnknownCount:    row.UnknownCount,
+		StatementTotal:  row.StatementTotal,
+		ExpectedTotal:   row.ExpectedTotal,
+		CutID:           row.CodPaymentCutID,
+		UploadedBy:      row.UploadedBy,
+		ConfirmedBy:     row.ConfirmedBy,
+		ConfirmedByName: row.ConfirmedByName,
+		ConfirmedAt:     row.ConfirmedAt,
+		CreatedAt:       row.CreatedAt,
+	}
+}
+
+func (r *Repository) Settlements(ctx context.Context, businessID uint) ([]entities.Settlement, error) {
+	var rows []models.CodSettlement
+	err := r.db.Conn(ctx).
+		Where("business_id = ?", businessID).
+		Order("created_at DESC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]entities.Settlement, len(rows))
+	for i := range rows {
+		out[i] = mapSettlement(&rows[i])
+	}
+	return out, nil
+}
+
+func (r *Repository) Settlement(ctx context.Context, businessID uint, settlementID uint) (*entities.Settlement, error) {
+	var row models.CodSettlement
+	err := r.db.Conn(ctx).
+		Where("id = ? AND business_id = ?", settlementID, businessID).
+		First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrSettlementNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	s := mapSettlement(&row)
+	return &s, nil
+}
+
+type settlementLineRow struct {
+	models.CodSettlementLine
+	OrderNumber string
+}
+
+func (r *Repository) SettlementLines(ctx context.Context, businessID uint, settlementID uint) ([]entities.SettlementLine, error) {
+	var rows []settlementLineRow
+	err := r.db.Conn(ctx).
+		Table("cod_settlement_lines AS l").
+		Select("l.*, COALESCE(o.order_number,'') AS order_number").
+		Joins("LEFT JOIN orders o ON o.id = l.order_id").
+		Where("l.cod_settlement_id = ? AND l.business_id = ? AND l.deleted_at IS NULL", settlementID, businessID).
+		Order("CASE WHEN l.row_number = 0 THEN 1 ELSE 0 END, l.row_number, l.id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	out := make([]entities.SettlementLine, len(rows))
+	for i := range rows {
+		out[i] = entities.SettlementLine{
+			ID:              rows[i].ID,
+			SettlementID:    rows[i].CodSettlementID,
+			RowNumber:       rows[i].RowNumber,
+			GuideNumber:     rows[i].GuideNumber,
+			OrderNumber:     rows[i].OrderNumber,
+			Status:          rows[i].Status,
+			StatementAmount: rows[i].StatementAmount,
+			ExpectedAmount:  rows[i].ExpectedAmount,
+			Difference:      rows[i].Difference,
+			CarrierFee:      rows[i].CarrierFee,
+			PaidDate:        rows[i].PaidDate,
+			Reference:       rows[i].Reference,
+			Note:            rows[i].Note,
+			AlreadyPaid:     rows[i].AlreadyPaid,
+			Confirmed:       rows[i].Confirmed,
+		}
+		if rows[i].OrderID != nil {
+			out[i].OrderID = *rows[i].OrderID
+		}
+	}
+	return out, nil
+}
+
+func (r *Repository) DeleteSettlement(ctx context.Context, businessID uint, settlementID uint) error {
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.CodSettlement{}).
+			Where("id = ? AND business_id = ? AND status = ?", settlementID, businessID, domain.SettlementPending).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return fmt.Errorf("solo se pueden eliminar liquidaciones sin confirmar")
+		}
+		if err := tx.Unscoped().
+			Where("cod_settlement_id = ? AND business_id = ?", settlementID, businessID).
+			Delete(&models.CodSettlementLine{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().
+			Where("id = ? AND business_id = ?", settlementID, businessID).
+			Delete(&models.CodSettlement{}).Error
+	})
+}
+
+func truncate(v string, max int) string {
+	if len(v) <= max {
+		return v
+	}
+	return v[:max]
+}
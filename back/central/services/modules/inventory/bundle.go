@@ -13,12 +13,18 @@ import (
 	"github.com/secamc93/probability/back/central/shared/db"
 	"github.com/secamc93/probability/back/central/shared/env"
 	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/outbox"
 	"github.com/secamc93/probability/back/central/shared/rabbitmq"
 	"github.com/secamc93/probability/back/central/shared/redis"
 )
 
+// Bundle expone a otros modulos las operaciones de inventario (ver gateway.go)
+type Bundle struct {
+	uc app.IUseCase
+}
+
 // New inicializa el módulo de inventory
-func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, environment env.IConfig, rabbitMQ rabbitmq.IQueue, redisClient redis.IRedis, moduleAccessMW gin.HandlerFunc) {
+func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, environment env.IConfig, rabbitMQ rabbitmq.IQueue, redisClient redis.IRedis, moduleAccessMW gin.HandlerFunc) *Bundle {
 	// 1. Init Cache (resiliente: si redis es nil, cache no-op)
 	var cache repository.IInventoryCache
 	if redisClient != nil {
@@ -29,7 +35,7 @@ func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, env
 	repo := repository.New(database, cache)
 
 	// 3. Init Sync Publisher
-	publisher := syncqueue.New(rabbitMQ, logger)
+	publisher := syncqueue.New(rabbitMQ, outbox.NewWriter(database), logger)
 
 	// 4. Init Event Publisher (Redis SSE + RabbitMQ central dispatcher)
 	eventPublisher := inventorycache.NewEventPublisher(redisClient, logger, rabbitMQ)
@@ -54,4 +60,6 @@ func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, env
 	// 10. Start Provider Inventory Sync Consumer (Siigo -> Probability, una via)
 	providerSyncConsumer := orderqueue.NewInventorySyncConsumer(rabbitMQ, uc, logger)
 	providerSyncConsumer.Start(context.Background())
+
+	return &Bundle{uc: uc}
 }
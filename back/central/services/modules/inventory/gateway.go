@@ -0,0 +1,57 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+)
+
+// Estados en que puede quedar una unidad devuelta segun la inspeccion
+const (
+	ReturnStateAvailable  = "available"
+	ReturnStateDamaged    = "damaged"
+	ReturnStateQuarantine = "quarantine"
+)
+
+// ReturnReceipt son las unidades de una devolucion que vuelven a bodega
+type ReturnReceipt struct {
+	BusinessID  uint
+	WarehouseID *uint
+	OrderID     string
+	ReturnCode  string
+	UserID      *uint
+	Items       []ReturnedItem
+}
+
+// ReturnedItem es una linea recibida; State es uno de los ReturnState*
+type ReturnedItem struct {
+	ProductID string
+	SKU       string
+	Quantity  int
+	State     string
+}
+
+// ReceiveReturn reingresa las unidades devueltas y las deja en el estado de la
+// inspeccion. Si ctx trae una transaccion (db.InTransaction), el movimiento
+// queda en ella.
+func (b *Bundle) ReceiveReturn(ctx context.Context, receipt ReturnReceipt) error {
+	items := make([]request.ReturnedItemDTO, 0, len(receipt.Items))
+	for _, item := range receipt.Items {
+		items = append(items, request.ReturnedItemDTO{
+			ProductID: item.ProductID,
+			SKU:       item.SKU,
+			Quantity:  item.Quantity,
+			StateCode: item.State,
+		})
+	}
+
+	_, err := b.uc.ReceiveReturn(ctx, request.ReceiveReturnDTO{
+		BusinessID:  receipt.BusinessID,
+		WarehouseID: receipt.WarehouseID,
+		OrderID:     receipt.OrderID,
+		ReturnCode:  receipt.ReturnCode,
+		CreatedByID: receipt.UserID,
+		Items:       items,
+	})
+	return err
+}
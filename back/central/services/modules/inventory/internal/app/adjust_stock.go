@@ -46,13 +46,21 @@ func (uc *useCase) AdjustStock(ctx context.Context, dto request.AdjustStockDTO)
 		return nil, err
 	}
 
-	txResult, err := uc.repo.AdjustStockTx(ctx, mappers.AdjustStockDTOToTxParams(dto, movTypeID, "manual"))
+	// El ajuste y los mensajes de sync (outbox) se confirman juntos
+	var txResult *dtos.AdjustStockTxResult
+	err = uc.repo.InTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		txResult, err = uc.repo.AdjustStockTx(ctx, mappers.AdjustStockDTOToTxParams(dto, movTypeID, "manual"))
+		if err != nil {
+			return err
+		}
+		uc.updateProductTotalStock(ctx, dto.ProductID, dto.BusinessID)
+		uc.publishSync(ctx, dto.ProductID, dto.BusinessID, txResult.NewQuantity, dto.WarehouseID, "manual_adjustment")
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-
-	uc.updateProductTotalStock(ctx, dto.ProductID, dto.BusinessID)
-	uc.publishSync(ctx, dto.ProductID, dto.BusinessID, txResult.NewQuantity, dto.WarehouseID, "manual_adjustment")
 	uc.publishLocationChanged(dto.BusinessID, dto.WarehouseID, dto.LocationID, dto.ProductID, txResult.NewQuantity)
 
 	return txResult.Movement, nil
@@ -79,6 +87,15 @@ func (uc *useCase) publishLocationChanged(businessID, warehouseID uint, location
 }
 
 func (uc *useCase) updateProductTotalStock(ctx context.Context, productID string, businessID uint) {
+	// Un kit virtual no tiene stock propio: lo que cambio fueron sus componentes,
+	// y al publicarlos se recalcula el disponible del kit
+	if kit, err := uc.repo.GetKit(ctx, businessID, productID); err == nil && kit != nil && kit.IsVirtual() {
+		for _, c := range kit.Components {
+			uc.updateProductTotalStock(ctx, c.ProductID, businessID)
+		}
+		return
+	}
+
 	levels, err := uc.repo.GetProductInventory(ctx, dtos.GetProductInventoryParams{
 		ProductID:  productID,
 		BusinessID: businessID,
@@ -93,6 +110,7 @@ func (uc *useCase) updateProductTotalStock(ctx context.Context, productID string
 	}
 
 	uc.pushEcommerceStock(ctx, productID, businessID, total)
+	uc.pushVirtualKitsStock(ctx, productID, businessID)
 }
 
 func (uc *useCase) pushEcommerceStock(ctx context.Context, productID string, businessID uint, total int) {
@@ -31,6 +31,7 @@ type IUseCase interface {
 	ConfirmSaleForOrder(ctx context.Context, orderID string, businessID uint, warehouseID *uint, items []dtos.OrderInventoryItem) (*response.OrderStockResult, error)
 	ReleaseStockForOrder(ctx context.Context, orderID string, businessID uint, warehouseID *uint, items []dtos.OrderInventoryItem) (*response.OrderStockResult, error)
 	ReturnStockForOrder(ctx context.Context, orderID string, businessID uint, warehouseID *uint, items []dtos.OrderInventoryItem) (*response.OrderStockResult, error)
+	ReceiveReturn(ctx context.Context, dto request.ReceiveReturnDTO) (*response.OrderStockResult, error)
 
 	ValidateCubing(ctx context.Context, dto request.ValidateCubingDTO) (*response.CubingCheckResult, error)
 
@@ -112,6 +113,57 @@ type IUseCase interface {
 
 	InboundSync(ctx context.Context, dto request.InboundSyncDTO) (*response.InboundSyncResult, error)
 	ListSyncLogs(ctx context.Context, params dtos.ListSyncLogsParams) ([]entities.InventorySyncLog, int64, error)
+
+	CreateSupplier(ctx context.Context, dto request.CreateSupplierDTO) (*entities.Supplier, error)
+	GetSupplier(ctx context.Context, businessID, id uint) (*entities.Supplier, error)
+	ListSuppliers(ctx context.Context, params dtos.ListSuppliersParams) ([]entities.Supplier, int64, error)
+	UpdateSupplier(ctx context.Context, dto request.UpdateSupplierDTO) (*entities.Supplier, error)
+	SetSupplierProduct(ctx context.Context, dto request.SetSupplierProductDTO) (*entities.SupplierProduct, error)
+	ListSupplierProducts(ctx context.Context, businessID, supplierID uint) ([]entities.SupplierProduct, error)
+	DeleteSupplierProduct(ctx context.Context, businessID, id uint) error
+
+	CreatePurchaseOrder(ctx context.Context, dto request.CreatePurchaseOrderDTO) (*entities.PurchaseOrder, error)
+	GetPurchaseOrder(ctx context.Context, businessID, id uint) (*entities.PurchaseOrder, error)
+	ListPurchaseOrders(ctx context.Context, params dtos.ListPurchaseOrdersParams) ([]entities.PurchaseOrder, int64, error)
+	UpdatePurchaseOrder(ctx context.Context, dto request.UpdatePurchaseOrderDTO) (*entities.PurchaseOrder, error)
+	ApprovePurchaseOrder(ctx context.Context, dto request.PurchaseOrderActionDTO) (*entities.PurchaseOrder, error)
+	CancelPurchaseOrder(ctx context.Context, dto request.PurchaseOrderActionDTO) (*entities.PurchaseOrder, error)
+	ClosePurchaseOrder(ctx context.Context, dto request.PurchaseOrderActionDTO) (*entities.PurchaseOrder, error)
+	ReceivePurchaseOrder(ctx context.Context, dto request.ReceivePurchaseOrderDTO) (*response.PurchaseReceiptResult, error)
+	ListPurchaseOrderReceipts(ctx context.Context, businessID, poID uint) ([]entities.PurchaseOrderReceipt, error)
+	AddLandedCost(ctx context.Context, dto request.AddLandedCostDTO) (*entities.PurchaseOrder, error)
+	ListLandedCosts(ctx context.Context, businessID, poID uint) ([]entities.PurchaseOrderLandedCost, error)
+	GenerateDraftPurchaseOrders(ctx context.Context, dto request.GenerateDraftPurchaseOrdersDTO) (*response.DraftPurchaseOrdersResult, error)
+
+	// Valorizacion
+	GetValuationSetting(ctx context.Context, businessID uint) (*entities.ValuationSetting, error)
+	SetValuationMethod(ctx context.Context, dto request.SetValuationMethodDTO) (*entities.ValuationSetting, error)
+	SetOpeningCost(ctx context.Context, dto request.SetOpeningCostDTO) (*entities.CostBalance, error)
+	GetProductCost(ctx context.Context, businessID uint, productID string) (*response.ProductCostResult, error)
+	GetValuationReport(ctx context.Context, dto request.ValuationReportDTO) (*response.ValuationReportResult, error)
+	GetOrderCOGS(ctx context.Context, businessID uint, orderID string) (*response.OrderCOGSResult, error)
+
+	// Kits
+	GetKit(ctx context.Context, businessID uint, productID string) (*entities.Kit, error)
+	ListKits(ctx context.Context, params dtos.ListKitsParams) ([]entities.Kit, int64, error)
+	SaveKit(ctx context.Context, dto request.SaveKitDTO) (*entities.Kit, error)
+	DeleteKit(ctx context.Context, businessID uint, productID string) error
+	GetKitAvailability(ctx context.Context, businessID uint, productID string, warehouseID *uint) (*response.KitAvailabilityResult, error)
+	CreateKitAssemblyOrder(ctx context.Context, dto request.CreateKitAssemblyOrderDTO) (*entities.KitAssemblyOrder, error)
+	GetKitAssemblyOrder(ctx context.Context, businessID, id uint) (*entities.KitAssemblyOrder, error)
+	ListKitAssemblyOrders(ctx context.Context, params dtos.ListKitAssemblyOrdersParams) ([]entities.KitAssemblyOrder, int64, error)
+	CompleteKitAssemblyOrder(ctx context.Context, dto request.KitAssemblyActionDTO) (*entities.KitAssemblyOrder, error)
+	CancelKitAssemblyOrder(ctx context.Context, dto request.KitAssemblyActionDTO) (*entities.KitAssemblyOrder, error)
+
+	// Olas de picking
+	CreatePickWave(ctx context.Context, dto request.CreatePickWaveDTO) (*entities.PickWave, error)
+	GetPickWave(ctx context.Context, businessID, id uint) (*entities.PickWave, error)
+	ListPickWaves(ctx context.Context, params dtos.ListPickWavesParams) ([]entities.PickWave, int64, error)
+	CancelPickWave(ctx context.Context, dto request.PickWaveActionDTO) (*entities.PickWave, error)
+	ConfirmPickLine(ctx context.Context, dto request.ConfirmPickLineDTO) (*entities.PickWave, error)
+	ShortPickLine(ctx context.Context, dto request.ShortPickLineDTO) (*entities.PickWave, error)
+	PackScan(ctx context.Context, dto request.PackScanDTO) (*entities.PickWaveOrder, error)
+	ClosePackage(ctx context.Context, dto request.ClosePackageDTO) (*entities.PickWaveOrder, error)
 }
 
 type useCase struct {
@@ -34,6 +34,16 @@ func (uc *useCase) ExportKardex(ctx context.Context, dto request.KardexExportDTO
 			result.TotalOut += -e.Quantity
 		}
 		result.FinalBalance = e.RunningBalance
+		if e.TotalCost != nil {
+			if *e.TotalCost > 0 {
+				result.TotalValueIn += *e.TotalCost
+			} else {
+				result.TotalValueOut += -*e.TotalCost
+			}
+		}
+		result.FinalValue = e.RunningValue
 	}
+	result.TotalValueIn = roundMoney(result.TotalValueIn)
+	result.TotalValueOut = roundMoney(result.TotalValueOut)
 	return result, nil
 }
@@ -0,0 +1,249 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func giftBox(mode string) *entities.Kit {
+	return &entities.Kit{
+		ID:         1,
+		BusinessID: 10,
+		ProductID:  "caja-regalo",
+		Mode:       mode,
+		IsActive:   true,
+		Components: []entities.KitComponent{
+			{ProductID: "taza", Quantity: 2},
+			{ProductID: "cafe", Quantity: 1},
+		},
+	}
+}
+
+func TestSaveKit_Validaciones(t *testing.T) {
+	cases := []struct {
+		name string
+		dto  request.SaveKitDTO
+		want error
+	}{
+		{
+			name: "modo invalido",
+			dto:  request.SaveKitDTO{ProductID: "kit", Mode: "bundle", Components: []request.KitComponentInput{{ProductID: "a", Quantity: 1}}},
+			want: domainerrors.ErrInvalidKitMode,
+		},
+		{
+			name: "sin componentes",
+			dto:  request.SaveKitDTO{ProductID: "kit"},
+			want: domainerrors.ErrKitEmpty,
+		},
+		{
+			name: "se contiene a si mismo",
+			dto:  request.SaveKitDTO{ProductID: "kit", Components: []request.KitComponentInput{{ProductID: "kit", Quantity: 1}}},
+			want: domainerrors.ErrKitSelfComponent,
+		},
+		{
+			name: "componente duplicado",
+			dto:  request.SaveKitDTO{ProductID: "kit", Components: []request.KitComponentInput{{ProductID: "a", Quantity: 1}, {ProductID: "a", Quantity: 2}}},
+			want: domainerrors.ErrKitDuplicateComponent,
+		},
+		{
+			name: "cantidad cero",
+			dto:  request.SaveKitDTO{ProductID: "kit", Components: []request.KitComponentInput{{ProductID: "a", Quantity: 0}}},
+			want: domainerrors.ErrInvalidQuantity,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			saved := false
+			repo := &mocks.RepositoryMock{
+				SaveKitFn: func(ctx context.Context, params dtos.SaveKitParams) (*entities.Kit, error) {
+					saved = true
+					return nil, nil
+				},
+			}
+			uc := buildUseCase(repo, nil, nil)
+			tc.dto.BusinessID = 10
+
+			_, err := uc.SaveKit(context.Background(), tc.dto)
+
+			assert.ErrorIs(t, err, tc.want)
+			assert.False(t, saved)
+		})
+	}
+}
+
+func TestSaveKit_RechazaKitComoComponente(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		GetKitFn: func(ctx context.Context, businessID uint, productID string) (*entities.Kit, error) {
+			if productID == "otro-kit" {
+				return giftBox(entities.KitModeVirtual), nil
+			}
+			return nil, domainerrors.ErrKitNotFound
+		},
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	_, err := uc.SaveKit(context.Background(), request.SaveKitDTO{
+		BusinessID: 10,
+		ProductID:  "kit",
+		Components: []request.KitComponentInput{{ProductID: "otro-kit", Quantity: 1}},
+	})
+
+	assert.ErrorIs(t, err, domainerrors.ErrKitNestedComponent)
+}
+
+func TestSaveKit_RechazaComponenteComoKit(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		IsKitComponentFn: func(ctx context.Context, businessID uint, productID string) (bool, error) {
+			return true, nil
+		},
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	_, err := uc.SaveKit(context.Background(), request.SaveKitDTO{
+		BusinessID: 10,
+		ProductID:  "taza",
+		Components: []request.KitComponentInput{{ProductID: "cafe", Quantity: 1}},
+	})
+
+	assert.ErrorIs(t, err, domainerrors.ErrKitNestedComponent)
+}
+
+func TestAdjustStock_PublicaDisponibleDelKitVirtual(t *testing.T) {
+	pushed := map[string]int{}
+	repo := &mocks.RepositoryMock{
+		GetKitFn: func(ctx context.Context, businessID uint, productID string) (*entities.Kit, error) {
+			if productID == "caja-regalo" {
+				return giftBox(entities.KitModeVirtual), nil
+			}
+			return nil, domainerrors.ErrKitNotFound
+		},
+		ListVirtualKitsByComponentFn: func(ctx context.Context, businessID uint, componentProductID string) ([]string, error) {
+			return []string{"caja-regalo"}, nil
+		},
+		GetAvailableByProductsFn: func(ctx context.Context, businessID uint, productIDs []string, warehouseID *uint) (map[string]int, error) {
+			return map[string]int{"taza": 9, "cafe": 3}, nil
+		},
+		GetProductIntegrationsFn: func(ctx context.Context, productID string, businessID uint) ([]ports.ProductIntegrationInfo, error) {
+			return []ports.ProductIntegrationInfo{{IntegrationID: 1, ExternalProductID: "ext-" + productID}}, nil
+		},
+	}
+	publisher := &mocks.SyncPublisherMock{
+		PublishEcommerceStockPushFn: func(ctx context.Context, msg ports.EcommerceStockPushMessage) error {
+			pushed[msg.ProductID] = msg.Quantity
+			return nil
+		},
+	}
+	uc := buildUseCase(repo, publisher, nil)
+
+	_, err := uc.AdjustStock(context.Background(), request.AdjustStockDTO{
+		ProductID:   "taza",
+		WarehouseID: 1,
+		BusinessID:  10,
+		Quantity:    5,
+		Reason:      "conteo",
+	})
+
+	require.NoError(t, err)
+	require.Contains(t, pushed, "caja-regalo")
+	assert.Equal(t, 3, pushed["caja-regalo"])
+}
+
+func TestCreateKitAssemblyOrder_KitVirtualNoSeEnsambla(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		GetKitFn: func(ctx context.Context, businessID uint, productID string) (*entities.Kit, error) {
+			return giftBox(entities.KitModeVirtual), nil
+		},
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	_, err := uc.CreateKitAssemblyOrder(context.Background(), request.CreateKitAssemblyOrderDTO{
+		BusinessID:   10,
+		Type:         entities.KitAssemblyTypeAssembly,
+		KitProductID: "caja-regalo",
+		WarehouseID:  1,
+		Quantity:     2,
+	})
+
+	assert.ErrorIs(t, err, domainerrors.ErrKitNotAssembled)
+}
+
+func TestCompleteKitAssemblyOrder_StockInsuficiente(t *testing.T) {
+	moved := false
+	repo := &mocks.RepositoryMock{
+		GetKitFn: func(ctx context.Context, businessID uint, productID string) (*entities.Kit, error) {
+			return giftBox(entities.KitModeAssembled), nil
+		},
+		GetKitAssemblyOrderFn: func(ctx context.Context, businessID, id uint) (*entities.KitAssemblyOrder, error) {
+			return &entities.KitAssemblyOrder{ID: id, BusinessID: businessID, Code: "ENS-000001", Type: entities.KitAssemblyTypeAssembly,
+				KitProductID: "caja-regalo", WarehouseID: 1, Quantity: 5, Status: entities.KitAssemblyPending}, nil
+		},
+		GetAvailableByProductsFn: func(ctx context.Context, businessID uint, productIDs []string, warehouseID *uint) (map[string]int, error) {
+			return map[string]int{"taza": 9, "cafe": 10}, nil
+		},
+		AdjustStockTxFn: func(ctx context.Context, params dtos.AdjustStockTxParams) (*dtos.AdjustStockTxResult, error) {
+			moved = true
+			return &dtos.AdjustStockTxResult{}, nil
+		},
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	_, err := uc.CompleteKitAssemblyOrder(context.Background(), request.KitAssemblyActionDTO{BusinessID: 10, ID: 7})
+
+	assert.ErrorIs(t, err, domainerrors.ErrInsufficientStock)
+	assert.False(t, moved)
+}
+
+func TestCompleteKitAssemblyOrder_EnsambleCosteaElKit(t *testing.T) {
+	var movements []dtos.AdjustStockTxParams
+	var completed dtos.CompleteKitAssemblyParams
+	repo := &mocks.RepositoryMock{
+		GetKitFn: func(ctx context.Context, businessID uint, productID string) (*entities.Kit, error) {
+			return giftBox(entities.KitModeAssembled), nil
+		},
+		GetKitAssemblyOrderFn: func(ctx context.Context, businessID, id uint) (*entities.KitAssemblyOrder, error) {
+			return &entities.KitAssemblyOrder{ID: id, BusinessID: businessID, Code: "ENS-000001", Type: entities.KitAssemblyTypeAssembly,
+				KitProductID: "caja-regalo", WarehouseID: 1, Quantity: 2, Status: entities.KitAssemblyPending}, nil
+		},
+		GetAvailableByProductsFn: func(ctx context.Context, businessID uint, productIDs []string, warehouseID *uint) (map[string]int, error) {
+			return map[string]int{"taza": 4, "cafe": 2}, nil
+		},
+		AdjustStockTxFn: func(ctx context.Context, params dtos.AdjustStockTxParams) (*dtos.AdjustStockTxResult, error) {
+			movements = append(movements, params)
+			var total float64
+			switch params.ProductID {
+			case "taza":
+				total = -4000
+			case "cafe":
+				total = -6000
+			}
+			return &dtos.AdjustStockTxResult{Movement: &entities.StockMovement{TotalCost: &total}}, nil
+		},
+		CompleteKitAssemblyOrderFn: func(ctx context.Context, params dtos.CompleteKitAssemblyParams) error {
+			completed = params
+			return nil
+		},
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	_, err := uc.CompleteKitAssemblyOrder(context.Background(), request.KitAssemblyActionDTO{BusinessID: 10, ID: 7})
+
+	require.NoError(t, err)
+	require.Len(t, movements, 3)
+	assert.Equal(t, -4, movements[0].Quantity)
+	assert.Equal(t, -2, movements[1].Quantity)
+	assert.Equal(t, "caja-regalo", movements[2].ProductID)
+	assert.Equal(t, 2, movements[2].Quantity)
+	require.NotNil(t, movements[2].UnitCost)
+	assert.InDelta(t, 5000, *movements[2].UnitCost, 0.0001)
+	require.NotNil(t, completed.KitUnitCost)
+	assert.InDelta(t, 5000, *completed.KitUnitCost, 0.0001)
+}
@@ -0,0 +1,353 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/response"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+)
+
+func (uc *useCase) GetKit(ctx context.Context, businessID uint, productID string) (*entities.Kit, error) {
+	return uc.repo.GetKit(ctx, businessID, productID)
+}
+
+func (uc *useCase) ListKits(ctx context.Context, params dtos.ListKitsParams) ([]entities.Kit, int64, error) {
+	if params.PageSize < 1 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+	return uc.repo.ListKits(ctx, params)
+}
+
+// SaveKit crea o reemplaza la lista de materiales de un producto. Solo se
+// permite un nivel: un kit no puede ser componente ni tener kits como
+// componentes.
+func (uc *useCase) SaveKit(ctx context.Context, dto request.SaveKitDTO) (*entities.Kit, error) {
+	if dto.Mode == "" {
+		dto.Mode = entities.KitModeVirtual
+	}
+	if !entities.IsValidKitMode(dto.Mode) {
+		return nil, domainerrors.ErrInvalidKitMode
+	}
+	if len(dto.Components) == 0 {
+		return nil, domainerrors.ErrKitEmpty
+	}
+	if _, _, _, err := uc.repo.GetProductByID(ctx, dto.ProductID, dto.BusinessID); err != nil {
+		return nil, domainerrors.ErrProductNotFound
+	}
+	isComponent, err := uc.repo.IsKitComponent(ctx, dto.BusinessID, dto.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	if isComponent {
+		return nil, domainerrors.ErrKitNestedComponent
+	}
+
+	seen := make(map[string]bool, len(dto.Components))
+	components := make([]entities.KitComponent, 0, len(dto.Components))
+	for _, in := range dto.Components {
+		if in.Quantity <= 0 {
+			return nil, domainerrors.ErrInvalidQuantity
+		}
+		if in.ProductID == dto.ProductID {
+			return nil, domainerrors.ErrKitSelfComponent
+		}
+		if seen[in.ProductID] {
+			return nil, fmt.Errorf("%w: %s", domainerrors.ErrKitDuplicateComponent, in.ProductID)
+		}
+		seen[in.ProductID] = true
+		if _, _, _, err := uc.repo.GetProductByID(ctx, in.ProductID, dto.BusinessID); err != nil {
+			return nil, fmt.Errorf("%w: %s", domainerrors.ErrProductNotFound, in.ProductID)
+		}
+		if kit, err := uc.repo.GetKit(ctx, dto.BusinessID, in.ProductID); err == nil && kit != nil {
+			return nil, fmt.Errorf("%w: %s", domainerrors.ErrKitNestedComponent, in.ProductID)
+		}
+		components = append(components, entities.KitComponent{ProductID: in.ProductID, Quantity: in.Quantity})
+	}
+
+	kit, err := uc.repo.SaveKit(ctx, dtos.SaveKitParams{
+		BusinessID: dto.BusinessID,
+		ProductID:  dto.ProductID,
+		Mode:       dto.Mode,
+		Components: components,
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.updateProductTotalStock(ctx, dto.ProductID, dto.BusinessID)
+	return kit, nil
+}
+
+func (uc *useCase) DeleteKit(ctx context.Context, businessID uint, productID string) error {
+	if err := uc.repo.DeleteKit(ctx, businessID, productID); err != nil {
+		return err
+	}
+	uc.updateProductTotalStock(ctx, productID, businessID)
+	return nil
+}
+
+// GetKitAvailability calcula cuantos kits se pueden vender. Un kit virtual
+// depende del disponible de sus componentes; uno armado vende su propio stock
+// y el detalle de componentes indica cuantos mas se pueden ensamblar.
+func (uc *useCase) GetKitAvailability(ctx context.Context, businessID uint, productID string, warehouseID *uint) (*response.KitAvailabilityResult, error) {
+	kit, err := uc.repo.GetKit(ctx, businessID, productID)
+	if err != nil {
+		return nil, err
+	}
+	ids := []string{kit.ProductID}
+	for _, c := range kit.Components {
+		ids = append(ids, c.ProductID)
+	}
+	available, err := uc.repo.GetAvailableByProducts(ctx, businessID, ids, warehouseID)
+	if err != nil {
+		return nil, err
+	}
+
+	ats, detail := entities.KitAvailableToSell(kit.Components, available)
+	if !kit.IsVirtual() {
+		ats = available[kit.ProductID]
+		if ats < 0 {
+			ats = 0
+		}
+	}
+	return &response.KitAvailabilityResult{
+		ProductID:       kit.ProductID,
+		Mode:            kit.Mode,
+		WarehouseID:     warehouseID,
+		AvailableToSell: ats,
+		Components:      detail,
+	}, nil
+}
+
+// pushVirtualKitsStock recalcula y publica a los canales el disponible de los
+// kits virtuales que usan el componente
+func (uc *useCase) pushVirtualKitsStock(ctx context.Context, componentProductID string, businessID uint) {
+	if uc.publisher == nil {
+		return
+	}
+	kitIDs, err := uc.repo.ListVirtualKitsByComponent(ctx, businessID, componentProductID)
+	if err != nil {
+		return
+	}
+	for _, kitID := range kitIDs {
+		result, err := uc.GetKitAvailability(ctx, businessID, kitID, nil)
+		if err != nil {
+			uc.log.Error(ctx).Err(err).Str("kit_product_id", kitID).Msg("Failed to compute kit availability")
+			continue
+		}
+		uc.pushEcommerceStock(ctx, kitID, businessID, result.AvailableToSell)
+	}
+}
+
+func (uc *useCase) CreateKitAssemblyOrder(ctx context.Context, dto request.CreateKitAssemblyOrderDTO) (*entities.KitAssemblyOrder, error) {
+	if dto.Type != entities.KitAssemblyTypeAssembly && dto.Type != entities.KitAssemblyTypeDisassembly {
+		return nil, domainerrors.ErrInvalidAssemblyType
+	}
+	if dto.Quantity <= 0 {
+		return nil, domainerrors.ErrInvalidQuantity
+	}
+	kit, err := uc.repo.GetKit(ctx, dto.BusinessID, dto.KitProductID)
+	if err != nil {
+		return nil, err
+	}
+	if kit.Mode != entities.KitModeAssembled {
+		return nil, domainerrors.ErrKitNotAssembled
+	}
+	exists, err := uc.repo.WarehouseExists(ctx, dto.WarehouseID, dto.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domainerrors.ErrWarehouseNotFound
+	}
+
+	order := &entities.KitAssemblyOrder{
+		BusinessID:   dto.BusinessID,
+		Type:         dto.Type,
+		KitProductID: dto.KitProductID,
+		WarehouseID:  dto.WarehouseID,
+		Quantity:     dto.Quantity,
+		Status:       entities.KitAssemblyPending,
+		Notes:        dto.Notes,
+		CreatedByID:  dto.UserID,
+	}
+	var created *entities.KitAssemblyOrder
+	err = uc.repo.InTransaction(ctx, func(ctx context.Context) error {
+		code, err := uc.repo.NextKitAssemblyCode(ctx, dto.BusinessID)
+		if err != nil {
+			return err
+		}
+		order.Code = code
+		created, err = uc.repo.CreateKitAssemblyOrder(ctx, order)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (uc *useCase) GetKitAssemblyOrder(ctx context.Context, businessID, id uint) (*entities.KitAssemblyOrder, error) {
+	return uc.repo.GetKitAssemblyOrder(ctx, businessID, id)
+}
+
+func (uc *useCase) ListKitAssemblyOrders(ctx context.Context, params dtos.ListKitAssemblyOrdersParams) ([]entities.KitAssemblyOrder, int64, error) {
+	if params.PageSize < 1 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+	return uc.repo.ListKitAssemblyOrders(ctx, params)
+}
+
+// CompleteKitAssemblyOrder mueve el stock de la orden en una sola transaccion.
+// Ensamble: salen los componentes y entra el kit al costo de lo consumido.
+// Desensamble: sale el kit y entran los componentes con su costo repartido
+// segun el costo promedio de cada uno.
+func (uc *useCase) CompleteKitAssemblyOrder(ctx context.Context, dto request.KitAssemblyActionDTO) (*entities.KitAssemblyOrder, error) {
+	order, err := uc.repo.GetKitAssemblyOrder(ctx, dto.BusinessID, dto.ID)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != entities.KitAssemblyPending {
+		return nil, domainerrors.ErrKitAssemblyNotPending
+	}
+	kit, err := uc.repo.GetKit(ctx, dto.BusinessID, order.KitProductID)
+	if err != nil {
+		return nil, err
+	}
+	if kit.Mode != entities.KitModeAssembled {
+		return nil, domainerrors.ErrKitNotAssembled
+	}
+	if err := uc.checkAssemblyStock(ctx, order, kit); err != nil {
+		return nil, err
+	}
+
+	inTypeID, err := uc.repo.GetMovementTypeIDByCode(ctx, "inbound")
+	if err != nil {
+		return nil, err
+	}
+	outTypeID, err := uc.repo.GetMovementTypeIDByCode(ctx, "outbound")
+	if err != nil {
+		return nil, err
+	}
+
+	move := func(ctx context.Context, productID string, qty int, movTypeID uint, unitCost *float64) (*dtos.AdjustStockTxResult, error) {
+		return uc.repo.AdjustStockTx(ctx, dtos.AdjustStockTxParams{
+			ProductID:      productID,
+			WarehouseID:    order.WarehouseID,
+			BusinessID:     order.BusinessID,
+			Quantity:       qty,
+			MovementTypeID: movTypeID,
+			Reason:         assemblyReason(order),
+			ReferenceType:  "kit_assembly",
+			ReferenceID:    order.Code,
+			CreatedByID:    dto.UserID,
+			UnitCost:       unitCost,
+		})
+	}
+
+	err = uc.repo.InTransaction(ctx, func(ctx context.Context) error {
+		var kitUnitCost float64
+		if order.Type == entities.KitAssemblyTypeAssembly {
+			var consumed float64
+			for _, c := range kit.Components {
+				res, err := move(ctx, c.ProductID, -c.Quantity*order.Quantity, outTypeID, nil)
+				if err != nil {
+					return err
+				}
+				if res.Movement != nil && res.Movement.TotalCost != nil {
+					consumed += -*res.Movement.TotalCost
+				}
+			}
+			kitUnitCost = consumed / float64(order.Quantity)
+			if _, err := move(ctx, kit.ProductID, order.Quantity, inTypeID, &kitUnitCost); err != nil {
+				return err
+			}
+		} else {
+			res, err := move(ctx, kit.ProductID, -order.Quantity, outTypeID, nil)
+			if err != nil {
+				return err
+			}
+			if res.Movement != nil && res.Movement.TotalCost != nil {
+				kitUnitCost = -*res.Movement.TotalCost / float64(order.Quantity)
+			}
+			averages := make(map[string]float64, len(kit.Components))
+			for _, c := range kit.Components {
+				balance, err := uc.repo.GetCostBalance(ctx, order.BusinessID, c.ProductID)
+				if err != nil {
+					return err
+				}
+				averages[c.ProductID] = balance.AverageCost
+			}
+			split := entities.SplitKitCost(kit.Components, averages, kitUnitCost)
+			for _, c := range kit.Components {
+				unitCost := split[c.ProductID]
+				if _, err := move(ctx, c.ProductID, c.Quantity*order.Quantity, inTypeID, &unitCost); err != nil {
+					return err
+				}
+			}
+		}
+
+		return uc.repo.CompleteKitAssemblyOrder(ctx, dtos.CompleteKitAssemblyParams{
+			BusinessID:    order.BusinessID,
+			ID:            order.ID,
+			KitUnitCost:   &kitUnitCost,
+			CompletedByID: dto.UserID,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.updateProductTotalStock(ctx, kit.ProductID, order.BusinessID)
+	uc.publishSync(ctx, kit.ProductID, order.BusinessID, 0, order.WarehouseID, "kit_assembly")
+	for _, c := range kit.Components {
+		uc.updateProductTotalStock(ctx, c.ProductID, order.BusinessID)
+		uc.publishSync(ctx, c.ProductID, order.BusinessID, 0, order.WarehouseID, "kit_assembly")
+	}
+
+	return uc.repo.GetKitAssemblyOrder(ctx, dto.BusinessID, dto.ID)
+}
+
+// checkAssemblyStock valida que la bodega tenga disponible lo que la orden va
+// a sacar: los componentes al ensamblar o los kits al desensamblar
+func (uc *useCase) checkAssemblyStock(ctx context.Context, order *entities.KitAssemblyOrder, kit *entities.Kit) error {
+	need := map[string]int{}
+	if order.Type == entities.KitAssemblyTypeAssembly {
+		for _, c := range kit.Components {
+			need[c.ProductID] = c.Quantity * order.Quantity
+		}
+	} else {
+		need[kit.ProductID] = order.Quantity
+	}
+	ids := make([]string, 0, len(need))
+	for id := range need {
+		ids = append(ids, id)
+	}
+	available, err := uc.repo.GetAvailableByProducts(ctx, order.BusinessID, ids, &order.WarehouseID)
+	if err != nil {
+		return err
+	}
+	for id, qty := range need {
+		if available[id] < qty {
+			return fmt.Errorf("%w: producto %s, disponible %d, requerido %d", domainerrors.ErrInsufficientStock, id, available[id], qty)
+		}
+	}
+	return nil
+}
+
+func assemblyReason(order *entities.KitAssemblyOrder) string {
+	if order.Type == entities.KitAssemblyTypeDisassembly {
+		return "Desensamble " + order.Code
+	}
+	return "Ensamble " + order.Code
+}
+
+func (uc *useCase) CancelKitAssemblyOrder(ctx context.Context, dto request.KitAssemblyActionDTO) (*entities.KitAssemblyOrder, error) {
+	if err := uc.repo.CancelKitAssemblyOrder(ctx, dto.BusinessID, dto.ID); err != nil {
+		return nil, err
+	}
+	return uc.repo.GetKitAssemblyOrder(ctx, dto.BusinessID, dto.ID)
+}
@@ -20,6 +20,7 @@ func AdjustStockDTOToTxParams(dto request.AdjustStockDTO, movTypeID uint, refere
 		Notes:          dto.Notes,
 		ReferenceType:  referenceType,
 		CreatedByID:    dto.CreatedByID,
+		UnitCost:       dto.UnitCost,
 	}
 }
 
@@ -0,0 +1,323 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func uintPtr(v uint) *uint { return &v }
+
+// pickingWave es una ola con dos ordenes que piden taza; la de mayor prioridad
+// se atiende primero
+func pickingWave() *entities.PickWave {
+	return &entities.PickWave{
+		ID:         7,
+		BusinessID: 10,
+		Code:       "OLA-000007",
+		Status:     entities.PickWavePicking,
+		Orders: []entities.PickWaveOrder{
+			{ID: 1, WaveID: 7, OrderID: "orden-a", Priority: 5, Status: entities.PickOrderPending,
+				Items: []entities.PickWaveItem{{ID: 11, WaveOrderID: 1, WaveID: 7, ProductID: "taza", Quantity: 2}}},
+			{ID: 2, WaveID: 7, OrderID: "orden-b", Priority: 0, Status: entities.PickOrderPending,
+				Items: []entities.PickWaveItem{{ID: 21, WaveOrderID: 2, WaveID: 7, ProductID: "taza", Quantity: 2}}},
+		},
+		Lines: []entities.PickListLine{
+			{ID: 100, WaveID: 7, Sequence: 1, ProductID: "taza", LocationID: uintPtr(5), LocationCode: "A-01", QuantityRequired: 4, Status: entities.PickLinePending},
+		},
+	}
+}
+
+func pickingScanCodes(_ context.Context, _ uint, code string) (*entities.ScanResolution, error) {
+	switch code {
+	case "A-01":
+		return &entities.ScanResolution{Code: code, CodeType: "location", LocationID: uintPtr(5)}, nil
+	case "A-02":
+		return &entities.ScanResolution{Code: code, CodeType: "location", LocationID: uintPtr(6)}, nil
+	case "TAZA":
+		return &entities.ScanResolution{Code: code, CodeType: "sku", ProductID: "taza"}, nil
+	case "CAFE":
+		return &entities.ScanResolution{Code: code, CodeType: "sku", ProductID: "cafe"}, nil
+	}
+	return nil, nil
+}
+
+func TestCreatePickWave_SinOrdenesCandidatas(t *testing.T) {
+	repo := &mocks.RepositoryMock{}
+	uc := buildUseCase(repo, nil, nil)
+
+	_, err := uc.CreatePickWave(context.Background(), request.CreatePickWaveDTO{BusinessID: 10, WarehouseID: 1})
+
+	assert.ErrorIs(t, err, domainerrors.ErrPickWaveNoOrders)
+}
+
+func TestCreatePickWave_ConsolidaYExplotaKits(t *testing.T) {
+	var created *entities.PickWave
+	repo := &mocks.RepositoryMock{
+		ListPickCandidatesFn: func(_ context.Context, params dtos.PickCandidateParams) ([]entities.PickCandidate, error) {
+			assert.True(t, params.IncludeUnassigned)
+			assert.Equal(t, 50, params.Limit)
+			return []entities.PickCandidate{
+				{OrderID: "orden-a", Items: []entities.PickDemand{{ProductID: "taza", Quantity: 1}}},
+				{OrderID: "orden-b", Items: []entities.PickDemand{{ProductID: "caja-regalo", Quantity: 1}}},
+			}, nil
+		},
+		GetKitFn: func(_ context.Context, _ uint, productID string) (*entities.Kit, error) {
+			if productID == "caja-regalo" {
+				return giftBox(entities.KitModeVirtual), nil
+			}
+			return nil, domainerrors.ErrKitNotFound
+		},
+		ListLocatedStockFn: func(_ context.Context, _, _ uint, productIDs []string, _ *uint) ([]entities.LocatedStock, error) {
+			assert.ElementsMatch(t, []string{"taza", "cafe"}, productIDs)
+			return []entities.LocatedStock{
+				{ProductID: "taza", LocationID: 5, LocationPath: "A/01", Quantity: 10},
+				{ProductID: "cafe", LocationID: 6, LocationPath: "A/02", Quantity: 10},
+			}, nil
+		},
+		CreatePickWaveFn: func(_ context.Context, wave *entities.PickWave) (*entities.PickWave, error) {
+			created = wave
+			return wave, nil
+		},
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	_, err := uc.CreatePickWave(context.Background(), request.CreatePickWaveDTO{BusinessID: 10, WarehouseID: 1})
+
+	require.NoError(t, err)
+	require.NotNil(t, created)
+	assert.Equal(t, "OLA-000001", created.Code)
+	require.Len(t, created.Orders, 2)
+	require.Len(t, created.Lines, 2)
+	assert.Equal(t, "taza", created.Lines[0].ProductID)
+	assert.Equal(t, 3, created.Lines[0].QuantityRequired)
+	assert.Equal(t, "cafe", created.Lines[1].ProductID)
+	assert.Equal(t, 1, created.Lines[1].QuantityRequired)
+}
+
+func TestConfirmPickLine_UbicacionOProductoEquivocado(t *testing.T) {
+	cases := []struct {
+		name     string
+		location string
+		product  string
+		want     error
+	}{
+		{"ubicacion equivocada", "A-02", "TAZA", domainerrors.ErrPickWrongLocation},
+		{"producto equivocado", "A-01", "CAFE", domainerrors.ErrPickWrongProduct},
+		{"codigo desconocido", "A-01", "XYZ", domainerrors.ErrPickWrongProduct},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var scans []string
+			repo := &mocks.RepositoryMock{
+				GetPickWaveFn:     func(context.Context, uint, uint) (*entities.PickWave, error) { return pickingWave(), nil },
+				ResolveScanCodeFn: pickingScanCodes,
+				RecordScanEventFn: func(_ context.Context, event *entities.ScanEvent) (*entities.ScanEvent, error) {
+					scans = append(scans, event.Action)
+					return event, nil
+				},
+				UpdatePickListLineFn: func(context.Context, *entities.PickListLine) error {
+					t.Fatal("no deberia guardar la linea")
+					return nil
+				},
+			}
+			uc := buildUseCase(repo, nil, nil)
+
+			_, err := uc.ConfirmPickLine(context.Background(), request.ConfirmPickLineDTO{
+				BusinessID: 10, WaveID: 7, LineID: 100, LocationCode: tc.location, ProductCode: tc.product,
+			})
+
+			assert.ErrorIs(t, err, tc.want)
+			assert.NotEmpty(t, scans)
+		})
+	}
+}
+
+func TestConfirmPickLine_SobrePicking(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		GetPickWaveFn:     func(context.Context, uint, uint) (*entities.PickWave, error) { return pickingWave(), nil },
+		ResolveScanCodeFn: pickingScanCodes,
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	_, err := uc.ConfirmPickLine(context.Background(), request.ConfirmPickLineDTO{
+		BusinessID: 10, WaveID: 7, LineID: 100, LocationCode: "A-01", ProductCode: "TAZA", Quantity: 5,
+	})
+
+	assert.ErrorIs(t, err, domainerrors.ErrPickOverPick)
+}
+
+func TestConfirmPickLine_SumaSobreLaLineaReleidaEnLaTransaccion(t *testing.T) {
+	// Entre la validacion y la transaccion otro escaneo ya guardo 3 unidades
+	locked := pickingWave()
+	locked.Lines[0].QuantityPicked = 3
+
+	var saved []entities.PickListLine
+	repo := &mocks.RepositoryMock{
+		GetPickWaveFn:     func(context.Context, uint, uint) (*entities.PickWave, error) { return pickingWave(), nil },
+		LockPickWaveFn:    func(context.Context, uint, uint) (*entities.PickWave, error) { return locked, nil },
+		ResolveScanCodeFn: pickingScanCodes,
+		UpdatePickListLineFn: func(_ context.Context, line *entities.PickListLine) error {
+			saved = append(saved, *line)
+			return nil
+		},
+	}
+	uc := buildUseCase(repo, &mocks.SyncPublisherMock{}, nil)
+	dto := request.ConfirmPickLineDTO{BusinessID: 10, WaveID: 7, LineID: 100, LocationCode: "A-01", ProductCode: "TAZA", Quantity: 2}
+
+	_, err := uc.ConfirmPickLine(context.Background(), dto)
+	assert.ErrorIs(t, err, domainerrors.ErrPickOverPick)
+
+	dto.Quantity = 1
+	_, err = uc.ConfirmPickLine(context.Background(), dto)
+
+	require.NoError(t, err)
+	require.Len(t, saved, 1)
+	assert.Equal(t, 4, saved[0].QuantityPicked)
+	assert.Equal(t, entities.PickLinePicked, saved[0].Status)
+}
+
+func TestConfirmPickLine_CompletaLineaYMarcaOrdenesRecogidas(t *testing.T) {
+	var orders []entities.PickWaveOrder
+	var waveStatus string
+	repo := &mocks.RepositoryMock{
+		GetPickWaveFn:     func(context.Context, uint, uint) (*entities.PickWave, error) { return pickingWave(), nil },
+		ResolveScanCodeFn: pickingScanCodes,
+		UpdatePickWaveOrderFn: func(_ context.Context, order *entities.PickWaveOrder) error {
+			orders = append(orders, *order)
+			return nil
+		},
+		UpdatePickWaveStatusFn: func(_ context.Context, params dtos.UpdatePickWaveStatusParams) error {
+			waveStatus = params.Status
+			return nil
+		},
+	}
+	publisher := &mocks.SyncPublisherMock{}
+	uc := buildUseCase(repo, publisher, nil)
+
+	_, err := uc.ConfirmPickLine(context.Background(), request.ConfirmPickLineDTO{
+		BusinessID: 10, WaveID: 7, LineID: 100, LocationCode: "A-01", ProductCode: "TAZA", Quantity: 4,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.Equal(t, entities.PickOrderPicked, orders[0].Status)
+	assert.Equal(t, entities.PickOrderPicked, orders[1].Status)
+	assert.Equal(t, entities.PickWavePicked, waveStatus)
+	require.Len(t, publisher.FeedbackCalls, 2)
+	assert.Equal(t, "inventory.picked", publisher.FeedbackCalls[0].EventType)
+	assert.True(t, publisher.FeedbackCalls[0].Success)
+}
+
+func TestShortPickLine_OrdenDeMenorPrioridadQuedaConFaltante(t *testing.T) {
+	wave := pickingWave()
+	wave.Lines[0].QuantityPicked = 3
+	var items = map[uint]int{}
+	var orders []entities.PickWaveOrder
+	repo := &mocks.RepositoryMock{
+		GetPickWaveFn: func(context.Context, uint, uint) (*entities.PickWave, error) { return wave, nil },
+		UpdatePickWaveItemFn: func(_ context.Context, item *entities.PickWaveItem) error {
+			items[item.ID] = item.PickedQuantity
+			return nil
+		},
+		UpdatePickWaveOrderFn: func(_ context.Context, order *entities.PickWaveOrder) error {
+			orders = append(orders, *order)
+			return nil
+		},
+	}
+	publisher := &mocks.SyncPublisherMock{}
+	uc := buildUseCase(repo, publisher, nil)
+
+	_, err := uc.ShortPickLine(context.Background(), request.ShortPickLineDTO{BusinessID: 10, WaveID: 7, LineID: 100})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, items[11])
+	assert.Equal(t, 1, items[21])
+	require.Len(t, orders, 2)
+	assert.Equal(t, entities.PickOrderPicked, orders[0].Status)
+	assert.Equal(t, entities.PickOrderShort, orders[1].Status)
+	require.Len(t, publisher.FeedbackCalls, 2)
+	assert.Equal(t, "orden-b", publisher.FeedbackCalls[1].OrderID)
+	assert.Equal(t, "inventory.short_pick", publisher.FeedbackCalls[1].EventType)
+	assert.False(t, publisher.FeedbackCalls[1].Success)
+}
+
+// packedWave es la ola ya recogida, lista para la estacion de empaque
+func packedWave() *entities.PickWave {
+	wave := pickingWave()
+	wave.Status = entities.PickWavePicked
+	wave.Lines[0].QuantityPicked = 4
+	wave.Lines[0].Status = entities.PickLinePicked
+	for i := range wave.Orders {
+		wave.Orders[i].Status = entities.PickOrderPicked
+		wave.Orders[i].Items[0].PickedQuantity = 2
+	}
+	return wave
+}
+
+func TestPackScan_ProductoAjenoYSobreEscaneo(t *testing.T) {
+	wave := packedWave()
+	wave.Orders[0].Items[0].PackedQuantity = 2
+	repo := &mocks.RepositoryMock{
+		GetPickWaveFn:     func(context.Context, uint, uint) (*entities.PickWave, error) { return wave, nil },
+		ResolveScanCodeFn: pickingScanCodes,
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	_, err := uc.PackScan(context.Background(), request.PackScanDTO{BusinessID: 10, WaveID: 7, OrderID: "orden-a", Code: "CAFE"})
+	assert.ErrorIs(t, err, domainerrors.ErrPackItemNotExpected)
+
+	_, err = uc.PackScan(context.Background(), request.PackScanDTO{BusinessID: 10, WaveID: 7, OrderID: "orden-a", Code: "TAZA"})
+	assert.ErrorIs(t, err, domainerrors.ErrPackOverScan)
+}
+
+func TestClosePackage_Incompleto(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		GetPickWaveFn: func(context.Context, uint, uint) (*entities.PickWave, error) { return packedWave(), nil },
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	_, err := uc.ClosePackage(context.Background(), request.ClosePackageDTO{BusinessID: 10, WaveID: 7, OrderID: "orden-a", WeightKg: 1})
+
+	assert.ErrorIs(t, err, domainerrors.ErrPackIncomplete)
+}
+
+func TestClosePackage_CreaPaqueteYActualizaMedidas(t *testing.T) {
+	wave := packedWave()
+	wave.Orders[0].Items[0].PackedQuantity = 2
+	var pkg dtos.OrderPackageParams
+	var lpnCode string
+	repo := &mocks.RepositoryMock{
+		GetPickWaveFn: func(context.Context, uint, uint) (*entities.PickWave, error) { return wave, nil },
+		CreateLPNFn: func(_ context.Context, lpn *entities.LicensePlate) (*entities.LicensePlate, error) {
+			lpnCode = lpn.Code
+			lpn.ID = 99
+			return lpn, nil
+		},
+		SetOrderPackageFn: func(_ context.Context, params dtos.OrderPackageParams) error {
+			pkg = params
+			return nil
+		},
+	}
+	publisher := &mocks.SyncPublisherMock{}
+	uc := buildUseCase(repo, publisher, nil)
+
+	order, err := uc.ClosePackage(context.Background(), request.ClosePackageDTO{
+		BusinessID: 10, WaveID: 7, OrderID: "orden-a", WeightKg: 1.5, LengthCm: 20, WidthCm: 15, HeightCm: 10,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.PickOrderPacked, order.Status)
+	assert.Equal(t, "PKG-OLA-000007-1", lpnCode)
+	assert.Equal(t, "orden-a", pkg.OrderID)
+	assert.Equal(t, 1.5, pkg.WeightKg)
+	require.Len(t, publisher.FeedbackCalls, 1)
+	assert.Equal(t, "inventory.packed", publisher.FeedbackCalls[0].EventType)
+}
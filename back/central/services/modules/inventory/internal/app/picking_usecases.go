@@ -0,0 +1,637 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/ports"
+)
+
+const (
+	defaultPickWaveOrders = 50
+	maxPickWaveOrders     = 500
+)
+
+// Eventos que la ola manda a ordenes por la cola de feedback de inventario; el
+// flujo del negocio decide a que estado mueven la orden
+const (
+	feedbackShortPick = "inventory.short_pick"
+	feedbackPicked    = "inventory.picked"
+	feedbackPacked    = "inventory.packed"
+)
+
+// CreatePickWave agrupa las ordenes en picking de la bodega que cumplen los
+// criterios y genera la lista de picking consolidada, ordenada por la ruta de
+// ubicaciones. Los kits virtuales se recogen por sus componentes.
+func (uc *useCase) CreatePickWave(ctx context.Context, dto request.CreatePickWaveDTO) (*entities.PickWave, error) {
+	exists, err := uc.repo.WarehouseExists(ctx, dto.WarehouseID, dto.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domainerrors.ErrWarehouseNotFound
+	}
+	if dto.MaxOrders <= 0 {
+		dto.MaxOrders = defaultPickWaveOrders
+	}
+	if dto.MaxOrders > maxPickWaveOrders {
+		dto.MaxOrders = maxPickWaveOrders
+	}
+
+	params := dtos.PickCandidateParams{
+		BusinessID:  dto.BusinessID,
+		WarehouseID: dto.WarehouseID,
+		CarrierCode: dto.CarrierCode,
+		CutoffAt:    dto.CutoffAt,
+		MinPriority: dto.MinPriority,
+	}
+	if defaultID, err := uc.repo.GetDefaultWarehouseID(ctx, dto.BusinessID); err == nil && defaultID == dto.WarehouseID {
+		params.IncludeUnassigned = true
+	}
+	// Con filtro de zona el corte se hace despues de descartar las ordenes
+	// que no se pueden recoger completas en la zona
+	if dto.ZoneID == nil {
+		params.Limit = dto.MaxOrders
+	}
+	candidates, err := uc.repo.ListPickCandidates(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := uc.explodePickKits(ctx, dto.BusinessID, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	var stock []entities.LocatedStock
+	if len(orders) > 0 {
+		stock, err = uc.repo.ListLocatedStock(ctx, dto.BusinessID, dto.WarehouseID, demandProductIDs(orders), dto.ZoneID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if dto.ZoneID != nil {
+		orders = filterByZoneStock(orders, stock)
+	}
+	if len(orders) > dto.MaxOrders {
+		orders = orders[:dto.MaxOrders]
+	}
+	if len(orders) == 0 {
+		return nil, domainerrors.ErrPickWaveNoOrders
+	}
+
+	demand := make(map[string]int)
+	waveOrders := make([]entities.PickWaveOrder, 0, len(orders))
+	for _, o := range orders {
+		wo := entities.PickWaveOrder{
+			OrderID:     o.OrderID,
+			OrderNumber: o.OrderNumber,
+			Priority:    o.Priority,
+			Status:      entities.PickOrderPending,
+		}
+		for _, d := range o.Items {
+			demand[d.ProductID] += d.Quantity
+			wo.Items = append(wo.Items, entities.PickWaveItem{ProductID: d.ProductID, Quantity: d.Quantity})
+		}
+		waveOrders = append(waveOrders, wo)
+	}
+
+	wave := &entities.PickWave{
+		BusinessID:  dto.BusinessID,
+		WarehouseID: dto.WarehouseID,
+		Status:      entities.PickWaveReleased,
+		ZoneID:      dto.ZoneID,
+		MinPriority: dto.MinPriority,
+		CutoffAt:    dto.CutoffAt,
+		MaxOrders:   dto.MaxOrders,
+		Notes:       dto.Notes,
+		CreatedByID: dto.UserID,
+		Orders:      waveOrders,
+		Lines:       entities.BuildPickList(demand, stock),
+	}
+	if dto.CarrierCode != "" {
+		carrier := dto.CarrierCode
+		wave.CarrierCode = &carrier
+	}
+
+	var created *entities.PickWave
+	err = uc.repo.InTransaction(ctx, func(ctx context.Context) error {
+		code, err := uc.repo.NextPickWaveCode(ctx, dto.BusinessID)
+		if err != nil {
+			return err
+		}
+		wave.Code = code
+		created, err = uc.repo.CreatePickWave(ctx, wave)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// explodePickKits reemplaza los kits virtuales por sus componentes, que son
+// los que estan fisicamente en las ubicaciones, y descarta las ordenes sin
+// nada que recoger
+func (uc *useCase) explodePickKits(ctx context.Context, businessID uint, candidates []entities.PickCandidate) ([]entities.PickCandidate, error) {
+	kits := make(map[string]*entities.Kit)
+	out := make([]entities.PickCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		qty := make(map[string]int)
+		var order []string
+		add := func(productID string, q int) {
+			if _, ok := qty[productID]; !ok {
+				order = append(order, productID)
+			}
+			qty[productID] += q
+		}
+		for _, item := range c.Items {
+			kit, cached := kits[item.ProductID]
+			if !cached {
+				found, err := uc.repo.GetKit(ctx, businessID, item.ProductID)
+				if err == nil && found.IsVirtual() && found.IsActive {
+					kit = found
+				}
+				kits[item.ProductID] = kit
+			}
+			if kit == nil {
+				add(item.ProductID, item.Quantity)
+				continue
+			}
+			for _, comp := range kit.Components {
+				add(comp.ProductID, comp.Quantity*item.Quantity)
+			}
+		}
+		if len(order) == 0 {
+			continue
+		}
+		c.Items = make([]entities.PickDemand, len(order))
+		for i, productID := range order {
+			c.Items[i] = entities.PickDemand{ProductID: productID, Quantity: qty[productID]}
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+func demandProductIDs(orders []entities.PickCandidate) []string {
+	seen := make(map[string]bool)
+	var ids []string
+	for _, o := range orders {
+		for _, d := range o.Items {
+			if !seen[d.ProductID] {
+				seen[d.ProductID] = true
+				ids = append(ids, d.ProductID)
+			}
+		}
+	}
+	return ids
+}
+
+// filterByZoneStock deja las ordenes cuyos productos se pueden recoger
+// completos en la zona, descontando lo que ya tomaron las ordenes anteriores
+func filterByZoneStock(orders []entities.PickCandidate, stock []entities.LocatedStock) []entities.PickCandidate {
+	left := make(map[string]int)
+	for _, s := range stock {
+		left[s.ProductID] += s.Quantity
+	}
+	var out []entities.PickCandidate
+	for _, o := range orders {
+		fits := true
+		for _, d := range o.Items {
+			if left[d.ProductID] < d.Quantity {
+				fits = false
+				break
+			}
+		}
+		if !fits {
+			continue
+		}
+		for _, d := range o.Items {
+			left[d.ProductID] -= d.Quantity
+		}
+		out = append(out, o)
+	}
+	return out
+}
+
+func (uc *useCase) GetPickWave(ctx context.Context, businessID, id uint) (*entities.PickWave, error) {
+	return uc.repo.GetPickWave(ctx, businessID, id)
+}
+
+func (uc *useCase) ListPickWaves(ctx context.Context, params dtos.ListPickWavesParams) ([]entities.PickWave, int64, error) {
+	if params.PageSize < 1 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+	return uc.repo.ListPickWaves(ctx, params)
+}
+
+// CancelPickWave cancela una ola que todavia no termino el picking; sus
+// ordenes quedan libres para otra ola
+func (uc *useCase) CancelPickWave(ctx context.Context, dto request.PickWaveActionDTO) (*entities.PickWave, error) {
+	if err := uc.repo.UpdatePickWaveStatus(ctx, dtos.UpdatePickWaveStatusParams{
+		BusinessID: dto.BusinessID,
+		ID:         dto.ID,
+		From:       []string{entities.PickWaveReleased, entities.PickWavePicking},
+		Status:     entities.PickWaveCancelled,
+		At:         time.Now(),
+	}); err != nil {
+		return nil, err
+	}
+	return uc.repo.GetPickWave(ctx, dto.BusinessID, dto.ID)
+}
+
+// ConfirmPickLine registra lo recogido en una linea. El picker escanea la
+// ubicacion de la linea y luego el producto; ambos escaneos quedan en el
+// historial de escaneos.
+func (uc *useCase) ConfirmPickLine(ctx context.Context, dto request.ConfirmPickLineDTO) (*entities.PickWave, error) {
+	if dto.Quantity == 0 {
+		dto.Quantity = 1
+	}
+	if dto.Quantity < 0 {
+		return nil, domainerrors.ErrInvalidQuantity
+	}
+	line, err := uc.openPickLine(ctx, dto.BusinessID, dto.WaveID, dto.LineID)
+	if err != nil {
+		return nil, err
+	}
+
+	if line.LocationID != nil {
+		res, err := uc.resolvePickScan(ctx, dto.BusinessID, dto.UserID, dto.DeviceID, dto.LocationCode, "pick_location")
+		if err != nil {
+			return nil, err
+		}
+		if res == nil || res.LocationID == nil || *res.LocationID != *line.LocationID {
+			return nil, domainerrors.ErrPickWrongLocation
+		}
+	}
+	res, err := uc.resolvePickScan(ctx, dto.BusinessID, dto.UserID, dto.DeviceID, dto.ProductCode, "pick")
+	if err != nil {
+		return nil, err
+	}
+	if res == nil || res.ProductID != line.ProductID {
+		return nil, domainerrors.ErrPickWrongProduct
+	}
+
+	return uc.savePickLine(ctx, dto.BusinessID, dto.WaveID, dto.LineID, func(line *entities.PickListLine) error {
+		picked := line.QuantityPicked + dto.Quantity
+		if picked > line.QuantityRequired {
+			return fmt.Errorf("%w: requerido %d, recogido %d", domainerrors.ErrPickOverPick, line.QuantityRequired, picked)
+		}
+		now := time.Now()
+		line.QuantityPicked = picked
+		line.PickedByID = dto.UserID
+		line.PickedAt = &now
+		if picked == line.QuantityRequired {
+			line.Status = entities.PickLinePicked
+		}
+		return nil
+	})
+}
+
+// ShortPickLine cierra la linea con lo recogido hasta el momento. Las ordenes
+// que quedan sin completar pasan a faltante y ordenes lo recibe como
+// inventory.short_pick.
+func (uc *useCase) ShortPickLine(ctx context.Context, dto request.ShortPickLineDTO) (*entities.PickWave, error) {
+	return uc.savePickLine(ctx, dto.BusinessID, dto.WaveID, dto.LineID, func(line *entities.PickListLine) error {
+		now := time.Now()
+		line.Status = entities.PickLineShort
+		line.PickedByID = dto.UserID
+		line.PickedAt = &now
+		return nil
+	})
+}
+
+// openPickLine lee la linea fuera de la transaccion para validar los escaneos
+// contra su ubicacion y producto, que no cambian
+func (uc *useCase) openPickLine(ctx context.Context, businessID, waveID, lineID uint) (*entities.PickListLine, error) {
+	wave, err := uc.repo.GetPickWave(ctx, businessID, waveID)
+	if err != nil {
+		return nil, err
+	}
+	return openLineOf(wave, lineID)
+}
+
+func openLineOf(wave *entities.PickWave, lineID uint) (*entities.PickListLine, error) {
+	if !wave.IsOpen() {
+		return nil, domainerrors.ErrPickWaveClosed
+	}
+	for i := range wave.Lines {
+		if wave.Lines[i].ID != lineID {
+			continue
+		}
+		if wave.Lines[i].IsClosed() {
+			return nil, domainerrors.ErrPickLineClosed
+		}
+		return &wave.Lines[i], nil
+	}
+	return nil, domainerrors.ErrPickLineNotFound
+}
+
+// savePickLine aplica apply a la linea y la guarda; si con ella quedan
+// cerradas todas las lineas del producto, reparte lo recogido entre las
+// ordenes de la ola. La ola se relee bloqueada dentro de la transaccion: dos
+// escaneos simultaneos suman sobre lo ya guardado y no sobre una lectura vieja
+func (uc *useCase) savePickLine(ctx context.Context, businessID, waveID, lineID uint, apply func(line *entities.PickListLine) error) (*entities.PickWave, error) {
+	var feedback []ports.OrderFeedbackMessage
+	err := uc.repo.InTransaction(ctx, func(ctx context.Context) error {
+		wave, err := uc.repo.LockPickWave(ctx, businessID, waveID)
+		if err != nil {
+			return err
+		}
+		line, err := openLineOf(wave, lineID)
+		if err != nil {
+			return err
+		}
+		if err := apply(line); err != nil {
+			return err
+		}
+		if err := uc.repo.UpdatePickListLine(ctx, line); err != nil {
+			return err
+		}
+		if wave.Status == entities.PickWaveReleased {
+			if err := uc.repo.UpdatePickWaveStatus(ctx, dtos.UpdatePickWaveStatusParams{
+				BusinessID: wave.BusinessID,
+				ID:         wave.ID,
+				From:       []string{entities.PickWaveReleased},
+				Status:     entities.PickWavePicking,
+				At:         time.Now(),
+			}); err != nil {
+				return err
+			}
+			wave.Status = entities.PickWavePicking
+		}
+		feedback, err = uc.settlePickedProduct(ctx, wave, line.ProductID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.publishOrderFeedback(ctx, feedback)
+	return uc.repo.GetPickWave(ctx, businessID, waveID)
+}
+
+func (uc *useCase) settlePickedProduct(ctx context.Context, wave *entities.PickWave, productID string) ([]ports.OrderFeedbackMessage, error) {
+	closed := make(map[string]bool)
+	picked := 0
+	allClosed := true
+	for _, l := range wave.Lines {
+		if _, ok := closed[l.ProductID]; !ok {
+			closed[l.ProductID] = true
+		}
+		if !l.IsClosed() {
+			closed[l.ProductID] = false
+			allClosed = false
+		}
+		if l.ProductID == productID {
+			picked += l.QuantityPicked
+		}
+	}
+	if !closed[productID] {
+		return nil, nil
+	}
+
+	alloc := entities.DistributePicked(wave.Orders, productID, picked)
+	var feedback []ports.OrderFeedbackMessage
+	for i := range wave.Orders {
+		order := &wave.Orders[i]
+		touched := false
+		for j := range order.Items {
+			item := &order.Items[j]
+			if item.ProductID != productID {
+				continue
+			}
+			item.PickedQuantity = alloc[item.ID]
+			if err := uc.repo.UpdatePickWaveItem(ctx, item); err != nil {
+				return nil, err
+			}
+			touched = true
+		}
+		if !touched || order.Status != entities.PickOrderPending {
+			continue
+		}
+
+		status := entities.PickOrderPicked
+		for _, item := range order.Items {
+			if !closed[item.ProductID] {
+				status = entities.PickOrderPending
+			} else if item.PickedQuantity < item.Quantity {
+				status = entities.PickOrderShort
+				break
+			}
+		}
+		if status == entities.PickOrderPending {
+			continue
+		}
+		order.Status = status
+		if err := uc.repo.UpdatePickWaveOrder(ctx, order); err != nil {
+			return nil, err
+		}
+		msg := ports.OrderFeedbackMessage{OrderID: order.OrderID, BusinessID: wave.BusinessID, Success: true, EventType: feedbackPicked}
+		if status == entities.PickOrderShort {
+			msg.Success = false
+			msg.EventType = feedbackShortPick
+		}
+		feedback = append(feedback, msg)
+	}
+
+	if allClosed {
+		if err := uc.repo.UpdatePickWaveStatus(ctx, dtos.UpdatePickWaveStatusParams{
+			BusinessID: wave.BusinessID,
+			ID:         wave.ID,
+			From:       []string{entities.PickWaveReleased, entities.PickWavePicking},
+			Status:     entities.PickWavePicked,
+			At:         time.Now(),
+		}); err != nil {
+			return nil, err
+		}
+		wave.Status = entities.PickWavePicked
+	}
+	return feedback, nil
+}
+
+// PackScan verifica en la estacion de empaque un producto de la orden contra lo
+// recogido en la ola
+func (uc *useCase) PackScan(ctx context.Context, dto request.PackScanDTO) (*entities.PickWaveOrder, error) {
+	if dto.Quantity == 0 {
+		dto.Quantity = 1
+	}
+	if dto.Quantity < 0 {
+		return nil, domainerrors.ErrInvalidQuantity
+	}
+	_, order, err := uc.packingOrder(ctx, dto.BusinessID, dto.WaveID, dto.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := uc.resolvePickScan(ctx, dto.BusinessID, dto.UserID, dto.DeviceID, dto.Code, "pack")
+	if err != nil {
+		return nil, err
+	}
+	if res == nil || res.ProductID == "" {
+		return nil, domainerrors.ErrPackItemNotExpected
+	}
+	for i := range order.Items {
+		item := &order.Items[i]
+		if item.ProductID != res.ProductID {
+			continue
+		}
+		if item.PackedQuantity+dto.Quantity > item.PickedQuantity {
+			return nil, fmt.Errorf("%w: recogido %d, empacado %d", domainerrors.ErrPackOverScan, item.PickedQuantity, item.PackedQuantity)
+		}
+		item.PackedQuantity += dto.Quantity
+		if err := uc.repo.UpdatePickWaveItem(ctx, item); err != nil {
+			return nil, err
+		}
+		return order, nil
+	}
+	return nil, domainerrors.ErrPackItemNotExpected
+}
+
+// ClosePackage cierra el paquete de una orden verificada completa: crea el LPN
+// tipo package con su contenido y copia peso y medidas a la orden para la
+// generacion de la guia
+func (uc *useCase) ClosePackage(ctx context.Context, dto request.ClosePackageDTO) (*entities.PickWaveOrder, error) {
+	wave, order, err := uc.packingOrder(ctx, dto.BusinessID, dto.WaveID, dto.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if !order.IsFullyPacked() {
+		return nil, domainerrors.ErrPackIncomplete
+	}
+
+	err = uc.repo.InTransaction(ctx, func(ctx context.Context) error {
+		lpn, err := uc.repo.CreateLPN(ctx, &entities.LicensePlate{
+			BusinessID: dto.BusinessID,
+			Code:       fmt.Sprintf("PKG-%s-%d", wave.Code, order.ID),
+			LpnType:    "package",
+			Status:     "active",
+		})
+		if err != nil {
+			return err
+		}
+		for _, item := range order.Items {
+			if _, err := uc.repo.AddLPNLine(ctx, &entities.LicensePlateLine{
+				LpnID:      lpn.ID,
+				BusinessID: dto.BusinessID,
+				ProductID:  item.ProductID,
+				Qty:        item.PackedQuantity,
+			}); err != nil {
+				return err
+			}
+		}
+		if err := uc.repo.SetOrderPackage(ctx, dtos.OrderPackageParams{
+			OrderID:  order.OrderID,
+			WeightKg: dto.WeightKg,
+			LengthCm: dto.LengthCm,
+			WidthCm:  dto.WidthCm,
+			HeightCm: dto.HeightCm,
+		}); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		order.Status = entities.PickOrderPacked
+		order.PackageLpnID = &lpn.ID
+		order.PackageLpnCode = lpn.Code
+		order.PackedByID = dto.UserID
+		order.PackedAt = &now
+		if err := uc.repo.UpdatePickWaveOrder(ctx, order); err != nil {
+			return err
+		}
+
+		if wave.Status != entities.PickWavePicked {
+			return nil
+		}
+		for _, o := range wave.Orders {
+			if o.Status != entities.PickOrderPacked && o.Status != entities.PickOrderShort {
+				return nil
+			}
+		}
+		return uc.repo.UpdatePickWaveStatus(ctx, dtos.UpdatePickWaveStatusParams{
+			BusinessID: wave.BusinessID,
+			ID:         wave.ID,
+			From:       []string{entities.PickWavePicked},
+			Status:     entities.PickWavePacked,
+			At:         now,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.publishOrderFeedback(ctx, []ports.OrderFeedbackMessage{{
+		OrderID:    order.OrderID,
+		BusinessID: wave.BusinessID,
+		Success:    true,
+		EventType:  feedbackPacked,
+	}})
+	return order, nil
+}
+
+// packingOrder busca la orden en la ola; solo se empaca lo que se recogio
+// completo
+func (uc *useCase) packingOrder(ctx context.Context, businessID, waveID uint, orderID string) (*entities.PickWave, *entities.PickWaveOrder, error) {
+	wave, err := uc.repo.GetPickWave(ctx, businessID, waveID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if wave.Status == entities.PickWaveCancelled {
+		return nil, nil, domainerrors.ErrPickWaveClosed
+	}
+	for i := range wave.Orders {
+		if wave.Orders[i].OrderID != orderID {
+			continue
+		}
+		if wave.Orders[i].Status != entities.PickOrderPicked {
+			return nil, nil, domainerrors.ErrPackOrderNotReady
+		}
+		return wave, &wave.Orders[i], nil
+	}
+	return nil, nil, domainerrors.ErrPickOrderNotFound
+}
+
+// resolvePickScan resuelve el codigo y lo deja en el historial de escaneos con
+// la accion de picking o empaque, aunque no corresponda a lo esperado
+func (uc *useCase) resolvePickScan(ctx context.Context, businessID uint, userID *uint, deviceID, code, action string) (*entities.ScanResolution, error) {
+	if code == "" {
+		return nil, nil
+	}
+	res, err := uc.repo.ResolveScanCode(ctx, businessID, code)
+	if err != nil {
+		return nil, err
+	}
+	codeType := "unknown"
+	if res != nil {
+		codeType = res.CodeType
+	}
+	if _, err := uc.repo.RecordScanEvent(ctx, &entities.ScanEvent{
+		BusinessID:  businessID,
+		UserID:      userID,
+		DeviceID:    deviceID,
+		ScannedCode: code,
+		CodeType:    codeType,
+		Action:      action,
+		ScannedAt:   time.Now(),
+	}); err != nil {
+		uc.log.Error(ctx).Err(err).Str("code", code).Msg("Failed to record scan event")
+	}
+	return res, nil
+}
+
+func (uc *useCase) publishOrderFeedback(ctx context.Context, messages []ports.OrderFeedbackMessage) {
+	if uc.publisher == nil {
+		return
+	}
+	for _, msg := range messages {
+		if err := uc.publisher.PublishOrderFeedback(ctx, msg); err != nil {
+			uc.log.Error(ctx).Err(err).Str("order_id", msg.OrderID).Str("event_type", msg.EventType).Msg("Failed to publish order feedback")
+		}
+	}
+}
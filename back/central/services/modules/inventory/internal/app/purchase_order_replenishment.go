@@ -0,0 +1,120 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/response"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+)
+
+// GenerateDraftPurchaseOrders toma los mismos candidatos que la deteccion de
+// reabastecimiento (niveles bajo el punto de reorden) y arma ordenes de compra
+// en borrador, una por proveedor preferido y bodega. Descuenta lo que ya viene
+// en ordenes abiertas para no pedir dos veces el mismo faltante.
+func (uc *useCase) GenerateDraftPurchaseOrders(ctx context.Context, dto request.GenerateDraftPurchaseOrdersDTO) (*response.DraftPurchaseOrdersResult, error) {
+	candidates, err := uc.repo.DetectReplenishmentCandidates(ctx, dto.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+
+	type need struct {
+		productID   string
+		warehouseID uint
+		deficit     int
+	}
+	var needs []*need
+	byKey := make(map[string]*need)
+	for _, c := range candidates {
+		key := fmt.Sprintf("%s|%d", c.ProductID, c.WarehouseID)
+		n, ok := byKey[key]
+		if !ok {
+			n = &need{productID: c.ProductID, warehouseID: c.WarehouseID}
+			byKey[key] = n
+			needs = append(needs, n)
+		}
+		n.deficit += c.Quantity
+	}
+
+	type draft struct {
+		supplierID  uint
+		warehouseID uint
+		leadDays    int
+		lines       []request.PurchaseOrderLineInput
+	}
+	var drafts []*draft
+	draftByKey := make(map[string]*draft)
+	result := &response.DraftPurchaseOrdersResult{}
+
+	for _, n := range needs {
+		open, err := uc.repo.OpenPurchaseQuantity(ctx, dto.BusinessID, n.warehouseID, n.productID)
+		if err != nil {
+			return nil, err
+		}
+		qty := n.deficit - open
+		if qty <= 0 {
+			result.AlreadyOrdered = append(result.AlreadyOrdered, n.productID)
+			continue
+		}
+
+		sp, err := uc.repo.GetPreferredSupplierProduct(ctx, dto.BusinessID, n.productID)
+		if err != nil {
+			if errors.Is(err, domainerrors.ErrSupplierProductNotFound) {
+				result.WithoutSupplier = append(result.WithoutSupplier, n.productID)
+				continue
+			}
+			return nil, err
+		}
+		if qty < sp.MinOrderQty {
+			qty = sp.MinOrderQty
+		}
+
+		key := fmt.Sprintf("%d|%d", sp.SupplierID, n.warehouseID)
+		d, ok := draftByKey[key]
+		if !ok {
+			d = &draft{supplierID: sp.SupplierID, warehouseID: n.warehouseID}
+			draftByKey[key] = d
+			drafts = append(drafts, d)
+		}
+		if sp.LeadTimeDays != nil && *sp.LeadTimeDays > d.leadDays {
+			d.leadDays = *sp.LeadTimeDays
+		}
+		d.lines = append(d.lines, request.PurchaseOrderLineInput{
+			ProductID: n.productID,
+			Quantity:  qty,
+			UnitCost:  sp.UnitCost,
+		})
+	}
+
+	for _, d := range drafts {
+		create := request.CreatePurchaseOrderDTO{
+			BusinessID:  dto.BusinessID,
+			SupplierID:  d.supplierID,
+			WarehouseID: d.warehouseID,
+			Source:      "replenishment",
+			Notes:       "Generada por reabastecimiento",
+			Lines:       d.lines,
+			CreatedByID: dto.UserID,
+		}
+		if d.leadDays > 0 {
+			expected := time.Now().AddDate(0, 0, d.leadDays)
+			create.ExpectedAt = &expected
+		}
+		po, err := uc.CreatePurchaseOrder(ctx, create)
+		if err != nil {
+			uc.log.Error(ctx).Err(err).Uint("supplier_id", d.supplierID).Msg("Failed to create draft purchase order from replenishment")
+			continue
+		}
+		result.PurchaseOrders = append(result.PurchaseOrders, *po)
+		result.Created++
+	}
+
+	if result.PurchaseOrders == nil {
+		result.PurchaseOrders = []entities.PurchaseOrder{}
+	}
+	return result, nil
+}
@@ -0,0 +1,384 @@
+package app
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+)
+
+func (uc *useCase) CreatePurchaseOrder(ctx context.Context, dto request.CreatePurchaseOrderDTO) (*entities.PurchaseOrder, error) {
+	supplier, err := uc.repo.GetSupplierByID(ctx, dto.BusinessID, dto.SupplierID)
+	if err != nil {
+		return nil, err
+	}
+	if !supplier.IsActive {
+		return nil, domainerrors.ErrSupplierInactive
+	}
+	exists, err := uc.repo.WarehouseExists(ctx, dto.WarehouseID, dto.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domainerrors.ErrWarehouseNotFound
+	}
+
+	lines, err := uc.buildPurchaseOrderLines(ctx, dto.BusinessID, dto.Lines)
+	if err != nil {
+		return nil, err
+	}
+
+	po := &entities.PurchaseOrder{
+		BusinessID:               dto.BusinessID,
+		SupplierID:               supplier.ID,
+		WarehouseID:              dto.WarehouseID,
+		Status:                   entities.PurchaseOrderDraft,
+		Source:                   dto.Source,
+		Currency:                 dto.Currency,
+		ExpectedAt:               dto.ExpectedAt,
+		OverReceiptTolerancePct:  supplier.OverReceiptTolerancePct,
+		UnderReceiptTolerancePct: supplier.UnderReceiptTolerancePct,
+		Notes:                    dto.Notes,
+		CreatedByID:              dto.CreatedByID,
+		Lines:                    lines,
+	}
+	if po.Source == "" {
+		po.Source = "manual"
+	}
+	if po.Currency == "" {
+		po.Currency = "COP"
+	}
+	if po.ExpectedAt == nil && supplier.LeadTimeDays > 0 {
+		expected := time.Now().AddDate(0, 0, supplier.LeadTimeDays)
+		po.ExpectedAt = &expected
+	}
+	if dto.OverReceiptTolerancePct != nil {
+		po.OverReceiptTolerancePct = *dto.OverReceiptTolerancePct
+	}
+	if dto.UnderReceiptTolerancePct != nil {
+		po.UnderReceiptTolerancePct = *dto.UnderReceiptTolerancePct
+	}
+	if !validTolerance(po.OverReceiptTolerancePct) || !validTolerance(po.UnderReceiptTolerancePct) {
+		return nil, domainerrors.ErrInvalidTolerance
+	}
+	po.Subtotal = purchaseOrderSubtotal(po.Lines)
+
+	var created *entities.PurchaseOrder
+	err = uc.repo.InTransaction(ctx, func(ctx context.Context) error {
+		code, err := uc.repo.NextPurchaseOrderCode(ctx, dto.BusinessID)
+		if err != nil {
+			return err
+		}
+		po.Code = code
+		created, err = uc.repo.CreatePurchaseOrder(ctx, po)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (uc *useCase) buildPurchaseOrderLines(ctx context.Context, businessID uint, inputs []request.PurchaseOrderLineInput) ([]entities.PurchaseOrderLine, error) {
+	if len(inputs) == 0 {
+		return nil, domainerrors.ErrPurchaseOrderEmpty
+	}
+	lines := make([]entities.PurchaseOrderLine, 0, len(inputs))
+	for _, in := range inputs {
+		if in.Quantity <= 0 || in.UnitCost < 0 {
+			return nil, domainerrors.ErrInvalidQuantity
+		}
+		if _, _, _, err := uc.repo.GetProductByID(ctx, in.ProductID, businessID); err != nil {
+			return nil, domainerrors.ErrProductNotFound
+		}
+		lines = append(lines, entities.PurchaseOrderLine{
+			ProductID:       in.ProductID,
+			QuantityOrdered: in.Quantity,
+			UnitCost:        in.UnitCost,
+			ExpectedAt:      in.ExpectedAt,
+			Notes:           in.Notes,
+		})
+	}
+	return lines, nil
+}
+
+func (uc *useCase) GetPurchaseOrder(ctx context.Context, businessID, id uint) (*entities.PurchaseOrder, error) {
+	return uc.repo.GetPurchaseOrderByID(ctx, businessID, id)
+}
+
+func (uc *useCase) ListPurchaseOrders(ctx context.Context, params dtos.ListPurchaseOrdersParams) ([]entities.PurchaseOrder, int64, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 || params.PageSize > 100 {
+		params.PageSize = 10
+	}
+	return uc.repo.ListPurchaseOrders(ctx, params)
+}
+
+// UpdatePurchaseOrder edita una orden que sigue en borrador. Las tolerancias se
+// pueden ajustar mientras la orden no este cerrada.
+func (uc *useCase) UpdatePurchaseOrder(ctx context.Context, dto request.UpdatePurchaseOrderDTO) (*entities.PurchaseOrder, error) {
+	existing, err := uc.repo.GetPurchaseOrderByID(ctx, dto.BusinessID, dto.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !existing.IsOpen() {
+		return nil, domainerrors.ErrPurchaseOrderNotEditable
+	}
+	draft := existing.Status == entities.PurchaseOrderDraft
+	if !draft && (dto.Lines != nil || dto.ExpectedAt != nil) {
+		return nil, domainerrors.ErrPurchaseOrderNotEditable
+	}
+
+	if dto.ExpectedAt != nil {
+		existing.ExpectedAt = dto.ExpectedAt
+	}
+	if dto.OverReceiptTolerancePct != nil {
+		existing.OverReceiptTolerancePct = *dto.OverReceiptTolerancePct
+	}
+	if dto.UnderReceiptTolerancePct != nil {
+		existing.UnderReceiptTolerancePct = *dto.UnderReceiptTolerancePct
+	}
+	if !validTolerance(existing.OverReceiptTolerancePct) || !validTolerance(existing.UnderReceiptTolerancePct) {
+		return nil, domainerrors.ErrInvalidTolerance
+	}
+	if dto.Notes != nil {
+		existing.Notes = *dto.Notes
+	}
+
+	var lines []entities.PurchaseOrderLine
+	if dto.Lines != nil {
+		lines, err = uc.buildPurchaseOrderLines(ctx, dto.BusinessID, dto.Lines)
+		if err != nil {
+			return nil, err
+		}
+		existing.Subtotal = purchaseOrderSubtotal(lines)
+	}
+
+	err = uc.repo.InTransaction(ctx, func(ctx context.Context) error {
+		if lines != nil {
+			if existing.LandedCostTotal > 0 {
+				costs, err := uc.repo.ListLandedCosts(ctx, existing.ID)
+				if err != nil {
+					return err
+				}
+				for i, amount := range allocateLandedCosts(lines, costs) {
+					lines[i].LandedCostAllocated = amount
+				}
+			}
+			if err := uc.repo.ReplacePurchaseOrderLines(ctx, existing.ID, lines); err != nil {
+				return err
+			}
+		}
+		return uc.repo.UpdatePurchaseOrder(ctx, existing)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.repo.GetPurchaseOrderByID(ctx, dto.BusinessID, dto.ID)
+}
+
+func (uc *useCase) ApprovePurchaseOrder(ctx context.Context, dto request.PurchaseOrderActionDTO) (*entities.PurchaseOrder, error) {
+	existing, err := uc.repo.GetPurchaseOrderByID(ctx, dto.BusinessID, dto.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Status != entities.PurchaseOrderDraft {
+		return nil, domainerrors.ErrPurchaseOrderTransition
+	}
+	if len(existing.Lines) == 0 {
+		return nil, domainerrors.ErrPurchaseOrderEmpty
+	}
+	now := time.Now()
+	existing.Status = entities.PurchaseOrderApproved
+	existing.ApprovedAt = &now
+	existing.ApprovedByID = dto.UserID
+	if err := uc.repo.UpdatePurchaseOrder(ctx, existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// CancelPurchaseOrder anula una orden que todavia no ha recibido nada
+func (uc *useCase) CancelPurchaseOrder(ctx context.Context, dto request.PurchaseOrderActionDTO) (*entities.PurchaseOrder, error) {
+	existing, err := uc.repo.GetPurchaseOrderByID(ctx, dto.BusinessID, dto.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Status != entities.PurchaseOrderDraft && existing.Status != entities.PurchaseOrderApproved {
+		return nil, domainerrors.ErrPurchaseOrderTransition
+	}
+	now := time.Now()
+	existing.Status = entities.PurchaseOrderCancelled
+	existing.CancelledAt = &now
+	if dto.Reason != "" {
+		existing.Notes = dto.Reason
+	}
+	if err := uc.repo.UpdatePurchaseOrder(ctx, existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// ClosePurchaseOrder cierra la orden aunque falten unidades: lo pendiente ya no
+// se espera y deja de contar en el reabastecimiento.
+func (uc *useCase) ClosePurchaseOrder(ctx context.Context, dto request.PurchaseOrderActionDTO) (*entities.PurchaseOrder, error) {
+	existing, err := uc.repo.GetPurchaseOrderByID(ctx, dto.BusinessID, dto.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existing.Status != entities.PurchaseOrderPartiallyReceived && existing.Status != entities.PurchaseOrderReceived {
+		return nil, domainerrors.ErrPurchaseOrderTransition
+	}
+	now := time.Now()
+	existing.Status = entities.PurchaseOrderClosed
+	existing.ClosedAt = &now
+	if dto.Reason != "" {
+		existing.Notes = dto.Reason
+	}
+	if err := uc.repo.UpdatePurchaseOrder(ctx, existing); err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+func (uc *useCase) ListPurchaseOrderReceipts(ctx context.Context, businessID, poID uint) ([]entities.PurchaseOrderReceipt, error) {
+	if _, err := uc.repo.GetPurchaseOrderByID(ctx, businessID, poID); err != nil {
+		return nil, err
+	}
+	return uc.repo.ListPurchaseOrderReceipts(ctx, businessID, poID)
+}
+
+// AddLandedCost registra un costo de importacion y lo vuelve a repartir entre
+// las lineas. Las recepciones ya hechas conservan el costo con el que entraron.
+func (uc *useCase) AddLandedCost(ctx context.Context, dto request.AddLandedCostDTO) (*entities.PurchaseOrder, error) {
+	if dto.Amount <= 0 {
+		return nil, domainerrors.ErrInvalidLandedCost
+	}
+	method := dto.AllocationMethod
+	if method == "" {
+		method = entities.LandedCostByValue
+	}
+	if method != entities.LandedCostByValue && method != entities.LandedCostByQuantity {
+		return nil, domainerrors.ErrInvalidLandedCost
+	}
+
+	err := uc.repo.InTransaction(ctx, func(ctx context.Context) error {
+		po, err := uc.repo.LockPurchaseOrder(ctx, dto.BusinessID, dto.PurchaseOrderID)
+		if err != nil {
+			return err
+		}
+		if po.Status == entities.PurchaseOrderCancelled || po.Status == entities.PurchaseOrderClosed {
+			return domainerrors.ErrPurchaseOrderNotEditable
+		}
+		if _, err := uc.repo.CreateLandedCost(ctx, &entities.PurchaseOrderLandedCost{
+			PurchaseOrderID:  po.ID,
+			Concept:          dto.Concept,
+			Description:      dto.Description,
+			Amount:           dto.Amount,
+			AllocationMethod: method,
+			CreatedByID:      dto.UserID,
+		}); err != nil {
+			return err
+		}
+		if err := uc.reallocateLandedCosts(ctx, po); err != nil {
+			return err
+		}
+		return uc.repo.UpdatePurchaseOrder(ctx, po)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.repo.GetPurchaseOrderByID(ctx, dto.BusinessID, dto.PurchaseOrderID)
+}
+
+func (uc *useCase) ListLandedCosts(ctx context.Context, businessID, poID uint) ([]entities.PurchaseOrderLandedCost, error) {
+	if _, err := uc.repo.GetPurchaseOrderByID(ctx, businessID, poID); err != nil {
+		return nil, err
+	}
+	return uc.repo.ListLandedCosts(ctx, poID)
+}
+
+// reallocateLandedCosts recalcula la porcion de importacion de cada linea con
+// todos los costos de la orden y deja el total en po.LandedCostTotal
+func (uc *useCase) reallocateLandedCosts(ctx context.Context, po *entities.PurchaseOrder) error {
+	costs, err := uc.repo.ListLandedCosts(ctx, po.ID)
+	if err != nil {
+		return err
+	}
+	allocated := allocateLandedCosts(po.Lines, costs)
+	po.LandedCostTotal = 0
+	for _, c := range costs {
+		po.LandedCostTotal += c.Amount
+	}
+	for i := range po.Lines {
+		po.Lines[i].LandedCostAllocated = allocated[i]
+		if err := uc.repo.UpdatePurchaseOrderLine(ctx, &po.Lines[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// allocateLandedCosts reparte cada costo entre las lineas en proporcion al
+// valor (cantidad x costo unitario) o a la cantidad pedida. Los centavos que
+// deja el redondeo se cargan a la ultima linea para que la suma cuadre.
+func allocateLandedCosts(lines []entities.PurchaseOrderLine, costs []entities.PurchaseOrderLandedCost) []float64 {
+	out := make([]float64, len(lines))
+	if len(lines) == 0 {
+		return out
+	}
+	for _, cost := range costs {
+		weights := make([]float64, len(lines))
+		var total float64
+		for i, l := range lines {
+			w := float64(l.QuantityOrdered)
+			if cost.AllocationMethod != entities.LandedCostByQuantity {
+				w *= l.UnitCost
+			}
+			weights[i] = w
+			total += w
+		}
+		// Sin valor (lineas a costo cero) se reparte por cantidad
+		if total == 0 {
+			for i, l := range lines {
+				weights[i] = float64(l.QuantityOrdered)
+				total += weights[i]
+			}
+		}
+		if total == 0 {
+			continue
+		}
+		var assigned float64
+		for i := range lines {
+			if i == len(lines)-1 {
+				out[i] += roundMoney(cost.Amount - assigned)
+				break
+			}
+			share := roundMoney(cost.Amount * weights[i] / total)
+			out[i] += share
+			assigned += share
+		}
+	}
+	for i := range out {
+		out[i] = roundMoney(out[i])
+	}
+	return out
+}
+
+func purchaseOrderSubtotal(lines []entities.PurchaseOrderLine) float64 {
+	var subtotal float64
+	for _, l := range lines {
+		subtotal += float64(l.QuantityOrdered) * l.UnitCost
+	}
+	return roundMoney(subtotal)
+}
+
+func roundMoney(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,315 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ordenAprobada() *entities.PurchaseOrder {
+	return &entities.PurchaseOrder{
+		ID:                       7,
+		BusinessID:               10,
+		Code:                     "OC-000007",
+		SupplierID:               3,
+		WarehouseID:              1,
+		Status:                   entities.PurchaseOrderApproved,
+		OverReceiptTolerancePct:  10,
+		UnderReceiptTolerancePct: 0,
+		Lines: []entities.PurchaseOrderLine{
+			{ID: 70, ProductID: "prod-1", QuantityOrdered: 10, UnitCost: 1000},
+			{ID: 71, ProductID: "prod-2", QuantityOrdered: 5, UnitCost: 2000},
+		},
+	}
+}
+
+func repoCompras(po *entities.PurchaseOrder) *mocks.RepositoryMock {
+	return &mocks.RepositoryMock{
+		LockPurchaseOrderFn: func(ctx context.Context, businessID, id uint) (*entities.PurchaseOrder, error) {
+			return po, nil
+		},
+		GetPurchaseOrderByIDFn: func(ctx context.Context, businessID, id uint) (*entities.PurchaseOrder, error) {
+			return po, nil
+		},
+	}
+}
+
+// ─── Prorrateo de costos de importacion ───
+
+func TestAllocateLandedCosts_PorValor(t *testing.T) {
+	lines := ordenAprobada().Lines // valores 10000 y 10000
+
+	got := allocateLandedCosts(lines, []entities.PurchaseOrderLandedCost{
+		{Amount: 500, AllocationMethod: entities.LandedCostByValue},
+	})
+
+	assert.Equal(t, []float64{250, 250}, got)
+}
+
+func TestAllocateLandedCosts_PorCantidad(t *testing.T) {
+	lines := ordenAprobada().Lines // cantidades 10 y 5
+
+	got := allocateLandedCosts(lines, []entities.PurchaseOrderLandedCost{
+		{Amount: 300, AllocationMethod: entities.LandedCostByQuantity},
+	})
+
+	assert.Equal(t, []float64{200, 100}, got)
+}
+
+func TestAllocateLandedCosts_RedondeoCuadraEnUltimaLinea(t *testing.T) {
+	lines := []entities.PurchaseOrderLine{
+		{QuantityOrdered: 1, UnitCost: 1},
+		{QuantityOrdered: 1, UnitCost: 1},
+		{QuantityOrdered: 1, UnitCost: 1},
+	}
+
+	got := allocateLandedCosts(lines, []entities.PurchaseOrderLandedCost{{Amount: 100}})
+
+	assert.Equal(t, 33.33, got[0])
+	assert.Equal(t, 33.33, got[1])
+	assert.Equal(t, 33.34, got[2])
+}
+
+func TestAllocateLandedCosts_SinValor_RepartePorCantidad(t *testing.T) {
+	lines := []entities.PurchaseOrderLine{
+		{QuantityOrdered: 3},
+		{QuantityOrdered: 1},
+	}
+
+	got := allocateLandedCosts(lines, []entities.PurchaseOrderLandedCost{{Amount: 40, AllocationMethod: entities.LandedCostByValue}})
+
+	assert.Equal(t, []float64{30, 10}, got)
+}
+
+// ─── Recepcion ───
+
+func TestReceivePurchaseOrder_SobreTolerancia_Falla(t *testing.T) {
+	po := ordenAprobada()
+	uc := buildUseCase(repoCompras(po), nil, nil)
+	loc := uint(5)
+
+	_, err := uc.ReceivePurchaseOrder(context.Background(), request.ReceivePurchaseOrderDTO{
+		BusinessID:      10,
+		PurchaseOrderID: 7,
+		LocationID:      &loc,
+		Lines:           []request.ReceivePurchaseOrderLineInput{{LineID: 70, Quantity: 12}},
+	})
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, domainerrors.ErrOverReceipt))
+}
+
+func TestReceivePurchaseOrder_DentroDeTolerancia_Acepta(t *testing.T) {
+	po := ordenAprobada()
+	uc := buildUseCase(repoCompras(po), nil, nil)
+	loc := uint(5)
+
+	got, err := uc.ReceivePurchaseOrder(context.Background(), request.ReceivePurchaseOrderDTO{
+		BusinessID:      10,
+		PurchaseOrderID: 7,
+		LocationID:      &loc,
+		Lines:           []request.ReceivePurchaseOrderLineInput{{LineID: 70, Quantity: 11}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 11, got.PurchaseOrder.Lines[0].QuantityReceived)
+}
+
+func TestReceivePurchaseOrder_Parcial_QuedaParcialmenteRecibida(t *testing.T) {
+	po := ordenAprobada()
+	var ajuste dtos.AdjustStockTxParams
+	repo := repoCompras(po)
+	repo.AdjustStockTxFn = func(ctx context.Context, params dtos.AdjustStockTxParams) (*dtos.AdjustStockTxResult, error) {
+		ajuste = params
+		return &dtos.AdjustStockTxResult{Movement: &entities.StockMovement{ID: 99}, NewQuantity: params.Quantity}, nil
+	}
+	uc := buildUseCase(repo, nil, nil)
+	loc := uint(5)
+
+	got, err := uc.ReceivePurchaseOrder(context.Background(), request.ReceivePurchaseOrderDTO{
+		BusinessID:      10,
+		PurchaseOrderID: 7,
+		LocationID:      &loc,
+		Lines:           []request.ReceivePurchaseOrderLineInput{{LineID: 70, Quantity: 4}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.PurchaseOrderPartiallyReceived, got.PurchaseOrder.Status)
+	assert.Equal(t, "purchase_order", ajuste.ReferenceType)
+	assert.Equal(t, "OC-000007", ajuste.ReferenceID)
+	require.Len(t, got.Receipt.Lines, 1)
+	require.NotNil(t, got.Receipt.Lines[0].MovementID)
+	assert.Equal(t, uint(99), *got.Receipt.Lines[0].MovementID)
+	assert.Nil(t, got.Putaway)
+}
+
+func TestReceivePurchaseOrder_Completa_QuedaRecibida(t *testing.T) {
+	po := ordenAprobada()
+	uc := buildUseCase(repoCompras(po), nil, nil)
+	loc := uint(5)
+
+	got, err := uc.ReceivePurchaseOrder(context.Background(), request.ReceivePurchaseOrderDTO{
+		BusinessID:      10,
+		PurchaseOrderID: 7,
+		LocationID:      &loc,
+		Lines: []request.ReceivePurchaseOrderLineInput{
+			{LineID: 70, Quantity: 10},
+			{LineID: 71, Quantity: 5},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.PurchaseOrderReceived, got.PurchaseOrder.Status)
+	assert.NotNil(t, got.PurchaseOrder.ReceivedAt)
+}
+
+func TestReceivePurchaseOrder_OrdenEnBorrador_Falla(t *testing.T) {
+	po := ordenAprobada()
+	po.Status = entities.PurchaseOrderDraft
+	uc := buildUseCase(repoCompras(po), nil, nil)
+
+	_, err := uc.ReceivePurchaseOrder(context.Background(), request.ReceivePurchaseOrderDTO{
+		BusinessID:      10,
+		PurchaseOrderID: 7,
+		Lines:           []request.ReceivePurchaseOrderLineInput{{LineID: 70, Quantity: 1}},
+	})
+
+	assert.ErrorIs(t, err, domainerrors.ErrPurchaseOrderNotReceiving)
+}
+
+func TestReceivePurchaseOrder_SerialesIncompletos_Falla(t *testing.T) {
+	po := ordenAprobada()
+	uc := buildUseCase(repoCompras(po), nil, nil)
+
+	_, err := uc.ReceivePurchaseOrder(context.Background(), request.ReceivePurchaseOrderDTO{
+		BusinessID:      10,
+		PurchaseOrderID: 7,
+		Lines:           []request.ReceivePurchaseOrderLineInput{{LineID: 70, Quantity: 2, SerialNumbers: []string{"SN-1"}}},
+	})
+
+	assert.ErrorIs(t, err, domainerrors.ErrSerialCountMismatch)
+}
+
+func TestReceivePurchaseOrder_CreaLoteDelProveedorYSugierePutaway(t *testing.T) {
+	po := ordenAprobada()
+	var lote *entities.InventoryLot
+	repo := repoCompras(po)
+	repo.CreateLotFn = func(ctx context.Context, lot *entities.InventoryLot) (*entities.InventoryLot, error) {
+		lot.ID = 55
+		lote = lot
+		return lot, nil
+	}
+	repo.FindApplicableRuleFn = func(ctx context.Context, businessID uint, productID string) (*entities.PutawayRule, error) {
+		return &entities.PutawayRule{ID: 1, TargetZoneID: 3, Strategy: "nearest_empty"}, nil
+	}
+	repo.PickLocationInZoneFn = func(ctx context.Context, zoneID uint) (uint, error) {
+		return 42, nil
+	}
+	repo.CreatePutawaySuggestionFn = func(ctx context.Context, s *entities.PutawaySuggestion) (*entities.PutawaySuggestion, error) {
+		return s, nil
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	got, err := uc.ReceivePurchaseOrder(context.Background(), request.ReceivePurchaseOrderDTO{
+		BusinessID:      10,
+		PurchaseOrderID: 7,
+		Lines:           []request.ReceivePurchaseOrderLineInput{{LineID: 70, Quantity: 3, LotCode: "L-2026-01"}},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, lote)
+	require.NotNil(t, lote.SupplierID)
+	assert.Equal(t, uint(3), *lote.SupplierID)
+	require.NotNil(t, got.Receipt.Lines[0].LotID)
+	assert.Equal(t, uint(55), *got.Receipt.Lines[0].LotID)
+	require.NotNil(t, got.Putaway)
+	require.Len(t, got.Putaway.Suggestions, 1)
+}
+
+// ─── Transiciones ───
+
+func TestApprovePurchaseOrder_DesdeBorrador(t *testing.T) {
+	po := ordenAprobada()
+	po.Status = entities.PurchaseOrderDraft
+	uc := buildUseCase(repoCompras(po), nil, nil)
+	userID := uint(4)
+
+	got, err := uc.ApprovePurchaseOrder(context.Background(), request.PurchaseOrderActionDTO{BusinessID: 10, ID: 7, UserID: &userID})
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.PurchaseOrderApproved, got.Status)
+	assert.Equal(t, &userID, got.ApprovedByID)
+}
+
+func TestCancelPurchaseOrder_ConRecepciones_Falla(t *testing.T) {
+	po := ordenAprobada()
+	po.Status = entities.PurchaseOrderPartiallyReceived
+	uc := buildUseCase(repoCompras(po), nil, nil)
+
+	_, err := uc.CancelPurchaseOrder(context.Background(), request.PurchaseOrderActionDTO{BusinessID: 10, ID: 7})
+
+	assert.ErrorIs(t, err, domainerrors.ErrPurchaseOrderTransition)
+}
+
+// ─── Borradores desde reabastecimiento ───
+
+func TestGenerateDraftPurchaseOrders_AgrupaPorProveedor(t *testing.T) {
+	lead := 4
+	var creadas []*entities.PurchaseOrder
+	repo := &mocks.RepositoryMock{
+		DetectReplenishmentCandidatesFn: func(ctx context.Context, businessID uint) ([]entities.ReplenishmentTask, error) {
+			return []entities.ReplenishmentTask{
+				{ProductID: "prod-1", WarehouseID: 1, Quantity: 20},
+				{ProductID: "prod-2", WarehouseID: 1, Quantity: 3},
+				{ProductID: "prod-3", WarehouseID: 1, Quantity: 8},
+				{ProductID: "prod-4", WarehouseID: 1, Quantity: 5},
+			}, nil
+		},
+		OpenPurchaseQuantityFn: func(ctx context.Context, businessID, warehouseID uint, productID string) (int, error) {
+			if productID == "prod-4" {
+				return 5, nil
+			}
+			if productID == "prod-1" {
+				return 5, nil
+			}
+			return 0, nil
+		},
+		GetPreferredSupplierProductFn: func(ctx context.Context, businessID uint, productID string) (*entities.SupplierProduct, error) {
+			switch productID {
+			case "prod-1":
+				return &entities.SupplierProduct{SupplierID: 3, ProductID: productID, UnitCost: 1000, LeadTimeDays: &lead}, nil
+			case "prod-2":
+				return &entities.SupplierProduct{SupplierID: 3, ProductID: productID, UnitCost: 500, MinOrderQty: 12}, nil
+			}
+			return nil, domainerrors.ErrSupplierProductNotFound
+		},
+		CreatePurchaseOrderFn: func(ctx context.Context, po *entities.PurchaseOrder) (*entities.PurchaseOrder, error) {
+			creadas = append(creadas, po)
+			return po, nil
+		},
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	got, err := uc.GenerateDraftPurchaseOrders(context.Background(), request.GenerateDraftPurchaseOrdersDTO{BusinessID: 10})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, got.Created)
+	assert.Equal(t, []string{"prod-3"}, got.WithoutSupplier)
+	assert.Equal(t, []string{"prod-4"}, got.AlreadyOrdered)
+	require.Len(t, creadas, 1)
+	po := creadas[0]
+	assert.Equal(t, entities.PurchaseOrderDraft, po.Status)
+	assert.Equal(t, "replenishment", po.Source)
+	require.Len(t, po.Lines, 2)
+	assert.Equal(t, 15, po.Lines[0].QuantityOrdered)
+	assert.Equal(t, 12, po.Lines[1].QuantityOrdered)
+	assert.NotNil(t, po.ExpectedAt)
+}
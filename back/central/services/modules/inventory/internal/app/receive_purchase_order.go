@@ -0,0 +1,244 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/response"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+)
+
+// ReceivePurchaseOrder registra una recepcion total o parcial contra una orden
+// de compra. Por cada linea crea (o reutiliza) el lote, los seriales y el
+// movimiento de entrada; valida la tolerancia de sobrante y deja la orden en
+// received cuando todas las lineas llegan al minimo de la tolerancia de
+// faltante. Todo va en una transaccion. Si no se indica ubicacion de recibo, al
+// final se piden sugerencias de put-away con las reglas del negocio.
+func (uc *useCase) ReceivePurchaseOrder(ctx context.Context, dto request.ReceivePurchaseOrderDTO) (*response.PurchaseReceiptResult, error) {
+	if len(dto.Lines) == 0 {
+		return nil, domainerrors.ErrInvalidQuantity
+	}
+
+	movTypeID, err := uc.repo.GetMovementTypeIDByCode(ctx, "inbound")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var po *entities.PurchaseOrder
+	var receipt *entities.PurchaseOrderReceipt
+	received := make(map[string]int)
+	var order []string
+
+	err = uc.repo.InTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		po, err = uc.repo.LockPurchaseOrder(ctx, dto.BusinessID, dto.PurchaseOrderID)
+		if err != nil {
+			return err
+		}
+		if !po.CanReceive() {
+			return domainerrors.ErrPurchaseOrderNotReceiving
+		}
+
+		lineIdx := make(map[uint]int, len(po.Lines))
+		for i := range po.Lines {
+			lineIdx[po.Lines[i].ID] = i
+		}
+
+		pending := &entities.PurchaseOrderReceipt{
+			BusinessID:      dto.BusinessID,
+			PurchaseOrderID: po.ID,
+			WarehouseID:     po.WarehouseID,
+			LocationID:      dto.LocationID,
+			ReceivedByID:    dto.UserID,
+			ReceivedAt:      now,
+			Notes:           dto.Notes,
+		}
+
+		for _, item := range dto.Lines {
+			idx, ok := lineIdx[item.LineID]
+			if !ok {
+				return domainerrors.ErrPurchaseOrderLineNotFound
+			}
+			line := &po.Lines[idx]
+
+			receiptLine, err := uc.receivePurchaseOrderLine(ctx, po, line, item, dto, movTypeID, now)
+			if err != nil {
+				return err
+			}
+			pending.Lines = append(pending.Lines, *receiptLine)
+
+			if _, seen := received[line.ProductID]; !seen {
+				order = append(order, line.ProductID)
+			}
+			received[line.ProductID] += item.Quantity
+		}
+
+		if po.FullyReceived() {
+			po.Status = entities.PurchaseOrderReceived
+			po.ReceivedAt = &now
+		} else {
+			po.Status = entities.PurchaseOrderPartiallyReceived
+		}
+		if err := uc.repo.UpdatePurchaseOrder(ctx, po); err != nil {
+			return err
+		}
+
+		receipt, err = uc.repo.CreatePurchaseOrderReceipt(ctx, pending)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.PurchaseReceiptResult{Receipt: receipt, PurchaseOrder: po}
+
+	if dto.LocationID == nil {
+		suggest := request.PutawaySuggestDTO{BusinessID: dto.BusinessID}
+		for _, productID := range order {
+			suggest.Items = append(suggest.Items, request.PutawaySuggestItem{ProductID: productID, Quantity: received[productID]})
+		}
+		putaway, err := uc.SuggestPutaway(ctx, suggest)
+		if err != nil {
+			uc.log.Error(ctx).Err(err).Str("purchase_order", po.Code).Msg("Failed to suggest putaway for purchase receipt")
+		} else {
+			result.Putaway = putaway
+		}
+	} else {
+		for _, productID := range order {
+			uc.publishLocationChanged(dto.BusinessID, po.WarehouseID, dto.LocationID, productID, received[productID])
+		}
+	}
+
+	return result, nil
+}
+
+func (uc *useCase) receivePurchaseOrderLine(
+	ctx context.Context,
+	po *entities.PurchaseOrder,
+	line *entities.PurchaseOrderLine,
+	item request.ReceivePurchaseOrderLineInput,
+	dto request.ReceivePurchaseOrderDTO,
+	movTypeID uint,
+	now time.Time,
+) (*entities.PurchaseOrderReceiptLine, error) {
+	if item.Quantity <= 0 {
+		return nil, domainerrors.ErrInvalidQuantity
+	}
+	if line.QuantityReceived+item.Quantity > line.MaxAcceptedQty(po.OverReceiptTolerancePct) {
+		return nil, fmt.Errorf("%w: producto %s, pedido %d, recibido %d", domainerrors.ErrOverReceipt,
+			line.ProductID, line.QuantityOrdered, line.QuantityReceived+item.Quantity)
+	}
+	if len(item.SerialNumbers) > 0 && len(item.SerialNumbers) != item.Quantity {
+		return nil, domainerrors.ErrSerialCountMismatch
+	}
+
+	_, _, trackInventory, err := uc.repo.GetProductByID(ctx, line.ProductID, dto.BusinessID)
+	if err != nil {
+		return nil, domainerrors.ErrProductNotFound
+	}
+	if !trackInventory {
+		if err := uc.repo.EnableProductTrackInventory(ctx, line.ProductID); err != nil {
+			return nil, err
+		}
+	}
+
+	var lotID *uint
+	if item.LotCode != "" {
+		lot, err := uc.lotForReceipt(ctx, po, line.ProductID, item, now)
+		if err != nil {
+			return nil, err
+		}
+		lotID = &lot.ID
+	}
+
+	// La entrada queda valorizada al costo puesto en bodega de la linea
+	landed := roundMoney(line.LandedUnitCost())
+	txResult, err := uc.repo.AdjustStockTx(ctx, dtos.AdjustStockTxParams{
+		ProductID:      line.ProductID,
+		WarehouseID:    po.WarehouseID,
+		LocationID:     dto.LocationID,
+		LotID:          lotID,
+		BusinessID:     dto.BusinessID,
+		Quantity:       item.Quantity,
+		MovementTypeID: movTypeID,
+		Reason:         "Recepcion " + po.Code,
+		Notes:          dto.Notes,
+		ReferenceType:  "purchase_order",
+		ReferenceID:    po.Code,
+		CreatedByID:    dto.UserID,
+		UnitCost:       &landed,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sn := range item.SerialNumbers {
+		dup, err := uc.repo.SerialExists(ctx, dto.BusinessID, line.ProductID, sn, nil)
+		if err != nil {
+			return nil, err
+		}
+		if dup {
+			return nil, fmt.Errorf("%w: %s", domainerrors.ErrDuplicateSerial, sn)
+		}
+		if _, err := uc.repo.CreateSerial(ctx, &entities.InventorySerial{
+			BusinessID:        dto.BusinessID,
+			ProductID:         line.ProductID,
+			SerialNumber:      sn,
+			LotID:             lotID,
+			CurrentLocationID: dto.LocationID,
+			ReceivedAt:        &now,
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	line.QuantityReceived += item.Quantity
+	if err := uc.repo.UpdatePurchaseOrderLine(ctx, line); err != nil {
+		return nil, err
+	}
+
+	uc.updateProductTotalStock(ctx, line.ProductID, dto.BusinessID)
+	uc.publishSync(ctx, line.ProductID, dto.BusinessID, txResult.NewQuantity, po.WarehouseID, "purchase_receipt")
+
+	receiptLine := &entities.PurchaseOrderReceiptLine{
+		PurchaseOrderLineID: line.ID,
+		ProductID:           line.ProductID,
+		Quantity:            item.Quantity,
+		LotID:               lotID,
+		UnitCost:            line.UnitCost,
+		LandedUnitCost:      landed,
+	}
+	if txResult.Movement != nil {
+		receiptLine.MovementID = &txResult.Movement.ID
+	}
+	return receiptLine, nil
+}
+
+// lotForReceipt reutiliza el lote si ya existe (otra recepcion parcial del
+// mismo lote) o lo crea ligado al proveedor de la orden
+func (uc *useCase) lotForReceipt(ctx context.Context, po *entities.PurchaseOrder, productID string, item request.ReceivePurchaseOrderLineInput, now time.Time) (*entities.InventoryLot, error) {
+	lot, err := uc.repo.GetLotByCode(ctx, po.BusinessID, productID, item.LotCode)
+	if err == nil {
+		return lot, nil
+	}
+	if !errors.Is(err, domainerrors.ErrLotNotFound) {
+		return nil, err
+	}
+	supplierID := po.SupplierID
+	return uc.repo.CreateLot(ctx, &entities.InventoryLot{
+		BusinessID:      po.BusinessID,
+		ProductID:       productID,
+		LotCode:         item.LotCode,
+		ManufactureDate: item.ManufactureDate,
+		ExpirationDate:  item.ExpirationDate,
+		ReceivedAt:      &now,
+		SupplierID:      &supplierID,
+		Status:          "active",
+	})
+}
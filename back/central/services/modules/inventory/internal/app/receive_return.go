@@ -0,0 +1,106 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/response"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+)
+
+const availableStateCode = "available"
+
+// ReceiveReturn reingresa a bodega las unidades de una devolucion. Las que la
+// inspeccion no deja disponibles se pasan a damaged o quarantine para que no
+// cuenten como stock vendible. Todas las lineas van en una transaccion: si una
+// falla no entra ninguna.
+func (uc *useCase) ReceiveReturn(ctx context.Context, dto request.ReceiveReturnDTO) (*response.OrderStockResult, error) {
+	whID, err := uc.resolveWarehouse(ctx, dto.WarehouseID, dto.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+
+	movTypeID, err := uc.repo.GetMovementTypeIDByCode(ctx, "return")
+	if err != nil {
+		uc.log.Error(ctx).Err(err).Msg("Failed to get return movement type")
+		return nil, err
+	}
+
+	result := &response.OrderStockResult{
+		OrderID:     dto.OrderID,
+		BusinessID:  dto.BusinessID,
+		WarehouseID: whID,
+		Success:     true,
+	}
+
+	err = uc.repo.InTransaction(ctx, func(ctx context.Context) error {
+		for _, item := range dto.Items {
+			if item.Quantity <= 0 {
+				return domainerrors.ErrInvalidQuantity
+			}
+
+			itemResult := response.ItemStockResult{
+				ProductID: item.ProductID,
+				SKU:       item.SKU,
+				Requested: item.Quantity,
+			}
+
+			_, _, trackInventory, err := uc.repo.GetProductByID(ctx, item.ProductID, dto.BusinessID)
+			if err != nil {
+				return fmt.Errorf("producto %s: %w", item.ProductID, err)
+			}
+			if !trackInventory {
+				itemResult.Processed = item.Quantity
+				itemResult.Sufficient = true
+				result.ItemResults = append(result.ItemResults, itemResult)
+				continue
+			}
+
+			if err := uc.repo.ReturnStockTx(ctx, dtos.ReturnStockTxParams{
+				ProductID:      item.ProductID,
+				WarehouseID:    whID,
+				BusinessID:     dto.BusinessID,
+				Quantity:       item.Quantity,
+				MovementTypeID: movTypeID,
+				OrderID:        dto.OrderID,
+			}); err != nil {
+				return err
+			}
+
+			if item.StateCode != "" && item.StateCode != availableStateCode {
+				level, err := uc.repo.GetOrCreateLevel(ctx, item.ProductID, whID, nil, dto.BusinessID)
+				if err != nil {
+					return err
+				}
+				if _, err := uc.repo.ChangeStateTx(ctx, dtos.ChangeInventoryStateTxParams{
+					LevelID:       level.ID,
+					FromStateCode: availableStateCode,
+					ToStateCode:   item.StateCode,
+					Quantity:      item.Quantity,
+					Reason:        fmt.Sprintf("Devolucion %s: inspeccion %s", dto.ReturnCode, item.StateCode),
+					BusinessID:    dto.BusinessID,
+					CreatedByID:   dto.CreatedByID,
+				}); err != nil {
+					return err
+				}
+			}
+
+			uc.updateProductTotalStock(ctx, item.ProductID, dto.BusinessID)
+			uc.publishSync(ctx, item.ProductID, dto.BusinessID, 0, whID, "order_return")
+
+			itemResult.Processed = item.Quantity
+			itemResult.Sufficient = true
+			result.ItemResults = append(result.ItemResults, itemResult)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.publishEvent(ctx, "inventory.returned", dto.OrderID, dto.BusinessID, whID, result)
+
+	return result, nil
+}
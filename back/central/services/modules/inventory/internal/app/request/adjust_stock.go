@@ -12,6 +12,8 @@ type AdjustStockDTO struct {
 	Reason      string
 	Notes       string
 	CreatedByID *uint
+	// UnitCost costo unitario de la entrada (solo cantidades positivas)
+	UnitCost *float64
 }
 
 type TransferStockDTO struct {
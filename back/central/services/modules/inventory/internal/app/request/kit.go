@@ -0,0 +1,29 @@
+package request
+
+type KitComponentInput struct {
+	ProductID string
+	Quantity  int
+}
+
+type SaveKitDTO struct {
+	BusinessID uint
+	ProductID  string
+	Mode       string
+	Components []KitComponentInput
+}
+
+type CreateKitAssemblyOrderDTO struct {
+	BusinessID   uint
+	Type         string
+	KitProductID string
+	WarehouseID  uint
+	Quantity     int
+	Notes        string
+	UserID       *uint
+}
+
+type KitAssemblyActionDTO struct {
+	BusinessID uint
+	ID         uint
+	UserID     *uint
+}
@@ -0,0 +1,62 @@
+package request
+
+import "time"
+
+type CreatePickWaveDTO struct {
+	BusinessID  uint
+	WarehouseID uint
+	CarrierCode string
+	CutoffAt    *time.Time
+	ZoneID      *uint
+	MinPriority *int
+	MaxOrders   int
+	Notes       string
+	UserID      *uint
+}
+
+type PickWaveActionDTO struct {
+	BusinessID uint
+	ID         uint
+	UserID     *uint
+}
+
+// ConfirmPickLineDTO confirma una linea con lo que el picker escaneo: la
+// ubicacion (si la linea tiene) y el producto
+type ConfirmPickLineDTO struct {
+	BusinessID   uint
+	WaveID       uint
+	LineID       uint
+	LocationCode string
+	ProductCode  string
+	Quantity     int
+	DeviceID     string
+	UserID       *uint
+}
+
+type ShortPickLineDTO struct {
+	BusinessID uint
+	WaveID     uint
+	LineID     uint
+	UserID     *uint
+}
+
+type PackScanDTO struct {
+	BusinessID uint
+	WaveID     uint
+	OrderID    string
+	Code       string
+	Quantity   int
+	DeviceID   string
+	UserID     *uint
+}
+
+type ClosePackageDTO struct {
+	BusinessID uint
+	WaveID     uint
+	OrderID    string
+	WeightKg   float64
+	LengthCm   float64
+	WidthCm    float64
+	HeightCm   float64
+	UserID     *uint
+}
@@ -0,0 +1,120 @@
+package request
+
+import "time"
+
+type CreateSupplierDTO struct {
+	BusinessID               uint
+	Name                     string
+	TaxID                    string
+	ContactName              string
+	Email                    string
+	Phone                    string
+	Address                  string
+	LeadTimeDays             int
+	OverReceiptTolerancePct  float64
+	UnderReceiptTolerancePct float64
+	Notes                    string
+}
+
+type UpdateSupplierDTO struct {
+	ID                       uint
+	BusinessID               uint
+	Name                     string
+	TaxID                    *string
+	ContactName              *string
+	Email                    *string
+	Phone                    *string
+	Address                  *string
+	LeadTimeDays             *int
+	OverReceiptTolerancePct  *float64
+	UnderReceiptTolerancePct *float64
+	IsActive                 *bool
+	Notes                    *string
+}
+
+type SetSupplierProductDTO struct {
+	BusinessID   uint
+	SupplierID   uint
+	ProductID    string
+	SupplierSKU  string
+	UnitCost     float64
+	MinOrderQty  int
+	LeadTimeDays *int
+	IsPreferred  bool
+}
+
+type PurchaseOrderLineInput struct {
+	ProductID  string
+	Quantity   int
+	UnitCost   float64
+	ExpectedAt *time.Time
+	Notes      string
+}
+
+type CreatePurchaseOrderDTO struct {
+	BusinessID  uint
+	SupplierID  uint
+	WarehouseID uint
+	Currency    string
+	ExpectedAt  *time.Time
+	// Sin valor se toman las tolerancias del proveedor
+	OverReceiptTolerancePct  *float64
+	UnderReceiptTolerancePct *float64
+	Notes                    string
+	Source                   string
+	Lines                    []PurchaseOrderLineInput
+	CreatedByID              *uint
+}
+
+// UpdatePurchaseOrderDTO edita una orden en borrador. Si Lines no es nil
+// reemplaza todas las lineas.
+type UpdatePurchaseOrderDTO struct {
+	ID                       uint
+	BusinessID               uint
+	ExpectedAt               *time.Time
+	OverReceiptTolerancePct  *float64
+	UnderReceiptTolerancePct *float64
+	Notes                    *string
+	Lines                    []PurchaseOrderLineInput
+}
+
+type PurchaseOrderActionDTO struct {
+	BusinessID uint
+	ID         uint
+	UserID     *uint
+	Reason     string
+}
+
+type ReceivePurchaseOrderLineInput struct {
+	LineID          uint
+	Quantity        int
+	LotCode         string
+	ManufactureDate *time.Time
+	ExpirationDate  *time.Time
+	SerialNumbers   []string
+}
+
+type ReceivePurchaseOrderDTO struct {
+	BusinessID      uint
+	PurchaseOrderID uint
+	// LocationID es la ubicacion de recibo. Sin ella se generan sugerencias de put-away.
+	LocationID *uint
+	Notes      string
+	Lines      []ReceivePurchaseOrderLineInput
+	UserID     *uint
+}
+
+type AddLandedCostDTO struct {
+	BusinessID       uint
+	PurchaseOrderID  uint
+	Concept          string
+	Description      string
+	Amount           float64
+	AllocationMethod string
+	UserID           *uint
+}
+
+type GenerateDraftPurchaseOrdersDTO struct {
+	BusinessID uint
+	UserID     *uint
+}
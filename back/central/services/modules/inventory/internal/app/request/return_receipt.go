@@ -0,0 +1,20 @@
+package request
+
+// ReceiveReturnDTO son las unidades de una devolucion que vuelven a bodega
+type ReceiveReturnDTO struct {
+	BusinessID  uint
+	WarehouseID *uint
+	OrderID     string
+	ReturnCode  string
+	CreatedByID *uint
+	Items       []ReturnedItemDTO
+}
+
+// ReturnedItemDTO es una linea recibida. StateCode es el estado en que queda
+// segun la inspeccion: available (vuelve a la venta), damaged o quarantine.
+type ReturnedItemDTO struct {
+	ProductID string
+	SKU       string
+	Quantity  int
+	StateCode string
+}
@@ -0,0 +1,24 @@
+package request
+
+import "time"
+
+type SetValuationMethodDTO struct {
+	BusinessID uint
+	Method     string
+	UserID     *uint
+}
+
+// SetOpeningCostDTO fija el costo de las existencias que ya habia antes de
+// valorizar (o corrige un costo mal cargado)
+type SetOpeningCostDTO struct {
+	BusinessID uint
+	ProductID  string
+	UnitCost   float64
+	UserID     *uint
+}
+
+type ValuationReportDTO struct {
+	BusinessID  uint
+	AsOf        *time.Time
+	WarehouseID *uint
+}
@@ -20,4 +20,8 @@ type KardexExportResult struct {
 	TotalIn     int                     `json:"total_in"`
 	TotalOut    int                     `json:"total_out"`
 	FinalBalance int                    `json:"final_balance"`
+	// Valorizado: suma de total_cost de los movimientos (entradas positivas, salidas negativas)
+	TotalValueIn  float64 `json:"total_value_in"`
+	TotalValueOut float64 `json:"total_value_out"`
+	FinalValue    float64 `json:"final_value"`
 }
@@ -0,0 +1,13 @@
+package response
+
+import "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+
+// KitAvailabilityResult es el disponible para la venta del kit y el detalle
+// por componente
+type KitAvailabilityResult struct {
+	ProductID       string                              `json:"product_id"`
+	Mode            string                              `json:"mode"`
+	WarehouseID     *uint                               `json:"warehouse_id"`
+	AvailableToSell int                                 `json:"available_to_sell"`
+	Components      []entities.KitComponentAvailability `json:"components"`
+}
@@ -0,0 +1,18 @@
+package response
+
+import "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+
+type PurchaseReceiptResult struct {
+	Receipt       *entities.PurchaseOrderReceipt `json:"receipt"`
+	PurchaseOrder *entities.PurchaseOrder        `json:"purchase_order"`
+	Putaway       *PutawaySuggestResult          `json:"putaway,omitempty"`
+}
+
+type DraftPurchaseOrdersResult struct {
+	Created        int                      `json:"created"`
+	PurchaseOrders []entities.PurchaseOrder `json:"purchase_orders"`
+	// Productos bajo el punto de reorden sin proveedor activo asignado
+	WithoutSupplier []string `json:"without_supplier"`
+	// Productos cuyo faltante ya esta cubierto por ordenes abiertas
+	AlreadyOrdered []string `json:"already_ordered"`
+}
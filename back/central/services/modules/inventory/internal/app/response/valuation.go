@@ -0,0 +1,30 @@
+package response
+
+import (
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+)
+
+type ProductCostResult struct {
+	Method  string                `json:"method"`
+	Balance *entities.CostBalance `json:"balance"`
+	// Capas FIFO abiertas; vacio con promedio ponderado
+	Layers []entities.CostLayer `json:"layers"`
+}
+
+type ValuationReportResult struct {
+	BusinessID    uint                           `json:"business_id"`
+	AsOf          time.Time                      `json:"as_of"`
+	WarehouseID   *uint                          `json:"warehouse_id"`
+	Method        string                         `json:"method"`
+	Lines         []entities.ValuationReportLine `json:"lines"`
+	TotalQuantity int                            `json:"total_quantity"`
+	TotalValue    float64                        `json:"total_value"`
+}
+
+type OrderCOGSResult struct {
+	OrderID   string                   `json:"order_id"`
+	Lines     []entities.OrderLineCost `json:"lines"`
+	TotalCost float64                  `json:"total_cost"`
+}
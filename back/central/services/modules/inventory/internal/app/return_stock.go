@@ -46,13 +46,21 @@ func (uc *useCase) ReturnStockForOrder(ctx context.Context, orderID string, busi
 			continue
 		}
 
-		err = uc.repo.ReturnStockTx(ctx, dtos.ReturnStockTxParams{
-			ProductID:      item.ProductID,
-			WarehouseID:    whID,
-			BusinessID:     businessID,
-			Quantity:       item.Quantity,
-			MovementTypeID: movTypeID,
-			OrderID:        orderID,
+		// Movimiento y mensajes de sync (outbox) se confirman juntos
+		err = uc.repo.InTransaction(ctx, func(ctx context.Context) error {
+			if err := uc.repo.ReturnStockTx(ctx, dtos.ReturnStockTxParams{
+				ProductID:      item.ProductID,
+				WarehouseID:    whID,
+				BusinessID:     businessID,
+				Quantity:       item.Quantity,
+				MovementTypeID: movTypeID,
+				OrderID:        orderID,
+			}); err != nil {
+				return err
+			}
+			uc.updateProductTotalStock(ctx, item.ProductID, businessID)
+			uc.publishSync(ctx, item.ProductID, businessID, 0, whID, "order_return")
+			return nil
 		})
 		if err != nil {
 			itemResult.ErrorMessage = err.Error()
@@ -64,9 +72,6 @@ func (uc *useCase) ReturnStockForOrder(ctx context.Context, orderID string, busi
 		itemResult.Processed = item.Quantity
 		itemResult.Sufficient = true
 		result.ItemResults = append(result.ItemResults, itemResult)
-
-		uc.updateProductTotalStock(ctx, item.ProductID, businessID)
-		uc.publishSync(ctx, item.ProductID, businessID, 0, whID, "order_return")
 	}
 
 	uc.publishEvent(ctx, "inventory.returned", orderID, businessID, whID, result)
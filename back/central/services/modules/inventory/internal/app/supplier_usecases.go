@@ -0,0 +1,130 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+)
+
+func (uc *useCase) CreateSupplier(ctx context.Context, dto request.CreateSupplierDTO) (*entities.Supplier, error) {
+	if !validTolerance(dto.OverReceiptTolerancePct) || !validTolerance(dto.UnderReceiptTolerancePct) {
+		return nil, domainerrors.ErrInvalidTolerance
+	}
+	supplier := &entities.Supplier{
+		BusinessID:               dto.BusinessID,
+		Name:                     strings.TrimSpace(dto.Name),
+		TaxID:                    strings.TrimSpace(dto.TaxID),
+		ContactName:              dto.ContactName,
+		Email:                    dto.Email,
+		Phone:                    dto.Phone,
+		Address:                  dto.Address,
+		LeadTimeDays:             dto.LeadTimeDays,
+		OverReceiptTolerancePct:  dto.OverReceiptTolerancePct,
+		UnderReceiptTolerancePct: dto.UnderReceiptTolerancePct,
+		IsActive:                 true,
+		Notes:                    dto.Notes,
+	}
+	return uc.repo.CreateSupplier(ctx, supplier)
+}
+
+func (uc *useCase) GetSupplier(ctx context.Context, businessID, id uint) (*entities.Supplier, error) {
+	return uc.repo.GetSupplierByID(ctx, businessID, id)
+}
+
+func (uc *useCase) ListSuppliers(ctx context.Context, params dtos.ListSuppliersParams) ([]entities.Supplier, int64, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 || params.PageSize > 100 {
+		params.PageSize = 10
+	}
+	return uc.repo.ListSuppliers(ctx, params)
+}
+
+func (uc *useCase) UpdateSupplier(ctx context.Context, dto request.UpdateSupplierDTO) (*entities.Supplier, error) {
+	existing, err := uc.repo.GetSupplierByID(ctx, dto.BusinessID, dto.ID)
+	if err != nil {
+		return nil, err
+	}
+	if dto.Name != "" {
+		existing.Name = strings.TrimSpace(dto.Name)
+	}
+	if dto.TaxID != nil {
+		existing.TaxID = strings.TrimSpace(*dto.TaxID)
+	}
+	if dto.ContactName != nil {
+		existing.ContactName = *dto.ContactName
+	}
+	if dto.Email != nil {
+		existing.Email = *dto.Email
+	}
+	if dto.Phone != nil {
+		existing.Phone = *dto.Phone
+	}
+	if dto.Address != nil {
+		existing.Address = *dto.Address
+	}
+	if dto.LeadTimeDays != nil {
+		existing.LeadTimeDays = *dto.LeadTimeDays
+	}
+	if dto.OverReceiptTolerancePct != nil {
+		if !validTolerance(*dto.OverReceiptTolerancePct) {
+			return nil, domainerrors.ErrInvalidTolerance
+		}
+		existing.OverReceiptTolerancePct = *dto.OverReceiptTolerancePct
+	}
+	if dto.UnderReceiptTolerancePct != nil {
+		if !validTolerance(*dto.UnderReceiptTolerancePct) {
+			return nil, domainerrors.ErrInvalidTolerance
+		}
+		existing.UnderReceiptTolerancePct = *dto.UnderReceiptTolerancePct
+	}
+	if dto.IsActive != nil {
+		existing.IsActive = *dto.IsActive
+	}
+	if dto.Notes != nil {
+		existing.Notes = *dto.Notes
+	}
+	return uc.repo.UpdateSupplier(ctx, existing)
+}
+
+func (uc *useCase) SetSupplierProduct(ctx context.Context, dto request.SetSupplierProductDTO) (*entities.SupplierProduct, error) {
+	if _, err := uc.repo.GetSupplierByID(ctx, dto.BusinessID, dto.SupplierID); err != nil {
+		return nil, err
+	}
+	if _, _, _, err := uc.repo.GetProductByID(ctx, dto.ProductID, dto.BusinessID); err != nil {
+		return nil, domainerrors.ErrProductNotFound
+	}
+	if dto.UnitCost < 0 || dto.MinOrderQty < 0 {
+		return nil, domainerrors.ErrInvalidQuantity
+	}
+	return uc.repo.UpsertSupplierProduct(ctx, &entities.SupplierProduct{
+		BusinessID:   dto.BusinessID,
+		SupplierID:   dto.SupplierID,
+		ProductID:    dto.ProductID,
+		SupplierSKU:  dto.SupplierSKU,
+		UnitCost:     dto.UnitCost,
+		MinOrderQty:  dto.MinOrderQty,
+		LeadTimeDays: dto.LeadTimeDays,
+		IsPreferred:  dto.IsPreferred,
+	})
+}
+
+func (uc *useCase) ListSupplierProducts(ctx context.Context, businessID, supplierID uint) ([]entities.SupplierProduct, error) {
+	if _, err := uc.repo.GetSupplierByID(ctx, businessID, supplierID); err != nil {
+		return nil, err
+	}
+	return uc.repo.ListSupplierProducts(ctx, businessID, supplierID)
+}
+
+func (uc *useCase) DeleteSupplierProduct(ctx context.Context, businessID, id uint) error {
+	return uc.repo.DeleteSupplierProduct(ctx, businessID, id)
+}
+
+func validTolerance(pct float64) bool {
+	return pct >= 0 && pct <= 100
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/mappers"
 	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
 	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
 )
 
@@ -43,12 +44,20 @@ func (uc *useCase) TransferStock(ctx context.Context, dto request.TransferStockD
 		return err
 	}
 
-	txResult, err := uc.repo.TransferStockTx(ctx, mappers.TransferStockDTOToTxParams(dto, transferTypeID, "manual"))
+	// La transferencia y los mensajes de sync (outbox) se confirman juntos
+	var txResult *dtos.TransferStockTxResult
+	err = uc.repo.InTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		txResult, err = uc.repo.TransferStockTx(ctx, mappers.TransferStockDTOToTxParams(dto, transferTypeID, "manual"))
+		if err != nil {
+			return err
+		}
+		uc.publishSync(ctx, dto.ProductID, dto.BusinessID, txResult.FromNewQty+txResult.ToNewQty, dto.FromWarehouseID, "transfer")
+		return nil
+	})
 	if err != nil {
 		return err
 	}
-
-	uc.publishSync(ctx, dto.ProductID, dto.BusinessID, txResult.FromNewQty+txResult.ToNewQty, dto.FromWarehouseID, "transfer")
 	uc.publishLocationChanged(dto.BusinessID, dto.FromWarehouseID, dto.FromLocationID, dto.ProductID, txResult.FromNewQty)
 	uc.publishLocationChanged(dto.BusinessID, dto.ToWarehouseID, dto.ToLocationID, dto.ProductID, txResult.ToNewQty)
 
@@ -0,0 +1,160 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetValuationMethod_MetodoInvalido(t *testing.T) {
+	called := false
+	repo := &mocks.RepositoryMock{
+		SetValuationMethodFn: func(ctx context.Context, businessID uint, method string, changedByID *uint) (*entities.ValuationSetting, error) {
+			called = true
+			return nil, nil
+		},
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	_, err := uc.SetValuationMethod(context.Background(), request.SetValuationMethodDTO{BusinessID: 10, Method: "lifo"})
+
+	assert.ErrorIs(t, err, domainerrors.ErrInvalidValuationMethod)
+	assert.False(t, called)
+}
+
+func TestSetOpeningCost_CostoNegativo(t *testing.T) {
+	uc := buildUseCase(&mocks.RepositoryMock{}, nil, nil)
+
+	_, err := uc.SetOpeningCost(context.Background(), request.SetOpeningCostDTO{BusinessID: 10, ProductID: "prod-1", UnitCost: -1})
+
+	assert.ErrorIs(t, err, domainerrors.ErrInvalidUnitCost)
+}
+
+func TestSetOpeningCost_ProductoNoEncontrado(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		GetProductByIDFn: func(ctx context.Context, productID string, businessID uint) (string, string, bool, error) {
+			return "", "", false, errors.New("not found")
+		},
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	_, err := uc.SetOpeningCost(context.Background(), request.SetOpeningCostDTO{BusinessID: 10, ProductID: "prod-x", UnitCost: 100})
+
+	assert.ErrorIs(t, err, domainerrors.ErrProductNotFound)
+}
+
+func TestSetOpeningCost_UsaTipoAjuste(t *testing.T) {
+	var gotCode string
+	var gotTypeID uint
+	repo := &mocks.RepositoryMock{
+		GetProductByIDFn: func(ctx context.Context, productID string, businessID uint) (string, string, bool, error) {
+			return "Camisa", "SKU-1", true, nil
+		},
+		GetMovementTypeIDByCodeFn: func(ctx context.Context, code string) (uint, error) {
+			gotCode = code
+			return 4, nil
+		},
+		SetOpeningCostFn: func(ctx context.Context, businessID uint, productID string, unitCost float64, movementTypeID uint, createdByID *uint) (*entities.CostBalance, error) {
+			gotTypeID = movementTypeID
+			return &entities.CostBalance{ProductID: productID, Quantity: 5, TotalValue: 500, AverageCost: unitCost}, nil
+		},
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	balance, err := uc.SetOpeningCost(context.Background(), request.SetOpeningCostDTO{BusinessID: 10, ProductID: "prod-1", UnitCost: 100})
+
+	require.NoError(t, err)
+	assert.Equal(t, "adjustment", gotCode)
+	assert.Equal(t, uint(4), gotTypeID)
+	assert.InDelta(t, 100, balance.AverageCost, 0.0001)
+}
+
+func TestGetProductCost_PromedioNoConsultaCapas(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		ListCostLayersFn: func(ctx context.Context, businessID uint, productID string) ([]entities.CostLayer, error) {
+			t.Fatal("no deberia consultar capas con promedio ponderado")
+			return nil, nil
+		},
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	got, err := uc.GetProductCost(context.Background(), 10, "prod-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.ValuationWeightedAverage, got.Method)
+	assert.Empty(t, got.Layers)
+}
+
+func TestGetValuationReport_SumaTotales(t *testing.T) {
+	var gotParams dtos.ValuationReportParams
+	repo := &mocks.RepositoryMock{
+		GetValuationSettingFn: func(ctx context.Context, businessID uint) (*entities.ValuationSetting, error) {
+			return &entities.ValuationSetting{BusinessID: businessID, Method: entities.ValuationFIFO}, nil
+		},
+		GetValuationReportFn: func(ctx context.Context, params dtos.ValuationReportParams) ([]entities.ValuationReportLine, error) {
+			gotParams = params
+			return []entities.ValuationReportLine{
+				{ProductID: "a", Quantity: 3, TotalValue: 300.10},
+				{ProductID: "b", Quantity: 2, TotalValue: 49.95},
+			}, nil
+		},
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	got, err := uc.GetValuationReport(context.Background(), request.ValuationReportDTO{BusinessID: 10})
+
+	require.NoError(t, err)
+	assert.False(t, gotParams.AsOf.IsZero(), "sin as_of se corta a la fecha actual")
+	assert.Equal(t, entities.ValuationFIFO, got.Method)
+	assert.Equal(t, 5, got.TotalQuantity)
+	assert.InDelta(t, 350.05, got.TotalValue, 0.001)
+}
+
+func TestGetOrderCOGS_NetoDeDevoluciones(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		ListOrderLineCostsFn: func(ctx context.Context, businessID uint, orderID string) ([]entities.OrderLineCost, error) {
+			return []entities.OrderLineCost{
+				{ProductID: "a", Quantity: 3, UnitCost: 100, TotalCost: 300},
+				{ProductID: "b", Quantity: 1, UnitCost: 50, TotalCost: 50},
+				{ProductID: "a", Quantity: -1, UnitCost: 100, TotalCost: -100},
+			}, nil
+		},
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	got, err := uc.GetOrderCOGS(context.Background(), 10, "ord-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "ord-1", got.OrderID)
+	assert.Len(t, got.Lines, 3)
+	assert.InDelta(t, 250, got.TotalCost, 0.001)
+}
+
+func TestExportKardex_TotalesValorizados(t *testing.T) {
+	cost := func(v float64) *float64 { return &v }
+	repo := &mocks.RepositoryMock{
+		GetKardexFn: func(ctx context.Context, params dtos.KardexQueryParams) ([]entities.KardexEntry, error) {
+			return []entities.KardexEntry{
+				{Quantity: 10, RunningBalance: 10, TotalCost: cost(1000), RunningValue: 1000},
+				{Quantity: -4, RunningBalance: 6, TotalCost: cost(-400), RunningValue: 600},
+				{Quantity: 0, RunningBalance: 6, RunningValue: 600},
+			}, nil
+		},
+	}
+	uc := buildUseCase(repo, nil, nil)
+
+	got, err := uc.ExportKardex(context.Background(), request.KardexExportDTO{BusinessID: 10, ProductID: "a", WarehouseID: 1})
+
+	require.NoError(t, err)
+	assert.InDelta(t, 1000, got.TotalValueIn, 0.001)
+	assert.InDelta(t, 400, got.TotalValueOut, 0.001)
+	assert.InDelta(t, 600, got.FinalValue, 0.001)
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/response"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+)
+
+func (uc *useCase) GetValuationSetting(ctx context.Context, businessID uint) (*entities.ValuationSetting, error) {
+	return uc.repo.GetValuationSetting(ctx, businessID)
+}
+
+// SetValuationMethod cambia entre promedio ponderado y FIFO. El cambio no
+// revaloriza lo ya vendido: aplica a los movimientos siguientes.
+func (uc *useCase) SetValuationMethod(ctx context.Context, dto request.SetValuationMethodDTO) (*entities.ValuationSetting, error) {
+	if !entities.IsValidValuationMethod(dto.Method) {
+		return nil, domainerrors.ErrInvalidValuationMethod
+	}
+	return uc.repo.SetValuationMethod(ctx, dto.BusinessID, dto.Method, dto.UserID)
+}
+
+func (uc *useCase) SetOpeningCost(ctx context.Context, dto request.SetOpeningCostDTO) (*entities.CostBalance, error) {
+	if dto.UnitCost < 0 {
+		return nil, domainerrors.ErrInvalidUnitCost
+	}
+	if _, _, _, err := uc.repo.GetProductByID(ctx, dto.ProductID, dto.BusinessID); err != nil {
+		return nil, domainerrors.ErrProductNotFound
+	}
+	movTypeID, err := uc.repo.GetMovementTypeIDByCode(ctx, "adjustment")
+	if err != nil {
+		return nil, err
+	}
+	return uc.repo.SetOpeningCost(ctx, dto.BusinessID, dto.ProductID, dto.UnitCost, movTypeID, dto.UserID)
+}
+
+func (uc *useCase) GetProductCost(ctx context.Context, businessID uint, productID string) (*response.ProductCostResult, error) {
+	setting, err := uc.repo.GetValuationSetting(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := uc.repo.GetCostBalance(ctx, businessID, productID)
+	if err != nil {
+		return nil, err
+	}
+	result := &response.ProductCostResult{Method: setting.Method, Balance: balance, Layers: []entities.CostLayer{}}
+	if setting.Method == entities.ValuationFIFO {
+		layers, err := uc.repo.ListCostLayers(ctx, businessID, productID)
+		if err != nil {
+			return nil, err
+		}
+		result.Layers = layers
+	}
+	return result, nil
+}
+
+// GetValuationReport arma el inventario valorizado a la fecha de corte (ahora
+// si no viene), por producto y opcionalmente de una sola bodega
+func (uc *useCase) GetValuationReport(ctx context.Context, dto request.ValuationReportDTO) (*response.ValuationReportResult, error) {
+	asOf := time.Now()
+	if dto.AsOf != nil {
+		asOf = *dto.AsOf
+	}
+	setting, err := uc.repo.GetValuationSetting(ctx, dto.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := uc.repo.GetValuationReport(ctx, dtos.ValuationReportParams{
+		BusinessID:  dto.BusinessID,
+		AsOf:        asOf,
+		WarehouseID: dto.WarehouseID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &response.ValuationReportResult{
+		BusinessID:  dto.BusinessID,
+		AsOf:        asOf,
+		WarehouseID: dto.WarehouseID,
+		Method:      setting.Method,
+		Lines:       lines,
+	}
+	for _, l := range lines {
+		result.TotalQuantity += l.Quantity
+		result.TotalValue += l.TotalValue
+	}
+	result.TotalValue = roundMoney(result.TotalValue)
+	return result, nil
+}
+
+// GetOrderCOGS devuelve el costo de venta de la orden neto de devoluciones
+func (uc *useCase) GetOrderCOGS(ctx context.Context, businessID uint, orderID string) (*response.OrderCOGSResult, error) {
+	lines, err := uc.repo.ListOrderLineCosts(ctx, businessID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	result := &response.OrderCOGSResult{OrderID: orderID, Lines: lines}
+	for _, l := range lines {
+		result.TotalCost += l.TotalCost
+	}
+	result.TotalCost = roundMoney(result.TotalCost)
+	return result, nil
+}
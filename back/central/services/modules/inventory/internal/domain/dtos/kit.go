@@ -0,0 +1,48 @@
+package dtos
+
+import "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+
+// SaveKitParams reemplaza la lista de materiales completa del kit
+type SaveKitParams struct {
+	BusinessID uint
+	ProductID  string
+	Mode       string
+	Components []entities.KitComponent
+}
+
+type ListKitsParams struct {
+	BusinessID uint
+	Search     string
+	Page       int
+	PageSize   int
+}
+
+type ListKitAssemblyOrdersParams struct {
+	BusinessID   uint
+	KitProductID string
+	Status       string
+	Page         int
+	PageSize     int
+}
+
+// CompleteKitAssemblyParams marca la orden completada solo si sigue pendiente
+type CompleteKitAssemblyParams struct {
+	BusinessID    uint
+	ID            uint
+	KitUnitCost   *float64
+	CompletedByID *uint
+}
+
+func (p ListKitsParams) Offset() int {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	return (p.Page - 1) * p.PageSize
+}
+
+func (p ListKitAssemblyOrdersParams) Offset() int {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	return (p.Page - 1) * p.PageSize
+}
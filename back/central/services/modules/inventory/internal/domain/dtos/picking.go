@@ -0,0 +1,51 @@
+package dtos
+
+import "time"
+
+// PickCandidateParams filtra las ordenes en picking que pueden entrar a una ola.
+// IncludeUnassigned suma las ordenes sin bodega cuando la ola es de la bodega
+// por defecto del negocio.
+type PickCandidateParams struct {
+	BusinessID        uint
+	WarehouseID       uint
+	IncludeUnassigned bool
+	CarrierCode       string
+	CutoffAt          *time.Time
+	MinPriority       *int
+	Limit             int
+}
+
+type ListPickWavesParams struct {
+	BusinessID  uint
+	WarehouseID *uint
+	Status      string
+	Page        int
+	PageSize    int
+}
+
+// UpdatePickWaveStatusParams cambia el estado de la ola solo si esta en uno de
+// los estados From
+type UpdatePickWaveStatusParams struct {
+	BusinessID uint
+	ID         uint
+	From       []string
+	Status     string
+	At         time.Time
+}
+
+// OrderPackageParams son las medidas del paquete que se copian a la orden para
+// generar la guia
+type OrderPackageParams struct {
+	OrderID  string
+	WeightKg float64
+	LengthCm float64
+	WidthCm  float64
+	HeightCm float64
+}
+
+func (p ListPickWavesParams) Offset() int {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	return (p.Page - 1) * p.PageSize
+}
@@ -0,0 +1,32 @@
+package dtos
+
+type ListSuppliersParams struct {
+	BusinessID uint
+	Search     string
+	ActiveOnly bool
+	Page       int
+	PageSize   int
+}
+
+func (p ListSuppliersParams) Offset() int {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	return (p.Page - 1) * p.PageSize
+}
+
+type ListPurchaseOrdersParams struct {
+	BusinessID  uint
+	SupplierID  *uint
+	WarehouseID *uint
+	Status      string
+	Page        int
+	PageSize    int
+}
+
+func (p ListPurchaseOrdersParams) Offset() int {
+	if p.Page < 1 {
+		p.Page = 1
+	}
+	return (p.Page - 1) * p.PageSize
+}
@@ -16,7 +16,10 @@ type AdjustStockTxParams struct {
 	Reason         string
 	Notes          string
 	ReferenceType  string
+	ReferenceID    string
 	CreatedByID    *uint
+	// UnitCost es el costo de la entrada; si no viene se usa el costo vigente
+	UnitCost *float64
 }
 
 // AdjustStockTxResult resultado de la transacción de ajuste
@@ -0,0 +1,11 @@
+package dtos
+
+import "time"
+
+// ValuationReportParams filtra el reporte de inventario valorizado a una fecha
+// de corte. Sin bodega el reporte es de todo el negocio.
+type ValuationReportParams struct {
+	BusinessID  uint
+	AsOf        time.Time
+	WarehouseID *uint
+}
@@ -73,4 +73,7 @@ type KardexEntry struct {
 	ReferenceID      *string
 	LocationID       *uint
 	LotID            *uint
+	UnitCost         *float64
+	TotalCost        *float64
+	RunningValue     float64
 }
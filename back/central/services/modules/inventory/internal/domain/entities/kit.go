@@ -0,0 +1,139 @@
+package entities
+
+import "time"
+
+// Modos de un kit
+const (
+	KitModeVirtual   = "virtual"   // sin stock propio, se descuenta de los componentes
+	KitModeAssembled = "assembled" // se arma con ordenes de ensamble y tiene stock propio
+)
+
+// Tipos y estados de una orden de ensamble
+const (
+	KitAssemblyTypeAssembly    = "assembly"
+	KitAssemblyTypeDisassembly = "disassembly"
+
+	KitAssemblyPending   = "pending"
+	KitAssemblyCompleted = "completed"
+	KitAssemblyCancelled = "cancelled"
+)
+
+func IsValidKitMode(mode string) bool {
+	return mode == KitModeVirtual || mode == KitModeAssembled
+}
+
+// Kit es la lista de materiales de un producto compuesto
+type Kit struct {
+	ID          uint
+	BusinessID  uint
+	ProductID   string
+	ProductName string
+	ProductSKU  string
+	Mode        string
+	IsActive    bool
+	Components  []KitComponent
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// IsVirtual indica si las operaciones de stock del kit se hacen sobre sus
+// componentes
+func (k *Kit) IsVirtual() bool {
+	return k.IsActive && k.Mode == KitModeVirtual
+}
+
+type KitComponent struct {
+	ID          uint
+	ProductID   string
+	ProductName string
+	ProductSKU  string
+	Quantity    int // unidades del componente por kit
+}
+
+// KitComponentAvailability es el disponible de un componente y cuantos kits
+// alcanza a armar
+type KitComponentAvailability struct {
+	ProductID   string
+	ProductName string
+	ProductSKU  string
+	Quantity    int
+	Available   int
+	Buildable   int
+}
+
+// KitAvailableToSell calcula cuantos kits se pueden vender con el disponible
+// de los componentes: el componente mas escaso manda
+func KitAvailableToSell(components []KitComponent, available map[string]int) (int, []KitComponentAvailability) {
+	if len(components) == 0 {
+		return 0, nil
+	}
+	detail := make([]KitComponentAvailability, len(components))
+	ats := -1
+	for i, c := range components {
+		avail := available[c.ProductID]
+		buildable := 0
+		if c.Quantity > 0 && avail > 0 {
+			buildable = avail / c.Quantity
+		}
+		detail[i] = KitComponentAvailability{
+			ProductID:   c.ProductID,
+			ProductName: c.ProductName,
+			ProductSKU:  c.ProductSKU,
+			Quantity:    c.Quantity,
+			Available:   avail,
+			Buildable:   buildable,
+		}
+		if ats < 0 || buildable < ats {
+			ats = buildable
+		}
+	}
+	return ats, detail
+}
+
+// SplitKitCost reparte el costo unitario de un kit entre sus componentes en
+// proporcion a su costo promedio vigente (o a la cantidad si ninguno tiene
+// costo). Devuelve el costo por unidad de cada componente.
+func SplitKitCost(components []KitComponent, averageCosts map[string]float64, kitUnitCost float64) map[string]float64 {
+	out := make(map[string]float64, len(components))
+	var weightTotal float64
+	for _, c := range components {
+		weightTotal += averageCosts[c.ProductID] * float64(c.Quantity)
+	}
+	useQuantity := weightTotal <= 0
+	if useQuantity {
+		for _, c := range components {
+			weightTotal += float64(c.Quantity)
+		}
+	}
+	for _, c := range components {
+		if c.Quantity <= 0 || weightTotal <= 0 {
+			continue
+		}
+		weight := averageCosts[c.ProductID] * float64(c.Quantity)
+		if useQuantity {
+			weight = float64(c.Quantity)
+		}
+		out[c.ProductID] = roundCost(kitUnitCost * weight / weightTotal / float64(c.Quantity))
+	}
+	return out
+}
+
+type KitAssemblyOrder struct {
+	ID            uint
+	BusinessID    uint
+	Code          string
+	Type          string
+	KitProductID  string
+	KitName       string
+	KitSKU        string
+	WarehouseID   uint
+	Quantity      int
+	Status        string
+	KitUnitCost   *float64
+	Notes         string
+	CreatedByID   *uint
+	CompletedByID *uint
+	CompletedAt   *time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
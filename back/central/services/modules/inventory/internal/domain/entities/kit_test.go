@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKitAvailableToSell_ComponenteMasEscasoManda(t *testing.T) {
+	components := []KitComponent{
+		{ProductID: "taza", Quantity: 2},
+		{ProductID: "cafe", Quantity: 1},
+	}
+
+	ats, detail := KitAvailableToSell(components, map[string]int{"taza": 9, "cafe": 7})
+
+	assert.Equal(t, 4, ats)
+	assert.Len(t, detail, 2)
+	assert.Equal(t, 4, detail[0].Buildable)
+	assert.Equal(t, 7, detail[1].Buildable)
+}
+
+func TestKitAvailableToSell_ComponenteSinStock(t *testing.T) {
+	components := []KitComponent{
+		{ProductID: "taza", Quantity: 1},
+		{ProductID: "cafe", Quantity: 1},
+	}
+
+	ats, _ := KitAvailableToSell(components, map[string]int{"taza": 5, "cafe": -2})
+
+	assert.Equal(t, 0, ats)
+}
+
+func TestKitAvailableToSell_SinComponentes(t *testing.T) {
+	ats, detail := KitAvailableToSell(nil, map[string]int{})
+
+	assert.Equal(t, 0, ats)
+	assert.Nil(t, detail)
+}
+
+func TestSplitKitCost_ProporcionalAlPromedio(t *testing.T) {
+	components := []KitComponent{
+		{ProductID: "taza", Quantity: 2},
+		{ProductID: "cafe", Quantity: 1},
+	}
+
+	split := SplitKitCost(components, map[string]float64{"taza": 1000, "cafe": 2000}, 8000)
+
+	// taza pesa 2000 y cafe 2000: cada linea se lleva 4000 del kit
+	assert.InDelta(t, 2000, split["taza"], 0.0001)
+	assert.InDelta(t, 4000, split["cafe"], 0.0001)
+}
+
+func TestSplitKitCost_SinCostosRepartePorCantidad(t *testing.T) {
+	components := []KitComponent{
+		{ProductID: "taza", Quantity: 3},
+		{ProductID: "cafe", Quantity: 1},
+	}
+
+	split := SplitKitCost(components, map[string]float64{}, 4000)
+
+	assert.InDelta(t, 1000, split["taza"], 0.0001)
+	assert.InDelta(t, 1000, split["cafe"], 0.0001)
+}
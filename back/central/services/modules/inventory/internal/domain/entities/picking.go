@@ -0,0 +1,247 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// Estados de una ola de picking
+const (
+	PickWaveReleased  = "released" // lista generada, nadie ha recogido
+	PickWavePicking   = "picking"  // hay al menos una linea confirmada
+	PickWavePicked    = "picked"   // todas las lineas cerradas
+	PickWavePacked    = "packed"   // todas las ordenes empacadas o con faltante
+	PickWaveCancelled = "cancelled"
+)
+
+// Estados de una orden dentro de la ola
+const (
+	PickOrderPending = "pending"
+	PickOrderPicked  = "picked"
+	PickOrderShort   = "short"
+	PickOrderPacked  = "packed"
+)
+
+// Estados de una linea de la lista de picking
+const (
+	PickLinePending = "pending"
+	PickLinePicked  = "picked"
+	PickLineShort   = "short"
+)
+
+type PickWave struct {
+	ID          uint
+	BusinessID  uint
+	WarehouseID uint
+	Code        string
+	Status      string
+	CarrierCode *string
+	CutoffAt    *time.Time
+	ZoneID      *uint
+	MinPriority *int
+	MaxOrders   int
+	Notes       string
+	CreatedByID *uint
+	StartedAt   *time.Time
+	PickedAt    *time.Time
+	PackedAt    *time.Time
+	CancelledAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Orders      []PickWaveOrder
+	Lines       []PickListLine
+}
+
+// IsOpen indica si todavia se puede recoger en la ola
+func (w *PickWave) IsOpen() bool {
+	return w.Status == PickWaveReleased || w.Status == PickWavePicking
+}
+
+type PickWaveOrder struct {
+	ID             uint
+	WaveID         uint
+	OrderID        string
+	OrderNumber    string
+	Priority       int
+	Status         string
+	PackageLpnID   *uint
+	PackageLpnCode string
+	PackedByID     *uint
+	PackedAt       *time.Time
+	Items          []PickWaveItem
+}
+
+// IsFullyPacked indica si cada producto de la orden se recogio completo y se
+// verifico por escaneo en la estacion de empaque
+func (o *PickWaveOrder) IsFullyPacked() bool {
+	for _, it := range o.Items {
+		if it.PickedQuantity < it.Quantity || it.PackedQuantity < it.Quantity {
+			return false
+		}
+	}
+	return len(o.Items) > 0
+}
+
+type PickWaveItem struct {
+	ID             uint
+	WaveOrderID    uint
+	WaveID         uint
+	ProductID      string
+	ProductName    string
+	ProductSKU     string
+	Quantity       int
+	PickedQuantity int
+	PackedQuantity int
+}
+
+type PickListLine struct {
+	ID               uint
+	WaveID           uint
+	Sequence         int
+	ProductID        string
+	ProductName      string
+	ProductSKU       string
+	LocationID       *uint
+	LocationCode     string
+	LocationPath     string
+	QuantityRequired int
+	QuantityPicked   int
+	Status           string
+	PickedByID       *uint
+	PickedAt         *time.Time
+}
+
+// IsClosed indica si la linea ya se recogio completa o se cerro con faltante
+func (l *PickListLine) IsClosed() bool {
+	return l.Status == PickLinePicked || l.Status == PickLineShort
+}
+
+// PickCandidate es una orden lista para picking que puede entrar a una ola
+type PickCandidate struct {
+	OrderID     string
+	OrderNumber string
+	Priority    int
+	Items       []PickDemand
+}
+
+// PickDemand es un producto y la cantidad que hay que recoger
+type PickDemand struct {
+	ProductID string
+	Quantity  int
+}
+
+// LocatedStock es el stock fisico de un producto en una ubicacion, con la ruta
+// zona/pasillo/estanteria/nivel/posicion para ordenar el recorrido
+type LocatedStock struct {
+	ProductID    string
+	LocationID   uint
+	LocationCode string
+	LocationPath string
+	ZoneID       *uint
+	Quantity     int
+}
+
+// BuildPickList consolida la demanda de la ola en paradas por ubicacion. Cada
+// producto se toma de sus ubicaciones en el orden de la ruta; lo que no alcanza
+// queda en una linea sin ubicacion al final del recorrido. Las lineas salen
+// numeradas en el orden en que se recorren.
+func BuildPickList(demand map[string]int, stock []LocatedStock) []PickListLine {
+	byProduct := make(map[string][]LocatedStock)
+	for _, s := range stock {
+		if s.Quantity > 0 {
+			byProduct[s.ProductID] = append(byProduct[s.ProductID], s)
+		}
+	}
+
+	productIDs := make([]string, 0, len(demand))
+	for id := range demand {
+		productIDs = append(productIDs, id)
+	}
+	sort.Strings(productIDs)
+
+	var lines []PickListLine
+	for _, productID := range productIDs {
+		remaining := demand[productID]
+		if remaining <= 0 {
+			continue
+		}
+		locations := byProduct[productID]
+		sort.SliceStable(locations, func(i, j int) bool {
+			return locations[i].LocationPath < locations[j].LocationPath
+		})
+		for _, loc := range locations {
+			if remaining == 0 {
+				break
+			}
+			take := loc.Quantity
+			if take > remaining {
+				take = remaining
+			}
+			locationID := loc.LocationID
+			lines = append(lines, PickListLine{
+				ProductID:        productID,
+				LocationID:       &locationID,
+				LocationCode:     loc.LocationCode,
+				LocationPath:     loc.LocationPath,
+				QuantityRequired: take,
+				Status:           PickLinePending,
+			})
+			remaining -= take
+		}
+		if remaining > 0 {
+			lines = append(lines, PickListLine{
+				ProductID:        productID,
+				QuantityRequired: remaining,
+				Status:           PickLinePending,
+			})
+		}
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		a, b := lines[i], lines[j]
+		if (a.LocationID == nil) != (b.LocationID == nil) {
+			return a.LocationID != nil
+		}
+		if a.LocationPath != b.LocationPath {
+			return a.LocationPath < b.LocationPath
+		}
+		return a.ProductID < b.ProductID
+	})
+	for i := range lines {
+		lines[i].Sequence = i + 1
+	}
+	return lines
+}
+
+// DistributePicked reparte lo recogido de un producto entre las ordenes de la
+// ola, primero las de mayor prioridad y luego por orden de llegada a la ola.
+// Retorna lo asignado a cada item por su ID.
+func DistributePicked(orders []PickWaveOrder, productID string, picked int) map[uint]int {
+	idx := make([]int, len(orders))
+	for i := range orders {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool {
+		oa, ob := orders[idx[a]], orders[idx[b]]
+		if oa.Priority != ob.Priority {
+			return oa.Priority > ob.Priority
+		}
+		return oa.ID < ob.ID
+	})
+
+	out := make(map[uint]int)
+	for _, i := range idx {
+		for _, it := range orders[i].Items {
+			if it.ProductID != productID {
+				continue
+			}
+			take := it.Quantity
+			if take > picked {
+				take = picked
+			}
+			out[it.ID] = take
+			picked -= take
+		}
+	}
+	return out
+}
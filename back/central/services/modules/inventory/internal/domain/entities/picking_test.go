@@ -0,0 +1,74 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildPickList_OrdenaPorRutaYPartePorUbicacion(t *testing.T) {
+	stock := []LocatedStock{
+		{ProductID: "taza", LocationID: 3, LocationCode: "P3", LocationPath: "A/02/R1/N1/P3", Quantity: 10},
+		{ProductID: "taza", LocationID: 1, LocationCode: "P1", LocationPath: "A/01/R1/N1/P1", Quantity: 4},
+		{ProductID: "cafe", LocationID: 2, LocationCode: "P2", LocationPath: "A/01/R2/N1/P2", Quantity: 8},
+	}
+
+	lines := BuildPickList(map[string]int{"taza": 6, "cafe": 3}, stock)
+
+	require.Len(t, lines, 3)
+	assert.Equal(t, "taza", lines[0].ProductID)
+	assert.Equal(t, 4, lines[0].QuantityRequired)
+	assert.Equal(t, "A/01/R1/N1/P1", lines[0].LocationPath)
+	assert.Equal(t, "cafe", lines[1].ProductID)
+	assert.Equal(t, 3, lines[1].QuantityRequired)
+	assert.Equal(t, "taza", lines[2].ProductID)
+	assert.Equal(t, 2, lines[2].QuantityRequired)
+	for i, l := range lines {
+		assert.Equal(t, i+1, l.Sequence)
+		assert.Equal(t, PickLinePending, l.Status)
+	}
+}
+
+func TestBuildPickList_FaltanteQuedaSinUbicacionAlFinal(t *testing.T) {
+	stock := []LocatedStock{
+		{ProductID: "taza", LocationID: 1, LocationPath: "A/01", Quantity: 2},
+	}
+
+	lines := BuildPickList(map[string]int{"taza": 5, "cafe": 1}, stock)
+
+	require.Len(t, lines, 3)
+	assert.Equal(t, "taza", lines[0].ProductID)
+	assert.NotNil(t, lines[0].LocationID)
+	assert.Nil(t, lines[1].LocationID)
+	assert.Equal(t, "cafe", lines[1].ProductID)
+	assert.Nil(t, lines[2].LocationID)
+	assert.Equal(t, 3, lines[2].QuantityRequired)
+}
+
+func TestDistributePicked_PrioridadPrimero(t *testing.T) {
+	orders := []PickWaveOrder{
+		{ID: 1, Priority: 0, Items: []PickWaveItem{{ID: 10, ProductID: "taza", Quantity: 3}}},
+		{ID: 2, Priority: 5, Items: []PickWaveItem{{ID: 20, ProductID: "taza", Quantity: 2}}},
+		{ID: 3, Priority: 0, Items: []PickWaveItem{{ID: 30, ProductID: "taza", Quantity: 2}, {ID: 31, ProductID: "cafe", Quantity: 1}}},
+	}
+
+	alloc := DistributePicked(orders, "taza", 4)
+
+	assert.Equal(t, 2, alloc[20])
+	assert.Equal(t, 2, alloc[10])
+	assert.Equal(t, 0, alloc[30])
+	_, tocaCafe := alloc[31]
+	assert.False(t, tocaCafe)
+}
+
+func TestPickWaveOrder_IsFullyPacked(t *testing.T) {
+	order := PickWaveOrder{Items: []PickWaveItem{
+		{ProductID: "taza", Quantity: 2, PickedQuantity: 2, PackedQuantity: 2},
+		{ProductID: "cafe", Quantity: 1, PickedQuantity: 1, PackedQuantity: 0},
+	}}
+	assert.False(t, order.IsFullyPacked())
+
+	order.Items[1].PackedQuantity = 1
+	assert.True(t, order.IsFullyPacked())
+}
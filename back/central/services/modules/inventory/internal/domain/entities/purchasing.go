@@ -0,0 +1,182 @@
+package entities
+
+import "time"
+
+// Estados de una orden de compra
+const (
+	PurchaseOrderDraft             = "draft"
+	PurchaseOrderApproved          = "approved"
+	PurchaseOrderPartiallyReceived = "partially_received"
+	PurchaseOrderReceived          = "received"
+	PurchaseOrderClosed            = "closed"
+	PurchaseOrderCancelled         = "cancelled"
+)
+
+// Metodos de reparto de los costos de importacion entre las lineas
+const (
+	LandedCostByValue    = "value"
+	LandedCostByQuantity = "quantity"
+)
+
+type Supplier struct {
+	ID                       uint
+	BusinessID               uint
+	Name                     string
+	TaxID                    string
+	ContactName              string
+	Email                    string
+	Phone                    string
+	Address                  string
+	LeadTimeDays             int
+	OverReceiptTolerancePct  float64
+	UnderReceiptTolerancePct float64
+	IsActive                 bool
+	Notes                    string
+	CreatedAt                time.Time
+	UpdatedAt                time.Time
+}
+
+type SupplierProduct struct {
+	ID           uint
+	BusinessID   uint
+	SupplierID   uint
+	ProductID    string
+	SupplierSKU  string
+	UnitCost     float64
+	MinOrderQty  int
+	LeadTimeDays *int
+	IsPreferred  bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	SupplierName string
+}
+
+type PurchaseOrder struct {
+	ID                       uint
+	BusinessID               uint
+	Code                     string
+	SupplierID               uint
+	WarehouseID              uint
+	Status                   string
+	Source                   string
+	Currency                 string
+	ExpectedAt               *time.Time
+	OverReceiptTolerancePct  float64
+	UnderReceiptTolerancePct float64
+	Subtotal                 float64
+	LandedCostTotal          float64
+	Notes                    string
+	CreatedByID              *uint
+	ApprovedByID             *uint
+	ApprovedAt               *time.Time
+	ReceivedAt               *time.Time
+	ClosedAt                 *time.Time
+	CancelledAt              *time.Time
+	CreatedAt                time.Time
+	UpdatedAt                time.Time
+
+	SupplierName string
+	Lines        []PurchaseOrderLine
+}
+
+// CanReceive indica si la orden admite recepciones
+func (po *PurchaseOrder) CanReceive() bool {
+	return po.Status == PurchaseOrderApproved || po.Status == PurchaseOrderPartiallyReceived
+}
+
+// IsOpen indica si la orden todavia puede traer mercancia
+func (po *PurchaseOrder) IsOpen() bool {
+	return po.Status == PurchaseOrderDraft || po.CanReceive()
+}
+
+// FullyReceived indica si todas las lineas llegaron al minimo que permite la
+// tolerancia de faltante. Con tolerancia 0 exige la cantidad pedida completa.
+func (po *PurchaseOrder) FullyReceived() bool {
+	for _, line := range po.Lines {
+		if line.QuantityReceived < line.MinAcceptedQty(po.UnderReceiptTolerancePct) {
+			return false
+		}
+	}
+	return true
+}
+
+type PurchaseOrderLine struct {
+	ID                  uint
+	PurchaseOrderID     uint
+	ProductID           string
+	QuantityOrdered     int
+	QuantityReceived    int
+	UnitCost            float64
+	LandedCostAllocated float64
+	ExpectedAt          *time.Time
+	Notes               string
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+
+	ProductName string
+	ProductSKU  string
+}
+
+// MaxAcceptedQty es lo maximo que se puede recibir de la linea con la tolerancia de sobrante
+func (l *PurchaseOrderLine) MaxAcceptedQty(overPct float64) int {
+	return l.QuantityOrdered + int(float64(l.QuantityOrdered)*overPct/100)
+}
+
+// MinAcceptedQty es lo minimo que debe llegar para dar la linea por completa
+func (l *PurchaseOrderLine) MinAcceptedQty(underPct float64) int {
+	return l.QuantityOrdered - int(float64(l.QuantityOrdered)*underPct/100)
+}
+
+// Pending es lo que falta por recibir de la linea
+func (l *PurchaseOrderLine) Pending() int {
+	if l.QuantityReceived >= l.QuantityOrdered {
+		return 0
+	}
+	return l.QuantityOrdered - l.QuantityReceived
+}
+
+// LandedUnitCost es el costo unitario mas la parte de importacion por unidad
+func (l *PurchaseOrderLine) LandedUnitCost() float64 {
+	if l.QuantityOrdered <= 0 {
+		return l.UnitCost
+	}
+	return l.UnitCost + l.LandedCostAllocated/float64(l.QuantityOrdered)
+}
+
+type PurchaseOrderReceipt struct {
+	ID              uint
+	BusinessID      uint
+	PurchaseOrderID uint
+	WarehouseID     uint
+	LocationID      *uint
+	ReceivedByID    *uint
+	ReceivedAt      time.Time
+	Notes           string
+	CreatedAt       time.Time
+
+	Lines []PurchaseOrderReceiptLine
+}
+
+type PurchaseOrderReceiptLine struct {
+	ID                  uint
+	ReceiptID           uint
+	PurchaseOrderLineID uint
+	ProductID           string
+	Quantity            int
+	LotID               *uint
+	MovementID          *uint
+	UnitCost            float64
+	LandedUnitCost      float64
+}
+
+type PurchaseOrderLandedCost struct {
+	ID               uint
+	PurchaseOrderID  uint
+	Concept          string
+	Description      string
+	Amount           float64
+	AllocationMethod string
+	CreatedByID      *uint
+	CreatedAt        time.Time
+}
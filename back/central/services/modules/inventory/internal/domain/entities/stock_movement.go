@@ -19,6 +19,8 @@ type StockMovement struct {
 	IntegrationID  *uint
 	Notes          string
 	CreatedByID    *uint
+	UnitCost       *float64 // costo segun el metodo de valorizacion; nil si no mueve existencias
+	TotalCost      *float64 // mismo signo que Quantity
 	CreatedAt      time.Time
 
 	// Datos enriquecidos (no en DB)
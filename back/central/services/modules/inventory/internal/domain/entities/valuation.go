@@ -0,0 +1,136 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	ValuationWeightedAverage = "weighted_average"
+	ValuationFIFO            = "fifo"
+)
+
+// IsValidValuationMethod indica si el metodo es uno de los soportados
+func IsValidValuationMethod(method string) bool {
+	return method == ValuationWeightedAverage || method == ValuationFIFO
+}
+
+type ValuationSetting struct {
+	BusinessID  uint
+	Method      string
+	ChangedByID *uint
+	UpdatedAt   time.Time
+}
+
+// CostBalance es el saldo valorizado de un producto en todo el negocio
+type CostBalance struct {
+	ID          uint
+	BusinessID  uint
+	ProductID   string
+	Quantity    int
+	TotalValue  float64
+	AverageCost float64
+	UpdatedAt   time.Time
+}
+
+// Receive suma una entrada al saldo y recalcula el promedio ponderado
+func (b *CostBalance) Receive(qty int, unitCost float64) {
+	b.Quantity += qty
+	b.TotalValue += float64(qty) * unitCost
+	b.recalcAverage(unitCost)
+}
+
+// Issue descuenta una salida valorizada en totalCost
+func (b *CostBalance) Issue(qty int, totalCost float64) {
+	b.Quantity -= qty
+	b.TotalValue -= totalCost
+	b.recalcAverage(b.AverageCost)
+}
+
+// recalcAverage deja el promedio en value/qty. Sin existencias se conserva el
+// ultimo costo conocido para valorizar la siguiente salida (stock negativo).
+func (b *CostBalance) recalcAverage(fallback float64) {
+	if b.Quantity > 0 {
+		b.AverageCost = roundCost(b.TotalValue / float64(b.Quantity))
+		return
+	}
+	b.AverageCost = fallback
+	b.TotalValue = roundCost(float64(b.Quantity) * fallback)
+}
+
+type CostLayer struct {
+	ID           uint
+	BusinessID   uint
+	ProductID    string
+	WarehouseID  *uint
+	MovementID   *uint
+	LotID        *uint
+	ReceivedAt   time.Time
+	OriginalQty  int
+	RemainingQty int
+	UnitCost     float64
+}
+
+// LayerConsumption es lo que una salida toma de una capa
+type LayerConsumption struct {
+	LayerID  uint
+	Quantity int
+	UnitCost float64
+}
+
+// ConsumeFIFO toma qty unidades de las capas en el orden recibido (el mas
+// antiguo primero). Si las capas no alcanzan, el faltante se valoriza a
+// fallbackCost (stock negativo o existencias sin costo de apertura).
+func ConsumeFIFO(layers []CostLayer, qty int, fallbackCost float64) ([]LayerConsumption, float64) {
+	var out []LayerConsumption
+	var total float64
+	pending := qty
+	for _, l := range layers {
+		if pending == 0 {
+			break
+		}
+		if l.RemainingQty <= 0 {
+			continue
+		}
+		take := l.RemainingQty
+		if take > pending {
+			take = pending
+		}
+		out = append(out, LayerConsumption{LayerID: l.ID, Quantity: take, UnitCost: l.UnitCost})
+		total += float64(take) * l.UnitCost
+		pending -= take
+	}
+	if pending > 0 {
+		total += float64(pending) * fallbackCost
+	}
+	return out, roundCost(total)
+}
+
+type OrderLineCost struct {
+	ID          uint
+	BusinessID  uint
+	OrderID     string
+	ProductID   string
+	WarehouseID uint
+	MovementID  uint
+	Quantity    int
+	UnitCost    float64
+	TotalCost   float64
+	Method      string
+	CreatedAt   time.Time
+}
+
+// ValuationReportLine es el valor de un producto a una fecha de corte
+type ValuationReportLine struct {
+	ProductID   string
+	ProductName string
+	ProductSKU  string
+	WarehouseID *uint
+	Quantity    int
+	TotalValue  float64
+	UnitCost    float64
+}
+
+func roundCost(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
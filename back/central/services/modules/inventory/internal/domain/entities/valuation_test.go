@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCostBalance_Receive_PromedioPonderado(t *testing.T) {
+	b := &CostBalance{}
+	b.Receive(10, 1000)
+	b.Receive(10, 2000)
+
+	assert.Equal(t, 20, b.Quantity)
+	assert.InDelta(t, 30000, b.TotalValue, 0.0001)
+	assert.InDelta(t, 1500, b.AverageCost, 0.0001)
+}
+
+func TestCostBalance_Issue_ConservaPromedio(t *testing.T) {
+	b := &CostBalance{}
+	b.Receive(10, 1500)
+	b.Issue(4, 6000)
+
+	assert.Equal(t, 6, b.Quantity)
+	assert.InDelta(t, 9000, b.TotalValue, 0.0001)
+	assert.InDelta(t, 1500, b.AverageCost, 0.0001)
+}
+
+func TestCostBalance_Issue_SinExistenciasQuedaUltimoCosto(t *testing.T) {
+	b := &CostBalance{}
+	b.Receive(2, 500)
+	b.Issue(3, 1500)
+
+	assert.Equal(t, -1, b.Quantity)
+	assert.InDelta(t, 500, b.AverageCost, 0.0001)
+	assert.InDelta(t, -500, b.TotalValue, 0.0001)
+}
+
+func TestConsumeFIFO_TomaLaCapaMasAntiguaPrimero(t *testing.T) {
+	layers := []CostLayer{
+		{ID: 1, RemainingQty: 3, UnitCost: 100},
+		{ID: 2, RemainingQty: 5, UnitCost: 150},
+	}
+
+	consumed, total := ConsumeFIFO(layers, 5, 999)
+
+	assert.Equal(t, []LayerConsumption{
+		{LayerID: 1, Quantity: 3, UnitCost: 100},
+		{LayerID: 2, Quantity: 2, UnitCost: 150},
+	}, consumed)
+	assert.InDelta(t, 600, total, 0.0001)
+}
+
+func TestConsumeFIFO_FaltanteSeValorizaAlCostoDeRespaldo(t *testing.T) {
+	layers := []CostLayer{
+		{ID: 1, RemainingQty: 0, UnitCost: 80},
+		{ID: 2, RemainingQty: 2, UnitCost: 100},
+	}
+
+	consumed, total := ConsumeFIFO(layers, 5, 120)
+
+	assert.Len(t, consumed, 1)
+	assert.Equal(t, uint(2), consumed[0].LayerID)
+	assert.InDelta(t, 2*100+3*120, total, 0.0001)
+}
+
+func TestIsValidValuationMethod(t *testing.T) {
+	assert.True(t, IsValidValuationMethod(ValuationWeightedAverage))
+	assert.True(t, IsValidValuationMethod(ValuationFIFO))
+	assert.False(t, IsValidValuationMethod("lifo"))
+}
@@ -49,4 +49,45 @@ var (
 	ErrLPNEmpty          = errors.New("LPN vacia")
 	ErrScanNotResolved   = errors.New("el codigo escaneado no corresponde a ninguna entidad")
 	ErrDuplicateSyncHash = errors.New("payload ya procesado (idempotencia)")
+
+	ErrSupplierNotFound          = errors.New("proveedor no encontrado")
+	ErrSupplierInactive          = errors.New("el proveedor esta inactivo")
+	ErrSupplierProductNotFound   = errors.New("producto del proveedor no encontrado")
+	ErrPurchaseOrderNotFound     = errors.New("orden de compra no encontrada")
+	ErrPurchaseOrderLineNotFound = errors.New("linea de orden de compra no encontrada")
+	ErrPurchaseOrderEmpty        = errors.New("la orden de compra debe tener al menos una linea")
+	ErrPurchaseOrderNotEditable  = errors.New("la orden de compra ya no se puede modificar")
+	ErrPurchaseOrderTransition   = errors.New("transicion de estado de la orden de compra no permitida")
+	ErrPurchaseOrderNotReceiving = errors.New("la orden de compra no admite recepciones en su estado actual")
+	ErrOverReceipt               = errors.New("la cantidad recibida supera la tolerancia de la linea")
+	ErrSerialCountMismatch       = errors.New("la cantidad de seriales no coincide con la cantidad recibida")
+	ErrInvalidLandedCost         = errors.New("el costo de importacion debe ser positivo")
+	ErrInvalidTolerance          = errors.New("la tolerancia debe estar entre 0 y 100")
+	ErrInvalidValuationMethod    = errors.New("metodo de valorizacion invalido: use weighted_average o fifo")
+	ErrInvalidUnitCost           = errors.New("el costo unitario no puede ser negativo")
+
+	ErrKitNotFound           = errors.New("el producto no tiene lista de materiales")
+	ErrInvalidKitMode        = errors.New("modo de kit invalido: use virtual o assembled")
+	ErrKitEmpty              = errors.New("el kit debe tener al menos un componente")
+	ErrKitSelfComponent      = errors.New("un kit no puede ser componente de si mismo")
+	ErrKitNestedComponent    = errors.New("no se permiten kits dentro de kits")
+	ErrKitDuplicateComponent = errors.New("componente repetido en el kit")
+	ErrKitNotAssembled       = errors.New("solo los kits en modo assembled se arman con ordenes de ensamble")
+	ErrKitAssemblyNotFound   = errors.New("orden de ensamble no encontrada")
+	ErrKitAssemblyNotPending = errors.New("la orden de ensamble ya fue completada o cancelada")
+	ErrInvalidAssemblyType   = errors.New("tipo de orden invalido: use assembly o disassembly")
+
+	ErrPickWaveNotFound      = errors.New("ola de picking no encontrada")
+	ErrPickWaveNoOrders      = errors.New("ninguna orden cumple los criterios de la ola")
+	ErrPickWaveClosed        = errors.New("la ola de picking ya no admite cambios")
+	ErrPickLineNotFound      = errors.New("linea de picking no encontrada")
+	ErrPickLineClosed        = errors.New("la linea de picking ya fue cerrada")
+	ErrPickWrongLocation     = errors.New("la ubicacion escaneada no es la de la linea")
+	ErrPickWrongProduct      = errors.New("el producto escaneado no es el de la linea")
+	ErrPickOverPick          = errors.New("la cantidad recogida supera la requerida")
+	ErrPickOrderNotFound     = errors.New("la orden no pertenece a la ola")
+	ErrPackOrderNotReady     = errors.New("la orden no esta lista para empacar")
+	ErrPackItemNotExpected   = errors.New("el producto escaneado no va en este paquete")
+	ErrPackOverScan          = errors.New("el producto ya se escaneo completo en este paquete")
+	ErrPackIncomplete        = errors.New("faltan productos por verificar en el paquete")
 )
@@ -39,6 +39,10 @@ type IRepository interface {
 	// Product-Integration queries (replicada para sync)
 	GetProductIntegrations(ctx context.Context, productID string, businessID uint) ([]ProductIntegrationInfo, error)
 
+	// InTransaction corre fn en una transaccion: las operaciones ...Tx y los
+	// mensajes del outbox que usen el ctx de fn se confirman juntos
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+
 	// Operaciones transaccionales (SELECT FOR UPDATE + commit atómico)
 	AdjustStockTx(ctx context.Context, params dtos.AdjustStockTxParams) (*dtos.AdjustStockTxResult, error)
 	TransferStockTx(ctx context.Context, params dtos.TransferStockTxParams) (*dtos.TransferStockTxResult, error)
@@ -156,6 +160,75 @@ type IRepository interface {
 	GetSyncLogByHash(ctx context.Context, businessID uint, direction, hash string) (*entities.InventorySyncLog, error)
 	UpdateSyncLogStatus(ctx context.Context, id uint, status, errorMsg string) error
 	ListSyncLogs(ctx context.Context, params dtos.ListSyncLogsParams) ([]entities.InventorySyncLog, int64, error)
+
+	// Compras: proveedores, ordenes de compra y recepciones
+	CreateSupplier(ctx context.Context, s *entities.Supplier) (*entities.Supplier, error)
+	GetSupplierByID(ctx context.Context, businessID, id uint) (*entities.Supplier, error)
+	ListSuppliers(ctx context.Context, params dtos.ListSuppliersParams) ([]entities.Supplier, int64, error)
+	UpdateSupplier(ctx context.Context, s *entities.Supplier) (*entities.Supplier, error)
+
+	UpsertSupplierProduct(ctx context.Context, sp *entities.SupplierProduct) (*entities.SupplierProduct, error)
+	ListSupplierProducts(ctx context.Context, businessID, supplierID uint) ([]entities.SupplierProduct, error)
+	DeleteSupplierProduct(ctx context.Context, businessID, id uint) error
+	GetPreferredSupplierProduct(ctx context.Context, businessID uint, productID string) (*entities.SupplierProduct, error)
+
+	NextPurchaseOrderCode(ctx context.Context, businessID uint) (string, error)
+	CreatePurchaseOrder(ctx context.Context, po *entities.PurchaseOrder) (*entities.PurchaseOrder, error)
+	GetPurchaseOrderByID(ctx context.Context, businessID, id uint) (*entities.PurchaseOrder, error)
+	LockPurchaseOrder(ctx context.Context, businessID, id uint) (*entities.PurchaseOrder, error)
+	ListPurchaseOrders(ctx context.Context, params dtos.ListPurchaseOrdersParams) ([]entities.PurchaseOrder, int64, error)
+	UpdatePurchaseOrder(ctx context.Context, po *entities.PurchaseOrder) error
+	ReplacePurchaseOrderLines(ctx context.Context, poID uint, lines []entities.PurchaseOrderLine) error
+	UpdatePurchaseOrderLine(ctx context.Context, line *entities.PurchaseOrderLine) error
+	OpenPurchaseQuantity(ctx context.Context, businessID, warehouseID uint, productID string) (int, error)
+
+	CreatePurchaseOrderReceipt(ctx context.Context, receipt *entities.PurchaseOrderReceipt) (*entities.PurchaseOrderReceipt, error)
+	ListPurchaseOrderReceipts(ctx context.Context, businessID, poID uint) ([]entities.PurchaseOrderReceipt, error)
+
+	CreateLandedCost(ctx context.Context, cost *entities.PurchaseOrderLandedCost) (*entities.PurchaseOrderLandedCost, error)
+	ListLandedCosts(ctx context.Context, poID uint) ([]entities.PurchaseOrderLandedCost, error)
+
+	GetLotByCode(ctx context.Context, businessID uint, productID, code string) (*entities.InventoryLot, error)
+
+	// Valorizacion: metodo del negocio, saldo valorizado, capas FIFO y costo de venta
+	GetValuationSetting(ctx context.Context, businessID uint) (*entities.ValuationSetting, error)
+	SetValuationMethod(ctx context.Context, businessID uint, method string, changedByID *uint) (*entities.ValuationSetting, error)
+	SetOpeningCost(ctx context.Context, businessID uint, productID string, unitCost float64, movementTypeID uint, createdByID *uint) (*entities.CostBalance, error)
+	GetCostBalance(ctx context.Context, businessID uint, productID string) (*entities.CostBalance, error)
+	ListCostLayers(ctx context.Context, businessID uint, productID string) ([]entities.CostLayer, error)
+	GetValuationReport(ctx context.Context, params dtos.ValuationReportParams) ([]entities.ValuationReportLine, error)
+	ListOrderLineCosts(ctx context.Context, businessID uint, orderID string) ([]entities.OrderLineCost, error)
+
+	// Kits: lista de materiales, disponible por componente y ordenes de ensamble
+	GetKit(ctx context.Context, businessID uint, productID string) (*entities.Kit, error)
+	ListKits(ctx context.Context, params dtos.ListKitsParams) ([]entities.Kit, int64, error)
+	SaveKit(ctx context.Context, params dtos.SaveKitParams) (*entities.Kit, error)
+	DeleteKit(ctx context.Context, businessID uint, productID string) error
+	IsKitComponent(ctx context.Context, businessID uint, productID string) (bool, error)
+	ListVirtualKitsByComponent(ctx context.Context, businessID uint, componentProductID string) ([]string, error)
+	GetAvailableByProducts(ctx context.Context, businessID uint, productIDs []string, warehouseID *uint) (map[string]int, error)
+	NextKitAssemblyCode(ctx context.Context, businessID uint) (string, error)
+	CreateKitAssemblyOrder(ctx context.Context, order *entities.KitAssemblyOrder) (*entities.KitAssemblyOrder, error)
+	GetKitAssemblyOrder(ctx context.Context, businessID, id uint) (*entities.KitAssemblyOrder, error)
+	ListKitAssemblyOrders(ctx context.Context, params dtos.ListKitAssemblyOrdersParams) ([]entities.KitAssemblyOrder, int64, error)
+	CompleteKitAssemblyOrder(ctx context.Context, params dtos.CompleteKitAssemblyParams) error
+	CancelKitAssemblyOrder(ctx context.Context, businessID, id uint) error
+
+	// Olas de picking: seleccion de ordenes, lista por ubicacion y empaque
+	NextPickWaveCode(ctx context.Context, businessID uint) (string, error)
+	ListPickCandidates(ctx context.Context, params dtos.PickCandidateParams) ([]entities.PickCandidate, error)
+	ListLocatedStock(ctx context.Context, businessID, warehouseID uint, productIDs []string, zoneID *uint) ([]entities.LocatedStock, error)
+	CreatePickWave(ctx context.Context, wave *entities.PickWave) (*entities.PickWave, error)
+	GetPickWave(ctx context.Context, businessID, id uint) (*entities.PickWave, error)
+	// LockPickWave es GetPickWave con la fila de la ola bloqueada (FOR UPDATE)
+	// hasta el fin de la transaccion. Solo dentro de InTransaction
+	LockPickWave(ctx context.Context, businessID, id uint) (*entities.PickWave, error)
+	ListPickWaves(ctx context.Context, params dtos.ListPickWavesParams) ([]entities.PickWave, int64, error)
+	UpdatePickWaveStatus(ctx context.Context, params dtos.UpdatePickWaveStatusParams) error
+	UpdatePickListLine(ctx context.Context, line *entities.PickListLine) error
+	UpdatePickWaveItem(ctx context.Context, item *entities.PickWaveItem) error
+	UpdatePickWaveOrder(ctx context.Context, order *entities.PickWaveOrder) error
+	SetOrderPackage(ctx context.Context, params dtos.OrderPackageParams) error
 }
 
 type LocationCapacityInfo struct {
@@ -186,6 +259,16 @@ type ProductIntegrationInfo struct {
 type ISyncPublisher interface {
 	PublishInventorySync(ctx context.Context, msg InventorySyncMessage) error
 	PublishEcommerceStockPush(ctx context.Context, msg EcommerceStockPushMessage) error
+	PublishOrderFeedback(ctx context.Context, msg OrderFeedbackMessage) error
+}
+
+// OrderFeedbackMessage avisa a ordenes un evento de inventario sobre la orden.
+// El flujo de estados del negocio decide a que estado la mueve.
+type OrderFeedbackMessage struct {
+	OrderID    string `json:"order_id"`
+	BusinessID uint   `json:"business_id"`
+	Success    bool   `json:"success"`
+	EventType  string `json:"event_type"`
 }
 
 type EcommerceStockPushMessage struct {
@@ -43,6 +43,7 @@ func (h *handlers) AdjustStock(c *gin.Context) {
 		Reason:      req.Reason,
 		Notes:       req.Notes,
 		CreatedByID: createdByID,
+		UnitCost:    req.UnitCost,
 	}
 
 	movement, err := h.uc.AdjustStock(c.Request.Context(), dto)
@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apprequest "github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/infra/primary/handlers/response"
+)
+
+// kitErrorStatus traduce los errores de kits y ensambles a codigos HTTP
+func kitErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domainerrors.ErrKitNotFound),
+		errors.Is(err, domainerrors.ErrKitAssemblyNotFound),
+		errors.Is(err, domainerrors.ErrProductNotFound),
+		errors.Is(err, domainerrors.ErrWarehouseNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domainerrors.ErrKitAssemblyNotPending),
+		errors.Is(err, domainerrors.ErrKitNotAssembled),
+		errors.Is(err, domainerrors.ErrKitNestedComponent),
+		errors.Is(err, domainerrors.ErrInsufficientStock):
+		return http.StatusConflict
+	case errors.Is(err, domainerrors.ErrInvalidKitMode),
+		errors.Is(err, domainerrors.ErrKitEmpty),
+		errors.Is(err, domainerrors.ErrKitSelfComponent),
+		errors.Is(err, domainerrors.ErrKitDuplicateComponent),
+		errors.Is(err, domainerrors.ErrInvalidAssemblyType),
+		errors.Is(err, domainerrors.ErrInvalidQuantity):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *handlers) ListKits(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	kits, total, err := h.uc.ListKits(c.Request.Context(), dtos.ListKitsParams{
+		BusinessID: businessID,
+		Search:     c.Query("search"),
+		Page:       page,
+		PageSize:   pageSize,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	data := make([]response.KitResponse, len(kits))
+	for i := range kits {
+		data[i] = response.KitFromEntity(&kits[i])
+	}
+	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
+	c.JSON(http.StatusOK, gin.H{
+		"data":        data,
+		"total":       total,
+		"page":        page,
+		"page_size":   pageSize,
+		"total_pages": totalPages,
+	})
+}
+
+func (h *handlers) GetKit(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	kit, err := h.uc.GetKit(c.Request.Context(), businessID, c.Param("productId"))
+	if err != nil {
+		c.JSON(kitErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.KitFromEntity(kit))
+}
+
+func (h *handlers) SaveKit(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	var body request.SaveKitBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": friendlyValidationError(err)})
+		return
+	}
+	dto := apprequest.SaveKitDTO{
+		BusinessID: businessID,
+		ProductID:  c.Param("productId"),
+		Mode:       body.Mode,
+		Components: make([]apprequest.KitComponentInput, len(body.Components)),
+	}
+	for i, comp := range body.Components {
+		dto.Components[i] = apprequest.KitComponentInput{ProductID: comp.ProductID, Quantity: comp.Quantity}
+	}
+	kit, err := h.uc.SaveKit(c.Request.Context(), dto)
+	if err != nil {
+		c.JSON(kitErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.KitFromEntity(kit))
+}
+
+func (h *handlers) DeleteKit(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	if err := h.uc.DeleteKit(c.Request.Context(), businessID, c.Param("productId")); err != nil {
+		c.JSON(kitErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "kit deleted"})
+}
+
+func (h *handlers) GetKitAvailability(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	var warehouseID *uint
+	if v, err := strconv.ParseUint(c.Query("warehouse_id"), 10, 64); err == nil && v > 0 {
+		id := uint(v)
+		warehouseID = &id
+	}
+	result, err := h.uc.GetKitAvailability(c.Request.Context(), businessID, c.Param("productId"), warehouseID)
+	if err != nil {
+		c.JSON(kitErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.KitAvailabilityFromResult(result))
+}
+
+func (h *handlers) CreateKitAssemblyOrder(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	var body request.CreateKitAssemblyOrderBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": friendlyValidationError(err)})
+		return
+	}
+	order, err := h.uc.CreateKitAssemblyOrder(c.Request.Context(), apprequest.CreateKitAssemblyOrderDTO{
+		BusinessID:   businessID,
+		Type:         body.Type,
+		KitProductID: body.KitProductID,
+		WarehouseID:  body.WarehouseID,
+		Quantity:     body.Quantity,
+		Notes:        body.Notes,
+		UserID:       optionalUserID(c),
+	})
+	if err != nil {
+		c.JSON(kitErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, response.KitAssemblyOrderFromEntity(order))
+}
+
+func (h *handlers) ListKitAssemblyOrders(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	orders, total, err := h.uc.ListKitAssemblyOrders(c.Request.Context(), dtos.ListKitAssemblyOrdersParams{
+		BusinessID:   businessID,
+		KitProductID: c.Query("kit_product_id"),
+		Status:       c.Query("status"),
+		Page:         page,
+		PageSize:     pageSize,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	data := make([]response.KitAssemblyOrderResponse, len(orders))
+	for i := range orders {
+		data[i] = response.KitAssemblyOrderFromEntity(&orders[i])
+	}
+	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
+	c.JSON(http.StatusOK, gin.H{
+		"data":        data,
+		"total":       total,
+		"page":        page,
+		"page_size":   pageSize,
+		"total_pages": totalPages,
+	})
+}
+
+func (h *handlers) GetKitAssemblyOrder(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	order, err := h.uc.GetKitAssemblyOrder(c.Request.Context(), businessID, id)
+	if err != nil {
+		c.JSON(kitErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.KitAssemblyOrderFromEntity(order))
+}
+
+func (h *handlers) CompleteKitAssemblyOrder(c *gin.Context) {
+	h.kitAssemblyAction(c, h.uc.CompleteKitAssemblyOrder)
+}
+
+func (h *handlers) CancelKitAssemblyOrder(c *gin.Context) {
+	h.kitAssemblyAction(c, h.uc.CancelKitAssemblyOrder)
+}
+
+func (h *handlers) kitAssemblyAction(c *gin.Context, action func(context.Context, apprequest.KitAssemblyActionDTO) (*entities.KitAssemblyOrder, error)) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	order, err := action(c.Request.Context(), apprequest.KitAssemblyActionDTO{
+		BusinessID: businessID,
+		ID:         id,
+		UserID:     optionalUserID(c),
+	})
+	if err != nil {
+		c.JSON(kitErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.KitAssemblyOrderFromEntity(order))
+}
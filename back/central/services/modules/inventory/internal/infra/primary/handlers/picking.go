@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apprequest "github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/infra/primary/handlers/response"
+)
+
+// pickingErrorStatus traduce los errores de olas de picking y empaque a
+// codigos HTTP
+func pickingErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domainerrors.ErrPickWaveNotFound),
+		errors.Is(err, domainerrors.ErrPickLineNotFound),
+		errors.Is(err, domainerrors.ErrPickOrderNotFound),
+		errors.Is(err, domainerrors.ErrWarehouseNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domainerrors.ErrPickWaveClosed),
+		errors.Is(err, domainerrors.ErrPickLineClosed),
+		errors.Is(err, domainerrors.ErrPickOverPick),
+		errors.Is(err, domainerrors.ErrPackOrderNotReady),
+		errors.Is(err, domainerrors.ErrPackOverScan),
+		errors.Is(err, domainerrors.ErrPackIncomplete):
+		return http.StatusConflict
+	case errors.Is(err, domainerrors.ErrPickWaveNoOrders),
+		errors.Is(err, domainerrors.ErrPickWrongLocation),
+		errors.Is(err, domainerrors.ErrPickWrongProduct),
+		errors.Is(err, domainerrors.ErrPackItemNotExpected),
+		errors.Is(err, domainerrors.ErrInvalidQuantity):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *handlers) CreatePickWave(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	var body request.CreatePickWaveBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": friendlyValidationError(err)})
+		return
+	}
+	wave, err := h.uc.CreatePickWave(c.Request.Context(), apprequest.CreatePickWaveDTO{
+		BusinessID:  businessID,
+		WarehouseID: body.WarehouseID,
+		CarrierCode: body.CarrierCode,
+		CutoffAt:    body.CutoffAt,
+		ZoneID:      body.ZoneID,
+		MinPriority: body.MinPriority,
+		MaxOrders:   body.MaxOrders,
+		Notes:       body.Notes,
+		UserID:      optionalUserID(c),
+	})
+	if err != nil {
+		c.JSON(pickingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, response.PickWaveFromEntity(wave))
+}
+
+func (h *handlers) ListPickWaves(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	params := dtos.ListPickWavesParams{
+		BusinessID: businessID,
+		Status:     c.Query("status"),
+		Page:       page,
+		PageSize:   pageSize,
+	}
+	if v, err := strconv.ParseUint(c.Query("warehouse_id"), 10, 64); err == nil && v > 0 {
+		id := uint(v)
+		params.WarehouseID = &id
+	}
+
+	waves, total, err := h.uc.ListPickWaves(c.Request.Context(), params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	data := make([]response.PickWaveResponse, len(waves))
+	for i := range waves {
+		data[i] = response.PickWaveSummaryFromEntity(&waves[i])
+	}
+	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
+	c.JSON(http.StatusOK, gin.H{
+		"data":        data,
+		"total":       total,
+		"page":        page,
+		"page_size":   pageSize,
+		"total_pages": totalPages,
+	})
+}
+
+func (h *handlers) GetPickWave(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	wave, err := h.uc.GetPickWave(c.Request.Context(), businessID, id)
+	if err != nil {
+		c.JSON(pickingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.PickWaveFromEntity(wave))
+}
+
+func (h *handlers) CancelPickWave(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	wave, err := h.uc.CancelPickWave(c.Request.Context(), apprequest.PickWaveActionDTO{
+		BusinessID: businessID,
+		ID:         id,
+		UserID:     optionalUserID(c),
+	})
+	if err != nil {
+		c.JSON(pickingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.PickWaveFromEntity(wave))
+}
+
+func (h *handlers) ConfirmPickLine(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	waveID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	lineID, ok := parseIDParam(c, "lineId")
+	if !ok {
+		return
+	}
+	var body request.ConfirmPickLineBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": friendlyValidationError(err)})
+		return
+	}
+	wave, err := h.uc.ConfirmPickLine(c.Request.Context(), apprequest.ConfirmPickLineDTO{
+		BusinessID:   businessID,
+		WaveID:       waveID,
+		LineID:       lineID,
+		LocationCode: body.LocationCode,
+		ProductCode:  body.ProductCode,
+		Quantity:     body.Quantity,
+		DeviceID:     body.DeviceID,
+		UserID:       optionalUserID(c),
+	})
+	if err != nil {
+		c.JSON(pickingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.PickWaveFromEntity(wave))
+}
+
+func (h *handlers) ShortPickLine(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	waveID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	lineID, ok := parseIDParam(c, "lineId")
+	if !ok {
+		return
+	}
+	wave, err := h.uc.ShortPickLine(c.Request.Context(), apprequest.ShortPickLineDTO{
+		BusinessID: businessID,
+		WaveID:     waveID,
+		LineID:     lineID,
+		UserID:     optionalUserID(c),
+	})
+	if err != nil {
+		c.JSON(pickingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.PickWaveFromEntity(wave))
+}
+
+func (h *handlers) PackScan(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	waveID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var body request.PackScanBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": friendlyValidationError(err)})
+		return
+	}
+	order, err := h.uc.PackScan(c.Request.Context(), apprequest.PackScanDTO{
+		BusinessID: businessID,
+		WaveID:     waveID,
+		OrderID:    c.Param("orderId"),
+		Code:       body.Code,
+		Quantity:   body.Quantity,
+		DeviceID:   body.DeviceID,
+		UserID:     optionalUserID(c),
+	})
+	if err != nil {
+		c.JSON(pickingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.PickWaveOrderFromEntity(order))
+}
+
+func (h *handlers) ClosePackage(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	waveID, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var body request.ClosePackageBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": friendlyValidationError(err)})
+		return
+	}
+	order, err := h.uc.ClosePackage(c.Request.Context(), apprequest.ClosePackageDTO{
+		BusinessID: businessID,
+		WaveID:     waveID,
+		OrderID:    c.Param("orderId"),
+		WeightKg:   body.WeightKg,
+		LengthCm:   body.LengthCm,
+		WidthCm:    body.WidthCm,
+		HeightCm:   body.HeightCm,
+		UserID:     optionalUserID(c),
+	})
+	if err != nil {
+		c.JSON(pickingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.PickWaveOrderFromEntity(order))
+}
@@ -0,0 +1,581 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	apprequest "github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/infra/primary/handlers/response"
+)
+
+// purchasingErrorStatus traduce los errores de compras a codigos HTTP
+func purchasingErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domainerrors.ErrSupplierNotFound),
+		errors.Is(err, domainerrors.ErrSupplierProductNotFound),
+		errors.Is(err, domainerrors.ErrPurchaseOrderNotFound),
+		errors.Is(err, domainerrors.ErrPurchaseOrderLineNotFound),
+		errors.Is(err, domainerrors.ErrProductNotFound),
+		errors.Is(err, domainerrors.ErrWarehouseNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domainerrors.ErrPurchaseOrderTransition),
+		errors.Is(err, domainerrors.ErrPurchaseOrderNotEditable),
+		errors.Is(err, domainerrors.ErrPurchaseOrderNotReceiving),
+		errors.Is(err, domainerrors.ErrOverReceipt),
+		errors.Is(err, domainerrors.ErrDuplicateSerial),
+		errors.Is(err, domainerrors.ErrSupplierInactive):
+		return http.StatusConflict
+	case errors.Is(err, domainerrors.ErrInvalidQuantity),
+		errors.Is(err, domainerrors.ErrPurchaseOrderEmpty),
+		errors.Is(err, domainerrors.ErrSerialCountMismatch),
+		errors.Is(err, domainerrors.ErrInvalidLandedCost),
+		errors.Is(err, domainerrors.ErrInvalidTolerance):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func optionalUserID(c *gin.Context) *uint {
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		return nil
+	}
+	return &userID
+}
+
+func parseIDParam(c *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func (h *handlers) CreateSupplier(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	var body request.CreateSupplierBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": friendlyValidationError(err)})
+		return
+	}
+	supplier, err := h.uc.CreateSupplier(c.Request.Context(), apprequest.CreateSupplierDTO{
+		BusinessID:               businessID,
+		Name:                     body.Name,
+		TaxID:                    body.TaxID,
+		ContactName:              body.ContactName,
+		Email:                    body.Email,
+		Phone:                    body.Phone,
+		Address:                  body.Address,
+		LeadTimeDays:             body.LeadTimeDays,
+		OverReceiptTolerancePct:  body.OverReceiptTolerancePct,
+		UnderReceiptTolerancePct: body.UnderReceiptTolerancePct,
+		Notes:                    body.Notes,
+	})
+	if err != nil {
+		c.JSON(purchasingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, response.SupplierFromEntity(supplier))
+}
+
+func (h *handlers) ListSuppliers(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	suppliers, total, err := h.uc.ListSuppliers(c.Request.Context(), dtos.ListSuppliersParams{
+		BusinessID: businessID,
+		Search:     c.Query("search"),
+		ActiveOnly: c.Query("active_only") == "true",
+		Page:       page,
+		PageSize:   pageSize,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	data := make([]response.SupplierResponse, len(suppliers))
+	for i := range suppliers {
+		data[i] = response.SupplierFromEntity(&suppliers[i])
+	}
+	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
+	c.JSON(http.StatusOK, gin.H{
+		"data":        data,
+		"total":       total,
+		"page":        page,
+		"page_size":   pageSize,
+		"total_pages": totalPages,
+	})
+}
+
+func (h *handlers) GetSupplier(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	supplier, err := h.uc.GetSupplier(c.Request.Context(), businessID, id)
+	if err != nil {
+		c.JSON(purchasingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.SupplierFromEntity(supplier))
+}
+
+func (h *handlers) UpdateSupplier(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var body request.UpdateSupplierBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": friendlyValidationError(err)})
+		return
+	}
+	supplier, err := h.uc.UpdateSupplier(c.Request.Context(), apprequest.UpdateSupplierDTO{
+		ID:                       id,
+		BusinessID:               businessID,
+		Name:                     body.Name,
+		TaxID:                    body.TaxID,
+		ContactName:              body.ContactName,
+		Email:                    body.Email,
+		Phone:                    body.Phone,
+		Address:                  body.Address,
+		LeadTimeDays:             body.LeadTimeDays,
+		OverReceiptTolerancePct:  body.OverReceiptTolerancePct,
+		UnderReceiptTolerancePct: body.UnderReceiptTolerancePct,
+		IsActive:                 body.IsActive,
+		Notes:                    body.Notes,
+	})
+	if err != nil {
+		c.JSON(purchasingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.SupplierFromEntity(supplier))
+}
+
+func (h *handlers) ListSupplierProducts(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	products, err := h.uc.ListSupplierProducts(c.Request.Context(), businessID, id)
+	if err != nil {
+		c.JSON(purchasingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	data := make([]response.SupplierProductResponse, len(products))
+	for i := range products {
+		data[i] = response.SupplierProductFromEntity(&products[i])
+	}
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+func (h *handlers) SetSupplierProduct(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var body request.SetSupplierProductBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": friendlyValidationError(err)})
+		return
+	}
+	sp, err := h.uc.SetSupplierProduct(c.Request.Context(), apprequest.SetSupplierProductDTO{
+		BusinessID:   businessID,
+		SupplierID:   id,
+		ProductID:    body.ProductID,
+		SupplierSKU:  body.SupplierSKU,
+		UnitCost:     body.UnitCost,
+		MinOrderQty:  body.MinOrderQty,
+		LeadTimeDays: body.LeadTimeDays,
+		IsPreferred:  body.IsPreferred,
+	})
+	if err != nil {
+		c.JSON(purchasingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.SupplierProductFromEntity(sp))
+}
+
+func (h *handlers) DeleteSupplierProduct(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.uc.DeleteSupplierProduct(c.Request.Context(), businessID, id); err != nil {
+		c.JSON(purchasingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "producto del proveedor eliminado"})
+}
+
+func purchaseOrderLineInputs(lines []request.PurchaseOrderLineBody) []apprequest.PurchaseOrderLineInput {
+	if lines == nil {
+		return nil
+	}
+	out := make([]apprequest.PurchaseOrderLineInput, len(lines))
+	for i, l := range lines {
+		out[i] = apprequest.PurchaseOrderLineInput{
+			ProductID:  l.ProductID,
+			Quantity:   l.Quantity,
+			UnitCost:   l.UnitCost,
+			ExpectedAt: l.ExpectedAt,
+			Notes:      l.Notes,
+		}
+	}
+	return out
+}
+
+func (h *handlers) CreatePurchaseOrder(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	var body request.CreatePurchaseOrderBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": friendlyValidationError(err)})
+		return
+	}
+	po, err := h.uc.CreatePurchaseOrder(c.Request.Context(), apprequest.CreatePurchaseOrderDTO{
+		BusinessID:               businessID,
+		SupplierID:               body.SupplierID,
+		WarehouseID:              body.WarehouseID,
+		Currency:                 body.Currency,
+		ExpectedAt:               body.ExpectedAt,
+		OverReceiptTolerancePct:  body.OverReceiptTolerancePct,
+		UnderReceiptTolerancePct: body.UnderReceiptTolerancePct,
+		Notes:                    body.Notes,
+		Lines:                    purchaseOrderLineInputs(body.Lines),
+		CreatedByID:              optionalUserID(c),
+	})
+	if err != nil {
+		c.JSON(purchasingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, response.PurchaseOrderFromEntity(po))
+}
+
+func (h *handlers) ListPurchaseOrders(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+	params := dtos.ListPurchaseOrdersParams{
+		BusinessID: businessID,
+		Status:     c.Query("status"),
+		Page:       page,
+		PageSize:   pageSize,
+	}
+	if v, err := strconv.ParseUint(c.Query("supplier_id"), 10, 64); err == nil && v > 0 {
+		id := uint(v)
+		params.SupplierID = &id
+	}
+	if v, err := strconv.ParseUint(c.Query("warehouse_id"), 10, 64); err == nil && v > 0 {
+		id := uint(v)
+		params.WarehouseID = &id
+	}
+
+	orders, total, err := h.uc.ListPurchaseOrders(c.Request.Context(), params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if pageSize < 1 {
+		pageSize = 10
+	}
+	data := make([]response.PurchaseOrderResponse, len(orders))
+	for i := range orders {
+		data[i] = response.PurchaseOrderFromEntity(&orders[i])
+	}
+	totalPages := int((total + int64(pageSize) - 1) / int64(pageSize))
+	c.JSON(http.StatusOK, gin.H{
+		"data":        data,
+		"total":       total,
+		"page":        page,
+		"page_size":   pageSize,
+		"total_pages": totalPages,
+	})
+}
+
+func (h *handlers) GetPurchaseOrder(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	po, err := h.uc.GetPurchaseOrder(c.Request.Context(), businessID, id)
+	if err != nil {
+		c.JSON(purchasingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.PurchaseOrderFromEntity(po))
+}
+
+func (h *handlers) UpdatePurchaseOrder(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var body request.UpdatePurchaseOrderBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": friendlyValidationError(err)})
+		return
+	}
+	po, err := h.uc.UpdatePurchaseOrder(c.Request.Context(), apprequest.UpdatePurchaseOrderDTO{
+		ID:                       id,
+		BusinessID:               businessID,
+		ExpectedAt:               body.ExpectedAt,
+		OverReceiptTolerancePct:  body.OverReceiptTolerancePct,
+		UnderReceiptTolerancePct: body.UnderReceiptTolerancePct,
+		Notes:                    body.Notes,
+		Lines:                    purchaseOrderLineInputs(body.Lines),
+	})
+	if err != nil {
+		c.JSON(purchasingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.PurchaseOrderFromEntity(po))
+}
+
+func (h *handlers) ApprovePurchaseOrder(c *gin.Context) {
+	h.purchaseOrderAction(c, h.uc.ApprovePurchaseOrder)
+}
+
+func (h *handlers) CancelPurchaseOrder(c *gin.Context) {
+	h.purchaseOrderAction(c, h.uc.CancelPurchaseOrder)
+}
+
+func (h *handlers) ClosePurchaseOrder(c *gin.Context) {
+	h.purchaseOrderAction(c, h.uc.ClosePurchaseOrder)
+}
+
+func (h *handlers) purchaseOrderAction(c *gin.Context, action func(context.Context, apprequest.PurchaseOrderActionDTO) (*entities.PurchaseOrder, error)) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var body request.PurchaseOrderActionBody
+	_ = c.ShouldBindJSON(&body)
+	po, err := action(c.Request.Context(), apprequest.PurchaseOrderActionDTO{
+		BusinessID: businessID,
+		ID:         id,
+		UserID:     optionalUserID(c),
+		Reason:     body.Reason,
+	})
+	if err != nil {
+		c.JSON(purchasingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.PurchaseOrderFromEntity(po))
+}
+
+func (h *handlers) ReceivePurchaseOrder(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var body request.ReceivePurchaseOrderBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": friendlyValidationError(err)})
+		return
+	}
+	dto := apprequest.ReceivePurchaseOrderDTO{
+		BusinessID:      businessID,
+		PurchaseOrderID: id,
+		LocationID:      body.LocationID,
+		Notes:           body.Notes,
+		UserID:          optionalUserID(c),
+	}
+	for _, l := range body.Lines {
+		dto.Lines = append(dto.Lines, apprequest.ReceivePurchaseOrderLineInput{
+			LineID:          l.LineID,
+			Quantity:        l.Quantity,
+			LotCode:         l.LotCode,
+			ManufactureDate: l.ManufactureDate,
+			ExpirationDate:  l.ExpirationDate,
+			SerialNumbers:   l.SerialNumbers,
+		})
+	}
+	result, err := h.uc.ReceivePurchaseOrder(c.Request.Context(), dto)
+	if err != nil {
+		c.JSON(purchasingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	resp := gin.H{
+		"receipt":        response.PurchaseOrderReceiptFromEntity(result.Receipt),
+		"purchase_order": response.PurchaseOrderFromEntity(result.PurchaseOrder),
+	}
+	if result.Putaway != nil {
+		resp["putaway"] = result.Putaway
+	}
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h *handlers) ListPurchaseOrderReceipts(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	receipts, err := h.uc.ListPurchaseOrderReceipts(c.Request.Context(), businessID, id)
+	if err != nil {
+		c.JSON(purchasingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	data := make([]response.PurchaseOrderReceiptResponse, len(receipts))
+	for i := range receipts {
+		data[i] = response.PurchaseOrderReceiptFromEntity(&receipts[i])
+	}
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+func (h *handlers) AddLandedCost(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	var body request.AddLandedCostBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": friendlyValidationError(err)})
+		return
+	}
+	po, err := h.uc.AddLandedCost(c.Request.Context(), apprequest.AddLandedCostDTO{
+		BusinessID:       businessID,
+		PurchaseOrderID:  id,
+		Concept:          body.Concept,
+		Description:      body.Description,
+		Amount:           body.Amount,
+		AllocationMethod: body.AllocationMethod,
+		UserID:           optionalUserID(c),
+	})
+	if err != nil {
+		c.JSON(purchasingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusCreated, response.PurchaseOrderFromEntity(po))
+}
+
+func (h *handlers) ListLandedCosts(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	id, ok := parseIDParam(c, "id")
+	if !ok {
+		return
+	}
+	costs, err := h.uc.ListLandedCosts(c.Request.Context(), businessID, id)
+	if err != nil {
+		c.JSON(purchasingErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	data := make([]response.LandedCostResponse, len(costs))
+	for i := range costs {
+		data[i] = response.LandedCostFromEntity(&costs[i])
+	}
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+func (h *handlers) GenerateDraftPurchaseOrders(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	result, err := h.uc.GenerateDraftPurchaseOrders(c.Request.Context(), apprequest.GenerateDraftPurchaseOrdersDTO{
+		BusinessID: businessID,
+		UserID:     optionalUserID(c),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	data := make([]response.PurchaseOrderResponse, len(result.PurchaseOrders))
+	for i := range result.PurchaseOrders {
+		data[i] = response.PurchaseOrderFromEntity(&result.PurchaseOrders[i])
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"created":          result.Created,
+		"purchase_orders":  data,
+		"without_supplier": result.WithoutSupplier,
+		"already_ordered":  result.AlreadyOrdered,
+	})
+}
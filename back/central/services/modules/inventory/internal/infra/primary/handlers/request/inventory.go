@@ -11,6 +11,8 @@ type AdjustStockRequest struct {
 	Quantity    int    `json:"quantity" binding:"required"`
 	Reason      string `json:"reason" binding:"required,min=2,max=255"`
 	Notes       string `json:"notes" binding:"omitempty,max=1000"`
+	// UnitCost costo de la entrada; sin el se toma el costo vigente del producto
+	UnitCost *float64 `json:"unit_cost" binding:"omitempty,min=0"`
 }
 
 // BulkLoadItemRequest un item de la carga masiva
@@ -0,0 +1,19 @@
+package request
+
+type KitComponentBody struct {
+	ProductID string `json:"product_id" binding:"required"`
+	Quantity  int    `json:"quantity" binding:"required,min=1"`
+}
+
+type SaveKitBody struct {
+	Mode       string             `json:"mode" binding:"omitempty,oneof=virtual assembled"`
+	Components []KitComponentBody `json:"components" binding:"required,min=1,dive"`
+}
+
+type CreateKitAssemblyOrderBody struct {
+	Type         string `json:"type" binding:"required,oneof=assembly disassembly"`
+	KitProductID string `json:"kit_product_id" binding:"required"`
+	WarehouseID  uint   `json:"warehouse_id" binding:"required,min=1"`
+	Quantity     int    `json:"quantity" binding:"required,min=1"`
+	Notes        string `json:"notes" binding:"omitempty,max=1000"`
+}
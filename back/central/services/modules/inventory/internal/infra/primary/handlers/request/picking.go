@@ -0,0 +1,33 @@
+package request
+
+import "time"
+
+type CreatePickWaveBody struct {
+	WarehouseID uint       `json:"warehouse_id" binding:"required,min=1"`
+	CarrierCode string     `json:"carrier_code" binding:"omitempty,max=50"`
+	CutoffAt    *time.Time `json:"cutoff_at"`
+	ZoneID      *uint      `json:"zone_id" binding:"omitempty,min=1"`
+	MinPriority *int       `json:"min_priority" binding:"omitempty,min=0"`
+	MaxOrders   int        `json:"max_orders" binding:"omitempty,min=1,max=500"`
+	Notes       string     `json:"notes" binding:"omitempty,max=1000"`
+}
+
+type ConfirmPickLineBody struct {
+	LocationCode string `json:"location_code"`
+	ProductCode  string `json:"product_code" binding:"required"`
+	Quantity     int    `json:"quantity" binding:"omitempty,min=1"`
+	DeviceID     string `json:"device_id"`
+}
+
+type PackScanBody struct {
+	Code     string `json:"code" binding:"required"`
+	Quantity int    `json:"quantity" binding:"omitempty,min=1"`
+	DeviceID string `json:"device_id"`
+}
+
+type ClosePackageBody struct {
+	WeightKg float64 `json:"weight_kg" binding:"required,gt=0"`
+	LengthCm float64 `json:"length_cm" binding:"omitempty,gt=0"`
+	WidthCm  float64 `json:"width_cm" binding:"omitempty,gt=0"`
+	HeightCm float64 `json:"height_cm" binding:"omitempty,gt=0"`
+}
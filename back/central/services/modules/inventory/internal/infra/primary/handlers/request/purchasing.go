@@ -0,0 +1,92 @@
+package request
+
+import "time"
+
+type CreateSupplierBody struct {
+	Name                     string  `json:"name" binding:"required,max=255"`
+	TaxID                    string  `json:"tax_id" binding:"max=50"`
+	ContactName              string  `json:"contact_name"`
+	Email                    string  `json:"email" binding:"omitempty,email"`
+	Phone                    string  `json:"phone"`
+	Address                  string  `json:"address"`
+	LeadTimeDays             int     `json:"lead_time_days" binding:"min=0"`
+	OverReceiptTolerancePct  float64 `json:"over_receipt_tolerance_pct" binding:"min=0,max=100"`
+	UnderReceiptTolerancePct float64 `json:"under_receipt_tolerance_pct" binding:"min=0,max=100"`
+	Notes                    string  `json:"notes"`
+}
+
+type UpdateSupplierBody struct {
+	Name                     string   `json:"name" binding:"max=255"`
+	TaxID                    *string  `json:"tax_id"`
+	ContactName              *string  `json:"contact_name"`
+	Email                    *string  `json:"email"`
+	Phone                    *string  `json:"phone"`
+	Address                  *string  `json:"address"`
+	LeadTimeDays             *int     `json:"lead_time_days"`
+	OverReceiptTolerancePct  *float64 `json:"over_receipt_tolerance_pct"`
+	UnderReceiptTolerancePct *float64 `json:"under_receipt_tolerance_pct"`
+	IsActive                 *bool    `json:"is_active"`
+	Notes                    *string  `json:"notes"`
+}
+
+type SetSupplierProductBody struct {
+	ProductID    string  `json:"product_id" binding:"required"`
+	SupplierSKU  string  `json:"supplier_sku"`
+	UnitCost     float64 `json:"unit_cost" binding:"min=0"`
+	MinOrderQty  int     `json:"min_order_qty" binding:"min=0"`
+	LeadTimeDays *int    `json:"lead_time_days"`
+	IsPreferred  bool    `json:"is_preferred"`
+}
+
+type PurchaseOrderLineBody struct {
+	ProductID  string     `json:"product_id" binding:"required"`
+	Quantity   int        `json:"quantity" binding:"required,min=1"`
+	UnitCost   float64    `json:"unit_cost" binding:"min=0"`
+	ExpectedAt *time.Time `json:"expected_at"`
+	Notes      string     `json:"notes"`
+}
+
+type CreatePurchaseOrderBody struct {
+	SupplierID               uint                    `json:"supplier_id" binding:"required,min=1"`
+	WarehouseID              uint                    `json:"warehouse_id" binding:"required,min=1"`
+	Currency                 string                  `json:"currency" binding:"omitempty,len=3"`
+	ExpectedAt               *time.Time              `json:"expected_at"`
+	OverReceiptTolerancePct  *float64                `json:"over_receipt_tolerance_pct"`
+	UnderReceiptTolerancePct *float64                `json:"under_receipt_tolerance_pct"`
+	Notes                    string                  `json:"notes"`
+	Lines                    []PurchaseOrderLineBody `json:"lines" binding:"required,min=1,dive"`
+}
+
+type UpdatePurchaseOrderBody struct {
+	ExpectedAt               *time.Time              `json:"expected_at"`
+	OverReceiptTolerancePct  *float64                `json:"over_receipt_tolerance_pct"`
+	UnderReceiptTolerancePct *float64                `json:"under_receipt_tolerance_pct"`
+	Notes                    *string                 `json:"notes"`
+	Lines                    []PurchaseOrderLineBody `json:"lines" binding:"omitempty,min=1,dive"`
+}
+
+type PurchaseOrderActionBody struct {
+	Reason string `json:"reason"`
+}
+
+type ReceivePurchaseOrderLineBody struct {
+	LineID          uint       `json:"line_id" binding:"required,min=1"`
+	Quantity        int        `json:"quantity" binding:"required,min=1"`
+	LotCode         string     `json:"lot_code" binding:"max=100"`
+	ManufactureDate *time.Time `json:"manufacture_date"`
+	ExpirationDate  *time.Time `json:"expiration_date"`
+	SerialNumbers   []string   `json:"serial_numbers"`
+}
+
+type ReceivePurchaseOrderBody struct {
+	LocationID *uint                          `json:"location_id"`
+	Notes      string                         `json:"notes"`
+	Lines      []ReceivePurchaseOrderLineBody `json:"lines" binding:"required,min=1,dive"`
+}
+
+type AddLandedCostBody struct {
+	Concept          string  `json:"concept" binding:"required,oneof=freight customs insurance handling other"`
+	Description      string  `json:"description" binding:"max=255"`
+	Amount           float64 `json:"amount" binding:"required,gt=0"`
+	AllocationMethod string  `json:"allocation_method" binding:"omitempty,oneof=value quantity"`
+}
@@ -0,0 +1,10 @@
+package request
+
+type SetValuationMethodBody struct {
+	Method string `json:"method" binding:"required,oneof=weighted_average fifo"`
+}
+
+type SetOpeningCostBody struct {
+	ProductID string   `json:"product_id" binding:"required"`
+	UnitCost  *float64 `json:"unit_cost" binding:"required,min=0"`
+}
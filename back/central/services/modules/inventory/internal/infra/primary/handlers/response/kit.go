@@ -0,0 +1,124 @@
+package response
+
+import (
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/response"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+)
+
+type KitComponentResponse struct {
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name"`
+	ProductSKU  string `json:"product_sku"`
+	Quantity    int    `json:"quantity"`
+}
+
+type KitResponse struct {
+	ID          uint                   `json:"id"`
+	ProductID   string                 `json:"product_id"`
+	ProductName string                 `json:"product_name"`
+	ProductSKU  string                 `json:"product_sku"`
+	Mode        string                 `json:"mode"`
+	IsActive    bool                   `json:"is_active"`
+	Components  []KitComponentResponse `json:"components"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+type KitComponentAvailabilityResponse struct {
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name"`
+	ProductSKU  string `json:"product_sku"`
+	Quantity    int    `json:"quantity"`
+	Available   int    `json:"available"`
+	Buildable   int    `json:"buildable"`
+}
+
+type KitAvailabilityResponse struct {
+	ProductID       string                             `json:"product_id"`
+	Mode            string                             `json:"mode"`
+	WarehouseID     *uint                              `json:"warehouse_id"`
+	AvailableToSell int                                `json:"available_to_sell"`
+	Components      []KitComponentAvailabilityResponse `json:"components"`
+}
+
+type KitAssemblyOrderResponse struct {
+	ID            uint       `json:"id"`
+	Code          string     `json:"code"`
+	Type          string     `json:"type"`
+	KitProductID  string     `json:"kit_product_id"`
+	KitName       string     `json:"kit_name"`
+	KitSKU        string     `json:"kit_sku"`
+	WarehouseID   uint       `json:"warehouse_id"`
+	Quantity      int        `json:"quantity"`
+	Status        string     `json:"status"`
+	KitUnitCost   *float64   `json:"kit_unit_cost"`
+	Notes         string     `json:"notes"`
+	CreatedByID   *uint      `json:"created_by_id"`
+	CompletedByID *uint      `json:"completed_by_id"`
+	CompletedAt   *time.Time `json:"completed_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+func KitFromEntity(e *entities.Kit) KitResponse {
+	out := KitResponse{
+		ID:          e.ID,
+		ProductID:   e.ProductID,
+		ProductName: e.ProductName,
+		ProductSKU:  e.ProductSKU,
+		Mode:        e.Mode,
+		IsActive:    e.IsActive,
+		Components:  make([]KitComponentResponse, len(e.Components)),
+		UpdatedAt:   e.UpdatedAt,
+	}
+	for i, c := range e.Components {
+		out.Components[i] = KitComponentResponse{
+			ProductID:   c.ProductID,
+			ProductName: c.ProductName,
+			ProductSKU:  c.ProductSKU,
+			Quantity:    c.Quantity,
+		}
+	}
+	return out
+}
+
+func KitAvailabilityFromResult(r *response.KitAvailabilityResult) KitAvailabilityResponse {
+	out := KitAvailabilityResponse{
+		ProductID:       r.ProductID,
+		Mode:            r.Mode,
+		WarehouseID:     r.WarehouseID,
+		AvailableToSell: r.AvailableToSell,
+		Components:      make([]KitComponentAvailabilityResponse, len(r.Components)),
+	}
+	for i, c := range r.Components {
+		out.Components[i] = KitComponentAvailabilityResponse{
+			ProductID:   c.ProductID,
+			ProductName: c.ProductName,
+			ProductSKU:  c.ProductSKU,
+			Quantity:    c.Quantity,
+			Available:   c.Available,
+			Buildable:   c.Buildable,
+		}
+	}
+	return out
+}
+
+func KitAssemblyOrderFromEntity(e *entities.KitAssemblyOrder) KitAssemblyOrderResponse {
+	return KitAssemblyOrderResponse{
+		ID:            e.ID,
+		Code:          e.Code,
+		Type:          e.Type,
+		KitProductID:  e.KitProductID,
+		KitName:       e.KitName,
+		KitSKU:        e.KitSKU,
+		WarehouseID:   e.WarehouseID,
+		Quantity:      e.Quantity,
+		Status:        e.Status,
+		KitUnitCost:   e.KitUnitCost,
+		Notes:         e.Notes,
+		CreatedByID:   e.CreatedByID,
+		CompletedByID: e.CompletedByID,
+		CompletedAt:   e.CompletedAt,
+		CreatedAt:     e.CreatedAt,
+	}
+}
@@ -0,0 +1,144 @@
+package response
+
+import (
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+)
+
+type PickWaveItemResponse struct {
+	ProductID      string `json:"product_id"`
+	ProductName    string `json:"product_name"`
+	ProductSKU     string `json:"product_sku"`
+	Quantity       int    `json:"quantity"`
+	PickedQuantity int    `json:"picked_quantity"`
+	PackedQuantity int    `json:"packed_quantity"`
+}
+
+type PickWaveOrderResponse struct {
+	ID             uint                   `json:"id"`
+	OrderID        string                 `json:"order_id"`
+	OrderNumber    string                 `json:"order_number"`
+	Priority       int                    `json:"priority"`
+	Status         string                 `json:"status"`
+	PackageLpnID   *uint                  `json:"package_lpn_id"`
+	PackageLpnCode string                 `json:"package_lpn_code"`
+	PackedByID     *uint                  `json:"packed_by_id"`
+	PackedAt       *time.Time             `json:"packed_at"`
+	Items          []PickWaveItemResponse `json:"items"`
+}
+
+type PickListLineResponse struct {
+	ID               uint       `json:"id"`
+	Sequence         int        `json:"sequence"`
+	ProductID        string     `json:"product_id"`
+	ProductName      string     `json:"product_name"`
+	ProductSKU       string     `json:"product_sku"`
+	LocationID       *uint      `json:"location_id"`
+	LocationCode     string     `json:"location_code"`
+	LocationPath     string     `json:"location_path"`
+	QuantityRequired int        `json:"quantity_required"`
+	QuantityPicked   int        `json:"quantity_picked"`
+	Status           string     `json:"status"`
+	PickedByID       *uint      `json:"picked_by_id"`
+	PickedAt         *time.Time `json:"picked_at"`
+}
+
+type PickWaveResponse struct {
+	ID          uint                    `json:"id"`
+	Code        string                  `json:"code"`
+	WarehouseID uint                    `json:"warehouse_id"`
+	Status      string                  `json:"status"`
+	CarrierCode *string                 `json:"carrier_code"`
+	CutoffAt    *time.Time              `json:"cutoff_at"`
+	ZoneID      *uint                   `json:"zone_id"`
+	MinPriority *int                    `json:"min_priority"`
+	MaxOrders   int                     `json:"max_orders"`
+	Notes       string                  `json:"notes"`
+	OrderCount  int                     `json:"order_count"`
+	CreatedByID *uint                   `json:"created_by_id"`
+	StartedAt   *time.Time              `json:"started_at"`
+	PickedAt    *time.Time              `json:"picked_at"`
+	PackedAt    *time.Time              `json:"packed_at"`
+	CancelledAt *time.Time              `json:"cancelled_at"`
+	CreatedAt   time.Time               `json:"created_at"`
+	Orders      []PickWaveOrderResponse `json:"orders,omitempty"`
+	Lines       []PickListLineResponse  `json:"lines,omitempty"`
+}
+
+// PickWaveSummaryFromEntity arma la respuesta sin ordenes ni lineas, para
+// listados
+func PickWaveSummaryFromEntity(e *entities.PickWave) PickWaveResponse {
+	return PickWaveResponse{
+		ID:          e.ID,
+		Code:        e.Code,
+		WarehouseID: e.WarehouseID,
+		Status:      e.Status,
+		CarrierCode: e.CarrierCode,
+		CutoffAt:    e.CutoffAt,
+		ZoneID:      e.ZoneID,
+		MinPriority: e.MinPriority,
+		MaxOrders:   e.MaxOrders,
+		Notes:       e.Notes,
+		OrderCount:  len(e.Orders),
+		CreatedByID: e.CreatedByID,
+		StartedAt:   e.StartedAt,
+		PickedAt:    e.PickedAt,
+		PackedAt:    e.PackedAt,
+		CancelledAt: e.CancelledAt,
+		CreatedAt:   e.CreatedAt,
+	}
+}
+
+func PickWaveFromEntity(e *entities.PickWave) PickWaveResponse {
+	out := PickWaveSummaryFromEntity(e)
+	out.Orders = make([]PickWaveOrderResponse, len(e.Orders))
+	for i := range e.Orders {
+		out.Orders[i] = PickWaveOrderFromEntity(&e.Orders[i])
+	}
+	out.Lines = make([]PickListLineResponse, len(e.Lines))
+	for i, l := range e.Lines {
+		out.Lines[i] = PickListLineResponse{
+			ID:               l.ID,
+			Sequence:         l.Sequence,
+			ProductID:        l.ProductID,
+			ProductName:      l.ProductName,
+			ProductSKU:       l.ProductSKU,
+			LocationID:       l.LocationID,
+			LocationCode:     l.LocationCode,
+			LocationPath:     l.LocationPath,
+			QuantityRequired: l.QuantityRequired,
+			QuantityPicked:   l.QuantityPicked,
+			Status:           l.Status,
+			PickedByID:       l.PickedByID,
+			PickedAt:         l.PickedAt,
+		}
+	}
+	return out
+}
+
+func PickWaveOrderFromEntity(e *entities.PickWaveOrder) PickWaveOrderResponse {
+	out := PickWaveOrderResponse{
+		ID:             e.ID,
+		OrderID:        e.OrderID,
+		OrderNumber:    e.OrderNumber,
+		Priority:       e.Priority,
+		Status:         e.Status,
+		PackageLpnID:   e.PackageLpnID,
+		PackageLpnCode: e.PackageLpnCode,
+		PackedByID:     e.PackedByID,
+		PackedAt:       e.PackedAt,
+		Items:          make([]PickWaveItemResponse, len(e.Items)),
+	}
+	for i, it := range e.Items {
+		out.Items[i] = PickWaveItemResponse{
+			ProductID:      it.ProductID,
+			ProductName:    it.ProductName,
+			ProductSKU:     it.ProductSKU,
+			Quantity:       it.Quantity,
+			PickedQuantity: it.PickedQuantity,
+			PackedQuantity: it.PackedQuantity,
+		}
+	}
+	return out
+}
@@ -0,0 +1,231 @@
+package response
+
+import (
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+)
+
+type SupplierResponse struct {
+	ID                       uint      `json:"id"`
+	BusinessID               uint      `json:"business_id"`
+	Name                     string    `json:"name"`
+	TaxID                    string    `json:"tax_id"`
+	ContactName              string    `json:"contact_name"`
+	Email                    string    `json:"email"`
+	Phone                    string    `json:"phone"`
+	Address                  string    `json:"address"`
+	LeadTimeDays             int       `json:"lead_time_days"`
+	OverReceiptTolerancePct  float64   `json:"over_receipt_tolerance_pct"`
+	UnderReceiptTolerancePct float64   `json:"under_receipt_tolerance_pct"`
+	IsActive                 bool      `json:"is_active"`
+	Notes                    string    `json:"notes"`
+	CreatedAt                time.Time `json:"created_at"`
+	UpdatedAt                time.Time `json:"updated_at"`
+}
+
+type SupplierProductResponse struct {
+	ID           uint    `json:"id"`
+	SupplierID   uint    `json:"supplier_id"`
+	SupplierName string  `json:"supplier_name,omitempty"`
+	ProductID    string  `json:"product_id"`
+	SupplierSKU  string  `json:"supplier_sku"`
+	UnitCost     float64 `json:"unit_cost"`
+	MinOrderQty  int     `json:"min_order_qty"`
+	LeadTimeDays *int    `json:"lead_time_days"`
+	IsPreferred  bool    `json:"is_preferred"`
+}
+
+type PurchaseOrderLineResponse struct {
+	ID                  uint       `json:"id"`
+	ProductID           string     `json:"product_id"`
+	ProductName         string     `json:"product_name"`
+	ProductSKU          string     `json:"product_sku"`
+	QuantityOrdered     int        `json:"quantity_ordered"`
+	QuantityReceived    int        `json:"quantity_received"`
+	QuantityPending     int        `json:"quantity_pending"`
+	UnitCost            float64    `json:"unit_cost"`
+	LandedCostAllocated float64    `json:"landed_cost_allocated"`
+	LandedUnitCost      float64    `json:"landed_unit_cost"`
+	ExpectedAt          *time.Time `json:"expected_at"`
+	Notes               string     `json:"notes"`
+}
+
+type PurchaseOrderResponse struct {
+	ID                       uint                        `json:"id"`
+	BusinessID               uint                        `json:"business_id"`
+	Code                     string                      `json:"code"`
+	SupplierID               uint                        `json:"supplier_id"`
+	SupplierName             string                      `json:"supplier_name"`
+	WarehouseID              uint                        `json:"warehouse_id"`
+	Status                   string                      `json:"status"`
+	Source                   string                      `json:"source"`
+	Currency                 string                      `json:"currency"`
+	ExpectedAt               *time.Time                  `json:"expected_at"`
+	OverReceiptTolerancePct  float64                     `json:"over_receipt_tolerance_pct"`
+	UnderReceiptTolerancePct float64                     `json:"under_receipt_tolerance_pct"`
+	Subtotal                 float64                     `json:"subtotal"`
+	LandedCostTotal          float64                     `json:"landed_cost_total"`
+	Notes                    string                      `json:"notes"`
+	CreatedByID              *uint                       `json:"created_by_id"`
+	ApprovedByID             *uint                       `json:"approved_by_id"`
+	ApprovedAt               *time.Time                  `json:"approved_at"`
+	ReceivedAt               *time.Time                  `json:"received_at"`
+	ClosedAt                 *time.Time                  `json:"closed_at"`
+	CancelledAt              *time.Time                  `json:"cancelled_at"`
+	CreatedAt                time.Time                   `json:"created_at"`
+	UpdatedAt                time.Time                   `json:"updated_at"`
+	Lines                    []PurchaseOrderLineResponse `json:"lines,omitempty"`
+}
+
+type PurchaseOrderReceiptLineResponse struct {
+	ID                  uint    `json:"id"`
+	PurchaseOrderLineID uint    `json:"purchase_order_line_id"`
+	ProductID           string  `json:"product_id"`
+	Quantity            int     `json:"quantity"`
+	LotID               *uint   `json:"lot_id"`
+	MovementID          *uint   `json:"movement_id"`
+	UnitCost            float64 `json:"unit_cost"`
+	LandedUnitCost      float64 `json:"landed_unit_cost"`
+}
+
+type PurchaseOrderReceiptResponse struct {
+	ID              uint                               `json:"id"`
+	PurchaseOrderID uint                               `json:"purchase_order_id"`
+	WarehouseID     uint                               `json:"warehouse_id"`
+	LocationID      *uint                              `json:"location_id"`
+	ReceivedByID    *uint                              `json:"received_by_id"`
+	ReceivedAt      time.Time                          `json:"received_at"`
+	Notes           string                             `json:"notes"`
+	Lines           []PurchaseOrderReceiptLineResponse `json:"lines"`
+}
+
+type LandedCostResponse struct {
+	ID               uint      `json:"id"`
+	PurchaseOrderID  uint      `json:"purchase_order_id"`
+	Concept          string    `json:"concept"`
+	Description      string    `json:"description"`
+	Amount           float64   `json:"amount"`
+	AllocationMethod string    `json:"allocation_method"`
+	CreatedByID      *uint     `json:"created_by_id"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+func SupplierFromEntity(e *entities.Supplier) SupplierResponse {
+	return SupplierResponse{
+		ID:                       e.ID,
+		BusinessID:               e.BusinessID,
+		Name:                     e.Name,
+		TaxID:                    e.TaxID,
+		ContactName:              e.ContactName,
+		Email:                    e.Email,
+		Phone:                    e.Phone,
+		Address:                  e.Address,
+		LeadTimeDays:             e.LeadTimeDays,
+		OverReceiptTolerancePct:  e.OverReceiptTolerancePct,
+		UnderReceiptTolerancePct: e.UnderReceiptTolerancePct,
+		IsActive:                 e.IsActive,
+		Notes:                    e.Notes,
+		CreatedAt:                e.CreatedAt,
+		UpdatedAt:                e.UpdatedAt,
+	}
+}
+
+func SupplierProductFromEntity(e *entities.SupplierProduct) SupplierProductResponse {
+	return SupplierProductResponse{
+		ID:           e.ID,
+		SupplierID:   e.SupplierID,
+		SupplierName: e.SupplierName,
+		ProductID:    e.ProductID,
+		SupplierSKU:  e.SupplierSKU,
+		UnitCost:     e.UnitCost,
+		MinOrderQty:  e.MinOrderQty,
+		LeadTimeDays: e.LeadTimeDays,
+		IsPreferred:  e.IsPreferred,
+	}
+}
+
+func PurchaseOrderFromEntity(e *entities.PurchaseOrder) PurchaseOrderResponse {
+	resp := PurchaseOrderResponse{
+		ID:                       e.ID,
+		BusinessID:               e.BusinessID,
+		Code:                     e.Code,
+		SupplierID:               e.SupplierID,
+		SupplierName:             e.SupplierName,
+		WarehouseID:              e.WarehouseID,
+		Status:                   e.Status,
+		Source:                   e.Source,
+		Currency:                 e.Currency,
+		ExpectedAt:               e.ExpectedAt,
+		OverReceiptTolerancePct:  e.OverReceiptTolerancePct,
+		UnderReceiptTolerancePct: e.UnderReceiptTolerancePct,
+		Subtotal:                 e.Subtotal,
+		LandedCostTotal:          e.LandedCostTotal,
+		Notes:                    e.Notes,
+		CreatedByID:              e.CreatedByID,
+		ApprovedByID:             e.ApprovedByID,
+		ApprovedAt:               e.ApprovedAt,
+		ReceivedAt:               e.ReceivedAt,
+		ClosedAt:                 e.ClosedAt,
+		CancelledAt:              e.CancelledAt,
+		CreatedAt:                e.CreatedAt,
+		UpdatedAt:                e.UpdatedAt,
+	}
+	for i := range e.Lines {
+		l := &e.Lines[i]
+		resp.Lines = append(resp.Lines, PurchaseOrderLineResponse{
+			ID:                  l.ID,
+			ProductID:           l.ProductID,
+			ProductName:         l.ProductName,
+			ProductSKU:          l.ProductSKU,
+			QuantityOrdered:     l.QuantityOrdered,
+			QuantityReceived:    l.QuantityReceived,
+			QuantityPending:     l.Pending(),
+			UnitCost:            l.UnitCost,
+			LandedCostAllocated: l.LandedCostAllocated,
+			LandedUnitCost:      l.LandedUnitCost(),
+			ExpectedAt:          l.ExpectedAt,
+			Notes:               l.Notes,
+		})
+	}
+	return resp
+}
+
+func PurchaseOrderReceiptFromEntity(e *entities.PurchaseOrderReceipt) PurchaseOrderReceiptResponse {
+	resp := PurchaseOrderReceiptResponse{
+		ID:              e.ID,
+		PurchaseOrderID: e.PurchaseOrderID,
+		WarehouseID:     e.WarehouseID,
+		LocationID:      e.LocationID,
+		ReceivedByID:    e.ReceivedByID,
+		ReceivedAt:      e.ReceivedAt,
+		Notes:           e.Notes,
+		Lines:           make([]PurchaseOrderReceiptLineResponse, 0, len(e.Lines)),
+	}
+	for _, l := range e.Lines {
+		resp.Lines = append(resp.Lines, PurchaseOrderReceiptLineResponse{
+			ID:                  l.ID,
+			PurchaseOrderLineID: l.PurchaseOrderLineID,
+			ProductID:           l.ProductID,
+			Quantity:            l.Quantity,
+			LotID:               l.LotID,
+			MovementID:          l.MovementID,
+			UnitCost:            l.UnitCost,
+			LandedUnitCost:      l.LandedUnitCost,
+		})
+	}
+	return resp
+}
+
+func LandedCostFromEntity(e *entities.PurchaseOrderLandedCost) LandedCostResponse {
+	return LandedCostResponse{
+		ID:               e.ID,
+		PurchaseOrderID:  e.PurchaseOrderID,
+		Concept:          e.Concept,
+		Description:      e.Description,
+		Amount:           e.Amount,
+		AllocationMethod: e.AllocationMethod,
+		CreatedByID:      e.CreatedByID,
+		CreatedAt:        e.CreatedAt,
+	}
+}
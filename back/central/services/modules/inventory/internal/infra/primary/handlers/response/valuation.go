@@ -0,0 +1,156 @@
+package response
+
+import (
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/response"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+)
+
+type ValuationSettingResponse struct {
+	BusinessID  uint      `json:"business_id"`
+	Method      string    `json:"method"`
+	ChangedByID *uint     `json:"changed_by_id"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type CostBalanceResponse struct {
+	ProductID   string  `json:"product_id"`
+	Quantity    int     `json:"quantity"`
+	TotalValue  float64 `json:"total_value"`
+	AverageCost float64 `json:"average_cost"`
+}
+
+type CostLayerResponse struct {
+	ID           uint      `json:"id"`
+	WarehouseID  *uint     `json:"warehouse_id"`
+	MovementID   *uint     `json:"movement_id"`
+	LotID        *uint     `json:"lot_id"`
+	ReceivedAt   time.Time `json:"received_at"`
+	OriginalQty  int       `json:"original_qty"`
+	RemainingQty int       `json:"remaining_qty"`
+	UnitCost     float64   `json:"unit_cost"`
+}
+
+type ProductCostResponse struct {
+	Method  string              `json:"method"`
+	Balance CostBalanceResponse `json:"balance"`
+	Layers  []CostLayerResponse `json:"layers"`
+}
+
+type ValuationReportLineResponse struct {
+	ProductID   string  `json:"product_id"`
+	ProductName string  `json:"product_name"`
+	ProductSKU  string  `json:"product_sku"`
+	Quantity    int     `json:"quantity"`
+	UnitCost    float64 `json:"unit_cost"`
+	TotalValue  float64 `json:"total_value"`
+}
+
+type ValuationReportResponse struct {
+	AsOf          time.Time                     `json:"as_of"`
+	WarehouseID   *uint                         `json:"warehouse_id"`
+	Method        string                        `json:"method"`
+	Lines         []ValuationReportLineResponse `json:"lines"`
+	TotalQuantity int                           `json:"total_quantity"`
+	TotalValue    float64                       `json:"total_value"`
+}
+
+type OrderLineCostResponse struct {
+	ProductID   string    `json:"product_id"`
+	WarehouseID uint      `json:"warehouse_id"`
+	MovementID  uint      `json:"movement_id"`
+	Quantity    int       `json:"quantity"`
+	UnitCost    float64   `json:"unit_cost"`
+	TotalCost   float64   `json:"total_cost"`
+	Method      string    `json:"method"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type OrderCOGSResponse struct {
+	OrderID   string                  `json:"order_id"`
+	Lines     []OrderLineCostResponse `json:"lines"`
+	TotalCost float64                 `json:"total_cost"`
+}
+
+func ValuationSettingFromEntity(e *entities.ValuationSetting) ValuationSettingResponse {
+	return ValuationSettingResponse{
+		BusinessID:  e.BusinessID,
+		Method:      e.Method,
+		ChangedByID: e.ChangedByID,
+		UpdatedAt:   e.UpdatedAt,
+	}
+}
+
+func CostBalanceFromEntity(e *entities.CostBalance) CostBalanceResponse {
+	return CostBalanceResponse{
+		ProductID:   e.ProductID,
+		Quantity:    e.Quantity,
+		TotalValue:  e.TotalValue,
+		AverageCost: e.AverageCost,
+	}
+}
+
+func ProductCostFromResult(r *response.ProductCostResult) ProductCostResponse {
+	out := ProductCostResponse{
+		Method:  r.Method,
+		Balance: CostBalanceFromEntity(r.Balance),
+		Layers:  make([]CostLayerResponse, len(r.Layers)),
+	}
+	for i, l := range r.Layers {
+		out.Layers[i] = CostLayerResponse{
+			ID:           l.ID,
+			WarehouseID:  l.WarehouseID,
+			MovementID:   l.MovementID,
+			LotID:        l.LotID,
+			ReceivedAt:   l.ReceivedAt,
+			OriginalQty:  l.OriginalQty,
+			RemainingQty: l.RemainingQty,
+			UnitCost:     l.UnitCost,
+		}
+	}
+	return out
+}
+
+func ValuationReportFromResult(r *response.ValuationReportResult) ValuationReportResponse {
+	out := ValuationReportResponse{
+		AsOf:          r.AsOf,
+		WarehouseID:   r.WarehouseID,
+		Method:        r.Method,
+		Lines:         make([]ValuationReportLineResponse, len(r.Lines)),
+		TotalQuantity: r.TotalQuantity,
+		TotalValue:    r.TotalValue,
+	}
+	for i, l := range r.Lines {
+		out.Lines[i] = ValuationReportLineResponse{
+			ProductID:   l.ProductID,
+			ProductName: l.ProductName,
+			ProductSKU:  l.ProductSKU,
+			Quantity:    l.Quantity,
+			UnitCost:    l.UnitCost,
+			TotalValue:  l.TotalValue,
+		}
+	}
+	return out
+}
+
+func OrderCOGSFromResult(r *response.OrderCOGSResult) OrderCOGSResponse {
+	out := OrderCOGSResponse{
+		OrderID:   r.OrderID,
+		Lines:     make([]OrderLineCostResponse, len(r.Lines)),
+		TotalCost: r.TotalCost,
+	}
+	for i, l := range r.Lines {
+		out.Lines[i] = OrderLineCostResponse{
+			ProductID:   l.ProductID,
+			WarehouseID: l.WarehouseID,
+			MovementID:  l.MovementID,
+			Quantity:    l.Quantity,
+			UnitCost:    l.UnitCost,
+			TotalCost:   l.TotalCost,
+			Method:      l.Method,
+			CreatedAt:   l.CreatedAt,
+		}
+	}
+	return out
+}
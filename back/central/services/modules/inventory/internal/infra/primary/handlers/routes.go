@@ -5,19 +5,33 @@ import (
 	"github.com/secamc93/probability/back/central/services/auth/middleware"
 )
 
+// inventoryResource es el recurso de permisos con el que se otorgan las API Keys
+const inventoryResource = "Inventario"
+
 func (h *handlers) RegisterRoutes(router *gin.RouterGroup) {
+	// Lo que consumen las integraciones acepta también API Key, con el
+	// permiso de Inventario que corresponde a cada ruta
+	api := router.Group("/inventory")
+	api.Use(middleware.Auto())
+	if h.moduleAccessMW != nil {
+		api.Use(h.moduleAccessMW)
+	}
+	{
+		api.GET("/product/:productId", middleware.RequirePermission(inventoryResource, "Read"), h.GetProductInventory)
+		api.GET("/warehouse/:warehouseId", middleware.RequirePermission(inventoryResource, "Read"), h.ListWarehouseInventory)
+		api.GET("/movements", middleware.RequirePermission(inventoryResource, "Read"), h.ListMovements)
+		api.POST("/adjust", middleware.RequirePermission(inventoryResource, "Update"), h.AdjustStock)
+		api.POST("/transfer", middleware.RequirePermission(inventoryResource, "Update"), h.TransferStock)
+		api.POST("/sync/inbound/:integrationId", middleware.RequirePermission(inventoryResource, "Update"), h.InboundSync)
+	}
+
 	inventory := router.Group("/inventory")
 	inventory.Use(middleware.JWT())
 	if h.moduleAccessMW != nil {
 		inventory.Use(h.moduleAccessMW)
 	}
 	{
-		inventory.GET("/product/:productId", h.GetProductInventory)
-		inventory.GET("/warehouse/:warehouseId", h.ListWarehouseInventory)
-		inventory.POST("/adjust", h.AdjustStock)
-		inventory.POST("/transfer", h.TransferStock)
 		inventory.POST("/bulk-load", h.BulkLoadInventory)
-		inventory.GET("/movements", h.ListMovements)
 		inventory.POST("/positions/validate-cubing", h.ValidateCubing)
 
 		lots := inventory.Group("/lots")
@@ -77,6 +91,33 @@ func (h *handlers) RegisterRoutes(router *gin.RouterGroup) {
 			replenishment.POST("/tasks/:id/complete", h.CompleteReplenishment)
 			replenishment.POST("/tasks/:id/cancel", h.CancelReplenishment)
 			replenishment.POST("/detect", h.DetectReplenishment)
+			replenishment.POST("/purchase-orders", h.GenerateDraftPurchaseOrders)
+		}
+
+		suppliers := inventory.Group("/suppliers")
+		{
+			suppliers.GET("", h.ListSuppliers)
+			suppliers.POST("", h.CreateSupplier)
+			suppliers.GET("/:id", h.GetSupplier)
+			suppliers.PUT("/:id", h.UpdateSupplier)
+			suppliers.GET("/:id/products", h.ListSupplierProducts)
+			suppliers.POST("/:id/products", h.SetSupplierProduct)
+		}
+		inventory.DELETE("/supplier-products/:id", h.DeleteSupplierProduct)
+
+		purchaseOrders := inventory.Group("/purchase-orders")
+		{
+			purchaseOrders.GET("", h.ListPurchaseOrders)
+			purchaseOrders.POST("", h.CreatePurchaseOrder)
+			purchaseOrders.GET("/:id", h.GetPurchaseOrder)
+			purchaseOrders.PUT("/:id", h.UpdatePurchaseOrder)
+			purchaseOrders.POST("/:id/approve", h.ApprovePurchaseOrder)
+			purchaseOrders.POST("/:id/cancel", h.CancelPurchaseOrder)
+			purchaseOrders.POST("/:id/close", h.ClosePurchaseOrder)
+			purchaseOrders.GET("/:id/receipts", h.ListPurchaseOrderReceipts)
+			purchaseOrders.POST("/:id/receipts", h.ReceivePurchaseOrder)
+			purchaseOrders.GET("/:id/landed-costs", h.ListLandedCosts)
+			purchaseOrders.POST("/:id/landed-costs", h.AddLandedCost)
 		}
 
 		crossDock := inventory.Group("/cross-dock")
@@ -120,6 +161,46 @@ func (h *handlers) RegisterRoutes(router *gin.RouterGroup) {
 
 		inventory.GET("/kardex/export", h.ExportKardex)
 
+		valuation := inventory.Group("/valuation")
+		{
+			valuation.GET("/settings", h.GetValuationSetting)
+			valuation.PUT("/settings", h.SetValuationMethod)
+			valuation.POST("/opening-cost", h.SetOpeningCost)
+			valuation.GET("/products/:productId", h.GetProductCost)
+			valuation.GET("/report", h.GetValuationReport)
+		}
+		inventory.GET("/orders/:orderId/cogs", h.GetOrderCOGS)
+
+		kits := inventory.Group("/kits")
+		{
+			kits.GET("", h.ListKits)
+			kits.GET("/:productId", h.GetKit)
+			kits.PUT("/:productId", h.SaveKit)
+			kits.DELETE("/:productId", h.DeleteKit)
+			kits.GET("/:productId/availability", h.GetKitAvailability)
+		}
+
+		assembly := inventory.Group("/kit-assembly-orders")
+		{
+			assembly.GET("", h.ListKitAssemblyOrders)
+			assembly.POST("", h.CreateKitAssemblyOrder)
+			assembly.GET("/:id", h.GetKitAssemblyOrder)
+			assembly.POST("/:id/complete", h.CompleteKitAssemblyOrder)
+			assembly.POST("/:id/cancel", h.CancelKitAssemblyOrder)
+		}
+
+		waves := inventory.Group("/pick-waves")
+		{
+			waves.GET("", h.ListPickWaves)
+			waves.POST("", h.CreatePickWave)
+			waves.GET("/:id", h.GetPickWave)
+			waves.POST("/:id/cancel", h.CancelPickWave)
+			waves.POST("/:id/lines/:lineId/confirm", h.ConfirmPickLine)
+			waves.POST("/:id/lines/:lineId/short", h.ShortPickLine)
+			waves.POST("/:id/orders/:orderId/pack-scan", h.PackScan)
+			waves.POST("/:id/orders/:orderId/close-package", h.ClosePackage)
+		}
+
 		inventory.POST("/scan", h.Scan)
 
 		lpn := inventory.Group("/lpn")
@@ -135,10 +216,6 @@ func (h *handlers) RegisterRoutes(router *gin.RouterGroup) {
 			lpn.POST("/:id/merge", h.MergeLPN)
 		}
 
-		sync := inventory.Group("/sync")
-		{
-			sync.POST("/inbound/:integrationId", h.InboundSync)
-			sync.GET("/logs", h.ListSyncLogs)
-		}
+		inventory.GET("/sync/logs", h.ListSyncLogs)
 	}
 }
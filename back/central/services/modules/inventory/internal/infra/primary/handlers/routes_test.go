@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/mocks"
+	"github.com/secamc93/probability/back/central/shared/testkit"
+)
+
+// apiKeysDePrueba valida dos claves del negocio 10: una con permiso de
+// lectura de Inventario y otra que solo puede leer Envios
+type apiKeysDePrueba struct{}
+
+func (apiKeysDePrueba) ValidateAPIKey(ctx context.Context, request middleware.ValidateAPIKeyRequest) (*middleware.ValidateAPIKeyResponse, error) {
+	permisos := map[string][]string{
+		"pk_inventario": {"Inventario:Read"},
+		"pk_envios":     {"Envios:Read"},
+	}
+	perms, ok := permisos[request.APIKey]
+	if !ok {
+		return &middleware.ValidateAPIKeyResponse{Success: false, Message: "API Key inválida"}, nil
+	}
+	return &middleware.ValidateAPIKeyResponse{Success: true, UserID: 3, BusinessID: 10, APIKeyID: 1, Permissions: perms}, nil
+}
+
+func routerDePrueba(t *testing.T) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	middleware.InitFromEnv(testkit.NewConfig("JWT_SECRET", "secreto-de-prueba"), testkit.NewSilentLogger())
+	middleware.SetAPIKeyValidator(apiKeysDePrueba{})
+
+	uc := &mocks.UseCaseMock{
+		GetProductInventoryFn: func(ctx context.Context, params dtos.GetProductInventoryParams) ([]entities.InventoryLevel, error) {
+			if params.BusinessID != 10 {
+				t.Errorf("se esperaba el negocio 10 de la API Key, llego %d", params.BusinessID)
+			}
+			return []entities.InventoryLevel{}, nil
+		},
+	}
+	engine := gin.New()
+	New(uc, nil, nil).RegisterRoutes(engine.Group("/api/v1"))
+	return engine
+}
+
+func llamar(engine *gin.Engine, method, path, apiKey string) int {
+	req := httptest.NewRequest(method, path, nil)
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestRoutes_APIKeyConPermiso_Accede(t *testing.T) {
+	engine := routerDePrueba(t)
+
+	code := llamar(engine, http.MethodGet, "/api/v1/inventory/product/prod-001", "pk_inventario")
+
+	if code != http.StatusOK {
+		t.Errorf("status esperado %d, se obtuvo %d", http.StatusOK, code)
+	}
+}
+
+func TestRoutes_APIKeySinPermiso_Rechaza(t *testing.T) {
+	engine := routerDePrueba(t)
+
+	casos := []struct {
+		nombre string
+		method string
+		path   string
+		apiKey string
+		status int
+	}{
+		{"permiso de otro recurso", http.MethodGet, "/api/v1/inventory/product/prod-001", "pk_envios", http.StatusForbidden},
+		{"solo lectura no ajusta", http.MethodPost, "/api/v1/inventory/adjust", "pk_inventario", http.StatusForbidden},
+		{"ruta solo con sesion", http.MethodGet, "/api/v1/inventory/lots", "pk_inventario", http.StatusUnauthorized},
+		{"clave desconocida", http.MethodGet, "/api/v1/inventory/product/prod-001", "pk_otra", http.StatusUnauthorized},
+		{"sin autenticacion", http.MethodGet, "/api/v1/inventory/product/prod-001", "", http.StatusUnauthorized},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			code := llamar(engine, tc.method, tc.path, tc.apiKey)
+
+			if code != tc.status {
+				t.Errorf("status esperado %d, se obtuvo %d", tc.status, code)
+			}
+		})
+	}
+}
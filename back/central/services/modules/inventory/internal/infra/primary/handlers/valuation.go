@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apprequest "github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/infra/primary/handlers/response"
+)
+
+func valuationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, domainerrors.ErrProductNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domainerrors.ErrInvalidValuationMethod),
+		errors.Is(err, domainerrors.ErrInvalidUnitCost):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func (h *handlers) GetValuationSetting(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	setting, err := h.uc.GetValuationSetting(c.Request.Context(), businessID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.ValuationSettingFromEntity(setting))
+}
+
+func (h *handlers) SetValuationMethod(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	var body request.SetValuationMethodBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": friendlyValidationError(err)})
+		return
+	}
+	setting, err := h.uc.SetValuationMethod(c.Request.Context(), apprequest.SetValuationMethodDTO{
+		BusinessID: businessID,
+		Method:     body.Method,
+		UserID:     optionalUserID(c),
+	})
+	if err != nil {
+		c.JSON(valuationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.ValuationSettingFromEntity(setting))
+}
+
+func (h *handlers) SetOpeningCost(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	var body request.SetOpeningCostBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": friendlyValidationError(err)})
+		return
+	}
+	balance, err := h.uc.SetOpeningCost(c.Request.Context(), apprequest.SetOpeningCostDTO{
+		BusinessID: businessID,
+		ProductID:  body.ProductID,
+		UnitCost:   *body.UnitCost,
+		UserID:     optionalUserID(c),
+	})
+	if err != nil {
+		c.JSON(valuationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.CostBalanceFromEntity(balance))
+}
+
+func (h *handlers) GetProductCost(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	result, err := h.uc.GetProductCost(c.Request.Context(), businessID, c.Param("productId"))
+	if err != nil {
+		c.JSON(valuationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.ProductCostFromResult(result))
+}
+
+// GetValuationReport acepta as_of en RFC3339 o como fecha (2006-01-02, se toma
+// hasta el final del dia)
+func (h *handlers) GetValuationReport(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	dto := apprequest.ValuationReportDTO{BusinessID: businessID}
+	if v := c.Query("as_of"); v != "" {
+		asOf, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			day, dayErr := time.Parse("2006-01-02", v)
+			if dayErr != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "as_of must be RFC3339 or YYYY-MM-DD"})
+				return
+			}
+			asOf = day.Add(24*time.Hour - time.Nanosecond)
+		}
+		dto.AsOf = &asOf
+	}
+	if v := c.Query("warehouse_id"); v != "" {
+		whID, err := strconv.ParseUint(v, 10, 64)
+		if err != nil || whID == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid warehouse_id"})
+			return
+		}
+		id := uint(whID)
+		dto.WarehouseID = &id
+	}
+	result, err := h.uc.GetValuationReport(c.Request.Context(), dto)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.ValuationReportFromResult(result))
+}
+
+func (h *handlers) GetOrderCOGS(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+	result, err := h.uc.GetOrderCOGS(c.Request.Context(), businessID, c.Param("orderId"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.OrderCOGSFromResult(result))
+}
@@ -89,10 +89,10 @@ func (c *OrderConsumer) Start(ctx context.Context) {
 	c.logger.Info(ctx).Str("queue", queueName).Msg("Starting inventory order consumer")
 
 	go func() {
-		err := c.queue.Consume(ctx, queueName, func(body []byte) error {
-			c.handleMessage(ctx, body)
+		err := rabbitmq.ConsumeDeliveries(ctx, c.queue, queueName, func(msgCtx context.Context, d rabbitmq.Delivery) error {
+			c.handleMessage(msgCtx, d.Body)
 			return nil
-		})
+		}, 1)
 		if err != nil {
 			c.logger.Error(ctx).Err(err).Msg("Inventory order consumer stopped with error")
 		}
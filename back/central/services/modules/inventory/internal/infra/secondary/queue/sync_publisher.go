@@ -8,6 +8,7 @@ import (
 
 	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/ports"
 	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/outbox"
 	"github.com/secamc93/probability/back/central/shared/rabbitmq"
 )
 
@@ -25,6 +26,10 @@ func ecommerceStockPushQueue(integrationTypeCode string) (string, bool) {
 		return rabbitmq.QueueVtexInventoryStockPush, true
 	case "tiendanube", "nuvemshop":
 		return rabbitmq.QueueTiendanubeInventoryStockPush, true
+	case "magento", "adobe_commerce":
+		return rabbitmq.QueueMagentoInventoryStockPush, true
+	case "falabella":
+		return rabbitmq.QueueFalabellaInventoryStockPush, true
 	}
 	return "", false
 }
@@ -34,12 +39,16 @@ const (
 	exchangeType = "topic"
 )
 
+// SyncPublisher publica los mensajes de sincronizacion de stock. Con outbox, los
+// mensajes se escriben en outbox_events (en la transaccion del movimiento, si
+// el ctx trae una) y el relay los entrega; sin outbox van directo al broker.
 type SyncPublisher struct {
 	queue  rabbitmq.IQueue
+	outbox outbox.IWriter
 	logger log.ILogger
 }
 
-func New(queue rabbitmq.IQueue, logger log.ILogger) ports.ISyncPublisher {
+func New(queue rabbitmq.IQueue, writer outbox.IWriter, logger log.ILogger) ports.ISyncPublisher {
 	if queue == nil {
 		return &SyncPublisher{queue: nil, logger: logger}
 	}
@@ -48,7 +57,17 @@ func New(queue rabbitmq.IQueue, logger log.ILogger) ports.ISyncPublisher {
 		logger.Error().Err(err).Msg("Failed to declare inventory exchange")
 	}
 
-	return &SyncPublisher{queue: queue, logger: logger}
+	return &SyncPublisher{queue: queue, outbox: writer, logger: logger}
+}
+
+func (p *SyncPublisher) send(ctx context.Context, msg outbox.Message) error {
+	if p.outbox != nil {
+		return p.outbox.Enqueue(ctx, msg)
+	}
+	if msg.Exchange == "" {
+		return p.queue.Publish(ctx, msg.RoutingKey, msg.Payload)
+	}
+	return p.queue.PublishToExchange(ctx, msg.Exchange, msg.RoutingKey, msg.Payload)
 }
 
 func (p *SyncPublisher) PublishInventorySync(ctx context.Context, msg ports.InventorySyncMessage) error {
@@ -63,7 +82,10 @@ func (p *SyncPublisher) PublishInventorySync(ctx context.Context, msg ports.Inve
 
 	routingKey := fmt.Sprintf("sync.%d", msg.IntegrationID)
 
-	if err := p.queue.PublishToExchange(ctx, exchangeName, routingKey, body); err != nil {
+	out := outbox.ToExchange(exchangeName, routingKey, "inventory.sync", body).
+		WithAggregate("product", msg.ProductID).
+		WithBusiness(msg.BusinessID)
+	if err := p.send(ctx, out); err != nil {
 		p.logger.Error().
 			Err(err).
 			Str("product_id", msg.ProductID).
@@ -102,7 +124,10 @@ func (p *SyncPublisher) PublishEcommerceStockPush(ctx context.Context, msg ports
 		return err
 	}
 
-	if err := p.queue.Publish(ctx, queueName, body); err != nil {
+	out := outbox.ToQueue(queueName, "inventory.ecommerce_stock_push", body).
+		WithAggregate("product", msg.ProductID).
+		WithBusiness(msg.BusinessID)
+	if err := p.send(ctx, out); err != nil {
 		p.logger.Error().
 			Err(err).
 			Str("product_id", msg.ProductID).
@@ -121,3 +146,29 @@ func (p *SyncPublisher) PublishEcommerceStockPush(ctx context.Context, msg ports
 
 	return nil
 }
+
+// PublishOrderFeedback avisa a ordenes un evento de picking o empaque por la
+// misma cola de feedback que usa la reserva de stock
+func (p *SyncPublisher) PublishOrderFeedback(ctx context.Context, msg ports.OrderFeedbackMessage) error {
+	if p.queue == nil {
+		return nil
+	}
+
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal order feedback message: %w", err)
+	}
+
+	out := outbox.ToQueue(rabbitmq.QueueInventoryOrderFeedback, msg.EventType, body).
+		WithAggregate("order", msg.OrderID).
+		WithBusiness(msg.BusinessID)
+	if err := p.send(ctx, out); err != nil {
+		p.logger.Error().
+			Err(err).
+			Str("order_id", msg.OrderID).
+			Str("event_type", msg.EventType).
+			Msg("Failed to publish order feedback message")
+		return err
+	}
+	return nil
+}
@@ -45,9 +45,15 @@ func (r *Repository) AdjustStockTx(ctx context.Context, params dtos.AdjustStockT
 			Notes:          params.Notes,
 			CreatedByID:    params.CreatedByID,
 		}
+		if params.ReferenceID != "" {
+			movement.ReferenceID = &params.ReferenceID
+		}
 		if err := r.createMovementTx(tx, movement); err != nil {
 			return fmt.Errorf("createMovementTx: %w", err)
 		}
+		if _, err := r.costMovementTx(tx, movement, params.UnitCost); err != nil {
+			return fmt.Errorf("costMovementTx: %w", err)
+		}
 
 		if err := tx.Exec(
 			"UPDATE products SET stock_quantity = COALESCE((SELECT SUM(quantity) FROM inventory_levels WHERE product_id = ? AND deleted_at IS NULL), 0) WHERE id = ?",
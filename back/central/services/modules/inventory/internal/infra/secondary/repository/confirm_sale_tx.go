@@ -11,53 +11,27 @@ import (
 
 // ConfirmSaleTx confirma la venta (shipped/completed): Quantity -= qty, ReservedQty -= qty.
 // Clamp: si ReservedQty < qty, solo decrementa lo que había reservado.
+// Los kits virtuales descuentan sus componentes, cada uno con su costo de venta.
 func (r *Repository) ConfirmSaleTx(ctx context.Context, params dtos.ConfirmSaleTxParams) error {
+	touched := []string{params.ProductID}
+
 	err := r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
-		// 1. SELECT FOR UPDATE
-		level, err := r.getOrCreateLevelTx(tx, params.ProductID, params.WarehouseID, nil, params.BusinessID)
+		components, err := r.virtualKitComponentsTx(tx, params.BusinessID, params.ProductID)
 		if err != nil {
-			return fmt.Errorf("getOrCreateLevelTx: %w", err)
-		}
-
-		previousQty := level.Quantity
-
-		// 2. Clamp reservedQty a lo que realmente está reservado
-		toRelease := params.Quantity
-		if toRelease > level.ReservedQty {
-			toRelease = level.ReservedQty
+			return fmt.Errorf("virtualKitComponentsTx: %w", err)
 		}
-
-		// 3. UPDATE: reducir stock real y reserva
-		level.Quantity -= params.Quantity
-		level.ReservedQty -= toRelease
-		if level.ReservedQty < 0 {
-			level.ReservedQty = 0
-		}
-		level.AvailableQty = level.Quantity - level.ReservedQty
-
-		if err := r.updateLevelTx(tx, level); err != nil {
-			return fmt.Errorf("updateLevelTx: %w", err)
+		if len(components) == 0 {
+			return r.confirmSaleLevelTx(tx, params, "")
 		}
-
-		// 4. INSERT stock_movement (out: Quantity negativo)
-		refType := "order"
-		movement := &models.StockMovement{
-			ProductID:      params.ProductID,
-			WarehouseID:    params.WarehouseID,
-			BusinessID:     params.BusinessID,
-			MovementTypeID: params.MovementTypeID,
-			Reason:         fmt.Sprintf("Venta confirmada por orden %s", params.OrderID),
-			Quantity:       -params.Quantity,
-			PreviousQty:    previousQty,
-			NewQty:         level.Quantity,
-			ReferenceType:  &refType,
-			ReferenceID:    &params.OrderID,
-			Notes:          fmt.Sprintf("Confirmado: %d unidades, Reserva liberada: %d", params.Quantity, toRelease),
+		touched = append(touched, componentProductIDs(components)...)
+		for _, c := range components {
+			componentParams := params
+			componentParams.ProductID = c.ComponentProductID
+			componentParams.Quantity = params.Quantity * c.Quantity
+			if err := r.confirmSaleLevelTx(tx, componentParams, params.ProductID); err != nil {
+				return err
+			}
 		}
-		if err := r.createMovementTx(tx, movement); err != nil {
-			return fmt.Errorf("createMovementTx: %w", err)
-		}
-
 		return nil
 	})
 
@@ -66,9 +40,64 @@ func (r *Repository) ConfirmSaleTx(ctx context.Context, params dtos.ConfirmSaleT
 	}
 
 	// Cache invalidation
-	if r.cache != nil {
-		go r.cache.InvalidateProduct(context.Background(), params.ProductID, params.BusinessID)
-		go r.cache.InvalidateLevel(context.Background(), params.ProductID, params.WarehouseID)
+	r.invalidateProductsCache(params.BusinessID, params.WarehouseID, touched)
+
+	return nil
+}
+
+func (r *Repository) confirmSaleLevelTx(tx *gorm.DB, params dtos.ConfirmSaleTxParams, kitProductID string) error {
+	// 1. SELECT FOR UPDATE
+	level, err := r.getOrCreateLevelTx(tx, params.ProductID, params.WarehouseID, nil, params.BusinessID)
+	if err != nil {
+		return fmt.Errorf("getOrCreateLevelTx: %w", err)
+	}
+
+	previousQty := level.Quantity
+
+	// 2. Clamp reservedQty a lo que realmente está reservado
+	toRelease := params.Quantity
+	if toRelease > level.ReservedQty {
+		toRelease = level.ReservedQty
+	}
+
+	// 3. UPDATE: reducir stock real y reserva
+	level.Quantity -= params.Quantity
+	level.ReservedQty -= toRelease
+	if level.ReservedQty < 0 {
+		level.ReservedQty = 0
+	}
+	level.AvailableQty = level.Quantity - level.ReservedQty
+
+	if err := r.updateLevelTx(tx, level); err != nil {
+		return fmt.Errorf("updateLevelTx: %w", err)
+	}
+
+	// 4. INSERT stock_movement (out: Quantity negativo)
+	refType := "order"
+	movement := &models.StockMovement{
+		ProductID:      params.ProductID,
+		WarehouseID:    params.WarehouseID,
+		BusinessID:     params.BusinessID,
+		MovementTypeID: params.MovementTypeID,
+		Reason:         fmt.Sprintf("Venta confirmada por orden %s", params.OrderID) + kitReasonSuffix(kitProductID),
+		Quantity:       -params.Quantity,
+		PreviousQty:    previousQty,
+		NewQty:         level.Quantity,
+		ReferenceType:  &refType,
+		ReferenceID:    &params.OrderID,
+		Notes:          fmt.Sprintf("Confirmado: %d unidades, Reserva liberada: %d", params.Quantity, toRelease),
+	}
+	if err := r.createMovementTx(tx, movement); err != nil {
+		return fmt.Errorf("createMovementTx: %w", err)
+	}
+
+	// 5. Costo de venta: promedio vigente o capas FIFO, queda por linea de orden
+	cost, err := r.costMovementTx(tx, movement, nil)
+	if err != nil {
+		return fmt.Errorf("costMovementTx: %w", err)
+	}
+	if err := r.createOrderLineCostTx(tx, movement, params.OrderID, cost); err != nil {
+		return fmt.Errorf("createOrderLineCostTx: %w", err)
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -36,3 +37,7 @@ func (r *Repository) resolveAvailableStateID(tx *gorm.DB) (uint, error) {
 	}
 	return r.availableStateID, nil
 }
+
+func (r *Repository) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return db.InTransaction(ctx, r.db, fn)
+}
@@ -149,6 +149,9 @@ func (r *Repository) ApproveDiscrepancyTx(ctx context.Context, params dtos.Appro
 		if err := tx.Create(movement).Error; err != nil {
 			return err
 		}
+		if _, err := r.costMovementTx(tx, movement, nil); err != nil {
+			return err
+		}
 
 		now := time.Now()
 		disc.Status = "approved"
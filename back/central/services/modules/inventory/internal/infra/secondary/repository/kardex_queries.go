@@ -21,6 +21,8 @@ func (r *Repository) GetKardex(ctx context.Context, params dtos.KardexQueryParam
 		ReferenceID      *string
 		LocationID       *uint
 		LotID            *uint
+		UnitCost         *float64
+		TotalCost        *float64
 	}
 	var rows []row
 
@@ -37,7 +39,9 @@ func (r *Repository) GetKardex(ctx context.Context, params dtos.KardexQueryParam
 			sm.reference_type,
 			sm.reference_id,
 			sm.location_id,
-			sm.lot_id`).
+			sm.lot_id,
+			sm.unit_cost,
+			sm.total_cost`).
 		Joins("INNER JOIN stock_movement_types smt ON smt.id = sm.movement_type_id").
 		Where("sm.business_id = ? AND sm.product_id = ? AND sm.warehouse_id = ? AND sm.deleted_at IS NULL",
 			params.BusinessID, params.ProductID, params.WarehouseID)
@@ -55,8 +59,12 @@ func (r *Repository) GetKardex(ctx context.Context, params dtos.KardexQueryParam
 
 	entries := make([]entities.KardexEntry, len(rows))
 	balance := 0
+	value := 0.0
 	for i, row := range rows {
 		balance += row.Quantity
+		if row.TotalCost != nil {
+			value = roundMoney(value + *row.TotalCost)
+		}
 		entries[i] = entities.KardexEntry{
 			MovementID:       row.MovementID,
 			MovementTypeCode: row.MovementTypeCode,
@@ -70,6 +78,9 @@ func (r *Repository) GetKardex(ctx context.Context, params dtos.KardexQueryParam
 			ReferenceID:      row.ReferenceID,
 			LocationID:       row.LocationID,
 			LotID:            row.LotID,
+			UnitCost:         row.UnitCost,
+			TotalCost:        row.TotalCost,
+			RunningValue:     value,
 		}
 	}
 	return entries, nil
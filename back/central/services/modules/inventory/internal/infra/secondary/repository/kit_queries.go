@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/infra/secondary/repository/mappers"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+)
+
+func preloadKit(q *gorm.DB) *gorm.DB {
+	return q.Preload("Product").
+		Preload("Components", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Components.Component")
+}
+
+func (r *Repository) GetKit(ctx context.Context, businessID uint, productID string) (*entities.Kit, error) {
+	var m models.ProductKit
+	err := preloadKit(r.db.Conn(ctx)).
+		Where("business_id = ? AND product_id = ?", businessID, productID).
+		First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerrors.ErrKitNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mappers.KitModelToEntity(&m), nil
+}
+
+func (r *Repository) ListKits(ctx context.Context, params dtos.ListKitsParams) ([]entities.Kit, int64, error) {
+	var ml []models.ProductKit
+	var total int64
+
+	q := r.db.Conn(ctx).Model(&models.ProductKit{}).Where("product_kits.business_id = ?", params.BusinessID)
+	if params.Search != "" {
+		like := "%" + params.Search + "%"
+		q = q.Where("product_kits.product_id IN (SELECT id FROM products WHERE business_id = ? AND (name ILIKE ? OR sku ILIKE ?))",
+			params.BusinessID, like, like)
+	}
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := preloadKit(q).
+		Offset(params.Offset()).Limit(params.PageSize).
+		Order("product_kits.id DESC").
+		Find(&ml).Error; err != nil {
+		return nil, 0, err
+	}
+
+	out := make([]entities.Kit, len(ml))
+	for i := range ml {
+		out[i] = *mappers.KitModelToEntity(&ml[i])
+	}
+	return out, total, nil
+}
+
+// SaveKit crea o reemplaza la lista de materiales. Los componentes se
+// reemplazan completos y el kit y sus componentes quedan con track_inventory
+// para que las operaciones de orden los procesen.
+func (r *Repository) SaveKit(ctx context.Context, params dtos.SaveKitParams) (*entities.Kit, error) {
+	err := r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		var kit models.ProductKit
+		err := tx.Unscoped().Where("business_id = ? AND product_id = ?", params.BusinessID, params.ProductID).First(&kit).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			kit = models.ProductKit{BusinessID: params.BusinessID, ProductID: params.ProductID, Mode: params.Mode, IsActive: true}
+			if err := tx.Create(&kit).Error; err != nil {
+				return err
+			}
+		case err != nil:
+			return err
+		default:
+			if err := tx.Unscoped().Model(&kit).Updates(map[string]any{
+				"mode":       params.Mode,
+				"is_active":  true,
+				"deleted_at": nil,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Unscoped().Where("kit_id = ?", kit.ID).Delete(&models.ProductKitComponent{}).Error; err != nil {
+			return err
+		}
+		components := make([]models.ProductKitComponent, len(params.Components))
+		productIDs := []string{params.ProductID}
+		for i, c := range params.Components {
+			components[i] = models.ProductKitComponent{KitID: kit.ID, ComponentProductID: c.ProductID, Quantity: c.Quantity}
+			productIDs = append(productIDs, c.ProductID)
+		}
+		if err := tx.Create(&components).Error; err != nil {
+			return fmt.Errorf("create kit components: %w", err)
+		}
+
+		return tx.Table("products").
+			Where("id IN ? AND business_id = ? AND deleted_at IS NULL", productIDs, params.BusinessID).
+			Update("track_inventory", true).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetKit(ctx, params.BusinessID, params.ProductID)
+}
+
+func (r *Repository) DeleteKit(ctx context.Context, businessID uint, productID string) error {
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		var kit models.ProductKit
+		if err := tx.Where("business_id = ? AND product_id = ?", businessID, productID).First(&kit).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return domainerrors.ErrKitNotFound
+			}
+			return err
+		}
+		if err := tx.Where("kit_id = ?", kit.ID).Delete(&models.ProductKitComponent{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&kit).Error
+	})
+}
+
+// IsKitComponent indica si el producto es componente de algun kit del negocio
+func (r *Repository) IsKitComponent(ctx context.Context, businessID uint, productID string) (bool, error) {
+	var count int64
+	err := r.db.Conn(ctx).Model(&models.ProductKitComponent{}).
+		Joins("INNER JOIN product_kits pk ON pk.id = product_kit_components.kit_id AND pk.deleted_at IS NULL").
+		Where("pk.business_id = ? AND product_kit_components.component_product_id = ?", businessID, productID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// ListVirtualKitsByComponent devuelve los kits virtuales activos que usan el
+// componente; su disponible para la venta cambia cuando cambia el componente
+func (r *Repository) ListVirtualKitsByComponent(ctx context.Context, businessID uint, componentProductID string) ([]string, error) {
+	var ids []string
+	err := r.db.Conn(ctx).Model(&models.ProductKitComponent{}).
+		Joins("INNER JOIN product_kits pk ON pk.id = product_kit_components.kit_id AND pk.deleted_at IS NULL").
+		Where("pk.business_id = ? AND pk.mode = ? AND pk.is_active = true AND product_kit_components.component_product_id = ?",
+			businessID, entities.KitModeVirtual, componentProductID).
+		Distinct().Pluck("pk.product_id", &ids).Error
+	return ids, err
+}
+
+// GetAvailableByProducts suma available_qty por producto, en todas las bodegas
+// o en una sola
+func (r *Repository) GetAvailableByProducts(ctx context.Context, businessID uint, productIDs []string, warehouseID *uint) (map[string]int, error) {
+	type row struct {
+		ProductID string
+		Available int
+	}
+	var rows []row
+	q := r.db.Conn(ctx).Model(&models.InventoryLevel{}).
+		Select("product_id, COALESCE(SUM(available_qty), 0) AS available").
+		Where("business_id = ? AND product_id IN ?", businessID, productIDs)
+	if warehouseID != nil {
+		q = q.Where("warehouse_id = ?", *warehouseID)
+	}
+	if err := q.Group("product_id").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make(map[string]int, len(rows))
+	for _, row := range rows {
+		out[row.ProductID] = row.Available
+	}
+	return out, nil
+}
+
+func (r *Repository) NextKitAssemblyCode(ctx context.Context, businessID uint) (string, error) {
+	var count int64
+	if err := r.db.Conn(ctx).Unscoped().Model(&models.KitAssemblyOrder{}).
+		Where("business_id = ?", businessID).
+		Count(&count).Error; err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("ENS-%06d", count+1), nil
+}
+
+func (r *Repository) CreateKitAssemblyOrder(ctx context.Context, order *entities.KitAssemblyOrder) (*entities.KitAssemblyOrder, error) {
+	m := mappers.KitAssemblyOrderEntityToModel(order)
+	if err := r.db.Conn(ctx).Create(m).Error; err != nil {
+		return nil, err
+	}
+	return r.GetKitAssemblyOrder(ctx, order.BusinessID, m.ID)
+}
+
+func (r *Repository) GetKitAssemblyOrder(ctx context.Context, businessID, id uint) (*entities.KitAssemblyOrder, error) {
+	var m models.KitAssemblyOrder
+	err := r.db.Conn(ctx).Preload("KitProduct").
+		Where("id = ? AND business_id = ?", id, businessID).
+		First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerrors.ErrKitAssemblyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mappers.KitAssemblyOrderModelToEntity(&m), nil
+}
+
+func (r *Repository) ListKitAssemblyOrders(ctx context.Context, params dtos.ListKitAssemblyOrdersParams) ([]entities.KitAssemblyOrder, int64, error) {
+	var ml []models.KitAssemblyOrder
+	var total int64
+
+	q := r.db.Conn(ctx).Model(&models.KitAssemblyOrder{}).Where("business_id = ?", params.BusinessID)
+	if params.KitProductID != "" {
+		q = q.Where("kit_product_id = ?", params.KitProductID)
+	}
+	if params.Status != "" {
+		q = q.Where("status = ?", params.Status)
+	}
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := q.Preload("KitProduct").
+		Offset(params.Offset()).Limit(params.PageSize).
+		Order("id DESC").
+		Find(&ml).Error; err != nil {
+		return nil, 0, err
+	}
+
+	out := make([]entities.KitAssemblyOrder, len(ml))
+	for i := range ml {
+		out[i] = *mappers.KitAssemblyOrderModelToEntity(&ml[i])
+	}
+	return out, total, nil
+}
+
+// CompleteKitAssemblyOrder pasa la orden a completed solo si sigue pendiente,
+// asi dos completados simultaneos no mueven el stock dos veces
+func (r *Repository) CompleteKitAssemblyOrder(ctx context.Context, params dtos.CompleteKitAssemblyParams) error {
+	now := time.Now()
+	res := r.db.Conn(ctx).Model(&models.KitAssemblyOrder{}).
+		Where("id = ? AND business_id = ? AND status = ?", params.ID, params.BusinessID, entities.KitAssemblyPending).
+		Updates(map[string]any{
+			"status":          entities.KitAssemblyCompleted,
+			"kit_unit_cost":   params.KitUnitCost,
+			"completed_by_id": params.CompletedByID,
+			"completed_at":    now,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainerrors.ErrKitAssemblyNotPending
+	}
+	return nil
+}
+
+func (r *Repository) CancelKitAssemblyOrder(ctx context.Context, businessID, id uint) error {
+	res := r.db.Conn(ctx).Model(&models.KitAssemblyOrder{}).
+		Where("id = ? AND business_id = ? AND status = ?", id, businessID, entities.KitAssemblyPending).
+		Update("status", entities.KitAssemblyCancelled)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainerrors.ErrKitAssemblyNotPending
+	}
+	return nil
+}
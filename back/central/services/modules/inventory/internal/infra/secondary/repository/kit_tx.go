@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+)
+
+// virtualKitComponentsTx devuelve los componentes si el producto es un kit
+// virtual activo (vacio en cualquier otro caso). Van ordenados por producto
+// para que los locks de nivel se tomen siempre en el mismo orden.
+func (r *Repository) virtualKitComponentsTx(tx *gorm.DB, businessID uint, productID string) ([]models.ProductKitComponent, error) {
+	var components []models.ProductKitComponent
+	err := tx.Model(&models.ProductKitComponent{}).
+		Joins("INNER JOIN product_kits pk ON pk.id = product_kit_components.kit_id AND pk.deleted_at IS NULL").
+		Where("pk.business_id = ? AND pk.product_id = ? AND pk.mode = ? AND pk.is_active = true",
+			businessID, productID, entities.KitModeVirtual).
+		Order("product_kit_components.component_product_id ASC").
+		Find(&components).Error
+	return components, err
+}
+
+// reserveKitTx reserva kits completos: con el disponible de cada componente se
+// calcula cuantos kits alcanzan y se reserva esa cantidad en todos, para que
+// una reserva parcial nunca deje componentes sueltos apartados.
+func (r *Repository) reserveKitTx(tx *gorm.DB, params dtos.ReserveStockTxParams, components []models.ProductKitComponent) (*dtos.ReserveStockTxResult, error) {
+	kits := params.Quantity
+	available := -1
+	for _, c := range components {
+		level, err := r.getOrCreateLevelTx(tx, c.ComponentProductID, params.WarehouseID, nil, params.BusinessID)
+		if err != nil {
+			return nil, err
+		}
+		buildable := 0
+		if c.Quantity > 0 && level.AvailableQty > 0 {
+			buildable = level.AvailableQty / c.Quantity
+		}
+		if available < 0 || buildable < available {
+			available = buildable
+		}
+	}
+	if available < kits {
+		kits = available
+	}
+	if kits < 0 {
+		kits = 0
+	}
+
+	if kits > 0 {
+		for _, c := range components {
+			componentParams := params
+			componentParams.ProductID = c.ComponentProductID
+			componentParams.Quantity = kits * c.Quantity
+			if _, err := r.reserveLevelTx(tx, componentParams, params.ProductID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &dtos.ReserveStockTxResult{
+		PreviousAvailable: available,
+		NewAvailable:      available - kits,
+		NewReserved:       kits,
+		Reserved:          kits,
+		Sufficient:        kits == params.Quantity,
+	}, nil
+}
+
+func componentProductIDs(components []models.ProductKitComponent) []string {
+	ids := make([]string, len(components))
+	for i, c := range components {
+		ids[i] = c.ComponentProductID
+	}
+	return ids
+}
+
+func kitReasonSuffix(kitProductID string) string {
+	if kitProductID == "" {
+		return ""
+	}
+	return " (componente del kit " + kitProductID + ")"
+}
+
+func (r *Repository) invalidateProductsCache(businessID, warehouseID uint, productIDs []string) {
+	if r.cache == nil {
+		return
+	}
+	for _, productID := range productIDs {
+		go r.cache.InvalidateProduct(context.Background(), productID, businessID)
+		go r.cache.InvalidateLevel(context.Background(), productID, warehouseID)
+	}
+}
@@ -105,6 +105,20 @@ func (r *Repository) LotExistsByCode(ctx context.Context, businessID uint, produ
 	return count > 0, err
 }
 
+func (r *Repository) GetLotByCode(ctx context.Context, businessID uint, productID, code string) (*entities.InventoryLot, error) {
+	var m models.InventoryLot
+	err := r.db.Conn(ctx).
+		Where("business_id = ? AND product_id = ? AND lot_code = ?", businessID, productID, code).
+		First(&m).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainerrors.ErrLotNotFound
+		}
+		return nil, err
+	}
+	return mappers.LotModelToEntity(&m), nil
+}
+
 func (r *Repository) ListLotsForReserve(ctx context.Context, productID string, warehouseID, businessID uint, strategy string) ([]entities.InventoryLot, error) {
 	var modelsList []models.InventoryLot
 
@@ -0,0 +1,67 @@
+package mappers
+
+import (
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func KitModelToEntity(m *models.ProductKit) *entities.Kit {
+	kit := &entities.Kit{
+		ID:          m.ID,
+		BusinessID:  m.BusinessID,
+		ProductID:   m.ProductID,
+		ProductName: m.Product.Name,
+		ProductSKU:  m.Product.SKU,
+		Mode:        m.Mode,
+		IsActive:    m.IsActive,
+		Components:  make([]entities.KitComponent, len(m.Components)),
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+	for i, c := range m.Components {
+		kit.Components[i] = entities.KitComponent{
+			ID:          c.ID,
+			ProductID:   c.ComponentProductID,
+			ProductName: c.Component.Name,
+			ProductSKU:  c.Component.SKU,
+			Quantity:    c.Quantity,
+		}
+	}
+	return kit
+}
+
+func KitAssemblyOrderModelToEntity(m *models.KitAssemblyOrder) *entities.KitAssemblyOrder {
+	return &entities.KitAssemblyOrder{
+		ID:            m.ID,
+		BusinessID:    m.BusinessID,
+		Code:          m.Code,
+		Type:          m.Type,
+		KitProductID:  m.KitProductID,
+		KitName:       m.KitProduct.Name,
+		KitSKU:        m.KitProduct.SKU,
+		WarehouseID:   m.WarehouseID,
+		Quantity:      m.Quantity,
+		Status:        m.Status,
+		KitUnitCost:   m.KitUnitCost,
+		Notes:         m.Notes,
+		CreatedByID:   m.CreatedByID,
+		CompletedByID: m.CompletedByID,
+		CompletedAt:   m.CompletedAt,
+		CreatedAt:     m.CreatedAt,
+		UpdatedAt:     m.UpdatedAt,
+	}
+}
+
+func KitAssemblyOrderEntityToModel(e *entities.KitAssemblyOrder) *models.KitAssemblyOrder {
+	return &models.KitAssemblyOrder{
+		BusinessID:   e.BusinessID,
+		Code:         e.Code,
+		Type:         e.Type,
+		KitProductID: e.KitProductID,
+		WarehouseID:  e.WarehouseID,
+		Quantity:     e.Quantity,
+		Status:       e.Status,
+		Notes:        e.Notes,
+		CreatedByID:  e.CreatedByID,
+	}
+}
@@ -22,6 +22,8 @@ func MovementModelToEntity(m *models.StockMovement) *entities.StockMovement {
 		IntegrationID:  m.IntegrationID,
 		Notes:          m.Notes,
 		CreatedByID:    m.CreatedByID,
+		UnitCost:       m.UnitCost,
+		TotalCost:      m.TotalCost,
 		CreatedAt:      m.CreatedAt,
 	}
 }
@@ -0,0 +1,136 @@
+package mappers
+
+import (
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func PickWaveModelToEntity(m *models.PickWave) *entities.PickWave {
+	wave := &entities.PickWave{
+		ID:          m.ID,
+		BusinessID:  m.BusinessID,
+		WarehouseID: m.WarehouseID,
+		Code:        m.Code,
+		Status:      m.Status,
+		CarrierCode: m.CarrierCode,
+		CutoffAt:    m.CutoffAt,
+		ZoneID:      m.ZoneID,
+		MinPriority: m.MinPriority,
+		MaxOrders:   m.MaxOrders,
+		Notes:       m.Notes,
+		CreatedByID: m.CreatedByID,
+		StartedAt:   m.StartedAt,
+		PickedAt:    m.PickedAt,
+		PackedAt:    m.PackedAt,
+		CancelledAt: m.CancelledAt,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+		Orders:      make([]entities.PickWaveOrder, len(m.Orders)),
+		Lines:       make([]entities.PickListLine, len(m.Lines)),
+	}
+	for i := range m.Orders {
+		wave.Orders[i] = *PickWaveOrderModelToEntity(&m.Orders[i])
+	}
+	for i := range m.Lines {
+		wave.Lines[i] = *PickListLineModelToEntity(&m.Lines[i])
+	}
+	return wave
+}
+
+func PickWaveOrderModelToEntity(m *models.PickWaveOrder) *entities.PickWaveOrder {
+	o := &entities.PickWaveOrder{
+		ID:           m.ID,
+		WaveID:       m.WaveID,
+		OrderID:      m.OrderID,
+		OrderNumber:  m.OrderNumber,
+		Priority:     m.Priority,
+		Status:       m.Status,
+		PackageLpnID: m.PackageLpnID,
+		PackedByID:   m.PackedByID,
+		PackedAt:     m.PackedAt,
+		Items:        make([]entities.PickWaveItem, len(m.Items)),
+	}
+	if m.PackageLpn != nil {
+		o.PackageLpnCode = m.PackageLpn.Code
+	}
+	for i, it := range m.Items {
+		o.Items[i] = entities.PickWaveItem{
+			ID:             it.ID,
+			WaveOrderID:    it.WaveOrderID,
+			WaveID:         it.WaveID,
+			ProductID:      it.ProductID,
+			ProductName:    it.Product.Name,
+			ProductSKU:     it.Product.SKU,
+			Quantity:       it.Quantity,
+			PickedQuantity: it.PickedQuantity,
+			PackedQuantity: it.PackedQuantity,
+		}
+	}
+	return o
+}
+
+func PickListLineModelToEntity(m *models.PickListLine) *entities.PickListLine {
+	return &entities.PickListLine{
+		ID:               m.ID,
+		WaveID:           m.WaveID,
+		Sequence:         m.Sequence,
+		ProductID:        m.ProductID,
+		ProductName:      m.Product.Name,
+		ProductSKU:       m.Product.SKU,
+		LocationID:       m.LocationID,
+		LocationCode:     m.LocationCode,
+		LocationPath:     m.LocationPath,
+		QuantityRequired: m.QuantityRequired,
+		QuantityPicked:   m.QuantityPicked,
+		Status:           m.Status,
+		PickedByID:       m.PickedByID,
+		PickedAt:         m.PickedAt,
+	}
+}
+
+// PickWaveEntityToModel arma la ola con sus ordenes, items y lineas para
+// crearla en un solo Create
+func PickWaveEntityToModel(e *entities.PickWave) *models.PickWave {
+	m := &models.PickWave{
+		BusinessID:  e.BusinessID,
+		WarehouseID: e.WarehouseID,
+		Code:        e.Code,
+		Status:      e.Status,
+		CarrierCode: e.CarrierCode,
+		CutoffAt:    e.CutoffAt,
+		ZoneID:      e.ZoneID,
+		MinPriority: e.MinPriority,
+		MaxOrders:   e.MaxOrders,
+		Notes:       e.Notes,
+		CreatedByID: e.CreatedByID,
+		Orders:      make([]models.PickWaveOrder, len(e.Orders)),
+		Lines:       make([]models.PickListLine, len(e.Lines)),
+	}
+	for i, o := range e.Orders {
+		m.Orders[i] = models.PickWaveOrder{
+			OrderID:     o.OrderID,
+			OrderNumber: o.OrderNumber,
+			Priority:    o.Priority,
+			Status:      o.Status,
+			Items:       make([]models.PickWaveItem, len(o.Items)),
+		}
+		for j, it := range o.Items {
+			m.Orders[i].Items[j] = models.PickWaveItem{
+				ProductID: it.ProductID,
+				Quantity:  it.Quantity,
+			}
+		}
+	}
+	for i, l := range e.Lines {
+		m.Lines[i] = models.PickListLine{
+			Sequence:         l.Sequence,
+			ProductID:        l.ProductID,
+			LocationID:       l.LocationID,
+			LocationCode:     l.LocationCode,
+			LocationPath:     l.LocationPath,
+			QuantityRequired: l.QuantityRequired,
+			Status:           l.Status,
+		}
+	}
+	return m
+}
@@ -0,0 +1,235 @@
+package mappers
+
+import (
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func SupplierModelToEntity(m *models.Supplier) *entities.Supplier {
+	return &entities.Supplier{
+		ID:                       m.ID,
+		BusinessID:               m.BusinessID,
+		Name:                     m.Name,
+		TaxID:                    m.TaxID,
+		ContactName:              m.ContactName,
+		Email:                    m.Email,
+		Phone:                    m.Phone,
+		Address:                  m.Address,
+		LeadTimeDays:             m.LeadTimeDays,
+		OverReceiptTolerancePct:  m.OverReceiptTolerancePct,
+		UnderReceiptTolerancePct: m.UnderReceiptTolerancePct,
+		IsActive:                 m.IsActive,
+		Notes:                    m.Notes,
+		CreatedAt:                m.CreatedAt,
+		UpdatedAt:                m.UpdatedAt,
+	}
+}
+
+func SupplierEntityToModel(e *entities.Supplier) *models.Supplier {
+	return &models.Supplier{
+		BusinessID:               e.BusinessID,
+		Name:                     e.Name,
+		TaxID:                    e.TaxID,
+		ContactName:              e.ContactName,
+		Email:                    e.Email,
+		Phone:                    e.Phone,
+		Address:                  e.Address,
+		LeadTimeDays:             e.LeadTimeDays,
+		OverReceiptTolerancePct:  e.OverReceiptTolerancePct,
+		UnderReceiptTolerancePct: e.UnderReceiptTolerancePct,
+		IsActive:                 e.IsActive,
+		Notes:                    e.Notes,
+	}
+}
+
+func SupplierProductModelToEntity(m *models.SupplierProduct) *entities.SupplierProduct {
+	return &entities.SupplierProduct{
+		ID:           m.ID,
+		BusinessID:   m.BusinessID,
+		SupplierID:   m.SupplierID,
+		ProductID:    m.ProductID,
+		SupplierSKU:  m.SupplierSKU,
+		UnitCost:     m.UnitCost,
+		MinOrderQty:  m.MinOrderQty,
+		LeadTimeDays: m.LeadTimeDays,
+		IsPreferred:  m.IsPreferred,
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+		SupplierName: m.Supplier.Name,
+	}
+}
+
+func SupplierProductEntityToModel(e *entities.SupplierProduct) *models.SupplierProduct {
+	return &models.SupplierProduct{
+		BusinessID:   e.BusinessID,
+		SupplierID:   e.SupplierID,
+		ProductID:    e.ProductID,
+		SupplierSKU:  e.SupplierSKU,
+		UnitCost:     e.UnitCost,
+		MinOrderQty:  e.MinOrderQty,
+		LeadTimeDays: e.LeadTimeDays,
+		IsPreferred:  e.IsPreferred,
+	}
+}
+
+func PurchaseOrderModelToEntity(m *models.PurchaseOrder) *entities.PurchaseOrder {
+	po := &entities.PurchaseOrder{
+		ID:                       m.ID,
+		BusinessID:               m.BusinessID,
+		Code:                     m.Code,
+		SupplierID:               m.SupplierID,
+		WarehouseID:              m.WarehouseID,
+		Status:                   m.Status,
+		Source:                   m.Source,
+		Currency:                 m.Currency,
+		ExpectedAt:               m.ExpectedAt,
+		OverReceiptTolerancePct:  m.OverReceiptTolerancePct,
+		UnderReceiptTolerancePct: m.UnderReceiptTolerancePct,
+		Subtotal:                 m.Subtotal,
+		LandedCostTotal:          m.LandedCostTotal,
+		Notes:                    m.Notes,
+		CreatedByID:              m.CreatedByID,
+		ApprovedByID:             m.ApprovedByID,
+		ApprovedAt:               m.ApprovedAt,
+		ReceivedAt:               m.ReceivedAt,
+		ClosedAt:                 m.ClosedAt,
+		CancelledAt:              m.CancelledAt,
+		CreatedAt:                m.CreatedAt,
+		UpdatedAt:                m.UpdatedAt,
+		SupplierName:             m.Supplier.Name,
+	}
+	for i := range m.Lines {
+		po.Lines = append(po.Lines, *PurchaseOrderLineModelToEntity(&m.Lines[i]))
+	}
+	return po
+}
+
+func PurchaseOrderEntityToModel(e *entities.PurchaseOrder) *models.PurchaseOrder {
+	m := &models.PurchaseOrder{
+		BusinessID:               e.BusinessID,
+		Code:                     e.Code,
+		SupplierID:               e.SupplierID,
+		WarehouseID:              e.WarehouseID,
+		Status:                   e.Status,
+		Source:                   e.Source,
+		Currency:                 e.Currency,
+		ExpectedAt:               e.ExpectedAt,
+		OverReceiptTolerancePct:  e.OverReceiptTolerancePct,
+		UnderReceiptTolerancePct: e.UnderReceiptTolerancePct,
+		Subtotal:                 e.Subtotal,
+		LandedCostTotal:          e.LandedCostTotal,
+		Notes:                    e.Notes,
+		CreatedByID:              e.CreatedByID,
+	}
+	for i := range e.Lines {
+		m.Lines = append(m.Lines, *PurchaseOrderLineEntityToModel(&e.Lines[i]))
+	}
+	return m
+}
+
+func PurchaseOrderLineModelToEntity(m *models.PurchaseOrderLine) *entities.PurchaseOrderLine {
+	return &entities.PurchaseOrderLine{
+		ID:                  m.ID,
+		PurchaseOrderID:     m.PurchaseOrderID,
+		ProductID:           m.ProductID,
+		QuantityOrdered:     m.QuantityOrdered,
+		QuantityReceived:    m.QuantityReceived,
+		UnitCost:            m.UnitCost,
+		LandedCostAllocated: m.LandedCostAllocated,
+		ExpectedAt:          m.ExpectedAt,
+		Notes:               m.Notes,
+		CreatedAt:           m.CreatedAt,
+		UpdatedAt:           m.UpdatedAt,
+		ProductName:         m.Product.Name,
+		ProductSKU:          m.Product.SKU,
+	}
+}
+
+func PurchaseOrderLineEntityToModel(e *entities.PurchaseOrderLine) *models.PurchaseOrderLine {
+	return &models.PurchaseOrderLine{
+		PurchaseOrderID:     e.PurchaseOrderID,
+		ProductID:           e.ProductID,
+		QuantityOrdered:     e.QuantityOrdered,
+		QuantityReceived:    e.QuantityReceived,
+		UnitCost:            e.UnitCost,
+		LandedCostAllocated: e.LandedCostAllocated,
+		ExpectedAt:          e.ExpectedAt,
+		Notes:               e.Notes,
+	}
+}
+
+func PurchaseOrderReceiptModelToEntity(m *models.PurchaseOrderReceipt) *entities.PurchaseOrderReceipt {
+	r := &entities.PurchaseOrderReceipt{
+		ID:              m.ID,
+		BusinessID:      m.BusinessID,
+		PurchaseOrderID: m.PurchaseOrderID,
+		WarehouseID:     m.WarehouseID,
+		LocationID:      m.LocationID,
+		ReceivedByID:    m.ReceivedByID,
+		ReceivedAt:      m.ReceivedAt,
+		Notes:           m.Notes,
+		CreatedAt:       m.CreatedAt,
+	}
+	for _, l := range m.Lines {
+		r.Lines = append(r.Lines, entities.PurchaseOrderReceiptLine{
+			ID:                  l.ID,
+			ReceiptID:           l.ReceiptID,
+			PurchaseOrderLineID: l.PurchaseOrderLineID,
+			ProductID:           l.ProductID,
+			Quantity:            l.Quantity,
+			LotID:               l.LotID,
+			MovementID:          l.MovementID,
+			UnitCost:            l.UnitCost,
+			LandedUnitCost:      l.LandedUnitCost,
+		})
+	}
+	return r
+}
+
+func PurchaseOrderReceiptEntityToModel(e *entities.PurchaseOrderReceipt) *models.PurchaseOrderReceipt {
+	m := &models.PurchaseOrderReceipt{
+		BusinessID:      e.BusinessID,
+		PurchaseOrderID: e.PurchaseOrderID,
+		WarehouseID:     e.WarehouseID,
+		LocationID:      e.LocationID,
+		ReceivedByID:    e.ReceivedByID,
+		ReceivedAt:      e.ReceivedAt,
+		Notes:           e.Notes,
+	}
+	for _, l := range e.Lines {
+		m.Lines = append(m.Lines, models.PurchaseOrderReceiptLine{
+			PurchaseOrderLineID: l.PurchaseOrderLineID,
+			ProductID:           l.ProductID,
+			Quantity:            l.Quantity,
+			LotID:               l.LotID,
+			MovementID:          l.MovementID,
+			UnitCost:            l.UnitCost,
+			LandedUnitCost:      l.LandedUnitCost,
+		})
+	}
+	return m
+}
+
+func LandedCostModelToEntity(m *models.PurchaseOrderLandedCost) *entities.PurchaseOrderLandedCost {
+	return &entities.PurchaseOrderLandedCost{
+		ID:               m.ID,
+		PurchaseOrderID:  m.PurchaseOrderID,
+		Concept:          m.Concept,
+		Description:      m.Description,
+		Amount:           m.Amount,
+		AllocationMethod: m.AllocationMethod,
+		CreatedByID:      m.CreatedByID,
+		CreatedAt:        m.CreatedAt,
+	}
+}
+
+func LandedCostEntityToModel(e *entities.PurchaseOrderLandedCost) *models.PurchaseOrderLandedCost {
+	return &models.PurchaseOrderLandedCost{
+		PurchaseOrderID:  e.PurchaseOrderID,
+		Concept:          e.Concept,
+		Description:      e.Description,
+		Amount:           e.Amount,
+		AllocationMethod: e.AllocationMethod,
+		CreatedByID:      e.CreatedByID,
+	}
+}
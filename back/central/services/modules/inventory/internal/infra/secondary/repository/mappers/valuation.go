@@ -0,0 +1,58 @@
+package mappers
+
+import (
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func ValuationSettingModelToEntity(m *models.InventoryValuationSetting) *entities.ValuationSetting {
+	return &entities.ValuationSetting{
+		BusinessID:  m.BusinessID,
+		Method:      m.Method,
+		ChangedByID: m.ChangedByID,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func CostBalanceModelToEntity(m *models.InventoryCostBalance) *entities.CostBalance {
+	return &entities.CostBalance{
+		ID:          m.ID,
+		BusinessID:  m.BusinessID,
+		ProductID:   m.ProductID,
+		Quantity:    m.Quantity,
+		TotalValue:  m.TotalValue,
+		AverageCost: m.AverageCost,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func CostLayerModelToEntity(m *models.InventoryCostLayer) *entities.CostLayer {
+	return &entities.CostLayer{
+		ID:           m.ID,
+		BusinessID:   m.BusinessID,
+		ProductID:    m.ProductID,
+		WarehouseID:  m.WarehouseID,
+		MovementID:   m.MovementID,
+		LotID:        m.LotID,
+		ReceivedAt:   m.ReceivedAt,
+		OriginalQty:  m.OriginalQty,
+		RemainingQty: m.RemainingQty,
+		UnitCost:     m.UnitCost,
+	}
+}
+
+func OrderLineCostModelToEntity(m *models.OrderLineCost) *entities.OrderLineCost {
+	return &entities.OrderLineCost{
+		ID:          m.ID,
+		BusinessID:  m.BusinessID,
+		OrderID:     m.OrderID,
+		ProductID:   m.ProductID,
+		WarehouseID: m.WarehouseID,
+		MovementID:  m.MovementID,
+		Quantity:    m.Quantity,
+		UnitCost:    m.UnitCost,
+		TotalCost:   m.TotalCost,
+		Method:      m.Method,
+		CreatedAt:   m.CreatedAt,
+	}
+}
@@ -0,0 +1,353 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/infra/secondary/repository/mappers"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *Repository) NextPickWaveCode(ctx context.Context, businessID uint) (string, error) {
+	var count int64
+	if err := r.db.Conn(ctx).Unscoped().Model(&models.PickWave{}).
+		Where("business_id = ?", businessID).
+		Count(&count).Error; err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("OLA-%06d", count+1), nil
+}
+
+// ListPickCandidates retorna las ordenes en picking de la bodega que no estan
+// en otra ola abierta, de mayor a menor prioridad y las mas antiguas primero
+func (r *Repository) ListPickCandidates(ctx context.Context, params dtos.PickCandidateParams) ([]entities.PickCandidate, error) {
+	type orderRow struct {
+		ID          string
+		OrderNumber string
+		Priority    int
+	}
+	var orders []orderRow
+
+	q := r.db.Conn(ctx).Table("orders o").
+		Select("o.id, o.order_number, o.priority").
+		Where("o.business_id = ? AND o.status = ? AND o.deleted_at IS NULL", params.BusinessID, "picking").
+		Where(`NOT EXISTS (
+			SELECT 1 FROM pick_wave_orders pwo
+			INNER JOIN pick_waves pw ON pw.id = pwo.wave_id AND pw.deleted_at IS NULL
+			WHERE pwo.order_id = o.id AND pwo.deleted_at IS NULL
+			  AND pwo.status IN ? AND pw.status <> ?)`,
+			[]string{entities.PickOrderPending, entities.PickOrderPicked}, entities.PickWaveCancelled)
+	if params.IncludeUnassigned {
+		q = q.Where("(o.warehouse_id = ? OR o.warehouse_id IS NULL)", params.WarehouseID)
+	} else {
+		q = q.Where("o.warehouse_id = ?", params.WarehouseID)
+	}
+	if params.CarrierCode != "" {
+		q = q.Where(`EXISTS (
+			SELECT 1 FROM shipments s
+			WHERE s.order_id = o.id AND s.deleted_at IS NULL
+			  AND (LOWER(s.carrier_code) = LOWER(?) OR LOWER(s.carrier) = LOWER(?)))`,
+			params.CarrierCode, params.CarrierCode)
+	}
+	if params.CutoffAt != nil {
+		q = q.Where("o.occurred_at <= ?", *params.CutoffAt)
+	}
+	if params.MinPriority != nil {
+		q = q.Where("o.priority >= ?", *params.MinPriority)
+	}
+	q = q.Order("o.priority DESC, o.occurred_at ASC, o.id ASC")
+	if params.Limit > 0 {
+		q = q.Limit(params.Limit)
+	}
+	if err := q.Scan(&orders).Error; err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]string, len(orders))
+	for i, o := range orders {
+		ids[i] = o.ID
+	}
+	type itemRow struct {
+		OrderID   string
+		ProductID string
+		Quantity  int
+	}
+	var items []itemRow
+	if err := r.db.Conn(ctx).Table("order_items").
+		Select("order_id, product_id, SUM(quantity) AS quantity").
+		Where("order_id IN ? AND deleted_at IS NULL AND product_id IS NOT NULL AND product_id <> ''", ids).
+		Group("order_id, product_id").
+		Order("order_id, product_id").
+		Scan(&items).Error; err != nil {
+		return nil, err
+	}
+	byOrder := make(map[string][]entities.PickDemand, len(orders))
+	for _, it := range items {
+		if it.Quantity > 0 {
+			byOrder[it.OrderID] = append(byOrder[it.OrderID], entities.PickDemand{ProductID: it.ProductID, Quantity: it.Quantity})
+		}
+	}
+
+	out := make([]entities.PickCandidate, 0, len(orders))
+	for _, o := range orders {
+		out = append(out, entities.PickCandidate{
+			OrderID:     o.ID,
+			OrderNumber: o.OrderNumber,
+			Priority:    o.Priority,
+			Items:       byOrder[o.ID],
+		})
+	}
+	return out, nil
+}
+
+// ListLocatedStock retorna el stock disponible por ubicacion activa con la ruta
+// zona/pasillo/estanteria/nivel/posicion. Las ubicaciones sueltas (sin nivel)
+// quedan solo con su codigo.
+func (r *Repository) ListLocatedStock(ctx context.Context, businessID, warehouseID uint, productIDs []string, zoneID *uint) ([]entities.LocatedStock, error) {
+	if len(productIDs) == 0 {
+		return nil, nil
+	}
+	availableStateID, err := r.resolveAvailableStateID(r.db.Conn(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	type row struct {
+		ProductID    string
+		LocationID   uint
+		LocationCode string
+		LocationPath string
+		ZoneID       *uint
+		Quantity     int
+	}
+	var rows []row
+	q := r.db.Conn(ctx).Table("inventory_levels il").
+		Select(`il.product_id, il.location_id, wl.code AS location_code, wz.id AS zone_id,
+			CONCAT_WS('/', wz.code, wa.code, wr.code, wrl.code, wl.code) AS location_path,
+			SUM(il.quantity) AS quantity`).
+		Joins("INNER JOIN warehouse_locations wl ON wl.id = il.location_id AND wl.deleted_at IS NULL AND wl.is_active = true").
+		Joins("LEFT JOIN warehouse_rack_levels wrl ON wrl.id = wl.level_id AND wrl.deleted_at IS NULL").
+		Joins("LEFT JOIN warehouse_racks wr ON wr.id = wrl.rack_id AND wr.deleted_at IS NULL").
+		Joins("LEFT JOIN warehouse_aisles wa ON wa.id = wr.aisle_id AND wa.deleted_at IS NULL").
+		Joins("LEFT JOIN warehouse_zones wz ON wz.id = wa.zone_id AND wz.deleted_at IS NULL").
+		Where("il.business_id = ? AND il.warehouse_id = ? AND il.product_id IN ?", businessID, warehouseID, productIDs).
+		Where("il.deleted_at IS NULL AND il.quantity > 0").
+		Where("(il.state_id = ? OR il.state_id IS NULL)", availableStateID)
+	if zoneID != nil {
+		q = q.Where("wz.id = ?", *zoneID)
+	}
+	if err := q.Group("il.product_id, il.location_id, wl.code, wz.id, wz.code, wa.code, wr.code, wrl.code").
+		Order("location_path, il.product_id").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]entities.LocatedStock, len(rows))
+	for i, row := range rows {
+		out[i] = entities.LocatedStock{
+			ProductID:    row.ProductID,
+			LocationID:   row.LocationID,
+			LocationCode: row.LocationCode,
+			LocationPath: row.LocationPath,
+			ZoneID:       row.ZoneID,
+			Quantity:     row.Quantity,
+		}
+	}
+	return out, nil
+}
+
+// CreatePickWave crea la ola con sus ordenes, items y lineas en una
+// transaccion
+func (r *Repository) CreatePickWave(ctx context.Context, wave *entities.PickWave) (*entities.PickWave, error) {
+	m := mappers.PickWaveEntityToModel(wave)
+	orders, lines := m.Orders, m.Lines
+	m.Orders, m.Lines = nil, nil
+
+	err := r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Create(m).Error; err != nil {
+			return err
+		}
+		for i := range orders {
+			orders[i].WaveID = m.ID
+			for j := range orders[i].Items {
+				orders[i].Items[j].WaveID = m.ID
+			}
+		}
+		if len(orders) > 0 {
+			if err := tx.Omit("Wave", "Order", "PackageLpn").Create(&orders).Error; err != nil {
+				return err
+			}
+		}
+		for i := range lines {
+			lines[i].WaveID = m.ID
+		}
+		if len(lines) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&lines).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r.GetPickWave(ctx, wave.BusinessID, m.ID)
+}
+
+func (r *Repository) GetPickWave(ctx context.Context, businessID, id uint) (*entities.PickWave, error) {
+	var m models.PickWave
+	err := r.db.Conn(ctx).
+		Preload("Orders", func(db *gorm.DB) *gorm.DB { return db.Order("priority DESC, id ASC") }).
+		Preload("Orders.Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Orders.Items.Product").
+		Preload("Orders.PackageLpn").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("sequence ASC") }).
+		Preload("Lines.Product").
+		Where("id = ? AND business_id = ?", id, businessID).
+		First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerrors.ErrPickWaveNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mappers.PickWaveModelToEntity(&m), nil
+}
+
+func (r *Repository) LockPickWave(ctx context.Context, businessID, id uint) (*entities.PickWave, error) {
+	var m models.PickWave
+	err := r.db.Conn(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ? AND business_id = ?", id, businessID).
+		First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domainerrors.ErrPickWaveNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.GetPickWave(ctx, businessID, id)
+}
+
+func (r *Repository) ListPickWaves(ctx context.Context, params dtos.ListPickWavesParams) ([]entities.PickWave, int64, error) {
+	var ml []models.PickWave
+	var total int64
+
+	q := r.db.Conn(ctx).Model(&models.PickWave{}).Where("business_id = ?", params.BusinessID)
+	if params.WarehouseID != nil {
+		q = q.Where("warehouse_id = ?", *params.WarehouseID)
+	}
+	if params.Status != "" {
+		q = q.Where("status = ?", params.Status)
+	}
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := q.Preload("Orders").
+		Offset(params.Offset()).Limit(params.PageSize).
+		Order("id DESC").
+		Find(&ml).Error; err != nil {
+		return nil, 0, err
+	}
+
+	out := make([]entities.PickWave, len(ml))
+	for i := range ml {
+		out[i] = *mappers.PickWaveModelToEntity(&ml[i])
+	}
+	return out, total, nil
+}
+
+// UpdatePickWaveStatus cambia el estado solo desde los estados permitidos; si
+// la ola ya cambio retorna ErrPickWaveClosed
+func (r *Repository) UpdatePickWaveStatus(ctx context.Context, params dtos.UpdatePickWaveStatusParams) error {
+	updates := map[string]any{"status": params.Status}
+	switch params.Status {
+	case entities.PickWavePicking:
+		updates["started_at"] = params.At
+	case entities.PickWavePicked:
+		updates["picked_at"] = params.At
+	case entities.PickWavePacked:
+		updates["packed_at"] = params.At
+	case entities.PickWaveCancelled:
+		updates["cancelled_at"] = params.At
+	}
+	res := r.db.Conn(ctx).Model(&models.PickWave{}).
+		Where("id = ? AND business_id = ? AND status IN ?", params.ID, params.BusinessID, params.From).
+		Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainerrors.ErrPickWaveClosed
+	}
+	return nil
+}
+
+// UpdatePickListLine guarda lo recogido en una linea que sigue pendiente; dos
+// pickers sobre la misma linea no pueden cerrarla dos veces
+func (r *Repository) UpdatePickListLine(ctx context.Context, line *entities.PickListLine) error {
+	res := r.db.Conn(ctx).Model(&models.PickListLine{}).
+		Where("id = ? AND wave_id = ? AND status = ?", line.ID, line.WaveID, entities.PickLinePending).
+		Updates(map[string]any{
+			"quantity_picked": line.QuantityPicked,
+			"status":          line.Status,
+			"picked_by_id":    line.PickedByID,
+			"picked_at":       line.PickedAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainerrors.ErrPickLineClosed
+	}
+	return nil
+}
+
+func (r *Repository) UpdatePickWaveItem(ctx context.Context, item *entities.PickWaveItem) error {
+	return r.db.Conn(ctx).Model(&models.PickWaveItem{}).
+		Where("id = ? AND wave_id = ?", item.ID, item.WaveID).
+		Updates(map[string]any{
+			"picked_quantity": item.PickedQuantity,
+			"packed_quantity": item.PackedQuantity,
+		}).Error
+}
+
+func (r *Repository) UpdatePickWaveOrder(ctx context.Context, order *entities.PickWaveOrder) error {
+	res := r.db.Conn(ctx).Model(&models.PickWaveOrder{}).
+		Where("id = ? AND wave_id = ?", order.ID, order.WaveID).
+		Updates(map[string]any{
+			"status":         order.Status,
+			"package_lpn_id": order.PackageLpnID,
+			"packed_by_id":   order.PackedByID,
+			"packed_at":      order.PackedAt,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainerrors.ErrPickOrderNotFound
+	}
+	return nil
+}
+
+// SetOrderPackage copia peso y medidas del paquete a la orden, que es de donde
+// las sacan la cotizacion y la generacion de guias
+func (r *Repository) SetOrderPackage(ctx context.Context, params dtos.OrderPackageParams) error {
+	return r.db.Conn(ctx).Model(&models.Order{}).
+		Where("id = ?", params.OrderID).
+		Updates(map[string]any{
+			"weight": params.WeightKg,
+			"length": params.LengthCm,
+			"width":  params.WidthCm,
+			"height": params.HeightCm,
+		}).Error
+}
@@ -0,0 +1,225 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/infra/secondary/repository/mappers"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *Repository) NextPurchaseOrderCode(ctx context.Context, businessID uint) (string, error) {
+	var count int64
+	if err := r.db.Conn(ctx).Unscoped().Model(&models.PurchaseOrder{}).
+		Where("business_id = ?", businessID).
+		Count(&count).Error; err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("OC-%06d", count+1), nil
+}
+
+func (r *Repository) CreatePurchaseOrder(ctx context.Context, po *entities.PurchaseOrder) (*entities.PurchaseOrder, error) {
+	m := mappers.PurchaseOrderEntityToModel(po)
+	if err := r.db.Conn(ctx).Create(m).Error; err != nil {
+		return nil, err
+	}
+	return r.GetPurchaseOrderByID(ctx, po.BusinessID, m.ID)
+}
+
+func (r *Repository) GetPurchaseOrderByID(ctx context.Context, businessID, id uint) (*entities.PurchaseOrder, error) {
+	return r.getPurchaseOrder(r.db.Conn(ctx), businessID, id)
+}
+
+// LockPurchaseOrder toma la orden con SELECT FOR UPDATE. Se usa dentro de
+// InTransaction para que dos recepciones simultaneas no sumen sobre la misma
+// cantidad recibida.
+func (r *Repository) LockPurchaseOrder(ctx context.Context, businessID, id uint) (*entities.PurchaseOrder, error) {
+	var locked models.PurchaseOrder
+	err := r.db.Conn(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ? AND business_id = ?", id, businessID).
+		First(&locked).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainerrors.ErrPurchaseOrderNotFound
+		}
+		return nil, err
+	}
+	return r.getPurchaseOrder(r.db.Conn(ctx), businessID, id)
+}
+
+func (r *Repository) getPurchaseOrder(q *gorm.DB, businessID, id uint) (*entities.PurchaseOrder, error) {
+	var m models.PurchaseOrder
+	err := q.
+		Joins("Supplier").
+		Preload("Lines", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Preload("Lines.Product").
+		Where("purchase_orders.id = ? AND purchase_orders.business_id = ?", id, businessID).
+		First(&m).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainerrors.ErrPurchaseOrderNotFound
+		}
+		return nil, err
+	}
+	return mappers.PurchaseOrderModelToEntity(&m), nil
+}
+
+func (r *Repository) ListPurchaseOrders(ctx context.Context, params dtos.ListPurchaseOrdersParams) ([]entities.PurchaseOrder, int64, error) {
+	var ml []models.PurchaseOrder
+	var total int64
+
+	q := r.db.Conn(ctx).Model(&models.PurchaseOrder{}).Where("purchase_orders.business_id = ?", params.BusinessID)
+	if params.SupplierID != nil {
+		q = q.Where("purchase_orders.supplier_id = ?", *params.SupplierID)
+	}
+	if params.WarehouseID != nil {
+		q = q.Where("purchase_orders.warehouse_id = ?", *params.WarehouseID)
+	}
+	if params.Status != "" {
+		q = q.Where("purchase_orders.status = ?", params.Status)
+	}
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := q.Joins("Supplier").
+		Offset(params.Offset()).Limit(params.PageSize).
+		Order("purchase_orders.id DESC").
+		Find(&ml).Error; err != nil {
+		return nil, 0, err
+	}
+
+	out := make([]entities.PurchaseOrder, len(ml))
+	for i := range ml {
+		out[i] = *mappers.PurchaseOrderModelToEntity(&ml[i])
+	}
+	return out, total, nil
+}
+
+func (r *Repository) UpdatePurchaseOrder(ctx context.Context, po *entities.PurchaseOrder) error {
+	updates := map[string]any{
+		"status":                      po.Status,
+		"expected_at":                 po.ExpectedAt,
+		"over_receipt_tolerance_pct":  po.OverReceiptTolerancePct,
+		"under_receipt_tolerance_pct": po.UnderReceiptTolerancePct,
+		"subtotal":                    po.Subtotal,
+		"landed_cost_total":           po.LandedCostTotal,
+		"notes":                       po.Notes,
+		"approved_by_id":              po.ApprovedByID,
+		"approved_at":                 po.ApprovedAt,
+		"received_at":                 po.ReceivedAt,
+		"closed_at":                   po.ClosedAt,
+		"cancelled_at":                po.CancelledAt,
+	}
+	res := r.db.Conn(ctx).Model(&models.PurchaseOrder{}).
+		Where("id = ? AND business_id = ?", po.ID, po.BusinessID).
+		Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainerrors.ErrPurchaseOrderNotFound
+	}
+	return nil
+}
+
+// ReplacePurchaseOrderLines reemplaza todas las lineas de una orden en borrador
+func (r *Repository) ReplacePurchaseOrderLines(ctx context.Context, poID uint, lines []entities.PurchaseOrderLine) error {
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("purchase_order_id = ?", poID).Delete(&models.PurchaseOrderLine{}).Error; err != nil {
+			return err
+		}
+		if len(lines) == 0 {
+			return nil
+		}
+		ml := make([]models.PurchaseOrderLine, len(lines))
+		for i := range lines {
+			ml[i] = *mappers.PurchaseOrderLineEntityToModel(&lines[i])
+			ml[i].PurchaseOrderID = poID
+		}
+		return tx.Create(&ml).Error
+	})
+}
+
+func (r *Repository) UpdatePurchaseOrderLine(ctx context.Context, line *entities.PurchaseOrderLine) error {
+	res := r.db.Conn(ctx).Model(&models.PurchaseOrderLine{}).
+		Where("id = ? AND purchase_order_id = ?", line.ID, line.PurchaseOrderID).
+		Updates(map[string]any{
+			"quantity_received":     line.QuantityReceived,
+			"landed_cost_allocated": line.LandedCostAllocated,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainerrors.ErrPurchaseOrderLineNotFound
+	}
+	return nil
+}
+
+// OpenPurchaseQuantity suma lo que falta por llegar de un producto a una bodega
+// en ordenes abiertas (borrador, aprobadas o recibidas en parte)
+func (r *Repository) OpenPurchaseQuantity(ctx context.Context, businessID, warehouseID uint, productID string) (int, error) {
+	var pending int
+	err := r.db.Conn(ctx).
+		Table("purchase_order_lines pol").
+		Select("COALESCE(SUM(GREATEST(pol.quantity_ordered - pol.quantity_received, 0)), 0)").
+		Joins("INNER JOIN purchase_orders po ON po.id = pol.purchase_order_id AND po.deleted_at IS NULL").
+		Where("pol.deleted_at IS NULL AND pol.product_id = ?", productID).
+		Where("po.business_id = ? AND po.warehouse_id = ?", businessID, warehouseID).
+		Where("po.status IN ?", []string{entities.PurchaseOrderDraft, entities.PurchaseOrderApproved, entities.PurchaseOrderPartiallyReceived}).
+		Row().Scan(&pending)
+	if err != nil {
+		return 0, err
+	}
+	return pending, nil
+}
+
+func (r *Repository) CreatePurchaseOrderReceipt(ctx context.Context, receipt *entities.PurchaseOrderReceipt) (*entities.PurchaseOrderReceipt, error) {
+	m := mappers.PurchaseOrderReceiptEntityToModel(receipt)
+	if err := r.db.Conn(ctx).Create(m).Error; err != nil {
+		return nil, err
+	}
+	return mappers.PurchaseOrderReceiptModelToEntity(m), nil
+}
+
+func (r *Repository) ListPurchaseOrderReceipts(ctx context.Context, businessID, poID uint) ([]entities.PurchaseOrderReceipt, error) {
+	var ml []models.PurchaseOrderReceipt
+	if err := r.db.Conn(ctx).
+		Preload("Lines").
+		Where("business_id = ? AND purchase_order_id = ?", businessID, poID).
+		Order("id ASC").
+		Find(&ml).Error; err != nil {
+		return nil, err
+	}
+	out := make([]entities.PurchaseOrderReceipt, len(ml))
+	for i := range ml {
+		out[i] = *mappers.PurchaseOrderReceiptModelToEntity(&ml[i])
+	}
+	return out, nil
+}
+
+func (r *Repository) CreateLandedCost(ctx context.Context, cost *entities.PurchaseOrderLandedCost) (*entities.PurchaseOrderLandedCost, error) {
+	m := mappers.LandedCostEntityToModel(cost)
+	if err := r.db.Conn(ctx).Create(m).Error; err != nil {
+		return nil, err
+	}
+	return mappers.LandedCostModelToEntity(m), nil
+}
+
+func (r *Repository) ListLandedCosts(ctx context.Context, poID uint) ([]entities.PurchaseOrderLandedCost, error) {
+	var ml []models.PurchaseOrderLandedCost
+	if err := r.db.Conn(ctx).Where("purchase_order_id = ?", poID).Order("id ASC").Find(&ml).Error; err != nil {
+		return nil, err
+	}
+	out := make([]entities.PurchaseOrderLandedCost, len(ml))
+	for i := range ml {
+		out[i] = *mappers.LandedCostModelToEntity(&ml[i])
+	}
+	return out, nil
+}
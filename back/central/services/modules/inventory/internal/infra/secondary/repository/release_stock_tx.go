@@ -11,50 +11,27 @@ import (
 
 // ReleaseStockTx libera reserva por cancelación: ReservedQty -= qty.
 // Clamp: si ReservedQty < qty, solo libera lo que había reservado.
+// Los kits virtuales liberan la reserva de sus componentes.
 func (r *Repository) ReleaseStockTx(ctx context.Context, params dtos.ReleaseTxParams) error {
+	touched := []string{params.ProductID}
+
 	err := r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
-		// 1. SELECT FOR UPDATE
-		level, err := r.getOrCreateLevelTx(tx, params.ProductID, params.WarehouseID, nil, params.BusinessID)
+		components, err := r.virtualKitComponentsTx(tx, params.BusinessID, params.ProductID)
 		if err != nil {
-			return fmt.Errorf("getOrCreateLevelTx: %w", err)
-		}
-
-		// 2. Clamp a lo que realmente está reservado
-		toRelease := params.Quantity
-		if toRelease > level.ReservedQty {
-			toRelease = level.ReservedQty
-		}
-		if toRelease <= 0 {
-			return nil // nada que liberar
-		}
-
-		// 3. UPDATE: solo ReservedQty baja, Quantity no cambia
-		level.ReservedQty -= toRelease
-		level.AvailableQty = level.Quantity - level.ReservedQty
-
-		if err := r.updateLevelTx(tx, level); err != nil {
-			return fmt.Errorf("updateLevelTx: %w", err)
+			return fmt.Errorf("virtualKitComponentsTx: %w", err)
 		}
-
-		// 4. INSERT stock_movement (neutral: no cambia Quantity)
-		refType := "order"
-		movement := &models.StockMovement{
-			ProductID:      params.ProductID,
-			WarehouseID:    params.WarehouseID,
-			BusinessID:     params.BusinessID,
-			MovementTypeID: params.MovementTypeID,
-			Reason:         fmt.Sprintf("Liberación de reserva por cancelación de orden %s", params.OrderID),
-			Quantity:       0,
-			PreviousQty:    level.Quantity,
-			NewQty:         level.Quantity,
-			ReferenceType:  &refType,
-			ReferenceID:    &params.OrderID,
-			Notes:          fmt.Sprintf("Liberado: %d, Reserva: %d->%d", toRelease, toRelease+level.ReservedQty, level.ReservedQty),
+		if len(components) == 0 {
+			return r.releaseLevelTx(tx, params, "")
 		}
-		if err := r.createMovementTx(tx, movement); err != nil {
-			return fmt.Errorf("createMovementTx: %w", err)
+		touched = append(touched, componentProductIDs(components)...)
+		for _, c := range components {
+			componentParams := params
+			componentParams.ProductID = c.ComponentProductID
+			componentParams.Quantity = params.Quantity * c.Quantity
+			if err := r.releaseLevelTx(tx, componentParams, params.ProductID); err != nil {
+				return err
+			}
 		}
-
 		return nil
 	})
 
@@ -63,9 +40,52 @@ func (r *Repository) ReleaseStockTx(ctx context.Context, params dtos.ReleaseTxPa
 	}
 
 	// Cache invalidation
-	if r.cache != nil {
-		go r.cache.InvalidateProduct(context.Background(), params.ProductID, params.BusinessID)
-		go r.cache.InvalidateLevel(context.Background(), params.ProductID, params.WarehouseID)
+	r.invalidateProductsCache(params.BusinessID, params.WarehouseID, touched)
+
+	return nil
+}
+
+func (r *Repository) releaseLevelTx(tx *gorm.DB, params dtos.ReleaseTxParams, kitProductID string) error {
+	// 1. SELECT FOR UPDATE
+	level, err := r.getOrCreateLevelTx(tx, params.ProductID, params.WarehouseID, nil, params.BusinessID)
+	if err != nil {
+		return fmt.Errorf("getOrCreateLevelTx: %w", err)
+	}
+
+	// 2. Clamp a lo que realmente está reservado
+	toRelease := params.Quantity
+	if toRelease > level.ReservedQty {
+		toRelease = level.ReservedQty
+	}
+	if toRelease <= 0 {
+		return nil // nada que liberar
+	}
+
+	// 3. UPDATE: solo ReservedQty baja, Quantity no cambia
+	level.ReservedQty -= toRelease
+	level.AvailableQty = level.Quantity - level.ReservedQty
+
+	if err := r.updateLevelTx(tx, level); err != nil {
+		return fmt.Errorf("updateLevelTx: %w", err)
+	}
+
+	// 4. INSERT stock_movement (neutral: no cambia Quantity)
+	refType := "order"
+	movement := &models.StockMovement{
+		ProductID:      params.ProductID,
+		WarehouseID:    params.WarehouseID,
+		BusinessID:     params.BusinessID,
+		MovementTypeID: params.MovementTypeID,
+		Reason:         fmt.Sprintf("Liberación de reserva por cancelación de orden %s", params.OrderID) + kitReasonSuffix(kitProductID),
+		Quantity:       0,
+		PreviousQty:    level.Quantity,
+		NewQty:         level.Quantity,
+		ReferenceType:  &refType,
+		ReferenceID:    &params.OrderID,
+		Notes:          fmt.Sprintf("Liberado: %d, Reserva: %d->%d", toRelease, toRelease+level.ReservedQty, level.ReservedQty),
+	}
+	if err := r.createMovementTx(tx, movement); err != nil {
+		return fmt.Errorf("createMovementTx: %w", err)
 	}
 
 	return nil
@@ -12,78 +12,95 @@ import (
 // ReserveStockTx reserva stock para una orden dentro de una transacción con SELECT FOR UPDATE.
 // ReservedQty += qty, AvailableQty = Quantity - ReservedQty.
 // Si no hay stock suficiente, reserva parcial (lo que haya disponible).
+// Los kits virtuales reservan sus componentes (ver reserveKitTx).
 func (r *Repository) ReserveStockTx(ctx context.Context, params dtos.ReserveStockTxParams) (*dtos.ReserveStockTxResult, error) {
-	var result dtos.ReserveStockTxResult
+	var result *dtos.ReserveStockTxResult
+	touched := []string{params.ProductID}
 
 	err := r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
-		// 1. SELECT FOR UPDATE del inventory_level (o crear si no existe)
-		level, err := r.getOrCreateLevelTx(tx, params.ProductID, params.WarehouseID, nil, params.BusinessID)
+		components, err := r.virtualKitComponentsTx(tx, params.BusinessID, params.ProductID)
 		if err != nil {
-			return fmt.Errorf("getOrCreateLevelTx: %w", err)
+			return fmt.Errorf("virtualKitComponentsTx: %w", err)
 		}
-
-		result.PreviousAvailable = level.AvailableQty
-
-		// 2. Calcular cuánto se puede reservar
-		toReserve := params.Quantity
-		if level.AvailableQty < toReserve {
-			toReserve = level.AvailableQty
-			if toReserve < 0 {
-				toReserve = 0
-			}
-			result.Sufficient = false
-		} else {
-			result.Sufficient = true
+		if len(components) > 0 {
+			touched = append(touched, componentProductIDs(components)...)
+			result, err = r.reserveKitTx(tx, params, components)
+			return err
 		}
-		result.Reserved = toReserve
+		result, err = r.reserveLevelTx(tx, params, "")
+		return err
+	})
 
-		if toReserve == 0 {
-			// Nada que reservar, pero no es error — insuficiente
-			result.NewAvailable = level.AvailableQty
-			result.NewReserved = level.ReservedQty
-			return nil
-		}
+	if err != nil {
+		return nil, err
+	}
 
-		// 3. UPDATE inventory_level
-		level.ReservedQty += toReserve
-		level.AvailableQty = level.Quantity - level.ReservedQty
-		if err := r.updateLevelTx(tx, level); err != nil {
-			return fmt.Errorf("updateLevelTx: %w", err)
+	// Cache invalidation después del commit
+	r.invalidateProductsCache(params.BusinessID, params.WarehouseID, touched)
+
+	return result, nil
+}
+
+// reserveLevelTx reserva sobre el nivel del producto. kitProductID viene
+// cuando el producto se reserva como componente de un kit.
+func (r *Repository) reserveLevelTx(tx *gorm.DB, params dtos.ReserveStockTxParams, kitProductID string) (*dtos.ReserveStockTxResult, error) {
+	var result dtos.ReserveStockTxResult
+
+	// 1. SELECT FOR UPDATE del inventory_level (o crear si no existe)
+	level, err := r.getOrCreateLevelTx(tx, params.ProductID, params.WarehouseID, nil, params.BusinessID)
+	if err != nil {
+		return nil, fmt.Errorf("getOrCreateLevelTx: %w", err)
+	}
+
+	result.PreviousAvailable = level.AvailableQty
+
+	// 2. Calcular cuánto se puede reservar
+	toReserve := params.Quantity
+	if level.AvailableQty < toReserve {
+		toReserve = level.AvailableQty
+		if toReserve < 0 {
+			toReserve = 0
 		}
+		result.Sufficient = false
+	} else {
+		result.Sufficient = true
+	}
+	result.Reserved = toReserve
 
+	if toReserve == 0 {
+		// Nada que reservar, pero no es error — insuficiente
 		result.NewAvailable = level.AvailableQty
 		result.NewReserved = level.ReservedQty
+		return &result, nil
+	}
 
-		// 4. INSERT stock_movement (neutral: no cambia Quantity real)
-		refType := "order"
-		movement := &models.StockMovement{
-			ProductID:      params.ProductID,
-			WarehouseID:    params.WarehouseID,
-			BusinessID:     params.BusinessID,
-			MovementTypeID: params.MovementTypeID,
-			Reason:         fmt.Sprintf("Reserva por orden %s", params.OrderID),
-			Quantity:       0, // neutral — no afecta Quantity total
-			PreviousQty:    level.Quantity,
-			NewQty:         level.Quantity,
-			ReferenceType:  &refType,
-			ReferenceID:    &params.OrderID,
-			Notes:          fmt.Sprintf("Reservado: %d, Disponible: %d->%d", toReserve, result.PreviousAvailable, level.AvailableQty),
-		}
-		if err := r.createMovementTx(tx, movement); err != nil {
-			return fmt.Errorf("createMovementTx: %w", err)
-		}
+	// 3. UPDATE inventory_level
+	level.ReservedQty += toReserve
+	level.AvailableQty = level.Quantity - level.ReservedQty
+	if err := r.updateLevelTx(tx, level); err != nil {
+		return nil, fmt.Errorf("updateLevelTx: %w", err)
+	}
 
-		return nil
-	})
+	result.NewAvailable = level.AvailableQty
+	result.NewReserved = level.ReservedQty
 
-	if err != nil {
-		return nil, err
+	// 4. INSERT stock_movement (neutral: no cambia Quantity real)
+	refType := "order"
+	movement := &models.StockMovement{
+		ProductID:      params.ProductID,
+		WarehouseID:    params.WarehouseID,
+		BusinessID:     params.BusinessID,
+		MovementTypeID: params.MovementTypeID,
+		Reason:         fmt.Sprintf("Reserva por orden %s", params.OrderID) + kitReasonSuffix(kitProductID),
+		Quantity:       0, // neutral — no afecta Quantity total
+		PreviousQty:    level.Quantity,
+		NewQty:         level.Quantity,
+		ReferenceType:  &refType,
+		ReferenceID:    &params.OrderID,
+		Notes:          fmt.Sprintf("Reservado: %d, Disponible: %d->%d", toReserve, result.PreviousAvailable, level.AvailableQty),
 	}
-
-	// Cache invalidation después del commit
-	if r.cache != nil {
-		go r.cache.InvalidateProduct(context.Background(), params.ProductID, params.BusinessID)
-		go r.cache.InvalidateLevel(context.Background(), params.ProductID, params.WarehouseID)
+	if err := r.createMovementTx(tx, movement); err != nil {
+		return nil, fmt.Errorf("createMovementTx: %w", err)
 	}
 
 	return &result, nil
@@ -10,44 +10,28 @@ import (
 )
 
 // ReturnStockTx devuelve stock por reembolso: Quantity += qty, AvailableQty = Quantity - ReservedQty.
-// Usa movement type "return" (ID 5).
+// Usa movement type "return" (ID 5). Un kit virtual devuelto reingresa sus
+// componentes, igual que salieron al confirmar la venta.
 func (r *Repository) ReturnStockTx(ctx context.Context, params dtos.ReturnStockTxParams) error {
+	touched := []string{params.ProductID}
+
 	err := r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
-		// 1. SELECT FOR UPDATE
-		level, err := r.getOrCreateLevelTx(tx, params.ProductID, params.WarehouseID, nil, params.BusinessID)
+		components, err := r.virtualKitComponentsTx(tx, params.BusinessID, params.ProductID)
 		if err != nil {
-			return fmt.Errorf("getOrCreateLevelTx: %w", err)
-		}
-
-		previousQty := level.Quantity
-
-		// 2. UPDATE: incrementar stock real
-		level.Quantity += params.Quantity
-		level.AvailableQty = level.Quantity - level.ReservedQty
-
-		if err := r.updateLevelTx(tx, level); err != nil {
-			return fmt.Errorf("updateLevelTx: %w", err)
+			return fmt.Errorf("virtualKitComponentsTx: %w", err)
 		}
-
-		// 3. INSERT stock_movement (in: Quantity positivo)
-		refType := "order"
-		movement := &models.StockMovement{
-			ProductID:      params.ProductID,
-			WarehouseID:    params.WarehouseID,
-			BusinessID:     params.BusinessID,
-			MovementTypeID: params.MovementTypeID,
-			Reason:         fmt.Sprintf("Devolución por reembolso de orden %s", params.OrderID),
-			Quantity:       params.Quantity,
-			PreviousQty:    previousQty,
-			NewQty:         level.Quantity,
-			ReferenceType:  &refType,
-			ReferenceID:    &params.OrderID,
-			Notes:          fmt.Sprintf("Devuelto: %d, Stock: %d->%d", params.Quantity, previousQty, level.Quantity),
+		if len(components) == 0 {
+			return r.returnLevelTx(tx, params, "")
 		}
-		if err := r.createMovementTx(tx, movement); err != nil {
-			return fmt.Errorf("createMovementTx: %w", err)
+		touched = append(touched, componentProductIDs(components)...)
+		for _, c := range components {
+			componentParams := params
+			componentParams.ProductID = c.ComponentProductID
+			componentParams.Quantity = params.Quantity * c.Quantity
+			if err := r.returnLevelTx(tx, componentParams, params.ProductID); err != nil {
+				return err
+			}
 		}
-
 		return nil
 	})
 
@@ -56,9 +40,55 @@ func (r *Repository) ReturnStockTx(ctx context.Context, params dtos.ReturnStockT
 	}
 
 	// Cache invalidation
-	if r.cache != nil {
-		go r.cache.InvalidateProduct(context.Background(), params.ProductID, params.BusinessID)
-		go r.cache.InvalidateLevel(context.Background(), params.ProductID, params.WarehouseID)
+	r.invalidateProductsCache(params.BusinessID, params.WarehouseID, touched)
+
+	return nil
+}
+
+func (r *Repository) returnLevelTx(tx *gorm.DB, params dtos.ReturnStockTxParams, kitProductID string) error {
+	// 1. SELECT FOR UPDATE
+	level, err := r.getOrCreateLevelTx(tx, params.ProductID, params.WarehouseID, nil, params.BusinessID)
+	if err != nil {
+		return fmt.Errorf("getOrCreateLevelTx: %w", err)
+	}
+
+	previousQty := level.Quantity
+
+	// 2. UPDATE: incrementar stock real
+	level.Quantity += params.Quantity
+	level.AvailableQty = level.Quantity - level.ReservedQty
+
+	if err := r.updateLevelTx(tx, level); err != nil {
+		return fmt.Errorf("updateLevelTx: %w", err)
+	}
+
+	// 3. INSERT stock_movement (in: Quantity positivo)
+	refType := "order"
+	movement := &models.StockMovement{
+		ProductID:      params.ProductID,
+		WarehouseID:    params.WarehouseID,
+		BusinessID:     params.BusinessID,
+		MovementTypeID: params.MovementTypeID,
+		Reason:         fmt.Sprintf("Devolución por reembolso de orden %s", params.OrderID) + kitReasonSuffix(kitProductID),
+		Quantity:       params.Quantity,
+		PreviousQty:    previousQty,
+		NewQty:         level.Quantity,
+		ReferenceType:  &refType,
+		ReferenceID:    &params.OrderID,
+		Notes:          fmt.Sprintf("Devuelto: %d, Stock: %d->%d", params.Quantity, previousQty, level.Quantity),
+	}
+	if err := r.createMovementTx(tx, movement); err != nil {
+		return fmt.Errorf("createMovementTx: %w", err)
+	}
+
+	// 4. La mercancia vuelve al costo con que salio en la orden y se reversa
+	// el costo de venta
+	cost, err := r.costMovementTx(tx, movement, r.soldUnitCostTx(tx, params.BusinessID, params.OrderID, params.ProductID))
+	if err != nil {
+		return fmt.Errorf("costMovementTx: %w", err)
+	}
+	if err := r.createOrderLineCostTx(tx, movement, params.OrderID, cost); err != nil {
+		return fmt.Errorf("createOrderLineCostTx: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/infra/secondary/repository/mappers"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+)
+
+func (r *Repository) CreateSupplier(ctx context.Context, s *entities.Supplier) (*entities.Supplier, error) {
+	m := mappers.SupplierEntityToModel(s)
+	if err := r.db.Conn(ctx).Create(m).Error; err != nil {
+		return nil, err
+	}
+	return mappers.SupplierModelToEntity(m), nil
+}
+
+func (r *Repository) GetSupplierByID(ctx context.Context, businessID, id uint) (*entities.Supplier, error) {
+	var m models.Supplier
+	if err := r.db.Conn(ctx).Where("id = ? AND business_id = ?", id, businessID).First(&m).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainerrors.ErrSupplierNotFound
+		}
+		return nil, err
+	}
+	return mappers.SupplierModelToEntity(&m), nil
+}
+
+func (r *Repository) ListSuppliers(ctx context.Context, params dtos.ListSuppliersParams) ([]entities.Supplier, int64, error) {
+	var ml []models.Supplier
+	var total int64
+
+	q := r.db.Conn(ctx).Model(&models.Supplier{}).Where("business_id = ?", params.BusinessID)
+	if params.ActiveOnly {
+		q = q.Where("is_active = true")
+	}
+	if params.Search != "" {
+		like := "%" + params.Search + "%"
+		q = q.Where("name ILIKE ? OR tax_id ILIKE ?", like, like)
+	}
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := q.Offset(params.Offset()).Limit(params.PageSize).Order("name ASC").Find(&ml).Error; err != nil {
+		return nil, 0, err
+	}
+
+	out := make([]entities.Supplier, len(ml))
+	for i := range ml {
+		out[i] = *mappers.SupplierModelToEntity(&ml[i])
+	}
+	return out, total, nil
+}
+
+func (r *Repository) UpdateSupplier(ctx context.Context, s *entities.Supplier) (*entities.Supplier, error) {
+	updates := map[string]any{
+		"name":                        s.Name,
+		"tax_id":                      s.TaxID,
+		"contact_name":                s.ContactName,
+		"email":                       s.Email,
+		"phone":                       s.Phone,
+		"address":                     s.Address,
+		"lead_time_days":              s.LeadTimeDays,
+		"over_receipt_tolerance_pct":  s.OverReceiptTolerancePct,
+		"under_receipt_tolerance_pct": s.UnderReceiptTolerancePct,
+		"is_active":                   s.IsActive,
+		"notes":                       s.Notes,
+	}
+	res := r.db.Conn(ctx).Model(&models.Supplier{}).
+		Where("id = ? AND business_id = ?", s.ID, s.BusinessID).
+		Updates(updates)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, domainerrors.ErrSupplierNotFound
+	}
+	return r.GetSupplierByID(ctx, s.BusinessID, s.ID)
+}
+
+// UpsertSupplierProduct crea o actualiza las condiciones de compra de un
+// producto con un proveedor. Si queda como preferido, desmarca a los demas
+// proveedores del mismo producto.
+func (r *Repository) UpsertSupplierProduct(ctx context.Context, sp *entities.SupplierProduct) (*entities.SupplierProduct, error) {
+	var result models.SupplierProduct
+	err := r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if sp.IsPreferred {
+			if err := tx.Model(&models.SupplierProduct{}).
+				Where("business_id = ? AND product_id = ? AND supplier_id <> ?", sp.BusinessID, sp.ProductID, sp.SupplierID).
+				Update("is_preferred", false).Error; err != nil {
+				return err
+			}
+		}
+
+		err := tx.Where("supplier_id = ? AND product_id = ?", sp.SupplierID, sp.ProductID).First(&result).Error
+		if err == gorm.ErrRecordNotFound {
+			result = *mappers.SupplierProductEntityToModel(sp)
+			return tx.Create(&result).Error
+		}
+		if err != nil {
+			return err
+		}
+		return tx.Model(&result).Updates(map[string]any{
+			"supplier_sku":   sp.SupplierSKU,
+			"unit_cost":      sp.UnitCost,
+			"min_order_qty":  sp.MinOrderQty,
+			"lead_time_days": sp.LeadTimeDays,
+			"is_preferred":   sp.IsPreferred,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return mappers.SupplierProductModelToEntity(&result), nil
+}
+
+func (r *Repository) ListSupplierProducts(ctx context.Context, businessID, supplierID uint) ([]entities.SupplierProduct, error) {
+	var ml []models.SupplierProduct
+	if err := r.db.Conn(ctx).
+		Where("business_id = ? AND supplier_id = ?", businessID, supplierID).
+		Order("product_id ASC").
+		Find(&ml).Error; err != nil {
+		return nil, err
+	}
+	out := make([]entities.SupplierProduct, len(ml))
+	for i := range ml {
+		out[i] = *mappers.SupplierProductModelToEntity(&ml[i])
+	}
+	return out, nil
+}
+
+func (r *Repository) DeleteSupplierProduct(ctx context.Context, businessID, id uint) error {
+	res := r.db.Conn(ctx).Where("id = ? AND business_id = ?", id, businessID).Delete(&models.SupplierProduct{})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domainerrors.ErrSupplierProductNotFound
+	}
+	return nil
+}
+
+// GetPreferredSupplierProduct devuelve el proveedor preferido y activo del
+// producto. Sin preferido marcado toma el de menor costo.
+func (r *Repository) GetPreferredSupplierProduct(ctx context.Context, businessID uint, productID string) (*entities.SupplierProduct, error) {
+	var m models.SupplierProduct
+	err := r.db.Conn(ctx).
+		Joins("Supplier").
+		Where("supplier_products.business_id = ? AND supplier_products.product_id = ?", businessID, productID).
+		Where(`"Supplier".is_active = true`).
+		Order("supplier_products.is_preferred DESC, supplier_products.unit_cost ASC").
+		First(&m).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domainerrors.ErrSupplierProductNotFound
+		}
+		return nil, err
+	}
+	return mappers.SupplierProductModelToEntity(&m), nil
+}
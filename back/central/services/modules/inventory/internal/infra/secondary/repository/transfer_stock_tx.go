@@ -96,6 +96,9 @@ func (r *Repository) TransferStockTx(ctx context.Context, params dtos.TransferSt
 		if err := r.createMovementTx(tx, outMovement); err != nil {
 			return fmt.Errorf("createMovementTx out: %w", err)
 		}
+		if err := r.stampCurrentCostTx(tx, outMovement); err != nil {
+			return fmt.Errorf("stampCurrentCostTx out: %w", err)
+		}
 
 		// Movimiento de entrada (destino)
 		inMovement := &models.StockMovement{
@@ -118,6 +121,9 @@ func (r *Repository) TransferStockTx(ctx context.Context, params dtos.TransferSt
 		if err := r.createMovementTx(tx, inMovement); err != nil {
 			return fmt.Errorf("createMovementTx in: %w", err)
 		}
+		if err := r.stampCurrentCostTx(tx, inMovement); err != nil {
+			return fmt.Errorf("stampCurrentCostTx in: %w", err)
+		}
 
 		result = dtos.TransferStockTxResult{
 			FromNewQty: fromLevel.Quantity,
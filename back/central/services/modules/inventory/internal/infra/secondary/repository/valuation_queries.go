@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/infra/secondary/repository/mappers"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func (r *Repository) GetValuationSetting(ctx context.Context, businessID uint) (*entities.ValuationSetting, error) {
+	var m models.InventoryValuationSetting
+	err := r.db.Conn(ctx).Where("business_id = ?", businessID).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entities.ValuationSetting{BusinessID: businessID, Method: entities.ValuationWeightedAverage}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mappers.ValuationSettingModelToEntity(&m), nil
+}
+
+// SetValuationMethod cambia el metodo del negocio. Al pasar a FIFO cada
+// producto arranca con una sola capa por su saldo al costo promedio; al volver
+// a promedio las capas se cierran y manda el saldo.
+func (r *Repository) SetValuationMethod(ctx context.Context, businessID uint, method string, changedByID *uint) (*entities.ValuationSetting, error) {
+	var result *entities.ValuationSetting
+	err := r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		current, err := r.valuationMethodTx(tx, businessID)
+		if err != nil {
+			return err
+		}
+		setting := models.InventoryValuationSetting{BusinessID: businessID, Method: method, ChangedByID: changedByID}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "business_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"method", "changed_by_id", "updated_at"}),
+		}).Create(&setting).Error; err != nil {
+			return err
+		}
+
+		if current != method {
+			if err := tx.Model(&models.InventoryCostLayer{}).
+				Where("business_id = ? AND remaining_qty > 0", businessID).
+				Update("remaining_qty", 0).Error; err != nil {
+				return fmt.Errorf("close cost layers: %w", err)
+			}
+			if method == entities.ValuationFIFO {
+				var balances []models.InventoryCostBalance
+				if err := tx.Where("business_id = ? AND quantity > 0", businessID).Find(&balances).Error; err != nil {
+					return err
+				}
+				now := time.Now()
+				for _, b := range balances {
+					if err := tx.Create(&models.InventoryCostLayer{
+						BusinessID:   businessID,
+						ProductID:    b.ProductID,
+						ReceivedAt:   now,
+						OriginalQty:  b.Quantity,
+						RemainingQty: b.Quantity,
+						UnitCost:     b.AverageCost,
+					}).Error; err != nil {
+						return fmt.Errorf("open cost layer: %w", err)
+					}
+				}
+			}
+		}
+
+		var saved models.InventoryValuationSetting
+		if err := tx.Where("business_id = ?", businessID).First(&saved).Error; err != nil {
+			return err
+		}
+		result = mappers.ValuationSettingModelToEntity(&saved)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SetOpeningCost fija el costo de las existencias actuales del producto. El
+// saldo queda en (existencias x costo) y la diferencia con lo ya valorizado se
+// registra por bodega como movimiento de cantidad cero (reference_type
+// valuation_opening), para que el reporte a fecha cuadre con el saldo.
+func (r *Repository) SetOpeningCost(ctx context.Context, businessID uint, productID string, unitCost float64, movementTypeID uint, createdByID *uint) (*entities.CostBalance, error) {
+	var result *entities.CostBalance
+	err := r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		model, err := r.lockCostBalanceTx(tx, businessID, productID)
+		if err != nil {
+			return err
+		}
+
+		type whRow struct {
+			WarehouseID uint
+			Quantity    int
+			Value       float64
+		}
+		var onHand []whRow
+		if err := tx.Model(&models.InventoryLevel{}).
+			Select("warehouse_id, COALESCE(SUM(quantity), 0) AS quantity").
+			Where("business_id = ? AND product_id = ?", businessID, productID).
+			Group("warehouse_id").Scan(&onHand).Error; err != nil {
+			return err
+		}
+		var valued []whRow
+		if err := tx.Model(&models.StockMovement{}).
+			Select("warehouse_id, COALESCE(SUM(total_cost), 0) AS value").
+			Where("business_id = ? AND product_id = ? AND total_cost IS NOT NULL", businessID, productID).
+			Group("warehouse_id").Scan(&valued).Error; err != nil {
+			return err
+		}
+		valueByWh := make(map[uint]float64, len(valued))
+		for _, v := range valued {
+			valueByWh[v.WarehouseID] = v.Value
+		}
+
+		total := 0
+		refType := "valuation_opening"
+		for _, wh := range onHand {
+			total += wh.Quantity
+			delta := roundMoney(float64(wh.Quantity)*unitCost - valueByWh[wh.WarehouseID])
+			if delta == 0 {
+				continue
+			}
+			movement := &models.StockMovement{
+				ProductID:      productID,
+				WarehouseID:    wh.WarehouseID,
+				BusinessID:     businessID,
+				MovementTypeID: movementTypeID,
+				Reason:         "Costo de apertura",
+				Quantity:       0,
+				PreviousQty:    wh.Quantity,
+				NewQty:         wh.Quantity,
+				ReferenceType:  &refType,
+				Notes:          fmt.Sprintf("Costo unitario %.4f", unitCost),
+				CreatedByID:    createdByID,
+			}
+			if err := r.createMovementTx(tx, movement); err != nil {
+				return fmt.Errorf("createMovementTx: %w", err)
+			}
+			if err := r.stampMovementTx(tx, movement, unitCost, delta); err != nil {
+				return err
+			}
+		}
+
+		balance := &entities.CostBalance{}
+		balance.Receive(total, unitCost)
+		if err := tx.Model(model).Updates(map[string]any{
+			"quantity":     balance.Quantity,
+			"total_value":  balance.TotalValue,
+			"average_cost": unitCost,
+		}).Error; err != nil {
+			return err
+		}
+
+		method, err := r.valuationMethodTx(tx, businessID)
+		if err != nil {
+			return err
+		}
+		if method == entities.ValuationFIFO {
+			if err := tx.Model(&models.InventoryCostLayer{}).
+				Where("business_id = ? AND product_id = ? AND remaining_qty > 0", businessID, productID).
+				Update("remaining_qty", 0).Error; err != nil {
+				return err
+			}
+			if total > 0 {
+				if err := tx.Create(&models.InventoryCostLayer{
+					BusinessID:   businessID,
+					ProductID:    productID,
+					ReceivedAt:   time.Now(),
+					OriginalQty:  total,
+					RemainingQty: total,
+					UnitCost:     unitCost,
+				}).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		var saved models.InventoryCostBalance
+		if err := tx.First(&saved, model.ID).Error; err != nil {
+			return err
+		}
+		result = mappers.CostBalanceModelToEntity(&saved)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (r *Repository) GetCostBalance(ctx context.Context, businessID uint, productID string) (*entities.CostBalance, error) {
+	var m models.InventoryCostBalance
+	err := r.db.Conn(ctx).Where("business_id = ? AND product_id = ?", businessID, productID).First(&m).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &entities.CostBalance{BusinessID: businessID, ProductID: productID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return mappers.CostBalanceModelToEntity(&m), nil
+}
+
+func (r *Repository) ListCostLayers(ctx context.Context, businessID uint, productID string) ([]entities.CostLayer, error) {
+	var list []models.InventoryCostLayer
+	if err := r.db.Conn(ctx).
+		Where("business_id = ? AND product_id = ? AND remaining_qty > 0", businessID, productID).
+		Order("received_at ASC, id ASC").
+		Find(&list).Error; err != nil {
+		return nil, err
+	}
+	out := make([]entities.CostLayer, len(list))
+	for i := range list {
+		out[i] = *mappers.CostLayerModelToEntity(&list[i])
+	}
+	return out, nil
+}
+
+// GetValuationReport suma cantidades y costos de los movimientos hasta la
+// fecha de corte. Los cambios de estado no mueven existencias y no cuentan.
+func (r *Repository) GetValuationReport(ctx context.Context, params dtos.ValuationReportParams) ([]entities.ValuationReportLine, error) {
+	type row struct {
+		ProductID   string
+		ProductName string
+		ProductSKU  string
+		Quantity    int
+		TotalValue  float64
+	}
+	var rows []row
+
+	q := r.db.Conn(ctx).
+		Table("stock_movements sm").
+		Select(`sm.product_id,
+			p.name AS product_name,
+			p.sku AS product_sku,
+			COALESCE(SUM(sm.quantity), 0) AS quantity,
+			COALESCE(SUM(sm.total_cost), 0) AS total_value`).
+		Joins("INNER JOIN products p ON p.id = sm.product_id").
+		Where("sm.business_id = ? AND sm.created_at <= ? AND sm.deleted_at IS NULL", params.BusinessID, params.AsOf).
+		Where("COALESCE(sm.reference_type, '') <> 'state_change'")
+	if params.WarehouseID != nil {
+		q = q.Where("sm.warehouse_id = ?", *params.WarehouseID)
+	}
+	if err := q.Group("sm.product_id, p.name, p.sku").
+		Having("SUM(sm.quantity) <> 0 OR COALESCE(SUM(sm.total_cost), 0) <> 0").
+		Order("p.name ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	out := make([]entities.ValuationReportLine, len(rows))
+	for i, row := range rows {
+		out[i] = entities.ValuationReportLine{
+			ProductID:   row.ProductID,
+			ProductName: row.ProductName,
+			ProductSKU:  row.ProductSKU,
+			WarehouseID: params.WarehouseID,
+			Quantity:    row.Quantity,
+			TotalValue:  roundMoney(row.TotalValue),
+		}
+		if row.Quantity > 0 {
+			out[i].UnitCost = row.TotalValue / float64(row.Quantity)
+		}
+	}
+	return out, nil
+}
+
+func (r *Repository) ListOrderLineCosts(ctx context.Context, businessID uint, orderID string) ([]entities.OrderLineCost, error) {
+	var list []models.OrderLineCost
+	if err := r.db.Conn(ctx).
+		Where("business_id = ? AND order_id = ?", businessID, orderID).
+		Order("id ASC").
+		Find(&list).Error; err != nil {
+		return nil, err
+	}
+	out := make([]entities.OrderLineCost, len(list))
+	for i := range list {
+		out[i] = *mappers.OrderLineCostModelToEntity(&list[i])
+	}
+	return out, nil
+}
@@ -0,0 +1,210 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/infra/secondary/repository/mappers"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// movementCost es el resultado de valorizar un movimiento
+type movementCost struct {
+	Method    string
+	UnitCost  float64
+	TotalCost float64 // mismo signo que la cantidad del movimiento
+}
+
+// valuationMethodTx devuelve el metodo del negocio (promedio ponderado si no
+// lo ha configurado)
+func (r *Repository) valuationMethodTx(tx *gorm.DB, businessID uint) (string, error) {
+	var setting models.InventoryValuationSetting
+	err := tx.Where("business_id = ?", businessID).First(&setting).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entities.ValuationWeightedAverage, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return setting.Method, nil
+}
+
+// lockCostBalanceTx bloquea (y crea si no existe) el saldo valorizado del
+// producto. Todas las entradas y salidas del producto pasan por este lock, asi
+// que tambien serializa el consumo de capas FIFO.
+func (r *Repository) lockCostBalanceTx(tx *gorm.DB, businessID uint, productID string) (*models.InventoryCostBalance, error) {
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.InventoryCostBalance{
+		BusinessID: businessID,
+		ProductID:  productID,
+	}).Error; err != nil {
+		return nil, err
+	}
+	var balance models.InventoryCostBalance
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("business_id = ? AND product_id = ?", businessID, productID).
+		First(&balance).Error; err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+// costMovementTx valoriza un movimiento ya insertado que cambia existencias:
+// las entradas suman al saldo (y abren capa en FIFO) al costo indicado o, si no
+// viene, al promedio vigente; las salidas se costean al promedio o consumiendo
+// capas FIFO. Deja unit_cost y total_cost en el movimiento.
+func (r *Repository) costMovementTx(tx *gorm.DB, movement *models.StockMovement, unitCost *float64) (*movementCost, error) {
+	if movement.Quantity == 0 {
+		return &movementCost{}, nil
+	}
+	method, err := r.valuationMethodTx(tx, movement.BusinessID)
+	if err != nil {
+		return nil, fmt.Errorf("valuation method: %w", err)
+	}
+	model, err := r.lockCostBalanceTx(tx, movement.BusinessID, movement.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("lock cost balance: %w", err)
+	}
+	balance := mappers.CostBalanceModelToEntity(model)
+	result := &movementCost{Method: method}
+
+	if movement.Quantity > 0 {
+		cost := balance.AverageCost
+		if unitCost != nil {
+			cost = *unitCost
+		}
+		balance.Receive(movement.Quantity, cost)
+		if method == entities.ValuationFIFO {
+			if err := tx.Create(&models.InventoryCostLayer{
+				BusinessID:   movement.BusinessID,
+				ProductID:    movement.ProductID,
+				WarehouseID:  &movement.WarehouseID,
+				MovementID:   &movement.ID,
+				LotID:        movement.LotID,
+				ReceivedAt:   movement.CreatedAt,
+				OriginalQty:  movement.Quantity,
+				RemainingQty: movement.Quantity,
+				UnitCost:     cost,
+			}).Error; err != nil {
+				return nil, fmt.Errorf("create cost layer: %w", err)
+			}
+		}
+		result.UnitCost = cost
+		result.TotalCost = roundMoney(float64(movement.Quantity) * cost)
+	} else {
+		qty := -movement.Quantity
+		var total float64
+		if method == entities.ValuationFIFO {
+			total, err = r.consumeLayersTx(tx, movement.BusinessID, movement.ProductID, qty, balance.AverageCost)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			total = float64(qty) * balance.AverageCost
+		}
+		balance.Issue(qty, total)
+		result.UnitCost = total / float64(qty)
+		result.TotalCost = -roundMoney(total)
+	}
+
+	if err := tx.Model(model).Updates(map[string]any{
+		"quantity":     balance.Quantity,
+		"total_value":  balance.TotalValue,
+		"average_cost": balance.AverageCost,
+	}).Error; err != nil {
+		return nil, fmt.Errorf("update cost balance: %w", err)
+	}
+	if err := r.stampMovementTx(tx, movement, result.UnitCost, result.TotalCost); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// consumeLayersTx descuenta qty de las capas abiertas, la mas antigua primero
+func (r *Repository) consumeLayersTx(tx *gorm.DB, businessID uint, productID string, qty int, fallbackCost float64) (float64, error) {
+	var layers []models.InventoryCostLayer
+	if err := tx.Where("business_id = ? AND product_id = ? AND remaining_qty > 0", businessID, productID).
+		Order("received_at ASC, id ASC").
+		Find(&layers).Error; err != nil {
+		return 0, fmt.Errorf("list cost layers: %w", err)
+	}
+	open := make([]entities.CostLayer, len(layers))
+	for i := range layers {
+		open[i] = *mappers.CostLayerModelToEntity(&layers[i])
+	}
+	consumed, total := entities.ConsumeFIFO(open, qty, fallbackCost)
+	for _, c := range consumed {
+		if err := tx.Model(&models.InventoryCostLayer{}).Where("id = ?", c.LayerID).
+			Update("remaining_qty", gorm.Expr("remaining_qty - ?", c.Quantity)).Error; err != nil {
+			return 0, fmt.Errorf("consume cost layer: %w", err)
+		}
+	}
+	return total, nil
+}
+
+// stampCurrentCostTx deja en el movimiento el costo promedio vigente sin tocar
+// el saldo. Lo usan las transferencias: la valorizacion es por negocio, asi que
+// mover entre bodegas no cambia el valor total pero la salida y la entrada
+// quedan valorizadas en el kardex de cada bodega.
+func (r *Repository) stampCurrentCostTx(tx *gorm.DB, movement *models.StockMovement) error {
+	var balance models.InventoryCostBalance
+	err := tx.Where("business_id = ? AND product_id = ?", movement.BusinessID, movement.ProductID).First(&balance).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return r.stampMovementTx(tx, movement, balance.AverageCost, roundMoney(float64(movement.Quantity)*balance.AverageCost))
+}
+
+func (r *Repository) stampMovementTx(tx *gorm.DB, movement *models.StockMovement, unitCost, totalCost float64) error {
+	unitCost = math.Round(unitCost*10000) / 10000
+	movement.UnitCost = &unitCost
+	movement.TotalCost = &totalCost
+	if err := tx.Model(&models.StockMovement{}).Where("id = ?", movement.ID).Updates(map[string]any{
+		"unit_cost":  unitCost,
+		"total_cost": totalCost,
+	}).Error; err != nil {
+		return fmt.Errorf("stamp movement cost: %w", err)
+	}
+	return nil
+}
+
+// createOrderLineCostTx guarda el costo de venta de la linea (o su reverso en
+// devoluciones, con cantidad negativa)
+func (r *Repository) createOrderLineCostTx(tx *gorm.DB, movement *models.StockMovement, orderID string, cost *movementCost) error {
+	if cost.Method == "" {
+		return nil
+	}
+	return tx.Create(&models.OrderLineCost{
+		BusinessID:  movement.BusinessID,
+		OrderID:     orderID,
+		ProductID:   movement.ProductID,
+		WarehouseID: movement.WarehouseID,
+		MovementID:  movement.ID,
+		Quantity:    -movement.Quantity,
+		UnitCost:    math.Round(cost.UnitCost*10000) / 10000,
+		TotalCost:   -cost.TotalCost,
+		Method:      cost.Method,
+	}).Error
+}
+
+func roundMoney(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// soldUnitCostTx busca el costo unitario con que salio el producto en la
+// orden; nil si la venta no quedo valorizada
+func (r *Repository) soldUnitCostTx(tx *gorm.DB, businessID uint, orderID, productID string) *float64 {
+	var line models.OrderLineCost
+	err := tx.Where("business_id = ? AND order_id = ? AND product_id = ? AND quantity > 0", businessID, orderID, productID).
+		Order("id DESC").First(&line).Error
+	if err != nil {
+		return nil
+	}
+	return &line.UnitCost
+}
@@ -10,9 +10,11 @@ import (
 type SyncPublisherMock struct {
 	PublishInventorySyncFn      func(ctx context.Context, msg ports.InventorySyncMessage) error
 	PublishEcommerceStockPushFn func(ctx context.Context, msg ports.EcommerceStockPushMessage) error
+	PublishOrderFeedbackFn      func(ctx context.Context, msg ports.OrderFeedbackMessage) error
 	// Registra las llamadas realizadas para poder verificar en tests
 	Calls          []ports.InventorySyncMessage
 	EcommerceCalls []ports.EcommerceStockPushMessage
+	FeedbackCalls  []ports.OrderFeedbackMessage
 }
 
 func (m *SyncPublisherMock) PublishInventorySync(ctx context.Context, msg ports.InventorySyncMessage) error {
@@ -31,6 +33,14 @@ func (m *SyncPublisherMock) PublishEcommerceStockPush(ctx context.Context, msg p
 	return nil
 }
 
+func (m *SyncPublisherMock) PublishOrderFeedback(ctx context.Context, msg ports.OrderFeedbackMessage) error {
+	m.FeedbackCalls = append(m.FeedbackCalls, msg)
+	if m.PublishOrderFeedbackFn != nil {
+		return m.PublishOrderFeedbackFn(ctx, msg)
+	}
+	return nil
+}
+
 // InventoryEventPublisherMock implementa ports.IInventoryEventPublisher para tests
 type InventoryEventPublisherMock struct {
 	PublishInventoryEventFn func(ctx context.Context, event ports.InventoryEvent) error
@@ -10,6 +10,7 @@ import (
 
 // RepositoryMock implementa ports.IRepository para tests
 type RepositoryMock struct {
+	InTransactionFn                                    func(ctx context.Context, fn func(ctx context.Context) error) error
 	GetProductInventoryFn                              func(ctx context.Context, params dtos.GetProductInventoryParams) ([]entities.InventoryLevel, error)
 	ListWarehouseInventoryFn                           func(ctx context.Context, params dtos.ListWarehouseInventoryParams) ([]entities.InventoryLevel, int64, error)
 	GetOrCreateLevelFn                                 func(ctx context.Context, productID string, warehouseID uint, locationID *uint, businessID uint) (*entities.InventoryLevel, error)
@@ -138,6 +139,64 @@ type RepositoryMock struct {
 	UpdateSyncLogStatusFn func(ctx context.Context, id uint, status, errorMsg string) error
 	ListSyncLogsFn        func(ctx context.Context, params dtos.ListSyncLogsParams) ([]entities.InventorySyncLog, int64, error)
 
+	CreateSupplierFn              func(ctx context.Context, s *entities.Supplier) (*entities.Supplier, error)
+	GetSupplierByIDFn             func(ctx context.Context, businessID, id uint) (*entities.Supplier, error)
+	ListSuppliersFn               func(ctx context.Context, params dtos.ListSuppliersParams) ([]entities.Supplier, int64, error)
+	UpdateSupplierFn              func(ctx context.Context, s *entities.Supplier) (*entities.Supplier, error)
+	UpsertSupplierProductFn       func(ctx context.Context, sp *entities.SupplierProduct) (*entities.SupplierProduct, error)
+	ListSupplierProductsFn        func(ctx context.Context, businessID, supplierID uint) ([]entities.SupplierProduct, error)
+	DeleteSupplierProductFn       func(ctx context.Context, businessID, id uint) error
+	GetPreferredSupplierProductFn func(ctx context.Context, businessID uint, productID string) (*entities.SupplierProduct, error)
+	NextPurchaseOrderCodeFn       func(ctx context.Context, businessID uint) (string, error)
+	CreatePurchaseOrderFn         func(ctx context.Context, po *entities.PurchaseOrder) (*entities.PurchaseOrder, error)
+	GetPurchaseOrderByIDFn        func(ctx context.Context, businessID, id uint) (*entities.PurchaseOrder, error)
+	LockPurchaseOrderFn           func(ctx context.Context, businessID, id uint) (*entities.PurchaseOrder, error)
+	ListPurchaseOrdersFn          func(ctx context.Context, params dtos.ListPurchaseOrdersParams) ([]entities.PurchaseOrder, int64, error)
+	UpdatePurchaseOrderFn         func(ctx context.Context, po *entities.PurchaseOrder) error
+	ReplacePurchaseOrderLinesFn   func(ctx context.Context, poID uint, lines []entities.PurchaseOrderLine) error
+	UpdatePurchaseOrderLineFn     func(ctx context.Context, line *entities.PurchaseOrderLine) error
+	OpenPurchaseQuantityFn        func(ctx context.Context, businessID, warehouseID uint, productID string) (int, error)
+	CreatePurchaseOrderReceiptFn  func(ctx context.Context, receipt *entities.PurchaseOrderReceipt) (*entities.PurchaseOrderReceipt, error)
+	ListPurchaseOrderReceiptsFn   func(ctx context.Context, businessID, poID uint) ([]entities.PurchaseOrderReceipt, error)
+	CreateLandedCostFn            func(ctx context.Context, cost *entities.PurchaseOrderLandedCost) (*entities.PurchaseOrderLandedCost, error)
+	ListLandedCostsFn             func(ctx context.Context, poID uint) ([]entities.PurchaseOrderLandedCost, error)
+	GetLotByCodeFn                func(ctx context.Context, businessID uint, productID, code string) (*entities.InventoryLot, error)
+
+	GetValuationSettingFn func(ctx context.Context, businessID uint) (*entities.ValuationSetting, error)
+	SetValuationMethodFn  func(ctx context.Context, businessID uint, method string, changedByID *uint) (*entities.ValuationSetting, error)
+	SetOpeningCostFn      func(ctx context.Context, businessID uint, productID string, unitCost float64, movementTypeID uint, createdByID *uint) (*entities.CostBalance, error)
+	GetCostBalanceFn      func(ctx context.Context, businessID uint, productID string) (*entities.CostBalance, error)
+	ListCostLayersFn      func(ctx context.Context, businessID uint, productID string) ([]entities.CostLayer, error)
+	GetValuationReportFn  func(ctx context.Context, params dtos.ValuationReportParams) ([]entities.ValuationReportLine, error)
+	ListOrderLineCostsFn  func(ctx context.Context, businessID uint, orderID string) ([]entities.OrderLineCost, error)
+
+	GetKitFn                     func(ctx context.Context, businessID uint, productID string) (*entities.Kit, error)
+	ListKitsFn                   func(ctx context.Context, params dtos.ListKitsParams) ([]entities.Kit, int64, error)
+	SaveKitFn                    func(ctx context.Context, params dtos.SaveKitParams) (*entities.Kit, error)
+	DeleteKitFn                  func(ctx context.Context, businessID uint, productID string) error
+	IsKitComponentFn             func(ctx context.Context, businessID uint, productID string) (bool, error)
+	ListVirtualKitsByComponentFn func(ctx context.Context, businessID uint, componentProductID string) ([]string, error)
+	GetAvailableByProductsFn     func(ctx context.Context, businessID uint, productIDs []string, warehouseID *uint) (map[string]int, error)
+	NextKitAssemblyCodeFn        func(ctx context.Context, businessID uint) (string, error)
+	CreateKitAssemblyOrderFn     func(ctx context.Context, order *entities.KitAssemblyOrder) (*entities.KitAssemblyOrder, error)
+	GetKitAssemblyOrderFn        func(ctx context.Context, businessID, id uint) (*entities.KitAssemblyOrder, error)
+	ListKitAssemblyOrdersFn      func(ctx context.Context, params dtos.ListKitAssemblyOrdersParams) ([]entities.KitAssemblyOrder, int64, error)
+	CompleteKitAssemblyOrderFn   func(ctx context.Context, params dtos.CompleteKitAssemblyParams) error
+	CancelKitAssemblyOrderFn     func(ctx context.Context, businessID, id uint) error
+
+	NextPickWaveCodeFn     func(ctx context.Context, businessID uint) (string, error)
+	ListPickCandidatesFn   func(ctx context.Context, params dtos.PickCandidateParams) ([]entities.PickCandidate, error)
+	ListLocatedStockFn     func(ctx context.Context, businessID, warehouseID uint, productIDs []string, zoneID *uint) ([]entities.LocatedStock, error)
+	CreatePickWaveFn       func(ctx context.Context, wave *entities.PickWave) (*entities.PickWave, error)
+	GetPickWaveFn          func(ctx context.Context, businessID, id uint) (*entities.PickWave, error)
+	LockPickWaveFn         func(ctx context.Context, businessID, id uint) (*entities.PickWave, error)
+	ListPickWavesFn        func(ctx context.Context, params dtos.ListPickWavesParams) ([]entities.PickWave, int64, error)
+	UpdatePickWaveStatusFn func(ctx context.Context, params dtos.UpdatePickWaveStatusParams) error
+	UpdatePickListLineFn   func(ctx context.Context, line *entities.PickListLine) error
+	UpdatePickWaveItemFn   func(ctx context.Context, item *entities.PickWaveItem) error
+	UpdatePickWaveOrderFn  func(ctx context.Context, order *entities.PickWaveOrder) error
+	SetOrderPackageFn      func(ctx context.Context, params dtos.OrderPackageParams) error
+
 	IsBusinessModuleActiveFn func(ctx context.Context, businessID uint, moduleCode string) (bool, error)
 }
 
@@ -295,6 +354,13 @@ func (m *RepositoryMock) GetProductIntegrations(ctx context.Context, productID s
 	return nil, nil
 }
 
+func (m *RepositoryMock) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if m.InTransactionFn != nil {
+		return m.InTransactionFn(ctx, fn)
+	}
+	return fn(ctx)
+}
+
 func (m *RepositoryMock) AdjustStockTx(ctx context.Context, params dtos.AdjustStockTxParams) (*dtos.AdjustStockTxResult, error) {
 	if m.AdjustStockTxFn != nil {
 		return m.AdjustStockTxFn(ctx, params)
@@ -0,0 +1,100 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+)
+
+func (m *RepositoryMock) GetKit(ctx context.Context, businessID uint, productID string) (*entities.Kit, error) {
+	if m.GetKitFn != nil {
+		return m.GetKitFn(ctx, businessID, productID)
+	}
+	return nil, domainerrors.ErrKitNotFound
+}
+
+func (m *RepositoryMock) ListKits(ctx context.Context, params dtos.ListKitsParams) ([]entities.Kit, int64, error) {
+	if m.ListKitsFn != nil {
+		return m.ListKitsFn(ctx, params)
+	}
+	return []entities.Kit{}, 0, nil
+}
+
+func (m *RepositoryMock) SaveKit(ctx context.Context, params dtos.SaveKitParams) (*entities.Kit, error) {
+	if m.SaveKitFn != nil {
+		return m.SaveKitFn(ctx, params)
+	}
+	return &entities.Kit{BusinessID: params.BusinessID, ProductID: params.ProductID, Mode: params.Mode, IsActive: true, Components: params.Components}, nil
+}
+
+func (m *RepositoryMock) DeleteKit(ctx context.Context, businessID uint, productID string) error {
+	if m.DeleteKitFn != nil {
+		return m.DeleteKitFn(ctx, businessID, productID)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) IsKitComponent(ctx context.Context, businessID uint, productID string) (bool, error) {
+	if m.IsKitComponentFn != nil {
+		return m.IsKitComponentFn(ctx, businessID, productID)
+	}
+	return false, nil
+}
+
+func (m *RepositoryMock) ListVirtualKitsByComponent(ctx context.Context, businessID uint, componentProductID string) ([]string, error) {
+	if m.ListVirtualKitsByComponentFn != nil {
+		return m.ListVirtualKitsByComponentFn(ctx, businessID, componentProductID)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) GetAvailableByProducts(ctx context.Context, businessID uint, productIDs []string, warehouseID *uint) (map[string]int, error) {
+	if m.GetAvailableByProductsFn != nil {
+		return m.GetAvailableByProductsFn(ctx, businessID, productIDs, warehouseID)
+	}
+	return map[string]int{}, nil
+}
+
+func (m *RepositoryMock) NextKitAssemblyCode(ctx context.Context, businessID uint) (string, error) {
+	if m.NextKitAssemblyCodeFn != nil {
+		return m.NextKitAssemblyCodeFn(ctx, businessID)
+	}
+	return "ENS-000001", nil
+}
+
+func (m *RepositoryMock) CreateKitAssemblyOrder(ctx context.Context, order *entities.KitAssemblyOrder) (*entities.KitAssemblyOrder, error) {
+	if m.CreateKitAssemblyOrderFn != nil {
+		return m.CreateKitAssemblyOrderFn(ctx, order)
+	}
+	return order, nil
+}
+
+func (m *RepositoryMock) GetKitAssemblyOrder(ctx context.Context, businessID, id uint) (*entities.KitAssemblyOrder, error) {
+	if m.GetKitAssemblyOrderFn != nil {
+		return m.GetKitAssemblyOrderFn(ctx, businessID, id)
+	}
+	return nil, domainerrors.ErrKitAssemblyNotFound
+}
+
+func (m *RepositoryMock) ListKitAssemblyOrders(ctx context.Context, params dtos.ListKitAssemblyOrdersParams) ([]entities.KitAssemblyOrder, int64, error) {
+	if m.ListKitAssemblyOrdersFn != nil {
+		return m.ListKitAssemblyOrdersFn(ctx, params)
+	}
+	return []entities.KitAssemblyOrder{}, 0, nil
+}
+
+func (m *RepositoryMock) CompleteKitAssemblyOrder(ctx context.Context, params dtos.CompleteKitAssemblyParams) error {
+	if m.CompleteKitAssemblyOrderFn != nil {
+		return m.CompleteKitAssemblyOrderFn(ctx, params)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) CancelKitAssemblyOrder(ctx context.Context, businessID, id uint) error {
+	if m.CancelKitAssemblyOrderFn != nil {
+		return m.CancelKitAssemblyOrderFn(ctx, businessID, id)
+	}
+	return nil
+}
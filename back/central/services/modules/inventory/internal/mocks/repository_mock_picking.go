@@ -0,0 +1,95 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+)
+
+func (m *RepositoryMock) NextPickWaveCode(ctx context.Context, businessID uint) (string, error) {
+	if m.NextPickWaveCodeFn != nil {
+		return m.NextPickWaveCodeFn(ctx, businessID)
+	}
+	return "OLA-000001", nil
+}
+
+func (m *RepositoryMock) ListPickCandidates(ctx context.Context, params dtos.PickCandidateParams) ([]entities.PickCandidate, error) {
+	if m.ListPickCandidatesFn != nil {
+		return m.ListPickCandidatesFn(ctx, params)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) ListLocatedStock(ctx context.Context, businessID, warehouseID uint, productIDs []string, zoneID *uint) ([]entities.LocatedStock, error) {
+	if m.ListLocatedStockFn != nil {
+		return m.ListLocatedStockFn(ctx, businessID, warehouseID, productIDs, zoneID)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) CreatePickWave(ctx context.Context, wave *entities.PickWave) (*entities.PickWave, error) {
+	if m.CreatePickWaveFn != nil {
+		return m.CreatePickWaveFn(ctx, wave)
+	}
+	wave.ID = 1
+	return wave, nil
+}
+
+func (m *RepositoryMock) GetPickWave(ctx context.Context, businessID, id uint) (*entities.PickWave, error) {
+	if m.GetPickWaveFn != nil {
+		return m.GetPickWaveFn(ctx, businessID, id)
+	}
+	return nil, domainerrors.ErrPickWaveNotFound
+}
+
+// LockPickWave sin LockPickWaveFn relee la ola con GetPickWave
+func (m *RepositoryMock) LockPickWave(ctx context.Context, businessID, id uint) (*entities.PickWave, error) {
+	if m.LockPickWaveFn != nil {
+		return m.LockPickWaveFn(ctx, businessID, id)
+	}
+	return m.GetPickWave(ctx, businessID, id)
+}
+
+func (m *RepositoryMock) ListPickWaves(ctx context.Context, params dtos.ListPickWavesParams) ([]entities.PickWave, int64, error) {
+	if m.ListPickWavesFn != nil {
+		return m.ListPickWavesFn(ctx, params)
+	}
+	return []entities.PickWave{}, 0, nil
+}
+
+func (m *RepositoryMock) UpdatePickWaveStatus(ctx context.Context, params dtos.UpdatePickWaveStatusParams) error {
+	if m.UpdatePickWaveStatusFn != nil {
+		return m.UpdatePickWaveStatusFn(ctx, params)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) UpdatePickListLine(ctx context.Context, line *entities.PickListLine) error {
+	if m.UpdatePickListLineFn != nil {
+		return m.UpdatePickListLineFn(ctx, line)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) UpdatePickWaveItem(ctx context.Context, item *entities.PickWaveItem) error {
+	if m.UpdatePickWaveItemFn != nil {
+		return m.UpdatePickWaveItemFn(ctx, item)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) UpdatePickWaveOrder(ctx context.Context, order *entities.PickWaveOrder) error {
+	if m.UpdatePickWaveOrderFn != nil {
+		return m.UpdatePickWaveOrderFn(ctx, order)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) SetOrderPackage(ctx context.Context, params dtos.OrderPackageParams) error {
+	if m.SetOrderPackageFn != nil {
+		return m.SetOrderPackageFn(ctx, params)
+	}
+	return nil
+}
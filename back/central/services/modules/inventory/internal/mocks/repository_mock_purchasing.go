@@ -0,0 +1,163 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/errors"
+)
+
+func (m *RepositoryMock) CreateSupplier(ctx context.Context, s *entities.Supplier) (*entities.Supplier, error) {
+	if m.CreateSupplierFn != nil {
+		return m.CreateSupplierFn(ctx, s)
+	}
+	return s, nil
+}
+
+func (m *RepositoryMock) GetSupplierByID(ctx context.Context, businessID, id uint) (*entities.Supplier, error) {
+	if m.GetSupplierByIDFn != nil {
+		return m.GetSupplierByIDFn(ctx, businessID, id)
+	}
+	return &entities.Supplier{ID: id, BusinessID: businessID, IsActive: true}, nil
+}
+
+func (m *RepositoryMock) ListSuppliers(ctx context.Context, params dtos.ListSuppliersParams) ([]entities.Supplier, int64, error) {
+	if m.ListSuppliersFn != nil {
+		return m.ListSuppliersFn(ctx, params)
+	}
+	return []entities.Supplier{}, 0, nil
+}
+
+func (m *RepositoryMock) UpdateSupplier(ctx context.Context, s *entities.Supplier) (*entities.Supplier, error) {
+	if m.UpdateSupplierFn != nil {
+		return m.UpdateSupplierFn(ctx, s)
+	}
+	return s, nil
+}
+
+func (m *RepositoryMock) UpsertSupplierProduct(ctx context.Context, sp *entities.SupplierProduct) (*entities.SupplierProduct, error) {
+	if m.UpsertSupplierProductFn != nil {
+		return m.UpsertSupplierProductFn(ctx, sp)
+	}
+	return sp, nil
+}
+
+func (m *RepositoryMock) ListSupplierProducts(ctx context.Context, businessID, supplierID uint) ([]entities.SupplierProduct, error) {
+	if m.ListSupplierProductsFn != nil {
+		return m.ListSupplierProductsFn(ctx, businessID, supplierID)
+	}
+	return []entities.SupplierProduct{}, nil
+}
+
+func (m *RepositoryMock) DeleteSupplierProduct(ctx context.Context, businessID, id uint) error {
+	if m.DeleteSupplierProductFn != nil {
+		return m.DeleteSupplierProductFn(ctx, businessID, id)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) GetPreferredSupplierProduct(ctx context.Context, businessID uint, productID string) (*entities.SupplierProduct, error) {
+	if m.GetPreferredSupplierProductFn != nil {
+		return m.GetPreferredSupplierProductFn(ctx, businessID, productID)
+	}
+	return nil, domainerrors.ErrSupplierProductNotFound
+}
+
+func (m *RepositoryMock) NextPurchaseOrderCode(ctx context.Context, businessID uint) (string, error) {
+	if m.NextPurchaseOrderCodeFn != nil {
+		return m.NextPurchaseOrderCodeFn(ctx, businessID)
+	}
+	return "OC-000001", nil
+}
+
+func (m *RepositoryMock) CreatePurchaseOrder(ctx context.Context, po *entities.PurchaseOrder) (*entities.PurchaseOrder, error) {
+	if m.CreatePurchaseOrderFn != nil {
+		return m.CreatePurchaseOrderFn(ctx, po)
+	}
+	return po, nil
+}
+
+func (m *RepositoryMock) GetPurchaseOrderByID(ctx context.Context, businessID, id uint) (*entities.PurchaseOrder, error) {
+	if m.GetPurchaseOrderByIDFn != nil {
+		return m.GetPurchaseOrderByIDFn(ctx, businessID, id)
+	}
+	return &entities.PurchaseOrder{ID: id, BusinessID: businessID}, nil
+}
+
+func (m *RepositoryMock) LockPurchaseOrder(ctx context.Context, businessID, id uint) (*entities.PurchaseOrder, error) {
+	if m.LockPurchaseOrderFn != nil {
+		return m.LockPurchaseOrderFn(ctx, businessID, id)
+	}
+	return m.GetPurchaseOrderByID(ctx, businessID, id)
+}
+
+func (m *RepositoryMock) ListPurchaseOrders(ctx context.Context, params dtos.ListPurchaseOrdersParams) ([]entities.PurchaseOrder, int64, error) {
+	if m.ListPurchaseOrdersFn != nil {
+		return m.ListPurchaseOrdersFn(ctx, params)
+	}
+	return []entities.PurchaseOrder{}, 0, nil
+}
+
+func (m *RepositoryMock) UpdatePurchaseOrder(ctx context.Context, po *entities.PurchaseOrder) error {
+	if m.UpdatePurchaseOrderFn != nil {
+		return m.UpdatePurchaseOrderFn(ctx, po)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) ReplacePurchaseOrderLines(ctx context.Context, poID uint, lines []entities.PurchaseOrderLine) error {
+	if m.ReplacePurchaseOrderLinesFn != nil {
+		return m.ReplacePurchaseOrderLinesFn(ctx, poID, lines)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) UpdatePurchaseOrderLine(ctx context.Context, line *entities.PurchaseOrderLine) error {
+	if m.UpdatePurchaseOrderLineFn != nil {
+		return m.UpdatePurchaseOrderLineFn(ctx, line)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) OpenPurchaseQuantity(ctx context.Context, businessID, warehouseID uint, productID string) (int, error) {
+	if m.OpenPurchaseQuantityFn != nil {
+		return m.OpenPurchaseQuantityFn(ctx, businessID, warehouseID, productID)
+	}
+	return 0, nil
+}
+
+func (m *RepositoryMock) CreatePurchaseOrderReceipt(ctx context.Context, receipt *entities.PurchaseOrderReceipt) (*entities.PurchaseOrderReceipt, error) {
+	if m.CreatePurchaseOrderReceiptFn != nil {
+		return m.CreatePurchaseOrderReceiptFn(ctx, receipt)
+	}
+	return receipt, nil
+}
+
+func (m *RepositoryMock) ListPurchaseOrderReceipts(ctx context.Context, businessID, poID uint) ([]entities.PurchaseOrderReceipt, error) {
+	if m.ListPurchaseOrderReceiptsFn != nil {
+		return m.ListPurchaseOrderReceiptsFn(ctx, businessID, poID)
+	}
+	return []entities.PurchaseOrderReceipt{}, nil
+}
+
+func (m *RepositoryMock) CreateLandedCost(ctx context.Context, cost *entities.PurchaseOrderLandedCost) (*entities.PurchaseOrderLandedCost, error) {
+	if m.CreateLandedCostFn != nil {
+		return m.CreateLandedCostFn(ctx, cost)
+	}
+	return cost, nil
+}
+
+func (m *RepositoryMock) ListLandedCosts(ctx context.Context, poID uint) ([]entities.PurchaseOrderLandedCost, error) {
+	if m.ListLandedCostsFn != nil {
+		return m.ListLandedCostsFn(ctx, poID)
+	}
+	return []entities.PurchaseOrderLandedCost{}, nil
+}
+
+func (m *RepositoryMock) GetLotByCode(ctx context.Context, businessID uint, productID, code string) (*entities.InventoryLot, error) {
+	if m.GetLotByCodeFn != nil {
+		return m.GetLotByCodeFn(ctx, businessID, productID, code)
+	}
+	return nil, domainerrors.ErrLotNotFound
+}
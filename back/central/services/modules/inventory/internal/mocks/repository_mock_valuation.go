@@ -0,0 +1,57 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+)
+
+func (m *RepositoryMock) GetValuationSetting(ctx context.Context, businessID uint) (*entities.ValuationSetting, error) {
+	if m.GetValuationSettingFn != nil {
+		return m.GetValuationSettingFn(ctx, businessID)
+	}
+	return &entities.ValuationSetting{BusinessID: businessID, Method: entities.ValuationWeightedAverage}, nil
+}
+
+func (m *RepositoryMock) SetValuationMethod(ctx context.Context, businessID uint, method string, changedByID *uint) (*entities.ValuationSetting, error) {
+	if m.SetValuationMethodFn != nil {
+		return m.SetValuationMethodFn(ctx, businessID, method, changedByID)
+	}
+	return &entities.ValuationSetting{BusinessID: businessID, Method: method, ChangedByID: changedByID}, nil
+}
+
+func (m *RepositoryMock) SetOpeningCost(ctx context.Context, businessID uint, productID string, unitCost float64, movementTypeID uint, createdByID *uint) (*entities.CostBalance, error) {
+	if m.SetOpeningCostFn != nil {
+		return m.SetOpeningCostFn(ctx, businessID, productID, unitCost, movementTypeID, createdByID)
+	}
+	return &entities.CostBalance{BusinessID: businessID, ProductID: productID, AverageCost: unitCost}, nil
+}
+
+func (m *RepositoryMock) GetCostBalance(ctx context.Context, businessID uint, productID string) (*entities.CostBalance, error) {
+	if m.GetCostBalanceFn != nil {
+		return m.GetCostBalanceFn(ctx, businessID, productID)
+	}
+	return &entities.CostBalance{BusinessID: businessID, ProductID: productID}, nil
+}
+
+func (m *RepositoryMock) ListCostLayers(ctx context.Context, businessID uint, productID string) ([]entities.CostLayer, error) {
+	if m.ListCostLayersFn != nil {
+		return m.ListCostLayersFn(ctx, businessID, productID)
+	}
+	return []entities.CostLayer{}, nil
+}
+
+func (m *RepositoryMock) GetValuationReport(ctx context.Context, params dtos.ValuationReportParams) ([]entities.ValuationReportLine, error) {
+	if m.GetValuationReportFn != nil {
+		return m.GetValuationReportFn(ctx, params)
+	}
+	return []entities.ValuationReportLine{}, nil
+}
+
+func (m *RepositoryMock) ListOrderLineCosts(ctx context.Context, businessID uint, orderID string) ([]entities.OrderLineCost, error) {
+	if m.ListOrderLineCostsFn != nil {
+		return m.ListOrderLineCostsFn(ctx, businessID, orderID)
+	}
+	return []entities.OrderLineCost{}, nil
+}
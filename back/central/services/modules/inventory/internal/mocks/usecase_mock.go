@@ -25,6 +25,7 @@ type UseCaseMock struct {
 	ConfirmSaleForOrderFn    func(ctx context.Context, orderID string, businessID uint, warehouseID *uint, items []dtos.OrderInventoryItem) (*response.OrderStockResult, error)
 	ReleaseStockForOrderFn   func(ctx context.Context, orderID string, businessID uint, warehouseID *uint, items []dtos.OrderInventoryItem) (*response.OrderStockResult, error)
 	ReturnStockForOrderFn    func(ctx context.Context, orderID string, businessID uint, warehouseID *uint, items []dtos.OrderInventoryItem) (*response.OrderStockResult, error)
+	ReceiveReturnFn          func(ctx context.Context, dto request.ReceiveReturnDTO) (*response.OrderStockResult, error)
 	ValidateCubingFn         func(ctx context.Context, dto request.ValidateCubingDTO) (*response.CubingCheckResult, error)
 
 	CreateLotFn func(ctx context.Context, dto request.CreateLotDTO) (*entities.InventoryLot, error)
@@ -105,6 +106,53 @@ type UseCaseMock struct {
 
 	InboundSyncFn  func(ctx context.Context, dto request.InboundSyncDTO) (*response.InboundSyncResult, error)
 	ListSyncLogsFn func(ctx context.Context, params dtos.ListSyncLogsParams) ([]entities.InventorySyncLog, int64, error)
+
+	CreateSupplierFn              func(ctx context.Context, dto request.CreateSupplierDTO) (*entities.Supplier, error)
+	GetSupplierFn                 func(ctx context.Context, businessID, id uint) (*entities.Supplier, error)
+	ListSuppliersFn               func(ctx context.Context, params dtos.ListSuppliersParams) ([]entities.Supplier, int64, error)
+	UpdateSupplierFn              func(ctx context.Context, dto request.UpdateSupplierDTO) (*entities.Supplier, error)
+	SetSupplierProductFn          func(ctx context.Context, dto request.SetSupplierProductDTO) (*entities.SupplierProduct, error)
+	ListSupplierProductsFn        func(ctx context.Context, businessID, supplierID uint) ([]entities.SupplierProduct, error)
+	DeleteSupplierProductFn       func(ctx context.Context, businessID, id uint) error
+	CreatePurchaseOrderFn         func(ctx context.Context, dto request.CreatePurchaseOrderDTO) (*entities.PurchaseOrder, error)
+	GetPurchaseOrderFn            func(ctx context.Context, businessID, id uint) (*entities.PurchaseOrder, error)
+	ListPurchaseOrdersFn          func(ctx context.Context, params dtos.ListPurchaseOrdersParams) ([]entities.PurchaseOrder, int64, error)
+	UpdatePurchaseOrderFn         func(ctx context.Context, dto request.UpdatePurchaseOrderDTO) (*entities.PurchaseOrder, error)
+	ApprovePurchaseOrderFn        func(ctx context.Context, dto request.PurchaseOrderActionDTO) (*entities.PurchaseOrder, error)
+	CancelPurchaseOrderFn         func(ctx context.Context, dto request.PurchaseOrderActionDTO) (*entities.PurchaseOrder, error)
+	ClosePurchaseOrderFn          func(ctx context.Context, dto request.PurchaseOrderActionDTO) (*entities.PurchaseOrder, error)
+	ReceivePurchaseOrderFn        func(ctx context.Context, dto request.ReceivePurchaseOrderDTO) (*response.PurchaseReceiptResult, error)
+	ListPurchaseOrderReceiptsFn   func(ctx context.Context, businessID, poID uint) ([]entities.PurchaseOrderReceipt, error)
+	AddLandedCostFn               func(ctx context.Context, dto request.AddLandedCostDTO) (*entities.PurchaseOrder, error)
+	ListLandedCostsFn             func(ctx context.Context, businessID, poID uint) ([]entities.PurchaseOrderLandedCost, error)
+	GenerateDraftPurchaseOrdersFn func(ctx context.Context, dto request.GenerateDraftPurchaseOrdersDTO) (*response.DraftPurchaseOrdersResult, error)
+
+	GetValuationSettingFn func(ctx context.Context, businessID uint) (*entities.ValuationSetting, error)
+	SetValuationMethodFn  func(ctx context.Context, dto request.SetValuationMethodDTO) (*entities.ValuationSetting, error)
+	SetOpeningCostFn      func(ctx context.Context, dto request.SetOpeningCostDTO) (*entities.CostBalance, error)
+	GetProductCostFn      func(ctx context.Context, businessID uint, productID string) (*response.ProductCostResult, error)
+	GetValuationReportFn  func(ctx context.Context, dto request.ValuationReportDTO) (*response.ValuationReportResult, error)
+	GetOrderCOGSFn        func(ctx context.Context, businessID uint, orderID string) (*response.OrderCOGSResult, error)
+
+	GetKitFn                   func(ctx context.Context, businessID uint, productID string) (*entities.Kit, error)
+	ListKitsFn                 func(ctx context.Context, params dtos.ListKitsParams) ([]entities.Kit, int64, error)
+	SaveKitFn                  func(ctx context.Context, dto request.SaveKitDTO) (*entities.Kit, error)
+	DeleteKitFn                func(ctx context.Context, businessID uint, productID string) error
+	GetKitAvailabilityFn       func(ctx context.Context, businessID uint, productID string, warehouseID *uint) (*response.KitAvailabilityResult, error)
+	CreateKitAssemblyOrderFn   func(ctx context.Context, dto request.CreateKitAssemblyOrderDTO) (*entities.KitAssemblyOrder, error)
+	GetKitAssemblyOrderFn      func(ctx context.Context, businessID, id uint) (*entities.KitAssemblyOrder, error)
+	ListKitAssemblyOrdersFn    func(ctx context.Context, params dtos.ListKitAssemblyOrdersParams) ([]entities.KitAssemblyOrder, int64, error)
+	CompleteKitAssemblyOrderFn func(ctx context.Context, dto request.KitAssemblyActionDTO) (*entities.KitAssemblyOrder, error)
+	CancelKitAssemblyOrderFn   func(ctx context.Context, dto request.KitAssemblyActionDTO) (*entities.KitAssemblyOrder, error)
+
+	CreatePickWaveFn  func(ctx context.Context, dto request.CreatePickWaveDTO) (*entities.PickWave, error)
+	GetPickWaveFn     func(ctx context.Context, businessID, id uint) (*entities.PickWave, error)
+	ListPickWavesFn   func(ctx context.Context, params dtos.ListPickWavesParams) ([]entities.PickWave, int64, error)
+	CancelPickWaveFn  func(ctx context.Context, dto request.PickWaveActionDTO) (*entities.PickWave, error)
+	ConfirmPickLineFn func(ctx context.Context, dto request.ConfirmPickLineDTO) (*entities.PickWave, error)
+	ShortPickLineFn   func(ctx context.Context, dto request.ShortPickLineDTO) (*entities.PickWave, error)
+	PackScanFn        func(ctx context.Context, dto request.PackScanDTO) (*entities.PickWaveOrder, error)
+	ClosePackageFn    func(ctx context.Context, dto request.ClosePackageDTO) (*entities.PickWaveOrder, error)
 }
 
 func (m *UseCaseMock) ValidateCubing(ctx context.Context, dto request.ValidateCubingDTO) (*response.CubingCheckResult, error) {
@@ -218,3 +266,10 @@ func (m *UseCaseMock) ReturnStockForOrder(ctx context.Context, orderID string, b
 	}
 	return &response.OrderStockResult{OrderID: orderID, Success: true}, nil
 }
+
+func (m *UseCaseMock) ReceiveReturn(ctx context.Context, dto request.ReceiveReturnDTO) (*response.OrderStockResult, error) {
+	if m.ReceiveReturnFn != nil {
+		return m.ReceiveReturnFn(ctx, dto)
+	}
+	return &response.OrderStockResult{OrderID: dto.OrderID, BusinessID: dto.BusinessID, Success: true}, nil
+}
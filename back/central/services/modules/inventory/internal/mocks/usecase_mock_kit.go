@@ -0,0 +1,80 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/response"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+)
+
+func (m *UseCaseMock) GetKit(ctx context.Context, businessID uint, productID string) (*entities.Kit, error) {
+	if m.GetKitFn != nil {
+		return m.GetKitFn(ctx, businessID, productID)
+	}
+	return nil, nil
+}
+
+func (m *UseCaseMock) ListKits(ctx context.Context, params dtos.ListKitsParams) ([]entities.Kit, int64, error) {
+	if m.ListKitsFn != nil {
+		return m.ListKitsFn(ctx, params)
+	}
+	return []entities.Kit{}, 0, nil
+}
+
+func (m *UseCaseMock) SaveKit(ctx context.Context, dto request.SaveKitDTO) (*entities.Kit, error) {
+	if m.SaveKitFn != nil {
+		return m.SaveKitFn(ctx, dto)
+	}
+	return &entities.Kit{BusinessID: dto.BusinessID, ProductID: dto.ProductID, Mode: dto.Mode}, nil
+}
+
+func (m *UseCaseMock) DeleteKit(ctx context.Context, businessID uint, productID string) error {
+	if m.DeleteKitFn != nil {
+		return m.DeleteKitFn(ctx, businessID, productID)
+	}
+	return nil
+}
+
+func (m *UseCaseMock) GetKitAvailability(ctx context.Context, businessID uint, productID string, warehouseID *uint) (*response.KitAvailabilityResult, error) {
+	if m.GetKitAvailabilityFn != nil {
+		return m.GetKitAvailabilityFn(ctx, businessID, productID, warehouseID)
+	}
+	return &response.KitAvailabilityResult{ProductID: productID, WarehouseID: warehouseID}, nil
+}
+
+func (m *UseCaseMock) CreateKitAssemblyOrder(ctx context.Context, dto request.CreateKitAssemblyOrderDTO) (*entities.KitAssemblyOrder, error) {
+	if m.CreateKitAssemblyOrderFn != nil {
+		return m.CreateKitAssemblyOrderFn(ctx, dto)
+	}
+	return &entities.KitAssemblyOrder{BusinessID: dto.BusinessID, Type: dto.Type, KitProductID: dto.KitProductID, Quantity: dto.Quantity}, nil
+}
+
+func (m *UseCaseMock) GetKitAssemblyOrder(ctx context.Context, businessID, id uint) (*entities.KitAssemblyOrder, error) {
+	if m.GetKitAssemblyOrderFn != nil {
+		return m.GetKitAssemblyOrderFn(ctx, businessID, id)
+	}
+	return nil, nil
+}
+
+func (m *UseCaseMock) ListKitAssemblyOrders(ctx context.Context, params dtos.ListKitAssemblyOrdersParams) ([]entities.KitAssemblyOrder, int64, error) {
+	if m.ListKitAssemblyOrdersFn != nil {
+		return m.ListKitAssemblyOrdersFn(ctx, params)
+	}
+	return []entities.KitAssemblyOrder{}, 0, nil
+}
+
+func (m *UseCaseMock) CompleteKitAssemblyOrder(ctx context.Context, dto request.KitAssemblyActionDTO) (*entities.KitAssemblyOrder, error) {
+	if m.CompleteKitAssemblyOrderFn != nil {
+		return m.CompleteKitAssemblyOrderFn(ctx, dto)
+	}
+	return &entities.KitAssemblyOrder{ID: dto.ID, BusinessID: dto.BusinessID}, nil
+}
+
+func (m *UseCaseMock) CancelKitAssemblyOrder(ctx context.Context, dto request.KitAssemblyActionDTO) (*entities.KitAssemblyOrder, error) {
+	if m.CancelKitAssemblyOrderFn != nil {
+		return m.CancelKitAssemblyOrderFn(ctx, dto)
+	}
+	return &entities.KitAssemblyOrder{ID: dto.ID, BusinessID: dto.BusinessID}, nil
+}
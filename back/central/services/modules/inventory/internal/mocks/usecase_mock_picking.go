@@ -0,0 +1,65 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+)
+
+func (m *UseCaseMock) CreatePickWave(ctx context.Context, dto request.CreatePickWaveDTO) (*entities.PickWave, error) {
+	if m.CreatePickWaveFn != nil {
+		return m.CreatePickWaveFn(ctx, dto)
+	}
+	return &entities.PickWave{BusinessID: dto.BusinessID, WarehouseID: dto.WarehouseID, Status: entities.PickWaveReleased}, nil
+}
+
+func (m *UseCaseMock) GetPickWave(ctx context.Context, businessID, id uint) (*entities.PickWave, error) {
+	if m.GetPickWaveFn != nil {
+		return m.GetPickWaveFn(ctx, businessID, id)
+	}
+	return nil, nil
+}
+
+func (m *UseCaseMock) ListPickWaves(ctx context.Context, params dtos.ListPickWavesParams) ([]entities.PickWave, int64, error) {
+	if m.ListPickWavesFn != nil {
+		return m.ListPickWavesFn(ctx, params)
+	}
+	return []entities.PickWave{}, 0, nil
+}
+
+func (m *UseCaseMock) CancelPickWave(ctx context.Context, dto request.PickWaveActionDTO) (*entities.PickWave, error) {
+	if m.CancelPickWaveFn != nil {
+		return m.CancelPickWaveFn(ctx, dto)
+	}
+	return &entities.PickWave{ID: dto.ID, BusinessID: dto.BusinessID, Status: entities.PickWaveCancelled}, nil
+}
+
+func (m *UseCaseMock) ConfirmPickLine(ctx context.Context, dto request.ConfirmPickLineDTO) (*entities.PickWave, error) {
+	if m.ConfirmPickLineFn != nil {
+		return m.ConfirmPickLineFn(ctx, dto)
+	}
+	return &entities.PickWave{ID: dto.WaveID, BusinessID: dto.BusinessID}, nil
+}
+
+func (m *UseCaseMock) ShortPickLine(ctx context.Context, dto request.ShortPickLineDTO) (*entities.PickWave, error) {
+	if m.ShortPickLineFn != nil {
+		return m.ShortPickLineFn(ctx, dto)
+	}
+	return &entities.PickWave{ID: dto.WaveID, BusinessID: dto.BusinessID}, nil
+}
+
+func (m *UseCaseMock) PackScan(ctx context.Context, dto request.PackScanDTO) (*entities.PickWaveOrder, error) {
+	if m.PackScanFn != nil {
+		return m.PackScanFn(ctx, dto)
+	}
+	return &entities.PickWaveOrder{WaveID: dto.WaveID, OrderID: dto.OrderID}, nil
+}
+
+func (m *UseCaseMock) ClosePackage(ctx context.Context, dto request.ClosePackageDTO) (*entities.PickWaveOrder, error) {
+	if m.ClosePackageFn != nil {
+		return m.ClosePackageFn(ctx, dto)
+	}
+	return &entities.PickWaveOrder{WaveID: dto.WaveID, OrderID: dto.OrderID, Status: entities.PickOrderPacked}, nil
+}
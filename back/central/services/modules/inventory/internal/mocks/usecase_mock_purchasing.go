@@ -0,0 +1,143 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/response"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+)
+
+func (m *UseCaseMock) CreateSupplier(ctx context.Context, dto request.CreateSupplierDTO) (*entities.Supplier, error) {
+	if m.CreateSupplierFn != nil {
+		return m.CreateSupplierFn(ctx, dto)
+	}
+	return &entities.Supplier{}, nil
+}
+
+func (m *UseCaseMock) GetSupplier(ctx context.Context, businessID, id uint) (*entities.Supplier, error) {
+	if m.GetSupplierFn != nil {
+		return m.GetSupplierFn(ctx, businessID, id)
+	}
+	return &entities.Supplier{ID: id, BusinessID: businessID}, nil
+}
+
+func (m *UseCaseMock) ListSuppliers(ctx context.Context, params dtos.ListSuppliersParams) ([]entities.Supplier, int64, error) {
+	if m.ListSuppliersFn != nil {
+		return m.ListSuppliersFn(ctx, params)
+	}
+	return []entities.Supplier{}, 0, nil
+}
+
+func (m *UseCaseMock) UpdateSupplier(ctx context.Context, dto request.UpdateSupplierDTO) (*entities.Supplier, error) {
+	if m.UpdateSupplierFn != nil {
+		return m.UpdateSupplierFn(ctx, dto)
+	}
+	return &entities.Supplier{}, nil
+}
+
+func (m *UseCaseMock) SetSupplierProduct(ctx context.Context, dto request.SetSupplierProductDTO) (*entities.SupplierProduct, error) {
+	if m.SetSupplierProductFn != nil {
+		return m.SetSupplierProductFn(ctx, dto)
+	}
+	return &entities.SupplierProduct{}, nil
+}
+
+func (m *UseCaseMock) ListSupplierProducts(ctx context.Context, businessID, supplierID uint) ([]entities.SupplierProduct, error) {
+	if m.ListSupplierProductsFn != nil {
+		return m.ListSupplierProductsFn(ctx, businessID, supplierID)
+	}
+	return []entities.SupplierProduct{}, nil
+}
+
+func (m *UseCaseMock) DeleteSupplierProduct(ctx context.Context, businessID, id uint) error {
+	if m.DeleteSupplierProductFn != nil {
+		return m.DeleteSupplierProductFn(ctx, businessID, id)
+	}
+	return nil
+}
+
+func (m *UseCaseMock) CreatePurchaseOrder(ctx context.Context, dto request.CreatePurchaseOrderDTO) (*entities.PurchaseOrder, error) {
+	if m.CreatePurchaseOrderFn != nil {
+		return m.CreatePurchaseOrderFn(ctx, dto)
+	}
+	return &entities.PurchaseOrder{}, nil
+}
+
+func (m *UseCaseMock) GetPurchaseOrder(ctx context.Context, businessID, id uint) (*entities.PurchaseOrder, error) {
+	if m.GetPurchaseOrderFn != nil {
+		return m.GetPurchaseOrderFn(ctx, businessID, id)
+	}
+	return &entities.PurchaseOrder{ID: id, BusinessID: businessID}, nil
+}
+
+func (m *UseCaseMock) ListPurchaseOrders(ctx context.Context, params dtos.ListPurchaseOrdersParams) ([]entities.PurchaseOrder, int64, error) {
+	if m.ListPurchaseOrdersFn != nil {
+		return m.ListPurchaseOrdersFn(ctx, params)
+	}
+	return []entities.PurchaseOrder{}, 0, nil
+}
+
+func (m *UseCaseMock) UpdatePurchaseOrder(ctx context.Context, dto request.UpdatePurchaseOrderDTO) (*entities.PurchaseOrder, error) {
+	if m.UpdatePurchaseOrderFn != nil {
+		return m.UpdatePurchaseOrderFn(ctx, dto)
+	}
+	return &entities.PurchaseOrder{}, nil
+}
+
+func (m *UseCaseMock) ApprovePurchaseOrder(ctx context.Context, dto request.PurchaseOrderActionDTO) (*entities.PurchaseOrder, error) {
+	if m.ApprovePurchaseOrderFn != nil {
+		return m.ApprovePurchaseOrderFn(ctx, dto)
+	}
+	return &entities.PurchaseOrder{}, nil
+}
+
+func (m *UseCaseMock) CancelPurchaseOrder(ctx context.Context, dto request.PurchaseOrderActionDTO) (*entities.PurchaseOrder, error) {
+	if m.CancelPurchaseOrderFn != nil {
+		return m.CancelPurchaseOrderFn(ctx, dto)
+	}
+	return &entities.PurchaseOrder{}, nil
+}
+
+func (m *UseCaseMock) ClosePurchaseOrder(ctx context.Context, dto request.PurchaseOrderActionDTO) (*entities.PurchaseOrder, error) {
+	if m.ClosePurchaseOrderFn != nil {
+		return m.ClosePurchaseOrderFn(ctx, dto)
+	}
+	return &entities.PurchaseOrder{}, nil
+}
+
+func (m *UseCaseMock) ReceivePurchaseOrder(ctx context.Context, dto request.ReceivePurchaseOrderDTO) (*response.PurchaseReceiptResult, error) {
+	if m.ReceivePurchaseOrderFn != nil {
+		return m.ReceivePurchaseOrderFn(ctx, dto)
+	}
+	return &response.PurchaseReceiptResult{}, nil
+}
+
+func (m *UseCaseMock) ListPurchaseOrderReceipts(ctx context.Context, businessID, poID uint) ([]entities.PurchaseOrderReceipt, error) {
+	if m.ListPurchaseOrderReceiptsFn != nil {
+		return m.ListPurchaseOrderReceiptsFn(ctx, businessID, poID)
+	}
+	return []entities.PurchaseOrderReceipt{}, nil
+}
+
+func (m *UseCaseMock) AddLandedCost(ctx context.Context, dto request.AddLandedCostDTO) (*entities.PurchaseOrder, error) {
+	if m.AddLandedCostFn != nil {
+		return m.AddLandedCostFn(ctx, dto)
+	}
+	return &entities.PurchaseOrder{}, nil
+}
+
+func (m *UseCaseMock) ListLandedCosts(ctx context.Context, businessID, poID uint) ([]entities.PurchaseOrderLandedCost, error) {
+	if m.ListLandedCostsFn != nil {
+		return m.ListLandedCostsFn(ctx, businessID, poID)
+	}
+	return []entities.PurchaseOrderLandedCost{}, nil
+}
+
+func (m *UseCaseMock) GenerateDraftPurchaseOrders(ctx context.Context, dto request.GenerateDraftPurchaseOrdersDTO) (*response.DraftPurchaseOrdersResult, error) {
+	if m.GenerateDraftPurchaseOrdersFn != nil {
+		return m.GenerateDraftPurchaseOrdersFn(ctx, dto)
+	}
+	return &response.DraftPurchaseOrdersResult{}, nil
+}
@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/request"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/app/response"
+	"github.com/secamc93/probability/back/central/services/modules/inventory/internal/domain/entities"
+)
+
+func (m *UseCaseMock) GetValuationSetting(ctx context.Context, businessID uint) (*entities.ValuationSetting, error) {
+	if m.GetValuationSettingFn != nil {
+		return m.GetValuationSettingFn(ctx, businessID)
+	}
+	return &entities.ValuationSetting{BusinessID: businessID, Method: entities.ValuationWeightedAverage}, nil
+}
+
+func (m *UseCaseMock) SetValuationMethod(ctx context.Context, dto request.SetValuationMethodDTO) (*entities.ValuationSetting, error) {
+	if m.SetValuationMethodFn != nil {
+		return m.SetValuationMethodFn(ctx, dto)
+	}
+	return &entities.ValuationSetting{BusinessID: dto.BusinessID, Method: dto.Method}, nil
+}
+
+func (m *UseCaseMock) SetOpeningCost(ctx context.Context, dto request.SetOpeningCostDTO) (*entities.CostBalance, error) {
+	if m.SetOpeningCostFn != nil {
+		return m.SetOpeningCostFn(ctx, dto)
+	}
+	return &entities.CostBalance{BusinessID: dto.BusinessID, ProductID: dto.ProductID, AverageCost: dto.UnitCost}, nil
+}
+
+func (m *UseCaseMock) GetProductCost(ctx context.Context, businessID uint, productID string) (*response.ProductCostResult, error) {
+	if m.GetProductCostFn != nil {
+		return m.GetProductCostFn(ctx, businessID, productID)
+	}
+	return &response.ProductCostResult{}, nil
+}
+
+func (m *UseCaseMock) GetValuationReport(ctx context.Context, dto request.ValuationReportDTO) (*response.ValuationReportResult, error) {
+	if m.GetValuationReportFn != nil {
+		return m.GetValuationReportFn(ctx, dto)
+	}
+	return &response.ValuationReportResult{}, nil
+}
+
+func (m *UseCaseMock) GetOrderCOGS(ctx context.Context, businessID uint, orderID string) (*response.OrderCOGSResult, error) {
+	if m.GetOrderCOGSFn != nil {
+		return m.GetOrderCOGSFn(ctx, businessID, orderID)
+	}
+	return &response.OrderCOGSResult{OrderID: orderID}, nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/secamc93/probability/back/central/services/modules/invoicing/internal/app"
+	"github.com/secamc93/probability/back/central/services/modules/invoicing/internal/domain/ports"
 	"github.com/secamc93/probability/back/central/services/modules/invoicing/internal/infra/primary/handlers"
 	"github.com/secamc93/probability/back/central/services/modules/invoicing/internal/infra/primary/queue/consumer"
 	"github.com/secamc93/probability/back/central/services/modules/invoicing/internal/infra/secondary/queue"
@@ -17,6 +18,12 @@ import (
 	"github.com/secamc93/probability/back/central/shared/redis"
 )
 
+// Bundle expone a otros modulos operaciones de facturacion (ver gateway.go)
+type Bundle struct {
+	useCase ports.IUseCase
+	repo    ports.IRepository
+}
+
 // New inicializa el módulo de facturación
 func New(
 	router *gin.RouterGroup,
@@ -26,7 +33,7 @@ func New(
 	rabbitMQ rabbitmq.IQueue,
 	redisClient redis.IRedis,
 	moduleAccessMW gin.HandlerFunc,
-) {
+) *Bundle {
 	ctx := context.Background()
 	moduleLogger := logger.WithModule("invoicing")
 
@@ -139,4 +146,6 @@ func New(
 	} else {
 		moduleLogger.Warn(ctx).Msg("RabbitMQ no disponible - consumers de facturación deshabilitados")
 	}
+
+	return &Bundle{useCase: useCase, repo: repo}
 }
@@ -0,0 +1,62 @@
+package invoicing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/central/services/modules/invoicing/internal/domain/constants"
+	"github.com/secamc93/probability/back/central/services/modules/invoicing/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/invoicing/internal/domain/errors"
+)
+
+// OrderCreditNoteRequest pide una nota credito sobre la factura de una orden.
+// Con Amount en cero la nota cubre toda la factura.
+type OrderCreditNoteRequest struct {
+	BusinessID uint
+	OrderID    string
+	Amount     float64
+	Reason     string
+	UserID     uint
+}
+
+// OrderCreditNote es la nota credito creada; el proveedor la emite despues
+type OrderCreditNote struct {
+	ID             uint
+	InvoiceID      uint
+	InternalNumber string
+	Amount         float64
+	Status         string
+}
+
+// CreateCreditNoteForOrder busca la factura de la orden y le crea una nota
+// credito con CreateCreditNote. Falla si la orden no tiene factura del negocio.
+func (b *Bundle) CreateCreditNoteForOrder(ctx context.Context, req OrderCreditNoteRequest) (*OrderCreditNote, error) {
+	invoice, err := b.repo.GetInvoiceByOrderID(ctx, req.OrderID)
+	if err != nil || invoice == nil || invoice.BusinessID != req.BusinessID {
+		return nil, fmt.Errorf("orden %s: %w", req.OrderID, errors.ErrInvoiceNotFound)
+	}
+
+	noteType := constants.CreditNoteTypeFullRefund
+	if req.Amount > 0 && req.Amount < invoice.TotalAmount {
+		noteType = constants.CreditNoteTypePartialRefund
+	}
+
+	note, err := b.useCase.CreateCreditNote(ctx, &dtos.CreateCreditNoteDTO{
+		InvoiceID:       invoice.ID,
+		NoteType:        noteType,
+		Amount:          req.Amount,
+		Reason:          req.Reason,
+		CreatedByUserID: req.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &OrderCreditNote{
+		ID:             note.ID,
+		InvoiceID:      note.InvoiceID,
+		InternalNumber: note.InternalNumber,
+		Amount:         note.Amount,
+		Status:         note.Status,
+	}, nil
+}
@@ -92,6 +92,16 @@ func (uc *useCase) CreateCreditNote(ctx context.Context, dto *dtos.CreateCreditN
 		"reason":         dto.Reason,
 		"note_type":      noteType,
 		"customer_dni":   invoice.CustomerDNI,
+		"customer_name":  invoice.CustomerName,
+		"customer_email": invoice.CustomerEmail,
+	}
+	// Referencia a la factura original: DIAN directo la necesita cuando no tiene
+	// el XML firmado (facturas emitidas antes de habilitar el proveedor)
+	if invoice.CUFE != nil {
+		creditNoteConfig["invoice_cufe"] = *invoice.CUFE
+	}
+	if invoice.IssuedAt != nil {
+		creditNoteConfig["invoice_issued_at"] = invoice.IssuedAt.Format(time.RFC3339)
 	}
 
 	config, configErr := uc.repo.GetConfigByIntegration(ctx, integrationID)
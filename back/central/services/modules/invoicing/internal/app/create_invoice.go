@@ -428,7 +428,7 @@ func (uc *useCase) parseFilterConfig(filtersMap map[string]interface{}) (*entiti
 
 // resolveProvider determina el proveedor de facturación según el tipo de integración
 // Consulta la tabla integrations para obtener el integration_type_id y mapea al proveedor
-// Mapa: 5 (Softpymes) -> "softpymes", 6 (Factus) -> "factus"
+// Mapa: 5 (Softpymes) -> "softpymes", 7 (Factus) -> "factus", 8 (Siigo) -> "siigo", 36 (DIAN directo) -> "dian"
 // Default: "softpymes" para compatibilidad hacia atrás
 func (uc *useCase) resolveProvider(ctx context.Context, integrationID uint) (string, error) {
 	typeID, err := uc.repo.GetIntegrationTypeByIntegrationID(ctx, integrationID)
@@ -443,6 +443,8 @@ func (uc *useCase) resolveProvider(ctx context.Context, integrationID uint) (str
 		return dtos.ProviderFactus, nil
 	case 8: // IntegrationTypeSiigo
 		return dtos.ProviderSiigo, nil
+	case 36: // IntegrationTypeDian
+		return dtos.ProviderDian, nil
 	default:
 		uc.log.Warn(ctx).
 			Uint("integration_id", integrationID).
@@ -33,4 +33,5 @@ const (
 	ProviderSoftpymes = "softpymes"
 	ProviderSiigo     = "siigo"
 	ProviderFactus    = "factus"
+	ProviderDian      = "dian"
 )
@@ -40,7 +40,7 @@ func (c *OrderConsumer) Start(ctx context.Context) error {
 	}
 
 	// Iniciar consumo
-	if err := c.queue.Consume(ctx, QueueOrderEvents, c.handleOrderEvent); err != nil {
+	if err := rabbitmq.ConsumeDeliveries(ctx, c.queue, QueueOrderEvents, c.handleOrderEvent, 1); err != nil {
 		c.log.Error(ctx).Err(err).Msg("Error al iniciar consumer de facturación")
 		return fmt.Errorf("failed to start consuming: %w", err)
 	}
@@ -49,12 +49,12 @@ func (c *OrderConsumer) Start(ctx context.Context) error {
 }
 
 // handleOrderEvent procesa un evento de orden
-func (c *OrderConsumer) handleOrderEvent(message []byte) error {
-	ctx := context.Background()
+func (c *OrderConsumer) handleOrderEvent(msgCtx context.Context, d rabbitmq.Delivery) error {
+	ctx := context.WithoutCancel(msgCtx)
 
 	// Deserializar evento
 	var event request.OrderEvent
-	if err := json.Unmarshal(message, &event); err != nil {
+	if err := json.Unmarshal(d.Body, &event); err != nil {
 		c.log.Error(ctx).Err(err).Msg("Error al deserializar evento de orden")
 		return fmt.Errorf("failed to unmarshal event: %w", err)
 	}
@@ -25,4 +25,8 @@ func New(router *gin.RouterGroup, logger log.ILogger, environment env.IConfig, r
 
 	// 4. Registrar rutas
 	handler.RegisterRoutes(router)
+
+	// 5. Administracion de DLQ (listar, inspeccionar, reenviar, purgar)
+	deadLetters := app.NewDeadLetters(queue.NewDeadLetterStore(rabbitMQ), logger)
+	handlers.NewDeadLetters(deadLetters, logger).RegisterRoutes(router)
 }
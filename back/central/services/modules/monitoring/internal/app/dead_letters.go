@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/modules/monitoring/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/monitoring/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/monitoring/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+const (
+	defaultDeadLetterLimit = 20
+	maxDeadLetterLimit     = 200
+)
+
+type deadLetterUseCase struct {
+	store ports.IDeadLetterStore
+	log   log.ILogger
+}
+
+// NewDeadLetters crea el caso de uso de administracion de DLQ
+func NewDeadLetters(store ports.IDeadLetterStore, logger log.ILogger) ports.IDeadLetterUseCase {
+	return &deadLetterUseCase{
+		store: store,
+		log:   logger,
+	}
+}
+
+// clampLimit acota cuantos mensajes se leen de una DLQ: leerlos los saca de la
+// cola mientras dura la operacion, asi que no se deja leer una DLQ entera.
+func clampLimit(limit int) int {
+	if limit <= 0 {
+		return defaultDeadLetterLimit
+	}
+	if limit > maxDeadLetterLimit {
+		return maxDeadLetterLimit
+	}
+	return limit
+}
+
+func (uc *deadLetterUseCase) ListDeadLetterQueues(ctx context.Context) ([]entities.DeadLetterQueue, error) {
+	return uc.store.ListQueues(ctx)
+}
+
+func (uc *deadLetterUseCase) ListDeadLetters(ctx context.Context, queueName string, limit int) ([]entities.DeadLetterMessage, error) {
+	queueName = strings.TrimSpace(queueName)
+	if queueName == "" {
+		return nil, domainerrors.ErrQueueNameRequired
+	}
+	return uc.store.Peek(ctx, queueName, clampLimit(limit))
+}
+
+// GetDeadLetter busca un mensaje por su message_id dentro de la ventana maxima de lectura
+func (uc *deadLetterUseCase) GetDeadLetter(ctx context.Context, queueName string, messageID string) (*entities.DeadLetterMessage, error) {
+	messages, err := uc.ListDeadLetters(ctx, queueName, maxDeadLetterLimit)
+	if err != nil {
+		return nil, err
+	}
+	for i := range messages {
+		if messages[i].MessageID == messageID {
+			return &messages[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (uc *deadLetterUseCase) ReplayDeadLetters(ctx context.Context, queueName string, messageID string, limit int) (int, error) {
+	queueName = strings.TrimSpace(queueName)
+	if queueName == "" {
+		return 0, domainerrors.ErrQueueNameRequired
+	}
+
+	limit = clampLimit(limit)
+	if messageID != "" {
+		// Para un mensaje puntual se revisa la ventana completa
+		limit = maxDeadLetterLimit
+	}
+
+	replayed, err := uc.store.Replay(ctx, queueName, strings.TrimSpace(messageID), limit)
+	if err != nil {
+		uc.log.Error(ctx).
+			Err(err).
+			Str("queue", queueName).
+			Str("message_id", messageID).
+			Int("replayed", replayed).
+			Msg("[Monitoring] Error reenviando mensajes de la DLQ")
+		return replayed, err
+	}
+
+	uc.log.Info(ctx).
+		Str("queue", queueName).
+		Str("message_id", messageID).
+		Int("replayed", replayed).
+		Msg("[Monitoring] Mensajes de la DLQ reenviados a su cola")
+
+	return replayed, nil
+}
+
+func (uc *deadLetterUseCase) PurgeDeadLetters(ctx context.Context, queueName string) (int, error) {
+	queueName = strings.TrimSpace(queueName)
+	if queueName == "" {
+		return 0, domainerrors.ErrQueueNameRequired
+	}
+
+	purged, err := uc.store.Purge(ctx, queueName)
+	if err != nil {
+		return 0, err
+	}
+
+	uc.log.Warn(ctx).
+		Str("queue", queueName).
+		Int("purged", purged).
+		Msg("[Monitoring] DLQ purgada")
+
+	return purged, nil
+}
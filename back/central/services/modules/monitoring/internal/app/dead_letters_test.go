@@ -0,0 +1,83 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/secamc93/probability/back/central/services/modules/monitoring/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/monitoring/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/monitoring/internal/mocks"
+)
+
+func TestListDeadLetters_AcotaCuantosMensajesSeLeen(t *testing.T) {
+	casos := map[int]int{
+		0:    defaultDeadLetterLimit,
+		-5:   defaultDeadLetterLimit,
+		15:   15,
+		5000: maxDeadLetterLimit,
+	}
+
+	for pedido, esperado := range casos {
+		store := &mocks.DeadLetterStoreMock{}
+		uc := NewDeadLetters(store, mocks.NewSilentLogger())
+
+		_, err := uc.ListDeadLetters(context.Background(), "invoicing.softpymes.requests", pedido)
+
+		require.NoError(t, err)
+		assert.Equal(t, esperado, store.LastLimit,
+			"leer la DLQ saca los mensajes mientras dura la lectura: no se puede pedir la cola entera")
+	}
+}
+
+func TestListDeadLetters_SinColaNoLlegaAlBroker(t *testing.T) {
+	store := &mocks.DeadLetterStoreMock{}
+	uc := NewDeadLetters(store, mocks.NewSilentLogger())
+
+	_, err := uc.ListDeadLetters(context.Background(), "  ", 10)
+
+	assert.ErrorIs(t, err, domainerrors.ErrQueueNameRequired)
+	assert.Zero(t, store.LastLimit)
+}
+
+func TestGetDeadLetter_BuscaPorMessageID(t *testing.T) {
+	store := &mocks.DeadLetterStoreMock{
+		PeekFn: func(ctx context.Context, queueName string, limit int) ([]entities.DeadLetterMessage, error) {
+			return []entities.DeadLetterMessage{
+				{MessageID: "a", LastError: "timeout"},
+				{MessageID: "b", LastError: "payload invalido", Permanent: true},
+			}, nil
+		},
+	}
+	uc := NewDeadLetters(store, mocks.NewSilentLogger())
+
+	msg, err := uc.GetDeadLetter(context.Background(), "orders.events.invoicing", "b")
+	require.NoError(t, err)
+	require.NotNil(t, msg)
+	assert.True(t, msg.Permanent)
+
+	msg, err = uc.GetDeadLetter(context.Background(), "orders.events.invoicing", "zzz")
+	require.NoError(t, err)
+	assert.Nil(t, msg)
+}
+
+func TestReplayDeadLetters_UnMensajePuntualRevisaLaVentanaCompleta(t *testing.T) {
+	var idRecibido string
+	store := &mocks.DeadLetterStoreMock{
+		ReplayFn: func(ctx context.Context, queueName string, messageID string, limit int) (int, error) {
+			idRecibido = messageID
+			return 1, nil
+		},
+	}
+	uc := NewDeadLetters(store, mocks.NewSilentLogger())
+
+	n, err := uc.ReplayDeadLetters(context.Background(), "orders.events.invoicing", "msg-42", 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "msg-42", idRecibido)
+	assert.Equal(t, maxDeadLetterLimit, store.LastLimit,
+		"el mensaje buscado puede no estar al frente de la DLQ")
+}
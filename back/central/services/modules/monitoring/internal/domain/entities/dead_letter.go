@@ -0,0 +1,21 @@
+package entities
+
+// DeadLetterQueue resume cuantos mensajes tiene la DLQ de una cola
+type DeadLetterQueue struct {
+	Queue           string
+	DeadLetterQueue string
+	Messages        int
+}
+
+// DeadLetterMessage es un mensaje que agoto sus reintentos o fallo de forma permanente
+type DeadLetterMessage struct {
+	MessageID     string
+	OriginalQueue string
+	RetryCount    int
+	Permanent     bool
+	LastError     string
+	FailedAt      string
+	ContentType   string
+	Headers       map[string]interface{}
+	Body          []byte
+}
@@ -6,3 +6,8 @@ var (
 	ErrInvalidSignature = errors.New("webhook signature inválida")
 	ErrEmptyAlerts      = errors.New("payload sin alertas")
 )
+
+var (
+	ErrDeadLettersUnavailable = errors.New("administracion de DLQ no disponible: RabbitMQ no soporta la operacion o esta caido")
+	ErrQueueNameRequired      = errors.New("el nombre de la cola es requerido")
+)
@@ -16,3 +16,20 @@ type IAlertPublisher interface {
 type IUseCase interface {
 	ProcessGrafanaAlert(ctx context.Context, dto dtos.GrafanaWebhookDTO) error
 }
+
+// IDeadLetterStore define el acceso a las DLQ del broker
+type IDeadLetterStore interface {
+	ListQueues(ctx context.Context) ([]entities.DeadLetterQueue, error)
+	Peek(ctx context.Context, queueName string, limit int) ([]entities.DeadLetterMessage, error)
+	Replay(ctx context.Context, queueName string, messageID string, limit int) (int, error)
+	Purge(ctx context.Context, queueName string) (int, error)
+}
+
+// IDeadLetterUseCase define el contrato de la administracion de DLQ
+type IDeadLetterUseCase interface {
+	ListDeadLetterQueues(ctx context.Context) ([]entities.DeadLetterQueue, error)
+	ListDeadLetters(ctx context.Context, queueName string, limit int) ([]entities.DeadLetterMessage, error)
+	GetDeadLetter(ctx context.Context, queueName string, messageID string) (*entities.DeadLetterMessage, error)
+	ReplayDeadLetters(ctx context.Context, queueName string, messageID string, limit int) (int, error)
+	PurgeDeadLetters(ctx context.Context, queueName string) (int, error)
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/monitoring/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/monitoring/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/services/modules/monitoring/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/modules/monitoring/internal/infra/primary/handlers/response"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+// IDeadLetterHandler define los endpoints de administracion de DLQ
+type IDeadLetterHandler interface {
+	ListQueues(c *gin.Context)
+	ListMessages(c *gin.Context)
+	GetMessage(c *gin.Context)
+	Replay(c *gin.Context)
+	Purge(c *gin.Context)
+	RegisterRoutes(router *gin.RouterGroup)
+}
+
+type deadLetterHandler struct {
+	useCase ports.IDeadLetterUseCase
+	log     log.ILogger
+}
+
+// NewDeadLetters crea el handler de administracion de DLQ
+func NewDeadLetters(useCase ports.IDeadLetterUseCase, logger log.ILogger) IDeadLetterHandler {
+	return &deadLetterHandler{
+		useCase: useCase,
+		log:     logger,
+	}
+}
+
+// RegisterRoutes registra las rutas de DLQ (solo super admin)
+func (h *deadLetterHandler) RegisterRoutes(router *gin.RouterGroup) {
+	dlq := router.Group("/monitoring/dlq", middleware.JWT(), middleware.RequireSuperAdmin())
+	dlq.GET("", h.ListQueues)
+	dlq.GET("/:queue/messages", h.ListMessages)
+	dlq.GET("/:queue/messages/:message_id", h.GetMessage)
+	dlq.POST("/:queue/replay", h.Replay)
+	dlq.DELETE("/:queue", h.Purge)
+}
+
+func deadLetterStatus(err error) int {
+	switch {
+	case errors.Is(err, domainerrors.ErrQueueNameRequired):
+		return http.StatusBadRequest
+	case errors.Is(err, domainerrors.ErrDeadLettersUnavailable):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// ListQueues lista las colas con DLQ y cuantos mensajes tiene cada una
+func (h *deadLetterHandler) ListQueues(c *gin.Context) {
+	queues, err := h.useCase.ListDeadLetterQueues(c.Request.Context())
+	if err != nil {
+		c.JSON(deadLetterStatus(err), gin.H{"success": false, "message": "Error al listar las DLQ", "error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": response.FromDeadLetterQueues(queues)})
+}
+
+// ListMessages muestra los primeros mensajes de la DLQ sin consumirlos
+func (h *deadLetterHandler) ListMessages(c *gin.Context) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	msgs, err := h.useCase.ListDeadLetters(c.Request.Context(), c.Param("queue"), limit)
+	if err != nil {
+		c.JSON(deadLetterStatus(err), gin.H{"success": false, "message": "Error al leer la DLQ", "error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": response.FromDeadLetterMessages(msgs)})
+}
+
+// GetMessage muestra un mensaje puntual de la DLQ por su message_id
+func (h *deadLetterHandler) GetMessage(c *gin.Context) {
+	msg, err := h.useCase.GetDeadLetter(c.Request.Context(), c.Param("queue"), c.Param("message_id"))
+	if err != nil {
+		c.JSON(deadLetterStatus(err), gin.H{"success": false, "message": "Error al leer la DLQ", "error": err.Error()})
+		return
+	}
+	if msg == nil {
+		c.JSON(http.StatusNotFound, gin.H{"success": false, "message": "Mensaje no encontrado en la DLQ"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": response.FromDeadLetterMessage(*msg)})
+}
+
+// Replay devuelve mensajes de la DLQ a su cola original
+func (h *deadLetterHandler) Replay(c *gin.Context) {
+	var req request.ReplayDeadLettersRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Cuerpo de la solicitud invalido"})
+			return
+		}
+	}
+
+	replayed, err := h.useCase.ReplayDeadLetters(c.Request.Context(), c.Param("queue"), req.MessageID, req.Limit)
+	if err != nil {
+		c.JSON(deadLetterStatus(err), gin.H{
+			"success": false,
+			"message": "Error al reenviar mensajes de la DLQ",
+			"error":   err.Error(),
+			"data":    gin.H{"replayed": replayed},
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": gin.H{"replayed": replayed}})
+}
+
+// Purge descarta todos los mensajes de la DLQ de una cola
+func (h *deadLetterHandler) Purge(c *gin.Context) {
+	purged, err := h.useCase.PurgeDeadLetters(c.Request.Context(), c.Param("queue"))
+	if err != nil {
+		c.JSON(deadLetterStatus(err), gin.H{"success": false, "message": "Error al purgar la DLQ", "error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": gin.H{"purged": purged}})
+}
@@ -0,0 +1,7 @@
+package request
+
+// ReplayDeadLettersRequest es el cuerpo opcional del reenvio de mensajes de la DLQ
+type ReplayDeadLettersRequest struct {
+	MessageID string `json:"message_id"`
+	Limit     int    `json:"limit"`
+}
@@ -0,0 +1,68 @@
+package response
+
+import (
+	"unicode/utf8"
+
+	"github.com/secamc93/probability/back/central/services/modules/monitoring/internal/domain/entities"
+)
+
+// DeadLetterQueueResponse es una DLQ en la respuesta HTTP
+type DeadLetterQueueResponse struct {
+	Queue           string `json:"queue"`
+	DeadLetterQueue string `json:"dead_letter_queue"`
+	Messages        int    `json:"messages"`
+}
+
+// DeadLetterMessageResponse es un mensaje de la DLQ en la respuesta HTTP.
+// El body se devuelve como texto cuando es UTF-8 valido (el caso de JSON).
+type DeadLetterMessageResponse struct {
+	MessageID     string                 `json:"message_id"`
+	OriginalQueue string                 `json:"original_queue"`
+	RetryCount    int                    `json:"retry_count"`
+	Permanent     bool                   `json:"permanent"`
+	LastError     string                 `json:"last_error"`
+	FailedAt      string                 `json:"failed_at"`
+	ContentType   string                 `json:"content_type"`
+	Headers       map[string]interface{} `json:"headers"`
+	Body          string                 `json:"body,omitempty"`
+	BodyBytes     []byte                 `json:"body_bytes,omitempty"`
+}
+
+func FromDeadLetterQueues(queues []entities.DeadLetterQueue) []DeadLetterQueueResponse {
+	out := make([]DeadLetterQueueResponse, len(queues))
+	for i := range queues {
+		out[i] = DeadLetterQueueResponse{
+			Queue:           queues[i].Queue,
+			DeadLetterQueue: queues[i].DeadLetterQueue,
+			Messages:        queues[i].Messages,
+		}
+	}
+	return out
+}
+
+func FromDeadLetterMessage(msg entities.DeadLetterMessage) DeadLetterMessageResponse {
+	out := DeadLetterMessageResponse{
+		MessageID:     msg.MessageID,
+		OriginalQueue: msg.OriginalQueue,
+		RetryCount:    msg.RetryCount,
+		Permanent:     msg.Permanent,
+		LastError:     msg.LastError,
+		FailedAt:      msg.FailedAt,
+		ContentType:   msg.ContentType,
+		Headers:       msg.Headers,
+	}
+	if utf8.Valid(msg.Body) {
+		out.Body = string(msg.Body)
+	} else {
+		out.BodyBytes = msg.Body
+	}
+	return out
+}
+
+func FromDeadLetterMessages(msgs []entities.DeadLetterMessage) []DeadLetterMessageResponse {
+	out := make([]DeadLetterMessageResponse, len(msgs))
+	for i := range msgs {
+		out[i] = FromDeadLetterMessage(msgs[i])
+	}
+	return out
+}
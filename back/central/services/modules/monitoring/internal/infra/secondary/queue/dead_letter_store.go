@@ -0,0 +1,78 @@
+package queue
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/monitoring/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/monitoring/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/monitoring/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/shared/rabbitmq"
+)
+
+type deadLetterStore struct {
+	admin rabbitmq.IDeadLetterAdmin
+}
+
+// NewDeadLetterStore adapta la administracion de DLQ de shared/rabbitmq al puerto del modulo
+func NewDeadLetterStore(queue rabbitmq.IQueue) ports.IDeadLetterStore {
+	admin, _ := rabbitmq.AsDeadLetterAdmin(queue)
+	return &deadLetterStore{admin: admin}
+}
+
+func (s *deadLetterStore) ListQueues(ctx context.Context) ([]entities.DeadLetterQueue, error) {
+	if s.admin == nil {
+		return nil, domainerrors.ErrDeadLettersUnavailable
+	}
+	stats, err := s.admin.ListDeadLetterQueues(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]entities.DeadLetterQueue, len(stats))
+	for i := range stats {
+		out[i] = entities.DeadLetterQueue{
+			Queue:           stats[i].Queue,
+			DeadLetterQueue: stats[i].DeadLetterQueue,
+			Messages:        stats[i].Messages,
+		}
+	}
+	return out, nil
+}
+
+func (s *deadLetterStore) Peek(ctx context.Context, queueName string, limit int) ([]entities.DeadLetterMessage, error) {
+	if s.admin == nil {
+		return nil, domainerrors.ErrDeadLettersUnavailable
+	}
+	msgs, err := s.admin.PeekDeadLetters(ctx, queueName, limit)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]entities.DeadLetterMessage, len(msgs))
+	for i := range msgs {
+		out[i] = entities.DeadLetterMessage{
+			MessageID:     msgs[i].MessageID,
+			OriginalQueue: msgs[i].OriginalQueue,
+			RetryCount:    msgs[i].RetryCount,
+			Permanent:     msgs[i].Permanent,
+			LastError:     msgs[i].LastError,
+			FailedAt:      msgs[i].FailedAt,
+			ContentType:   msgs[i].ContentType,
+			Headers:       msgs[i].Headers,
+			Body:          msgs[i].Body,
+		}
+	}
+	return out, nil
+}
+
+func (s *deadLetterStore) Replay(ctx context.Context, queueName string, messageID string, limit int) (int, error) {
+	if s.admin == nil {
+		return 0, domainerrors.ErrDeadLettersUnavailable
+	}
+	return s.admin.ReplayDeadLetters(ctx, queueName, messageID, limit)
+}
+
+func (s *deadLetterStore) Purge(ctx context.Context, queueName string) (int, error) {
+	if s.admin == nil {
+		return 0, domainerrors.ErrDeadLettersUnavailable
+	}
+	return s.admin.PurgeDeadLetters(ctx, queueName)
+}
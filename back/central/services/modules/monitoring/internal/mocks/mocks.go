@@ -29,6 +29,47 @@ func (m *AlertPublisherMock) Publish(ctx context.Context, event entities.AlertEv
 	return nil
 }
 
+type DeadLetterStoreMock struct {
+	ListQueuesFn func(ctx context.Context) ([]entities.DeadLetterQueue, error)
+	PeekFn       func(ctx context.Context, queueName string, limit int) ([]entities.DeadLetterMessage, error)
+	ReplayFn     func(ctx context.Context, queueName string, messageID string, limit int) (int, error)
+	PurgeFn      func(ctx context.Context, queueName string) (int, error)
+
+	LastLimit int
+}
+
+var _ ports.IDeadLetterStore = (*DeadLetterStoreMock)(nil)
+
+func (m *DeadLetterStoreMock) ListQueues(ctx context.Context) ([]entities.DeadLetterQueue, error) {
+	if m.ListQueuesFn != nil {
+		return m.ListQueuesFn(ctx)
+	}
+	return nil, nil
+}
+
+func (m *DeadLetterStoreMock) Peek(ctx context.Context, queueName string, limit int) ([]entities.DeadLetterMessage, error) {
+	m.LastLimit = limit
+	if m.PeekFn != nil {
+		return m.PeekFn(ctx, queueName, limit)
+	}
+	return nil, nil
+}
+
+func (m *DeadLetterStoreMock) Replay(ctx context.Context, queueName string, messageID string, limit int) (int, error) {
+	m.LastLimit = limit
+	if m.ReplayFn != nil {
+		return m.ReplayFn(ctx, queueName, messageID, limit)
+	}
+	return 0, nil
+}
+
+func (m *DeadLetterStoreMock) Purge(ctx context.Context, queueName string) (int, error) {
+	if m.PurgeFn != nil {
+		return m.PurgeFn(ctx, queueName)
+	}
+	return 0, nil
+}
+
 type SilentLogger struct{}
 
 func NewSilentLogger() log.ILogger {
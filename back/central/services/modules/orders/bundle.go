@@ -8,6 +8,7 @@ import (
 	"github.com/secamc93/probability/back/central/services/modules/orders/internal/app/usecasecreateorder"
 	"github.com/secamc93/probability/back/central/services/modules/orders/internal/app/usecaseupdateorder"
 	"github.com/secamc93/probability/back/central/services/modules/orders/internal/app/usecaseupdatestatus"
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/app/usecaseworkflow"
 	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/ports"
 	"github.com/secamc93/probability/back/central/services/modules/orders/internal/infra/primary/handlers"
 	"github.com/secamc93/probability/back/central/services/modules/orders/internal/infra/primary/queue"
@@ -18,6 +19,7 @@ import (
 	"github.com/secamc93/probability/back/central/shared/db"
 	"github.com/secamc93/probability/back/central/shared/env"
 	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/outbox"
 	"github.com/secamc93/probability/back/central/shared/rabbitmq"
 )
 
@@ -29,28 +31,30 @@ type Bundle struct {
 
 func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, environment env.IConfig, rabbitMQ rabbitmq.IQueue) *Bundle {
 	repo := repository.New(database, environment)
+	transactor := db.NewTransactor(database)
 
-	rabbitPublisher := initRabbitPublisher(rabbitMQ, logger)
+	rabbitPublisher := initRabbitPublisher(rabbitMQ, database, logger)
 	integrationEventPub := eventpublisher.New(rabbitMQ)
 
 	invoiceQuery := repository.NewInvoiceQuery(database)
-	orderCRUD := usecaseorder.New(repo, rabbitPublisher, invoiceQuery, logger)
+	orderCRUD := usecaseorder.New(repo, transactor, rabbitPublisher, invoiceQuery, logger)
 
 	geocoderAdapter := geocoder.New(environment.Get("GOOGLE_MAPS_API_KEY"), logger)
 
 	updateUC := usecaseupdateorder.New(repo, logger, rabbitPublisher, integrationEventPub)
-	createUC := usecasecreateorder.New(repo, logger, rabbitPublisher, integrationEventPub, updateUC, geocoderAdapter)
+	createUC := usecasecreateorder.New(repo, transactor, logger, rabbitPublisher, integrationEventPub, updateUC, geocoderAdapter)
 
-	statusUC := usecaseupdatestatus.New(repo, logger, rabbitPublisher)
+	workflowUC := usecaseworkflow.New(repository.NewWorkflowRepository(database), logger)
+	statusUC := usecaseupdatestatus.New(repo, transactor, logger, rabbitPublisher, workflowUC)
 	requestConfirmationUC := initRequestConfirmationUseCase(repo, rabbitPublisher, logger)
 	sendGuideNotificationUC := initSendGuideNotificationUseCase(repo, rabbitPublisher, logger)
 
-	h := handlers.New(orderCRUD, createUC, requestConfirmationUC, sendGuideNotificationUC, statusUC, logger)
+	h := handlers.New(orderCRUD, createUC, requestConfirmationUC, sendGuideNotificationUC, statusUC, workflowUC, logger)
 	h.RegisterRoutes(router)
 
 	startRabbitMQConsumer(rabbitMQ, logger, createUC, repo, integrationEventPub)
 	startWhatsAppConsumer(rabbitMQ, logger, repo, rabbitPublisher)
-	startInventoryFeedbackConsumer(rabbitMQ, logger, repo, rabbitPublisher)
+	startInventoryFeedbackConsumer(rabbitMQ, logger, repo, rabbitPublisher, workflowUC)
 
 	return &Bundle{
 		CreateUC:                createUC,
@@ -59,7 +63,7 @@ func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, env
 	}
 }
 
-func initRabbitPublisher(rabbitMQ rabbitmq.IQueue, logger log.ILogger) ports.IOrderRabbitPublisher {
+func initRabbitPublisher(rabbitMQ rabbitmq.IQueue, database db.IDatabase, logger log.ILogger) ports.IOrderRabbitPublisher {
 	if rabbitMQ == nil {
 		logger.Warn(context.Background()).Msg("RabbitMQ not available, rabbit publisher disabled")
 		return nil
@@ -67,7 +71,7 @@ func initRabbitPublisher(rabbitMQ rabbitmq.IQueue, logger log.ILogger) ports.IOr
 
 	setupOrdersExchange(rabbitMQ, logger)
 
-	publisher := rabbitqueue.NewOrderRabbitPublisher(rabbitMQ, logger)
+	publisher := rabbitqueue.NewOrderRabbitPublisher(rabbitMQ, outbox.NewWriter(database), logger)
 
 	return publisher
 }
@@ -156,11 +160,11 @@ func startWhatsAppConsumer(rabbitMQ rabbitmq.IQueue, logger log.ILogger, repo po
 	}()
 }
 
-func startInventoryFeedbackConsumer(rabbitMQ rabbitmq.IQueue, logger log.ILogger, repo ports.IRepository, rabbitPublisher ports.IOrderRabbitPublisher) {
+func startInventoryFeedbackConsumer(rabbitMQ rabbitmq.IQueue, logger log.ILogger, repo ports.IRepository, rabbitPublisher ports.IOrderRabbitPublisher, workflows ports.IWorkflowResolver) {
 	if rabbitMQ == nil {
 		return
 	}
 
-	consumer := queue.NewInventoryConsumer(rabbitMQ, repo, rabbitPublisher, logger)
+	consumer := queue.NewInventoryConsumer(rabbitMQ, repo, rabbitPublisher, workflows, logger)
 	consumer.Start(context.Background())
 }
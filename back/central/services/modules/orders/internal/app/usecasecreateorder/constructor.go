@@ -1,12 +1,15 @@
 package usecasecreateorder
 
 import (
+	"context"
+
 	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/ports"
 	"github.com/secamc93/probability/back/central/shared/log"
 )
 
 type UseCaseCreateOrder struct {
 	repo                      ports.IRepository
+	transactor                ports.ITransactor
 	logger                    log.ILogger
 	rabbitEventPublisher      ports.IOrderRabbitPublisher
 	integrationEventPublisher ports.IIntegrationEventPublisher
@@ -16,6 +19,7 @@ type UseCaseCreateOrder struct {
 
 func New(
 	repo ports.IRepository,
+	transactor ports.ITransactor,
 	logger log.ILogger,
 	rabbitPublisher ports.IOrderRabbitPublisher,
 	integrationEventPub ports.IIntegrationEventPublisher,
@@ -24,6 +28,7 @@ func New(
 ) ports.IOrderCreateUseCase {
 	return &UseCaseCreateOrder{
 		repo:                      repo,
+		transactor:                transactor,
 		logger:                    logger,
 		rabbitEventPublisher:      rabbitPublisher,
 		integrationEventPublisher: integrationEventPub,
@@ -31,3 +36,12 @@ func New(
 		geocoder:                  geocoder,
 	}
 }
+
+// inTransaction corre fn en la transaccion del transactor; sin transactor
+// (tests) corre fn directo.
+func (uc *UseCaseCreateOrder) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if uc.transactor == nil {
+		return fn(ctx)
+	}
+	return uc.transactor.InTransaction(ctx, fn)
+}
@@ -84,7 +84,18 @@ func (uc *UseCaseCreateOrder) MapAndSaveOrder(ctx context.Context, dto *dtos.Pro
 
 	uc.geocodeOrderIfNeeded(ctx, order)
 
-	if err := uc.repo.CreateOrder(ctx, order); err != nil {
+	// La orden, sus entidades y el evento de orden creada (outbox) se guardan
+	// en una sola transaccion: o queda todo o no queda nada.
+	err = uc.inTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.CreateOrder(ctx, order); err != nil {
+			return fmt.Errorf("error creating order: %w", err)
+		}
+		if err := uc.saveRelatedEntities(ctx, order, dto); err != nil {
+			return err
+		}
+		return uc.publishOrderCreatedEvent(ctx, order)
+	})
+	if err != nil {
 		if errors.Is(err, domainerrors.ErrOrderAlreadyExists) {
 			existingOrder, gerr := uc.repo.GetOrderByExternalID(ctx, dto.ExternalID, dto.IntegrationID)
 			if gerr != nil {
@@ -92,7 +103,7 @@ func (uc *UseCaseCreateOrder) MapAndSaveOrder(ctx context.Context, dto *dtos.Pro
 			}
 			return uc.updateUseCase.UpdateOrder(ctx, existingOrder, dto)
 		}
-		return nil, fmt.Errorf("error creating order: %w", err)
+		return nil, err
 	}
 
 	uc.saveCreationHistory(ctx, order, dto)
@@ -103,10 +114,6 @@ func (uc *UseCaseCreateOrder) MapAndSaveOrder(ctx context.Context, dto *dtos.Pro
 		}
 	}
 
-	if err := uc.saveRelatedEntities(ctx, order, dto); err != nil {
-		return nil, err
-	}
-
 	uc.publishOrderEvents(ctx, order, dto.IsManualOrder)
 
 	return uc.mapOrderToResponse(order), nil
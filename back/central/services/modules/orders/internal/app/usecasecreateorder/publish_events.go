@@ -2,26 +2,21 @@ package usecasecreateorder
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/entities"
 )
 
-// publishOrderEvents es el punto de entrada ÚNICO para publicar todos los eventos
-// después de crear una orden exitosamente.
-// Orquesta la publicación a todos los canales necesarios:
-//   - Integration sync (notifica al módulo de integraciones)
-//   - RabbitMQ fanout (invoicing, inventory, score, whatsapp, events consumers)
+// publishOrderEvents publica los eventos que salen despues de confirmar la
+// creacion de la orden. El evento de orden creada NO va aqui: se encola en la
+// misma transaccion que la orden (ver MapAndSaveOrder y publishOrderCreatedEvent).
 func (uc *UseCaseCreateOrder) publishOrderEvents(ctx context.Context, order *entities.ProbabilityOrder, isManualOrder bool) {
-	// 1. Notificar sincronización exitosa a integraciones (solo órdenes de integración)
+	// Notificar sincronización exitosa a integraciones (solo órdenes de integración)
 	if !isManualOrder {
 		uc.publishSyncOrderCreated(ctx, order)
 	}
 
-	// 2. Publicar evento de orden creada a RabbitMQ fanout
-	// (invoicing, inventory, score, whatsapp, events consumers)
-	uc.publishOrderCreatedEvent(ctx, order)
-
 	// Score calculation handled by probability module via QueueOrdersToScore
 }
 
@@ -52,11 +47,12 @@ func (uc *UseCaseCreateOrder) publishSyncOrderCreated(ctx context.Context, order
 //	RABBITMQ FANOUT EVENTS
 //
 
-// publishOrderCreatedEvent publica el evento de orden creada al exchange fanout de RabbitMQ
-// (invoicing, inventory, score, whatsapp consumers)
-func (uc *UseCaseCreateOrder) publishOrderCreatedEvent(_ context.Context, order *entities.ProbabilityOrder) {
+// publishOrderCreatedEvent encola el evento de orden creada para el exchange
+// fanout (invoicing, inventory, score, whatsapp consumers). Corre dentro de la
+// transaccion de la creacion: si falla, la orden no se crea.
+func (uc *UseCaseCreateOrder) publishOrderCreatedEvent(ctx context.Context, order *entities.ProbabilityOrder) error {
 	if uc.rabbitEventPublisher == nil {
-		return
+		return nil
 	}
 
 	eventData := entities.OrderEventData{
@@ -77,15 +73,13 @@ func (uc *UseCaseCreateOrder) publishOrderCreatedEvent(_ context.Context, order
 		event.IntegrationID = &integrationID
 	}
 
-	go func() {
-		if err := uc.rabbitEventPublisher.PublishOrderEvent(context.Background(), event, order); err != nil {
-			uc.logger.Error(context.Background()).
-				Err(err).
-				Str("event_type", string(event.Type)).
-				Str("order_id", event.OrderID).
-				Msg("Error al publicar evento a RabbitMQ")
-		}
-	}()
+	if err := uc.rabbitEventPublisher.PublishOrderEvent(ctx, event, order); err != nil {
+		uc.logger.Error(ctx).
+			Err(err).
+			Str("event_type", string(event.Type)).
+			Str("order_id", event.OrderID).
+			Msg("Error al encolar evento de orden creada")
+		return fmt.Errorf("error publishing order created event: %w", err)
+	}
+	return nil
 }
-
-
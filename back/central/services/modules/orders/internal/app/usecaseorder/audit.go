@@ -0,0 +1,42 @@
+package usecaseorder
+
+import "github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/entities"
+
+// orderSnapshot son los campos editables de la orden que se auditan. El
+// diff solo guarda los que cambiaron.
+func orderSnapshot(order *entities.ProbabilityOrder) map[string]any {
+	if order == nil {
+		return nil
+	}
+	return map[string]any{
+		"status":            order.Status,
+		"subtotal":          order.Subtotal,
+		"tax":               order.Tax,
+		"discount":          order.Discount,
+		"shipping_cost":     order.ShippingCost,
+		"total_amount":      order.TotalAmount,
+		"currency":          order.Currency,
+		"is_cod":            order.IsCod,
+		"cod_total":         order.CodTotal,
+		"payment_method_id": order.PaymentMethodID,
+		"is_paid":           order.IsPaid,
+		"customer_name":     order.CustomerName,
+		"customer_email":    order.CustomerEmail,
+		"customer_phone":    order.CustomerPhone,
+		"customer_dni":      order.CustomerDNI,
+		"shipping_street":   order.ShippingStreet,
+		"shipping_city":     order.ShippingCity,
+		"shipping_state":    order.ShippingState,
+		"shipping_country":  order.ShippingCountry,
+		"shipping_postal":   order.ShippingPostalCode,
+		"tracking_number":   order.TrackingNumber,
+		"guide_id":          order.GuideID,
+		"warehouse_id":      order.WarehouseID,
+		"driver_id":         order.DriverID,
+		"is_confirmed":      order.IsConfirmed,
+		"novelty":           order.Novelty,
+		"notes":             order.Notes,
+		"priority":          order.Priority,
+		"invoiceable":       order.Invoiceable,
+	}
+}
@@ -1,6 +1,8 @@
 package usecaseorder
 
 import (
+	"context"
+
 	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/ports"
 	"github.com/secamc93/probability/back/central/shared/log"
 )
@@ -8,6 +10,7 @@ import (
 // UseCaseOrder contiene los casos de uso CRUD básicos de órdenes
 type UseCaseOrder struct {
 	repo                 ports.IRepository
+	transactor           ports.ITransactor
 	rabbitEventPublisher ports.IOrderRabbitPublisher
 	invoiceQueryPort     ports.IInvoiceQueryPort
 	logger               log.ILogger
@@ -16,14 +19,25 @@ type UseCaseOrder struct {
 // New crea una nueva instancia de UseCaseOrder retornando la interfaz IOrderUseCase
 func New(
 	repo ports.IRepository,
+	transactor ports.ITransactor,
 	rabbitPublisher ports.IOrderRabbitPublisher,
 	invoiceQueryPort ports.IInvoiceQueryPort,
 	logger log.ILogger,
 ) ports.IOrderUseCase {
 	return &UseCaseOrder{
 		repo:                 repo,
+		transactor:           transactor,
 		rabbitEventPublisher: rabbitPublisher,
 		invoiceQueryPort:     invoiceQueryPort,
 		logger:               logger,
 	}
 }
+
+// inTransaction corre fn en la transaccion del transactor; sin transactor
+// (tests) corre fn directo.
+func (uc *UseCaseOrder) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if uc.transactor == nil {
+		return fn(ctx)
+	}
+	return uc.transactor.InTransaction(ctx, fn)
+}
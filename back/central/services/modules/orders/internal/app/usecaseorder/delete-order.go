@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+
+	"github.com/secamc93/probability/back/central/shared/audit"
 )
 
 // DeleteOrder elimina (soft delete) una orden
@@ -13,15 +15,23 @@ func (uc *UseCaseOrder) DeleteOrder(ctx context.Context, id string) error {
 	}
 
 	// Verificar que la orden existe
-	_, err := uc.repo.GetOrderByID(ctx, id)
+	order, err := uc.repo.GetOrderByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("error getting order: %w", err)
 	}
 
 	// Eliminar la orden
-	if err := uc.repo.DeleteOrder(ctx, id); err != nil {
-		return fmt.Errorf("error deleting order: %w", err)
-	}
-
-	return nil
+	return uc.inTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.DeleteOrder(ctx, id); err != nil {
+			return fmt.Errorf("error deleting order: %w", err)
+		}
+		return audit.Record(ctx, audit.Entry{
+			BusinessID: order.BusinessID,
+			Resource:   "order",
+			ResourceID: id,
+			Action:     "deleted",
+			Before:     orderSnapshot(order),
+			Metadata:   map[string]any{"order_number": order.OrderNumber},
+		})
+	})
 }
@@ -17,7 +17,7 @@ func TestGetOrderByID_Success(t *testing.T) {
 	mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 	mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 	mockLogger := new(mocks.LoggerMock)
-	useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+	useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 	ctx := context.Background()
 	orderID := "order-uuid-123"
@@ -74,7 +74,7 @@ func TestGetOrderByID_EmptyID(t *testing.T) {
 	mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 	mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 	mockLogger := new(mocks.LoggerMock)
-	useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+	useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 	ctx := context.Background()
 
@@ -96,7 +96,7 @@ func TestGetOrderByID_NotFound(t *testing.T) {
 	mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 	mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 	mockLogger := new(mocks.LoggerMock)
-	useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+	useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 	ctx := context.Background()
 	orderID := "non-existent-uuid"
@@ -123,7 +123,7 @@ func TestGetOrderByID_DatabaseError(t *testing.T) {
 	mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 	mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 	mockLogger := new(mocks.LoggerMock)
-	useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+	useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 	ctx := context.Background()
 	orderID := "order-uuid-123"
@@ -150,7 +150,7 @@ func TestGetOrderByID_WithCompleteData(t *testing.T) {
 	mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 	mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 	mockLogger := new(mocks.LoggerMock)
-	useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+	useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 	ctx := context.Background()
 	orderID := "order-uuid-complete"
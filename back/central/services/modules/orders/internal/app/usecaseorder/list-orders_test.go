@@ -17,7 +17,7 @@ func TestListOrders_Success(t *testing.T) {
 	mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 	mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 	mockLogger := new(mocks.LoggerMock)
-	useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+	useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 	ctx := context.Background()
 	page := 1
@@ -90,7 +90,7 @@ func TestListOrders_EmptyResult(t *testing.T) {
 	mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 	mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 	mockLogger := new(mocks.LoggerMock)
-	useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+	useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 	ctx := context.Background()
 	page := 1
@@ -178,7 +178,7 @@ func TestListOrders_PaginationValidation(t *testing.T) {
 			mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 			mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 			mockLogger := new(mocks.LoggerMock)
-			useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+			useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 			ctx := context.Background()
 			filters := map[string]interface{}{}
@@ -206,7 +206,7 @@ func TestListOrders_RepositoryError(t *testing.T) {
 	mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 	mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 	mockLogger := new(mocks.LoggerMock)
-	useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+	useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 	ctx := context.Background()
 	page := 1
@@ -275,7 +275,7 @@ func TestListOrders_TotalPagesCalculation(t *testing.T) {
 			mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 			mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 			mockLogger := new(mocks.LoggerMock)
-			useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+			useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 			ctx := context.Background()
 			page := 1
@@ -300,7 +300,7 @@ func TestListOrders_WithFilters(t *testing.T) {
 	mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 	mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 	mockLogger := new(mocks.LoggerMock)
-	useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+	useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 	ctx := context.Background()
 	page := 1
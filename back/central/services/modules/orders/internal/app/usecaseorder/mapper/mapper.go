@@ -127,6 +127,9 @@ func ToOrderResponse(order *entities.ProbabilityOrder) *dtos.OrderResponse {
 		// Testing
 		IsTest: order.IsTest,
 
+		// Prioridad de preparacion
+		Priority: order.Priority,
+
 		// Facturación
 		Invoiceable:     order.Invoiceable,
 		InvoiceURL:      order.InvoiceURL,
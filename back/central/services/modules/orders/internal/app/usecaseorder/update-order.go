@@ -9,6 +9,7 @@ import (
 	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain"
 	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/dtos"
 	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/shared/audit"
 )
 
 // UpdateOrder actualiza una orden existente
@@ -25,6 +26,7 @@ func (uc *UseCaseOrder) UpdateOrder(ctx context.Context, id string, req *dtos.Up
 
 	// Guardar el estado anterior para detectar cambios
 	previousStatus := order.Status
+	before := orderSnapshot(order)
 
 	// Actualizar solo los campos proporcionados
 	if req.Subtotal != nil {
@@ -140,6 +142,9 @@ func (uc *UseCaseOrder) UpdateOrder(ctx context.Context, id string, req *dtos.Up
 	if req.WarehouseName != nil {
 		order.WarehouseName = *req.WarehouseName
 	}
+	if req.Priority != nil {
+		order.Priority = *req.Priority
+	}
 	if req.DriverID != nil {
 		order.DriverID = req.DriverID
 	}
@@ -300,8 +305,22 @@ func (uc *UseCaseOrder) UpdateOrder(ctx context.Context, id string, req *dtos.Up
 		}
 	}
 
-	if err := uc.repo.UpdateOrder(ctx, order); err != nil {
-		return nil, fmt.Errorf("error updating order: %w", err)
+	err = uc.inTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.UpdateOrder(ctx, order); err != nil {
+			return fmt.Errorf("error updating order: %w", err)
+		}
+		return audit.Record(ctx, audit.Entry{
+			BusinessID: order.BusinessID,
+			Resource:   "order",
+			ResourceID: order.ID,
+			Action:     "updated",
+			Before:     before,
+			After:      orderSnapshot(order),
+			Metadata:   map[string]any{"order_number": order.OrderNumber},
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Score recalculation handled by probability module via QueueOrdersToScore
@@ -18,7 +18,7 @@ func TestUpdateOrder_Success(t *testing.T) {
 	mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 	mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 	mockLogger := new(mocks.LoggerMock)
-	useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+	useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 	ctx := context.Background()
 	orderID := "order-uuid-123"
@@ -85,7 +85,7 @@ func TestUpdateOrder_EmptyID(t *testing.T) {
 	mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 	mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 	mockLogger := new(mocks.LoggerMock)
-	useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+	useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 	ctx := context.Background()
 	req := &dtos.UpdateOrderRequest{}
@@ -108,7 +108,7 @@ func TestUpdateOrder_OrderNotFound(t *testing.T) {
 	mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 	mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 	mockLogger := new(mocks.LoggerMock)
-	useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+	useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 	ctx := context.Background()
 	orderID := "non-existent"
@@ -135,7 +135,7 @@ func TestUpdateOrder_StatusChange_PublishesStatusEvent(t *testing.T) {
 	mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 	mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 	mockLogger := new(mocks.LoggerMock)
-	useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+	useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 	ctx := context.Background()
 	orderID := "order-uuid-123"
@@ -190,7 +190,7 @@ func TestUpdateOrder_PartialUpdate(t *testing.T) {
 	mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 	mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 	mockLogger := new(mocks.LoggerMock)
-	useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+	useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 	ctx := context.Background()
 	orderID := "order-uuid-123"
@@ -258,7 +258,7 @@ func TestUpdateOrder_RepositoryError(t *testing.T) {
 	mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 	mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 	mockLogger := new(mocks.LoggerMock)
-	useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+	useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 	ctx := context.Background()
 	orderID := "order-uuid-123"
@@ -334,7 +334,7 @@ func TestUpdateOrder_ConfirmationStatus(t *testing.T) {
 			mockRabbitPublisher := new(mocks.RabbitPublisherMock)
 			mockInvoiceQuery := new(mocks.InvoiceQueryMock)
 			mockLogger := new(mocks.LoggerMock)
-			useCase := New(mockRepo, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
+			useCase := New(mockRepo, nil, mockRabbitPublisher, mockInvoiceQuery, mockLogger)
 
 			ctx := context.Background()
 			orderID := "order-uuid-123"
@@ -13,7 +13,7 @@ import (
 )
 
 // ChangeStatus es el orquestador principal para cambios de estado de órdenes.
-// Valida la transición, delega la lógica específica al strategy correspondiente,
+// Valida la transición contra el flujo de estados del negocio, delega la lógica específica al strategy correspondiente,
 // persiste los cambios, registra el historial y publica eventos.
 func (uc *UseCaseUpdateStatus) ChangeStatus(ctx context.Context, orderID string, req *dtos.ChangeStatusRequest) (*dtos.OrderResponse, error) {
 	if orderID == "" {
@@ -35,16 +35,20 @@ func (uc *UseCaseUpdateStatus) ChangeStatus(ctx context.Context, orderID string,
 		return nil, fmt.Errorf("error getting order: %w", err)
 	}
 
-	// 3. Validar que el estado actual no es terminal
+	// 3. Validar que el estado actual no es terminal en el flujo del negocio
 	currentStatus := entities.OrderStatus(order.Status)
-	if currentStatus.IsTerminal() {
+	workflow := uc.resolveWorkflow(ctx, order.BusinessID)
+	if workflow.IsTerminal(currentStatus) {
 		return nil, fmt.Errorf("%w: current status is %s", domainerrors.ErrOrderInTerminalState, order.Status)
 	}
 
-	// 4. Validar la transición
-	if !currentStatus.CanTransitionTo(targetStatus) {
+	// 4. Validar la transición y los campos que exige
+	if !workflow.CanTransition(currentStatus, targetStatus) {
 		return nil, fmt.Errorf("%w: cannot transition from %s to %s", domainerrors.ErrInvalidStatusTransition, order.Status, req.Status)
 	}
+	if missing := missingRequiredFields(order, req, workflow.RequiredFields(currentStatus, targetStatus)); len(missing) > 0 {
+		return nil, &domainerrors.MissingWorkflowFieldsError{From: order.Status, To: req.Status, Fields: missing}
+	}
 
 	// 4.1 Validar inventario al regresar de Novedad de inventario a Seleccionando productos
 	if currentStatus == entities.OrderStatusInventoryIssue && targetStatus == entities.OrderStatusPicking {
@@ -77,17 +81,22 @@ func (uc *UseCaseUpdateStatus) ChangeStatus(ctx context.Context, orderID string,
 	now := time.Now()
 	order.StatusChangedAt = &now
 
-	// 8. Persistir cambios
-	if err := uc.repo.UpdateOrder(ctx, order); err != nil {
-		return nil, fmt.Errorf("error updating order: %w", err)
+	// 8. Persistir cambios, historial y eventos en la misma transaccion
+	// (outbox): si el cambio se guarda, queda su historial y los eventos salen
+	// aunque el broker este caido
+	err = uc.inTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.UpdateOrder(ctx, order); err != nil {
+			return fmt.Errorf("error updating order: %w", err)
+		}
+		if err := uc.saveOrderHistory(ctx, order, previousStatus, req); err != nil {
+			return err
+		}
+		return uc.publishStatusChangeEvents(ctx, order, previousStatus)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// 9. Registrar historial de cambio de estado
-	uc.saveOrderHistory(ctx, order, previousStatus, req)
-
-	// 10. Publicar eventos
-	uc.publishStatusChangeEvents(ctx, order, previousStatus)
-
 	uc.logger.Info(ctx).
 		Str("order_id", orderID).
 		Str("previous_status", previousStatus).
@@ -144,7 +153,7 @@ func (uc *UseCaseUpdateStatus) executeStrategy(order *entities.ProbabilityOrder,
 }
 
 // saveOrderHistory registra el cambio de estado en la tabla de historial
-func (uc *UseCaseUpdateStatus) saveOrderHistory(ctx context.Context, order *entities.ProbabilityOrder, previousStatus string, req *dtos.ChangeStatusRequest) {
+func (uc *UseCaseUpdateStatus) saveOrderHistory(ctx context.Context, order *entities.ProbabilityOrder, previousStatus string, req *dtos.ChangeStatusRequest) error {
 	var reason *string
 	if req.Metadata != nil {
 		if r, ok := req.Metadata["reason"].(string); ok {
@@ -174,11 +183,7 @@ func (uc *UseCaseUpdateStatus) saveOrderHistory(ctx context.Context, order *enti
 	}
 
 	if err := uc.repo.CreateOrderHistory(ctx, history); err != nil {
-		uc.logger.Error(ctx).
-			Err(err).
-			Str("order_id", order.ID).
-			Str("previous_status", previousStatus).
-			Str("new_status", req.Status).
-			Msg("Error al guardar historial de cambio de estado")
+		return fmt.Errorf("error saving order history: %w", err)
 	}
+	return nil
 }
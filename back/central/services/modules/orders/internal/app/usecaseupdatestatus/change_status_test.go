@@ -584,9 +584,9 @@ func TestChangeStatus_History_RecordsReasonFromMetadata(t *testing.T) {
 	repo.AssertExpectations(t)
 }
 
-// --- Test: History error no bloquea el flujo ---
+// --- Test: History error revierte el cambio de estado ---
 
-func TestChangeStatus_HistoryError_DoesNotBlockFlow(t *testing.T) {
+func TestChangeStatus_HistoryError_RevierteElCambio(t *testing.T) {
 	uc, repo, rabbit, logger := setupUseCase()
 	ctx := context.Background()
 	order := newOrder("order-1", "pending")
@@ -602,9 +602,9 @@ func TestChangeStatus_HistoryError_DoesNotBlockFlow(t *testing.T) {
 
 	result, err := uc.ChangeStatus(ctx, "order-1", &dtos.ChangeStatusRequest{Status: "picking"})
 
-	// Should succeed despite history error (it only logs the error)
-	assert.NoError(t, err)
-	assert.NotNil(t, result)
+	// El historial se guarda en la misma transaccion: si falla, el cambio no se confirma
+	assert.Error(t, err)
+	assert.Nil(t, result)
 	repo.AssertExpectations(t)
 }
 
@@ -1,6 +1,8 @@
 package usecaseupdatestatus
 
 import (
+	"context"
+
 	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/ports"
 	"github.com/secamc93/probability/back/central/shared/log"
 )
@@ -8,19 +10,34 @@ import (
 // UseCaseUpdateStatus maneja los cambios de estado de órdenes con strategy pattern
 type UseCaseUpdateStatus struct {
 	repo                 ports.IRepository
+	transactor           ports.ITransactor
 	logger               log.ILogger
 	rabbitEventPublisher ports.IOrderRabbitPublisher
+	workflows            ports.IWorkflowResolver
 }
 
 // New crea una nueva instancia del caso de uso de cambio de estado
 func New(
 	repo ports.IRepository,
+	transactor ports.ITransactor,
 	logger log.ILogger,
 	rabbitPublisher ports.IOrderRabbitPublisher,
+	workflows ports.IWorkflowResolver,
 ) ports.IOrderStatusUseCase {
 	return &UseCaseUpdateStatus{
 		repo:                 repo,
+		transactor:           transactor,
 		logger:               logger,
 		rabbitEventPublisher: rabbitPublisher,
+		workflows:            workflows,
+	}
+}
+
+// inTransaction corre fn en la transaccion del transactor; sin transactor
+// (tests) corre fn directo.
+func (uc *UseCaseUpdateStatus) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if uc.transactor == nil {
+		return fn(ctx)
 	}
+	return uc.transactor.InTransaction(ctx, fn)
 }
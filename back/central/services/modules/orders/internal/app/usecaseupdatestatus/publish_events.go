@@ -2,25 +2,29 @@ package usecaseupdatestatus
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/entities"
 )
 
-// publishStatusChangeEvents publica eventos después de un cambio de estado exitoso
-func (uc *UseCaseUpdateStatus) publishStatusChangeEvents(ctx context.Context, order *entities.ProbabilityOrder, previousStatus string) {
+// publishStatusChangeEvents encola los eventos del cambio de estado. Se llama
+// dentro de la transaccion del UpdateOrder: si falla, el cambio se revierte.
+func (uc *UseCaseUpdateStatus) publishStatusChangeEvents(ctx context.Context, order *entities.ProbabilityOrder, previousStatus string) error {
 	if uc.rabbitEventPublisher == nil {
-		return
+		return nil
 	}
 
 	// 1. Publicar evento de orden actualizada
-	uc.publishOrderUpdatedEvent(ctx, order)
+	if err := uc.publishOrderUpdatedEvent(ctx, order); err != nil {
+		return err
+	}
 
 	// 2. Publicar evento de cambio de estado
-	uc.publishOrderStatusChangedEvent(ctx, order, previousStatus)
+	return uc.publishOrderStatusChangedEvent(ctx, order, previousStatus)
 }
 
 // publishOrderUpdatedEvent publica el evento de orden actualizada al exchange fanout
-func (uc *UseCaseUpdateStatus) publishOrderUpdatedEvent(ctx context.Context, order *entities.ProbabilityOrder) {
+func (uc *UseCaseUpdateStatus) publishOrderUpdatedEvent(ctx context.Context, order *entities.ProbabilityOrder) error {
 	eventData := entities.OrderEventData{
 		OrderNumber:    order.OrderNumber,
 		InternalNumber: order.InternalNumber,
@@ -39,19 +43,19 @@ func (uc *UseCaseUpdateStatus) publishOrderUpdatedEvent(ctx context.Context, ord
 		event.IntegrationID = &integrationID
 	}
 
-	go func() {
-		if err := uc.rabbitEventPublisher.PublishOrderEvent(context.Background(), event, order); err != nil {
-			uc.logger.Error(context.Background()).
-				Err(err).
-				Str("event_type", string(event.Type)).
-				Str("order_id", event.OrderID).
-				Msg("Error al publicar evento de actualización a RabbitMQ")
-		}
-	}()
+	if err := uc.rabbitEventPublisher.PublishOrderEvent(ctx, event, order); err != nil {
+		uc.logger.Error(ctx).
+			Err(err).
+			Str("event_type", string(event.Type)).
+			Str("order_id", event.OrderID).
+			Msg("Error al encolar evento de actualización")
+		return fmt.Errorf("error publishing order updated event: %w", err)
+	}
+	return nil
 }
 
 // publishOrderStatusChangedEvent publica el evento de cambio de estado al exchange fanout
-func (uc *UseCaseUpdateStatus) publishOrderStatusChangedEvent(_ context.Context, order *entities.ProbabilityOrder, previousStatus string) {
+func (uc *UseCaseUpdateStatus) publishOrderStatusChangedEvent(ctx context.Context, order *entities.ProbabilityOrder, previousStatus string) error {
 	eventData := entities.OrderEventData{
 		OrderNumber:    order.OrderNumber,
 		InternalNumber: order.InternalNumber,
@@ -71,13 +75,13 @@ func (uc *UseCaseUpdateStatus) publishOrderStatusChangedEvent(_ context.Context,
 		event.IntegrationID = &integrationID
 	}
 
-	go func() {
-		if err := uc.rabbitEventPublisher.PublishOrderEvent(context.Background(), event, order); err != nil {
-			uc.logger.Error(context.Background()).
-				Err(err).
-				Str("event_type", string(event.Type)).
-				Str("order_id", event.OrderID).
-				Msg("Error al publicar evento de cambio de estado a RabbitMQ")
-		}
-	}()
+	if err := uc.rabbitEventPublisher.PublishOrderEvent(ctx, event, order); err != nil {
+		uc.logger.Error(ctx).
+			Err(err).
+			Str("event_type", string(event.Type)).
+			Str("order_id", event.OrderID).
+			Msg("Error al encolar evento de cambio de estado")
+		return fmt.Errorf("error publishing order status changed event: %w", err)
+	}
+	return nil
 }
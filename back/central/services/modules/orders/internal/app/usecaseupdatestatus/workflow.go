@@ -0,0 +1,62 @@
+package usecaseupdatestatus
+
+import (
+	"context"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/entities"
+)
+
+// resolveWorkflow retorna el flujo del negocio; sin resolver (tests) aplica el
+// flujo por defecto.
+func (uc *UseCaseUpdateStatus) resolveWorkflow(ctx context.Context, businessID *uint) *entities.OrderWorkflow {
+	if uc.workflows == nil {
+		return entities.DefaultOrderWorkflow()
+	}
+	return uc.workflows.ResolveWorkflow(ctx, businessID)
+}
+
+// missingRequiredFields retorna los campos exigidos que no vienen en la
+// metadata del cambio ni estan ya en la orden.
+func missingRequiredFields(order *entities.ProbabilityOrder, req *dtos.ChangeStatusRequest, required []string) []string {
+	var missing []string
+	for _, field := range required {
+		if hasMetadataValue(req.Metadata, field) || orderHasField(order, field) {
+			continue
+		}
+		missing = append(missing, field)
+	}
+	return missing
+}
+
+func hasMetadataValue(metadata map[string]interface{}, field string) bool {
+	if metadata == nil {
+		return false
+	}
+	switch v := metadata[field].(type) {
+	case string:
+		return strings.TrimSpace(v) != ""
+	case float64:
+		return v != 0
+	case nil:
+		return false
+	default:
+		return true
+	}
+}
+
+func orderHasField(order *entities.ProbabilityOrder, field string) bool {
+	switch field {
+	case entities.WorkflowFieldTrackingNumber:
+		return order.TrackingNumber != nil && *order.TrackingNumber != ""
+	case entities.WorkflowFieldTrackingLink:
+		return order.TrackingLink != nil && *order.TrackingLink != ""
+	case entities.WorkflowFieldDriverID:
+		return order.DriverID != nil && *order.DriverID != 0
+	case entities.WorkflowFieldDriverName:
+		return order.DriverName != ""
+	default:
+		return false
+	}
+}
@@ -0,0 +1,122 @@
+package usecaseupdatestatus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// dropshipWorkflow salta la bodega: pendiente pasa directo a en camino, pero
+// solo con numero de guia
+func dropshipWorkflow() *entities.OrderWorkflow {
+	return &entities.OrderWorkflow{
+		BusinessID:    1,
+		EntryStatuses: []entities.OrderStatus{entities.OrderStatusPending},
+		Transitions: []entities.WorkflowTransition{
+			{From: entities.OrderStatusPending, To: entities.OrderStatusInTransit, RequiredFields: []string{entities.WorkflowFieldTrackingNumber}},
+			{From: entities.OrderStatusInTransit, To: entities.OrderStatusDelivered},
+		},
+		TerminalStatuses:   []entities.OrderStatus{entities.OrderStatusCancelled, entities.OrderStatusDelivered},
+		AllowCancelFromAny: true,
+	}
+}
+
+func setupUseCaseWithWorkflow(workflow *entities.OrderWorkflow) (*UseCaseUpdateStatus, *mocks.RepositoryMock, *mocks.RabbitPublisherMock, *mocks.LoggerMock) {
+	uc, repo, rabbit, logger := setupUseCase()
+	resolver := new(mocks.WorkflowResolverMock)
+	resolver.On("ResolveWorkflow", mock.Anything, mock.Anything).Return(workflow)
+	uc.workflows = resolver
+	return uc, repo, rabbit, logger
+}
+
+func TestChangeStatus_Workflow_RechazaTransicionFueraDelFlujo(t *testing.T) {
+	uc, repo, _, _ := setupUseCaseWithWorkflow(dropshipWorkflow())
+	ctx := context.Background()
+
+	repo.On("GetOrderByID", ctx, "order-1").Return(newOrder("order-1", "pending"), nil)
+
+	// pending -> picking es valido en el flujo por defecto, no en este
+	result, err := uc.ChangeStatus(ctx, "order-1", &dtos.ChangeStatusRequest{Status: "picking"})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainerrors.ErrInvalidStatusTransition)
+}
+
+func TestChangeStatus_Workflow_TerminalDelNegocio(t *testing.T) {
+	uc, repo, _, _ := setupUseCaseWithWorkflow(dropshipWorkflow())
+	ctx := context.Background()
+
+	repo.On("GetOrderByID", ctx, "order-1").Return(newOrder("order-1", "delivered"), nil)
+
+	result, err := uc.ChangeStatus(ctx, "order-1", &dtos.ChangeStatusRequest{Status: "cancelled"})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainerrors.ErrOrderInTerminalState)
+}
+
+func TestChangeStatus_Workflow_ExigeCamposRequeridos(t *testing.T) {
+	uc, repo, _, _ := setupUseCaseWithWorkflow(dropshipWorkflow())
+	ctx := context.Background()
+
+	repo.On("GetOrderByID", ctx, "order-1").Return(newOrder("order-1", "pending"), nil)
+
+	result, err := uc.ChangeStatus(ctx, "order-1", &dtos.ChangeStatusRequest{
+		Status:   "in_transit",
+		Metadata: map[string]interface{}{"tracking_number": "  "},
+	})
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, domainerrors.ErrWorkflowRequiredFields)
+	assert.Contains(t, err.Error(), "tracking_number")
+	repo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything)
+}
+
+func TestChangeStatus_Workflow_DropshipPendienteAEnCamino(t *testing.T) {
+	uc, repo, rabbit, logger := setupUseCaseWithWorkflow(dropshipWorkflow())
+	ctx := context.Background()
+	statusID := uint(9)
+
+	repo.On("GetOrderByID", ctx, "order-1").Return(newOrder("order-1", "pending"), nil)
+	repo.On("GetOrderStatusIDByCode", ctx, "in_transit").Return(&statusID, nil)
+	repo.On("UpdateOrder", ctx, mock.MatchedBy(func(o *entities.ProbabilityOrder) bool {
+		return o.Status == "in_transit" && o.TrackingNumber != nil && *o.TrackingNumber == "GUIA-123"
+	})).Return(nil)
+	repo.On("CreateOrderHistory", ctx, mock.Anything).Return(nil)
+	logger.On("Info", mock.Anything).Maybe()
+	rabbit.On("PublishOrderEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	result, err := uc.ChangeStatus(ctx, "order-1", &dtos.ChangeStatusRequest{
+		Status:   "in_transit",
+		Metadata: map[string]interface{}{"tracking_number": "GUIA-123"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "in_transit", result.Status)
+	repo.AssertExpectations(t)
+}
+
+func TestChangeStatus_Workflow_CampoRequeridoYaEnLaOrden(t *testing.T) {
+	uc, repo, rabbit, logger := setupUseCaseWithWorkflow(dropshipWorkflow())
+	ctx := context.Background()
+	order := newOrder("order-1", "pending")
+	tracking := "GUIA-EXISTENTE"
+	order.TrackingNumber = &tracking
+
+	repo.On("GetOrderByID", ctx, "order-1").Return(order, nil)
+	repo.On("GetOrderStatusIDByCode", ctx, "in_transit").Return(nil, nil)
+	repo.On("UpdateOrder", ctx, mock.Anything).Return(nil)
+	repo.On("CreateOrderHistory", ctx, mock.Anything).Return(nil)
+	logger.On("Info", mock.Anything).Maybe()
+	rabbit.On("PublishOrderEvent", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	result, err := uc.ChangeStatus(ctx, "order-1", &dtos.ChangeStatusRequest{Status: "in_transit"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "in_transit", result.Status)
+}
@@ -0,0 +1,20 @@
+package usecaseworkflow
+
+import (
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+// UseCaseWorkflow administra el flujo de estados de ordenes de cada negocio
+type UseCaseWorkflow struct {
+	repo   ports.IWorkflowRepository
+	logger log.ILogger
+}
+
+// New crea una nueva instancia de UseCaseWorkflow
+func New(repo ports.IWorkflowRepository, logger log.ILogger) ports.IOrderWorkflowUseCase {
+	return &UseCaseWorkflow{
+		repo:   repo,
+		logger: logger,
+	}
+}
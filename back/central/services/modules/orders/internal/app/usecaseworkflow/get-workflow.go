@@ -0,0 +1,29 @@
+package usecaseworkflow
+
+import (
+	"context"
+	"errors"
+
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/errors"
+)
+
+// GetWorkflow retorna el flujo del negocio; si no tiene uno propio retorna el
+// flujo por defecto con su BusinessID.
+func (uc *UseCaseWorkflow) GetWorkflow(ctx context.Context, businessID uint) (*entities.OrderWorkflow, error) {
+	workflow, err := uc.repo.GetOrderWorkflow(ctx, businessID)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrWorkflowNotFound) {
+			workflow = entities.DefaultOrderWorkflow()
+			workflow.BusinessID = businessID
+			return workflow, nil
+		}
+		return nil, err
+	}
+	return workflow, nil
+}
+
+// GetDefaultWorkflow retorna la plantilla por defecto
+func (uc *UseCaseWorkflow) GetDefaultWorkflow() *entities.OrderWorkflow {
+	return entities.DefaultOrderWorkflow()
+}
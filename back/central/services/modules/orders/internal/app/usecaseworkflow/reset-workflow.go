@@ -0,0 +1,23 @@
+package usecaseworkflow
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/entities"
+)
+
+// ResetWorkflow borra el flujo propio del negocio y retorna el flujo por
+// defecto, que es el que aplica desde ese momento.
+func (uc *UseCaseWorkflow) ResetWorkflow(ctx context.Context, businessID uint) (*entities.OrderWorkflow, error) {
+	if err := uc.repo.DeleteOrderWorkflow(ctx, businessID); err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info(ctx).
+		Uint("business_id", businessID).
+		Msg("Flujo de estados de ordenes restablecido al flujo por defecto")
+
+	workflow := entities.DefaultOrderWorkflow()
+	workflow.BusinessID = businessID
+	return workflow, nil
+}
@@ -0,0 +1,26 @@
+package usecaseworkflow
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/entities"
+)
+
+// ResolveWorkflow retorna el flujo que aplica a una orden. Nunca falla: si el
+// negocio no tiene flujo propio o no se pudo leer, aplica el flujo por defecto
+// para no bloquear los cambios de estado.
+func (uc *UseCaseWorkflow) ResolveWorkflow(ctx context.Context, businessID *uint) *entities.OrderWorkflow {
+	if businessID == nil || *businessID == 0 {
+		return entities.DefaultOrderWorkflow()
+	}
+
+	workflow, err := uc.GetWorkflow(ctx, *businessID)
+	if err != nil {
+		uc.logger.Warn(ctx).
+			Err(err).
+			Uint("business_id", *businessID).
+			Msg("No se pudo leer el flujo de estados del negocio, se usa el flujo por defecto")
+		return entities.DefaultOrderWorkflow()
+	}
+	return workflow
+}
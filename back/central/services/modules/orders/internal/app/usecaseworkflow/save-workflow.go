@@ -0,0 +1,37 @@
+package usecaseworkflow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/errors"
+)
+
+// SaveWorkflow valida y guarda el flujo propio del negocio. Si el flujo no es
+// valido retorna ErrInvalidWorkflow con la lista de problemas.
+func (uc *UseCaseWorkflow) SaveWorkflow(ctx context.Context, workflow *entities.OrderWorkflow, updatedBy *uint) (*entities.OrderWorkflow, error) {
+	if workflow == nil || workflow.BusinessID == 0 {
+		return nil, fmt.Errorf("%w: business_id is required", domainerrors.ErrInvalidWorkflow)
+	}
+
+	if problems := workflow.Validate(); len(problems) > 0 {
+		return nil, &domainerrors.WorkflowValidationError{Problems: problems}
+	}
+
+	workflow.IsDefault = false
+	if workflow.Name == "" {
+		workflow.Name = "custom"
+	}
+
+	if err := uc.repo.SaveOrderWorkflow(ctx, workflow, updatedBy); err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info(ctx).
+		Uint("business_id", workflow.BusinessID).
+		Int("transitions", len(workflow.Transitions)).
+		Msg("Flujo de estados de ordenes actualizado")
+
+	return workflow, nil
+}
@@ -126,6 +126,9 @@ type OrderResponse struct {
 	// Testing
 	IsTest bool
 
+	// Prioridad de preparacion en bodega
+	Priority int
+
 	// Facturación
 	Invoiceable     bool
 	InvoiceURL      *string
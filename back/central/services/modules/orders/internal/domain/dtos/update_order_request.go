@@ -48,6 +48,7 @@ type UpdateOrderRequest struct {
 	// Información de fulfillment
 	WarehouseID   *uint
 	WarehouseName *string
+	Priority      *int
 	DriverID      *uint
 	DriverName    *string
 	IsLastMile    *bool
@@ -129,6 +129,9 @@ type ProbabilityOrder struct {
 	// Testing
 	IsTest bool
 
+	// Prioridad de preparacion en bodega (mayor = antes)
+	Priority int
+
 	// Facturación
 	Invoiceable     bool
 	InvoiceURL      *string
@@ -0,0 +1,366 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Eventos que pueden disparar transiciones automaticas
+const (
+	// WorkflowEventInventoryReserved - inventario reservo el stock de la orden
+	WorkflowEventInventoryReserved = "inventory.reserved"
+
+	// WorkflowEventInventoryInsufficient - inventario no pudo reservar el stock
+	WorkflowEventInventoryInsufficient = "inventory.insufficient"
+
+	// WorkflowEventInventoryShortPick - la ola de picking no encontro todo lo de la orden
+	WorkflowEventInventoryShortPick = "inventory.short_pick"
+
+	// WorkflowEventInventoryPicked - la ola de picking recogio todo lo de la orden
+	WorkflowEventInventoryPicked = "inventory.picked"
+
+	// WorkflowEventInventoryPacked - la estacion de empaque verifico y cerro el paquete
+	WorkflowEventInventoryPacked = "inventory.packed"
+)
+
+var workflowEvents = map[string]bool{
+	WorkflowEventInventoryReserved:     true,
+	WorkflowEventInventoryInsufficient: true,
+	WorkflowEventInventoryShortPick:    true,
+	WorkflowEventInventoryPicked:       true,
+	WorkflowEventInventoryPacked:       true,
+}
+
+// Campos que una transicion puede exigir. Se leen de la metadata del cambio de
+// estado o, si ya estan en la orden, de la orden.
+const (
+	WorkflowFieldTrackingNumber = "tracking_number"
+	WorkflowFieldTrackingLink   = "tracking_link"
+	WorkflowFieldDriverID       = "driver_id"
+	WorkflowFieldDriverName     = "driver_name"
+	WorkflowFieldReason         = "reason"
+)
+
+var workflowFields = map[string]bool{
+	WorkflowFieldTrackingNumber: true,
+	WorkflowFieldTrackingLink:   true,
+	WorkflowFieldDriverID:       true,
+	WorkflowFieldDriverName:     true,
+	WorkflowFieldReason:         true,
+}
+
+// WorkflowTransition es una transicion permitida dentro del flujo de un negocio
+type WorkflowTransition struct {
+	From           OrderStatus
+	To             OrderStatus
+	RequiredFields []string
+}
+
+// WorkflowAutoTransition mueve la orden a To cuando llega Event. Si From esta
+// vacio aplica desde cualquier estado que tenga la transicion a To.
+type WorkflowAutoTransition struct {
+	Event string
+	From  []OrderStatus
+	To    OrderStatus
+}
+
+// OrderWorkflow es el flujo de estados de ordenes de un negocio. Los negocios
+// sin flujo propio usan DefaultOrderWorkflow.
+type OrderWorkflow struct {
+	BusinessID         uint
+	Name               string
+	EntryStatuses      []OrderStatus
+	Transitions        []WorkflowTransition
+	TerminalStatuses   []OrderStatus
+	AllowCancelFromAny bool
+	AutoTransitions    []WorkflowAutoTransition
+	IsDefault          bool
+}
+
+// DefaultOrderWorkflow arma el flujo por defecto a partir de validTransitions y
+// terminalStatuses, de modo que un negocio sin flujo propio se comporta igual
+// que CanTransitionTo.
+func DefaultOrderWorkflow() *OrderWorkflow {
+	froms := make([]string, 0, len(validTransitions))
+	for from := range validTransitions {
+		froms = append(froms, string(from))
+	}
+	sort.Strings(froms)
+
+	transitions := make([]WorkflowTransition, 0)
+	for _, from := range froms {
+		for _, to := range validTransitions[OrderStatus(from)] {
+			transitions = append(transitions, WorkflowTransition{From: OrderStatus(from), To: to})
+		}
+	}
+
+	terminals := make([]string, 0, len(terminalStatuses))
+	for status := range terminalStatuses {
+		terminals = append(terminals, string(status))
+	}
+	sort.Strings(terminals)
+	terminalList := make([]OrderStatus, 0, len(terminals))
+	for _, status := range terminals {
+		terminalList = append(terminalList, OrderStatus(status))
+	}
+
+	return &OrderWorkflow{
+		Name: "default",
+		// failed no tiene transiciones entrantes: lo escribe el sistema al fallar
+		// el procesamiento
+		EntryStatuses:      []OrderStatus{OrderStatusPending, OrderStatusFailed},
+		Transitions:        transitions,
+		TerminalStatuses:   terminalList,
+		AllowCancelFromAny: true,
+		AutoTransitions: []WorkflowAutoTransition{
+			{Event: WorkflowEventInventoryReserved, To: OrderStatusPicking},
+			{Event: WorkflowEventInventoryInsufficient, To: OrderStatusInventoryIssue},
+			{Event: WorkflowEventInventoryShortPick, From: []OrderStatus{OrderStatusPicking}, To: OrderStatusInventoryIssue},
+			{Event: WorkflowEventInventoryPicked, From: []OrderStatus{OrderStatusPicking}, To: OrderStatusPacking},
+			{Event: WorkflowEventInventoryPacked, From: []OrderStatus{OrderStatusPacking}, To: OrderStatusReadyToShip},
+		},
+		IsDefault: true,
+	}
+}
+
+// IsTerminal verifica si el estado es terminal en este flujo
+func (w *OrderWorkflow) IsTerminal(status OrderStatus) bool {
+	for _, terminal := range w.TerminalStatuses {
+		if terminal == status {
+			return true
+		}
+	}
+	return false
+}
+
+// CanTransition verifica si el flujo permite pasar de from a to
+func (w *OrderWorkflow) CanTransition(from, to OrderStatus) bool {
+	if w.IsTerminal(from) {
+		return false
+	}
+	if to == OrderStatusCancelled && w.AllowCancelFromAny {
+		return true
+	}
+	return w.transition(from, to) != nil
+}
+
+// RequiredFields retorna los campos que exige la transicion from -> to
+func (w *OrderWorkflow) RequiredFields(from, to OrderStatus) []string {
+	if t := w.transition(from, to); t != nil {
+		return t.RequiredFields
+	}
+	return nil
+}
+
+// AutoTransitionFor retorna el estado al que el evento mueve una orden que esta
+// en current. ok es false si el flujo no define transicion automatica para el
+// evento o si no aplica desde current.
+func (w *OrderWorkflow) AutoTransitionFor(event string, current OrderStatus) (OrderStatus, bool) {
+	for _, auto := range w.AutoTransitions {
+		if auto.Event != event {
+			continue
+		}
+		if len(auto.From) > 0 && !containsStatus(auto.From, current) {
+			continue
+		}
+		if current == auto.To || !w.CanTransition(current, auto.To) {
+			continue
+		}
+		return auto.To, true
+	}
+	return "", false
+}
+
+// Validate revisa que el flujo sea usable y retorna los problemas encontrados:
+// estados validos, todos los estados alcanzables desde un estado de entrada y
+// ningun callejon sin salida (todo estado no terminal llega a uno terminal).
+func (w *OrderWorkflow) Validate() []string {
+	var problems []string
+
+	if len(w.EntryStatuses) == 0 {
+		problems = append(problems, "el flujo debe tener al menos un estado de entrada")
+	}
+	if len(w.TerminalStatuses) == 0 {
+		problems = append(problems, "el flujo debe tener al menos un estado terminal")
+	}
+	if w.AllowCancelFromAny && !w.IsTerminal(OrderStatusCancelled) {
+		problems = append(problems, "si se permite cancelar desde cualquier estado, cancelled debe ser terminal")
+	}
+
+	for _, status := range w.statuses() {
+		if !status.IsValid() {
+			problems = append(problems, fmt.Sprintf("estado invalido: %s", status))
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, t := range w.Transitions {
+		key := string(t.From) + "->" + string(t.To)
+		if seen[key] {
+			problems = append(problems, fmt.Sprintf("transicion duplicada: %s", key))
+		}
+		seen[key] = true
+
+		if t.From == t.To {
+			problems = append(problems, fmt.Sprintf("transicion a si mismo: %s", key))
+		}
+		if w.IsTerminal(t.From) {
+			problems = append(problems, fmt.Sprintf("%s es terminal y no puede tener transiciones salientes", t.From))
+		}
+		for _, field := range t.RequiredFields {
+			if !workflowFields[field] {
+				problems = append(problems, fmt.Sprintf("campo requerido desconocido en %s: %s", key, field))
+			}
+		}
+	}
+
+	for _, auto := range w.AutoTransitions {
+		if !workflowEvents[auto.Event] {
+			problems = append(problems, fmt.Sprintf("evento desconocido en transicion automatica: %s", auto.Event))
+			continue
+		}
+		sources := auto.From
+		if len(sources) == 0 {
+			sources = w.sourcesOf(auto.To)
+		}
+		if len(sources) == 0 {
+			problems = append(problems, fmt.Sprintf("la transicion automatica de %s lleva a %s pero ningun estado transiciona a %s", auto.Event, auto.To, auto.To))
+		}
+		for _, from := range sources {
+			if !w.CanTransition(from, auto.To) {
+				problems = append(problems, fmt.Sprintf("la transicion automatica de %s usa %s->%s, que el flujo no permite", auto.Event, from, auto.To))
+				continue
+			}
+			if len(w.RequiredFields(from, auto.To)) > 0 {
+				problems = append(problems, fmt.Sprintf("la transicion automatica de %s usa %s->%s, que exige campos", auto.Event, from, auto.To))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return problems
+	}
+
+	reachable := w.reachableFrom(w.EntryStatuses)
+	for _, status := range w.statuses() {
+		if !reachable[status] {
+			problems = append(problems, fmt.Sprintf("%s no es alcanzable desde los estados de entrada", status))
+		}
+	}
+
+	exits := w.statusesReachingTerminal()
+	for _, status := range w.statuses() {
+		if reachable[status] && !w.IsTerminal(status) && !exits[status] {
+			problems = append(problems, fmt.Sprintf("%s es un callejon sin salida: no llega a ningun estado terminal", status))
+		}
+	}
+
+	return problems
+}
+
+func (w *OrderWorkflow) transition(from, to OrderStatus) *WorkflowTransition {
+	for i := range w.Transitions {
+		if w.Transitions[i].From == from && w.Transitions[i].To == to {
+			return &w.Transitions[i]
+		}
+	}
+	return nil
+}
+
+// next retorna los destinos de from, incluida la cancelacion si aplica
+func (w *OrderWorkflow) next(from OrderStatus) []OrderStatus {
+	var targets []OrderStatus
+	for _, t := range w.Transitions {
+		if t.From == from {
+			targets = append(targets, t.To)
+		}
+	}
+	if w.AllowCancelFromAny && !w.IsTerminal(from) && from != OrderStatusCancelled {
+		targets = append(targets, OrderStatusCancelled)
+	}
+	return targets
+}
+
+func (w *OrderWorkflow) sourcesOf(to OrderStatus) []OrderStatus {
+	var sources []OrderStatus
+	for _, t := range w.Transitions {
+		if t.To == to {
+			sources = append(sources, t.From)
+		}
+	}
+	return sources
+}
+
+// statuses retorna todos los estados que menciona el flujo, en orden estable
+func (w *OrderWorkflow) statuses() []OrderStatus {
+	seen := make(map[OrderStatus]bool)
+	var result []OrderStatus
+	add := func(status OrderStatus) {
+		if !seen[status] {
+			seen[status] = true
+			result = append(result, status)
+		}
+	}
+	for _, status := range w.EntryStatuses {
+		add(status)
+	}
+	for _, t := range w.Transitions {
+		add(t.From)
+		add(t.To)
+	}
+	for _, status := range w.TerminalStatuses {
+		add(status)
+	}
+	for _, auto := range w.AutoTransitions {
+		add(auto.To)
+	}
+	return result
+}
+
+func (w *OrderWorkflow) reachableFrom(entries []OrderStatus) map[OrderStatus]bool {
+	visited := make(map[OrderStatus]bool)
+	pending := append([]OrderStatus(nil), entries...)
+	for len(pending) > 0 {
+		current := pending[0]
+		pending = pending[1:]
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+		pending = append(pending, w.next(current)...)
+	}
+	return visited
+}
+
+// statusesReachingTerminal recorre el grafo al reves desde los terminales
+func (w *OrderWorkflow) statusesReachingTerminal() map[OrderStatus]bool {
+	reaches := make(map[OrderStatus]bool)
+	for _, status := range w.TerminalStatuses {
+		reaches[status] = true
+	}
+	for changed := true; changed; {
+		changed = false
+		for _, status := range w.statuses() {
+			if reaches[status] {
+				continue
+			}
+			for _, target := range w.next(status) {
+				if reaches[target] {
+					reaches[status] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	return reaches
+}
+
+func containsStatus(list []OrderStatus, status OrderStatus) bool {
+	for _, s := range list {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package entities
+
+import (
+	"strings"
+	"testing"
+)
+
+// dropshipWorkflow es un flujo sin bodega: la orden pasa de pendiente a en
+// camino con la guia del proveedor
+func dropshipWorkflow() *OrderWorkflow {
+	return &OrderWorkflow{
+		BusinessID:    7,
+		Name:          "dropshipping",
+		EntryStatuses: []OrderStatus{OrderStatusPending},
+		Transitions: []WorkflowTransition{
+			{From: OrderStatusPending, To: OrderStatusInTransit, RequiredFields: []string{WorkflowFieldTrackingNumber}},
+			{From: OrderStatusInTransit, To: OrderStatusDelivered},
+			{From: OrderStatusDelivered, To: OrderStatusCompleted},
+			{From: OrderStatusCompleted, To: OrderStatusRefunded},
+		},
+		TerminalStatuses:   []OrderStatus{OrderStatusCancelled, OrderStatusRefunded},
+		AllowCancelFromAny: true,
+	}
+}
+
+func TestDefaultOrderWorkflow_EquivaleAlMapaDeTransiciones(t *testing.T) {
+	workflow := DefaultOrderWorkflow()
+
+	for status := range validStatuses {
+		for target := range validStatuses {
+			want := status.CanTransitionTo(target)
+			got := workflow.CanTransition(status, target)
+			if got != want {
+				t.Errorf("%s -> %s = %v, el mapa dice %v", status, target, got, want)
+			}
+		}
+		if workflow.IsTerminal(status) != status.IsTerminal() {
+			t.Errorf("%s: terminal = %v, el mapa dice %v", status, workflow.IsTerminal(status), status.IsTerminal())
+		}
+	}
+}
+
+func TestDefaultOrderWorkflow_EsValido(t *testing.T) {
+	if problems := DefaultOrderWorkflow().Validate(); len(problems) > 0 {
+		t.Fatalf("el flujo por defecto no valida: %v", problems)
+	}
+}
+
+func TestDefaultOrderWorkflow_TransicionesAutomaticasDeInventario(t *testing.T) {
+	workflow := DefaultOrderWorkflow()
+
+	tests := []struct {
+		event  string
+		actual OrderStatus
+		want   OrderStatus
+		aplica bool
+	}{
+		{WorkflowEventInventoryReserved, OrderStatusPending, OrderStatusPicking, true},
+		{WorkflowEventInventoryReserved, OrderStatusInventoryIssue, OrderStatusPicking, true},
+		{WorkflowEventInventoryReserved, OrderStatusPicking, "", false},
+		{WorkflowEventInventoryReserved, OrderStatusDelivered, "", false},
+		{WorkflowEventInventoryInsufficient, OrderStatusPicking, OrderStatusInventoryIssue, true},
+		{WorkflowEventInventoryInsufficient, OrderStatusPending, "", false},
+		{WorkflowEventInventoryShortPick, OrderStatusPicking, OrderStatusInventoryIssue, true},
+		{WorkflowEventInventoryShortPick, OrderStatusPacking, "", false},
+		{WorkflowEventInventoryPicked, OrderStatusPicking, OrderStatusPacking, true},
+		{WorkflowEventInventoryPicked, OrderStatusPending, "", false},
+		{WorkflowEventInventoryPacked, OrderStatusPacking, OrderStatusReadyToShip, true},
+		{WorkflowEventInventoryPacked, OrderStatusPicking, "", false},
+		{"shipment.created", OrderStatusPending, "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := workflow.AutoTransitionFor(tt.event, tt.actual)
+		if ok != tt.aplica || got != tt.want {
+			t.Errorf("%s desde %s = (%s, %v), se esperaba (%s, %v)", tt.event, tt.actual, got, ok, tt.want, tt.aplica)
+		}
+	}
+}
+
+func TestOrderWorkflow_DropshipSaltaBodega(t *testing.T) {
+	workflow := dropshipWorkflow()
+
+	if problems := workflow.Validate(); len(problems) > 0 {
+		t.Fatalf("el flujo de dropshipping no valida: %v", problems)
+	}
+	if !workflow.CanTransition(OrderStatusPending, OrderStatusInTransit) {
+		t.Error("pending -> in_transit deberia estar permitido")
+	}
+	if workflow.CanTransition(OrderStatusPending, OrderStatusPicking) {
+		t.Error("pending -> picking no deberia estar permitido")
+	}
+	if !workflow.CanTransition(OrderStatusInTransit, OrderStatusCancelled) {
+		t.Error("cancelar desde in_transit deberia estar permitido")
+	}
+	fields := workflow.RequiredFields(OrderStatusPending, OrderStatusInTransit)
+	if len(fields) != 1 || fields[0] != WorkflowFieldTrackingNumber {
+		t.Errorf("campos requeridos = %v", fields)
+	}
+}
+
+func TestOrderWorkflow_Validate_Rechaza(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(w *OrderWorkflow)
+		espera string
+	}{
+		{
+			name: "callejon sin salida",
+			mutate: func(w *OrderWorkflow) {
+				w.AllowCancelFromAny = false
+				w.TerminalStatuses = []OrderStatus{OrderStatusRefunded}
+				w.Transitions = append(w.Transitions, WorkflowTransition{From: OrderStatusInTransit, To: OrderStatusDeliveryFailed})
+			},
+			espera: "delivery_failed es un callejon sin salida",
+		},
+		{
+			name: "ciclo sin salida",
+			mutate: func(w *OrderWorkflow) {
+				w.AllowCancelFromAny = false
+				w.TerminalStatuses = []OrderStatus{OrderStatusRefunded}
+				w.Transitions = append(w.Transitions,
+					WorkflowTransition{From: OrderStatusInTransit, To: OrderStatusDeliveryNovelty},
+					WorkflowTransition{From: OrderStatusDeliveryNovelty, To: OrderStatusOutForDelivery},
+					WorkflowTransition{From: OrderStatusOutForDelivery, To: OrderStatusDeliveryNovelty},
+				)
+			},
+			espera: "out_for_delivery es un callejon sin salida",
+		},
+		{
+			name: "estado inalcanzable",
+			mutate: func(w *OrderWorkflow) {
+				w.Transitions = append(w.Transitions, WorkflowTransition{From: OrderStatusPicking, To: OrderStatusInTransit})
+			},
+			espera: "picking no es alcanzable",
+		},
+		{
+			name: "transicion desde terminal",
+			mutate: func(w *OrderWorkflow) {
+				w.Transitions = append(w.Transitions, WorkflowTransition{From: OrderStatusRefunded, To: OrderStatusPending})
+			},
+			espera: "refunded es terminal",
+		},
+		{
+			name: "estado invalido",
+			mutate: func(w *OrderWorkflow) {
+				w.Transitions = append(w.Transitions, WorkflowTransition{From: OrderStatusInTransit, To: "teleported"})
+			},
+			espera: "estado invalido: teleported",
+		},
+		{
+			name: "campo requerido desconocido",
+			mutate: func(w *OrderWorkflow) {
+				w.Transitions[1].RequiredFields = []string{"signature"}
+			},
+			espera: "campo requerido desconocido",
+		},
+		{
+			name: "evento desconocido",
+			mutate: func(w *OrderWorkflow) {
+				w.AutoTransitions = []WorkflowAutoTransition{{Event: "carrier.scanned", To: OrderStatusInTransit}}
+			},
+			espera: "evento desconocido",
+		},
+		{
+			name: "automatica que exige campos",
+			mutate: func(w *OrderWorkflow) {
+				w.AutoTransitions = []WorkflowAutoTransition{{Event: WorkflowEventInventoryReserved, To: OrderStatusInTransit}}
+			},
+			espera: "que exige campos",
+		},
+		{
+			name: "sin estados de entrada",
+			mutate: func(w *OrderWorkflow) {
+				w.EntryStatuses = nil
+			},
+			espera: "al menos un estado de entrada",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workflow := dropshipWorkflow()
+			tt.mutate(workflow)
+
+			problems := workflow.Validate()
+			if !strings.Contains(strings.Join(problems, "\n"), tt.espera) {
+				t.Errorf("se esperaba un problema con %q, se obtuvo %v", tt.espera, problems)
+			}
+		})
+	}
+}
@@ -10,4 +10,7 @@ var (
 	ErrOrderInTerminalState = errors.New("order is in a terminal state and cannot be changed")
 	ErrInsufficientStock = errors.New("inventario insuficiente para mover la orden a Seleccionando productos")
 	ErrOrderBusinessDeleted = errors.New("business is deleted or does not exist")
+	ErrWorkflowNotFound = errors.New("order workflow not found")
+	ErrInvalidWorkflow = errors.New("invalid order workflow")
+	ErrWorkflowRequiredFields = errors.New("missing fields required by the order workflow")
 )
@@ -0,0 +1,36 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WorkflowValidationError lleva los problemas que encontro la validacion de un
+// flujo de estados. Es ErrInvalidWorkflow para errors.Is.
+type WorkflowValidationError struct {
+	Problems []string
+}
+
+func (e *WorkflowValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrInvalidWorkflow, strings.Join(e.Problems, "; "))
+}
+
+func (e *WorkflowValidationError) Unwrap() error {
+	return ErrInvalidWorkflow
+}
+
+// MissingWorkflowFieldsError indica los campos que exige la transicion y no
+// llegaron. Es ErrWorkflowRequiredFields para errors.Is.
+type MissingWorkflowFieldsError struct {
+	From   string
+	To     string
+	Fields []string
+}
+
+func (e *MissingWorkflowFieldsError) Error() string {
+	return fmt.Sprintf("%s: %s -> %s requires %s", ErrWorkflowRequiredFields, e.From, e.To, strings.Join(e.Fields, ", "))
+}
+
+func (e *MissingWorkflowFieldsError) Unwrap() error {
+	return ErrWorkflowRequiredFields
+}
@@ -73,6 +73,13 @@ type IRepository interface {
 	ResolveOrderGeozone(ctx context.Context, orderID string, businessID uint) error
 }
 
+// IWorkflowRepository persiste el flujo de estados propio de cada negocio
+type IWorkflowRepository interface {
+	GetOrderWorkflow(ctx context.Context, businessID uint) (*entities.OrderWorkflow, error)
+	SaveOrderWorkflow(ctx context.Context, workflow *entities.OrderWorkflow, updatedBy *uint) error
+	DeleteOrderWorkflow(ctx context.Context, businessID uint) error
+}
+
 type IOrderConsumer interface {
 	Start(ctx context.Context) error
 }
@@ -98,6 +105,20 @@ type IOrderStatusUseCase interface {
 	ChangeStatus(ctx context.Context, orderID string, req *dtos.ChangeStatusRequest) (*dtos.OrderResponse, error)
 }
 
+// IWorkflowResolver retorna el flujo que aplica a un negocio: el propio o, si
+// no tiene, el flujo por defecto.
+type IWorkflowResolver interface {
+	ResolveWorkflow(ctx context.Context, businessID *uint) *entities.OrderWorkflow
+}
+
+type IOrderWorkflowUseCase interface {
+	IWorkflowResolver
+	GetWorkflow(ctx context.Context, businessID uint) (*entities.OrderWorkflow, error)
+	GetDefaultWorkflow() *entities.OrderWorkflow
+	SaveWorkflow(ctx context.Context, workflow *entities.OrderWorkflow, updatedBy *uint) (*entities.OrderWorkflow, error)
+	ResetWorkflow(ctx context.Context, businessID uint) (*entities.OrderWorkflow, error)
+}
+
 type IOrderUseCase interface {
 	GetOrderByID(ctx context.Context, id string) (*dtos.OrderResponse, error)
 	GetOrderRaw(ctx context.Context, id string) (*dtos.OrderRawResponse, error)
@@ -124,6 +145,12 @@ type IOrderRabbitPublisher interface {
 	PublishOrderEvent(ctx context.Context, event *entities.OrderEvent, order *entities.ProbabilityOrder) error
 }
 
+// ITransactor abre una transaccion: lo que se escriba con el ctx que recibe fn
+// (cambios de la orden y eventos del outbox) se confirma o revierte junto.
+type ITransactor interface {
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type IInvoiceQueryPort interface {
 	GetInvoiceByOrderID(ctx context.Context, orderID string) (*dtos.InvoiceData, error)
 }
@@ -15,6 +15,7 @@ type Handlers struct {
 	requestConfirmationUC   ports.IRequestConfirmationUseCase
 	sendGuideNotificationUC ports.ISendGuideNotificationUseCase
 	statusUC                ports.IOrderStatusUseCase
+	workflowUC              ports.IOrderWorkflowUseCase
 	logger                  log.ILogger
 }
 
@@ -43,6 +44,7 @@ func New(
 	requestConfirmationUC ports.IRequestConfirmationUseCase,
 	sendGuideNotificationUC ports.ISendGuideNotificationUseCase,
 	statusUC ports.IOrderStatusUseCase,
+	workflowUC ports.IOrderWorkflowUseCase,
 	logger log.ILogger,
 ) *Handlers {
 	return &Handlers{
@@ -51,6 +53,7 @@ func New(
 		requestConfirmationUC:   requestConfirmationUC,
 		sendGuideNotificationUC: sendGuideNotificationUC,
 		statusUC:                statusUC,
+		workflowUC:              workflowUC,
 		logger:                  logger,
 	}
 }
@@ -44,6 +44,7 @@ func MapUpdateOrderRequestToDomain(req *request.UpdateOrder) *dtos.UpdateOrderRe
 		DeliveredAt:        req.DeliveredAt,
 		WarehouseID:        req.WarehouseID,
 		WarehouseName:      req.WarehouseName,
+		Priority:           req.Priority,
 		DriverID:           req.DriverID,
 		DriverName:         req.DriverName,
 		IsLastMile:         req.IsLastMile,
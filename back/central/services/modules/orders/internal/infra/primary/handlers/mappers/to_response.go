@@ -165,6 +165,7 @@ func OrderToResponse(dto *dtos.OrderResponse) *response.Order {
 		IsConfirmed:                 dto.IsConfirmed,
 		Novelty:                     dto.Novelty,
 		IsTest:                      dto.IsTest,
+		Priority:                    dto.Priority,
 		Invoiceable:                 dto.Invoiceable,
 		InvoiceURL:                  dto.InvoiceURL,
 		InvoiceID:                   dto.InvoiceID,
@@ -0,0 +1,78 @@
+package mappers
+
+import (
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/infra/primary/handlers/response"
+)
+
+// WorkflowToDomain convierte la petición HTTP al flujo de dominio
+func WorkflowToDomain(businessID uint, req *request.SaveWorkflow) *entities.OrderWorkflow {
+	workflow := &entities.OrderWorkflow{
+		BusinessID:         businessID,
+		Name:               req.Name,
+		EntryStatuses:      toStatuses(req.EntryStatuses),
+		TerminalStatuses:   toStatuses(req.TerminalStatuses),
+		AllowCancelFromAny: req.AllowCancelFromAny,
+	}
+	for _, t := range req.Transitions {
+		workflow.Transitions = append(workflow.Transitions, entities.WorkflowTransition{
+			From:           entities.OrderStatus(t.From),
+			To:             entities.OrderStatus(t.To),
+			RequiredFields: t.RequiredFields,
+		})
+	}
+	for _, a := range req.AutoTransitions {
+		workflow.AutoTransitions = append(workflow.AutoTransitions, entities.WorkflowAutoTransition{
+			Event: a.Event,
+			From:  toStatuses(a.From),
+			To:    entities.OrderStatus(a.To),
+		})
+	}
+	return workflow
+}
+
+// WorkflowToResponse convierte el flujo de dominio a la respuesta HTTP
+func WorkflowToResponse(workflow *entities.OrderWorkflow) response.Workflow {
+	resp := response.Workflow{
+		BusinessID:         workflow.BusinessID,
+		Name:               workflow.Name,
+		IsDefault:          workflow.IsDefault,
+		EntryStatuses:      fromStatuses(workflow.EntryStatuses),
+		Transitions:        make([]response.WorkflowTransition, 0, len(workflow.Transitions)),
+		TerminalStatuses:   fromStatuses(workflow.TerminalStatuses),
+		AllowCancelFromAny: workflow.AllowCancelFromAny,
+		AutoTransitions:    make([]response.WorkflowAutoTransition, 0, len(workflow.AutoTransitions)),
+	}
+	for _, t := range workflow.Transitions {
+		resp.Transitions = append(resp.Transitions, response.WorkflowTransition{
+			From:           string(t.From),
+			To:             string(t.To),
+			RequiredFields: t.RequiredFields,
+		})
+	}
+	for _, a := range workflow.AutoTransitions {
+		resp.AutoTransitions = append(resp.AutoTransitions, response.WorkflowAutoTransition{
+			Event: a.Event,
+			From:  fromStatuses(a.From),
+			To:    string(a.To),
+		})
+	}
+	return resp
+}
+
+func toStatuses(values []string) []entities.OrderStatus {
+	statuses := make([]entities.OrderStatus, 0, len(values))
+	for _, v := range values {
+		statuses = append(statuses, entities.OrderStatus(v))
+	}
+	return statuses
+}
+
+func fromStatuses(statuses []entities.OrderStatus) []string {
+	values := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		values = append(values, string(s))
+	}
+	return values
+}
@@ -52,6 +52,7 @@ type UpdateOrder struct {
 	// Información de fulfillment
 	WarehouseID   *uint   `json:"warehouse_id"`
 	WarehouseName *string `json:"warehouse_name" binding:"omitempty,max=128"`
+	Priority      *int    `json:"priority" binding:"omitempty,min=0"`
 	DriverID      *uint   `json:"driver_id"`
 	DriverName    *string `json:"driver_name" binding:"omitempty,max=255"`
 	IsLastMile    *bool   `json:"is_last_mile"`
@@ -0,0 +1,26 @@
+package request
+
+// SaveWorkflow representa la petición HTTP para guardar el flujo de estados
+// de ordenes de un negocio
+type SaveWorkflow struct {
+	Name               string                   `json:"name" binding:"max=100"`
+	EntryStatuses      []string                 `json:"entry_statuses" binding:"required,min=1"`
+	Transitions        []WorkflowTransition     `json:"transitions" binding:"required,min=1,dive"`
+	TerminalStatuses   []string                 `json:"terminal_statuses" binding:"required,min=1"`
+	AllowCancelFromAny bool                     `json:"allow_cancel_from_any"`
+	AutoTransitions    []WorkflowAutoTransition `json:"auto_transitions" binding:"dive"`
+}
+
+// WorkflowTransition es una transicion permitida y los campos que exige
+type WorkflowTransition struct {
+	From           string   `json:"from" binding:"required"`
+	To             string   `json:"to" binding:"required"`
+	RequiredFields []string `json:"required_fields"`
+}
+
+// WorkflowAutoTransition mueve la orden a To cuando llega Event
+type WorkflowAutoTransition struct {
+	Event string   `json:"event" binding:"required"`
+	From  []string `json:"from"`
+	To    string   `json:"to" binding:"required"`
+}
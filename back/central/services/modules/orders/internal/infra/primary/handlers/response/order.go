@@ -126,6 +126,9 @@ type Order struct {
 	// Testing
 	IsTest bool `json:"is_test"`
 
+	// Prioridad de preparacion en bodega
+	Priority int `json:"priority"`
+
 	// Facturación
 	Invoiceable     bool    `json:"invoiceable"`
 	InvoiceURL      *string `json:"invoice_url,omitempty"`
@@ -0,0 +1,31 @@
+package response
+
+// Workflow representa el flujo de estados de ordenes de un negocio
+type Workflow struct {
+	BusinessID         uint                     `json:"business_id,omitempty"`
+	Name               string                   `json:"name"`
+	IsDefault          bool                     `json:"is_default"`
+	EntryStatuses      []string                 `json:"entry_statuses"`
+	Transitions        []WorkflowTransition     `json:"transitions"`
+	TerminalStatuses   []string                 `json:"terminal_statuses"`
+	AllowCancelFromAny bool                     `json:"allow_cancel_from_any"`
+	AutoTransitions    []WorkflowAutoTransition `json:"auto_transitions"`
+}
+
+type WorkflowTransition struct {
+	From           string   `json:"from"`
+	To             string   `json:"to"`
+	RequiredFields []string `json:"required_fields,omitempty"`
+}
+
+type WorkflowAutoTransition struct {
+	Event string   `json:"event"`
+	From  []string `json:"from,omitempty"`
+	To    string   `json:"to"`
+}
+
+// WorkflowValidationError lista los problemas por los que no se guardo el flujo
+type WorkflowValidationError struct {
+	Error    string   `json:"error"`
+	Problems []string `json:"problems"`
+}
@@ -5,23 +5,33 @@ import (
 	"github.com/secamc93/probability/back/central/services/auth/middleware"
 )
 
+// ordersResource es el recurso de permisos con el que se otorgan las API Keys
+const ordersResource = "Ordenes"
+
 // RegisterRoutes registra todas las rutas del módulo orders
 func (h *Handlers) RegisterRoutes(router *gin.RouterGroup) {
 	orders := router.Group("/orders")
 	{
-		// CRUD básico
-		orders.GET("", middleware.JWT(), h.ListOrders)
-		orders.GET("/:id", middleware.JWT(), h.GetOrderByID)
-		orders.GET("/:id/raw", middleware.JWT(), h.GetOrderRaw)
-		orders.GET("/:id/history", middleware.JWT(), h.GetOrderHistory)
-		orders.POST("", middleware.JWT(), h.CreateOrder)
+		// Flujo de estados del negocio
+		orders.GET("/workflow", middleware.JWT(), h.GetWorkflow)
+		orders.GET("/workflow/default", middleware.JWT(), h.GetDefaultWorkflow)
+		orders.PUT("/workflow", middleware.JWT(), h.SaveWorkflow)
+		orders.DELETE("/workflow", middleware.JWT(), h.ResetWorkflow)
+
+		// CRUD básico. Las integraciones entran con API Key y el permiso de
+		// Ordenes que corresponde; el resto del módulo sigue solo con sesión
+		orders.GET("", middleware.Auto(), middleware.RequirePermission(ordersResource, "Read"), h.ListOrders)
+		orders.GET("/:id", middleware.Auto(), middleware.RequirePermission(ordersResource, "Read"), h.GetOrderByID)
+		orders.GET("/:id/raw", middleware.Auto(), middleware.RequirePermission(ordersResource, "Read"), h.GetOrderRaw)
+		orders.GET("/:id/history", middleware.Auto(), middleware.RequirePermission(ordersResource, "Read"), h.GetOrderHistory)
+		orders.POST("", middleware.Auto(), middleware.RequirePermission(ordersResource, "Create"), h.CreateOrder)
 		orders.POST("/upload-bulk", middleware.JWT(), h.UploadBulkOrders)
-		orders.PUT("/:id", middleware.JWT(), h.UpdateOrder)
-		orders.PUT("/:id/status", middleware.JWT(), h.ChangeStatus)
-		orders.DELETE("/:id", middleware.JWT(), h.DeleteOrder)
+		orders.PUT("/:id", middleware.Auto(), middleware.RequirePermission(ordersResource, "Update"), h.UpdateOrder)
+		orders.PUT("/:id/status", middleware.Auto(), middleware.RequirePermission(ordersResource, "Update"), h.ChangeStatus)
+		orders.DELETE("/:id", middleware.Auto(), middleware.RequirePermission(ordersResource, "Delete"), h.DeleteOrder)
 
 		// Mapeo de órdenes canónicas (para integraciones)
-		orders.POST("/map", middleware.JWT(), h.MapAndSaveOrder)
+		orders.POST("/map", middleware.Auto(), middleware.RequirePermission(ordersResource, "Create"), h.MapAndSaveOrder)
 
 		// Confirmación de órdenes vía WhatsApp
 		orders.POST("/:id/request-confirmation", middleware.JWT(), h.RequestConfirmation)
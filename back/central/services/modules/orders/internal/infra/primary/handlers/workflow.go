@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/infra/primary/handlers/mappers"
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/infra/primary/handlers/response"
+)
+
+// GetWorkflow maneja la petición GET /orders/workflow
+func (h *Handlers) GetWorkflow(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+
+	workflow, err := h.workflowUC.GetWorkflow(c.Request.Context(), businessID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.WorkflowToResponse(workflow))
+}
+
+// GetDefaultWorkflow maneja la petición GET /orders/workflow/default
+func (h *Handlers) GetDefaultWorkflow(c *gin.Context) {
+	c.JSON(http.StatusOK, mappers.WorkflowToResponse(h.workflowUC.GetDefaultWorkflow()))
+}
+
+// SaveWorkflow maneja la petición PUT /orders/workflow
+func (h *Handlers) SaveWorkflow(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+
+	var req request.SaveWorkflow
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request: " + err.Error()})
+		return
+	}
+
+	var userID *uint
+	if uid, exists := c.Get("user_id"); exists {
+		if id, ok := uid.(uint); ok {
+			userID = &id
+		}
+	}
+
+	workflow, err := h.workflowUC.SaveWorkflow(c.Request.Context(), mappers.WorkflowToDomain(businessID, &req), userID)
+	if err != nil {
+		var validationErr *domainerrors.WorkflowValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			c.JSON(http.StatusBadRequest, response.WorkflowValidationError{
+				Error:    domainerrors.ErrInvalidWorkflow.Error(),
+				Problems: validationErr.Problems,
+			})
+		case errors.Is(err, domainerrors.ErrInvalidWorkflow):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.WorkflowToResponse(workflow))
+}
+
+// ResetWorkflow maneja la petición DELETE /orders/workflow: el negocio vuelve
+// al flujo por defecto
+func (h *Handlers) ResetWorkflow(c *gin.Context) {
+	businessID, ok := h.resolveBusinessID(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+
+	workflow, err := h.workflowUC.ResetWorkflow(c.Request.Context(), businessID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.WorkflowToResponse(workflow))
+}
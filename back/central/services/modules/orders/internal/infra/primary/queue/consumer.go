@@ -51,7 +51,7 @@ func (c *OrderConsumer) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to declare queue: %w", err)
 	}
 
-	if err := c.queue.Consume(ctx, OrdersCanonicalQueueName, c.handleMessage); err != nil {
+	if err := rabbitmq.ConsumeDeliveries(ctx, c.queue, OrdersCanonicalQueueName, c.handleMessage, 1); err != nil {
 		c.logger.Error().
 			Err(err).
 			Str("queue", OrdersCanonicalQueueName).
@@ -62,11 +62,15 @@ func (c *OrderConsumer) Start(ctx context.Context) error {
 	return nil
 }
 
-func (c *OrderConsumer) handleMessage(messageBody []byte) error {
-	ctx := context.Background()
+func (c *OrderConsumer) handleMessage(msgCtx context.Context, d rabbitmq.Delivery) error {
+	// Sin cancelacion: el apagado no debe cortar una orden a medio guardar,
+	// pero si conservar la correlacion del mensaje.
+	ctx := context.WithoutCancel(msgCtx)
+	messageBody := d.Body
 
-	c.logger.Debug().
+	c.logger.Debug(ctx).
 		Str("queue", OrdersCanonicalQueueName).
+		Str("message_id", d.MessageID).
 		Int("message_size", len(messageBody)).
 		Msg("Processing order message from queue")
 
@@ -21,14 +21,16 @@ type InventoryConsumer struct {
 	queue           rabbitmq.IQueue
 	repo            ports.IRepository
 	rabbitPublisher ports.IOrderRabbitPublisher
+	workflows       ports.IWorkflowResolver
 	logger          log.ILogger
 }
 
-func NewInventoryConsumer(queue rabbitmq.IQueue, repo ports.IRepository, rabbitPublisher ports.IOrderRabbitPublisher, logger log.ILogger) *InventoryConsumer {
+func NewInventoryConsumer(queue rabbitmq.IQueue, repo ports.IRepository, rabbitPublisher ports.IOrderRabbitPublisher, workflows ports.IWorkflowResolver, logger log.ILogger) *InventoryConsumer {
 	return &InventoryConsumer{
 		queue:           queue,
 		repo:            repo,
 		rabbitPublisher: rabbitPublisher,
+		workflows:       workflows,
 		logger:          logger.WithModule("orders.inventory.consumer"),
 	}
 }
@@ -68,22 +70,12 @@ func (c *InventoryConsumer) handleMessage(ctx context.Context, body []byte) {
 		return
 	}
 
-	targetCode := "picking"
-	if !msg.Success {
-		targetCode = "inventory_issue"
-	}
-
-	c.logger.Info(ctx).
-		Str("order_id", msg.OrderID).
-		Uint("business_id", msg.BusinessID).
-		Bool("success", msg.Success).
-		Str("target_status", targetCode).
-		Msg("Inventory feedback received - updating order status")
-
-	statusID, err := c.repo.GetOrderStatusIDByCode(ctx, targetCode)
-	if err != nil || statusID == nil {
-		c.logger.Warn(ctx).Str("order_id", msg.OrderID).Str("status", targetCode).Msg("status not found, skipping")
-		return
+	event := msg.EventType
+	if event == "" {
+		event = entities.WorkflowEventInventoryReserved
+		if !msg.Success {
+			event = entities.WorkflowEventInventoryInsufficient
+		}
 	}
 
 	order, err := c.repo.GetOrderByID(ctx, msg.OrderID)
@@ -92,17 +84,31 @@ func (c *InventoryConsumer) handleMessage(ctx context.Context, body []byte) {
 		return
 	}
 
+	// El flujo del negocio decide a que estado mueve el evento; un flujo sin
+	// picking (dropshipping) simplemente no define la transicion automatica
 	previousStatus := order.Status
-	if previousStatus == targetCode {
-		return
-	}
-
-	if !entities.OrderStatus(previousStatus).CanTransitionTo(entities.OrderStatus(targetCode)) {
+	workflow := c.workflows.ResolveWorkflow(ctx, order.BusinessID)
+	target, ok := workflow.AutoTransitionFor(event, entities.OrderStatus(previousStatus))
+	if !ok {
 		c.logger.Info(ctx).
 			Str("order_id", msg.OrderID).
 			Str("current_status", previousStatus).
-			Str("target_status", targetCode).
-			Msg("Inventory feedback ignored: the order already moved past the picking stage")
+			Str("event", event).
+			Msg("Inventory feedback ignored: the order workflow has no transition for this event from the current status")
+		return
+	}
+	targetCode := target.String()
+
+	c.logger.Info(ctx).
+		Str("order_id", msg.OrderID).
+		Uint("business_id", msg.BusinessID).
+		Bool("success", msg.Success).
+		Str("target_status", targetCode).
+		Msg("Inventory feedback received - updating order status")
+
+	statusID, err := c.repo.GetOrderStatusIDByCode(ctx, targetCode)
+	if err != nil || statusID == nil {
+		c.logger.Warn(ctx).Str("order_id", msg.OrderID).Str("status", targetCode).Msg("status not found, skipping")
 		return
 	}
 
@@ -13,23 +13,48 @@ import (
 	"github.com/secamc93/probability/back/central/services/modules/orders/internal/infra/secondary/queue/mappers"
 	"github.com/secamc93/probability/back/central/services/modules/orders/internal/infra/secondary/queue/response"
 	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/outbox"
 	"github.com/secamc93/probability/back/central/shared/rabbitmq"
 )
 
 var itemsWhitespace = regexp.MustCompile(`\s+`)
 
+// OrderRabbitPublisher publica los eventos de orden. Con outbox, los eventos se
+// escriben en outbox_events (dentro de la transaccion del ctx, si hay una) y el
+// relay los publica; sin outbox van directo al broker.
 type OrderRabbitPublisher struct {
 	rabbit rabbitmq.IQueue
+	outbox outbox.IWriter
 	log    log.ILogger
 }
 
-func NewOrderRabbitPublisher(rabbit rabbitmq.IQueue, logger log.ILogger) ports.IOrderRabbitPublisher {
+func NewOrderRabbitPublisher(rabbit rabbitmq.IQueue, writer outbox.IWriter, logger log.ILogger) ports.IOrderRabbitPublisher {
 	return &OrderRabbitPublisher{
 		rabbit: rabbit,
+		outbox: writer,
 		log:    logger,
 	}
 }
 
+// send entrega el mensaje al outbox o, si no hay outbox, directo al broker.
+func (p *OrderRabbitPublisher) send(ctx context.Context, msg outbox.Message) error {
+	if p.outbox != nil {
+		return p.outbox.Enqueue(ctx, msg)
+	}
+	if msg.Exchange == "" {
+		return p.rabbit.Publish(ctx, msg.RoutingKey, msg.Payload)
+	}
+	return p.rabbit.PublishToExchange(ctx, msg.Exchange, msg.RoutingKey, msg.Payload)
+}
+
+func orderOutboxMessage(msg outbox.Message, order *entities.ProbabilityOrder) outbox.Message {
+	msg = msg.WithAggregate("order", order.ID)
+	if order.BusinessID != nil {
+		msg = msg.WithBusiness(*order.BusinessID)
+	}
+	return msg
+}
+
 func (p *OrderRabbitPublisher) PublishOrderCreated(ctx context.Context, order *entities.ProbabilityOrder) error {
 	message := &response.OrderEventMessage{
 		EventID:       mappers.GenerateEventID(),
@@ -154,7 +179,8 @@ func (p *OrderRabbitPublisher) PublishConfirmationRequested(ctx context.Context,
 		return fmt.Errorf("error marshaling event: %w", err)
 	}
 
-	if err := p.rabbit.Publish(ctx, rabbitmq.QueueOrdersConfirmationRequested, payload); err != nil {
+	msg := orderOutboxMessage(outbox.ToQueue(rabbitmq.QueueOrdersConfirmationRequested, "order.confirmation_requested", payload), order)
+	if err := p.send(ctx, msg); err != nil {
 		p.log.Error().
 			Err(err).
 			Str("order_id", order.ID).
@@ -244,7 +270,8 @@ func (p *OrderRabbitPublisher) PublishGuideNotificationRequested(ctx context.Con
 		return fmt.Errorf("error marshaling event: %w", err)
 	}
 
-	if err := p.rabbit.Publish(ctx, rabbitmq.QueueShipmentsWhatsAppGuideNotification, payload); err != nil {
+	msg := orderOutboxMessage(outbox.ToQueue(rabbitmq.QueueShipmentsWhatsAppGuideNotification, "order.guide_notification_requested", payload), order)
+	if err := p.send(ctx, msg); err != nil {
 		p.log.Error().
 			Err(err).
 			Str("order_id", order.ID).
@@ -282,7 +309,12 @@ func (p *OrderRabbitPublisher) publishToQueue(ctx context.Context, _ string, mes
 		Int("payload_size", len(payload)).
 		Msg("Publishing order event to exchange (fanout distribution)")
 
-	if err := p.rabbit.PublishToExchange(ctx, rabbitmq.ExchangeOrderEvents, routingKey, payload); err != nil {
+	msg := outbox.ToExchange(rabbitmq.ExchangeOrderEvents, routingKey, message.EventType, payload).
+		WithAggregate("order", message.OrderID)
+	if message.BusinessID != nil {
+		msg = msg.WithBusiness(*message.BusinessID)
+	}
+	if err := p.send(ctx, msg); err != nil {
 		p.log.Error().
 			Err(err).
 			Str("order_id", message.OrderID).
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/shared/outbox"
 	"github.com/secamc93/probability/back/central/shared/rabbitmq"
 	"github.com/secamc93/probability/back/central/shared/testkit"
 )
@@ -38,7 +39,7 @@ func orden() *entities.ProbabilityOrder {
 }
 
 func nuevoPublisher(q *testkit.QueueMock) *OrderRabbitPublisher {
-	return NewOrderRabbitPublisher(q, testkit.NewSilentLogger()).(*OrderRabbitPublisher)
+	return NewOrderRabbitPublisher(q, nil, testkit.NewSilentLogger()).(*OrderRabbitPublisher)
 }
 
 func cuerpo(t *testing.T, p testkit.Publicacion) map[string]any {
@@ -447,3 +448,36 @@ func TestPublicaciones_NingunaEsperaConfirmacionDelBroker(t *testing.T) {
 		"ningun metodo declara la cola ni usa publisher confirms: un nil aqui significa 'se entrego al canal', no 'el broker lo persistio'")
 	assert.Empty(t, q.Declaradas)
 }
+
+type outboxFalso struct {
+	encolados []outbox.Message
+}
+
+func (o *outboxFalso) Enqueue(ctx context.Context, msgs ...outbox.Message) error {
+	o.encolados = append(o.encolados, msgs...)
+	return nil
+}
+
+func TestPublicaciones_ConOutboxSeEncolanYNoTocanElBroker(t *testing.T) {
+	q := &testkit.QueueMock{}
+	ob := &outboxFalso{}
+	p := NewOrderRabbitPublisher(q, ob, testkit.NewSilentLogger())
+
+	require.NoError(t, p.PublishOrderCreated(context.Background(), orden()))
+	require.NoError(t, p.PublishConfirmationRequested(context.Background(), orden()))
+
+	assert.Empty(t, q.Publicados, "con outbox el relay es el unico que habla con el broker")
+	require.Len(t, ob.encolados, 2)
+
+	creada := ob.encolados[0]
+	assert.Equal(t, rabbitmq.ExchangeOrderEvents, creada.Exchange)
+	assert.Equal(t, "order.created", creada.EventType)
+	assert.Equal(t, "order", creada.AggregateType)
+	assert.Equal(t, "ord-1", creada.AggregateID)
+	require.NotNil(t, creada.BusinessID)
+	assert.Equal(t, uint(26), *creada.BusinessID)
+
+	confirmacion := ob.encolados[1]
+	assert.Empty(t, confirmacion.Exchange)
+	assert.Equal(t, rabbitmq.QueueOrdersConfirmationRequested, confirmacion.RoutingKey)
+}
@@ -105,6 +105,7 @@ func ToDBOrder(o *entities.ProbabilityOrder) *models.Order {
 		IsConfirmed:                 o.IsConfirmed,
 		Novelty:                     o.Novelty,
 		IsTest:                      o.IsTest,
+		Priority:                    o.Priority,
 		Invoiceable:                 o.Invoiceable,
 		InvoiceURL:                  o.InvoiceURL,
 		InvoiceID:                   o.InvoiceID,
@@ -238,6 +239,7 @@ func ToDomainOrder(o *models.Order, imageURLBase string) *entities.ProbabilityOr
 		IsConfirmed:                 o.IsConfirmed,
 		Novelty:                     o.Novelty,
 		IsTest:                      o.IsTest,
+		Priority:                    o.Priority,
 		Invoiceable:                 o.Invoiceable,
 		InvoiceURL:                  o.InvoiceURL,
 		InvoiceID:                   o.InvoiceID,
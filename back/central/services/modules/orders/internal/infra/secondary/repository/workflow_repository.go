@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/shared/db"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WorkflowRepository guarda los flujos de estados por negocio en order_workflows
+type WorkflowRepository struct {
+	db db.IDatabase
+}
+
+func NewWorkflowRepository(database db.IDatabase) ports.IWorkflowRepository {
+	return &WorkflowRepository{db: database}
+}
+
+// workflowDefinition es la forma en que el flujo se guarda en la columna jsonb
+type workflowDefinition struct {
+	EntryStatuses      []string                 `json:"entry_statuses"`
+	Transitions        []workflowTransitionJSON `json:"transitions"`
+	TerminalStatuses   []string                 `json:"terminal_statuses"`
+	AllowCancelFromAny bool                     `json:"allow_cancel_from_any"`
+	AutoTransitions    []workflowAutoJSON       `json:"auto_transitions"`
+}
+
+type workflowTransitionJSON struct {
+	From           string   `json:"from"`
+	To             string   `json:"to"`
+	RequiredFields []string `json:"required_fields,omitempty"`
+}
+
+type workflowAutoJSON struct {
+	Event string   `json:"event"`
+	From  []string `json:"from,omitempty"`
+	To    string   `json:"to"`
+}
+
+// GetOrderWorkflow retorna el flujo propio del negocio o ErrWorkflowNotFound
+func (r *WorkflowRepository) GetOrderWorkflow(ctx context.Context, businessID uint) (*entities.OrderWorkflow, error) {
+	var model models.OrderWorkflow
+	err := r.db.Conn(ctx).Where("business_id = ?", businessID).First(&model).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrWorkflowNotFound
+		}
+		return nil, fmt.Errorf("error getting order workflow: %w", err)
+	}
+
+	var def workflowDefinition
+	if err := json.Unmarshal(model.Definition, &def); err != nil {
+		return nil, fmt.Errorf("error decoding order workflow: %w", err)
+	}
+
+	return definitionToEntity(model.BusinessID, model.Name, def), nil
+}
+
+// SaveOrderWorkflow crea o reemplaza el flujo del negocio
+func (r *WorkflowRepository) SaveOrderWorkflow(ctx context.Context, workflow *entities.OrderWorkflow, updatedBy *uint) error {
+	definition, err := json.Marshal(entityToDefinition(workflow))
+	if err != nil {
+		return fmt.Errorf("error encoding order workflow: %w", err)
+	}
+
+	model := models.OrderWorkflow{
+		BusinessID:  workflow.BusinessID,
+		Name:        workflow.Name,
+		Definition:  datatypes.JSON(definition),
+		UpdatedByID: updatedBy,
+	}
+
+	err = r.db.Conn(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "business_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "definition", "updated_by_id", "updated_at"}),
+	}).Create(&model).Error
+	if err != nil {
+		return fmt.Errorf("error saving order workflow: %w", err)
+	}
+	return nil
+}
+
+// DeleteOrderWorkflow borra el flujo propio; el negocio vuelve al flujo por
+// defecto. Se borra de verdad para no chocar con el indice unico de business_id.
+func (r *WorkflowRepository) DeleteOrderWorkflow(ctx context.Context, businessID uint) error {
+	err := r.db.Conn(ctx).Unscoped().Where("business_id = ?", businessID).Delete(&models.OrderWorkflow{}).Error
+	if err != nil {
+		return fmt.Errorf("error deleting order workflow: %w", err)
+	}
+	return nil
+}
+
+func definitionToEntity(businessID uint, name string, def workflowDefinition) *entities.OrderWorkflow {
+	workflow := &entities.OrderWorkflow{
+		BusinessID:         businessID,
+		Name:               name,
+		EntryStatuses:      toStatuses(def.EntryStatuses),
+		TerminalStatuses:   toStatuses(def.TerminalStatuses),
+		AllowCancelFromAny: def.AllowCancelFromAny,
+	}
+	for _, t := range def.Transitions {
+		workflow.Transitions = append(workflow.Transitions, entities.WorkflowTransition{
+			From:           entities.OrderStatus(t.From),
+			To:             entities.OrderStatus(t.To),
+			RequiredFields: t.RequiredFields,
+		})
+	}
+	for _, a := range def.AutoTransitions {
+		workflow.AutoTransitions = append(workflow.AutoTransitions, entities.WorkflowAutoTransition{
+			Event: a.Event,
+			From:  toStatuses(a.From),
+			To:    entities.OrderStatus(a.To),
+		})
+	}
+	return workflow
+}
+
+func entityToDefinition(workflow *entities.OrderWorkflow) workflowDefinition {
+	def := workflowDefinition{
+		EntryStatuses:      fromStatuses(workflow.EntryStatuses),
+		TerminalStatuses:   fromStatuses(workflow.TerminalStatuses),
+		AllowCancelFromAny: workflow.AllowCancelFromAny,
+	}
+	for _, t := range workflow.Transitions {
+		def.Transitions = append(def.Transitions, workflowTransitionJSON{
+			From:           string(t.From),
+			To:             string(t.To),
+			RequiredFields: t.RequiredFields,
+		})
+	}
+	for _, a := range workflow.AutoTransitions {
+		def.AutoTransitions = append(def.AutoTransitions, workflowAutoJSON{
+			Event: a.Event,
+			From:  fromStatuses(a.From),
+			To:    string(a.To),
+		})
+	}
+	return def
+}
+
+func toStatuses(values []string) []entities.OrderStatus {
+	if len(values) == 0 {
+		return nil
+	}
+	statuses := make([]entities.OrderStatus, 0, len(values))
+	for _, v := range values {
+		statuses = append(statuses, entities.OrderStatus(v))
+	}
+	return statuses
+}
+
+func fromStatuses(statuses []entities.OrderStatus) []string {
+	if len(statuses) == 0 {
+		return nil
+	}
+	values := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		values = append(values, string(s))
+	}
+	return values
+}
@@ -0,0 +1,17 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/orders/internal/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type WorkflowResolverMock struct {
+	mock.Mock
+}
+
+func (m *WorkflowResolverMock) ResolveWorkflow(ctx context.Context, businessID *uint) *entities.OrderWorkflow {
+	args := m.Called(ctx, businessID)
+	return args.Get(0).(*entities.OrderWorkflow)
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/secamc93/probability/back/central/shared/db"
 	"github.com/secamc93/probability/back/central/shared/env"
 	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/outbox"
 	"github.com/secamc93/probability/back/central/shared/rabbitmq"
 	"github.com/secamc93/probability/back/central/shared/redis"
 )
@@ -38,7 +39,14 @@ func New(
 	moduleLogger := logger.WithModule("pay")
 
 	repo := repository.New(database, moduleLogger, integrationCore)
-	requestPublisher := payqueue.New(rabbitMQ, moduleLogger)
+
+	// Las publicaciones de pagos y billetera pasan por el outbox: se guardan en
+	// la transaccion del cambio y el relay las entrega al broker
+	publishQueue := rabbitMQ
+	if rabbitMQ != nil {
+		publishQueue = outbox.NewQueue(rabbitMQ, outbox.NewWriter(database))
+	}
+	requestPublisher := payqueue.New(publishQueue, moduleLogger)
 
 	var ssePublisher = payredis.NewNoopSSEPublisher()
 	if redisClient != nil {
@@ -48,12 +56,15 @@ func New(
 		moduleLogger.Warn(ctx).Msg("Redis no disponible - SSE de pagos deshabilitado")
 	}
 
-	useCase := app.New(repo, requestPublisher, ssePublisher, rabbitMQ, config, moduleLogger)
-	walletUC := app.NewWalletUseCase(repo, useCase, rabbitMQ, config, moduleLogger)
+	useCase := app.New(repo, requestPublisher, ssePublisher, publishQueue, config, moduleLogger)
+	walletUC := app.NewWalletUseCase(repo, useCase, db.NewTransactor(database), publishQueue, config, moduleLogger)
 
 	lowBalanceWorker := worker.NewLowBalanceWorker(walletUC, moduleLogger)
 	go lowBalanceWorker.Start(ctx)
 
+	ledgerWorker := worker.NewLedgerConsistencyWorker(walletUC, moduleLogger)
+	go ledgerWorker.Start(ctx)
+
 	handler := handlers.New(useCase, moduleLogger)
 	handler.RegisterRoutes(router)
 
@@ -4,6 +4,13 @@ import (
 	"context"
 
 	"github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/dtos"
+	payerrs "github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/errors"
+)
+
+// Errores de billetera que los modulos que usan el gateway distinguen con errors.Is
+var (
+	ErrWalletNotFound      = payerrs.ErrWalletNotFound
+	ErrInsufficientBalance = payerrs.ErrInsufficientBalance
 )
 
 func (b *Bundle) GetBalance(ctx context.Context, businessID uint) (float64, error) {
@@ -24,6 +31,35 @@ func (b *Bundle) Debit(ctx context.Context, businessID uint, amount float64, ref
 	})
 }
 
+// HoldForGuide retiene el costo de una guia mientras la transportadora la
+// genera. ErrInsufficientBalance si el disponible no alcanza; ErrWalletNotFound
+// si el negocio no tiene billetera
+func (b *Bundle) HoldForGuide(ctx context.Context, businessID, shipmentID uint, amount float64, correlationID string) error {
+	_, err := b.WalletUseCase.HoldForGuide(ctx, &dtos.HoldForGuideDTO{
+		BusinessID:    businessID,
+		ShipmentID:    shipmentID,
+		Amount:        amount,
+		CorrelationID: correlationID,
+	})
+	return err
+}
+
+// DebitForGuide cobra una guia confirmada: captura la retencion del envio si
+// la hay y si no debita directo. Cobrar dos veces la misma guia no hace nada
+func (b *Bundle) DebitForGuide(ctx context.Context, businessID uint, amount float64, trackingNumber string, shipmentID *uint) error {
+	return b.WalletUseCase.DebitForGuide(ctx, &dtos.DebitForGuideDTO{
+		BusinessID:     businessID,
+		Amount:         amount,
+		TrackingNumber: trackingNumber,
+		ShipmentID:     shipmentID,
+	})
+}
+
+// ReleaseGuideHolds libera las retenciones activas del envio
+func (b *Bundle) ReleaseGuideHolds(ctx context.Context, shipmentID uint) error {
+	return b.WalletUseCase.ReleaseShipmentHolds(ctx, shipmentID)
+}
+
 // BoldSignature es la respuesta publica (cruza limites de modulo) para iniciar un pago
 // con Bold que no es recarga de billetera.
 type BoldSignature struct {
@@ -3,6 +3,7 @@ package app
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/constants"
 	"github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/dtos"
 	"github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/entities"
+	payerrs "github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/errors"
 	"github.com/secamc93/probability/back/central/shared/rabbitmq"
 )
 
@@ -401,6 +403,25 @@ func (uc *useCase) ApplyWalletRechargeStatus(ctx context.Context, in *dtos.Walle
 		return nil
 	}
 
+	// El credito va antes que el estado: si el webhook se reprocesa despues de
+	// un fallo a mitad de camino, la llave recharge:<id> evita acreditar dos veces
+	var wallet *entities.Wallet
+	if newStatus == entities.WalletTxStatusCompleted {
+		current, err := uc.repo.GetWalletByID(ctx, walletTx.WalletID)
+		if err != nil {
+			return fmt.Errorf("get wallet: %w", err)
+		}
+		journal := entities.NewRechargeJournal(current, walletTx.Amount, "recharge:"+walletTx.ID.String(), walletTx.Reference)
+		journal.TransactionID = &walletTx.ID
+		wallet, err = uc.repo.PostWalletJournal(ctx, journal, false)
+		if errors.Is(err, payerrs.ErrDuplicateIdempotencyKey) {
+			wallet, err = current, nil
+		}
+		if err != nil {
+			return fmt.Errorf("credit wallet: %w", err)
+		}
+	}
+
 	walletTx.Status = newStatus
 	if err := uc.repo.UpdateWalletTransaction(ctx, walletTx); err != nil {
 		return fmt.Errorf("update wallet transaction: %w", err)
@@ -413,14 +434,6 @@ func (uc *useCase) ApplyWalletRechargeStatus(ctx context.Context, in *dtos.Walle
 	}
 
 	if newStatus == entities.WalletTxStatusCompleted {
-		wallet, err := uc.repo.GetWalletByID(ctx, walletTx.WalletID)
-		if err != nil {
-			return fmt.Errorf("get wallet: %w", err)
-		}
-		wallet.Balance += walletTx.Amount
-		if err := uc.repo.UpdateWallet(ctx, wallet); err != nil {
-			return fmt.Errorf("update wallet balance: %w", err)
-		}
 		uc.log.Info(ctx).
 			Str("source", in.Source).
 			Str("wallet_tx_id", walletTx.ID.String()).
@@ -437,7 +450,7 @@ func (uc *useCase) ApplyWalletRechargeStatus(ctx context.Context, in *dtos.Walle
 		Str("wallet_tx_id", walletTx.ID.String()).
 		Str("status", newStatus).
 		Msg("wallet recharge marked failed")
-	wallet, _ := uc.repo.GetWalletByID(ctx, walletTx.WalletID)
+	wallet, _ = uc.repo.GetWalletByID(ctx, walletTx.WalletID)
 	var businessID uint
 	var balancePtr *float64
 	if wallet != nil {
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/dtos"
 	"github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/shared/audit"
 )
 
 func (uc *walletUseCase) AdminAdjustBalance(ctx context.Context, dto *dtos.AdminAdjustBalanceDTO) error {
@@ -44,19 +45,43 @@ func (uc *walletUseCase) AdminAdjustBalance(ctx context.Context, dto *dtos.Admin
 		CreatedAt:  time.Now(),
 	}
 
-	if err := uc.repo.CreateWalletTransaction(ctx, tx); err != nil {
-		return fmt.Errorf("error creating transaction: %w", err)
-	}
+	journal := entities.NewAdjustmentJournal(wallet, dto.Amount, "adjustment:"+tx.ID.String(), tx.Reference)
+	journal.TransactionID = &tx.ID
 
-	wallet.Balance += dto.Amount
-	if err := uc.repo.UpdateWallet(ctx, wallet); err != nil {
-		return fmt.Errorf("error updating wallet balance: %w", err)
+	var updated *entities.Wallet
+	err = uc.inTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.CreateWalletTransaction(ctx, tx); err != nil {
+			return fmt.Errorf("error creating transaction: %w", err)
+		}
+		var err error
+		if updated, err = uc.postJournal(ctx, journal, false); err != nil {
+			return fmt.Errorf("error updating wallet balance: %w", err)
+		}
+		// El saldo previo sale del resultado y no de la lectura inicial: otro
+		// movimiento pudo entrar entre ambas
+		return audit.Record(ctx, audit.Entry{
+			BusinessID: &dto.BusinessID,
+			Resource:   "wallet",
+			ResourceID: wallet.ID.String(),
+			Action:     "admin_adjust",
+			Before:     map[string]any{"balance": updated.Balance - dto.Amount},
+			After:      map[string]any{"balance": updated.Balance},
+			Metadata: map[string]any{
+				"amount":         dto.Amount,
+				"concept":        concept,
+				"reference":      dto.Reference,
+				"transaction_id": tx.ID.String(),
+			},
+		})
+	})
+	if err != nil {
+		return err
 	}
 
 	uc.log.Info(ctx).
 		Uint("business_id", dto.BusinessID).
 		Float64("amount", dto.Amount).
-		Float64("new_balance", wallet.Balance).
+		Float64("new_balance", updated.Balance).
 		Str("reference", dto.Reference).
 		Msg("Admin adjusted wallet balance")
 
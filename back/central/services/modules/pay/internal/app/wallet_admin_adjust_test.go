@@ -40,7 +40,7 @@ func TestAdminAdjustBalance_MontoPositivo_CreaRechargeYSumaSaldo(t *testing.T) {
 		createdTx = tx
 		return true
 	})).Return(nil)
-	repo.On("UpdateWallet", ctx, wallet).Return(nil)
+	expectJournalPosted(repo, wallet)
 
 	uc := newWalletUseCaseForTest(repo)
 
@@ -74,7 +74,7 @@ func TestAdminAdjustBalance_MontoNegativo_CreaUsageConMontoPositivoYRestaSaldo(t
 		createdTx = tx
 		return true
 	})).Return(nil)
-	repo.On("UpdateWallet", ctx, wallet).Return(nil)
+	expectJournalPosted(repo, wallet)
 
 	uc := newWalletUseCaseForTest(repo)
 
@@ -103,7 +103,7 @@ func TestAdminAdjustBalance_ConceptoInvalido_SeNormalizaAOther(t *testing.T) {
 		createdTx = tx
 		return true
 	})).Return(nil)
-	repo.On("UpdateWallet", ctx, wallet).Return(nil)
+	expectJournalPosted(repo, wallet)
 
 	uc := newWalletUseCaseForTest(repo)
 
@@ -134,10 +134,10 @@ func TestAdminAdjustBalance_FallaCrearTransaccion_NoModificaSaldo(t *testing.T)
 	require.Error(t, err)
 	assert.ErrorIs(t, err, dbErr)
 	assert.InDelta(t, 5000.0, wallet.Balance, 0.001)
-	repo.AssertNotCalled(t, "UpdateWallet", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "PostWalletJournal", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestAdminAdjustBalance_FallaActualizarWallet_DejaTransaccionHuerfana(t *testing.T) {
+func TestAdminAdjustBalance_FallaAsiento_PropagaError(t *testing.T) {
 	ctx := context.Background()
 	repo := new(mocks.RepositoryMock)
 	wallet := existingWallet(12, 5000)
@@ -145,7 +145,7 @@ func TestAdminAdjustBalance_FallaActualizarWallet_DejaTransaccionHuerfana(t *tes
 
 	repo.On("GetWalletByBusinessID", ctx, uint(12)).Return(wallet, nil)
 	repo.On("CreateWalletTransaction", ctx, mock.Anything).Return(nil)
-	repo.On("UpdateWallet", ctx, wallet).Return(dbErr)
+	repo.On("PostWalletJournal", ctx, mock.Anything, false).Return(nil, dbErr)
 
 	uc := newWalletUseCaseForTest(repo)
 
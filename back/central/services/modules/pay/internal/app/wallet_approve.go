@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 	"github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/entities"
@@ -29,18 +30,33 @@ func (uc *walletUseCase) approveTransactionInternal(ctx context.Context, tx *ent
 		return payerrs.ErrTransactionNotPending
 	}
 
-	tx.Status = entities.WalletTxStatusCompleted
-	if err := uc.repo.UpdateWalletTransaction(ctx, tx); err != nil {
-		return err
-	}
-
 	wallet, err := uc.repo.GetWalletByID(ctx, tx.WalletID)
 	if err != nil {
 		return err
 	}
 
-	wallet.Balance += tx.Amount
-	if err := uc.repo.UpdateWallet(ctx, wallet); err != nil {
+	// La llave recharge:<id> es unica: aunque la aprobacion llegue dos veces
+	// (admin y webhook a la vez), el saldo se acredita una sola
+	journal := entities.NewRechargeJournal(wallet, tx.Amount, "recharge:"+tx.ID.String(), tx.Reference)
+	journal.TransactionID = &tx.ID
+
+	err = uc.inTransaction(ctx, func(ctx context.Context) error {
+		tx.Status = entities.WalletTxStatusCompleted
+		if err := uc.repo.UpdateWalletTransaction(ctx, tx); err != nil {
+			return err
+		}
+
+		updated, err := uc.postJournal(ctx, journal, false)
+		if errors.Is(err, payerrs.ErrDuplicateIdempotencyKey) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		wallet = updated
+		return nil
+	})
+	if err != nil {
 		return err
 	}
 
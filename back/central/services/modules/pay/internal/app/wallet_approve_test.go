@@ -44,7 +44,7 @@ func TestApproveTransaction_Exitoso_AcreditaSaldoYCompleta(t *testing.T) {
 	repo.On("GetWalletTransactionByID", ctx, tx.ID).Return(tx, nil)
 	repo.On("UpdateWalletTransaction", ctx, tx).Return(nil)
 	repo.On("GetWalletByID", ctx, wallet.ID).Return(wallet, nil)
-	repo.On("UpdateWallet", ctx, wallet).Return(nil)
+	expectJournalPosted(repo, wallet)
 
 	uc := newWalletUseCaseForTest(repo)
 
@@ -71,7 +71,7 @@ func TestApproveTransaction_YaCompletada_NoAcreditaDosVeces(t *testing.T) {
 
 	assert.ErrorIs(t, err, payerrs.ErrTransactionNotPending)
 	assert.InDelta(t, 10000.0, wallet.Balance, 0.001)
-	repo.AssertNotCalled(t, "UpdateWallet", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "PostWalletJournal", mock.Anything, mock.Anything, mock.Anything)
 	repo.AssertNotCalled(t, "UpdateWalletTransaction", mock.Anything, mock.Anything)
 }
 
@@ -89,7 +89,7 @@ func TestApproveTransaction_Rechazada_NoSePuedeAprobar(t *testing.T) {
 	err := uc.ApproveTransaction(ctx, tx.ID.String())
 
 	assert.ErrorIs(t, err, payerrs.ErrTransactionNotPending)
-	repo.AssertNotCalled(t, "UpdateWallet", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "PostWalletJournal", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestApproveTransaction_FallaBuscarTransaccion_PropagaError(t *testing.T) {
@@ -115,6 +115,7 @@ func TestApproveTransaction_FallaActualizarTransaccion_NoAcreditaSaldo(t *testin
 	dbErr := errors.New("update failed")
 
 	repo.On("GetWalletTransactionByID", ctx, tx.ID).Return(tx, nil)
+	repo.On("GetWalletByID", ctx, wallet.ID).Return(wallet, nil)
 	repo.On("UpdateWalletTransaction", ctx, tx).Return(dbErr)
 
 	uc := newWalletUseCaseForTest(repo)
@@ -123,10 +124,10 @@ func TestApproveTransaction_FallaActualizarTransaccion_NoAcreditaSaldo(t *testin
 
 	assert.ErrorIs(t, err, dbErr)
 	assert.InDelta(t, 10000.0, wallet.Balance, 0.001)
-	repo.AssertNotCalled(t, "UpdateWallet", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "PostWalletJournal", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestApproveTransaction_FallaObtenerWallet_DejaTransaccionCompletadaSinAcreditar(t *testing.T) {
+func TestApproveTransaction_FallaObtenerWallet_NoCompletaLaTransaccion(t *testing.T) {
 	ctx := context.Background()
 	repo := new(mocks.RepositoryMock)
 	walletID := uuid.New()
@@ -134,7 +135,6 @@ func TestApproveTransaction_FallaObtenerWallet_DejaTransaccionCompletadaSinAcred
 	dbErr := errors.New("wallet not found")
 
 	repo.On("GetWalletTransactionByID", ctx, tx.ID).Return(tx, nil)
-	repo.On("UpdateWalletTransaction", ctx, tx).Return(nil)
 	repo.On("GetWalletByID", ctx, walletID).Return(nil, dbErr)
 
 	uc := newWalletUseCaseForTest(repo)
@@ -142,8 +142,31 @@ func TestApproveTransaction_FallaObtenerWallet_DejaTransaccionCompletadaSinAcred
 	err := uc.ApproveTransaction(ctx, tx.ID.String())
 
 	assert.ErrorIs(t, err, dbErr)
+	assert.Equal(t, entities.WalletTxStatusPending, tx.Status)
+	repo.AssertNotCalled(t, "UpdateWalletTransaction", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "PostWalletJournal", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestApproveTransaction_CreditoYaAplicado_NoAcreditaDosVeces(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+	wallet := existingWallet(20, 10000)
+	tx := pendingRecharge(wallet.ID, 250000)
+
+	repo.On("GetWalletTransactionByID", ctx, tx.ID).Return(tx, nil)
+	repo.On("UpdateWalletTransaction", ctx, tx).Return(nil)
+	repo.On("GetWalletByID", ctx, wallet.ID).Return(wallet, nil)
+	repo.On("PostWalletJournal", ctx, mock.MatchedBy(func(j *entities.WalletJournal) bool {
+		return j.IdempotencyKey == "recharge:"+tx.ID.String()
+	}), false).Return(nil, payerrs.ErrDuplicateIdempotencyKey)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	err := uc.ApproveTransaction(ctx, tx.ID.String())
+
+	require.NoError(t, err)
 	assert.Equal(t, entities.WalletTxStatusCompleted, tx.Status)
-	repo.AssertNotCalled(t, "UpdateWallet", mock.Anything, mock.Anything)
+	assert.InDelta(t, 10000.0, wallet.Balance, 0.001)
 }
 
 func TestRejectTransaction_IDNoEsUUID_RetornaNotFound(t *testing.T) {
@@ -171,7 +194,7 @@ func TestRejectTransaction_Exitoso_MarcaFailedSinTocarSaldo(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, entities.WalletTxStatusFailed, tx.Status)
 	assert.InDelta(t, 10000.0, wallet.Balance, 0.001)
-	repo.AssertNotCalled(t, "UpdateWallet", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "PostWalletJournal", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestRejectTransaction_YaCompletada_NoSePuedeRechazar(t *testing.T) {
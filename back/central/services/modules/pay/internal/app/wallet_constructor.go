@@ -1,6 +1,8 @@
 package app
 
 import (
+	"context"
+
 	"github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/ports"
 	"github.com/secamc93/probability/back/central/shared/env"
 	"github.com/secamc93/probability/back/central/shared/log"
@@ -10,6 +12,7 @@ import (
 type walletUseCase struct {
 	repo           ports.IRepository
 	paymentUseCase ports.IUseCase
+	transactor     ports.ITransactor
 	rabbit         rabbitmq.IQueue
 	config         env.IConfig
 	log            log.ILogger
@@ -18,6 +21,7 @@ type walletUseCase struct {
 func NewWalletUseCase(
 	repo ports.IRepository,
 	paymentUseCase ports.IUseCase,
+	transactor ports.ITransactor,
 	rabbit rabbitmq.IQueue,
 	config env.IConfig,
 	logger log.ILogger,
@@ -25,8 +29,18 @@ func NewWalletUseCase(
 	return &walletUseCase{
 		repo:           repo,
 		paymentUseCase: paymentUseCase,
+		transactor:     transactor,
 		rabbit:         rabbit,
 		config:         config,
 		log:            logger.WithModule("pay.wallet.usecase"),
 	}
 }
+
+// inTransaction corre fn en la transaccion del transactor; sin transactor
+// (tests) corre fn directo.
+func (uc *walletUseCase) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if uc.transactor == nil {
+		return fn(ctx)
+	}
+	return uc.transactor.InTransaction(ctx, fn)
+}
@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -27,6 +28,7 @@ func (uc *walletUseCase) ManualDebit(ctx context.Context, dto *dtos.ManualDebitD
 	}
 
 	tx := &entities.WalletTransaction{
+		ID:         uuid.New(),
 		WalletID:   wallet.ID,
 		Amount:     dto.Amount,
 		Type:       entities.WalletTxTypeUsage,
@@ -34,40 +36,129 @@ func (uc *walletUseCase) ManualDebit(ctx context.Context, dto *dtos.ManualDebitD
 		Concept:    concept,
 		Reference:  "MAN_DEB_" + uuid.New().String()[:8] + ": " + dto.Reference,
 		UserID:     dto.UserID,
+		ShipmentID: dto.ShipmentID,
 		BusinessID: dto.BusinessID,
 		CreatedAt:  time.Now(),
 	}
 
-	if err := uc.repo.CreateWalletTransaction(ctx, tx); err != nil {
-		return err
+	key := dto.IdempotencyKey
+	if key == "" {
+		key = "debit:" + tx.ID.String()
 	}
+	journal := entities.NewDebitJournal(wallet, dto.Amount, key, tx.Reference)
+	journal.TransactionID = &tx.ID
+
+	// Movimiento, asientos, saldo y aviso de saldo bajo (outbox) van en una
+	// transaccion: el aviso solo sale si el debito quedo guardado
+	var updated *entities.Wallet
+	err = uc.inTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.CreateWalletTransaction(ctx, tx); err != nil {
+			return err
+		}
+
+		var err error
+		if updated, err = uc.postJournal(ctx, journal, false); err != nil {
+			return err
+		}
 
-	wallet.Balance -= dto.Amount
-	if err := uc.repo.UpdateWallet(ctx, wallet); err != nil {
+		uc.CheckLowBalanceForBusiness(ctx, dto.BusinessID)
+		return nil
+	})
+	if errors.Is(err, payerrs.ErrDuplicateIdempotencyKey) {
+		uc.log.Info(ctx).
+			Uint("business_id", dto.BusinessID).
+			Str("idempotency_key", key).
+			Msg("Manual debit already applied, skipping")
+		return nil
+	}
+	if err != nil {
 		return err
 	}
 
 	uc.log.Info(ctx).
 		Uint("business_id", dto.BusinessID).
 		Float64("amount", dto.Amount).
-		Float64("new_balance", wallet.Balance).
+		Float64("new_balance", updated.Balance).
 		Msg("Manual debit applied")
 
-	uc.CheckLowBalanceForBusiness(ctx, dto.BusinessID)
-
 	return nil
 }
 
+// DebitForGuide cobra una guia confirmada por la transportadora. Con envio:
+// si ya tiene USAGE no cobra de nuevo; si tiene retenciones activas captura la
+// mas reciente con el costo final y libera las de reintentos anteriores. Sin
+// retencion debita directo
 func (uc *walletUseCase) DebitForGuide(ctx context.Context, dto *dtos.DebitForGuideDTO) error {
 	if dto.Amount <= 0 {
 		return payerrs.ErrInvalidAmount
 	}
 
+	if dto.ShipmentID != nil {
+		charged, err := uc.repo.HasShipmentUsage(ctx, *dto.ShipmentID)
+		if err != nil {
+			return err
+		}
+		if charged {
+			return nil
+		}
+
+		holds, err := uc.repo.ListShipmentWalletHolds(ctx, *dto.ShipmentID, entities.WalletHoldStatusHeld)
+		if err != nil {
+			return err
+		}
+		if len(holds) > 0 {
+			return uc.captureGuideHold(ctx, dto, holds)
+		}
+
+		// El cobro de un envio no crea la billetera: sin ella la guia queda
+		// pendiente para la conciliacion
+		wallet, err := uc.repo.GetWalletByBusinessID(ctx, dto.BusinessID)
+		if err != nil {
+			return err
+		}
+		if wallet == nil {
+			return payerrs.ErrWalletNotFound
+		}
+	}
+
 	return uc.ManualDebit(ctx, &dtos.ManualDebitDTO{
 		BusinessID: dto.BusinessID,
 		Amount:     dto.Amount,
 		Reference:  fmt.Sprintf("Guide generation: %s", dto.TrackingNumber),
 		Concept:    entities.WalletTxConceptGuide,
 		UserID:     dto.UserID,
+		ShipmentID: dto.ShipmentID,
+		// Una guia se cobra una sola vez aunque el debito llegue repetido
+		IdempotencyKey: guideDebitKey(dto.TrackingNumber, dto.ShipmentID),
 	})
 }
+
+// captureGuideHold cobra la retencion mas reciente del envio y libera las demas
+func (uc *walletUseCase) captureGuideHold(ctx context.Context, dto *dtos.DebitForGuideDTO, holds []*entities.WalletHold) error {
+	_, err := uc.CaptureHold(ctx, &dtos.CaptureHoldDTO{
+		HoldID:  holds[0].ID.String(),
+		Amount:  dto.Amount,
+		Concept: entities.WalletTxConceptGuide,
+		UserID:  dto.UserID,
+	})
+	// Otro proceso ya capturo la retencion: la guia quedo cobrada
+	if errors.Is(err, payerrs.ErrHoldNotActive) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return uc.voidHolds(ctx, holds[1:])
+}
+
+// guideDebitKey es la llave del debito directo de una guia: el tracking, o el
+// envio si la transportadora aun no lo asigno
+func guideDebitKey(trackingNumber string, shipmentID *uint) string {
+	if trackingNumber != "" {
+		return "guide:" + trackingNumber
+	}
+	if shipmentID != nil {
+		return fmt.Sprintf("guide:shipment:%d", *shipmentID)
+	}
+	return ""
+}
@@ -18,7 +18,7 @@ import (
 )
 
 func newWalletUseCaseForTest(repo ports.IRepository) ports.IWalletUseCase {
-	return NewWalletUseCase(repo, nil, nil, nil, mocks.NewSilentLogger())
+	return NewWalletUseCase(repo, nil, nil, nil, nil, mocks.NewSilentLogger())
 }
 
 func existingWallet(businessID uint, balance float64) *entities.Wallet {
@@ -29,6 +29,28 @@ func existingWallet(businessID uint, balance float64) *entities.Wallet {
 	}
 }
 
+// expectJournalPosted simula el UPDATE atomico del repositorio: aplica al
+// wallet el efecto de cada journal posteado
+func expectJournalPosted(repo *mocks.RepositoryMock, wallet *entities.Wallet) *mock.Call {
+	return repo.On("PostWalletJournal", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			available, held := args.Get(1).(*entities.WalletJournal).WalletDelta()
+			wallet.Balance += available
+			wallet.HeldBalance += held
+		}).
+		Return(wallet, nil)
+}
+
+func postedJournals(repo *mocks.RepositoryMock) []*entities.WalletJournal {
+	var journals []*entities.WalletJournal
+	for _, call := range repo.Calls {
+		if call.Method == "PostWalletJournal" {
+			journals = append(journals, call.Arguments.Get(1).(*entities.WalletJournal))
+		}
+	}
+	return journals
+}
+
 func TestManualDebit_MontoCero_RetornaErrorSinTocarRepo(t *testing.T) {
 	repo := new(mocks.RepositoryMock)
 	uc := newWalletUseCaseForTest(repo)
@@ -42,7 +64,7 @@ func TestManualDebit_MontoCero_RetornaErrorSinTocarRepo(t *testing.T) {
 	assert.ErrorIs(t, err, payerrs.ErrInvalidAmount)
 	repo.AssertNotCalled(t, "GetWalletByBusinessID", mock.Anything, mock.Anything)
 	repo.AssertNotCalled(t, "CreateWalletTransaction", mock.Anything, mock.Anything)
-	repo.AssertNotCalled(t, "UpdateWallet", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "PostWalletJournal", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestManualDebit_MontoNegativo_RetornaErrorSinTocarRepo(t *testing.T) {
@@ -56,7 +78,7 @@ func TestManualDebit_MontoNegativo_RetornaErrorSinTocarRepo(t *testing.T) {
 	})
 
 	assert.ErrorIs(t, err, payerrs.ErrInvalidAmount)
-	repo.AssertNotCalled(t, "UpdateWallet", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "PostWalletJournal", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestManualDebit_Exitoso_CreaTransaccionUsageYRestaSaldo(t *testing.T) {
@@ -70,7 +92,7 @@ func TestManualDebit_Exitoso_CreaTransaccionUsageYRestaSaldo(t *testing.T) {
 		createdTx = tx
 		return true
 	})).Return(nil)
-	repo.On("UpdateWallet", ctx, wallet).Return(nil)
+	expectJournalPosted(repo, wallet)
 
 	uc := newWalletUseCaseForTest(repo)
 	userID := uint(42)
@@ -111,7 +133,7 @@ func TestManualDebit_ConceptoInvalido_SeNormalizaAOther(t *testing.T) {
 		createdTx = tx
 		return true
 	})).Return(nil)
-	repo.On("UpdateWallet", ctx, wallet).Return(nil)
+	expectJournalPosted(repo, wallet)
 
 	uc := newWalletUseCaseForTest(repo)
 
@@ -141,7 +163,7 @@ func TestManualDebit_FallaCrearTransaccion_NoModificaSaldo(t *testing.T) {
 
 	assert.ErrorIs(t, err, dbErr)
 	assert.InDelta(t, 20000.0, wallet.Balance, 0.001)
-	repo.AssertNotCalled(t, "UpdateWallet", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "PostWalletJournal", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestManualDebit_FallaObtenerWallet_PropagaError(t *testing.T) {
@@ -166,7 +188,7 @@ func TestManualDebit_SaldoInsuficiente_HoyPermiteBalanceNegativo(t *testing.T) {
 
 	repo.On("GetWalletByBusinessID", ctx, uint(5)).Return(wallet, nil)
 	repo.On("CreateWalletTransaction", ctx, mock.Anything).Return(nil)
-	repo.On("UpdateWallet", ctx, wallet).Return(nil)
+	expectJournalPosted(repo, wallet)
 
 	uc := newWalletUseCaseForTest(repo)
 
@@ -188,7 +210,7 @@ func TestDebitForGuide_Exitoso_UsaConceptoGuideYReferenciaConTracking(t *testing
 		createdTx = tx
 		return true
 	})).Return(nil)
-	repo.On("UpdateWallet", ctx, wallet).Return(nil)
+	expectJournalPosted(repo, wallet)
 
 	uc := newWalletUseCaseForTest(repo)
 
@@ -220,14 +242,16 @@ func TestDebitForGuide_MontoInvalido_RetornaErrorSinTocarRepo(t *testing.T) {
 	repo.AssertNotCalled(t, "GetWalletByBusinessID", mock.Anything, mock.Anything)
 }
 
-func TestDebitForGuide_DosLlamadasMismoTracking_DebitanDosVeces(t *testing.T) {
+func TestDebitForGuide_DosLlamadasMismoTracking_CobraUnaSolaVez(t *testing.T) {
 	ctx := context.Background()
 	repo := new(mocks.RepositoryMock)
 	wallet := existingWallet(36, 100000)
 
 	repo.On("GetWalletByBusinessID", ctx, uint(36)).Return(wallet, nil)
 	repo.On("CreateWalletTransaction", ctx, mock.Anything).Return(nil)
-	repo.On("UpdateWallet", ctx, wallet).Return(nil)
+	expectJournalPosted(repo, wallet).Once()
+	repo.On("PostWalletJournal", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, payerrs.ErrDuplicateIdempotencyKey).Once()
 
 	uc := newWalletUseCaseForTest(repo)
 	dto := &dtos.DebitForGuideDTO{BusinessID: 36, Amount: 16353, TrackingNumber: "034057376067"}
@@ -235,6 +259,92 @@ func TestDebitForGuide_DosLlamadasMismoTracking_DebitanDosVeces(t *testing.T) {
 	require.NoError(t, uc.DebitForGuide(ctx, dto))
 	require.NoError(t, uc.DebitForGuide(ctx, dto))
 
-	assert.InDelta(t, 67294.0, wallet.Balance, 0.001)
-	repo.AssertNumberOfCalls(t, "CreateWalletTransaction", 2)
+	assert.InDelta(t, 83647.0, wallet.Balance, 0.001)
+	journals := postedJournals(repo)
+	require.Len(t, journals, 2)
+	assert.Equal(t, "guide:034057376067", journals[0].IdempotencyKey)
+	assert.Equal(t, journals[0].IdempotencyKey, journals[1].IdempotencyKey)
+}
+
+func TestDebitForGuide_ConRetenciones_CapturaLaMasRecienteYLiberaLasDemas(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+	wallet := existingWallet(36, 70000)
+	wallet.HeldBalance = 30000
+	shipmentID := uint(9)
+	latest := activeHold(wallet, 15000)
+	latest.ShipmentID = &shipmentID
+	previous := activeHold(wallet, 15000)
+	previous.ShipmentID = &shipmentID
+
+	var createdTx *entities.WalletTransaction
+	repo.On("HasShipmentUsage", ctx, shipmentID).Return(false, nil)
+	repo.On("ListShipmentWalletHolds", ctx, shipmentID, entities.WalletHoldStatusHeld).
+		Return([]*entities.WalletHold{latest, previous}, nil)
+	repo.On("GetWalletHoldByID", ctx, latest.ID).Return(latest, nil)
+	repo.On("GetWalletHoldByID", ctx, previous.ID).Return(previous, nil)
+	repo.On("GetWalletByID", ctx, wallet.ID).Return(wallet, nil)
+	repo.On("TransitionWalletHold", ctx, mock.Anything, entities.WalletHoldStatusHeld).Return(nil)
+	repo.On("CreateWalletTransaction", ctx, mock.MatchedBy(func(tx *entities.WalletTransaction) bool {
+		createdTx = tx
+		return true
+	})).Return(nil)
+	expectJournalPosted(repo, wallet)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	err := uc.DebitForGuide(ctx, &dtos.DebitForGuideDTO{
+		BusinessID:     36,
+		Amount:         14000,
+		TrackingNumber: "034057376067",
+		ShipmentID:     &shipmentID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.WalletHoldStatusCaptured, latest.Status)
+	assert.Equal(t, entities.WalletHoldStatusVoided, previous.Status)
+	require.NotNil(t, createdTx)
+	assert.Equal(t, &shipmentID, createdTx.ShipmentID)
+	assert.InDelta(t, 86000.0, wallet.Balance, 0.001)
+	assert.InDelta(t, 0.0, wallet.HeldBalance, 0.001)
+	repo.AssertNotCalled(t, "GetWalletByBusinessID", mock.Anything, mock.Anything)
+}
+
+func TestDebitForGuide_EnvioYaCobrado_NoCobraDeNuevo(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+	shipmentID := uint(9)
+
+	repo.On("HasShipmentUsage", ctx, shipmentID).Return(true, nil)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	err := uc.DebitForGuide(ctx, &dtos.DebitForGuideDTO{BusinessID: 36, Amount: 14000, ShipmentID: &shipmentID})
+
+	require.NoError(t, err)
+	repo.AssertNotCalled(t, "ListShipmentWalletHolds", mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "PostWalletJournal", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestManualDebit_AsientoCuadraContraIngreso(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+	wallet := existingWallet(8, 10000)
+
+	repo.On("GetWalletByBusinessID", ctx, uint(8)).Return(wallet, nil)
+	repo.On("CreateWalletTransaction", ctx, mock.Anything).Return(nil)
+	expectJournalPosted(repo, wallet)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	require.NoError(t, uc.ManualDebit(ctx, &dtos.ManualDebitDTO{BusinessID: 8, Amount: 2500}))
+
+	journals := postedJournals(repo)
+	require.Len(t, journals, 1)
+	j := journals[0]
+	assert.Equal(t, entities.LedgerKindDebit, j.Kind)
+	assert.True(t, j.IsBalanced())
+	assert.NotNil(t, j.TransactionID)
+	assert.True(t, strings.HasPrefix(j.IdempotencyKey, "debit:"))
+	repo.AssertCalled(t, "PostWalletJournal", mock.Anything, j, false)
 }
@@ -0,0 +1,304 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/entities"
+	payerrs "github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/errors"
+)
+
+// postJournal valida que el journal cuadre antes de guardarlo. El saldo solo
+// cambia por aqui: el repositorio lo actualiza en el mismo UPDATE que lo lee
+func (uc *walletUseCase) postJournal(ctx context.Context, journal *entities.WalletJournal, requireFunds bool) (*entities.Wallet, error) {
+	if !journal.IsBalanced() {
+		return nil, payerrs.ErrUnbalancedJournal
+	}
+	return uc.repo.PostWalletJournal(ctx, journal, requireFunds)
+}
+
+// PlaceHold reserva saldo disponible. Con la misma llave de idempotencia en el
+// mismo negocio retorna la retencion existente en vez de reservar dos veces
+func (uc *walletUseCase) PlaceHold(ctx context.Context, dto *dtos.PlaceHoldDTO) (*entities.WalletHold, error) {
+	if dto.Amount <= 0 {
+		return nil, payerrs.ErrInvalidAmount
+	}
+
+	if dto.IdempotencyKey != "" {
+		existing, err := uc.repo.GetWalletHoldByIdempotencyKey(ctx, dto.BusinessID, dto.IdempotencyKey)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return existing, nil
+		}
+	}
+
+	wallet, err := uc.GetWallet(ctx, dto.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	hold := &entities.WalletHold{
+		ID:             uuid.New(),
+		WalletID:       wallet.ID,
+		BusinessID:     dto.BusinessID,
+		Amount:         dto.Amount,
+		Status:         entities.WalletHoldStatusHeld,
+		IdempotencyKey: dto.IdempotencyKey,
+		Reference:      dto.Reference,
+		ShipmentID:     dto.ShipmentID,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if hold.IdempotencyKey == "" {
+		hold.IdempotencyKey = "hold:" + hold.ID.String()
+	}
+
+	err = uc.inTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.CreateWalletHold(ctx, hold); err != nil {
+			return err
+		}
+		_, err := uc.postJournal(ctx, entities.NewHoldJournal(wallet, hold), true)
+		return err
+	})
+	if errors.Is(err, payerrs.ErrDuplicateIdempotencyKey) {
+		return uc.repo.GetWalletHoldByIdempotencyKey(ctx, dto.BusinessID, hold.IdempotencyKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log.Info(ctx).
+		Uint("business_id", dto.BusinessID).
+		Str("hold_id", hold.ID.String()).
+		Float64("amount", dto.Amount).
+		Msg("Wallet hold placed")
+
+	return hold, nil
+}
+
+// CaptureHold cobra una retencion: crea el movimiento USAGE y pasa el monto de
+// retenido a ingreso. Capturar dos veces retorna la retencion ya capturada.
+// Si el costo final supera lo retenido, el excedente sale del disponible y
+// exige saldo (ErrInsufficientBalance), como cualquier otro debito
+func (uc *walletUseCase) CaptureHold(ctx context.Context, dto *dtos.CaptureHoldDTO) (*entities.WalletHold, error) {
+	if dto.Amount < 0 {
+		return nil, payerrs.ErrInvalidAmount
+	}
+
+	hold, err := uc.getHold(ctx, dto.HoldID)
+	if err != nil {
+		return nil, err
+	}
+	switch hold.Status {
+	case entities.WalletHoldStatusCaptured:
+		return hold, nil
+	case entities.WalletHoldStatusVoided:
+		return nil, payerrs.ErrHoldNotActive
+	}
+
+	amount := dto.Amount
+	if amount == 0 {
+		amount = hold.Amount
+	}
+	concept := dto.Concept
+	if !entities.ValidWalletTxConcept(concept) {
+		concept = entities.WalletTxConceptGuide
+	}
+
+	wallet, err := uc.repo.GetWalletByID(ctx, hold.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	tx := &entities.WalletTransaction{
+		ID:         uuid.New(),
+		WalletID:   hold.WalletID,
+		Amount:     amount,
+		Type:       entities.WalletTxTypeUsage,
+		Status:     entities.WalletTxStatusCompleted,
+		Concept:    concept,
+		Reference:  fmt.Sprintf("HOLD_%s: %s", hold.ID.String()[:8], hold.Reference),
+		UserID:     dto.UserID,
+		ShipmentID: hold.ShipmentID,
+		BusinessID: hold.BusinessID,
+		CreatedAt:  now,
+	}
+
+	hold.Status = entities.WalletHoldStatusCaptured
+	hold.CapturedAmount = amount
+	hold.TransactionID = &tx.ID
+	hold.CapturedAt = &now
+
+	journal := entities.NewCaptureJournal(wallet, hold, amount)
+	journal.TransactionID = &tx.ID
+
+	err = uc.inTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.TransitionWalletHold(ctx, hold, entities.WalletHoldStatusHeld); err != nil {
+			return err
+		}
+		if err := uc.repo.CreateWalletTransaction(ctx, tx); err != nil {
+			return err
+		}
+		if _, err := uc.postJournal(ctx, journal, amount > hold.Amount); err != nil {
+			return err
+		}
+		uc.CheckLowBalanceForBusiness(ctx, hold.BusinessID)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log.Info(ctx).
+		Uint("business_id", hold.BusinessID).
+		Str("hold_id", hold.ID.String()).
+		Float64("held", hold.Amount).
+		Float64("captured", amount).
+		Msg("Wallet hold captured")
+
+	return hold, nil
+}
+
+// VoidHold libera una retencion y devuelve el monto al disponible
+func (uc *walletUseCase) VoidHold(ctx context.Context, holdID string) (*entities.WalletHold, error) {
+	hold, err := uc.getHold(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+	switch hold.Status {
+	case entities.WalletHoldStatusVoided:
+		return hold, nil
+	case entities.WalletHoldStatusCaptured:
+		return nil, payerrs.ErrHoldNotActive
+	}
+
+	wallet, err := uc.repo.GetWalletByID(ctx, hold.WalletID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	hold.Status = entities.WalletHoldStatusVoided
+	hold.VoidedAt = &now
+
+	err = uc.inTransaction(ctx, func(ctx context.Context) error {
+		if err := uc.repo.TransitionWalletHold(ctx, hold, entities.WalletHoldStatusHeld); err != nil {
+			return err
+		}
+		_, err := uc.postJournal(ctx, entities.NewVoidJournal(wallet, hold), false)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log.Info(ctx).
+		Uint("business_id", hold.BusinessID).
+		Str("hold_id", hold.ID.String()).
+		Float64("amount", hold.Amount).
+		Msg("Wallet hold voided")
+
+	return hold, nil
+}
+
+// HoldForGuide retiene el costo de la guia mientras la transportadora la
+// genera. Cada solicitud (CorrelationID) tiene su propia retencion. Un negocio
+// sin billetera no retiene: retorna ErrWalletNotFound sin crearla
+func (uc *walletUseCase) HoldForGuide(ctx context.Context, dto *dtos.HoldForGuideDTO) (*entities.WalletHold, error) {
+	wallet, err := uc.repo.GetWalletByBusinessID(ctx, dto.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+	if wallet == nil {
+		return nil, payerrs.ErrWalletNotFound
+	}
+
+	shipmentID := dto.ShipmentID
+	return uc.PlaceHold(ctx, &dtos.PlaceHoldDTO{
+		BusinessID:     dto.BusinessID,
+		Amount:         dto.Amount,
+		Reference:      fmt.Sprintf("Guide generation: shipment %d", dto.ShipmentID),
+		ShipmentID:     &shipmentID,
+		IdempotencyKey: fmt.Sprintf("guide:%d:%s", dto.ShipmentID, dto.CorrelationID),
+	})
+}
+
+// ReleaseShipmentHolds libera las retenciones activas del envio (la guia fallo
+// o se cancelo antes de cobrarse)
+func (uc *walletUseCase) ReleaseShipmentHolds(ctx context.Context, shipmentID uint) error {
+	holds, err := uc.repo.ListShipmentWalletHolds(ctx, shipmentID, entities.WalletHoldStatusHeld)
+	if err != nil {
+		return err
+	}
+	return uc.voidHolds(ctx, holds)
+}
+
+// voidHolds libera cada retencion; las que otro proceso ya movio se omiten
+func (uc *walletUseCase) voidHolds(ctx context.Context, holds []*entities.WalletHold) error {
+	for _, hold := range holds {
+		if _, err := uc.VoidHold(ctx, hold.ID.String()); err != nil && !errors.Is(err, payerrs.ErrHoldNotActive) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc *walletUseCase) ListHolds(ctx context.Context, businessID uint, status string) ([]*entities.WalletHold, error) {
+	return uc.repo.ListWalletHolds(ctx, businessID, status)
+}
+
+func (uc *walletUseCase) getHold(ctx context.Context, holdID string) (*entities.WalletHold, error) {
+	id, err := uuid.Parse(holdID)
+	if err != nil {
+		return nil, payerrs.ErrHoldNotFound
+	}
+	return uc.repo.GetWalletHoldByID(ctx, id)
+}
+
+// CheckLedgerConsistency recalcula el saldo de cada billetera desde sus
+// asientos y reporta las que no coinciden y los journals que no suman cero
+func (uc *walletUseCase) CheckLedgerConsistency(ctx context.Context) (*entities.LedgerConsistencyReport, error) {
+	wallets, err := uc.repo.GetAllWallets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	drifts, err := uc.repo.ListWalletLedgerDrift(ctx)
+	if err != nil {
+		return nil, err
+	}
+	unbalanced, err := uc.repo.ListUnbalancedWalletJournals(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entities.LedgerConsistencyReport{
+		CheckedAt:          time.Now(),
+		WalletsChecked:     len(wallets),
+		Drifts:             drifts,
+		UnbalancedJournals: unbalanced,
+	}
+
+	for _, d := range drifts {
+		uc.log.Warn(ctx).
+			Uint("business_id", d.BusinessID).
+			Str("wallet_id", d.WalletID.String()).
+			Float64("balance_drift", d.BalanceDrift()).
+			Float64("held_drift", d.HeldDrift()).
+			Msg("Wallet balance does not match its ledger")
+	}
+	if len(unbalanced) > 0 {
+		uc.log.Error(ctx).
+			Int("count", len(unbalanced)).
+			Msg("Wallet journals whose entries do not sum to zero")
+	}
+
+	return report, nil
+}
@@ -0,0 +1,326 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/entities"
+	payerrs "github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/pay/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func activeHold(wallet *entities.Wallet, amount float64) *entities.WalletHold {
+	return &entities.WalletHold{
+		ID:             uuid.New(),
+		WalletID:       wallet.ID,
+		BusinessID:     wallet.BusinessID,
+		Amount:         amount,
+		Status:         entities.WalletHoldStatusHeld,
+		IdempotencyKey: "guide:1:abc",
+		Reference:      "Guia envio 1",
+	}
+}
+
+func TestPlaceHold_Exitoso_PasaDeDisponibleARetenidoExigiendoFondos(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+	wallet := existingWallet(30, 50000)
+
+	repo.On("GetWalletHoldByIdempotencyKey", ctx, uint(30), "guide:1:abc").Return(nil, nil)
+	repo.On("GetWalletByBusinessID", ctx, uint(30)).Return(wallet, nil)
+	repo.On("CreateWalletHold", ctx, mock.Anything).Return(nil)
+	expectJournalPosted(repo, wallet)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	hold, err := uc.PlaceHold(ctx, &dtos.PlaceHoldDTO{
+		BusinessID:     30,
+		Amount:         12000,
+		Reference:      "Guia envio 1",
+		IdempotencyKey: "guide:1:abc",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.WalletHoldStatusHeld, hold.Status)
+	assert.InDelta(t, 38000.0, wallet.Balance, 0.001)
+	assert.InDelta(t, 12000.0, wallet.HeldBalance, 0.001)
+	repo.AssertCalled(t, "PostWalletJournal", mock.Anything, mock.Anything, true)
+}
+
+func TestPlaceHold_SaldoInsuficiente_RetornaErrorDelRepositorio(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+	wallet := existingWallet(30, 1000)
+
+	repo.On("GetWalletByBusinessID", ctx, uint(30)).Return(wallet, nil)
+	repo.On("CreateWalletHold", ctx, mock.Anything).Return(nil)
+	repo.On("PostWalletJournal", ctx, mock.Anything, true).Return(nil, payerrs.ErrInsufficientBalance)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	_, err := uc.PlaceHold(ctx, &dtos.PlaceHoldDTO{BusinessID: 30, Amount: 12000})
+
+	assert.ErrorIs(t, err, payerrs.ErrInsufficientBalance)
+}
+
+func TestPlaceHold_MismaLlave_RetornaLaRetencionExistente(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+	existing := activeHold(existingWallet(30, 0), 12000)
+
+	repo.On("GetWalletHoldByIdempotencyKey", ctx, uint(30), existing.IdempotencyKey).Return(existing, nil)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	hold, err := uc.PlaceHold(ctx, &dtos.PlaceHoldDTO{BusinessID: 30, Amount: 12000, IdempotencyKey: existing.IdempotencyKey})
+
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID, hold.ID)
+	repo.AssertNotCalled(t, "CreateWalletHold", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "PostWalletJournal", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPlaceHold_MismaLlaveEnOtroNegocio_CreaRetencionPropia(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+	wallet := existingWallet(31, 50000)
+
+	repo.On("GetWalletHoldByIdempotencyKey", ctx, uint(31), "guide:1:abc").Return(nil, nil)
+	repo.On("GetWalletByBusinessID", ctx, uint(31)).Return(wallet, nil)
+	repo.On("CreateWalletHold", ctx, mock.Anything).Return(nil)
+	expectJournalPosted(repo, wallet)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	hold, err := uc.PlaceHold(ctx, &dtos.PlaceHoldDTO{BusinessID: 31, Amount: 12000, IdempotencyKey: "guide:1:abc"})
+
+	require.NoError(t, err)
+	assert.Equal(t, uint(31), hold.BusinessID)
+	assert.Equal(t, wallet.ID, hold.WalletID)
+	repo.AssertCalled(t, "CreateWalletHold", ctx, mock.Anything)
+}
+
+func TestHoldForGuide_SinBilletera_NoRetieneNiLaCrea(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+
+	repo.On("GetWalletByBusinessID", ctx, uint(30)).Return(nil, nil)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	_, err := uc.HoldForGuide(ctx, &dtos.HoldForGuideDTO{BusinessID: 30, ShipmentID: 1, Amount: 12000, CorrelationID: "abc"})
+
+	assert.ErrorIs(t, err, payerrs.ErrWalletNotFound)
+	repo.AssertNotCalled(t, "CreateWallet", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "CreateWalletHold", mock.Anything, mock.Anything)
+}
+
+func TestCaptureHold_CostoFinalMenor_DevuelveLaDiferenciaAlDisponible(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+	wallet := existingWallet(30, 38000)
+	wallet.HeldBalance = 12000
+	hold := activeHold(wallet, 12000)
+
+	var createdTx *entities.WalletTransaction
+	repo.On("GetWalletHoldByID", ctx, hold.ID).Return(hold, nil)
+	repo.On("GetWalletByID", ctx, wallet.ID).Return(wallet, nil)
+	repo.On("TransitionWalletHold", ctx, hold, entities.WalletHoldStatusHeld).Return(nil)
+	repo.On("CreateWalletTransaction", ctx, mock.MatchedBy(func(tx *entities.WalletTransaction) bool {
+		createdTx = tx
+		return true
+	})).Return(nil)
+	expectJournalPosted(repo, wallet)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	got, err := uc.CaptureHold(ctx, &dtos.CaptureHoldDTO{HoldID: hold.ID.String(), Amount: 10500})
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.WalletHoldStatusCaptured, got.Status)
+	assert.InDelta(t, 10500.0, got.CapturedAmount, 0.001)
+	require.NotNil(t, createdTx)
+	assert.Equal(t, entities.WalletTxTypeUsage, createdTx.Type)
+	assert.Equal(t, entities.WalletTxConceptGuide, createdTx.Concept)
+	assert.InDelta(t, 10500.0, createdTx.Amount, 0.001)
+	assert.InDelta(t, 39500.0, wallet.Balance, 0.001)
+	assert.InDelta(t, 0.0, wallet.HeldBalance, 0.001)
+
+	journals := postedJournals(repo)
+	require.Len(t, journals, 1)
+	assert.Equal(t, "capture:"+hold.ID.String(), journals[0].IdempotencyKey)
+	assert.True(t, journals[0].IsBalanced())
+	repo.AssertCalled(t, "PostWalletJournal", ctx, mock.Anything, false)
+}
+
+func TestCaptureHold_CostoFinalMayor_ExigeSaldoParaElExcedente(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+	wallet := existingWallet(30, 5000)
+	wallet.HeldBalance = 12000
+	hold := activeHold(wallet, 12000)
+
+	repo.On("GetWalletHoldByID", ctx, hold.ID).Return(hold, nil)
+	repo.On("GetWalletByID", ctx, wallet.ID).Return(wallet, nil)
+	repo.On("TransitionWalletHold", ctx, hold, entities.WalletHoldStatusHeld).Return(nil)
+	repo.On("CreateWalletTransaction", ctx, mock.Anything).Return(nil)
+	expectJournalPosted(repo, wallet)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	got, err := uc.CaptureHold(ctx, &dtos.CaptureHoldDTO{HoldID: hold.ID.String(), Amount: 15000})
+
+	require.NoError(t, err)
+	assert.InDelta(t, 15000.0, got.CapturedAmount, 0.001)
+	assert.InDelta(t, 2000.0, wallet.Balance, 0.001, "el excedente sale del disponible")
+	repo.AssertCalled(t, "PostWalletJournal", ctx, mock.Anything, true)
+}
+
+func TestCaptureHold_CostoFinalMayorSinSaldo_NoCobra(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+	wallet := existingWallet(30, 1000)
+	wallet.HeldBalance = 12000
+	hold := activeHold(wallet, 12000)
+
+	repo.On("GetWalletHoldByID", ctx, hold.ID).Return(hold, nil)
+	repo.On("GetWalletByID", ctx, wallet.ID).Return(wallet, nil)
+	repo.On("TransitionWalletHold", ctx, hold, entities.WalletHoldStatusHeld).Return(nil)
+	repo.On("CreateWalletTransaction", ctx, mock.Anything).Return(nil)
+	repo.On("PostWalletJournal", ctx, mock.Anything, true).Return(nil, payerrs.ErrInsufficientBalance)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	_, err := uc.CaptureHold(ctx, &dtos.CaptureHoldDTO{HoldID: hold.ID.String(), Amount: 15000})
+
+	assert.ErrorIs(t, err, payerrs.ErrInsufficientBalance)
+	assert.InDelta(t, 1000.0, wallet.Balance, 0.001)
+}
+
+func TestCaptureHold_YaCapturada_EsIdempotente(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+	hold := activeHold(existingWallet(30, 0), 12000)
+	hold.Status = entities.WalletHoldStatusCaptured
+
+	repo.On("GetWalletHoldByID", ctx, hold.ID).Return(hold, nil)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	got, err := uc.CaptureHold(ctx, &dtos.CaptureHoldDTO{HoldID: hold.ID.String()})
+
+	require.NoError(t, err)
+	assert.Equal(t, hold.ID, got.ID)
+	repo.AssertNotCalled(t, "PostWalletJournal", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCaptureHold_Liberada_RetornaHoldNotActive(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+	hold := activeHold(existingWallet(30, 0), 12000)
+	hold.Status = entities.WalletHoldStatusVoided
+
+	repo.On("GetWalletHoldByID", ctx, hold.ID).Return(hold, nil)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	_, err := uc.CaptureHold(ctx, &dtos.CaptureHoldDTO{HoldID: hold.ID.String()})
+
+	assert.ErrorIs(t, err, payerrs.ErrHoldNotActive)
+}
+
+func TestCaptureHold_OtroProcesoLaMovio_NoCobra(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+	wallet := existingWallet(30, 38000)
+	hold := activeHold(wallet, 12000)
+
+	repo.On("GetWalletHoldByID", ctx, hold.ID).Return(hold, nil)
+	repo.On("GetWalletByID", ctx, wallet.ID).Return(wallet, nil)
+	repo.On("TransitionWalletHold", ctx, hold, entities.WalletHoldStatusHeld).Return(payerrs.ErrHoldNotActive)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	_, err := uc.CaptureHold(ctx, &dtos.CaptureHoldDTO{HoldID: hold.ID.String()})
+
+	assert.ErrorIs(t, err, payerrs.ErrHoldNotActive)
+	repo.AssertNotCalled(t, "CreateWalletTransaction", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "PostWalletJournal", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVoidHold_DevuelveElMontoAlDisponible(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+	wallet := existingWallet(30, 38000)
+	wallet.HeldBalance = 12000
+	hold := activeHold(wallet, 12000)
+
+	repo.On("GetWalletHoldByID", ctx, hold.ID).Return(hold, nil)
+	repo.On("GetWalletByID", ctx, wallet.ID).Return(wallet, nil)
+	repo.On("TransitionWalletHold", ctx, hold, entities.WalletHoldStatusHeld).Return(nil)
+	expectJournalPosted(repo, wallet)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	got, err := uc.VoidHold(ctx, hold.ID.String())
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.WalletHoldStatusVoided, got.Status)
+	assert.NotNil(t, got.VoidedAt)
+	assert.InDelta(t, 50000.0, wallet.Balance, 0.001)
+	assert.InDelta(t, 0.0, wallet.HeldBalance, 0.001)
+	repo.AssertNotCalled(t, "CreateWalletTransaction", mock.Anything, mock.Anything)
+}
+
+func TestVoidHold_IDInvalido_RetornaHoldNotFound(t *testing.T) {
+	repo := new(mocks.RepositoryMock)
+	uc := newWalletUseCaseForTest(repo)
+
+	_, err := uc.VoidHold(context.Background(), "no-es-uuid")
+
+	assert.ErrorIs(t, err, payerrs.ErrHoldNotFound)
+}
+
+func TestCheckLedgerConsistency_ReportaDescuadres(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+	w1, w2 := existingWallet(1, 100), existingWallet(2, 500)
+	drift := entities.WalletLedgerDrift{WalletID: w2.ID, BusinessID: 2, Balance: 500, LedgerBalance: 483.65}
+	unbalanced := uuid.New()
+
+	repo.On("GetAllWallets", ctx).Return([]*entities.Wallet{w1, w2}, nil)
+	repo.On("ListWalletLedgerDrift", ctx).Return([]entities.WalletLedgerDrift{drift}, nil)
+	repo.On("ListUnbalancedWalletJournals", ctx).Return([]uuid.UUID{unbalanced}, nil)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	report, err := uc.CheckLedgerConsistency(ctx)
+
+	require.NoError(t, err)
+	assert.False(t, report.Consistent())
+	assert.Equal(t, 2, report.WalletsChecked)
+	require.Len(t, report.Drifts, 1)
+	assert.InDelta(t, 16.35, report.Drifts[0].BalanceDrift(), 0.001)
+	assert.Equal(t, []uuid.UUID{unbalanced}, report.UnbalancedJournals)
+}
+
+func TestCheckLedgerConsistency_SinDescuadres_EsConsistente(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.RepositoryMock)
+
+	repo.On("GetAllWallets", ctx).Return([]*entities.Wallet{existingWallet(1, 100)}, nil)
+	repo.On("ListWalletLedgerDrift", ctx).Return([]entities.WalletLedgerDrift{}, nil)
+	repo.On("ListUnbalancedWalletJournals", ctx).Return([]uuid.UUID{}, nil)
+
+	uc := newWalletUseCaseForTest(repo)
+
+	report, err := uc.CheckLedgerConsistency(ctx)
+
+	require.NoError(t, err)
+	assert.True(t, report.Consistent())
+}
@@ -60,7 +60,7 @@ func TestRechargeWallet_Exitoso_CreaPendienteSinAcreditarSaldo(t *testing.T) {
 	assert.InDelta(t, 300000.0, tx.Amount, 0.001)
 
 	assert.InDelta(t, 5000.0, wallet.Balance, 0.001)
-	repo.AssertNotCalled(t, "UpdateWallet", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "PostWalletJournal", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestRechargeWallet_SinReferencia_GeneraReferenciaManual(t *testing.T) {
@@ -158,7 +158,7 @@ func TestRechargeYAprobacion_FlujoCompleto_AcreditaUnaSolaVez(t *testing.T) {
 	repo.On("GetWalletTransactionByID", ctx, tx.ID).Return(tx, nil)
 	repo.On("UpdateWalletTransaction", ctx, tx).Return(nil)
 	repo.On("GetWalletByID", ctx, wallet.ID).Return(wallet, nil)
-	repo.On("UpdateWallet", ctx, wallet).Return(nil)
+	expectJournalPosted(repo, wallet)
 
 	require.NoError(t, uc.ApproveTransaction(ctx, tx.ID.String()))
 	assert.InDelta(t, 51000.0, wallet.Balance, 0.001)
@@ -10,11 +10,13 @@ type RechargeWalletDTO struct {
 
 // ManualDebitDTO datos para débito manual (admin)
 type ManualDebitDTO struct {
-	BusinessID uint
-	Amount     float64
-	Reference  string
-	Concept    string
-	UserID     *uint
+	BusinessID     uint
+	Amount         float64
+	Reference      string
+	Concept        string
+	UserID         *uint
+	ShipmentID     *uint
+	IdempotencyKey string // vacío: cada llamada es un débito nuevo
 }
 
 // DebitForGuideDTO datos para débito por generación de guía
@@ -23,6 +25,32 @@ type DebitForGuideDTO struct {
 	Amount         float64
 	TrackingNumber string
 	UserID         *uint
+	ShipmentID     *uint // con envío: se captura su retención activa si la hay
+}
+
+// HoldForGuideDTO datos para retener el costo de una guía en generación
+type HoldForGuideDTO struct {
+	BusinessID    uint
+	ShipmentID    uint
+	Amount        float64
+	CorrelationID string // cada solicitud a la transportadora tiene su retención
+}
+
+// PlaceHoldDTO datos para retener saldo
+type PlaceHoldDTO struct {
+	BusinessID     uint
+	Amount         float64
+	Reference      string
+	ShipmentID     *uint
+	IdempotencyKey string // vacío: se genera una
+}
+
+// CaptureHoldDTO datos para cobrar una retención
+type CaptureHoldDTO struct {
+	HoldID  string
+	Amount  float64 // 0: se cobra el monto retenido
+	Concept string
+	UserID  *uint
 }
 
 // FinancialStatsDTO datos para obtener estadísticas financieras
@@ -41,13 +41,15 @@ func ValidWalletTxConcept(c string) bool {
 	}
 }
 
-// Wallet es la billetera de un negocio
+// Wallet es la billetera de un negocio. Balance es el saldo disponible;
+// HeldBalance lo reservado por retenciones activas
 type Wallet struct {
-	ID         uuid.UUID
-	BusinessID uint
-	Balance    float64
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID          uuid.UUID
+	BusinessID  uint
+	Balance     float64
+	HeldBalance float64
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 }
 
 // WalletTransaction es un movimiento de la billetera
@@ -67,6 +69,7 @@ type WalletTransaction struct {
 	GatewayRequest       []byte
 	GatewayResponse      []byte
 	IntegrationImageURL  string
+	ShipmentID           *uint // guia cobrada (USAGE de concepto GUIDE)
 	CreatedAt            time.Time
 	BusinessID           uint
 }
@@ -0,0 +1,220 @@
+package entities
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Tipos de journal del libro mayor de la billetera
+const (
+	LedgerKindOpening    = "opening"
+	LedgerKindRecharge   = "recharge"
+	LedgerKindDebit      = "debit"
+	LedgerKindAdjustment = "adjustment"
+	LedgerKindHold       = "hold"
+	LedgerKindCapture    = "capture"
+	LedgerKindVoid       = "void"
+)
+
+// Cuentas del libro mayor. available y held son de la billetera; las system:*
+// son la contrapartida (dinero que entra, ingreso de la plataforma, ajustes)
+const (
+	LedgerAccountAvailable  = "available"
+	LedgerAccountHeld       = "held"
+	LedgerAccountFunding    = "system:funding"
+	LedgerAccountRevenue    = "system:revenue"
+	LedgerAccountAdjustment = "system:adjustment"
+	LedgerAccountOpening    = "system:opening"
+)
+
+// Estados de una retencion de saldo
+const (
+	WalletHoldStatusHeld     = "HELD"
+	WalletHoldStatusCaptured = "CAPTURED"
+	WalletHoldStatusVoided   = "VOIDED"
+)
+
+// ledgerTolerance es el descuadre que se ignora (redondeo a centavos)
+const ledgerTolerance = 0.005
+
+// WalletJournal es una operacion del libro mayor: sus asientos suman cero
+type WalletJournal struct {
+	ID             uuid.UUID
+	WalletID       uuid.UUID
+	BusinessID     uint
+	Kind           string
+	IdempotencyKey string
+	TransactionID  *uuid.UUID
+	HoldID         *uuid.UUID
+	Description    string
+	Entries        []WalletLedgerEntry
+	CreatedAt      time.Time
+}
+
+// WalletLedgerEntry es un asiento. Amount va con signo; WalletID es nil en
+// las cuentas system:*
+type WalletLedgerEntry struct {
+	ID        uint
+	JournalID uuid.UUID
+	WalletID  *uuid.UUID
+	Account   string
+	Amount    float64
+	CreatedAt time.Time
+}
+
+// WalletHold es saldo reservado para una guia mientras la transportadora la
+// confirma: se captura (cobro) o se libera (cancelacion o fallo)
+type WalletHold struct {
+	ID             uuid.UUID
+	WalletID       uuid.UUID
+	BusinessID     uint
+	Amount         float64
+	CapturedAmount float64
+	Status         string // HELD|CAPTURED|VOIDED
+	IdempotencyKey string
+	Reference      string
+	ShipmentID     *uint
+	TransactionID  *uuid.UUID
+	CapturedAt     *time.Time
+	VoidedAt       *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func newWalletJournal(wallet *Wallet, kind, key, description string) *WalletJournal {
+	return &WalletJournal{
+		ID:             uuid.New(),
+		WalletID:       wallet.ID,
+		BusinessID:     wallet.BusinessID,
+		Kind:           kind,
+		IdempotencyKey: key,
+		Description:    description,
+		CreatedAt:      time.Now(),
+	}
+}
+
+func (j *WalletJournal) walletEntry(account string, amount float64) {
+	walletID := j.WalletID
+	j.Entries = append(j.Entries, WalletLedgerEntry{JournalID: j.ID, WalletID: &walletID, Account: account, Amount: roundCents(amount)})
+}
+
+func (j *WalletJournal) systemEntry(account string, amount float64) {
+	j.Entries = append(j.Entries, WalletLedgerEntry{JournalID: j.ID, Account: account, Amount: roundCents(amount)})
+}
+
+// NewDebitJournal descuenta amount del disponible como ingreso de la plataforma
+func NewDebitJournal(wallet *Wallet, amount float64, key, description string) *WalletJournal {
+	j := newWalletJournal(wallet, LedgerKindDebit, key, description)
+	j.walletEntry(LedgerAccountAvailable, -amount)
+	j.systemEntry(LedgerAccountRevenue, amount)
+	return j
+}
+
+// NewRechargeJournal acredita una recarga aprobada al disponible
+func NewRechargeJournal(wallet *Wallet, amount float64, key, description string) *WalletJournal {
+	j := newWalletJournal(wallet, LedgerKindRecharge, key, description)
+	j.walletEntry(LedgerAccountAvailable, amount)
+	j.systemEntry(LedgerAccountFunding, -amount)
+	return j
+}
+
+// NewAdjustmentJournal suma (o resta, si amount es negativo) al disponible
+func NewAdjustmentJournal(wallet *Wallet, amount float64, key, description string) *WalletJournal {
+	j := newWalletJournal(wallet, LedgerKindAdjustment, key, description)
+	j.walletEntry(LedgerAccountAvailable, amount)
+	j.systemEntry(LedgerAccountAdjustment, -amount)
+	return j
+}
+
+// NewHoldJournal pasa el monto de la retencion de disponible a retenido
+func NewHoldJournal(wallet *Wallet, hold *WalletHold) *WalletJournal {
+	j := newWalletJournal(wallet, LedgerKindHold, "hold:"+hold.ID.String(), hold.Reference)
+	j.HoldID = &hold.ID
+	j.walletEntry(LedgerAccountAvailable, -hold.Amount)
+	j.walletEntry(LedgerAccountHeld, hold.Amount)
+	return j
+}
+
+// NewCaptureJournal cobra amount contra la retencion. Si el costo final es
+// distinto al retenido, la diferencia vuelve al disponible (o se descuenta de el)
+func NewCaptureJournal(wallet *Wallet, hold *WalletHold, amount float64) *WalletJournal {
+	j := newWalletJournal(wallet, LedgerKindCapture, "capture:"+hold.ID.String(), hold.Reference)
+	j.HoldID = &hold.ID
+	j.walletEntry(LedgerAccountHeld, -hold.Amount)
+	if diff := hold.Amount - amount; math.Abs(diff) >= ledgerTolerance {
+		j.walletEntry(LedgerAccountAvailable, diff)
+	}
+	j.systemEntry(LedgerAccountRevenue, amount)
+	return j
+}
+
+// NewVoidJournal devuelve la retencion completa al disponible
+func NewVoidJournal(wallet *Wallet, hold *WalletHold) *WalletJournal {
+	j := newWalletJournal(wallet, LedgerKindVoid, "void:"+hold.ID.String(), hold.Reference)
+	j.HoldID = &hold.ID
+	j.walletEntry(LedgerAccountHeld, -hold.Amount)
+	j.walletEntry(LedgerAccountAvailable, hold.Amount)
+	return j
+}
+
+// IsBalanced indica si los asientos suman cero
+func (j *WalletJournal) IsBalanced() bool {
+	sum := 0.0
+	for _, e := range j.Entries {
+		sum += e.Amount
+	}
+	return len(j.Entries) >= 2 && math.Abs(sum) < ledgerTolerance
+}
+
+// WalletDelta retorna cuanto cambian el disponible y el retenido de la billetera
+func (j *WalletJournal) WalletDelta() (available, held float64) {
+	for _, e := range j.Entries {
+		switch e.Account {
+		case LedgerAccountAvailable:
+			available += e.Amount
+		case LedgerAccountHeld:
+			held += e.Amount
+		}
+	}
+	return roundCents(available), roundCents(held)
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// WalletLedgerDrift es una billetera cuyo saldo guardado no coincide con la
+// suma de sus asientos
+type WalletLedgerDrift struct {
+	WalletID      uuid.UUID
+	BusinessID    uint
+	Balance       float64
+	LedgerBalance float64
+	HeldBalance   float64
+	LedgerHeld    float64
+}
+
+// BalanceDrift es saldo guardado menos saldo del libro
+func (d WalletLedgerDrift) BalanceDrift() float64 {
+	return roundCents(d.Balance - d.LedgerBalance)
+}
+
+// HeldDrift es retenido guardado menos retenido del libro
+func (d WalletLedgerDrift) HeldDrift() float64 {
+	return roundCents(d.HeldBalance - d.LedgerHeld)
+}
+
+// LedgerConsistencyReport es el resultado de recalcular todas las billeteras
+type LedgerConsistencyReport struct {
+	CheckedAt          time.Time
+	WalletsChecked     int
+	Drifts             []WalletLedgerDrift
+	UnbalancedJournals []uuid.UUID
+}
+
+// Consistent indica si no hubo descuadres
+func (r *LedgerConsistencyReport) Consistent() bool {
+	return len(r.Drifts) == 0 && len(r.UnbalancedJournals) == 0
+}
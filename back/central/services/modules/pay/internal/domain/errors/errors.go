@@ -15,6 +15,11 @@ var (
 	ErrMinimumRechargeAmount = errors.New("amount is below the minimum recharge amount")
 	ErrInsufficientBalance   = errors.New("insufficient wallet balance")
 
+	ErrDuplicateIdempotencyKey = errors.New("wallet operation already applied")
+	ErrUnbalancedJournal       = errors.New("wallet journal entries do not sum to zero")
+	ErrHoldNotFound            = errors.New("wallet hold not found")
+	ErrHoldNotActive           = errors.New("wallet hold is already captured or voided")
+
 	ErrBoldConfigNotFound      = errors.New("bold integration type not configured")
 	ErrBoldCredentialsMissing  = errors.New("bold credentials are not configured")
 	ErrBoldOrderNotFound       = errors.New("bold order not found")
@@ -27,7 +27,6 @@ type IRepository interface {
 	GetWalletByBusinessID(ctx context.Context, businessID uint) (*entities.Wallet, error)
 	GetWalletByID(ctx context.Context, walletID uuid.UUID) (*entities.Wallet, error)
 	CreateWallet(ctx context.Context, wallet *entities.Wallet) error
-	UpdateWallet(ctx context.Context, wallet *entities.Wallet) error
 	GetAllWallets(ctx context.Context) ([]*entities.Wallet, error)
 
 	CreateWalletTransaction(ctx context.Context, tx *entities.WalletTransaction) error
@@ -41,6 +40,26 @@ type IRepository interface {
 	DeleteTransactionsByWalletIDAndType(ctx context.Context, walletID uuid.UUID, txType string) error
 	DeleteAllTransactionsByWalletID(ctx context.Context, walletID uuid.UUID) error
 
+	// PostWalletJournal guarda el journal con sus asientos y aplica su efecto al
+	// saldo en un solo UPDATE. ErrDuplicateIdempotencyKey si el negocio ya uso la llave;
+	// con requireFunds, ErrInsufficientBalance si el disponible quedaria negativo
+	PostWalletJournal(ctx context.Context, journal *entities.WalletJournal, requireFunds bool) (*entities.Wallet, error)
+	CreateWalletHold(ctx context.Context, hold *entities.WalletHold) error
+	GetWalletHoldByID(ctx context.Context, id uuid.UUID) (*entities.WalletHold, error)
+	// GetWalletHoldByIdempotencyKey busca la retencion del negocio con esa llave;
+	// nil si no existe. La llave es unica por negocio, no global
+	GetWalletHoldByIdempotencyKey(ctx context.Context, businessID uint, key string) (*entities.WalletHold, error)
+	// TransitionWalletHold guarda el hold solo si sigue en fromStatus; si otro
+	// proceso ya lo movio retorna ErrHoldNotActive
+	TransitionWalletHold(ctx context.Context, hold *entities.WalletHold, fromStatus string) error
+	ListWalletHolds(ctx context.Context, businessID uint, status string) ([]*entities.WalletHold, error)
+	// ListShipmentWalletHolds retorna las retenciones del envio, la mas reciente primero
+	ListShipmentWalletHolds(ctx context.Context, shipmentID uint, status string) ([]*entities.WalletHold, error)
+	// HasShipmentUsage indica si el envio ya tiene un movimiento USAGE (la guia ya se cobro)
+	HasShipmentUsage(ctx context.Context, shipmentID uint) (bool, error)
+	ListWalletLedgerDrift(ctx context.Context) ([]entities.WalletLedgerDrift, error)
+	ListUnbalancedWalletJournals(ctx context.Context) ([]uuid.UUID, error)
+
 	GetFinancialStats(ctx context.Context, dto *dtos.FinancialStatsDTO) (*dtos.FinancialStatsResponse, error)
 
 	GetBoldCredentials(ctx context.Context) (*dtos.BoldCredentials, error)
@@ -61,6 +80,12 @@ type IRepository interface {
 	GetPlatformIntegrationID(ctx context.Context, businessID uint) (*uint, error)
 }
 
+// ITransactor abre una transaccion: lo que se escriba con el ctx que recibe fn
+// (movimientos, saldo y eventos del outbox) se confirma o revierte junto.
+type ITransactor interface {
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 type IRequestPublisher interface {
 	PublishPaymentRequest(ctx context.Context, msg *dtos.PaymentRequestMessage) error
 }
@@ -90,12 +115,20 @@ type IWalletUseCase interface {
 	RejectTransaction(ctx context.Context, transactionID string) error
 	ManualDebit(ctx context.Context, dto *dtos.ManualDebitDTO) error
 	DebitForGuide(ctx context.Context, dto *dtos.DebitForGuideDTO) error
+	HoldForGuide(ctx context.Context, dto *dtos.HoldForGuideDTO) (*entities.WalletHold, error)
+	ReleaseShipmentHolds(ctx context.Context, shipmentID uint) error
 	GetAllWallets(ctx context.Context) ([]*entities.Wallet, error)
 	GetPendingTransactions(ctx context.Context) ([]*entities.WalletTransaction, error)
 	GetProcessedTransactions(ctx context.Context) ([]*entities.WalletTransaction, error)
 	GetTransactionsByBusinessID(ctx context.Context, businessID uint) ([]*entities.WalletTransaction, error)
 	ClearRechargeHistory(ctx context.Context, businessID uint) error
 	AdminAdjustBalance(ctx context.Context, dto *dtos.AdminAdjustBalanceDTO) error
+
+	PlaceHold(ctx context.Context, dto *dtos.PlaceHoldDTO) (*entities.WalletHold, error)
+	CaptureHold(ctx context.Context, dto *dtos.CaptureHoldDTO) (*entities.WalletHold, error)
+	VoidHold(ctx context.Context, holdID string) (*entities.WalletHold, error)
+	ListHolds(ctx context.Context, businessID uint, status string) ([]*entities.WalletHold, error)
+	CheckLedgerConsistency(ctx context.Context) (*entities.LedgerConsistencyReport, error)
 	GetFinancialStats(ctx context.Context, dto *dtos.FinancialStatsDTO) (*dtos.FinancialStatsResponse, error)
 
 	BoldGenerateSignature(ctx context.Context, businessID uint, amount float64, currency string) (*dtos.BoldSignatureResponse, error)
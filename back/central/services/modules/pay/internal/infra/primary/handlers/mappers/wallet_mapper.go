@@ -10,11 +10,12 @@ import (
 // WalletToResponse convierte una entidad Wallet a respuesta HTTP
 func WalletToResponse(w *entities.Wallet) *response.WalletResponse {
 	return &response.WalletResponse{
-		ID:         w.ID.String(),
-		BusinessID: w.BusinessID,
-		Balance:    w.Balance,
-		CreatedAt:  w.CreatedAt,
-		UpdatedAt:  w.UpdatedAt,
+		ID:          w.ID.String(),
+		BusinessID:  w.BusinessID,
+		Balance:     w.Balance,
+		HeldBalance: w.HeldBalance,
+		CreatedAt:   w.CreatedAt,
+		UpdatedAt:   w.UpdatedAt,
 	}
 }
 
@@ -94,3 +95,62 @@ func WalletTxListToResponse(txs []*entities.WalletTransaction) []*response.Walle
 	}
 	return result
 }
+
+// WalletHoldToResponse convierte una retención a respuesta HTTP
+func WalletHoldToResponse(h *entities.WalletHold) *response.WalletHoldResponse {
+	resp := &response.WalletHoldResponse{
+		ID:             h.ID.String(),
+		WalletID:       h.WalletID.String(),
+		BusinessID:     h.BusinessID,
+		Amount:         h.Amount,
+		CapturedAmount: h.CapturedAmount,
+		Status:         h.Status,
+		IdempotencyKey: h.IdempotencyKey,
+		Reference:      h.Reference,
+		ShipmentID:     h.ShipmentID,
+		CapturedAt:     h.CapturedAt,
+		VoidedAt:       h.VoidedAt,
+		CreatedAt:      h.CreatedAt,
+	}
+	if h.TransactionID != nil {
+		id := h.TransactionID.String()
+		resp.TransactionID = &id
+	}
+	return resp
+}
+
+// WalletHoldListToResponse convierte una lista de retenciones a respuesta HTTP
+func WalletHoldListToResponse(holds []*entities.WalletHold) []*response.WalletHoldResponse {
+	result := make([]*response.WalletHoldResponse, len(holds))
+	for i, h := range holds {
+		result[i] = WalletHoldToResponse(h)
+	}
+	return result
+}
+
+// LedgerConsistencyToResponse convierte el reporte del verificador a respuesta HTTP
+func LedgerConsistencyToResponse(r *entities.LedgerConsistencyReport) *response.LedgerConsistencyResponse {
+	resp := &response.LedgerConsistencyResponse{
+		CheckedAt:          r.CheckedAt,
+		WalletsChecked:     r.WalletsChecked,
+		Consistent:         r.Consistent(),
+		Drifts:             make([]response.WalletLedgerDriftResponse, len(r.Drifts)),
+		UnbalancedJournals: make([]string, len(r.UnbalancedJournals)),
+	}
+	for i, d := range r.Drifts {
+		resp.Drifts[i] = response.WalletLedgerDriftResponse{
+			WalletID:      d.WalletID.String(),
+			BusinessID:    d.BusinessID,
+			Balance:       d.Balance,
+			LedgerBalance: d.LedgerBalance,
+			BalanceDrift:  d.BalanceDrift(),
+			HeldBalance:   d.HeldBalance,
+			LedgerHeld:    d.LedgerHeld,
+			HeldDrift:     d.HeldDrift(),
+		}
+	}
+	for i, id := range r.UnbalancedJournals {
+		resp.UnbalancedJournals[i] = id.String()
+	}
+	return resp
+}
@@ -22,3 +22,17 @@ type DebitForGuideRequest struct {
 	TrackingNumber string  `json:"tracking_number" binding:"required"`
 	BusinessID     *uint   `json:"business_id"` // Requerido cuando el caller es admin (business_id=0)
 }
+
+// PlaceHoldRequest cuerpo de petición para retener saldo
+type PlaceHoldRequest struct {
+	Amount         float64 `json:"amount" binding:"required,gt=0"`
+	Reference      string  `json:"reference" binding:"max=255"`
+	ShipmentID     *uint   `json:"shipment_id"`
+	IdempotencyKey string  `json:"idempotency_key" binding:"max=150"`
+}
+
+// CaptureHoldRequest cuerpo de petición para cobrar una retención
+type CaptureHoldRequest struct {
+	Amount  float64 `json:"amount" binding:"gte=0"` // 0: se cobra el monto retenido
+	Concept string  `json:"concept"`
+}
@@ -4,11 +4,12 @@ import "time"
 
 // WalletResponse respuesta HTTP de una billetera
 type WalletResponse struct {
-	ID         string    `json:"ID"`
-	BusinessID uint      `json:"BusinessID"`
-	Balance    float64   `json:"Balance"`
-	CreatedAt  time.Time `json:"CreatedAt"`
-	UpdatedAt  time.Time `json:"UpdatedAt"`
+	ID          string    `json:"ID"`
+	BusinessID  uint      `json:"BusinessID"`
+	Balance     float64   `json:"Balance"`
+	HeldBalance float64   `json:"HeldBalance"`
+	CreatedAt   time.Time `json:"CreatedAt"`
+	UpdatedAt   time.Time `json:"UpdatedAt"`
 }
 
 // WalletTransactionResponse respuesta HTTP de una transacción de billetera
@@ -31,3 +32,41 @@ type WalletTransactionResponse struct {
 	CreatedAt            time.Time `json:"CreatedAt"`
 	BusinessID           uint      `json:"BusinessID"`
 }
+
+// WalletHoldResponse respuesta HTTP de una retención de saldo
+type WalletHoldResponse struct {
+	ID             string     `json:"id"`
+	WalletID       string     `json:"wallet_id"`
+	BusinessID     uint       `json:"business_id"`
+	Amount         float64    `json:"amount"`
+	CapturedAmount float64    `json:"captured_amount"`
+	Status         string     `json:"status"`
+	IdempotencyKey string     `json:"idempotency_key"`
+	Reference      string     `json:"reference"`
+	ShipmentID     *uint      `json:"shipment_id,omitempty"`
+	TransactionID  *string    `json:"transaction_id,omitempty"`
+	CapturedAt     *time.Time `json:"captured_at,omitempty"`
+	VoidedAt       *time.Time `json:"voided_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// WalletLedgerDriftResponse billetera cuyo saldo no coincide con su libro mayor
+type WalletLedgerDriftResponse struct {
+	WalletID      string  `json:"wallet_id"`
+	BusinessID    uint    `json:"business_id"`
+	Balance       float64 `json:"balance"`
+	LedgerBalance float64 `json:"ledger_balance"`
+	BalanceDrift  float64 `json:"balance_drift"`
+	HeldBalance   float64 `json:"held_balance"`
+	LedgerHeld    float64 `json:"ledger_held"`
+	HeldDrift     float64 `json:"held_drift"`
+}
+
+// LedgerConsistencyResponse resultado del verificador de consistencia
+type LedgerConsistencyResponse struct {
+	CheckedAt          time.Time                   `json:"checked_at"`
+	WalletsChecked     int                         `json:"wallets_checked"`
+	Consistent         bool                        `json:"consistent"`
+	Drifts             []WalletLedgerDriftResponse `json:"drifts"`
+	UnbalancedJournals []string                    `json:"unbalanced_journals"`
+}
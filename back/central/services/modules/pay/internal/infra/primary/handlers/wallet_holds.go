@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+	"github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/dtos"
+	payerrs "github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/pay/internal/infra/primary/handlers/mappers"
+	"github.com/secamc93/probability/back/central/services/modules/pay/internal/infra/primary/handlers/request"
+)
+
+// ListHolds maneja GET /pay/wallet/holds
+func (h *walletHandler) ListHolds(c *gin.Context) {
+	businessID, ok := resolveBusinessID(c)
+	if !ok {
+		return
+	}
+
+	holds, err := h.walletUC.ListHolds(c.Request.Context(), businessID, c.Query("status"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.WalletHoldListToResponse(holds))
+}
+
+// PlaceHold maneja POST /pay/wallet/holds
+func (h *walletHandler) PlaceHold(c *gin.Context) {
+	businessID, ok := resolveBusinessID(c)
+	if !ok {
+		return
+	}
+
+	var req request.PlaceHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	hold, err := h.walletUC.PlaceHold(c.Request.Context(), &dtos.PlaceHoldDTO{
+		BusinessID:     businessID,
+		Amount:         req.Amount,
+		Reference:      req.Reference,
+		ShipmentID:     req.ShipmentID,
+		IdempotencyKey: req.IdempotencyKey,
+	})
+	if err != nil {
+		c.JSON(holdErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, mappers.WalletHoldToResponse(hold))
+}
+
+// CaptureHold maneja POST /pay/wallet/admin/holds/:id/capture
+func (h *walletHandler) CaptureHold(c *gin.Context) {
+	if !middleware.IsSuperAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+		return
+	}
+
+	var req request.CaptureHoldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var userID *uint
+	if uid, ok := middleware.GetUserID(c); ok && uid != 0 {
+		userID = &uid
+	}
+
+	hold, err := h.walletUC.CaptureHold(c.Request.Context(), &dtos.CaptureHoldDTO{
+		HoldID:  c.Param("id"),
+		Amount:  req.Amount,
+		Concept: req.Concept,
+		UserID:  userID,
+	})
+	if err != nil {
+		c.JSON(holdErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.WalletHoldToResponse(hold))
+}
+
+// VoidHold maneja POST /pay/wallet/admin/holds/:id/void
+func (h *walletHandler) VoidHold(c *gin.Context) {
+	if !middleware.IsSuperAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+		return
+	}
+
+	hold, err := h.walletUC.VoidHold(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.JSON(holdErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.WalletHoldToResponse(hold))
+}
+
+// GetLedgerConsistency maneja GET /pay/wallet/admin/ledger/consistency
+func (h *walletHandler) GetLedgerConsistency(c *gin.Context) {
+	if !middleware.IsSuperAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+		return
+	}
+
+	report, err := h.walletUC.CheckLedgerConsistency(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, mappers.LedgerConsistencyToResponse(report))
+}
+
+func holdErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, payerrs.ErrHoldNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, payerrs.ErrHoldNotActive):
+		return http.StatusConflict
+	case errors.Is(err, payerrs.ErrInsufficientBalance):
+		return http.StatusPaymentRequired
+	case errors.Is(err, payerrs.ErrInvalidAmount):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -15,6 +15,8 @@ func (h *walletHandler) RegisterWalletRoutes(router *gin.RouterGroup) {
 		wallet.POST("/recharge", h.RechargeWallet)
 		wallet.GET("/history", h.GetHistory)
 		wallet.POST("/debit-guide", h.DebitForGuide)
+		wallet.GET("/holds", h.ListHolds)
+		wallet.POST("/holds", h.PlaceHold)
 
 		wallet.GET("/bold/signature", h.BoldGenerateSignature)
 		wallet.GET("/bold/status/:id", h.GetBoldStatus)
@@ -33,5 +35,8 @@ func (h *walletHandler) RegisterWalletRoutes(router *gin.RouterGroup) {
 		wallet.GET("/admin/financial-stats", h.GetFinancialStats)
 		wallet.GET("/admin/kpi-selection", h.GetKPISelection)
 		wallet.POST("/admin/kpi-selection", h.UpdateKPISelection)
+		wallet.POST("/admin/holds/:id/capture", h.CaptureHold)
+		wallet.POST("/admin/holds/:id/void", h.VoidHold)
+		wallet.GET("/admin/ledger/consistency", h.GetLedgerConsistency)
 	}
 }
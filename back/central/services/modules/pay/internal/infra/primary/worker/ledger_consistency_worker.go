@@ -0,0 +1,54 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+// ledgerCheckHour corre de madrugada, cuando casi no se mueven saldos
+const ledgerCheckHour = 3
+
+// LedgerConsistencyWorker recalcula cada noche los saldos desde el libro mayor
+// y deja en el log las billeteras descuadradas
+type LedgerConsistencyWorker struct {
+	uc  ports.IWalletUseCase
+	log log.ILogger
+}
+
+func NewLedgerConsistencyWorker(uc ports.IWalletUseCase, logger log.ILogger) *LedgerConsistencyWorker {
+	return &LedgerConsistencyWorker{uc: uc, log: logger.WithModule("pay.ledger_consistency_worker")}
+}
+
+func (w *LedgerConsistencyWorker) Start(ctx context.Context) {
+	for {
+		next := nextDailyRun(time.Now(), ledgerCheckHour)
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			w.runCheck(ctx)
+		}
+	}
+}
+
+func (w *LedgerConsistencyWorker) runCheck(ctx context.Context) {
+	report, err := w.uc.CheckLedgerConsistency(ctx)
+	if err != nil {
+		w.log.Error(ctx).Err(err).Msg("failed to check wallet ledger consistency")
+		return
+	}
+	if report.Consistent() {
+		w.log.Info(ctx).Int("wallets", report.WalletsChecked).Msg("wallet ledger consistent")
+		return
+	}
+	w.log.Error(ctx).
+		Int("wallets", report.WalletsChecked).
+		Int("drifts", len(report.Drifts)).
+		Int("unbalanced_journals", len(report.UnbalancedJournals)).
+		Msg("wallet ledger drift detected")
+}
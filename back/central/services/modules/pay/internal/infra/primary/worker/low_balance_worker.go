@@ -21,7 +21,7 @@ func NewLowBalanceWorker(uc ports.IWalletUseCase, logger log.ILogger) *LowBalanc
 
 func (w *LowBalanceWorker) Start(ctx context.Context) {
 	for {
-		next := nextDailyRun(time.Now())
+		next := nextDailyRun(time.Now(), dailyRunHour)
 		timer := time.NewTimer(time.Until(next))
 		select {
 		case <-ctx.Done():
@@ -33,9 +33,9 @@ func (w *LowBalanceWorker) Start(ctx context.Context) {
 	}
 }
 
-func nextDailyRun(now time.Time) time.Time {
+func nextDailyRun(now time.Time, hour int) time.Time {
 	loc := now.Location()
-	target := time.Date(now.Year(), now.Month(), now.Day(), dailyRunHour, 0, 0, 0, loc)
+	target := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, loc)
 	if !now.Before(target) {
 		target = target.Add(24 * time.Hour)
 	}
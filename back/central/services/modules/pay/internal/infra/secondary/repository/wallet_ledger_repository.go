@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/entities"
+	payerrs "github.com/secamc93/probability/back/central/services/modules/pay/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/shared/db"
+	models "github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Wallet Ledger
+
+func (r *Repository) PostWalletJournal(ctx context.Context, journal *entities.WalletJournal, requireFunds bool) (*entities.Wallet, error) {
+	available, held := journal.WalletDelta()
+
+	var updated models.Wallet
+	err := db.InTransaction(ctx, r.db, func(ctx context.Context) error {
+		conn := r.db.Conn(ctx)
+
+		// La llave unica es la que evita el doble cobro: si dos procesos
+		// postean la misma operacion, el segundo no inserta y no toca el saldo.
+		// Es por negocio: la llave de otro negocio no descarta este journal
+		res := conn.Omit(clause.Associations).
+			Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "business_id"}, {Name: "idempotency_key"}}, DoNothing: true}).
+			Create(walletJournalToModel(journal))
+		if res.Error != nil {
+			return fmt.Errorf("failed to create wallet journal: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return payerrs.ErrDuplicateIdempotencyKey
+		}
+
+		entries := walletLedgerEntriesToModel(journal)
+		if err := conn.Omit(clause.Associations).Create(&entries).Error; err != nil {
+			return fmt.Errorf("failed to create wallet ledger entries: %w", err)
+		}
+
+		q := conn.Model(&models.Wallet{}).Where("id = ?", journal.WalletID)
+		if requireFunds {
+			q = q.Where("balance + ? >= 0", available)
+		}
+		res = q.Updates(map[string]any{
+			"balance":      gorm.Expr("balance + ?", available),
+			"held_balance": gorm.Expr("held_balance + ?", held),
+			"updated_at":   time.Now(),
+		})
+		if res.Error != nil {
+			return fmt.Errorf("failed to update wallet balance: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			if requireFunds {
+				return payerrs.ErrInsufficientBalance
+			}
+			return payerrs.ErrWalletNotFound
+		}
+
+		return conn.Where("id = ?", journal.WalletID).First(&updated).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return walletToDomain(&updated), nil
+}
+
+func (r *Repository) CreateWalletHold(ctx context.Context, hold *entities.WalletHold) error {
+	m := walletHoldToModel(hold)
+	res := r.db.Conn(ctx).Omit(clause.Associations).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "business_id"}, {Name: "idempotency_key"}}, DoNothing: true}).
+		Create(m)
+	if res.Error != nil {
+		return fmt.Errorf("failed to create wallet hold: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return payerrs.ErrDuplicateIdempotencyKey
+	}
+	hold.CreatedAt = m.CreatedAt
+	hold.UpdatedAt = m.UpdatedAt
+	return nil
+}
+
+func (r *Repository) GetWalletHoldByID(ctx context.Context, id uuid.UUID) (*entities.WalletHold, error) {
+	var m models.WalletHold
+	if err := r.db.Conn(ctx).Where("id = ?", id).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, payerrs.ErrHoldNotFound
+		}
+		return nil, err
+	}
+	return walletHoldToDomain(&m), nil
+}
+
+func (r *Repository) GetWalletHoldByIdempotencyKey(ctx context.Context, businessID uint, key string) (*entities.WalletHold, error) {
+	var m models.WalletHold
+	if err := r.db.Conn(ctx).Where("business_id = ? AND idempotency_key = ?", businessID, key).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return walletHoldToDomain(&m), nil
+}
+
+func (r *Repository) TransitionWalletHold(ctx context.Context, hold *entities.WalletHold, fromStatus string) error {
+	res := r.db.Conn(ctx).Model(&models.WalletHold{}).
+		Where("id = ? AND status = ?", hold.ID, fromStatus).
+		Updates(map[string]any{
+			"status":          hold.Status,
+			"captured_amount": hold.CapturedAmount,
+			"transaction_id":  hold.TransactionID,
+			"captured_at":     hold.CapturedAt,
+			"voided_at":       hold.VoidedAt,
+			"updated_at":      time.Now(),
+		})
+	if res.Error != nil {
+		return fmt.Errorf("failed to update wallet hold: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return payerrs.ErrHoldNotActive
+	}
+	return nil
+}
+
+func (r *Repository) ListWalletHolds(ctx context.Context, businessID uint, status string) ([]*entities.WalletHold, error) {
+	q := r.db.Conn(ctx).Where("business_id = ?", businessID)
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	var list []models.WalletHold
+	if err := q.Order("created_at DESC").Limit(200).Find(&list).Error; err != nil {
+		return nil, err
+	}
+	result := make([]*entities.WalletHold, len(list))
+	for i := range list {
+		result[i] = walletHoldToDomain(&list[i])
+	}
+	return result, nil
+}
+
+func (r *Repository) ListShipmentWalletHolds(ctx context.Context, shipmentID uint, status string) ([]*entities.WalletHold, error) {
+	q := r.db.Conn(ctx).Where("shipment_id = ?", shipmentID)
+	if status != "" {
+		q = q.Where("status = ?", status)
+	}
+	var list []models.WalletHold
+	if err := q.Order("created_at DESC").Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("failed to list shipment wallet holds: %w", err)
+	}
+	result := make([]*entities.WalletHold, len(list))
+	for i := range list {
+		result[i] = walletHoldToDomain(&list[i])
+	}
+	return result, nil
+}
+
+func (r *Repository) HasShipmentUsage(ctx context.Context, shipmentID uint) (bool, error) {
+	var count int64
+	if err := r.db.Conn(ctx).Model(&models.WalletTransaction{}).
+		Where("shipment_id = ? AND type = ?", shipmentID, entities.WalletTxTypeUsage).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check shipment wallet usage: %w", err)
+	}
+	return count > 0, nil
+}
+
+func (r *Repository) ListWalletLedgerDrift(ctx context.Context) ([]entities.WalletLedgerDrift, error) {
+	var rows []struct {
+		WalletID      uuid.UUID
+		BusinessID    uint
+		Balance       float64
+		HeldBalance   float64
+		LedgerBalance float64
+		LedgerHeld    float64
+	}
+	err := r.db.Conn(ctx).Raw(`
+SELECT w.id AS wallet_id, w.business_id, w.balance, w.held_balance,
+       COALESCE(SUM(e.amount) FILTER (WHERE e.account = ?), 0) AS ledger_balance,
+       COALESCE(SUM(e.amount) FILTER (WHERE e.account = ?), 0) AS ledger_held
+FROM wallet w
+LEFT JOIN wallet_ledger_entries e ON e.wallet_id = w.id
+GROUP BY w.id, w.business_id, w.balance, w.held_balance
+HAVING ABS(w.balance - COALESCE(SUM(e.amount) FILTER (WHERE e.account = ?), 0)) >= 0.005
+    OR ABS(w.held_balance - COALESCE(SUM(e.amount) FILTER (WHERE e.account = ?), 0)) >= 0.005
+ORDER BY w.business_id
+`, entities.LedgerAccountAvailable, entities.LedgerAccountHeld,
+		entities.LedgerAccountAvailable, entities.LedgerAccountHeld).Scan(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute wallet ledger drift: %w", err)
+	}
+
+	result := make([]entities.WalletLedgerDrift, len(rows))
+	for i, row := range rows {
+		result[i] = entities.WalletLedgerDrift{
+			WalletID:      row.WalletID,
+			BusinessID:    row.BusinessID,
+			Balance:       row.Balance,
+			LedgerBalance: row.LedgerBalance,
+			HeldBalance:   row.HeldBalance,
+			LedgerHeld:    row.LedgerHeld,
+		}
+	}
+	return result, nil
+}
+
+func (r *Repository) ListUnbalancedWalletJournals(ctx context.Context) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Conn(ctx).Model(&models.WalletLedgerEntry{}).
+		Select("journal_id").
+		Group("journal_id").
+		Having("ABS(SUM(amount)) >= 0.005").
+		Pluck("journal_id", &ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find unbalanced wallet journals: %w", err)
+	}
+	return ids, nil
+}
+
+// Ledger Mappers
+
+func walletJournalToModel(e *entities.WalletJournal) *models.WalletJournal {
+	return &models.WalletJournal{
+		ID:             e.ID,
+		WalletID:       e.WalletID,
+		BusinessID:     e.BusinessID,
+		Kind:           e.Kind,
+		IdempotencyKey: e.IdempotencyKey,
+		TransactionID:  e.TransactionID,
+		HoldID:         e.HoldID,
+		Description:    truncate(e.Description, 255),
+		CreatedAt:      e.CreatedAt,
+	}
+}
+
+func walletLedgerEntriesToModel(e *entities.WalletJournal) []models.WalletLedgerEntry {
+	result := make([]models.WalletLedgerEntry, len(e.Entries))
+	for i, entry := range e.Entries {
+		result[i] = models.WalletLedgerEntry{
+			JournalID: e.ID,
+			WalletID:  entry.WalletID,
+			Account:   entry.Account,
+			Amount:    entry.Amount,
+			CreatedAt: e.CreatedAt,
+		}
+	}
+	return result
+}
+
+func walletHoldToModel(e *entities.WalletHold) *models.WalletHold {
+	return &models.WalletHold{
+		ID:             e.ID,
+		WalletID:       e.WalletID,
+		BusinessID:     e.BusinessID,
+		Amount:         e.Amount,
+		CapturedAmount: e.CapturedAmount,
+		Status:         e.Status,
+		IdempotencyKey: e.IdempotencyKey,
+		Reference:      truncate(e.Reference, 255),
+		ShipmentID:     e.ShipmentID,
+		TransactionID:  e.TransactionID,
+		CapturedAt:     e.CapturedAt,
+		VoidedAt:       e.VoidedAt,
+		CreatedAt:      e.CreatedAt,
+		UpdatedAt:      e.UpdatedAt,
+	}
+}
+
+func walletHoldToDomain(m *models.WalletHold) *entities.WalletHold {
+	return &entities.WalletHold{
+		ID:             m.ID,
+		WalletID:       m.WalletID,
+		BusinessID:     m.BusinessID,
+		Amount:         m.Amount,
+		CapturedAmount: m.CapturedAmount,
+		Status:         m.Status,
+		IdempotencyKey: m.IdempotencyKey,
+		Reference:      m.Reference,
+		ShipmentID:     m.ShipmentID,
+		TransactionID:  m.TransactionID,
+		CapturedAt:     m.CapturedAt,
+		VoidedAt:       m.VoidedAt,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
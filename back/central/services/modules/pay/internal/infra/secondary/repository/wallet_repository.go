@@ -53,14 +53,6 @@ func (r *Repository) CreateWallet(ctx context.Context, wallet *entities.Wallet)
 	return nil
 }
 
-func (r *Repository) UpdateWallet(ctx context.Context, wallet *entities.Wallet) error {
-	m := walletToModel(wallet)
-	if err := r.db.Conn(ctx).Save(m).Error; err != nil {
-		return fmt.Errorf("failed to update wallet: %w", err)
-	}
-	return nil
-}
-
 func (r *Repository) GetAllWallets(ctx context.Context) ([]*entities.Wallet, error) {
 	var list []models.Wallet
 	if err := r.db.Conn(ctx).Find(&list).Error; err != nil {
@@ -201,21 +193,23 @@ func (r *Repository) DeleteAllTransactionsByWalletID(ctx context.Context, wallet
 
 func walletToModel(e *entities.Wallet) *models.Wallet {
 	return &models.Wallet{
-		ID:         e.ID,
-		BusinessID: e.BusinessID,
-		Balance:    e.Balance,
-		CreatedAt:  e.CreatedAt,
-		UpdatedAt:  e.UpdatedAt,
+		ID:          e.ID,
+		BusinessID:  e.BusinessID,
+		Balance:     e.Balance,
+		HeldBalance: e.HeldBalance,
+		CreatedAt:   e.CreatedAt,
+		UpdatedAt:   e.UpdatedAt,
 	}
 }
 
 func walletToDomain(m *models.Wallet) *entities.Wallet {
 	return &entities.Wallet{
-		ID:         m.ID,
-		BusinessID: m.BusinessID,
-		Balance:    m.Balance,
-		CreatedAt:  m.CreatedAt,
-		UpdatedAt:  m.UpdatedAt,
+		ID:          m.ID,
+		BusinessID:  m.BusinessID,
+		Balance:     m.Balance,
+		HeldBalance: m.HeldBalance,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
 	}
 }
 
@@ -235,6 +229,7 @@ func walletTxToModel(e *entities.WalletTransaction) *models.WalletTransaction {
 		IntegrationID:        e.IntegrationID,
 		GatewayRequest:       datatypes.JSON(e.GatewayRequest),
 		GatewayResponse:      datatypes.JSON(e.GatewayResponse),
+		ShipmentID:           e.ShipmentID,
 		CreatedAt:            e.CreatedAt,
 		BusinessID:           e.BusinessID,
 	}
@@ -256,6 +251,7 @@ func walletTxToDomain(m *models.WalletTransaction) *entities.WalletTransaction {
 		IntegrationID:        m.IntegrationID,
 		GatewayRequest:       []byte(m.GatewayRequest),
 		GatewayResponse:      []byte(m.GatewayResponse),
+		ShipmentID:           m.ShipmentID,
 		CreatedAt:            m.CreatedAt,
 		BusinessID:           m.BusinessID,
 	}
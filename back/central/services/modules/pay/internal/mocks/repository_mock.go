@@ -105,10 +105,6 @@ func (m *RepositoryMock) CreateWallet(ctx context.Context, wallet *entities.Wall
 	return m.Called(ctx, wallet).Error(0)
 }
 
-func (m *RepositoryMock) UpdateWallet(ctx context.Context, wallet *entities.Wallet) error {
-	return m.Called(ctx, wallet).Error(0)
-}
-
 func (m *RepositoryMock) GetAllWallets(ctx context.Context) ([]*entities.Wallet, error) {
 	args := m.Called(ctx)
 	if args.Get(0) == nil {
@@ -177,6 +173,75 @@ func (m *RepositoryMock) DeleteAllTransactionsByWalletID(ctx context.Context, wa
 	return m.Called(ctx, walletID).Error(0)
 }
 
+func (m *RepositoryMock) PostWalletJournal(ctx context.Context, journal *entities.WalletJournal, requireFunds bool) (*entities.Wallet, error) {
+	args := m.Called(ctx, journal, requireFunds)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.Wallet), args.Error(1)
+}
+
+func (m *RepositoryMock) CreateWalletHold(ctx context.Context, hold *entities.WalletHold) error {
+	return m.Called(ctx, hold).Error(0)
+}
+
+func (m *RepositoryMock) GetWalletHoldByID(ctx context.Context, id uuid.UUID) (*entities.WalletHold, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.WalletHold), args.Error(1)
+}
+
+func (m *RepositoryMock) GetWalletHoldByIdempotencyKey(ctx context.Context, businessID uint, key string) (*entities.WalletHold, error) {
+	args := m.Called(ctx, businessID, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.WalletHold), args.Error(1)
+}
+
+func (m *RepositoryMock) TransitionWalletHold(ctx context.Context, hold *entities.WalletHold, fromStatus string) error {
+	return m.Called(ctx, hold, fromStatus).Error(0)
+}
+
+func (m *RepositoryMock) ListWalletHolds(ctx context.Context, businessID uint, status string) ([]*entities.WalletHold, error) {
+	args := m.Called(ctx, businessID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.WalletHold), args.Error(1)
+}
+
+func (m *RepositoryMock) ListShipmentWalletHolds(ctx context.Context, shipmentID uint, status string) ([]*entities.WalletHold, error) {
+	args := m.Called(ctx, shipmentID, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.WalletHold), args.Error(1)
+}
+
+func (m *RepositoryMock) HasShipmentUsage(ctx context.Context, shipmentID uint) (bool, error) {
+	args := m.Called(ctx, shipmentID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *RepositoryMock) ListWalletLedgerDrift(ctx context.Context) ([]entities.WalletLedgerDrift, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entities.WalletLedgerDrift), args.Error(1)
+}
+
+func (m *RepositoryMock) ListUnbalancedWalletJournals(ctx context.Context) ([]uuid.UUID, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
 func (m *RepositoryMock) GetFinancialStats(ctx context.Context, dto *dtos.FinancialStatsDTO) (*dtos.FinancialStatsResponse, error) {
 	args := m.Called(ctx, dto)
 	if args.Get(0) == nil {
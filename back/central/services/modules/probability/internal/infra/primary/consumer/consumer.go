@@ -31,13 +31,15 @@ func (c *Consumer) Start(ctx context.Context) error {
 	}
 
 	c.logger.Info(ctx).Str("queue", rabbitmq.QueueOrdersToScore).Msg("Probability score consumer iniciado")
-	return c.queue.Consume(ctx, rabbitmq.QueueOrdersToScore, c.handleMessage)
+	return rabbitmq.ConsumeDeliveries(ctx, c.queue, rabbitmq.QueueOrdersToScore, c.handleMessage, 1)
 }
 
-func (c *Consumer) handleMessage(msg []byte) error {
+func (c *Consumer) handleMessage(msgCtx context.Context, d rabbitmq.Delivery) error {
+	ctx := context.WithoutCancel(msgCtx)
+
 	var event orderEventMessage
-	if err := json.Unmarshal(msg, &event); err != nil {
-		c.logger.Error(context.Background()).Err(err).Msg("Error deserializando evento en probability consumer")
+	if err := json.Unmarshal(d.Body, &event); err != nil {
+		c.logger.Error(ctx).Err(err).Msg("Error deserializando evento en probability consumer")
 		return nil // ACK: mensaje malformado, no reintentar
 	}
 
@@ -46,11 +48,10 @@ func (c *Consumer) handleMessage(msg []byte) error {
 	}
 
 	if event.OrderID == "" {
-		c.logger.Warn(context.Background()).Str("event_type", event.EventType).Msg("Evento sin order_id, ignorando")
+		c.logger.Warn(ctx).Str("event_type", event.EventType).Msg("Evento sin order_id, ignorando")
 		return nil
 	}
 
-	ctx := context.Background()
 	if err := c.useCase.CalculateAndUpdateOrderScore(ctx, event.OrderID); err != nil {
 		c.logger.Error(ctx).Err(err).
 			Str("order_id", event.OrderID).
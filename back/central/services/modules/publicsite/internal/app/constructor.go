@@ -22,6 +22,8 @@ type IUseCase interface {
 	GetSession(ctx context.Context, slug string, userID uint) (*entities.TiendaSession, error)
 	GetMyPrices(ctx context.Context, slug string, userID uint) (map[string]float64, error)
 	GetMyOrders(ctx context.Context, slug string, userID uint, page, pageSize int) ([]entities.TiendaOrder, int64, error)
+	RequestReturn(ctx context.Context, slug string, userID uint, orderID string, dto *dtos.CreateReturnDTO) (*entities.TiendaReturn, error)
+	GetMyReturns(ctx context.Context, slug string, userID uint, page, pageSize int) ([]entities.TiendaReturn, int64, error)
 	UpdateProfile(ctx context.Context, slug string, userID uint, name, phone, dni string) error
 	SaveAvatar(ctx context.Context, slug string, userID uint, avatarURL string) error
 	ListAddresses(ctx context.Context, slug string, userID uint) ([]entities.CustomerAddress, error)
@@ -32,11 +34,12 @@ type IUseCase interface {
 }
 
 type UseCase struct {
-	repo   ports.IRepository
-	orders ports.IBoldGateway
-	logger log.ILogger
+	repo    ports.IRepository
+	orders  ports.IBoldGateway
+	returns ports.IReturnsGateway
+	logger  log.ILogger
 }
 
-func New(repo ports.IRepository, orders ports.IBoldGateway, logger log.ILogger) IUseCase {
-	return &UseCase{repo: repo, orders: orders, logger: logger}
+func New(repo ports.IRepository, orders ports.IBoldGateway, returns ports.IReturnsGateway, logger log.ILogger) IUseCase {
+	return &UseCase{repo: repo, orders: orders, returns: returns, logger: logger}
 }
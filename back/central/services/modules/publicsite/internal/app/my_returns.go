@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/publicsite/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/publicsite/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/publicsite/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/returns"
+)
+
+// RequestReturn pide la devolucion de items de un pedido del cliente
+func (uc *UseCase) RequestReturn(ctx context.Context, slug string, userID uint, orderID string, dto *dtos.CreateReturnDTO) (*entities.TiendaReturn, error) {
+	if uc.returns == nil {
+		return nil, domainerrors.ErrReturnsUnavailable
+	}
+
+	business, err := uc.repo.GetBusinessBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if business == nil {
+		return nil, domainerrors.ErrBusinessNotFound
+	}
+
+	var customerID uint
+	session, err := uc.repo.GetClientSession(ctx, business.ID, userID)
+	if err == nil && session != nil {
+		customerID = session.CustomerID
+	}
+	owns, err := uc.repo.CustomerOwnsOrder(ctx, business.ID, userID, customerID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if !owns {
+		return nil, domainerrors.ErrOrderNotFound
+	}
+
+	req := returns.CustomerReturnRequest{
+		BusinessID: business.ID,
+		UserID:     userID,
+		OrderID:    orderID,
+		Reason:     dto.Reason,
+		Note:       dto.Note,
+	}
+	for _, item := range dto.Items {
+		req.Items = append(req.Items, returns.CustomerReturnItem{
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+			Reason:      item.Reason,
+		})
+	}
+
+	summary, err := uc.returns.RequestReturn(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	out := toTiendaReturn(summary)
+	return &out, nil
+}
+
+func (uc *UseCase) GetMyReturns(ctx context.Context, slug string, userID uint, page, pageSize int) ([]entities.TiendaReturn, int64, error) {
+	if uc.returns == nil {
+		return nil, 0, domainerrors.ErrReturnsUnavailable
+	}
+
+	business, err := uc.repo.GetBusinessBySlug(ctx, slug)
+	if err != nil {
+		return nil, 0, err
+	}
+	if business == nil {
+		return nil, 0, domainerrors.ErrBusinessNotFound
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 50 {
+		pageSize = 10
+	}
+
+	items, total, err := uc.returns.ListCustomerReturns(ctx, business.ID, userID, page, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	out := make([]entities.TiendaReturn, 0, len(items))
+	for i := range items {
+		out = append(out, toTiendaReturn(&items[i]))
+	}
+	return out, total, nil
+}
+
+func toTiendaReturn(s *returns.ReturnSummary) entities.TiendaReturn {
+	out := entities.TiendaReturn{
+		ID:             s.ID,
+		Code:           s.Code,
+		OrderID:        s.OrderID,
+		OrderNumber:    s.OrderNumber,
+		Status:         s.Status,
+		Reason:         s.Reason,
+		RequestedTotal: s.RequestedTotal,
+		RefundMethod:   s.RefundMethod,
+		RefundAmount:   s.RefundAmount,
+		CreatedAt:      s.CreatedAt,
+	}
+	for _, item := range s.Items {
+		out.Items = append(out.Items, entities.TiendaReturnItem{
+			ProductName:      item.ProductName,
+			ProductSKU:       item.ProductSKU,
+			Quantity:         item.Quantity,
+			Reason:           item.Reason,
+			InspectionResult: item.InspectionResult,
+		})
+	}
+	return out
+}
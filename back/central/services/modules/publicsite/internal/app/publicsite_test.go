@@ -21,7 +21,7 @@ func newPublicsiteUseCase(repo *mocks.RepositoryMock, bold *mocks.BoldGatewayMoc
 	if bold == nil {
 		bold = &mocks.BoldGatewayMock{}
 	}
-	return New(repo, bold, mocks.NewSilentLogger())
+	return New(repo, bold, &mocks.ReturnsGatewayMock{}, mocks.NewSilentLogger())
 }
 
 func repoSinNegocio() *mocks.RepositoryMock {
@@ -1293,3 +1293,42 @@ func TestGetMyOrders_ErrorDelRepo_SePropaga(t *testing.T) {
 
 	assert.ErrorIs(t, err, dbErr)
 }
+
+func TestRequestReturn_PedidoDeOtroCliente_NoCreaDevolucion(t *testing.T) {
+	repo := &mocks.RepositoryMock{}
+	devoluciones := &mocks.ReturnsGatewayMock{}
+	uc := New(repo, &mocks.BoldGatewayMock{}, devoluciones, mocks.NewSilentLogger())
+
+	_, err := uc.RequestReturn(context.Background(), "demo", 42, "ord-1", &dtos.CreateReturnDTO{
+		Items: []dtos.ReturnItemDTO{{OrderItemID: 10, Quantity: 1}},
+	})
+
+	assert.ErrorIs(t, err, domainerrors.ErrOrderNotFound)
+	assert.Empty(t, devoluciones.Requests)
+}
+
+func TestRequestReturn_PedidoPropio_CreaConElUsuarioDeLaSesion(t *testing.T) {
+	var propietario struct{ userID, customerID uint }
+	repo := &mocks.RepositoryMock{
+		CustomerOwnsOrderFn: func(ctx context.Context, businessID, userID, customerID uint, orderID string) (bool, error) {
+			propietario.userID, propietario.customerID = userID, customerID
+			return true, nil
+		},
+	}
+	devoluciones := &mocks.ReturnsGatewayMock{}
+	uc := New(repo, &mocks.BoldGatewayMock{}, devoluciones, mocks.NewSilentLogger())
+
+	got, err := uc.RequestReturn(context.Background(), "demo", 42, "ord-1", &dtos.CreateReturnDTO{
+		Reason: "size_or_fit",
+		Items:  []dtos.ReturnItemDTO{{OrderItemID: 10, Quantity: 1}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "RMA-000001", got.Code)
+	assert.Equal(t, uint(42), propietario.userID)
+	assert.Equal(t, uint(7), propietario.customerID)
+	require.Len(t, devoluciones.Requests, 1)
+	assert.Equal(t, uint(26), devoluciones.Requests[0].BusinessID)
+	assert.Equal(t, uint(42), devoluciones.Requests[0].UserID)
+	assert.Equal(t, "size_or_fit", devoluciones.Requests[0].Reason)
+}
@@ -0,0 +1,13 @@
+package dtos
+
+type CreateReturnDTO struct {
+	Reason string
+	Note   string
+	Items  []ReturnItemDTO
+}
+
+type ReturnItemDTO struct {
+	OrderItemID uint
+	Quantity    int
+	Reason      string
+}
@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+// TiendaReturn es una devolucion del cliente vista desde la tienda
+type TiendaReturn struct {
+	ID             uint
+	Code           string
+	OrderID        string
+	OrderNumber    string
+	Status         string
+	Reason         string
+	RequestedTotal float64
+	RefundMethod   string
+	RefundAmount   float64
+	CreatedAt      time.Time
+	Items          []TiendaReturnItem
+}
+
+type TiendaReturnItem struct {
+	ProductName      string
+	ProductSKU       string
+	Quantity         int
+	Reason           string
+	InspectionResult string
+}
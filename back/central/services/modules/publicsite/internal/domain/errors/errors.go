@@ -11,4 +11,6 @@ var (
 	ErrInvalidCartItem     = errors.New("cantidad invalida en un item del carrito")
 	ErrCheckoutNotFound    = errors.New("checkout no encontrado")
 	ErrOnlinePayNotReady   = errors.New("el pago en linea no esta disponible para esta tienda")
+	ErrOrderNotFound       = errors.New("pedido no encontrado")
+	ErrReturnsUnavailable  = errors.New("las devoluciones no estan disponibles")
 )
@@ -6,6 +6,7 @@ import (
 	"github.com/secamc93/probability/back/central/services/modules/pay"
 	"github.com/secamc93/probability/back/central/services/modules/publicsite/internal/domain/dtos"
 	"github.com/secamc93/probability/back/central/services/modules/publicsite/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/modules/returns"
 )
 
 type IRepository interface {
@@ -27,6 +28,7 @@ type IRepository interface {
 	UserBelongsToBusiness(ctx context.Context, businessID uint, userID uint) (bool, error)
 	GetCustomerPrices(ctx context.Context, businessID uint, clientID uint) (map[string]float64, error)
 	ListCustomerOrders(ctx context.Context, businessID, userID, customerID uint, page, pageSize int) ([]entities.TiendaOrder, int64, error)
+	CustomerOwnsOrder(ctx context.Context, businessID, userID, customerID uint, orderID string) (bool, error)
 
 	UpdateClientProfile(ctx context.Context, businessID, userID uint, name, phone, dni string) error
 	UpdateUserAvatar(ctx context.Context, userID uint, avatarURL string) error
@@ -44,3 +46,9 @@ type IBoldGateway interface {
 	BoldGenerateSignatureForReference(ctx context.Context, businessID uint, amount float64, currency, referencePrefix string) (*pay.BoldSignature, error)
 	PublishAgreedStorefrontOrder(ctx context.Context, reference string) error
 }
+
+// IReturnsGateway crea y lista las devoluciones que pide el cliente
+type IReturnsGateway interface {
+	RequestReturn(ctx context.Context, req returns.CustomerReturnRequest) (*returns.ReturnSummary, error)
+	ListCustomerReturns(ctx context.Context, businessID, userID uint, page, pageSize int) ([]returns.ReturnSummary, int64, error)
+}
@@ -21,6 +21,8 @@ type IHandlers interface {
 	GetSession(c *gin.Context)
 	GetMyPrices(c *gin.Context)
 	GetMyOrders(c *gin.Context)
+	RequestReturn(c *gin.Context)
+	GetMyReturns(c *gin.Context)
 	UpdateProfile(c *gin.Context)
 	UploadAvatar(c *gin.Context)
 	ListAddresses(c *gin.Context)
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/modules/publicsite/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/publicsite/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/publicsite/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/modules/returns"
+)
+
+func (h *Handlers) RequestReturn(c *gin.Context) {
+	slug := c.Param("slug")
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "error": "sesion no valida"})
+		return
+	}
+
+	var req request.CreateReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": "datos invalidos: " + err.Error()})
+		return
+	}
+
+	ret, err := h.uc.RequestReturn(c.Request.Context(), slug, userID, c.Param("id"), req.ToDTO())
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrBusinessNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "negocio no encontrado"})
+		case errors.Is(err, domainerrors.ErrOrderNotFound), returns.IsOrderNotFound(err):
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "pedido no encontrado"})
+		case returns.IsRequestError(err):
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "error": err.Error()})
+		case errors.Is(err, domainerrors.ErrReturnsUnavailable):
+			c.JSON(http.StatusServiceUnavailable, gin.H{"success": false, "error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": "error solicitando la devolucion"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"success": true, "data": tiendaReturnToJSON(ret)})
+}
+
+func (h *Handlers) GetMyReturns(c *gin.Context) {
+	slug := c.Param("slug")
+	userID := c.GetUint("user_id")
+	if userID == 0 {
+		c.JSON(http.StatusUnauthorized, gin.H{"success": false, "error": "sesion no valida"})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "10"))
+
+	items, total, err := h.uc.GetMyReturns(c.Request.Context(), slug, userID, page, pageSize)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrBusinessNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "error": "negocio no encontrado"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "error": "error consultando devoluciones"})
+		return
+	}
+
+	data := make([]gin.H, 0, len(items))
+	for i := range items {
+		data = append(data, tiendaReturnToJSON(&items[i]))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data":    data,
+		"total":   total,
+		"page":    page,
+	})
+}
+
+func tiendaReturnToJSON(r *entities.TiendaReturn) gin.H {
+	items := make([]gin.H, 0, len(r.Items))
+	for _, item := range r.Items {
+		items = append(items, gin.H{
+			"product_name":      item.ProductName,
+			"product_sku":       item.ProductSKU,
+			"quantity":          item.Quantity,
+			"reason":            item.Reason,
+			"inspection_result": item.InspectionResult,
+		})
+	}
+	return gin.H{
+		"id":              r.ID,
+		"code":            r.Code,
+		"order_id":        r.OrderID,
+		"order_number":    r.OrderNumber,
+		"status":          r.Status,
+		"reason":          r.Reason,
+		"requested_total": r.RequestedTotal,
+		"refund_method":   r.RefundMethod,
+		"refund_amount":   r.RefundAmount,
+		"created_at":      r.CreatedAt,
+		"items":           items,
+	}
+}
@@ -0,0 +1,27 @@
+package request
+
+import "github.com/secamc93/probability/back/central/services/modules/publicsite/internal/domain/dtos"
+
+type CreateReturnRequest struct {
+	Reason string              `json:"reason"`
+	Note   string              `json:"note"`
+	Items  []ReturnItemRequest `json:"items" binding:"required,min=1"`
+}
+
+type ReturnItemRequest struct {
+	OrderItemID uint   `json:"order_item_id" binding:"required"`
+	Quantity    int    `json:"quantity" binding:"required"`
+	Reason      string `json:"reason"`
+}
+
+func (r *CreateReturnRequest) ToDTO() *dtos.CreateReturnDTO {
+	dto := &dtos.CreateReturnDTO{Reason: r.Reason, Note: r.Note}
+	for _, item := range r.Items {
+		dto.Items = append(dto.Items, dtos.ReturnItemDTO{
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+			Reason:      item.Reason,
+		})
+	}
+	return dto
+}
@@ -18,6 +18,8 @@ func (h *Handlers) RegisterRoutes(router *gin.RouterGroup) {
 		pub.GET("/:slug/session", middleware.JWT(), h.GetSession)
 		pub.GET("/:slug/my-prices", middleware.JWT(), h.GetMyPrices)
 		pub.GET("/:slug/my-orders", middleware.JWT(), h.GetMyOrders)
+		pub.POST("/:slug/my-orders/:id/returns", middleware.JWT(), h.RequestReturn)
+		pub.GET("/:slug/my-returns", middleware.JWT(), h.GetMyReturns)
 		pub.PUT("/:slug/profile", middleware.JWT(), h.UpdateProfile)
 		pub.POST("/:slug/profile/avatar", middleware.JWT(), h.UploadAvatar)
 		pub.GET("/:slug/addresses", middleware.JWT(), h.ListAddresses)
@@ -61,3 +61,20 @@ func (r *Repository) ListCustomerOrders(ctx context.Context, businessID, userID,
 	}
 	return orders, total, nil
 }
+
+func (r *Repository) CustomerOwnsOrder(ctx context.Context, businessID, userID, customerID uint, orderID string) (bool, error) {
+	q := r.db.Conn(ctx).
+		Table("orders o").
+		Where("o.id = ? AND o.business_id = ? AND o.deleted_at IS NULL", orderID, businessID)
+	if customerID > 0 {
+		q = q.Where("(o.user_id = ? OR o.customer_id = ?)", userID, customerID)
+	} else {
+		q = q.Where("o.user_id = ?", userID)
+	}
+
+	var count int64
+	if err := q.Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/secamc93/probability/back/central/services/modules/publicsite/internal/domain/dtos"
 	"github.com/secamc93/probability/back/central/services/modules/publicsite/internal/domain/entities"
 	"github.com/secamc93/probability/back/central/services/modules/publicsite/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/services/modules/returns"
 	"github.com/secamc93/probability/back/central/shared/log"
 )
 
@@ -29,6 +30,7 @@ type RepositoryMock struct {
 	UserBelongsToBusinessFn func(ctx context.Context, businessID uint, userID uint) (bool, error)
 	GetCustomerPricesFn     func(ctx context.Context, businessID uint, clientID uint) (map[string]float64, error)
 	ListCustomerOrdersFn    func(ctx context.Context, businessID, userID, customerID uint, page, pageSize int) ([]entities.TiendaOrder, int64, error)
+	CustomerOwnsOrderFn     func(ctx context.Context, businessID, userID, customerID uint, orderID string) (bool, error)
 
 	UpdateClientProfileFn   func(ctx context.Context, businessID, userID uint, name, phone, dni string) error
 	UpdateUserAvatarFn      func(ctx context.Context, userID uint, avatarURL string) error
@@ -149,6 +151,13 @@ func (m *RepositoryMock) ListCustomerOrders(ctx context.Context, businessID, use
 	return nil, 0, nil
 }
 
+func (m *RepositoryMock) CustomerOwnsOrder(ctx context.Context, businessID, userID, customerID uint, orderID string) (bool, error) {
+	if m.CustomerOwnsOrderFn != nil {
+		return m.CustomerOwnsOrderFn(ctx, businessID, userID, customerID, orderID)
+	}
+	return false, nil
+}
+
 func (m *RepositoryMock) UpdateClientProfile(ctx context.Context, businessID, userID uint, name, phone, dni string) error {
 	if m.UpdateClientProfileFn != nil {
 		return m.UpdateClientProfileFn(ctx, businessID, userID, name, phone, dni)
@@ -238,6 +247,30 @@ func (m *BoldGatewayMock) PublishAgreedStorefrontOrder(ctx context.Context, refe
 	return nil
 }
 
+type ReturnsGatewayMock struct {
+	RequestReturnFn       func(ctx context.Context, req returns.CustomerReturnRequest) (*returns.ReturnSummary, error)
+	ListCustomerReturnsFn func(ctx context.Context, businessID, userID uint, page, pageSize int) ([]returns.ReturnSummary, int64, error)
+
+	Requests []returns.CustomerReturnRequest
+}
+
+var _ ports.IReturnsGateway = (*ReturnsGatewayMock)(nil)
+
+func (m *ReturnsGatewayMock) RequestReturn(ctx context.Context, req returns.CustomerReturnRequest) (*returns.ReturnSummary, error) {
+	m.Requests = append(m.Requests, req)
+	if m.RequestReturnFn != nil {
+		return m.RequestReturnFn(ctx, req)
+	}
+	return &returns.ReturnSummary{Code: "RMA-000001", OrderID: req.OrderID, Status: "requested"}, nil
+}
+
+func (m *ReturnsGatewayMock) ListCustomerReturns(ctx context.Context, businessID, userID uint, page, pageSize int) ([]returns.ReturnSummary, int64, error) {
+	if m.ListCustomerReturnsFn != nil {
+		return m.ListCustomerReturnsFn(ctx, businessID, userID, page, pageSize)
+	}
+	return nil, 0, nil
+}
+
 type SilentLogger struct{}
 
 func NewSilentLogger() log.ILogger { return &SilentLogger{} }
@@ -0,0 +1,75 @@
+package returns
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/modules/inventory"
+	"github.com/secamc93/probability/back/central/services/modules/invoicing"
+	"github.com/secamc93/probability/back/central/services/modules/pay"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/app"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/infra/primary/handlers"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/infra/secondary/queue"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/infra/secondary/repository"
+	"github.com/secamc93/probability/back/central/services/modules/shipments"
+	"github.com/secamc93/probability/back/central/shared/db"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/outbox"
+	"github.com/secamc93/probability/back/central/shared/rabbitmq"
+)
+
+type Bundle struct {
+	uc app.IUseCase
+}
+
+// New arma el modulo de devoluciones (RMA). Inventario, billetera, facturacion
+// y envios entran como gateways de sus bundles; si alguno es nil, la accion que
+// lo usa responde 503 y el resto del flujo sigue disponible.
+func New(
+	router *gin.RouterGroup,
+	database db.IDatabase,
+	logger log.ILogger,
+	rabbitMQ rabbitmq.IQueue,
+	inventoryBundle *inventory.Bundle,
+	payBundle *pay.Bundle,
+	invoicingBundle *invoicing.Bundle,
+	shipmentsBundle *shipments.Bundle,
+) *Bundle {
+	logger = logger.WithModule("returns")
+	ctx := context.Background()
+
+	if rabbitMQ != nil {
+		if err := rabbitMQ.DeclareExchange(rabbitmq.ExchangeReturnEvents, "topic", true); err != nil {
+			logger.Error(ctx).Err(err).Msg("Error al declarar exchange de devoluciones")
+		}
+	}
+
+	// Los bundles llegan como punteros; un nil tipado dentro de la interfaz no
+	// es nil, por eso se convierten solo si existen.
+	var inventoryGW ports.IInventoryGateway
+	if inventoryBundle != nil {
+		inventoryGW = inventoryBundle
+	}
+	var walletGW ports.IWalletGateway
+	if payBundle != nil {
+		walletGW = payBundle
+	}
+	var creditNoteGW ports.ICreditNoteGateway
+	if invoicingBundle != nil {
+		creditNoteGW = invoicingBundle
+	}
+	var labelGW ports.ILabelGateway
+	if shipmentsBundle != nil {
+		labelGW = shipmentsBundle
+	}
+
+	repo := repository.New(database)
+	events := queue.NewEventPublisher(outbox.NewWriter(database), logger)
+	uc := app.New(repo, events, inventoryGW, walletGW, creditNoteGW, labelGW, logger)
+
+	h := handlers.New(uc, logger)
+	h.RegisterRoutes(router)
+
+	return &Bundle{uc: uc}
+}
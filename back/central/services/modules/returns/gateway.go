@@ -0,0 +1,142 @@
+package returns
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/errors"
+)
+
+// CustomerReturnRequest es una devolucion pedida por el cliente desde la tienda
+// publica. Quien llama ya verifico que la orden es del cliente.
+type CustomerReturnRequest struct {
+	BusinessID uint
+	UserID     uint
+	OrderID    string
+	Reason     string
+	Note       string
+	Items      []CustomerReturnItem
+}
+
+type CustomerReturnItem struct {
+	OrderItemID uint
+	Quantity    int
+	Reason      string
+}
+
+// ReturnSummary es la vista de una devolucion para el cliente
+type ReturnSummary struct {
+	ID             uint
+	Code           string
+	OrderID        string
+	OrderNumber    string
+	Status         string
+	Reason         string
+	RequestedTotal float64
+	RefundMethod   string
+	RefundAmount   float64
+	CreatedAt      time.Time
+	Items          []ReturnSummaryItem
+}
+
+type ReturnSummaryItem struct {
+	ProductName      string
+	ProductSKU       string
+	Quantity         int
+	Reason           string
+	InspectionResult string
+}
+
+// IsRequestError indica que la devolucion se rechazo por datos invalidos
+// (items, cantidades o motivo), no por una falla interna
+func IsRequestError(err error) bool {
+	for _, target := range []error{
+		dom.ErrOrderItemNotFound, dom.ErrItemsRequired, dom.ErrInvalidQuantity,
+		dom.ErrQuantityExceeded, dom.ErrInvalidReason,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsOrderNotFound indica que la orden no existe en el negocio
+func IsOrderNotFound(err error) bool {
+	return errors.Is(err, dom.ErrOrderNotFound)
+}
+
+// RequestReturn crea la solicitud con origen customer
+func (b *Bundle) RequestReturn(ctx context.Context, req CustomerReturnRequest) (*ReturnSummary, error) {
+	dto := dtos.CreateReturnDTO{
+		BusinessID:   req.BusinessID,
+		OrderID:      req.OrderID,
+		Source:       entities.ReturnSourceCustomer,
+		Reason:       req.Reason,
+		CustomerNote: req.Note,
+	}
+	if req.UserID > 0 {
+		dto.RequestedByID = &req.UserID
+	}
+	for _, item := range req.Items {
+		dto.Items = append(dto.Items, dtos.CreateReturnItemDTO{
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+			Reason:      item.Reason,
+		})
+	}
+
+	ret, err := b.uc.Create(ctx, dto)
+	if err != nil {
+		return nil, err
+	}
+	summary := toSummary(ret)
+	return &summary, nil
+}
+
+// ListCustomerReturns lista las devoluciones que pidio el usuario en el negocio
+func (b *Bundle) ListCustomerReturns(ctx context.Context, businessID, userID uint, page, pageSize int) ([]ReturnSummary, int64, error) {
+	items, total, err := b.uc.List(ctx, dtos.ListReturnsParams{
+		Page:          page,
+		PageSize:      pageSize,
+		BusinessID:    businessID,
+		Source:        entities.ReturnSourceCustomer,
+		RequestedByID: &userID,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	out := make([]ReturnSummary, 0, len(items))
+	for i := range items {
+		out = append(out, toSummary(&items[i]))
+	}
+	return out, total, nil
+}
+
+func toSummary(r *entities.ReturnRequest) ReturnSummary {
+	out := ReturnSummary{
+		ID:             r.ID,
+		Code:           r.Code,
+		OrderID:        r.OrderID,
+		OrderNumber:    r.OrderNumber,
+		Status:         string(r.Status),
+		Reason:         r.Reason,
+		RequestedTotal: r.RequestedTotal(),
+		RefundMethod:   r.RefundMethod,
+		RefundAmount:   r.RefundAmount,
+		CreatedAt:      r.CreatedAt,
+	}
+	for _, item := range r.Items {
+		out.Items = append(out.Items, ReturnSummaryItem{
+			ProductName:      item.ProductName,
+			ProductSKU:       item.ProductSKU,
+			Quantity:         item.Quantity,
+			Reason:           item.Reason,
+			InspectionResult: item.InspectionResult,
+		})
+	}
+	return out
+}
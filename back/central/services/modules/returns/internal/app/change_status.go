@@ -0,0 +1,52 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/errors"
+)
+
+// Approve autoriza la devolucion. WarehouseID, si llega, es la bodega que va a
+// recibir las unidades en vez de la de la orden.
+func (uc *UseCase) Approve(ctx context.Context, dto dtos.TransitionDTO) (*entities.ReturnRequest, error) {
+	change := statusChange{to: entities.ReturnStatusApproved, changedBy: dto.ChangedByID, note: dto.Note}
+	if dto.WarehouseID != nil && *dto.WarehouseID > 0 {
+		change.updates = map[string]any{"warehouse_id": *dto.WarehouseID}
+	}
+	return uc.changeStatus(ctx, dto.BusinessID, dto.ReturnID, change, nil)
+}
+
+// Reject rechaza la solicitud; el motivo es obligatorio porque se le muestra al cliente
+func (uc *UseCase) Reject(ctx context.Context, dto dtos.TransitionDTO) (*entities.ReturnRequest, error) {
+	if strings.TrimSpace(dto.Note) == "" {
+		return nil, dom.ErrNoteRequired
+	}
+	change := statusChange{to: entities.ReturnStatusRejected, changedBy: dto.ChangedByID, note: dto.Note}
+	return uc.changeStatus(ctx, dto.BusinessID, dto.ReturnID, change, nil)
+}
+
+func (uc *UseCase) Cancel(ctx context.Context, dto dtos.TransitionDTO) (*entities.ReturnRequest, error) {
+	change := statusChange{to: entities.ReturnStatusCancelled, changedBy: dto.ChangedByID, note: dto.Note}
+	return uc.changeStatus(ctx, dto.BusinessID, dto.ReturnID, change, nil)
+}
+
+func (uc *UseCase) MarkInTransit(ctx context.Context, dto dtos.TransitionDTO) (*entities.ReturnRequest, error) {
+	change := statusChange{to: entities.ReturnStatusInTransit, changedBy: dto.ChangedByID, note: dto.Note}
+	return uc.changeStatus(ctx, dto.BusinessID, dto.ReturnID, change, nil)
+}
+
+// Receive registra que el paquete llego a bodega. El stock no se mueve aca
+// sino en la inspeccion, que decide en que estado queda cada unidad.
+func (uc *UseCase) Receive(ctx context.Context, dto dtos.TransitionDTO) (*entities.ReturnRequest, error) {
+	change := statusChange{to: entities.ReturnStatusReceived, changedBy: dto.ChangedByID, note: dto.Note}
+	return uc.changeStatus(ctx, dto.BusinessID, dto.ReturnID, change, nil)
+}
+
+// Close cierra la devolucion. Desde inspected cierra sin reembolso.
+func (uc *UseCase) Close(ctx context.Context, dto dtos.TransitionDTO) (*entities.ReturnRequest, error) {
+	change := statusChange{to: entities.ReturnStatusClosed, changedBy: dto.ChangedByID, note: dto.Note}
+	return uc.changeStatus(ctx, dto.BusinessID, dto.ReturnID, change, nil)
+}
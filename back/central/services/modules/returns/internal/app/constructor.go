@@ -0,0 +1,61 @@
+package app
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+type IUseCase interface {
+	Create(ctx context.Context, dto dtos.CreateReturnDTO) (*entities.ReturnRequest, error)
+	Get(ctx context.Context, businessID, id uint) (*entities.ReturnRequest, error)
+	List(ctx context.Context, params dtos.ListReturnsParams) ([]entities.ReturnRequest, int64, error)
+	ListHistory(ctx context.Context, businessID, id uint) ([]entities.ReturnStatusHistory, error)
+
+	Approve(ctx context.Context, dto dtos.TransitionDTO) (*entities.ReturnRequest, error)
+	Reject(ctx context.Context, dto dtos.TransitionDTO) (*entities.ReturnRequest, error)
+	Cancel(ctx context.Context, dto dtos.TransitionDTO) (*entities.ReturnRequest, error)
+	MarkInTransit(ctx context.Context, dto dtos.TransitionDTO) (*entities.ReturnRequest, error)
+	Receive(ctx context.Context, dto dtos.TransitionDTO) (*entities.ReturnRequest, error)
+	Close(ctx context.Context, dto dtos.TransitionDTO) (*entities.ReturnRequest, error)
+
+	RequestLabel(ctx context.Context, dto dtos.RequestLabelDTO) (*entities.ReturnRequest, error)
+	GetLabel(ctx context.Context, businessID, id uint) (*entities.ReturnLabel, error)
+
+	Inspect(ctx context.Context, dto dtos.InspectReturnDTO) (*entities.ReturnRequest, error)
+	Refund(ctx context.Context, dto dtos.RefundReturnDTO) (*entities.ReturnRequest, error)
+	ConfirmRefund(ctx context.Context, dto dtos.ConfirmRefundDTO) (*entities.ReturnRequest, error)
+}
+
+type UseCase struct {
+	repo        ports.IRepository
+	events      ports.IEventPublisher
+	inventory   ports.IInventoryGateway
+	wallet      ports.IWalletGateway
+	creditNotes ports.ICreditNoteGateway
+	labels      ports.ILabelGateway
+	log         log.ILogger
+}
+
+func New(
+	repo ports.IRepository,
+	events ports.IEventPublisher,
+	inventory ports.IInventoryGateway,
+	wallet ports.IWalletGateway,
+	creditNotes ports.ICreditNoteGateway,
+	labels ports.ILabelGateway,
+	logger log.ILogger,
+) IUseCase {
+	return &UseCase{
+		repo:        repo,
+		events:      events,
+		inventory:   inventory,
+		wallet:      wallet,
+		creditNotes: creditNotes,
+		labels:      labels,
+		log:         logger,
+	}
+}
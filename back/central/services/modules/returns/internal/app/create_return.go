@@ -0,0 +1,144 @@
+package app
+
+import (
+	"context"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/errors"
+)
+
+// Create registra una solicitud de devolucion sobre items de la orden. Cada
+// linea no puede superar lo que queda por devolver de su item, contando las
+// devoluciones abiertas o cerradas de la misma orden.
+func (uc *UseCase) Create(ctx context.Context, dto dtos.CreateReturnDTO) (*entities.ReturnRequest, error) {
+	if len(dto.Items) == 0 {
+		return nil, dom.ErrItemsRequired
+	}
+
+	source := dto.Source
+	if source == "" {
+		source = entities.ReturnSourceOperator
+	}
+	if source != entities.ReturnSourceOperator && source != entities.ReturnSourceCustomer {
+		return nil, dom.ErrForbidden
+	}
+
+	order, err := uc.repo.GetOrder(ctx, dto.BusinessID, dto.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	orderItems := make(map[uint]entities.ReturnOrderItem, len(order.Items))
+	for _, item := range order.Items {
+		orderItems[item.ID] = item
+	}
+
+	requested := make(map[uint]int, len(dto.Items))
+	items := make([]entities.ReturnItem, 0, len(dto.Items))
+	for _, in := range dto.Items {
+		if in.Quantity <= 0 {
+			return nil, dom.ErrInvalidQuantity
+		}
+		reason := strings.TrimSpace(in.Reason)
+		if reason == "" {
+			reason = strings.TrimSpace(dto.Reason)
+		}
+		if !entities.IsValidReturnReason(reason) {
+			return nil, dom.ErrInvalidReason
+		}
+		orderItem, ok := orderItems[in.OrderItemID]
+		if !ok {
+			return nil, dom.ErrOrderItemNotFound
+		}
+
+		requested[in.OrderItemID] += in.Quantity
+		items = append(items, entities.ReturnItem{
+			OrderItemID: orderItem.ID,
+			ProductID:   orderItem.ProductID,
+			ProductSKU:  orderItem.ProductSKU,
+			ProductName: orderItem.ProductName,
+			Quantity:    in.Quantity,
+			UnitPrice:   orderItem.UnitPrice,
+			Reason:      reason,
+			Note:        in.Note,
+		})
+	}
+
+	var created *entities.ReturnRequest
+	err = uc.repo.InTransaction(ctx, func(ctx context.Context) error {
+		// Con la orden bloqueada, otra devolucion de la misma orden espera a
+		// que esta confirme antes de sumar lo ya devuelto
+		if err := uc.repo.LockOrder(ctx, order.ID); err != nil {
+			return err
+		}
+		returned, err := uc.repo.ReturnedQuantities(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		for itemID, qty := range requested {
+			if returned[itemID]+qty > orderItems[itemID].Quantity {
+				return dom.ErrQuantityExceeded
+			}
+		}
+
+		code, err := uc.repo.NextCode(ctx)
+		if err != nil {
+			return err
+		}
+
+		created, err = uc.repo.Create(ctx, &entities.ReturnRequest{
+			Code:          code,
+			BusinessID:    dto.BusinessID,
+			OrderID:       order.ID,
+			Status:        entities.ReturnStatusRequested,
+			Source:        source,
+			Reason:        dto.Reason,
+			CustomerNote:  dto.CustomerNote,
+			RequestedByID: dto.RequestedByID,
+			WarehouseID:   order.WarehouseID,
+			OrderNumber:   order.OrderNumber,
+			Items:         items,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := uc.repo.AddHistory(ctx, &entities.ReturnStatusHistory{
+			ReturnRequestID: created.ID,
+			ToStatus:        entities.ReturnStatusRequested,
+			ChangedByID:     dto.RequestedByID,
+			Note:            dto.CustomerNote,
+		}); err != nil {
+			return err
+		}
+
+		return uc.events.PublishReturnEvent(ctx, entities.ReturnEvent{
+			EventType:   entities.ReturnEventRequested,
+			ReturnID:    created.ID,
+			Code:        created.Code,
+			BusinessID:  created.BusinessID,
+			OrderID:     created.OrderID,
+			Status:      string(entities.ReturnStatusRequested),
+			ChangedByID: dto.RequestedByID,
+			Timestamp:   created.CreatedAt,
+			Data: map[string]any{
+				"source":          source,
+				"order_number":    order.OrderNumber,
+				"items":           len(items),
+				"requested_total": created.RequestedTotal(),
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	uc.log.Info(ctx).
+		Str("code", created.Code).
+		Str("order_id", created.OrderID).
+		Str("source", source).
+		Msg("Devolucion solicitada")
+
+	return created, nil
+}
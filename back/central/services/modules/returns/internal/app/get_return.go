@@ -0,0 +1,55 @@
+package app
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/errors"
+)
+
+func (uc *UseCase) Get(ctx context.Context, businessID, id uint) (*entities.ReturnRequest, error) {
+	return uc.repo.GetByID(ctx, businessID, id)
+}
+
+func (uc *UseCase) List(ctx context.Context, params dtos.ListReturnsParams) ([]entities.ReturnRequest, int64, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+	return uc.repo.List(ctx, params)
+}
+
+func (uc *UseCase) ListHistory(ctx context.Context, businessID, id uint) ([]entities.ReturnStatusHistory, error) {
+	if _, err := uc.repo.GetByID(ctx, businessID, id); err != nil {
+		return nil, err
+	}
+	return uc.repo.ListHistory(ctx, id)
+}
+
+// GetLabel retorna la guia de devolucion con el estado actual del envio
+func (uc *UseCase) GetLabel(ctx context.Context, businessID, id uint) (*entities.ReturnLabel, error) {
+	ret, err := uc.repo.GetByID(ctx, businessID, id)
+	if err != nil {
+		return nil, err
+	}
+	if ret.ReturnShipmentID == nil {
+		return nil, dom.ErrLabelNotAvailable
+	}
+	if uc.labels == nil {
+		return nil, dom.ErrGatewayUnavailable
+	}
+
+	label, err := uc.labels.GetReturnLabel(ctx, *ret.ReturnShipmentID)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.ReturnLabel{
+		ShipmentID:     label.ShipmentID,
+		TrackingNumber: label.TrackingNumber,
+		GuideURL:       label.GuideURL,
+		Status:         label.Status,
+	}, nil
+}
@@ -0,0 +1,105 @@
+package app
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/errors"
+)
+
+// inventoryStates es el estado de inventario en que queda cada resultado de inspeccion
+var inventoryStates = map[string]string{
+	entities.InspectionRestock:    inventory.ReturnStateAvailable,
+	entities.InspectionDamaged:    inventory.ReturnStateDamaged,
+	entities.InspectionQuarantine: inventory.ReturnStateQuarantine,
+}
+
+// Inspect registra el resultado de cada linea y reingresa las unidades a
+// inventario en el estado correspondiente. El reembolso sugerido es el valor de
+// las unidades que llegaron; el operador lo ajusta al reembolsar.
+func (uc *UseCase) Inspect(ctx context.Context, dto dtos.InspectReturnDTO) (*entities.ReturnRequest, error) {
+	if uc.inventory == nil {
+		return nil, dom.ErrGatewayUnavailable
+	}
+
+	ret, err := uc.repo.GetByID(ctx, dto.BusinessID, dto.ReturnID)
+	if err != nil {
+		return nil, err
+	}
+	if !ret.Status.CanTransitionTo(entities.ReturnStatusInspected) {
+		return nil, dom.ErrInvalidTransition
+	}
+
+	results := make(map[uint]dtos.InspectItemDTO, len(dto.Items))
+	for _, in := range dto.Items {
+		if !entities.IsValidInspectionResult(in.Result) {
+			return nil, dom.ErrInvalidInspection
+		}
+		results[in.ItemID] = in
+	}
+
+	warehouseID := ret.WarehouseID
+	if dto.WarehouseID != nil && *dto.WarehouseID > 0 {
+		warehouseID = dto.WarehouseID
+	}
+	receipt := inventory.ReturnReceipt{
+		BusinessID:  ret.BusinessID,
+		WarehouseID: warehouseID,
+		OrderID:     ret.OrderID,
+		ReturnCode:  ret.Code,
+		UserID:      dto.ChangedByID,
+	}
+
+	refund := 0.0
+	for _, item := range ret.Items {
+		in, ok := results[item.ID]
+		if !ok {
+			return nil, dom.ErrInspectionIncomplete
+		}
+		if in.Quantity < 0 || in.Quantity > item.Quantity {
+			return nil, dom.ErrInvalidQuantity
+		}
+		refund += float64(in.Quantity) * item.UnitPrice
+		// Los items sin producto de catalogo no tienen stock que mover
+		if in.Quantity > 0 && item.ProductID != nil && *item.ProductID != "" {
+			receipt.Items = append(receipt.Items, inventory.ReturnedItem{
+				ProductID: *item.ProductID,
+				SKU:       item.ProductSKU,
+				Quantity:  in.Quantity,
+				State:     inventoryStates[in.Result],
+			})
+		}
+	}
+	if len(results) != len(ret.Items) {
+		return nil, dom.ErrInvalidInspection
+	}
+
+	change := statusChange{
+		to:        entities.ReturnStatusInspected,
+		changedBy: dto.ChangedByID,
+		note:      dto.Note,
+		updates:   map[string]any{"refund_amount": refund},
+		data: map[string]any{
+			"restocked_items":  len(receipt.Items),
+			"suggested_refund": refund,
+		},
+	}
+	if warehouseID != nil {
+		change.updates["warehouse_id"] = *warehouseID
+	}
+
+	return uc.changeStatus(ctx, dto.BusinessID, dto.ReturnID, change, func(ctx context.Context, ret *entities.ReturnRequest) error {
+		for _, item := range ret.Items {
+			in := results[item.ID]
+			if err := uc.repo.UpdateItemInspection(ctx, item.ID, in.Result, in.Quantity, in.Note); err != nil {
+				return err
+			}
+		}
+		if len(receipt.Items) == 0 {
+			return nil
+		}
+		return uc.inventory.ReceiveReturn(ctx, receipt)
+	})
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/modules/invoicing"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/errors"
+)
+
+// refundConcept es el concepto del debito en la billetera
+const refundConcept = "REFUND"
+
+// Refund ejecuta el reembolso de una devolucion inspeccionada:
+//   - wallet: debita la billetera del negocio en la misma transaccion y queda refunded
+//   - credit_note: la reclama (refund_pending), crea la nota credito sobre la
+//     factura de la orden y queda refunded
+//   - payment_gateway: queda refund_pending hasta que se confirme la referencia
+//     del reembolso en la pasarela (ConfirmRefund)
+func (uc *UseCase) Refund(ctx context.Context, dto dtos.RefundReturnDTO) (*entities.ReturnRequest, error) {
+	if !entities.IsValidRefundMethod(dto.Method) {
+		return nil, dom.ErrInvalidRefundMethod
+	}
+
+	ret, err := uc.repo.GetByID(ctx, dto.BusinessID, dto.ReturnID)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Status != entities.ReturnStatusInspected {
+		return nil, dom.ErrInvalidTransition
+	}
+
+	amount := dto.Amount
+	if amount == 0 {
+		amount = ret.RefundAmount
+	}
+	if amount <= 0 || amount > ret.RequestedTotal() {
+		return nil, dom.ErrInvalidRefundAmount
+	}
+
+	var userID uint
+	if dto.ChangedByID != nil {
+		userID = *dto.ChangedByID
+	}
+	updates := map[string]any{
+		"refund_method": dto.Method,
+		"refund_amount": amount,
+	}
+	data := map[string]any{
+		"method": dto.Method,
+		"amount": amount,
+	}
+
+	switch dto.Method {
+	case entities.RefundMethodWallet:
+		if uc.wallet == nil {
+			return nil, dom.ErrGatewayUnavailable
+		}
+		updates["refund_reference"] = ret.Code
+		change := statusChange{to: entities.ReturnStatusRefunded, changedBy: dto.ChangedByID, note: dto.Note, updates: updates, data: data}
+		return uc.changeStatus(ctx, dto.BusinessID, dto.ReturnID, change, func(ctx context.Context, ret *entities.ReturnRequest) error {
+			reference := fmt.Sprintf("%s orden %s", ret.Code, ret.OrderNumber)
+			return uc.wallet.Debit(ctx, ret.BusinessID, amount, reference, refundConcept, userID)
+		})
+
+	case entities.RefundMethodCreditNote:
+		if uc.creditNotes == nil {
+			return nil, dom.ErrGatewayUnavailable
+		}
+		// Primero se reclama la devolucion: con la fila bloqueada pasa a
+		// refund_pending, asi un request simultaneo falla antes de emitir otra
+		// nota sobre la misma factura. La nota se emite con el proveedor por
+		// fuera de la transaccion; si falla, la devolucion queda refund_pending
+		// y se cierra con ConfirmRefund cuando la nota se emita a mano.
+		claim := statusChange{to: entities.ReturnStatusRefundPending, changedBy: dto.ChangedByID, note: dto.Note, updates: updates, data: data}
+		if _, err := uc.changeStatus(ctx, dto.BusinessID, dto.ReturnID, claim, nil); err != nil {
+			return nil, err
+		}
+		note, err := uc.creditNotes.CreateCreditNoteForOrder(ctx, invoicing.OrderCreditNoteRequest{
+			BusinessID: ret.BusinessID,
+			OrderID:    ret.OrderID,
+			Amount:     amount,
+			Reason:     fmt.Sprintf("Devolucion %s", ret.Code),
+			UserID:     userID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		change := statusChange{
+			to:        entities.ReturnStatusRefunded,
+			changedBy: dto.ChangedByID,
+			updates:   map[string]any{"credit_note_id": note.ID, "refund_reference": note.InternalNumber},
+			data:      map[string]any{"method": dto.Method, "amount": amount, "credit_note_id": note.ID},
+		}
+		return uc.changeStatus(ctx, dto.BusinessID, dto.ReturnID, change, nil)
+
+	default:
+		change := statusChange{to: entities.ReturnStatusRefundPending, changedBy: dto.ChangedByID, note: dto.Note, updates: updates, data: data}
+		return uc.changeStatus(ctx, dto.BusinessID, dto.ReturnID, change, nil)
+	}
+}
+
+// ConfirmRefund registra la referencia del reembolso hecho en la pasarela de pago
+func (uc *UseCase) ConfirmRefund(ctx context.Context, dto dtos.ConfirmRefundDTO) (*entities.ReturnRequest, error) {
+	reference := strings.TrimSpace(dto.Reference)
+	if reference == "" {
+		return nil, dom.ErrRefundReferenceMissing
+	}
+	change := statusChange{
+		to:        entities.ReturnStatusRefunded,
+		changedBy: dto.ChangedByID,
+		note:      dto.Note,
+		updates:   map[string]any{"refund_reference": reference},
+		data:      map[string]any{"reference": reference},
+	}
+	return uc.changeStatus(ctx, dto.BusinessID, dto.ReturnID, change, nil)
+}
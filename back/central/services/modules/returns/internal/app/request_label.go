@@ -0,0 +1,59 @@
+package app
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/shipments"
+)
+
+// RequestLabel pide la guia de devolucion a la transportadora. La guia se
+// genera de forma asincrona; la devolucion queda en label_requested con el
+// envio asociado para consultarla despues.
+func (uc *UseCase) RequestLabel(ctx context.Context, dto dtos.RequestLabelDTO) (*entities.ReturnRequest, error) {
+	if uc.labels == nil {
+		return nil, dom.ErrGatewayUnavailable
+	}
+
+	ret, err := uc.repo.GetByID(ctx, dto.BusinessID, dto.ReturnID)
+	if err != nil {
+		return nil, err
+	}
+	if !ret.Status.CanTransitionTo(entities.ReturnStatusLabelRequested) {
+		return nil, dom.ErrInvalidTransition
+	}
+
+	label, err := uc.labels.GenerateReturnLabel(ctx, shipments.ReturnLabelRequest{
+		BusinessID: ret.BusinessID,
+		ReturnID:   ret.ID,
+		ReturnCode: ret.Code,
+		OrderID:    ret.OrderID,
+		Payload:    dto.Payload,
+		UserID:     dto.ChangedByID,
+		UserName:   dto.ChangedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	change := statusChange{
+		to:        entities.ReturnStatusLabelRequested,
+		changedBy: dto.ChangedByID,
+		updates:   map[string]any{"return_shipment_id": label.ShipmentID},
+		data: map[string]any{
+			"shipment_id":    label.ShipmentID,
+			"correlation_id": label.CorrelationID,
+		},
+	}
+	updated, err := uc.changeStatus(ctx, dto.BusinessID, dto.ReturnID, change, nil)
+	if err != nil {
+		uc.log.Error(ctx).Err(err).
+			Str("code", ret.Code).
+			Uint("shipment_id", label.ShipmentID).
+			Msg("Guia de devolucion solicitada pero no se pudo asociar a la devolucion")
+		return nil, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,543 @@
+package app
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory"
+	"github.com/secamc93/probability/back/central/services/modules/invoicing"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type returnsDeps struct {
+	repo        *mocks.RepositoryMock
+	events      *mocks.EventPublisherMock
+	inventory   *mocks.InventoryGatewayMock
+	wallet      *mocks.WalletGatewayMock
+	creditNotes *mocks.CreditNoteGatewayMock
+	labels      *mocks.LabelGatewayMock
+}
+
+func newReturnsUseCase() (IUseCase, *returnsDeps) {
+	d := &returnsDeps{
+		repo:        &mocks.RepositoryMock{},
+		events:      &mocks.EventPublisherMock{},
+		inventory:   &mocks.InventoryGatewayMock{},
+		wallet:      &mocks.WalletGatewayMock{},
+		creditNotes: &mocks.CreditNoteGatewayMock{},
+		labels:      &mocks.LabelGatewayMock{},
+	}
+	uc := New(d.repo, d.events, d.inventory, d.wallet, d.creditNotes, d.labels, mocks.NewSilentLogger())
+	return uc, d
+}
+
+func uintPtr(v uint) *uint    { return &v }
+func strPtr(v string) *string { return &v }
+
+func ordenConDosItems() *entities.ReturnOrder {
+	return &entities.ReturnOrder{
+		ID: "ord-1", BusinessID: 5, OrderNumber: "1001", WarehouseID: uintPtr(3),
+		Items: []entities.ReturnOrderItem{
+			{ID: 10, ProductID: strPtr("p-1"), ProductSKU: "CAM-M", ProductName: "Camisa", Quantity: 2, UnitPrice: 50000},
+			{ID: 11, ProductID: strPtr("p-2"), ProductSKU: "PAN-32", ProductName: "Pantalon", Quantity: 1, UnitPrice: 80000},
+		},
+	}
+}
+
+func devolucionEn(status entities.ReturnStatus) *entities.ReturnRequest {
+	return &entities.ReturnRequest{
+		ID: 1, Code: "RMA-000001", BusinessID: 5, OrderID: "ord-1", OrderNumber: "1001",
+		Status: status, WarehouseID: uintPtr(3),
+		Items: []entities.ReturnItem{
+			{ID: 1, OrderItemID: 10, ProductID: strPtr("p-1"), ProductSKU: "CAM-M", Quantity: 2, UnitPrice: 50000, Reason: entities.ReturnReasonSizeOrFit},
+			{ID: 2, OrderItemID: 11, ProductID: strPtr("p-2"), ProductSKU: "PAN-32", Quantity: 1, UnitPrice: 80000, Reason: entities.ReturnReasonDefective},
+		},
+	}
+}
+
+func TestReturnStatus_Transiciones(t *testing.T) {
+	casos := []struct {
+		nombre string
+		from   entities.ReturnStatus
+		to     entities.ReturnStatus
+		valida bool
+	}{
+		{"aprobar solicitud", entities.ReturnStatusRequested, entities.ReturnStatusApproved, true},
+		{"recibir sin guia", entities.ReturnStatusApproved, entities.ReturnStatusReceived, true},
+		{"inspeccionar sin recibir", entities.ReturnStatusApproved, entities.ReturnStatusInspected, false},
+		{"reembolsar sin inspeccion", entities.ReturnStatusReceived, entities.ReturnStatusRefunded, false},
+		{"cancelar en transito", entities.ReturnStatusInTransit, entities.ReturnStatusCancelled, false},
+		{"cerrar sin reembolso", entities.ReturnStatusInspected, entities.ReturnStatusClosed, true},
+		{"reabrir cerrada", entities.ReturnStatusClosed, entities.ReturnStatusRequested, false},
+		{"confirmar reembolso pendiente", entities.ReturnStatusRefundPending, entities.ReturnStatusRefunded, true},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			assert.Equal(t, tc.valida, tc.from.CanTransitionTo(tc.to))
+		})
+	}
+
+	for _, s := range []entities.ReturnStatus{entities.ReturnStatusRejected, entities.ReturnStatusCancelled, entities.ReturnStatusClosed} {
+		assert.True(t, s.IsTerminal(), s)
+		assert.True(t, s.IsValid(), s)
+	}
+	assert.False(t, entities.ReturnStatus("lost").IsValid())
+}
+
+func TestCreate_ValidaItems(t *testing.T) {
+	base := func(items ...dtos.CreateReturnItemDTO) dtos.CreateReturnDTO {
+		return dtos.CreateReturnDTO{BusinessID: 5, OrderID: "ord-1", Reason: entities.ReturnReasonDefective, Items: items}
+	}
+	casos := []struct {
+		nombre   string
+		dto      dtos.CreateReturnDTO
+		devuelto map[uint]int
+		wantErr  error
+	}{
+		{"sin items", base(), nil, dom.ErrItemsRequired},
+		{"cantidad cero", base(dtos.CreateReturnItemDTO{OrderItemID: 10}), nil, dom.ErrInvalidQuantity},
+		{"motivo invalido", base(dtos.CreateReturnItemDTO{OrderItemID: 10, Quantity: 1, Reason: "capricho"}), nil, dom.ErrInvalidReason},
+		{"item de otra orden", base(dtos.CreateReturnItemDTO{OrderItemID: 99, Quantity: 1}), nil, dom.ErrOrderItemNotFound},
+		{"supera lo comprado", base(dtos.CreateReturnItemDTO{OrderItemID: 10, Quantity: 3}), nil, dom.ErrQuantityExceeded},
+		{"lineas repetidas suman", base(dtos.CreateReturnItemDTO{OrderItemID: 10, Quantity: 1}, dtos.CreateReturnItemDTO{OrderItemID: 10, Quantity: 2}), nil, dom.ErrQuantityExceeded},
+		{"ya devuelto en otra RMA", base(dtos.CreateReturnItemDTO{OrderItemID: 11, Quantity: 1}), map[uint]int{11: 1}, dom.ErrQuantityExceeded},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			uc, d := newReturnsUseCase()
+			d.repo.Order = ordenConDosItems()
+			d.repo.ReturnedQuantitiesFn = func(ctx context.Context, orderID string) (map[uint]int, error) {
+				return tc.devuelto, nil
+			}
+
+			got, err := uc.Create(context.Background(), tc.dto)
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Nil(t, got)
+			assert.Nil(t, d.repo.Created)
+			assert.Empty(t, d.events.Events)
+		})
+	}
+}
+
+func TestCreate_OrdenDeOtroNegocio_NoEncontrada(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Order = ordenConDosItems()
+
+	_, err := uc.Create(context.Background(), dtos.CreateReturnDTO{
+		BusinessID: 6, OrderID: "ord-1",
+		Items: []dtos.CreateReturnItemDTO{{OrderItemID: 10, Quantity: 1, Reason: entities.ReturnReasonOther}},
+	})
+
+	assert.ErrorIs(t, err, dom.ErrOrderNotFound)
+}
+
+func TestCreate_Exitoso_CopiaDatosDelItemYPublicaEvento(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Order = ordenConDosItems()
+	d.repo.ReturnedQuantitiesFn = func(ctx context.Context, orderID string) (map[uint]int, error) {
+		return map[uint]int{10: 1}, nil
+	}
+
+	got, err := uc.Create(context.Background(), dtos.CreateReturnDTO{
+		BusinessID: 5, OrderID: "ord-1", Source: entities.ReturnSourceCustomer,
+		RequestedByID: uintPtr(42), Reason: entities.ReturnReasonSizeOrFit,
+		Items: []dtos.CreateReturnItemDTO{{OrderItemID: 10, Quantity: 1}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "RMA-000001", got.Code)
+	assert.Equal(t, entities.ReturnStatusRequested, got.Status)
+	assert.Equal(t, entities.ReturnSourceCustomer, got.Source)
+	assert.Equal(t, uintPtr(3), got.WarehouseID)
+	require.Len(t, got.Items, 1)
+	assert.Equal(t, "CAM-M", got.Items[0].ProductSKU)
+	assert.Equal(t, 50000.0, got.Items[0].UnitPrice)
+	assert.Equal(t, entities.ReturnReasonSizeOrFit, got.Items[0].Reason)
+
+	require.Len(t, d.repo.History, 1)
+	assert.Equal(t, entities.ReturnStatusRequested, d.repo.History[0].ToStatus)
+	require.Len(t, d.events.Events, 1)
+	assert.Equal(t, entities.ReturnEventRequested, d.events.Events[0].EventType)
+}
+
+func TestTransiciones_RegistranHistorialYEvento(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Return = devolucionEn(entities.ReturnStatusRequested)
+	ctx := context.Background()
+	dto := dtos.TransitionDTO{ReturnID: 1, BusinessID: 5, ChangedByID: uintPtr(9)}
+
+	_, err := uc.Approve(ctx, dtos.TransitionDTO{ReturnID: 1, BusinessID: 5, WarehouseID: uintPtr(4)})
+	require.NoError(t, err)
+	_, err = uc.MarkInTransit(ctx, dto)
+	require.NoError(t, err)
+	got, err := uc.Receive(ctx, dto)
+	require.NoError(t, err)
+
+	assert.Equal(t, entities.ReturnStatusReceived, got.Status)
+	assert.Equal(t, uint(4), d.repo.Updates[0]["warehouse_id"])
+	assert.Contains(t, d.repo.Updates[0], "approved_at")
+	assert.Contains(t, d.repo.Updates[2], "received_at")
+
+	require.Len(t, d.repo.History, 3)
+	assert.Equal(t, entities.ReturnStatusInTransit, d.repo.History[2].FromStatus)
+	require.Len(t, d.events.Events, 3)
+	assert.Equal(t, []string{entities.ReturnEventApproved, entities.ReturnEventInTransit, entities.ReturnEventReceived},
+		[]string{d.events.Events[0].EventType, d.events.Events[1].EventType, d.events.Events[2].EventType})
+	assert.Equal(t, string(entities.ReturnStatusApproved), d.events.Events[1].PreviousStatus)
+}
+
+func TestTransiciones_Invalidas(t *testing.T) {
+	casos := []struct {
+		nombre string
+		status entities.ReturnStatus
+		accion func(uc IUseCase, dto dtos.TransitionDTO) error
+	}{
+		{"aprobar rechazada", entities.ReturnStatusRejected, func(uc IUseCase, dto dtos.TransitionDTO) error {
+			_, err := uc.Approve(context.Background(), dto)
+			return err
+		}},
+		{"cancelar recibida", entities.ReturnStatusReceived, func(uc IUseCase, dto dtos.TransitionDTO) error {
+			_, err := uc.Cancel(context.Background(), dto)
+			return err
+		}},
+		{"cerrar sin inspeccion", entities.ReturnStatusReceived, func(uc IUseCase, dto dtos.TransitionDTO) error {
+			_, err := uc.Close(context.Background(), dto)
+			return err
+		}},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			uc, d := newReturnsUseCase()
+			d.repo.Return = devolucionEn(tc.status)
+
+			err := tc.accion(uc, dtos.TransitionDTO{ReturnID: 1, BusinessID: 5})
+
+			assert.ErrorIs(t, err, dom.ErrInvalidTransition)
+			assert.Empty(t, d.repo.Updates)
+			assert.Empty(t, d.events.Events)
+		})
+	}
+}
+
+func TestReject_RequiereMotivo(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Return = devolucionEn(entities.ReturnStatusRequested)
+
+	_, err := uc.Reject(context.Background(), dtos.TransitionDTO{ReturnID: 1, BusinessID: 5, Note: "  "})
+
+	assert.ErrorIs(t, err, dom.ErrNoteRequired)
+	assert.Equal(t, entities.ReturnStatusRequested, d.repo.Return.Status)
+}
+
+func TestGet_OtroNegocio_NoEncontrada(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Return = devolucionEn(entities.ReturnStatusRequested)
+
+	_, err := uc.Get(context.Background(), 6, 1)
+
+	assert.ErrorIs(t, err, dom.ErrReturnNotFound)
+}
+
+func TestRequestLabel_GuardaEnvioDeLaGuia(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Return = devolucionEn(entities.ReturnStatusApproved)
+	payload := map[string]interface{}{"origin": map[string]any{}, "destination": map[string]any{}, "packages": []any{}}
+
+	got, err := uc.RequestLabel(context.Background(), dtos.RequestLabelDTO{ReturnID: 1, BusinessID: 5, Payload: payload})
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.ReturnStatusLabelRequested, got.Status)
+	require.Len(t, d.labels.Requests, 1)
+	assert.Equal(t, "RMA-000001", d.labels.Requests[0].ReturnCode)
+	assert.Equal(t, "ord-1", d.labels.Requests[0].OrderID)
+	assert.Equal(t, uint(99), d.repo.Updates[0]["return_shipment_id"])
+}
+
+func TestRequestLabel_EstadoInvalido_NoGeneraGuia(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Return = devolucionEn(entities.ReturnStatusRequested)
+
+	_, err := uc.RequestLabel(context.Background(), dtos.RequestLabelDTO{ReturnID: 1, BusinessID: 5})
+
+	assert.ErrorIs(t, err, dom.ErrInvalidTransition)
+	assert.Empty(t, d.labels.Requests)
+}
+
+func TestGetLabel_SinGuia(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Return = devolucionEn(entities.ReturnStatusApproved)
+
+	_, err := uc.GetLabel(context.Background(), 5, 1)
+
+	assert.ErrorIs(t, err, dom.ErrLabelNotAvailable)
+}
+
+func TestInspect_Validaciones(t *testing.T) {
+	casos := []struct {
+		nombre  string
+		items   []dtos.InspectItemDTO
+		wantErr error
+	}{
+		{"falta una linea", []dtos.InspectItemDTO{{ItemID: 1, Result: entities.InspectionRestock, Quantity: 2}}, dom.ErrInspectionIncomplete},
+		{"resultado invalido", []dtos.InspectItemDTO{
+			{ItemID: 1, Result: "reciclar", Quantity: 2}, {ItemID: 2, Result: entities.InspectionRestock, Quantity: 1},
+		}, dom.ErrInvalidInspection},
+		{"mas unidades de las solicitadas", []dtos.InspectItemDTO{
+			{ItemID: 1, Result: entities.InspectionRestock, Quantity: 3}, {ItemID: 2, Result: entities.InspectionRestock, Quantity: 1},
+		}, dom.ErrInvalidQuantity},
+		{"linea de otra devolucion", []dtos.InspectItemDTO{
+			{ItemID: 1, Result: entities.InspectionRestock, Quantity: 2}, {ItemID: 2, Result: entities.InspectionRestock, Quantity: 1},
+			{ItemID: 8, Result: entities.InspectionRestock, Quantity: 1},
+		}, dom.ErrInvalidInspection},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			uc, d := newReturnsUseCase()
+			d.repo.Return = devolucionEn(entities.ReturnStatusReceived)
+
+			_, err := uc.Inspect(context.Background(), dtos.InspectReturnDTO{ReturnID: 1, BusinessID: 5, Items: tc.items})
+
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Empty(t, d.inventory.Receipts)
+			assert.Empty(t, d.repo.Inspections)
+		})
+	}
+}
+
+func TestInspect_ReingresaEnElEstadoDeCadaLinea(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Return = devolucionEn(entities.ReturnStatusReceived)
+
+	got, err := uc.Inspect(context.Background(), dtos.InspectReturnDTO{
+		ReturnID: 1, BusinessID: 5, ChangedByID: uintPtr(9),
+		Items: []dtos.InspectItemDTO{
+			{ItemID: 1, Result: entities.InspectionRestock, Quantity: 1},
+			{ItemID: 2, Result: entities.InspectionDamaged, Quantity: 1},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.ReturnStatusInspected, got.Status)
+	assert.Len(t, d.repo.Inspections, 2)
+	require.Len(t, d.inventory.Receipts, 1)
+	receipt := d.inventory.Receipts[0]
+	assert.Equal(t, "RMA-000001", receipt.ReturnCode)
+	assert.Equal(t, uintPtr(3), receipt.WarehouseID)
+	assert.Equal(t, []inventory.ReturnedItem{
+		{ProductID: "p-1", SKU: "CAM-M", Quantity: 1, State: inventory.ReturnStateAvailable},
+		{ProductID: "p-2", SKU: "PAN-32", Quantity: 1, State: inventory.ReturnStateDamaged},
+	}, receipt.Items)
+	assert.Equal(t, 130000.0, got.RefundAmount)
+	assert.Equal(t, 1, d.repo.Transaction)
+}
+
+func TestInspect_FallaInventario_NoCambiaEstado(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Return = devolucionEn(entities.ReturnStatusReceived)
+	d.inventory.ReceiveReturnFn = func(ctx context.Context, receipt inventory.ReturnReceipt) error {
+		return stderrors.New("bodega no encontrada")
+	}
+
+	_, err := uc.Inspect(context.Background(), dtos.InspectReturnDTO{
+		ReturnID: 1, BusinessID: 5,
+		Items: []dtos.InspectItemDTO{
+			{ItemID: 1, Result: entities.InspectionQuarantine, Quantity: 2},
+			{ItemID: 2, Result: entities.InspectionRestock, Quantity: 0},
+		},
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, entities.ReturnStatusReceived, d.repo.Return.Status)
+	assert.Empty(t, d.events.Events)
+}
+
+func TestRefund_Monto(t *testing.T) {
+	casos := []struct {
+		nombre   string
+		sugerido float64
+		monto    float64
+		wantErr  error
+	}{
+		{"sin monto ni sugerido", 0, 0, dom.ErrInvalidRefundAmount},
+		{"negativo", 0, -10, dom.ErrInvalidRefundAmount},
+		{"mayor a lo solicitado", 0, 180001, dom.ErrInvalidRefundAmount},
+		{"toma el sugerido", 100000, 0, nil},
+	}
+
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			uc, d := newReturnsUseCase()
+			d.repo.Return = devolucionEn(entities.ReturnStatusInspected)
+			d.repo.Return.RefundAmount = tc.sugerido
+
+			_, err := uc.Refund(context.Background(), dtos.RefundReturnDTO{
+				ReturnID: 1, BusinessID: 5, Method: entities.RefundMethodWallet, Amount: tc.monto,
+			})
+
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				assert.Empty(t, d.wallet.Debits)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, d.wallet.Debits, 1)
+			assert.Equal(t, tc.sugerido, d.wallet.Debits[0].Amount)
+		})
+	}
+}
+
+func TestRefund_Wallet_DebitaEnLaMismaTransaccion(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Return = devolucionEn(entities.ReturnStatusInspected)
+
+	got, err := uc.Refund(context.Background(), dtos.RefundReturnDTO{
+		ReturnID: 1, BusinessID: 5, ChangedByID: uintPtr(9), Method: entities.RefundMethodWallet, Amount: 50000,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.ReturnStatusRefunded, got.Status)
+	require.Len(t, d.wallet.Debits, 1)
+	assert.Equal(t, mocks.WalletDebit{BusinessID: 5, Amount: 50000, Reference: "RMA-000001 orden 1001", Concept: "REFUND", UserID: 9}, d.wallet.Debits[0])
+	assert.Equal(t, 1, d.repo.Transaction)
+	assert.Equal(t, entities.ReturnEventRefunded, d.events.Events[0].EventType)
+}
+
+func TestRefund_Wallet_FallaDebito_NoCambiaEstado(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Return = devolucionEn(entities.ReturnStatusInspected)
+	d.wallet.DebitFn = func(ctx context.Context, businessID uint, amount float64, reference, concept string, userID uint) error {
+		return stderrors.New("wallet not found")
+	}
+
+	_, err := uc.Refund(context.Background(), dtos.RefundReturnDTO{ReturnID: 1, BusinessID: 5, Method: entities.RefundMethodWallet, Amount: 50000})
+
+	assert.Error(t, err)
+	assert.Equal(t, entities.ReturnStatusInspected, d.repo.Return.Status)
+	assert.Empty(t, d.events.Events)
+}
+
+func TestRefund_NotaCredito_GuardaNumeroDeLaNota(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Return = devolucionEn(entities.ReturnStatusInspected)
+
+	_, err := uc.Refund(context.Background(), dtos.RefundReturnDTO{ReturnID: 1, BusinessID: 5, Method: entities.RefundMethodCreditNote, Amount: 80000})
+
+	require.NoError(t, err)
+	require.Len(t, d.creditNotes.Requests, 1)
+	assert.Equal(t, invoicing.OrderCreditNoteRequest{BusinessID: 5, OrderID: "ord-1", Amount: 80000, Reason: "Devolucion RMA-000001"}, d.creditNotes.Requests[0])
+	last := d.repo.Updates[len(d.repo.Updates)-1]
+	assert.Equal(t, uint(7), last["credit_note_id"])
+	assert.Equal(t, "NC-0007", last["refund_reference"])
+	assert.Equal(t, entities.ReturnStatusRefunded, d.repo.Return.Status)
+}
+
+func TestRefund_NotaCredito_Concurrente_EmiteUnaSolaNota(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Return = devolucionEn(entities.ReturnStatusInspected)
+	ctx := context.Background()
+	dto := dtos.RefundReturnDTO{ReturnID: 1, BusinessID: 5, Method: entities.RefundMethodCreditNote, Amount: 80000}
+
+	// El segundo request llega mientras el primero emite la nota
+	var segundoErr error
+	d.creditNotes.CreateFn = func(ctx context.Context, req invoicing.OrderCreditNoteRequest) (*invoicing.OrderCreditNote, error) {
+		if len(d.creditNotes.Requests) == 1 {
+			_, segundoErr = uc.Refund(ctx, dto)
+		}
+		return &invoicing.OrderCreditNote{ID: 7, InternalNumber: "NC-0007", Amount: req.Amount}, nil
+	}
+
+	got, err := uc.Refund(ctx, dto)
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.ReturnStatusRefunded, got.Status)
+	assert.ErrorIs(t, segundoErr, dom.ErrInvalidTransition)
+	assert.Len(t, d.creditNotes.Requests, 1, "solo el request que reclamo la devolucion emite la nota")
+}
+
+func TestRefund_NotaCredito_FallaEmision_QuedaPendiente(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Return = devolucionEn(entities.ReturnStatusInspected)
+	d.creditNotes.CreateFn = func(ctx context.Context, req invoicing.OrderCreditNoteRequest) (*invoicing.OrderCreditNote, error) {
+		return nil, stderrors.New("factura no emitida")
+	}
+
+	_, err := uc.Refund(context.Background(), dtos.RefundReturnDTO{ReturnID: 1, BusinessID: 5, Method: entities.RefundMethodCreditNote, Amount: 80000})
+
+	assert.Error(t, err)
+	assert.Equal(t, entities.ReturnStatusRefundPending, d.repo.Return.Status)
+}
+
+func TestRefund_Pasarela_QuedaPendienteHastaConfirmar(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Return = devolucionEn(entities.ReturnStatusInspected)
+	ctx := context.Background()
+
+	got, err := uc.Refund(ctx, dtos.RefundReturnDTO{ReturnID: 1, BusinessID: 5, Method: entities.RefundMethodPaymentGateway, Amount: 80000})
+	require.NoError(t, err)
+	assert.Equal(t, entities.ReturnStatusRefundPending, got.Status)
+	assert.Equal(t, entities.ReturnEventRefundRequested, d.events.Events[0].EventType)
+
+	_, err = uc.ConfirmRefund(ctx, dtos.ConfirmRefundDTO{ReturnID: 1, BusinessID: 5})
+	assert.ErrorIs(t, err, dom.ErrRefundReferenceMissing)
+
+	got, err = uc.ConfirmRefund(ctx, dtos.ConfirmRefundDTO{ReturnID: 1, BusinessID: 5, Reference: "BOLD-RF-123"})
+	require.NoError(t, err)
+	assert.Equal(t, entities.ReturnStatusRefunded, got.Status)
+	assert.Empty(t, d.wallet.Debits)
+	assert.Empty(t, d.creditNotes.Requests)
+}
+
+func TestRefund_MetodoInvalido(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Return = devolucionEn(entities.ReturnStatusInspected)
+
+	_, err := uc.Refund(context.Background(), dtos.RefundReturnDTO{ReturnID: 1, BusinessID: 5, Method: "efectivo"})
+
+	assert.ErrorIs(t, err, dom.ErrInvalidRefundMethod)
+}
+
+func TestCreate_BloqueaLaOrdenAntesDeSumarLoDevuelto(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Order = ordenConDosItems()
+	d.repo.ReturnedQuantitiesFn = func(ctx context.Context, orderID string) (map[uint]int, error) {
+		require.Equal(t, []string{"ord-1"}, d.repo.LockedOrders, "la suma debe leerse con la orden bloqueada")
+		return map[uint]int{}, nil
+	}
+
+	_, err := uc.Create(context.Background(), dtos.CreateReturnDTO{
+		BusinessID: 5, OrderID: "ord-1", Reason: entities.ReturnReasonDefective,
+		Items: []dtos.CreateReturnItemDTO{{OrderItemID: 10, Quantity: 1}},
+	})
+
+	require.NoError(t, err)
+}
+
+func TestRefund_Concurrente_SoloUnoCambiaElEstado(t *testing.T) {
+	uc, d := newReturnsUseCase()
+	d.repo.Return = devolucionEn(entities.ReturnStatusRefunded)
+	// El otro request reembolso despues de que este leyera la fila: la
+	// actualizacion condicionada al estado anterior lo detecta
+	inspeccionada := func(ctx context.Context, businessID uint, id uint) (*entities.ReturnRequest, error) {
+		return devolucionEn(entities.ReturnStatusInspected), nil
+	}
+	d.repo.GetByIDFn = inspeccionada
+	d.repo.GetByIDForUpdateFn = inspeccionada
+
+	_, err := uc.Refund(context.Background(), dtos.RefundReturnDTO{ReturnID: 1, BusinessID: 5, Method: entities.RefundMethodWallet, Amount: 50000})
+
+	assert.ErrorIs(t, err, dom.ErrInvalidTransition)
+	assert.Empty(t, d.repo.History)
+	assert.Empty(t, d.events.Events)
+	assert.Equal(t, 1, d.repo.LockedReturns)
+}
@@ -0,0 +1,102 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/errors"
+)
+
+// statusTimestamps es la columna que guarda cuando la devolucion entro a cada estado
+var statusTimestamps = map[entities.ReturnStatus]string{
+	entities.ReturnStatusApproved:  "approved_at",
+	entities.ReturnStatusReceived:  "received_at",
+	entities.ReturnStatusInspected: "inspected_at",
+	entities.ReturnStatusRefunded:  "refunded_at",
+	entities.ReturnStatusClosed:    "closed_at",
+}
+
+// statusChange es un cambio de estado con los campos extra que se guardan y
+// los datos que viajan en el evento
+type statusChange struct {
+	to        entities.ReturnStatus
+	changedBy *uint
+	note      string
+	updates   map[string]any
+	data      map[string]any
+}
+
+// transition aplica el cambio sobre ret con el ctx de una transaccion abierta:
+// valida la maquina de estados y guarda estado, historial y evento juntos.
+func (uc *UseCase) transition(ctx context.Context, ret *entities.ReturnRequest, change statusChange) error {
+	from := ret.Status
+	if !from.CanTransitionTo(change.to) {
+		return dom.ErrInvalidTransition
+	}
+
+	now := time.Now()
+	updates := map[string]any{"status": string(change.to)}
+	for k, v := range change.updates {
+		updates[k] = v
+	}
+	if column, ok := statusTimestamps[change.to]; ok {
+		updates[column] = now
+	}
+
+	if err := uc.repo.UpdateStatus(ctx, ret.ID, from, updates); err != nil {
+		return err
+	}
+	if err := uc.repo.AddHistory(ctx, &entities.ReturnStatusHistory{
+		ReturnRequestID: ret.ID,
+		FromStatus:      from,
+		ToStatus:        change.to,
+		ChangedByID:     change.changedBy,
+		Note:            change.note,
+	}); err != nil {
+		return err
+	}
+	if err := uc.events.PublishReturnEvent(ctx, entities.ReturnEvent{
+		EventType:      entities.EventFor(change.to),
+		ReturnID:       ret.ID,
+		Code:           ret.Code,
+		BusinessID:     ret.BusinessID,
+		OrderID:        ret.OrderID,
+		Status:         string(change.to),
+		PreviousStatus: string(from),
+		ChangedByID:    change.changedBy,
+		Timestamp:      now,
+		Data:           change.data,
+	}); err != nil {
+		return err
+	}
+
+	ret.Status = change.to
+	return nil
+}
+
+// changeStatus carga la devolucion con la fila bloqueada y aplica el cambio en
+// una transaccion. before corre dentro de la misma transaccion, antes del
+// cambio de estado, asi que dos requests simultaneos no reingresan ni debitan
+// dos veces: el segundo espera el bloqueo y ve el estado nuevo.
+func (uc *UseCase) changeStatus(ctx context.Context, businessID, id uint, change statusChange, before func(ctx context.Context, ret *entities.ReturnRequest) error) (*entities.ReturnRequest, error) {
+	err := uc.repo.InTransaction(ctx, func(ctx context.Context) error {
+		ret, err := uc.repo.GetByIDForUpdate(ctx, businessID, id)
+		if err != nil {
+			return err
+		}
+		if !ret.Status.CanTransitionTo(change.to) {
+			return dom.ErrInvalidTransition
+		}
+		if before != nil {
+			if err := before(ctx, ret); err != nil {
+				return err
+			}
+		}
+		return uc.transition(ctx, ret, change)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return uc.repo.GetByID(ctx, businessID, id)
+}
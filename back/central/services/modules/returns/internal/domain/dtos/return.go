@@ -0,0 +1,83 @@
+package dtos
+
+type ListReturnsParams struct {
+	Page     int
+	PageSize int
+
+	BusinessID    uint
+	OrderID       string
+	Status        []string
+	Source        string
+	RequestedByID *uint
+	Search        string
+}
+
+type CreateReturnDTO struct {
+	BusinessID    uint
+	OrderID       string
+	Source        string
+	RequestedByID *uint
+	Reason        string
+	CustomerNote  string
+	Items         []CreateReturnItemDTO
+}
+
+type CreateReturnItemDTO struct {
+	OrderItemID uint
+	Quantity    int
+	Reason      string
+	Note        string
+}
+
+// TransitionDTO cubre las acciones que solo cambian el estado (aprobar,
+// rechazar, cancelar, recibir, cerrar...)
+type TransitionDTO struct {
+	ReturnID    uint
+	BusinessID  uint
+	ChangedByID *uint
+	Note        string
+	WarehouseID *uint
+}
+
+type RequestLabelDTO struct {
+	ReturnID    uint
+	BusinessID  uint
+	ChangedByID *uint
+	ChangedBy   string
+	Payload     map[string]interface{}
+}
+
+type InspectReturnDTO struct {
+	ReturnID    uint
+	BusinessID  uint
+	ChangedByID *uint
+	WarehouseID *uint
+	Note        string
+	Items       []InspectItemDTO
+}
+
+// InspectItemDTO es el resultado de inspeccionar una linea. Quantity son las
+// unidades que llegaron en ese estado; las que no llegaron quedan fuera.
+type InspectItemDTO struct {
+	ItemID   uint
+	Result   string
+	Quantity int
+	Note     string
+}
+
+type RefundReturnDTO struct {
+	ReturnID    uint
+	BusinessID  uint
+	ChangedByID *uint
+	Method      string
+	Amount      float64
+	Note        string
+}
+
+type ConfirmRefundDTO struct {
+	ReturnID    uint
+	BusinessID  uint
+	ChangedByID *uint
+	Reference   string
+	Note        string
+}
@@ -0,0 +1,53 @@
+package entities
+
+import "time"
+
+// Eventos de devoluciones publicados en el exchange returns.events. El routing
+// key es el tipo de evento.
+const (
+	ReturnEventRequested       = "return.requested"
+	ReturnEventApproved        = "return.approved"
+	ReturnEventRejected        = "return.rejected"
+	ReturnEventCancelled       = "return.cancelled"
+	ReturnEventLabelRequested  = "return.label_requested"
+	ReturnEventInTransit       = "return.in_transit"
+	ReturnEventReceived        = "return.received"
+	ReturnEventInspected       = "return.inspected"
+	ReturnEventRefundRequested = "return.refund_requested"
+	ReturnEventRefunded        = "return.refunded"
+	ReturnEventClosed          = "return.closed"
+)
+
+// statusEvents es el evento que publica cada estado al entrar en el
+var statusEvents = map[ReturnStatus]string{
+	ReturnStatusRequested:      ReturnEventRequested,
+	ReturnStatusApproved:       ReturnEventApproved,
+	ReturnStatusRejected:       ReturnEventRejected,
+	ReturnStatusCancelled:      ReturnEventCancelled,
+	ReturnStatusLabelRequested: ReturnEventLabelRequested,
+	ReturnStatusInTransit:      ReturnEventInTransit,
+	ReturnStatusReceived:       ReturnEventReceived,
+	ReturnStatusInspected:      ReturnEventInspected,
+	ReturnStatusRefundPending:  ReturnEventRefundRequested,
+	ReturnStatusRefunded:       ReturnEventRefunded,
+	ReturnStatusClosed:         ReturnEventClosed,
+}
+
+// EventFor retorna el evento que se publica al entrar en status
+func EventFor(status ReturnStatus) string {
+	return statusEvents[status]
+}
+
+// ReturnEvent es el mensaje publicado en cada cambio de estado
+type ReturnEvent struct {
+	EventType      string
+	ReturnID       uint
+	Code           string
+	BusinessID     uint
+	OrderID        string
+	Status         string
+	PreviousStatus string
+	ChangedByID    *uint
+	Timestamp      time.Time
+	Data           map[string]any
+}
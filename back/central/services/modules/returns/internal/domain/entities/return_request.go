@@ -0,0 +1,180 @@
+package entities
+
+import "time"
+
+// Origen de la solicitud
+const (
+	ReturnSourceCustomer = "customer"
+	ReturnSourceOperator = "operator"
+)
+
+// Motivos de devolucion por linea
+const (
+	ReturnReasonDefective        = "defective"
+	ReturnReasonWrongItem        = "wrong_item"
+	ReturnReasonNotAsDescribed   = "not_as_described"
+	ReturnReasonDamagedInTransit = "damaged_in_transit"
+	ReturnReasonSizeOrFit        = "size_or_fit"
+	ReturnReasonNoLongerNeeded   = "no_longer_needed"
+	ReturnReasonOther            = "other"
+)
+
+var returnReasons = map[string]bool{
+	ReturnReasonDefective:        true,
+	ReturnReasonWrongItem:        true,
+	ReturnReasonNotAsDescribed:   true,
+	ReturnReasonDamagedInTransit: true,
+	ReturnReasonSizeOrFit:        true,
+	ReturnReasonNoLongerNeeded:   true,
+	ReturnReasonOther:            true,
+}
+
+// IsValidReturnReason verifica si el motivo es uno de los permitidos
+func IsValidReturnReason(reason string) bool {
+	return returnReasons[reason]
+}
+
+// Resultado de la inspeccion de una linea. Cada uno corresponde a un estado de
+// inventario: restock vuelve a available, damaged y quarantine a su estado.
+const (
+	InspectionRestock    = "restock"
+	InspectionDamaged    = "damaged"
+	InspectionQuarantine = "quarantine"
+)
+
+var inspectionResults = map[string]bool{
+	InspectionRestock:    true,
+	InspectionDamaged:    true,
+	InspectionQuarantine: true,
+}
+
+// IsValidInspectionResult verifica si el resultado de inspeccion existe
+func IsValidInspectionResult(result string) bool {
+	return inspectionResults[result]
+}
+
+// Formas de reembolso
+const (
+	RefundMethodWallet         = "wallet"
+	RefundMethodPaymentGateway = "payment_gateway"
+	RefundMethodCreditNote     = "credit_note"
+)
+
+var refundMethods = map[string]bool{
+	RefundMethodWallet:         true,
+	RefundMethodPaymentGateway: true,
+	RefundMethodCreditNote:     true,
+}
+
+// IsValidRefundMethod verifica si la forma de reembolso existe
+func IsValidRefundMethod(method string) bool {
+	return refundMethods[method]
+}
+
+// ReturnRequest es una autorizacion de devolucion (RMA) sobre una orden
+type ReturnRequest struct {
+	ID         uint
+	Code       string
+	BusinessID uint
+	OrderID    string
+	Status     ReturnStatus
+	Source     string
+
+	Reason        string
+	CustomerNote  string
+	RequestedByID *uint
+	WarehouseID   *uint
+
+	RefundMethod    string
+	RefundAmount    float64
+	RefundReference string
+	CreditNoteID    *uint
+
+	ReturnShipmentID *uint
+
+	ApprovedAt  *time.Time
+	ReceivedAt  *time.Time
+	InspectedAt *time.Time
+	RefundedAt  *time.Time
+	ClosedAt    *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	OrderNumber string
+	Items       []ReturnItem
+}
+
+// RequestedTotal es el valor de las unidades solicitadas, el tope del reembolso
+func (r *ReturnRequest) RequestedTotal() float64 {
+	total := 0.0
+	for _, item := range r.Items {
+		total += float64(item.Quantity) * item.UnitPrice
+	}
+	return total
+}
+
+// InspectedTotal es el valor de las unidades aceptadas en la inspeccion, el
+// reembolso sugerido
+func (r *ReturnRequest) InspectedTotal() float64 {
+	total := 0.0
+	for _, item := range r.Items {
+		total += float64(item.InspectedQuantity) * item.UnitPrice
+	}
+	return total
+}
+
+// ReturnItem es una linea de la devolucion
+type ReturnItem struct {
+	ID          uint
+	OrderItemID uint
+	ProductID   *string
+	ProductSKU  string
+	ProductName string
+	Quantity    int
+	UnitPrice   float64
+	Reason      string
+	Note        string
+
+	InspectionResult  string
+	InspectedQuantity int
+	InspectionNote    string
+}
+
+// ReturnStatusHistory es un cambio de estado de una devolucion
+type ReturnStatusHistory struct {
+	ID              uint
+	ReturnRequestID uint
+	FromStatus      ReturnStatus
+	ToStatus        ReturnStatus
+	ChangedByID     *uint
+	Note            string
+	CreatedAt       time.Time
+}
+
+// ReturnOrder es lo que la devolucion necesita de la orden original
+type ReturnOrder struct {
+	ID          string
+	BusinessID  uint
+	OrderNumber string
+	Currency    string
+	WarehouseID *uint
+	Items       []ReturnOrderItem
+}
+
+// ReturnOrderItem es un item de la orden original
+type ReturnOrderItem struct {
+	ID          uint
+	ProductID   *string
+	ProductSKU  string
+	ProductName string
+	Quantity    int
+	UnitPrice   float64
+}
+
+// ReturnLabel es la guia de devolucion generada por la transportadora
+type ReturnLabel struct {
+	ShipmentID     uint
+	TrackingNumber string
+	GuideURL       string
+	Status         string
+}
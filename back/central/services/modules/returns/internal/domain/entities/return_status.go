@@ -0,0 +1,69 @@
+package entities
+
+// ReturnStatus es el estado de una devolucion
+type ReturnStatus string
+
+const (
+	ReturnStatusRequested      ReturnStatus = "requested"
+	ReturnStatusApproved       ReturnStatus = "approved"
+	ReturnStatusRejected       ReturnStatus = "rejected"
+	ReturnStatusLabelRequested ReturnStatus = "label_requested"
+	ReturnStatusInTransit      ReturnStatus = "in_transit"
+	ReturnStatusReceived       ReturnStatus = "received"
+	ReturnStatusInspected      ReturnStatus = "inspected"
+	ReturnStatusRefundPending  ReturnStatus = "refund_pending"
+	ReturnStatusRefunded       ReturnStatus = "refunded"
+	ReturnStatusClosed         ReturnStatus = "closed"
+	ReturnStatusCancelled      ReturnStatus = "cancelled"
+)
+
+// returnTransitions define a que estados puede pasar cada estado. La guia es
+// opcional: un cliente puede traer el paquete o enviarlo por su cuenta, por eso
+// approved puede ir directo a in_transit o received. inspected puede cerrar sin
+// reembolso cuando nada de lo recibido se acepta.
+var returnTransitions = map[ReturnStatus][]ReturnStatus{
+	ReturnStatusRequested:      {ReturnStatusApproved, ReturnStatusRejected, ReturnStatusCancelled},
+	ReturnStatusApproved:       {ReturnStatusLabelRequested, ReturnStatusInTransit, ReturnStatusReceived, ReturnStatusCancelled},
+	ReturnStatusLabelRequested: {ReturnStatusInTransit, ReturnStatusReceived, ReturnStatusCancelled},
+	ReturnStatusInTransit:      {ReturnStatusReceived},
+	ReturnStatusReceived:       {ReturnStatusInspected},
+	ReturnStatusInspected:      {ReturnStatusRefundPending, ReturnStatusRefunded, ReturnStatusClosed},
+	ReturnStatusRefundPending:  {ReturnStatusRefunded},
+	ReturnStatusRefunded:       {ReturnStatusClosed},
+}
+
+var terminalReturnStatuses = map[ReturnStatus]bool{
+	ReturnStatusRejected:  true,
+	ReturnStatusCancelled: true,
+	ReturnStatusClosed:    true,
+}
+
+// IsValid verifica si el estado existe
+func (s ReturnStatus) IsValid() bool {
+	if terminalReturnStatuses[s] {
+		return true
+	}
+	_, ok := returnTransitions[s]
+	return ok
+}
+
+// IsTerminal verifica si la devolucion ya no puede cambiar de estado
+func (s ReturnStatus) IsTerminal() bool {
+	return terminalReturnStatuses[s]
+}
+
+// CanTransitionTo verifica si se puede pasar de s a target
+func (s ReturnStatus) CanTransitionTo(target ReturnStatus) bool {
+	for _, allowed := range returnTransitions[s] {
+		if allowed == target {
+			return true
+		}
+	}
+	return false
+}
+
+// CountsAgainstOrder indica si las unidades de la devolucion cuentan como ya
+// devueltas al validar una nueva devolucion de la misma orden
+func (s ReturnStatus) CountsAgainstOrder() bool {
+	return s != ReturnStatusRejected && s != ReturnStatusCancelled
+}
@@ -0,0 +1,23 @@
+package errors
+
+import "errors"
+
+var (
+	ErrReturnNotFound         = errors.New("return not found")
+	ErrOrderNotFound          = errors.New("order not found")
+	ErrOrderItemNotFound      = errors.New("order item not found")
+	ErrForbidden              = errors.New("forbidden")
+	ErrItemsRequired          = errors.New("at least one item is required")
+	ErrInvalidQuantity        = errors.New("invalid quantity")
+	ErrQuantityExceeded       = errors.New("quantity exceeds what is left to return on the order item")
+	ErrInvalidReason          = errors.New("invalid return reason")
+	ErrInvalidTransition      = errors.New("invalid return status transition")
+	ErrInvalidInspection      = errors.New("invalid inspection result")
+	ErrInspectionIncomplete   = errors.New("every item must be inspected")
+	ErrInvalidRefundMethod    = errors.New("invalid refund method")
+	ErrInvalidRefundAmount    = errors.New("invalid refund amount")
+	ErrRefundReferenceMissing = errors.New("refund reference is required")
+	ErrNoteRequired           = errors.New("note is required")
+	ErrLabelNotAvailable      = errors.New("return has no shipping label")
+	ErrGatewayUnavailable     = errors.New("integration not available")
+)
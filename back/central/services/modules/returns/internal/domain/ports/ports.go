@@ -0,0 +1,66 @@
+package ports
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/inventory"
+	"github.com/secamc93/probability/back/central/services/modules/invoicing"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/modules/shipments"
+)
+
+type IRepository interface {
+	// InTransaction corre fn en una transaccion: el cambio de estado, el
+	// historial, el evento (outbox) y los movimientos de otros modulos que usen
+	// el mismo ctx se confirman juntos
+	InTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+
+	NextCode(ctx context.Context) (string, error)
+	GetOrder(ctx context.Context, businessID uint, orderID string) (*entities.ReturnOrder, error)
+	// LockOrder bloquea la orden hasta el fin de la transaccion para que dos
+	// devoluciones de la misma orden no validen cantidades a la vez
+	LockOrder(ctx context.Context, orderID string) error
+	// ReturnedQuantities suma por item de la orden las unidades en devoluciones
+	// que no fueron rechazadas ni canceladas
+	ReturnedQuantities(ctx context.Context, orderID string) (map[uint]int, error)
+
+	Create(ctx context.Context, ret *entities.ReturnRequest) (*entities.ReturnRequest, error)
+	GetByID(ctx context.Context, businessID uint, id uint) (*entities.ReturnRequest, error)
+	// GetByIDForUpdate es GetByID bloqueando la fila (SELECT ... FOR UPDATE)
+	// hasta el fin de la transaccion
+	GetByIDForUpdate(ctx context.Context, businessID uint, id uint) (*entities.ReturnRequest, error)
+	List(ctx context.Context, params dtos.ListReturnsParams) ([]entities.ReturnRequest, int64, error)
+	// UpdateStatus aplica updates solo si la devolucion sigue en from; si otro
+	// request ya la movio responde ErrInvalidTransition
+	UpdateStatus(ctx context.Context, id uint, from entities.ReturnStatus, updates map[string]any) error
+	UpdateItemInspection(ctx context.Context, itemID uint, result string, quantity int, note string) error
+
+	AddHistory(ctx context.Context, history *entities.ReturnStatusHistory) error
+	ListHistory(ctx context.Context, returnID uint) ([]entities.ReturnStatusHistory, error)
+}
+
+type IEventPublisher interface {
+	PublishReturnEvent(ctx context.Context, event entities.ReturnEvent) error
+}
+
+// IInventoryGateway reingresa a bodega las unidades inspeccionadas
+type IInventoryGateway interface {
+	ReceiveReturn(ctx context.Context, receipt inventory.ReturnReceipt) error
+}
+
+// IWalletGateway debita la billetera del negocio para reembolsar
+type IWalletGateway interface {
+	Debit(ctx context.Context, businessID uint, amount float64, reference, concept string, userID uint) error
+}
+
+// ICreditNoteGateway emite la nota credito sobre la factura de la orden
+type ICreditNoteGateway interface {
+	CreateCreditNoteForOrder(ctx context.Context, req invoicing.OrderCreditNoteRequest) (*invoicing.OrderCreditNote, error)
+}
+
+// ILabelGateway genera la guia de devolucion por el router de transporte
+type ILabelGateway interface {
+	GenerateReturnLabel(ctx context.Context, req shipments.ReturnLabelRequest) (*shipments.ReturnLabel, error)
+	GetReturnLabel(ctx context.Context, shipmentID uint) (*shipments.ReturnLabel, error)
+}
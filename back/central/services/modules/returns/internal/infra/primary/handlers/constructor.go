@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/app"
+	dom "github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+type IHandlers interface {
+	RegisterRoutes(router *gin.RouterGroup)
+}
+
+type Handlers struct {
+	uc  app.IUseCase
+	log log.ILogger
+}
+
+func New(uc app.IUseCase, logger log.ILogger) IHandlers {
+	return &Handlers{uc: uc, log: logger}
+}
+
+func (h *Handlers) parseUintParam(c *gin.Context, key string) (uint, bool) {
+	v, err := strconv.ParseUint(c.Param(key), 10, 64)
+	if err != nil || v == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return 0, false
+	}
+	return uint(v), true
+}
+
+// requesterContext resuelve el usuario y el negocio. El super admin opera sobre
+// cualquier negocio y lo elige con ?business_id=; si no lo envia, businessID
+// queda en 0 y las consultas por id no filtran por negocio. Un usuario sin
+// negocio en el token recibe 403.
+func (h *Handlers) requesterContext(c *gin.Context) (userID uint, businessID uint, allowed bool) {
+	userID, _ = middleware.GetUserID(c)
+	businessID, _ = middleware.GetBusinessID(c)
+	if middleware.IsSuperAdmin(c) {
+		businessID = 0
+		if param := c.Query("business_id"); param != "" {
+			if v, err := strconv.ParseUint(param, 10, 64); err == nil && v > 0 {
+				businessID = uint(v)
+			}
+		}
+		return userID, businessID, true
+	}
+	if businessID == 0 {
+		c.JSON(http.StatusForbidden, gin.H{"error": dom.ErrForbidden.Error()})
+		return 0, 0, false
+	}
+	return userID, businessID, true
+}
+
+func (h *Handlers) userPtr(userID uint) *uint {
+	if userID == 0 {
+		return nil
+	}
+	return &userID
+}
+
+func (h *Handlers) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, dom.ErrReturnNotFound), errors.Is(err, dom.ErrOrderNotFound),
+		errors.Is(err, dom.ErrLabelNotAvailable):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, dom.ErrForbidden):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, dom.ErrInvalidTransition):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, dom.ErrOrderItemNotFound), errors.Is(err, dom.ErrItemsRequired),
+		errors.Is(err, dom.ErrInvalidQuantity), errors.Is(err, dom.ErrQuantityExceeded),
+		errors.Is(err, dom.ErrInvalidReason), errors.Is(err, dom.ErrInvalidInspection),
+		errors.Is(err, dom.ErrInspectionIncomplete), errors.Is(err, dom.ErrInvalidRefundMethod),
+		errors.Is(err, dom.ErrInvalidRefundAmount), errors.Is(err, dom.ErrRefundReferenceMissing),
+		errors.Is(err, dom.ErrNoteRequired):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, dom.ErrGatewayUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	default:
+		h.log.Error(c.Request.Context()).Err(err).Str("path", c.FullPath()).Msg("Error en devoluciones")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+func (h *Handlers) splitCSV(s string) []string {
+	if s == "" {
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	out := make([]string, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/infra/primary/handlers/response"
+)
+
+func (h *Handlers) Create(c *gin.Context) {
+	var req request.CreateReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, businessID, allowed := h.requesterContext(c)
+	if !allowed {
+		return
+	}
+	if businessID == 0 {
+		businessID = req.BusinessID
+	}
+	if businessID == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "business_id is required"})
+		return
+	}
+
+	dto := dtos.CreateReturnDTO{
+		BusinessID:    businessID,
+		OrderID:       req.OrderID,
+		Source:        entities.ReturnSourceOperator,
+		RequestedByID: h.userPtr(userID),
+		Reason:        req.Reason,
+		CustomerNote:  req.CustomerNote,
+	}
+	for _, item := range req.Items {
+		dto.Items = append(dto.Items, dtos.CreateReturnItemDTO{
+			OrderItemID: item.OrderItemID,
+			Quantity:    item.Quantity,
+			Reason:      item.Reason,
+			Note:        item.Note,
+		})
+	}
+
+	ret, err := h.uc.Create(c.Request.Context(), dto)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response.FromReturn(ret))
+}
+
+func (h *Handlers) Get(c *gin.Context) {
+	id, ok := h.parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	_, businessID, allowed := h.requesterContext(c)
+	if !allowed {
+		return
+	}
+	ret, err := h.uc.Get(c.Request.Context(), businessID, id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response.FromReturn(ret))
+}
+
+func (h *Handlers) List(c *gin.Context) {
+	_, businessID, allowed := h.requesterContext(c)
+	if !allowed {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	params := dtos.ListReturnsParams{
+		Page:       page,
+		PageSize:   pageSize,
+		BusinessID: businessID,
+		OrderID:    c.Query("order_id"),
+		Status:     h.splitCSV(c.Query("status")),
+		Source:     c.Query("source"),
+		Search:     c.Query("search"),
+	}
+
+	items, total, err := h.uc.List(c.Request.Context(), params)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	out := make([]response.ReturnResponse, 0, len(items))
+	for i := range items {
+		out = append(out, response.FromReturn(&items[i]))
+	}
+	totalPages := total / int64(pageSize)
+	if total%int64(pageSize) > 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data":        out,
+		"total":       total,
+		"page":        page,
+		"page_size":   pageSize,
+		"total_pages": totalPages,
+	})
+}
+
+func (h *Handlers) ListHistory(c *gin.Context) {
+	id, ok := h.parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	_, businessID, allowed := h.requesterContext(c)
+	if !allowed {
+		return
+	}
+	items, err := h.uc.ListHistory(c.Request.Context(), businessID, id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": response.FromHistory(items)})
+}
+
+func (h *Handlers) GetLabel(c *gin.Context) {
+	id, ok := h.parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	_, businessID, allowed := h.requesterContext(c)
+	if !allowed {
+		return
+	}
+	label, err := h.uc.GetLabel(c.Request.Context(), businessID, id)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response.FromLabel(label))
+}
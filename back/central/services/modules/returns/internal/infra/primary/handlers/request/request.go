@@ -0,0 +1,51 @@
+package request
+
+type CreateReturnRequest struct {
+	BusinessID   uint                `json:"business_id"`
+	OrderID      string              `json:"order_id" binding:"required"`
+	Reason       string              `json:"reason"`
+	CustomerNote string              `json:"customer_note"`
+	Items        []ReturnItemRequest `json:"items" binding:"required,min=1"`
+}
+
+type ReturnItemRequest struct {
+	OrderItemID uint   `json:"order_item_id" binding:"required"`
+	Quantity    int    `json:"quantity" binding:"required"`
+	Reason      string `json:"reason"`
+	Note        string `json:"note"`
+}
+
+type TransitionRequest struct {
+	Note        string `json:"note"`
+	WarehouseID *uint  `json:"warehouse_id"`
+}
+
+// RequestLabelRequest tiene el mismo payload de POST /shipments/generate:
+// origin es la direccion del cliente y destination la bodega que recibe
+type RequestLabelRequest struct {
+	Payload map[string]interface{} `json:"payload" binding:"required"`
+}
+
+type InspectReturnRequest struct {
+	WarehouseID *uint                `json:"warehouse_id"`
+	Note        string               `json:"note"`
+	Items       []InspectItemRequest `json:"items" binding:"required,min=1"`
+}
+
+type InspectItemRequest struct {
+	ItemID   uint   `json:"item_id" binding:"required"`
+	Result   string `json:"result" binding:"required"`
+	Quantity int    `json:"quantity"`
+	Note     string `json:"note"`
+}
+
+type RefundReturnRequest struct {
+	Method string  `json:"method" binding:"required"`
+	Amount float64 `json:"amount"`
+	Note   string  `json:"note"`
+}
+
+type ConfirmRefundRequest struct {
+	Reference string `json:"reference" binding:"required"`
+	Note      string `json:"note"`
+}
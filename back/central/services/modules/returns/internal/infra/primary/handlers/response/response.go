@@ -0,0 +1,138 @@
+package response
+
+import (
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+)
+
+type ReturnResponse struct {
+	ID               uint       `json:"id"`
+	Code             string     `json:"code"`
+	BusinessID       uint       `json:"business_id"`
+	OrderID          string     `json:"order_id"`
+	OrderNumber      string     `json:"order_number,omitempty"`
+	Status           string     `json:"status"`
+	Source           string     `json:"source"`
+	Reason           string     `json:"reason,omitempty"`
+	CustomerNote     string     `json:"customer_note,omitempty"`
+	RequestedByID    *uint      `json:"requested_by_id,omitempty"`
+	WarehouseID      *uint      `json:"warehouse_id,omitempty"`
+	RequestedTotal   float64    `json:"requested_total"`
+	RefundMethod     string     `json:"refund_method,omitempty"`
+	RefundAmount     float64    `json:"refund_amount"`
+	RefundReference  string     `json:"refund_reference,omitempty"`
+	CreditNoteID     *uint      `json:"credit_note_id,omitempty"`
+	ReturnShipmentID *uint      `json:"return_shipment_id,omitempty"`
+	ApprovedAt       *time.Time `json:"approved_at,omitempty"`
+	ReceivedAt       *time.Time `json:"received_at,omitempty"`
+	InspectedAt      *time.Time `json:"inspected_at,omitempty"`
+	RefundedAt       *time.Time `json:"refunded_at,omitempty"`
+	ClosedAt         *time.Time `json:"closed_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+
+	Items []ReturnItemResponse `json:"items"`
+}
+
+type ReturnItemResponse struct {
+	ID                uint    `json:"id"`
+	OrderItemID       uint    `json:"order_item_id"`
+	ProductID         *string `json:"product_id,omitempty"`
+	ProductSKU        string  `json:"product_sku"`
+	ProductName       string  `json:"product_name"`
+	Quantity          int     `json:"quantity"`
+	UnitPrice         float64 `json:"unit_price"`
+	Reason            string  `json:"reason"`
+	Note              string  `json:"note,omitempty"`
+	InspectionResult  string  `json:"inspection_result,omitempty"`
+	InspectedQuantity int     `json:"inspected_quantity"`
+	InspectionNote    string  `json:"inspection_note,omitempty"`
+}
+
+type HistoryResponse struct {
+	ID          uint      `json:"id"`
+	FromStatus  string    `json:"from_status,omitempty"`
+	ToStatus    string    `json:"to_status"`
+	ChangedByID *uint     `json:"changed_by_id,omitempty"`
+	Note        string    `json:"note,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type LabelResponse struct {
+	ShipmentID     uint   `json:"shipment_id"`
+	TrackingNumber string `json:"tracking_number,omitempty"`
+	GuideURL       string `json:"guide_url,omitempty"`
+	Status         string `json:"status"`
+}
+
+func FromReturn(r *entities.ReturnRequest) ReturnResponse {
+	out := ReturnResponse{
+		ID:               r.ID,
+		Code:             r.Code,
+		BusinessID:       r.BusinessID,
+		OrderID:          r.OrderID,
+		OrderNumber:      r.OrderNumber,
+		Status:           string(r.Status),
+		Source:           r.Source,
+		Reason:           r.Reason,
+		CustomerNote:     r.CustomerNote,
+		RequestedByID:    r.RequestedByID,
+		WarehouseID:      r.WarehouseID,
+		RequestedTotal:   r.RequestedTotal(),
+		RefundMethod:     r.RefundMethod,
+		RefundAmount:     r.RefundAmount,
+		RefundReference:  r.RefundReference,
+		CreditNoteID:     r.CreditNoteID,
+		ReturnShipmentID: r.ReturnShipmentID,
+		ApprovedAt:       r.ApprovedAt,
+		ReceivedAt:       r.ReceivedAt,
+		InspectedAt:      r.InspectedAt,
+		RefundedAt:       r.RefundedAt,
+		ClosedAt:         r.ClosedAt,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+		Items:            make([]ReturnItemResponse, 0, len(r.Items)),
+	}
+	for _, item := range r.Items {
+		out.Items = append(out.Items, ReturnItemResponse{
+			ID:                item.ID,
+			OrderItemID:       item.OrderItemID,
+			ProductID:         item.ProductID,
+			ProductSKU:        item.ProductSKU,
+			ProductName:       item.ProductName,
+			Quantity:          item.Quantity,
+			UnitPrice:         item.UnitPrice,
+			Reason:            item.Reason,
+			Note:              item.Note,
+			InspectionResult:  item.InspectionResult,
+			InspectedQuantity: item.InspectedQuantity,
+			InspectionNote:    item.InspectionNote,
+		})
+	}
+	return out
+}
+
+func FromHistory(items []entities.ReturnStatusHistory) []HistoryResponse {
+	out := make([]HistoryResponse, 0, len(items))
+	for _, h := range items {
+		out = append(out, HistoryResponse{
+			ID:          h.ID,
+			FromStatus:  string(h.FromStatus),
+			ToStatus:    string(h.ToStatus),
+			ChangedByID: h.ChangedByID,
+			Note:        h.Note,
+			CreatedAt:   h.CreatedAt,
+		})
+	}
+	return out
+}
+
+func FromLabel(l *entities.ReturnLabel) LabelResponse {
+	return LabelResponse{
+		ShipmentID:     l.ShipmentID,
+		TrackingNumber: l.TrackingNumber,
+		GuideURL:       l.GuideURL,
+		Status:         l.Status,
+	}
+}
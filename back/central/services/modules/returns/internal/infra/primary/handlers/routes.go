@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+)
+
+func (h *Handlers) RegisterRoutes(router *gin.RouterGroup) {
+	g := router.Group("/returns", middleware.JWT())
+	{
+		g.GET("", h.List)
+		g.POST("", h.Create)
+		g.GET(":id", h.Get)
+		g.GET(":id/history", h.ListHistory)
+
+		g.PATCH(":id/approve", h.Approve)
+		g.PATCH(":id/reject", h.Reject)
+		g.PATCH(":id/cancel", h.Cancel)
+		g.PATCH(":id/in-transit", h.MarkInTransit)
+		g.PATCH(":id/receive", h.Receive)
+		g.PATCH(":id/inspect", h.Inspect)
+		g.PATCH(":id/refund", h.Refund)
+		g.PATCH(":id/refund/confirm", h.ConfirmRefund)
+		g.PATCH(":id/close", h.Close)
+
+		g.POST(":id/label", h.RequestLabel)
+		g.GET(":id/label", h.GetLabel)
+	}
+}
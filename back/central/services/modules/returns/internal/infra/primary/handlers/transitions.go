@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/infra/primary/handlers/response"
+)
+
+type transitionFn func(ctx context.Context, dto dtos.TransitionDTO) (*entities.ReturnRequest, error)
+
+// transition atiende las acciones que solo cambian el estado. El body es
+// opcional.
+func (h *Handlers) transition(c *gin.Context, fn transitionFn) {
+	id, ok := h.parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.TransitionRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	userID, businessID, allowed := h.requesterContext(c)
+	if !allowed {
+		return
+	}
+	ret, err := fn(c.Request.Context(), dtos.TransitionDTO{
+		ReturnID:    id,
+		BusinessID:  businessID,
+		ChangedByID: h.userPtr(userID),
+		Note:        req.Note,
+		WarehouseID: req.WarehouseID,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response.FromReturn(ret))
+}
+
+func (h *Handlers) Approve(c *gin.Context)       { h.transition(c, h.uc.Approve) }
+func (h *Handlers) Reject(c *gin.Context)        { h.transition(c, h.uc.Reject) }
+func (h *Handlers) Cancel(c *gin.Context)        { h.transition(c, h.uc.Cancel) }
+func (h *Handlers) MarkInTransit(c *gin.Context) { h.transition(c, h.uc.MarkInTransit) }
+func (h *Handlers) Receive(c *gin.Context)       { h.transition(c, h.uc.Receive) }
+func (h *Handlers) Close(c *gin.Context)         { h.transition(c, h.uc.Close) }
+
+func (h *Handlers) RequestLabel(c *gin.Context) {
+	id, ok := h.parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.RequestLabelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, businessID, allowed := h.requesterContext(c)
+	if !allowed {
+		return
+	}
+	userName, _ := c.Get("user_email")
+	uname, _ := userName.(string)
+
+	ret, err := h.uc.RequestLabel(c.Request.Context(), dtos.RequestLabelDTO{
+		ReturnID:    id,
+		BusinessID:  businessID,
+		ChangedByID: h.userPtr(userID),
+		ChangedBy:   uname,
+		Payload:     req.Payload,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, response.FromReturn(ret))
+}
+
+func (h *Handlers) Inspect(c *gin.Context) {
+	id, ok := h.parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.InspectReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, businessID, allowed := h.requesterContext(c)
+	if !allowed {
+		return
+	}
+	dto := dtos.InspectReturnDTO{
+		ReturnID:    id,
+		BusinessID:  businessID,
+		ChangedByID: h.userPtr(userID),
+		WarehouseID: req.WarehouseID,
+		Note:        req.Note,
+	}
+	for _, item := range req.Items {
+		dto.Items = append(dto.Items, dtos.InspectItemDTO{
+			ItemID:   item.ItemID,
+			Result:   item.Result,
+			Quantity: item.Quantity,
+			Note:     item.Note,
+		})
+	}
+
+	ret, err := h.uc.Inspect(c.Request.Context(), dto)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response.FromReturn(ret))
+}
+
+func (h *Handlers) Refund(c *gin.Context) {
+	id, ok := h.parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.RefundReturnRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, businessID, allowed := h.requesterContext(c)
+	if !allowed {
+		return
+	}
+	ret, err := h.uc.Refund(c.Request.Context(), dtos.RefundReturnDTO{
+		ReturnID:    id,
+		BusinessID:  businessID,
+		ChangedByID: h.userPtr(userID),
+		Method:      req.Method,
+		Amount:      req.Amount,
+		Note:        req.Note,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response.FromReturn(ret))
+}
+
+func (h *Handlers) ConfirmRefund(c *gin.Context) {
+	id, ok := h.parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.ConfirmRefundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, businessID, allowed := h.requesterContext(c)
+	if !allowed {
+		return
+	}
+	ret, err := h.uc.ConfirmRefund(c.Request.Context(), dtos.ConfirmRefundDTO{
+		ReturnID:    id,
+		BusinessID:  businessID,
+		ChangedByID: h.userPtr(userID),
+		Reference:   req.Reference,
+		Note:        req.Note,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response.FromReturn(ret))
+}
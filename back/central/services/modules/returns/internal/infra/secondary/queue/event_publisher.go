@@ -0,0 +1,77 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/outbox"
+	"github.com/secamc93/probability/back/central/shared/rabbitmq"
+)
+
+// ReturnEventMessage es el formato en el exchange returns.events
+type ReturnEventMessage struct {
+	EventID        string         `json:"event_id"`
+	EventType      string         `json:"event_type"`
+	ReturnID       uint           `json:"return_id"`
+	Code           string         `json:"code"`
+	BusinessID     uint           `json:"business_id"`
+	OrderID        string         `json:"order_id"`
+	Status         string         `json:"status"`
+	PreviousStatus string         `json:"previous_status,omitempty"`
+	ChangedByID    *uint          `json:"changed_by_id,omitempty"`
+	Timestamp      time.Time      `json:"timestamp"`
+	Data           map[string]any `json:"data,omitempty"`
+}
+
+// EventPublisher escribe los eventos en el outbox, dentro de la transaccion del
+// cambio de estado; el relay los publica en el exchange con el tipo de evento
+// como routing key.
+type EventPublisher struct {
+	outbox outbox.IWriter
+	log    log.ILogger
+}
+
+func NewEventPublisher(writer outbox.IWriter, logger log.ILogger) ports.IEventPublisher {
+	return &EventPublisher{outbox: writer, log: logger}
+}
+
+func (p *EventPublisher) PublishReturnEvent(ctx context.Context, event entities.ReturnEvent) error {
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	payload, err := json.Marshal(ReturnEventMessage{
+		EventID:        uuid.NewString(),
+		EventType:      event.EventType,
+		ReturnID:       event.ReturnID,
+		Code:           event.Code,
+		BusinessID:     event.BusinessID,
+		OrderID:        event.OrderID,
+		Status:         event.Status,
+		PreviousStatus: event.PreviousStatus,
+		ChangedByID:    event.ChangedByID,
+		Timestamp:      event.Timestamp,
+		Data:           event.Data,
+	})
+	if err != nil {
+		return fmt.Errorf("error marshaling return event: %w", err)
+	}
+
+	msg := outbox.ToExchange(rabbitmq.ExchangeReturnEvents, event.EventType, event.EventType, payload).
+		WithAggregate("return", strconv.FormatUint(uint64(event.ReturnID), 10)).
+		WithBusiness(event.BusinessID)
+	if err := p.outbox.Enqueue(ctx, msg); err != nil {
+		p.log.Error(ctx).Err(err).
+			Str("code", event.Code).
+			Str("event_type", event.EventType).
+			Msg("Error encolando evento de devolucion")
+		return err
+	}
+	return nil
+}
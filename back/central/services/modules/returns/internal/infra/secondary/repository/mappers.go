@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func entityToModel(r *entities.ReturnRequest) *models.ReturnRequest {
+	m := &models.ReturnRequest{
+		Code:             r.Code,
+		BusinessID:       r.BusinessID,
+		OrderID:          r.OrderID,
+		Status:           string(r.Status),
+		Source:           r.Source,
+		Reason:           r.Reason,
+		CustomerNote:     r.CustomerNote,
+		RequestedByID:    r.RequestedByID,
+		WarehouseID:      r.WarehouseID,
+		RefundMethod:     r.RefundMethod,
+		RefundAmount:     r.RefundAmount,
+		RefundReference:  r.RefundReference,
+		CreditNoteID:     r.CreditNoteID,
+		ReturnShipmentID: r.ReturnShipmentID,
+	}
+	for _, item := range r.Items {
+		m.Items = append(m.Items, models.ReturnRequestItem{
+			OrderItemID: item.OrderItemID,
+			ProductID:   item.ProductID,
+			ProductSKU:  item.ProductSKU,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Reason:      item.Reason,
+			Note:        item.Note,
+		})
+	}
+	return m
+}
+
+func modelToEntity(m *models.ReturnRequest) *entities.ReturnRequest {
+	out := &entities.ReturnRequest{
+		ID:               m.ID,
+		Code:             m.Code,
+		BusinessID:       m.BusinessID,
+		OrderID:          m.OrderID,
+		Status:           entities.ReturnStatus(m.Status),
+		Source:           m.Source,
+		Reason:           m.Reason,
+		CustomerNote:     m.CustomerNote,
+		RequestedByID:    m.RequestedByID,
+		WarehouseID:      m.WarehouseID,
+		RefundMethod:     m.RefundMethod,
+		RefundAmount:     m.RefundAmount,
+		RefundReference:  m.RefundReference,
+		CreditNoteID:     m.CreditNoteID,
+		ReturnShipmentID: m.ReturnShipmentID,
+		ApprovedAt:       m.ApprovedAt,
+		ReceivedAt:       m.ReceivedAt,
+		InspectedAt:      m.InspectedAt,
+		RefundedAt:       m.RefundedAt,
+		ClosedAt:         m.ClosedAt,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+		OrderNumber:      m.Order.OrderNumber,
+	}
+	for i := range m.Items {
+		out.Items = append(out.Items, itemToEntity(&m.Items[i]))
+	}
+	return out
+}
+
+func itemToEntity(m *models.ReturnRequestItem) entities.ReturnItem {
+	return entities.ReturnItem{
+		ID:                m.ID,
+		OrderItemID:       m.OrderItemID,
+		ProductID:         m.ProductID,
+		ProductSKU:        m.ProductSKU,
+		ProductName:       m.ProductName,
+		Quantity:          m.Quantity,
+		UnitPrice:         m.UnitPrice,
+		Reason:            m.Reason,
+		Note:              m.Note,
+		InspectionResult:  m.InspectionResult,
+		InspectedQuantity: m.InspectedQuantity,
+		InspectionNote:    m.InspectionNote,
+	}
+}
+
+func historyToEntity(m *models.ReturnStatusHistory) entities.ReturnStatusHistory {
+	return entities.ReturnStatusHistory{
+		ID:              m.ID,
+		ReturnRequestID: m.ReturnRequestID,
+		FromStatus:      entities.ReturnStatus(m.FromStatus),
+		ToStatus:        entities.ReturnStatus(m.ToStatus),
+		ChangedByID:     m.ChangedByID,
+		Note:            m.Note,
+		CreatedAt:       m.CreatedAt,
+	}
+}
@@ -0,0 +1,268 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/shared/db"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository struct {
+	db db.IDatabase
+}
+
+func New(database db.IDatabase) ports.IRepository {
+	return &Repository{db: database}
+}
+
+func (r *Repository) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return db.InTransaction(ctx, r.db, fn)
+}
+
+func (r *Repository) NextCode(ctx context.Context) (string, error) {
+	var maxID uint
+	row := r.db.Conn(ctx).Unscoped().Model(&models.ReturnRequest{}).Select("COALESCE(MAX(id), 0)").Row()
+	if err := row.Scan(&maxID); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("RMA-%06d", maxID+1), nil
+}
+
+func (r *Repository) GetOrder(ctx context.Context, businessID uint, orderID string) (*entities.ReturnOrder, error) {
+	var order models.Order
+	err := r.db.Conn(ctx).
+		Select("id", "business_id", "order_number", "currency", "warehouse_id").
+		Where("id = ? AND business_id = ? AND deleted_at IS NULL", orderID, businessID).
+		First(&order).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, dom.ErrOrderNotFound
+		}
+		return nil, err
+	}
+
+	var items []models.OrderItem
+	if err := r.db.Conn(ctx).
+		Where("order_id = ?", orderID).
+		Order("id ASC").
+		Find(&items).Error; err != nil {
+		return nil, err
+	}
+
+	out := &entities.ReturnOrder{
+		ID:          order.ID,
+		BusinessID:  businessID,
+		OrderNumber: order.OrderNumber,
+		Currency:    order.Currency,
+		WarehouseID: order.WarehouseID,
+	}
+	for _, item := range items {
+		out.Items = append(out.Items, entities.ReturnOrderItem{
+			ID:          item.ID,
+			ProductID:   item.ProductID,
+			ProductSKU:  item.ProductSKU,
+			ProductName: item.ProductName,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+		})
+	}
+	return out, nil
+}
+
+func (r *Repository) LockOrder(ctx context.Context, orderID string) error {
+	var ids []string
+	err := r.db.Conn(ctx).Model(&models.Order{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", orderID).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return dom.ErrOrderNotFound
+	}
+	return nil
+}
+
+func (r *Repository) ReturnedQuantities(ctx context.Context, orderID string) (map[uint]int, error) {
+	var rows []struct {
+		OrderItemID uint
+		Quantity    int
+	}
+	err := r.db.Conn(ctx).
+		Table("return_request_items ri").
+		Select("ri.order_item_id, COALESCE(SUM(ri.quantity), 0) AS quantity").
+		Joins("JOIN return_requests rr ON rr.id = ri.return_request_id AND rr.deleted_at IS NULL").
+		Where("rr.order_id = ? AND ri.deleted_at IS NULL", orderID).
+		Where("rr.status NOT IN ?", []string{string(entities.ReturnStatusRejected), string(entities.ReturnStatusCancelled)}).
+		Group("ri.order_item_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		out[row.OrderItemID] = row.Quantity
+	}
+	return out, nil
+}
+
+func (r *Repository) Create(ctx context.Context, ret *entities.ReturnRequest) (*entities.ReturnRequest, error) {
+	m := entityToModel(ret)
+	if err := r.db.Conn(ctx).Omit("Business", "Order").Create(m).Error; err != nil {
+		return nil, err
+	}
+	ret.ID = m.ID
+	ret.CreatedAt = m.CreatedAt
+	ret.UpdatedAt = m.UpdatedAt
+	for i := range ret.Items {
+		ret.Items[i].ID = m.Items[i].ID
+	}
+	return ret, nil
+}
+
+func (r *Repository) GetByID(ctx context.Context, businessID uint, id uint) (*entities.ReturnRequest, error) {
+	var m models.ReturnRequest
+	q := r.db.Conn(ctx).
+		Preload("Order", func(db *gorm.DB) *gorm.DB { return db.Select("id", "order_number") }).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Where("id = ?", id)
+	if businessID > 0 {
+		q = q.Where("business_id = ?", businessID)
+	}
+	if err := q.First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, dom.ErrReturnNotFound
+		}
+		return nil, err
+	}
+	return modelToEntity(&m), nil
+}
+
+func (r *Repository) GetByIDForUpdate(ctx context.Context, businessID uint, id uint) (*entities.ReturnRequest, error) {
+	// Se bloquea primero la fila sola: los Preload no heredan el FOR UPDATE
+	q := r.db.Conn(ctx).Model(&models.ReturnRequest{}).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id)
+	if businessID > 0 {
+		q = q.Where("business_id = ?", businessID)
+	}
+	var locked []uint
+	if err := q.Pluck("id", &locked).Error; err != nil {
+		return nil, err
+	}
+	if len(locked) == 0 {
+		return nil, dom.ErrReturnNotFound
+	}
+	return r.GetByID(ctx, businessID, id)
+}
+
+func (r *Repository) List(ctx context.Context, params dtos.ListReturnsParams) ([]entities.ReturnRequest, int64, error) {
+	q := r.db.Conn(ctx).Model(&models.ReturnRequest{})
+
+	if params.BusinessID > 0 {
+		q = q.Where("return_requests.business_id = ?", params.BusinessID)
+	}
+	if params.OrderID != "" {
+		q = q.Where("return_requests.order_id = ?", params.OrderID)
+	}
+	if len(params.Status) > 0 {
+		q = q.Where("return_requests.status IN ?", params.Status)
+	}
+	if params.Source != "" {
+		q = q.Where("return_requests.source = ?", params.Source)
+	}
+	if params.RequestedByID != nil {
+		q = q.Where("return_requests.requested_by_id = ?", *params.RequestedByID)
+	}
+	if s := strings.TrimSpace(params.Search); s != "" {
+		like := "%" + s + "%"
+		q = q.Joins("LEFT JOIN orders ON orders.id = return_requests.order_id").
+			Where("return_requests.code ILIKE ? OR orders.order_number ILIKE ?", like, like)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var rows []models.ReturnRequest
+	err := q.
+		Preload("Order", func(db *gorm.DB) *gorm.DB { return db.Select("id", "order_number") }).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id ASC") }).
+		Order("return_requests.created_at DESC").
+		Offset((params.Page - 1) * params.PageSize).
+		Limit(params.PageSize).
+		Find(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	out := make([]entities.ReturnRequest, 0, len(rows))
+	for i := range rows {
+		out = append(out, *modelToEntity(&rows[i]))
+	}
+	return out, total, nil
+}
+
+func (r *Repository) UpdateStatus(ctx context.Context, id uint, from entities.ReturnStatus, updates map[string]any) error {
+	result := r.db.Conn(ctx).Model(&models.ReturnRequest{}).
+		Where("id = ? AND status = ?", id, string(from)).
+		Updates(updates)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return dom.ErrInvalidTransition
+	}
+	return nil
+}
+
+func (r *Repository) UpdateItemInspection(ctx context.Context, itemID uint, result string, quantity int, note string) error {
+	return r.db.Conn(ctx).Model(&models.ReturnRequestItem{}).Where("id = ?", itemID).Updates(map[string]any{
+		"inspection_result":  result,
+		"inspected_quantity": quantity,
+		"inspection_note":    note,
+	}).Error
+}
+
+func (r *Repository) AddHistory(ctx context.Context, history *entities.ReturnStatusHistory) error {
+	m := &models.ReturnStatusHistory{
+		ReturnRequestID: history.ReturnRequestID,
+		FromStatus:      string(history.FromStatus),
+		ToStatus:        string(history.ToStatus),
+		ChangedByID:     history.ChangedByID,
+		Note:            history.Note,
+	}
+	if err := r.db.Conn(ctx).Create(m).Error; err != nil {
+		return err
+	}
+	history.ID = m.ID
+	history.CreatedAt = m.CreatedAt
+	return nil
+}
+
+func (r *Repository) ListHistory(ctx context.Context, returnID uint) ([]entities.ReturnStatusHistory, error) {
+	var rows []models.ReturnStatusHistory
+	if err := r.db.Conn(ctx).
+		Where("return_request_id = ?", returnID).
+		Order("created_at ASC, id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]entities.ReturnStatusHistory, 0, len(rows))
+	for i := range rows {
+		out = append(out, historyToEntity(&rows[i]))
+	}
+	return out, nil
+}
@@ -0,0 +1,281 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"github.com/secamc93/probability/back/central/services/modules/inventory"
+	"github.com/secamc93/probability/back/central/services/modules/invoicing"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/returns/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/services/modules/shipments"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+type InspectionCall struct {
+	ItemID   uint
+	Result   string
+	Quantity int
+	Note     string
+}
+
+// RepositoryMock guarda la devolucion en Return: GetByID la retorna y
+// UpdateStatus le aplica el nuevo estado, para encadenar acciones en un mismo
+// test.
+type RepositoryMock struct {
+	InTransactionFn        func(ctx context.Context, fn func(ctx context.Context) error) error
+	NextCodeFn             func(ctx context.Context) (string, error)
+	GetOrderFn             func(ctx context.Context, businessID uint, orderID string) (*entities.ReturnOrder, error)
+	ReturnedQuantitiesFn   func(ctx context.Context, orderID string) (map[uint]int, error)
+	CreateFn               func(ctx context.Context, ret *entities.ReturnRequest) (*entities.ReturnRequest, error)
+	GetByIDFn              func(ctx context.Context, businessID uint, id uint) (*entities.ReturnRequest, error)
+	GetByIDForUpdateFn     func(ctx context.Context, businessID uint, id uint) (*entities.ReturnRequest, error)
+	ListFn                 func(ctx context.Context, params dtos.ListReturnsParams) ([]entities.ReturnRequest, int64, error)
+	UpdateStatusFn         func(ctx context.Context, id uint, from entities.ReturnStatus, updates map[string]any) error
+	UpdateItemInspectionFn func(ctx context.Context, itemID uint, result string, quantity int, note string) error
+	AddHistoryFn           func(ctx context.Context, history *entities.ReturnStatusHistory) error
+	ListHistoryFn          func(ctx context.Context, returnID uint) ([]entities.ReturnStatusHistory, error)
+
+	Return      *entities.ReturnRequest
+	Order       *entities.ReturnOrder
+	Created     *entities.ReturnRequest
+	Updates     []map[string]any
+	Inspections []InspectionCall
+	History     []entities.ReturnStatusHistory
+	Transaction int
+	// LockedOrders y LockedReturns cuentan los bloqueos tomados
+	LockedOrders  []string
+	LockedReturns int
+}
+
+var _ ports.IRepository = (*RepositoryMock)(nil)
+
+func (m *RepositoryMock) InTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	m.Transaction++
+	if m.InTransactionFn != nil {
+		return m.InTransactionFn(ctx, fn)
+	}
+	return fn(ctx)
+}
+
+func (m *RepositoryMock) NextCode(ctx context.Context) (string, error) {
+	if m.NextCodeFn != nil {
+		return m.NextCodeFn(ctx)
+	}
+	return "RMA-000001", nil
+}
+
+func (m *RepositoryMock) GetOrder(ctx context.Context, businessID uint, orderID string) (*entities.ReturnOrder, error) {
+	if m.GetOrderFn != nil {
+		return m.GetOrderFn(ctx, businessID, orderID)
+	}
+	if m.Order == nil || m.Order.ID != orderID || m.Order.BusinessID != businessID {
+		return nil, dom.ErrOrderNotFound
+	}
+	return m.Order, nil
+}
+
+func (m *RepositoryMock) LockOrder(ctx context.Context, orderID string) error {
+	m.LockedOrders = append(m.LockedOrders, orderID)
+	return nil
+}
+
+func (m *RepositoryMock) ReturnedQuantities(ctx context.Context, orderID string) (map[uint]int, error) {
+	if m.ReturnedQuantitiesFn != nil {
+		return m.ReturnedQuantitiesFn(ctx, orderID)
+	}
+	return map[uint]int{}, nil
+}
+
+func (m *RepositoryMock) Create(ctx context.Context, ret *entities.ReturnRequest) (*entities.ReturnRequest, error) {
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, ret)
+	}
+	ret.ID = 1
+	for i := range ret.Items {
+		ret.Items[i].ID = uint(i + 1)
+	}
+	m.Created = ret
+	return ret, nil
+}
+
+func (m *RepositoryMock) GetByID(ctx context.Context, businessID uint, id uint) (*entities.ReturnRequest, error) {
+	if m.GetByIDFn != nil {
+		return m.GetByIDFn(ctx, businessID, id)
+	}
+	if m.Return == nil || m.Return.ID != id || (businessID > 0 && m.Return.BusinessID != businessID) {
+		return nil, dom.ErrReturnNotFound
+	}
+	copied := *m.Return
+	copied.Items = append([]entities.ReturnItem(nil), m.Return.Items...)
+	return &copied, nil
+}
+
+func (m *RepositoryMock) GetByIDForUpdate(ctx context.Context, businessID uint, id uint) (*entities.ReturnRequest, error) {
+	m.LockedReturns++
+	if m.GetByIDForUpdateFn != nil {
+		return m.GetByIDForUpdateFn(ctx, businessID, id)
+	}
+	return m.GetByID(ctx, businessID, id)
+}
+
+func (m *RepositoryMock) List(ctx context.Context, params dtos.ListReturnsParams) ([]entities.ReturnRequest, int64, error) {
+	if m.ListFn != nil {
+		return m.ListFn(ctx, params)
+	}
+	return nil, 0, nil
+}
+
+func (m *RepositoryMock) UpdateStatus(ctx context.Context, id uint, from entities.ReturnStatus, updates map[string]any) error {
+	m.Updates = append(m.Updates, updates)
+	if m.UpdateStatusFn != nil {
+		return m.UpdateStatusFn(ctx, id, from, updates)
+	}
+	if m.Return != nil {
+		if m.Return.Status != from {
+			return dom.ErrInvalidTransition
+		}
+		if status, ok := updates["status"].(string); ok {
+			m.Return.Status = entities.ReturnStatus(status)
+		}
+		if amount, ok := updates["refund_amount"].(float64); ok {
+			m.Return.RefundAmount = amount
+		}
+	}
+	return nil
+}
+
+func (m *RepositoryMock) UpdateItemInspection(ctx context.Context, itemID uint, result string, quantity int, note string) error {
+	m.Inspections = append(m.Inspections, InspectionCall{ItemID: itemID, Result: result, Quantity: quantity, Note: note})
+	if m.UpdateItemInspectionFn != nil {
+		return m.UpdateItemInspectionFn(ctx, itemID, result, quantity, note)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) AddHistory(ctx context.Context, history *entities.ReturnStatusHistory) error {
+	m.History = append(m.History, *history)
+	if m.AddHistoryFn != nil {
+		return m.AddHistoryFn(ctx, history)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) ListHistory(ctx context.Context, returnID uint) ([]entities.ReturnStatusHistory, error) {
+	if m.ListHistoryFn != nil {
+		return m.ListHistoryFn(ctx, returnID)
+	}
+	return m.History, nil
+}
+
+type EventPublisherMock struct {
+	PublishFn func(ctx context.Context, event entities.ReturnEvent) error
+	Events    []entities.ReturnEvent
+}
+
+var _ ports.IEventPublisher = (*EventPublisherMock)(nil)
+
+func (m *EventPublisherMock) PublishReturnEvent(ctx context.Context, event entities.ReturnEvent) error {
+	if m.PublishFn != nil {
+		if err := m.PublishFn(ctx, event); err != nil {
+			return err
+		}
+	}
+	m.Events = append(m.Events, event)
+	return nil
+}
+
+type InventoryGatewayMock struct {
+	ReceiveReturnFn func(ctx context.Context, receipt inventory.ReturnReceipt) error
+	Receipts        []inventory.ReturnReceipt
+}
+
+var _ ports.IInventoryGateway = (*InventoryGatewayMock)(nil)
+
+func (m *InventoryGatewayMock) ReceiveReturn(ctx context.Context, receipt inventory.ReturnReceipt) error {
+	m.Receipts = append(m.Receipts, receipt)
+	if m.ReceiveReturnFn != nil {
+		return m.ReceiveReturnFn(ctx, receipt)
+	}
+	return nil
+}
+
+type WalletDebit struct {
+	BusinessID uint
+	Amount     float64
+	Reference  string
+	Concept    string
+	UserID     uint
+}
+
+type WalletGatewayMock struct {
+	DebitFn func(ctx context.Context, businessID uint, amount float64, reference, concept string, userID uint) error
+	Debits  []WalletDebit
+}
+
+var _ ports.IWalletGateway = (*WalletGatewayMock)(nil)
+
+func (m *WalletGatewayMock) Debit(ctx context.Context, businessID uint, amount float64, reference, concept string, userID uint) error {
+	m.Debits = append(m.Debits, WalletDebit{BusinessID: businessID, Amount: amount, Reference: reference, Concept: concept, UserID: userID})
+	if m.DebitFn != nil {
+		return m.DebitFn(ctx, businessID, amount, reference, concept, userID)
+	}
+	return nil
+}
+
+type CreditNoteGatewayMock struct {
+	CreateFn func(ctx context.Context, req invoicing.OrderCreditNoteRequest) (*invoicing.OrderCreditNote, error)
+	Requests []invoicing.OrderCreditNoteRequest
+}
+
+var _ ports.ICreditNoteGateway = (*CreditNoteGatewayMock)(nil)
+
+func (m *CreditNoteGatewayMock) CreateCreditNoteForOrder(ctx context.Context, req invoicing.OrderCreditNoteRequest) (*invoicing.OrderCreditNote, error) {
+	m.Requests = append(m.Requests, req)
+	if m.CreateFn != nil {
+		return m.CreateFn(ctx, req)
+	}
+	return &invoicing.OrderCreditNote{ID: 7, InternalNumber: "NC-0007", Amount: req.Amount, Status: "pending"}, nil
+}
+
+type LabelGatewayMock struct {
+	GenerateFn func(ctx context.Context, req shipments.ReturnLabelRequest) (*shipments.ReturnLabel, error)
+	GetFn      func(ctx context.Context, shipmentID uint) (*shipments.ReturnLabel, error)
+	Requests   []shipments.ReturnLabelRequest
+}
+
+var _ ports.ILabelGateway = (*LabelGatewayMock)(nil)
+
+func (m *LabelGatewayMock) GenerateReturnLabel(ctx context.Context, req shipments.ReturnLabelRequest) (*shipments.ReturnLabel, error) {
+	m.Requests = append(m.Requests, req)
+	if m.GenerateFn != nil {
+		return m.GenerateFn(ctx, req)
+	}
+	return &shipments.ReturnLabel{ShipmentID: 99, CorrelationID: "corr-1", Status: "pending"}, nil
+}
+
+func (m *LabelGatewayMock) GetReturnLabel(ctx context.Context, shipmentID uint) (*shipments.ReturnLabel, error) {
+	if m.GetFn != nil {
+		return m.GetFn(ctx, shipmentID)
+	}
+	return &shipments.ReturnLabel{ShipmentID: shipmentID, Status: "pending"}, nil
+}
+
+type SilentLogger struct{}
+
+func NewSilentLogger() log.ILogger { return &SilentLogger{} }
+
+func (l *SilentLogger) nop() zerolog.Logger { return zerolog.Nop() }
+
+func (l *SilentLogger) Info(ctx ...context.Context) *zerolog.Event  { n := l.nop(); return n.Info() }
+func (l *SilentLogger) Error(ctx ...context.Context) *zerolog.Event { n := l.nop(); return n.Error() }
+func (l *SilentLogger) Warn(ctx ...context.Context) *zerolog.Event  { n := l.nop(); return n.Warn() }
+func (l *SilentLogger) Debug(ctx ...context.Context) *zerolog.Event { n := l.nop(); return n.Debug() }
+func (l *SilentLogger) Fatal(ctx ...context.Context) *zerolog.Event { n := l.nop(); return n.Fatal() }
+func (l *SilentLogger) Panic(ctx ...context.Context) *zerolog.Event { n := l.nop(); return n.Panic() }
+func (l *SilentLogger) With() zerolog.Context                       { n := l.nop(); return n.With() }
+func (l *SilentLogger) WithService(service string) log.ILogger      { return l }
+func (l *SilentLogger) WithModule(module string) log.ILogger        { return l }
+func (l *SilentLogger) WithBusinessID(businessID uint) log.ILogger  { return l }
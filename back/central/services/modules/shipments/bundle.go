@@ -4,7 +4,9 @@ import (
 	"context"
 
 	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/modules/pay"
 	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/app/usecases"
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/app/usecasetracking"
 	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
 	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/infra/primary/handlers"
 	queueconsumer "github.com/secamc93/probability/back/central/services/modules/shipments/internal/infra/primary/queue/consumer"
@@ -24,16 +26,21 @@ import (
 )
 
 type Bundle struct {
-	handlers *handlers.Handlers
+	handlers     *handlers.Handlers
+	uc           *usecases.UseCases
+	repo         domain.IRepository
+	transportPub domain.ITransportRequestPublisher
 }
 
 func (b *Bundle) SetSubscriptionOverageChecker(checker domain.ShipmentOverageChecker) {
 	b.handlers.SetOverageChecker(checker)
 }
 
-// New inicializa el módulo de shipments
-func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, environment env.IConfig, rabbitMQ rabbitmq.IQueue, redisClient redis.IRedis, s3 storage.IS3Service) *Bundle {
+// New inicializa el módulo de shipments. Las retenciones y cobros de guias se
+// hacen con la billetera de payBundle
+func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, environment env.IConfig, rabbitMQ rabbitmq.IQueue, redisClient redis.IRedis, s3 storage.IS3Service, payBundle *pay.Bundle) *Bundle {
 	repo := repository.New(database)
+	wallet := guideWallet{pay: payBundle}
 	pdfUploader := pdfstorage.New(s3)
 
 	transportPub := queue.NewTransportRequestPublisher(rabbitMQ, logger)
@@ -48,10 +55,12 @@ func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, env
 	marginReader := cache.NewShippingMarginReader(redisClient, database, logger)
 
 	uc := usecases.New(repo, marginReader, pdfUploader)
+	tracking := usecasetracking.New(repo, repository.NewTracking(database))
+	uc.SetTracking(tracking)
 
 	// 5. Transport Response Consumer
 	if rabbitMQ != nil {
-		responseConsumer := queueconsumer.NewResponseConsumer(rabbitMQ, repo, logger, ssePublisher, redisClient, marginReader)
+		responseConsumer := queueconsumer.NewResponseConsumer(rabbitMQ, repo, wallet, logger, ssePublisher, redisClient, marginReader, tracking)
 		go func() {
 			ctx := context.Background()
 			logger.Info(ctx).Msg("🚀 Starting transport response consumer in background...")
@@ -70,7 +79,7 @@ func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, env
 		}()
 	}
 
-	reconciliationWorker := queueconsumer.NewWalletReconciliationWorker(repo, logger)
+	reconciliationWorker := queueconsumer.NewWalletReconciliationWorker(repo, wallet, logger)
 	go reconciliationWorker.Start(context.Background())
 
 	syncStatusWorker := worker.NewSyncStatusWorker(repo, transportPub, logger)
@@ -86,10 +95,10 @@ func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, env
 		RedisPrefix: "shiprates",
 	}, redisClient, logger)
 	geo := geocoder.New(environment.Get("GOOGLE_MAPS_API_KEY"))
-	h := handlers.New(uc, transportPub, repo, redisClient, tokenSecret, pluginBaseURL, ratesLimiter, geo)
+	h := handlers.New(uc, transportPub, wallet, repo, redisClient, tokenSecret, pluginBaseURL, ratesLimiter, geo)
 
 	// 7. Register Routes
 	h.RegisterRoutes(router)
 
-	return &Bundle{handlers: h}
+	return &Bundle{handlers: h, uc: uc, repo: repo, transportPub: transportPub}
 }
@@ -0,0 +1,146 @@
+package shipments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
+)
+
+// ReturnLabelRequest pide la guia de una devolucion. Payload tiene el mismo
+// formato que POST /shipments/generate, con origin en la direccion del cliente
+// y destination en la bodega que recibe.
+type ReturnLabelRequest struct {
+	BusinessID uint
+	ReturnID   uint
+	ReturnCode string
+	OrderID    string
+	Payload    map[string]interface{}
+	UserID     *uint
+	UserName   string
+}
+
+// ReturnLabel es el envio de una devolucion. TrackingNumber y GuideURL llegan
+// cuando la transportadora responde.
+type ReturnLabel struct {
+	ShipmentID     uint
+	CorrelationID  string
+	TrackingNumber string
+	GuideURL       string
+	Status         string
+}
+
+// GenerateReturnLabel crea el envio de la devolucion y pide la guia a la
+// transportadora activa del negocio por el router de transporte. El envio no se
+// liga a la orden (OrderID nil) para que su estado no mueva el de la orden; la
+// devolucion y la orden quedan en la metadata.
+func (b *Bundle) GenerateReturnLabel(ctx context.Context, req ReturnLabelRequest) (*ReturnLabel, error) {
+	for _, key := range []string{"origin", "destination", "packages"} {
+		if _, ok := req.Payload[key]; !ok {
+			return nil, fmt.Errorf("%s es requerido", key)
+		}
+	}
+
+	carrier, err := b.repo.GetActiveShippingCarrier(ctx, req.BusinessID)
+	if err != nil {
+		return nil, fmt.Errorf("error al resolver transportadora: %w", err)
+	}
+	if carrier == nil {
+		return nil, fmt.Errorf("el negocio no tiene una transportadora activa configurada")
+	}
+
+	payload := make(map[string]interface{}, len(req.Payload))
+	for k, v := range req.Payload {
+		payload[k] = v
+	}
+	// La guia de devolucion no se cobra contra entrega ni se asocia a la orden
+	delete(payload, "order_uuid")
+	delete(payload, "codValue")
+
+	metadata, err := json.Marshal(map[string]interface{}{
+		"kind":        "return",
+		"return_id":   req.ReturnID,
+		"return_code": req.ReturnCode,
+		"order_id":    req.OrderID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	shipmentReq := &domain.CreateShipmentRequest{
+		Status:        "pending",
+		CarrierCode:   &carrier.ProviderCode,
+		Metadata:      metadata,
+		CreatedBy:     req.UserID,
+		CreatedByName: req.UserName,
+	}
+	if dest, ok := payload["destination"].(map[string]interface{}); ok {
+		firstName, _ := dest["firstName"].(string)
+		lastName, _ := dest["lastName"].(string)
+		shipmentReq.ClientName = strings.TrimSpace(firstName + " " + lastName)
+		shipmentReq.DestinationAddress, _ = dest["address"].(string)
+		shipmentReq.DestinationCity, _ = dest["city"].(string)
+		shipmentReq.DestinationState, _ = dest["state"].(string)
+	}
+	if shipmentReq.ClientName == "" {
+		shipmentReq.ClientName = req.ReturnCode
+	}
+
+	shipment, err := b.uc.CreateShipment(ctx, shipmentReq)
+	if err != nil {
+		return nil, fmt.Errorf("error al crear registro de envio: %w", err)
+	}
+
+	baseURL := carrier.BaseURL
+	if carrier.IsTesting && carrier.BaseURLTest != "" {
+		baseURL = carrier.BaseURLTest
+	}
+
+	shipmentID := shipment.ID
+	correlationID := uuid.New().String()
+	msg := &domain.TransportRequestMessage{
+		ShipmentID:        &shipmentID,
+		Provider:          carrier.ProviderCode,
+		IntegrationTypeID: carrier.IntegrationTypeID,
+		Operation:         "generate",
+		CorrelationID:     correlationID,
+		BusinessID:        req.BusinessID,
+		IntegrationID:     carrier.IntegrationID,
+		BaseURL:           baseURL,
+		IsTest:            carrier.IsTesting,
+		Timestamp:         time.Now(),
+		Payload:           payload,
+		UserID:            req.UserID,
+		TriggeredBy:       "user",
+	}
+	if err := b.transportPub.PublishTransportRequest(ctx, msg); err != nil {
+		return nil, fmt.Errorf("error al enviar solicitud de guia de devolucion: %w", err)
+	}
+
+	return &ReturnLabel{
+		ShipmentID:    shipmentID,
+		CorrelationID: correlationID,
+		Status:        shipment.Status,
+	}, nil
+}
+
+// GetReturnLabel retorna el estado actual del envio de una devolucion
+func (b *Bundle) GetReturnLabel(ctx context.Context, shipmentID uint) (*ReturnLabel, error) {
+	shipment, err := b.repo.GetShipmentByID(ctx, shipmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	label := &ReturnLabel{ShipmentID: shipment.ID, Status: shipment.Status}
+	if shipment.TrackingNumber != nil {
+		label.TrackingNumber = *shipment.TrackingNumber
+	}
+	if shipment.GuideURL != nil {
+		label.GuideURL = *shipment.GuideURL
+	}
+	return label, nil
+}
@@ -47,6 +47,7 @@ type PendingShipmentDTO struct {
 	OrderCreatedAt     *time.Time `json:"order_created_at,omitempty"`
 	ShipmentStatus     string     `json:"shipment_status"`
 	OrderStatus        string     `json:"order_status"`
+	PackageQuantity    int64      `json:"package_quantity"`
 }
 
 type PendingPage struct {
@@ -121,6 +122,7 @@ func (uc *UseCaseManifest) ListPending(ctx context.Context, f PendingFilter) (*P
 			OrderCreatedAt:     r.OrderCreatedAt,
 			ShipmentStatus:     r.ShipmentStatus,
 			OrderStatus:        r.OrderStatus,
+			PackageQuantity:    r.PackageQuantity,
 		})
 	}
 
@@ -184,6 +186,18 @@ func (uc *UseCaseManifest) GeneratePDF(ctx context.Context, in GeneratePDFInput)
 		return nil, fmt.Errorf("ninguno de los envios seleccionados esta pendiente")
 	}
 
+	selectedIDs := make([]uint, 0, len(selected))
+	for _, r := range selected {
+		selectedIDs = append(selectedIDs, r.ShipmentID)
+	}
+	packages, err := uc.repo.ListShipmentPackages(ctx, selectedIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range selected {
+		selected[i].Packages = packages[selected[i].ShipmentID]
+	}
+
 	byCarrier := map[string][]domain.ManifestShipmentRow{}
 	carrierOrder := []string{}
 	for _, r := range selected {
@@ -305,3 +305,54 @@ func TestGetCarrierLogoPNG_CarrierDesconocido_RetornaNil(t *testing.T) {
 	assert.Nil(t, getCarrierLogoPNG("inventada"))
 	assert.Nil(t, getCarrierLogoPNG(""))
 }
+
+func TestManifestLines_EnvioMultiBultoListaSusPaquetes(t *testing.T) {
+	w1, w2 := 4.5, 2.0
+	tn := "GUIA-1-2"
+	rows := []domain.ManifestShipmentRow{
+		{ShipmentID: 1, TrackingNumber: "GUIA-1", Packages: []domain.ShipmentPackage{
+			{Sequence: 1, Weight: &w1},
+			{Sequence: 2, Weight: &w2, TrackingNumber: &tn},
+		}},
+		{ShipmentID: 2, TrackingNumber: "GUIA-2", PackageQuantity: 1},
+	}
+
+	lines := manifestLines(rows)
+
+	require.Len(t, lines, 4)
+	assert.Nil(t, lines[0].Package)
+	assert.Equal(t, 1, lines[1].Num)
+	assert.Equal(t, 1, lines[1].Package.Sequence)
+	assert.Equal(t, 2, lines[2].Packages)
+	assert.Equal(t, "GUIA-1-2", *lines[2].Package.TrackingNumber)
+	assert.Equal(t, 2, lines[3].Num)
+	assert.Nil(t, lines[3].Package)
+}
+
+func TestBultosCount(t *testing.T) {
+	assert.Equal(t, 1, domain.ManifestShipmentRow{}.BultosCount())
+	assert.Equal(t, 3, domain.ManifestShipmentRow{PackageQuantity: 3}.BultosCount())
+	assert.Equal(t, 2, domain.ManifestShipmentRow{PackageQuantity: 1, Packages: make([]domain.ShipmentPackage, 2)}.BultosCount())
+}
+
+func TestPackageSummary(t *testing.T) {
+	w, l, wd, h := 3.25, 40.0, 30.0, 20.0
+	pkg := &domain.ShipmentPackage{
+		Weight: &w, Length: &l, Width: &wd, Height: &h,
+		Contents: []domain.PackageContent{{SKU: "CAM-01", Quantity: 2}, {Name: "Gorra", Quantity: 1}},
+	}
+
+	assert.Equal(t, "3.25 kg | 40x30x20 cm | 2x CAM-01, 1x Gorra", packageSummary(pkg))
+}
+
+func TestBuildManifestPDF_ConPaquetes(t *testing.T) {
+	w := 1.0
+	rows := []domain.ManifestShipmentRow{
+		{ShipmentID: 1, TrackingNumber: "GUIA-1", Packages: []domain.ShipmentPackage{{Sequence: 1, Weight: &w}, {Sequence: 2, Weight: &w}}},
+	}
+
+	pdf, err := buildManifestPDF(domain.ManifestPDFInput{Carrier: "Servientrega", Rows: rows})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, pdf)
+}
@@ -3,6 +3,7 @@ package usecasemanifest
 import (
 	"bytes"
 	"fmt"
+	"strings"
 
 	"github.com/jung-kurt/gofpdf"
 
@@ -27,15 +28,16 @@ func buildManifestPDF(in domain.ManifestPDFInput) ([]byte, error) {
 	tr := pdf.UnicodeTranslatorFromDescriptor("")
 
 	rowsPerPage := 28
-	total := len(in.Rows)
+	lines := manifestLines(in.Rows)
+	total := len(lines)
 	pages := (total + rowsPerPage - 1) / rowsPerPage
 	if pages == 0 {
 		pages = 1
 	}
 
 	totalBultos := 0
-	for range in.Rows {
-		totalBultos++
+	for _, r := range in.Rows {
+		totalBultos += r.BultosCount()
 	}
 
 	for p := 0; p < pages; p++ {
@@ -47,9 +49,9 @@ func buildManifestPDF(in domain.ManifestPDFInput) ([]byte, error) {
 		if end > total {
 			end = total
 		}
-		drawTable(pdf, tr, in.Rows[start:end], start+1)
+		drawTable(pdf, tr, lines[start:end])
 		if p == pages-1 {
-			drawSignatures(pdf, tr, total, totalBultos)
+			drawSignatures(pdf, tr, len(in.Rows), totalBultos)
 		}
 	}
 
@@ -145,7 +147,31 @@ func drawInfoBox(pdf *gofpdf.Fpdf, tr func(string) string, in domain.ManifestPDF
 	}
 }
 
-func drawTable(pdf *gofpdf.Fpdf, tr func(string) string, rows []domain.ManifestShipmentRow, startNum int) {
+// manifestLine renglón de la tabla: un envío o, en envíos multi-bulto, uno de
+// sus paquetes debajo del envío
+type manifestLine struct {
+	Num      int
+	Row      *domain.ManifestShipmentRow
+	Package  *domain.ShipmentPackage
+	Packages int
+}
+
+func manifestLines(rows []domain.ManifestShipmentRow) []manifestLine {
+	lines := make([]manifestLine, 0, len(rows))
+	for i := range rows {
+		r := &rows[i]
+		lines = append(lines, manifestLine{Num: i + 1, Row: r})
+		if len(r.Packages) < 2 {
+			continue
+		}
+		for j := range r.Packages {
+			lines = append(lines, manifestLine{Num: i + 1, Row: r, Package: &r.Packages[j], Packages: len(r.Packages)})
+		}
+	}
+	return lines
+}
+
+func drawTable(pdf *gofpdf.Fpdf, tr func(string) string, lines []manifestLine) {
 	y := mTop + 42
 	pdf.SetY(y)
 	pdf.SetX(mLeft)
@@ -172,13 +198,29 @@ func drawTable(pdf *gofpdf.Fpdf, tr func(string) string, rows []domain.ManifestS
 	}
 	pdf.Ln(6)
 
-	pdf.SetFont("Helvetica", "", 7.5)
-	for i, r := range rows {
-		num := fmt.Sprintf("%d", startNum+i)
-		pdf.CellFormat(cols[0].W, 5, num, "1", 0, "C", false, 0, "")
+	for _, l := range lines {
+		r := l.Row
+		if l.Package != nil {
+			pkg := l.Package
+			tracking := ""
+			if pkg.TrackingNumber != nil {
+				tracking = *pkg.TrackingNumber
+			}
+			pdf.SetFont("Helvetica", "I", 6.5)
+			pdf.CellFormat(cols[0].W, 4, "", "LR", 0, "C", false, 0, "")
+			pdf.CellFormat(cols[1].W, 4, "", "LR", 0, "C", false, 0, "")
+			pdf.CellFormat(cols[2].W, 4, tr(truncate(tracking, 18)), "1", 0, "L", false, 0, "")
+			pdf.CellFormat(cols[3].W, 4, fmt.Sprintf("%d/%d", pkg.Sequence, l.Packages), "1", 0, "C", false, 0, "")
+			pdf.CellFormat(cols[4].W+cols[5].W+cols[6].W, 4, tr(truncate(packageSummary(pkg), 95)), "1", 0, "L", false, 0, "")
+			pdf.Ln(4)
+			continue
+		}
+
+		pdf.SetFont("Helvetica", "", 7.5)
+		pdf.CellFormat(cols[0].W, 5, fmt.Sprintf("%d", l.Num), "1", 0, "C", false, 0, "")
 		pdf.CellFormat(cols[1].W, 5, tr(truncate(r.CarrierCode, 6)), "1", 0, "C", false, 0, "")
 		pdf.CellFormat(cols[2].W, 5, tr(truncate(r.TrackingNumber, 16)), "1", 0, "L", false, 0, "")
-		pdf.CellFormat(cols[3].W, 5, "1", "1", 0, "C", false, 0, "")
+		pdf.CellFormat(cols[3].W, 5, fmt.Sprintf("%d", r.BultosCount()), "1", 0, "C", false, 0, "")
 		pdf.CellFormat(cols[4].W, 5, tr(truncate(r.CustomerDocument, 14)), "1", 0, "L", false, 0, "")
 		pdf.CellFormat(cols[5].W, 5, tr(truncate(r.CustomerName, 40)), "1", 0, "L", false, 0, "")
 		pdf.CellFormat(cols[6].W, 5, tr(truncate(r.DestinationCity, 28)), "1", 0, "L", false, 0, "")
@@ -186,6 +228,29 @@ func drawTable(pdf *gofpdf.Fpdf, tr func(string) string, rows []domain.ManifestS
 	}
 }
 
+// packageSummary peso, medidas y contenido de un paquete en una línea
+func packageSummary(pkg *domain.ShipmentPackage) string {
+	parts := make([]string, 0, 3)
+	if pkg.Weight != nil {
+		parts = append(parts, fmt.Sprintf("%.2f kg", *pkg.Weight))
+	}
+	if pkg.Length != nil && pkg.Width != nil && pkg.Height != nil {
+		parts = append(parts, fmt.Sprintf("%.0fx%.0fx%.0f cm", *pkg.Length, *pkg.Width, *pkg.Height))
+	}
+	if len(pkg.Contents) > 0 {
+		items := make([]string, 0, len(pkg.Contents))
+		for _, c := range pkg.Contents {
+			name := c.SKU
+			if name == "" {
+				name = c.Name
+			}
+			items = append(items, fmt.Sprintf("%dx %s", c.Quantity, name))
+		}
+		parts = append(parts, strings.Join(items, ", "))
+	}
+	return strings.Join(parts, " | ")
+}
+
 func drawSignatures(pdf *gofpdf.Fpdf, tr func(string) string, totalEnvios, totalBultos int) {
 	y := pageH - 50
 	w := (pageW - 2*mLeft) / 3
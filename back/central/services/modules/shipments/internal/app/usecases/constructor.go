@@ -7,6 +7,7 @@ import (
 	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/app/usecaseoriginaddress"
 	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/app/usecasequotes"
 	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/app/usecaseshipment"
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/app/usecasetracking"
 	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
 )
 
@@ -19,6 +20,7 @@ type UseCases struct {
 	OriginAddress *usecaseoriginaddress.OriginAddressUseCase
 	Manifest      *usecasemanifest.UseCaseManifest
 	Quotes        *usecasequotes.UseCaseQuotes
+	Tracking      *usecasetracking.UseCaseTracking
 }
 
 func New(repo domain.IRepository, marginReader domain.IShippingMarginReader, pdfUploader domain.IPDFUploader) *UseCases {
@@ -31,6 +33,11 @@ func New(repo domain.IRepository, marginReader domain.IShippingMarginReader, pdf
 	}
 }
 
+// SetTracking conecta el historial normalizado de tracking (timeline y métricas)
+func (uc *UseCases) SetTracking(tracking *usecasetracking.UseCaseTracking) {
+	uc.Tracking = tracking
+}
+
 // Repo exposes the repository for direct queries (e.g. business_id resolution in handlers)
 func (uc *UseCases) Repo() domain.IRepository {
 	return uc.repo
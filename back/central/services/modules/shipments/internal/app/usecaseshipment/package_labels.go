@@ -0,0 +1,75 @@
+package usecaseshipment
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
+)
+
+// buildPackageLabels en envíos multi-bulto genera una etiqueta por paquete, cada
+// una con su peso, medidas, contenido y la guía propia si la transportadora la
+// asignó. Con un solo paquete es la etiqueta de siempre.
+func buildPackageLabels(c *domain.GuidePDFContext, format *domain.GuideFormat) ([]byte, error) {
+	if len(c.Packages) < 2 {
+		return buildProbabilityLabel(c, format)
+	}
+
+	labels := make([]io.ReadSeeker, 0, len(c.Packages))
+	for i := range c.Packages {
+		label, err := buildProbabilityLabel(packageLabelContext(c, i), format)
+		if err != nil {
+			return nil, fmt.Errorf("paquete %d: %w", i+1, err)
+		}
+		labels = append(labels, bytes.NewReader(label))
+	}
+
+	var out bytes.Buffer
+	if err := api.MergeRaw(labels, &out, false, model.NewDefaultConfiguration()); err != nil {
+		return nil, fmt.Errorf("merge package labels: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+func packageLabelContext(c *domain.GuidePDFContext, i int) *domain.GuidePDFContext {
+	pkg := c.Packages[i]
+	pc := *c
+	pc.Unidad = fmt.Sprintf("%d/%d", i+1, len(c.Packages))
+	pc.Weight = floatOrZero(pkg.Weight)
+	pc.Height = floatOrZero(pkg.Height)
+	pc.Width = floatOrZero(pkg.Width)
+	pc.Length = floatOrZero(pkg.Length)
+	if pkg.DeclaredValue != nil {
+		pc.DeclaredValue = *pkg.DeclaredValue
+	}
+	if pkg.TrackingNumber != nil && *pkg.TrackingNumber != "" {
+		pc.TrackingNumber = *pkg.TrackingNumber
+	}
+	if len(pkg.Contents) > 0 {
+		pc.OrderItems = make([]domain.OrderItemContext, 0, len(pkg.Contents))
+		for _, item := range pkg.Contents {
+			pc.OrderItems = append(pc.OrderItems, domain.OrderItemContext{
+				SKU:         item.SKU,
+				ProductName: item.Name,
+				Quantity:    item.Quantity,
+			})
+		}
+	}
+	// El contra entrega se cobra una sola vez: lo rotula el primer bulto
+	if i > 0 {
+		pc.CodTotal = 0
+		pc.CodCarrierFee = 0
+	}
+	return &pc
+}
+
+func floatOrZero(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
@@ -0,0 +1,57 @@
+package usecaseshipment
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageLabelContext_UsaDatosDelBulto(t *testing.T) {
+	c := &domain.GuidePDFContext{
+		TrackingNumber: "MAESTRA",
+		Weight:         9,
+		CodTotal:       120000,
+		OrderItems:     []domain.OrderItemContext{{SKU: "TODO", Quantity: 5}},
+		Packages: []domain.ShipmentPackage{
+			{Sequence: 1, Weight: f64(4), Length: f64(40), Width: f64(30), Height: f64(20)},
+			{Sequence: 2, Weight: f64(5), TrackingNumber: s("HIJA-2"), Contents: []domain.PackageContent{{SKU: "CAM-01", Quantity: 2}}},
+		},
+	}
+
+	first := packageLabelContext(c, 0)
+	second := packageLabelContext(c, 1)
+
+	assert.Equal(t, "1/2", first.Unidad)
+	assert.Equal(t, "MAESTRA", first.TrackingNumber)
+	assert.InDelta(t, 4.0, first.Weight, 0.001)
+	assert.InDelta(t, 120000.0, first.CodTotal, 0.001)
+	assert.Equal(t, "TODO", first.OrderItems[0].SKU)
+
+	assert.Equal(t, "2/2", second.Unidad)
+	assert.Equal(t, "HIJA-2", second.TrackingNumber)
+	assert.Zero(t, second.CodTotal)
+	require.Len(t, second.OrderItems, 1)
+	assert.Equal(t, "CAM-01", second.OrderItems[0].SKU)
+	assert.Equal(t, "MAESTRA", c.TrackingNumber, "el contexto original no se modifica")
+}
+
+func TestBuildPackageLabels_UnaPaginaPorBulto(t *testing.T) {
+	c := &domain.GuidePDFContext{
+		TrackingNumber: "MAESTRA",
+		Carrier:        "SERVIENTREGA",
+		Packages:       []domain.ShipmentPackage{{Sequence: 1, Weight: f64(1)}, {Sequence: 2, Weight: f64(2)}, {Sequence: 3, Weight: f64(3)}},
+	}
+
+	pdf, err := buildPackageLabels(c, &domain.GuideFormat{WidthCm: 10, HeightCm: 15})
+
+	require.NoError(t, err)
+	pages, err := api.PageCount(bytes.NewReader(pdf), model.NewDefaultConfiguration())
+	require.NoError(t, err)
+	assert.Equal(t, 3, pages)
+}
@@ -751,7 +751,11 @@ func drawColumnPaquete(pdf *gofpdf.Fpdf, tr func(string) string, c *domain.Guide
 	pdf.SetXY(x+margin, currentY)
 	pdf.SetFont("Helvetica", "B", 7*scale)
 	pdf.SetTextColor(int(headerCol.R), int(headerCol.G), int(headerCol.B))
-	pdf.CellFormat(w-margin*2, 2*scale, "PAQUETE", "", 1, "L", false, 0, "")
+	header := "PAQUETE"
+	if len(c.Packages) > 1 && c.Unidad != "" {
+		header = "PAQUETE " + c.Unidad
+	}
+	pdf.CellFormat(w-margin*2, 2*scale, header, "", 1, "L", false, 0, "")
 	currentY += 3.5
 
 	pdf.SetFont("Helvetica", "", 4.8*scale)
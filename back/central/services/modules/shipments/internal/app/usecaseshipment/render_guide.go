@@ -83,7 +83,7 @@ func (uc *UseCaseShipment) RenderGuide(ctx context.Context, shipmentID uint, for
 			}
 		}
 
-		pdfBytes, err := buildProbabilityLabel(pdfCtx, format)
+		pdfBytes, err := buildPackageLabels(pdfCtx, format)
 		if err != nil {
 			return nil, fmt.Errorf("build probability label: %w", err)
 		}
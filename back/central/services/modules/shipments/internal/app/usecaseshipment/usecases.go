@@ -86,12 +86,24 @@ func (uc *UseCaseShipment) CreateShipment(ctx context.Context, req *domain.Creat
 		UpdatedByName: req.CreatedByName,
 	}
 
+	packages := domain.NormalizePackages(req.Packages)
+	if len(packages) > 0 {
+		shipment.Weight, shipment.Height, shipment.Width, shipment.Length = domain.ConsolidatePackages(packages)
+	}
+
 	uc.applyCarrierCost(ctx, shipment)
 
 	if err := uc.repo.CreateShipment(ctx, shipment); err != nil {
 		return nil, fmt.Errorf("error creating shipment: %w", err)
 	}
 
+	if len(packages) > 0 {
+		if err := uc.repo.ReplaceShipmentPackages(ctx, shipment.ID, packages); err != nil {
+			return nil, fmt.Errorf("error saving shipment packages: %w", err)
+		}
+		shipment.Packages = packages
+	}
+
 	uc.resolveGeozoneBestEffort(ctx, shipment)
 
 	return mapShipmentToResponse(shipment), nil
@@ -127,6 +139,12 @@ func (uc *UseCaseShipment) GetShipmentByID(ctx context.Context, id uint) (*domai
 		return nil, domain.ErrShipmentNotFound
 	}
 
+	packages, err := uc.repo.ListShipmentPackages(ctx, []uint{shipment.ID})
+	if err != nil {
+		return nil, fmt.Errorf("error getting shipment packages: %w", err)
+	}
+	shipment.Packages = packages[shipment.ID]
+
 	return mapShipmentToResponse(shipment), nil
 }
 
@@ -265,6 +283,14 @@ func (uc *UseCaseShipment) UpdateShipment(ctx context.Context, id uint, req *dom
 	if req.Length != nil {
 		shipment.Length = req.Length
 	}
+	// Packages nil deja los bultos como están; un arreglo vacío los elimina
+	var packages []domain.ShipmentPackage
+	if req.Packages != nil {
+		packages = domain.NormalizePackages(req.Packages)
+		if len(packages) > 0 {
+			shipment.Weight, shipment.Height, shipment.Width, shipment.Length = domain.ConsolidatePackages(packages)
+		}
+	}
 	if req.WarehouseID != nil {
 		shipment.WarehouseID = req.WarehouseID
 	}
@@ -300,6 +326,13 @@ func (uc *UseCaseShipment) UpdateShipment(ctx context.Context, id uint, req *dom
 		return nil, fmt.Errorf("error updating shipment: %w", err)
 	}
 
+	if req.Packages != nil {
+		if err := uc.repo.ReplaceShipmentPackages(ctx, shipment.ID, packages); err != nil {
+			return nil, fmt.Errorf("error saving shipment packages: %w", err)
+		}
+		shipment.Packages = packages
+	}
+
 	return mapShipmentToResponse(shipment), nil
 }
 
@@ -410,6 +443,7 @@ func mapShipmentToResponse(shipment *domain.Shipment) *domain.ShipmentResponse {
 		Height:              shipment.Height,
 		Width:               shipment.Width,
 		Length:              shipment.Length,
+		Packages:            shipment.Packages,
 		WarehouseID:         shipment.WarehouseID,
 		WarehouseName:       shipment.WarehouseName,
 		DriverID:            shipment.DriverID,
@@ -355,3 +355,95 @@ func TestResolveGeozoneBestEffort_ResuelveConDatosCompletos(t *testing.T) {
 	assert.Equal(t, uint(55), resueltoShipment)
 	assert.Equal(t, uint(36), resueltoBusiness)
 }
+
+func TestCreateShipment_ConPaquetes_GuardaBultosYConsolida(t *testing.T) {
+	var created *domain.Shipment
+	var savedPkgs []domain.ShipmentPackage
+	repo := &mocks.RepositoryMock{
+		CreateShipmentFn: func(ctx context.Context, shipment *domain.Shipment) error {
+			shipment.ID = 55
+			created = shipment
+			return nil
+		},
+		ReplaceShipmentPackagesFn: func(ctx context.Context, shipmentID uint, pkgs []domain.ShipmentPackage) error {
+			require.Equal(t, uint(55), shipmentID)
+			savedPkgs = pkgs
+			return nil
+		},
+	}
+	uc := newUseCaseForCost(repo, nil)
+
+	resp, err := uc.CreateShipment(context.Background(), &domain.CreateShipmentRequest{
+		ClientName:         "Ana",
+		DestinationAddress: "Calle 1",
+		Packages: []domain.ShipmentPackage{
+			{Weight: f64(2), Height: f64(10), Width: f64(30), Length: f64(40)},
+			{},
+			{Weight: f64(3), Height: f64(25), Width: f64(20), Length: f64(20), TrackingNumber: s("hijo")},
+		},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, savedPkgs, 2)
+	assert.Equal(t, 1, savedPkgs[0].Sequence)
+	assert.Equal(t, 2, savedPkgs[1].Sequence)
+	assert.InDelta(t, 5.0, *created.Weight, 0.001)
+	assert.InDelta(t, 25.0, *created.Height, 0.001)
+	assert.InDelta(t, 40.0, *created.Length, 0.001)
+	assert.Len(t, resp.Packages, 2)
+}
+
+func TestUpdateShipment_PaquetesNil_NoTocaLosBultos(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		GetShipmentByIDFn: func(ctx context.Context, id uint) (*domain.Shipment, error) {
+			return &domain.Shipment{ID: id, Status: "pending"}, nil
+		},
+		ReplaceShipmentPackagesFn: func(ctx context.Context, shipmentID uint, pkgs []domain.ShipmentPackage) error {
+			t.Fatal("sin packages en la solicitud no se deben reescribir los bultos")
+			return nil
+		},
+	}
+	uc := newUseCaseForCost(repo, nil)
+
+	_, err := uc.UpdateShipment(context.Background(), 7, &domain.UpdateShipmentRequest{Carrier: s("Servientrega")})
+
+	require.NoError(t, err)
+}
+
+func TestUpdateShipment_PaquetesVacios_EliminaLosBultos(t *testing.T) {
+	called := false
+	repo := &mocks.RepositoryMock{
+		GetShipmentByIDFn: func(ctx context.Context, id uint) (*domain.Shipment, error) {
+			return &domain.Shipment{ID: id, Status: "pending", Weight: f64(4)}, nil
+		},
+		ReplaceShipmentPackagesFn: func(ctx context.Context, shipmentID uint, pkgs []domain.ShipmentPackage) error {
+			called = true
+			assert.Empty(t, pkgs)
+			return nil
+		},
+	}
+	uc := newUseCaseForCost(repo, nil)
+
+	resp, err := uc.UpdateShipment(context.Background(), 7, &domain.UpdateShipmentRequest{Packages: []domain.ShipmentPackage{}})
+
+	require.NoError(t, err)
+	assert.True(t, called)
+	assert.InDelta(t, 4.0, *resp.Weight, 0.001)
+}
+
+func TestGetShipmentByID_CargaLosPaquetes(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		GetShipmentByIDFn: func(ctx context.Context, id uint) (*domain.Shipment, error) {
+			return &domain.Shipment{ID: id, Status: "pending"}, nil
+		},
+		ListShipmentPackagesFn: func(ctx context.Context, shipmentIDs []uint) (map[uint][]domain.ShipmentPackage, error) {
+			return map[uint][]domain.ShipmentPackage{9: {{Sequence: 1}, {Sequence: 2}}}, nil
+		},
+	}
+	uc := newUseCaseForCost(repo, nil)
+
+	resp, err := uc.GetShipmentByID(context.Background(), 9)
+
+	require.NoError(t, err)
+	assert.Len(t, resp.Packages, 2)
+}
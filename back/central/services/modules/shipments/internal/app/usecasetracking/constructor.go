@@ -0,0 +1,38 @@
+package usecasetracking
+
+import (
+	"sync"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
+)
+
+// mappingCacheTTL cada cuánto se recarga la tabla de traducción de un proveedor.
+// Un cambio hecho por otra instancia tarda a lo sumo esto en aplicar.
+const mappingCacheTTL = 5 * time.Minute
+
+type cachedTable struct {
+	table    domain.StatusMappingTable
+	loadedAt time.Time
+}
+
+// UseCaseTracking historial normalizado de eventos de transporte: registra los
+// escaneos que llegan por webhook o consulta y deriva de ellos el estado del
+// envío y de la orden
+type UseCaseTracking struct {
+	repo     domain.IRepository
+	tracking domain.ITrackingRepository
+	now      func() time.Time
+
+	mu     sync.Mutex
+	tables map[string]cachedTable
+}
+
+func New(repo domain.IRepository, tracking domain.ITrackingRepository) *UseCaseTracking {
+	return &UseCaseTracking{
+		repo:     repo,
+		tracking: tracking,
+		now:      time.Now,
+		tables:   make(map[string]cachedTable),
+	}
+}
@@ -0,0 +1,65 @@
+package usecasetracking
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
+)
+
+var (
+	ErrInvalidEventCode  = errors.New("event_code no pertenece a la taxonomía de tracking")
+	ErrMappingIncomplete = errors.New("provider y raw_status son requeridos")
+)
+
+// ListMappings tabla de traducción de un proveedor (todas si provider es vacío)
+func (uc *UseCaseTracking) ListMappings(ctx context.Context, provider string) ([]domain.CarrierStatusMapping, error) {
+	return uc.tracking.ListCarrierStatusMappings(ctx, strings.ToLower(strings.TrimSpace(provider)))
+}
+
+// UpsertMapping crea o corrige la traducción de un código raw. Aplica a los
+// eventos que lleguen desde ahora; el historial ya guardado no se reescribe.
+func (uc *UseCaseTracking) UpsertMapping(ctx context.Context, mapping *domain.CarrierStatusMapping) error {
+	mapping.Provider = strings.ToLower(strings.TrimSpace(mapping.Provider))
+	mapping.Carrier = domain.NormalizeCarrier(mapping.Carrier)
+	mapping.RawStatus = domain.NormalizeRawStatus(mapping.RawStatus)
+	if mapping.Provider == "" || mapping.RawStatus == "" {
+		return ErrMappingIncomplete
+	}
+	if !mapping.EventCode.IsValid() {
+		return ErrInvalidEventCode
+	}
+
+	if err := uc.tracking.UpsertCarrierStatusMapping(ctx, mapping); err != nil {
+		return err
+	}
+	uc.invalidateMappings(mapping.Provider)
+	return nil
+}
+
+// ListUnmapped códigos raw que se tradujeron por el estado de la integración o
+// quedaron sin traducir, ordenados por frecuencia: candidatos a agregar a la tabla
+func (uc *UseCaseTracking) ListUnmapped(ctx context.Context, provider string, limit int) ([]domain.UnmappedTrackingStatus, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return uc.tracking.ListUnmappedTrackingStatuses(ctx, strings.ToLower(strings.TrimSpace(provider)), limit)
+}
+
+// CarrierMetrics tiempo por etapa y tasa de entrega al primer intento por transportadora
+func (uc *UseCaseTracking) CarrierMetrics(ctx context.Context, filter domain.TrackingMetricsFilter) ([]domain.CarrierTrackingMetrics, error) {
+	if filter.Carrier != "" {
+		filter.Carrier = domain.NormalizeCarrier(filter.Carrier)
+	}
+	metrics, err := uc.tracking.GetCarrierTrackingMetrics(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	for i := range metrics {
+		if metrics[i].Delivered > 0 {
+			metrics[i].FirstAttemptRate = float64(metrics[i].FirstAttemptDelivered) / float64(metrics[i].Delivered)
+		}
+	}
+	return metrics, nil
+}
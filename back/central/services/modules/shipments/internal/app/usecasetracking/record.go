@@ -0,0 +1,161 @@
+package usecasetracking
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
+)
+
+// RecordInput eventos reportados por un proveedor para un envío
+type RecordInput struct {
+	Shipment   *domain.Shipment
+	BusinessID uint
+	Provider   string
+	Source     string
+	Events     []domain.TrackingEventInput
+	// ApplyStatus recalcula el estado del envío desde el historial y persiste
+	// el envío (y la orden si el estado cambió). Sin él solo se guardan los eventos.
+	ApplyStatus bool
+}
+
+// RecordResult resultado de registrar eventos
+type RecordResult struct {
+	Inserted       int
+	PreviousStatus string
+	Status         string
+	StatusChanged  bool
+	Current        *domain.ShipmentTrackingEvent
+	OrderSyncErr   error
+}
+
+// RecordEvents normaliza y guarda los eventos (los repetidos se ignoran) y, si se
+// pide, deriva el estado del envío del evento vigente del historial completo.
+// Así un webhook atrasado no retrocede un envío que ya avanzó.
+func (uc *UseCaseTracking) RecordEvents(ctx context.Context, in RecordInput) (*RecordResult, error) {
+	if in.Shipment == nil || in.Shipment.ID == 0 {
+		return nil, fmt.Errorf("%w: shipment is required", domain.ErrInvalidShipmentData)
+	}
+	shipment := in.Shipment
+
+	table, err := uc.mappingTable(ctx, in.Provider)
+	if err != nil {
+		return nil, fmt.Errorf("loading status mappings: %w", err)
+	}
+
+	events := make([]domain.ShipmentTrackingEvent, 0, len(in.Events))
+	for _, input := range in.Events {
+		if input.Carrier == "" && shipment.Carrier != nil {
+			input.Carrier = *shipment.Carrier
+		}
+		if input.OccurredAt.IsZero() {
+			input.OccurredAt = uc.now()
+		}
+		code, source := table.Resolve(input)
+		events = append(events, domain.ShipmentTrackingEvent{
+			ShipmentID:      shipment.ID,
+			BusinessID:      in.BusinessID,
+			Provider:        in.Provider,
+			Carrier:         domain.NormalizeCarrier(input.Carrier),
+			Source:          in.Source,
+			DedupKey:        domain.TrackingDedupKey(shipment.ID, input),
+			EventCode:       code,
+			ShipmentStatus:  code.ShipmentStatus(),
+			MappingSource:   source,
+			RawStatus:       input.RawStatus,
+			RawStatusDetail: input.RawStatusDetail,
+			Description:     input.Description,
+			Location:        input.Location,
+			HasIncidence:    input.HasIncidence,
+			OccurredAt:      input.OccurredAt,
+		})
+	}
+
+	result := &RecordResult{PreviousStatus: shipment.Status, Status: shipment.Status}
+	if len(events) > 0 {
+		inserted, err := uc.tracking.InsertTrackingEvents(ctx, events)
+		if err != nil {
+			return nil, fmt.Errorf("saving tracking events: %w", err)
+		}
+		result.Inserted = inserted
+	}
+
+	if !in.ApplyStatus {
+		return result, nil
+	}
+
+	history, err := uc.tracking.ListTrackingEvents(ctx, shipment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("loading tracking history: %w", err)
+	}
+	applyHistory(shipment, history, result)
+
+	if err := uc.repo.UpdateShipment(ctx, shipment); err != nil {
+		return nil, fmt.Errorf("updating shipment: %w", err)
+	}
+
+	if result.StatusChanged && shipment.OrderID != nil && *shipment.OrderID != "" {
+		result.OrderSyncErr = uc.repo.UpdateOrderStatusByOrderID(ctx, *shipment.OrderID, result.Status)
+	}
+
+	return result, nil
+}
+
+// applyHistory lleva al envío el estado del evento vigente y las fechas de
+// despacho y entrega
+func applyHistory(shipment *domain.Shipment, history []domain.ShipmentTrackingEvent, result *RecordResult) {
+	current := domain.CurrentTrackingEvent(history)
+	if current == nil {
+		return
+	}
+	result.Current = current
+
+	status := current.EventCode.ShipmentStatus()
+	result.Status = status
+	result.StatusChanged = status != result.PreviousStatus
+	shipment.Status = status
+
+	if current.RawStatus != "" {
+		raw := current.RawStatus
+		shipment.CarrierStatus = &raw
+	}
+	if current.RawStatusDetail != "" {
+		detail := current.RawStatusDetail
+		shipment.CarrierStatusDetail = &detail
+	}
+
+	if shipment.ShippedAt == nil {
+		shipment.ShippedAt = domain.FirstTrackingEventAt(history,
+			domain.TrackingEventPickedUp, domain.TrackingEventInTransit, domain.TrackingEventOutForDelivery)
+	}
+	if current.EventCode == domain.TrackingEventDelivered && shipment.DeliveredAt == nil {
+		shipment.DeliveredAt = domain.FirstTrackingEventAt(history, domain.TrackingEventDelivered)
+	}
+}
+
+// mappingTable tabla de traducción del proveedor, cacheada por mappingCacheTTL
+func (uc *UseCaseTracking) mappingTable(ctx context.Context, provider string) (domain.StatusMappingTable, error) {
+	uc.mu.Lock()
+	cached, ok := uc.tables[provider]
+	uc.mu.Unlock()
+	if ok && uc.now().Sub(cached.loadedAt) < mappingCacheTTL {
+		return cached.table, nil
+	}
+
+	mappings, err := uc.tracking.ListCarrierStatusMappings(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	table := domain.NewStatusMappingTable(mappings)
+
+	uc.mu.Lock()
+	uc.tables[provider] = cachedTable{table: table, loadedAt: uc.now()}
+	uc.mu.Unlock()
+	return table, nil
+}
+
+func (uc *UseCaseTracking) invalidateMappings(provider string) {
+	uc.mu.Lock()
+	delete(uc.tables, provider)
+	uc.mu.Unlock()
+}
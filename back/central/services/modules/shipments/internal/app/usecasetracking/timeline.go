@@ -0,0 +1,142 @@
+package usecasetracking
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
+)
+
+// Timeline historial completo de un envío para operadores: incluye los códigos
+// raw de la transportadora y cómo se tradujo cada uno
+func (uc *UseCaseTracking) Timeline(ctx context.Context, shipmentID uint) (*domain.TrackingTimeline, error) {
+	shipment, err := uc.repo.GetShipmentByID(ctx, shipmentID)
+	if err != nil {
+		return nil, err
+	}
+	if shipment == nil {
+		return nil, domain.ErrShipmentNotFound
+	}
+	return uc.buildTimeline(ctx, shipment, true)
+}
+
+// PublicTimeline historial de un envío para el cliente final, buscado por número
+// de guía. Solo expone la etiqueta normalizada, la descripción y la ubicación.
+func (uc *UseCaseTracking) PublicTimeline(ctx context.Context, trackingNumber string) (*domain.TrackingTimeline, error) {
+	shipment, err := uc.repo.GetShipmentByTrackingNumber(ctx, trackingNumber)
+	if err != nil {
+		return nil, err
+	}
+	if shipment == nil {
+		return nil, domain.ErrShipmentNotFound
+	}
+	return uc.buildTimeline(ctx, shipment, false)
+}
+
+func (uc *UseCaseTracking) buildTimeline(ctx context.Context, shipment *domain.Shipment, includeRaw bool) (*domain.TrackingTimeline, error) {
+	events, err := uc.tracking.ListTrackingEvents(ctx, shipment.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		// Envíos anteriores al historial normalizado: solo tienen metadata.tracking_events
+		events = legacyMetadataEvents(shipment)
+	}
+
+	timeline := &domain.TrackingTimeline{
+		ShipmentID:  shipment.ID,
+		Status:      shipment.Status,
+		ShippedAt:   shipment.ShippedAt,
+		DeliveredAt: shipment.DeliveredAt,
+		Timeline:    make([]domain.TrackingTimelineEntry, 0, len(events)),
+	}
+	if shipment.TrackingNumber != nil {
+		timeline.TrackingNumber = *shipment.TrackingNumber
+	}
+	if shipment.Carrier != nil {
+		timeline.Carrier = *shipment.Carrier
+	}
+	if current := domain.CurrentTrackingEvent(events); current != nil {
+		code := current.EventCode
+		timeline.CurrentEvent = &code
+	}
+
+	for _, e := range events {
+		timeline.Timeline = append(timeline.Timeline, domain.TrackingTimelineEntry{
+			EventCode:    e.EventCode,
+			Label:        e.EventCode.Label(),
+			Description:  e.Description,
+			Location:     e.Location,
+			HasIncidence: e.HasIncidence,
+			OccurredAt:   e.OccurredAt,
+		})
+	}
+	if includeRaw {
+		timeline.Events = events
+	}
+	return timeline, nil
+}
+
+// legacyMetadataEvents traduce metadata.tracking_events (estado ya mapeado por
+// la integración) a eventos normalizados, solo para mostrarlos
+func legacyMetadataEvents(shipment *domain.Shipment) []domain.ShipmentTrackingEvent {
+	if len(shipment.Metadata) == 0 {
+		return nil
+	}
+	var meta struct {
+		TrackingEvents []struct {
+			Date            string `json:"date"`
+			Status          string `json:"status"`
+			RawStatus       string `json:"raw_status"`
+			RawStatusDetail string `json:"raw_status_detail"`
+			Carrier         string `json:"carrier"`
+			Description     string `json:"description"`
+			Location        string `json:"location"`
+			HasIncidence    bool   `json:"has_incidence"`
+			Source          string `json:"source"`
+		} `json:"tracking_events"`
+	}
+	if err := json.Unmarshal(shipment.Metadata, &meta); err != nil {
+		return nil
+	}
+
+	empty := domain.NewStatusMappingTable(nil)
+	events := make([]domain.ShipmentTrackingEvent, 0, len(meta.TrackingEvents))
+	for _, e := range meta.TrackingEvents {
+		occurredAt, ok := ParseEventTime(e.Date)
+		if !ok {
+			continue
+		}
+		rawStatus := e.RawStatus
+		if rawStatus == "" {
+			rawStatus = e.Status
+		}
+		code, source := empty.Resolve(domain.TrackingEventInput{IntegrationStatus: e.Status})
+		events = append(events, domain.ShipmentTrackingEvent{
+			ShipmentID:      shipment.ID,
+			Provider:        e.Source,
+			Carrier:         domain.NormalizeCarrier(e.Carrier),
+			EventCode:       code,
+			ShipmentStatus:  code.ShipmentStatus(),
+			MappingSource:   source,
+			RawStatus:       rawStatus,
+			RawStatusDetail: e.RawStatusDetail,
+			Description:     e.Description,
+			Location:        e.Location,
+			HasIncidence:    e.HasIncidence,
+			OccurredAt:      occurredAt,
+		})
+	}
+	return events
+}
+
+// ParseEventTime fechas de eventos de transportadoras: RFC3339 o fecha y hora sin zona
+func ParseEventTime(s string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
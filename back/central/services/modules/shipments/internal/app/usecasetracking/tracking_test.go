@@ -0,0 +1,262 @@
+package usecasetracking
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func s(v string) *string {
+	return &v
+}
+
+// memoryTracking guarda en memoria lo insertado para que ListTrackingEvents lo devuelva
+func memoryTracking(mappings []domain.CarrierStatusMapping) (*mocks.TrackingRepositoryMock, *[]domain.ShipmentTrackingEvent) {
+	stored := []domain.ShipmentTrackingEvent{}
+	seen := map[string]bool{}
+	repo := &mocks.TrackingRepositoryMock{
+		ListCarrierStatusMappingsFn: func(ctx context.Context, provider string) ([]domain.CarrierStatusMapping, error) {
+			return mappings, nil
+		},
+		InsertTrackingEventsFn: func(ctx context.Context, events []domain.ShipmentTrackingEvent) (int, error) {
+			inserted := 0
+			for _, e := range events {
+				if seen[e.DedupKey] {
+					continue
+				}
+				seen[e.DedupKey] = true
+				e.ID = uint(len(stored) + 1)
+				stored = append(stored, e)
+				inserted++
+			}
+			return inserted, nil
+		},
+		ListTrackingEventsFn: func(ctx context.Context, shipmentID uint) ([]domain.ShipmentTrackingEvent, error) {
+			return stored, nil
+		},
+	}
+	return repo, &stored
+}
+
+var envioclickMappings = []domain.CarrierStatusMapping{
+	{Provider: "envioclick", RawStatus: "envio recolectado", EventCode: domain.TrackingEventPickedUp},
+	{Provider: "envioclick", RawStatus: "en transito", EventCode: domain.TrackingEventInTransit},
+	{Provider: "envioclick", RawStatus: "entregado", EventCode: domain.TrackingEventDelivered},
+	{Provider: "envioclick", Carrier: "servientrega", RawStatus: "direccion errada", EventCode: domain.TrackingEventAttemptFailed},
+}
+
+func TestRecordEvents_DerivaEstadoYSincronizaOrden(t *testing.T) {
+	var orderStatus string
+	repo := &mocks.RepositoryMock{
+		UpdateShipmentFn: func(ctx context.Context, shipment *domain.Shipment) error { return nil },
+		UpdateOrderStatusByOrderIDFn: func(ctx context.Context, orderID string, status string) error {
+			orderStatus = status
+			return nil
+		},
+	}
+	tracking, stored := memoryTracking(envioclickMappings)
+	uc := New(repo, tracking)
+
+	base := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	shipment := &domain.Shipment{ID: 10, Status: "pending", OrderID: s("order-1"), Carrier: s("Servientrega")}
+
+	result, err := uc.RecordEvents(context.Background(), RecordInput{
+		Shipment: shipment,
+		Provider: "envioclick",
+		Source:   domain.TrackingSourceTrack,
+		Events: []domain.TrackingEventInput{
+			{RawStatus: "Envio Recolectado", OccurredAt: base},
+			{RawStatus: "En Transito", OccurredAt: base.Add(20 * time.Hour)},
+		},
+		ApplyStatus: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Inserted)
+	assert.True(t, result.StatusChanged)
+	assert.Equal(t, "in_transit", shipment.Status)
+	assert.Equal(t, "in_transit", orderStatus)
+	require.NotNil(t, shipment.ShippedAt)
+	assert.Equal(t, base, *shipment.ShippedAt)
+	assert.Equal(t, "servientrega", (*stored)[0].Carrier)
+	assert.Equal(t, domain.MappingSourceProvider, (*stored)[0].MappingSource)
+}
+
+func TestRecordEvents_EventoRepetidoNoCambiaNada(t *testing.T) {
+	orderUpdates := 0
+	repo := &mocks.RepositoryMock{
+		UpdateShipmentFn: func(ctx context.Context, shipment *domain.Shipment) error { return nil },
+		UpdateOrderStatusByOrderIDFn: func(ctx context.Context, orderID string, status string) error {
+			orderUpdates++
+			return nil
+		},
+	}
+	tracking, _ := memoryTracking(envioclickMappings)
+	uc := New(repo, tracking)
+
+	at := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	shipment := &domain.Shipment{ID: 10, Status: "pending", OrderID: s("order-1")}
+	in := RecordInput{
+		Shipment:    shipment,
+		Provider:    "envioclick",
+		Events:      []domain.TrackingEventInput{{RawStatus: "Entregado", OccurredAt: at}},
+		ApplyStatus: true,
+	}
+
+	_, err := uc.RecordEvents(context.Background(), in)
+	require.NoError(t, err)
+	result, err := uc.RecordEvents(context.Background(), in)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Inserted)
+	assert.False(t, result.StatusChanged)
+	assert.Equal(t, 1, orderUpdates)
+	require.NotNil(t, shipment.DeliveredAt)
+	assert.Equal(t, at, *shipment.DeliveredAt)
+}
+
+func TestRecordEvents_DetalleDeTransportadoraMarcaIntentoFallido(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		UpdateShipmentFn: func(ctx context.Context, shipment *domain.Shipment) error { return nil },
+	}
+	tracking, stored := memoryTracking(envioclickMappings)
+	uc := New(repo, tracking)
+
+	shipment := &domain.Shipment{ID: 10, Status: "in_transit"}
+	result, err := uc.RecordEvents(context.Background(), RecordInput{
+		Shipment: shipment,
+		Provider: "envioclick",
+		Events: []domain.TrackingEventInput{{
+			Carrier:           "SERVIENTREGA",
+			RawStatus:         "Novedad",
+			RawStatusDetail:   "Dirección errada",
+			IntegrationStatus: "on_hold",
+			OccurredAt:        time.Date(2026, 10, 2, 9, 0, 0, 0, time.UTC),
+		}},
+		ApplyStatus: true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.TrackingEventAttemptFailed, (*stored)[0].EventCode)
+	assert.Equal(t, domain.MappingSourceCarrier, (*stored)[0].MappingSource)
+	assert.Equal(t, "on_hold", result.Status)
+	require.NotNil(t, shipment.CarrierStatusDetail)
+	assert.Equal(t, "Dirección errada", *shipment.CarrierStatusDetail)
+}
+
+func TestRecordEvents_SinApplyStatusNoTocaElEnvio(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		UpdateShipmentFn: func(ctx context.Context, shipment *domain.Shipment) error {
+			t.Fatal("no debe actualizar el envío")
+			return nil
+		},
+	}
+	tracking, stored := memoryTracking(nil)
+	uc := New(repo, tracking)
+
+	shipment := &domain.Shipment{ID: 10, Status: "pending"}
+	result, err := uc.RecordEvents(context.Background(), RecordInput{
+		Shipment: shipment,
+		Provider: "envioclick",
+		Source:   domain.TrackingSourceGenerate,
+		Events:   []domain.TrackingEventInput{{RawStatus: "Guía generada", IntegrationStatus: "pending"}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Inserted)
+	assert.Equal(t, domain.TrackingEventLabelCreated, (*stored)[0].EventCode)
+	assert.False(t, (*stored)[0].OccurredAt.IsZero())
+}
+
+func TestRecordEvents_FallaInsertarRetornaError(t *testing.T) {
+	tracking := &mocks.TrackingRepositoryMock{
+		InsertTrackingEventsFn: func(ctx context.Context, events []domain.ShipmentTrackingEvent) (int, error) {
+			return 0, errors.New("db down")
+		},
+	}
+	uc := New(&mocks.RepositoryMock{}, tracking)
+
+	_, err := uc.RecordEvents(context.Background(), RecordInput{
+		Shipment: &domain.Shipment{ID: 10},
+		Events:   []domain.TrackingEventInput{{RawStatus: "x"}},
+	})
+
+	assert.Error(t, err)
+}
+
+func TestUpsertMapping_NormalizaEInvalidaCache(t *testing.T) {
+	loads := 0
+	var saved *domain.CarrierStatusMapping
+	tracking := &mocks.TrackingRepositoryMock{
+		ListCarrierStatusMappingsFn: func(ctx context.Context, provider string) ([]domain.CarrierStatusMapping, error) {
+			loads++
+			return nil, nil
+		},
+		UpsertCarrierStatusMappingFn: func(ctx context.Context, mapping *domain.CarrierStatusMapping) error {
+			saved = mapping
+			return nil
+		},
+	}
+	uc := New(&mocks.RepositoryMock{}, tracking)
+
+	_, err := uc.mappingTable(context.Background(), "envioclick")
+	require.NoError(t, err)
+	_, err = uc.mappingTable(context.Background(), "envioclick")
+	require.NoError(t, err)
+	assert.Equal(t, 1, loads)
+
+	err = uc.UpsertMapping(context.Background(), &domain.CarrierStatusMapping{
+		Provider:  " EnvioClick ",
+		Carrier:   "Inter Rapidísimo",
+		RawStatus: "  En  Bodega ",
+		EventCode: domain.TrackingEventInTransit,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "envioclick", saved.Provider)
+	assert.Equal(t, "interrapidisimo", saved.Carrier)
+	assert.Equal(t, "en bodega", saved.RawStatus)
+
+	_, err = uc.mappingTable(context.Background(), "envioclick")
+	require.NoError(t, err)
+	assert.Equal(t, 2, loads)
+}
+
+func TestUpsertMapping_CodigoFueraDeLaTaxonomia(t *testing.T) {
+	uc := New(&mocks.RepositoryMock{}, &mocks.TrackingRepositoryMock{})
+
+	err := uc.UpsertMapping(context.Background(), &domain.CarrierStatusMapping{
+		Provider:  "shipit",
+		RawStatus: "lost",
+		EventCode: "perdido",
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidEventCode)
+}
+
+func TestPublicTimeline_SinHistorialUsaMetadataLegacy(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		GetShipmentByTrackingNumberFn: func(ctx context.Context, trackingNumber string) (*domain.Shipment, error) {
+			return &domain.Shipment{
+				ID:             10,
+				Status:         "in_transit",
+				TrackingNumber: s(trackingNumber),
+				Metadata:       []byte(`{"tracking_events":[{"date":"2026-10-01T08:00:00Z","status":"in_transit","raw_status":"En Transito","description":"Salió de bodega"}]}`),
+			}, nil
+		},
+	}
+	uc := New(repo, &mocks.TrackingRepositoryMock{})
+
+	timeline, err := uc.PublicTimeline(context.Background(), "ABC123")
+
+	require.NoError(t, err)
+	require.Len(t, timeline.Timeline, 1)
+	assert.Equal(t, domain.TrackingEventInTransit, timeline.Timeline[0].EventCode)
+	assert.Equal(t, "En tránsito", timeline.Timeline[0].Label)
+	assert.Nil(t, timeline.Events)
+}
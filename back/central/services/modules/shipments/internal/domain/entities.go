@@ -44,6 +44,10 @@ type CreateShipmentRequest struct {
 	Width  *float64 `json:"width" binding:"omitempty,min=0"`
 	Length *float64 `json:"length" binding:"omitempty,min=0"`
 
+	// Packages bultos del envío. Si vienen, las dimensiones de arriba se
+	// recalculan como el consolidado de los paquetes.
+	Packages []ShipmentPackage `json:"packages" binding:"omitempty,dive"`
+
 	WarehouseID   *uint  `json:"warehouse_id"`
 	WarehouseName string `json:"warehouse_name" binding:"omitempty,max=128"`
 	DriverID      *uint  `json:"driver_id"`
@@ -84,6 +88,10 @@ type UpdateShipmentRequest struct {
 	Width  *float64 `json:"width" binding:"omitempty,min=0"`
 	Length *float64 `json:"length" binding:"omitempty,min=0"`
 
+	// Packages bultos del envío. Si vienen, las dimensiones de arriba se
+	// recalculan como el consolidado de los paquetes.
+	Packages []ShipmentPackage `json:"packages" binding:"omitempty,dive"`
+
 	WarehouseID   *uint   `json:"warehouse_id"`
 	WarehouseName *string `json:"warehouse_name" binding:"omitempty,max=128"`
 	DriverID      *uint   `json:"driver_id"`
@@ -146,6 +154,8 @@ type ShipmentResponse struct {
 	Width  *float64 `json:"width,omitempty"`
 	Length *float64 `json:"length,omitempty"`
 
+	Packages []ShipmentPackage `json:"packages,omitempty"`
+
 	WarehouseID   *uint  `json:"warehouse_id,omitempty"`
 	WarehouseName string `json:"warehouse_name"`
 	DriverID      *uint  `json:"driver_id,omitempty"`
@@ -18,5 +18,11 @@ var (
 	ErrShipmentNotDelivered = errors.New("shipment must be delivered before collecting COD")
 	ErrOrderAlreadyPaid     = errors.New("order is already paid")
 	ErrOrderNotCOD          = errors.New("order is not a cash on delivery order")
+
+	// ErrWalletNotFound se retorna cuando el negocio no tiene billetera
+	ErrWalletNotFound = errors.New("wallet not found")
+
+	// ErrInsufficientWalletBalance se retorna cuando el disponible no alcanza para retener el costo de la guia
+	ErrInsufficientWalletBalance = errors.New("insufficient wallet balance")
 )
 
@@ -43,6 +43,19 @@ type ManifestShipmentRow struct {
 	ShipmentStatus     string
 	OrderStatus        string
 	PackageQuantity    int64
+	Packages           []ShipmentPackage
+}
+
+// BultosCount bultos físicos del envío: los paquetes cargados o, si no se
+// cargaron, el conteo del listado. Un envío sin paquetes es un bulto.
+func (r ManifestShipmentRow) BultosCount() int {
+	if len(r.Packages) > 0 {
+		return len(r.Packages)
+	}
+	if r.PackageQuantity > 0 {
+		return int(r.PackageQuantity)
+	}
+	return 1
 }
 
 type ManifestPDFInput struct {
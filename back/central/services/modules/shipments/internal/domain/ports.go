@@ -61,6 +61,7 @@ type GuidePDFContext struct {
 	Guia               string
 	Observaciones      string
 	OrderItems         []OrderItemContext
+	Packages           []ShipmentPackage
 }
 
 type IRepository interface {
@@ -74,6 +75,10 @@ type IRepository interface {
 
 	ShipmentExists(ctx context.Context, orderID string, trackingNumber string) (bool, error)
 
+	ReplaceShipmentPackages(ctx context.Context, shipmentID uint, pkgs []ShipmentPackage) error
+	ListShipmentPackages(ctx context.Context, shipmentIDs []uint) (map[uint][]ShipmentPackage, error)
+	SetShipmentPackageTrackingNumbers(ctx context.Context, shipmentID uint, trackingNumbers []string) error
+
 	GetActiveShippingCarrier(ctx context.Context, businessID uint) (*CarrierInfo, error)
 
 	GetBusinessName(ctx context.Context, businessID uint) (string, error)
@@ -127,7 +132,6 @@ type IRepository interface {
 	GetBusinessActiveIntegration(ctx context.Context, businessID uint, providerCode string) (uint, string, error)
 	ListBusinessesWithActiveProvider(ctx context.Context, providerCode string) ([]uint, error)
 
-	DebitWalletForGuide(ctx context.Context, businessID uint, amount float64, trackingNumber string, shipmentID *uint) error
 	FindUnchargedGuides(ctx context.Context, createdAfter, createdBefore time.Time, limit int) ([]UnchargedGuide, error)
 
 	CreateOriginAddress(ctx context.Context, address *OriginAddress) error
@@ -224,6 +228,21 @@ type ICarrierResolver interface {
 	GetActiveShippingCarrier(ctx context.Context, businessID uint) (*CarrierInfo, error)
 }
 
+// IGuideWallet retiene y cobra el costo de las guias en la billetera del
+// negocio. Lo implementa el modulo pay: los asientos del libro mayor solo se
+// escriben alli
+type IGuideWallet interface {
+	// HoldForGuide retiene el costo mientras la transportadora genera la guia.
+	// ErrInsufficientWalletBalance si no alcanza; ErrWalletNotFound si el
+	// negocio no tiene billetera
+	HoldForGuide(ctx context.Context, businessID, shipmentID uint, amount float64, correlationID string) error
+	// DebitForGuide cobra la guia confirmada: captura la retencion del envio o
+	// debita directo. Cobrar dos veces el mismo envio no hace nada
+	DebitForGuide(ctx context.Context, businessID uint, amount float64, trackingNumber string, shipmentID *uint) error
+	// ReleaseGuideHolds libera las retenciones activas del envio
+	ReleaseGuideHolds(ctx context.Context, shipmentID uint) error
+}
+
 type ShipmentOverageChecker func(ctx context.Context, businessID uint) (blocked bool, reason string, fee float64, err error)
 
 type TransportRequestMessage struct {
@@ -53,6 +53,8 @@ type Shipment struct {
 	Width  *float64 `json:"width"`
 	Length *float64 `json:"length"`
 
+	Packages []ShipmentPackage `json:"packages,omitempty"`
+
 	WarehouseID   *uint  `json:"warehouse_id"`
 	WarehouseName string `json:"warehouse_name"`
 	DriverID      *uint  `json:"driver_id"`
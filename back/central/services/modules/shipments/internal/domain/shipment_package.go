@@ -0,0 +1,331 @@
+package domain
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PackageContent ítem de la orden que va dentro de un paquete
+type PackageContent struct {
+	SKU      string `json:"sku"`
+	Name     string `json:"name,omitempty"`
+	Quantity int    `json:"quantity"`
+}
+
+// ShipmentPackage bulto físico de un envío. Las dimensiones de Shipment son el
+// consolidado (ConsolidatePackages); estas son las que se cotizan y se rotulan.
+type ShipmentPackage struct {
+	ID             uint             `json:"id,omitempty"`
+	ShipmentID     uint             `json:"shipment_id,omitempty"`
+	Sequence       int              `json:"sequence"`
+	Reference      string           `json:"reference,omitempty"`
+	Weight         *float64         `json:"weight,omitempty" binding:"omitempty,min=0"`
+	Height         *float64         `json:"height,omitempty" binding:"omitempty,min=0"`
+	Width          *float64         `json:"width,omitempty" binding:"omitempty,min=0"`
+	Length         *float64         `json:"length,omitempty" binding:"omitempty,min=0"`
+	DeclaredValue  *float64         `json:"declared_value,omitempty" binding:"omitempty,min=0"`
+	Contents       []PackageContent `json:"contents,omitempty"`
+	TrackingNumber *string          `json:"tracking_number,omitempty"`
+}
+
+// PackedItem unidad de la orden a repartir en cajas. Las dimensiones y el peso
+// son por unidad; Price es el valor unitario que suma al valor declarado.
+type PackedItem struct {
+	SKU      string
+	Name     string
+	Quantity int
+	Weight   float64
+	Length   float64
+	Width    float64
+	Height   float64
+	Price    float64
+}
+
+// NormalizePackages numera los paquetes en el orden recibido y descarta los que
+// no traen ni peso ni dimensiones
+func NormalizePackages(pkgs []ShipmentPackage) []ShipmentPackage {
+	out := make([]ShipmentPackage, 0, len(pkgs))
+	for _, p := range pkgs {
+		if p.Weight == nil && p.Height == nil && p.Width == nil && p.Length == nil {
+			continue
+		}
+		p.ID = 0
+		p.Sequence = len(out) + 1
+		out = append(out, p)
+	}
+	return out
+}
+
+// ConsolidatePackages peso total y la caja más grande de cada eje: lo que se
+// guarda en las columnas de Shipment para quien no lee los paquetes
+func ConsolidatePackages(pkgs []ShipmentPackage) (weight, height, width, length *float64) {
+	if len(pkgs) == 0 {
+		return nil, nil, nil, nil
+	}
+	sumWeight := func(acc *float64, v *float64) *float64 {
+		if v == nil {
+			return acc
+		}
+		if acc == nil {
+			x := *v
+			return &x
+		}
+		x := *acc + *v
+		return &x
+	}
+	maxDim := func(acc *float64, v *float64) *float64 {
+		if v == nil || (acc != nil && *acc >= *v) {
+			return acc
+		}
+		x := *v
+		return &x
+	}
+	for _, p := range pkgs {
+		weight = sumWeight(weight, p.Weight)
+		height = maxDim(height, p.Height)
+		width = maxDim(width, p.Width)
+		length = maxDim(length, p.Length)
+	}
+	return weight, height, width, length
+}
+
+// ParsePackages lee el arreglo "packages" del payload que va al transport router.
+// Acepta declaredValue/declared_value y contents/items para los ítems.
+func ParsePackages(raw map[string]interface{}) []ShipmentPackage {
+	list, _ := raw["packages"].([]interface{})
+	pkgs := make([]ShipmentPackage, 0, len(list))
+	for _, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		p := ShipmentPackage{
+			Weight:        payloadFloat(m["weight"]),
+			Height:        payloadFloat(m["height"]),
+			Width:         payloadFloat(m["width"]),
+			Length:        payloadFloat(m["length"]),
+			DeclaredValue: payloadFloat(m["declaredValue"]),
+		}
+		if p.DeclaredValue == nil {
+			p.DeclaredValue = payloadFloat(m["declared_value"])
+		}
+		p.Reference, _ = m["reference"].(string)
+
+		contents, _ := m["contents"].([]interface{})
+		if contents == nil {
+			contents, _ = m["items"].([]interface{})
+		}
+		for _, c := range contents {
+			cm, ok := c.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			content := PackageContent{}
+			content.SKU, _ = cm["sku"].(string)
+			content.Name, _ = cm["name"].(string)
+			if q := payloadFloat(cm["quantity"]); q != nil {
+				content.Quantity = int(*q)
+			}
+			p.Contents = append(p.Contents, content)
+		}
+		pkgs = append(pkgs, p)
+	}
+	return NormalizePackages(pkgs)
+}
+
+// PayloadPackages arreglo "packages" para el transport router. Las integraciones
+// leen weight/height/width/length; el resto viaja para las que lo usen.
+func PayloadPackages(pkgs []ShipmentPackage) []interface{} {
+	out := make([]interface{}, 0, len(pkgs))
+	for _, p := range pkgs {
+		m := map[string]interface{}{
+			"weight": floatOrZero(p.Weight),
+			"height": floatOrZero(p.Height),
+			"width":  floatOrZero(p.Width),
+			"length": floatOrZero(p.Length),
+		}
+		if p.DeclaredValue != nil {
+			m["declaredValue"] = *p.DeclaredValue
+		}
+		if p.Reference != "" {
+			m["reference"] = p.Reference
+		}
+		if len(p.Contents) > 0 {
+			contents := make([]interface{}, 0, len(p.Contents))
+			for _, c := range p.Contents {
+				contents = append(contents, map[string]interface{}{"sku": c.SKU, "name": c.Name, "quantity": c.Quantity})
+			}
+			m["contents"] = contents
+		}
+		out = append(out, m)
+	}
+	return out
+}
+
+// PlanPackages reparte los ítems en cajas estándar. Si una sola caja alcanza se
+// usa la más pequeña que sirva; si no, se llena la más grande que admita los
+// ítems y el resto vuelve a planearse. Sin cajas configuradas devuelve nil y el
+// llamador decide con las dimensiones del producto.
+func (c *ShippingPackageConfig) PlanPackages(items []PackedItem) []ShipmentPackage {
+	if c == nil || len(c.StandardBoxes) == 0 {
+		return nil
+	}
+
+	units := make([]PackedItem, 0)
+	for _, it := range items {
+		for i := 0; i < it.Quantity; i++ {
+			u := it
+			u.Quantity = 1
+			units = append(units, u)
+		}
+	}
+	if len(units) == 0 {
+		return nil
+	}
+	// Los ítems más voluminosos primero: el que no cabe en ninguna caja sale
+	// suelto antes de repartir el resto
+	sort.SliceStable(units, func(i, j int) bool {
+		return units[i].Length*units[i].Width*units[i].Height > units[j].Length*units[j].Width*units[j].Height
+	})
+
+	var pkgs []ShipmentPackage
+	for len(units) > 0 {
+		l, w, h := largestUnit(units)
+		box := c.SelectBox(len(units), l, w, h)
+		take := len(units)
+		if box == nil {
+			box = c.largestFittingBox(l, w, h)
+			if box == nil {
+				// Ninguna caja admite el ítem más grande: va tal cual, uno por paquete
+				pkgs = append(pkgs, looseUnitPackage(units[0]))
+				units = units[1:]
+				continue
+			}
+			take = box.MaxItems
+			if take <= 0 || take > len(units) {
+				take = len(units)
+			}
+		}
+		pkgs = append(pkgs, boxPackage(box, units[:take]))
+		units = units[take:]
+	}
+
+	for i := range pkgs {
+		pkgs[i].Sequence = i + 1
+	}
+	return pkgs
+}
+
+func (c *ShippingPackageConfig) largestFittingBox(itemLength, itemWidth, itemHeight float64) *StandardBox {
+	var best *StandardBox
+	for i := range c.StandardBoxes {
+		box := &c.StandardBoxes[i]
+		if box.MaxItems <= 0 || !boxFitsItem(box, itemLength, itemWidth, itemHeight) {
+			continue
+		}
+		if best == nil || box.MaxItems > best.MaxItems {
+			best = box
+		}
+	}
+	return best
+}
+
+func largestUnit(units []PackedItem) (l, w, h float64) {
+	for _, u := range units {
+		l = math.Max(l, u.Length)
+		w = math.Max(w, u.Width)
+		h = math.Max(h, u.Height)
+	}
+	return l, w, h
+}
+
+func boxPackage(box *StandardBox, units []PackedItem) ShipmentPackage {
+	weight, value := 0.0, 0.0
+	for _, u := range units {
+		weight += u.Weight
+		value += u.Price
+	}
+	// El peso configurado en la caja es el mínimo que se cotiza
+	if box.Weight != nil && *box.Weight > weight {
+		weight = *box.Weight
+	}
+	p := ShipmentPackage{
+		Reference: box.Name,
+		Weight:    roundedPtr(weight),
+		Height:    box.Height,
+		Width:     box.Width,
+		Length:    box.Length,
+		Contents:  groupContents(units),
+	}
+	if value > 0 {
+		p.DeclaredValue = roundedPtr(value)
+	}
+	return p
+}
+
+func looseUnitPackage(u PackedItem) ShipmentPackage {
+	p := ShipmentPackage{
+		Weight:   roundedPtr(u.Weight),
+		Height:   roundedPtr(u.Height),
+		Width:    roundedPtr(u.Width),
+		Length:   roundedPtr(u.Length),
+		Contents: groupContents([]PackedItem{u}),
+	}
+	if u.Price > 0 {
+		p.DeclaredValue = roundedPtr(u.Price)
+	}
+	return p
+}
+
+func groupContents(units []PackedItem) []PackageContent {
+	var contents []PackageContent
+	index := make(map[string]int)
+	for _, u := range units {
+		key := u.SKU
+		if key == "" {
+			key = u.Name
+		}
+		if i, ok := index[key]; ok {
+			contents[i].Quantity++
+			continue
+		}
+		index[key] = len(contents)
+		contents = append(contents, PackageContent{SKU: u.SKU, Name: u.Name, Quantity: 1})
+	}
+	return contents
+}
+
+func roundedPtr(v float64) *float64 {
+	r := math.Round(v*100) / 100
+	return &r
+}
+
+func floatOrZero(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+func payloadFloat(v interface{}) *float64 {
+	switch n := v.(type) {
+	case float64:
+		return &n
+	case float32:
+		f := float64(n)
+		return &f
+	case int:
+		f := float64(n)
+		return &f
+	case int64:
+		f := float64(n)
+		return &f
+	case string:
+		if f, err := strconv.ParseFloat(strings.TrimSpace(n), 64); err == nil {
+			return &f
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package domain
+
+import "testing"
+
+func fp(v float64) *float64 { return &v }
+
+func standardBoxes() *ShippingPackageConfig {
+	return &ShippingPackageConfig{
+		Strategy: ShippingPackageStrategyStandardBox,
+		StandardBoxes: []StandardBox{
+			{Name: "pequena", Weight: fp(0.5), Length: fp(20), Width: fp(15), Height: fp(10), MaxItems: 2},
+			{Name: "grande", Weight: fp(1), Length: fp(40), Width: fp(30), Height: fp(30), MaxItems: 5},
+		},
+	}
+}
+
+func TestPlanPackagesUnaCajaAlcanza(t *testing.T) {
+	pkgs := standardBoxes().PlanPackages([]PackedItem{
+		{SKU: "CAM-01", Quantity: 2, Weight: 0.3, Length: 10, Width: 10, Height: 5, Price: 50000},
+	})
+
+	if len(pkgs) != 1 {
+		t.Fatalf("esperaba 1 paquete, got %d", len(pkgs))
+	}
+	p := pkgs[0]
+	if p.Reference != "pequena" || p.Sequence != 1 {
+		t.Errorf("esperaba la caja pequena con secuencia 1, got %q/%d", p.Reference, p.Sequence)
+	}
+	// El peso de la caja (0.5) es el mínimo; los ítems pesan 0.6
+	if p.Weight == nil || *p.Weight != 0.6 {
+		t.Errorf("peso esperado 0.6, got %v", p.Weight)
+	}
+	if p.DeclaredValue == nil || *p.DeclaredValue != 100000 {
+		t.Errorf("valor declarado esperado 100000, got %v", p.DeclaredValue)
+	}
+	if len(p.Contents) != 1 || p.Contents[0].Quantity != 2 {
+		t.Errorf("contenido agrupado por SKU esperado, got %+v", p.Contents)
+	}
+}
+
+func TestPlanPackagesReparteEnVariasCajas(t *testing.T) {
+	pkgs := standardBoxes().PlanPackages([]PackedItem{
+		{SKU: "A", Quantity: 6, Weight: 0.2},
+		{SKU: "B", Quantity: 1, Weight: 0.2},
+	})
+
+	if len(pkgs) != 2 {
+		t.Fatalf("esperaba 2 paquetes, got %d", len(pkgs))
+	}
+	if pkgs[0].Reference != "grande" || pkgs[1].Reference != "pequena" {
+		t.Errorf("esperaba grande + pequena, got %q + %q", pkgs[0].Reference, pkgs[1].Reference)
+	}
+	total := 0
+	for i, p := range pkgs {
+		if p.Sequence != i+1 {
+			t.Errorf("secuencia %d esperada, got %d", i+1, p.Sequence)
+		}
+		for _, c := range p.Contents {
+			total += c.Quantity
+		}
+	}
+	if total != 7 {
+		t.Errorf("esperaba 7 unidades repartidas, got %d", total)
+	}
+}
+
+func TestPlanPackagesItemQueNoCabeVaSuelto(t *testing.T) {
+	pkgs := standardBoxes().PlanPackages([]PackedItem{
+		{SKU: "SILLA", Quantity: 1, Weight: 8, Length: 90, Width: 50, Height: 50},
+		{SKU: "COJIN", Quantity: 1, Weight: 0.4, Length: 15, Width: 10, Height: 5},
+	})
+
+	if len(pkgs) != 2 {
+		t.Fatalf("esperaba 2 paquetes, got %d", len(pkgs))
+	}
+	if pkgs[0].Reference != "" || pkgs[0].Length == nil || *pkgs[0].Length != 90 {
+		t.Errorf("la silla debía ir suelta con sus medidas, got %+v", pkgs[0])
+	}
+	if pkgs[1].Reference != "pequena" {
+		t.Errorf("el cojín debía ir en la caja pequena, got %q", pkgs[1].Reference)
+	}
+}
+
+func TestPlanPackagesSinCajas(t *testing.T) {
+	var cfg *ShippingPackageConfig
+	if pkgs := cfg.PlanPackages([]PackedItem{{SKU: "A", Quantity: 1}}); pkgs != nil {
+		t.Errorf("sin configuración esperaba nil, got %+v", pkgs)
+	}
+}
+
+func TestConsolidatePackages(t *testing.T) {
+	weight, height, width, length := ConsolidatePackages([]ShipmentPackage{
+		{Weight: fp(2), Height: fp(10), Width: fp(30), Length: fp(40)},
+		{Weight: fp(3.5), Height: fp(25), Width: fp(20), Length: fp(20)},
+	})
+
+	if *weight != 5.5 || *height != 25 || *width != 30 || *length != 40 {
+		t.Errorf("consolidado inesperado: %v %v %v %v", *weight, *height, *width, *length)
+	}
+
+	if w, _, _, _ := ConsolidatePackages(nil); w != nil {
+		t.Errorf("sin paquetes esperaba nil, got %v", *w)
+	}
+}
+
+func TestParsePackages(t *testing.T) {
+	raw := map[string]interface{}{
+		"packages": []interface{}{
+			map[string]interface{}{
+				"weight": 1.5, "height": float64(10), "width": "20", "length": float64(30),
+				"declaredValue": float64(80000),
+				"contents":      []interface{}{map[string]interface{}{"sku": "CAM-01", "quantity": float64(2)}},
+			},
+			map[string]interface{}{},
+			map[string]interface{}{"weight": float64(2), "declared_value": float64(1000), "items": []interface{}{map[string]interface{}{"name": "Gorra", "quantity": float64(1)}}},
+		},
+	}
+
+	pkgs := ParsePackages(raw)
+
+	if len(pkgs) != 2 {
+		t.Fatalf("esperaba 2 paquetes (el vacío se descarta), got %d", len(pkgs))
+	}
+	if pkgs[0].Width == nil || *pkgs[0].Width != 20 {
+		t.Errorf("ancho como texto debía convertirse, got %v", pkgs[0].Width)
+	}
+	if pkgs[0].Contents[0].SKU != "CAM-01" || pkgs[0].Contents[0].Quantity != 2 {
+		t.Errorf("contenido inesperado: %+v", pkgs[0].Contents)
+	}
+	if pkgs[1].Sequence != 2 || *pkgs[1].DeclaredValue != 1000 || pkgs[1].Contents[0].Name != "Gorra" {
+		t.Errorf("segundo paquete inesperado: %+v", pkgs[1])
+	}
+}
+
+func TestPayloadPackagesIdaYVuelta(t *testing.T) {
+	in := []ShipmentPackage{{Sequence: 1, Weight: fp(1), Height: fp(2), Width: fp(3), Length: fp(4), DeclaredValue: fp(5),
+		Contents: []PackageContent{{SKU: "A", Quantity: 1}}}}
+
+	out := ParsePackages(map[string]interface{}{"packages": PayloadPackages(in)})
+
+	if len(out) != 1 || *out[0].Length != 4 || *out[0].DeclaredValue != 5 || out[0].Contents[0].SKU != "A" {
+		t.Errorf("el payload no conserva el paquete: %+v", out)
+	}
+}
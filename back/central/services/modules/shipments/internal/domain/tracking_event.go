@@ -0,0 +1,364 @@
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// TrackingEventCode taxonomía normalizada de eventos de transporte. Los códigos
+// raw de cada transportadora se traducen a uno de estos valores.
+type TrackingEventCode string
+
+const (
+	TrackingEventLabelCreated   TrackingEventCode = "label_created"
+	TrackingEventPickedUp       TrackingEventCode = "picked_up"
+	TrackingEventInTransit      TrackingEventCode = "in_transit"
+	TrackingEventOutForDelivery TrackingEventCode = "out_for_delivery"
+	TrackingEventAttemptFailed  TrackingEventCode = "attempt_failed"
+	TrackingEventException      TrackingEventCode = "exception"
+	TrackingEventDelivered      TrackingEventCode = "delivered"
+	TrackingEventReturning      TrackingEventCode = "returning"
+	TrackingEventReturned       TrackingEventCode = "returned"
+	TrackingEventCancelled      TrackingEventCode = "cancelled"
+	TrackingEventUnknown        TrackingEventCode = "unknown"
+)
+
+// Origen de la traducción de un evento: tabla de la transportadora, tabla del
+// proveedor (cualquier transportadora), estado ya mapeado por la integración o
+// sin traducción.
+const (
+	MappingSourceCarrier     = "carrier"
+	MappingSourceProvider    = "provider"
+	MappingSourceIntegration = "integration"
+	MappingSourceUnmapped    = "unmapped"
+)
+
+// Origen del evento dentro de Probability
+const (
+	TrackingSourceWebhook  = "webhook"
+	TrackingSourceTrack    = "track"
+	TrackingSourceGenerate = "generate"
+	TrackingSourceCancel   = "cancel"
+)
+
+type trackingEventInfo struct {
+	shipmentStatus string
+	terminal       bool
+	label          string
+}
+
+var trackingEventTaxonomy = map[TrackingEventCode]trackingEventInfo{
+	TrackingEventLabelCreated:   {shipmentStatus: "pending", label: "Guía creada"},
+	TrackingEventPickedUp:       {shipmentStatus: "picked_up", label: "Recogido por la transportadora"},
+	TrackingEventInTransit:      {shipmentStatus: "in_transit", label: "En tránsito"},
+	TrackingEventOutForDelivery: {shipmentStatus: "out_for_delivery", label: "En reparto"},
+	TrackingEventAttemptFailed:  {shipmentStatus: "on_hold", label: "Intento de entrega fallido"},
+	TrackingEventException:      {shipmentStatus: "on_hold", label: "Novedad en el envío"},
+	TrackingEventDelivered:      {shipmentStatus: "delivered", terminal: true, label: "Entregado"},
+	TrackingEventReturning:      {shipmentStatus: "returned", label: "En devolución"},
+	TrackingEventReturned:       {shipmentStatus: "returned", terminal: true, label: "Devuelto al remitente"},
+	TrackingEventCancelled:      {shipmentStatus: "cancelled", terminal: true, label: "Envío cancelado"},
+	TrackingEventUnknown:        {label: "Actualización de la transportadora"},
+}
+
+// IsValid indica si el código pertenece a la taxonomía
+func (c TrackingEventCode) IsValid() bool {
+	_, ok := trackingEventTaxonomy[c]
+	return ok
+}
+
+// ShipmentStatus estado del envío (y de la orden) que implica el evento.
+// Vacío para unknown: el evento queda en el historial sin mover el estado.
+func (c TrackingEventCode) ShipmentStatus() string {
+	return trackingEventTaxonomy[c].shipmentStatus
+}
+
+// IsTerminal entregado, devuelto y cancelado no se revierten con escaneos posteriores
+func (c TrackingEventCode) IsTerminal() bool {
+	return trackingEventTaxonomy[c].terminal
+}
+
+// Label texto para el cliente final
+func (c TrackingEventCode) Label() string {
+	if info, ok := trackingEventTaxonomy[c]; ok {
+		return info.label
+	}
+	return trackingEventTaxonomy[TrackingEventUnknown].label
+}
+
+// TrackingEventCodes todos los códigos de la taxonomía
+func TrackingEventCodes() []TrackingEventCode {
+	return []TrackingEventCode{
+		TrackingEventLabelCreated, TrackingEventPickedUp, TrackingEventInTransit,
+		TrackingEventOutForDelivery, TrackingEventAttemptFailed, TrackingEventException,
+		TrackingEventDelivered, TrackingEventReturning, TrackingEventReturned,
+		TrackingEventCancelled, TrackingEventUnknown,
+	}
+}
+
+// eventCodeFromShipmentStatus traduce el estado que ya calculó la integración
+// (probability_status) cuando la tabla de la transportadora no conoce el código raw
+var eventCodeFromShipmentStatus = map[string]TrackingEventCode{
+	"pending":          TrackingEventLabelCreated,
+	"picked_up":        TrackingEventPickedUp,
+	"in_transit":       TrackingEventInTransit,
+	"out_for_delivery": TrackingEventOutForDelivery,
+	"on_hold":          TrackingEventException,
+	"failed":           TrackingEventAttemptFailed,
+	"delivered":        TrackingEventDelivered,
+	"returned":         TrackingEventReturned,
+	"cancelled":        TrackingEventCancelled,
+}
+
+// NormalizeRawStatus minúsculas, sin tildes y con espacios colapsados: es la
+// forma en que se guardan los códigos raw en carrier_status_mappings
+func NormalizeRawStatus(s string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	result, _, err := transform.String(t, s)
+	if err != nil {
+		result = s
+	}
+	return strings.Join(strings.Fields(strings.ToLower(result)), " ")
+}
+
+// NormalizeCarrier nombre de transportadora comparable entre proveedores
+func NormalizeCarrier(carrier string) string {
+	return strings.ReplaceAll(NormalizeRawStatus(carrier), " ", "")
+}
+
+// CarrierStatusMapping fila de la tabla de traducción. Carrier vacío aplica a
+// todas las transportadoras del proveedor.
+type CarrierStatusMapping struct {
+	ID        uint              `json:"id"`
+	Provider  string            `json:"provider"`
+	Carrier   string            `json:"carrier"`
+	RawStatus string            `json:"raw_status"`
+	EventCode TrackingEventCode `json:"event_code"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+type statusMappingKey struct {
+	carrier   string
+	rawStatus string
+}
+
+// StatusMappingTable tabla de traducción de un proveedor
+type StatusMappingTable map[statusMappingKey]TrackingEventCode
+
+// NewStatusMappingTable indexa las filas de un proveedor
+func NewStatusMappingTable(mappings []CarrierStatusMapping) StatusMappingTable {
+	table := make(StatusMappingTable, len(mappings))
+	for _, m := range mappings {
+		table[statusMappingKey{NormalizeCarrier(m.Carrier), NormalizeRawStatus(m.RawStatus)}] = m.EventCode
+	}
+	return table
+}
+
+// Resolve traduce un evento raw. Orden: detalle y estado en la tabla de la
+// transportadora, luego en la del proveedor, luego el estado que mapeó la
+// integración; si nada aplica el evento queda como unknown.
+func (t StatusMappingTable) Resolve(input TrackingEventInput) (TrackingEventCode, string) {
+	carrier := NormalizeCarrier(input.Carrier)
+	detail := NormalizeRawStatus(input.RawStatusDetail)
+	raw := NormalizeRawStatus(input.RawStatus)
+
+	scopes := []struct {
+		carrier string
+		source  string
+	}{{carrier, MappingSourceCarrier}, {"", MappingSourceProvider}}
+	if carrier == "" {
+		scopes = scopes[1:]
+	}
+	for _, scope := range scopes {
+		for _, value := range []string{detail, raw} {
+			if value == "" {
+				continue
+			}
+			if code, ok := t[statusMappingKey{scope.carrier, value}]; ok {
+				return code, scope.source
+			}
+		}
+	}
+
+	if code, ok := eventCodeFromShipmentStatus[strings.ToLower(strings.TrimSpace(input.IntegrationStatus))]; ok {
+		return code, MappingSourceIntegration
+	}
+	return TrackingEventUnknown, MappingSourceUnmapped
+}
+
+// TrackingEventInput evento tal como lo reporta el proveedor
+type TrackingEventInput struct {
+	Carrier           string
+	RawStatus         string
+	RawStatusDetail   string
+	Description       string
+	Location          string
+	IntegrationStatus string // probability_status calculado por la integración, si lo hay
+	HasIncidence      bool
+	OccurredAt        time.Time
+}
+
+// ShipmentTrackingEvent evento normalizado del historial de un envío
+type ShipmentTrackingEvent struct {
+	ID              uint              `json:"id"`
+	ShipmentID      uint              `json:"shipment_id"`
+	BusinessID      uint              `json:"business_id"`
+	Provider        string            `json:"provider"`
+	Carrier         string            `json:"carrier"`
+	Source          string            `json:"source"`
+	DedupKey        string            `json:"-"`
+	EventCode       TrackingEventCode `json:"event_code"`
+	ShipmentStatus  string            `json:"shipment_status,omitempty"`
+	MappingSource   string            `json:"mapping_source"`
+	RawStatus       string            `json:"raw_status"`
+	RawStatusDetail string            `json:"raw_status_detail,omitempty"`
+	Description     string            `json:"description,omitempty"`
+	Location        string            `json:"location,omitempty"`
+	HasIncidence    bool              `json:"has_incidence"`
+	OccurredAt      time.Time         `json:"occurred_at"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+// TrackingDedupKey identifica un escaneo: el mismo evento llega por webhook y
+// por consulta de tracking, y los proveedores reenvían webhooks
+func TrackingDedupKey(shipmentID uint, input TrackingEventInput) string {
+	raw := fmt.Sprintf("%d|%s|%s|%s|%d",
+		shipmentID,
+		NormalizeRawStatus(input.RawStatus),
+		NormalizeRawStatus(input.RawStatusDetail),
+		NormalizeRawStatus(input.Description),
+		input.OccurredAt.UTC().Unix(),
+	)
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+// CurrentTrackingEvent evento que define el estado actual del envío: el más
+// reciente que mueve estado, salvo que ya haya un evento terminal, que gana
+// sobre cualquier escaneo posterior. Nil si ningún evento mueve estado.
+func CurrentTrackingEvent(events []ShipmentTrackingEvent) *ShipmentTrackingEvent {
+	ordered := make([]ShipmentTrackingEvent, 0, len(events))
+	for _, e := range events {
+		if e.EventCode.ShipmentStatus() != "" {
+			ordered = append(ordered, e)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if !ordered[i].OccurredAt.Equal(ordered[j].OccurredAt) {
+			return ordered[i].OccurredAt.Before(ordered[j].OccurredAt)
+		}
+		return ordered[i].ID < ordered[j].ID
+	})
+
+	var current, terminal *ShipmentTrackingEvent
+	for i := range ordered {
+		current = &ordered[i]
+		if current.EventCode.IsTerminal() {
+			terminal = current
+		}
+	}
+	if terminal != nil {
+		return terminal
+	}
+	return current
+}
+
+// FirstTrackingEventAt fecha del primer evento con alguno de los códigos
+func FirstTrackingEventAt(events []ShipmentTrackingEvent, codes ...TrackingEventCode) *time.Time {
+	var first *time.Time
+	for _, e := range events {
+		for _, code := range codes {
+			if e.EventCode == code && (first == nil || e.OccurredAt.Before(*first)) {
+				t := e.OccurredAt
+				first = &t
+			}
+		}
+	}
+	return first
+}
+
+// TrackingTimelineEntry evento del timeline para el cliente final: sin códigos raw
+type TrackingTimelineEntry struct {
+	EventCode    TrackingEventCode `json:"event_code"`
+	Label        string            `json:"label"`
+	Description  string            `json:"description,omitempty"`
+	Location     string            `json:"location,omitempty"`
+	HasIncidence bool              `json:"has_incidence"`
+	OccurredAt   time.Time         `json:"occurred_at"`
+}
+
+// TrackingTimeline historial normalizado de un envío
+type TrackingTimeline struct {
+	ShipmentID     uint                    `json:"shipment_id"`
+	TrackingNumber string                  `json:"tracking_number,omitempty"`
+	Carrier        string                  `json:"carrier,omitempty"`
+	Status         string                  `json:"status"`
+	CurrentEvent   *TrackingEventCode      `json:"current_event,omitempty"`
+	ShippedAt      *time.Time              `json:"shipped_at,omitempty"`
+	DeliveredAt    *time.Time              `json:"delivered_at,omitempty"`
+	Events         []ShipmentTrackingEvent `json:"events,omitempty"`
+	Timeline       []TrackingTimelineEntry `json:"timeline"`
+}
+
+// TrackingMetricsFilter rango por fecha de creación del envío
+type TrackingMetricsFilter struct {
+	BusinessID uint
+	Carrier    string
+	From       time.Time
+	To         time.Time
+}
+
+// TrackingEventDwell tiempo promedio que los envíos permanecen en una etapa
+// (desde el primer evento con ese código hasta el siguiente evento distinto)
+type TrackingEventDwell struct {
+	EventCode TrackingEventCode `json:"event_code"`
+	Stages    int64             `json:"stages"`
+	AvgHours  float64           `json:"avg_hours"`
+}
+
+// CarrierTrackingMetrics desempeño de una transportadora en el rango
+type CarrierTrackingMetrics struct {
+	Carrier               string               `json:"carrier"`
+	Shipments             int64                `json:"shipments"`
+	Delivered             int64                `json:"delivered"`
+	FirstAttemptDelivered int64                `json:"first_attempt_delivered"`
+	FirstAttemptRate      float64              `json:"first_attempt_rate"`
+	Returned              int64                `json:"returned"`
+	AvgTransitHours       *float64             `json:"avg_transit_hours"`
+	Dwell                 []TrackingEventDwell `json:"dwell"`
+}
+
+// UnmappedTrackingStatus código raw que no está en la tabla de traducción
+type UnmappedTrackingStatus struct {
+	Provider      string            `json:"provider"`
+	Carrier       string            `json:"carrier"`
+	RawStatus     string            `json:"raw_status"`
+	MappedAs      TrackingEventCode `json:"mapped_as"`
+	MappingSource string            `json:"mapping_source"`
+	Occurrences   int64             `json:"occurrences"`
+	LastSeenAt    time.Time         `json:"last_seen_at"`
+}
+
+// ITrackingRepository historial de eventos y tablas de traducción por transportadora
+type ITrackingRepository interface {
+	// InsertTrackingEvents guarda los eventos ignorando los que ya existen
+	// (por dedup_key) y retorna cuántos fueron nuevos
+	InsertTrackingEvents(ctx context.Context, events []ShipmentTrackingEvent) (int, error)
+	ListTrackingEvents(ctx context.Context, shipmentID uint) ([]ShipmentTrackingEvent, error)
+
+	ListCarrierStatusMappings(ctx context.Context, provider string) ([]CarrierStatusMapping, error)
+	UpsertCarrierStatusMapping(ctx context.Context, mapping *CarrierStatusMapping) error
+	ListUnmappedTrackingStatuses(ctx context.Context, provider string, limit int) ([]UnmappedTrackingStatus, error)
+
+	GetCarrierTrackingMetrics(ctx context.Context, filter TrackingMetricsFilter) ([]CarrierTrackingMetrics, error)
+}
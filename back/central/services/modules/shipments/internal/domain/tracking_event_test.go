@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func testMappingTable() StatusMappingTable {
+	return NewStatusMappingTable([]CarrierStatusMapping{
+		{Provider: "envioclick", Carrier: "", RawStatus: "En Transito", EventCode: TrackingEventInTransit},
+		{Provider: "envioclick", Carrier: "", RawStatus: "Novedad", EventCode: TrackingEventException},
+		{Provider: "envioclick", Carrier: "Servientrega", RawStatus: "Direccion errada", EventCode: TrackingEventAttemptFailed},
+	})
+}
+
+func TestResolve_DetalleDeTransportadoraGanaSobreElPaso(t *testing.T) {
+	code, source := testMappingTable().Resolve(TrackingEventInput{
+		Carrier:         "SERVIENTREGA",
+		RawStatus:       "Novedad",
+		RawStatusDetail: "Dirección errada",
+	})
+
+	if code != TrackingEventAttemptFailed || source != MappingSourceCarrier {
+		t.Fatalf("esperaba attempt_failed/carrier, obtuve %s/%s", code, source)
+	}
+}
+
+func TestResolve_OtraTransportadoraUsaLaTablaDelProveedor(t *testing.T) {
+	code, source := testMappingTable().Resolve(TrackingEventInput{
+		Carrier:         "Envia",
+		RawStatus:       "Novedad",
+		RawStatusDetail: "Direccion errada",
+	})
+
+	if code != TrackingEventException || source != MappingSourceProvider {
+		t.Fatalf("esperaba exception/provider, obtuve %s/%s", code, source)
+	}
+}
+
+func TestResolve_SinTraduccionUsaElEstadoDeLaIntegracion(t *testing.T) {
+	code, source := testMappingTable().Resolve(TrackingEventInput{
+		RawStatus:         "Paquete en bodega destino",
+		IntegrationStatus: "in_transit",
+	})
+
+	if code != TrackingEventInTransit || source != MappingSourceIntegration {
+		t.Fatalf("esperaba in_transit/integration, obtuve %s/%s", code, source)
+	}
+}
+
+func TestResolve_CodigoDesconocidoQuedaUnknown(t *testing.T) {
+	code, source := testMappingTable().Resolve(TrackingEventInput{RawStatus: "algo nuevo"})
+
+	if code != TrackingEventUnknown || source != MappingSourceUnmapped {
+		t.Fatalf("esperaba unknown/unmapped, obtuve %s/%s", code, source)
+	}
+	if code.ShipmentStatus() != "" {
+		t.Fatalf("unknown no debe mover el estado del envío")
+	}
+}
+
+func TestCurrentTrackingEvent_WebhookAtrasadoNoRetrocede(t *testing.T) {
+	base := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	events := []ShipmentTrackingEvent{
+		{ID: 1, EventCode: TrackingEventPickedUp, OccurredAt: base},
+		{ID: 2, EventCode: TrackingEventOutForDelivery, OccurredAt: base.Add(48 * time.Hour)},
+		// llegó después pero ocurrió antes
+		{ID: 3, EventCode: TrackingEventInTransit, OccurredAt: base.Add(24 * time.Hour)},
+		{ID: 4, EventCode: TrackingEventUnknown, OccurredAt: base.Add(72 * time.Hour)},
+	}
+
+	current := CurrentTrackingEvent(events)
+
+	if current == nil || current.EventCode != TrackingEventOutForDelivery {
+		t.Fatalf("esperaba out_for_delivery, obtuve %+v", current)
+	}
+}
+
+func TestCurrentTrackingEvent_TerminalGanaSobreEscaneosPosteriores(t *testing.T) {
+	base := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	events := []ShipmentTrackingEvent{
+		{ID: 1, EventCode: TrackingEventInTransit, OccurredAt: base},
+		{ID: 2, EventCode: TrackingEventDelivered, OccurredAt: base.Add(24 * time.Hour)},
+		{ID: 3, EventCode: TrackingEventInTransit, OccurredAt: base.Add(30 * time.Hour)},
+	}
+
+	current := CurrentTrackingEvent(events)
+
+	if current == nil || current.EventCode != TrackingEventDelivered {
+		t.Fatalf("esperaba delivered, obtuve %+v", current)
+	}
+}
+
+func TestTrackingDedupKey_IgnoraMayusculasYTildes(t *testing.T) {
+	at := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	a := TrackingDedupKey(7, TrackingEventInput{RawStatus: "En Tránsito", OccurredAt: at})
+	b := TrackingDedupKey(7, TrackingEventInput{RawStatus: "en transito", OccurredAt: at.In(time.FixedZone("COT", -5*3600))})
+
+	if a != b {
+		t.Fatalf("el mismo escaneo debe producir la misma llave")
+	}
+	if a == TrackingDedupKey(8, TrackingEventInput{RawStatus: "En Tránsito", OccurredAt: at}) {
+		t.Fatalf("envíos distintos no deben compartir llave")
+	}
+}
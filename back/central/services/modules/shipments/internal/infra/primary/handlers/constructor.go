@@ -11,6 +11,7 @@ import (
 type Handlers struct {
 	uc              *usecases.UseCases
 	transportPub    domain.ITransportRequestPublisher // Async: quote, generate, track, cancel
+	wallet          domain.IGuideWallet               // Holds the guide cost in the business wallet (pay module)
 	carrierResolver domain.ICarrierResolver           // Resolves active shipping carrier per business
 	redisClient     redis.IRedis                      // Used for synchronous quote polling
 	tokenSecret     string                            // Seed for per-integration WooCommerce shipping tokens
@@ -21,10 +22,11 @@ type Handlers struct {
 }
 
 // New crea una nueva instancia de Handlers
-func New(uc *usecases.UseCases, transportPub domain.ITransportRequestPublisher, carrierResolver domain.ICarrierResolver, redisClient redis.IRedis, tokenSecret, pluginBaseURL string, ratesLimiter ratelimit.Limiter, geocoder domain.IGeocoder) *Handlers {
+func New(uc *usecases.UseCases, transportPub domain.ITransportRequestPublisher, wallet domain.IGuideWallet, carrierResolver domain.ICarrierResolver, redisClient redis.IRedis, tokenSecret, pluginBaseURL string, ratesLimiter ratelimit.Limiter, geocoder domain.IGeocoder) *Handlers {
 	return &Handlers{
 		uc:              uc,
 		transportPub:    transportPub,
+		wallet:          wallet,
 		carrierResolver: carrierResolver,
 		redisClient:     redisClient,
 		tokenSecret:     tokenSecret,
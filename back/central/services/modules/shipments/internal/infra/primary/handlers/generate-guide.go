@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -113,6 +114,7 @@ func (h *Handlers) GenerateGuide(c *gin.Context) {
 			Height:        shipmentReq.Height,
 			Width:         shipmentReq.Width,
 			Length:        shipmentReq.Length,
+			Packages:      shipmentReq.Packages,
 		}
 		if _, err := h.uc.UpdateShipment(c.Request.Context(), shipmentID, updateReq); err != nil {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al actualizar envio: " + err.Error()})
@@ -124,6 +126,23 @@ func (h *Handlers) GenerateGuide(c *gin.Context) {
 
 	correlationID := uuid.New().String()
 
+	// Se retiene el costo de la guia; se cobra cuando la transportadora confirma
+	// y se libera si la generacion falla o la guia se cancela
+	if shipmentReq.TotalCost != nil && *shipmentReq.TotalCost > 0 {
+		err := h.wallet.HoldForGuide(c.Request.Context(), businessID, shipmentID, *shipmentReq.TotalCost, correlationID)
+		switch {
+		case errors.Is(err, domain.ErrInsufficientWalletBalance):
+			c.JSON(http.StatusPaymentRequired, gin.H{
+				"error": "Saldo insuficiente en la billetera para generar la guia",
+				"code":  "insufficient_wallet_balance",
+			})
+			return
+		case err != nil && !errors.Is(err, domain.ErrWalletNotFound):
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Error al reservar saldo de la billetera: " + err.Error()})
+			return
+		}
+	}
+
 	effectiveBaseURL := carrier.BaseURL
 	if carrier.IsTesting && carrier.BaseURLTest != "" {
 		effectiveBaseURL = carrier.BaseURLTest
@@ -146,6 +165,7 @@ func (h *Handlers) GenerateGuide(c *gin.Context) {
 	}
 
 	if err := h.transportPub.PublishTransportRequest(c.Request.Context(), msg); err != nil {
+		_ = h.wallet.ReleaseGuideHolds(c.Request.Context(), shipmentID)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": "Error al enviar solicitud de generacion de guia: " + err.Error(),
 		})
@@ -244,22 +264,9 @@ func buildShipmentRequest(raw map[string]interface{}, carrier *domain.CarrierInf
 		req.DestinationSuburb = suburb
 	}
 
-	if pkgs, ok := raw["packages"].([]interface{}); ok && len(pkgs) > 0 {
-		if pkg, ok := pkgs[0].(map[string]interface{}); ok {
-			if v, ok := pkg["weight"].(float64); ok {
-				req.Weight = float64Ptr(v)
-			}
-			if v, ok := pkg["height"].(float64); ok {
-				req.Height = float64Ptr(v)
-			}
-			if v, ok := pkg["width"].(float64); ok {
-				req.Width = float64Ptr(v)
-			}
-			if v, ok := pkg["length"].(float64); ok {
-				req.Length = float64Ptr(v)
-			}
-		}
-	}
+	// Cada paquete se guarda con sus dimensiones; el envío queda con el consolidado
+	req.Packages = domain.ParsePackages(raw)
+	req.Weight, req.Height, req.Width, req.Length = domain.ConsolidatePackages(req.Packages)
 
 	return req
 }
@@ -6,9 +6,13 @@ import (
 	"github.com/secamc93/probability/back/central/shared/ratelimit"
 )
 
+// shipmentsResource es el recurso de permisos con el que se otorgan las API Keys
+const shipmentsResource = "Envios"
+
 func (h *Handlers) RegisterRoutes(router *gin.RouterGroup) {
 	router.GET("/tracking/search", h.PublicSearchTracking)
 	router.GET("/tracking/:tracking_number/history", h.PublicGetTrackingHistory)
+	router.GET("/tracking/:tracking_number/timeline", h.PublicGetTrackingTimeline)
 
 	router.POST("/shopify/shipping-rates/:integration_id",
 		ratelimit.Gin(h.ratesLimiter, ratelimit.FirstNonEmpty(
@@ -39,17 +43,29 @@ func (h *Handlers) RegisterRoutes(router *gin.RouterGroup) {
 	wooAuth.POST("/connection-info/:integration_id/rotate", h.WooCommerceRotateToken)
 	wooAuth.POST("/connection-info/:integration_id/revoke", h.WooCommerceRevokeToken)
 
-	shipments := router.Group("/shipments", middleware.JWT())
+	// Lo que consumen las integraciones acepta también API Key, con el
+	// permiso de Envios que corresponde a cada ruta
+	api := router.Group("/shipments", middleware.Auto())
 	{
-		shipments.GET("", h.ListShipments)
-		shipments.GET("/:id", h.GetShipmentByID)
-		shipments.POST("", h.CreateShipment)
-		shipments.PUT("/:id", h.UpdateShipment)
-		shipments.DELETE("/:id", h.DeleteShipment)
+		api.GET("", middleware.RequirePermission(shipmentsResource, "Read"), h.ListShipments)
+		api.GET("/:id", middleware.RequirePermission(shipmentsResource, "Read"), h.GetShipmentByID)
+		api.POST("", middleware.RequirePermission(shipmentsResource, "Create"), h.CreateShipment)
+		api.PUT("/:id", middleware.RequirePermission(shipmentsResource, "Update"), h.UpdateShipment)
+		api.DELETE("/:id", middleware.RequirePermission(shipmentsResource, "Delete"), h.DeleteShipment)
 
-		shipments.GET("/order/:order_id", h.GetShipmentsByOrderID)
-		shipments.GET("/tracking/:tracking_number", h.GetShipmentByTrackingNumber)
+		api.GET("/order/:order_id", middleware.RequirePermission(shipmentsResource, "Read"), h.GetShipmentsByOrderID)
+		api.GET("/tracking/:tracking_number", middleware.RequirePermission(shipmentsResource, "Read"), h.GetShipmentByTrackingNumber)
+		api.GET("/:id/timeline", middleware.RequirePermission(shipmentsResource, "Read"), h.GetShipmentTimeline)
+		api.GET("/:id/guide", middleware.RequirePermission(shipmentsResource, "Read"), h.RenderGuide)
+
+		api.POST("/quote", middleware.RequirePermission(shipmentsResource, "Read"), h.QuoteShipment)
+		api.POST("/generate", middleware.RequirePermission(shipmentsResource, "Create"), h.GenerateGuide)
+		api.POST("/tracking/:tracking_number/track", middleware.RequirePermission(shipmentsResource, "Update"), h.TrackShipment)
+		api.POST("/:id/cancel", middleware.RequirePermission(shipmentsResource, "Update"), h.CancelShipment)
+	}
 
+	shipments := router.Group("/shipments", middleware.JWT())
+	{
 		shipments.GET("/quotes", h.ListSavedQuotes)
 		shipments.GET("/quotes/:id", h.GetSavedQuote)
 		shipments.PATCH("/quotes/:id/associate", h.AssociateSavedQuote)
@@ -61,18 +77,18 @@ func (h *Handlers) RegisterRoutes(router *gin.RouterGroup) {
 		shipments.PUT("/origin-addresses/:id", h.UpdateOriginAddress)
 		shipments.DELETE("/origin-addresses/:id", h.DeleteOriginAddress)
 
-		shipments.POST("/quote", h.QuoteShipment)
-		shipments.POST("/generate", h.GenerateGuide)
 		shipments.GET("/guide-formats", h.ListGuideFormats)
-		shipments.GET("/:id/guide", h.RenderGuide)
 		shipments.POST("/:id/extract-coordinadora-data", h.ExtractCoordinadoraData)
-		shipments.POST("/tracking/:tracking_number/track", h.TrackShipment)
-		shipments.POST("/:id/cancel", h.CancelShipment)
 		shipments.POST("/cancel-batch", h.CancelBatchShipments)
 		shipments.POST("/sync-status", h.SyncShipmentStatus)
 
 		shipments.GET("/stats/by-geozone", h.StatsByGeozone)
 
+		shipments.GET("/tracking/metrics", h.TrackingMetrics)
+		shipments.GET("/tracking/status-mappings", h.ListTrackingStatusMappings)
+		shipments.PUT("/tracking/status-mappings", h.UpsertTrackingStatusMapping)
+		shipments.GET("/tracking/unmapped-statuses", h.ListUnmappedTrackingStatuses)
+
 		shipments.GET("/cod", h.ListCODShipments)
 		shipments.POST("/:id/collect-cod", h.CollectCOD)
 
@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/app/usecasetracking"
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
+)
+
+// GetShipmentTimeline godoc
+// @Summary      Línea de tiempo del envío
+// @Description  Historial normalizado de eventos de la transportadora, con el código raw de cada escaneo
+// @Tags         Shipments
+// @Produce      json
+// @Param        id   path      int  true  "ID del envío"
+// @Security     BearerAuth
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /shipments/{id}/timeline [get]
+func (h *Handlers) GetShipmentTimeline(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "ID de envío inválido"})
+		return
+	}
+
+	if !middleware.IsSuperAdmin(c) {
+		businessID, _ := middleware.GetBusinessID(c)
+		owner, err := h.uc.Repo().GetShipmentBusinessIDByID(c.Request.Context(), uint(id))
+		if err != nil || owner != businessID {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "message": "Envío no encontrado"})
+			return
+		}
+	}
+
+	timeline, err := h.uc.Tracking.Timeline(c.Request.Context(), uint(id))
+	if err != nil {
+		if errors.Is(err, domain.ErrShipmentNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "message": "Envío no encontrado"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Error al obtener la línea de tiempo", "error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": timeline})
+}
+
+// PublicGetTrackingTimeline godoc
+// @Summary      Línea de tiempo pública
+// @Description  Historial normalizado de un envío por número de guía (sin autenticación)
+// @Tags         Tracking
+// @Produce      json
+// @Param        tracking_number   path      string  true  "Número de tracking"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      404  {object}  map[string]interface{}
+// @Router       /tracking/{tracking_number}/timeline [get]
+func (h *Handlers) PublicGetTrackingTimeline(c *gin.Context) {
+	trackingNumber := c.Param("tracking_number")
+	if trackingNumber == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Número de tracking requerido"})
+		return
+	}
+
+	timeline, err := h.uc.Tracking.PublicTimeline(c.Request.Context(), trackingNumber)
+	if err != nil {
+		if errors.Is(err, domain.ErrShipmentNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"success": false, "message": "Envío no encontrado"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": "Error al obtener la línea de tiempo"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": timeline})
+}
+
+// TrackingMetrics tiempo por etapa y entrega al primer intento por transportadora.
+// Rango por fecha de creación del envío; por defecto los últimos 30 días.
+func (h *Handlers) TrackingMetrics(c *gin.Context) {
+	businessID, ok := h.resolveBusinessIDParam(c)
+	if !ok && !middleware.IsSuperAdmin(c) {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "business_id is required"})
+		return
+	}
+
+	now := time.Now()
+	filter := domain.TrackingMetricsFilter{
+		BusinessID: businessID,
+		Carrier:    c.Query("carrier"),
+		From:       now.AddDate(0, 0, -30),
+		To:         now,
+	}
+	if v := c.Query("from"); v != "" {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			filter.From = t
+		}
+	}
+	if v := c.Query("to"); v != "" {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			filter.To = t
+		}
+	}
+
+	metrics, err := h.uc.Tracking.CarrierMetrics(c.Request.Context(), filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": metrics})
+}
+
+// ListTrackingStatusMappings tabla de traducción de códigos raw a la taxonomía
+func (h *Handlers) ListTrackingStatusMappings(c *gin.Context) {
+	mappings, err := h.uc.Tracking.ListMappings(c.Request.Context(), c.Query("provider"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": true,
+		"data": gin.H{
+			"mappings":    mappings,
+			"event_codes": domain.TrackingEventCodes(),
+		},
+	})
+}
+
+type upsertTrackingStatusMappingRequest struct {
+	Provider  string `json:"provider" binding:"required"`
+	Carrier   string `json:"carrier"`
+	RawStatus string `json:"raw_status" binding:"required"`
+	EventCode string `json:"event_code" binding:"required"`
+}
+
+// UpsertTrackingStatusMapping crea o corrige una traducción (solo super admin)
+func (h *Handlers) UpsertTrackingStatusMapping(c *gin.Context) {
+	if !middleware.IsSuperAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"success": false, "message": "Solo super admin puede editar la tabla de estados"})
+		return
+	}
+
+	var req upsertTrackingStatusMappingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": "Datos inválidos", "error": err.Error()})
+		return
+	}
+
+	mapping := &domain.CarrierStatusMapping{
+		Provider:  req.Provider,
+		Carrier:   req.Carrier,
+		RawStatus: req.RawStatus,
+		EventCode: domain.TrackingEventCode(req.EventCode),
+	}
+	if err := h.uc.Tracking.UpsertMapping(c.Request.Context(), mapping); err != nil {
+		if errors.Is(err, usecasetracking.ErrInvalidEventCode) || errors.Is(err, usecasetracking.ErrMappingIncomplete) {
+			c.JSON(http.StatusBadRequest, gin.H{"success": false, "message": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "message": "Traducción guardada", "data": mapping})
+}
+
+// ListUnmappedTrackingStatuses códigos raw sin traducción propia (solo super admin)
+func (h *Handlers) ListUnmappedTrackingStatuses(c *gin.Context) {
+	if !middleware.IsSuperAdmin(c) {
+		c.JSON(http.StatusForbidden, gin.H{"success": false, "message": "Solo super admin puede ver los estados sin traducir"})
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	statuses, err := h.uc.Tracking.ListUnmapped(c.Request.Context(), c.Query("provider"), limit)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"success": false, "message": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true, "data": statuses})
+}
@@ -125,9 +125,9 @@ func (h *Handlers) WooCommerceShippingRates(c *gin.Context) {
 		return
 	}
 
-	pkg := h.resolveWooPackageDimensions(ctx, businessID, resolved, req)
+	pkgs := h.resolveWooPackages(ctx, businessID, resolved, req)
 
-	payload := buildWooQuotePayload(req, resolved.Origin, normalizeDaneCode(destDane), pkg)
+	payload := buildWooQuotePayload(req, resolved.Origin, normalizeDaneCode(destDane), pkgs)
 
 	correlationID := uuid.New().String()
 	result, err := h.runQuote(ctx, carrier, businessID, payload, correlationID, 12*time.Second)
@@ -204,16 +204,11 @@ func normalizeDaneCode(code string) string {
 	return code
 }
 
-type wooPackageDims struct {
-	Weight float64
-	Length float64
-	Width  float64
-	Height float64
-}
-
-func (h *Handlers) resolveWooPackageDimensions(ctx context.Context, businessID uint, resolved *wooResolved, req wooRateRequest) wooPackageDims {
+// resolveWooPackages bultos a cotizar. Con cajas estándar en la bodega de origen
+// los ítems se reparten en tantas cajas como hagan falta; si no, un solo paquete
+// con el peso total y las dimensiones del producto más grande.
+func (h *Handlers) resolveWooPackages(ctx context.Context, businessID uint, resolved *wooResolved, req wooRateRequest) []domain.ShipmentPackage {
 	var totalGrams float64
-	var totalQuantity int
 	skus := make([]string, 0, len(req.Contents))
 	for _, it := range req.Contents {
 		qty := it.Quantity
@@ -221,7 +216,6 @@ func (h *Handlers) resolveWooPackageDimensions(ctx context.Context, businessID u
 			qty = 1
 		}
 		totalGrams += it.WeightGrams * float64(qty)
-		totalQuantity += qty
 		if it.Sku != "" {
 			skus = append(skus, it.Sku)
 		}
@@ -232,9 +226,11 @@ func (h *Handlers) resolveWooPackageDimensions(ctx context.Context, businessID u
 		weightKg = 1
 	}
 
+	var dims map[string]domain.ProductDimensions
 	var maxLength, maxWidth, maxHeight float64
 	if businessID > 0 && len(skus) > 0 {
-		dims, err := h.uc.Repo().GetProductDimensionsBySKUs(ctx, businessID, skus)
+		var err error
+		dims, err = h.uc.Repo().GetProductDimensionsBySKUs(ctx, businessID, skus)
 		if err == nil {
 			for _, d := range dims {
 				if d.Length != nil && *d.Length > maxLength {
@@ -252,34 +248,59 @@ func (h *Handlers) resolveWooPackageDimensions(ctx context.Context, businessID u
 
 	if resolved.OriginIsWarehouse && resolved.PackageConfig != nil &&
 		resolved.PackageConfig.Strategy == domain.ShippingPackageStrategyStandardBox {
-		if box := resolved.PackageConfig.SelectBox(totalQuantity, maxLength, maxWidth, maxHeight); box != nil {
-			out := wooPackageDims{Weight: weightKg, Length: maxLength, Width: maxWidth, Height: maxHeight}
-			if box.Weight != nil {
-				out.Weight = *box.Weight
-			}
-			if box.Length != nil {
-				out.Length = *box.Length
-			}
-			if box.Width != nil {
-				out.Width = *box.Width
-			}
-			if box.Height != nil {
-				out.Height = *box.Height
+		items := make([]domain.PackedItem, 0, len(req.Contents))
+		for _, it := range req.Contents {
+			qty := it.Quantity
+			if qty <= 0 {
+				qty = 1
 			}
-			if out.Length > 0 && out.Width > 0 && out.Height > 0 {
-				return out
+			item := domain.PackedItem{SKU: it.Sku, Name: it.Name, Quantity: qty, Weight: it.WeightGrams / 1000.0, Price: it.Price}
+			if d, ok := dims[it.Sku]; ok {
+				item.Length, item.Width, item.Height = floatOrZero(d.Length), floatOrZero(d.Width), floatOrZero(d.Height)
 			}
+			items = append(items, item)
+		}
+		if pkgs := resolved.PackageConfig.PlanPackages(items); completeWooPackages(pkgs) {
+			return pkgs
 		}
 	}
 
+	pkg := domain.ShipmentPackage{Sequence: 1, Weight: &weightKg}
 	if maxLength > 0 && maxWidth > 0 && maxHeight > 0 {
-		return wooPackageDims{Weight: weightKg, Length: maxLength, Width: maxWidth, Height: maxHeight}
+		pkg.Length, pkg.Width, pkg.Height = &maxLength, &maxWidth, &maxHeight
+	} else {
+		d := defaultPackageDimCm
+		pkg.Length, pkg.Width, pkg.Height = &d, &d, &d
 	}
+	return []domain.ShipmentPackage{pkg}
+}
+
+// completeWooPackages las transportadoras rechazan paquetes sin dimensiones; si
+// alguna caja no las tiene configuradas se cotiza como un solo paquete
+func completeWooPackages(pkgs []domain.ShipmentPackage) bool {
+	if len(pkgs) == 0 {
+		return false
+	}
+	for i := range pkgs {
+		if floatOrZero(pkgs[i].Length) <= 0 || floatOrZero(pkgs[i].Width) <= 0 || floatOrZero(pkgs[i].Height) <= 0 {
+			return false
+		}
+		if floatOrZero(pkgs[i].Weight) <= 0 {
+			w := 1.0
+			pkgs[i].Weight = &w
+		}
+	}
+	return true
+}
 
-	return wooPackageDims{Weight: weightKg, Length: defaultPackageDimCm, Width: defaultPackageDimCm, Height: defaultPackageDimCm}
+func floatOrZero(v *float64) float64 {
+	if v == nil {
+		return 0
+	}
+	return *v
 }
 
-func buildWooQuotePayload(req wooRateRequest, origin *domain.OriginAddress, destDane string, pkgDims wooPackageDims) map[string]interface{} {
+func buildWooQuotePayload(req wooRateRequest, origin *domain.OriginAddress, destDane string, pkgs []domain.ShipmentPackage) map[string]interface{} {
 	dest := req.Destination
 
 	firstName, lastName := splitName(dest.Name)
@@ -298,19 +319,12 @@ func buildWooQuotePayload(req wooRateRequest, origin *domain.OriginAddress, dest
 		contentValue += it.Price * float64(qty)
 	}
 
-	pkg := map[string]interface{}{
-		"weight": pkgDims.Weight,
-		"height": pkgDims.Height,
-		"width":  pkgDims.Width,
-		"length": pkgDims.Length,
-	}
-
 	return map[string]interface{}{
 		"requestPickup": false,
 		"insurance":     false,
 		"description":   "Compra en linea",
 		"contentValue":  contentValue,
-		"packages":      []interface{}{pkg},
+		"packages":      domain.PayloadPackages(pkgs),
 		"origin": map[string]interface{}{
 			"company":   origin.Company,
 			"firstName": origin.FirstName,
@@ -234,14 +234,8 @@ func buildGenerateShipmentReq(payload map[string]interface{}, carrier *domain.Ca
 		req.DestinationAddress = strFromAny(dest["address"])
 		req.DestinationSuburb = strFromAny(dest["suburb"])
 	}
-	if pkgs, ok := payload["packages"].([]interface{}); ok && len(pkgs) > 0 {
-		if pkg, ok := pkgs[0].(map[string]interface{}); ok {
-			req.Weight = floatPtrFromAny(pkg["weight"])
-			req.Height = floatPtrFromAny(pkg["height"])
-			req.Width = floatPtrFromAny(pkg["width"])
-			req.Length = floatPtrFromAny(pkg["length"])
-		}
-	}
+	req.Packages = domain.ParsePackages(payload)
+	req.Weight, req.Height, req.Width, req.Length = domain.ConsolidatePackages(req.Packages)
 	return req
 }
 
@@ -285,20 +279,3 @@ func strFromAny(v interface{}) string {
 	}
 	return fmt.Sprintf("%v", v)
 }
-
-func floatPtrFromAny(v interface{}) *float64 {
-	switch n := v.(type) {
-	case float64:
-		return &n
-	case float32:
-		f := float64(n)
-		return &f
-	case int:
-		f := float64(n)
-		return &f
-	case int64:
-		f := float64(n)
-		return &f
-	}
-	return nil
-}
@@ -63,17 +63,17 @@ func (c *OrderCreatedConsumer) Start(ctx context.Context) error {
 
 	c.log.Info(ctx).Str("queue", QueueOrderCreatedForShipments).Msg("Starting order created consumer for shipments")
 
-	if err := c.queue.Consume(ctx, QueueOrderCreatedForShipments, c.handle); err != nil {
+	if err := rabbitmq.ConsumeDeliveries(ctx, c.queue, QueueOrderCreatedForShipments, c.handle, 1); err != nil {
 		return fmt.Errorf("failed to start consuming: %w", err)
 	}
 	return nil
 }
 
-func (c *OrderCreatedConsumer) handle(message []byte) error {
-	ctx := context.Background()
+func (c *OrderCreatedConsumer) handle(msgCtx context.Context, d rabbitmq.Delivery) error {
+	ctx := context.WithoutCancel(msgCtx)
 
 	var msg orderCreatedMessage
-	if err := json.Unmarshal(message, &msg); err != nil {
+	if err := json.Unmarshal(d.Body, &msg); err != nil {
 		c.log.Error(ctx).Err(err).Msg("Failed to unmarshal order event")
 		return nil
 	}
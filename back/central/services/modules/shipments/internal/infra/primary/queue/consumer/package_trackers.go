@@ -0,0 +1,22 @@
+package consumer
+
+import "fmt"
+
+// packageTrackers guías por paquete de la respuesta de generate, en el orden de
+// la secuencia. Las integraciones que las devuelven las dejan en packageTrackers;
+// sin ellas el envío solo tiene la guía maestra.
+func packageTrackers(dataField map[string]interface{}) []string {
+	list, _ := dataField["packageTrackers"].([]interface{})
+	trackers := make([]string, 0, len(list))
+	for _, item := range list {
+		switch v := item.(type) {
+		case string:
+			trackers = append(trackers, v)
+		case float64:
+			trackers = append(trackers, fmt.Sprintf("%.0f", v))
+		default:
+			trackers = append(trackers, "")
+		}
+	}
+	return trackers
+}
@@ -16,14 +16,16 @@ const (
 )
 
 type WalletReconciliationWorker struct {
-	repo domain.IRepository
-	log  log.ILogger
+	repo   domain.IRepository
+	wallet domain.IGuideWallet
+	log    log.ILogger
 }
 
-func NewWalletReconciliationWorker(repo domain.IRepository, logger log.ILogger) *WalletReconciliationWorker {
+func NewWalletReconciliationWorker(repo domain.IRepository, wallet domain.IGuideWallet, logger log.ILogger) *WalletReconciliationWorker {
 	return &WalletReconciliationWorker{
-		repo: repo,
-		log:  logger.WithModule("shipments.wallet_reconciliation"),
+		repo:   repo,
+		wallet: wallet,
+		log:    logger.WithModule("shipments.wallet_reconciliation"),
 	}
 }
 
@@ -62,7 +64,7 @@ func (w *WalletReconciliationWorker) runOnce(ctx context.Context) {
 	recovered := 0
 	for _, g := range guides {
 		shipmentID := g.ShipmentID
-		if err := w.repo.DebitWalletForGuide(ctx, g.BusinessID, g.TotalCost, g.TrackingNumber, &shipmentID); err != nil {
+		if err := w.wallet.DebitForGuide(ctx, g.BusinessID, g.TotalCost, g.TrackingNumber, &shipmentID); err != nil {
 			w.log.Error(ctx).Err(err).
 				Uint("business_id", g.BusinessID).
 				Uint("shipment_id", g.ShipmentID).
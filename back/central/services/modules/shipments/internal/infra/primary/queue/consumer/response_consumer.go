@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/app/usecasetracking"
 	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
 	"github.com/secamc93/probability/back/central/shared/log"
 	"github.com/secamc93/probability/back/central/shared/rabbitmq"
@@ -34,27 +35,33 @@ type TransportResponseMessage struct {
 type ResponseConsumer struct {
 	queue        rabbitmq.IQueue
 	repo         domain.IRepository
+	wallet       domain.IGuideWallet
 	log          log.ILogger
 	ssePublisher domain.IShipmentSSEPublisher
 	redisClient  redis.IRedis
 	marginReader domain.IShippingMarginReader
+	tracking     *usecasetracking.UseCaseTracking
 }
 
 func NewResponseConsumer(
 	queue rabbitmq.IQueue,
 	repo domain.IRepository,
+	wallet domain.IGuideWallet,
 	logger log.ILogger,
 	ssePublisher domain.IShipmentSSEPublisher,
 	redisClient redis.IRedis,
 	marginReader domain.IShippingMarginReader,
+	tracking *usecasetracking.UseCaseTracking,
 ) *ResponseConsumer {
 	return &ResponseConsumer{
 		queue:        queue,
 		repo:         repo,
+		wallet:       wallet,
 		log:          logger.WithModule("shipments.transport_response_consumer"),
 		ssePublisher: ssePublisher,
 		redisClient:  redisClient,
 		marginReader: marginReader,
+		tracking:     tracking,
 	}
 }
 
@@ -139,6 +146,7 @@ func (c *ResponseConsumer) handleGenerateResponse(ctx context.Context, response
 					c.markQuoteFailed(ctx, *shipment.OrderID, response.Error)
 				}
 			}
+			c.releaseWalletHolds(ctx, *response.ShipmentID)
 			c.ssePublisher.PublishGuideFailed(ctx, businessID, *response.ShipmentID, response.CorrelationID, response.Error)
 		}
 		return
@@ -251,6 +259,22 @@ func (c *ResponseConsumer) handleGenerateResponse(ctx context.Context, response
 				c.log.Error(ctx).Err(err).Msg("Failed to update shipment with tracking data")
 			}
 
+			if trackers := packageTrackers(dataField); len(trackers) > 0 {
+				if err := c.repo.SetShipmentPackageTrackingNumbers(ctx, shipment.ID, trackers); err != nil {
+					c.log.Warn(ctx).Err(err).
+						Uint("shipment_id", shipment.ID).
+						Int("packages", len(trackers)).
+						Msg("Failed to save per-package tracking numbers")
+				}
+			}
+
+			c.recordLifecycleEvent(ctx, shipment, businessID, response.Provider, domain.TrackingSourceGenerate, domain.TrackingEventInput{
+				Carrier:           carrier,
+				RawStatus:         "Guía generada",
+				Description:       fmt.Sprintf("Guía creada con %s (tracking: %s)", carrierOrDefault(carrier, response.Provider), trackingNumber),
+				IntegrationStatus: "pending",
+			})
+
 			if shipment.TotalCost != nil && *shipment.TotalCost > 0 {
 				if businessID == 0 {
 					c.log.Error(ctx).
@@ -261,7 +285,7 @@ func (c *ResponseConsumer) handleGenerateResponse(ctx context.Context, response
 						Msg("Guide generated but business_id unresolved: wallet NOT debited, needs reconciliation")
 				} else {
 					shipmentIDRef := shipment.ID
-					if err := c.repo.DebitWalletForGuide(ctx, businessID, *shipment.TotalCost, trackingNumber, &shipmentIDRef); err != nil {
+					if err := c.wallet.DebitForGuide(ctx, businessID, *shipment.TotalCost, trackingNumber, &shipmentIDRef); err != nil {
 						c.log.Error(ctx).Err(err).
 							Uint("business_id", businessID).
 							Uint("shipment_id", shipment.ID).
@@ -438,7 +462,13 @@ func (c *ResponseConsumer) handleTrackResponse(ctx context.Context, response *Tr
 				}
 			}
 
-			if (ok && status != "") || hasHistory {
+			inputs := trackEventInputs(response.Provider, response.Data)
+			if _, recorded := c.recordAndApply(ctx, shipment, businessID, response.Provider, domain.TrackingSourceTrack, inputs); recorded {
+				c.log.Info(ctx).
+					Str("shipment_id", fmt.Sprintf("%d", *response.ShipmentID)).
+					Int("events", len(inputs)).
+					Msg("✅ Shipment status/history updated from tracking events")
+			} else if (ok && status != "") || hasHistory {
 				if ok && status != "" {
 					shipment.Status = status
 				}
@@ -493,6 +523,12 @@ func (c *ResponseConsumer) handleCancelResponse(ctx context.Context, response *T
 			if err := c.repo.UpdateShipment(ctx, shipment); err != nil {
 				c.log.Error(ctx).Err(err).Msg("Failed to update shipment status to cancelled")
 			}
+			c.recordLifecycleEvent(ctx, shipment, businessID, response.Provider, domain.TrackingSourceCancel, domain.TrackingEventInput{
+				RawStatus:         cancelStatus,
+				RawStatusDetail:   cancelDetail,
+				Description:       "Envío cancelado",
+				IntegrationStatus: "cancelled",
+			})
 
 			if shipment.OrderID != nil && *shipment.OrderID != "" {
 				if err := c.repo.ClearOrderGuideData(ctx, *shipment.OrderID); err != nil {
@@ -503,10 +539,20 @@ func (c *ResponseConsumer) handleCancelResponse(ctx context.Context, response *T
 			}
 		}
 
+		c.releaseWalletHolds(ctx, *response.ShipmentID)
 		c.ssePublisher.PublishShipmentCancelled(ctx, businessID, *response.ShipmentID)
 	}
 }
 
+// releaseWalletHolds devuelve al disponible el saldo retenido para la guia
+func (c *ResponseConsumer) releaseWalletHolds(ctx context.Context, shipmentID uint) {
+	if err := c.wallet.ReleaseGuideHolds(ctx, shipmentID); err != nil {
+		c.log.Error(ctx).Err(err).
+			Uint("shipment_id", shipmentID).
+			Msg("Failed to release wallet holds for shipment: needs reconciliation")
+	}
+}
+
 func (c *ResponseConsumer) handleWebhookUpdate(ctx context.Context, response *TransportResponseMessage) {
 	if response.Data == nil {
 		c.log.Warn(ctx).Str("correlation_id", response.CorrelationID).Msg("webhook_update response has no data")
@@ -536,7 +582,8 @@ func (c *ResponseConsumer) handleWebhookUpdate(ctx context.Context, response *Tr
 	}
 
 	previousStatus := shipment.Status
-	shipment.Status = probabilityStatus
+	newStatus := probabilityStatus
+	businessID, _ := c.repo.GetShipmentBusinessIDByID(ctx, shipment.ID)
 
 	if rawStatus, ok := response.Data["raw_status"].(string); ok && rawStatus != "" {
 		s := rawStatus
@@ -561,22 +608,30 @@ func (c *ResponseConsumer) handleWebhookUpdate(ctx context.Context, response *Tr
 
 	appendTrackingEvent(shipment, response, probabilityStatus)
 
-	if err := c.repo.UpdateShipment(ctx, shipment); err != nil {
-		c.log.Error(ctx).
-			Err(err).
-			Uint("shipment_id", shipment.ID).
-			Str("correlation_id", response.CorrelationID).
-			Msg("Failed to update shipment from webhook")
-		return
-	}
-
-	if previousStatus != probabilityStatus && shipment.OrderID != nil && *shipment.OrderID != "" {
-		if err := c.repo.UpdateOrderStatusByOrderID(ctx, *shipment.OrderID, probabilityStatus); err != nil {
-			c.log.Warn(ctx).
+	// El estado sale del historial normalizado: un webhook atrasado queda en la
+	// línea de tiempo sin retroceder el envío
+	inputs := []domain.TrackingEventInput{webhookEventInput(response.Data, probabilityStatus)}
+	if result, recorded := c.recordAndApply(ctx, shipment, businessID, response.Provider, domain.TrackingSourceWebhook, inputs); recorded {
+		newStatus = result.Status
+	} else {
+		shipment.Status = probabilityStatus
+		if err := c.repo.UpdateShipment(ctx, shipment); err != nil {
+			c.log.Error(ctx).
 				Err(err).
-				Str("order_id", *shipment.OrderID).
-				Str("new_status", probabilityStatus).
-				Msg("Failed to sync order status from webhook")
+				Uint("shipment_id", shipment.ID).
+				Str("correlation_id", response.CorrelationID).
+				Msg("Failed to update shipment from webhook")
+			return
+		}
+
+		if previousStatus != probabilityStatus && shipment.OrderID != nil && *shipment.OrderID != "" {
+			if err := c.repo.UpdateOrderStatusByOrderID(ctx, *shipment.OrderID, probabilityStatus); err != nil {
+				c.log.Warn(ctx).
+					Err(err).
+					Str("order_id", *shipment.OrderID).
+					Str("new_status", probabilityStatus).
+					Msg("Failed to sync order status from webhook")
+			}
 		}
 	}
 
@@ -584,16 +639,14 @@ func (c *ResponseConsumer) handleWebhookUpdate(ctx context.Context, response *Tr
 		Uint("shipment_id", shipment.ID).
 		Str("tracking_number", trackingNumber).
 		Str("previous_status", previousStatus).
-		Str("new_status", probabilityStatus).
+		Str("new_status", newStatus).
 		Str("provider", response.Provider).
 		Str("correlation_id", response.CorrelationID).
 		Msg("✅ Shipment updated from provider webhook")
 
-	businessID, _ := c.repo.GetShipmentBusinessIDByID(ctx, shipment.ID)
-
 	response.Data["shipment_id"] = shipment.ID
 	response.Data["previous_status"] = previousStatus
-	response.Data["new_status"] = probabilityStatus
+	response.Data["new_status"] = newStatus
 	if shipment.OrderID != nil {
 		response.Data["order_id"] = *shipment.OrderID
 	}
@@ -12,9 +12,14 @@ import (
 )
 
 func newTestConsumer(repo domain.IRepository, ssePublisher domain.IShipmentSSEPublisher) *ResponseConsumer {
+	return newTestConsumerWithWallet(repo, &mocks.GuideWalletMock{}, ssePublisher)
+}
+
+func newTestConsumerWithWallet(repo domain.IRepository, wallet domain.IGuideWallet, ssePublisher domain.IShipmentSSEPublisher) *ResponseConsumer {
 	return &ResponseConsumer{
 		queue:        &mocks.RabbitMQMock{},
 		repo:         repo,
+		wallet:       wallet,
 		log:          mocks.NewLoggerMock(),
 		ssePublisher: ssePublisher,
 		redisClient:  nil,
@@ -462,3 +467,127 @@ func TestHandleGenerateResponse_Error_SinCotizacionNoRompe(t *testing.T) {
 		t.Fatalf("expected no error, got %v", err)
 	}
 }
+
+func TestHandleGenerateResponse_Error_LiberaRetencionDeBilletera(t *testing.T) {
+	var released []uint
+	repoMock := &mocks.RepositoryMock{
+		GetShipmentByIDFn: func(ctx context.Context, id uint) (*domain.Shipment, error) {
+			return &domain.Shipment{ID: id, Status: "pending"}, nil
+		},
+		UpdateShipmentFn: func(ctx context.Context, shipment *domain.Shipment) error {
+			return nil
+		},
+	}
+	walletMock := &mocks.GuideWalletMock{
+		ReleaseGuideHoldsFn: func(ctx context.Context, shipmentID uint) error {
+			released = append(released, shipmentID)
+			return nil
+		},
+	}
+
+	consumer := newTestConsumerWithWallet(repoMock, walletMock, &mocks.SSEPublisherMock{})
+
+	if err := consumer.handleResponse(buildGenerateErrorMessage(shipmentIDPtr(88), 46, "boom")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(released) != 1 || released[0] != 88 {
+		t.Fatalf("expected holds of shipment 88 released once, got %v", released)
+	}
+}
+
+func TestHandleCancelResponse_Success_LiberaRetencionDeBilletera(t *testing.T) {
+	var released []uint
+	repoMock := &mocks.RepositoryMock{
+		GetShipmentByIDFn: func(ctx context.Context, id uint) (*domain.Shipment, error) {
+			return &domain.Shipment{ID: id, Status: "pending"}, nil
+		},
+		UpdateShipmentFn: func(ctx context.Context, shipment *domain.Shipment) error {
+			return nil
+		},
+	}
+	walletMock := &mocks.GuideWalletMock{
+		ReleaseGuideHoldsFn: func(ctx context.Context, shipmentID uint) error {
+			released = append(released, shipmentID)
+			return errors.New("db down")
+		},
+	}
+
+	consumer := newTestConsumerWithWallet(repoMock, walletMock, &mocks.SSEPublisherMock{})
+
+	if err := consumer.handleResponse(buildCancelMessage(shipmentIDPtr(91), 3, "success", "")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(released) != 1 || released[0] != 91 {
+		t.Fatalf("expected holds of shipment 91 released once, got %v", released)
+	}
+}
+
+func TestHandleCancelResponse_Error_NoLiberaRetencion(t *testing.T) {
+	walletMock := &mocks.GuideWalletMock{
+		ReleaseGuideHoldsFn: func(ctx context.Context, shipmentID uint) error {
+			t.Fatal("una cancelacion fallida no debe liberar la retencion")
+			return nil
+		},
+	}
+
+	consumer := newTestConsumerWithWallet(&mocks.RepositoryMock{}, walletMock, &mocks.SSEPublisherMock{})
+
+	if err := consumer.handleResponse(buildCancelMessage(shipmentIDPtr(91), 3, "error", "carrier down")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestHandleGenerateResponse_Success_GuardaGuiasPorPaquete(t *testing.T) {
+	var savedShipmentID uint
+	var savedTrackers []string
+	repoMock := &mocks.RepositoryMock{
+		GetShipmentByIDFn: func(ctx context.Context, id uint) (*domain.Shipment, error) {
+			return &domain.Shipment{ID: id, Status: "pending"}, nil
+		},
+		UpdateShipmentFn: func(ctx context.Context, shipment *domain.Shipment) error {
+			return nil
+		},
+		SetShipmentPackageTrackingNumbersFn: func(ctx context.Context, shipmentID uint, trackingNumbers []string) error {
+			savedShipmentID = shipmentID
+			savedTrackers = trackingNumbers
+			return nil
+		},
+	}
+
+	msg := TransportResponseMessage{
+		ShipmentID:    shipmentIDPtr(120),
+		BusinessID:    5,
+		Provider:      "envioclick",
+		Operation:     "generate",
+		Status:        "success",
+		CorrelationID: "corr-generate-002",
+		Timestamp:     time.Now(),
+		Data: map[string]interface{}{
+			"data": map[string]interface{}{
+				"tracker":         "MASTER-1",
+				"url":             "https://example.com/guia.pdf",
+				"carrier":         "COORDINADORA",
+				"packageTrackers": []interface{}{"MASTER-1-1", "MASTER-1-2", float64(99887766)},
+			},
+		},
+	}
+	b, _ := json.Marshal(msg)
+
+	consumer := newTestConsumer(repoMock, &mocks.SSEPublisherMock{})
+	if err := consumer.handleResponse(b); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if savedShipmentID != 120 {
+		t.Fatalf("expected trackers saved for shipment 120, got %d", savedShipmentID)
+	}
+	want := []string{"MASTER-1-1", "MASTER-1-2", "99887766"}
+	if len(savedTrackers) != len(want) {
+		t.Fatalf("expected %v, got %v", want, savedTrackers)
+	}
+	for i := range want {
+		if savedTrackers[i] != want[i] {
+			t.Errorf("tracker %d: expected %q, got %q", i, want[i], savedTrackers[i])
+		}
+	}
+}
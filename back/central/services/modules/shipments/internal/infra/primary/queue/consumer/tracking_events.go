@@ -0,0 +1,140 @@
+package consumer
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/app/usecasetracking"
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
+)
+
+// webhookEventInput evento único que trae un webhook de transportadora
+func webhookEventInput(data map[string]interface{}, probabilityStatus string) domain.TrackingEventInput {
+	input := domain.TrackingEventInput{IntegrationStatus: probabilityStatus}
+	input.RawStatus, _ = data["raw_status"].(string)
+	input.RawStatusDetail, _ = data["raw_status_detail"].(string)
+	input.Description, _ = data["event_description"].(string)
+	input.Carrier, _ = data["carrier"].(string)
+	input.HasIncidence, _ = data["has_incidence"].(bool)
+	if ts, ok := data["event_timestamp"].(string); ok {
+		if t, ok := usecasetracking.ParseEventTime(ts); ok {
+			input.OccurredAt = t
+		}
+	}
+	return input
+}
+
+// trackEventInputs historial que devuelve la operación track. Envioclick lo
+// anida en data.data.history con el paso raw; Shipit y el resto lo dejan en
+// history con el estado ya mapeado (Shipit pone el estado raw en description).
+func trackEventInputs(provider string, data map[string]interface{}) []domain.TrackingEventInput {
+	carrier, _ := data["carrier"].(string)
+	history, _ := data["history"].([]interface{})
+	nested := false
+	if inner, ok := data["data"].(map[string]interface{}); ok {
+		if h, ok := inner["history"].([]interface{}); ok {
+			history = h
+			nested = true
+		}
+		if c, ok := inner["carrier"].(string); ok && c != "" {
+			carrier = c
+		}
+	}
+
+	inputs := make([]domain.TrackingEventInput, 0, len(history))
+	for _, item := range history {
+		ev, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		date, _ := ev["date"].(string)
+		occurredAt, ok := usecasetracking.ParseEventTime(date)
+		if !ok {
+			// Sin fecha el evento no se puede ordenar ni deduplicar entre consultas
+			continue
+		}
+		status, _ := ev["status"].(string)
+		description, _ := ev["description"].(string)
+		location, _ := ev["location"].(string)
+
+		input := domain.TrackingEventInput{
+			Carrier:     carrier,
+			Description: description,
+			Location:    location,
+			OccurredAt:  occurredAt,
+		}
+		switch {
+		case nested:
+			input.RawStatus = status
+		case provider == "shipit":
+			input.RawStatus = description
+			input.IntegrationStatus = status
+		default:
+			input.RawStatus = status
+			input.IntegrationStatus = status
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs
+}
+
+// recordLifecycleEvent deja en el historial la creación o cancelación de la guía.
+// El estado de esas operaciones lo fija el consumer, no el historial.
+func (c *ResponseConsumer) recordLifecycleEvent(ctx context.Context, shipment *domain.Shipment, businessID uint, provider, source string, input domain.TrackingEventInput) {
+	if c.tracking == nil {
+		return
+	}
+	_, err := c.tracking.RecordEvents(ctx, usecasetracking.RecordInput{
+		Shipment:   shipment,
+		BusinessID: c.eventBusinessID(ctx, shipment.ID, businessID),
+		Provider:   provider,
+		Source:     source,
+		Events:     []domain.TrackingEventInput{input},
+	})
+	if err != nil {
+		c.log.Warn(ctx).Err(err).
+			Uint("shipment_id", shipment.ID).
+			Str("source", source).
+			Msg("Failed to record tracking event")
+	}
+}
+
+// recordAndApply guarda los eventos y deriva de ellos el estado del envío y de
+// la orden. Devuelve false si no hay historial disponible o falló, para que el
+// llamador aplique el estado reportado por la integración como antes.
+func (c *ResponseConsumer) recordAndApply(ctx context.Context, shipment *domain.Shipment, businessID uint, provider, source string, inputs []domain.TrackingEventInput) (*usecasetracking.RecordResult, bool) {
+	if c.tracking == nil || len(inputs) == 0 {
+		return nil, false
+	}
+	result, err := c.tracking.RecordEvents(ctx, usecasetracking.RecordInput{
+		Shipment:    shipment,
+		BusinessID:  c.eventBusinessID(ctx, shipment.ID, businessID),
+		Provider:    provider,
+		Source:      source,
+		Events:      inputs,
+		ApplyStatus: true,
+	})
+	if err != nil {
+		c.log.Error(ctx).Err(err).
+			Uint("shipment_id", shipment.ID).
+			Str("source", source).
+			Msg("Failed to record tracking events, falling back to integration status")
+		return nil, false
+	}
+	if result.OrderSyncErr != nil && shipment.OrderID != nil {
+		c.log.Warn(ctx).Err(result.OrderSyncErr).
+			Str("order_id", *shipment.OrderID).
+			Str("new_status", result.Status).
+			Msg("Failed to sync order status from tracking events")
+	}
+	return result, true
+}
+
+// eventBusinessID las respuestas de track no siempre traen business_id; los
+// eventos lo necesitan para las métricas por negocio
+func (c *ResponseConsumer) eventBusinessID(ctx context.Context, shipmentID, businessID uint) uint {
+	if businessID != 0 {
+		return businessID
+	}
+	bid, _ := c.repo.GetShipmentBusinessIDByID(ctx, shipmentID)
+	return bid
+}
@@ -227,5 +227,11 @@ func (r *Repository) GetGuidePDFContext(ctx context.Context, shipmentID uint) (*
 		OrderItems:         orderItems,
 	}
 
+	packages, err := r.ListShipmentPackages(ctx, []uint{shipmentID})
+	if err != nil {
+		return nil, err
+	}
+	result.Packages = packages[shipmentID]
+
 	return result, nil
 }
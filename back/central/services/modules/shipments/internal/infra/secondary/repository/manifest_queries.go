@@ -144,7 +144,7 @@ func (r *Repository) ListPendingForManifest(ctx context.Context, filter domain.M
 			o.created_at AS order_created_at,
 			COALESCE(s.status, '') AS shipment_status,
 			COALESCE(o.status, '') AS order_status,
-			1 AS package_quantity`).
+			GREATEST((SELECT COUNT(*) FROM shipment_packages sp WHERE sp.shipment_id = s.id), 1) AS package_quantity`).
 		Joins("LEFT JOIN business b ON b.id = o.business_id").
 		Joins("LEFT JOIN warehouses w ON w.id = s.warehouse_id").
 		Order("s.created_at DESC")
@@ -63,6 +63,15 @@ func (r *Repository) GetShipmentByTrackingNumber(ctx context.Context, trackingNu
 		Where("tracking_number = ?", trackingNumber).
 		First(&shipment).Error
 
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Guía de un paquete hijo en envíos multi-bulto
+		err = r.db.Conn(ctx).
+			Preload("Order").
+			Preload("ShippingAddress").
+			Where("id IN (SELECT shipment_id FROM shipment_packages WHERE tracking_number = ?)", trackingNumber).
+			First(&shipment).Error
+	}
+
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrShipmentNotFound
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ReplaceShipmentPackages reemplaza los bultos del envío. Se reescriben todos
+// porque la secuencia identifica al paquete dentro del envío.
+func (r *Repository) ReplaceShipmentPackages(ctx context.Context, shipmentID uint, pkgs []domain.ShipmentPackage) error {
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("shipment_id = ?", shipmentID).Delete(&models.ShipmentPackage{}).Error; err != nil {
+			return err
+		}
+		if len(pkgs) == 0 {
+			return nil
+		}
+
+		rows := make([]models.ShipmentPackage, 0, len(pkgs))
+		for i, p := range pkgs {
+			var contents []byte
+			if len(p.Contents) > 0 {
+				contents, _ = json.Marshal(p.Contents)
+			}
+			rows = append(rows, models.ShipmentPackage{
+				ShipmentID:     shipmentID,
+				Sequence:       i + 1,
+				Reference:      truncate(p.Reference, 128),
+				Weight:         p.Weight,
+				Height:         p.Height,
+				Width:          p.Width,
+				Length:         p.Length,
+				DeclaredValue:  p.DeclaredValue,
+				Contents:       contents,
+				TrackingNumber: p.TrackingNumber,
+			})
+		}
+		if err := tx.Omit(clause.Associations).Create(&rows).Error; err != nil {
+			return err
+		}
+		for i := range rows {
+			pkgs[i].ID = rows[i].ID
+			pkgs[i].ShipmentID = shipmentID
+			pkgs[i].Sequence = rows[i].Sequence
+		}
+		return nil
+	})
+}
+
+func (r *Repository) ListShipmentPackages(ctx context.Context, shipmentIDs []uint) (map[uint][]domain.ShipmentPackage, error) {
+	result := make(map[uint][]domain.ShipmentPackage, len(shipmentIDs))
+	if len(shipmentIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []models.ShipmentPackage
+	if err := r.db.Conn(ctx).
+		Where("shipment_id IN ?", shipmentIDs).
+		Order("shipment_id, sequence").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		pkg := domain.ShipmentPackage{
+			ID:             row.ID,
+			ShipmentID:     row.ShipmentID,
+			Sequence:       row.Sequence,
+			Reference:      row.Reference,
+			Weight:         row.Weight,
+			Height:         row.Height,
+			Width:          row.Width,
+			Length:         row.Length,
+			DeclaredValue:  row.DeclaredValue,
+			TrackingNumber: row.TrackingNumber,
+		}
+		if len(row.Contents) > 0 {
+			_ = json.Unmarshal(row.Contents, &pkg.Contents)
+		}
+		result[row.ShipmentID] = append(result[row.ShipmentID], pkg)
+	}
+	return result, nil
+}
+
+// SetShipmentPackageTrackingNumbers asigna las guías por paquete en el orden de
+// la secuencia. Las guías que sobran se ignoran; los paquetes sin guía la conservan.
+func (r *Repository) SetShipmentPackageTrackingNumbers(ctx context.Context, shipmentID uint, trackingNumbers []string) error {
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		for i, tn := range trackingNumbers {
+			if tn == "" {
+				continue
+			}
+			if err := tx.Model(&models.ShipmentPackage{}).
+				Where("shipment_id = ? AND sequence = ?", shipmentID, i+1).
+				Updates(map[string]interface{}{"tracking_number": truncate(tn, 128), "updated_at": gorm.Expr("NOW()")}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
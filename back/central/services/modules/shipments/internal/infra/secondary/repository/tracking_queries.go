@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
+	"github.com/secamc93/probability/back/central/shared/db"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm/clause"
+)
+
+// NewTracking expone el historial de tracking y las tablas de traducción sobre
+// la misma conexión del repositorio de envíos
+func NewTracking(database db.IDatabase) domain.ITrackingRepository {
+	return &Repository{db: database}
+}
+
+func (r *Repository) InsertTrackingEvents(ctx context.Context, events []domain.ShipmentTrackingEvent) (int, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+	rows := make([]models.ShipmentTrackingEvent, 0, len(events))
+	for _, e := range events {
+		rows = append(rows, models.ShipmentTrackingEvent{
+			ShipmentID:      e.ShipmentID,
+			BusinessID:      e.BusinessID,
+			Provider:        e.Provider,
+			Carrier:         e.Carrier,
+			Source:          e.Source,
+			DedupKey:        e.DedupKey,
+			EventCode:       string(e.EventCode),
+			ShipmentStatus:  e.ShipmentStatus,
+			MappingSource:   e.MappingSource,
+			RawStatus:       truncate(e.RawStatus, 255),
+			RawStatusDetail: truncate(e.RawStatusDetail, 255),
+			Description:     e.Description,
+			Location:        truncate(e.Location, 255),
+			HasIncidence:    e.HasIncidence,
+			OccurredAt:      e.OccurredAt,
+		})
+	}
+
+	res := r.db.Conn(ctx).
+		Omit(clause.Associations).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "dedup_key"}}, DoNothing: true}).
+		Create(&rows)
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	return int(res.RowsAffected), nil
+}
+
+func (r *Repository) ListTrackingEvents(ctx context.Context, shipmentID uint) ([]domain.ShipmentTrackingEvent, error) {
+	var rows []models.ShipmentTrackingEvent
+	if err := r.db.Conn(ctx).
+		Where("shipment_id = ?", shipmentID).
+		Order("occurred_at ASC, id ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	events := make([]domain.ShipmentTrackingEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, domain.ShipmentTrackingEvent{
+			ID:              row.ID,
+			ShipmentID:      row.ShipmentID,
+			BusinessID:      row.BusinessID,
+			Provider:        row.Provider,
+			Carrier:         row.Carrier,
+			Source:          row.Source,
+			DedupKey:        row.DedupKey,
+			EventCode:       domain.TrackingEventCode(row.EventCode),
+			ShipmentStatus:  row.ShipmentStatus,
+			MappingSource:   row.MappingSource,
+			RawStatus:       row.RawStatus,
+			RawStatusDetail: row.RawStatusDetail,
+			Description:     row.Description,
+			Location:        row.Location,
+			HasIncidence:    row.HasIncidence,
+			OccurredAt:      row.OccurredAt,
+			CreatedAt:       row.CreatedAt,
+		})
+	}
+	return events, nil
+}
+
+func (r *Repository) ListCarrierStatusMappings(ctx context.Context, provider string) ([]domain.CarrierStatusMapping, error) {
+	var rows []models.CarrierStatusMapping
+	query := r.db.Conn(ctx).Order("provider, carrier, raw_status")
+	if provider != "" {
+		query = query.Where("provider = ?", provider)
+	}
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	mappings := make([]domain.CarrierStatusMapping, 0, len(rows))
+	for _, row := range rows {
+		mappings = append(mappings, domain.CarrierStatusMapping{
+			ID:        row.ID,
+			Provider:  row.Provider,
+			Carrier:   row.Carrier,
+			RawStatus: row.RawStatus,
+			EventCode: domain.TrackingEventCode(row.EventCode),
+			UpdatedAt: row.UpdatedAt,
+		})
+	}
+	return mappings, nil
+}
+
+func (r *Repository) UpsertCarrierStatusMapping(ctx context.Context, mapping *domain.CarrierStatusMapping) error {
+	row := models.CarrierStatusMapping{
+		Provider:  mapping.Provider,
+		Carrier:   mapping.Carrier,
+		RawStatus: mapping.RawStatus,
+		EventCode: string(mapping.EventCode),
+	}
+	if err := r.db.Conn(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "provider"}, {Name: "carrier"}, {Name: "raw_status"}},
+			DoUpdates: clause.AssignmentColumns([]string{"event_code", "updated_at"}),
+		}).
+		Create(&row).Error; err != nil {
+		return err
+	}
+	mapping.ID = row.ID
+	mapping.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+func (r *Repository) ListUnmappedTrackingStatuses(ctx context.Context, provider string, limit int) ([]domain.UnmappedTrackingStatus, error) {
+	var rows []struct {
+		Provider      string
+		Carrier       string
+		RawStatus     string
+		EventCode     string
+		MappingSource string
+		Occurrences   int64
+		LastSeenAt    time.Time
+	}
+	query := r.db.Conn(ctx).
+		Table("shipment_tracking_events").
+		Select(`provider, carrier, raw_status, event_code, mapping_source,
+			COUNT(*) AS occurrences, MAX(occurred_at) AS last_seen_at`).
+		Where("mapping_source IN ?", []string{domain.MappingSourceIntegration, domain.MappingSourceUnmapped}).
+		Where("raw_status <> ''").
+		Group("provider, carrier, raw_status, event_code, mapping_source").
+		Order("occurrences DESC").
+		Limit(limit)
+	if provider != "" {
+		query = query.Where("provider = ?", provider)
+	}
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.UnmappedTrackingStatus, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, domain.UnmappedTrackingStatus{
+			Provider:      row.Provider,
+			Carrier:       row.Carrier,
+			RawStatus:     row.RawStatus,
+			MappedAs:      domain.TrackingEventCode(row.EventCode),
+			MappingSource: row.MappingSource,
+			Occurrences:   row.Occurrences,
+			LastSeenAt:    row.LastSeenAt,
+		})
+	}
+	return result, nil
+}
+
+// GetCarrierTrackingMetrics agrega el historial de los envíos creados en el rango.
+// El tiempo por etapa toma cada racha de eventos con el mismo código como una
+// sola etapa, desde su primer escaneo hasta el primer escaneo de la siguiente.
+func (r *Repository) GetCarrierTrackingMetrics(ctx context.Context, filter domain.TrackingMetricsFilter) ([]domain.CarrierTrackingMetrics, error) {
+	scope := `s.created_at >= ? AND s.created_at < ? AND e.event_code <> 'unknown'`
+	args := []any{filter.From, filter.To}
+	if filter.BusinessID != 0 {
+		scope += ` AND e.business_id = ?`
+		args = append(args, filter.BusinessID)
+	}
+	if filter.Carrier != "" {
+		scope += ` AND e.carrier = ?`
+		args = append(args, filter.Carrier)
+	}
+
+	var summary []struct {
+		Carrier               string
+		Shipments             int64
+		Delivered             int64
+		FirstAttemptDelivered int64
+		Returned              int64
+		AvgTransitHours       *float64
+	}
+	if err := r.db.Conn(ctx).Raw(`
+		WITH per_shipment AS (
+		    SELECT e.shipment_id,
+		           MAX(e.carrier) AS carrier,
+		           MIN(e.occurred_at) FILTER (WHERE e.event_code = 'delivered') AS delivered_at,
+		           MIN(e.occurred_at) FILTER (WHERE e.event_code IN ('picked_up', 'in_transit', 'out_for_delivery')) AS shipped_at,
+		           COUNT(*) FILTER (WHERE e.event_code = 'attempt_failed') AS failed_attempts,
+		           BOOL_OR(e.event_code IN ('returning', 'returned')) AS returned
+		    FROM shipment_tracking_events e
+		    JOIN shipments s ON s.id = e.shipment_id
+		    WHERE `+scope+`
+		    GROUP BY e.shipment_id
+		)
+		SELECT carrier,
+		       COUNT(*) AS shipments,
+		       COUNT(*) FILTER (WHERE delivered_at IS NOT NULL) AS delivered,
+		       COUNT(*) FILTER (WHERE delivered_at IS NOT NULL AND failed_attempts = 0) AS first_attempt_delivered,
+		       COUNT(*) FILTER (WHERE returned) AS returned,
+		       AVG(EXTRACT(EPOCH FROM (delivered_at - shipped_at)) / 3600.0)
+		           FILTER (WHERE delivered_at IS NOT NULL AND shipped_at IS NOT NULL) AS avg_transit_hours
+		FROM per_shipment
+		GROUP BY carrier
+		ORDER BY shipments DESC`, args...).Scan(&summary).Error; err != nil {
+		return nil, err
+	}
+
+	var dwell []struct {
+		Carrier   string
+		EventCode string
+		Stages    int64
+		AvgHours  float64
+	}
+	if err := r.db.Conn(ctx).Raw(`
+		WITH ordered AS (
+		    SELECT e.shipment_id, e.carrier, e.event_code, e.occurred_at,
+		           LAG(e.event_code) OVER (PARTITION BY e.shipment_id ORDER BY e.occurred_at, e.id) AS prev_code
+		    FROM shipment_tracking_events e
+		    JOIN shipments s ON s.id = e.shipment_id
+		    WHERE `+scope+`
+		),
+		stages AS (
+		    SELECT carrier, event_code, occurred_at,
+		           LEAD(occurred_at) OVER (PARTITION BY shipment_id ORDER BY occurred_at) AS left_at
+		    FROM ordered
+		    WHERE prev_code IS DISTINCT FROM event_code
+		)
+		SELECT carrier, event_code,
+		       COUNT(*) AS stages,
+		       AVG(EXTRACT(EPOCH FROM (left_at - occurred_at)) / 3600.0) AS avg_hours
+		FROM stages
+		WHERE left_at IS NOT NULL
+		GROUP BY carrier, event_code`, args...).Scan(&dwell).Error; err != nil {
+		return nil, err
+	}
+
+	byCarrier := make(map[string][]domain.TrackingEventDwell)
+	for _, d := range dwell {
+		byCarrier[d.Carrier] = append(byCarrier[d.Carrier], domain.TrackingEventDwell{
+			EventCode: domain.TrackingEventCode(d.EventCode),
+			Stages:    d.Stages,
+			AvgHours:  d.AvgHours,
+		})
+	}
+
+	metrics := make([]domain.CarrierTrackingMetrics, 0, len(summary))
+	for _, s := range summary {
+		metrics = append(metrics, domain.CarrierTrackingMetrics{
+			Carrier:               s.Carrier,
+			Shipments:             s.Shipments,
+			Delivered:             s.Delivered,
+			FirstAttemptDelivered: s.FirstAttemptDelivered,
+			Returned:              s.Returned,
+			AvgTransitHours:       s.AvgTransitHours,
+			Dwell:                 byCarrier[s.Carrier],
+		})
+	}
+	return metrics, nil
+}
+
+// truncate recorta a max caracteres sin partir runas multibyte
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
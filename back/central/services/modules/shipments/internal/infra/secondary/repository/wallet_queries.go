@@ -5,61 +5,9 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
-	"github.com/secamc93/probability/back/migration/shared/models"
 )
 
-func (r *Repository) DebitWalletForGuide(ctx context.Context, businessID uint, amount float64, trackingNumber string, shipmentID *uint) error {
-	var wallet models.Wallet
-	if err := r.db.Conn(ctx).Where("business_id = ?", businessID).First(&wallet).Error; err != nil {
-		return fmt.Errorf("wallet not found for business %d: %w", businessID, err)
-	}
-
-	if shipmentID != nil {
-		var existing int64
-		if err := r.db.Conn(ctx).Model(&models.WalletTransaction{}).
-			Where("shipment_id = ? AND type = ?", *shipmentID, "USAGE").
-			Count(&existing).Error; err != nil {
-			return fmt.Errorf("failed to check existing wallet transaction: %w", err)
-		}
-		if existing > 0 {
-			return nil
-		}
-	}
-
-	tx := r.db.Conn(ctx).Begin()
-	if tx.Error != nil {
-		return tx.Error
-	}
-
-	txRecord := &models.WalletTransaction{
-		ID:         uuid.New(),
-		WalletID:   wallet.ID,
-		Amount:     amount,
-		Type:       "USAGE",
-		Status:     "COMPLETED",
-		Concept:    "GUIDE",
-		Reference:  fmt.Sprintf("MAN_DEB_%s: Guide generation: %s", uuid.New().String()[:8], trackingNumber),
-		ShipmentID: shipmentID,
-		BusinessID: businessID,
-		CreatedAt:  time.Now(),
-	}
-
-	if err := tx.Create(txRecord).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to create wallet transaction: %w", err)
-	}
-
-	wallet.Balance -= amount
-	if err := tx.Save(&wallet).Error; err != nil {
-		tx.Rollback()
-		return fmt.Errorf("failed to update wallet balance: %w", err)
-	}
-
-	return tx.Commit().Error
-}
-
 func (r *Repository) FindUnchargedGuides(ctx context.Context, createdAfter, createdBefore time.Time, limit int) ([]domain.UnchargedGuide, error) {
 	var rows []domain.UnchargedGuide
 	err := r.db.Conn(ctx).
@@ -0,0 +1,32 @@
+package mocks
+
+import (
+	"context"
+)
+
+type GuideWalletMock struct {
+	HoldForGuideFn      func(ctx context.Context, businessID, shipmentID uint, amount float64, correlationID string) error
+	DebitForGuideFn     func(ctx context.Context, businessID uint, amount float64, trackingNumber string, shipmentID *uint) error
+	ReleaseGuideHoldsFn func(ctx context.Context, shipmentID uint) error
+}
+
+func (m *GuideWalletMock) HoldForGuide(ctx context.Context, businessID, shipmentID uint, amount float64, correlationID string) error {
+	if m.HoldForGuideFn != nil {
+		return m.HoldForGuideFn(ctx, businessID, shipmentID, amount, correlationID)
+	}
+	return nil
+}
+
+func (m *GuideWalletMock) DebitForGuide(ctx context.Context, businessID uint, amount float64, trackingNumber string, shipmentID *uint) error {
+	if m.DebitForGuideFn != nil {
+		return m.DebitForGuideFn(ctx, businessID, amount, trackingNumber, shipmentID)
+	}
+	return nil
+}
+
+func (m *GuideWalletMock) ReleaseGuideHolds(ctx context.Context, shipmentID uint) error {
+	if m.ReleaseGuideHoldsFn != nil {
+		return m.ReleaseGuideHoldsFn(ctx, shipmentID)
+	}
+	return nil
+}
@@ -3,3 +3,5 @@ package mocks
 import "github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
 
 var _ domain.IRepository = (*RepositoryMock)(nil)
+var _ domain.ITrackingRepository = (*TrackingRepositoryMock)(nil)
+var _ domain.IGuideWallet = (*GuideWalletMock)(nil)
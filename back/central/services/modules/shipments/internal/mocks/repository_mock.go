@@ -8,55 +8,58 @@ import (
 )
 
 type RepositoryMock struct {
-	CreateShipmentFn                  func(ctx context.Context, shipment *domain.Shipment) error
-	GetShipmentByIDFn                 func(ctx context.Context, id uint) (*domain.Shipment, error)
-	GetShipmentByTrackingNumberFn     func(ctx context.Context, trackingNumber string) (*domain.Shipment, error)
-	GetShipmentsByOrderIDFn           func(ctx context.Context, orderID string) ([]domain.Shipment, error)
-	ListShipmentsFn                   func(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]domain.Shipment, int64, error)
-	UpdateShipmentFn                  func(ctx context.Context, shipment *domain.Shipment) error
-	DeleteShipmentFn                  func(ctx context.Context, id uint) error
-	ShipmentExistsFn                  func(ctx context.Context, orderID string, trackingNumber string) (bool, error)
-	GetActiveShippingCarrierFn        func(ctx context.Context, businessID uint) (*domain.CarrierInfo, error)
-	GetBusinessNameFn                 func(ctx context.Context, businessID uint) (string, error)
-	GetOrderBusinessIDFn              func(ctx context.Context, orderUUID string) (uint, error)
-	ResolveShipmentGeozoneFn          func(ctx context.Context, shipmentID uint, businessID uint) error
-	ListPendingForManifestFn          func(ctx context.Context, filter domain.ManifestFilter) ([]domain.ManifestShipmentRow, int64, error)
-	ListPendingCarriersFn             func(ctx context.Context, businessID uint, includeChildren bool) ([]domain.ManifestCarrierCount, error)
-	GetShipmentBusinessIDByTrackingFn func(ctx context.Context, trackingNumber string) (uint, error)
-	GetShipmentBusinessIDByIDFn       func(ctx context.Context, shipmentID uint) (uint, error)
-	UpdateOrderGuideLinkFn            func(ctx context.Context, orderID string, guideLink string, trackingNumber string, carrier string, shippingCost float64) error
-	UpdateOrderStatusByOrderIDFn      func(ctx context.Context, orderID string, status string) error
-	ClearOrderGuideDataFn             func(ctx context.Context, orderID string) error
-	EnsureAllBusinessesActiveFn       func(ctx context.Context) error
-	GetOrderIntegrationIDFn           func(ctx context.Context, orderUUID string) (uint, error)
-	GetOrderCodTotalFn                func(ctx context.Context, orderUUID string) (*float64, error)
-	GetOrderCodBasisFn                func(ctx context.Context, orderUUID string) (*domain.OrderCodBasis, error)
-	GetOrderExternalGuideFn           func(ctx context.Context, orderUUID string) (*domain.OrderExternalGuide, error)
-	GetIntegrationBusinessIDFn        func(ctx context.Context, integrationID uint) (uint, error)
-	GetCityDaneByNameFn               func(ctx context.Context, city, province string) (string, error)
-	CreateSavedQuoteFn                func(ctx context.Context, quote *domain.SavedQuote) error
-	GetSavedQuoteByIDFn               func(ctx context.Context, id uint) (*domain.SavedQuote, error)
-	GetSavedQuoteByOrderUUIDFn        func(ctx context.Context, orderUUID string) (*domain.SavedQuote, error)
-	GetOrderRecipientFn               func(ctx context.Context, orderUUID string) (*domain.OrderRecipient, error)
-	GetUserDisplayNameFn              func(ctx context.Context, userID uint) string
-	ListSavedQuotesFn                 func(ctx context.Context, filter domain.SavedQuoteFilter) ([]domain.SavedQuote, int64, error)
-	UpdateSavedQuoteFn                func(ctx context.Context, quote *domain.SavedQuote) error
-	GetOrderSelectedShippingFn        func(ctx context.Context, orderUUID string) (*domain.OrderSelectedShipping, error)
-	GetIntegrationConfigFlagFn        func(ctx context.Context, integrationID uint, key string) (bool, error)
-	GetIntegrationConfigValueFn       func(ctx context.Context, integrationID uint, key string) (string, error)
-	GetIntegrationConfigStringArrayFn func(ctx context.Context, integrationID uint, key string) ([]string, error)
-	CreateOriginAddressFn             func(ctx context.Context, address *domain.OriginAddress) error
-	GetOriginAddressByIDFn            func(ctx context.Context, id uint) (*domain.OriginAddress, error)
-	ListOriginAddressesByBusinessFn   func(ctx context.Context, businessID uint) ([]domain.OriginAddress, error)
-	GetDefaultOriginAddressFn         func(ctx context.Context, businessID uint) (*domain.OriginAddress, error)
-	GetDefaultWarehouseOriginFn       func(ctx context.Context, businessID uint) (*domain.OriginAddress, error)
-	GetWarehouseShippingConfigFn      func(ctx context.Context, warehouseID uint) (*domain.ShippingPackageConfig, error)
-	GetProductDimensionsBySKUsFn      func(ctx context.Context, businessID uint, skus []string) (map[string]domain.ProductDimensions, error)
-	ListDaneStatesFn                  func(ctx context.Context) ([]domain.DaneItem, error)
-	ListDaneCitiesByStateFn           func(ctx context.Context, stateCode string) ([]domain.DaneItem, error)
-	UpdateOriginAddressFn             func(ctx context.Context, address *domain.OriginAddress) error
-	DeleteOriginAddressFn             func(ctx context.Context, id uint) error
-	SetDefaultOriginAddressFn         func(ctx context.Context, businessID, addressID uint) error
+	CreateShipmentFn                    func(ctx context.Context, shipment *domain.Shipment) error
+	GetShipmentByIDFn                   func(ctx context.Context, id uint) (*domain.Shipment, error)
+	GetShipmentByTrackingNumberFn       func(ctx context.Context, trackingNumber string) (*domain.Shipment, error)
+	GetShipmentsByOrderIDFn             func(ctx context.Context, orderID string) ([]domain.Shipment, error)
+	ListShipmentsFn                     func(ctx context.Context, page, pageSize int, filters map[string]interface{}) ([]domain.Shipment, int64, error)
+	UpdateShipmentFn                    func(ctx context.Context, shipment *domain.Shipment) error
+	DeleteShipmentFn                    func(ctx context.Context, id uint) error
+	ShipmentExistsFn                    func(ctx context.Context, orderID string, trackingNumber string) (bool, error)
+	ReplaceShipmentPackagesFn           func(ctx context.Context, shipmentID uint, pkgs []domain.ShipmentPackage) error
+	ListShipmentPackagesFn              func(ctx context.Context, shipmentIDs []uint) (map[uint][]domain.ShipmentPackage, error)
+	SetShipmentPackageTrackingNumbersFn func(ctx context.Context, shipmentID uint, trackingNumbers []string) error
+	GetActiveShippingCarrierFn          func(ctx context.Context, businessID uint) (*domain.CarrierInfo, error)
+	GetBusinessNameFn                   func(ctx context.Context, businessID uint) (string, error)
+	GetOrderBusinessIDFn                func(ctx context.Context, orderUUID string) (uint, error)
+	ResolveShipmentGeozoneFn            func(ctx context.Context, shipmentID uint, businessID uint) error
+	ListPendingForManifestFn            func(ctx context.Context, filter domain.ManifestFilter) ([]domain.ManifestShipmentRow, int64, error)
+	ListPendingCarriersFn               func(ctx context.Context, businessID uint, includeChildren bool) ([]domain.ManifestCarrierCount, error)
+	GetShipmentBusinessIDByTrackingFn   func(ctx context.Context, trackingNumber string) (uint, error)
+	GetShipmentBusinessIDByIDFn         func(ctx context.Context, shipmentID uint) (uint, error)
+	UpdateOrderGuideLinkFn              func(ctx context.Context, orderID string, guideLink string, trackingNumber string, carrier string, shippingCost float64) error
+	UpdateOrderStatusByOrderIDFn        func(ctx context.Context, orderID string, status string) error
+	ClearOrderGuideDataFn               func(ctx context.Context, orderID string) error
+	EnsureAllBusinessesActiveFn         func(ctx context.Context) error
+	GetOrderIntegrationIDFn             func(ctx context.Context, orderUUID string) (uint, error)
+	GetOrderCodTotalFn                  func(ctx context.Context, orderUUID string) (*float64, error)
+	GetOrderCodBasisFn                  func(ctx context.Context, orderUUID string) (*domain.OrderCodBasis, error)
+	GetOrderExternalGuideFn             func(ctx context.Context, orderUUID string) (*domain.OrderExternalGuide, error)
+	GetIntegrationBusinessIDFn          func(ctx context.Context, integrationID uint) (uint, error)
+	GetCityDaneByNameFn                 func(ctx context.Context, city, province string) (string, error)
+	CreateSavedQuoteFn                  func(ctx context.Context, quote *domain.SavedQuote) error
+	GetSavedQuoteByIDFn                 func(ctx context.Context, id uint) (*domain.SavedQuote, error)
+	GetSavedQuoteByOrderUUIDFn          func(ctx context.Context, orderUUID string) (*domain.SavedQuote, error)
+	GetOrderRecipientFn                 func(ctx context.Context, orderUUID string) (*domain.OrderRecipient, error)
+	GetUserDisplayNameFn                func(ctx context.Context, userID uint) string
+	ListSavedQuotesFn                   func(ctx context.Context, filter domain.SavedQuoteFilter) ([]domain.SavedQuote, int64, error)
+	UpdateSavedQuoteFn                  func(ctx context.Context, quote *domain.SavedQuote) error
+	GetOrderSelectedShippingFn          func(ctx context.Context, orderUUID string) (*domain.OrderSelectedShipping, error)
+	GetIntegrationConfigFlagFn          func(ctx context.Context, integrationID uint, key string) (bool, error)
+	GetIntegrationConfigValueFn         func(ctx context.Context, integrationID uint, key string) (string, error)
+	GetIntegrationConfigStringArrayFn   func(ctx context.Context, integrationID uint, key string) ([]string, error)
+	CreateOriginAddressFn               func(ctx context.Context, address *domain.OriginAddress) error
+	GetOriginAddressByIDFn              func(ctx context.Context, id uint) (*domain.OriginAddress, error)
+	ListOriginAddressesByBusinessFn     func(ctx context.Context, businessID uint) ([]domain.OriginAddress, error)
+	GetDefaultOriginAddressFn           func(ctx context.Context, businessID uint) (*domain.OriginAddress, error)
+	GetDefaultWarehouseOriginFn         func(ctx context.Context, businessID uint) (*domain.OriginAddress, error)
+	GetWarehouseShippingConfigFn        func(ctx context.Context, warehouseID uint) (*domain.ShippingPackageConfig, error)
+	GetProductDimensionsBySKUsFn        func(ctx context.Context, businessID uint, skus []string) (map[string]domain.ProductDimensions, error)
+	ListDaneStatesFn                    func(ctx context.Context) ([]domain.DaneItem, error)
+	ListDaneCitiesByStateFn             func(ctx context.Context, stateCode string) ([]domain.DaneItem, error)
+	UpdateOriginAddressFn               func(ctx context.Context, address *domain.OriginAddress) error
+	DeleteOriginAddressFn               func(ctx context.Context, id uint) error
+	SetDefaultOriginAddressFn           func(ctx context.Context, businessID, addressID uint) error
 }
 
 func (m *RepositoryMock) CreateShipment(ctx context.Context, shipment *domain.Shipment) error {
@@ -409,10 +412,6 @@ func (m *RepositoryMock) ListBusinessesWithActiveProvider(ctx context.Context, p
 	return nil, nil
 }
 
-func (m *RepositoryMock) DebitWalletForGuide(ctx context.Context, businessID uint, amount float64, trackingNumber string, shipmentID *uint) error {
-	return nil
-}
-
 func (m *RepositoryMock) FindUnchargedGuides(ctx context.Context, createdAfter, createdBefore time.Time, limit int) ([]domain.UnchargedGuide, error) {
 	return nil, nil
 }
@@ -485,3 +484,24 @@ func (m *RepositoryMock) ListGuideFormats(ctx context.Context) ([]domain.GuideFo
 func (m *RepositoryMock) GetDefaultGuideFormat(ctx context.Context, carrier string) (*domain.GuideFormat, error) {
 	return nil, nil
 }
+
+func (m *RepositoryMock) ReplaceShipmentPackages(ctx context.Context, shipmentID uint, pkgs []domain.ShipmentPackage) error {
+	if m.ReplaceShipmentPackagesFn != nil {
+		return m.ReplaceShipmentPackagesFn(ctx, shipmentID, pkgs)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) ListShipmentPackages(ctx context.Context, shipmentIDs []uint) (map[uint][]domain.ShipmentPackage, error) {
+	if m.ListShipmentPackagesFn != nil {
+		return m.ListShipmentPackagesFn(ctx, shipmentIDs)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) SetShipmentPackageTrackingNumbers(ctx context.Context, shipmentID uint, trackingNumbers []string) error {
+	if m.SetShipmentPackageTrackingNumbersFn != nil {
+		return m.SetShipmentPackageTrackingNumbersFn(ctx, shipmentID, trackingNumbers)
+	}
+	return nil
+}
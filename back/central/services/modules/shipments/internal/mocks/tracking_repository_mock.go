@@ -0,0 +1,58 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
+)
+
+type TrackingRepositoryMock struct {
+	InsertTrackingEventsFn         func(ctx context.Context, events []domain.ShipmentTrackingEvent) (int, error)
+	ListTrackingEventsFn           func(ctx context.Context, shipmentID uint) ([]domain.ShipmentTrackingEvent, error)
+	ListCarrierStatusMappingsFn    func(ctx context.Context, provider string) ([]domain.CarrierStatusMapping, error)
+	UpsertCarrierStatusMappingFn   func(ctx context.Context, mapping *domain.CarrierStatusMapping) error
+	ListUnmappedTrackingStatusesFn func(ctx context.Context, provider string, limit int) ([]domain.UnmappedTrackingStatus, error)
+	GetCarrierTrackingMetricsFn    func(ctx context.Context, filter domain.TrackingMetricsFilter) ([]domain.CarrierTrackingMetrics, error)
+}
+
+func (m *TrackingRepositoryMock) InsertTrackingEvents(ctx context.Context, events []domain.ShipmentTrackingEvent) (int, error) {
+	if m.InsertTrackingEventsFn != nil {
+		return m.InsertTrackingEventsFn(ctx, events)
+	}
+	return len(events), nil
+}
+
+func (m *TrackingRepositoryMock) ListTrackingEvents(ctx context.Context, shipmentID uint) ([]domain.ShipmentTrackingEvent, error) {
+	if m.ListTrackingEventsFn != nil {
+		return m.ListTrackingEventsFn(ctx, shipmentID)
+	}
+	return nil, nil
+}
+
+func (m *TrackingRepositoryMock) ListCarrierStatusMappings(ctx context.Context, provider string) ([]domain.CarrierStatusMapping, error) {
+	if m.ListCarrierStatusMappingsFn != nil {
+		return m.ListCarrierStatusMappingsFn(ctx, provider)
+	}
+	return nil, nil
+}
+
+func (m *TrackingRepositoryMock) UpsertCarrierStatusMapping(ctx context.Context, mapping *domain.CarrierStatusMapping) error {
+	if m.UpsertCarrierStatusMappingFn != nil {
+		return m.UpsertCarrierStatusMappingFn(ctx, mapping)
+	}
+	return nil
+}
+
+func (m *TrackingRepositoryMock) ListUnmappedTrackingStatuses(ctx context.Context, provider string, limit int) ([]domain.UnmappedTrackingStatus, error) {
+	if m.ListUnmappedTrackingStatusesFn != nil {
+		return m.ListUnmappedTrackingStatusesFn(ctx, provider, limit)
+	}
+	return nil, nil
+}
+
+func (m *TrackingRepositoryMock) GetCarrierTrackingMetrics(ctx context.Context, filter domain.TrackingMetricsFilter) ([]domain.CarrierTrackingMetrics, error) {
+	if m.GetCarrierTrackingMetricsFn != nil {
+		return m.GetCarrierTrackingMetricsFn(ctx, filter)
+	}
+	return nil, nil
+}
@@ -0,0 +1,37 @@
+package shipments
+
+import (
+	"context"
+	"errors"
+
+	"github.com/secamc93/probability/back/central/services/modules/pay"
+	"github.com/secamc93/probability/back/central/services/modules/shipments/internal/domain"
+)
+
+// guideWallet cobra las guias con la billetera del modulo pay y traduce sus
+// errores a los del dominio de shipments
+type guideWallet struct {
+	pay *pay.Bundle
+}
+
+func (w guideWallet) HoldForGuide(ctx context.Context, businessID, shipmentID uint, amount float64, correlationID string) error {
+	return walletError(w.pay.HoldForGuide(ctx, businessID, shipmentID, amount, correlationID))
+}
+
+func (w guideWallet) DebitForGuide(ctx context.Context, businessID uint, amount float64, trackingNumber string, shipmentID *uint) error {
+	return walletError(w.pay.DebitForGuide(ctx, businessID, amount, trackingNumber, shipmentID))
+}
+
+func (w guideWallet) ReleaseGuideHolds(ctx context.Context, shipmentID uint) error {
+	return walletError(w.pay.ReleaseGuideHolds(ctx, shipmentID))
+}
+
+func walletError(err error) error {
+	switch {
+	case errors.Is(err, pay.ErrInsufficientBalance):
+		return domain.ErrInsufficientWalletBalance
+	case errors.Is(err, pay.ErrWalletNotFound):
+		return domain.ErrWalletNotFound
+	}
+	return err
+}
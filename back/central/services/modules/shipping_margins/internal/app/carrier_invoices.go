@@ -0,0 +1,284 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/errors"
+)
+
+func (uc *UseCase) InvoiceProfiles(ctx context.Context) ([]entities.CarrierInvoiceProfile, error) {
+	return uc.repo.InvoiceProfiles(ctx)
+}
+
+func (uc *UseCase) SaveInvoiceProfile(ctx context.Context, dto dtos.SaveInvoiceProfileDTO) (*entities.CarrierInvoiceProfile, error) {
+	code := strings.ToLower(strings.TrimSpace(dto.CarrierCode))
+	if code == "" {
+		return nil, domainerrors.ErrInvalidCarrierCode
+	}
+	p := &entities.CarrierInvoiceProfile{
+		CarrierCode:         code,
+		TrackingColumn:      strings.TrimSpace(dto.TrackingColumn),
+		AmountColumn:        strings.TrimSpace(dto.AmountColumn),
+		WeightColumn:        strings.TrimSpace(dto.WeightColumn),
+		SurchargeColumn:     strings.TrimSpace(dto.SurchargeColumn),
+		ConceptColumn:       strings.TrimSpace(dto.ConceptColumn),
+		InvoiceNumberColumn: strings.TrimSpace(dto.InvoiceNumberColumn),
+		HeaderRow:           dto.HeaderRow,
+		Delimiter:           dto.Delimiter,
+		DecimalSeparator:    dto.DecimalSeparator,
+		Tolerance:           dto.Tolerance,
+		WeightTolerance:     dto.WeightTolerance,
+	}
+	if p.TrackingColumn == "" || p.AmountColumn == "" {
+		return nil, errors.New("tracking_column and amount_column are required")
+	}
+	if p.HeaderRow < 1 {
+		p.HeaderRow = 1
+	}
+	if p.DecimalSeparator == "" {
+		p.DecimalSeparator = ","
+	}
+	if p.DecimalSeparator != "," && p.DecimalSeparator != "." {
+		return nil, errors.New("decimal_separator must be ',' or '.'")
+	}
+	if p.Tolerance < 0 || p.WeightTolerance < 0 {
+		return nil, errors.New("tolerance and weight_tolerance must be >= 0")
+	}
+	return uc.repo.SaveInvoiceProfile(ctx, p)
+}
+
+// ImportInvoice audita una factura de transportadora. Llega como archivo (se
+// lee con el perfil de la transportadora) o como filas ya leidas cuando se
+// trae por API; en ese caso el perfil solo aporta las tolerancias.
+func (uc *UseCase) ImportInvoice(ctx context.Context, dto dtos.ImportInvoiceDTO) (*entities.CarrierInvoice, []entities.CarrierInvoiceLine, error) {
+	code := strings.ToLower(strings.TrimSpace(dto.CarrierCode))
+	if code == "" {
+		return nil, nil, domainerrors.ErrInvalidCarrierCode
+	}
+	profile, err := uc.repo.InvoiceProfile(ctx, code)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	source := entities.InvoiceSourceAPI
+	var rows []entities.InvoiceRow
+	if len(dto.Rows) > 0 {
+		for _, r := range dto.Rows {
+			r.TrackingNumber = normalizeTracking(r.TrackingNumber)
+			if r.TrackingNumber != "" {
+				rows = append(rows, r)
+			}
+		}
+		if len(rows) == 0 {
+			return nil, nil, domainerrors.ErrInvoiceNoRows
+		}
+		if profile == nil {
+			profile = &entities.CarrierInvoiceProfile{CarrierCode: code}
+		}
+	} else {
+		if profile == nil {
+			return nil, nil, domainerrors.ErrInvoiceProfileNotFound
+		}
+		cells, err := readInvoiceRows(dto.FileName, dto.Content, profile.Delimiter)
+		if err != nil {
+			return nil, nil, err
+		}
+		if rows, err = parseInvoice(cells, *profile); err != nil {
+			return nil, nil, err
+		}
+		source = entities.InvoiceSourceFile
+	}
+
+	seen := map[string]bool{}
+	trackings := make([]string, 0, len(rows))
+	for _, r := range rows {
+		if !seen[r.TrackingNumber] {
+			seen[r.TrackingNumber] = true
+			trackings = append(trackings, r.TrackingNumber)
+		}
+	}
+	shipments, err := uc.repo.InvoiceShipmentsByTracking(ctx, trackings)
+	if err != nil {
+		return nil, nil, err
+	}
+	ids := make([]uint, len(shipments))
+	for i := range shipments {
+		ids[i] = shipments[i].ShipmentID
+	}
+	billedBefore, err := uc.repo.BilledShipments(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lines := auditInvoice(rows, shipments, billedBefore, *profile)
+
+	invoiceNumber := strings.TrimSpace(dto.InvoiceNumber)
+	if invoiceNumber == "" {
+		invoiceNumber = rows[0].InvoiceNumber
+	}
+	inv := &entities.CarrierInvoice{
+		CarrierCode:   code,
+		InvoiceNumber: invoiceNumber,
+		FileName:      dto.FileName,
+		Source:        source,
+		UploadedBy:    dto.UserID,
+	}
+	summarizeInvoice(inv, lines)
+
+	if err := uc.repo.CreateInvoice(ctx, inv, lines); err != nil {
+		return nil, nil, err
+	}
+	return inv, lines, nil
+}
+
+func (uc *UseCase) ListInvoices(ctx context.Context, params dtos.ListInvoicesParams) ([]entities.CarrierInvoice, int64, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+	params.CarrierCode = strings.ToLower(strings.TrimSpace(params.CarrierCode))
+	return uc.repo.ListInvoices(ctx, params)
+}
+
+func (uc *UseCase) InvoiceLines(ctx context.Context, invoiceID uint, discrepancy string) ([]entities.CarrierInvoiceLine, error) {
+	if _, err := uc.repo.GetInvoice(ctx, invoiceID); err != nil {
+		return nil, err
+	}
+	return uc.repo.InvoiceLines(ctx, invoiceID, strings.TrimSpace(discrepancy))
+}
+
+// DeleteInvoice borra una carga equivocada. Si ya se envio o resolvio alguna
+// disputa la factura hace parte del historial con la transportadora y no se
+// puede borrar.
+func (uc *UseCase) DeleteInvoice(ctx context.Context, invoiceID uint) error {
+	if _, err := uc.repo.GetInvoice(ctx, invoiceID); err != nil {
+		return err
+	}
+	lines, err := uc.repo.InvoiceLines(ctx, invoiceID, "")
+	if err != nil {
+		return err
+	}
+	for i := range lines {
+		if lines[i].DisputeStatus != "" && lines[i].DisputeStatus != entities.DisputeOpen {
+			return domainerrors.ErrInvoiceHasDisputes
+		}
+	}
+	return uc.repo.DeleteInvoice(ctx, invoiceID)
+}
+
+func (uc *UseCase) Disputes(ctx context.Context, params dtos.DisputesParams) ([]entities.CarrierInvoiceLine, error) {
+	params.CarrierCode = strings.ToLower(strings.TrimSpace(params.CarrierCode))
+	if params.Status != "" && !validDisputeStatus(params.Status) {
+		return nil, domainerrors.ErrInvalidDisputeStatus
+	}
+	return uc.repo.Disputes(ctx, params)
+}
+
+// ExportDisputes devuelve las disputas a enviar a la transportadora. Con
+// markSent las que estaban abiertas pasan a enviadas.
+func (uc *UseCase) ExportDisputes(ctx context.Context, params dtos.DisputesParams, markSent bool) ([]entities.CarrierInvoiceLine, error) {
+	lines, err := uc.Disputes(ctx, params)
+	if err != nil || !markSent {
+		return lines, err
+	}
+
+	now := time.Now().UTC()
+	var changed []entities.CarrierInvoiceLine
+	for i := range lines {
+		if lines[i].DisputeStatus != entities.DisputeOpen {
+			continue
+		}
+		lines[i].DisputeStatus = entities.DisputeSent
+		lines[i].DisputeSentAt = &now
+		changed = append(changed, lines[i])
+	}
+	if len(changed) > 0 {
+		if err := uc.repo.UpdateDisputeLines(ctx, changed); err != nil {
+			return nil, err
+		}
+	}
+	return lines, nil
+}
+
+// UpdateDisputes registra la respuesta de la transportadora. Lo acreditado al
+// aceptar se descuenta del costo facturado en el reporte de ganancias.
+func (uc *UseCase) UpdateDisputes(ctx context.Context, dto dtos.UpdateDisputesDTO) ([]entities.CarrierInvoiceLine, error) {
+	if !validDisputeStatus(dto.Status) {
+		return nil, domainerrors.ErrInvalidDisputeStatus
+	}
+	if len(dto.LineIDs) == 0 {
+		return nil, domainerrors.ErrNoDisputeLines
+	}
+	if dto.CreditedAmount != nil {
+		if dto.Status != entities.DisputeAccepted || len(dto.LineIDs) != 1 {
+			return nil, errors.New("credited_amount only applies when accepting a single line")
+		}
+		if *dto.CreditedAmount < 0 {
+			return nil, errors.New("credited_amount must be >= 0")
+		}
+	}
+
+	found, err := uc.repo.InvoiceLinesByIDs(ctx, dto.LineIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	lines := make([]entities.CarrierInvoiceLine, 0, len(found))
+	for _, l := range found {
+		if l.DisputeStatus == "" {
+			continue
+		}
+		if l.DisputeStatus == entities.DisputeAccepted || l.DisputeStatus == entities.DisputeRejected {
+			return nil, domainerrors.ErrDisputeClosed
+		}
+
+		l.DisputeStatus = dto.Status
+		if note := strings.TrimSpace(dto.Note); note != "" {
+			l.DisputeNote = note
+		}
+		switch dto.Status {
+		case entities.DisputeSent:
+			if l.DisputeSentAt == nil {
+				l.DisputeSentAt = &now
+			}
+		case entities.DisputeAccepted:
+			credited := l.DisputedAmount
+			if dto.CreditedAmount != nil {
+				credited = *dto.CreditedAmount
+			}
+			if credited > l.BilledAmount {
+				credited = l.BilledAmount
+			}
+			l.CreditedAmount = round2(credited)
+			l.ResolvedAt = &now
+		case entities.DisputeRejected:
+			l.CreditedAmount = 0
+			l.ResolvedAt = &now
+		}
+		lines = append(lines, l)
+	}
+	if len(lines) == 0 {
+		return nil, domainerrors.ErrNoDisputeLines
+	}
+
+	if err := uc.repo.UpdateDisputeLines(ctx, lines); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func validDisputeStatus(s string) bool {
+	switch s {
+	case entities.DisputeOpen, entities.DisputeSent, entities.DisputeAccepted, entities.DisputeRejected:
+		return true
+	}
+	return false
+}
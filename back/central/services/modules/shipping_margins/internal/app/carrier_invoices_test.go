@@ -0,0 +1,275 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func peso(v float64) *float64 { return &v }
+
+func lineasPorGuia(lines []entities.CarrierInvoiceLine) map[string][]entities.CarrierInvoiceLine {
+	out := map[string][]entities.CarrierInvoiceLine{}
+	for _, l := range lines {
+		out[l.TrackingNumber] = append(out[l.TrackingNumber], l)
+	}
+	return out
+}
+
+func TestParseInvoice_PerfilConEncabezadoYMiles(t *testing.T) {
+	profile := entities.CarrierInvoiceProfile{
+		TrackingColumn:   "Número Guía",
+		AmountColumn:     "Valor Total",
+		WeightColumn:     "Peso Kg",
+		ConceptColumn:    "Concepto",
+		HeaderRow:        2,
+		DecimalSeparator: ",",
+	}
+	cells := [][]string{
+		{"Factura FE-991"},
+		{"NUMERO GUIA", "valor_total", "peso kg", "concepto"},
+		{"'0240 001", "12.500", "3,5", "Flete"},
+		{"", "12.500", "", "TOTAL"},
+	}
+
+	rows, err := parseInvoice(cells, profile)
+
+	require.NoError(t, err)
+	require.Len(t, rows, 1, "la fila de total sin guia se salta")
+	assert.Equal(t, "0240001", rows[0].TrackingNumber)
+	assert.Equal(t, 12500.0, rows[0].Amount)
+	require.NotNil(t, rows[0].Weight)
+	assert.Equal(t, 3.5, *rows[0].Weight)
+	assert.Equal(t, 3, rows[0].RowNumber)
+}
+
+func TestParseInvoice_ColumnaFaltante(t *testing.T) {
+	_, err := parseInvoice([][]string{{"guia", "valor"}}, entities.CarrierInvoiceProfile{TrackingColumn: "guia", AmountColumn: "total"})
+	assert.Error(t, err)
+}
+
+func TestAuditInvoice_ClasificaCadaGuia(t *testing.T) {
+	profile := entities.CarrierInvoiceProfile{Tolerance: 100, WeightTolerance: 0.5}
+	shipments := []entities.InvoiceShipment{
+		{ShipmentID: 1, BusinessID: 26, ExpectedCost: 10000, Trackings: []string{"G1"}},
+		{ShipmentID: 2, BusinessID: 26, ExpectedCost: 10000, Weight: peso(2), Trackings: []string{"G2"}},
+		{ShipmentID: 3, BusinessID: 27, ExpectedCost: 10000, Trackings: []string{"G3"}},
+		{ShipmentID: 4, BusinessID: 27, ExpectedCost: 10000, Trackings: []string{"G4"}},
+		{ShipmentID: 5, BusinessID: 27, ExpectedCost: 10000, Status: "cancelled", Trackings: []string{"G5"}},
+		{ShipmentID: 6, BusinessID: 27, ExpectedCost: 10000, Trackings: []string{"G6"}},
+		{ShipmentID: 7, BusinessID: 28, ExpectedCost: 15000, Trackings: []string{"MASTER7", "PKG-7B"}},
+	}
+	rows := []entities.InvoiceRow{
+		{RowNumber: 2, TrackingNumber: "G1", Amount: 10050, Concept: "Flete"},
+		{RowNumber: 3, TrackingNumber: "G2", Amount: 14000, Weight: peso(5), Concept: "Flete"},
+		{RowNumber: 4, TrackingNumber: "G3", Amount: 10000, Concept: "Flete"},
+		{RowNumber: 5, TrackingNumber: "G3", Amount: 2500, Concept: "Zona de dificil acceso"},
+		{RowNumber: 6, TrackingNumber: "G4", Amount: 11000, Concept: "Flete"},
+		{RowNumber: 7, TrackingNumber: "G4", Amount: 11000, Concept: "Flete"},
+		{RowNumber: 8, TrackingNumber: "G5", Amount: 9000, Concept: "Flete"},
+		{RowNumber: 9, TrackingNumber: "G6", Amount: 10000, Concept: "Flete"},
+		{RowNumber: 10, TrackingNumber: "NOEXISTE", Amount: 7000},
+		{RowNumber: 11, TrackingNumber: "pkg-7b", Amount: 15000},
+	}
+
+	lines := auditInvoice(rows, shipments, map[uint]bool{6: true}, profile)
+	got := lineasPorGuia(lines)
+
+	assert.Equal(t, entities.DiscrepancyMatched, got["G1"][0].Discrepancy, "50 de diferencia esta dentro de la tolerancia")
+	assert.Empty(t, got["G1"][0].DisputeStatus)
+
+	assert.Equal(t, entities.DiscrepancyReweigh, got["G2"][0].Discrepancy)
+	assert.Equal(t, 4000.0, got["G2"][0].DisputedAmount)
+	assert.Equal(t, entities.DisputeOpen, got["G2"][0].DisputeStatus)
+
+	require.Len(t, got["G3"], 1, "las filas de una misma guia se suman")
+	assert.Equal(t, entities.DiscrepancySurcharge, got["G3"][0].Discrepancy)
+	assert.Equal(t, 12500.0, got["G3"][0].BilledAmount)
+	assert.Equal(t, 2500.0, got["G3"][0].SurchargeAmount)
+	assert.Equal(t, "Flete + Zona de dificil acceso", got["G3"][0].Concept)
+
+	require.Len(t, got["G4"], 2)
+	assert.Equal(t, entities.DiscrepancyOvercharge, got["G4"][0].Discrepancy)
+	assert.Equal(t, entities.DiscrepancyDuplicate, got["G4"][1].Discrepancy)
+	assert.Equal(t, 11000.0, got["G4"][1].DisputedAmount, "el cobro repetido se reclama completo")
+
+	assert.Equal(t, entities.DiscrepancyBilledCancelled, got["G5"][0].Discrepancy)
+	assert.Equal(t, 9000.0, got["G5"][0].DisputedAmount)
+
+	assert.Equal(t, entities.DiscrepancyDuplicate, got["G6"][0].Discrepancy, "el envio ya venia en otra factura")
+
+	assert.Equal(t, entities.DiscrepancyUnknownTracking, got["NOEXISTE"][0].Discrepancy)
+	assert.Nil(t, got["NOEXISTE"][0].ShipmentID)
+
+	require.NotNil(t, got["pkg-7b"][0].ShipmentID, "la guia de bulto encuentra el envio")
+	assert.Equal(t, uint(7), *got["pkg-7b"][0].ShipmentID)
+	assert.Equal(t, entities.DiscrepancyMatched, got["pkg-7b"][0].Discrepancy)
+
+	var inv entities.CarrierInvoice
+	summarizeInvoice(&inv, lines)
+	assert.Equal(t, 9, inv.LinesCount)
+	assert.Equal(t, 2, inv.MatchedCount)
+	assert.Equal(t, 6, inv.DiscrepancyCount)
+	assert.Equal(t, 1, inv.UnknownCount)
+}
+
+func TestImportInvoice_ArchivoSinPerfil_Rechaza(t *testing.T) {
+	repo := &mocks.RepositoryMock{}
+
+	_, _, err := newMarginsUseCase(repo, nil).ImportInvoice(context.Background(), dtos.ImportInvoiceDTO{
+		CarrierCode: "coordinadora",
+		FileName:    "factura.csv",
+		Content:     []byte("guia,valor\n1,100\n"),
+	})
+
+	assert.ErrorIs(t, err, domainerrors.ErrInvoiceProfileNotFound)
+	assert.Nil(t, repo.CreatedInvoice)
+}
+
+func TestImportInvoice_CSVConPerfil(t *testing.T) {
+	var buscadas []string
+	repo := &mocks.RepositoryMock{
+		InvoiceProfileFn: func(ctx context.Context, carrierCode string) (*entities.CarrierInvoiceProfile, error) {
+			assert.Equal(t, "servientrega", carrierCode)
+			return &entities.CarrierInvoiceProfile{
+				CarrierCode: carrierCode, TrackingColumn: "Guia", AmountColumn: "Valor",
+				InvoiceNumberColumn: "Factura", HeaderRow: 1, DecimalSeparator: ",",
+			}, nil
+		},
+		InvoiceShipmentsByTrackingFn: func(ctx context.Context, trackings []string) ([]entities.InvoiceShipment, error) {
+			buscadas = trackings
+			return []entities.InvoiceShipment{{ShipmentID: 9, BusinessID: 26, ExpectedCost: 8000, Trackings: []string{"240001"}}}, nil
+		},
+	}
+
+	inv, lines, err := newMarginsUseCase(repo, nil).ImportInvoice(context.Background(), dtos.ImportInvoiceDTO{
+		CarrierCode: " Servientrega ",
+		FileName:    "factura.csv",
+		Content:     []byte("Guia;Valor;Factura\n240001;9.500;FE-12\n240001;9.500;FE-12\n"),
+		UserID:      3,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"240001"}, buscadas, "cada guia se busca una sola vez")
+	assert.Len(t, lines, 2)
+	assert.Equal(t, "servientrega", inv.CarrierCode)
+	assert.Equal(t, "FE-12", inv.InvoiceNumber, "el numero de factura sale del archivo si no se indica")
+	assert.Equal(t, entities.InvoiceSourceFile, inv.Source)
+	assert.Equal(t, 19000.0, inv.BilledTotal)
+	assert.Equal(t, 8000.0, inv.ExpectedTotal)
+	assert.Equal(t, 11000.0, inv.DisputedTotal)
+	assert.Same(t, inv, repo.CreatedInvoice)
+}
+
+func TestImportInvoice_FilasPorAPISinPerfil(t *testing.T) {
+	repo := &mocks.RepositoryMock{}
+
+	inv, lines, err := newMarginsUseCase(repo, nil).ImportInvoice(context.Background(), dtos.ImportInvoiceDTO{
+		CarrierCode:   "envia",
+		InvoiceNumber: "API-7",
+		Rows: []entities.InvoiceRow{
+			{RowNumber: 1, TrackingNumber: " 'x1 "},
+			{RowNumber: 2, TrackingNumber: "   "},
+		},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, lines, 1, "las filas sin guia se descartan")
+	assert.Equal(t, "X1", lines[0].TrackingNumber)
+	assert.Equal(t, entities.InvoiceSourceAPI, inv.Source)
+	assert.Equal(t, 1, inv.UnknownCount)
+}
+
+func TestUpdateDisputes_AceptarAcreditaLoReclamado(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		InvoiceLinesByIDsFn: func(ctx context.Context, ids []uint) ([]entities.CarrierInvoiceLine, error) {
+			return []entities.CarrierInvoiceLine{
+				{ID: 1, BilledAmount: 14000, DisputedAmount: 4000, DisputeStatus: entities.DisputeSent},
+				{ID: 2, BilledAmount: 10000, Discrepancy: entities.DiscrepancyMatched},
+			}, nil
+		},
+	}
+
+	lines, err := newMarginsUseCase(repo, nil).UpdateDisputes(context.Background(), dtos.UpdateDisputesDTO{
+		LineIDs: []uint{1, 2}, Status: entities.DisputeAccepted, Note: "nota credito NC-4",
+	})
+
+	require.NoError(t, err)
+	require.Len(t, lines, 1, "las lineas sin disputa no se tocan")
+	assert.Equal(t, 4000.0, lines[0].CreditedAmount)
+	assert.Equal(t, "nota credito NC-4", lines[0].DisputeNote)
+	assert.NotNil(t, lines[0].ResolvedAt)
+	assert.Len(t, repo.UpdatedDisputes, 1)
+}
+
+func TestUpdateDisputes_ValorAcreditadoSoloParaUnaLinea(t *testing.T) {
+	credito := 1000.0
+	repo := &mocks.RepositoryMock{}
+
+	_, err := newMarginsUseCase(repo, nil).UpdateDisputes(context.Background(), dtos.UpdateDisputesDTO{
+		LineIDs: []uint{1, 2}, Status: entities.DisputeAccepted, CreditedAmount: &credito,
+	})
+
+	assert.Error(t, err)
+	assert.Empty(t, repo.UpdatedDisputes)
+}
+
+func TestUpdateDisputes_DisputaResuelta_Rechaza(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		InvoiceLinesByIDsFn: func(ctx context.Context, ids []uint) ([]entities.CarrierInvoiceLine, error) {
+			return []entities.CarrierInvoiceLine{{ID: 1, DisputeStatus: entities.DisputeRejected}}, nil
+		},
+	}
+
+	_, err := newMarginsUseCase(repo, nil).UpdateDisputes(context.Background(), dtos.UpdateDisputesDTO{
+		LineIDs: []uint{1}, Status: entities.DisputeAccepted,
+	})
+
+	assert.ErrorIs(t, err, domainerrors.ErrDisputeClosed)
+	assert.Empty(t, repo.UpdatedDisputes)
+}
+
+func TestExportDisputes_MarcaEnviadasSoloLasAbiertas(t *testing.T) {
+	repo := &mocks.RepositoryMock{
+		DisputesFn: func(ctx context.Context, params dtos.DisputesParams) ([]entities.CarrierInvoiceLine, error) {
+			assert.Equal(t, "tcc", params.CarrierCode)
+			return []entities.CarrierInvoiceLine{
+				{ID: 1, DisputeStatus: entities.DisputeOpen},
+				{ID: 2, DisputeStatus: entities.DisputeAccepted},
+			}, nil
+		},
+	}
+
+	lines, err := newMarginsUseCase(repo, nil).ExportDisputes(context.Background(), dtos.DisputesParams{CarrierCode: "TCC"}, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, entities.DisputeSent, lines[0].DisputeStatus)
+	assert.NotNil(t, lines[0].DisputeSentAt)
+	assert.Equal(t, entities.DisputeAccepted, lines[1].DisputeStatus)
+	require.Len(t, repo.UpdatedDisputes, 1)
+	assert.Equal(t, uint(1), repo.UpdatedDisputes[0].ID)
+}
+
+func TestDeleteInvoice_ConDisputasEnviadas_Rechaza(t *testing.T) {
+	borrada := false
+	repo := &mocks.RepositoryMock{
+		InvoiceLinesFn: func(ctx context.Context, invoiceID uint, discrepancy string) ([]entities.CarrierInvoiceLine, error) {
+			return []entities.CarrierInvoiceLine{{ID: 1, DisputeStatus: entities.DisputeSent}}, nil
+		},
+		DeleteInvoiceFn: func(ctx context.Context, id uint) error {
+			borrada = true
+			return nil
+		},
+	}
+
+	err := newMarginsUseCase(repo, nil).DeleteInvoice(context.Background(), 4)
+
+	assert.ErrorIs(t, err, domainerrors.ErrInvoiceHasDisputes)
+	assert.False(t, borrada)
+}
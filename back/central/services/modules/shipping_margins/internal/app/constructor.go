@@ -16,6 +16,16 @@ type IUseCase interface {
 	ProfitReport(ctx context.Context, params dtos.ProfitReportParams) (*dtos.ProfitReportResponse, error)
 	ProfitReportDetail(ctx context.Context, params dtos.ProfitReportDetailParams) (*dtos.ProfitReportDetailResponse, error)
 	EnsureDefaultsForBusiness(ctx context.Context, businessID uint) error
+
+	InvoiceProfiles(ctx context.Context) ([]entities.CarrierInvoiceProfile, error)
+	SaveInvoiceProfile(ctx context.Context, dto dtos.SaveInvoiceProfileDTO) (*entities.CarrierInvoiceProfile, error)
+	ImportInvoice(ctx context.Context, dto dtos.ImportInvoiceDTO) (*entities.CarrierInvoice, []entities.CarrierInvoiceLine, error)
+	ListInvoices(ctx context.Context, params dtos.ListInvoicesParams) ([]entities.CarrierInvoice, int64, error)
+	InvoiceLines(ctx context.Context, invoiceID uint, discrepancy string) ([]entities.CarrierInvoiceLine, error)
+	DeleteInvoice(ctx context.Context, invoiceID uint) error
+	Disputes(ctx context.Context, params dtos.DisputesParams) ([]entities.CarrierInvoiceLine, error)
+	ExportDisputes(ctx context.Context, params dtos.DisputesParams, markSent bool) ([]entities.CarrierInvoiceLine, error)
+	UpdateDisputes(ctx context.Context, dto dtos.UpdateDisputesDTO) ([]entities.CarrierInvoiceLine, error)
 }
 
 type UseCase struct {
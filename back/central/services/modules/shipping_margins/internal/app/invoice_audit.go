@@ -0,0 +1,331 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/errors"
+)
+
+var plainNumber = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+
+var headerReplacer = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+	"_", " ", ".", " ", ":", " ",
+)
+
+// normalizeTracking deja la guia en mayusculas y sin espacios; Excel antepone
+// un apostrofe a las guias numericas para no perder los ceros.
+func normalizeTracking(v string) string {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "'")
+	return strings.ToUpper(strings.Join(strings.Fields(v), ""))
+}
+
+func normalizeHeader(v string) string {
+	v = headerReplacer.Replace(strings.ToLower(strings.TrimSpace(v)))
+	return strings.Join(strings.Fields(v), " ")
+}
+
+// parseAmount lee valores con separador de miles. Un numero plano (como los
+// entrega XLSX) se toma tal cual, salvo que el punto tenga exactamente tres
+// digitos detras y el separador decimal sea coma: ahi es de miles.
+func parseAmount(v, decimalSep string) (float64, error) {
+	s := strings.TrimSpace(v)
+	if s == "" {
+		return 0, nil
+	}
+	negative := strings.HasPrefix(s, "-") || (strings.HasPrefix(s, "(") && strings.HasSuffix(s, ")"))
+	s = strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '.' || r == ',' {
+			return r
+		}
+		return -1
+	}, s)
+	if s == "" {
+		return 0, fmt.Errorf("invalid amount %q", v)
+	}
+
+	if plainNumber.MatchString(s) {
+		dot := strings.LastIndex(s, ".")
+		if decimalSep == "." || dot < 0 || len(s)-dot-1 != 3 {
+			f, err := strconv.ParseFloat(s, 64)
+			if negative {
+				f = -f
+			}
+			return f, err
+		}
+	}
+
+	thousands := "."
+	if decimalSep == "." {
+		thousands = ","
+	}
+	s = strings.ReplaceAll(s, thousands, "")
+	if decimalSep != "." {
+		s = strings.ReplaceAll(s, ",", ".")
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", v)
+	}
+	if negative {
+		f = -f
+	}
+	return f, nil
+}
+
+// parseInvoice convierte las celdas del archivo en filas con el perfil de la
+// transportadora. Las filas sin guia (totales, notas) se saltan.
+func parseInvoice(cells [][]string, p entities.CarrierInvoiceProfile) ([]entities.InvoiceRow, error) {
+	headerIdx := p.HeaderRow - 1
+	if headerIdx < 0 {
+		headerIdx = 0
+	}
+	if len(cells) <= headerIdx {
+		return nil, fmt.Errorf("the file has no header row %d", headerIdx+1)
+	}
+
+	cols := make(map[string]int, len(cells[headerIdx]))
+	for i, h := range cells[headerIdx] {
+		key := normalizeHeader(h)
+		if _, dup := cols[key]; !dup && key != "" {
+			cols[key] = i
+		}
+	}
+	column := func(name string, required bool) (int, error) {
+		if strings.TrimSpace(name) == "" {
+			if required {
+				return -1, errors.New("the profile has no tracking or amount column")
+			}
+			return -1, nil
+		}
+		idx, ok := cols[normalizeHeader(name)]
+		if !ok {
+			return -1, fmt.Errorf("column %q not found in the file", name)
+		}
+		return idx, nil
+	}
+
+	trackingCol, err := column(p.TrackingColumn, true)
+	if err != nil {
+		return nil, err
+	}
+	amountCol, err := column(p.AmountColumn, true)
+	if err != nil {
+		return nil, err
+	}
+	weightCol, err := column(p.WeightColumn, false)
+	if err != nil {
+		return nil, err
+	}
+	surchargeCol, err := column(p.SurchargeColumn, false)
+	if err != nil {
+		return nil, err
+	}
+	conceptCol, err := column(p.ConceptColumn, false)
+	if err != nil {
+		return nil, err
+	}
+	invoiceCol, err := column(p.InvoiceNumberColumn, false)
+	if err != nil {
+		return nil, err
+	}
+
+	out := []entities.InvoiceRow{}
+	for i := headerIdx + 1; i < len(cells); i++ {
+		record := cells[i]
+		cell := func(idx int) string {
+			if idx < 0 || idx >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[idx])
+		}
+		rowNumber := i + 1
+
+		tracking := normalizeTracking(cell(trackingCol))
+		if tracking == "" {
+			continue
+		}
+		amount, err := parseAmount(cell(amountCol), p.DecimalSeparator)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", rowNumber, err)
+		}
+		surcharge, err := parseAmount(cell(surchargeCol), p.DecimalSeparator)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", rowNumber, err)
+		}
+		row := entities.InvoiceRow{
+			RowNumber:      rowNumber,
+			TrackingNumber: tracking,
+			Amount:         amount,
+			Surcharge:      surcharge,
+			Concept:        cell(conceptCol),
+			InvoiceNumber:  cell(invoiceCol),
+		}
+		if raw := cell(weightCol); raw != "" {
+			w, err := parseAmount(raw, p.DecimalSeparator)
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %w", rowNumber, err)
+			}
+			row.Weight = &w
+		}
+		out = append(out, row)
+	}
+	if len(out) == 0 {
+		return nil, domainerrors.ErrInvoiceNoRows
+	}
+	return out, nil
+}
+
+// auditInvoice cruza cada guia facturada con su envio. Las filas de una misma
+// guia se suman en una linea (las adicionales cuentan como recargo), salvo las
+// que repiten concepto y valor o las de un envio ya facturado antes: esas son
+// cobro duplicado. Lo que supera el costo esperado mas la tolerancia se
+// clasifica como repesaje, recargo o sobrecosto y queda como disputa abierta.
+func auditInvoice(rows []entities.InvoiceRow, shipments []entities.InvoiceShipment, billedBefore map[uint]bool, p entities.CarrierInvoiceProfile) []entities.CarrierInvoiceLine {
+	byTracking := make(map[string]*entities.InvoiceShipment, len(shipments))
+	for i := range shipments {
+		for _, t := range shipments[i].Trackings {
+			if key := normalizeTracking(t); key != "" {
+				byTracking[key] = &shipments[i]
+			}
+		}
+	}
+
+	lines := []entities.CarrierInvoiceLine{}
+	aggregated := map[uint]int{}
+	charges := map[string]bool{}
+
+	for _, row := range rows {
+		sh := byTracking[normalizeTracking(row.TrackingNumber)]
+		if sh == nil {
+			lines = append(lines, entities.CarrierInvoiceLine{
+				RowNumber:       row.RowNumber,
+				TrackingNumber:  row.TrackingNumber,
+				Concept:         row.Concept,
+				InvoiceNumber:   row.InvoiceNumber,
+				BilledAmount:    row.Amount,
+				SurchargeAmount: row.Surcharge,
+				BilledWeight:    row.Weight,
+				Discrepancy:     entities.DiscrepancyUnknownTracking,
+			})
+			continue
+		}
+
+		shipmentID, businessID := sh.ShipmentID, sh.BusinessID
+		chargeKey := fmt.Sprintf("%d|%s|%.2f", sh.ShipmentID, strings.ToLower(strings.TrimSpace(row.Concept)), row.Amount)
+		if billedBefore[sh.ShipmentID] || charges[chargeKey] {
+			lines = append(lines, entities.CarrierInvoiceLine{
+				ShipmentID:      &shipmentID,
+				BusinessID:      &businessID,
+				RowNumber:       row.RowNumber,
+				TrackingNumber:  row.TrackingNumber,
+				Concept:         row.Concept,
+				InvoiceNumber:   row.InvoiceNumber,
+				BilledAmount:    row.Amount,
+				SurchargeAmount: row.Surcharge,
+				BilledWeight:    row.Weight,
+				DeclaredWeight:  sh.Weight,
+				Discrepancy:     entities.DiscrepancyDuplicate,
+			})
+			continue
+		}
+		charges[chargeKey] = true
+
+		if j, ok := aggregated[sh.ShipmentID]; ok {
+			l := &lines[j]
+			l.BilledAmount += row.Amount
+			if row.Surcharge > 0 {
+				l.SurchargeAmount += row.Surcharge
+			} else {
+				l.SurchargeAmount += row.Amount
+			}
+			if row.Weight != nil && (l.BilledWeight == nil || *row.Weight > *l.BilledWeight) {
+				l.BilledWeight = row.Weight
+			}
+			if row.Concept != "" && !strings.Contains(l.Concept, row.Concept) {
+				l.Concept = strings.TrimPrefix(l.Concept+" + "+row.Concept, " + ")
+			}
+			continue
+		}
+		aggregated[sh.ShipmentID] = len(lines)
+		lines = append(lines, entities.CarrierInvoiceLine{
+			ShipmentID:      &shipmentID,
+			BusinessID:      &businessID,
+			RowNumber:       row.RowNumber,
+			TrackingNumber:  row.TrackingNumber,
+			Concept:         row.Concept,
+			InvoiceNumber:   row.InvoiceNumber,
+			BilledAmount:    row.Amount,
+			ExpectedAmount:  sh.ExpectedCost,
+			SurchargeAmount: row.Surcharge,
+			BilledWeight:    row.Weight,
+			DeclaredWeight:  sh.Weight,
+		})
+	}
+
+	statusByShipment := make(map[uint]string, len(shipments))
+	for i := range shipments {
+		statusByShipment[shipments[i].ShipmentID] = shipments[i].Status
+	}
+
+	for i := range lines {
+		l := &lines[i]
+		l.BilledAmount = round2(l.BilledAmount)
+		l.SurchargeAmount = round2(l.SurchargeAmount)
+		if l.Discrepancy == "" && l.ShipmentID != nil && statusByShipment[*l.ShipmentID] == "cancelled" {
+			l.Discrepancy = entities.DiscrepancyBilledCancelled
+			l.ExpectedAmount = 0
+		}
+		l.Difference = round2(l.BilledAmount - l.ExpectedAmount)
+
+		if l.Discrepancy == "" {
+			switch {
+			case l.Difference <= p.Tolerance:
+				l.Discrepancy = entities.DiscrepancyMatched
+			case l.BilledWeight != nil && l.DeclaredWeight != nil && *l.BilledWeight > *l.DeclaredWeight+p.WeightTolerance:
+				l.Discrepancy = entities.DiscrepancyReweigh
+			case l.SurchargeAmount > 0:
+				l.Discrepancy = entities.DiscrepancySurcharge
+			default:
+				l.Discrepancy = entities.DiscrepancyOvercharge
+			}
+		}
+		if l.Discrepancy != entities.DiscrepancyMatched && l.Difference > 0 {
+			l.DisputedAmount = l.Difference
+			l.DisputeStatus = entities.DisputeOpen
+		}
+	}
+	return lines
+}
+
+func summarizeInvoice(inv *entities.CarrierInvoice, lines []entities.CarrierInvoiceLine) {
+	inv.LinesCount = len(lines)
+	inv.MatchedCount, inv.DiscrepancyCount, inv.UnknownCount = 0, 0, 0
+	inv.BilledTotal, inv.ExpectedTotal, inv.DisputedTotal = 0, 0, 0
+	for i := range lines {
+		switch lines[i].Discrepancy {
+		case entities.DiscrepancyMatched:
+			inv.MatchedCount++
+		case entities.DiscrepancyUnknownTracking:
+			inv.UnknownCount++
+		default:
+			inv.DiscrepancyCount++
+		}
+		inv.BilledTotal += lines[i].BilledAmount
+		inv.ExpectedTotal += lines[i].ExpectedAmount
+		inv.DisputedTotal += lines[i].DisputedAmount
+	}
+	inv.BilledTotal = round2(inv.BilledTotal)
+	inv.ExpectedTotal = round2(inv.ExpectedTotal)
+	inv.DisputedTotal = round2(inv.DisputedTotal)
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,77 @@
+package app
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// readInvoiceRows devuelve las celdas de la factura como texto. En XLSX
+// se leen los valores crudos (numeros con punto decimal, fechas como serial)
+// para no depender del formato de celda que use cada transportadora.
+func readInvoiceRows(filename string, content []byte, delimiter string) ([][]string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv", ".txt":
+		return readInvoiceCSV(content, delimiter)
+	case ".xlsx", ".xlsm":
+		return readInvoiceXLSX(content)
+	default:
+		return nil, errors.New("unsupported format: use CSV or XLSX")
+	}
+}
+
+func readInvoiceCSV(content []byte, delimiter string) ([][]string, error) {
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+
+	comma := ','
+	switch {
+	case delimiter == `\t` || delimiter == "tab":
+		comma = '\t'
+	case delimiter != "":
+		comma = []rune(delimiter)[0]
+	default:
+		scanner := bufio.NewScanner(bytes.NewReader(content))
+		if scanner.Scan() {
+			line := scanner.Text()
+			if strings.Count(line, ";") > strings.Count(line, ",") {
+				comma = ';'
+			} else if strings.Count(line, "\t") > strings.Count(line, ",") {
+				comma = '\t'
+			}
+		}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = comma
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	return rows, nil
+}
+
+func readInvoiceXLSX(content []byte) ([][]string, error) {
+	f, err := excelize.OpenReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, fmt.Errorf("cannot open Excel file: %w", err)
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("the Excel file has no sheets")
+	}
+	rows, err := f.GetRows(sheets[0], excelize.Options{RawCellValue: true})
+	if err != nil {
+		return nil, fmt.Errorf("cannot read sheet %s: %w", sheets[0], err)
+	}
+	return rows, nil
+}
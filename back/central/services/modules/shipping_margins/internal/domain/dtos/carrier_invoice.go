@@ -0,0 +1,50 @@
+package dtos
+
+import "github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/entities"
+
+type SaveInvoiceProfileDTO struct {
+	CarrierCode         string
+	TrackingColumn      string
+	AmountColumn        string
+	WeightColumn        string
+	SurchargeColumn     string
+	ConceptColumn       string
+	InvoiceNumberColumn string
+	HeaderRow           int
+	Delimiter           string
+	DecimalSeparator    string
+	Tolerance           float64
+	WeightTolerance     float64
+}
+
+// ImportInvoiceDTO trae el archivo (Content) o, cuando la factura se obtiene
+// por API de la transportadora, las filas ya leidas (Rows).
+type ImportInvoiceDTO struct {
+	CarrierCode   string
+	InvoiceNumber string
+	FileName      string
+	Content       []byte
+	Rows          []entities.InvoiceRow
+	UserID        uint
+}
+
+type ListInvoicesParams struct {
+	CarrierCode string
+	Page        int
+	PageSize    int
+}
+
+type DisputesParams struct {
+	CarrierCode string
+	InvoiceID   uint
+	Status      string
+}
+
+// UpdateDisputesDTO cambia el estado de varias disputas. CreditedAmount solo
+// aplica al aceptar una sola linea; sin el se acredita lo reclamado.
+type UpdateDisputesDTO struct {
+	LineIDs        []uint
+	Status         string
+	CreditedAmount *float64
+	Note           string
+}
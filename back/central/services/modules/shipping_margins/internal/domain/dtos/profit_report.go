@@ -16,6 +16,11 @@ type ProfitReportRow struct {
 	CarrierCostTotal    float64 `json:"carrier_cost_total"`
 	CustomerChargeTotal float64 `json:"customer_charge_total"`
 	ProfitTotal         float64 `json:"profit_total"`
+	// Ajustado con las facturas de la transportadora
+	BilledShipments      int     `json:"billed_shipments"`
+	RealCarrierCostTotal float64 `json:"real_carrier_cost_total"`
+	CostAdjustmentTotal  float64 `json:"cost_adjustment_total"`
+	RealProfitTotal      float64 `json:"real_profit_total"`
 }
 
 type ProfitReportResponse struct {
@@ -41,6 +46,8 @@ type ProfitReportDetailRow struct {
 	CustomerCharge float64   `json:"customer_charge"`
 	CarrierCost    float64   `json:"carrier_cost"`
 	Profit         float64   `json:"profit"`
+	BilledCost     *float64  `json:"billed_cost"`
+	RealProfit     float64   `json:"real_profit"`
 	Status         string    `json:"status"`
 	CreatedAt      time.Time `json:"created_at"`
 }
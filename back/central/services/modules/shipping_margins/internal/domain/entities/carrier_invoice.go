@@ -0,0 +1,111 @@
+package entities
+
+import "time"
+
+// Resultado de auditar una guia facturada contra el envio
+const (
+	DiscrepancyMatched         = "matched"
+	DiscrepancyReweigh         = "reweigh"
+	DiscrepancySurcharge       = "surcharge"
+	DiscrepancyOvercharge      = "overcharge"
+	DiscrepancyDuplicate       = "duplicate_billing"
+	DiscrepancyBilledCancelled = "billed_cancelled"
+	DiscrepancyUnknownTracking = "unknown_tracking"
+)
+
+// Estados de la disputa de una linea. Vacio = no hay nada que reclamar.
+const (
+	DisputeOpen     = "open"
+	DisputeSent     = "sent"
+	DisputeAccepted = "accepted"
+	DisputeRejected = "rejected"
+)
+
+const (
+	InvoiceSourceFile = "file"
+	InvoiceSourceAPI  = "api"
+)
+
+type CarrierInvoiceProfile struct {
+	ID                  uint
+	CarrierCode         string
+	TrackingColumn      string
+	AmountColumn        string
+	WeightColumn        string
+	SurchargeColumn     string
+	ConceptColumn       string
+	InvoiceNumberColumn string
+	HeaderRow           int
+	Delimiter           string
+	DecimalSeparator    string
+	Tolerance           float64
+	WeightTolerance     float64
+	UpdatedAt           time.Time
+}
+
+// InvoiceRow fila de la factura ya leida. Amount es el total cobrado en la
+// fila; Surcharge, si la transportadora lo separa, la parte que es recargo.
+type InvoiceRow struct {
+	RowNumber      int
+	TrackingNumber string
+	Amount         float64
+	Surcharge      float64
+	Weight         *float64
+	Concept        string
+	InvoiceNumber  string
+}
+
+// InvoiceShipment envio contra el que se audita una guia facturada.
+// ExpectedCost es el costo real guardado al generar la guia.
+type InvoiceShipment struct {
+	ShipmentID   uint
+	BusinessID   uint
+	Carrier      string
+	Status       string
+	ExpectedCost float64
+	Weight       *float64
+	Trackings    []string
+}
+
+type CarrierInvoice struct {
+	ID               uint
+	CarrierCode      string
+	InvoiceNumber    string
+	FileName         string
+	Source           string
+	LinesCount       int
+	MatchedCount     int
+	DiscrepancyCount int
+	UnknownCount     int
+	BilledTotal      float64
+	ExpectedTotal    float64
+	DisputedTotal    float64
+	UploadedBy       uint
+	CreatedAt        time.Time
+}
+
+type CarrierInvoiceLine struct {
+	ID               uint
+	CarrierInvoiceID uint
+	CarrierCode      string
+	InvoiceNumber    string
+	ShipmentID       *uint
+	BusinessID       *uint
+	RowNumber        int
+	TrackingNumber   string
+	Concept          string
+	BilledAmount     float64
+	ExpectedAmount   float64
+	Difference       float64
+	SurchargeAmount  float64
+	BilledWeight     *float64
+	DeclaredWeight   *float64
+	Discrepancy      string
+	DisputeStatus    string
+	DisputedAmount   float64
+	CreditedAmount   float64
+	DisputeNote      string
+	DisputeSentAt    *time.Time
+	ResolvedAt       *time.Time
+	CreatedAt        time.Time
+}
@@ -7,4 +7,12 @@ var (
 	ErrDuplicateCarrier        = errors.New("a shipping margin for this carrier already exists for this business")
 	ErrInvalidCarrierCode      = errors.New("invalid carrier_code")
 	ErrInvalidMargin           = errors.New("margin_amount and insurance_margin must be >= 0")
+
+	ErrInvoiceProfileNotFound  = errors.New("no invoice profile configured for this carrier")
+	ErrInvoiceNoRows           = errors.New("the invoice has no tracking numbers to audit")
+	ErrInvoiceNotFound         = errors.New("carrier invoice not found")
+	ErrInvoiceHasDisputes      = errors.New("the invoice has disputes already sent or resolved")
+	ErrInvalidDisputeStatus    = errors.New("invalid dispute status")
+	ErrDisputeClosed           = errors.New("the dispute is already resolved")
+	ErrNoDisputeLines          = errors.New("no dispute lines to update")
 )
@@ -17,6 +17,20 @@ type IRepository interface {
 
 	ProfitReport(ctx context.Context, params dtos.ProfitReportParams) (*dtos.ProfitReportResponse, error)
 	ProfitReportDetail(ctx context.Context, params dtos.ProfitReportDetailParams) (*dtos.ProfitReportDetailResponse, error)
+
+	InvoiceProfiles(ctx context.Context) ([]entities.CarrierInvoiceProfile, error)
+	InvoiceProfile(ctx context.Context, carrierCode string) (*entities.CarrierInvoiceProfile, error)
+	SaveInvoiceProfile(ctx context.Context, p *entities.CarrierInvoiceProfile) (*entities.CarrierInvoiceProfile, error)
+	InvoiceShipmentsByTracking(ctx context.Context, trackings []string) ([]entities.InvoiceShipment, error)
+	BilledShipments(ctx context.Context, shipmentIDs []uint) (map[uint]bool, error)
+	CreateInvoice(ctx context.Context, inv *entities.CarrierInvoice, lines []entities.CarrierInvoiceLine) error
+	ListInvoices(ctx context.Context, params dtos.ListInvoicesParams) ([]entities.CarrierInvoice, int64, error)
+	GetInvoice(ctx context.Context, id uint) (*entities.CarrierInvoice, error)
+	InvoiceLines(ctx context.Context, invoiceID uint, discrepancy string) ([]entities.CarrierInvoiceLine, error)
+	InvoiceLinesByIDs(ctx context.Context, ids []uint) ([]entities.CarrierInvoiceLine, error)
+	DeleteInvoice(ctx context.Context, id uint) error
+	Disputes(ctx context.Context, params dtos.DisputesParams) ([]entities.CarrierInvoiceLine, error)
+	UpdateDisputeLines(ctx context.Context, lines []entities.CarrierInvoiceLine) error
 }
 
 type ICacheWriter interface {
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/errors"
+	"github.com/xuri/excelize/v2"
+)
+
+var disputeExportHeaders = []string{
+	"transportadora", "factura", "guia", "concepto", "tipo",
+	"valor_facturado", "valor_esperado", "diferencia", "recargo",
+	"peso_facturado", "peso_declarado", "valor_reclamado", "estado", "nota",
+}
+
+// ExportDisputes descarga las disputas en CSV o XLSX para enviarlas a la
+// transportadora. Con mark_sent=true las abiertas quedan como enviadas.
+func (h *Handlers) ExportDisputes(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	params, ok := disputesParams(c)
+	if !ok {
+		return
+	}
+	format := c.DefaultQuery("format", "xlsx")
+	if format != "xlsx" && format != "csv" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv or xlsx"})
+		return
+	}
+
+	lines, err := h.uc.ExportDisputes(c.Request.Context(), params, c.Query("mark_sent") == "true")
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidDisputeStatus) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := "disputas-transportadora"
+	if params.CarrierCode != "" {
+		filename += "-" + params.CarrierCode
+	}
+	filename += "-" + time.Now().Format("2006-01-02") + "." + format
+
+	if format == "csv" {
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		_ = w.Write(disputeExportHeaders)
+		for _, l := range lines {
+			_ = w.Write(disputeExportRecord(l))
+		}
+		w.Flush()
+		c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", buf.Bytes())
+		return
+	}
+
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheet := "Disputas"
+	f.SetSheetName("Sheet1", sheet)
+	for col, head := range disputeExportHeaders {
+		cell, _ := excelize.CoordinatesToCellName(col+1, 1)
+		f.SetCellValue(sheet, cell, head)
+	}
+	for i, l := range lines {
+		values := []interface{}{
+			l.CarrierCode, l.InvoiceNumber, l.TrackingNumber, l.Concept, l.Discrepancy,
+			l.BilledAmount, l.ExpectedAmount, l.Difference, l.SurchargeAmount,
+			nil, nil, l.DisputedAmount, l.DisputeStatus, l.DisputeNote,
+		}
+		if l.BilledWeight != nil {
+			values[9] = *l.BilledWeight
+		}
+		if l.DeclaredWeight != nil {
+			values[10] = *l.DeclaredWeight
+		}
+		for col, v := range values {
+			if v == nil {
+				continue
+			}
+			cell, _ := excelize.CoordinatesToCellName(col+1, i+2)
+			f.SetCellValue(sheet, cell, v)
+		}
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", buf.Bytes())
+}
+
+func disputeExportRecord(l entities.CarrierInvoiceLine) []string {
+	weight := func(w *float64) string {
+		if w == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*w, 'f', -1, 64)
+	}
+	money := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	}
+	return []string{
+		l.CarrierCode, l.InvoiceNumber, l.TrackingNumber, l.Concept, l.Discrepancy,
+		money(l.BilledAmount), money(l.ExpectedAmount), money(l.Difference), money(l.SurchargeAmount),
+		weight(l.BilledWeight), weight(l.DeclaredWeight), money(l.DisputedAmount), l.DisputeStatus, l.DisputeNote,
+	}
+}
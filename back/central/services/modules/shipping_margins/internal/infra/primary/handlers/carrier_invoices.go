@@ -0,0 +1,295 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+	"github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/infra/primary/handlers/response"
+)
+
+const maxInvoiceFileSize = 10 << 20
+
+func (h *Handlers) InvoiceProfiles(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	profiles, err := h.uc.InvoiceProfiles(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	data := make([]response.InvoiceProfileResponse, len(profiles))
+	for i := range profiles {
+		data[i] = response.FromInvoiceProfile(&profiles[i])
+	}
+	c.JSON(http.StatusOK, gin.H{"data": data})
+}
+
+func (h *Handlers) SaveInvoiceProfile(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	var req request.SaveInvoiceProfileRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	p, err := h.uc.SaveInvoiceProfile(c.Request.Context(), dtos.SaveInvoiceProfileDTO{
+		CarrierCode:         req.CarrierCode,
+		TrackingColumn:      req.TrackingColumn,
+		AmountColumn:        req.AmountColumn,
+		WeightColumn:        req.WeightColumn,
+		SurchargeColumn:     req.SurchargeColumn,
+		ConceptColumn:       req.ConceptColumn,
+		InvoiceNumberColumn: req.InvoiceNumberColumn,
+		HeaderRow:           req.HeaderRow,
+		Delimiter:           req.Delimiter,
+		DecimalSeparator:    req.DecimalSeparator,
+		Tolerance:           req.Tolerance,
+		WeightTolerance:     req.WeightTolerance,
+	})
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, response.FromInvoiceProfile(p))
+}
+
+// ImportInvoice recibe la factura como archivo (multipart: file, carrier_code
+// y opcionalmente invoice_number).
+func (h *Handlers) ImportInvoice(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxInvoiceFileSize+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "cannot read file"})
+		return
+	}
+	if len(content) > maxInvoiceFileSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file exceeds 10 MB"})
+		return
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	h.respondImport(c, dtos.ImportInvoiceDTO{
+		CarrierCode:   c.PostForm("carrier_code"),
+		InvoiceNumber: c.PostForm("invoice_number"),
+		FileName:      header.Filename,
+		Content:       content,
+		UserID:        userID,
+	})
+}
+
+// ImportInvoiceLines recibe la factura ya leida, para las transportadoras que
+// la exponen por API.
+func (h *Handlers) ImportInvoiceLines(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	var req request.ImportInvoiceLinesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	rows := make([]entities.InvoiceRow, len(req.Lines))
+	for i, l := range req.Lines {
+		rows[i] = entities.InvoiceRow{
+			RowNumber:      i + 1,
+			TrackingNumber: l.TrackingNumber,
+			Amount:         l.Amount,
+			Surcharge:      l.Surcharge,
+			Weight:         l.Weight,
+			Concept:        l.Concept,
+		}
+	}
+
+	userID, _ := middleware.GetUserID(c)
+	h.respondImport(c, dtos.ImportInvoiceDTO{
+		CarrierCode:   req.CarrierCode,
+		InvoiceNumber: req.InvoiceNumber,
+		Rows:          rows,
+		UserID:        userID,
+	})
+}
+
+func (h *Handlers) respondImport(c *gin.Context, dto dtos.ImportInvoiceDTO) {
+	inv, lines, err := h.uc.ImportInvoice(c.Request.Context(), dto)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrInvoiceProfileNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"invoice": response.FromCarrierInvoice(inv),
+		"lines":   response.FromCarrierInvoiceLines(lines),
+	})
+}
+
+func (h *Handlers) ListInvoices(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+
+	items, total, err := h.uc.ListInvoices(c.Request.Context(), dtos.ListInvoicesParams{
+		CarrierCode: c.Query("carrier_code"),
+		Page:        page,
+		PageSize:    pageSize,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	data := make([]response.CarrierInvoiceResponse, len(items))
+	for i := range items {
+		data[i] = response.FromCarrierInvoice(&items[i])
+	}
+	totalPages := int(total) / pageSize
+	if int(total)%pageSize != 0 {
+		totalPages++
+	}
+	c.JSON(http.StatusOK, response.CarrierInvoicesListResponse{
+		Data:       data,
+		Total:      total,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalPages: totalPages,
+	})
+}
+
+func (h *Handlers) InvoiceLines(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	lines, err := h.uc.InvoiceLines(c.Request.Context(), uint(id), c.Query("discrepancy"))
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvoiceNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": response.FromCarrierInvoiceLines(lines)})
+}
+
+func (h *Handlers) DeleteInvoice(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	if err := h.uc.DeleteInvoice(c.Request.Context(), uint(id)); err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrInvoiceNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, domainerrors.ErrInvoiceHasDisputes):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handlers) Disputes(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	params, ok := disputesParams(c)
+	if !ok {
+		return
+	}
+	lines, err := h.uc.Disputes(c.Request.Context(), params)
+	if err != nil {
+		if errors.Is(err, domainerrors.ErrInvalidDisputeStatus) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": response.FromCarrierInvoiceLines(lines)})
+}
+
+func (h *Handlers) UpdateDisputes(c *gin.Context) {
+	if !h.requireSuperAdmin(c) {
+		return
+	}
+	var req request.UpdateDisputesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	lines, err := h.uc.UpdateDisputes(c.Request.Context(), dtos.UpdateDisputesDTO{
+		LineIDs:        req.LineIDs,
+		Status:         req.Status,
+		CreditedAmount: req.CreditedAmount,
+		Note:           req.Note,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domainerrors.ErrDisputeClosed):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, domainerrors.ErrNoDisputeLines):
+			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": response.FromCarrierInvoiceLines(lines)})
+}
+
+func disputesParams(c *gin.Context) (dtos.DisputesParams, bool) {
+	params := dtos.DisputesParams{
+		CarrierCode: c.Query("carrier_code"),
+		Status:      c.Query("status"),
+	}
+	if raw := c.Query("invoice_id"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil || id == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid invoice_id"})
+			return params, false
+		}
+		params.InvoiceID = uint(id)
+	}
+	return params, true
+}
@@ -14,6 +14,9 @@ type IHandlers interface {
 	Create(c *gin.Context)
 	Update(c *gin.Context)
 	ProfitReport(c *gin.Context)
+	ImportInvoice(c *gin.Context)
+	Disputes(c *gin.Context)
+	ExportDisputes(c *gin.Context)
 	RegisterRoutes(router *gin.RouterGroup)
 }
 
@@ -0,0 +1,39 @@
+package request
+
+type SaveInvoiceProfileRequest struct {
+	CarrierCode         string  `json:"carrier_code" binding:"required,min=2,max=50"`
+	TrackingColumn      string  `json:"tracking_column" binding:"required,max=128"`
+	AmountColumn        string  `json:"amount_column" binding:"required,max=128"`
+	WeightColumn        string  `json:"weight_column" binding:"max=128"`
+	SurchargeColumn     string  `json:"surcharge_column" binding:"max=128"`
+	ConceptColumn       string  `json:"concept_column" binding:"max=128"`
+	InvoiceNumberColumn string  `json:"invoice_number_column" binding:"max=128"`
+	HeaderRow           int     `json:"header_row" binding:"gte=0"`
+	Delimiter           string  `json:"delimiter" binding:"max=4"`
+	DecimalSeparator    string  `json:"decimal_separator" binding:"max=1"`
+	Tolerance           float64 `json:"tolerance" binding:"gte=0"`
+	WeightTolerance     float64 `json:"weight_tolerance" binding:"gte=0"`
+}
+
+// ImportInvoiceLinesRequest factura traida por API de la transportadora y ya
+// normalizada por quien la consulta.
+type ImportInvoiceLinesRequest struct {
+	CarrierCode   string               `json:"carrier_code" binding:"required,min=2,max=50"`
+	InvoiceNumber string               `json:"invoice_number" binding:"max=64"`
+	Lines         []InvoiceLineRequest `json:"lines" binding:"required,min=1,dive"`
+}
+
+type InvoiceLineRequest struct {
+	TrackingNumber string   `json:"tracking_number" binding:"required,max=128"`
+	Amount         float64  `json:"amount"`
+	Surcharge      float64  `json:"surcharge" binding:"gte=0"`
+	Weight         *float64 `json:"weight" binding:"omitempty,gte=0"`
+	Concept        string   `json:"concept" binding:"max=255"`
+}
+
+type UpdateDisputesRequest struct {
+	LineIDs        []uint   `json:"line_ids" binding:"required,min=1"`
+	Status         string   `json:"status" binding:"required,oneof=open sent accepted rejected"`
+	CreditedAmount *float64 `json:"credited_amount" binding:"omitempty,gte=0"`
+	Note           string   `json:"note" binding:"max=500"`
+}
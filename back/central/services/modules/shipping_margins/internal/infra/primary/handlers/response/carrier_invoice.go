@@ -0,0 +1,141 @@
+package response
+
+import (
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/entities"
+)
+
+type InvoiceProfileResponse struct {
+	ID                  uint      `json:"id"`
+	CarrierCode         string    `json:"carrier_code"`
+	TrackingColumn      string    `json:"tracking_column"`
+	AmountColumn        string    `json:"amount_column"`
+	WeightColumn        string    `json:"weight_column"`
+	SurchargeColumn     string    `json:"surcharge_column"`
+	ConceptColumn       string    `json:"concept_column"`
+	InvoiceNumberColumn string    `json:"invoice_number_column"`
+	HeaderRow           int       `json:"header_row"`
+	Delimiter           string    `json:"delimiter"`
+	DecimalSeparator    string    `json:"decimal_separator"`
+	Tolerance           float64   `json:"tolerance"`
+	WeightTolerance     float64   `json:"weight_tolerance"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+type CarrierInvoiceResponse struct {
+	ID               uint      `json:"id"`
+	CarrierCode      string    `json:"carrier_code"`
+	InvoiceNumber    string    `json:"invoice_number"`
+	FileName         string    `json:"file_name"`
+	Source           string    `json:"source"`
+	LinesCount       int       `json:"lines_count"`
+	MatchedCount     int       `json:"matched_count"`
+	DiscrepancyCount int       `json:"discrepancy_count"`
+	UnknownCount     int       `json:"unknown_count"`
+	BilledTotal      float64   `json:"billed_total"`
+	ExpectedTotal    float64   `json:"expected_total"`
+	DisputedTotal    float64   `json:"disputed_total"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type CarrierInvoicesListResponse struct {
+	Data       []CarrierInvoiceResponse `json:"data"`
+	Total      int64                    `json:"total"`
+	Page       int                      `json:"page"`
+	PageSize   int                      `json:"page_size"`
+	TotalPages int                      `json:"total_pages"`
+}
+
+type CarrierInvoiceLineResponse struct {
+	ID               uint       `json:"id"`
+	CarrierInvoiceID uint       `json:"carrier_invoice_id"`
+	CarrierCode      string     `json:"carrier_code"`
+	InvoiceNumber    string     `json:"invoice_number"`
+	ShipmentID       *uint      `json:"shipment_id"`
+	BusinessID       *uint      `json:"business_id"`
+	RowNumber        int        `json:"row_number"`
+	TrackingNumber   string     `json:"tracking_number"`
+	Concept          string     `json:"concept"`
+	BilledAmount     float64    `json:"billed_amount"`
+	ExpectedAmount   float64    `json:"expected_amount"`
+	Difference       float64    `json:"difference"`
+	SurchargeAmount  float64    `json:"surcharge_amount"`
+	BilledWeight     *float64   `json:"billed_weight"`
+	DeclaredWeight   *float64   `json:"declared_weight"`
+	Discrepancy      string     `json:"discrepancy"`
+	DisputeStatus    string     `json:"dispute_status"`
+	DisputedAmount   float64    `json:"disputed_amount"`
+	CreditedAmount   float64    `json:"credited_amount"`
+	DisputeNote      string     `json:"dispute_note"`
+	DisputeSentAt    *time.Time `json:"dispute_sent_at"`
+	ResolvedAt       *time.Time `json:"resolved_at"`
+}
+
+func FromInvoiceProfile(p *entities.CarrierInvoiceProfile) InvoiceProfileResponse {
+	return InvoiceProfileResponse{
+		ID:                  p.ID,
+		CarrierCode:         p.CarrierCode,
+		TrackingColumn:      p.TrackingColumn,
+		AmountColumn:        p.AmountColumn,
+		WeightColumn:        p.WeightColumn,
+		SurchargeColumn:     p.SurchargeColumn,
+		ConceptColumn:       p.ConceptColumn,
+		InvoiceNumberColumn: p.InvoiceNumberColumn,
+		HeaderRow:           p.HeaderRow,
+		Delimiter:           p.Delimiter,
+		DecimalSeparator:    p.DecimalSeparator,
+		Tolerance:           p.Tolerance,
+		WeightTolerance:     p.WeightTolerance,
+		UpdatedAt:           p.UpdatedAt,
+	}
+}
+
+func FromCarrierInvoice(inv *entities.CarrierInvoice) CarrierInvoiceResponse {
+	return CarrierInvoiceResponse{
+		ID:               inv.ID,
+		CarrierCode:      inv.CarrierCode,
+		InvoiceNumber:    inv.InvoiceNumber,
+		FileName:         inv.FileName,
+		Source:           inv.Source,
+		LinesCount:       inv.LinesCount,
+		MatchedCount:     inv.MatchedCount,
+		DiscrepancyCount: inv.DiscrepancyCount,
+		UnknownCount:     inv.UnknownCount,
+		BilledTotal:      inv.BilledTotal,
+		ExpectedTotal:    inv.ExpectedTotal,
+		DisputedTotal:    inv.DisputedTotal,
+		CreatedAt:        inv.CreatedAt,
+	}
+}
+
+func FromCarrierInvoiceLines(lines []entities.CarrierInvoiceLine) []CarrierInvoiceLineResponse {
+	out := make([]CarrierInvoiceLineResponse, len(lines))
+	for i, l := range lines {
+		out[i] = CarrierInvoiceLineResponse{
+			ID:               l.ID,
+			CarrierInvoiceID: l.CarrierInvoiceID,
+			CarrierCode:      l.CarrierCode,
+			InvoiceNumber:    l.InvoiceNumber,
+			ShipmentID:       l.ShipmentID,
+			BusinessID:       l.BusinessID,
+			RowNumber:        l.RowNumber,
+			TrackingNumber:   l.TrackingNumber,
+			Concept:          l.Concept,
+			BilledAmount:     l.BilledAmount,
+			ExpectedAmount:   l.ExpectedAmount,
+			Difference:       l.Difference,
+			SurchargeAmount:  l.SurchargeAmount,
+			BilledWeight:     l.BilledWeight,
+			DeclaredWeight:   l.DeclaredWeight,
+			Discrepancy:      l.Discrepancy,
+			DisputeStatus:    l.DisputeStatus,
+			DisputedAmount:   l.DisputedAmount,
+			CreditedAmount:   l.CreditedAmount,
+			DisputeNote:      l.DisputeNote,
+			DisputeSentAt:    l.DisputeSentAt,
+			ResolvedAt:       l.ResolvedAt,
+		}
+	}
+	return out
+}
@@ -12,6 +12,16 @@ func (h *Handlers) RegisterRoutes(router *gin.RouterGroup) {
 		g.GET("", h.List)
 		g.GET("/profit-report", h.ProfitReport)
 		g.GET("/profit-report/detail", h.ProfitReportDetail)
+		g.GET("/carrier-invoices", h.ListInvoices)
+		g.POST("/carrier-invoices", h.ImportInvoice)
+		g.POST("/carrier-invoices/lines", h.ImportInvoiceLines)
+		g.GET("/carrier-invoices/profiles", h.InvoiceProfiles)
+		g.PUT("/carrier-invoices/profiles", h.SaveInvoiceProfile)
+		g.GET("/carrier-invoices/disputes", h.Disputes)
+		g.GET("/carrier-invoices/disputes/export", h.ExportDisputes)
+		g.PUT("/carrier-invoices/disputes", h.UpdateDisputes)
+		g.GET("/carrier-invoices/:id/lines", h.InvoiceLines)
+		g.DELETE("/carrier-invoices/:id", h.DeleteInvoice)
 		g.GET("/:id", h.Get)
 		g.POST("", h.Create)
 		g.PUT("/:id", h.Update)
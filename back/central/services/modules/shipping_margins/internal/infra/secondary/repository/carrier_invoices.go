@@ -0,0 +1,407 @@
+package repository
+
+import (
+	"context"
+	stderrors "errors"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/entities"
+	domainerrors "github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/errors"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+)
+
+const invoiceTrackingBatch = 1000
+
+// expectedCarrierCost costo real del carrier guardado al generar la guia. Las
+// guias viejas sin carrier_cost se estiman con el total menos el margen.
+const expectedCarrierCost = `COALESCE(s.carrier_cost, s.total_cost - COALESCE(s.applied_margin, 0), 0)`
+
+func (r *Repository) InvoiceProfiles(ctx context.Context) ([]entities.CarrierInvoiceProfile, error) {
+	var rows []models.CarrierInvoiceProfile
+	if err := r.db.Conn(ctx).Order("carrier_code ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]entities.CarrierInvoiceProfile, len(rows))
+	for i := range rows {
+		out[i] = *invoiceProfileToEntity(&rows[i])
+	}
+	return out, nil
+}
+
+func (r *Repository) InvoiceProfile(ctx context.Context, carrierCode string) (*entities.CarrierInvoiceProfile, error) {
+	var row models.CarrierInvoiceProfile
+	err := r.db.Conn(ctx).Where("carrier_code = ?", carrierCode).First(&row).Error
+	if err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return invoiceProfileToEntity(&row), nil
+}
+
+func (r *Repository) SaveInvoiceProfile(ctx context.Context, p *entities.CarrierInvoiceProfile) (*entities.CarrierInvoiceProfile, error) {
+	var row models.CarrierInvoiceProfile
+	err := r.db.Conn(ctx).Where("carrier_code = ?", p.CarrierCode).First(&row).Error
+	if err != nil && !stderrors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	row.CarrierCode = p.CarrierCode
+	row.TrackingColumn = p.TrackingColumn
+	row.AmountColumn = p.AmountColumn
+	row.WeightColumn = p.WeightColumn
+	row.SurchargeColumn = p.SurchargeColumn
+	row.ConceptColumn = p.ConceptColumn
+	row.InvoiceNumberColumn = p.InvoiceNumberColumn
+	row.HeaderRow = p.HeaderRow
+	row.Delimiter = p.Delimiter
+	row.DecimalSeparator = p.DecimalSeparator
+	row.Tolerance = p.Tolerance
+	row.WeightTolerance = p.WeightTolerance
+
+	if err := r.db.Conn(ctx).Save(&row).Error; err != nil {
+		return nil, err
+	}
+	return invoiceProfileToEntity(&row), nil
+}
+
+type invoiceShipmentRow struct {
+	ShipmentID     uint
+	BusinessID     uint
+	Carrier        string
+	Status         string
+	ExpectedCost   float64
+	Weight         *float64
+	TrackingNumber string
+	PackageGuides  string
+}
+
+// InvoiceShipmentsByTracking busca los envios de cualquier negocio por guia
+// principal o por guia de bulto.
+func (r *Repository) InvoiceShipmentsByTracking(ctx context.Context, trackings []string) ([]entities.InvoiceShipment, error) {
+	out := []entities.InvoiceShipment{}
+	for start := 0; start < len(trackings); start += invoiceTrackingBatch {
+		end := start + invoiceTrackingBatch
+		if end > len(trackings) {
+			end = len(trackings)
+		}
+		batch := trackings[start:end]
+
+		var rows []invoiceShipmentRow
+		err := r.db.Conn(ctx).Raw(`
+SELECT s.id AS shipment_id, o.business_id,
+	COALESCE(s.carrier, '') AS carrier,
+	COALESCE(s.status, '') AS status,
+	`+expectedCarrierCost+` AS expected_cost,
+	s.weight,
+	COALESCE(s.tracking_number, '') AS tracking_number,
+	COALESCE((SELECT STRING_AGG(sp.tracking_number, ',') FROM shipment_packages sp WHERE sp.shipment_id = s.id AND COALESCE(sp.tracking_number, '') <> ''), '') AS package_guides
+FROM shipments s
+JOIN orders o ON o.id = s.order_id
+WHERE s.deleted_at IS NULL
+	AND (UPPER(REPLACE(COALESCE(s.tracking_number, ''), ' ', '')) IN ?
+		OR EXISTS (SELECT 1 FROM shipment_packages sp WHERE sp.shipment_id = s.id AND UPPER(REPLACE(COALESCE(sp.tracking_number, ''), ' ', '')) IN ?))`,
+			batch, batch).Scan(&rows).Error
+		if err != nil {
+			return nil, err
+		}
+		for i := range rows {
+			trackings := []string{rows[i].TrackingNumber}
+			if rows[i].PackageGuides != "" {
+				trackings = append(trackings, strings.Split(rows[i].PackageGuides, ",")...)
+			}
+			out = append(out, entities.InvoiceShipment{
+				ShipmentID:   rows[i].ShipmentID,
+				BusinessID:   rows[i].BusinessID,
+				Carrier:      rows[i].Carrier,
+				Status:       rows[i].Status,
+				ExpectedCost: rows[i].ExpectedCost,
+				Weight:       rows[i].Weight,
+				Trackings:    trackings,
+			})
+		}
+	}
+	return out, nil
+}
+
+// BilledShipments envios que ya tienen un cobro en otra factura. Los cobros
+// marcados como duplicados no cuentan: el original es el que quedo antes.
+func (r *Repository) BilledShipments(ctx context.Context, shipmentIDs []uint) (map[uint]bool, error) {
+	out := map[uint]bool{}
+	if len(shipmentIDs) == 0 {
+		return out, nil
+	}
+	var ids []uint
+	err := r.db.Conn(ctx).Model(&models.CarrierInvoiceLine{}).
+		Distinct("shipment_id").
+		Where("shipment_id IN ? AND discrepancy <> ?", shipmentIDs, entities.DiscrepancyDuplicate).
+		Pluck("shipment_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		out[id] = true
+	}
+	return out, nil
+}
+
+func (r *Repository) CreateInvoice(ctx context.Context, inv *entities.CarrierInvoice, lines []entities.CarrierInvoiceLine) error {
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		row := models.CarrierInvoice{
+			CarrierCode:      inv.CarrierCode,
+			InvoiceNumber:    truncate(inv.InvoiceNumber, 64),
+			FileName:         truncate(inv.FileName, 255),
+			Source:           inv.Source,
+			LinesCount:       inv.LinesCount,
+			MatchedCount:     inv.MatchedCount,
+			DiscrepancyCount: inv.DiscrepancyCount,
+			UnknownCount:     inv.UnknownCount,
+			BilledTotal:      inv.BilledTotal,
+			ExpectedTotal:    inv.ExpectedTotal,
+			DisputedTotal:    inv.DisputedTotal,
+			UploadedBy:       inv.UploadedBy,
+		}
+		if err := tx.Create(&row).Error; err != nil {
+			return err
+		}
+
+		if len(lines) > 0 {
+			rows := make([]models.CarrierInvoiceLine, len(lines))
+			for i, l := range lines {
+				rows[i] = models.CarrierInvoiceLine{
+					CarrierInvoiceID: row.ID,
+					CarrierCode:      inv.CarrierCode,
+					ShipmentID:       l.ShipmentID,
+					BusinessID:       l.BusinessID,
+					RowNumber:        l.RowNumber,
+					TrackingNumber:   truncate(l.TrackingNumber, 128),
+					Concept:          truncate(l.Concept, 255),
+					BilledAmount:     l.BilledAmount,
+					ExpectedAmount:   l.ExpectedAmount,
+					Difference:       l.Difference,
+					SurchargeAmount:  l.SurchargeAmount,
+					BilledWeight:     l.BilledWeight,
+					DeclaredWeight:   l.DeclaredWeight,
+					Discrepancy:      l.Discrepancy,
+					DisputeStatus:    l.DisputeStatus,
+					DisputedAmount:   l.DisputedAmount,
+				}
+			}
+			if err := tx.Omit("CarrierInvoice").CreateInBatches(&rows, 500).Error; err != nil {
+				return err
+			}
+			for i := range rows {
+				lines[i].ID = rows[i].ID
+				lines[i].CarrierInvoiceID = row.ID
+				lines[i].CarrierCode = inv.CarrierCode
+				lines[i].InvoiceNumber = inv.InvoiceNumber
+				lines[i].CreatedAt = rows[i].CreatedAt
+			}
+		}
+
+		inv.ID = row.ID
+		inv.CreatedAt = row.CreatedAt
+		return nil
+	})
+}
+
+func (r *Repository) ListInvoices(ctx context.Context, params dtos.ListInvoicesParams) ([]entities.CarrierInvoice, int64, error) {
+	var rows []models.CarrierInvoice
+	var total int64
+
+	query := r.db.Conn(ctx).Model(&models.CarrierInvoice{})
+	if params.CarrierCode != "" {
+		query = query.Where("carrier_code = ?", params.CarrierCode)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.Offset((params.Page - 1) * params.PageSize).Limit(params.PageSize).
+		Order("created_at DESC, id DESC").
+		Find(&rows).Error; err != nil {
+		return nil, 0, err
+	}
+
+	out := make([]entities.CarrierInvoice, len(rows))
+	for i := range rows {
+		out[i] = *invoiceToEntity(&rows[i])
+	}
+	return out, total, nil
+}
+
+func (r *Repository) GetInvoice(ctx context.Context, id uint) (*entities.CarrierInvoice, error) {
+	var row models.CarrierInvoice
+	if err := r.db.Conn(ctx).First(&row, id).Error; err != nil {
+		if stderrors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainerrors.ErrInvoiceNotFound
+		}
+		return nil, err
+	}
+	return invoiceToEntity(&row), nil
+}
+
+type invoiceLineRow struct {
+	models.CarrierInvoiceLine
+	InvoiceNumber string
+}
+
+func (r *Repository) invoiceLinesQuery(ctx context.Context) *gorm.DB {
+	return r.db.Conn(ctx).Table("carrier_invoice_lines AS l").
+		Select("l.*, COALESCE(i.invoice_number, '') AS invoice_number").
+		Joins("JOIN carrier_invoices i ON i.id = l.carrier_invoice_id AND i.deleted_at IS NULL").
+		Where("l.deleted_at IS NULL")
+}
+
+func (r *Repository) InvoiceLines(ctx context.Context, invoiceID uint, discrepancy string) ([]entities.CarrierInvoiceLine, error) {
+	q := r.invoiceLinesQuery(ctx).Where("l.carrier_invoice_id = ?", invoiceID)
+	if discrepancy != "" {
+		q = q.Where("l.discrepancy = ?", discrepancy)
+	}
+	var rows []invoiceLineRow
+	if err := q.Order("l.row_number ASC, l.id ASC").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return invoiceLinesToEntities(rows), nil
+}
+
+func (r *Repository) InvoiceLinesByIDs(ctx context.Context, ids []uint) ([]entities.CarrierInvoiceLine, error) {
+	var rows []invoiceLineRow
+	if err := r.invoiceLinesQuery(ctx).Where("l.id IN ?", ids).Order("l.id ASC").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return invoiceLinesToEntities(rows), nil
+}
+
+func (r *Repository) DeleteInvoice(ctx context.Context, id uint) error {
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("carrier_invoice_id = ?", id).Delete(&models.CarrierInvoiceLine{}).Error; err != nil {
+			return err
+		}
+		res := tx.Delete(&models.CarrierInvoice{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return domainerrors.ErrInvoiceNotFound
+		}
+		return nil
+	})
+}
+
+func (r *Repository) Disputes(ctx context.Context, params dtos.DisputesParams) ([]entities.CarrierInvoiceLine, error) {
+	q := r.invoiceLinesQuery(ctx).Where("COALESCE(l.dispute_status, '') <> ''")
+	if params.CarrierCode != "" {
+		q = q.Where("l.carrier_code = ?", params.CarrierCode)
+	}
+	if params.InvoiceID > 0 {
+		q = q.Where("l.carrier_invoice_id = ?", params.InvoiceID)
+	}
+	if params.Status != "" {
+		q = q.Where("l.dispute_status = ?", params.Status)
+	}
+	var rows []invoiceLineRow
+	if err := q.Order("l.carrier_code ASC, l.carrier_invoice_id ASC, l.row_number ASC").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+	return invoiceLinesToEntities(rows), nil
+}
+
+func (r *Repository) UpdateDisputeLines(ctx context.Context, lines []entities.CarrierInvoiceLine) error {
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, l := range lines {
+			if err := tx.Model(&models.CarrierInvoiceLine{}).
+				Where("id = ?", l.ID).
+				Updates(map[string]interface{}{
+					"dispute_status":  l.DisputeStatus,
+					"credited_amount": l.CreditedAmount,
+					"dispute_note":    truncate(l.DisputeNote, 500),
+					"dispute_sent_at": l.DisputeSentAt,
+					"resolved_at":     l.ResolvedAt,
+				}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func invoiceProfileToEntity(m *models.CarrierInvoiceProfile) *entities.CarrierInvoiceProfile {
+	return &entities.CarrierInvoiceProfile{
+		ID:                  m.ID,
+		CarrierCode:         m.CarrierCode,
+		TrackingColumn:      m.TrackingColumn,
+		AmountColumn:        m.AmountColumn,
+		WeightColumn:        m.WeightColumn,
+		SurchargeColumn:     m.SurchargeColumn,
+		ConceptColumn:       m.ConceptColumn,
+		InvoiceNumberColumn: m.InvoiceNumberColumn,
+		HeaderRow:           m.HeaderRow,
+		Delimiter:           m.Delimiter,
+		DecimalSeparator:    m.DecimalSeparator,
+		Tolerance:           m.Tolerance,
+		WeightTolerance:     m.WeightTolerance,
+		UpdatedAt:           m.UpdatedAt,
+	}
+}
+
+func invoiceToEntity(m *models.CarrierInvoice) *entities.CarrierInvoice {
+	return &entities.CarrierInvoice{
+		ID:               m.ID,
+		CarrierCode:      m.CarrierCode,
+		InvoiceNumber:    m.InvoiceNumber,
+		FileName:         m.FileName,
+		Source:           m.Source,
+		LinesCount:       m.LinesCount,
+		MatchedCount:     m.MatchedCount,
+		DiscrepancyCount: m.DiscrepancyCount,
+		UnknownCount:     m.UnknownCount,
+		BilledTotal:      m.BilledTotal,
+		ExpectedTotal:    m.ExpectedTotal,
+		DisputedTotal:    m.DisputedTotal,
+		UploadedBy:       m.UploadedBy,
+		CreatedAt:        m.CreatedAt,
+	}
+}
+
+func invoiceLinesToEntities(rows []invoiceLineRow) []entities.CarrierInvoiceLine {
+	out := make([]entities.CarrierInvoiceLine, len(rows))
+	for i := range rows {
+		m := rows[i].CarrierInvoiceLine
+		out[i] = entities.CarrierInvoiceLine{
+			ID:               m.ID,
+			CarrierInvoiceID: m.CarrierInvoiceID,
+			CarrierCode:      m.CarrierCode,
+			InvoiceNumber:    rows[i].InvoiceNumber,
+			ShipmentID:       m.ShipmentID,
+			BusinessID:       m.BusinessID,
+			RowNumber:        m.RowNumber,
+			TrackingNumber:   m.TrackingNumber,
+			Concept:          m.Concept,
+			BilledAmount:     m.BilledAmount,
+			ExpectedAmount:   m.ExpectedAmount,
+			Difference:       m.Difference,
+			SurchargeAmount:  m.SurchargeAmount,
+			BilledWeight:     m.BilledWeight,
+			DeclaredWeight:   m.DeclaredWeight,
+			Discrepancy:      m.Discrepancy,
+			DisputeStatus:    m.DisputeStatus,
+			DisputedAmount:   m.DisputedAmount,
+			CreditedAmount:   m.CreditedAmount,
+			DisputeNote:      m.DisputeNote,
+			DisputeSentAt:    m.DisputeSentAt,
+			ResolvedAt:       m.ResolvedAt,
+			CreatedAt:        m.CreatedAt,
+		}
+	}
+	return out
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
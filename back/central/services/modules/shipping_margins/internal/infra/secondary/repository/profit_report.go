@@ -7,6 +7,17 @@ import (
 	"github.com/secamc93/probability/back/central/services/modules/shipping_margins/internal/domain/dtos"
 )
 
+// billedCostSubquery lo que la transportadora facturo por envio, descontando lo
+// acreditado en disputas aceptadas.
+const billedCostSubquery = `SELECT shipment_id, SUM(billed_amount - credited_amount) AS billed_cost
+FROM carrier_invoice_lines
+WHERE deleted_at IS NULL AND shipment_id IS NOT NULL
+GROUP BY shipment_id`
+
+// realCarrierCost costo facturado cuando ya llego la factura; si no, el costo
+// guardado al generar la guia.
+const realCarrierCost = `COALESCE(b.billed_cost, s.carrier_cost, 0)`
+
 func (r *Repository) ProfitReport(ctx context.Context, params dtos.ProfitReportParams) (*dtos.ProfitReportResponse, error) {
 	type row struct {
 		Carrier             string
@@ -14,6 +25,9 @@ func (r *Repository) ProfitReport(ctx context.Context, params dtos.ProfitReportP
 		CarrierCostTotal    float64
 		CustomerChargeTotal float64
 		ProfitTotal         float64
+		BilledShipments     int
+		RealCarrierCost     float64
+		RealProfitTotal     float64
 	}
 
 	conn := r.db.Conn(ctx)
@@ -23,8 +37,12 @@ func (r *Repository) ProfitReport(ctx context.Context, params dtos.ProfitReportP
 COUNT(*) AS shipments,
 COALESCE(SUM(s.carrier_cost), 0) AS carrier_cost_total,
 COALESCE(SUM(s.total_cost), 0) AS customer_charge_total,
-COALESCE(SUM(s.total_cost - s.carrier_cost), 0) AS profit_total`).
+COALESCE(SUM(s.total_cost - s.carrier_cost), 0) AS profit_total,
+COUNT(b.shipment_id) AS billed_shipments,
+COALESCE(SUM(`+realCarrierCost+`), 0) AS real_carrier_cost,
+COALESCE(SUM(s.total_cost - `+realCarrierCost+`), 0) AS real_profit_total`).
 		Joins("JOIN orders o ON o.id = s.order_id").
+		Joins("LEFT JOIN ("+billedCostSubquery+") b ON b.shipment_id = s.id").
 		Where("s.deleted_at IS NULL").
 		Where("s.tracking_number IS NOT NULL AND s.tracking_number <> ''").
 		Where("s.total_cost IS NOT NULL").
@@ -52,18 +70,26 @@ COALESCE(SUM(s.total_cost - s.carrier_cost), 0) AS profit_total`).
 	resp := &dtos.ProfitReportResponse{Rows: make([]dtos.ProfitReportRow, 0, len(rows))}
 	for _, r := range rows {
 		item := dtos.ProfitReportRow{
-			Carrier:             r.Carrier,
-			CarrierCode:         strings.ToLower(r.Carrier),
-			Shipments:           r.Shipments,
-			CarrierCostTotal:    r.CarrierCostTotal,
-			CustomerChargeTotal: r.CustomerChargeTotal,
-			ProfitTotal:         r.ProfitTotal,
+			Carrier:              r.Carrier,
+			CarrierCode:          strings.ToLower(r.Carrier),
+			Shipments:            r.Shipments,
+			CarrierCostTotal:     r.CarrierCostTotal,
+			CustomerChargeTotal:  r.CustomerChargeTotal,
+			ProfitTotal:          r.ProfitTotal,
+			BilledShipments:      r.BilledShipments,
+			RealCarrierCostTotal: r.RealCarrierCost,
+			CostAdjustmentTotal:  r.RealCarrierCost - r.CarrierCostTotal,
+			RealProfitTotal:      r.RealProfitTotal,
 		}
 		resp.Rows = append(resp.Rows, item)
 		resp.Totals.Shipments += item.Shipments
 		resp.Totals.CarrierCostTotal += item.CarrierCostTotal
 		resp.Totals.CustomerChargeTotal += item.CustomerChargeTotal
 		resp.Totals.ProfitTotal += item.ProfitTotal
+		resp.Totals.BilledShipments += item.BilledShipments
+		resp.Totals.RealCarrierCostTotal += item.RealCarrierCostTotal
+		resp.Totals.CostAdjustmentTotal += item.CostAdjustmentTotal
+		resp.Totals.RealProfitTotal += item.RealProfitTotal
 	}
 	resp.Totals.Carrier = "TOTAL"
 	resp.Totals.CarrierCode = "total"
@@ -17,6 +17,7 @@ func (r *Repository) ProfitReportDetail(ctx context.Context, params dtos.ProfitR
 		ServiceType    string
 		CustomerCharge float64
 		CarrierCost    float64
+		BilledCost     *float64
 		Status         string
 		CreatedAt      time.Time
 	}
@@ -54,7 +55,7 @@ func (r *Repository) ProfitReportDetail(ctx context.Context, params dtos.ProfitR
 
 	baseSQL := `
 SELECT shipment_id, order_number, tracking_number, carrier, service_type,
-       customer_charge, carrier_cost, status, created_at
+       customer_charge, carrier_cost, billed_cost, status, created_at
 FROM (
     SELECT s.id AS shipment_id,
            COALESCE(o.order_number, '') AS order_number,
@@ -63,11 +64,13 @@ FROM (
            'guide' AS service_type,
            ` + guideCharge + ` AS customer_charge,
            COALESCE(s.carrier_cost, 0) AS carrier_cost,
+           b.billed_cost AS billed_cost,
            COALESCE(s.status, '') AS status,
            s.created_at AS created_at,
            1 AS service_order
     FROM shipments s
     JOIN orders o ON o.id = s.order_id
+    LEFT JOIN (` + billedCostSubquery + `) b ON b.shipment_id = s.id
     WHERE ` + whereSQL + `
 
     UNION ALL
@@ -79,6 +82,7 @@ FROM (
            'cod' AS service_type,
            COALESCE(s.cod_probability_margin, 0) AS customer_charge,
            0 AS carrier_cost,
+           NULL::numeric AS billed_cost,
            COALESCE(s.status, '') AS status,
            s.created_at AS created_at,
            2 AS service_order
@@ -136,6 +140,10 @@ SELECT COUNT(*) FROM (
 
 	data := make([]dtos.ProfitReportDetailRow, 0, len(rows))
 	for _, r := range rows {
+		realCost := r.CarrierCost
+		if r.BilledCost != nil {
+			realCost = *r.BilledCost
+		}
 		data = append(data, dtos.ProfitReportDetailRow{
 			ShipmentID:     r.ShipmentID,
 			OrderNumber:    r.OrderNumber,
@@ -145,6 +153,8 @@ SELECT COUNT(*) FROM (
 			CustomerCharge: r.CustomerCharge,
 			CarrierCost:    r.CarrierCost,
 			Profit:         r.CustomerCharge - r.CarrierCost,
+			BilledCost:     r.BilledCost,
+			RealProfit:     r.CustomerCharge - realCost,
 			Status:         r.Status,
 			CreatedAt:      r.CreatedAt,
 		})
@@ -18,7 +18,24 @@ type RepositoryMock struct {
 	ProfitReportFn            func(ctx context.Context, params dtos.ProfitReportParams) (*dtos.ProfitReportResponse, error)
 	ProfitReportDetailFn      func(ctx context.Context, params dtos.ProfitReportDetailParams) (*dtos.ProfitReportDetailResponse, error)
 
+	InvoiceProfilesFn            func(ctx context.Context) ([]entities.CarrierInvoiceProfile, error)
+	InvoiceProfileFn             func(ctx context.Context, carrierCode string) (*entities.CarrierInvoiceProfile, error)
+	SaveInvoiceProfileFn         func(ctx context.Context, p *entities.CarrierInvoiceProfile) (*entities.CarrierInvoiceProfile, error)
+	InvoiceShipmentsByTrackingFn func(ctx context.Context, trackings []string) ([]entities.InvoiceShipment, error)
+	BilledShipmentsFn            func(ctx context.Context, shipmentIDs []uint) (map[uint]bool, error)
+	CreateInvoiceFn              func(ctx context.Context, inv *entities.CarrierInvoice, lines []entities.CarrierInvoiceLine) error
+	ListInvoicesFn               func(ctx context.Context, params dtos.ListInvoicesParams) ([]entities.CarrierInvoice, int64, error)
+	GetInvoiceFn                 func(ctx context.Context, id uint) (*entities.CarrierInvoice, error)
+	InvoiceLinesFn               func(ctx context.Context, invoiceID uint, discrepancy string) ([]entities.CarrierInvoiceLine, error)
+	InvoiceLinesByIDsFn          func(ctx context.Context, ids []uint) ([]entities.CarrierInvoiceLine, error)
+	DeleteInvoiceFn              func(ctx context.Context, id uint) error
+	DisputesFn                   func(ctx context.Context, params dtos.DisputesParams) ([]entities.CarrierInvoiceLine, error)
+	UpdateDisputeLinesFn         func(ctx context.Context, lines []entities.CarrierInvoiceLine) error
+
 	CreatedCarriers []string
+	CreatedInvoice  *entities.CarrierInvoice
+	CreatedLines    []entities.CarrierInvoiceLine
+	UpdatedDisputes []entities.CarrierInvoiceLine
 }
 
 var _ ports.IRepository = (*RepositoryMock)(nil)
@@ -80,6 +97,100 @@ func (m *RepositoryMock) ProfitReportDetail(ctx context.Context, params dtos.Pro
 	return &dtos.ProfitReportDetailResponse{}, nil
 }
 
+func (m *RepositoryMock) InvoiceProfiles(ctx context.Context) ([]entities.CarrierInvoiceProfile, error) {
+	if m.InvoiceProfilesFn != nil {
+		return m.InvoiceProfilesFn(ctx)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) InvoiceProfile(ctx context.Context, carrierCode string) (*entities.CarrierInvoiceProfile, error) {
+	if m.InvoiceProfileFn != nil {
+		return m.InvoiceProfileFn(ctx, carrierCode)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) SaveInvoiceProfile(ctx context.Context, p *entities.CarrierInvoiceProfile) (*entities.CarrierInvoiceProfile, error) {
+	if m.SaveInvoiceProfileFn != nil {
+		return m.SaveInvoiceProfileFn(ctx, p)
+	}
+	return p, nil
+}
+
+func (m *RepositoryMock) InvoiceShipmentsByTracking(ctx context.Context, trackings []string) ([]entities.InvoiceShipment, error) {
+	if m.InvoiceShipmentsByTrackingFn != nil {
+		return m.InvoiceShipmentsByTrackingFn(ctx, trackings)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) BilledShipments(ctx context.Context, shipmentIDs []uint) (map[uint]bool, error) {
+	if m.BilledShipmentsFn != nil {
+		return m.BilledShipmentsFn(ctx, shipmentIDs)
+	}
+	return map[uint]bool{}, nil
+}
+
+func (m *RepositoryMock) CreateInvoice(ctx context.Context, inv *entities.CarrierInvoice, lines []entities.CarrierInvoiceLine) error {
+	m.CreatedInvoice = inv
+	m.CreatedLines = lines
+	if m.CreateInvoiceFn != nil {
+		return m.CreateInvoiceFn(ctx, inv, lines)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) ListInvoices(ctx context.Context, params dtos.ListInvoicesParams) ([]entities.CarrierInvoice, int64, error) {
+	if m.ListInvoicesFn != nil {
+		return m.ListInvoicesFn(ctx, params)
+	}
+	return nil, 0, nil
+}
+
+func (m *RepositoryMock) GetInvoice(ctx context.Context, id uint) (*entities.CarrierInvoice, error) {
+	if m.GetInvoiceFn != nil {
+		return m.GetInvoiceFn(ctx, id)
+	}
+	return &entities.CarrierInvoice{ID: id}, nil
+}
+
+func (m *RepositoryMock) InvoiceLines(ctx context.Context, invoiceID uint, discrepancy string) ([]entities.CarrierInvoiceLine, error) {
+	if m.InvoiceLinesFn != nil {
+		return m.InvoiceLinesFn(ctx, invoiceID, discrepancy)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) InvoiceLinesByIDs(ctx context.Context, ids []uint) ([]entities.CarrierInvoiceLine, error) {
+	if m.InvoiceLinesByIDsFn != nil {
+		return m.InvoiceLinesByIDsFn(ctx, ids)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) DeleteInvoice(ctx context.Context, id uint) error {
+	if m.DeleteInvoiceFn != nil {
+		return m.DeleteInvoiceFn(ctx, id)
+	}
+	return nil
+}
+
+func (m *RepositoryMock) Disputes(ctx context.Context, params dtos.DisputesParams) ([]entities.CarrierInvoiceLine, error) {
+	if m.DisputesFn != nil {
+		return m.DisputesFn(ctx, params)
+	}
+	return nil, nil
+}
+
+func (m *RepositoryMock) UpdateDisputeLines(ctx context.Context, lines []entities.CarrierInvoiceLine) error {
+	m.UpdatedDisputes = append(m.UpdatedDisputes, lines...)
+	if m.UpdateDisputeLinesFn != nil {
+		return m.UpdateDisputeLinesFn(ctx, lines)
+	}
+	return nil
+}
+
 type CacheCall struct {
 	BusinessID  uint
 	CarrierCode string
@@ -0,0 +1,45 @@
+package webhooks
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/app"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/infra/primary/handlers"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/infra/primary/queue/consumer"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/infra/primary/worker"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/infra/secondary/repository"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/infra/secondary/sender"
+	"github.com/secamc93/probability/back/central/shared/db"
+	"github.com/secamc93/probability/back/central/shared/env"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/netguard"
+	"github.com/secamc93/probability/back/central/shared/rabbitmq"
+)
+
+// New inicializa los webhooks salientes: el registro de endpoints por
+// negocio, el consumer del canal webhook del dispatcher de eventos y el
+// worker de reintentos.
+func New(router *gin.RouterGroup, database db.IDatabase, logger log.ILogger, environment env.IConfig, rabbitMQ rabbitmq.IQueue) {
+	logger = logger.WithModule("webhooks")
+	repo := repository.New(database)
+	// Las URLs las pone el tenant: nunca se llama a la red interna. Loopback
+	// solo en desarrollo, para los simuladores.
+	guard := netguard.Guard{AllowLoopback: environment.Get("WEBHOOK_ALLOW_LOOPBACK") == "true"}
+	uc := app.New(repo, sender.New(guard), guard, logger)
+	handlers.New(uc, logger).RegisterRoutes(router)
+
+	ctx := context.Background()
+	if rabbitMQ != nil {
+		eventConsumer := consumer.New(rabbitMQ, uc, logger)
+		go func() {
+			if err := eventConsumer.Start(ctx); err != nil {
+				logger.Error(ctx).Err(err).Msg("Error al iniciar consumer de webhooks salientes")
+			}
+		}()
+	} else {
+		logger.Warn(ctx).Msg("RabbitMQ no disponible - webhooks salientes solo reintentan y reenvian")
+	}
+
+	go worker.NewRetryWorker(uc, logger).Start(ctx)
+}
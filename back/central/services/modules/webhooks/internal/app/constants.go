@@ -0,0 +1,26 @@
+package app
+
+import "time"
+
+const (
+	// maxAttempts cuenta el primer envio: 1 + 7 reintentos (~1 hora en total)
+	maxAttempts    = 8
+	retryBaseDelay = 30 * time.Second
+	retryMaxDelay  = 6 * time.Hour
+	retryBatchSize = 100
+
+	// autoDisableThreshold son los intentos fallidos seguidos, sin importar
+	// el evento, tras los que se deshabilita el endpoint
+	autoDisableThreshold = 20
+
+	defaultGraceHours = 24
+	maxGraceHours     = 168
+
+	secretPrefix = "whsec_"
+	userAgent    = "Probability-Webhooks/1.0"
+
+	headerSignature = "X-Probability-Signature"
+	headerEvent     = "X-Probability-Event"
+	headerEventID   = "X-Probability-Event-Id"
+	headerDelivery  = "X-Probability-Delivery"
+)
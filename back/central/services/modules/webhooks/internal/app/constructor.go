@@ -0,0 +1,43 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/entities"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/netguard"
+)
+
+// En los metodos que reciben businessID, 0 significa super admin: puede ver y
+// operar los endpoints de cualquier negocio.
+type IUseCase interface {
+	CreateEndpoint(ctx context.Context, dto dtos.CreateEndpointDTO) (*entities.Endpoint, error)
+	ListEndpoints(ctx context.Context, businessID uint) ([]entities.Endpoint, error)
+	GetEndpoint(ctx context.Context, id, businessID uint) (*entities.Endpoint, error)
+	UpdateEndpoint(ctx context.Context, dto dtos.UpdateEndpointDTO) (*entities.Endpoint, error)
+	DeleteEndpoint(ctx context.Context, id, businessID uint) error
+	RotateSecret(ctx context.Context, dto dtos.RotateSecretDTO) (*entities.Endpoint, error)
+
+	ListDeliveries(ctx context.Context, params dtos.ListDeliveriesParams) ([]entities.Delivery, int64, error)
+	GetDelivery(ctx context.Context, id, businessID uint) (*entities.Delivery, error)
+	Redeliver(ctx context.Context, id, businessID uint) (*entities.Delivery, error)
+
+	HandleEvent(ctx context.Context, msg dtos.EventMessage) error
+	ProcessDueRetries(ctx context.Context) (int, error)
+}
+
+type UseCase struct {
+	repo   ports.IRepository
+	sender ports.ISender
+	// guard es el mismo que usa el sender al conectar
+	guard netguard.Guard
+	log   log.ILogger
+	now   func() time.Time
+}
+
+func New(repo ports.IRepository, sender ports.ISender, guard netguard.Guard, logger log.ILogger) IUseCase {
+	return &UseCase{repo: repo, sender: sender, guard: guard, log: logger, now: time.Now}
+}
@@ -0,0 +1,197 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/entities"
+)
+
+// HandleEvent registra una entrega del evento por cada endpoint activo del
+// negocio y hace el primer intento. Un evento repetido por la cola no genera
+// entregas nuevas.
+func (uc *UseCase) HandleEvent(ctx context.Context, msg dtos.EventMessage) error {
+	if msg.BusinessID == 0 || msg.EventID == "" || msg.EventType == "" {
+		uc.log.Warn(ctx).
+			Str("event_id", msg.EventID).
+			Str("event_type", msg.EventType).
+			Uint("business_id", msg.BusinessID).
+			Msg("Webhooks: evento incompleto, descartado")
+		return nil
+	}
+
+	endpoints, err := uc.repo.ListActiveEndpoints(ctx, msg.BusinessID)
+	if err != nil {
+		return fmt.Errorf("list active webhook endpoints: %w", err)
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	payload, err := buildPayload(msg)
+	if err != nil {
+		return err
+	}
+
+	for i := range endpoints {
+		endpoint := &endpoints[i]
+		delivery := &entities.Delivery{
+			EndpointID: endpoint.ID,
+			BusinessID: msg.BusinessID,
+			EventID:    msg.EventID,
+			EventType:  msg.EventType,
+			Payload:    payload,
+			Status:     entities.DeliveryPending,
+		}
+		created, err := uc.repo.CreateDelivery(ctx, delivery)
+		if err != nil {
+			uc.log.Error(ctx).Err(err).Uint("endpoint_id", endpoint.ID).Str("event_id", msg.EventID).Msg("Webhooks: no se pudo registrar la entrega")
+			continue
+		}
+		if !created {
+			uc.log.Debug(ctx).Uint("endpoint_id", endpoint.ID).Str("event_id", msg.EventID).Msg("Webhooks: evento ya registrado para el endpoint")
+			continue
+		}
+		uc.attempt(ctx, endpoint, delivery)
+	}
+	return nil
+}
+
+// buildPayload es el cuerpo que recibe el endpoint. Se guarda tal cual en la
+// entrega para que los reintentos y reenvios manden los mismos bytes.
+func buildPayload(msg dtos.EventMessage) ([]byte, error) {
+	createdAt := msg.Timestamp
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	data := msg.Data
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"id":             msg.EventID,
+		"type":           msg.EventType,
+		"category":       msg.Category,
+		"created_at":     createdAt.UTC().Format(time.RFC3339),
+		"business_id":    msg.BusinessID,
+		"integration_id": msg.IntegrationID,
+		"data":           data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("marshal webhook payload: %w", err)
+	}
+	return payload, nil
+}
+
+// attempt firma y envia la entrega, guarda el resultado y programa el
+// siguiente reintento con backoff exponencial. Deshabilita el endpoint cuando
+// acumula autoDisableThreshold fallos seguidos.
+func (uc *UseCase) attempt(ctx context.Context, endpoint *entities.Endpoint, delivery *entities.Delivery) {
+	now := uc.now()
+	headers := map[string]string{
+		"Content-Type":  "application/json",
+		"User-Agent":    userAgent,
+		headerEvent:     delivery.EventType,
+		headerEventID:   delivery.EventID,
+		headerDelivery:  strconv.FormatUint(uint64(delivery.ID), 10),
+		headerSignature: signatureHeader(endpoint.SigningSecrets(now), now.Unix(), delivery.Payload),
+	}
+
+	result := uc.sender.Send(ctx, dtos.SendRequest{URL: endpoint.URL, Headers: headers, Body: delivery.Payload})
+	success := result.Success()
+
+	attempt := dtos.AttemptResult{
+		Attempts:       delivery.Attempts + 1,
+		RequestHeaders: headers,
+		ResponseStatus: result.StatusCode,
+		ResponseBody:   result.Body,
+		Error:          result.Error,
+		DurationMs:     result.Duration.Milliseconds(),
+	}
+	switch {
+	case success:
+		attempt.Status = entities.DeliverySucceeded
+		attempt.DeliveredAt = &now
+	case attempt.Attempts >= maxAttempts:
+		attempt.Status = entities.DeliveryFailed
+	default:
+		attempt.Status = entities.DeliveryRetrying
+		next := now.Add(backoff(attempt.Attempts))
+		attempt.NextAttemptAt = &next
+	}
+	if !success && attempt.Error == "" {
+		attempt.Error = fmt.Sprintf("el endpoint respondio HTTP %d", result.StatusCode)
+	}
+
+	if err := uc.repo.SaveAttempt(ctx, delivery.ID, attempt); err != nil {
+		uc.log.Error(ctx).Err(err).Uint("delivery_id", delivery.ID).Msg("Webhooks: no se pudo guardar el intento")
+	}
+	delivery.Status = attempt.Status
+	delivery.Attempts = attempt.Attempts
+	delivery.NextAttemptAt = attempt.NextAttemptAt
+	delivery.RequestHeaders = attempt.RequestHeaders
+	delivery.ResponseStatus = attempt.ResponseStatus
+	delivery.ResponseBody = attempt.ResponseBody
+	delivery.Error = attempt.Error
+	delivery.DurationMs = attempt.DurationMs
+	delivery.DeliveredAt = attempt.DeliveredAt
+
+	uc.log.Info(ctx).
+		Uint("delivery_id", delivery.ID).
+		Uint("endpoint_id", endpoint.ID).
+		Str("event_type", delivery.EventType).
+		Int("attempt", attempt.Attempts).
+		Int("status_code", result.StatusCode).
+		Str("status", attempt.Status).
+		Msg("Webhooks: intento de entrega")
+
+	updated, err := uc.repo.RecordAttempt(ctx, endpoint.ID, success, now)
+	if err != nil {
+		uc.log.Error(ctx).Err(err).Uint("endpoint_id", endpoint.ID).Msg("Webhooks: no se pudo actualizar el endpoint")
+		return
+	}
+	if !success && updated.Enabled && updated.ConsecutiveFailures >= autoDisableThreshold {
+		uc.autoDisable(ctx, updated, now)
+	}
+}
+
+func (uc *UseCase) autoDisable(ctx context.Context, endpoint *entities.Endpoint, now time.Time) {
+	reason := fmt.Sprintf("deshabilitado automaticamente tras %d entregas fallidas seguidas", endpoint.ConsecutiveFailures)
+	if _, err := uc.repo.UpdateEndpoint(ctx, endpoint.ID, map[string]any{
+		"enabled":         false,
+		"disabled_at":     now,
+		"disabled_reason": reason,
+	}); err != nil {
+		uc.log.Error(ctx).Err(err).Uint("endpoint_id", endpoint.ID).Msg("Webhooks: no se pudo deshabilitar el endpoint")
+		return
+	}
+	if err := uc.repo.FailPendingDeliveries(ctx, endpoint.ID, reason); err != nil {
+		uc.log.Warn(ctx).Err(err).Uint("endpoint_id", endpoint.ID).Msg("Webhooks: no se pudieron cerrar los reintentos pendientes")
+	}
+	endpoint.Enabled = false
+	uc.log.Warn(ctx).
+		Uint("endpoint_id", endpoint.ID).
+		Uint("business_id", endpoint.BusinessID).
+		Int("consecutive_failures", endpoint.ConsecutiveFailures).
+		Msg("Webhooks: endpoint deshabilitado por fallos repetidos")
+}
+
+// backoff duplica la espera en cada intento: 30s, 1m, 2m, 4m... hasta
+// retryMaxDelay.
+func backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	if attempts > 20 {
+		return retryMaxDelay
+	}
+	delay := retryBaseDelay << (attempts - 1)
+	if delay > retryMaxDelay {
+		return retryMaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,68 @@
+package app
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/errors"
+)
+
+func (uc *UseCase) ListDeliveries(ctx context.Context, params dtos.ListDeliveriesParams) ([]entities.Delivery, int64, error) {
+	if params.Page < 1 {
+		params.Page = 1
+	}
+	if params.PageSize < 1 || params.PageSize > 100 {
+		params.PageSize = 20
+	}
+	return uc.repo.ListDeliveries(ctx, params)
+}
+
+func (uc *UseCase) GetDelivery(ctx context.Context, id, businessID uint) (*entities.Delivery, error) {
+	delivery, err := uc.repo.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if businessID != 0 && delivery.BusinessID != businessID {
+		return nil, dom.ErrDeliveryNotFound
+	}
+	return delivery, nil
+}
+
+// Redeliver reenvia el mismo cuerpo como una entrega nueva ligada a la
+// original. Si falla sigue la politica de reintentos normal.
+func (uc *UseCase) Redeliver(ctx context.Context, id, businessID uint) (*entities.Delivery, error) {
+	original, err := uc.GetDelivery(ctx, id, businessID)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := uc.repo.GetEndpoint(ctx, original.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	if !endpoint.Enabled {
+		return nil, dom.ErrEndpointDisabled
+	}
+
+	rootID := original.ID
+	if original.RedeliveryOfID != nil {
+		rootID = *original.RedeliveryOfID
+	}
+	delivery := &entities.Delivery{
+		EndpointID:     original.EndpointID,
+		BusinessID:     original.BusinessID,
+		EventID:        original.EventID,
+		EventType:      original.EventType,
+		Payload:        original.Payload,
+		Status:         entities.DeliveryPending,
+		RedeliveryOfID: &rootID,
+	}
+	if _, err := uc.repo.CreateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	uc.attempt(ctx, endpoint, delivery)
+	delivery.EndpointName = endpoint.Name
+	delivery.EndpointURL = endpoint.URL
+	return delivery, nil
+}
@@ -0,0 +1,193 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/shared/netguard"
+)
+
+func (uc *UseCase) CreateEndpoint(ctx context.Context, dto dtos.CreateEndpointDTO) (*entities.Endpoint, error) {
+	if dto.BusinessID == 0 {
+		return nil, dom.ErrBusinessRequired
+	}
+	name := strings.TrimSpace(dto.Name)
+	if name == "" {
+		return nil, dom.ErrNameRequired
+	}
+	endpointURL, err := uc.validateURL(ctx, dto.URL)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.repo.CreateEndpoint(ctx, &entities.Endpoint{
+		BusinessID:  dto.BusinessID,
+		Name:        name,
+		URL:         endpointURL,
+		Description: strings.TrimSpace(dto.Description),
+		Secret:      secret,
+		Enabled:     true,
+	})
+}
+
+func (uc *UseCase) ListEndpoints(ctx context.Context, businessID uint) ([]entities.Endpoint, error) {
+	return uc.repo.ListEndpoints(ctx, businessID)
+}
+
+func (uc *UseCase) GetEndpoint(ctx context.Context, id, businessID uint) (*entities.Endpoint, error) {
+	endpoint, err := uc.repo.GetEndpoint(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if businessID != 0 && endpoint.BusinessID != businessID {
+		return nil, dom.ErrEndpointNotFound
+	}
+	return endpoint, nil
+}
+
+func (uc *UseCase) UpdateEndpoint(ctx context.Context, dto dtos.UpdateEndpointDTO) (*entities.Endpoint, error) {
+	endpoint, err := uc.GetEndpoint(ctx, dto.ID, dto.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]any{}
+	if dto.Name != nil {
+		name := strings.TrimSpace(*dto.Name)
+		if name == "" {
+			return nil, dom.ErrNameRequired
+		}
+		updates["name"] = name
+	}
+	if dto.URL != nil {
+		endpointURL, err := uc.validateURL(ctx, *dto.URL)
+		if err != nil {
+			return nil, err
+		}
+		updates["url"] = endpointURL
+	}
+	if dto.Description != nil {
+		updates["description"] = strings.TrimSpace(*dto.Description)
+	}
+	disabling := false
+	if dto.Enabled != nil && *dto.Enabled != endpoint.Enabled {
+		updates["enabled"] = *dto.Enabled
+		if *dto.Enabled {
+			// Rehabilitar arranca la cuenta de fallos de cero
+			updates["consecutive_failures"] = 0
+			updates["disabled_at"] = nil
+			updates["disabled_reason"] = ""
+		} else {
+			disabling = true
+			updates["disabled_at"] = uc.now()
+			updates["disabled_reason"] = "deshabilitado manualmente"
+		}
+	}
+	if len(updates) == 0 {
+		return endpoint, nil
+	}
+
+	updated, err := uc.repo.UpdateEndpoint(ctx, endpoint.ID, updates)
+	if err != nil {
+		return nil, err
+	}
+	if disabling {
+		if err := uc.repo.FailPendingDeliveries(ctx, endpoint.ID, "endpoint deshabilitado manualmente"); err != nil {
+			uc.log.Warn(ctx).Err(err).Uint("endpoint_id", endpoint.ID).Msg("Webhooks: no se pudieron cerrar los reintentos pendientes")
+		}
+	}
+	return updated, nil
+}
+
+func (uc *UseCase) DeleteEndpoint(ctx context.Context, id, businessID uint) error {
+	endpoint, err := uc.GetEndpoint(ctx, id, businessID)
+	if err != nil {
+		return err
+	}
+	if err := uc.repo.FailPendingDeliveries(ctx, endpoint.ID, "endpoint eliminado"); err != nil {
+		return err
+	}
+	return uc.repo.DeleteEndpoint(ctx, endpoint.ID)
+}
+
+// RotateSecret genera un secreto nuevo. El anterior sigue firmando durante
+// GraceHours (24 por defecto) para que el receptor pueda cambiarlo sin perder
+// eventos; con 0 queda revocado de inmediato.
+func (uc *UseCase) RotateSecret(ctx context.Context, dto dtos.RotateSecretDTO) (*entities.Endpoint, error) {
+	graceHours := defaultGraceHours
+	if dto.GraceHours != nil {
+		graceHours = *dto.GraceHours
+	}
+	if graceHours < 0 || graceHours > maxGraceHours {
+		return nil, dom.ErrInvalidGraceHours
+	}
+	endpoint, err := uc.GetEndpoint(ctx, dto.ID, dto.BusinessID)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	updates := map[string]any{
+		"secret":                     secret,
+		"previous_secret":            "",
+		"previous_secret_expires_at": nil,
+	}
+	if graceHours > 0 {
+		updates["previous_secret"] = endpoint.Secret
+		updates["previous_secret_expires_at"] = uc.now().Add(time.Duration(graceHours) * time.Hour)
+	}
+	return uc.repo.UpdateEndpoint(ctx, endpoint.ID, updates)
+}
+
+// validateURL exige https hacia un destino publico: el host se resuelve y se
+// rechaza si alguna IP es interna. Solo con WEBHOOK_ALLOW_LOOPBACK se acepta
+// la maquina local, tambien por http, para probar con los simuladores. El
+// sender repite el chequeo al conectar.
+func (uc *UseCase) validateURL(ctx context.Context, raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	parsed, err := url.Parse(raw)
+	if err != nil || parsed.Hostname() == "" {
+		return "", dom.ErrInvalidURL
+	}
+	host := parsed.Hostname()
+	switch parsed.Scheme {
+	case "https":
+	case "http":
+		if !uc.guard.AllowLoopback || !isLoopbackHost(host) {
+			return "", dom.ErrInvalidURL
+		}
+	default:
+		return "", dom.ErrInvalidURL
+	}
+
+	if err := uc.guard.CheckHost(ctx, host); err != nil {
+		if errors.Is(err, netguard.ErrBlockedAddress) {
+			return "", dom.ErrURLNotAllowed
+		}
+		uc.log.Warn(ctx).Err(err).Str("host", host).Msg("No se pudo resolver el host del webhook")
+		return "", dom.ErrURLUnresolvable
+	}
+	return raw, nil
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
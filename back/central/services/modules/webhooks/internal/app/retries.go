@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/errors"
+)
+
+// ProcessDueRetries reintenta las entregas cuyo backoff ya vencio. Devuelve
+// cuantas se intentaron.
+func (uc *UseCase) ProcessDueRetries(ctx context.Context) (int, error) {
+	deliveries, err := uc.repo.ClaimDueDeliveries(ctx, uc.now(), retryBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("claim due webhook deliveries: %w", err)
+	}
+
+	endpoints := map[uint]*entities.Endpoint{}
+	attempted := 0
+	for i := range deliveries {
+		delivery := &deliveries[i]
+
+		endpoint, ok := endpoints[delivery.EndpointID]
+		if !ok {
+			endpoint, err = uc.repo.GetEndpoint(ctx, delivery.EndpointID)
+			if err != nil && !errors.Is(err, dom.ErrEndpointNotFound) {
+				uc.log.Error(ctx).Err(err).Uint("endpoint_id", delivery.EndpointID).Msg("Webhooks: no se pudo leer el endpoint")
+				continue
+			}
+			endpoints[delivery.EndpointID] = endpoint
+		}
+		if endpoint == nil || !endpoint.Enabled {
+			if err := uc.repo.FailPendingDeliveries(ctx, delivery.EndpointID, "endpoint deshabilitado o eliminado"); err != nil {
+				uc.log.Warn(ctx).Err(err).Uint("endpoint_id", delivery.EndpointID).Msg("Webhooks: no se pudieron cerrar los reintentos pendientes")
+			}
+			continue
+		}
+
+		uc.attempt(ctx, endpoint, delivery)
+		attempted++
+	}
+	return attempted, nil
+}
@@ -0,0 +1,36 @@
+package app
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// signatureHeader arma X-Probability-Signature: t=<unix>,v1=<hex>[,v1=<hex>].
+// Cada v1 es HMAC-SHA256 de "<t>.<body>" con uno de los secretos vigentes;
+// el receptor acepta la peticion si alguna coincide con el suyo.
+func signatureHeader(secrets []string, timestamp int64, body []byte) string {
+	parts := []string{fmt.Sprintf("t=%d", timestamp)}
+	for _, secret := range secrets {
+		parts = append(parts, "v1="+sign(secret, timestamp, body))
+	}
+	return strings.Join(parts, ",")
+}
+
+func sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func newSecret() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate webhook secret: %w", err)
+	}
+	return secretPrefix + hex.EncodeToString(buf), nil
+}
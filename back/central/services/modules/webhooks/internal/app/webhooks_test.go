@@ -0,0 +1,337 @@
+package app
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	stderrors "errors"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/mocks"
+	"github.com/secamc93/probability/back/central/shared/netguard"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var baseTime = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+type reloj struct{ t time.Time }
+
+func (r *reloj) now() time.Time          { return r.t }
+func (r *reloj) avanzar(d time.Duration) { r.t = r.t.Add(d) }
+
+func newWebhooksUseCase(sender *mocks.SenderMock) (*UseCase, *mocks.RepositoryMock, *reloj) {
+	repo := mocks.NewRepositoryMock()
+	clock := &reloj{t: baseTime}
+	if sender == nil {
+		sender = &mocks.SenderMock{}
+	}
+	return &UseCase{repo: repo, sender: sender, guard: guardDePrueba(false), log: mocks.NewSilentLogger(), now: clock.now}, repo, clock
+}
+
+// guardDePrueba resuelve sin red: "interno.example.com" apunta a la red
+// privada, "nuevo.example.com" no existe y el resto es publico.
+func guardDePrueba(loopback bool) netguard.Guard {
+	return netguard.Guard{
+		AllowLoopback: loopback,
+		LookupIP: func(ctx context.Context, host string) ([]net.IP, error) {
+			switch host {
+			case "localhost":
+				return []net.IP{net.ParseIP("127.0.0.1")}, nil
+			case "interno.example.com":
+				return []net.IP{net.ParseIP("10.0.4.20")}, nil
+			case "nuevo.example.com":
+				return nil, stderrors.New("no such host")
+			}
+			return []net.IP{net.ParseIP("93.184.216.34")}, nil
+		},
+	}
+}
+
+func crearEndpoint(t *testing.T, uc *UseCase, businessID uint) *entities.Endpoint {
+	t.Helper()
+	ep, err := uc.CreateEndpoint(context.Background(), dtos.CreateEndpointDTO{
+		BusinessID: businessID, Name: "ERP", URL: "https://erp.example.com/hooks",
+	})
+	require.NoError(t, err)
+	return ep
+}
+
+func evento(id string) dtos.EventMessage {
+	return dtos.EventMessage{
+		EventID:    id,
+		EventType:  "order.status_changed",
+		Category:   "order",
+		BusinessID: 7,
+		Timestamp:  baseTime,
+		Data:       map[string]interface{}{"order_id": "abc", "current_status": "shipped"},
+	}
+}
+
+// firmaValida dice si alguna firma v1 del header corresponde al secreto
+func firmaValida(header, secret string, body []byte) bool {
+	var ts int64
+	var firmas []string
+	for _, part := range strings.Split(header, ",") {
+		k, v, _ := strings.Cut(part, "=")
+		switch k {
+		case "t":
+			fmt.Sscan(v, &ts)
+		case "v1":
+			firmas = append(firmas, v)
+		}
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", ts)
+	mac.Write(body)
+	esperada := hex.EncodeToString(mac.Sum(nil))
+	for _, f := range firmas {
+		if hmac.Equal([]byte(f), []byte(esperada)) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCreateEndpoint_Validaciones(t *testing.T) {
+	uc, _, _ := newWebhooksUseCase(nil)
+	casos := []struct {
+		nombre  string
+		dto     dtos.CreateEndpointDTO
+		wantErr error
+	}{
+		{"sin negocio", dtos.CreateEndpointDTO{Name: "ERP", URL: "https://a.com"}, dom.ErrBusinessRequired},
+		{"sin nombre", dtos.CreateEndpointDTO{BusinessID: 1, Name: "  ", URL: "https://a.com"}, dom.ErrNameRequired},
+		{"http publico", dtos.CreateEndpointDTO{BusinessID: 1, Name: "ERP", URL: "http://erp.example.com"}, dom.ErrInvalidURL},
+		{"sin host", dtos.CreateEndpointDTO{BusinessID: 1, Name: "ERP", URL: "https://"}, dom.ErrInvalidURL},
+		{"otro esquema", dtos.CreateEndpointDTO{BusinessID: 1, Name: "ERP", URL: "ftp://erp.example.com"}, dom.ErrInvalidURL},
+		{"red privada", dtos.CreateEndpointDTO{BusinessID: 1, Name: "ERP", URL: "https://192.168.1.10/hook"}, dom.ErrURLNotAllowed},
+		{"metadata de la nube", dtos.CreateEndpointDTO{BusinessID: 1, Name: "ERP", URL: "https://169.254.169.254/latest/meta-data"}, dom.ErrURLNotAllowed},
+		{"ipv6 ula", dtos.CreateEndpointDTO{BusinessID: 1, Name: "ERP", URL: "https://[fd00::1]/hook"}, dom.ErrURLNotAllowed},
+		{"dns a red interna", dtos.CreateEndpointDTO{BusinessID: 1, Name: "ERP", URL: "https://interno.example.com/hook"}, dom.ErrURLNotAllowed},
+		{"dns que no resuelve", dtos.CreateEndpointDTO{BusinessID: 1, Name: "ERP", URL: "https://nuevo.example.com/hook"}, dom.ErrURLUnresolvable},
+		{"localhost sin modo desarrollo", dtos.CreateEndpointDTO{BusinessID: 1, Name: "Local", URL: "https://localhost:9000/hook"}, dom.ErrURLNotAllowed},
+		{"http localhost sin modo desarrollo", dtos.CreateEndpointDTO{BusinessID: 1, Name: "Local", URL: "http://127.0.0.1:9000/hook"}, dom.ErrInvalidURL},
+	}
+	for _, tc := range casos {
+		t.Run(tc.nombre, func(t *testing.T) {
+			_, err := uc.CreateEndpoint(context.Background(), tc.dto)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+
+	uc.guard = guardDePrueba(true)
+	ep, err := uc.CreateEndpoint(context.Background(), dtos.CreateEndpointDTO{BusinessID: 1, Name: "Local", URL: "http://localhost:9000/hook"})
+	require.NoError(t, err, "con WEBHOOK_ALLOW_LOOPBACK http contra localhost se acepta para pruebas")
+	assert.True(t, ep.Enabled)
+	assert.True(t, strings.HasPrefix(ep.Secret, secretPrefix))
+}
+
+func TestHandleEvent_EntregaFirmadaSoloAEndpointsDelNegocio(t *testing.T) {
+	sender := &mocks.SenderMock{}
+	uc, repo, _ := newWebhooksUseCase(sender)
+	propio := crearEndpoint(t, uc, 7)
+	crearEndpoint(t, uc, 8)
+
+	require.NoError(t, uc.HandleEvent(context.Background(), evento("evt-1")))
+
+	require.Len(t, sender.Requests, 1, "solo se entrega a los endpoints del negocio del evento")
+	req := sender.Requests[0]
+	assert.Equal(t, propio.URL, req.URL)
+	assert.Equal(t, "order.status_changed", req.Headers[headerEvent])
+	assert.Equal(t, "evt-1", req.Headers[headerEventID])
+	assert.True(t, firmaValida(req.Headers[headerSignature], propio.Secret, req.Body), "la firma debe verificar con el secreto del endpoint")
+	assert.Contains(t, string(req.Body), `"id":"evt-1"`)
+
+	deliveries, _, _ := repo.ListDeliveries(context.Background(), dtos.ListDeliveriesParams{})
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, entities.DeliverySucceeded, deliveries[0].Status)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.NotNil(t, deliveries[0].DeliveredAt)
+}
+
+func TestHandleEvent_EventoRepetidoNoSeReenvia(t *testing.T) {
+	sender := &mocks.SenderMock{}
+	uc, _, _ := newWebhooksUseCase(sender)
+	crearEndpoint(t, uc, 7)
+
+	require.NoError(t, uc.HandleEvent(context.Background(), evento("evt-1")))
+	require.NoError(t, uc.HandleEvent(context.Background(), evento("evt-1")))
+
+	assert.Len(t, sender.Requests, 1)
+}
+
+func TestAttempt_BackoffExponencialHastaAgotarIntentos(t *testing.T) {
+	sender := &mocks.SenderMock{Responses: []dtos.SendResult{{StatusCode: 500, Body: "boom"}}}
+	uc, repo, clock := newWebhooksUseCase(sender)
+	crearEndpoint(t, uc, 7)
+
+	require.NoError(t, uc.HandleEvent(context.Background(), evento("evt-1")))
+	d, _ := repo.GetDelivery(context.Background(), 2)
+	require.Equal(t, entities.DeliveryRetrying, d.Status)
+	assert.Equal(t, baseTime.Add(30*time.Second), *d.NextAttemptAt)
+	assert.Equal(t, "boom", d.ResponseBody)
+	assert.Contains(t, d.Error, "500")
+
+	// Antes de vencer el backoff no se reintenta
+	n, err := uc.ProcessDueRetries(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	esperas := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 16 * time.Minute, 32 * time.Minute}
+	clock.avanzar(30 * time.Second)
+	for i, espera := range esperas {
+		n, err := uc.ProcessDueRetries(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 1, n, "reintento %d", i+2)
+		d, _ = repo.GetDelivery(context.Background(), 2)
+		require.Equal(t, entities.DeliveryRetrying, d.Status)
+		assert.Equal(t, clock.t.Add(espera), *d.NextAttemptAt)
+		clock.avanzar(espera)
+	}
+
+	_, err = uc.ProcessDueRetries(context.Background())
+	require.NoError(t, err)
+	d, _ = repo.GetDelivery(context.Background(), 2)
+	assert.Equal(t, entities.DeliveryFailed, d.Status, "el octavo intento cierra la entrega")
+	assert.Equal(t, maxAttempts, d.Attempts)
+	assert.Nil(t, d.NextAttemptAt)
+	assert.Len(t, sender.Requests, maxAttempts)
+}
+
+func TestAttempt_DeshabilitaTrasFallosSeguidos(t *testing.T) {
+	sender := &mocks.SenderMock{Responses: []dtos.SendResult{{Error: "connection refused"}}}
+	uc, repo, _ := newWebhooksUseCase(sender)
+	ep := crearEndpoint(t, uc, 7)
+
+	for i := 0; i < autoDisableThreshold; i++ {
+		require.NoError(t, uc.HandleEvent(context.Background(), evento(fmt.Sprintf("evt-%d", i))))
+	}
+
+	got, _ := repo.GetEndpoint(context.Background(), ep.ID)
+	assert.False(t, got.Enabled)
+	assert.NotNil(t, got.DisabledAt)
+	assert.Contains(t, got.DisabledReason, "automaticamente")
+
+	deliveries, _, _ := repo.ListDeliveries(context.Background(), dtos.ListDeliveriesParams{})
+	for _, d := range deliveries {
+		assert.Equal(t, entities.DeliveryFailed, d.Status, "los reintentos pendientes se cierran al deshabilitar")
+	}
+
+	// Deshabilitado ya no recibe eventos
+	require.NoError(t, uc.HandleEvent(context.Background(), evento("evt-nuevo")))
+	assert.Len(t, sender.Requests, autoDisableThreshold)
+
+	// Rehabilitar reinicia el contador
+	enabled := true
+	got, err := uc.UpdateEndpoint(context.Background(), dtos.UpdateEndpointDTO{ID: ep.ID, BusinessID: 7, Enabled: &enabled})
+	require.NoError(t, err)
+	assert.True(t, got.Enabled)
+	assert.Zero(t, got.ConsecutiveFailures)
+	assert.Empty(t, got.DisabledReason)
+}
+
+func TestAttempt_ExitoReiniciaFallosConsecutivos(t *testing.T) {
+	sender := &mocks.SenderMock{Responses: []dtos.SendResult{{StatusCode: 503}, {StatusCode: 503}, {StatusCode: 204}}}
+	uc, repo, _ := newWebhooksUseCase(sender)
+	ep := crearEndpoint(t, uc, 7)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, uc.HandleEvent(context.Background(), evento(fmt.Sprintf("evt-%d", i))))
+	}
+
+	got, _ := repo.GetEndpoint(context.Background(), ep.ID)
+	assert.Zero(t, got.ConsecutiveFailures)
+	assert.NotNil(t, got.LastSuccessAt)
+}
+
+func TestRotateSecret_FirmaConAmbosDuranteLaGracia(t *testing.T) {
+	sender := &mocks.SenderMock{}
+	uc, _, clock := newWebhooksUseCase(sender)
+	ep := crearEndpoint(t, uc, 7)
+	anterior := ep.Secret
+
+	grace := 2
+	rotado, err := uc.RotateSecret(context.Background(), dtos.RotateSecretDTO{ID: ep.ID, BusinessID: 7, GraceHours: &grace})
+	require.NoError(t, err)
+	require.NotEqual(t, anterior, rotado.Secret)
+	assert.Equal(t, baseTime.Add(2*time.Hour), *rotado.PreviousSecretExpiresAt)
+
+	require.NoError(t, uc.HandleEvent(context.Background(), evento("evt-1")))
+	req := sender.Requests[0]
+	assert.True(t, firmaValida(req.Headers[headerSignature], rotado.Secret, req.Body))
+	assert.True(t, firmaValida(req.Headers[headerSignature], anterior, req.Body), "el secreto anterior sigue firmando en la gracia")
+
+	clock.avanzar(3 * time.Hour)
+	require.NoError(t, uc.HandleEvent(context.Background(), evento("evt-2")))
+	req = sender.Requests[1]
+	assert.True(t, firmaValida(req.Headers[headerSignature], rotado.Secret, req.Body))
+	assert.False(t, firmaValida(req.Headers[headerSignature], anterior, req.Body), "vencida la gracia el anterior ya no firma")
+}
+
+func TestRotateSecret_SinGraciaYLimites(t *testing.T) {
+	uc, _, _ := newWebhooksUseCase(nil)
+	ep := crearEndpoint(t, uc, 7)
+
+	cero := 0
+	rotado, err := uc.RotateSecret(context.Background(), dtos.RotateSecretDTO{ID: ep.ID, BusinessID: 7, GraceHours: &cero})
+	require.NoError(t, err)
+	assert.Empty(t, rotado.PreviousSecret)
+	assert.Nil(t, rotado.PreviousSecretExpiresAt)
+
+	demasiado := maxGraceHours + 1
+	_, err = uc.RotateSecret(context.Background(), dtos.RotateSecretDTO{ID: ep.ID, BusinessID: 7, GraceHours: &demasiado})
+	assert.ErrorIs(t, err, dom.ErrInvalidGraceHours)
+
+	_, err = uc.RotateSecret(context.Background(), dtos.RotateSecretDTO{ID: ep.ID, BusinessID: 99})
+	assert.ErrorIs(t, err, dom.ErrEndpointNotFound, "otro negocio no ve el endpoint")
+}
+
+func TestRedeliver(t *testing.T) {
+	sender := &mocks.SenderMock{Responses: []dtos.SendResult{{StatusCode: 500}, {StatusCode: 200}}}
+	uc, _, _ := newWebhooksUseCase(sender)
+	ep := crearEndpoint(t, uc, 7)
+	require.NoError(t, uc.HandleEvent(context.Background(), evento("evt-1")))
+	const originalID = 2
+
+	_, err := uc.Redeliver(context.Background(), originalID, 99)
+	assert.ErrorIs(t, err, dom.ErrDeliveryNotFound, "otro negocio no puede reenviar")
+
+	nueva, err := uc.Redeliver(context.Background(), originalID, 7)
+	require.NoError(t, err)
+	assert.Equal(t, entities.DeliverySucceeded, nueva.Status)
+	require.NotNil(t, nueva.RedeliveryOfID)
+	assert.Equal(t, uint(originalID), *nueva.RedeliveryOfID)
+	assert.Equal(t, sender.Requests[0].Body, sender.Requests[1].Body, "se reenvia el mismo cuerpo")
+
+	// Reenviar un reenvio apunta a la entrega original
+	otra, err := uc.Redeliver(context.Background(), nueva.ID, 7)
+	require.NoError(t, err)
+	assert.Equal(t, uint(originalID), *otra.RedeliveryOfID)
+
+	disabled := false
+	_, err = uc.UpdateEndpoint(context.Background(), dtos.UpdateEndpointDTO{ID: ep.ID, BusinessID: 7, Enabled: &disabled})
+	require.NoError(t, err)
+	_, err = uc.Redeliver(context.Background(), originalID, 7)
+	assert.ErrorIs(t, err, dom.ErrEndpointDisabled)
+}
+
+func TestUpdateEndpoint_NoPermiteApuntarALaRedInterna(t *testing.T) {
+	uc, repo, _ := newWebhooksUseCase(nil)
+	ep := crearEndpoint(t, uc, 7)
+
+	interna := "https://interno.example.com/hook"
+	_, err := uc.UpdateEndpoint(context.Background(), dtos.UpdateEndpointDTO{ID: ep.ID, BusinessID: 7, URL: &interna})
+
+	assert.ErrorIs(t, err, dom.ErrURLNotAllowed)
+	got, _ := repo.GetEndpoint(context.Background(), ep.ID)
+	assert.Equal(t, "https://erp.example.com/hooks", got.URL)
+}
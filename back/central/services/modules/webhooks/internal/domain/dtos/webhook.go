@@ -0,0 +1,83 @@
+package dtos
+
+import "time"
+
+type CreateEndpointDTO struct {
+	BusinessID  uint
+	Name        string
+	URL         string
+	Description string
+}
+
+type UpdateEndpointDTO struct {
+	ID          uint
+	BusinessID  uint
+	Name        *string
+	URL         *string
+	Description *string
+	Enabled     *bool
+}
+
+type RotateSecretDTO struct {
+	ID         uint
+	BusinessID uint
+	// GraceHours es cuanto sigue firmando el secreto anterior; nil usa el
+	// valor por defecto
+	GraceHours *int
+}
+
+type ListDeliveriesParams struct {
+	BusinessID uint
+	EndpointID uint
+	Status     string
+	EventType  string
+	Page       int
+	PageSize   int
+}
+
+// EventMessage es el evento que llega desde el dispatcher de eventos por la
+// cola events.webhooks.deliveries.
+type EventMessage struct {
+	EventID       string
+	EventType     string
+	Category      string
+	BusinessID    uint
+	IntegrationID uint
+	ConfigID      uint
+	Timestamp     time.Time
+	Data          map[string]interface{}
+}
+
+// SendRequest es una peticion HTTP firmada lista para enviar.
+type SendRequest struct {
+	URL     string
+	Headers map[string]string
+	Body    []byte
+}
+
+// SendResult es lo que respondio el endpoint. Error queda en blanco si hubo
+// respuesta HTTP, aunque no sea 2xx.
+type SendResult struct {
+	StatusCode int
+	Body       string
+	Duration   time.Duration
+	Error      string
+}
+
+// Success indica si el endpoint acepto la entrega.
+func (r SendResult) Success() bool {
+	return r.Error == "" && r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// AttemptResult es como queda una entrega despues de un intento.
+type AttemptResult struct {
+	Status         string
+	Attempts       int
+	NextAttemptAt  *time.Time
+	RequestHeaders map[string]string
+	ResponseStatus int
+	ResponseBody   string
+	Error          string
+	DurationMs     int64
+	DeliveredAt    *time.Time
+}
@@ -0,0 +1,71 @@
+package entities
+
+import "time"
+
+// Estados de una entrega
+const (
+	DeliveryPending   = "pending"
+	DeliverySending   = "sending"
+	DeliveryRetrying  = "retrying"
+	DeliverySucceeded = "succeeded"
+	DeliveryFailed    = "failed"
+)
+
+type Endpoint struct {
+	ID          uint
+	BusinessID  uint
+	Name        string
+	URL         string
+	Description string
+
+	Secret                  string
+	PreviousSecret          string
+	PreviousSecretExpiresAt *time.Time
+
+	Enabled             bool
+	ConsecutiveFailures int
+	DisabledAt          *time.Time
+	DisabledReason      string
+	LastDeliveryAt      *time.Time
+	LastSuccessAt       *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// SigningSecrets devuelve los secretos con los que se firma en el instante
+// dado: el vigente y, durante la rotacion, el anterior.
+func (e *Endpoint) SigningSecrets(now time.Time) []string {
+	secrets := []string{e.Secret}
+	if e.PreviousSecret != "" && e.PreviousSecretExpiresAt != nil && now.Before(*e.PreviousSecretExpiresAt) {
+		secrets = append(secrets, e.PreviousSecret)
+	}
+	return secrets
+}
+
+type Delivery struct {
+	ID         uint
+	EndpointID uint
+	BusinessID uint
+	EventID    string
+	EventType  string
+	Payload    []byte
+
+	Status        string
+	Attempts      int
+	NextAttemptAt *time.Time
+
+	RequestHeaders map[string]string
+	ResponseStatus int
+	ResponseBody   string
+	Error          string
+	DurationMs     int64
+	DeliveredAt    *time.Time
+
+	RedeliveryOfID *uint
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	EndpointName string
+	EndpointURL  string
+}
@@ -0,0 +1,15 @@
+package errors
+
+import "errors"
+
+var (
+	ErrEndpointNotFound  = errors.New("webhook endpoint not found")
+	ErrDeliveryNotFound  = errors.New("webhook delivery not found")
+	ErrBusinessRequired  = errors.New("business_id is required")
+	ErrNameRequired      = errors.New("name is required")
+	ErrInvalidURL        = errors.New("url must be a valid https url")
+	ErrURLNotAllowed     = errors.New("url must point to a public address")
+	ErrURLUnresolvable   = errors.New("url host could not be resolved")
+	ErrEndpointDisabled  = errors.New("webhook endpoint is disabled")
+	ErrInvalidGraceHours = errors.New("grace_hours must be between 0 and 168")
+)
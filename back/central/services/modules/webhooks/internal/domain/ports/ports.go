@@ -0,0 +1,37 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/entities"
+)
+
+type IRepository interface {
+	CreateEndpoint(ctx context.Context, endpoint *entities.Endpoint) (*entities.Endpoint, error)
+	GetEndpoint(ctx context.Context, id uint) (*entities.Endpoint, error)
+	ListEndpoints(ctx context.Context, businessID uint) ([]entities.Endpoint, error)
+	ListActiveEndpoints(ctx context.Context, businessID uint) ([]entities.Endpoint, error)
+	UpdateEndpoint(ctx context.Context, id uint, updates map[string]any) (*entities.Endpoint, error)
+	DeleteEndpoint(ctx context.Context, id uint) error
+	// RecordAttempt suma o reinicia los fallos consecutivos del endpoint y
+	// devuelve como quedo.
+	RecordAttempt(ctx context.Context, endpointID uint, success bool, at time.Time) (*entities.Endpoint, error)
+
+	// CreateDelivery inserta la entrega; created es false si el evento ya se
+	// habia registrado para ese endpoint (reentrega de la cola).
+	CreateDelivery(ctx context.Context, delivery *entities.Delivery) (created bool, err error)
+	GetDelivery(ctx context.Context, id uint) (*entities.Delivery, error)
+	ListDeliveries(ctx context.Context, params dtos.ListDeliveriesParams) ([]entities.Delivery, int64, error)
+	SaveAttempt(ctx context.Context, deliveryID uint, attempt dtos.AttemptResult) error
+	// ClaimDueDeliveries pasa a sending las entregas en retrying cuyo
+	// reintento ya vencio y las devuelve, sin chocar con otra instancia.
+	ClaimDueDeliveries(ctx context.Context, now time.Time, limit int) ([]entities.Delivery, error)
+	// FailPendingDeliveries cierra los reintentos de un endpoint deshabilitado.
+	FailPendingDeliveries(ctx context.Context, endpointID uint, reason string) error
+}
+
+type ISender interface {
+	Send(ctx context.Context, req dtos.SendRequest) dtos.SendResult
+}
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/app"
+	dom "github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+type IHandlers interface {
+	RegisterRoutes(router *gin.RouterGroup)
+}
+
+type Handlers struct {
+	uc  app.IUseCase
+	log log.ILogger
+}
+
+func New(uc app.IUseCase, logger log.ILogger) IHandlers {
+	return &Handlers{uc: uc, log: logger}
+}
+
+func (h *Handlers) parseUintParam(c *gin.Context, key string) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param(key), 10, 64)
+	if err != nil || id == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// businessScope devuelve el negocio sobre el que opera la peticion. El super
+// admin ve todos (0) salvo que filtre con ?business_id.
+func (h *Handlers) businessScope(c *gin.Context) uint {
+	if middleware.IsSuperAdmin(c) {
+		if v, err := strconv.ParseUint(c.Query("business_id"), 10, 64); err == nil {
+			return uint(v)
+		}
+		return 0
+	}
+	businessID, _ := middleware.GetBusinessID(c)
+	return businessID
+}
+
+func (h *Handlers) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, dom.ErrEndpointNotFound), errors.Is(err, dom.ErrDeliveryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, dom.ErrEndpointDisabled):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, dom.ErrBusinessRequired), errors.Is(err, dom.ErrNameRequired),
+		errors.Is(err, dom.ErrInvalidURL), errors.Is(err, dom.ErrURLNotAllowed),
+		errors.Is(err, dom.ErrURLUnresolvable), errors.Is(err, dom.ErrInvalidGraceHours):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
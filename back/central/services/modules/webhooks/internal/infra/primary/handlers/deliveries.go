@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/infra/primary/handlers/response"
+)
+
+func (h *Handlers) ListDeliveries(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("page_size", "20"))
+	endpointID, _ := strconv.ParseUint(c.Query("endpoint_id"), 10, 64)
+
+	params := dtos.ListDeliveriesParams{
+		BusinessID: h.businessScope(c),
+		EndpointID: uint(endpointID),
+		Status:     c.Query("status"),
+		EventType:  c.Query("event_type"),
+		Page:       page,
+		PageSize:   pageSize,
+	}
+	list, total, err := h.uc.ListDeliveries(c.Request.Context(), params)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"data":      response.FromDeliveries(list),
+		"total":     total,
+		"page":      page,
+		"page_size": pageSize,
+	})
+}
+
+func (h *Handlers) GetDelivery(c *gin.Context) {
+	id, ok := h.parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	delivery, err := h.uc.GetDelivery(c.Request.Context(), id, h.businessScope(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response.FromDelivery(delivery))
+}
+
+// Redeliver reenvia el evento de una entrega ya registrada y responde con el
+// resultado del intento.
+func (h *Handlers) Redeliver(c *gin.Context) {
+	id, ok := h.parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	delivery, err := h.uc.Redeliver(c.Request.Context(), id, h.businessScope(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response.FromDelivery(delivery))
+}
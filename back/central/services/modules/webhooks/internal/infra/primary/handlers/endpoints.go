@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/infra/primary/handlers/request"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/infra/primary/handlers/response"
+)
+
+func (h *Handlers) ListEndpoints(c *gin.Context) {
+	list, err := h.uc.ListEndpoints(c.Request.Context(), h.businessScope(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"data": response.FromEndpoints(list)})
+}
+
+func (h *Handlers) CreateEndpoint(c *gin.Context) {
+	var req request.CreateEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	businessID := h.businessScope(c)
+	if middleware.IsSuperAdmin(c) && req.BusinessID != 0 {
+		businessID = req.BusinessID
+	}
+
+	endpoint, err := h.uc.CreateEndpoint(c.Request.Context(), dtos.CreateEndpointDTO{
+		BusinessID:  businessID,
+		Name:        req.Name,
+		URL:         req.URL,
+		Description: req.Description,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, response.FromEndpoint(endpoint, true))
+}
+
+func (h *Handlers) GetEndpoint(c *gin.Context) {
+	id, ok := h.parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	endpoint, err := h.uc.GetEndpoint(c.Request.Context(), id, h.businessScope(c))
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response.FromEndpoint(endpoint, false))
+}
+
+func (h *Handlers) UpdateEndpoint(c *gin.Context) {
+	id, ok := h.parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.UpdateEndpointRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	endpoint, err := h.uc.UpdateEndpoint(c.Request.Context(), dtos.UpdateEndpointDTO{
+		ID:          id,
+		BusinessID:  h.businessScope(c),
+		Name:        req.Name,
+		URL:         req.URL,
+		Description: req.Description,
+		Enabled:     req.Enabled,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response.FromEndpoint(endpoint, false))
+}
+
+func (h *Handlers) DeleteEndpoint(c *gin.Context) {
+	id, ok := h.parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.uc.DeleteEndpoint(c.Request.Context(), id, h.businessScope(c)); err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handlers) RotateSecret(c *gin.Context) {
+	id, ok := h.parseUintParam(c, "id")
+	if !ok {
+		return
+	}
+	var req request.RotateSecretRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	endpoint, err := h.uc.RotateSecret(c.Request.Context(), dtos.RotateSecretDTO{
+		ID:         id,
+		BusinessID: h.businessScope(c),
+		GraceHours: req.GraceHours,
+	})
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, response.FromEndpoint(endpoint, true))
+}
@@ -0,0 +1,22 @@
+package request
+
+type CreateEndpointRequest struct {
+	Name        string `json:"name" binding:"required"`
+	URL         string `json:"url" binding:"required"`
+	Description string `json:"description"`
+	// Solo super admin: negocio dueno del endpoint
+	BusinessID uint `json:"business_id"`
+}
+
+type UpdateEndpointRequest struct {
+	Name        *string `json:"name"`
+	URL         *string `json:"url"`
+	Description *string `json:"description"`
+	Enabled     *bool   `json:"enabled"`
+}
+
+type RotateSecretRequest struct {
+	// Horas que el secreto anterior sigue firmando (24 por defecto, 0 lo
+	// revoca de inmediato)
+	GraceHours *int `json:"grace_hours"`
+}
@@ -0,0 +1,128 @@
+package response
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/entities"
+)
+
+type EndpointResponse struct {
+	ID          uint   `json:"id"`
+	BusinessID  uint   `json:"business_id"`
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
+	// Secret solo viaja completo al crear y al rotar
+	Secret                  string     `json:"secret,omitempty"`
+	SecretHint              string     `json:"secret_hint"`
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at"`
+	Enabled                 bool       `json:"enabled"`
+	ConsecutiveFailures     int        `json:"consecutive_failures"`
+	DisabledAt              *time.Time `json:"disabled_at"`
+	DisabledReason          string     `json:"disabled_reason"`
+	LastDeliveryAt          *time.Time `json:"last_delivery_at"`
+	LastSuccessAt           *time.Time `json:"last_success_at"`
+	CreatedAt               time.Time  `json:"created_at"`
+	UpdatedAt               time.Time  `json:"updated_at"`
+}
+
+func FromEndpoint(e *entities.Endpoint, revealSecret bool) EndpointResponse {
+	out := EndpointResponse{
+		ID:                  e.ID,
+		BusinessID:          e.BusinessID,
+		Name:                e.Name,
+		URL:                 e.URL,
+		Description:         e.Description,
+		SecretHint:          secretHint(e.Secret),
+		Enabled:             e.Enabled,
+		ConsecutiveFailures: e.ConsecutiveFailures,
+		DisabledAt:          e.DisabledAt,
+		DisabledReason:      e.DisabledReason,
+		LastDeliveryAt:      e.LastDeliveryAt,
+		LastSuccessAt:       e.LastSuccessAt,
+		CreatedAt:           e.CreatedAt,
+		UpdatedAt:           e.UpdatedAt,
+	}
+	if e.PreviousSecret != "" {
+		out.PreviousSecretExpiresAt = e.PreviousSecretExpiresAt
+	}
+	if revealSecret {
+		out.Secret = e.Secret
+	}
+	return out
+}
+
+func FromEndpoints(list []entities.Endpoint) []EndpointResponse {
+	out := make([]EndpointResponse, 0, len(list))
+	for i := range list {
+		out = append(out, FromEndpoint(&list[i], false))
+	}
+	return out
+}
+
+func secretHint(secret string) string {
+	if len(secret) <= 10 {
+		return ""
+	}
+	return secret[:6] + "..." + secret[len(secret)-4:]
+}
+
+type DeliveryResponse struct {
+	ID             uint              `json:"id"`
+	EndpointID     uint              `json:"endpoint_id"`
+	EndpointName   string            `json:"endpoint_name"`
+	EndpointURL    string            `json:"endpoint_url"`
+	BusinessID     uint              `json:"business_id"`
+	EventID        string            `json:"event_id"`
+	EventType      string            `json:"event_type"`
+	Status         string            `json:"status"`
+	Attempts       int               `json:"attempts"`
+	NextAttemptAt  *time.Time        `json:"next_attempt_at"`
+	ResponseStatus int               `json:"response_status"`
+	Error          string            `json:"error"`
+	DurationMs     int64             `json:"duration_ms"`
+	DeliveredAt    *time.Time        `json:"delivered_at"`
+	RedeliveryOfID *uint             `json:"redelivery_of_id"`
+	CreatedAt      time.Time         `json:"created_at"`
+	UpdatedAt      time.Time         `json:"updated_at"`
+	RequestHeaders map[string]string `json:"request_headers,omitempty"`
+	RequestBody    json.RawMessage   `json:"request_body,omitempty"`
+	ResponseBody   string            `json:"response_body,omitempty"`
+}
+
+func FromDelivery(d *entities.Delivery) DeliveryResponse {
+	out := DeliveryResponse{
+		ID:             d.ID,
+		EndpointID:     d.EndpointID,
+		EndpointName:   d.EndpointName,
+		EndpointURL:    d.EndpointURL,
+		BusinessID:     d.BusinessID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		NextAttemptAt:  d.NextAttemptAt,
+		ResponseStatus: d.ResponseStatus,
+		Error:          d.Error,
+		DurationMs:     d.DurationMs,
+		DeliveredAt:    d.DeliveredAt,
+		RedeliveryOfID: d.RedeliveryOfID,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+		RequestHeaders: d.RequestHeaders,
+		ResponseBody:   d.ResponseBody,
+	}
+	if len(d.Payload) > 0 {
+		out.RequestBody = json.RawMessage(d.Payload)
+	}
+	return out
+}
+
+func FromDeliveries(list []entities.Delivery) []DeliveryResponse {
+	out := make([]DeliveryResponse, 0, len(list))
+	for i := range list {
+		out = append(out, FromDelivery(&list[i]))
+	}
+	return out
+}
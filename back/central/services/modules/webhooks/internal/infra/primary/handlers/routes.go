@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/services/auth/middleware"
+)
+
+func (h *Handlers) RegisterRoutes(router *gin.RouterGroup) {
+	g := router.Group("/webhooks", middleware.JWT())
+	{
+		g.GET("/endpoints", h.ListEndpoints)
+		g.POST("/endpoints", h.CreateEndpoint)
+		g.GET("/endpoints/:id", h.GetEndpoint)
+		g.PUT("/endpoints/:id", h.UpdateEndpoint)
+		g.DELETE("/endpoints/:id", h.DeleteEndpoint)
+		g.POST("/endpoints/:id/rotate-secret", h.RotateSecret)
+
+		g.GET("/deliveries", h.ListDeliveries)
+		g.GET("/deliveries/:id", h.GetDelivery)
+		g.POST("/deliveries/:id/redeliver", h.Redeliver)
+	}
+}
@@ -0,0 +1,69 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/app"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/infra/primary/queue/consumer/request"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/rabbitmq"
+)
+
+// eventConsumerWorkers limita los envios simultaneos: cada uno puede esperar
+// hasta el timeout HTTP del endpoint
+const eventConsumerWorkers = 4
+
+type EventConsumer struct {
+	queue rabbitmq.IQueue
+	uc    app.IUseCase
+	log   log.ILogger
+}
+
+func New(queue rabbitmq.IQueue, uc app.IUseCase, logger log.ILogger) *EventConsumer {
+	return &EventConsumer{queue: queue, uc: uc, log: logger}
+}
+
+// Start consume los eventos que el dispatcher rutea al canal webhook
+func (c *EventConsumer) Start(ctx context.Context) error {
+	if err := c.queue.DeclareQueue(rabbitmq.QueueEventsWebhookDeliveries, true); err != nil {
+		return err
+	}
+
+	c.log.Info(ctx).
+		Str("queue", rabbitmq.QueueEventsWebhookDeliveries).
+		Msg("Iniciando consumer de webhooks salientes")
+
+	return c.queue.ConsumeConcurrent(ctx, rabbitmq.QueueEventsWebhookDeliveries, c.handleMessage, eventConsumerWorkers)
+}
+
+func (c *EventConsumer) handleMessage(body []byte) error {
+	ctx := context.Background()
+
+	var msg request.EventMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		c.log.Error(ctx).
+			Err(err).
+			Str("raw", string(body)).
+			Msg("Error deserializando evento para webhooks")
+		return nil // No reintentar mensajes malformados
+	}
+
+	timestamp, _ := time.Parse(time.RFC3339, msg.Timestamp)
+	dto := dtos.EventMessage{
+		EventID:       msg.EventID,
+		EventType:     msg.EventType,
+		Category:      msg.Category,
+		BusinessID:    msg.BusinessID,
+		IntegrationID: msg.IntegrationID,
+		ConfigID:      msg.ConfigID,
+		Timestamp:     timestamp,
+		Data:          msg.Data,
+	}
+
+	// Los fallos del endpoint se reintentan desde la tabla de entregas; a la
+	// cola solo vuelve el mensaje si no se pudo ni registrar
+	return c.uc.HandleEvent(ctx, dto)
+}
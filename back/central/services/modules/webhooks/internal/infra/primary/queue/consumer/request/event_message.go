@@ -0,0 +1,14 @@
+package request
+
+// EventMessage es el mensaje que publica el dispatcher de eventos en
+// events.webhooks.deliveries.
+type EventMessage struct {
+	EventID       string                 `json:"event_id"`
+	EventType     string                 `json:"event_type"`
+	Category      string                 `json:"category"`
+	BusinessID    uint                   `json:"business_id"`
+	IntegrationID uint                   `json:"integration_id"`
+	ConfigID      uint                   `json:"config_id"`
+	Timestamp     string                 `json:"timestamp"`
+	Data          map[string]interface{} `json:"data"`
+}
@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/app"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+const retryInterval = 30 * time.Second
+
+type RetryWorker struct {
+	uc  app.IUseCase
+	log log.ILogger
+}
+
+func NewRetryWorker(uc app.IUseCase, logger log.ILogger) *RetryWorker {
+	return &RetryWorker{uc: uc, log: logger.WithModule("webhooks.retry")}
+}
+
+func (w *RetryWorker) Start(ctx context.Context) {
+	ticker := time.NewTicker(retryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.runOnce(ctx)
+		}
+	}
+}
+
+func (w *RetryWorker) runOnce(ctx context.Context) {
+	attempted, err := w.uc.ProcessDueRetries(ctx)
+	if err != nil {
+		w.log.Error(ctx).Err(err).Msg("Webhooks retry worker: cycle failed")
+		return
+	}
+	if attempted > 0 {
+		w.log.Info(ctx).Int("attempted", attempted).Msg("Webhooks retry worker: cycle complete")
+	}
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"encoding/json"
+
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/entities"
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func endpointToModel(e *entities.Endpoint) *models.WebhookEndpoint {
+	return &models.WebhookEndpoint{
+		BusinessID:              e.BusinessID,
+		Name:                    e.Name,
+		URL:                     e.URL,
+		Description:             e.Description,
+		Secret:                  e.Secret,
+		PreviousSecret:          e.PreviousSecret,
+		PreviousSecretExpiresAt: e.PreviousSecretExpiresAt,
+		Enabled:                 e.Enabled,
+	}
+}
+
+func endpointToEntity(m *models.WebhookEndpoint) *entities.Endpoint {
+	return &entities.Endpoint{
+		ID:                      m.ID,
+		BusinessID:              m.BusinessID,
+		Name:                    m.Name,
+		URL:                     m.URL,
+		Description:             m.Description,
+		Secret:                  m.Secret,
+		PreviousSecret:          m.PreviousSecret,
+		PreviousSecretExpiresAt: m.PreviousSecretExpiresAt,
+		Enabled:                 m.Enabled,
+		ConsecutiveFailures:     m.ConsecutiveFailures,
+		DisabledAt:              m.DisabledAt,
+		DisabledReason:          m.DisabledReason,
+		LastDeliveryAt:          m.LastDeliveryAt,
+		LastSuccessAt:           m.LastSuccessAt,
+		CreatedAt:               m.CreatedAt,
+		UpdatedAt:               m.UpdatedAt,
+	}
+}
+
+func deliveryToEntity(m *models.WebhookDelivery) entities.Delivery {
+	out := entities.Delivery{
+		ID:             m.ID,
+		EndpointID:     m.EndpointID,
+		BusinessID:     m.BusinessID,
+		EventID:        m.EventID,
+		EventType:      m.EventType,
+		Payload:        []byte(m.Payload),
+		Status:         m.Status,
+		Attempts:       m.Attempts,
+		NextAttemptAt:  m.NextAttemptAt,
+		ResponseStatus: m.ResponseStatus,
+		ResponseBody:   m.ResponseBody,
+		Error:          m.Error,
+		DurationMs:     m.DurationMs,
+		DeliveredAt:    m.DeliveredAt,
+		RedeliveryOfID: m.RedeliveryOfID,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+		EndpointName:   m.Endpoint.Name,
+		EndpointURL:    m.Endpoint.URL,
+	}
+	if len(m.RequestHeaders) > 0 {
+		_ = json.Unmarshal(m.RequestHeaders, &out.RequestHeaders)
+	}
+	return out
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/shared/db"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository struct {
+	db db.IDatabase
+}
+
+func New(database db.IDatabase) ports.IRepository {
+	return &Repository{db: database}
+}
+
+func (r *Repository) CreateEndpoint(ctx context.Context, e *entities.Endpoint) (*entities.Endpoint, error) {
+	m := endpointToModel(e)
+	if err := r.db.Conn(ctx).Create(m).Error; err != nil {
+		return nil, err
+	}
+	return endpointToEntity(m), nil
+}
+
+func (r *Repository) GetEndpoint(ctx context.Context, id uint) (*entities.Endpoint, error) {
+	var m models.WebhookEndpoint
+	if err := r.db.Conn(ctx).Where("id = ?", id).First(&m).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, dom.ErrEndpointNotFound
+		}
+		return nil, err
+	}
+	return endpointToEntity(&m), nil
+}
+
+func (r *Repository) ListEndpoints(ctx context.Context, businessID uint) ([]entities.Endpoint, error) {
+	return r.listEndpoints(ctx, businessID, false)
+}
+
+func (r *Repository) ListActiveEndpoints(ctx context.Context, businessID uint) ([]entities.Endpoint, error) {
+	return r.listEndpoints(ctx, businessID, true)
+}
+
+func (r *Repository) listEndpoints(ctx context.Context, businessID uint, onlyEnabled bool) ([]entities.Endpoint, error) {
+	q := r.db.Conn(ctx).Model(&models.WebhookEndpoint{})
+	if businessID != 0 {
+		q = q.Where("business_id = ?", businessID)
+	}
+	if onlyEnabled {
+		q = q.Where("enabled = ?", true)
+	}
+	var rows []models.WebhookEndpoint
+	if err := q.Order("id ASC").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	out := make([]entities.Endpoint, 0, len(rows))
+	for i := range rows {
+		out = append(out, *endpointToEntity(&rows[i]))
+	}
+	return out, nil
+}
+
+func (r *Repository) UpdateEndpoint(ctx context.Context, id uint, updates map[string]any) (*entities.Endpoint, error) {
+	res := r.db.Conn(ctx).Model(&models.WebhookEndpoint{}).Where("id = ?", id).Updates(updates)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return nil, dom.ErrEndpointNotFound
+	}
+	return r.GetEndpoint(ctx, id)
+}
+
+func (r *Repository) DeleteEndpoint(ctx context.Context, id uint) error {
+	res := r.db.Conn(ctx).Delete(&models.WebhookEndpoint{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return dom.ErrEndpointNotFound
+	}
+	return nil
+}
+
+func (r *Repository) RecordAttempt(ctx context.Context, endpointID uint, success bool, at time.Time) (*entities.Endpoint, error) {
+	updates := map[string]any{"last_delivery_at": at}
+	if success {
+		updates["consecutive_failures"] = 0
+		updates["last_success_at"] = at
+	} else {
+		updates["consecutive_failures"] = gorm.Expr("consecutive_failures + 1")
+	}
+	return r.UpdateEndpoint(ctx, endpointID, updates)
+}
+
+func (r *Repository) CreateDelivery(ctx context.Context, d *entities.Delivery) (bool, error) {
+	m := &models.WebhookDelivery{
+		EndpointID:     d.EndpointID,
+		BusinessID:     d.BusinessID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        datatypes.JSON(d.Payload),
+		Status:         d.Status,
+		RedeliveryOfID: d.RedeliveryOfID,
+	}
+	// El indice unico (endpoint_id, event_id) de las entregas originales
+	// absorbe los eventos que la cola entrega dos veces
+	res := r.db.Conn(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(m)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	if res.RowsAffected == 0 {
+		return false, nil
+	}
+	d.ID = m.ID
+	d.CreatedAt = m.CreatedAt
+	d.UpdatedAt = m.UpdatedAt
+	return true, nil
+}
+
+func (r *Repository) GetDelivery(ctx context.Context, id uint) (*entities.Delivery, error) {
+	var m models.WebhookDelivery
+	err := r.db.Conn(ctx).
+		Preload("Endpoint", func(tx *gorm.DB) *gorm.DB { return tx.Unscoped() }).
+		Where("id = ?", id).
+		First(&m).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, dom.ErrDeliveryNotFound
+		}
+		return nil, err
+	}
+	out := deliveryToEntity(&m)
+	return &out, nil
+}
+
+func (r *Repository) ListDeliveries(ctx context.Context, params dtos.ListDeliveriesParams) ([]entities.Delivery, int64, error) {
+	q := r.db.Conn(ctx).Model(&models.WebhookDelivery{})
+	if params.BusinessID != 0 {
+		q = q.Where("business_id = ?", params.BusinessID)
+	}
+	if params.EndpointID != 0 {
+		q = q.Where("endpoint_id = ?", params.EndpointID)
+	}
+	if params.Status != "" {
+		q = q.Where("status = ?", params.Status)
+	}
+	if params.EventType != "" {
+		q = q.Where("event_type = ?", params.EventType)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	// El listado no trae cuerpos; se leen en el detalle
+	var rows []models.WebhookDelivery
+	err := q.
+		Omit("payload", "request_headers", "response_body").
+		Preload("Endpoint", func(tx *gorm.DB) *gorm.DB { return tx.Unscoped() }).
+		Order("id DESC").
+		Offset((params.Page - 1) * params.PageSize).
+		Limit(params.PageSize).
+		Find(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	out := make([]entities.Delivery, 0, len(rows))
+	for i := range rows {
+		out = append(out, deliveryToEntity(&rows[i]))
+	}
+	return out, total, nil
+}
+
+func (r *Repository) SaveAttempt(ctx context.Context, deliveryID uint, a dtos.AttemptResult) error {
+	headers, err := json.Marshal(a.RequestHeaders)
+	if err != nil {
+		return err
+	}
+	return r.db.Conn(ctx).Model(&models.WebhookDelivery{}).Where("id = ?", deliveryID).Updates(map[string]any{
+		"status":          a.Status,
+		"attempts":        a.Attempts,
+		"next_attempt_at": a.NextAttemptAt,
+		"request_headers": datatypes.JSON(headers),
+		"response_status": a.ResponseStatus,
+		"response_body":   a.ResponseBody,
+		"error":           a.Error,
+		"duration_ms":     a.DurationMs,
+		"delivered_at":    a.DeliveredAt,
+	}).Error
+}
+
+func (r *Repository) ClaimDueDeliveries(ctx context.Context, now time.Time, limit int) ([]entities.Delivery, error) {
+	var rows []models.WebhookDelivery
+	err := r.db.Conn(ctx).Raw(`
+UPDATE webhook_deliveries SET status = ?, updated_at = ?
+WHERE id IN (
+	SELECT id FROM webhook_deliveries
+	WHERE status = ? AND next_attempt_at <= ?
+	ORDER BY next_attempt_at
+	LIMIT ?
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *
+`, entities.DeliverySending, now, entities.DeliveryRetrying, now, limit).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]entities.Delivery, 0, len(rows))
+	for i := range rows {
+		out = append(out, deliveryToEntity(&rows[i]))
+	}
+	return out, nil
+}
+
+func (r *Repository) FailPendingDeliveries(ctx context.Context, endpointID uint, reason string) error {
+	return r.db.Conn(ctx).Model(&models.WebhookDelivery{}).
+		Where("endpoint_id = ? AND status IN ?", endpointID, []string{entities.DeliveryPending, entities.DeliverySending, entities.DeliveryRetrying}).
+		Updates(map[string]any{
+			"status":          entities.DeliveryFailed,
+			"next_attempt_at": nil,
+			"error":           reason,
+		}).Error
+}
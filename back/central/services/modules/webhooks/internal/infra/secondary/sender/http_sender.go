@@ -0,0 +1,63 @@
+package sender
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/ports"
+	"github.com/secamc93/probability/back/central/shared/netguard"
+)
+
+const (
+	requestTimeout = 10 * time.Second
+	// responseBodyLimit es cuanto de la respuesta queda en el log de entregas
+	responseBodyLimit = 4096
+)
+
+type httpSender struct {
+	client *http.Client
+}
+
+// New usa un transporte que solo conecta a IPs publicas: la URL se valido al
+// registrarla, pero el DNS pudo cambiar desde entonces.
+func New(guard netguard.Guard) ports.ISender {
+	return &httpSender{
+		client: &http.Client{
+			Timeout:   requestTimeout,
+			Transport: guard.Transport(),
+			// Un 3xx cuenta como fallo: el negocio debe registrar la URL final
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *httpSender) Send(ctx context.Context, req dtos.SendRequest) dtos.SendResult {
+	start := time.Now()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Body))
+	if err != nil {
+		return dtos.SendResult{Error: err.Error(), Duration: time.Since(start)}
+	}
+	for k, v := range req.Headers {
+		httpReq.Header.Set(k, v)
+	}
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return dtos.SendResult{Error: err.Error(), Duration: time.Since(start)}
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, responseBodyLimit))
+	return dtos.SendResult{
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+		Duration:   time.Since(start),
+	}
+}
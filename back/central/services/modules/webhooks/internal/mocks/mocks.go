@@ -0,0 +1,263 @@
+package mocks
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/dtos"
+	"github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/entities"
+	dom "github.com/secamc93/probability/back/central/services/modules/webhooks/internal/domain/errors"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+// RepositoryMock guarda endpoints y entregas en memoria y replica las reglas
+// del repositorio real que importan al caso de uso (unicidad por evento,
+// contador de fallos, claim de reintentos vencidos).
+type RepositoryMock struct {
+	mu         sync.Mutex
+	Endpoints  map[uint]*entities.Endpoint
+	Deliveries map[uint]*entities.Delivery
+	nextID     uint
+}
+
+func NewRepositoryMock() *RepositoryMock {
+	return &RepositoryMock{Endpoints: map[uint]*entities.Endpoint{}, Deliveries: map[uint]*entities.Delivery{}}
+}
+
+func (m *RepositoryMock) id() uint {
+	m.nextID++
+	return m.nextID
+}
+
+func (m *RepositoryMock) CreateEndpoint(_ context.Context, e *entities.Endpoint) (*entities.Endpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := *e
+	cp.ID = m.id()
+	m.Endpoints[cp.ID] = &cp
+	out := cp
+	return &out, nil
+}
+
+func (m *RepositoryMock) GetEndpoint(_ context.Context, id uint) (*entities.Endpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, ok := m.Endpoints[id]
+	if !ok {
+		return nil, dom.ErrEndpointNotFound
+	}
+	out := *e
+	return &out, nil
+}
+
+func (m *RepositoryMock) ListEndpoints(_ context.Context, businessID uint) ([]entities.Endpoint, error) {
+	return m.list(businessID, false), nil
+}
+
+func (m *RepositoryMock) ListActiveEndpoints(_ context.Context, businessID uint) ([]entities.Endpoint, error) {
+	return m.list(businessID, true), nil
+}
+
+func (m *RepositoryMock) list(businessID uint, onlyEnabled bool) []entities.Endpoint {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []entities.Endpoint
+	for _, e := range m.Endpoints {
+		if (businessID == 0 || e.BusinessID == businessID) && (!onlyEnabled || e.Enabled) {
+			out = append(out, *e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+func (m *RepositoryMock) UpdateEndpoint(ctx context.Context, id uint, updates map[string]any) (*entities.Endpoint, error) {
+	m.mu.Lock()
+	e, ok := m.Endpoints[id]
+	if !ok {
+		m.mu.Unlock()
+		return nil, dom.ErrEndpointNotFound
+	}
+	for k, v := range updates {
+		switch k {
+		case "name":
+			e.Name = v.(string)
+		case "url":
+			e.URL = v.(string)
+		case "description":
+			e.Description = v.(string)
+		case "enabled":
+			e.Enabled = v.(bool)
+		case "consecutive_failures":
+			e.ConsecutiveFailures = v.(int)
+		case "disabled_at":
+			e.DisabledAt = timePtr(v)
+		case "disabled_reason":
+			e.DisabledReason = v.(string)
+		case "secret":
+			e.Secret = v.(string)
+		case "previous_secret":
+			e.PreviousSecret = v.(string)
+		case "previous_secret_expires_at":
+			e.PreviousSecretExpiresAt = timePtr(v)
+		}
+	}
+	m.mu.Unlock()
+	return m.GetEndpoint(ctx, id)
+}
+
+func timePtr(v any) *time.Time {
+	if t, ok := v.(time.Time); ok {
+		return &t
+	}
+	return nil
+}
+
+func (m *RepositoryMock) DeleteEndpoint(_ context.Context, id uint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.Endpoints[id]; !ok {
+		return dom.ErrEndpointNotFound
+	}
+	delete(m.Endpoints, id)
+	return nil
+}
+
+func (m *RepositoryMock) RecordAttempt(ctx context.Context, endpointID uint, success bool, at time.Time) (*entities.Endpoint, error) {
+	m.mu.Lock()
+	e, ok := m.Endpoints[endpointID]
+	if !ok {
+		m.mu.Unlock()
+		return nil, dom.ErrEndpointNotFound
+	}
+	e.LastDeliveryAt = &at
+	if success {
+		e.ConsecutiveFailures = 0
+		e.LastSuccessAt = &at
+	} else {
+		e.ConsecutiveFailures++
+	}
+	m.mu.Unlock()
+	return m.GetEndpoint(ctx, endpointID)
+}
+
+func (m *RepositoryMock) CreateDelivery(_ context.Context, d *entities.Delivery) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if d.RedeliveryOfID == nil {
+		for _, existing := range m.Deliveries {
+			if existing.RedeliveryOfID == nil && existing.EndpointID == d.EndpointID && existing.EventID == d.EventID {
+				return false, nil
+			}
+		}
+	}
+	d.ID = m.id()
+	cp := *d
+	m.Deliveries[d.ID] = &cp
+	return true, nil
+}
+
+func (m *RepositoryMock) GetDelivery(_ context.Context, id uint) (*entities.Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d, ok := m.Deliveries[id]
+	if !ok {
+		return nil, dom.ErrDeliveryNotFound
+	}
+	out := *d
+	return &out, nil
+}
+
+func (m *RepositoryMock) ListDeliveries(_ context.Context, params dtos.ListDeliveriesParams) ([]entities.Delivery, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []entities.Delivery
+	for _, d := range m.Deliveries {
+		if params.BusinessID != 0 && d.BusinessID != params.BusinessID {
+			continue
+		}
+		out = append(out, *d)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID > out[j].ID })
+	return out, int64(len(out)), nil
+}
+
+func (m *RepositoryMock) SaveAttempt(_ context.Context, deliveryID uint, a dtos.AttemptResult) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	d, ok := m.Deliveries[deliveryID]
+	if !ok {
+		return dom.ErrDeliveryNotFound
+	}
+	d.Status = a.Status
+	d.Attempts = a.Attempts
+	d.NextAttemptAt = a.NextAttemptAt
+	d.RequestHeaders = a.RequestHeaders
+	d.ResponseStatus = a.ResponseStatus
+	d.ResponseBody = a.ResponseBody
+	d.Error = a.Error
+	d.DurationMs = a.DurationMs
+	d.DeliveredAt = a.DeliveredAt
+	return nil
+}
+
+func (m *RepositoryMock) ClaimDueDeliveries(_ context.Context, now time.Time, limit int) ([]entities.Delivery, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []entities.Delivery
+	for _, d := range m.Deliveries {
+		if len(out) >= limit {
+			break
+		}
+		if d.Status == entities.DeliveryRetrying && d.NextAttemptAt != nil && !d.NextAttemptAt.After(now) {
+			d.Status = entities.DeliverySending
+			out = append(out, *d)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (m *RepositoryMock) FailPendingDeliveries(_ context.Context, endpointID uint, reason string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, d := range m.Deliveries {
+		if d.EndpointID != endpointID {
+			continue
+		}
+		switch d.Status {
+		case entities.DeliveryPending, entities.DeliverySending, entities.DeliveryRetrying:
+			d.Status = entities.DeliveryFailed
+			d.NextAttemptAt = nil
+			d.Error = reason
+		}
+	}
+	return nil
+}
+
+// SenderMock responde con Responses en orden (la ultima se repite) y guarda
+// cada peticion recibida.
+type SenderMock struct {
+	mu        sync.Mutex
+	Responses []dtos.SendResult
+	Requests  []dtos.SendRequest
+}
+
+func (s *SenderMock) Send(_ context.Context, req dtos.SendRequest) dtos.SendResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Requests = append(s.Requests, req)
+	if len(s.Responses) == 0 {
+		return dtos.SendResult{StatusCode: 200}
+	}
+	idx := len(s.Requests) - 1
+	if idx >= len(s.Responses) {
+		idx = len(s.Responses) - 1
+	}
+	return s.Responses[idx]
+}
+
+func NewSilentLogger() log.ILogger { return log.NewFromZerolog(zerolog.Nop()) }
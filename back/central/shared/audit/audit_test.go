@@ -0,0 +1,293 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/secamc93/probability/back/central/shared/db"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/testkit"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func uintPtr(v uint) *uint { return &v }
+
+// recorderDePrueba activa un recorder sin base ni escritor: lo que Record
+// escribe queda en escritas y las filas del middleware en la cola
+func recorderDePrueba(t *testing.T) (*Recorder, *[]*models.AuditLog) {
+	t.Helper()
+	var escritas []*models.AuditLog
+	r := newRecorder(nil, testkit.NewSilentLogger(), 0)
+	r.now = func() time.Time { return time.Date(2026, 10, 18, 12, 0, 0, 123456789, time.UTC) }
+	r.store = func(_ context.Context, rows []*models.AuditLog) error {
+		escritas = append(escritas, rows...)
+		return nil
+	}
+	active.Store(r)
+	t.Cleanup(func() { active.Store(nil) })
+	return r, &escritas
+}
+
+func encoladas(r *Recorder) []*models.AuditLog {
+	var rows []*models.AuditLog
+	for {
+		select {
+		case row := <-r.queue:
+			rows = append(rows, row)
+		default:
+			return rows
+		}
+	}
+}
+
+func TestDiff_SoloCamposQueCambian(t *testing.T) {
+	antes := map[string]any{"status": "draft", "total": 100, "address": map[string]any{"city": "Cali", "zip": "760001"}}
+	despues := map[string]any{"status": "confirmed", "total": 100, "address": map[string]any{"city": "Bogota", "zip": "760001"}}
+
+	diff := Diff(antes, despues)
+
+	assert.Equal(t, map[string]Change{
+		"status":       {Before: "draft", After: "confirmed"},
+		"address.city": {Before: "Cali", After: "Bogota"},
+	}, diff)
+}
+
+func TestDiff_CreacionYCamposSensibles(t *testing.T) {
+	despues := struct {
+		Name        string            `json:"name"`
+		Credentials map[string]string `json:"credentials"`
+	}{Name: "Shopify", Credentials: map[string]string{"access_token": "shpat_123"}}
+
+	diff := Diff(nil, despues)
+
+	assert.Equal(t, Change{Before: nil, After: "Shopify"}, diff["name"])
+	assert.Equal(t, Change{Before: nil, After: Redacted}, diff["credentials.access_token"], "el cambio se ve, el valor no")
+}
+
+func TestRedact_ACualquierProfundidad(t *testing.T) {
+	out, ok := Redact([]byte(`{"email":"a@b.co","password":"x","items":[{"api_key":"k","sku":"A"}]}`))
+
+	require.True(t, ok)
+	assert.JSONEq(t, `{"email":"a@b.co","password":"[REDACTED]","items":[{"api_key":"[REDACTED]","sku":"A"}]}`, string(out))
+}
+
+func TestHash_NoDependeDelFormatoDelJSON(t *testing.T) {
+	f := Fields{ChainID: 3, ChainSeq: 1, Resource: "orders", Action: "update", PrevHash: GenesisHash,
+		Changes:    json.RawMessage(`{"b": 1, "a": {"x": true}}`),
+		OccurredAt: time.Date(2026, 10, 18, 12, 0, 0, 123456789, time.FixedZone("COT", -5*3600)),
+	}
+	igual := f
+	igual.Changes = json.RawMessage(`{"a":{"x":true},"b":1}`)
+	igual.OccurredAt = f.OccurredAt.UTC().Truncate(time.Microsecond)
+
+	assert.Equal(t, Hash(f), Hash(igual), "jsonb reordena llaves y Postgres guarda microsegundos")
+
+	distinto := f
+	distinto.PrevHash = "otro"
+	assert.NotEqual(t, Hash(f), Hash(distinto))
+}
+
+func TestRecord_SinStartEsNoop(t *testing.T) {
+	assert.NotPanics(t, func() {
+		assert.NoError(t, Record(context.Background(), Entry{Resource: "wallet", Action: "admin_adjust"}))
+	})
+}
+
+func TestRecord_FueraDeRequest_ActorDeSistemaYCorrelation(t *testing.T) {
+	r, escritas := recorderDePrueba(t)
+	ctx := log.WithCorrelationIDCtx(context.Background(), "corr-1")
+
+	err := Record(ctx, Entry{BusinessID: uintPtr(7), Resource: "cod_cut", ResourceID: "9", Action: "confirmed",
+		Before: map[string]any{"status": "draft"}, After: map[string]any{"status": "confirmed"}})
+
+	require.NoError(t, err)
+	assert.Empty(t, encoladas(r), "las entradas de dominio no pasan por la cola")
+	rows := *escritas
+	require.Len(t, rows, 1)
+	assert.Equal(t, ActorSystem, rows[0].ActorType)
+	assert.Equal(t, uint(7), rows[0].ChainID)
+	assert.Equal(t, SourceDomain, rows[0].Source)
+	assert.Equal(t, "corr-1", rows[0].CorrelationID)
+	assert.JSONEq(t, `{"status":{"before":"draft","after":"confirmed"}}`, string(rows[0].Changes))
+}
+
+func TestRecord_FallaLaEscritura_DevuelveError(t *testing.T) {
+	r, _ := recorderDePrueba(t)
+	r.store = func(context.Context, []*models.AuditLog) error { return errors.New("db caida") }
+
+	err := Record(context.Background(), Entry{Resource: "wallet", Action: "admin_adjust"})
+
+	assert.ErrorContains(t, err, "db caida", "el llamador decide: dentro de su transaccion hace rollback")
+}
+
+func TestRecord_EscribeEnLaTransaccionDelContexto(t *testing.T) {
+	database := testkit.NewDB(t)
+	r := newRecorder(database, testkit.NewSilentLogger(), 0)
+	active.Store(r)
+	t.Cleanup(func() { active.Store(nil) })
+
+	database.Mock.ExpectBegin()
+	database.Mock.ExpectExec(`UPDATE "wallets"`).WillReturnResult(sqlmock.NewResult(0, 1))
+	database.Mock.ExpectExec(`SAVEPOINT`).WillReturnResult(sqlmock.NewResult(0, 0))
+	database.Mock.ExpectExec(`pg_advisory_xact_lock`).WillReturnResult(sqlmock.NewResult(0, 1))
+	database.Mock.ExpectQuery(`FROM "audit_logs"`).WillReturnRows(sqlmock.NewRows([]string{"chain_seq", "hash"}).AddRow(1, "h1"))
+	database.Mock.ExpectQuery(`INSERT INTO "audit_logs"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+	database.Mock.ExpectCommit()
+
+	err := db.InTransaction(context.Background(), database, func(ctx context.Context) error {
+		if err := database.Conn(ctx).Exec(`UPDATE "wallets" SET balance = 1`).Error; err != nil {
+			return err
+		}
+		return Record(ctx, Entry{BusinessID: uintPtr(7), Resource: "wallet", Action: "admin_adjust"})
+	})
+
+	require.NoError(t, err)
+	database.SinPendientes(t)
+}
+
+func TestWrite_AgotaReintentos_CuentaDescartadas(t *testing.T) {
+	r, _ := recorderDePrueba(t)
+	r.store = func(context.Context, []*models.AuditLog) error { return errors.New("db caida") }
+	antes := testutil.ToFloat64(auditDroppedEntriesTotal)
+
+	r.write(context.Background(), []*models.AuditLog{{}, {}})
+
+	assert.Equal(t, antes+2, testutil.ToFloat64(auditDroppedEntriesTotal))
+}
+
+func routerDePrueba(actor Actor, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	v1 := r.Group("/api/v1")
+	v1.Use(Middleware(func(*gin.Context) Actor { return actor }))
+	v1.Any("/orders/:id", handler)
+	return r
+}
+
+func TestMiddleware_RegistraRequestMutanteConEntradasDeDominio(t *testing.T) {
+	r, escritas := recorderDePrueba(t)
+	actor := Actor{Type: ActorUser, UserID: uintPtr(5), Label: "ana@x.co", BusinessID: uintPtr(7)}
+	var bodyLeido string
+	var escritasEnElHandler int
+	router := routerDePrueba(actor, func(c *gin.Context) {
+		raw, _ := io.ReadAll(c.Request.Body)
+		bodyLeido = string(raw)
+		_ = Record(c.Request.Context(), Entry{Resource: "order", ResourceID: "o-1", Action: "update",
+			Before: map[string]any{"status": "pending"}, After: map[string]any{"status": "shipped"}})
+		_ = Record(c, Entry{Resource: "order", ResourceID: "o-1", Action: "note"})
+		escritasEnElHandler = len(*escritas)
+		c.Status(http.StatusOK)
+	})
+
+	body := `{"status":"shipped","token":"abc"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/orders/o-1", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "test")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, body, bodyLeido, "el handler recibe el body completo")
+	assert.Equal(t, 2, escritasEnElHandler, "las entradas de dominio se escriben durante el request")
+	rows := encoladas(r)
+	require.Len(t, rows, 1, "solo la fila del middleware va a la cola")
+
+	fila := rows[0]
+	assert.Equal(t, SourceHTTP, fila.Source)
+	assert.Equal(t, "orders", fila.Resource)
+	assert.Equal(t, "o-1", fila.ResourceID)
+	assert.Equal(t, "update", fila.Action)
+	assert.Equal(t, 200, fila.StatusCode)
+	assert.Equal(t, "/api/v1/orders/o-1", fila.Path)
+	assert.JSONEq(t, `{"status":"shipped","token":"[REDACTED]"}`, string(fila.RequestBody))
+
+	require.Len(t, *escritas, 2)
+	for _, row := range *escritas {
+		assert.Equal(t, SourceDomain, row.Source)
+		assert.Equal(t, uint(5), *row.ActorUserID, "la entrada de dominio toma el actor del request")
+		assert.Equal(t, uint(7), row.ChainID)
+		assert.Equal(t, "test", row.UserAgent)
+	}
+}
+
+func TestMiddleware_IgnoraLecturasYAnonimos(t *testing.T) {
+	r, _ := recorderDePrueba(t)
+
+	anonimo := routerDePrueba(Actor{}, func(c *gin.Context) { c.Status(http.StatusOK) })
+	anonimo.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/v1/orders/1", nil))
+
+	lector := routerDePrueba(Actor{Type: ActorUser, UserID: uintPtr(1)}, func(c *gin.Context) { c.Status(http.StatusOK) })
+	lector.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/orders/1", nil))
+
+	assert.Empty(t, encoladas(r))
+}
+
+func TestMiddleware_SuperAdminVaALaCadenaDePlataforma(t *testing.T) {
+	r, _ := recorderDePrueba(t)
+	router := routerDePrueba(Actor{Type: ActorUser, UserID: uintPtr(1), BusinessID: uintPtr(0)}, func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/api/v1/orders/1", nil))
+
+	rows := encoladas(r)
+	require.Len(t, rows, 1)
+	assert.Nil(t, rows[0].BusinessID)
+	assert.Equal(t, uint(0), rows[0].ChainID)
+	assert.Equal(t, "delete", rows[0].Action)
+}
+
+func TestAppend_EncadenaDesdeLaUltimaFila(t *testing.T) {
+	database := testkit.NewDB(t)
+	r := newRecorder(database, testkit.NewSilentLogger(), 0)
+
+	primera := r.newRow(SourceDomain, Actor{Type: ActorSystem}, uintPtr(4))
+	primera.Resource, primera.Action = "wallet", "admin_adjust"
+	segunda := r.newRow(SourceDomain, Actor{Type: ActorSystem}, uintPtr(4))
+	segunda.Resource, segunda.Action = "wallet", "admin_adjust"
+
+	database.Mock.ExpectBegin()
+	database.Mock.ExpectExec(`pg_advisory_xact_lock`).WillReturnResult(sqlmock.NewResult(0, 1))
+	database.Mock.ExpectQuery(`SELECT "chain_seq","hash" FROM "audit_logs"`).
+		WillReturnRows(sqlmock.NewRows([]string{"chain_seq", "hash"}).AddRow(41, "h41"))
+	database.Mock.ExpectQuery(`INSERT INTO "audit_logs"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	database.Mock.ExpectCommit()
+
+	require.NoError(t, r.append(context.Background(), []*models.AuditLog{primera, segunda}))
+
+	database.SinPendientes(t)
+	assert.Equal(t, uint64(42), primera.ChainSeq)
+	assert.Equal(t, "h41", primera.PrevHash)
+	assert.Equal(t, uint64(43), segunda.ChainSeq)
+	assert.Equal(t, primera.Hash, segunda.PrevHash)
+	assert.Equal(t, Hash(FieldsOf(segunda)), segunda.Hash)
+}
+
+func TestAppend_CadenaNuevaArrancaEnGenesis(t *testing.T) {
+	database := testkit.NewDB(t)
+	r := newRecorder(database, testkit.NewSilentLogger(), 0)
+	row := r.newRow(SourceDomain, Actor{Type: ActorSystem}, nil)
+	row.Resource, row.Action = "roles", "permissions_assigned"
+
+	database.Mock.ExpectBegin()
+	database.Mock.ExpectExec(`pg_advisory_xact_lock`).WillReturnResult(sqlmock.NewResult(0, 1))
+	database.Mock.ExpectQuery(`FROM "audit_logs"`).WillReturnRows(sqlmock.NewRows([]string{"chain_seq", "hash"}))
+	database.Mock.ExpectQuery(`FROM "audit_log_checkpoints"`).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	database.Mock.ExpectQuery(`INSERT INTO "audit_logs"`).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	database.Mock.ExpectCommit()
+
+	require.NoError(t, r.append(context.Background(), []*models.AuditLog{row}))
+
+	database.SinPendientes(t)
+	assert.Equal(t, uint64(1), row.ChainSeq)
+	assert.Equal(t, GenesisHash, row.PrevHash)
+}
@@ -0,0 +1,134 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Redacted reemplaza el valor de los campos sensibles
+const Redacted = "[REDACTED]"
+
+// sensitiveKeys marca un campo como sensible si su nombre contiene alguna de
+// estas palabras
+var sensitiveKeys = []string{
+	"password", "secret", "token", "apikey", "api_key", "private_key",
+	"credential", "authorization", "otp", "cvv", "card_number",
+}
+
+// Change es el antes y el despues de un campo
+type Change struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// Diff compara before y after (structs, mapas o valores sueltos, via su JSON)
+// y devuelve los campos que cambiaron con su ruta punteada ("address.city").
+// Los campos sensibles aparecen en el diff pero con el valor redactado.
+func Diff(before, after any) map[string]Change {
+	changes := map[string]Change{}
+	walk("", toTree(before), toTree(after), changes)
+	return changes
+}
+
+func toTree(v any) map[string]any {
+	if v == nil {
+		return nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var tree any
+	if err := json.Unmarshal(raw, &tree); err != nil || tree == nil {
+		return nil
+	}
+	if m, ok := tree.(map[string]any); ok {
+		return m
+	}
+	return map[string]any{"value": tree}
+}
+
+func walk(prefix string, before, after map[string]any, changes map[string]Change) {
+	keys := make(map[string]struct{}, len(before)+len(after))
+	for k := range before {
+		keys[k] = struct{}{}
+	}
+	for k := range after {
+		keys[k] = struct{}{}
+	}
+
+	for k := range keys {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+		b, a := before[k], after[k]
+
+		bm, bIsMap := b.(map[string]any)
+		am, aIsMap := a.(map[string]any)
+		if (bIsMap || b == nil) && (aIsMap || a == nil) && (bIsMap || aIsMap) {
+			walk(path, bm, am, changes)
+			continue
+		}
+		if reflect.DeepEqual(b, a) {
+			continue
+		}
+		if isSensitive(path) {
+			b, a = redactValue(b), redactValue(a)
+		}
+		changes[path] = Change{Before: b, After: a}
+	}
+}
+
+func redactValue(v any) any {
+	if v == nil {
+		return nil
+	}
+	return Redacted
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact devuelve una copia de un JSON con los campos sensibles redactados,
+// a cualquier profundidad.
+func Redact(raw []byte) (json.RawMessage, bool) {
+	var tree any
+	if err := json.Unmarshal(raw, &tree); err != nil {
+		return nil, false
+	}
+	out, err := json.Marshal(redactTree(tree))
+	if err != nil {
+		return nil, false
+	}
+	return out, true
+}
+
+func redactTree(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			if isSensitive(k) && val != nil {
+				t[k] = Redacted
+				continue
+			}
+			t[k] = redactTree(val)
+		}
+		return t
+	case []any:
+		for i := range t {
+			t[i] = redactTree(t[i])
+		}
+		return t
+	default:
+		return v
+	}
+}
@@ -0,0 +1,100 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+// GenesisHash es el PrevHash de la primera entrada de cada cadena
+const GenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// Fields son los datos de una entrada que cubre el hash. El modulo de
+// consulta los reconstruye desde la base para verificar la cadena.
+type Fields struct {
+	ChainID       uint            `json:"chain_id"`
+	ChainSeq      uint64          `json:"chain_seq"`
+	BusinessID    *uint           `json:"business_id"`
+	ActorType     string          `json:"actor_type"`
+	ActorUserID   *uint           `json:"actor_user_id"`
+	ActorLabel    string          `json:"actor_label"`
+	APIKeyID      *uint           `json:"api_key_id"`
+	Source        string          `json:"source"`
+	Method        string          `json:"method"`
+	Path          string          `json:"path"`
+	StatusCode    int             `json:"status_code"`
+	Resource      string          `json:"resource"`
+	ResourceID    string          `json:"resource_id"`
+	Action        string          `json:"action"`
+	Changes       json.RawMessage `json:"changes"`
+	RequestBody   json.RawMessage `json:"request_body"`
+	Metadata      json.RawMessage `json:"metadata"`
+	IP            string          `json:"ip"`
+	UserAgent     string          `json:"user_agent"`
+	CorrelationID string          `json:"correlation_id"`
+	OccurredAt    time.Time       `json:"-"`
+	PrevHash      string          `json:"prev_hash"`
+}
+
+// Hash es el SHA-256 (hex) del JSON canonico de la entrada. Los JSON se
+// normalizan (jsonb no conserva espacios ni orden de llaves) y la fecha va en
+// UTC con precision de microsegundos, la misma que guarda Postgres.
+func Hash(f Fields) string {
+	canonical := struct {
+		Fields
+		OccurredAt string `json:"occurred_at"`
+	}{Fields: f, OccurredAt: f.OccurredAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano)}
+	canonical.Changes = canonicalJSON(f.Changes)
+	canonical.RequestBody = canonicalJSON(f.RequestBody)
+	canonical.Metadata = canonicalJSON(f.Metadata)
+
+	raw, _ := json.Marshal(canonical)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+func canonicalJSON(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 {
+		return nil
+	}
+	var v any
+	if err := json.Unmarshal(raw, &v); err != nil || v == nil {
+		return nil
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return out
+}
+
+// FieldsOf extrae del modelo los datos que cubre el hash
+func FieldsOf(row *models.AuditLog) Fields {
+	return Fields{
+		ChainID:       row.ChainID,
+		ChainSeq:      row.ChainSeq,
+		BusinessID:    row.BusinessID,
+		ActorType:     row.ActorType,
+		ActorUserID:   row.ActorUserID,
+		ActorLabel:    row.ActorLabel,
+		APIKeyID:      row.APIKeyID,
+		Source:        row.Source,
+		Method:        row.Method,
+		Path:          row.Path,
+		StatusCode:    row.StatusCode,
+		Resource:      row.Resource,
+		ResourceID:    row.ResourceID,
+		Action:        row.Action,
+		Changes:       json.RawMessage(row.Changes),
+		RequestBody:   json.RawMessage(row.RequestBody),
+		Metadata:      json.RawMessage(row.Metadata),
+		IP:            row.IP,
+		UserAgent:     row.UserAgent,
+		CorrelationID: row.CorrelationID,
+		OccurredAt:    row.OccurredAt,
+		PrevHash:      row.PrevHash,
+	}
+}
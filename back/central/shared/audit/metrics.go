@@ -0,0 +1,11 @@
+package audit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var auditDroppedEntriesTotal = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "audit_dropped_entries_total",
+	Help: "Entradas de auditoria de requests descartadas tras agotar los reintentos de escritura",
+})
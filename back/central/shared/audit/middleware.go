@@ -0,0 +1,144 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"gorm.io/datatypes"
+)
+
+// maxBody es el tope del body que se guarda; los mas grandes quedan marcados
+// como truncados.
+const maxBody = 64 << 10
+
+// ActorFunc resuelve el actor de un request ya autenticado
+type ActorFunc func(c *gin.Context) Actor
+
+// Middleware registra los requests mutantes de actores autenticados y da a
+// los handlers el scope de donde Record toma los datos del request. Va en el
+// grupo /api/v1 antes de registrar las rutas; el actor se resuelve despues,
+// cuando el middleware de autenticacion de la ruta ya corrio. Su fila va a la
+// cola del recorder: es la unica que se escribe en segundo plano.
+func Middleware(actorOf ActorFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		r := current()
+		if r == nil {
+			c.Next()
+			return
+		}
+
+		s := &scope{c: c, actorOf: actorOf}
+		c.Set(scopeGinKey, s)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), scopeKey{}, s))
+
+		mutating := isMutating(c.Request.Method)
+		var body datatypes.JSON
+		if mutating {
+			body = captureBody(c)
+		}
+
+		c.Next()
+
+		s.close()
+		actor := actorOf(c)
+		if !mutating || !actor.authenticated() {
+			return
+		}
+
+		req := newRequestInfo(c, actor)
+		req.status = c.Writer.Status()
+		row := r.newRow(SourceHTTP, actor, actor.BusinessID)
+		req.apply(row)
+		row.Resource, row.ResourceID = resourceOf(c)
+		row.Action = actionOf(c.Request.Method)
+		row.RequestBody = body
+		r.enqueue(row)
+	}
+}
+
+// newRequestInfo arma los datos del request sin el status, que solo se conoce
+// al terminar: las entradas de dominio se escriben antes.
+func newRequestInfo(c *gin.Context, actor Actor) *requestInfo {
+	req := &requestInfo{
+		actor:     actor,
+		method:    c.Request.Method,
+		path:      c.Request.URL.Path,
+		ip:        c.ClientIP(),
+		userAgent: c.Request.UserAgent(),
+	}
+	if id, ok := log.CorrelationIDFromCtx(c.Request.Context()); ok {
+		req.correlationID = id
+	}
+	return req
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+func actionOf(method string) string {
+	switch method {
+	case http.MethodPost:
+		return "create"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return "update"
+	}
+}
+
+// resourceOf toma el recurso del primer segmento de la ruta registrada
+// (/api/v1/orders/:id -> orders) y el id del parametro :id o el primero.
+func resourceOf(c *gin.Context) (string, string) {
+	path := c.FullPath()
+	if path == "" {
+		path = c.Request.URL.Path
+	}
+	path = strings.TrimPrefix(path, "/api/v1")
+	resource := strings.Trim(path, "/")
+	if i := strings.Index(resource, "/"); i >= 0 {
+		resource = resource[:i]
+	}
+	if resource == "" {
+		resource = "root"
+	}
+
+	id := c.Param("id")
+	if id == "" && len(c.Params) > 0 {
+		id = c.Params[0].Value
+	}
+	return clip(resource, 80), clip(id, 100)
+}
+
+// captureBody lee el body JSON (redactado) y lo deja intacto para el handler
+func captureBody(c *gin.Context) datatypes.JSON {
+	if c.Request.Body == nil || !strings.Contains(c.ContentType(), "json") {
+		return nil
+	}
+	raw, err := io.ReadAll(io.LimitReader(c.Request.Body, maxBody+1))
+	rest := c.Request.Body
+	c.Request.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(raw), rest), rest}
+	if err != nil || len(raw) == 0 {
+		return nil
+	}
+	if len(raw) > maxBody {
+		return datatypes.JSON(`{"_truncated":true}`)
+	}
+	redacted, ok := Redact(raw)
+	if !ok {
+		return nil
+	}
+	return datatypes.JSON(redacted)
+}
@@ -0,0 +1,327 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"github.com/secamc93/probability/back/central/shared/db"
+	"github.com/secamc93/probability/back/central/shared/env"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+const (
+	queueSize     = 1024
+	batchSize     = 100
+	writeAttempts = 3
+
+	defaultRetentionDays = 365
+
+	// lockNamespace separa los advisory locks de la bitacora de los demas
+	lockNamespace = 7301
+)
+
+// Recorder escribe las entradas en audit_logs. Hay uno solo por proceso,
+// creado por Start. Las de dominio se escriben en linea; la cola es solo para
+// las filas del middleware.
+type Recorder struct {
+	db        db.IDatabase
+	logger    log.ILogger
+	queue     chan *models.AuditLog
+	retention time.Duration
+	now       func() time.Time
+	// store inserta las filas; los tests lo reemplazan
+	store func(ctx context.Context, rows []*models.AuditLog) error
+}
+
+var active atomic.Pointer[Recorder]
+
+func current() *Recorder {
+	return active.Load()
+}
+
+// Start crea el recorder global y arranca el escritor y la purga por
+// retencion (AUDIT_RETENTION_DAYS, 365 por defecto, 0 no purga).
+func Start(ctx context.Context, database db.IDatabase, logger log.ILogger, cfg env.IConfig) *Recorder {
+	r := newRecorder(database, logger, retentionFromEnv(cfg))
+	active.Store(r)
+
+	go r.writeLoop(ctx)
+	if r.retention > 0 {
+		go r.retentionLoop(ctx)
+	}
+	return r
+}
+
+func newRecorder(database db.IDatabase, logger log.ILogger, retention time.Duration) *Recorder {
+	r := &Recorder{
+		db:        database,
+		logger:    logger.WithModule("audit"),
+		queue:     make(chan *models.AuditLog, queueSize),
+		retention: retention,
+		now:       time.Now,
+	}
+	r.store = r.append
+	return r
+}
+
+func retentionFromEnv(cfg env.IConfig) time.Duration {
+	days := defaultRetentionDays
+	if raw := cfg.Get("AUDIT_RETENTION_DAYS"); raw != "" {
+		if n, err := strconv.Atoi(raw); err == nil && n >= 0 {
+			days = n
+		}
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// enqueue nunca descarta: con la cola llena escribe en linea. Lo que siga en
+// cola si el proceso muere sin apagar se pierde; por eso solo pasan por aca
+// las filas del middleware, que repiten lo que el request ya dejo en el log.
+func (r *Recorder) enqueue(rows ...*models.AuditLog) {
+	for _, row := range rows {
+		select {
+		case r.queue <- row:
+		default:
+			r.write(context.Background(), []*models.AuditLog{row})
+		}
+	}
+}
+
+func (r *Recorder) writeLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			r.drain()
+			return
+		case row := <-r.queue:
+			batch := []*models.AuditLog{row}
+		fill:
+			for len(batch) < batchSize {
+				select {
+				case next := <-r.queue:
+					batch = append(batch, next)
+				default:
+					break fill
+				}
+			}
+			r.write(ctx, batch)
+		}
+	}
+}
+
+// drain escribe lo que quede en cola al apagar
+func (r *Recorder) drain() {
+	var batch []*models.AuditLog
+	for {
+		select {
+		case row := <-r.queue:
+			batch = append(batch, row)
+		default:
+			if len(batch) > 0 {
+				r.write(context.Background(), batch)
+			}
+			return
+		}
+	}
+}
+
+func (r *Recorder) write(ctx context.Context, batch []*models.AuditLog) {
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+	var err error
+	for attempt := 1; attempt <= writeAttempts; attempt++ {
+		if err = r.store(ctx, batch); err == nil {
+			return
+		}
+		if attempt < writeAttempts {
+			time.Sleep(time.Duration(attempt) * 500 * time.Millisecond)
+		}
+	}
+	auditDroppedEntriesTotal.Add(float64(len(batch)))
+	r.logger.Error(ctx).Err(err).Int("entries", len(batch)).Msg("No se pudieron escribir entradas de auditoria")
+}
+
+// append inserta el lote encadenando cada fila con la anterior de su cadena.
+// El advisory lock por cadena serializa a las instancias que escriben a la
+// vez; se toman en orden para no caer en deadlock.
+func (r *Recorder) append(ctx context.Context, batch []*models.AuditLog) error {
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		heads := map[uint]chainHead{}
+		for _, row := range batch {
+			heads[row.ChainID] = chainHead{}
+		}
+		chains := make([]uint, 0, len(heads))
+		for id := range heads {
+			chains = append(chains, id)
+		}
+		sort.Slice(chains, func(i, j int) bool { return chains[i] < chains[j] })
+
+		for _, id := range chains {
+			if err := lockChain(tx, id); err != nil {
+				return err
+			}
+			head, err := loadHead(tx, id)
+			if err != nil {
+				return err
+			}
+			heads[id] = head
+		}
+
+		for _, row := range batch {
+			head := heads[row.ChainID]
+			row.ChainSeq = head.seq + 1
+			row.PrevHash = head.hash
+			row.Hash = Hash(FieldsOf(row))
+			heads[row.ChainID] = chainHead{seq: row.ChainSeq, hash: row.Hash}
+		}
+
+		if err := tx.Create(&batch).Error; err != nil {
+			return fmt.Errorf("failed to insert audit logs: %w", err)
+		}
+		return nil
+	})
+}
+
+type chainHead struct {
+	seq  uint64
+	hash string
+}
+
+func lockChain(tx *gorm.DB, chainID uint) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(?::int, ?::int)", lockNamespace, int64(chainID)).Error; err != nil {
+		return fmt.Errorf("failed to lock audit chain %d: %w", chainID, err)
+	}
+	return nil
+}
+
+// loadHead devuelve el ultimo eslabon de la cadena: la ultima fila, o el
+// ultimo checkpoint si la purga se llevo todas, o el genesis.
+func loadHead(tx *gorm.DB, chainID uint) (chainHead, error) {
+	var last models.AuditLog
+	err := tx.Select("chain_seq", "hash").
+		Where("chain_id = ?", chainID).
+		Order("chain_seq DESC").
+		Limit(1).
+		Take(&last).Error
+	if err == nil {
+		return chainHead{seq: last.ChainSeq, hash: last.Hash}, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return chainHead{}, fmt.Errorf("failed to read audit chain head: %w", err)
+	}
+
+	var cp models.AuditLogCheckpoint
+	err = tx.Where("chain_id = ?", chainID).Order("up_to_seq DESC").Limit(1).Take(&cp).Error
+	if err == nil {
+		return chainHead{seq: cp.UpToSeq, hash: cp.Hash}, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return chainHead{}, fmt.Errorf("failed to read audit checkpoint: %w", err)
+	}
+	return chainHead{hash: GenesisHash}, nil
+}
+
+// requestInfo son los datos del request que acompanan a sus entradas
+type requestInfo struct {
+	actor         Actor
+	method        string
+	path          string
+	status        int
+	ip            string
+	userAgent     string
+	correlationID string
+}
+
+func (r *Recorder) newRow(source string, actor Actor, businessID *uint) *models.AuditLog {
+	// El negocio 0 es la plataforma (super admin): va sin negocio, cadena 0
+	if businessID != nil && *businessID == 0 {
+		businessID = nil
+	}
+	row := &models.AuditLog{
+		BusinessID:  businessID,
+		ActorType:   actor.Type,
+		ActorUserID: actor.UserID,
+		ActorLabel:  clip(actor.Label, 255),
+		APIKeyID:    actor.APIKeyID,
+		Source:      source,
+		OccurredAt:  r.now().UTC().Truncate(time.Microsecond),
+	}
+	if businessID != nil {
+		row.ChainID = *businessID
+	}
+	return row
+}
+
+func (req *requestInfo) apply(row *models.AuditLog) {
+	row.Method = req.method
+	row.Path = clip(req.path, 255)
+	row.StatusCode = req.status
+	row.IP = clip(req.ip, 64)
+	row.UserAgent = clip(req.userAgent, 255)
+	row.CorrelationID = clip(req.correlationID, 128)
+}
+
+// domainRow arma la fila de una Entry. Con req es parte de un request; sin
+// el, el actor es el sistema o el usuario indicado en la entrada.
+func (r *Recorder) domainRow(ctx context.Context, entry Entry, req *requestInfo) *models.AuditLog {
+	actor := Actor{Type: ActorSystem}
+	if req != nil && req.actor.authenticated() {
+		actor = req.actor
+	} else if entry.ActorUserID != nil {
+		actor = Actor{Type: ActorUser, UserID: entry.ActorUserID}
+	}
+
+	businessID := entry.BusinessID
+	if businessID == nil {
+		businessID = actor.BusinessID
+	}
+
+	row := r.newRow(SourceDomain, actor, businessID)
+	if req != nil {
+		req.apply(row)
+	} else if id, ok := log.CorrelationIDFromCtx(ctx); ok {
+		row.CorrelationID = clip(id, 128)
+	}
+	row.Resource = clip(entry.Resource, 80)
+	row.ResourceID = clip(entry.ResourceID, 100)
+	row.Action = clip(entry.Action, 80)
+
+	if entry.Before != nil || entry.After != nil {
+		if diff := Diff(entry.Before, entry.After); len(diff) > 0 {
+			row.Changes = toJSON(diff)
+		}
+	}
+	if len(entry.Metadata) > 0 {
+		if raw, ok := Redact(toJSON(entry.Metadata)); ok {
+			row.Metadata = datatypes.JSON(raw)
+		}
+	}
+	return row
+}
+
+func toJSON(v any) datatypes.JSON {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	return datatypes.JSON(raw)
+}
+
+// clip recorta a n caracteres (varchar cuenta caracteres, no bytes)
+func clip(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
@@ -0,0 +1,102 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+)
+
+const (
+	retentionInterval = 24 * time.Hour
+	// retentionDelay deja arrancar el servicio antes de la primera purga
+	retentionDelay = 5 * time.Minute
+)
+
+func (r *Recorder) retentionLoop(ctx context.Context) {
+	timer := time.NewTimer(retentionDelay)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		purged, err := r.Purge(ctx, r.now().Add(-r.retention))
+		if err != nil {
+			r.logger.Error(ctx).Err(err).Msg("Error purgando la bitacora de auditoria")
+		} else if purged > 0 {
+			r.logger.Info(ctx).Int64("purged", purged).Msg("Bitacora de auditoria purgada por retencion")
+		}
+		timer.Reset(retentionInterval)
+	}
+}
+
+// Purge borra las entradas anteriores a cutoff. Por cada cadena deja un
+// checkpoint con el ultimo eslabon borrado, para que la cadena siga siendo
+// verificable desde la primera fila que queda.
+func (r *Recorder) Purge(ctx context.Context, cutoff time.Time) (int64, error) {
+	var chains []uint
+	if err := r.db.Conn(ctx).
+		Model(&models.AuditLog{}).
+		Where("occurred_at < ?", cutoff).
+		Distinct("chain_id").
+		Pluck("chain_id", &chains).Error; err != nil {
+		return 0, fmt.Errorf("failed to list audit chains to purge: %w", err)
+	}
+
+	var total int64
+	for _, chainID := range chains {
+		purged, err := r.purgeChain(ctx, chainID, cutoff)
+		if err != nil {
+			return total, err
+		}
+		total += purged
+	}
+	return total, nil
+}
+
+func (r *Recorder) purgeChain(ctx context.Context, chainID uint, cutoff time.Time) (int64, error) {
+	var purged int64
+	err := r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		// El trigger de audit_logs solo deja borrar con esta variable activa
+		if err := tx.Exec("SET LOCAL audit.retention = 'on'").Error; err != nil {
+			return fmt.Errorf("failed to enable audit retention: %w", err)
+		}
+		if err := lockChain(tx, chainID); err != nil {
+			return err
+		}
+
+		var last models.AuditLog
+		err := tx.Select("chain_seq", "hash").
+			Where("chain_id = ? AND occurred_at < ?", chainID, cutoff).
+			Order("chain_seq DESC").
+			Limit(1).
+			Take(&last).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find last purgeable audit log: %w", err)
+		}
+
+		res := tx.Where("chain_id = ? AND chain_seq <= ?", chainID, last.ChainSeq).Delete(&models.AuditLog{})
+		if res.Error != nil {
+			return fmt.Errorf("failed to purge audit logs: %w", res.Error)
+		}
+		purged = res.RowsAffected
+
+		return tx.Create(&models.AuditLogCheckpoint{
+			ChainID: chainID,
+			UpToSeq: last.ChainSeq,
+			Hash:    last.Hash,
+			Purged:  purged,
+		}).Error
+	})
+	return purged, err
+}
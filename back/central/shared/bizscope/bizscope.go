@@ -0,0 +1,66 @@
+// Package bizscope resuelve sobre que negocio opera un usuario en las
+// pantallas de administracion (claves API, bitacora, SSO): el super admin
+// elige el negocio, un administrador solo opera sobre el suyo y el resto de
+// roles no entra. Cada modulo conserva sus propios errores de dominio.
+package bizscope
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// AdminRoleLevel es el nivel de rol mas bajo (1=super, 2=admin) que
+// administra su negocio
+const AdminRoleLevel = 2
+
+// ErrRoleNotFound lo devuelve un RoleLevelFunc cuando el rol no existe; el
+// solicitante se trata como sin permiso
+var ErrRoleNotFound = errors.New("role not found")
+
+// Actor es quien hace la peticion, tal como viene del token
+type Actor struct {
+	BusinessID   uint
+	RoleID       uint
+	IsSuperAdmin bool
+}
+
+// RoleLevelFunc devuelve el nivel jerarquico del rol
+type RoleLevelFunc func(ctx context.Context, roleID uint) (int, error)
+
+// Policy define que error devuelve cada modulo cuando se niega el acceso
+type Policy struct {
+	// AllowAllBusinesses deja al super admin pedir el negocio 0 (todos)
+	AllowAllBusinesses bool
+	// ErrForbidden se devuelve si el solicitante no es administrador
+	ErrForbidden error
+	// ErrBusinessRequired se devuelve si el super admin no indica negocio
+	// y AllowAllBusinesses es false
+	ErrBusinessRequired error
+}
+
+// Resolve verifica que el actor pueda operar y devuelve el negocio: el que
+// indique el super admin, o el del token si el actor es administrador
+func (p Policy) Resolve(ctx context.Context, roleLevel RoleLevelFunc, actor Actor, businessID uint) (uint, error) {
+	if actor.IsSuperAdmin {
+		if businessID == 0 && !p.AllowAllBusinesses {
+			return 0, p.ErrBusinessRequired
+		}
+		return businessID, nil
+	}
+
+	if actor.BusinessID == 0 || actor.RoleID == 0 {
+		return 0, p.ErrForbidden
+	}
+	level, err := roleLevel(ctx, actor.RoleID)
+	if errors.Is(err, ErrRoleNotFound) {
+		return 0, p.ErrForbidden
+	}
+	if err != nil {
+		return 0, fmt.Errorf("consultar rol %d: %w", actor.RoleID, err)
+	}
+	if level > AdminRoleLevel {
+		return 0, p.ErrForbidden
+	}
+	return actor.BusinessID, nil
+}
@@ -0,0 +1,72 @@
+package bizscope
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errProhibido  = errors.New("prohibido")
+	errSinNegocio = errors.New("sin negocio")
+)
+
+func niveles(ctx context.Context, roleID uint) (int, error) {
+	switch roleID {
+	case 2:
+		return 2, nil
+	case 3:
+		return 3, nil
+	}
+	return 0, ErrRoleNotFound
+}
+
+func TestResolve_SuperAdminEligeNegocio(t *testing.T) {
+	p := Policy{ErrForbidden: errProhibido, ErrBusinessRequired: errSinNegocio}
+
+	id, err := p.Resolve(context.Background(), niveles, Actor{IsSuperAdmin: true}, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(7), id)
+
+	_, err = p.Resolve(context.Background(), niveles, Actor{IsSuperAdmin: true}, 0)
+	assert.ErrorIs(t, err, errSinNegocio)
+
+	todos := Policy{AllowAllBusinesses: true, ErrForbidden: errProhibido}
+	id, err = todos.Resolve(context.Background(), niveles, Actor{IsSuperAdmin: true}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(0), id)
+}
+
+func TestResolve_AdminQuedaEnSuNegocio(t *testing.T) {
+	p := Policy{ErrForbidden: errProhibido}
+
+	id, err := p.Resolve(context.Background(), niveles, Actor{BusinessID: 10, RoleID: 2}, 99)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint(10), id, "el negocio pedido se ignora para un admin")
+}
+
+func TestResolve_SinPermiso(t *testing.T) {
+	p := Policy{ErrForbidden: errProhibido}
+	casos := map[string]Actor{
+		"rol de menor jerarquia": {BusinessID: 10, RoleID: 3},
+		"rol inexistente":        {BusinessID: 10, RoleID: 9},
+		"sin rol":                {BusinessID: 10},
+		"sin negocio":            {RoleID: 2},
+	}
+	for nombre, actor := range casos {
+		_, err := p.Resolve(context.Background(), niveles, actor, 10)
+		assert.ErrorIs(t, err, errProhibido, nombre)
+	}
+}
+
+func TestResolve_ErrorDelRolSePropaga(t *testing.T) {
+	falla := errors.New("db caida")
+	p := Policy{ErrForbidden: errProhibido}
+
+	_, err := p.Resolve(context.Background(), func(context.Context, uint) (int, error) { return 0, falla }, Actor{BusinessID: 10, RoleID: 2}, 0)
+
+	assert.ErrorIs(t, err, falla)
+}
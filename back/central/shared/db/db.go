@@ -7,6 +7,7 @@ import (
 
 	"github.com/secamc93/probability/back/central/shared/env"
 	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/tracing"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -80,6 +81,9 @@ func (d *database) Connect(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
+	if err := d.conn.Use(tracing.GormPlugin()); err != nil {
+		return fmt.Errorf("failed to register tracing plugin: %w", err)
+	}
 	d.conn = d.conn.Omit(clause.Associations).Session(&gorm.Session{
 		FullSaveAssociations: false,
 	})
@@ -113,8 +117,12 @@ func (d *database) Close() error {
 	return nil
 }
 
-// GetConnection retorna la conexión actual
+// GetConnection retorna la conexión actual, o la transacción abierta con
+// InTransaction si el contexto trae una
 func (d *database) Conn(ctx context.Context) *gorm.DB {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
 	return d.conn.WithContext(ctx)
 }
 
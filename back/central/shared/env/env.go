@@ -193,8 +193,22 @@ type Config struct {
 	ShopifyShopDomain   string `env:"SHOPIFY_SHOP_DOMAIN"`
 	ShopifyAPIVersion   string `env:"SHOPIFY_API_VERSION"`
 
+	// SSO (OIDC por negocio). Sin valor se usa FRONTEND_BASE_URL + /auth/sso/callback
+	SSORedirectURL string `env:"SSO_REDIRECT_URL"`
+	// Solo desarrollo: "true" permite issuers OIDC en localhost (IdP de pruebas)
+	SSOAllowLoopback string `env:"SSO_ALLOW_LOOPBACK"`
+
 	// Webhooks
 	WebhookBaseURL string `env:"WEBHOOK_BASE_URL"`
+	// Solo desarrollo: "true" permite webhooks salientes hacia localhost
+	WebhookAllowLoopback string `env:"WEBHOOK_ALLOW_LOOPBACK"`
+
+	// Proxies (IPs o CIDRs separados por coma) cuyo X-Forwarded-For se cree.
+	// Sin valor la IP del cliente es la de la conexion
+	TrustedProxies string `env:"TRUSTED_PROXIES"`
+
+	// Bitacora de auditoria: dias que se conservan las entradas (365 por defecto, 0 no purga)
+	AuditRetentionDays string `env:"AUDIT_RETENTION_DAYS"`
 
 	// Woo store (EC2 temporal on/off por super admin)
 	WooStoreAWSRegion  string `env:"WOO_STORE_AWS_REGION"`
@@ -217,6 +231,12 @@ type Config struct {
 	BedrockAccessKey string `env:"BEDROCK_ACCESS_KEY"`
 	BedrockSecretKey string `env:"BEDROCK_SECRET_KEY"`
 	BedrockRegion    string `env:"BEDROCK_REGION"`
+
+	// OpenTelemetry (sin endpoint las trazas salen por stdout)
+	OtelExporterEndpoint string `env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OtelTracesExporter   string `env:"OTEL_TRACES_EXPORTER"`
+	OtelServiceName      string `env:"OTEL_SERVICE_NAME"`
+	OtelSamplerArg       string `env:"OTEL_TRACES_SAMPLER_ARG"`
 }
 
 func splitTag(tag string) []string {
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/tracing"
 
 	"github.com/go-resty/resty/v2"
 )
@@ -43,6 +44,9 @@ func New(cfg HTTPClientConfig, logger log.ILogger) *Client {
 			return r.StatusCode() == 429
 		})
 
+	// Span por request saliente (ERPs, transportadoras) con traceparent
+	client.SetTransport(tracing.NewTransport(client.GetClient().Transport))
+
 	if cfg.BaseURL != "" {
 		client.SetBaseURL(cfg.BaseURL)
 	}
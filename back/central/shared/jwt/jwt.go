@@ -11,6 +11,8 @@ import (
 type IJWTService interface {
 	// Token unificado que incluye toda la información
 	GenerateToken(userID, businessID, businessTypeID, roleID uint, subscriptionStatus string) (string, error)
+	// GenerateSessionToken incluye el id de la sesion registrada en el login (claim "sid")
+	GenerateSessionToken(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error)
 	ValidateToken(tokenString string) (*JWTClaims, error)
 	RefreshToken(tokenString string) (string, error)
 
@@ -21,6 +23,9 @@ type IJWTService interface {
 	ValidateVotingAuthToken(tokenString string) (*VotingAuthClaims, error)
 }
 
+// TokenTTL es la vigencia del token unificado (7 días para coincidir con el login cookie)
+const TokenTTL = 168 * time.Hour
+
 // JWTService implementación concreta
 type JWTService struct {
 	secretKey string
@@ -33,6 +38,7 @@ type Claims struct {
 	BusinessTypeID     uint   `json:"business_type_id"`
 	RoleID             uint   `json:"role_id"`
 	SubscriptionStatus string `json:"subscription_status"`
+	SessionID          string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -43,6 +49,7 @@ type JWTClaims struct {
 	BusinessTypeID     uint
 	RoleID             uint
 	SubscriptionStatus string
+	SessionID          string // Vacío en tokens emitidos antes del registro de sesiones
 }
 
 // New crea una nueva instancia del servicio JWT (autocontenida)
@@ -54,14 +61,20 @@ func New(secretKey string) IJWTService {
 
 // GenerateToken genera un nuevo token JWT unificado con toda la información
 func (j *JWTService) GenerateToken(userID, businessID, businessTypeID, roleID uint, subscriptionStatus string) (string, error) {
+	return j.GenerateSessionToken(userID, businessID, businessTypeID, roleID, subscriptionStatus, "")
+}
+
+// GenerateSessionToken genera el token unificado atado a una sesión registrada
+func (j *JWTService) GenerateSessionToken(userID, businessID, businessTypeID, roleID uint, subscriptionStatus, sessionID string) (string, error) {
 	claims := Claims{
 		UserID:             userID,
 		BusinessID:         businessID,
 		BusinessTypeID:     businessTypeID,
 		RoleID:             roleID,
 		SubscriptionStatus: subscriptionStatus,
+		SessionID:          sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(168 * time.Hour)), // 7 días para coincidir con el login cookie
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(TokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			Issuer:    "central-reserve-api",
@@ -102,6 +115,7 @@ func (j *JWTService) ValidateToken(tokenString string) (*JWTClaims, error) {
 			BusinessTypeID:     claims.BusinessTypeID,
 			RoleID:             claims.RoleID,
 			SubscriptionStatus: claims.SubscriptionStatus,
+			SessionID:          claims.SessionID,
 		}, nil
 	}
 
@@ -115,5 +129,6 @@ func (j *JWTService) RefreshToken(tokenString string) (string, error) {
 		return "", err
 	}
 
-	return j.GenerateToken(claims.UserID, claims.BusinessID, claims.BusinessTypeID, claims.RoleID, claims.SubscriptionStatus)
+	// El token refrescado sigue atado a la misma sesión
+	return j.GenerateSessionToken(claims.UserID, claims.BusinessID, claims.BusinessTypeID, claims.RoleID, claims.SubscriptionStatus, claims.SessionID)
 }
@@ -0,0 +1,33 @@
+package log
+
+import "context"
+
+// El correlation ID identifica un flujo completo (una orden de Shopify desde el
+// webhook hasta el ultimo consumer); el causation ID es el mensaje o request
+// que provoco directamente el trabajo actual.
+
+type correlationIDKey struct{}
+
+var correlationID correlationIDKey
+
+type causationIDKey struct{}
+
+var causationID causationIDKey
+
+func CorrelationIDFromCtx(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(correlationID).(string)
+	return id, ok && id != ""
+}
+
+func CausationIDFromCtx(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(causationID).(string)
+	return id, ok && id != ""
+}
+
+func WithCorrelationIDCtx(ctx context.Context, value string) context.Context {
+	return context.WithValue(ctx, correlationID, value)
+}
+
+func WithCausationIDCtx(ctx context.Context, value string) context.Context {
+	return context.WithValue(ctx, causationID, value)
+}
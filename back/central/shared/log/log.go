@@ -12,6 +12,7 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 	lumberjack "gopkg.in/natefinch/lumberjack.v2"
 )
 
@@ -254,6 +255,15 @@ func (l *logger) addContextualFields(event *zerolog.Event, ctx context.Context)
 		if function, ok := FunctionFromCtx(ctx); ok {
 			event = event.Str("function", function)
 		}
+		if correlationID, ok := CorrelationIDFromCtx(ctx); ok {
+			event = event.Str("correlation_id", correlationID)
+		}
+		if causationID, ok := CausationIDFromCtx(ctx); ok {
+			event = event.Str("causation_id", causationID)
+		}
+		if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+			event = event.Str("trace_id", span.TraceID().String()).Str("span_id", span.SpanID().String())
+		}
 	}
 
 	return event
@@ -488,32 +498,32 @@ func FunctionFromCtx(ctx context.Context) (string, bool) {
 	return function, ok
 }
 
-func WithServiceCtx(ctx context.Context, service string) context.Context {
-	return context.WithValue(ctx, service, service)
+func WithServiceCtx(ctx context.Context, value string) context.Context {
+	return context.WithValue(ctx, service, value)
 }
 
-func WithModuleCtx(ctx context.Context, module string) context.Context {
-	return context.WithValue(ctx, module, module)
+func WithModuleCtx(ctx context.Context, value string) context.Context {
+	return context.WithValue(ctx, module, value)
 }
 
-func WithBusinessIDCtx(ctx context.Context, businessID uint) context.Context {
-	return context.WithValue(ctx, businessID, businessID)
+func WithBusinessIDCtx(ctx context.Context, value uint) context.Context {
+	return context.WithValue(ctx, businessID, value)
 }
 
-func WithUserIDCtx(ctx context.Context, userID uint) context.Context {
-	return context.WithValue(ctx, userID, userID)
+func WithUserIDCtx(ctx context.Context, value uint) context.Context {
+	return context.WithValue(ctx, userID, value)
 }
 
-func WithDurationCtx(ctx context.Context, duration string) context.Context {
-	return context.WithValue(ctx, duration, duration)
+func WithDurationCtx(ctx context.Context, value string) context.Context {
+	return context.WithValue(ctx, duration, value)
 }
 
-func WithStatusCodeCtx(ctx context.Context, statusCode int) context.Context {
-	return context.WithValue(ctx, statusCode, statusCode)
+func WithStatusCodeCtx(ctx context.Context, value int) context.Context {
+	return context.WithValue(ctx, statusCode, value)
 }
 
-func WithFunctionCtx(ctx context.Context, function string) context.Context {
-	return context.WithValue(ctx, function, function)
+func WithFunctionCtx(ctx context.Context, value string) context.Context {
+	return context.WithValue(ctx, function, value)
 }
 
 type skipFunctionKey struct{}
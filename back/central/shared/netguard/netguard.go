@@ -0,0 +1,137 @@
+// Package netguard evita que una URL que controla un tenant (webhooks
+// salientes, callbacks) haga que el servidor llame a la red interna: IPs
+// privadas, loopback, link-local (metadata de la nube en 169.254.169.254),
+// ULA o sin especificar. El chequeo se hace al registrar la URL y otra vez
+// al conectar, sobre la IP ya resuelta, para que un DNS que cambia de
+// respuesta (rebinding) no lo salte.
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrBlockedAddress indica que el destino resuelve a una direccion interna
+var ErrBlockedAddress = errors.New("destination resolves to a private or internal address")
+
+// blockedNets son rangos que no cubren los metodos de net.IP
+var blockedNets = mustParseCIDRs(
+	"0.0.0.0/8",     // "esta red"
+	"100.64.0.0/10", // CGNAT, usado por algunas VPC
+	"192.0.0.0/24",  // asignaciones IETF
+	"198.18.0.0/15", // benchmarking
+	"240.0.0.0/4",   // reservado
+	"64:ff9b::/96",  // NAT64, puede envolver una IPv4 interna
+)
+
+// Guard decide que destinos se permiten. El valor cero bloquea todo lo
+// interno, loopback incluido.
+type Guard struct {
+	// AllowLoopback permite 127.0.0.0/8 y ::1, solo para desarrollo local
+	AllowLoopback bool
+	// LookupIP resuelve el host; nil usa el resolver del sistema
+	LookupIP func(ctx context.Context, host string) ([]net.IP, error)
+}
+
+// Allowed dice si la IP es un destino publico (o loopback con AllowLoopback)
+func (g Guard) Allowed(ip net.IP) bool {
+	if ip == nil {
+		return false
+	}
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	if ip.IsLoopback() {
+		return g.AllowLoopback
+	}
+	if ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, n := range blockedNets {
+		if n.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckHost resuelve el host y falla si alguna de sus IPs no esta permitida
+func (g Guard) CheckHost(ctx context.Context, host string) error {
+	if ip := net.ParseIP(host); ip != nil {
+		if !g.Allowed(ip) {
+			return ErrBlockedAddress
+		}
+		return nil
+	}
+
+	lookup := g.LookupIP
+	if lookup == nil {
+		lookup = func(ctx context.Context, host string) ([]net.IP, error) {
+			return net.DefaultResolver.LookupIP(ctx, "ip", host)
+		}
+	}
+	ips, err := lookup(ctx, host)
+	if err != nil {
+		return fmt.Errorf("resolve %s: %w", host, err)
+	}
+	if len(ips) == 0 {
+		return fmt.Errorf("resolve %s: no addresses", host)
+	}
+	for _, ip := range ips {
+		if !g.Allowed(ip) {
+			return ErrBlockedAddress
+		}
+	}
+	return nil
+}
+
+// Control es el hook de net.Dialer: corre con la IP que se va a usar, despues
+// de resolver, asi que cubre tambien el rebinding entre registro y envio.
+func (g Guard) Control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if !g.Allowed(net.ParseIP(host)) {
+		return ErrBlockedAddress
+	}
+	return nil
+}
+
+// Transport devuelve un http.Transport que solo conecta a destinos
+// permitidos. Ignora HTTP_PROXY: con proxy el dial iria al proxy y no al
+// destino real.
+func (g Guard) Transport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   10 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   g.Control,
+	}
+	return &http.Transport{
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	out := make([]*net.IPNet, 0, len(cidrs))
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+		out = append(out, n)
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+package netguard
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowed_BloqueaDireccionesInternas(t *testing.T) {
+	bloqueadas := []string{
+		"10.0.0.5", "172.16.3.4", "172.31.255.255", "192.168.1.10",
+		"169.254.169.254", "127.0.0.1", "0.0.0.0", "100.64.0.1",
+		"::1", "::", "fd00::1", "fe80::1", "::ffff:10.0.0.1", "::ffff:169.254.169.254",
+	}
+	for _, raw := range bloqueadas {
+		assert.False(t, Guard{}.Allowed(net.ParseIP(raw)), raw)
+	}
+
+	for _, raw := range []string{"8.8.8.8", "172.32.0.1", "2606:4700::1111"} {
+		assert.True(t, Guard{}.Allowed(net.ParseIP(raw)), raw)
+	}
+}
+
+func TestAllowed_LoopbackSoloConFlag(t *testing.T) {
+	dev := Guard{AllowLoopback: true}
+
+	assert.True(t, dev.Allowed(net.ParseIP("127.0.0.1")))
+	assert.True(t, dev.Allowed(net.ParseIP("::1")))
+	assert.False(t, dev.Allowed(net.ParseIP("10.0.0.1")), "el flag no abre la red privada")
+}
+
+func TestCheckHost_NombreQueResuelveAInterna(t *testing.T) {
+	g := Guard{LookupIP: func(ctx context.Context, host string) ([]net.IP, error) {
+		return []net.IP{net.ParseIP("93.184.216.34"), net.ParseIP("10.1.2.3")}, nil
+	}}
+
+	err := g.CheckHost(context.Background(), "interno.example.com")
+
+	assert.ErrorIs(t, err, ErrBlockedAddress, "basta una IP interna para rechazar")
+}
+
+func TestTransport_RechazaAlConectar(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: Guard{}.Transport()}
+	_, err := client.Get(srv.URL)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrBlockedAddress)
+
+	client = &http.Client{Transport: Guard{AllowLoopback: true}.Transport()}
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+}
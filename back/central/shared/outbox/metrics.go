@@ -0,0 +1,34 @@
+package outbox
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	outboxPendingEvents = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "outbox_pending_events",
+		Help: "Eventos del outbox pendientes de publicar",
+	})
+
+	outboxOldestPendingAge = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "outbox_oldest_pending_age_seconds",
+		Help: "Antiguedad en segundos del evento pendiente mas viejo (lag del relay)",
+	})
+
+	outboxPublishedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "outbox_published_total",
+		Help: "Eventos del outbox publicados y confirmados por el broker",
+	}, []string{"event_type"})
+
+	outboxPublishFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "outbox_publish_failures_total",
+		Help: "Intentos fallidos de publicar eventos del outbox",
+	}, []string{"event_type"})
+
+	outboxPublishLag = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "outbox_publish_lag_seconds",
+		Help:    "Tiempo entre que el evento se escribio en el outbox y que el broker lo confirmo",
+		Buckets: []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60, 300, 900},
+	})
+)
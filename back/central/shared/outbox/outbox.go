@@ -0,0 +1,168 @@
+// Package outbox implementa el patron transactional outbox: los eventos se
+// escriben en outbox_events dentro de la misma transaccion que el cambio de
+// negocio y un relay los publica a RabbitMQ despues, con confirmacion del broker.
+// Si el broker esta caido el cambio igual se guarda y el evento sale cuando vuelva.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/secamc93/probability/back/central/shared/db"
+	"github.com/secamc93/probability/back/central/shared/rabbitmq"
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+// Message es un evento pendiente de publicar. Exchange vacio publica directo a
+// la cola RoutingKey (igual que IQueue.Publish).
+type Message struct {
+	Exchange      string
+	RoutingKey    string
+	EventType     string
+	AggregateType string
+	AggregateID   string
+	BusinessID    *uint
+	Headers       map[string]interface{}
+	Payload       []byte
+}
+
+// IWriter encola eventos en el outbox. Si el ctx trae una transaccion abierta
+// con db.InTransaction, el evento se guarda en ella. Los headers de correlacion
+// del ctx se guardan con el evento para que el relay los publique.
+type IWriter interface {
+	Enqueue(ctx context.Context, msgs ...Message) error
+}
+
+type writer struct {
+	db db.IDatabase
+}
+
+// NewWriter construye el IWriter sobre la base de datos compartida.
+func NewWriter(database db.IDatabase) IWriter {
+	return &writer{db: database}
+}
+
+func (w *writer) Enqueue(ctx context.Context, msgs ...Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	correlation := rabbitmq.CorrelationHeaders(ctx)
+	rows := make([]models.OutboxEvent, 0, len(msgs))
+	for _, msg := range msgs {
+		row, err := toModel(msg.withHeaders(correlation), time.Now())
+		if err != nil {
+			return err
+		}
+		rows = append(rows, row)
+	}
+
+	if err := w.db.Conn(ctx).Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to enqueue outbox events: %w", err)
+	}
+	return nil
+}
+
+// ToQueue arma un mensaje que se publica directo a una cola.
+func ToQueue(queueName string, eventType string, payload []byte) Message {
+	return Message{RoutingKey: queueName, EventType: eventType, Payload: payload}
+}
+
+// ToExchange arma un mensaje que se publica a un exchange.
+func ToExchange(exchange string, routingKey string, eventType string, payload []byte) Message {
+	return Message{Exchange: exchange, RoutingKey: routingKey, EventType: eventType, Payload: payload}
+}
+
+// FromEventEnvelope arma el mensaje de un evento unificado (el mismo destino
+// que rabbitmq.PublishEvent).
+func FromEventEnvelope(envelope rabbitmq.EventEnvelope) (Message, error) {
+	if envelope.ID == "" {
+		envelope.ID = uuid.New().String()
+	}
+	if envelope.Timestamp.IsZero() {
+		envelope.Timestamp = time.Now()
+	}
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return Message{}, fmt.Errorf("failed to marshal event envelope: %w", err)
+	}
+	msg := ToExchange(rabbitmq.EventsExchangeName, envelope.Type, envelope.Type, payload)
+	if envelope.BusinessID != 0 {
+		businessID := envelope.BusinessID
+		msg.BusinessID = &businessID
+	}
+	return msg, nil
+}
+
+// WithAggregate indica a que entidad pertenece el evento, para poder rastrearlo.
+func (m Message) WithAggregate(aggregateType string, aggregateID string) Message {
+	m.AggregateType = aggregateType
+	m.AggregateID = aggregateID
+	return m
+}
+
+// WithBusiness asocia el evento a un negocio.
+func (m Message) WithBusiness(businessID uint) Message {
+	if businessID != 0 {
+		m.BusinessID = &businessID
+	}
+	return m
+}
+
+// withHeaders agrega headers sin pisar los que el mensaje ya trae. Copia el
+// mapa para no compartirlo entre mensajes del mismo Enqueue.
+func (m Message) withHeaders(extra map[string]interface{}) Message {
+	if len(extra) == 0 {
+		return m
+	}
+	headers := make(map[string]interface{}, len(m.Headers)+len(extra))
+	for key, value := range extra {
+		headers[key] = value
+	}
+	for key, value := range m.Headers {
+		headers[key] = value
+	}
+	m.Headers = headers
+	return m
+}
+
+func toModel(msg Message, now time.Time) (models.OutboxEvent, error) {
+	if msg.Exchange == "" && msg.RoutingKey == "" {
+		return models.OutboxEvent{}, fmt.Errorf("outbox message %q has no destination", msg.EventType)
+	}
+	if len(msg.Payload) == 0 {
+		return models.OutboxEvent{}, fmt.Errorf("outbox message %q has empty payload", msg.EventType)
+	}
+
+	var headers []byte
+	if len(msg.Headers) > 0 {
+		encoded, err := json.Marshal(msg.Headers)
+		if err != nil {
+			return models.OutboxEvent{}, fmt.Errorf("failed to marshal outbox headers: %w", err)
+		}
+		headers = encoded
+	}
+
+	eventType := msg.EventType
+	if eventType == "" {
+		eventType = "unknown"
+	}
+
+	return models.OutboxEvent{
+		ID:            uuid.New(),
+		BusinessID:    msg.BusinessID,
+		AggregateType: msg.AggregateType,
+		AggregateID:   msg.AggregateID,
+		EventType:     eventType,
+		Exchange:      msg.Exchange,
+		RoutingKey:    msg.RoutingKey,
+		ContentType:   "application/json",
+		Payload:       msg.Payload,
+		Headers:       headers,
+		Status:        models.OutboxStatusPending,
+		AvailableAt:   now,
+	}, nil
+}
@@ -0,0 +1,265 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/secamc93/probability/back/central/shared/db"
+	"github.com/secamc93/probability/back/central/shared/rabbitmq"
+	"github.com/secamc93/probability/back/central/shared/testkit"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type writerFalso struct {
+	encolados []Message
+}
+
+func (w *writerFalso) Enqueue(ctx context.Context, msgs ...Message) error {
+	w.encolados = append(w.encolados, msgs...)
+	return nil
+}
+
+func TestToModel_SinDestinoEsError(t *testing.T) {
+	_, err := toModel(Message{EventType: "order.created", Payload: []byte(`{}`)}, time.Now())
+
+	assert.Error(t, err, "un evento sin exchange ni cola quedaria pendiente para siempre")
+}
+
+func TestToModel_SinPayloadEsError(t *testing.T) {
+	_, err := toModel(ToQueue("orders.confirmation.requested", "order.confirmation_requested", nil), time.Now())
+
+	assert.Error(t, err)
+}
+
+func TestToModel_QuedaPendienteYDisponibleYa(t *testing.T) {
+	ahora := time.Now()
+	msg := ToExchange(rabbitmq.ExchangeOrderEvents, "", "order.created", []byte(`{"order_id":"o-1"}`)).
+		WithAggregate("order", "o-1").
+		WithBusiness(26)
+	msg.Headers = map[string]interface{}{"x-source": "orders"}
+
+	row, err := toModel(msg, ahora)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.OutboxStatusPending, row.Status)
+	assert.Equal(t, ahora, row.AvailableAt)
+	assert.Equal(t, rabbitmq.ExchangeOrderEvents, row.Exchange)
+	assert.Equal(t, "o-1", row.AggregateID)
+	require.NotNil(t, row.BusinessID)
+	assert.Equal(t, uint(26), *row.BusinessID)
+	assert.JSONEq(t, `{"x-source":"orders"}`, string(row.Headers))
+}
+
+func TestWithBusiness_CeroNoAsociaNegocio(t *testing.T) {
+	msg := ToQueue("cola", "evento", []byte(`{}`)).WithBusiness(0)
+
+	assert.Nil(t, msg.BusinessID)
+}
+
+func TestFromEventEnvelope_MismoDestinoQuePublishEvent(t *testing.T) {
+	msg, err := FromEventEnvelope(rabbitmq.EventEnvelope{
+		Type:       "wallet.low_balance",
+		Category:   "pay",
+		BusinessID: 7,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, rabbitmq.EventsExchangeName, msg.Exchange)
+	assert.Equal(t, "wallet.low_balance", msg.RoutingKey, "el routing key del exchange de eventos es el tipo")
+
+	var envelope rabbitmq.EventEnvelope
+	require.NoError(t, json.Unmarshal(msg.Payload, &envelope))
+	assert.NotEmpty(t, envelope.ID)
+	assert.False(t, envelope.Timestamp.IsZero())
+}
+
+func TestBackoff_CreceHastaElTope(t *testing.T) {
+	assert.Equal(t, time.Second, backoff(1))
+	assert.Equal(t, 2*time.Second, backoff(2))
+	assert.Equal(t, 64*time.Second, backoff(7))
+	assert.Equal(t, 256*time.Second, backoff(9))
+	assert.Equal(t, maxBackoff, backoff(10))
+	assert.Equal(t, maxBackoff, backoff(500), "el evento nunca se descarta, solo se espacia")
+}
+
+func TestToConfirmedMessage_UsaElIDDelEventoComoMessageID(t *testing.T) {
+	row, err := toModel(ToQueue("cola", "evento", []byte(`{"a":1}`)), time.Now())
+	require.NoError(t, err)
+
+	msg, err := toConfirmedMessage(&row)
+
+	require.NoError(t, err)
+	assert.Equal(t, row.ID.String(), msg.MessageID,
+		"los consumidores deduplican por message id si el relay reenvia tras un corte")
+	assert.Equal(t, "cola", msg.RoutingKey)
+	assert.Empty(t, msg.Exchange)
+}
+
+func TestNewQueue_PublicarVaAlOutboxYNoAlBroker(t *testing.T) {
+	broker := &testkit.QueueMock{}
+	writer := &writerFalso{}
+	q := NewQueue(broker, writer)
+
+	require.NoError(t, q.Publish(context.Background(), "orders.canonical", []byte(`{}`)))
+	require.NoError(t, q.PublishToExchange(context.Background(), rabbitmq.EventsExchangeName, "wallet.low_balance", []byte(`{}`)))
+
+	assert.Empty(t, broker.Publicados)
+	require.Len(t, writer.encolados, 2)
+	assert.Equal(t, "orders.canonical", writer.encolados[0].RoutingKey)
+	assert.Empty(t, writer.encolados[0].Exchange)
+	assert.Equal(t, "wallet.low_balance", writer.encolados[1].EventType)
+}
+
+func TestNewQueue_DeclararSigueYendoAlBroker(t *testing.T) {
+	broker := &testkit.QueueMock{}
+	q := NewQueue(broker, &writerFalso{})
+
+	require.NoError(t, q.DeclareQueue("inventory.sync", true))
+
+	require.Len(t, broker.Declaradas, 1)
+}
+
+func TestWriterEnqueue_UsaLaTransaccionDelContexto(t *testing.T) {
+	database := testkit.NewDB(t)
+	database.Mock.ExpectBegin()
+	database.Mock.ExpectQuery(`INSERT INTO "outbox_events"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("6730e91c-b152-4700-96b9-8fa01df01db9"))
+	database.Mock.ExpectCommit()
+
+	writer := NewWriter(database)
+	err := db.InTransaction(context.Background(), database, func(ctx context.Context) error {
+		return writer.Enqueue(ctx, ToQueue("cola", "evento", []byte(`{}`)))
+	})
+
+	require.NoError(t, err)
+	database.SinPendientes(t)
+}
+
+func TestWriterEnqueue_SiFallaElNegocioNoQuedaEvento(t *testing.T) {
+	database := testkit.NewDB(t)
+	database.Mock.ExpectBegin()
+	database.Mock.ExpectQuery(`INSERT INTO "outbox_events"`).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("6730e91c-b152-4700-96b9-8fa01df01db9"))
+	database.Mock.ExpectRollback()
+
+	writer := NewWriter(database)
+	err := db.InTransaction(context.Background(), database, func(ctx context.Context) error {
+		if err := writer.Enqueue(ctx, ToQueue("cola", "evento", []byte(`{}`))); err != nil {
+			return err
+		}
+		return assert.AnError
+	})
+
+	assert.ErrorIs(t, err, assert.AnError)
+	database.SinPendientes(t)
+}
+
+func TestWithHeaders_NoPisaLosHeadersDelMensaje(t *testing.T) {
+	msg := Message{Headers: map[string]interface{}{rabbitmq.HeaderCorrelationID: "propio"}}
+
+	out := msg.withHeaders(map[string]interface{}{
+		rabbitmq.HeaderCorrelationID: "del-ctx",
+		rabbitmq.HeaderCausationID:   "msg-1",
+	})
+
+	assert.Equal(t, "propio", out.Headers[rabbitmq.HeaderCorrelationID])
+	assert.Equal(t, "msg-1", out.Headers[rabbitmq.HeaderCausationID])
+	assert.Len(t, msg.Headers, 1, "no debe modificar el mapa original")
+}
+
+func filasPendientes(ids ...string) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "created_at", "event_type", "routing_key", "payload", "attempts"})
+	for _, id := range ids {
+		rows.AddRow(id, time.Now().Add(-time.Minute), "order.created", "orders.events", []byte(`{}`), 2)
+	}
+	return rows
+}
+
+func esperarReclamo(database *testkit.DBMock, ids ...string) {
+	database.Mock.ExpectBegin()
+	database.Mock.ExpectQuery(`SELECT \* FROM "outbox_events" WHERE .*locked_until IS NULL OR locked_until <= .*FOR UPDATE SKIP LOCKED`).
+		WillReturnRows(filasPendientes(ids...))
+	database.Mock.ExpectExec(`UPDATE "outbox_events" SET "claimed_by"=.*"locked_until"=.*WHERE id IN`).
+		WillReturnResult(sqlmock.NewResult(0, int64(len(ids))))
+	database.Mock.ExpectCommit()
+}
+
+func TestRunOnce_PublicaFueraDeLaTransaccionDelReclamo(t *testing.T) {
+	database := testkit.NewDB(t)
+	esperarReclamo(database, "6730e91c-b152-4700-96b9-8fa01df01db9", "9b1f3c1e-6a55-4b39-8a0e-2f1c1d6f0a11")
+
+	var reclamoCerrado []bool
+	broker := &testkit.QueueMock{PublishFn: func(context.Context, string, []byte) error {
+		reclamoCerrado = append(reclamoCerrado, database.Mock.ExpectationsWereMet() == nil)
+		if len(reclamoCerrado) == 2 {
+			database.Mock.ExpectBegin()
+			database.Mock.ExpectExec(`UPDATE "outbox_events" SET .*"status"=.*WHERE claimed_by = .* AND id = `).WillReturnResult(sqlmock.NewResult(0, 1))
+			database.Mock.ExpectExec(`UPDATE "outbox_events" SET .*"status"=.*WHERE claimed_by = .* AND id = `).WillReturnResult(sqlmock.NewResult(0, 1))
+			database.Mock.ExpectCommit()
+		}
+		return nil
+	}}
+	relay := NewRelay(database, broker, testkit.NewSilentLogger())
+
+	published, err := relay.RunOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, published)
+	assert.Equal(t, []bool{true, true}, reclamoCerrado, "se publica con la transaccion del reclamo ya confirmada")
+	database.SinPendientes(t)
+}
+
+func TestRunOnce_FallaPublicacion_ReprogramaYLiberaElResto(t *testing.T) {
+	database := testkit.NewDB(t)
+	esperarReclamo(database,
+		"6730e91c-b152-4700-96b9-8fa01df01db9",
+		"9b1f3c1e-6a55-4b39-8a0e-2f1c1d6f0a11",
+		"c2d4e6f8-1a3b-4c5d-8e7f-9a0b1c2d3e4f")
+	database.Mock.ExpectBegin()
+	database.Mock.ExpectExec(`UPDATE "outbox_events" SET .*"status"=.*WHERE claimed_by = .* AND id = `).WillReturnResult(sqlmock.NewResult(0, 1))
+	database.Mock.ExpectExec(`UPDATE "outbox_events" SET "attempts"=.*"available_at"=.*"last_error"=.*WHERE claimed_by = .* AND id = `).
+		WithArgs(3, sqlmock.AnyArg(), sqlmock.AnyArg(), "broker caido", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), "9b1f3c1e-6a55-4b39-8a0e-2f1c1d6f0a11").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	database.Mock.ExpectExec(`UPDATE "outbox_events" SET "claimed_by"=.*"locked_until"=.*WHERE claimed_by = .* AND id IN`).
+		WithArgs(nil, nil, sqlmock.AnyArg(), sqlmock.AnyArg(), "c2d4e6f8-1a3b-4c5d-8e7f-9a0b1c2d3e4f").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	database.Mock.ExpectCommit()
+
+	llamadas := 0
+	broker := &testkit.QueueMock{PublishFn: func(context.Context, string, []byte) error {
+		llamadas++
+		if llamadas == 2 {
+			return errors.New("broker caido")
+		}
+		return nil
+	}}
+	relay := NewRelay(database, broker, testkit.NewSilentLogger())
+
+	published, err := relay.RunOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, published)
+	assert.Equal(t, 2, llamadas, "el tercero no se publica antes que el que fallo")
+	database.SinPendientes(t)
+}
+
+func TestRunOnce_SinPendientesNoPublica(t *testing.T) {
+	database := testkit.NewDB(t)
+	database.Mock.ExpectBegin()
+	database.Mock.ExpectQuery(`FROM "outbox_events"`).WillReturnRows(filasPendientes())
+	database.Mock.ExpectCommit()
+	broker := &testkit.QueueMock{}
+
+	published, err := NewRelay(database, broker, testkit.NewSilentLogger()).RunOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Zero(t, published)
+	assert.Empty(t, broker.Publicaciones())
+	database.SinPendientes(t)
+}
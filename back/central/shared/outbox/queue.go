@@ -0,0 +1,39 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/central/shared/rabbitmq"
+)
+
+// publishingQueue es una IQueue cuyas publicaciones van al outbox. Consumir,
+// declarar y el resto se delegan a la IQueue real. Sirve para mover al outbox
+// codigo que ya publica con IQueue o rabbitmq.PublishEvent sin reescribirlo.
+type publishingQueue struct {
+	rabbitmq.IQueue
+	writer IWriter
+}
+
+// NewQueue envuelve inner para que Publish y PublishToExchange escriban en el
+// outbox. Si el ctx trae una transaccion (db.InTransaction), el evento queda en ella.
+func NewQueue(inner rabbitmq.IQueue, writer IWriter) rabbitmq.IQueue {
+	return &publishingQueue{IQueue: inner, writer: writer}
+}
+
+func (q *publishingQueue) Publish(ctx context.Context, queueName string, message []byte) error {
+	return q.writer.Enqueue(ctx, ToQueue(queueName, queueName, message))
+}
+
+func (q *publishingQueue) PublishToExchange(ctx context.Context, exchangeName string, routingKey string, message []byte) error {
+	eventType := routingKey
+	if eventType == "" {
+		eventType = exchangeName
+	}
+	return q.writer.Enqueue(ctx, ToExchange(exchangeName, routingKey, eventType, message))
+}
+
+// ConsumeDeliveries delega en la IQueue real; sin esto el type assertion de
+// rabbitmq.ConsumeDeliveries no veria el soporte del broker detras del wrapper.
+func (q *publishingQueue) ConsumeDeliveries(ctx context.Context, queueName string, handler rabbitmq.DeliveryHandler, workers int) error {
+	return rabbitmq.ConsumeDeliveries(ctx, q.IQueue, queueName, handler, workers)
+}
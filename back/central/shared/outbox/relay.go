@@ -0,0 +1,340 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/secamc93/probability/back/central/shared/db"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/rabbitmq"
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultBatchSize    = 100
+	defaultPollInterval = time.Second
+	defaultRetention    = 7 * 24 * time.Hour
+	cleanupInterval     = time.Hour
+
+	// defaultClaimTTL es cuanto dura el reclamo de un lote. La publicacion se
+	// corta al vencer, asi otra instancia no lo toma mientras sigue en curso.
+	defaultClaimTTL = 2 * time.Minute
+
+	// maxBackoff acota la espera entre intentos: un evento nunca se descarta,
+	// solo se espacia mientras el broker no responda.
+	maxBackoff = 5 * time.Minute
+	maxErrLen  = 1000
+)
+
+// Relay publica los eventos pendientes del outbox. Varias instancias pueden
+// correr a la vez: cada lote se reclama (locked_until, claimed_by) en una
+// transaccion corta con FOR UPDATE SKIP LOCKED.
+type Relay struct {
+	id        string
+	db        db.IDatabase
+	queue     rabbitmq.IQueue
+	publisher rabbitmq.IConfirmPublisher
+	logger    log.ILogger
+
+	batchSize    int
+	pollInterval time.Duration
+	retention    time.Duration
+	claimTTL     time.Duration
+}
+
+// NewRelay construye el relay. Si la IQueue no soporta publisher confirms
+// (modo degradado, mocks) publica sin confirmacion.
+func NewRelay(database db.IDatabase, queue rabbitmq.IQueue, logger log.ILogger) *Relay {
+	publisher, _ := rabbitmq.AsConfirmPublisher(queue)
+	return &Relay{
+		id:           uuid.New().String(),
+		db:           database,
+		queue:        queue,
+		publisher:    publisher,
+		logger:       logger.WithModule("outbox"),
+		batchSize:    defaultBatchSize,
+		pollInterval: defaultPollInterval,
+		retention:    defaultRetention,
+		claimTTL:     defaultClaimTTL,
+	}
+}
+
+// Start arranca el loop del relay en una goroutine; termina con ctx.
+func (r *Relay) Start(ctx context.Context) {
+	go r.loop(ctx)
+}
+
+func (r *Relay) loop(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	lastCleanup := time.Time{}
+
+	for {
+		published, err := r.RunOnce(ctx)
+		if err != nil {
+			r.logger.Error(ctx).Err(err).Msg("Error procesando lote del outbox")
+		}
+		r.refreshMetrics(ctx)
+
+		if time.Since(lastCleanup) >= cleanupInterval {
+			r.cleanup(ctx)
+			lastCleanup = time.Now()
+		}
+
+		// Lote lleno: probablemente hay mas pendientes, no esperar al tick.
+		if err == nil && published >= r.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce reclama un lote de eventos vencidos, los publica en orden y marca
+// como enviados los que el broker confirmo. Reclamar y marcar son
+// transacciones cortas: la publicacion corre fuera, sin locks de fila ni
+// conexion tomados mientras se esperan las confirmaciones. Ante el primer
+// fallo reprograma ese evento, libera el resto del lote y corta, para no
+// adelantar eventos posteriores del mismo agregado.
+func (r *Relay) RunOnce(ctx context.Context) (int, error) {
+	rows, err := r.claim(ctx)
+	if err != nil || len(rows) == 0 {
+		return 0, err
+	}
+
+	publishCtx, cancel := context.WithTimeout(ctx, r.claimTTL)
+	defer cancel()
+
+	sent := 0
+	var pubErr error
+	for i := range rows {
+		if pubErr = r.publish(publishCtx, &rows[i]); pubErr != nil {
+			break
+		}
+		now := time.Now()
+		rows[i].SentAt = &now
+		sent++
+	}
+
+	if pubErr != nil {
+		failed := rows[sent]
+		outboxPublishFailuresTotal.WithLabelValues(failed.EventType).Inc()
+		r.logger.Warn(ctx).
+			Err(pubErr).
+			Str("event_id", failed.ID.String()).
+			Str("event_type", failed.EventType).
+			Int("attempts", failed.Attempts+1).
+			Msg("No se pudo publicar evento del outbox - se reintentara")
+	}
+
+	// Lo confirmado se marca aunque el relay se este apagando: si no, se
+	// publicaria de nuevo al arrancar
+	if err := r.settle(context.WithoutCancel(ctx), rows, sent, pubErr); err != nil {
+		return 0, err
+	}
+
+	for _, row := range rows[:sent] {
+		outboxPublishedTotal.WithLabelValues(row.EventType).Inc()
+		outboxPublishLag.Observe(row.SentAt.Sub(row.CreatedAt).Seconds())
+	}
+	return sent, nil
+}
+
+// claim toma hasta batchSize eventos vencidos y sin reclamo vigente y los
+// reclama a nombre de este relay hasta claimTTL.
+func (r *Relay) claim(ctx context.Context) ([]models.OutboxEvent, error) {
+	var rows []models.OutboxEvent
+	err := r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND available_at <= ?", models.OutboxStatusPending, now).
+			Where("(locked_until IS NULL OR locked_until <= ?)", now).
+			Order("created_at ASC").
+			Limit(r.batchSize).
+			Find(&rows).Error; err != nil {
+			return fmt.Errorf("failed to claim outbox events: %w", err)
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+
+		ids := make([]uuid.UUID, len(rows))
+		for i := range rows {
+			ids[i] = rows[i].ID
+		}
+		if err := tx.Model(&models.OutboxEvent{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"locked_until": now.Add(r.claimTTL),
+				"claimed_by":   r.id,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to claim outbox events: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// settle cierra el lote: marca enviados los primeros sent eventos, reprograma
+// el que fallo y libera el resto. Solo toca filas que siguen reclamadas por
+// este relay: si el reclamo vencio y otra instancia las tomo, son de ella.
+func (r *Relay) settle(ctx context.Context, rows []models.OutboxEvent, sent int, pubErr error) error {
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		claimed := func() *gorm.DB {
+			return tx.Model(&models.OutboxEvent{}).Where("claimed_by = ?", r.id)
+		}
+
+		for _, row := range rows[:sent] {
+			if err := claimed().
+				Where("id = ?", row.ID).
+				Updates(map[string]interface{}{
+					"status":       models.OutboxStatusSent,
+					"sent_at":      *row.SentAt,
+					"attempts":     row.Attempts + 1,
+					"last_error":   nil,
+					"locked_until": nil,
+					"claimed_by":   nil,
+				}).Error; err != nil {
+				return fmt.Errorf("failed to mark outbox event %s as sent: %w", row.ID, err)
+			}
+		}
+		if pubErr == nil {
+			return nil
+		}
+
+		failed := rows[sent]
+		attempts := failed.Attempts + 1
+		if err := claimed().
+			Where("id = ?", failed.ID).
+			Updates(map[string]interface{}{
+				"attempts":     attempts,
+				"last_error":   truncate(pubErr.Error()),
+				"available_at": time.Now().Add(backoff(attempts)),
+				"locked_until": nil,
+				"claimed_by":   nil,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to reschedule outbox event %s: %w", failed.ID, err)
+		}
+
+		rest := rows[sent+1:]
+		if len(rest) == 0 {
+			return nil
+		}
+		ids := make([]uuid.UUID, len(rest))
+		for i := range rest {
+			ids[i] = rest[i].ID
+		}
+		if err := claimed().
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"locked_until": nil,
+				"claimed_by":   nil,
+			}).Error; err != nil {
+			return fmt.Errorf("failed to release outbox events: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *Relay) publish(ctx context.Context, row *models.OutboxEvent) error {
+	if r.publisher != nil {
+		msg, err := toConfirmedMessage(row)
+		if err != nil {
+			return err
+		}
+		return r.publisher.PublishConfirmed(ctx, msg)
+	}
+
+	if row.Exchange == "" {
+		return r.queue.Publish(ctx, row.RoutingKey, row.Payload)
+	}
+	return r.queue.PublishToExchange(ctx, row.Exchange, row.RoutingKey, row.Payload)
+}
+
+func (r *Relay) refreshMetrics(ctx context.Context) {
+	var stats struct {
+		Pending int64
+		Oldest  *time.Time
+	}
+	if err := r.db.Conn(ctx).
+		Model(&models.OutboxEvent{}).
+		Select("count(*) AS pending, min(created_at) AS oldest").
+		Where("status = ?", models.OutboxStatusPending).
+		Scan(&stats).Error; err != nil {
+		r.logger.Warn(ctx).Err(err).Msg("No se pudieron calcular las metricas del outbox")
+		return
+	}
+
+	outboxPendingEvents.Set(float64(stats.Pending))
+	if stats.Oldest == nil {
+		outboxOldestPendingAge.Set(0)
+		return
+	}
+	outboxOldestPendingAge.Set(time.Since(*stats.Oldest).Seconds())
+}
+
+func (r *Relay) cleanup(ctx context.Context) {
+	res := r.db.Conn(ctx).
+		Where("status = ? AND sent_at < ?", models.OutboxStatusSent, time.Now().Add(-r.retention)).
+		Delete(&models.OutboxEvent{})
+	if res.Error != nil {
+		r.logger.Warn(ctx).Err(res.Error).Msg("No se pudieron limpiar los eventos enviados del outbox")
+		return
+	}
+	if res.RowsAffected > 0 {
+		r.logger.Info(ctx).Int64("deleted", res.RowsAffected).Msg("Eventos enviados del outbox eliminados")
+	}
+}
+
+func toConfirmedMessage(row *models.OutboxEvent) (rabbitmq.ConfirmedMessage, error) {
+	var headers map[string]interface{}
+	if len(row.Headers) > 0 {
+		if err := json.Unmarshal(row.Headers, &headers); err != nil {
+			return rabbitmq.ConfirmedMessage{}, fmt.Errorf("invalid headers in outbox event %s: %w", row.ID, err)
+		}
+	}
+
+	return rabbitmq.ConfirmedMessage{
+		Exchange:    row.Exchange,
+		RoutingKey:  row.RoutingKey,
+		MessageID:   row.ID.String(),
+		ContentType: row.ContentType,
+		Headers:     headers,
+		Body:        row.Payload,
+	}, nil
+}
+
+// backoff duplica la espera por intento desde 1s hasta maxBackoff.
+func backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return time.Second
+	}
+	if attempts > 9 {
+		return maxBackoff
+	}
+	d := time.Second << uint(attempts-1)
+	if d > maxBackoff {
+		return maxBackoff
+	}
+	return d
+}
+
+func truncate(msg string) string {
+	if len(msg) > maxErrLen {
+		return msg[:maxErrLen]
+	}
+	return msg
+}
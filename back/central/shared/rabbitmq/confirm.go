@@ -0,0 +1,129 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ConfirmedMessage es un mensaje que se publica esperando el ACK del broker.
+// Exchange vacio publica directo a la cola RoutingKey.
+type ConfirmedMessage struct {
+	Exchange    string
+	RoutingKey  string
+	MessageID   string
+	ContentType string
+	Headers     map[string]interface{}
+	Body        []byte
+}
+
+// IConfirmPublisher publica con publisher confirms: PublishConfirmed solo
+// retorna nil cuando el broker confirmo que el mensaje quedo encolado. Se
+// obtiene con AsConfirmPublisher para no ensanchar IQueue.
+type IConfirmPublisher interface {
+	PublishConfirmed(ctx context.Context, msg ConfirmedMessage) error
+}
+
+// AsConfirmPublisher expone la publicacion con confirmacion de una IQueue, si la soporta.
+func AsConfirmPublisher(queue IQueue) (IConfirmPublisher, bool) {
+	publisher, ok := queue.(IConfirmPublisher)
+	return publisher, ok
+}
+
+// PublishConfirmed usa un canal dedicado en modo confirm. Las publicaciones
+// se serializan: el volumen del relay del outbox no justifica un pool.
+func (r *rabbitMQ) PublishConfirmed(ctx context.Context, msg ConfirmedMessage) error {
+	contentType := msg.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+
+	publishing := amqp.Publishing{
+		Headers:      amqp.Table(msg.Headers),
+		ContentType:  contentType,
+		DeliveryMode: amqp.Persistent,
+		MessageId:    msg.MessageID,
+		Body:         msg.Body,
+	}
+	ctx, span := startPublishSpan(ctx, publishing.Headers, msg.Exchange, msg.RoutingKey)
+	stampPublishing(ctx, &publishing)
+
+	err := r.publishWithConfirm(ctx, msg.Exchange, msg.RoutingKey, publishing)
+	endSpan(span, &publishing, err)
+	return err
+}
+
+// publishWithConfirm publica una Publishing ya armada en el canal confirm y
+// espera el ACK del broker.
+func (r *rabbitMQ) publishWithConfirm(ctx context.Context, exchange string, routingKey string, publishing amqp.Publishing) error {
+	r.confirmMu.Lock()
+	defer r.confirmMu.Unlock()
+
+	ch, err := r.confirmChannel()
+	if err != nil {
+		return err
+	}
+	return r.publishAndWait(ctx, ch, exchange, routingKey, publishing)
+}
+
+// publishAndWait publica en el canal confirm y espera el ACK del broker.
+func (r *rabbitMQ) publishAndWait(ctx context.Context, ch *amqp.Channel, exchange string, routingKey string, publishing amqp.Publishing) error {
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(
+		ctx,
+		exchange,
+		routingKey,
+		false,
+		false,
+		publishing,
+	)
+	if err != nil {
+		r.resetConfirmChannel()
+		return fmt.Errorf("failed to publish confirmed message: %w", err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		r.resetConfirmChannel()
+		return fmt.Errorf("failed waiting for publisher confirm: %w", err)
+	}
+	if !acked {
+		return fmt.Errorf("broker rejected message %s (nack)", publishing.MessageId)
+	}
+
+	return nil
+}
+
+// confirmChannel reutiliza el canal en modo confirm mientras la conexion sea
+// la misma; tras una reconexion abre uno nuevo.
+func (r *rabbitMQ) confirmChannel() (*amqp.Channel, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.conn == nil || r.conn.IsClosed() {
+		return nil, fmt.Errorf("rabbitmq connection is closed")
+	}
+	if r.confirmCh != nil && !r.confirmCh.IsClosed() && r.confirmEpoch == r.connEpoch {
+		return r.confirmCh, nil
+	}
+
+	ch, err := r.conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create confirm channel: %w", err)
+	}
+	if err := ch.Confirm(false); err != nil {
+		ch.Close()
+		return nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	r.confirmCh = ch
+	r.confirmEpoch = r.connEpoch
+	return ch, nil
+}
+
+func (r *rabbitMQ) resetConfirmChannel() {
+	if r.confirmCh != nil {
+		r.confirmCh.Close()
+		r.confirmCh = nil
+	}
+}
@@ -0,0 +1,187 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/secamc93/probability/back/central/shared/log"
+)
+
+// Headers de correlacion. Viajan con cada mensaje para poder seguir un flujo
+// (p.ej. una orden de Shopify) por todos los consumers que toca.
+const (
+	HeaderCorrelationID = "x-correlation-id"
+	HeaderCausationID   = "x-causation-id"
+	HeaderBusinessID    = "x-business-id"
+)
+
+// Delivery es un mensaje recibido de una cola con sus metadatos AMQP.
+type Delivery struct {
+	Queue         string
+	MessageID     string
+	CorrelationID string
+	CausationID   string
+	BusinessID    uint
+	ContentType   string
+	Headers       map[string]interface{}
+	Timestamp     time.Time
+	Redelivered   bool
+	RetryCount    int
+	Body          []byte
+}
+
+// DeliveryHandler procesa un mensaje. El ctx trae el correlation ID, el
+// causation ID (el MessageID de este mensaje) y el business ID, de modo que
+// lo que el handler publique y loguee quede encadenado a este mensaje.
+type DeliveryHandler func(ctx context.Context, d Delivery) error
+
+// IDeliveryConsumer consume entregando el mensaje completo en vez del body.
+// Se usa a traves de ConsumeDeliveries para no ensanchar IQueue.
+type IDeliveryConsumer interface {
+	ConsumeDeliveries(ctx context.Context, queueName string, handler DeliveryHandler, workers int) error
+}
+
+// ConsumeDeliveries registra un DeliveryHandler sobre la cola. Si la IQueue no
+// soporta entregas completas (mocks, adaptadores) cae a ConsumeConcurrent y el
+// handler recibe un Delivery con solo el body.
+func ConsumeDeliveries(ctx context.Context, queue IQueue, queueName string, handler DeliveryHandler, workers int) error {
+	if consumer, ok := queue.(IDeliveryConsumer); ok {
+		return consumer.ConsumeDeliveries(ctx, queueName, handler, workers)
+	}
+	return queue.ConsumeConcurrent(ctx, queueName, func(body []byte) error {
+		return handler(ctx, Delivery{Queue: queueName, Body: body})
+	}, workers)
+}
+
+// CorrelationHeaders arma los headers de correlacion a partir del ctx: el
+// correlation ID del flujo, el causation ID (mensaje o request padre), el
+// business ID y el contexto de trazas. Retorna nil si el ctx no trae nada.
+func CorrelationHeaders(ctx context.Context) map[string]interface{} {
+	if ctx == nil {
+		return nil
+	}
+
+	headers := map[string]interface{}{}
+	if correlationID, ok := log.CorrelationIDFromCtx(ctx); ok {
+		headers[HeaderCorrelationID] = correlationID
+	}
+	if causationID, ok := log.CausationIDFromCtx(ctx); ok {
+		headers[HeaderCausationID] = causationID
+	}
+	if businessID, ok := log.BusinessIDFromCtx(ctx); ok && businessID != 0 {
+		headers[HeaderBusinessID] = strconv.FormatUint(uint64(businessID), 10)
+	}
+	injectTraceContext(ctx, headers)
+
+	if len(headers) == 0 {
+		return nil
+	}
+	return headers
+}
+
+// newDelivery traduce la entrega de amqp091 al Delivery del paquete.
+func newDelivery(queueName string, msg amqp.Delivery) Delivery {
+	d := Delivery{
+		Queue:         queueName,
+		MessageID:     msg.MessageId,
+		CorrelationID: headerString(msg.Headers, HeaderCorrelationID),
+		CausationID:   headerString(msg.Headers, HeaderCausationID),
+		BusinessID:    headerUint(msg.Headers, HeaderBusinessID),
+		ContentType:   msg.ContentType,
+		Headers:       copyHeaders(msg.Headers),
+		Timestamp:     msg.Timestamp,
+		Redelivered:   msg.Redelivered,
+		RetryCount:    retryCountFromHeaders(msg.Headers),
+		Body:          msg.Body,
+	}
+	if d.CorrelationID == "" {
+		d.CorrelationID = msg.CorrelationId
+	}
+	// Un mensaje sin correlacion (publicado por fuera de este backend) abre su
+	// propio flujo.
+	if d.CorrelationID == "" {
+		d.CorrelationID = d.MessageID
+	}
+	return d
+}
+
+// deliveryContext deriva el ctx del handler: lo que se publique dentro hereda
+// el correlation ID y tiene a este mensaje como causa.
+func deliveryContext(parent context.Context, d Delivery) context.Context {
+	ctx := parent
+	if d.CorrelationID != "" {
+		ctx = log.WithCorrelationIDCtx(ctx, d.CorrelationID)
+	}
+	if d.MessageID != "" {
+		ctx = log.WithCausationIDCtx(ctx, d.MessageID)
+	}
+	if d.BusinessID != 0 {
+		ctx = log.WithBusinessIDCtx(ctx, d.BusinessID)
+	}
+	return ctx
+}
+
+// stampPublishing completa MessageId, Timestamp y los headers de correlacion
+// de un mensaje saliente. Los headers que ya trae (p.ej. los que guardo el
+// outbox) tienen prioridad sobre el ctx.
+func stampPublishing(ctx context.Context, p *amqp.Publishing) {
+	if p.MessageId == "" {
+		p.MessageId = uuid.New().String()
+	}
+	if p.Timestamp.IsZero() {
+		p.Timestamp = time.Now().UTC()
+	}
+
+	headers := copyHeaders(p.Headers)
+	for key, value := range CorrelationHeaders(ctx) {
+		if _, ok := headers[key]; !ok {
+			headers[key] = value
+		}
+	}
+
+	correlationID := headerString(headers, HeaderCorrelationID)
+	if correlationID == "" {
+		correlationID = p.MessageId
+		headers[HeaderCorrelationID] = correlationID
+	}
+	if p.CorrelationId == "" {
+		p.CorrelationId = correlationID
+	}
+
+	// El traceparent del ctx (el span de publicacion) reemplaza al guardado
+	injectTraceContext(ctx, headers)
+
+	p.Headers = headers
+}
+
+func headerString(headers amqp.Table, key string) string {
+	if headers == nil {
+		return ""
+	}
+	switch v := headers[key].(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case nil:
+		return ""
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func headerUint(headers amqp.Table, key string) uint {
+	value := headerString(headers, key)
+	if value == "" {
+		return 0
+	}
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return uint(parsed)
+}
@@ -0,0 +1,159 @@
+package rabbitmq
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestNewDelivery_TraeLosMetadatosDelMensaje(t *testing.T) {
+	ts := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	d := newDelivery("orders.events.invoicing", amqp.Delivery{
+		MessageId:   "msg-2",
+		ContentType: "application/json",
+		Timestamp:   ts,
+		Redelivered: true,
+		Headers: amqp.Table{
+			HeaderCorrelationID: "corr-1",
+			HeaderCausationID:   "msg-1",
+			HeaderBusinessID:    "42",
+			HeaderRetryCount:    int32(2),
+		},
+		Body: []byte(`{}`),
+	})
+
+	assert.Equal(t, "orders.events.invoicing", d.Queue)
+	assert.Equal(t, "msg-2", d.MessageID)
+	assert.Equal(t, "corr-1", d.CorrelationID)
+	assert.Equal(t, "msg-1", d.CausationID)
+	assert.Equal(t, uint(42), d.BusinessID)
+	assert.Equal(t, ts, d.Timestamp)
+	assert.True(t, d.Redelivered)
+	assert.Equal(t, 2, d.RetryCount)
+}
+
+func TestNewDelivery_SinCorrelacionAbreUnFlujoNuevo(t *testing.T) {
+	d := newDelivery("q", amqp.Delivery{MessageId: "msg-1"})
+
+	assert.Equal(t, "msg-1", d.CorrelationID)
+}
+
+func TestNewDelivery_BusinessIDNumericoDelOutbox(t *testing.T) {
+	// El outbox guarda los headers como JSON: los numeros vuelven como float64.
+	d := newDelivery("q", amqp.Delivery{Headers: amqp.Table{HeaderBusinessID: float64(7)}})
+
+	assert.Equal(t, uint(7), d.BusinessID)
+}
+
+func TestDeliveryContext_ElMensajeEsLaCausaDeLoQueSePublique(t *testing.T) {
+	ctx := deliveryContext(context.Background(), Delivery{MessageID: "msg-2", CorrelationID: "corr-1", BusinessID: 42})
+
+	headers := CorrelationHeaders(ctx)
+
+	assert.Equal(t, "corr-1", headers[HeaderCorrelationID])
+	assert.Equal(t, "msg-2", headers[HeaderCausationID])
+	assert.Equal(t, "42", headers[HeaderBusinessID])
+}
+
+func TestCorrelationHeaders_CtxVacioNoAgregaNada(t *testing.T) {
+	assert.Nil(t, CorrelationHeaders(context.Background()))
+}
+
+func TestStampPublishing_TomaLaCorrelacionDelCtx(t *testing.T) {
+	ctx := log.WithCorrelationIDCtx(context.Background(), "corr-1")
+	p := amqp.Publishing{Body: []byte(`{}`)}
+
+	stampPublishing(ctx, &p)
+
+	assert.NotEmpty(t, p.MessageId)
+	assert.False(t, p.Timestamp.IsZero())
+	assert.Equal(t, "corr-1", p.CorrelationId)
+	assert.Equal(t, "corr-1", p.Headers[HeaderCorrelationID])
+}
+
+func TestStampPublishing_SinCorrelacionUsaElMessageID(t *testing.T) {
+	p := amqp.Publishing{MessageId: "msg-1"}
+
+	stampPublishing(context.Background(), &p)
+
+	assert.Equal(t, "msg-1", p.CorrelationId)
+	assert.Equal(t, "msg-1", p.Headers[HeaderCorrelationID])
+}
+
+func TestStampPublishing_LosHeadersDelMensajeGananAlCtx(t *testing.T) {
+	// El relay del outbox publica con un ctx propio: la correlacion buena es
+	// la que se guardo con el evento.
+	ctx := log.WithCorrelationIDCtx(context.Background(), "corr-relay")
+	p := amqp.Publishing{Headers: amqp.Table{HeaderCorrelationID: "corr-orden"}}
+
+	stampPublishing(ctx, &p)
+
+	assert.Equal(t, "corr-orden", p.CorrelationId)
+}
+
+type colaSoloBytes struct {
+	IQueue
+	handler func([]byte) error
+}
+
+func (c *colaSoloBytes) ConsumeConcurrent(ctx context.Context, queueName string, handler func([]byte) error, workers int) error {
+	c.handler = handler
+	return nil
+}
+
+func TestConsumeDeliveries_SinSoporteCaeAConsumeConcurrent(t *testing.T) {
+	cola := &colaSoloBytes{}
+	var recibido Delivery
+
+	err := ConsumeDeliveries(context.Background(), cola, "q", func(_ context.Context, d Delivery) error {
+		recibido = d
+		return nil
+	}, 2)
+
+	assert.NoError(t, err)
+	assert.NoError(t, cola.handler([]byte(`{"a":1}`)))
+	assert.Equal(t, "q", recibido.Queue)
+	assert.Equal(t, []byte(`{"a":1}`), recibido.Body)
+}
+
+func TestTrazas_ElRelayContinuaLaTrazaGuardadaEnElOutbox(t *testing.T) {
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	}()
+
+	// El request que encola el evento
+	reqCtx, reqSpan := otel.Tracer("test").Start(context.Background(), "POST /orders")
+	guardados := CorrelationHeaders(reqCtx)
+	reqSpan.End()
+
+	// El relay publica despues con un ctx sin span
+	p := amqp.Publishing{Headers: amqp.Table(guardados)}
+	ctx, span := startPublishSpan(context.Background(), p.Headers, ExchangeOrderEvents, "")
+	stampPublishing(ctx, &p)
+	endSpan(span, &p, nil)
+
+	// El consumer arranca su span desde los headers publicados
+	_, consumeSpan := startConsumeSpan(context.Background(), newDelivery("orders.events.invoicing", amqp.Delivery{Headers: p.Headers}))
+	consumeSpan.End()
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 3) {
+		traceID := reqSpan.SpanContext().TraceID()
+		assert.Equal(t, traceID, spans[1].SpanContext().TraceID(), "la publicacion cuelga del request")
+		assert.Equal(t, traceID, spans[2].SpanContext().TraceID(), "el consumer cuelga de la publicacion")
+		assert.Equal(t, spans[1].SpanContext().SpanID(), spans[2].Parent().SpanID())
+	}
+}
@@ -0,0 +1,322 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// DeadLetterQueueStats resume el estado de la DLQ de una cola.
+type DeadLetterQueueStats struct {
+	Queue           string
+	DeadLetterQueue string
+	Messages        int
+}
+
+// DeadLetterMessage es un mensaje de la DLQ tal como lo ve el admin.
+type DeadLetterMessage struct {
+	MessageID     string
+	OriginalQueue string
+	RetryCount    int
+	Permanent     bool
+	LastError     string
+	FailedAt      string
+	ContentType   string
+	Headers       map[string]interface{}
+	Body          []byte
+}
+
+// IDeadLetterAdmin opera las DLQ de las colas declaradas con DeclareQueue.
+// Se obtiene con AsDeadLetterAdmin para no ensanchar IQueue.
+type IDeadLetterAdmin interface {
+	ListDeadLetterQueues(ctx context.Context) ([]DeadLetterQueueStats, error)
+	PeekDeadLetters(ctx context.Context, queueName string, limit int) ([]DeadLetterMessage, error)
+	ReplayDeadLetters(ctx context.Context, queueName string, messageID string, limit int) (int, error)
+	PurgeDeadLetters(ctx context.Context, queueName string) (int, error)
+}
+
+// AsDeadLetterAdmin expone la administracion de DLQ de una IQueue, si la soporta.
+func AsDeadLetterAdmin(queue IQueue) (IDeadLetterAdmin, bool) {
+	admin, ok := queue.(IDeadLetterAdmin)
+	return admin, ok
+}
+
+// failureConfirmTimeout acota la espera del ACK del broker al mover un mensaje
+// fallido: el ctx del consumidor vive lo que vive el proceso.
+const failureConfirmTimeout = 10 * time.Second
+
+// handleFailure decide el destino de un mensaje cuyo handler fallo: el
+// siguiente escalon de reintento o la DLQ. Solo hace ACK cuando el broker
+// confirmo que el mensaje quedo en su nuevo destino; si no, vuelve al requeue
+// inmediato.
+func (r *rabbitMQ) handleFailure(ctx context.Context, queueName string, msg amqp.Delivery, handlerErr error) {
+	r.retryMu.RLock()
+	hasRetry := r.retryQueues[queueName]
+	r.retryMu.RUnlock()
+
+	if !hasRetry {
+		r.logger.Debug().
+			Str("queue", queueName).
+			Msg("Message processing FAILED - queue has no retry topology, will be requeued")
+		msg.Nack(false, true)
+		return
+	}
+
+	retries := retryCountFromHeaders(msg.Headers)
+	permanent := IsPermanent(handlerErr)
+	decision, tier := decidirFallo(retries, permanent, r.retryPolicy)
+
+	headers := copyHeaders(msg.Headers)
+	headers[HeaderOriginalQueue] = queueName
+	headers[HeaderLastError] = truncateError(handlerErr)
+
+	routingKey := deadLetterRouting
+	if decision == falloReintentar {
+		headers[HeaderRetryCount] = int32(retries + 1)
+		routingKey = retryTierLabel(tier)
+	} else {
+		headers[HeaderRetryCount] = int32(retries)
+		headers[HeaderFailedAt] = time.Now().UTC().Format(time.RFC3339)
+		headers[HeaderPermanent] = permanent
+	}
+
+	publishing := republishing(msg, headers)
+	if publishing.MessageId == "" {
+		publishing.MessageId = uuid.New().String()
+	}
+
+	confirmCtx, cancel := context.WithTimeout(ctx, failureConfirmTimeout)
+	err := r.publishWithConfirm(confirmCtx, RetryExchangeName(queueName), routingKey, publishing)
+	cancel()
+	if err != nil {
+		r.logger.Error().
+			Err(err).
+			Str("queue", queueName).
+			Str("decision", string(decision)).
+			Msg("Failed to route failed message to retry/DLQ - will be requeued")
+		msg.Nack(false, true)
+		return
+	}
+
+	evento := r.logger.Warn().
+		Str("queue", queueName).
+		Str("message_id", publishing.MessageId).
+		Int("retry_count", retries).
+		Bool("permanent", permanent)
+	if decision == falloReintentar {
+		evento.Dur("delay", tier).Msg("Message processing FAILED - scheduled for delayed retry")
+	} else {
+		evento.Msg("Message processing FAILED - moved to dead letter queue")
+	}
+
+	msg.Ack(false)
+}
+
+func (r *rabbitMQ) retryQueueNames() []string {
+	r.retryMu.RLock()
+	defer r.retryMu.RUnlock()
+
+	names := make([]string, 0, len(r.retryQueues))
+	for name := range r.retryQueues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (r *rabbitMQ) adminChannel() (*amqp.Channel, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.conn == nil || r.conn.IsClosed() {
+		return nil, fmt.Errorf("rabbitmq connection is closed")
+	}
+	ch, err := r.conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create channel: %w", err)
+	}
+	return ch, nil
+}
+
+func (r *rabbitMQ) ensureRetryQueue(queueName string) error {
+	r.retryMu.RLock()
+	defer r.retryMu.RUnlock()
+
+	if !r.retryQueues[queueName] {
+		return fmt.Errorf("la cola %s no tiene DLQ declarada en este proceso", queueName)
+	}
+	return nil
+}
+
+func (r *rabbitMQ) ListDeadLetterQueues(ctx context.Context) ([]DeadLetterQueueStats, error) {
+	names := r.retryQueueNames()
+	out := make([]DeadLetterQueueStats, 0, len(names))
+
+	for _, name := range names {
+		// QueueDeclarePassive cierra el canal si la cola no existe, por eso
+		// cada consulta va en su propio canal.
+		ch, err := r.adminChannel()
+		if err != nil {
+			return nil, err
+		}
+		q, err := ch.QueueDeclarePassive(DeadLetterQueueName(name), true, false, false, false, nil)
+		ch.Close()
+		if err != nil {
+			r.logger.Warn(ctx).
+				Err(err).
+				Str("queue", name).
+				Msg("No se pudo inspeccionar la DLQ")
+			continue
+		}
+		out = append(out, DeadLetterQueueStats{
+			Queue:           name,
+			DeadLetterQueue: q.Name,
+			Messages:        q.Messages,
+		})
+	}
+
+	return out, nil
+}
+
+// PeekDeadLetters lee hasta limit mensajes sin consumirlos: los toma sin ACK y
+// al cerrar el canal el broker los devuelve a la DLQ en el mismo orden.
+func (r *rabbitMQ) PeekDeadLetters(ctx context.Context, queueName string, limit int) ([]DeadLetterMessage, error) {
+	if err := r.ensureRetryQueue(queueName); err != nil {
+		return nil, err
+	}
+	if limit < 1 {
+		limit = 1
+	}
+
+	ch, err := r.adminChannel()
+	if err != nil {
+		return nil, err
+	}
+	defer ch.Close()
+
+	out := make([]DeadLetterMessage, 0, limit)
+	for len(out) < limit {
+		msg, ok, err := ch.Get(DeadLetterQueueName(queueName), false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read dead letter queue: %w", err)
+		}
+		if !ok {
+			break
+		}
+		out = append(out, toDeadLetterMessage(msg))
+	}
+
+	return out, nil
+}
+
+// ReplayDeadLetters devuelve mensajes de la DLQ a su cola original con el
+// contador de reintentos en cero. Si messageID viene, solo reenvia ese mensaje
+// (revisando como maximo limit mensajes); si no, reenvia hasta limit mensajes.
+func (r *rabbitMQ) ReplayDeadLetters(ctx context.Context, queueName string, messageID string, limit int) (int, error) {
+	if err := r.ensureRetryQueue(queueName); err != nil {
+		return 0, err
+	}
+	if limit < 1 {
+		limit = 1
+	}
+
+	ch, err := r.adminChannel()
+	if err != nil {
+		return 0, err
+	}
+	defer ch.Close()
+
+	replayed := 0
+	for scanned := 0; scanned < limit; scanned++ {
+		msg, ok, err := ch.Get(DeadLetterQueueName(queueName), false)
+		if err != nil {
+			return replayed, fmt.Errorf("failed to read dead letter queue: %w", err)
+		}
+		if !ok {
+			break
+		}
+		if messageID != "" && msg.MessageId != messageID {
+			// Queda sin ACK: vuelve a la DLQ cuando se cierre el canal.
+			continue
+		}
+
+		headers := copyHeaders(msg.Headers)
+		delete(headers, HeaderRetryCount)
+		delete(headers, HeaderLastError)
+		delete(headers, HeaderFailedAt)
+		delete(headers, HeaderPermanent)
+		headers[HeaderOriginalQueue] = queueName
+
+		// El ACK de la DLQ solo sale cuando el broker confirmo el reenvio
+		if err := r.publishWithConfirm(ctx, "", queueName, republishing(msg, headers)); err != nil {
+			return replayed, fmt.Errorf("failed to replay message: %w", err)
+		}
+		if err := msg.Ack(false); err != nil {
+			return replayed, fmt.Errorf("failed to ack replayed message: %w", err)
+		}
+		replayed++
+
+		if messageID != "" {
+			break
+		}
+	}
+
+	r.logger.Info(ctx).
+		Str("queue", queueName).
+		Str("message_id", messageID).
+		Int("replayed", replayed).
+		Msg("Dead letter messages replayed")
+
+	return replayed, nil
+}
+
+func (r *rabbitMQ) PurgeDeadLetters(ctx context.Context, queueName string) (int, error) {
+	if err := r.ensureRetryQueue(queueName); err != nil {
+		return 0, err
+	}
+
+	ch, err := r.adminChannel()
+	if err != nil {
+		return 0, err
+	}
+	defer ch.Close()
+
+	purged, err := ch.QueuePurge(DeadLetterQueueName(queueName), false)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge dead letter queue: %w", err)
+	}
+
+	r.logger.Warn(ctx).
+		Str("queue", queueName).
+		Int("purged", purged).
+		Msg("Dead letter queue purged")
+
+	return purged, nil
+}
+
+func toDeadLetterMessage(msg amqp.Delivery) DeadLetterMessage {
+	out := DeadLetterMessage{
+		MessageID:   msg.MessageId,
+		RetryCount:  retryCountFromHeaders(msg.Headers),
+		ContentType: msg.ContentType,
+		Headers:     map[string]interface{}(msg.Headers),
+		Body:        msg.Body,
+	}
+	if v, ok := msg.Headers[HeaderOriginalQueue].(string); ok {
+		out.OriginalQueue = v
+	}
+	if v, ok := msg.Headers[HeaderLastError].(string); ok {
+		out.LastError = v
+	}
+	if v, ok := msg.Headers[HeaderFailedAt].(string); ok {
+		out.FailedAt = v
+	}
+	if v, ok := msg.Headers[HeaderPermanent].(bool); ok {
+		out.Permanent = v
+	}
+	return out
+}
@@ -57,3 +57,23 @@ func (q *noopQueue) BindQueue(queueName string, exchangeName string, routingKey
 func (q *noopQueue) Ping() error {
 	return ErrQueueUnavailable
 }
+
+func (q *noopQueue) ListDeadLetterQueues(ctx context.Context) ([]DeadLetterQueueStats, error) {
+	return nil, ErrQueueUnavailable
+}
+
+func (q *noopQueue) PeekDeadLetters(ctx context.Context, queueName string, limit int) ([]DeadLetterMessage, error) {
+	return nil, ErrQueueUnavailable
+}
+
+func (q *noopQueue) ReplayDeadLetters(ctx context.Context, queueName string, messageID string, limit int) (int, error) {
+	return 0, ErrQueueUnavailable
+}
+
+func (q *noopQueue) PurgeDeadLetters(ctx context.Context, queueName string) (int, error) {
+	return 0, ErrQueueUnavailable
+}
+
+func (q *noopQueue) PublishConfirmed(ctx context.Context, msg ConfirmedMessage) error {
+	return ErrQueueUnavailable
+}
@@ -6,6 +6,8 @@ const (
 	ExchangeOrderEvents = "orders.events"
 
 	ExchangeInventory = "probability.inventory"
+
+	ExchangeReturnEvents = "returns.events"
 )
 
 const (
@@ -92,6 +94,8 @@ const (
 	QueueInvoicingWorldOfficeRequests = "invoicing.world_office.requests"
 
 	QueueInvoicingHelisaRequests = "invoicing.helisa.requests"
+
+	QueueInvoicingDianRequests = "invoicing.dian.requests"
 )
 
 const (
@@ -185,6 +189,10 @@ const (
 
 	QueueTiendanubeInventoryStockPush = "inventory.tiendanube.stock_push"
 
+	QueueMagentoInventoryStockPush = "inventory.magento.stock_push"
+
+	QueueFalabellaInventoryStockPush = "inventory.falabella.stock_push"
+
 	QueueProductsProviderUpsert = "products.provider_upsert.requests"
 
 	QueueTiktokShopSnapshots = "integrations.tiktok.shop_snapshots"
@@ -198,6 +206,10 @@ const (
 	QueueMessagingEmailRequests = "messaging.email.requests"
 )
 
+const (
+	QueueEventsWebhookDeliveries = "events.webhooks.deliveries"
+)
+
 const (
 	QueueNotificationDeliveryResults = "notification.delivery.results"
 )
@@ -35,7 +35,7 @@ type QueueRegistryCallback func(queueName string)
 
 type consumerRegistration struct {
 	queueName string
-	handler   func([]byte) error
+	handler   DeliveryHandler
 	ctx       context.Context
 	workers   int
 }
@@ -51,6 +51,14 @@ type rabbitMQ struct {
 	consumers []consumerRegistration
 	done      chan struct{}
 	connEpoch uint64
+
+	retryPolicy RetryPolicy
+	retryMu     sync.RWMutex
+	retryQueues map[string]bool
+
+	confirmMu    sync.Mutex
+	confirmCh    *amqp.Channel
+	confirmEpoch uint64
 }
 
 func New(logger log.ILogger, config env.IConfig) (IQueue, error) {
@@ -59,6 +67,9 @@ func New(logger log.ILogger, config env.IConfig) (IQueue, error) {
 		config:    config,
 		consumers: make([]consumerRegistration, 0),
 		done:      make(chan struct{}),
+
+		retryPolicy: DefaultRetryPolicy,
+		retryQueues: make(map[string]bool),
 	}
 
 	if err := r.connect(); err != nil {
@@ -284,7 +295,7 @@ func (r *rabbitMQ) reregisterConsumers() {
 	}
 }
 
-func (r *rabbitMQ) startConsumer(ctx context.Context, queueName string, handler func([]byte) error, workers int) error {
+func (r *rabbitMQ) startConsumer(ctx context.Context, queueName string, handler DeliveryHandler, workers int) error {
 	if workers < 1 {
 		workers = 1
 	}
@@ -346,22 +357,26 @@ func (r *rabbitMQ) startConsumer(ctx context.Context, queueName string, handler
 						return
 					}
 
-					r.logger.Debug().
+					delivery := newDelivery(queueName, msg)
+					msgCtx, span := startConsumeSpan(deliveryContext(ctx, delivery), delivery)
+
+					r.logger.Debug(msgCtx).
 						Str("queue", queueName).
 						Int("worker", workerID).
+						Str("message_id", delivery.MessageID).
 						Int("message_size", len(msg.Body)).
 						Msg("Message received from queue - processing")
 
-					if err := handler(msg.Body); err != nil {
-						r.logger.Error().
+					err := handler(msgCtx, delivery)
+					endSpan(span, nil, err)
+					if err != nil {
+						r.logger.Error(msgCtx).
 							Err(err).
 							Str("queue", queueName).
+							Str("message_id", delivery.MessageID).
+							Int("retry_count", delivery.RetryCount).
 							Msg("Error processing message")
-						r.logger.Debug().
-							Err(err).
-							Str("queue", queueName).
-							Msg("Message processing FAILED - will be requeued")
-						msg.Nack(false, true)
+						r.handleFailure(ctx, queueName, msg, err)
 					} else {
 						r.logger.Debug().
 							Str("queue", queueName).
@@ -376,7 +391,7 @@ func (r *rabbitMQ) startConsumer(ctx context.Context, queueName string, handler
 	return nil
 }
 
-func (r *rabbitMQ) watchConsumerChannel(ctx context.Context, ch *amqp.Channel, queueName string, handler func([]byte) error, workers int, epochAlStart uint64) {
+func (r *rabbitMQ) watchConsumerChannel(ctx context.Context, ch *amqp.Channel, queueName string, handler DeliveryHandler, workers int, epochAlStart uint64) {
 	closeChan := ch.NotifyClose(make(chan *amqp.Error, 1))
 
 	go func() {
@@ -419,7 +434,7 @@ func (r *rabbitMQ) watchConsumerChannel(ctx context.Context, ch *amqp.Channel, q
 	}()
 }
 
-func (r *rabbitMQ) restartConsumer(ctx context.Context, queueName string, handler func([]byte) error, workers int, epochAlStart uint64) {
+func (r *rabbitMQ) restartConsumer(ctx context.Context, queueName string, handler DeliveryHandler, workers int, epochAlStart uint64) {
 	backoff := time.Second
 	maxBackoff := 30 * time.Second
 
@@ -474,17 +489,22 @@ func (r *rabbitMQ) Publish(ctx context.Context, queueName string, message []byte
 		return fmt.Errorf("rabbitmq channel is not initialized")
 	}
 
+	publishing := amqp.Publishing{
+		ContentType: "application/json",
+		Body:        message,
+	}
+	ctx, span := startPublishSpan(ctx, nil, "", queueName)
+	stampPublishing(ctx, &publishing)
+
 	err := r.channel.PublishWithContext(
 		ctx,
 		"",
 		queueName,
 		false,
 		false,
-		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        message,
-		},
+		publishing,
 	)
+	endSpan(span, &publishing, err)
 
 	if err != nil {
 		r.logger.Error().
@@ -495,8 +515,9 @@ func (r *rabbitMQ) Publish(ctx context.Context, queueName string, message []byte
 		return fmt.Errorf("failed to publish message: %w", err)
 	}
 
-	r.logger.Info().
+	r.logger.Info(ctx).
 		Str("queue", queueName).
+		Str("message_id", publishing.MessageId).
 		Int("message_size", len(message)).
 		Msg("Message published to queue")
 
@@ -508,6 +529,14 @@ func (r *rabbitMQ) Consume(ctx context.Context, queueName string, handler func([
 }
 
 func (r *rabbitMQ) ConsumeConcurrent(ctx context.Context, queueName string, handler func([]byte) error, workers int) error {
+	return r.ConsumeDeliveries(ctx, queueName, func(_ context.Context, d Delivery) error {
+		return handler(d.Body)
+	}, workers)
+}
+
+// ConsumeDeliveries es la forma completa de ConsumeConcurrent: el handler
+// recibe el ctx con la correlacion del mensaje y sus metadatos AMQP.
+func (r *rabbitMQ) ConsumeDeliveries(ctx context.Context, queueName string, handler DeliveryHandler, workers int) error {
 	if workers < 1 {
 		workers = 1
 	}
@@ -573,6 +602,19 @@ func (r *rabbitMQ) DeclareQueue(queueName string, durable bool) error {
 		return fmt.Errorf("failed to declare queue: %w", err)
 	}
 
+	// La topologia de reintentos es best-effort: si no se puede declarar, la
+	// cola sigue funcionando con el requeue inmediato de siempre.
+	if err := declareRetryTopology(ch, queueName, durable, r.retryPolicy); err != nil {
+		r.logger.Error().
+			Err(err).
+			Str("queue", queueName).
+			Msg("Error al declarar reintentos y DLQ de la cola - fallos se reencolaran de inmediato")
+	} else {
+		r.retryMu.Lock()
+		r.retryQueues[queueName] = true
+		r.retryMu.Unlock()
+	}
+
 	if r.queueRegistry != nil {
 		r.queueRegistry(queueName)
 	}
@@ -601,17 +643,22 @@ func (r *rabbitMQ) PublishToExchange(ctx context.Context, exchangeName string, r
 		return fmt.Errorf("rabbitmq channel is not initialized")
 	}
 
+	publishing := amqp.Publishing{
+		ContentType: "application/json",
+		Body:        message,
+	}
+	ctx, span := startPublishSpan(ctx, nil, exchangeName, routingKey)
+	stampPublishing(ctx, &publishing)
+
 	err := r.channel.PublishWithContext(
 		ctx,
 		exchangeName,
 		routingKey,
 		false,
 		false,
-		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        message,
-		},
+		publishing,
 	)
+	endSpan(span, &publishing, err)
 
 	if err != nil {
 		r.logger.Error().
@@ -623,9 +670,10 @@ func (r *rabbitMQ) PublishToExchange(ctx context.Context, exchangeName string, r
 		return fmt.Errorf("failed to publish message: %w", err)
 	}
 
-	r.logger.Info().
+	r.logger.Info(ctx).
 		Str("exchange", exchangeName).
 		Str("routing_key", routingKey).
+		Str("message_id", publishing.MessageId).
 		Int("message_size", len(message)).
 		Msg("Message published to exchange")
 
@@ -0,0 +1,217 @@
+package rabbitmq
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// Headers que viajan con un mensaje cuando pasa por los reintentos o termina
+// en la DLQ. Sirven para saber cuantas veces se intento y por que se rindio.
+const (
+	HeaderRetryCount    = "x-retry-count"
+	HeaderOriginalQueue = "x-original-queue"
+	HeaderLastError     = "x-last-error"
+	HeaderFailedAt      = "x-failed-at"
+	HeaderPermanent     = "x-permanent-failure"
+)
+
+const (
+	retryExchangeSuffix = ".retry"
+	deadLetterSuffix    = ".dlq"
+	deadLetterRouting   = "dlq"
+
+	// maxErrorHeaderLen acota el error que se guarda en el header: un stack de
+	// SoftPymes completo no cabe en un frame AMQP razonable.
+	maxErrorHeaderLen = 1000
+)
+
+// RetryPolicy define los escalones de espera antes de devolver un mensaje a su
+// cola. Cada escalon es una cola con TTL cuyo dead-letter apunta de vuelta a la
+// cola original; cuando se agotan los escalones el mensaje va a <cola>.dlq.
+type RetryPolicy struct {
+	Tiers []time.Duration
+}
+
+// DefaultRetryPolicy es la politica de todas las colas declaradas con
+// DeclareQueue: 10s, 1m y 10m. Con el intento original son 4 intentos.
+var DefaultRetryPolicy = RetryPolicy{
+	Tiers: []time.Duration{10 * time.Second, time.Minute, 10 * time.Minute},
+}
+
+// MaxAttempts es el total de veces que un mensaje se entrega al handler.
+func (p RetryPolicy) MaxAttempts() int {
+	return len(p.Tiers) + 1
+}
+
+// RetryExchangeName es el exchange direct que reparte a los escalones y a la DLQ.
+func RetryExchangeName(queueName string) string {
+	return queueName + retryExchangeSuffix
+}
+
+// DeadLetterQueueName es la cola donde terminan los mensajes que agotaron los
+// reintentos o que el handler marco como fallo permanente.
+func DeadLetterQueueName(queueName string) string {
+	return queueName + deadLetterSuffix
+}
+
+func retryTierLabel(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	default:
+		return fmt.Sprintf("%ds", int(d/time.Second))
+	}
+}
+
+func retryTierQueueName(queueName string, d time.Duration) string {
+	return queueName + retryExchangeSuffix + "." + retryTierLabel(d)
+}
+
+// permanentError marca un error que no se arregla reintentando (payload
+// invalido, negocio inexistente, credenciales revocadas...).
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent envuelve el error de un handler para que el mensaje vaya directo a
+// la DLQ sin pasar por los reintentos.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent indica si el error (o alguno de los que envuelve) se marco con Permanent.
+func IsPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
+
+type decisionFallo string
+
+const (
+	falloReintentar decisionFallo = "retry"
+	falloDLQ        decisionFallo = "dead_letter"
+)
+
+// decidirFallo resuelve que hacer con un mensaje cuyo handler fallo. reintentos
+// es cuantos escalones ya recorrio; devuelve el escalon al que debe ir.
+func decidirFallo(reintentos int, permanente bool, policy RetryPolicy) (decisionFallo, time.Duration) {
+	if permanente || reintentos < 0 || reintentos >= len(policy.Tiers) {
+		return falloDLQ, 0
+	}
+	return falloReintentar, policy.Tiers[reintentos]
+}
+
+// retryCountFromHeaders lee el contador de reintentos; tolera los tipos
+// numericos con los que la libreria puede decodificar la tabla.
+func retryCountFromHeaders(headers amqp.Table) int {
+	if headers == nil {
+		return 0
+	}
+	switch v := headers[HeaderRetryCount].(type) {
+	case int:
+		return v
+	case int8:
+		return int(v)
+	case int16:
+		return int(v)
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case uint8:
+		return int(v)
+	case uint16:
+		return int(v)
+	case uint32:
+		return int(v)
+	default:
+		return 0
+	}
+}
+
+func truncateError(err error) string {
+	msg := err.Error()
+	if len(msg) > maxErrorHeaderLen {
+		return msg[:maxErrorHeaderLen]
+	}
+	return msg
+}
+
+// republishing copia las propiedades del mensaje original para que el
+// reintento llegue igual al handler, con los headers de control actualizados.
+func republishing(msg amqp.Delivery, headers amqp.Table) amqp.Publishing {
+	return amqp.Publishing{
+		Headers:         headers,
+		ContentType:     msg.ContentType,
+		ContentEncoding: msg.ContentEncoding,
+		DeliveryMode:    amqp.Persistent,
+		Priority:        msg.Priority,
+		CorrelationId:   msg.CorrelationId,
+		ReplyTo:         msg.ReplyTo,
+		MessageId:       msg.MessageId,
+		Timestamp:       msg.Timestamp,
+		Type:            msg.Type,
+		AppId:           msg.AppId,
+		Body:            msg.Body,
+	}
+}
+
+func copyHeaders(headers amqp.Table) amqp.Table {
+	out := amqp.Table{}
+	for k, v := range headers {
+		out[k] = v
+	}
+	return out
+}
+
+// declareRetryTopology declara el exchange de reintentos, una cola con TTL por
+// escalon (cuyo dead-letter devuelve el mensaje a la cola original) y la DLQ.
+// La cola original no se toca: cambiarle argumentos a una cola existente hace
+// fallar el QueueDeclare con PRECONDITION_FAILED.
+func declareRetryTopology(ch *amqp.Channel, queueName string, durable bool, policy RetryPolicy) error {
+	exchange := RetryExchangeName(queueName)
+	if err := ch.ExchangeDeclare(exchange, "direct", durable, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare retry exchange: %w", err)
+	}
+
+	for _, tier := range policy.Tiers {
+		tierQueue := retryTierQueueName(queueName, tier)
+		args := amqp.Table{
+			"x-message-ttl":             int64(tier / time.Millisecond),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": queueName,
+		}
+		if _, err := ch.QueueDeclare(tierQueue, durable, false, false, false, args); err != nil {
+			return fmt.Errorf("failed to declare retry queue %s: %w", tierQueue, err)
+		}
+		if err := ch.QueueBind(tierQueue, retryTierLabel(tier), exchange, false, nil); err != nil {
+			return fmt.Errorf("failed to bind retry queue %s: %w", tierQueue, err)
+		}
+	}
+
+	dlq := DeadLetterQueueName(queueName)
+	if _, err := ch.QueueDeclare(dlq, durable, false, false, false, nil); err != nil {
+		return fmt.Errorf("failed to declare dead letter queue: %w", err)
+	}
+	if err := ch.QueueBind(dlq, deadLetterRouting, exchange, false, nil); err != nil {
+		return fmt.Errorf("failed to bind dead letter queue: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package rabbitmq
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecidirFallo_RecorreLosEscalonesEnOrden(t *testing.T) {
+	policy := DefaultRetryPolicy
+
+	for reintentos, esperado := range []time.Duration{10 * time.Second, time.Minute, 10 * time.Minute} {
+		decision, escalon := decidirFallo(reintentos, false, policy)
+
+		assert.Equal(t, falloReintentar, decision)
+		assert.Equal(t, esperado, escalon)
+	}
+}
+
+func TestDecidirFallo_AgotadosLosEscalonesVaALaDLQ(t *testing.T) {
+	decision, _ := decidirFallo(3, false, DefaultRetryPolicy)
+
+	assert.Equal(t, falloDLQ, decision,
+		"es el caso de los timeouts de SoftPymes: antes el Nack con requeue lo devolvia a la cola para siempre y dejaba un worker girando")
+	assert.Equal(t, 4, DefaultRetryPolicy.MaxAttempts())
+}
+
+func TestDecidirFallo_UnErrorPermanenteNoSeReintenta(t *testing.T) {
+	decision, _ := decidirFallo(0, true, DefaultRetryPolicy)
+
+	assert.Equal(t, falloDLQ, decision,
+		"un payload invalido falla igual dentro de 10 minutos: reintentarlo solo retrasa que alguien lo vea")
+}
+
+func TestDecidirFallo_SinEscalonesTodoFalloVaALaDLQ(t *testing.T) {
+	decision, _ := decidirFallo(0, false, RetryPolicy{})
+
+	assert.Equal(t, falloDLQ, decision)
+}
+
+func TestIsPermanent_SobreviveAlWrapping(t *testing.T) {
+	base := errors.New("orden sin items")
+	err := fmt.Errorf("procesando factura: %w", Permanent(base))
+
+	assert.True(t, IsPermanent(err))
+	assert.ErrorIs(t, err, base, "Permanent no debe esconder el error original a errors.Is")
+	assert.False(t, IsPermanent(base))
+	assert.Nil(t, Permanent(nil))
+}
+
+func TestRetryCountFromHeaders_ToleraLosTiposNumericosDeAMQP(t *testing.T) {
+	assert.Equal(t, 0, retryCountFromHeaders(nil))
+	assert.Equal(t, 0, retryCountFromHeaders(amqp.Table{}))
+	assert.Equal(t, 2, retryCountFromHeaders(amqp.Table{HeaderRetryCount: int32(2)}))
+	assert.Equal(t, 3, retryCountFromHeaders(amqp.Table{HeaderRetryCount: int64(3)}))
+	assert.Equal(t, 0, retryCountFromHeaders(amqp.Table{HeaderRetryCount: "2"}),
+		"un header corrupto cuenta como primer intento en vez de tumbar el consumidor")
+}
+
+func TestNombresDeLaTopologiaDeReintentos(t *testing.T) {
+	cola := QueueInvoicingSoftpymesRequests
+
+	assert.Equal(t, "invoicing.softpymes.requests.retry", RetryExchangeName(cola))
+	assert.Equal(t, "invoicing.softpymes.requests.dlq", DeadLetterQueueName(cola))
+	assert.Equal(t, "invoicing.softpymes.requests.retry.10s", retryTierQueueName(cola, 10*time.Second))
+	assert.Equal(t, "invoicing.softpymes.requests.retry.1m", retryTierQueueName(cola, time.Minute))
+	assert.Equal(t, "invoicing.softpymes.requests.retry.10m", retryTierQueueName(cola, 10*time.Minute))
+}
@@ -0,0 +1,104 @@
+package rabbitmq
+
+import (
+	"context"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/secamc93/probability/back/central/shared/rabbitmq"
+
+// headerCarrier adapta los headers AMQP al TextMapCarrier de OpenTelemetry
+// para llevar el traceparent de un servicio a otro.
+type headerCarrier amqp.Table
+
+func (c headerCarrier) Get(key string) string {
+	return headerString(amqp.Table(c), key)
+}
+
+func (c headerCarrier) Set(key string, value string) {
+	c[key] = value
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// startPublishSpan abre el span de productor. Si el ctx no trae span (el
+// relay del outbox) continua la traza guardada en los headers del mensaje.
+func startPublishSpan(ctx context.Context, headers amqp.Table, exchange string, routingKey string) (context.Context, trace.Span) {
+	if !trace.SpanContextFromContext(ctx).IsValid() && len(headers) > 0 {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier(headers))
+	}
+
+	destination := routingKey
+	if exchange != "" {
+		destination = exchange
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, destination+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.operation.type", "send"),
+			attribute.String("messaging.destination.name", destination),
+			attribute.String("messaging.rabbitmq.destination.routing_key", routingKey),
+		),
+	)
+}
+
+// startConsumeSpan abre el span de consumo como hijo de la traza que viene
+// en los headers del mensaje.
+func startConsumeSpan(ctx context.Context, d Delivery) (context.Context, trace.Span) {
+	if len(d.Headers) > 0 {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier(d.Headers))
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("messaging.system", "rabbitmq"),
+		attribute.String("messaging.operation.type", "process"),
+		attribute.String("messaging.destination.name", d.Queue),
+		attribute.String("messaging.message.id", d.MessageID),
+		attribute.Int("messaging.rabbitmq.retry_count", d.RetryCount),
+		attribute.Bool("messaging.rabbitmq.redelivered", d.Redelivered),
+	}
+	if d.CorrelationID != "" {
+		attrs = append(attrs, attribute.String("probability.correlation_id", d.CorrelationID))
+	}
+	if d.BusinessID != 0 {
+		attrs = append(attrs, attribute.Int64("probability.business_id", int64(d.BusinessID)))
+	}
+
+	return otel.Tracer(tracerName).Start(ctx, d.Queue+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// injectTraceContext escribe el traceparent del ctx en los headers.
+func injectTraceContext(ctx context.Context, headers amqp.Table) {
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier(headers))
+}
+
+// endSpan cierra el span registrando el error, si lo hubo.
+func endSpan(span trace.Span, p *amqp.Publishing, err error) {
+	if p != nil {
+		span.SetAttributes(attribute.String("messaging.message.id", p.MessageId))
+		if correlationID := headerString(p.Headers, HeaderCorrelationID); correlationID != "" {
+			span.SetAttributes(attribute.String("probability.correlation_id", correlationID))
+		}
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
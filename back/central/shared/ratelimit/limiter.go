@@ -18,6 +18,24 @@ type Decision struct {
 
 type Limiter interface {
 	Check(ctx context.Context, key string) Decision
+	// CheckRate es Check con un ritmo propio para la clave en lugar del de
+	// Config; si el ritmo de la clave cambia se ajusta sin perder su estado.
+	CheckRate(ctx context.Context, key string, r Rate) Decision
+}
+
+// Rate es el ritmo de un token bucket: PerSec tokens por segundo con rafaga
+// maxima de Burst.
+type Rate struct {
+	PerSec float64
+	Burst  int
+}
+
+// PerMinute arma el ritmo de n requests por minuto, con rafaga de n.
+func PerMinute(n int) Rate {
+	if n <= 0 {
+		n = 1
+	}
+	return Rate{PerSec: float64(n) / 60, Burst: n}
 }
 
 type Config struct {
@@ -70,21 +88,34 @@ func New(cfg Config, r redis.IRedis, logger log.ILogger) Limiter {
 	return l
 }
 
-func (l *limiter) getEntry(key string, now time.Time) *entry {
+func (l *limiter) getEntry(key string, now time.Time, r Rate) *entry {
 	l.mu.Lock()
 	defer l.mu.Unlock()
 	e, ok := l.items[key]
 	if !ok {
-		e = &entry{lim: rate.NewLimiter(rate.Limit(l.cfg.RatePerSec), l.cfg.Burst)}
+		e = &entry{lim: rate.NewLimiter(rate.Limit(r.PerSec), r.Burst)}
 		l.items[key] = e
+	} else if e.lim.Limit() != rate.Limit(r.PerSec) || e.lim.Burst() != r.Burst {
+		e.lim.SetLimitAt(now, rate.Limit(r.PerSec))
+		e.lim.SetBurstAt(now, r.Burst)
 	}
 	e.lastSeen = now
 	return e
 }
 
 func (l *limiter) Check(ctx context.Context, key string) Decision {
+	return l.CheckRate(ctx, key, Rate{PerSec: l.cfg.RatePerSec, Burst: l.cfg.Burst})
+}
+
+func (l *limiter) CheckRate(ctx context.Context, key string, r Rate) Decision {
+	if r.PerSec <= 0 {
+		r.PerSec = l.cfg.RatePerSec
+	}
+	if r.Burst <= 0 {
+		r.Burst = l.cfg.Burst
+	}
 	now := time.Now()
-	e := l.getEntry(key, now)
+	e := l.getEntry(key, now, r)
 
 	l.mu.Lock()
 	if now.Before(e.blockUntil) {
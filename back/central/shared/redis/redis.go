@@ -8,6 +8,7 @@ import (
 	"github.com/redis/go-redis/v9"
 	"github.com/secamc93/probability/back/central/shared/env"
 	"github.com/secamc93/probability/back/central/shared/log"
+	"github.com/secamc93/probability/back/central/shared/tracing"
 )
 
 // CacheRegistryCallback es un callback para registrar prefijos de caché usados
@@ -114,6 +115,7 @@ func (r *redisClient) Connect(ctx context.Context) error {
 		Password: password,
 		DB:       db,
 	})
+	r.client.AddHook(tracing.NewRedisHook(addr))
 
 	// Verificar conexión
 	if err := r.Ping(ctx); err != nil {
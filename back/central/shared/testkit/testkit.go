@@ -249,7 +249,12 @@ func (d *DBMock) Connect(ctx context.Context) error { return nil }
 
 func (d *DBMock) Close() error { return nil }
 
-func (d *DBMock) Conn(ctx context.Context) *gorm.DB { return d.gorm.WithContext(ctx) }
+func (d *DBMock) Conn(ctx context.Context) *gorm.DB {
+	if tx, ok := db.TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
+	return d.gorm.WithContext(ctx)
+}
 
 func (d *DBMock) WithContext(ctx context.Context) *gorm.DB { return d.gorm.WithContext(ctx) }
 
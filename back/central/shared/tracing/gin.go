@@ -0,0 +1,70 @@
+package tracing
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// rutas tecnicas que no vale la pena trazar (las consultan Prometheus y el
+// balanceador cada pocos segundos)
+var untracedPaths = map[string]bool{
+	"/metrics": true,
+	"/health":  true,
+}
+
+// GinMiddleware abre un span de servidor por request. Respeta el traceparent
+// entrante para que el span cuelgue de la traza del llamador.
+func GinMiddleware() gin.HandlerFunc {
+	tracer := Tracer()
+	propagator := otel.GetTextMapPropagator
+
+	return func(c *gin.Context) {
+		if untracedPaths[c.Request.URL.Path] {
+			c.Next()
+			return
+		}
+
+		ctx := propagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		ctx, span := tracer.Start(ctx, fmt.Sprintf("%s %s", c.Request.Method, route),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Request.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", c.Request.URL.Path),
+				attribute.String("client.address", c.ClientIP()),
+			),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if correlationID, ok := log.CorrelationIDFromCtx(c.Request.Context()); ok {
+			span.SetAttributes(attribute.String("probability.correlation_id", correlationID))
+		}
+		if businessID, ok := c.Get("business_id"); ok {
+			span.SetAttributes(attribute.String("probability.business_id", fmt.Sprint(businessID)))
+		}
+		if len(c.Errors) > 0 {
+			span.RecordError(c.Errors.Last())
+		}
+		if status >= 500 {
+			span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", status))
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package tracing
+
+import (
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const gormSpanKey = "tracing:span"
+
+// maximo de SQL que se guarda en el span: los INSERT masivos pueden pesar MB
+const maxStatementLength = 2048
+
+type gormPlugin struct{}
+
+// GormPlugin crea un span por operacion de GORM (create, query, update,
+// delete, row, raw) que ocurra dentro de una traza. Se registra con
+// db.Use(tracing.GormPlugin()).
+func GormPlugin() gorm.Plugin {
+	return gormPlugin{}
+}
+
+func (gormPlugin) Name() string {
+	return "tracing"
+}
+
+func (p gormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	registrations := []struct {
+		operation string
+		before    func(string, func(*gorm.DB)) error
+		after     func(string, func(*gorm.DB)) error
+	}{
+		{"create", cb.Create().Before("gorm:create").Register, cb.Create().After("gorm:create").Register},
+		{"query", cb.Query().Before("gorm:query").Register, cb.Query().After("gorm:query").Register},
+		{"update", cb.Update().Before("gorm:update").Register, cb.Update().After("gorm:update").Register},
+		{"delete", cb.Delete().Before("gorm:delete").Register, cb.Delete().After("gorm:delete").Register},
+		{"row", cb.Row().Before("gorm:row").Register, cb.Row().After("gorm:row").Register},
+		{"raw", cb.Raw().Before("gorm:raw").Register, cb.Raw().After("gorm:raw").Register},
+	}
+
+	for _, r := range registrations {
+		if err := r.before("tracing:before_"+r.operation, startGormSpan(r.operation)); err != nil {
+			return err
+		}
+		if err := r.after("tracing:after_"+r.operation, endGormSpan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func startGormSpan(operation string) func(*gorm.DB) {
+	tracer := Tracer()
+	return func(db *gorm.DB) {
+		if db.Statement == nil || db.Statement.Context == nil {
+			return
+		}
+		// Solo dentro de una traza: los pollers (relay del outbox, crons)
+		// generarian una traza huerfana por consulta.
+		if !trace.SpanContextFromContext(db.Statement.Context).IsValid() {
+			return
+		}
+		name := "gorm." + operation
+		if db.Statement.Table != "" {
+			name += " " + db.Statement.Table
+		}
+		ctx, span := tracer.Start(db.Statement.Context, name,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("db.system.name", "postgresql"),
+				attribute.String("db.operation.name", operation),
+				attribute.String("db.collection.name", db.Statement.Table),
+			),
+		)
+		db.Statement.Context = ctx
+		// El span va en la instancia y no se recupera del ctx: si el before no
+		// corrio, el after terminaria el span del request.
+		db.InstanceSet(gormSpanKey, span)
+	}
+}
+
+func endGormSpan(db *gorm.DB) {
+	value, ok := db.InstanceGet(gormSpanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("db.query.text", truncateStatement(db.Statement.SQL.String())),
+		attribute.Int64("db.response.returned_rows", db.RowsAffected),
+	)
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		RecordError(span, db.Error)
+	}
+}
+
+func truncateStatement(sql string) string {
+	sql = strings.TrimSpace(sql)
+	if len(sql) <= maxStatementLength {
+		return sql
+	}
+	return sql[:maxStatementLength] + "..."
+}
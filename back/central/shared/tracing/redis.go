@@ -0,0 +1,86 @@
+package tracing
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type redisHook struct {
+	tracer trace.Tracer
+	addr   string
+}
+
+// NewRedisHook crea un span por comando (o por pipeline) de go-redis que
+// ocurra dentro de una traza. Los argumentos no se guardan: pueden traer
+// tokens y datos de clientes.
+func NewRedisHook(addr string) redis.Hook {
+	return &redisHook{tracer: Tracer(), addr: addr}
+}
+
+func (h *redisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if !trace.SpanContextFromContext(ctx).IsValid() {
+			return next(ctx, network, addr)
+		}
+		ctx, span := h.tracer.Start(ctx, "redis.dial", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(h.attributes()...))
+		defer span.End()
+
+		conn, err := next(ctx, network, addr)
+		RecordError(span, err)
+		return conn, err
+	}
+}
+
+func (h *redisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if !trace.SpanContextFromContext(ctx).IsValid() {
+			return next(ctx, cmd)
+		}
+		attrs := append(h.attributes(), attribute.String("db.operation.name", cmd.Name()))
+		ctx, span := h.tracer.Start(ctx, "redis."+cmd.Name(), trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+		defer span.End()
+
+		err := next(ctx, cmd)
+		if err != nil && err != redis.Nil {
+			RecordError(span, err)
+		}
+		return err
+	}
+}
+
+func (h *redisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if !trace.SpanContextFromContext(ctx).IsValid() {
+			return next(ctx, cmds)
+		}
+		names := make([]string, 0, len(cmds))
+		for _, cmd := range cmds {
+			names = append(names, cmd.Name())
+		}
+		attrs := append(h.attributes(),
+			attribute.String("db.operation.name", "pipeline"),
+			attribute.String("db.operation.batch", strings.Join(names, " ")),
+			attribute.Int("db.operation.batch.size", len(cmds)),
+		)
+		ctx, span := h.tracer.Start(ctx, "redis.pipeline", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+		defer span.End()
+
+		err := next(ctx, cmds)
+		if err != nil && err != redis.Nil {
+			RecordError(span, err)
+		}
+		return err
+	}
+}
+
+func (h *redisHook) attributes() []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("db.system.name", "redis"),
+		attribute.String("server.address", h.addr),
+	}
+}
@@ -0,0 +1,158 @@
+// Package tracing configura OpenTelemetry para el monolito: spans para las
+// rutas de Gin, las consultas de GORM, Redis, las llamadas HTTP salientes
+// (transportadoras, ERPs) y la publicacion/consumo en RabbitMQ. Exporta por
+// OTLP/HTTP al collector configurado o a stdout si no hay ninguno.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/secamc93/probability/back/central/shared/env"
+	"github.com/secamc93/probability/back/central/shared/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName identifica los spans creados por este backend.
+const InstrumentationName = "github.com/secamc93/probability/back/central"
+
+const defaultServiceName = "probability-central"
+
+// Exportadores soportados en OTEL_TRACES_EXPORTER.
+const (
+	exporterOTLP   = "otlp"
+	exporterStdout = "stdout"
+	exporterNone   = "none"
+)
+
+// ShutdownFunc vacia los spans pendientes y cierra el exportador.
+type ShutdownFunc func(ctx context.Context) error
+
+// Tracer retorna el tracer compartido del backend.
+func Tracer() trace.Tracer {
+	return otel.Tracer(InstrumentationName)
+}
+
+// Init registra el TracerProvider global y la propagacion W3C (traceparent +
+// baggage). Sin OTEL_EXPORTER_OTLP_ENDPOINT exporta a stdout; con
+// OTEL_TRACES_EXPORTER=none no exporta nada, pero los IDs se siguen propagando.
+func Init(ctx context.Context, config env.IConfig, logger log.ILogger) (ShutdownFunc, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	endpoint := config.Get("OTEL_EXPORTER_OTLP_ENDPOINT")
+	kind := exporterKind(config.Get("OTEL_TRACES_EXPORTER"), endpoint)
+	if kind == exporterNone {
+		logger.Info(ctx).Msg("Tracing deshabilitado (OTEL_TRACES_EXPORTER=none)")
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newExporter(ctx, kind, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceName := config.Get("OTEL_SERVICE_NAME")
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+		attribute.String("deployment.environment", config.Get("APP_ENV")),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio(config.Get("OTEL_TRACES_SAMPLER_ARG"))))),
+	)
+	otel.SetTracerProvider(provider)
+
+	logger.Info(ctx).
+		Str("exporter", kind).
+		Str("endpoint", endpoint).
+		Str("service_name", serviceName).
+		Msg("Tracing inicializado")
+
+	return provider.Shutdown, nil
+}
+
+// exporterKind resuelve el exportador: el explicito si viene, si no OTLP
+// cuando hay endpoint y stdout cuando no.
+func exporterKind(explicit string, endpoint string) string {
+	switch strings.ToLower(strings.TrimSpace(explicit)) {
+	case exporterNone:
+		return exporterNone
+	case exporterStdout, "console":
+		return exporterStdout
+	case exporterOTLP:
+		return exporterOTLP
+	}
+	if endpoint != "" {
+		return exporterOTLP
+	}
+	return exporterStdout
+}
+
+func newExporter(ctx context.Context, kind string, endpoint string) (sdktrace.SpanExporter, error) {
+	if kind == exporterStdout {
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create stdout trace exporter: %w", err)
+		}
+		return exporter, nil
+	}
+
+	opts := []otlptracehttp.Option{}
+	if endpoint != "" {
+		// Con esquema (http://collector:4318) se respeta tal cual; sin esquema
+		// se asume un collector interno sin TLS.
+		if strings.Contains(endpoint, "://") {
+			opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+		} else {
+			opts = append(opts, otlptracehttp.WithEndpoint(endpoint), otlptracehttp.WithInsecure())
+		}
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create otlp trace exporter: %w", err)
+	}
+	return exporter, nil
+}
+
+// sampleRatio interpreta OTEL_TRACES_SAMPLER_ARG; vacio o invalido muestrea todo.
+func sampleRatio(raw string) float64 {
+	if raw == "" {
+		return 1
+	}
+	ratio, err := strconv.ParseFloat(raw, 64)
+	if err != nil || ratio < 0 || ratio > 1 {
+		return 1
+	}
+	return ratio
+}
+
+// RecordError marca el span como fallido.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instalarRecorder registra un provider en memoria y restaura el global al terminar.
+func instalarRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	prevProvider := otel.GetTracerProvider()
+	prevPropagator := otel.GetTextMapPropagator()
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+	})
+	return recorder
+}
+
+func TestExporterKind_SinEndpointVaAStdout(t *testing.T) {
+	assert.Equal(t, exporterStdout, exporterKind("", ""))
+	assert.Equal(t, exporterOTLP, exporterKind("", "http://otel-collector:4318"))
+	assert.Equal(t, exporterNone, exporterKind("none", "http://otel-collector:4318"))
+	assert.Equal(t, exporterStdout, exporterKind("console", "http://otel-collector:4318"))
+}
+
+func TestSampleRatio_InvalidoMuestreaTodo(t *testing.T) {
+	assert.Equal(t, 1.0, sampleRatio(""))
+	assert.Equal(t, 1.0, sampleRatio("abc"))
+	assert.Equal(t, 1.0, sampleRatio("1.5"))
+	assert.Equal(t, 0.25, sampleRatio("0.25"))
+}
+
+func TestTransport_InyectaTraceparentYRegistraElSpan(t *testing.T) {
+	recorder := instalarRecorder(t)
+
+	var traceparent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	ctx, parent := Tracer().Start(context.Background(), "padre")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, server.URL+"/v1/invoices?api_key=secreto", nil)
+	resp, err := (&http.Client{Transport: NewTransport(nil)}).Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	parent.End()
+
+	assert.NotEmpty(t, traceparent, "el ERP debe recibir la traza")
+	assert.Empty(t, req.Header.Get("traceparent"), "no debe modificar el request original")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	cliente := spans[0]
+	assert.Equal(t, trace.SpanKindClient, cliente.SpanKind())
+	assert.Equal(t, parent.SpanContext().TraceID(), cliente.SpanContext().TraceID())
+	for _, attr := range cliente.Attributes() {
+		assert.NotContains(t, attr.Value.Emit(), "secreto", "la query string no va al span")
+	}
+	assert.Equal(t, "Error", cliente.Status().Code.String())
+}
+
+func TestGinMiddleware_ContinuaLaTrazaDelLlamador(t *testing.T) {
+	recorder := instalarRecorder(t)
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(GinMiddleware())
+	r.GET("/api/v1/orders/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/42", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1, "/health no se traza")
+	assert.Equal(t, "GET /api/v1/orders/:id", spans[0].Name())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+}
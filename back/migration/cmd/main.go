@@ -2,7 +2,13 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"text/tabwriter"
+	"time"
 
 	"github.com/secamc93/probability/back/migration/internal/infra/repository"
 	"github.com/secamc93/probability/back/migration/shared/db"
@@ -10,19 +16,151 @@ import (
 	"github.com/secamc93/probability/back/migration/shared/log"
 )
 
+const sqlMigrationsDir = "internal/infra/repository/sql"
+
+var sqlNamePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+const usage = `uso: go run cmd/main.go <comando>
+
+  up [version]   aplica las pendientes (hasta version, si se indica)
+  down [n]       revierte las ultimas n aplicadas (default 1)
+  redo           revierte y vuelve a aplicar la ultima
+  status         lista registradas y aplicadas
+  dry-run        muestra lo que aplicaria up, sin tocar la base
+  create nombre  crea sql/<version>_<nombre>.up.sql y .down.sql vacios
+`
+
 func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	command, args := os.Args[1], os.Args[2:]
+
+	if command == "create" {
+		if err := createSQLMigration(args); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	logger := log.New()
 	cfg := env.New(logger)
 	database := db.New(logger, cfg)
 	defer database.Close()
 
-	repo := repository.New(database, cfg)
+	repo := repository.New(database, cfg, logger)
+	ctx := context.Background()
 
-	logger.Info().Msg("Starting database migration...")
-	if err := repo.Migrate(context.Background()); err != nil {
-		logger.Fatal(context.Background()).Err(err).Msg("Migration failed")
+	if err := run(ctx, repo, logger, command, args); err != nil {
+		logger.Error(ctx).Err(err).Str("command", command).Msg("Migration failed")
+		database.Close()
 		os.Exit(1)
 	}
-	logger.Info().Msg("Database migration completed successfully")
+}
+
+func run(ctx context.Context, repo *repository.Repository, logger log.ILogger, command string, args []string) error {
+	switch command {
+	case "up":
+		target, err := intArg(args, 0)
+		if err != nil {
+			return err
+		}
+		applied, err := repo.Up(ctx, target)
+		logger.Info(ctx).Int("applied", len(applied)).Msg("up terminado")
+		return err
+
+	case "down":
+		steps, err := intArg(args, 1)
+		if err != nil {
+			return err
+		}
+		reverted, err := repo.Down(ctx, int(steps))
+		logger.Info(ctx).Int("reverted", len(reverted)).Msg("down terminado")
+		return err
+
+	case "redo":
+		m, err := repo.Redo(ctx)
+		if err != nil {
+			return err
+		}
+		if m == nil {
+			logger.Info(ctx).Msg("No hay migraciones aplicadas")
+			return nil
+		}
+		logger.Info(ctx).Int64("version", m.Version).Str("name", m.Name).Msg("redo terminado")
+		return nil
+
+	case "status":
+		statuses, err := repo.Status(ctx)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNOMBRE\tTIPO\tESTADO\tAPLICADA\tREVERSIBLE")
+		for _, s := range statuses {
+			appliedAt := "-"
+			if s.AppliedAt != nil {
+				appliedAt = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%t\n", s.Version, s.Name, s.Kind, s.State, appliedAt, s.Reversible)
+		}
+		return w.Flush()
+
+	case "dry-run":
+		pending, err := repo.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			fmt.Println("Sin migraciones pendientes")
+			return nil
+		}
+		for _, m := range pending {
+			fmt.Printf("-- %d_%s (%s, checksum %s, reversible=%t, transaccion=%t)\n",
+				m.Version, m.Name, m.Kind(), m.Checksum()[:12], m.Reversible(), !m.NoTransaction)
+			if m.UpSQL() != "" {
+				fmt.Println(m.UpSQL())
+			}
+		}
+		return nil
+
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("comando desconocido: %s", command)
+	}
+}
 
+// createSQLMigration arma el par up/down con la siguiente version del dia.
+func createSQLMigration(args []string) error {
+	if len(args) == 0 || !sqlNamePattern.MatchString(args[0]) {
+		return fmt.Errorf("uso: go run cmd/main.go create nombre_en_snake_case")
+	}
+
+	version, err := repository.NextMigrationVersion(time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, direction := range []string{"up", "down"} {
+		path := filepath.Join(sqlMigrationsDir, fmt.Sprintf("%d_%s.%s.sql", version, args[0], direction))
+		header := fmt.Sprintf("-- %d %s\n", version, args[0])
+		if err := os.WriteFile(path, []byte(header), 0o644); err != nil {
+			return err
+		}
+		fmt.Println(path)
+	}
+	return nil
+}
+
+func intArg(args []string, def int64) (int64, error) {
+	if len(args) == 0 {
+		return def, nil
+	}
+	v, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("argumento invalido: %s", args[0])
+	}
+	return v, nil
 }
@@ -5,24 +5,23 @@ import (
 
 	"github.com/secamc93/probability/back/migration/shared/db"
 	"github.com/secamc93/probability/back/migration/shared/env"
+	"github.com/secamc93/probability/back/migration/shared/log"
 )
 
 type Repository struct {
 	db  db.IDatabase
 	cfg env.IConfig
+	log log.ILogger
 }
 
-func New(db db.IDatabase, cfg env.IConfig) *Repository {
+func New(db db.IDatabase, cfg env.IConfig, logger log.ILogger) *Repository {
 	return &Repository{
 		db:  db,
 		cfg: cfg,
+		log: logger,
 	}
 }
 
-func (r *Repository) Migrate(ctx context.Context) error {
-	return nil
-}
-
 func (r *Repository) migrateHistorico(ctx context.Context) error {
 	if err := r.migrateWalletTxBusinessID(ctx); err != nil {
 		return err
@@ -180,6 +179,8 @@ func (r *Repository) migrateHistorico(ctx context.Context) error {
 	if err := r.migrateTrazabilidadUsuario(ctx); err != nil {
 		return err
 	}
+	// Los fix_viga_cod_* de aqui abajo tambien estan registrados en
+	// migrations() (2026101821 a 2026101827)
 	if err := r.fixVigaCodRealPayout(ctx); err != nil {
 		return err
 	}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+// pucTemplate es el subconjunto del PUC (decreto 2650) que usa la plataforma:
+// las clases, los grupos y las subcuentas a las que apuntan las reglas de
+// contabilizacion. Cada negocio puede agregar las suyas sobre esta plantilla.
+var pucTemplate = []struct{ code, name string }{
+	{"1", "Activo"},
+	{"11", "Disponible"},
+	{"1105", "Caja"},
+	{"110505", "Caja general"},
+	{"1110", "Bancos"},
+	{"111005", "Moneda nacional"},
+	{"13", "Deudores"},
+	{"1305", "Clientes"},
+	{"130505", "Nacionales"},
+	{"1355", "Anticipo de impuestos y contribuciones o saldos a favor"},
+	{"135515", "Retencion en la fuente"},
+	{"135517", "Impuesto a las ventas retenido"},
+	{"135518", "Impuesto de industria y comercio retenido"},
+	{"14", "Inventarios"},
+	{"1435", "Mercancias no fabricadas por la empresa"},
+	{"143505", "Mercancias en existencia"},
+	{"2", "Pasivo"},
+	{"22", "Proveedores"},
+	{"2205", "Nacionales"},
+	{"220505", "Proveedores nacionales"},
+	{"23", "Cuentas por pagar"},
+	{"2335", "Costos y gastos por pagar"},
+	{"233595", "Otros"},
+	{"2365", "Retencion en la fuente"},
+	{"236515", "Honorarios"},
+	{"236525", "Servicios"},
+	{"2368", "Impuesto de industria y comercio retenido"},
+	{"236805", "ReteICA por pagar"},
+	{"24", "Impuestos, gravamenes y tasas"},
+	{"2408", "Impuesto sobre las ventas por pagar"},
+	{"240805", "IVA generado"},
+	{"240810", "IVA descontable"},
+	{"28", "Otros pasivos"},
+	{"2805", "Anticipos y avances recibidos"},
+	{"280505", "De clientes"},
+	{"2815", "Ingresos recibidos para terceros"},
+	{"281505", "Valores recibidos para terceros"},
+	{"3", "Patrimonio"},
+	{"31", "Capital social"},
+	{"3105", "Capital suscrito y pagado"},
+	{"310505", "Capital autorizado"},
+	{"36", "Resultados del ejercicio"},
+	{"3605", "Utilidad del ejercicio"},
+	{"360505", "Utilidad del ejercicio"},
+	{"3610", "Perdida del ejercicio"},
+	{"361005", "Perdida del ejercicio"},
+	{"37", "Resultados de ejercicios anteriores"},
+	{"3705", "Utilidades acumuladas"},
+	{"370505", "Utilidades acumuladas"},
+	{"4", "Ingresos"},
+	{"41", "Operacionales"},
+	{"4145", "Transporte, almacenamiento y comunicaciones"},
+	{"414595", "Actividades conexas"},
+	{"4155", "Actividades inmobiliarias, empresariales y de alquiler"},
+	{"415595", "Actividades conexas"},
+	{"4175", "Devoluciones en ventas (DB)"},
+	{"417505", "Devoluciones en ventas"},
+	{"42", "No operacionales"},
+	{"4250", "Recuperaciones"},
+	{"425050", "Reintegro de otros costos y gastos"},
+	{"4295", "Diversos"},
+	{"429595", "Otros"},
+	{"5", "Gastos"},
+	{"51", "Operacionales de administracion"},
+	{"5110", "Honorarios"},
+	{"511095", "Otros"},
+	{"5195", "Diversos"},
+	{"519595", "Otros"},
+	{"52", "Operacionales de ventas"},
+	{"5295", "Diversos"},
+	{"529595", "Otros"},
+	{"53", "No operacionales"},
+	{"5305", "Financieros"},
+	{"530505", "Gastos bancarios"},
+	{"530595", "Otros"},
+	{"6", "Costos de ventas"},
+	{"61", "Costo de ventas y de prestacion de servicios"},
+	{"6135", "Comercio al por mayor y al por menor"},
+	{"613595", "Venta de otros productos"},
+	{"7", "Costos de produccion o de operacion"},
+	{"8", "Cuentas de orden deudoras"},
+	{"9", "Cuentas de orden acreedoras"},
+}
+
+// pucDefaultRules son las reglas globales por origen. INCOME acredita
+// CreditAccount; EXPENSE debita DebitAccount.
+var pucDefaultRules = []struct {
+	source, description, debit, credit, tax, withholding, otherTax string
+}{
+	{"GUIDE_MARGIN", "Margen de guias: se consume el anticipo del negocio", "280505", "414595", "240805", "135515", "530595"},
+	{"SUBSCRIPTION", "Membresias cobradas", "111005", "415595", "240805", "135515", "530595"},
+	{"WALLET_RECHARGE", "Recargas de billetera: anticipo recibido, no ingreso", "111005", "280505", "", "", ""},
+	{"COD_PAYOUT", "Consignacion del recaudo contra entrega al negocio", "281505", "111005", "", "", "530595"},
+	{"COGS", "Costo de la mercancia despachada", "613595", "143505", "", "", ""},
+	{"COGS_RETURN", "Mercancia devuelta que vuelve al inventario", "143505", "613595", "", "", ""},
+	{"INVENTORY_SHRINKAGE", "Faltantes de inventario", "529595", "143505", "", "", ""},
+	{"INVENTORY_SURPLUS", "Sobrantes de inventario", "143505", "429595", "", "", ""},
+	{"INVOICE", "Facturas pagadas", "111005", "415595", "240805", "135515", "530595"},
+	{"CREDIT_NOTE", "Nota credito por factura anulada", "417505", "111005", "240805", "135515", "530595"},
+	{"COLLABORATOR", "Pagos a colaboradores", "511095", "111005", "240810", "236515", "530595"},
+	{"MANUAL_INCOME", "Ingresos manuales", "111005", "429595", "240805", "135515", "530595"},
+	{"MANUAL_EXPENSE", "Gastos manuales", "519595", "111005", "240810", "236525", "530595"},
+}
+
+// migrateAccountingLedger crea la contabilidad de partida doble: plan de
+// cuentas con la plantilla PUC, reglas de contabilizacion, comprobantes con
+// sus lineas y periodos con cierre
+func (r *Repository) migrateAccountingLedger(ctx context.Context) error {
+	db := r.db.Conn(ctx)
+
+	if err := db.AutoMigrate(
+		&models.AccountingAccount{},
+		&models.AccountingPostingRule{},
+		&models.AccountingPeriod{},
+		&models.AccountingJournalEntry{},
+		&models.AccountingJournalLine{},
+	); err != nil {
+		return fmt.Errorf("automigrate accounting ledger: %w", err)
+	}
+
+	// Un movimiento se contabiliza una sola vez y un comprobante se reversa
+	// una sola vez
+	if err := db.Exec(`
+CREATE UNIQUE INDEX IF NOT EXISTS idx_acc_journal_posting
+ON accounting_journal_entries(accounting_entry_id)
+WHERE accounting_entry_id IS NOT NULL AND reversal_of_id IS NULL AND deleted_at IS NULL
+`).Error; err != nil {
+		return fmt.Errorf("create journal posting index: %w", err)
+	}
+	if err := db.Exec(`
+CREATE UNIQUE INDEX IF NOT EXISTS idx_acc_journal_reversal
+ON accounting_journal_entries(reversal_of_id)
+WHERE reversal_of_id IS NOT NULL AND deleted_at IS NULL
+`).Error; err != nil {
+		return fmt.Errorf("create journal reversal index: %w", err)
+	}
+
+	for _, a := range pucTemplate {
+		nature := "DEBIT"
+		switch a.code[0] {
+		case '2', '3', '4', '9':
+			nature = "CREDIT"
+		}
+		if a.code == "4175" || a.code == "417505" {
+			nature = "DEBIT"
+		}
+		parent := ""
+		switch len(a.code) {
+		case 2:
+			parent = a.code[:1]
+		case 4:
+			parent = a.code[:2]
+		case 6:
+			parent = a.code[:4]
+		}
+		level := map[int]int{1: 1, 2: 2, 4: 3, 6: 4}[len(a.code)]
+		if err := db.Exec(`
+INSERT INTO accounting_accounts (business_id, code, name, nature, parent_code, level, is_postable, is_active, created_at, updated_at)
+VALUES (0, ?, ?, ?, ?, ?, ?, TRUE, NOW(), NOW())
+ON CONFLICT (business_id, code) DO NOTHING
+`, a.code, a.name, nature, parent, level, len(a.code) >= 6).Error; err != nil {
+			return fmt.Errorf("seed puc account %s: %w", a.code, err)
+		}
+	}
+
+	for _, rule := range pucDefaultRules {
+		if err := db.Exec(`
+INSERT INTO accounting_posting_rules (business_id, source_type, description, debit_account, credit_account, tax_account, withholding_account, other_tax_account, is_active, created_at, updated_at)
+VALUES (0, ?, ?, ?, ?, ?, ?, ?, TRUE, NOW(), NOW())
+ON CONFLICT (business_id, source_type) DO NOTHING
+`, rule.source, rule.description, rule.debit, rule.credit, rule.tax, rule.withholding, rule.otherTax).Error; err != nil {
+			return fmt.Errorf("seed posting rule %s: %w", rule.source, err)
+		}
+	}
+
+	return nil
+}
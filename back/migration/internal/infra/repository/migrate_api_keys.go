@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func (r *Repository) migrateAPIKeys(ctx context.Context) error {
+	if err := r.db.Conn(ctx).AutoMigrate(&models.APIKey{}); err != nil {
+		return fmt.Errorf("automigrate api keys: %w", err)
+	}
+	return nil
+}
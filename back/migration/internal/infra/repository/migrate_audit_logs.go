@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+//go:embed sql/audit_logs_immutable.sql
+var auditLogsImmutableSQL string
+
+func (r *Repository) migrateAuditLogs(ctx context.Context) error {
+	if err := r.db.Conn(ctx).AutoMigrate(&models.AuditLog{}, &models.AuditLogCheckpoint{}); err != nil {
+		return fmt.Errorf("failed to auto-migrate audit log tables: %w", err)
+	}
+	if err := r.db.Conn(ctx).Exec(auditLogsImmutableSQL).Error; err != nil {
+		return fmt.Errorf("failed to create audit_logs triggers: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) dropAuditLogs(ctx context.Context) error {
+	if err := r.dropTables(&models.AuditLogCheckpoint{}, &models.AuditLog{})(ctx); err != nil {
+		return err
+	}
+	return r.db.Conn(ctx).Exec("DROP FUNCTION IF EXISTS audit_logs_immutable()").Error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func (r *Repository) migrateBusinessSSO(ctx context.Context) error {
+	if err := r.db.Conn(ctx).AutoMigrate(&models.BusinessSSOConfig{}, &models.UserSSOIdentity{}, &models.SSOLoginState{}); err != nil {
+		return fmt.Errorf("failed to auto-migrate business sso tables: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+// migrateCarrierInvoices crea los perfiles de lectura de facturas de
+// transportadora y las facturas auditadas guia por guia con sus disputas
+func (r *Repository) migrateCarrierInvoices(ctx context.Context) error {
+	if err := r.db.Conn(ctx).AutoMigrate(
+		&models.CarrierInvoiceProfile{},
+		&models.CarrierInvoice{},
+		&models.CarrierInvoiceLine{},
+	); err != nil {
+		return fmt.Errorf("automigrate carrier invoices: %w", err)
+	}
+	return nil
+}
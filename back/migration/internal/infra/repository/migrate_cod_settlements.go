@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+// migrateCodSettlements crea los perfiles de lectura por transportadora y las
+// liquidaciones cargadas con sus lineas cruzadas contra las ordenes COD
+func (r *Repository) migrateCodSettlements(ctx context.Context) error {
+	if err := r.db.Conn(ctx).AutoMigrate(
+		&models.CodSettlementProfile{},
+		&models.CodSettlement{},
+		&models.CodSettlementLine{},
+	); err != nil {
+		return fmt.Errorf("automigrate cod settlements: %w", err)
+	}
+	return nil
+}
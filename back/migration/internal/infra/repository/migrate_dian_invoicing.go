@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+const (
+	dianTypeID          = 36
+	dianTypeCode        = "dian"
+	dianTypeName        = "DIAN (facturación directa)"
+	dianCategoryCode    = "invoicing"
+	dianBaseURL         = "https://vpfe.dian.gov.co/WcfDianCustomerServices.svc"
+	dianBaseURLTest     = "https://vpfe-hab.dian.gov.co/WcfDianCustomerServices.svc"
+	dianImageURL        = "integration-types/dian.png"
+	dianDescription     = "Facturacion electronica directa ante la DIAN (UBL 2.1): genera el XML con CUFE/CUDE, lo firma con el certificado del facturador (XAdES-EPES) y lo envia al web service sin proveedor tecnologico intermedio."
+	dianCredsSchemaJSON = `{
+  "type": "object",
+  "properties": {
+    "certificate": {
+      "type": "string",
+      "label": "Certificado de firma (.p12 en base64)",
+      "order": 1,
+      "required": true,
+      "input_type": "password",
+      "description": "Certificado de firma digital del facturador en formato PKCS#12, codificado en base64",
+      "help_text": "Genera el base64 con: base64 -w0 certificado.p12. El archivo debe usar cifrado legacy (openssl pkcs12 -legacy).",
+      "error_message": "El certificado es obligatorio"
+    },
+    "certificate_password": {
+      "type": "string",
+      "label": "Clave del certificado",
+      "order": 2,
+      "required": false,
+      "input_type": "password",
+      "description": "Clave con la que se protegio el archivo .p12"
+    },
+    "software_pin": {
+      "type": "string",
+      "label": "PIN del software",
+      "order": 3,
+      "required": true,
+      "input_type": "password",
+      "description": "PIN de 5 digitos registrado al crear el software propio en el portal de habilitacion",
+      "error_message": "El PIN del software es obligatorio"
+    },
+    "technical_key": {
+      "type": "string",
+      "label": "Clave tecnica",
+      "order": 4,
+      "required": true,
+      "input_type": "password",
+      "description": "Clave tecnica del rango de numeracion (se usa en el CUFE)",
+      "help_text": "La entrega la DIAN al asociar el prefijo al software (GetNumberingRange o portal de habilitacion).",
+      "error_message": "La clave tecnica es obligatoria"
+    }
+  }
+}`
+	dianConfigSchemaJSON = `{
+  "type": "object",
+  "properties": {
+    "software_id": {"type": "string", "label": "ID del software", "order": 1, "required": true, "input_type": "text", "description": "Identificador del software propio registrado ante la DIAN"},
+    "test_set_id": {"type": "string", "label": "TestSetId", "order": 2, "required": false, "input_type": "text", "description": "Identificador del set de pruebas; solo aplica en modo pruebas durante la habilitacion"},
+    "issuer_nit": {"type": "string", "label": "NIT del facturador", "order": 3, "required": true, "input_type": "text", "description": "NIT sin digito de verificacion"},
+    "issuer_dv": {"type": "string", "label": "Digito de verificacion", "order": 4, "required": true, "input_type": "text"},
+    "issuer_name": {"type": "string", "label": "Razon social", "order": 5, "required": true, "input_type": "text"},
+    "issuer_trade_name": {"type": "string", "label": "Nombre comercial", "order": 6, "required": false, "input_type": "text"},
+    "issuer_address": {"type": "string", "label": "Direccion", "order": 7, "required": true, "input_type": "text"},
+    "issuer_city_code": {"type": "string", "label": "Codigo DANE del municipio", "order": 8, "required": true, "input_type": "text", "placeholder": "11001"},
+    "issuer_city_name": {"type": "string", "label": "Municipio", "order": 9, "required": false, "input_type": "text"},
+    "issuer_department_code": {"type": "string", "label": "Codigo DANE del departamento", "order": 10, "required": true, "input_type": "text", "placeholder": "11"},
+    "issuer_department_name": {"type": "string", "label": "Departamento", "order": 11, "required": false, "input_type": "text"},
+    "issuer_email": {"type": "string", "label": "Correo del facturador", "order": 12, "required": false, "input_type": "text"},
+    "tax_level_code": {"type": "string", "label": "Responsabilidad fiscal", "order": 13, "required": false, "input_type": "text", "placeholder": "O-13", "description": "Codigo de responsabilidad fiscal del RUT (por defecto O-13)"},
+    "prefix": {"type": "string", "label": "Prefijo de facturacion", "order": 14, "required": false, "input_type": "text"},
+    "resolution_number": {"type": "string", "label": "Numero de resolucion", "order": 15, "required": true, "input_type": "text"},
+    "resolution_start_date": {"type": "string", "label": "Vigencia desde (AAAA-MM-DD)", "order": 16, "required": true, "input_type": "text"},
+    "resolution_end_date": {"type": "string", "label": "Vigencia hasta (AAAA-MM-DD)", "order": 17, "required": true, "input_type": "text"},
+    "range_from": {"type": "string", "label": "Rango desde", "order": 18, "required": true, "input_type": "text"},
+    "range_to": {"type": "string", "label": "Rango hasta", "order": 19, "required": true, "input_type": "text"},
+    "credit_note_prefix": {"type": "string", "label": "Prefijo notas credito", "order": 20, "required": false, "input_type": "text", "placeholder": "NC"},
+    "debit_note_prefix": {"type": "string", "label": "Prefijo notas debito", "order": 21, "required": false, "input_type": "text", "placeholder": "ND"}
+  }
+}`
+	dianSetupInstructions = `Pasos para facturar directamente ante la DIAN:
+
+1. En el portal de habilitacion de la DIAN registra Probability como "software propio"
+   y anota el ID del software, el PIN y el TestSetId.
+2. Asocia el prefijo de facturacion al software y copia la clave tecnica del rango.
+3. Convierte el certificado de firma a base64 (base64 -w0 certificado.p12). Si el .p12
+   fue generado con OpenSSL 3, reexportalo con: openssl pkcs12 -export -legacy.
+4. Llena los datos del facturador y de la resolucion de numeracion.
+5. Activa "modo pruebas" y emite las facturas y notas que exige el set de pruebas.
+   Los documentos quedan pendientes de validacion hasta que la DIAN los procese.
+6. Cuando el set de pruebas quede aceptado, desactiva el modo pruebas y configura
+   la resolucion de produccion.`
+)
+
+func (r *Repository) migrateDianInvoicing(ctx context.Context) error {
+	if err := r.db.Conn(ctx).AutoMigrate(&models.DianDocument{}, &models.DianNumberingCounter{}); err != nil {
+		return fmt.Errorf("migrateDianInvoicing: creando tablas: %w", err)
+	}
+
+	var category models.IntegrationCategory
+	if err := r.db.Conn(ctx).Where("code = ?", dianCategoryCode).First(&category).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return fmt.Errorf("migrateDianInvoicing: integration_category 'invoicing' not found")
+		}
+		return fmt.Errorf("migrateDianInvoicing: consultando categoria: %w", err)
+	}
+	categoryID := category.ID
+
+	var existing models.IntegrationType
+	err := r.db.Conn(ctx).
+		Where("id = ? OR code = ?", dianTypeID, dianTypeCode).
+		First(&existing).Error
+
+	if err == nil {
+		updates := map[string]interface{}{
+			"name":               dianTypeName,
+			"code":               dianTypeCode,
+			"description":        dianDescription,
+			"image_url":          dianImageURL,
+			"category_id":        categoryID,
+			"base_url":           dianBaseURL,
+			"base_url_test":      dianBaseURLTest,
+			"config_schema":      datatypes.JSON(dianConfigSchemaJSON),
+			"credentials_schema": datatypes.JSON(dianCredsSchemaJSON),
+			"setup_instructions": dianSetupInstructions,
+			"in_development":     false,
+		}
+		if uerr := r.db.Conn(ctx).Model(&models.IntegrationType{}).
+			Where("id = ?", existing.ID).
+			Updates(updates).Error; uerr != nil {
+			return fmt.Errorf("migrateDianInvoicing: actualizando tipo: %w", uerr)
+		}
+		return nil
+	}
+
+	if err != gorm.ErrRecordNotFound {
+		return fmt.Errorf("migrateDianInvoicing: consultando tipo: %w", err)
+	}
+
+	integrationType := models.IntegrationType{
+		Model:             gorm.Model{ID: dianTypeID},
+		Name:              dianTypeName,
+		Code:              dianTypeCode,
+		Description:       dianDescription,
+		ImageURL:          dianImageURL,
+		IsActive:          true,
+		InDevelopment:     false,
+		CategoryID:        &categoryID,
+		ConfigSchema:      datatypes.JSON(dianConfigSchemaJSON),
+		CredentialsSchema: datatypes.JSON(dianCredsSchemaJSON),
+		SetupInstructions: dianSetupInstructions,
+		BaseURL:           dianBaseURL,
+		BaseURLTest:       dianBaseURLTest,
+	}
+
+	if cerr := r.db.Conn(ctx).Create(&integrationType).Error; cerr != nil {
+		return fmt.Errorf("migrateDianInvoicing: creando tipo: %w", cerr)
+	}
+
+	// El tipo se crea con ID explicito: resincronizar la secuencia para que
+	// los siguientes inserts no colisionen
+	if err := r.db.Conn(ctx).Exec(`SELECT setval('integration_types_id_seq', GREATEST((SELECT COALESCE(MAX(id), 0) FROM integration_types), 1))`).Error; err != nil {
+		return fmt.Errorf("migrateDianInvoicing: resync integration_types sequence: %w", err)
+	}
+
+	return nil
+}
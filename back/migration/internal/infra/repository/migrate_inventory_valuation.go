@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func (r *Repository) migrateInventoryValuation(ctx context.Context) error {
+	db := r.db.Conn(ctx)
+
+	if err := db.AutoMigrate(
+		&models.StockMovement{},
+		&models.InventoryValuationSetting{},
+		&models.InventoryCostBalance{},
+		&models.InventoryCostLayer{},
+		&models.OrderLineCost{},
+	); err != nil {
+		return fmt.Errorf("failed to auto-migrate inventory valuation: %w", err)
+	}
+
+	// Conceptos contables que alimenta la valorizacion (ver accounting Sync)
+	concepts := []struct {
+		code, name, description, kind string
+	}{
+		{"COGS", "Costo de ventas", "Costo del inventario despachado en ordenes confirmadas", "EXPENSE"},
+		{"COGS_RETURN", "Reverso de costo de ventas", "Costo de la mercancia devuelta que vuelve al inventario", "INCOME"},
+		{"INVENTORY_SHRINKAGE", "Faltantes de inventario", "Ajustes manuales y de conteo que bajan el inventario valorizado", "EXPENSE"},
+		{"INVENTORY_SURPLUS", "Sobrantes de inventario", "Ajustes manuales y de conteo que suben el inventario valorizado", "INCOME"},
+	}
+	for _, c := range concepts {
+		if err := db.Exec(`
+INSERT INTO accounting_concepts (code, name, description, kind, is_real_income, is_automatic, source_type, is_active, created_at, updated_at)
+VALUES (?, ?, ?, ?, TRUE, TRUE, ?, TRUE, NOW(), NOW())
+ON CONFLICT (code) DO NOTHING
+`, c.code, c.name, c.description, c.kind, c.code).Error; err != nil {
+			return fmt.Errorf("seed accounting concept %s: %w", c.code, err)
+		}
+	}
+
+	return nil
+}
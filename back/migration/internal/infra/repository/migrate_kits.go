@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func (r *Repository) migrateKits(ctx context.Context) error {
+	if err := r.db.Conn(ctx).AutoMigrate(
+		&models.ProductKit{},
+		&models.ProductKitComponent{},
+		&models.KitAssemblyOrder{},
+	); err != nil {
+		return fmt.Errorf("failed to auto-migrate kits: %w", err)
+	}
+	return nil
+}
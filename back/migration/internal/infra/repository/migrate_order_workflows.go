@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func (r *Repository) migrateOrderWorkflows(ctx context.Context) error {
+	if err := r.db.Conn(ctx).AutoMigrate(&models.OrderWorkflow{}); err != nil {
+		return fmt.Errorf("failed to auto-migrate order_workflows: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// migrateOutboxClaims agrega el reclamo de eventos del outbox: el relay marca
+// locked_until y claimed_by en una transaccion corta y publica fuera de ella,
+// sin sostener locks de fila mientras espera las confirmaciones del broker
+func (r *Repository) migrateOutboxClaims(ctx context.Context) error {
+	db := r.db.Conn(ctx)
+
+	if err := db.Exec(`ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ`).Error; err != nil {
+		return fmt.Errorf("add outbox_events.locked_until: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE outbox_events ADD COLUMN IF NOT EXISTS claimed_by VARCHAR(64)`).Error; err != nil {
+		return fmt.Errorf("add outbox_events.claimed_by: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) revertOutboxClaims(ctx context.Context) error {
+	db := r.db.Conn(ctx)
+
+	if err := db.Exec(`ALTER TABLE outbox_events DROP COLUMN IF EXISTS claimed_by`).Error; err != nil {
+		return fmt.Errorf("drop outbox_events.claimed_by: %w", err)
+	}
+
+	if err := db.Exec(`ALTER TABLE outbox_events DROP COLUMN IF EXISTS locked_until`).Error; err != nil {
+		return fmt.Errorf("drop outbox_events.locked_until: %w", err)
+	}
+
+	return nil
+}
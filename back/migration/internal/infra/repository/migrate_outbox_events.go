@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func (r *Repository) migrateOutboxEvents(ctx context.Context) error {
+	if err := r.db.Conn(ctx).AutoMigrate(&models.OutboxEvent{}); err != nil {
+		return fmt.Errorf("failed to auto-migrate outbox_events: %w", err)
+	}
+	return nil
+}
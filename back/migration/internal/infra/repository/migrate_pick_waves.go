@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func (r *Repository) migratePickWaves(ctx context.Context) error {
+	if err := r.db.Conn(ctx).AutoMigrate(&models.Order{}); err != nil {
+		return fmt.Errorf("add orders.priority: %w", err)
+	}
+
+	if err := r.db.Conn(ctx).AutoMigrate(
+		&models.PickWave{},
+		&models.PickWaveOrder{},
+		&models.PickWaveItem{},
+		&models.PickListLine{},
+	); err != nil {
+		return fmt.Errorf("failed to auto-migrate pick waves: %w", err)
+	}
+	return nil
+}
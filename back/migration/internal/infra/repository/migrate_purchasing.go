@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func (r *Repository) migratePurchasing(ctx context.Context) error {
+	if err := r.db.Conn(ctx).AutoMigrate(
+		&models.Supplier{},
+		&models.SupplierProduct{},
+		&models.PurchaseOrder{},
+		&models.PurchaseOrderLine{},
+		&models.PurchaseOrderReceipt{},
+		&models.PurchaseOrderReceiptLine{},
+		&models.PurchaseOrderLandedCost{},
+	); err != nil {
+		return fmt.Errorf("failed to auto-migrate purchasing: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func (r *Repository) migrateReturns(ctx context.Context) error {
+	if err := r.db.Conn(ctx).AutoMigrate(
+		&models.ReturnRequest{},
+		&models.ReturnRequestItem{},
+		&models.ReturnStatusHistory{},
+	); err != nil {
+		return fmt.Errorf("failed to auto-migrate returns: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+// migrateShipmentPackages no rellena paquetes para los envíos existentes: un
+// envío sin filas en shipment_packages se sigue leyendo como un solo bulto con
+// las dimensiones de shipments
+func (r *Repository) migrateShipmentPackages(ctx context.Context) error {
+	if err := r.db.Conn(ctx).AutoMigrate(&models.ShipmentPackage{}); err != nil {
+		return fmt.Errorf("automigrate shipment_packages: %w", err)
+	}
+	return nil
+}
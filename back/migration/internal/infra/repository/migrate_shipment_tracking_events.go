@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+// trackingStatusSeed estado raw (normalizado: minúsculas, sin tildes) de un
+// proveedor o de una transportadora dentro del proveedor
+type trackingStatusSeed struct {
+	provider  string
+	carrier   string
+	rawStatus string
+	eventCode string
+}
+
+func trackingStatusSeeds() []trackingStatusSeed {
+	seeds := []trackingStatusSeed{
+		// EnvioClick: pasos del tracking, comunes a todas las transportadoras
+		{"envioclick", "", "pendiente", "label_created"},
+		{"envioclick", "", "envio recolectado", "picked_up"},
+		{"envioclick", "", "en transito", "in_transit"},
+		{"envioclick", "", "en distribucion", "out_for_delivery"},
+		{"envioclick", "", "entregado", "delivered"},
+		{"envioclick", "", "novedad", "exception"},
+		{"envioclick", "", "no entregado", "attempt_failed"},
+		{"envioclick", "", "en devolucion", "returning"},
+		{"envioclick", "", "devuelto", "returned"},
+
+		// EnvioClick: statusDetail propio de cada transportadora
+		{"envioclick", "envia", "fecha de captura en el sistema", "label_created"},
+		{"envioclick", "envia", "guia generada en espera de transportadora", "label_created"},
+		{"envioclick", "envia", "fecha de recoleccion del envio", "picked_up"},
+		{"envioclick", "envia", "salida a ruta", "in_transit"},
+		{"envioclick", "envia", "en ruta de entrega", "out_for_delivery"},
+		{"envioclick", "envia", "envio entregado", "delivered"},
+		{"envioclick", "envia", "en espera de ruta poblacion aledana", "exception"},
+		{"envioclick", "envia", "no conocen destinatario en direccion destino", "attempt_failed"},
+		{"envioclick", "envia", "direccion incorrecta/ insuficiente", "attempt_failed"},
+		{"envioclick", "envia", "direccion incorrecta insuficiente", "attempt_failed"},
+		{"envioclick", "interrapidisimo", "envio admitido", "picked_up"},
+		{"envioclick", "interrapidisimo", "ingresado a bodega", "in_transit"},
+		{"envioclick", "interrapidisimo", "despachado para bodega", "in_transit"},
+		{"envioclick", "interrapidisimo", "viajando en ruta nacional", "in_transit"},
+		{"envioclick", "interrapidisimo", "viajando en ruta regional", "in_transit"},
+		{"envioclick", "deprisa", "guia generada en espera de transportadora", "label_created"},
+		{"envioclick", "servientrega", "en alistamiento del cliente", "label_created"},
+
+		// Shipit: estado del courier
+		{"shipit", "", "created", "label_created"},
+		{"shipit", "", "in_preparation", "label_created"},
+		{"shipit", "", "waiting_courier", "label_created"},
+		{"shipit", "", "ready_to_ship", "label_created"},
+		{"shipit", "", "ready_for_pickup", "label_created"},
+		{"shipit", "", "pending_pickup", "label_created"},
+		{"shipit", "", "picked_up", "picked_up"},
+		{"shipit", "", "withdrawn", "picked_up"},
+		{"shipit", "", "shipped", "in_transit"},
+		{"shipit", "", "dispatched", "in_transit"},
+		{"shipit", "", "in_transit", "in_transit"},
+		{"shipit", "", "out_for_delivery", "out_for_delivery"},
+		{"shipit", "", "in_route", "out_for_delivery"},
+		{"shipit", "", "in_distribution", "out_for_delivery"},
+		{"shipit", "", "delivered", "delivered"},
+		{"shipit", "", "successful", "delivered"},
+		{"shipit", "", "failed", "attempt_failed"},
+		{"shipit", "", "failed_delivery", "attempt_failed"},
+		{"shipit", "", "rejected", "attempt_failed"},
+		{"shipit", "", "in_return", "returning"},
+		{"shipit", "", "returning", "returning"},
+		{"shipit", "", "returned", "returned"},
+		{"shipit", "", "on_hold", "exception"},
+		{"shipit", "", "retained", "exception"},
+		{"shipit", "", "incidence", "exception"},
+		{"shipit", "", "claim", "exception"},
+		{"shipit", "", "cancelled", "cancelled"},
+		{"shipit", "", "canceled", "cancelled"},
+		{"shipit", "", "annulled", "cancelled"},
+	}
+
+	// Eventos que registra el propio consumer al generar y cancelar la guía
+	for _, provider := range []string{"envioclick", "shipit", "enviame", "mipaquete"} {
+		seeds = append(seeds,
+			trackingStatusSeed{provider, "", "guia generada", "label_created"},
+			trackingStatusSeed{provider, "", "cancelado", "cancelled"},
+		)
+	}
+	return seeds
+}
+
+func (r *Repository) migrateShipmentTrackingEvents(ctx context.Context) error {
+	if err := r.db.Conn(ctx).AutoMigrate(&models.ShipmentTrackingEvent{}, &models.CarrierStatusMapping{}); err != nil {
+		return fmt.Errorf("automigrate shipment_tracking_events: %w", err)
+	}
+
+	// DO NOTHING: no pisa las correcciones hechas desde el admin
+	const insert = `
+		INSERT INTO carrier_status_mappings (provider, carrier, raw_status, event_code, created_at, updated_at)
+		VALUES (?, ?, ?, ?, NOW(), NOW())
+		ON CONFLICT (provider, carrier, raw_status) DO NOTHING`
+
+	for _, s := range trackingStatusSeeds() {
+		if err := r.db.Conn(ctx).Exec(insert, s.provider, s.carrier, s.rawStatus, s.eventCode).Error; err != nil {
+			return fmt.Errorf("seed mapeo %s/%s %q: %w", s.provider, s.carrier, s.rawStatus, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func (r *Repository) migrateSSODomains(ctx context.Context) error {
+	db := r.db.Conn(ctx)
+	if err := db.AutoMigrate(&models.BusinessSSODomain{}); err != nil {
+		return fmt.Errorf("failed to auto-migrate business_sso_domains: %w", err)
+	}
+	// Un dominio verificado es de un solo negocio: el descubrimiento por email
+	// no puede tener dos candidatos
+	if err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_sso_domain_verified
+		ON business_sso_domains (domain)
+		WHERE verified_at IS NOT NULL AND deleted_at IS NULL`).Error; err != nil {
+		return fmt.Errorf("failed to create idx_sso_domain_verified: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func (r *Repository) migrateTwoFactorAndSessions(ctx context.Context) error {
+	db := r.db.Conn(ctx)
+
+	if !db.Migrator().HasColumn(&models.Role{}, "RequireTwoFactor") {
+		if err := db.Migrator().AddColumn(&models.Role{}, "RequireTwoFactor"); err != nil {
+			return fmt.Errorf("failed to add role.require_two_factor: %w", err)
+		}
+	}
+
+	if err := db.AutoMigrate(&models.UserTwoFactor{}, &models.UserRecoveryCode{}, &models.UserSession{}); err != nil {
+		return fmt.Errorf("failed to auto-migrate two factor and session tables: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// migrateWalletHoldBusinessIdempotency cambia la llave unica de wallet_holds de
+// idempotency_key a (business_id, idempotency_key): la llave la elige quien
+// retiene y no debe chocar con la de otro negocio
+func (r *Repository) migrateWalletHoldBusinessIdempotency(ctx context.Context) error {
+	db := r.db.Conn(ctx)
+
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_wallet_holds_idempotency_key`).Error; err != nil {
+		return fmt.Errorf("drop idx_wallet_holds_idempotency_key: %w", err)
+	}
+
+	if err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_wallet_holds_business_key ON wallet_holds (business_id, idempotency_key)`).Error; err != nil {
+		return fmt.Errorf("create idx_wallet_holds_business_key: %w", err)
+	}
+
+	return nil
+}
+
+// revertWalletHoldBusinessIdempotency vuelve a la llave unica global. Falla si
+// dos negocios ya usaron la misma llave
+func (r *Repository) revertWalletHoldBusinessIdempotency(ctx context.Context) error {
+	db := r.db.Conn(ctx)
+
+	if err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_wallet_holds_idempotency_key ON wallet_holds (idempotency_key)`).Error; err != nil {
+		return fmt.Errorf("create idx_wallet_holds_idempotency_key: %w", err)
+	}
+
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_wallet_holds_business_key`).Error; err != nil {
+		return fmt.Errorf("drop idx_wallet_holds_business_key: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+// migrateWalletJournalBusinessIdempotency cambia la llave unica de
+// wallet_journals de idempotency_key a (business_id, idempotency_key): con la
+// llave global, el debito de un negocio con la misma llave de cliente o el
+// mismo tracking que otro chocaba y se daba por aplicado sin cobrarse
+func (r *Repository) migrateWalletJournalBusinessIdempotency(ctx context.Context) error {
+	db := r.db.Conn(ctx)
+
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_wallet_journals_idempotency_key`).Error; err != nil {
+		return fmt.Errorf("drop idx_wallet_journals_idempotency_key: %w", err)
+	}
+
+	if err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_wallet_journals_business_key ON wallet_journals (business_id, idempotency_key)`).Error; err != nil {
+		return fmt.Errorf("create idx_wallet_journals_business_key: %w", err)
+	}
+
+	return nil
+}
+
+// revertWalletJournalBusinessIdempotency vuelve a la llave unica global. Falla
+// si dos negocios ya usaron la misma llave
+func (r *Repository) revertWalletJournalBusinessIdempotency(ctx context.Context) error {
+	db := r.db.Conn(ctx)
+
+	if err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_wallet_journals_idempotency_key ON wallet_journals (idempotency_key)`).Error; err != nil {
+		return fmt.Errorf("create idx_wallet_journals_idempotency_key: %w", err)
+	}
+
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_wallet_journals_business_key`).Error; err != nil {
+		return fmt.Errorf("drop idx_wallet_journals_business_key: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+	"gorm.io/gorm"
+)
+
+func (r *Repository) migrateWalletLedger(ctx context.Context) error {
+	if err := r.db.Conn(ctx).AutoMigrate(&models.Wallet{}); err != nil {
+		return fmt.Errorf("add wallet.held_balance: %w", err)
+	}
+
+	if err := r.db.Conn(ctx).AutoMigrate(
+		&models.WalletJournal{},
+		&models.WalletLedgerEntry{},
+		&models.WalletHold{},
+	); err != nil {
+		return fmt.Errorf("failed to auto-migrate wallet ledger: %w", err)
+	}
+
+	// Asiento de apertura: el saldo actual de cada billetera entra al libro
+	// contra system:opening, para que el verificador de consistencia cuadre
+	// desde el primer dia. Es idempotente por la llave opening:<wallet_id>
+	return r.db.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+INSERT INTO wallet_journals (id, wallet_id, business_id, kind, idempotency_key, description, created_at)
+SELECT gen_random_uuid(), w.id, w.business_id, 'opening', 'opening:' || w.id::text, 'Saldo de apertura del libro mayor', NOW()
+FROM wallet w
+ON CONFLICT (idempotency_key) DO NOTHING
+`).Error; err != nil {
+			return fmt.Errorf("create opening journals: %w", err)
+		}
+
+		if err := tx.Exec(`
+WITH pending AS (
+    SELECT j.id, j.wallet_id, w.balance
+    FROM wallet_journals j
+    INNER JOIN wallet w ON w.id = j.wallet_id
+    WHERE j.kind = 'opening'
+      AND NOT EXISTS (SELECT 1 FROM wallet_ledger_entries e WHERE e.journal_id = j.id)
+)
+INSERT INTO wallet_ledger_entries (journal_id, wallet_id, account, amount, created_at)
+SELECT id, wallet_id, 'available', balance, NOW() FROM pending
+UNION ALL
+SELECT id, NULL, 'system:opening', -balance, NOW() FROM pending
+`).Error; err != nil {
+			return fmt.Errorf("create opening entries: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+// notificationTypeWebhookID es el id fijo del canal webhook: el dispatcher de
+// eventos rutea por id (1=SSE, 2=WhatsApp, 3=Email, 4=SMS).
+const notificationTypeWebhookID = 5
+
+// webhookEventTypes son los eventos a los que un negocio puede suscribir sus
+// endpoints desde notification configs.
+var webhookEventTypes = []struct{ code, name, description string }{
+	{"order.created", "Orden creada", "Se crea una orden en cualquier canal"},
+	{"order.status_changed", "Cambio de estado de orden", "Cambia el estado de una orden"},
+	{"order.shipped", "Orden enviada", "La orden sale a despacho"},
+	{"order.delivered", "Orden entregada", "La orden fue entregada al cliente"},
+	{"order.cancelled", "Orden cancelada", "La orden fue cancelada"},
+	{"shipment.guide_generated", "Guia generada", "Se genero la guia de un envio"},
+	{"shipment.tracking_updated", "Tracking actualizado", "La transportadora reporto un nuevo estado del envio"},
+	{"shipment.cancelled", "Envio cancelado", "Se cancelo la guia de un envio"},
+	{"invoice.created", "Factura emitida", "Se emitio la factura electronica de una orden"},
+	{"invoice.cancelled", "Factura anulada", "Se anulo una factura electronica"},
+	{"credit_note.created", "Nota credito emitida", "Se emitio una nota credito"},
+}
+
+func (r *Repository) migrateWebhookEndpoints(ctx context.Context) error {
+	db := r.db.Conn(ctx)
+
+	if err := db.AutoMigrate(&models.WebhookEndpoint{}, &models.WebhookDelivery{}); err != nil {
+		return fmt.Errorf("automigrate webhook endpoints: %w", err)
+	}
+
+	// Un evento se entrega una sola vez por endpoint; los reenvios manuales
+	// quedan por fuera porque apuntan a la entrega original
+	if err := db.Exec(`
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_delivery_event
+ON webhook_deliveries(endpoint_id, event_id)
+WHERE redelivery_of_id IS NULL
+`).Error; err != nil {
+		return fmt.Errorf("create webhook delivery event index: %w", err)
+	}
+
+	var taken string
+	if err := db.Raw(`SELECT code FROM notification_types WHERE id = ?`, notificationTypeWebhookID).Scan(&taken).Error; err != nil {
+		return fmt.Errorf("lookup notification type %d: %w", notificationTypeWebhookID, err)
+	}
+	if taken != "" && taken != "webhook" {
+		return fmt.Errorf("notification type %d ya esta ocupado por %q", notificationTypeWebhookID, taken)
+	}
+
+	if err := db.Exec(`
+INSERT INTO notification_types (id, name, code, description, icon, is_active, config_schema, created_at, updated_at)
+VALUES (?, 'Webhook', 'webhook', 'Eventos firmados enviados a endpoints HTTPS del negocio', 'webhook', true, '{"required_fields": [], "optional_fields": []}', NOW(), NOW())
+ON CONFLICT (code) DO NOTHING
+`, notificationTypeWebhookID).Error; err != nil {
+		return fmt.Errorf("seed webhook notification type: %w", err)
+	}
+	if err := db.Exec(`
+SELECT setval(pg_get_serial_sequence('notification_types', 'id'), GREATEST((SELECT MAX(id) FROM notification_types), 1))
+`).Error; err != nil {
+		return fmt.Errorf("sync notification_types sequence: %w", err)
+	}
+
+	for _, evt := range webhookEventTypes {
+		if err := db.Exec(`
+INSERT INTO notification_event_types (notification_type_id, event_code, event_name, description, is_active, created_at, updated_at)
+VALUES (?, ?, ?, ?, true, NOW(), NOW())
+ON CONFLICT (notification_type_id, event_code) DO NOTHING
+`, notificationTypeWebhookID, evt.code, evt.name, evt.description).Error; err != nil {
+			return fmt.Errorf("seed webhook event type %s: %w", evt.code, err)
+		}
+	}
+
+	return nil
+}
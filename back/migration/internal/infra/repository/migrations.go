@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+// migrations es el registro ordenado de migraciones Go que maneja el runner.
+// Para agregar una: escribir la funcion en su archivo y sumarla aqui con la
+// siguiente version (AAAAMMDDNN). Los fixes de datos de una sola corrida van
+// sin Down. Lo anterior a este registro vive en migrateHistorico().
+func (r *Repository) migrations() []Migration {
+	return []Migration{
+		{
+			Version: 2026101801,
+			Name:    "outbox_events",
+			Up:      r.migrateOutboxEvents,
+			Down:    r.dropTables(&models.OutboxEvent{}),
+		},
+		{
+			Version: 2026101802,
+			Name:    "order_workflows",
+			Up:      r.migrateOrderWorkflows,
+			Down:    r.dropTables(&models.OrderWorkflow{}),
+		},
+		{
+			Version: 2026101803,
+			Name:    "returns",
+			Up:      r.migrateReturns,
+			Down:    r.dropTables(&models.ReturnStatusHistory{}, &models.ReturnRequestItem{}, &models.ReturnRequest{}),
+		},
+		{
+			Version: 2026101804,
+			Name:    "purchasing",
+			Up:      r.migratePurchasing,
+			Down: r.dropTables(
+				&models.PurchaseOrderLandedCost{},
+				&models.PurchaseOrderReceiptLine{},
+				&models.PurchaseOrderReceipt{},
+				&models.PurchaseOrderLine{},
+				&models.PurchaseOrder{},
+				&models.SupplierProduct{},
+				&models.Supplier{},
+			),
+		},
+		{
+			// Down deja sembrados los conceptos contables: pueden tener asientos
+			Version: 2026101805,
+			Name:    "inventory_valuation",
+			Up:      r.migrateInventoryValuation,
+			Down: r.downSteps(
+				r.dropTables(
+					&models.OrderLineCost{},
+					&models.InventoryCostLayer{},
+					&models.InventoryCostBalance{},
+					&models.InventoryValuationSetting{},
+				),
+				r.dropColumns(&models.StockMovement{}, "unit_cost", "total_cost"),
+			),
+		},
+		{
+			Version: 2026101806,
+			Name:    "kits",
+			Up:      r.migrateKits,
+			Down:    r.dropTables(&models.KitAssemblyOrder{}, &models.ProductKitComponent{}, &models.ProductKit{}),
+		},
+		{
+			Version: 2026101807,
+			Name:    "pick_waves",
+			Up:      r.migratePickWaves,
+			Down: r.downSteps(
+				r.dropTables(&models.PickListLine{}, &models.PickWaveItem{}, &models.PickWaveOrder{}, &models.PickWave{}),
+				r.dropColumns(&models.Order{}, "priority"),
+			),
+		},
+		{
+			// Irreversible: borrar el libro mayor con retenciones activas deja
+			// el saldo retenido sin contrapartida
+			Version: 2026101808,
+			Name:    "wallet_ledger",
+			Up:      r.migrateWalletLedger,
+		},
+		{
+			// Down conserva el tipo de integracion 36: puede tener integraciones creadas
+			Version: 2026101809,
+			Name:    "dian_invoicing",
+			Up:      r.migrateDianInvoicing,
+			Down:    r.dropTables(&models.DianDocument{}, &models.DianNumberingCounter{}),
+		},
+		{
+			Version: 2026101810,
+			Name:    "shipment_tracking_events",
+			Up:      r.migrateShipmentTrackingEvents,
+			Down:    r.dropTables(&models.ShipmentTrackingEvent{}, &models.CarrierStatusMapping{}),
+		},
+		{
+			Version: 2026101811,
+			Name:    "shipment_packages",
+			Up:      r.migrateShipmentPackages,
+			Down:    r.dropTables(&models.ShipmentPackage{}),
+		},
+		{
+			Version: 2026101812,
+			Name:    "cod_settlements",
+			Up:      r.migrateCodSettlements,
+			Down:    r.dropTables(&models.CodSettlementLine{}, &models.CodSettlement{}, &models.CodSettlementProfile{}),
+		},
+		{
+			Version: 2026101813,
+			Name:    "carrier_invoices",
+			Up:      r.migrateCarrierInvoices,
+			Down:    r.dropTables(&models.CarrierInvoiceLine{}, &models.CarrierInvoice{}, &models.CarrierInvoiceProfile{}),
+		},
+		{
+			Version: 2026101814,
+			Name:    "accounting_ledger",
+			Up:      r.migrateAccountingLedger,
+			Down:    r.dropTables(&models.AccountingJournalLine{}, &models.AccountingJournalEntry{}, &models.AccountingPeriod{}, &models.AccountingPostingRule{}, &models.AccountingAccount{}),
+		},
+		{
+			Version: 2026101815,
+			Name:    "webhook_endpoints",
+			Up:      r.migrateWebhookEndpoints,
+			Down:    r.dropTables(&models.WebhookDelivery{}, &models.WebhookEndpoint{}),
+		},
+		{
+			Version: 2026101816,
+			Name:    "api_keys",
+			Up:      r.migrateAPIKeys,
+			Down:    r.dropTables("api_key_permissions", &models.APIKey{}),
+		},
+		{
+			Version: 2026101817,
+			Name:    "two_factor_and_sessions",
+			Up:      r.migrateTwoFactorAndSessions,
+			Down: r.downSteps(
+				r.dropTables(&models.UserSession{}, &models.UserRecoveryCode{}, &models.UserTwoFactor{}),
+				r.dropColumns(&models.Role{}, "require_two_factor"),
+			),
+		},
+		{
+			Version: 2026101818,
+			Name:    "business_sso",
+			Up:      r.migrateBusinessSSO,
+			Down:    r.dropTables(&models.SSOLoginState{}, &models.UserSSOIdentity{}, &models.BusinessSSOConfig{}),
+		},
+		{
+			Version: 2026101819,
+			Name:    "audit_logs",
+			Up:      r.migrateAuditLogs,
+			Down:    r.dropAuditLogs,
+		},
+		{
+			Version: 2026101820,
+			Name:    "sso_domain_verification",
+			Up:      r.migrateSSODomains,
+			Down:    r.dropTables(&models.BusinessSSODomain{}),
+		},
+		// Fixes de datos de Viga (negocio 46), en el orden de migrateHistorico.
+		// Cada uno solo toca filas que siguen con el valor viejo, asi que en
+		// los entornos donde ya corrieron quedan registrados sin cambiar nada.
+		{
+			Version: 2026101821,
+			Name:    "fix_viga_cod_real_payout",
+			Up:      r.fixVigaCodRealPayout,
+		},
+		{
+			Version: 2026101822,
+			Name:    "seed_viga_cod_margin_amount",
+			Up:      r.seedVigaCodMarginAmount,
+		},
+		{
+			Version: 2026101823,
+			Name:    "fix_viga_cod_en_curso",
+			Up:      r.fixVigaCodEnCurso,
+		},
+		{
+			Version: 2026101824,
+			Name:    "fix_viga_cod_calibracion_fallida",
+			Up:      r.fixVigaCodCalibracionFallida,
+		},
+		{
+			Version: 2026101825,
+			Name:    "fix_viga_cod_promesa_corte52",
+			Up:      r.FixVigaCodPromesaCorte52,
+		},
+		{
+			Version: 2026101826,
+			Name:    "fix_viga_cod_promesa_resto",
+			Up:      r.FixVigaCodPromesaResto,
+		},
+		{
+			Version: 2026101827,
+			Name:    "fix_viga_cod_revert_confirmadas",
+			Up:      r.FixVigaCodRevertConfirmadas,
+		},
+		{
+			Version: 2026101828,
+			Name:    "wallet_hold_business_idempotency",
+			Up:      r.migrateWalletHoldBusinessIdempotency,
+			Down:    r.revertWalletHoldBusinessIdempotency,
+		},
+		{
+			Version: 2026101829,
+			Name:    "wallet_journal_business_idempotency",
+			Up:      r.migrateWalletJournalBusinessIdempotency,
+			Down:    r.revertWalletJournalBusinessIdempotency,
+		},
+		{
+			Version: 2026101830,
+			Name:    "outbox_claims",
+			Up:      r.migrateOutboxClaims,
+			Down:    r.revertOutboxClaims,
+		},
+	}
+}
+
+func (r *Repository) dropTables(tables ...interface{}) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return r.db.Conn(ctx).Migrator().DropTable(tables...)
+	}
+}
+
+func (r *Repository) dropColumns(model interface{}, columns ...string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		m := r.db.Conn(ctx).Migrator()
+		for _, column := range columns {
+			if !m.HasColumn(model, column) {
+				continue
+			}
+			if err := m.DropColumn(model, column); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func (r *Repository) downSteps(steps ...func(ctx context.Context) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		for _, step := range steps {
+			if err := step(ctx); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
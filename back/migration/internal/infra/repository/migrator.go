@@ -0,0 +1,444 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/secamc93/probability/back/migration/shared/db"
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+const (
+	migrationKindGo  = "go"
+	migrationKindSQL = "sql"
+)
+
+// migrationLockKey es la llave del advisory lock de Postgres que serializa las
+// corridas del runner: dos deploys al tiempo no aplican la misma migracion.
+const migrationLockKey int64 = 7_305_020_261_018
+
+// Migration es un paso versionado del esquema o de datos. Se registra en
+// migrations() (Go) o como archivo en sql/ (<version>_<nombre>.up.sql).
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(ctx context.Context) error
+	// Down revierte Up. nil marca la migracion como irreversible (fixes de
+	// datos de una sola corrida): down y redo se niegan a tocarla.
+	Down func(ctx context.Context) error
+	// NoTransaction corre la migracion fuera de transaccion. Solo para lo que
+	// Postgres no permite dentro de una (CREATE INDEX CONCURRENTLY).
+	NoTransaction bool
+	// Revision se sube al editar el cuerpo de una migracion Go ya aplicada. Go
+	// no deja hashear el codigo de una funcion, asi que es lo que entra al
+	// checksum: sin subirla, status no ve el cambio.
+	Revision int
+
+	kind     string
+	checksum string
+	upSQL    string
+}
+
+// Kind indica si la migracion viene de codigo Go o de un archivo SQL.
+func (m Migration) Kind() string { return m.kind }
+
+// Checksum identifica el contenido aplicado. En las SQL es el hash del archivo
+// .up.sql; en las Go, de la version, el nombre y la Revision.
+func (m Migration) Checksum() string { return m.checksum }
+
+// UpSQL es el contenido del archivo .up.sql (vacio en las Go).
+func (m Migration) UpSQL() string { return m.upSQL }
+
+// Reversible indica si la migracion tiene Down.
+func (m Migration) Reversible() bool { return m.Down != nil }
+
+// Estados de una migracion frente a schema_migrations.
+const (
+	MigrationPending = "pending"
+	MigrationApplied = "applied"
+	// MigrationChanged: aplicada, pero el archivo (o la Revision de una Go)
+	// cambio despues de correrla
+	MigrationChanged = "changed"
+	// MigrationMissing: aplicada en la base, pero ya no existe en el codigo
+	MigrationMissing = "missing"
+)
+
+// MigrationStatus es una fila del reporte de status.
+type MigrationStatus struct {
+	Version    int64
+	Name       string
+	Kind       string
+	State      string
+	AppliedAt  *time.Time
+	Reversible bool
+}
+
+// Status compara las migraciones registradas con las aplicadas.
+func (r *Repository) Status(ctx context.Context) ([]MigrationStatus, error) {
+	registered, err := r.registeredMigrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := r.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return classifyMigrations(registered, applied), nil
+}
+
+// classifyMigrations cruza lo registrado con lo aplicado: pending, applied,
+// changed (checksum distinto) o missing (aplicada pero fuera del codigo).
+func classifyMigrations(registered []Migration, applied map[int64]models.SchemaMigration) []MigrationStatus {
+	applied = maps.Clone(applied)
+	result := make([]MigrationStatus, 0, len(registered))
+	for _, m := range registered {
+		st := MigrationStatus{Version: m.Version, Name: m.Name, Kind: m.kind, State: MigrationPending, Reversible: m.Reversible()}
+		if row, ok := applied[m.Version]; ok {
+			appliedAt := row.AppliedAt
+			st.AppliedAt = &appliedAt
+			st.State = MigrationApplied
+			if row.Checksum != m.checksum {
+				st.State = MigrationChanged
+			}
+			delete(applied, m.Version)
+		}
+		result = append(result, st)
+	}
+	for _, row := range applied {
+		appliedAt := row.AppliedAt
+		result = append(result, MigrationStatus{Version: row.Version, Name: row.Name, Kind: row.Kind, State: MigrationMissing, AppliedAt: &appliedAt})
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result
+}
+
+// Pending retorna las migraciones que up aplicaria, sin tomar el lock ni
+// tocar nada (dry-run): en una base nueva ni siquiera crea schema_migrations.
+func (r *Repository) Pending(ctx context.Context) ([]Migration, error) {
+	registered, err := r.registeredMigrations()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := r.appliedMigrations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAppliedChecksums(registered, applied); err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, m := range registered {
+		if _, ok := applied[m.Version]; !ok {
+			pending = append(pending, m)
+		}
+	}
+	return pending, nil
+}
+
+// Up aplica en orden las migraciones pendientes hasta target (0 = todas).
+// Se niega a correr si alguna migracion ya aplicada cambio de contenido.
+func (r *Repository) Up(ctx context.Context, target int64) ([]Migration, error) {
+	var done []Migration
+	err := r.withMigrationLock(ctx, func(ctx context.Context) error {
+		if err := r.ensureSchemaMigrations(ctx); err != nil {
+			return err
+		}
+		pending, err := r.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		for _, m := range pending {
+			if target > 0 && m.Version > target {
+				break
+			}
+			if err := r.applyMigration(ctx, m); err != nil {
+				return err
+			}
+			done = append(done, m)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down revierte las ultimas steps migraciones aplicadas, de la mas nueva a la
+// mas vieja. Se detiene antes de tocar nada si alguna es irreversible.
+func (r *Repository) Down(ctx context.Context, steps int) ([]Migration, error) {
+	var done []Migration
+	err := r.withMigrationLock(ctx, func(ctx context.Context) error {
+		targets, err := r.lastApplied(ctx, steps)
+		if err != nil {
+			return err
+		}
+		for _, m := range targets {
+			if err := r.revertMigration(ctx, m); err != nil {
+				return err
+			}
+			done = append(done, m)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Redo revierte y vuelve a aplicar la ultima migracion aplicada.
+func (r *Repository) Redo(ctx context.Context) (*Migration, error) {
+	var redone *Migration
+	err := r.withMigrationLock(ctx, func(ctx context.Context) error {
+		targets, err := r.lastApplied(ctx, 1)
+		if err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			return nil
+		}
+		m := targets[0]
+		if err := r.revertMigration(ctx, m); err != nil {
+			return err
+		}
+		if err := r.applyMigration(ctx, m); err != nil {
+			return err
+		}
+		redone = &m
+		return nil
+	})
+	return redone, err
+}
+
+// NextMigrationVersion retorna la siguiente version libre del dia (AAAAMMDDNN)
+// entre las migraciones Go y SQL registradas. No necesita base de datos.
+func NextMigrationVersion(now time.Time) (int64, error) {
+	registered, err := (&Repository{}).registeredMigrations()
+	if err != nil {
+		return 0, err
+	}
+	return nextVersion(registered, now)
+}
+
+func nextVersion(registered []Migration, now time.Time) (int64, error) {
+	day, _ := strconv.ParseInt(now.Format("20060102"), 10, 64)
+	next := day*100 + 1
+	for _, m := range registered {
+		if m.Version >= next && m.Version < (day+1)*100 {
+			next = m.Version + 1
+		}
+	}
+	if next >= (day+1)*100 {
+		return 0, fmt.Errorf("no quedan versiones libres para %d", day)
+	}
+	return next, nil
+}
+
+func (r *Repository) registeredMigrations() ([]Migration, error) {
+	all := r.migrations()
+	for i := range all {
+		all[i].kind = migrationKindGo
+		all[i].checksum = goMigrationChecksum(all[i])
+	}
+
+	fromSQL, err := r.sqlMigrations()
+	if err != nil {
+		return nil, err
+	}
+	all = append(all, fromSQL...)
+	if err := sortAndValidate(all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// sortAndValidate ordena por version y rechaza migraciones incompletas o dos
+// con la misma version (Go contra Go, SQL contra SQL o Go contra SQL).
+func sortAndValidate(all []Migration) error {
+	sort.Slice(all, func(i, j int) bool { return all[i].Version < all[j].Version })
+
+	seen := make(map[int64]string, len(all))
+	for _, m := range all {
+		if m.Version <= 0 || m.Name == "" || m.Up == nil {
+			return fmt.Errorf("migracion invalida: version=%d nombre=%q", m.Version, m.Name)
+		}
+		if prev, ok := seen[m.Version]; ok {
+			return fmt.Errorf("version %d duplicada: %s y %s", m.Version, prev, m.Name)
+		}
+		seen[m.Version] = m.Name
+	}
+	return nil
+}
+
+// lastApplied retorna las ultimas n migraciones aplicadas, de la mas nueva a
+// la mas vieja, validando que todas existan y tengan Down.
+func (r *Repository) lastApplied(ctx context.Context, n int) ([]Migration, error) {
+	if n <= 0 {
+		return nil, nil
+	}
+	registered, err := r.registeredMigrations()
+	if err != nil {
+		return nil, err
+	}
+	byVersion := make(map[int64]Migration, len(registered))
+	for _, m := range registered {
+		byVersion[m.Version] = m
+	}
+
+	if err := r.ensureSchemaMigrations(ctx); err != nil {
+		return nil, err
+	}
+	var rows []models.SchemaMigration
+	if err := r.db.Conn(ctx).Order("version DESC").Limit(n).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+
+	result := make([]Migration, 0, len(rows))
+	for _, row := range rows {
+		m, ok := byVersion[row.Version]
+		if !ok {
+			return nil, fmt.Errorf("migracion %d_%s aplicada pero no existe en el codigo", row.Version, row.Name)
+		}
+		if !m.Reversible() {
+			return nil, fmt.Errorf("migracion %d_%s es irreversible", m.Version, m.Name)
+		}
+		result = append(result, m)
+	}
+	return result, nil
+}
+
+func (r *Repository) applyMigration(ctx context.Context, m Migration) error {
+	r.log.Info(ctx).Int64("version", m.Version).Str("name", m.Name).Str("kind", m.kind).Msg("Aplicando migracion")
+
+	run := func(ctx context.Context) error {
+		start := time.Now()
+		if err := m.Up(ctx); err != nil {
+			return fmt.Errorf("migracion %d_%s: %w", m.Version, m.Name, err)
+		}
+		row := &models.SchemaMigration{
+			Version:     m.Version,
+			Name:        m.Name,
+			Kind:        m.kind,
+			Checksum:    m.checksum,
+			AppliedAt:   time.Now(),
+			ExecutionMs: time.Since(start).Milliseconds(),
+		}
+		if err := r.db.Conn(ctx).Create(row).Error; err != nil {
+			return fmt.Errorf("failed to record migration %d: %w", m.Version, err)
+		}
+		return nil
+	}
+
+	if m.NoTransaction {
+		return run(ctx)
+	}
+	return db.InTransaction(ctx, r.db, run)
+}
+
+func (r *Repository) revertMigration(ctx context.Context, m Migration) error {
+	r.log.Info(ctx).Int64("version", m.Version).Str("name", m.Name).Str("kind", m.kind).Msg("Revirtiendo migracion")
+
+	run := func(ctx context.Context) error {
+		if err := m.Down(ctx); err != nil {
+			return fmt.Errorf("revertir migracion %d_%s: %w", m.Version, m.Name, err)
+		}
+		if err := r.db.Conn(ctx).Delete(&models.SchemaMigration{}, "version = ?", m.Version).Error; err != nil {
+			return fmt.Errorf("failed to unrecord migration %d: %w", m.Version, err)
+		}
+		return nil
+	}
+
+	if m.NoTransaction {
+		return run(ctx)
+	}
+	return db.InTransaction(ctx, r.db, run)
+}
+
+func (r *Repository) ensureSchemaMigrations(ctx context.Context) error {
+	if err := r.db.Conn(ctx).AutoMigrate(&models.SchemaMigration{}); err != nil {
+		return fmt.Errorf("failed to auto-migrate schema_migrations: %w", err)
+	}
+	return nil
+}
+
+// appliedMigrations lee schema_migrations sin crearla: si aun no existe no hay
+// nada aplicado.
+func (r *Repository) appliedMigrations(ctx context.Context) (map[int64]models.SchemaMigration, error) {
+	if !r.db.Conn(ctx).Migrator().HasTable(&models.SchemaMigration{}) {
+		return map[int64]models.SchemaMigration{}, nil
+	}
+	var rows []models.SchemaMigration
+	if err := r.db.Conn(ctx).Order("version").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	applied := make(map[int64]models.SchemaMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// withMigrationLock toma el advisory lock en una conexion dedicada (el lock es
+// de sesion) y lo suelta al terminar. Si otra corrida lo tiene, espera.
+func (r *Repository) withMigrationLock(ctx context.Context, fn func(ctx context.Context) error) error {
+	sqlDB, err := r.db.Conn(ctx).DB()
+	if err != nil {
+		return fmt.Errorf("failed to get sql connection: %w", err)
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to reserve lock connection: %w", err)
+	}
+	defer conn.Close()
+
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", migrationLockKey).Scan(&acquired); err != nil {
+		return fmt.Errorf("failed to acquire migration lock: %w", err)
+	}
+	if !acquired {
+		r.log.Warn(ctx).Msg("Otra corrida de migraciones tiene el lock, esperando...")
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", migrationLockKey); err != nil {
+			r.log.Error(ctx).Err(err).Msg("failed to release migration lock")
+		}
+	}()
+
+	return fn(ctx)
+}
+
+func checkAppliedChecksums(registered []Migration, applied map[int64]models.SchemaMigration) error {
+	var changed []error
+	for _, m := range registered {
+		if row, ok := applied[m.Version]; ok && row.Checksum != m.checksum {
+			changed = append(changed, fmt.Errorf("%d_%s", m.Version, m.Name))
+		}
+	}
+	if len(changed) > 0 {
+		return fmt.Errorf("migraciones aplicadas que cambiaron despues de correrlas (revertir el archivo o usar redo): %w", errors.Join(changed...))
+	}
+	return nil
+}
+
+// goMigrationChecksum conserva el formato sin revision para que las Go ya
+// aplicadas no aparezcan como changed
+func goMigrationChecksum(m Migration) string {
+	content := fmt.Sprintf("go:%d:%s", m.Version, m.Name)
+	if m.Revision > 0 {
+		content = fmt.Sprintf("%s:r%d", content, m.Revision)
+	}
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func sqlChecksum(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/secamc93/probability/back/migration/shared/models"
+)
+
+func noop(context.Context) error { return nil }
+
+func goMigration(version int64, name string, revision int) Migration {
+	m := Migration{Version: version, Name: name, Up: noop, Revision: revision, kind: migrationKindGo}
+	m.checksum = goMigrationChecksum(m)
+	return m
+}
+
+func TestClassifyMigrations_EstadosPendienteAplicadaCambiadaFaltante(t *testing.T) {
+	registered := []Migration{
+		goMigration(2026101801, "aplicada", 0),
+		goMigration(2026101802, "cambiada", 1),
+		goMigration(2026101803, "pendiente", 0),
+	}
+	applied := map[int64]models.SchemaMigration{
+		2026101801: {Version: 2026101801, Name: "aplicada", Checksum: registered[0].checksum},
+		2026101802: {Version: 2026101802, Name: "cambiada", Checksum: goMigrationChecksum(goMigration(2026101802, "cambiada", 0))},
+		2026101700: {Version: 2026101700, Name: "borrada", Kind: migrationKindSQL},
+	}
+
+	result := classifyMigrations(registered, applied)
+
+	want := map[int64]string{
+		2026101700: MigrationMissing,
+		2026101801: MigrationApplied,
+		2026101802: MigrationChanged,
+		2026101803: MigrationPending,
+	}
+	if len(result) != len(want) {
+		t.Fatalf("esperaba %d estados, obtuvo %d", len(want), len(result))
+	}
+	for i, st := range result {
+		if i > 0 && result[i-1].Version >= st.Version {
+			t.Errorf("resultado no ordenado por version: %d antes de %d", result[i-1].Version, st.Version)
+		}
+		if st.State != want[st.Version] {
+			t.Errorf("version %d: esperaba %s, obtuvo %s", st.Version, want[st.Version], st.State)
+		}
+		if st.State == MigrationPending && st.AppliedAt != nil {
+			t.Errorf("version %d pendiente no deberia tener fecha de aplicacion", st.Version)
+		}
+	}
+	if len(applied) != 3 {
+		t.Errorf("classifyMigrations no deberia modificar el mapa recibido")
+	}
+}
+
+func TestGoMigrationChecksum_RevisionCambiaElChecksum(t *testing.T) {
+	base := goMigration(2026101801, "x", 0)
+	revisada := goMigration(2026101801, "x", 1)
+
+	if base.checksum == revisada.checksum {
+		t.Errorf("subir la Revision deberia cambiar el checksum")
+	}
+	if goMigration(2026101801, "x", 0).checksum != base.checksum {
+		t.Errorf("el checksum deberia ser estable")
+	}
+}
+
+func TestSortAndValidate_VersionDuplicada(t *testing.T) {
+	all := []Migration{
+		goMigration(2026101802, "b", 0),
+		goMigration(2026101801, "a", 0),
+		{Version: 2026101802, Name: "b_sql", Up: noop, kind: migrationKindSQL},
+	}
+
+	err := sortAndValidate(all)
+
+	if err == nil || !strings.Contains(err.Error(), "duplicada") {
+		t.Fatalf("esperaba error de version duplicada, obtuvo %v", err)
+	}
+}
+
+func TestSortAndValidate_OrdenaYRechazaInvalidas(t *testing.T) {
+	all := []Migration{goMigration(2026101802, "b", 0), goMigration(2026101801, "a", 0)}
+	if err := sortAndValidate(all); err != nil {
+		t.Fatalf("no esperaba error: %v", err)
+	}
+	if all[0].Version != 2026101801 {
+		t.Errorf("esperaba las migraciones ordenadas por version")
+	}
+
+	sinUp := []Migration{{Version: 2026101801, Name: "sin_up"}}
+	if err := sortAndValidate(sinUp); err == nil {
+		t.Errorf("esperaba error para una migracion sin Up")
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name       string
+		registered []Migration
+		want       int64
+		wantErr    bool
+	}{
+		{name: "dia sin migraciones", registered: []Migration{goMigration(2026101705, "ayer", 0)}, want: 2026101801},
+		{name: "sigue a la ultima del dia", registered: []Migration{goMigration(2026101801, "a", 0), goMigration(2026101807, "b", 0)}, want: 2026101808},
+		{name: "ignora dias posteriores", registered: []Migration{goMigration(2026101901, "manana", 0)}, want: 2026101801},
+		{name: "dia agotado", registered: []Migration{goMigration(2026101899, "ultima", 0)}, wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := nextVersion(tc.registered, now)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("esperaba error, obtuvo %d", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("no esperaba error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("esperaba %d, obtuvo %d", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRegisteredMigrations_SinDuplicados(t *testing.T) {
+	if _, err := (&Repository{}).registeredMigrations(); err != nil {
+		t.Fatalf("las migraciones registradas deberian ser validas: %v", err)
+	}
+}
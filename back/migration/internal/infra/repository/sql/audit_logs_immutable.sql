@@ -0,0 +1,21 @@
+-- audit_logs es append-only: nadie edita una entrada y solo la purga por
+-- retencion (que abre la puerta con SET LOCAL audit.retention = 'on' dentro
+-- de su transaccion) puede borrar las viejas.
+CREATE OR REPLACE FUNCTION audit_logs_immutable() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' AND current_setting('audit.retention', true) = 'on' THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'audit_logs es de solo insercion (% rechazado)', TG_OP;
+END $$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_logs_immutable_trg ON audit_logs;
+CREATE TRIGGER audit_logs_immutable_trg
+BEFORE UPDATE OR DELETE ON audit_logs
+FOR EACH ROW EXECUTE FUNCTION audit_logs_immutable();
+
+-- TRUNCATE no dispara triggers de fila
+DROP TRIGGER IF EXISTS audit_logs_no_truncate_trg ON audit_logs;
+CREATE TRIGGER audit_logs_no_truncate_trg
+BEFORE TRUNCATE ON audit_logs
+FOR EACH STATEMENT EXECUTE FUNCTION audit_logs_immutable();
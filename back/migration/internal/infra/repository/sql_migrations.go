@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+//go:embed sql/*.sql
+var sqlMigrationFiles embed.FS
+
+// sqlMigrationPattern: 2026101901_add_algo.up.sql / 2026101901_add_algo.down.sql.
+// Los demas .sql de la carpeta son los que embeben las migraciones Go.
+var sqlMigrationPattern = regexp.MustCompile(`^(\d{10})_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// noTransactionMarker en la primera linea del .up.sql corre el archivo fuera
+// de transaccion (CREATE INDEX CONCURRENTLY).
+const noTransactionMarker = "-- migrate:no-transaction"
+
+// sqlMigrations arma una Migration por cada par up/down de sql/. El .down.sql
+// es opcional: sin el, la migracion es irreversible.
+func (r *Repository) sqlMigrations() ([]Migration, error) {
+	return r.sqlMigrationsFrom(sqlMigrationFiles)
+}
+
+func (r *Repository) sqlMigrationsFrom(files fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, "sql")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sql migrations: %w", err)
+	}
+
+	ups := map[int64]Migration{}
+	downs := map[int64]string{}
+	for _, entry := range entries {
+		match := sqlMigrationPattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := fs.ReadFile(files, path.Join("sql", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", entry.Name(), err)
+		}
+
+		if match[3] == "down" {
+			downs[version] = string(content)
+			continue
+		}
+		upSQL := string(content)
+		ups[version] = Migration{
+			Version:       version,
+			Name:          match[2],
+			Up:            r.execSQL(upSQL),
+			NoTransaction: strings.HasPrefix(strings.TrimSpace(upSQL), noTransactionMarker),
+			kind:          migrationKindSQL,
+			checksum:      sqlChecksum(upSQL),
+			upSQL:         upSQL,
+		}
+	}
+
+	result := make([]Migration, 0, len(ups))
+	for version, m := range ups {
+		if downSQL, ok := downs[version]; ok {
+			m.Down = r.execSQL(downSQL)
+			delete(downs, version)
+		}
+		result = append(result, m)
+	}
+	for version := range downs {
+		return nil, fmt.Errorf("sql/%d_*.down.sql no tiene su .up.sql", version)
+	}
+	return result, nil
+}
+
+func (r *Repository) execSQL(content string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return r.db.Conn(ctx).Exec(content).Error
+	}
+}
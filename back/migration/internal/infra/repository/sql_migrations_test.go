@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestSQLMigrationsFrom_EmparejaUpYDown(t *testing.T) {
+	files := fstest.MapFS{
+		"sql/2026101801_crear_tabla.up.sql":   {Data: []byte("CREATE TABLE t (id int);")},
+		"sql/2026101801_crear_tabla.down.sql": {Data: []byte("DROP TABLE t;")},
+		"sql/2026101802_indice.up.sql":        {Data: []byte("-- migrate:no-transaction\nCREATE INDEX CONCURRENTLY i ON t (id);")},
+		"sql/README.md":                       {Data: []byte("no es migracion")},
+	}
+
+	migrations, err := (&Repository{}).sqlMigrationsFrom(files)
+	if err != nil {
+		t.Fatalf("no esperaba error: %v", err)
+	}
+	if len(migrations) != 2 {
+		t.Fatalf("esperaba 2 migraciones, obtuvo %d", len(migrations))
+	}
+
+	byVersion := map[int64]Migration{}
+	for _, m := range migrations {
+		byVersion[m.Version] = m
+	}
+	tabla := byVersion[2026101801]
+	if tabla.Name != "crear_tabla" || tabla.Kind() != migrationKindSQL {
+		t.Errorf("migracion mal armada: nombre=%q tipo=%q", tabla.Name, tabla.Kind())
+	}
+	if !tabla.Reversible() {
+		t.Errorf("con .down.sql la migracion deberia ser reversible")
+	}
+	if tabla.NoTransaction {
+		t.Errorf("sin marcador la migracion deberia correr en transaccion")
+	}
+	if tabla.Checksum() != sqlChecksum("CREATE TABLE t (id int);") {
+		t.Errorf("el checksum deberia salir del .up.sql")
+	}
+
+	indice := byVersion[2026101802]
+	if indice.Reversible() {
+		t.Errorf("sin .down.sql la migracion deberia ser irreversible")
+	}
+	if !indice.NoTransaction {
+		t.Errorf("con el marcador la migracion deberia correr fuera de transaccion")
+	}
+}
+
+func TestSQLMigrationsFrom_DownSinUp(t *testing.T) {
+	files := fstest.MapFS{
+		"sql/2026101801_huerfana.down.sql": {Data: []byte("DROP TABLE t;")},
+	}
+
+	if _, err := (&Repository{}).sqlMigrationsFrom(files); err == nil {
+		t.Errorf("esperaba error por un .down.sql sin su .up.sql")
+	}
+}
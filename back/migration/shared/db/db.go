@@ -115,6 +115,9 @@ func (d *database) Close() error {
 
 // GetConnection retorna la conexión actual
 func (d *database) Conn(ctx context.Context) *gorm.DB {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.WithContext(ctx)
+	}
 	return d.conn.WithContext(ctx)
 }
 
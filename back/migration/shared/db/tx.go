@@ -0,0 +1,34 @@
+package db
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// WithTx guarda la transaccion en el contexto. Conn(ctx) la devuelve en vez de
+// la conexion del pool, asi las migraciones corren dentro de la transaccion
+// del runner sin cambiar su firma.
+func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext retorna la transaccion abierta con InTransaction, si hay una.
+func TxFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return tx, ok && tx != nil
+}
+
+// InTransaction ejecuta fn dentro de una transaccion. Todo lo que use
+// Conn(ctx) con el ctx que recibe fn queda en la misma transaccion; si fn
+// retorna error se hace rollback. Si ctx ya trae una transaccion, se reutiliza.
+func InTransaction(ctx context.Context, database IDatabase, fn func(ctx context.Context) error) error {
+	if _, ok := TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+	return database.Conn(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(WithTx(ctx, tx))
+	})
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AccountingAccount es una cuenta del PUC. BusinessID 0 es la plantilla
+// global; un negocio puede agregar o renombrar cuentas con su propio id.
+type AccountingAccount struct {
+	gorm.Model
+	BusinessID uint   `gorm:"not null;default:0;uniqueIndex:idx_acc_account_code,priority:1"`
+	Code       string `gorm:"size:20;not null;uniqueIndex:idx_acc_account_code,priority:2"`
+	Name       string `gorm:"size:200;not null"`
+	Nature     string `gorm:"size:6;not null"`
+	ParentCode string `gorm:"size:20;index"`
+	Level      int    `gorm:"not null"`
+	IsPostable bool   `gorm:"default:false"`
+	IsActive   bool   `gorm:"default:true;index"`
+}
+
+func (AccountingAccount) TableName() string { return "accounting_accounts" }
+
+// AccountingPostingRule dice a que cuentas va cada origen de movimiento
+// (GUIDE_MARGIN, INVOICE, CREDIT_NOTE, MANUAL_EXPENSE...).
+type AccountingPostingRule struct {
+	gorm.Model
+	BusinessID         uint   `gorm:"not null;default:0;uniqueIndex:idx_acc_posting_rule,priority:1"`
+	SourceType         string `gorm:"size:50;not null;uniqueIndex:idx_acc_posting_rule,priority:2"`
+	Description        string `gorm:"size:255"`
+	DebitAccount       string `gorm:"size:20;not null"`
+	CreditAccount      string `gorm:"size:20;not null"`
+	TaxAccount         string `gorm:"size:20"`
+	WithholdingAccount string `gorm:"size:20"`
+	OtherTaxAccount    string `gorm:"size:20"`
+	IsActive           bool   `gorm:"default:true"`
+}
+
+func (AccountingPostingRule) TableName() string { return "accounting_posting_rules" }
+
+type AccountingPeriod struct {
+	gorm.Model
+	Year     int    `gorm:"not null;uniqueIndex:idx_acc_period,priority:1"`
+	Month    int    `gorm:"not null;uniqueIndex:idx_acc_period,priority:2"`
+	Status   string `gorm:"size:10;not null;default:'OPEN'"`
+	ClosedAt *time.Time
+	ClosedBy *uint
+}
+
+func (AccountingPeriod) TableName() string { return "accounting_periods" }
+
+type AccountingJournalEntry struct {
+	gorm.Model
+	BusinessID        *uint     `gorm:"index"`
+	EntryDate         time.Time `gorm:"type:date;not null;index"`
+	Description       string    `gorm:"size:500"`
+	SourceType        string    `gorm:"size:50;not null;default:'MANUAL';index"`
+	SourceID          string    `gorm:"size:64;not null;default:''"`
+	AccountingEntryID *uint     `gorm:"index"`
+	ReversalOfID      *uint     `gorm:"index"`
+	IsAutomatic       bool      `gorm:"default:false"`
+	TotalDebit        float64   `gorm:"type:decimal(15,2);not null;default:0"`
+	TotalCredit       float64   `gorm:"type:decimal(15,2);not null;default:0"`
+	CreatedBy         *uint
+
+	Lines []AccountingJournalLine `gorm:"foreignKey:JournalEntryID"`
+}
+
+func (AccountingJournalEntry) TableName() string { return "accounting_journal_entries" }
+
+type AccountingJournalLine struct {
+	gorm.Model
+	JournalEntryID uint    `gorm:"not null;index"`
+	AccountCode    string  `gorm:"size:20;not null;index"`
+	AccountName    string  `gorm:"size:200"`
+	BusinessID     *uint   `gorm:"index"`
+	Debit          float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	Credit         float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	Description    string  `gorm:"size:500"`
+
+	JournalEntry AccountingJournalEntry `gorm:"foreignKey:JournalEntryID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (AccountingJournalLine) TableName() string { return "accounting_journal_lines" }
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// AuditLog es una entrada de la bitacora central de auditoria. La tabla es
+// append-only (un trigger rechaza UPDATE y DELETE fuera de la purga por
+// retencion) y cada fila se encadena con la anterior de su cadena: Hash es el
+// SHA-256 de la fila mas PrevHash, y ChainSeq no deja huecos.
+//
+// ChainID es el negocio de la entrada (0 para acciones de plataforma). No hay
+// FK a business: el rastro debe sobrevivir al negocio.
+type AuditLog struct {
+	ID         uint64 `gorm:"primaryKey"`
+	ChainID    uint   `gorm:"not null;uniqueIndex:idx_audit_chain_seq,priority:1"`
+	ChainSeq   uint64 `gorm:"not null;uniqueIndex:idx_audit_chain_seq,priority:2"`
+	BusinessID *uint  `gorm:"index:idx_audit_business_time,priority:1"`
+
+	ActorType   string `gorm:"size:20;not null"` // user | api_key | system
+	ActorUserID *uint  `gorm:"index"`
+	ActorLabel  string `gorm:"size:255"`
+	APIKeyID    *uint
+
+	Source        string         `gorm:"size:10;not null"` // http | domain
+	Method        string         `gorm:"size:10"`
+	Path          string         `gorm:"size:255"`
+	StatusCode    int            `gorm:"default:0"`
+	Resource      string         `gorm:"size:80;not null;index:idx_audit_resource,priority:1"`
+	ResourceID    string         `gorm:"size:100;index:idx_audit_resource,priority:2"`
+	Action        string         `gorm:"size:80;not null;index"`
+	Changes       datatypes.JSON `gorm:"type:jsonb"`
+	RequestBody   datatypes.JSON `gorm:"type:jsonb"`
+	Metadata      datatypes.JSON `gorm:"type:jsonb"`
+	IP            string         `gorm:"size:64"`
+	UserAgent     string         `gorm:"size:255"`
+	CorrelationID string         `gorm:"size:128;index"`
+
+	OccurredAt time.Time `gorm:"not null;index:idx_audit_business_time,priority:2;index"`
+	PrevHash   string    `gorm:"size:64;not null"`
+	Hash       string    `gorm:"size:64;not null"`
+}
+
+func (AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+// AuditLogCheckpoint guarda el ultimo eslabon de una cadena antes de cada
+// purga por retencion: la verificacion arranca desde aqui cuando las primeras
+// filas ya no existen.
+type AuditLogCheckpoint struct {
+	ID        uint      `gorm:"primaryKey"`
+	ChainID   uint      `gorm:"not null;index"`
+	UpToSeq   uint64    `gorm:"not null"`
+	Hash      string    `gorm:"size:64;not null"`
+	Purged    int64     `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
+
+func (AuditLogCheckpoint) TableName() string {
+	return "audit_log_checkpoints"
+}
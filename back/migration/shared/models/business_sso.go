@@ -0,0 +1,98 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// BusinessSSOConfig es la configuracion OIDC de un negocio para que su
+// personal entre al dashboard con el proveedor de identidad corporativo.
+// El client secret se guarda cifrado con ENCRYPTION_KEY.
+type BusinessSSOConfig struct {
+	gorm.Model
+	BusinessID            uint   `gorm:"not null;uniqueIndex"`
+	Enabled               bool   `gorm:"not null;default:false"`
+	ProviderName          string `gorm:"size:100"`
+	Issuer                string `gorm:"size:500;not null"`
+	ClientID              string `gorm:"size:255;not null"`
+	ClientSecretEncrypted string `gorm:"type:text"`
+	Scopes                string `gorm:"size:255;not null;default:'openid email profile'"`
+
+	// RoleClaim es el claim del id_token con los grupos/roles del IdP y
+	// RoleMappings la lista ordenada [{claim_value, role_id}] hacia roles
+	// nuestros. Sin coincidencia se usa DefaultRoleID.
+	RoleClaim     string         `gorm:"size:100"`
+	RoleMappings  datatypes.JSON `gorm:"type:jsonb"`
+	DefaultRoleID *uint          `gorm:"index"`
+
+	// EmailDomains limita el aprovisionamiento y permite descubrir el negocio
+	// desde el email en la pantalla de login
+	EmailDomains         datatypes.JSON `gorm:"type:jsonb"`
+	AutoProvision        bool           `gorm:"not null;default:true"`
+	DisablePasswordLogin bool           `gorm:"not null;default:false"`
+
+	Business    *Business `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	DefaultRole *Role     `gorm:"foreignKey:DefaultRoleID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+}
+
+func (BusinessSSOConfig) TableName() string {
+	return "business_sso_configs"
+}
+
+// BusinessSSODomain es un dominio de email que un negocio reclama para su
+// SSO. Mientras no se verifique con el registro TXT no sirve para descubrir
+// el negocio desde el email ni para activar el SSO. Un dominio verificado
+// pertenece a un solo negocio (indice unico parcial idx_sso_domain_verified).
+type BusinessSSODomain struct {
+	gorm.Model
+	BusinessID        uint   `gorm:"not null;uniqueIndex:idx_sso_domain_business,priority:1"`
+	Domain            string `gorm:"size:255;not null;uniqueIndex:idx_sso_domain_business,priority:2;index"`
+	VerificationToken string `gorm:"size:64;not null"`
+	VerifiedAt        *time.Time
+
+	Business *Business `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (BusinessSSODomain) TableName() string {
+	return "business_sso_domains"
+}
+
+// UserSSOIdentity vincula un usuario con su identidad (issuer + sub) en el
+// IdP de un negocio. El sub es estable aunque el usuario cambie de email; el
+// negocio va en la llave porque dos negocios pueden compartir el mismo tenant.
+type UserSSOIdentity struct {
+	gorm.Model
+	UserID      uint   `gorm:"not null;index"`
+	BusinessID  uint   `gorm:"not null;uniqueIndex:idx_sso_identity_subject,priority:1"`
+	Issuer      string `gorm:"size:500;not null;uniqueIndex:idx_sso_identity_subject,priority:2"`
+	Subject     string `gorm:"size:255;not null;uniqueIndex:idx_sso_identity_subject,priority:3"`
+	Email       string `gorm:"size:255"`
+	LastLoginAt *time.Time
+
+	User     *User     `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Business *Business `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (UserSSOIdentity) TableName() string {
+	return "user_sso_identities"
+}
+
+// SSOLoginState es un intento de login OIDC en curso: guarda el nonce y el
+// code_verifier de PKCE hasta que el IdP redirige de vuelta. Del state solo
+// se guarda el hash y se consume una sola vez.
+type SSOLoginState struct {
+	gorm.Model
+	StateHash    string    `gorm:"size:64;not null;uniqueIndex"`
+	BusinessID   uint      `gorm:"not null;index"`
+	Nonce        string    `gorm:"size:64;not null"`
+	CodeVerifier string    `gorm:"size:128;not null"`
+	RedirectURI  string    `gorm:"size:500;not null"`
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	UsedAt       *time.Time
+}
+
+func (SSOLoginState) TableName() string {
+	return "sso_login_states"
+}
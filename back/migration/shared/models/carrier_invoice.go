@@ -0,0 +1,91 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CarrierInvoiceProfile dice como leer la factura de una transportadora. La
+// factura llega a la plataforma (no al negocio) y cubre guias de varios
+// negocios, por eso el perfil es unico por transportadora.
+type CarrierInvoiceProfile struct {
+	gorm.Model
+	CarrierCode string `gorm:"size:50;not null;uniqueIndex"`
+
+	TrackingColumn      string `gorm:"size:128;not null"`
+	AmountColumn        string `gorm:"size:128;not null"`
+	WeightColumn        string `gorm:"size:128"`
+	SurchargeColumn     string `gorm:"size:128"`
+	ConceptColumn       string `gorm:"size:128"`
+	InvoiceNumberColumn string `gorm:"size:128"`
+
+	HeaderRow        int     `gorm:"not null;default:1"`
+	Delimiter        string  `gorm:"size:4"`
+	DecimalSeparator string  `gorm:"size:1;not null;default:','"`
+	Tolerance        float64 `gorm:"type:decimal(12,2);not null;default:0"`
+	WeightTolerance  float64 `gorm:"type:decimal(10,2);not null;default:0"` // kg que se aceptan de diferencia antes de marcar repesaje
+}
+
+func (CarrierInvoiceProfile) TableName() string {
+	return "carrier_invoice_profiles"
+}
+
+// CarrierInvoice es una factura de transportadora cargada por archivo o
+// recibida por API, ya cruzada por guia contra los envios.
+type CarrierInvoice struct {
+	gorm.Model
+	CarrierCode   string `gorm:"size:50;not null;index"`
+	InvoiceNumber string `gorm:"size:64;index"`
+	FileName      string `gorm:"size:255"`
+	Source        string `gorm:"size:16;not null;default:'file'"` // file | api
+
+	LinesCount       int     `gorm:"not null;default:0"`
+	MatchedCount     int     `gorm:"not null;default:0"`
+	DiscrepancyCount int     `gorm:"not null;default:0"`
+	UnknownCount     int     `gorm:"not null;default:0"`
+	BilledTotal      float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	ExpectedTotal    float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	DisputedTotal    float64 `gorm:"type:decimal(15,2);not null;default:0"`
+
+	UploadedBy uint `gorm:"not null;default:0"`
+}
+
+func (CarrierInvoice) TableName() string {
+	return "carrier_invoices"
+}
+
+// CarrierInvoiceLine es una guia facturada. ExpectedAmount es el costo real
+// guardado al generar la guia; lo facturado menos lo acreditado en disputas es
+// lo que termina costando el envio en el reporte de ganancias.
+type CarrierInvoiceLine struct {
+	gorm.Model
+	CarrierInvoiceID uint   `gorm:"not null;index"`
+	CarrierCode      string `gorm:"size:50;not null;index"`
+	ShipmentID       *uint  `gorm:"index"`
+	BusinessID       *uint  `gorm:"index"`
+	RowNumber        int    `gorm:"not null;default:0"`
+	TrackingNumber   string `gorm:"size:128;not null;index"`
+	Concept          string `gorm:"size:255"`
+
+	BilledAmount    float64  `gorm:"type:decimal(12,2);not null;default:0"`
+	ExpectedAmount  float64  `gorm:"type:decimal(12,2);not null;default:0"`
+	Difference      float64  `gorm:"type:decimal(12,2);not null;default:0"`
+	SurchargeAmount float64  `gorm:"type:decimal(12,2);not null;default:0"`
+	BilledWeight    *float64 `gorm:"type:decimal(10,2)"`
+	DeclaredWeight  *float64 `gorm:"type:decimal(10,2)"`
+
+	Discrepancy    string  `gorm:"size:32;not null;index"` // matched | reweigh | surcharge | overcharge | duplicate_billing | billed_cancelled | unknown_tracking
+	DisputeStatus  string  `gorm:"size:16;index"`          // vacio | open | sent | accepted | rejected
+	DisputedAmount float64 `gorm:"type:decimal(12,2);not null;default:0"`
+	CreditedAmount float64 `gorm:"type:decimal(12,2);not null;default:0"`
+	DisputeNote    string  `gorm:"size:500"`
+	DisputeSentAt  *time.Time
+	ResolvedAt     *time.Time
+
+	CarrierInvoice CarrierInvoice `gorm:"foreignKey:CarrierInvoiceID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (CarrierInvoiceLine) TableName() string {
+	return "carrier_invoice_lines"
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CodSettlementProfile dice como leer la liquidacion de una transportadora:
+// que encabezado trae la guia, cual el valor consignado y en que formato vienen
+// los numeros y las fechas. Los nombres de columna se comparan sin tildes ni
+// mayusculas.
+type CodSettlementProfile struct {
+	gorm.Model
+	BusinessID  uint   `gorm:"not null;index;uniqueIndex:idx_cod_settlement_profile_biz_carrier,priority:1"`
+	CarrierName string `gorm:"size:128;not null;uniqueIndex:idx_cod_settlement_profile_biz_carrier,priority:2"`
+
+	GuideColumn     string `gorm:"size:128;not null"`
+	AmountColumn    string `gorm:"size:128;not null"`
+	AmountBasis     string `gorm:"size:16;not null;default:'gross'"` // gross: recaudo bruto | net: consignado despues de la comision
+	FeeColumn       string `gorm:"size:128"`
+	DateColumn      string `gorm:"size:128"`
+	ReferenceColumn string `gorm:"size:128"`
+
+	HeaderRow        int     `gorm:"not null;default:1"` // fila (1..N) donde estan los encabezados
+	Delimiter        string  `gorm:"size:4"`             // CSV; vacio = detectar
+	DecimalSeparator string  `gorm:"size:1;not null;default:','"`
+	DateFormat       string  `gorm:"size:32"` // layout de Go; vacio = formatos comunes
+	Tolerance        float64 `gorm:"type:decimal(15,2);not null;default:0"`
+
+	Business Business `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (CodSettlementProfile) TableName() string {
+	return "cod_settlement_profiles"
+}
+
+// CodSettlement es un archivo de liquidacion cargado para una transportadora y
+// un periodo. Al confirmarlo sus ordenes quedan en un corte confirmado.
+type CodSettlement struct {
+	gorm.Model
+	BusinessID  uint      `gorm:"not null;index"`
+	CarrierName string    `gorm:"size:128;not null;index"`
+	FileName    string    `gorm:"size:255"`
+	PeriodStart time.Time `gorm:"type:date;not null"`
+	PeriodEnd   time.Time `gorm:"type:date;not null"`
+	Status      string    `gorm:"size:32;not null;default:'pending';index"` // pending | confirmed
+
+	LinesCount     int     `gorm:"not null;default:0"`
+	MatchedCount   int     `gorm:"not null;default:0"`
+	MismatchCount  int     `gorm:"not null;default:0"`
+	MissingCount   int     `gorm:"not null;default:0"`
+	UnknownCount   int     `gorm:"not null;default:0"`
+	StatementTotal float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	ExpectedTotal  float64 `gorm:"type:decimal(15,2);not null;default:0"`
+
+	CodPaymentCutID *uint  `gorm:"index"` // corte donde quedaron las ordenes que no estaban en un borrador
+	UploadedBy      uint   `gorm:"not null;default:0"`
+	ConfirmedBy     uint   `gorm:"not null;default:0"`
+	ConfirmedByName string `gorm:"size:160"`
+	ConfirmedAt     *time.Time
+
+	Business Business `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (CodSettlement) TableName() string {
+	return "cod_settlements"
+}
+
+// CodSettlementLine es una guia de la liquidacion cruzada contra las ordenes
+// COD. Las ordenes que esperabamos y no vinieron en el archivo tambien quedan
+// como linea (status missing_from_statement, RowNumber 0).
+type CodSettlementLine struct {
+	gorm.Model
+	CodSettlementID uint    `gorm:"not null;index"`
+	BusinessID      uint    `gorm:"not null;index"`
+	RowNumber       int     `gorm:"not null;default:0"` // fila del archivo; 0 si no vino
+	GuideNumber     string  `gorm:"size:128;index"`
+	OrderID         *string `gorm:"type:varchar(36);index"`
+	Status          string  `gorm:"size:32;not null;index"` // matched | amount_mismatch | missing_from_statement | unknown_guide
+
+	StatementAmount float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	ExpectedAmount  float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	Difference      float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	CarrierFee      float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	PaidDate        *time.Time
+	Reference       string `gorm:"size:255"`
+	Note            string `gorm:"size:255"`
+	AlreadyPaid     bool   `gorm:"not null;default:false"` // la orden ya estaba en un corte confirmado
+	Confirmed       bool   `gorm:"not null;default:false"`
+
+	CodSettlement CodSettlement `gorm:"foreignKey:CodSettlementID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (CodSettlementLine) TableName() string {
+	return "cod_settlement_lines"
+}
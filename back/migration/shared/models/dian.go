@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+//
+//	DIAN - Facturación electrónica directa (UBL 2.1 firmado por el propio negocio)
+//
+
+// DianDocument factura, nota crédito o nota débito emitida directamente ante la
+// DIAN. Se registra al reservar el consecutivo, antes de enviar: un reintento
+// reusa el número y, si el documento alcanzó a firmarse, el mismo XML.
+// SourceID es el id de la factura o de la nota en el módulo de facturación.
+type DianDocument struct {
+	ID            uint   `gorm:"primaryKey"`
+	IntegrationID uint   `gorm:"not null;uniqueIndex:idx_dian_documents_source;uniqueIndex:idx_dian_documents_number"`
+	InvoiceID     uint   `gorm:"not null;index"`
+	Kind          string `gorm:"type:varchar(20);not null;uniqueIndex:idx_dian_documents_source"` // invoice|credit_note|debit_note
+	SourceID      string `gorm:"type:varchar(64);not null;uniqueIndex:idx_dian_documents_source"`
+	Prefix        string `gorm:"type:varchar(10);not null;default:'';uniqueIndex:idx_dian_documents_number"`
+	Consecutive   int64  `gorm:"not null;uniqueIndex:idx_dian_documents_number"`
+	Number        string `gorm:"type:varchar(30);not null"`
+	UUID          string `gorm:"type:varchar(96);index"` // CUFE o CUDE
+	IssuedAt      *time.Time
+	Status        string `gorm:"type:varchar(20);not null;index"` // signed|pending|accepted|rejected
+	ZipKey        string `gorm:"type:varchar(100)"`
+
+	SignedXML           string `gorm:"type:text"`
+	ApplicationResponse string `gorm:"type:text"`
+	AttachedDocument    string `gorm:"type:text"`
+
+	StatusMessage string         `gorm:"type:text"`
+	Errors        datatypes.JSON `gorm:"type:jsonb"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (DianDocument) TableName() string { return "dian_documents" }
+
+// DianNumberingCounter último consecutivo usado por integración y prefijo.
+// Se incrementa con un upsert atómico para que dos facturas simultáneas
+// nunca reciban el mismo número.
+type DianNumberingCounter struct {
+	IntegrationID uint   `gorm:"primaryKey"`
+	Prefix        string `gorm:"type:varchar(10);primaryKey"`
+	LastNumber    int64  `gorm:"not null"`
+	UpdatedAt     time.Time
+}
+
+func (DianNumberingCounter) TableName() string { return "dian_numbering_counters" }
@@ -52,6 +52,11 @@ type StockMovement struct {
 	IntegrationID  *uint   `gorm:"index"`
 	Notes          string  `gorm:"type:text"`
 	CreatedByID    *uint   `gorm:"index"`
+	// Costo del movimiento segun el metodo de valorizacion del negocio. NULL en
+	// movimientos que no cambian existencias (reservas, cambios de estado).
+	// TotalCost lleva el mismo signo que Quantity.
+	UnitCost  *float64 `gorm:"type:decimal(15,4)"`
+	TotalCost *float64 `gorm:"type:decimal(18,2)"`
 
 	MovementType StockMovementType `gorm:"foreignKey:MovementTypeID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
 	Product      Product           `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ProductKit es la lista de materiales (BOM) de un producto compuesto. En modo
+// virtual el kit no tiene stock propio: reservas, ventas y devoluciones se
+// hacen sobre los componentes. En modo assembled el kit se arma con ordenes de
+// ensamble y se vende de su propio stock.
+type ProductKit struct {
+	gorm.Model
+	BusinessID uint   `gorm:"not null;uniqueIndex:idx_product_kit_key,priority:1"`
+	ProductID  string `gorm:"type:varchar(64);not null;uniqueIndex:idx_product_kit_key,priority:2"`
+	Mode       string `gorm:"size:20;not null;default:'virtual'"` // virtual, assembled
+	IsActive   bool   `gorm:"not null;default:true"`
+
+	Business   Business              `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Product    Product               `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Components []ProductKitComponent `gorm:"foreignKey:KitID"`
+}
+
+func (ProductKit) TableName() string {
+	return "product_kits"
+}
+
+// ProductKitComponent es un componente del kit y cuantas unidades lleva cada kit
+type ProductKitComponent struct {
+	gorm.Model
+	KitID              uint   `gorm:"not null;uniqueIndex:idx_kit_component_key,priority:1"`
+	ComponentProductID string `gorm:"type:varchar(64);not null;index;uniqueIndex:idx_kit_component_key,priority:2"`
+	Quantity           int    `gorm:"not null"`
+
+	Kit       ProductKit `gorm:"foreignKey:KitID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Component Product    `gorm:"foreignKey:ComponentProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (ProductKitComponent) TableName() string {
+	return "product_kit_components"
+}
+
+// KitAssemblyOrder convierte componentes en kits armados (assembly) o un kit
+// armado de vuelta en sus componentes (disassembly) dentro de una bodega
+type KitAssemblyOrder struct {
+	gorm.Model
+	BusinessID    uint       `gorm:"not null;index;uniqueIndex:idx_kit_assembly_code,priority:1"`
+	Code          string     `gorm:"size:30;not null;uniqueIndex:idx_kit_assembly_code,priority:2"`
+	Type          string     `gorm:"size:20;not null"` // assembly, disassembly
+	KitProductID  string     `gorm:"type:varchar(64);not null;index"`
+	WarehouseID   uint       `gorm:"not null;index"`
+	Quantity      int        `gorm:"not null"`
+	Status        string     `gorm:"size:20;not null;default:'pending';index"` // pending, completed, cancelled
+	KitUnitCost   *float64   `gorm:"type:decimal(15,4)"`
+	Notes         string     `gorm:"type:text"`
+	CreatedByID   *uint      `gorm:"index"`
+	CompletedByID *uint      `gorm:"index"`
+	CompletedAt   *time.Time `gorm:"index"`
+
+	Business   Business  `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	KitProduct Product   `gorm:"foreignKey:KitProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Warehouse  Warehouse `gorm:"foreignKey:WarehouseID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+}
+
+func (KitAssemblyOrder) TableName() string {
+	return "kit_assembly_orders"
+}
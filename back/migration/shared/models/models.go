@@ -128,6 +128,8 @@ type Role struct {
 	Description string `gorm:"size:255"`
 	Level       int    `gorm:"not null;default:1"` // Nivel jerárquico (1=super, 2=admin, 3=manager, 4=staff)
 	IsSystem    bool   `gorm:"default:false"`      // Si es rol del sistema (no se puede eliminar)
+	// Obliga a los usuarios con este rol a usar segundo factor (TOTP)
+	RequireTwoFactor bool `gorm:"not null;default:false"`
 
 	// Scope del rol
 	ScopeID uint  `gorm:"not null;index"`
@@ -224,28 +226,39 @@ type Action struct {
 }
 
 // API KEYS - Claves de API para integraciones
+// Cada clave pertenece a un negocio y solo puede usar los permisos que se le
+// asignaron. La clave completa se muestra una sola vez; aqui se guarda el
+// prefijo publico (para buscarla) y el hash SHA-256 del secreto.
 type APIKey struct {
 	gorm.Model
-	UserID      uint   `gorm:"not null;index"`    // Usuario para el cual se genera la API Key
 	BusinessID  uint   `gorm:"not null;index"`    // Business asociado
-	CreatedByID uint   `gorm:"not null;index"`    // Usuario que creó la API Key (super admin)
+	CreatedByID uint   `gorm:"not null;index"`    // Usuario que creó la API Key (admin del negocio o super admin)
 	Name        string `gorm:"size:255;not null"` // Nombre de referencia (ej. "API para sitio web")
-	KeyHash     string `gorm:"size:255;not null"` // Hash de la API Key (bcrypt)
 	Description string `gorm:"size:500"`          // Descripción opcional
+	KeyPrefix   string `gorm:"size:32;not null;uniqueIndex"`
+	KeyHash     string `gorm:"size:64;not null"` // Hash SHA-256 (hex) de la API Key
+
+	// Rotación: la clave anterior sigue valiendo hasta PreviousKeyExpiresAt
+	PreviousKeyPrefix    *string `gorm:"size:32;index"`
+	PreviousKeyHash      string  `gorm:"size:64"`
+	PreviousKeyExpiresAt *time.Time
+	RotatedAt            *time.Time
 
 	// Control de uso
+	ExpiresAt  *time.Time `gorm:"index"`               // nil = no expira
 	LastUsedAt *time.Time `gorm:"index"`               // Última vez que se usó
+	LastUsedIP string     `gorm:"size:64"`             // IP del último uso
 	Revoked    bool       `gorm:"default:false;index"` // Si está revocada
 	RevokedAt  *time.Time // Cuándo fue revocada
 
 	// Configuración opcional
-	RateLimit   int    `gorm:"default:1000"` // Límite de requests por hora
-	IPWhitelist string `gorm:"size:1000"`    // IPs permitidas (separadas por coma)
+	RateLimit   int    `gorm:"not null;default:60"` // Límite de requests por minuto
+	IPWhitelist string `gorm:"size:1000"`           // IPs o CIDRs permitidos (separados por coma, vacío = cualquiera)
 
 	// Relaciones
-	User      User     `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	Business  Business `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
-	CreatedBy User     `gorm:"foreignKey:CreatedByID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Business    Business     `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	CreatedBy   User         `gorm:"foreignKey:CreatedByID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Permissions []Permission `gorm:"many2many:api_key_permissions;"`
 }
 
 // INTEGRATION CATEGORIES - Categorías de integraciones
@@ -124,6 +124,10 @@ type Order struct {
 
 	IsTest bool `gorm:"default:false;index"`
 
+	// Priority ordena la preparacion en bodega: las olas de picking toman primero
+	// las de mayor prioridad
+	Priority int `gorm:"not null;default:0;index"`
+
 	Invoiceable     bool    `gorm:"default:false"`
 	InvoiceURL      *string `gorm:"size:512"`
 	InvoiceID       *string `gorm:"size:128;index"`
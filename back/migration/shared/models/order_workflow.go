@@ -0,0 +1,24 @@
+package models
+
+import (
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// OrderWorkflow guarda el flujo de estados de ordenes propio de un negocio:
+// transiciones permitidas, campos requeridos por transicion y transiciones
+// automaticas por evento. Sin fila, el negocio usa el flujo por defecto.
+type OrderWorkflow struct {
+	gorm.Model
+	BusinessID uint     `gorm:"not null;uniqueIndex"`
+	Business   Business `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+
+	Name       string         `gorm:"type:varchar(100)"`
+	Definition datatypes.JSON `gorm:"type:jsonb;not null"`
+
+	UpdatedByID *uint
+}
+
+func (OrderWorkflow) TableName() string {
+	return "order_workflows"
+}
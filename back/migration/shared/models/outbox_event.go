@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Estados de un evento del outbox.
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+)
+
+// OutboxEvent es un mensaje de RabbitMQ escrito en la misma transaccion que el
+// cambio de negocio que lo origina. El relay de central lo publica despues con
+// confirmacion del broker y lo marca como enviado.
+type OutboxEvent struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	CreatedAt time.Time `gorm:"not null;index"`
+	UpdatedAt time.Time `gorm:"not null"`
+
+	BusinessID    *uint  `gorm:"index"`
+	AggregateType string `gorm:"size:64;not null;index:idx_outbox_aggregate,priority:1"`
+	AggregateID   string `gorm:"size:128;not null;index:idx_outbox_aggregate,priority:2"`
+	EventType     string `gorm:"size:128;not null"`
+
+	// Destino: Exchange vacio publica directo a la cola RoutingKey.
+	Exchange    string         `gorm:"size:128;not null;default:''"`
+	RoutingKey  string         `gorm:"size:255;not null;default:''"`
+	ContentType string         `gorm:"size:64;not null;default:'application/json'"`
+	Payload     []byte         `gorm:"type:bytea;not null"`
+	Headers     datatypes.JSON `gorm:"type:jsonb"`
+
+	Status      string     `gorm:"size:16;not null;default:'pending';index:idx_outbox_status_available,priority:1"`
+	AvailableAt time.Time  `gorm:"not null;index:idx_outbox_status_available,priority:2"`
+	Attempts    int        `gorm:"not null;default:0"`
+	LastError   *string    `gorm:"type:text"`
+	SentAt      *time.Time `gorm:"index"`
+
+	// Reclamo del relay: mientras LockedUntil no vence, otra instancia no toma
+	// el evento aunque siga pendiente.
+	LockedUntil *time.Time
+	ClaimedBy   *string `gorm:"size:64"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
+
+func (e *OutboxEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = uuid.New()
+	}
+	if e.AvailableAt.IsZero() {
+		e.AvailableAt = time.Now()
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PickWave agrupa ordenes de una bodega para recogerlas en un solo recorrido.
+// Guarda los criterios con que se seleccionaron las ordenes.
+type PickWave struct {
+	gorm.Model
+	BusinessID  uint    `gorm:"not null;index;uniqueIndex:idx_pick_wave_code,priority:1"`
+	WarehouseID uint    `gorm:"not null;index"`
+	Code        string  `gorm:"size:30;not null;uniqueIndex:idx_pick_wave_code,priority:2"`
+	Status      string  `gorm:"size:20;not null;default:'released';index"` // released, picking, picked, packed, cancelled
+	CarrierCode *string `gorm:"size:50"`
+	CutoffAt    *time.Time
+	ZoneID      *uint
+	MinPriority *int
+	MaxOrders   int    `gorm:"not null;default:0"`
+	Notes       string `gorm:"type:text"`
+	CreatedByID *uint
+	StartedAt   *time.Time
+	PickedAt    *time.Time
+	PackedAt    *time.Time
+	CancelledAt *time.Time
+
+	Business  Business        `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Warehouse Warehouse       `gorm:"foreignKey:WarehouseID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Zone      *WarehouseZone  `gorm:"foreignKey:ZoneID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	Orders    []PickWaveOrder `gorm:"foreignKey:WaveID"`
+	Lines     []PickListLine  `gorm:"foreignKey:WaveID"`
+}
+
+func (PickWave) TableName() string {
+	return "pick_waves"
+}
+
+// PickWaveOrder es una orden dentro de la ola. Al empacarla queda ligada al LPN
+// del paquete.
+type PickWaveOrder struct {
+	gorm.Model
+	WaveID       uint   `gorm:"not null;index;uniqueIndex:idx_pick_wave_order,priority:1"`
+	OrderID      string `gorm:"type:varchar(36);not null;index;uniqueIndex:idx_pick_wave_order,priority:2"`
+	OrderNumber  string `gorm:"size:128"`
+	Priority     int    `gorm:"not null;default:0"`
+	Status       string `gorm:"size:20;not null;default:'pending';index"` // pending, picked, short, packed, removed
+	PackageLpnID *uint  `gorm:"index"`
+	PackedByID   *uint
+	PackedAt     *time.Time
+
+	Wave       PickWave       `gorm:"foreignKey:WaveID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Order      Order          `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	PackageLpn *LicensePlate  `gorm:"foreignKey:PackageLpnID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	Items      []PickWaveItem `gorm:"foreignKey:WaveOrderID"`
+}
+
+func (PickWaveOrder) TableName() string {
+	return "pick_wave_orders"
+}
+
+// PickWaveItem es lo que hay que entregarle a una orden de la ola (los kits
+// virtuales ya vienen explotados en componentes). PickedQuantity se llena al
+// cerrar las lineas de picking y PackedQuantity con los escaneos de empaque.
+type PickWaveItem struct {
+	gorm.Model
+	WaveOrderID    uint   `gorm:"not null;index"`
+	WaveID         uint   `gorm:"not null;index"`
+	ProductID      string `gorm:"type:varchar(64);not null;index"`
+	Quantity       int    `gorm:"not null"`
+	PickedQuantity int    `gorm:"not null;default:0"`
+	PackedQuantity int    `gorm:"not null;default:0"`
+
+	WaveOrder PickWaveOrder `gorm:"foreignKey:WaveOrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Product   Product       `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (PickWaveItem) TableName() string {
+	return "pick_wave_items"
+}
+
+// PickListLine es una parada del recorrido: un producto consolidado de todas las
+// ordenes de la ola en una ubicacion. Sequence sigue la ruta
+// zona/pasillo/estanteria/nivel/posicion.
+type PickListLine struct {
+	gorm.Model
+	WaveID           uint   `gorm:"not null;index"`
+	Sequence         int    `gorm:"not null"`
+	ProductID        string `gorm:"type:varchar(64);not null;index"`
+	LocationID       *uint  `gorm:"index"`
+	LocationCode     string `gorm:"size:50"`
+	LocationPath     string `gorm:"size:255"`
+	QuantityRequired int    `gorm:"not null"`
+	QuantityPicked   int    `gorm:"not null;default:0"`
+	Status           string `gorm:"size:20;not null;default:'pending';index"` // pending, picked, short
+	PickedByID       *uint
+	PickedAt         *time.Time
+
+	Wave     PickWave           `gorm:"foreignKey:WaveID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Product  Product            `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Location *WarehouseLocation `gorm:"foreignKey:LocationID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+}
+
+func (PickListLine) TableName() string {
+	return "pick_list_lines"
+}
@@ -0,0 +1,168 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Supplier es un proveedor del negocio. InventoryLot.SupplierID apunta aqui.
+type Supplier struct {
+	gorm.Model
+	BusinessID   uint   `gorm:"not null;index"`
+	Name         string `gorm:"size:255;not null"`
+	TaxID        string `gorm:"size:50;index"`
+	ContactName  string `gorm:"size:255"`
+	Email        string `gorm:"size:255"`
+	Phone        string `gorm:"size:50"`
+	Address      string `gorm:"size:500"`
+	LeadTimeDays int    `gorm:"default:0"`
+	// Tolerancias de recepcion por defecto para las ordenes de compra del proveedor
+	OverReceiptTolerancePct  float64 `gorm:"type:decimal(5,2);not null;default:0"`
+	UnderReceiptTolerancePct float64 `gorm:"type:decimal(5,2);not null;default:0"`
+	IsActive                 bool    `gorm:"default:true;index"`
+	Notes                    string  `gorm:"type:text"`
+
+	Business Business `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (Supplier) TableName() string {
+	return "suppliers"
+}
+
+// SupplierProduct son las condiciones de compra de un producto a un proveedor.
+// El preferido es el que usa el reabastecimiento para armar ordenes en borrador.
+type SupplierProduct struct {
+	gorm.Model
+	BusinessID   uint    `gorm:"not null;index"`
+	SupplierID   uint    `gorm:"not null;uniqueIndex:idx_supplier_product,priority:1"`
+	ProductID    string  `gorm:"type:varchar(64);not null;index;uniqueIndex:idx_supplier_product,priority:2"`
+	SupplierSKU  string  `gorm:"size:100"`
+	UnitCost     float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	MinOrderQty  int     `gorm:"default:0"`
+	LeadTimeDays *int
+	IsPreferred  bool `gorm:"default:false;index"`
+
+	Supplier Supplier `gorm:"foreignKey:SupplierID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Product  Product  `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (SupplierProduct) TableName() string {
+	return "supplier_products"
+}
+
+// PurchaseOrder es una orden de compra a un proveedor con destino a una bodega
+type PurchaseOrder struct {
+	gorm.Model
+	BusinessID  uint   `gorm:"not null;index;uniqueIndex:idx_po_business_code,priority:1"`
+	Code        string `gorm:"size:20;not null;uniqueIndex:idx_po_business_code,priority:2"`
+	SupplierID  uint   `gorm:"not null;index"`
+	WarehouseID uint   `gorm:"not null;index"`
+	Status      string `gorm:"size:32;not null;default:'draft';index"`
+	Source      string `gorm:"size:16;not null;default:'manual'"`
+	Currency    string `gorm:"size:3;not null;default:'COP'"`
+	ExpectedAt  *time.Time
+
+	OverReceiptTolerancePct  float64 `gorm:"type:decimal(5,2);not null;default:0"`
+	UnderReceiptTolerancePct float64 `gorm:"type:decimal(5,2);not null;default:0"`
+
+	Subtotal        float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	LandedCostTotal float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	Notes           string  `gorm:"type:text"`
+
+	CreatedByID  *uint `gorm:"index"`
+	ApprovedByID *uint
+	ApprovedAt   *time.Time
+	ReceivedAt   *time.Time
+	ClosedAt     *time.Time
+	CancelledAt  *time.Time
+
+	Business  Business            `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Supplier  Supplier            `gorm:"foreignKey:SupplierID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Warehouse Warehouse           `gorm:"foreignKey:WarehouseID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Lines     []PurchaseOrderLine `gorm:"foreignKey:PurchaseOrderID"`
+}
+
+func (PurchaseOrder) TableName() string {
+	return "purchase_orders"
+}
+
+// PurchaseOrderLine es un producto pedido en la orden de compra.
+// LandedCostAllocated es la parte de los costos de importacion que le toca a la linea.
+type PurchaseOrderLine struct {
+	gorm.Model
+	PurchaseOrderID     uint    `gorm:"not null;index"`
+	ProductID           string  `gorm:"type:varchar(64);not null;index"`
+	QuantityOrdered     int     `gorm:"not null"`
+	QuantityReceived    int     `gorm:"not null;default:0"`
+	UnitCost            float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	LandedCostAllocated float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	ExpectedAt          *time.Time
+	Notes               string `gorm:"type:text"`
+
+	PurchaseOrder PurchaseOrder `gorm:"foreignKey:PurchaseOrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Product       Product       `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+}
+
+func (PurchaseOrderLine) TableName() string {
+	return "purchase_order_lines"
+}
+
+// PurchaseOrderReceipt es una recepcion (total o parcial) de mercancia contra una orden de compra
+type PurchaseOrderReceipt struct {
+	gorm.Model
+	BusinessID      uint  `gorm:"not null;index"`
+	PurchaseOrderID uint  `gorm:"not null;index"`
+	WarehouseID     uint  `gorm:"not null;index"`
+	LocationID      *uint `gorm:"index"`
+	ReceivedByID    *uint `gorm:"index"`
+	ReceivedAt      time.Time
+	Notes           string `gorm:"type:text"`
+
+	PurchaseOrder PurchaseOrder              `gorm:"foreignKey:PurchaseOrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Lines         []PurchaseOrderReceiptLine `gorm:"foreignKey:ReceiptID"`
+}
+
+func (PurchaseOrderReceipt) TableName() string {
+	return "purchase_order_receipts"
+}
+
+// PurchaseOrderReceiptLine registra lo que entro de una linea en una recepcion,
+// con el lote creado, el movimiento de stock y el costo unitario con importacion.
+type PurchaseOrderReceiptLine struct {
+	gorm.Model
+	ReceiptID           uint    `gorm:"not null;index"`
+	PurchaseOrderLineID uint    `gorm:"not null;index"`
+	ProductID           string  `gorm:"type:varchar(64);not null;index"`
+	Quantity            int     `gorm:"not null"`
+	LotID               *uint   `gorm:"index"`
+	MovementID          *uint   `gorm:"index"`
+	UnitCost            float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	LandedUnitCost      float64 `gorm:"type:decimal(15,2);not null;default:0"`
+
+	Receipt PurchaseOrderReceipt `gorm:"foreignKey:ReceiptID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Line    PurchaseOrderLine    `gorm:"foreignKey:PurchaseOrderLineID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Lot     *InventoryLot        `gorm:"foreignKey:LotID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+}
+
+func (PurchaseOrderReceiptLine) TableName() string {
+	return "purchase_order_receipt_lines"
+}
+
+// PurchaseOrderLandedCost es un costo adicional de la orden (flete, arancel,
+// seguro...) que se reparte entre las lineas por valor o por cantidad
+type PurchaseOrderLandedCost struct {
+	gorm.Model
+	PurchaseOrderID  uint    `gorm:"not null;index"`
+	Concept          string  `gorm:"size:32;not null"`
+	Description      string  `gorm:"size:255"`
+	Amount           float64 `gorm:"type:decimal(15,2);not null"`
+	AllocationMethod string  `gorm:"size:16;not null;default:'value'"`
+	CreatedByID      *uint
+
+	PurchaseOrder PurchaseOrder `gorm:"foreignKey:PurchaseOrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (PurchaseOrderLandedCost) TableName() string {
+	return "purchase_order_landed_costs"
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ReturnRequest es una autorizacion de devolucion (RMA) sobre una orden. La
+// puede pedir el cliente desde la tienda publica o un operador.
+type ReturnRequest struct {
+	gorm.Model
+
+	Code string `gorm:"size:20;uniqueIndex;not null"`
+
+	BusinessID uint     `gorm:"not null;index"`
+	Business   Business `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+
+	OrderID string `gorm:"type:varchar(36);not null;index"`
+	Order   Order  `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+
+	Status string `gorm:"size:32;not null;index;default:'requested'"`
+	Source string `gorm:"size:16;not null;default:'operator'"`
+
+	Reason       string `gorm:"size:64"`
+	CustomerNote string `gorm:"type:text"`
+
+	RequestedByID *uint `gorm:"index"`
+	WarehouseID   *uint `gorm:"index"`
+
+	RefundMethod    string  `gorm:"size:32"`
+	RefundAmount    float64 `gorm:"type:decimal(12,2);not null;default:0"`
+	RefundReference string  `gorm:"size:255"`
+	CreditNoteID    *uint   `gorm:"index"`
+
+	ReturnShipmentID *uint `gorm:"index"`
+
+	ApprovedAt  *time.Time
+	ReceivedAt  *time.Time
+	InspectedAt *time.Time
+	RefundedAt  *time.Time
+	ClosedAt    *time.Time
+
+	Items   []ReturnRequestItem   `gorm:"foreignKey:ReturnRequestID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	History []ReturnStatusHistory `gorm:"foreignKey:ReturnRequestID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (ReturnRequest) TableName() string {
+	return "return_requests"
+}
+
+// ReturnRequestItem es una linea de la devolucion: cuantas unidades de un item
+// de la orden vuelven, por que, y el resultado de la inspeccion al recibirlas.
+type ReturnRequestItem struct {
+	gorm.Model
+
+	ReturnRequestID uint `gorm:"not null;index"`
+	OrderItemID     uint `gorm:"not null;index"`
+
+	ProductID   *string `gorm:"type:varchar(64);index"`
+	ProductSKU  string  `gorm:"size:255"`
+	ProductName string  `gorm:"size:255"`
+
+	Quantity  int     `gorm:"not null"`
+	UnitPrice float64 `gorm:"type:decimal(12,2);not null;default:0"`
+	Reason    string  `gorm:"size:64;not null"`
+	Note      string  `gorm:"type:text"`
+
+	InspectionResult  string `gorm:"size:16"`
+	InspectedQuantity int    `gorm:"not null;default:0"`
+	InspectionNote    string `gorm:"type:text"`
+}
+
+func (ReturnRequestItem) TableName() string {
+	return "return_request_items"
+}
+
+// ReturnStatusHistory registra cada cambio de estado de una devolucion
+type ReturnStatusHistory struct {
+	gorm.Model
+
+	ReturnRequestID uint   `gorm:"not null;index"`
+	FromStatus      string `gorm:"size:32"`
+	ToStatus        string `gorm:"size:32;not null"`
+	ChangedByID     *uint  `gorm:"index"`
+	Note            string `gorm:"type:text"`
+}
+
+func (ReturnStatusHistory) TableName() string {
+	return "return_status_history"
+}
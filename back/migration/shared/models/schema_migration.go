@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// SchemaMigration registra cada migracion versionada aplicada por el runner de
+// back/migration. Checksum permite detectar un archivo SQL editado despues de
+// correrlo.
+type SchemaMigration struct {
+	Version     int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name        string    `gorm:"size:150;not null"`
+	Kind        string    `gorm:"size:10;not null"`
+	Checksum    string    `gorm:"size:64;not null"`
+	AppliedAt   time.Time `gorm:"not null"`
+	ExecutionMs int64     `gorm:"not null;default:0"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
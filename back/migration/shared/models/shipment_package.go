@@ -0,0 +1,40 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// ShipmentPackage es una caja física de un envío. Un envío puede salir en N
+// paquetes, cada uno con sus dimensiones, valor declarado, contenido y, si la
+// transportadora lo soporta, su propio número de guía. Las columnas de peso y
+// dimensiones de shipments quedan como el consolidado del envío.
+type ShipmentPackage struct {
+	ID         uint `gorm:"primarykey"`
+	ShipmentID uint `gorm:"not null;uniqueIndex:idx_shipment_packages_sequence,priority:1"`
+	Sequence   int  `gorm:"not null;uniqueIndex:idx_shipment_packages_sequence,priority:2"` // 1..N dentro del envío
+
+	Reference string `gorm:"size:128"` // nombre de la caja estándar o referencia libre
+
+	Weight *float64 `gorm:"type:decimal(10,2)"`
+	Height *float64 `gorm:"type:decimal(10,2)"`
+	Width  *float64 `gorm:"type:decimal(10,2)"`
+	Length *float64 `gorm:"type:decimal(10,2)"`
+
+	DeclaredValue *float64 `gorm:"type:decimal(12,2)"`
+
+	// [{"sku": "...", "name": "...", "quantity": 2}]
+	Contents datatypes.JSON `gorm:"type:jsonb"`
+
+	TrackingNumber *string `gorm:"size:128;index"`
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	Shipment Shipment `gorm:"foreignKey:ShipmentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (ShipmentPackage) TableName() string {
+	return "shipment_packages"
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"time"
+)
+
+// ShipmentTrackingEvent es un escaneo de la transportadora sobre un envío, tal como
+// llegó (raw) y normalizado a la taxonomía de Probability (event_code). El historial
+// es append-only: el estado del envío se recalcula desde aquí.
+type ShipmentTrackingEvent struct {
+	ID         uint `gorm:"primarykey"`
+	ShipmentID uint `gorm:"not null;index:idx_tracking_events_shipment,priority:1"`
+	BusinessID uint `gorm:"not null;index"`
+
+	Provider string `gorm:"size:64;not null"`             // envioclick, shipit, ...
+	Carrier  string `gorm:"size:128;not null;index"`      // transportadora real (normalizada)
+	Source   string `gorm:"size:32;not null"`             // webhook, track, generate, cancel
+	DedupKey string `gorm:"size:64;not null;uniqueIndex"` // sha256 de envío + estado raw + fecha
+
+	EventCode      string `gorm:"size:32;not null;index"` // label_created, picked_up, in_transit, ...
+	ShipmentStatus string `gorm:"size:64"`                // estado del envío que implica el evento (vacío si unknown)
+	MappingSource  string `gorm:"size:32;not null"`       // carrier, provider, integration, unmapped
+
+	RawStatus       string `gorm:"size:255"`
+	RawStatusDetail string `gorm:"size:255"`
+	Description     string `gorm:"type:text"`
+	Location        string `gorm:"size:255"`
+	HasIncidence    bool   `gorm:"not null;default:false"`
+
+	OccurredAt time.Time `gorm:"not null;index:idx_tracking_events_shipment,priority:2"`
+	CreatedAt  time.Time
+
+	Shipment Shipment `gorm:"foreignKey:ShipmentID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (ShipmentTrackingEvent) TableName() string {
+	return "shipment_tracking_events"
+}
+
+// CarrierStatusMapping traduce un estado raw de una transportadora (o de un
+// proveedor, cuando Carrier es "") al event_code normalizado.
+type CarrierStatusMapping struct {
+	ID        uint   `gorm:"primarykey"`
+	Provider  string `gorm:"size:64;not null;uniqueIndex:idx_carrier_status_mapping,priority:1"`
+	Carrier   string `gorm:"size:128;not null;default:'';uniqueIndex:idx_carrier_status_mapping,priority:2"`
+	RawStatus string `gorm:"size:255;not null;uniqueIndex:idx_carrier_status_mapping,priority:3"` // normalizado: minúsculas, sin tildes
+	EventCode string `gorm:"size:32;not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (CarrierStatusMapping) TableName() string {
+	return "carrier_status_mappings"
+}
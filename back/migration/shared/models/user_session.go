@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UserSession registra cada JWT emitido en el login. El token lleva el
+// SessionID en el claim "sid" y el middleware rechaza las sesiones revocadas.
+type UserSession struct {
+	gorm.Model
+	SessionID     string    `gorm:"size:64;not null;uniqueIndex"`
+	UserID        uint      `gorm:"not null;index"`
+	BusinessID    uint      `gorm:"not null;default:0"`
+	UserAgent     string    `gorm:"size:500"`
+	Device        string    `gorm:"size:100"`
+	IPAddress     string    `gorm:"size:64"`
+	LastSeenAt    time.Time `gorm:"not null"`
+	ExpiresAt     time.Time `gorm:"not null;index"`
+	RevokedAt     *time.Time
+	RevokedByID   *uint
+	RevokedReason string `gorm:"size:50"`
+	User          *User  `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (UserSession) TableName() string {
+	return "user_sessions"
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// UserTwoFactor guarda el segundo factor TOTP de un usuario del dashboard.
+// El secreto se guarda cifrado con ENCRYPTION_KEY; mientras EnabledAt sea
+// nil el enrolamiento esta pendiente de confirmar con un codigo.
+type UserTwoFactor struct {
+	gorm.Model
+	UserID          uint   `gorm:"not null;uniqueIndex"`
+	SecretEncrypted string `gorm:"size:255;not null"`
+	Enabled         bool   `gorm:"not null;default:false"`
+	EnabledAt       *time.Time
+	// LastUsedStep es el ultimo paso de 30s aceptado: evita reusar un codigo
+	LastUsedStep   int64 `gorm:"not null;default:0"`
+	FailedAttempts int   `gorm:"not null;default:0"`
+	LockedUntil    *time.Time
+	User           *User `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (UserTwoFactor) TableName() string {
+	return "user_two_factors"
+}
+
+// UserRecoveryCode es un codigo de recuperacion de un solo uso para entrar
+// sin la app autenticadora. Solo se guarda el hash SHA-256.
+type UserRecoveryCode struct {
+	gorm.Model
+	UserID   uint   `gorm:"not null;index"`
+	CodeHash string `gorm:"size:64;not null;uniqueIndex"`
+	UsedAt   *time.Time
+	User     *User `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (UserRecoveryCode) TableName() string {
+	return "user_recovery_codes"
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// InventoryValuationSetting es el metodo de valorizacion del negocio. Sin fila
+// el negocio valoriza por promedio ponderado.
+type InventoryValuationSetting struct {
+	gorm.Model
+	BusinessID  uint   `gorm:"not null;uniqueIndex"`
+	Method      string `gorm:"size:20;not null;default:'weighted_average'"` // weighted_average, fifo
+	ChangedByID *uint  `gorm:"index"`
+
+	Business Business `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (InventoryValuationSetting) TableName() string {
+	return "inventory_valuation_settings"
+}
+
+// InventoryCostBalance es el saldo valorizado de un producto en el negocio
+// (todas las bodegas). Con promedio ponderado es la fuente del costo; con FIFO
+// TotalValue coincide con la suma de las capas abiertas.
+type InventoryCostBalance struct {
+	gorm.Model
+	BusinessID  uint    `gorm:"not null;uniqueIndex:idx_cost_balance_key,priority:1"`
+	ProductID   string  `gorm:"type:varchar(64);not null;uniqueIndex:idx_cost_balance_key,priority:2"`
+	Quantity    int     `gorm:"not null;default:0"`
+	TotalValue  float64 `gorm:"type:decimal(18,4);not null;default:0"`
+	AverageCost float64 `gorm:"type:decimal(15,4);not null;default:0"`
+
+	Business Business `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Product  Product  `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (InventoryCostBalance) TableName() string {
+	return "inventory_cost_balances"
+}
+
+// InventoryCostLayer es una capa FIFO: cada entrada valorizada abre una y las
+// salidas la van consumiendo en orden de llegada.
+type InventoryCostLayer struct {
+	gorm.Model
+	BusinessID   uint      `gorm:"not null;index:idx_cost_layer_open,priority:1"`
+	ProductID    string    `gorm:"type:varchar(64);not null;index:idx_cost_layer_open,priority:2"`
+	WarehouseID  *uint     `gorm:"index"` // NULL en la capa de apertura (todo el negocio)
+	MovementID   *uint     `gorm:"index"`
+	LotID        *uint     `gorm:"index"`
+	ReceivedAt   time.Time `gorm:"not null;index:idx_cost_layer_open,priority:3"`
+	OriginalQty  int       `gorm:"not null"`
+	RemainingQty int       `gorm:"not null"`
+	UnitCost     float64   `gorm:"type:decimal(15,4);not null;default:0"`
+
+	Business Business       `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Product  Product        `gorm:"foreignKey:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Movement *StockMovement `gorm:"foreignKey:MovementID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+}
+
+func (InventoryCostLayer) TableName() string {
+	return "inventory_cost_layers"
+}
+
+// OrderLineCost es el costo de venta (COGS) de un producto de una orden,
+// guardado al confirmar la venta. Las devoluciones quedan como fila de reverso
+// con cantidad y costo negativos.
+type OrderLineCost struct {
+	gorm.Model
+	BusinessID  uint    `gorm:"not null;index"`
+	OrderID     string  `gorm:"type:varchar(64);not null;index"`
+	ProductID   string  `gorm:"type:varchar(64);not null;index"`
+	WarehouseID uint    `gorm:"not null"`
+	MovementID  uint    `gorm:"not null;uniqueIndex"`
+	Quantity    int     `gorm:"not null"`
+	UnitCost    float64 `gorm:"type:decimal(15,4);not null;default:0"`
+	TotalCost   float64 `gorm:"type:decimal(18,2);not null;default:0"`
+	Method      string  `gorm:"size:20;not null"`
+
+	Business Business      `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Movement StockMovement `gorm:"foreignKey:MovementID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (OrderLineCost) TableName() string {
+	return "order_line_costs"
+}
@@ -15,8 +15,11 @@ type Wallet struct {
 	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
 	BusinessID uint      `gorm:"not null;uniqueIndex"`
 	Balance    float64   `gorm:"type:decimal(15,2);default:0"`
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	// HeldBalance es lo reservado por retenciones activas (guias cotizadas o en
+	// generacion). Balance ya lo descuenta: es el saldo disponible
+	HeldBalance float64 `gorm:"type:decimal(15,2);not null;default:0"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
 
 	// Relaciones
 	Business Business `gorm:"foreignKey:BusinessID"`
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+//
+//	WALLET LEDGER - Libro mayor de la billetera (partida doble, append-only)
+//
+
+// WalletJournal es una operacion sobre la billetera (recarga, debito, ajuste,
+// retencion, captura o liberacion). Sus asientos suman cero. IdempotencyKey es
+// unico por negocio: reintentar la misma operacion no vuelve a mover el saldo,
+// y la llave de un negocio no bloquea la de otro.
+type WalletJournal struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	WalletID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	BusinessID     uint       `gorm:"not null;index;uniqueIndex:idx_wallet_journals_business_key,priority:1"`
+	Kind           string     `gorm:"type:varchar(20);not null;index"` // opening|recharge|debit|adjustment|hold|capture|void
+	IdempotencyKey string     `gorm:"type:varchar(150);not null;uniqueIndex:idx_wallet_journals_business_key,priority:2"`
+	TransactionID  *uuid.UUID `gorm:"type:uuid;index"`
+	HoldID         *uuid.UUID `gorm:"type:uuid;index"`
+	Description    string     `gorm:"type:varchar(255)"`
+	CreatedAt      time.Time
+
+	// Relaciones
+	Wallet Wallet `gorm:"foreignKey:WalletID"`
+}
+
+func (WalletJournal) TableName() string { return "wallet_journals" }
+
+// WalletLedgerEntry es un asiento del journal. Amount va con signo: en las
+// cuentas de la billetera (available, held) positivo suma saldo del negocio.
+// Las cuentas system:* no tienen WalletID y son la contrapartida.
+type WalletLedgerEntry struct {
+	ID        uint       `gorm:"primaryKey"`
+	JournalID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	WalletID  *uuid.UUID `gorm:"type:uuid;index:idx_wallet_ledger_entries_wallet_account"`
+	Account   string     `gorm:"type:varchar(30);not null;index:idx_wallet_ledger_entries_wallet_account"`
+	Amount    float64    `gorm:"type:decimal(15,2);not null"`
+	CreatedAt time.Time
+
+	// Relaciones
+	Journal WalletJournal `gorm:"foreignKey:JournalID"`
+}
+
+func (WalletLedgerEntry) TableName() string { return "wallet_ledger_entries" }
+
+// WalletHold - Retencion de saldo mientras la transportadora confirma la guia.
+// IdempotencyKey es unico por negocio: dos negocios pueden usar la misma llave
+type WalletHold struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	WalletID       uuid.UUID  `gorm:"type:uuid;not null;index"`
+	BusinessID     uint       `gorm:"not null;index;uniqueIndex:idx_wallet_holds_business_key,priority:1"`
+	Amount         float64    `gorm:"type:decimal(15,2);not null"`
+	CapturedAmount float64    `gorm:"type:decimal(15,2);not null;default:0"`
+	Status         string     `gorm:"type:varchar(20);not null;default:'HELD';index"` // HELD|CAPTURED|VOIDED
+	IdempotencyKey string     `gorm:"type:varchar(150);not null;uniqueIndex:idx_wallet_holds_business_key,priority:2"`
+	Reference      string     `gorm:"type:varchar(255)"`
+	ShipmentID     *uint      `gorm:"index"`
+	TransactionID  *uuid.UUID `gorm:"type:uuid"` // movimiento USAGE creado al capturar
+	CapturedAt     *time.Time
+	VoidedAt       *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	// Relaciones
+	Wallet Wallet `gorm:"foreignKey:WalletID"`
+}
+
+func (WalletHold) TableName() string { return "wallet_holds" }
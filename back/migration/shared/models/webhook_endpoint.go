@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// WebhookEndpoint es una URL del negocio que recibe los eventos a los que
+// esta suscrito por medio de notification configs de tipo webhook.
+type WebhookEndpoint struct {
+	gorm.Model
+	BusinessID uint     `gorm:"not null;index"`
+	Business   Business `gorm:"foreignKey:BusinessID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+
+	Name        string `gorm:"size:150;not null"`
+	URL         string `gorm:"size:1000;not null"`
+	Description string `gorm:"size:500"`
+
+	// Secreto HMAC vigente. Al rotarlo el anterior sigue firmando hasta
+	// PreviousSecretExpiresAt para que el receptor alcance a cambiarlo.
+	Secret                  string `gorm:"size:100;not null"`
+	PreviousSecret          string `gorm:"size:100"`
+	PreviousSecretExpiresAt *time.Time
+
+	Enabled             bool `gorm:"default:true;index"`
+	ConsecutiveFailures int  `gorm:"not null;default:0"`
+	DisabledAt          *time.Time
+	DisabledReason      string `gorm:"size:255"`
+	LastDeliveryAt      *time.Time
+	LastSuccessAt       *time.Time
+}
+
+func (WebhookEndpoint) TableName() string { return "webhook_endpoints" }
+
+// WebhookDelivery es el envio de un evento a un endpoint. Guarda el cuerpo
+// enviado y la ultima respuesta; los reintentos actualizan la misma fila y un
+// reenvio manual crea una nueva con RedeliveryOfID.
+type WebhookDelivery struct {
+	ID         uint `gorm:"primaryKey"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	EndpointID uint            `gorm:"not null;index"`
+	Endpoint   WebhookEndpoint `gorm:"foreignKey:EndpointID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	BusinessID uint            `gorm:"not null;index"`
+
+	EventID   string         `gorm:"size:64;not null;index"`
+	EventType string         `gorm:"size:100;not null;index"`
+	Payload   datatypes.JSON `gorm:"type:jsonb;not null"`
+
+	// pending, sending, retrying, succeeded, failed
+	Status        string     `gorm:"size:20;not null;default:'pending';index"`
+	Attempts      int        `gorm:"not null;default:0"`
+	NextAttemptAt *time.Time `gorm:"index"`
+
+	RequestHeaders datatypes.JSON `gorm:"type:jsonb"`
+	ResponseStatus int
+	ResponseBody   string `gorm:"type:text"`
+	Error          string `gorm:"type:text"`
+	DurationMs     int64
+	DeliveredAt    *time.Time
+
+	RedeliveryOfID *uint `gorm:"index"`
+}
+
+func (WebhookDelivery) TableName() string { return "webhook_deliveries" }
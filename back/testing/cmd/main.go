@@ -12,8 +12,11 @@ import (
 	"github.com/secamc93/probability/back/migration/shared/models"
 	"github.com/secamc93/probability/back/testing/integrations/bold"
 	"github.com/secamc93/probability/back/testing/integrations/envioclick"
+	"github.com/secamc93/probability/back/testing/integrations/falabella"
 	"github.com/secamc93/probability/back/testing/integrations/jumpseller"
+	"github.com/secamc93/probability/back/testing/integrations/magento"
 	"github.com/secamc93/probability/back/testing/integrations/mercadolibre"
+	"github.com/secamc93/probability/back/testing/integrations/oidc"
 	"github.com/secamc93/probability/back/testing/integrations/shipit"
 	"github.com/secamc93/probability/back/testing/integrations/shopify"
 	"github.com/secamc93/probability/back/testing/integrations/siigo"
@@ -159,6 +162,46 @@ func main() {
 		}
 	}()
 
+	magentoPort := getEnv("MAGENTO_MOCK_PORT", "9103")
+	magentoWebhookTarget := getEnv("MAGENTO_MOCK_WEBHOOK_TARGET", "")
+	magentoWebhookSecret := getEnv("MAGENTO_MOCK_WEBHOOK_SECRET", "")
+	magentoServer := magento.New(logger, magentoPort, magentoWebhookTarget, magentoWebhookSecret)
+
+	go func() {
+		if err := magentoServer.Start(); err != nil {
+			logger.Error().Msgf("Error starting Magento mock: %s", err.Error())
+			os.Exit(1)
+		}
+	}()
+
+	falabellaPort := getEnv("FALABELLA_MOCK_PORT", "9104")
+	falabellaWebhookTarget := getEnv("FALABELLA_MOCK_WEBHOOK_TARGET", "")
+	falabellaAPIKey := getEnv("FALABELLA_MOCK_API_KEY", "")
+	falabellaServer := falabella.New(logger, falabellaPort, falabellaWebhookTarget, falabellaAPIKey)
+
+	go func() {
+		if err := falabellaServer.Start(); err != nil {
+			logger.Error().Msgf("Error starting Falabella mock: %s", err.Error())
+			os.Exit(1)
+		}
+	}()
+
+	oidcPort := getEnv("OIDC_MOCK_PORT", "9105")
+	oidcServer, err := oidc.New(logger, oidcPort,
+		getEnv("OIDC_MOCK_ISSUER", ""),
+		getEnv("OIDC_MOCK_CLIENT_ID", ""),
+		getEnv("OIDC_MOCK_CLIENT_SECRET", ""))
+	if err != nil {
+		logger.Fatal().Msgf("Error creating OIDC mock: %s", err.Error())
+	}
+
+	go func() {
+		if err := oidcServer.Start(); err != nil {
+			logger.Error().Msgf("Error starting OIDC mock: %s", err.Error())
+			os.Exit(1)
+		}
+	}()
+
 	shopifyMockPort := getEnv("SHOPIFY_MOCK_PORT", "9093")
 	shopifyIntegration := shopify.New(config, logger, shopifyMockPort)
 
@@ -193,6 +236,9 @@ func main() {
 	fmt.Printf("Jumpseller HTTP:   http://localhost:%s\n", jumpsellerPort)
 	fmt.Printf("TikTok HTTP:       http://localhost:%s\n", tiktokPort)
 	fmt.Printf("Tiendanube HTTP:   http://localhost:%s\n", tiendanubePort)
+	fmt.Printf("Magento HTTP:      http://localhost:%s\n", magentoPort)
+	fmt.Printf("Falabella HTTP:    http://localhost:%s\n", falabellaPort)
+	fmt.Printf("OIDC (SSO) HTTP:   http://localhost:%s\n", oidcPort)
 	fmt.Printf("MercadoLibre HTTP: http://localhost:%s\n", meliPort)
 	fmt.Printf("VTEX HTTP:         http://localhost:%s\n", vtexPort)
 	fmt.Printf("Shipit HTTP:       http://localhost:%s\n", shipitPort)
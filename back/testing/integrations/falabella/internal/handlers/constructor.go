@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/testing/shared/log"
+)
+
+const falabellaTimeLayout = "2006-01-02 15:04:05"
+
+type product struct {
+	SellerSku string  `json:"SellerSku"`
+	Name      string  `json:"Name"`
+	Price     float64 `json:"Price"`
+	Quantity  int     `json:"Quantity"`
+}
+
+type orderItem struct {
+	OrderItemID      int    `json:"OrderItemId"`
+	OrderID          int    `json:"OrderId"`
+	Name             string `json:"Name"`
+	Sku              string `json:"Sku"`
+	ShopSku          string `json:"ShopSku"`
+	ItemPrice        string `json:"ItemPrice"`
+	PaidPrice        string `json:"PaidPrice"`
+	Currency         string `json:"Currency"`
+	TaxAmount        string `json:"TaxAmount"`
+	ShippingAmount   string `json:"ShippingAmount"`
+	VoucherAmount    string `json:"VoucherAmount"`
+	Status           string `json:"Status"`
+	ShipmentProvider string `json:"ShipmentProvider"`
+	ShippingType     string `json:"ShippingType"`
+	TrackingCode     string `json:"TrackingCode"`
+	PackageID        string `json:"PackageId"`
+	CreatedAt        string `json:"CreatedAt"`
+	UpdatedAt        string `json:"UpdatedAt"`
+	price            float64
+}
+
+type order struct {
+	id        int
+	number    int
+	createdAt time.Time
+	updatedAt time.Time
+	items     []*orderItem
+}
+
+type feed struct {
+	id        string
+	action    string
+	createdAt time.Time
+	total     int
+	errors    []map[string]interface{}
+	updates   []productUpdate
+	applied   bool
+}
+
+type Handler struct {
+	logger log.ILogger
+
+	mu          sync.Mutex
+	products    map[string]*product
+	orders      map[int]*order
+	feeds       map[string]*feed
+	nextOrderID int
+	nextItemID  int
+	nextFeed    int
+	webhookURL  string
+	apiKey      string
+}
+
+// New crea el simulador de Seller Center. Con apiKey se valida la firma de
+// cada request; sin ella se acepta cualquier firma.
+func New(logger log.ILogger, webhookURL, apiKey string) *Handler {
+	h := &Handler{
+		logger:      logger,
+		products:    make(map[string]*product),
+		orders:      make(map[int]*order),
+		feeds:       make(map[string]*feed),
+		nextOrderID: 1,
+		nextItemID:  1,
+		nextFeed:    1,
+		webhookURL:  webhookURL,
+		apiKey:      apiKey,
+	}
+	h.seedProduct("FAL-MOCK-001", "Camiseta Mock Falabella", 59900, 10)
+	h.seedProduct("FAL-MOCK-002", "Gorra Mock Falabella", 35000, 25)
+
+	// Historial de ordenes para probar la paginacion del import
+	base := time.Now().UTC().AddDate(0, 0, -5)
+	for i := 0; i < 3; i++ {
+		h.newOrder(base.Add(time.Duration(i) * time.Hour))
+	}
+	return h
+}
+
+func (h *Handler) seedProduct(sku, name string, price float64, qty int) {
+	h.products[sku] = &product{SellerSku: sku, Name: name, Price: price, Quantity: qty}
+}
+
+// newOrder crea una orden con dos unidades del primer producto: Seller
+// Center crea un item por unidad. Debe llamarse con el mutex tomado (o
+// durante la construccion).
+func (h *Handler) newOrder(createdAt time.Time) *order {
+	id := h.nextOrderID
+	h.nextOrderID++
+
+	o := &order{
+		id:        id,
+		number:    3050000 + id,
+		createdAt: createdAt.UTC(),
+		updatedAt: createdAt.UTC(),
+	}
+	created := o.createdAt.Format(falabellaTimeLayout)
+	for i := 0; i < 2; i++ {
+		o.items = append(o.items, &orderItem{
+			OrderItemID:      h.nextItemID,
+			OrderID:          id,
+			Name:             "Camiseta Mock Falabella",
+			Sku:              "FAL-MOCK-001",
+			ShopSku:          "FAL-SHOP-001",
+			ItemPrice:        "59900.00",
+			PaidPrice:        "59900.00",
+			Currency:         "COP",
+			TaxAmount:        "9563.87",
+			ShippingAmount:   "7500.00",
+			VoucherAmount:    "0.00",
+			Status:           "pending",
+			ShipmentProvider: "Falabella Logistica",
+			ShippingType:     "Dropshipping",
+			CreatedAt:        created,
+			UpdatedAt:        created,
+			price:            59900,
+		})
+		h.nextItemID++
+	}
+	h.orders[id] = o
+	return o
+}
+
+func (o *order) statuses() []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, it := range o.items {
+		if !seen[it.Status] {
+			seen[it.Status] = true
+			out = append(out, it.Status)
+		}
+	}
+	return out
+}
+
+// toJSON arma la orden con la forma de GetOrders/GetOrder.
+func (o *order) toJSON() map[string]interface{} {
+	total := 0.0
+	for _, it := range o.items {
+		total += it.price
+	}
+	address := map[string]interface{}{
+		"FirstName":     "Cliente",
+		"LastName":      "Mock Falabella",
+		"Phone":         "3001234567",
+		"Address1":      "Calle 100 #15-20",
+		"Address2":      "Oficina 301",
+		"CustomerEmail": "cliente.falabella@mock.test",
+		"City":          "Bogota",
+		"Ward":          "Usaquen",
+		"Region":        "Cundinamarca",
+		"PostCode":      "110111",
+		"Country":       "Colombia",
+	}
+	return map[string]interface{}{
+		"OrderId":                    fmt.Sprintf("%d", o.id),
+		"OrderNumber":                fmt.Sprintf("%d", o.number),
+		"CustomerFirstName":          "Cliente",
+		"CustomerLastName":           "Mock Falabella",
+		"NationalRegistrationNumber": "1020304050",
+		"PaymentMethod":              "CreditCard",
+		"Remarks":                    "",
+		"Price":                      fmt.Sprintf("%.2f", total+15000),
+		"VoucherCode":                "",
+		"CreatedAt":                  o.createdAt.Format(falabellaTimeLayout),
+		"UpdatedAt":                  o.updatedAt.Format(falabellaTimeLayout),
+		"AddressBilling":             address,
+		"AddressShipping":            address,
+		"ItemsCount":                 len(o.items),
+		"Statuses":                   map[string]interface{}{"Status": o.statuses()},
+	}
+}
+
+func (h *Handler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/health", h.handleHealth)
+
+	// Seller Center expone una sola ruta; la operacion va en Action
+	router.GET("/", h.handleAction)
+	router.POST("/", h.handleAction)
+
+	router.POST("/simulate/order", h.handleSimulateOrder)
+	router.POST("/mock/seed-products", h.handleSeedProducts)
+	router.GET("/mock/products", h.handleListProducts)
+}
@@ -0,0 +1,502 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// feedProcessingTime simula el procesamiento asincrono de los feeds.
+const feedProcessingTime = 3 * time.Second
+
+// mockLabel es un PDF minimo valido usado como etiqueta shippingParcel.
+const mockLabel = "%PDF-1.4\n1 0 obj<</Type/Catalog/Pages 2 0 R>>endobj\n" +
+	"2 0 obj<</Type/Pages/Kids[3 0 R]/Count 1>>endobj\n" +
+	"3 0 obj<</Type/Page/Parent 2 0 R/MediaBox[0 0 288 432]>>endobj\n" +
+	"trailer<</Root 1 0 R>>\n%%EOF\n"
+
+func (h *Handler) handleHealth(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "mock": "falabella"})
+}
+
+func success(c *gin.Context, action string, head gin.H, body interface{}) {
+	if head == nil {
+		head = gin.H{}
+	}
+	head["RequestAction"] = action
+	head["ResponseType"] = ""
+	head["Timestamp"] = time.Now().Format(time.RFC3339)
+	if _, ok := head["RequestId"]; !ok {
+		head["RequestId"] = ""
+	}
+	c.JSON(http.StatusOK, gin.H{"SuccessResponse": gin.H{"Head": head, "Body": body}})
+}
+
+func failure(c *gin.Context, status int, action string, code int, message string) {
+	c.JSON(status, gin.H{"ErrorResponse": gin.H{"Head": gin.H{
+		"RequestAction": action,
+		"ErrorType":     "Sender",
+		"ErrorCode":     strconv.Itoa(code),
+		"ErrorMessage":  message,
+	}}})
+}
+
+// verifySignature replica la firma de Seller Center: HMAC-SHA256 en hex de
+// los parametros ordenados y codificados con rawurlencode.
+func (h *Handler) verifySignature(params url.Values) bool {
+	if h.apiKey == "" {
+		return params.Get("Signature") != ""
+	}
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		if k != "Signature" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, rawURLEncode(k)+"="+rawURLEncode(params.Get(k)))
+	}
+	mac := hmac.New(sha256.New, []byte(h.apiKey))
+	mac.Write([]byte(strings.Join(parts, "&")))
+	return hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(params.Get("Signature")))
+}
+
+func rawURLEncode(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func (h *Handler) handleAction(c *gin.Context) {
+	params := c.Request.URL.Query()
+	action := params.Get("Action")
+
+	if params.Get("UserID") == "" || !h.verifySignature(params) {
+		failure(c, http.StatusForbidden, action, 7, "E7: Login failed. Signature mismatching")
+		return
+	}
+
+	switch action {
+	case "GetOrders":
+		h.getOrders(c, params)
+	case "GetOrder":
+		h.getOrder(c, params)
+	case "GetOrderItems":
+		h.getOrderItems(c, params)
+	case "SetStatusToReadyToShip":
+		h.setReadyToShip(c, params)
+	case "GetDocument":
+		h.getDocument(c, params)
+	case "ProductUpdate":
+		h.productUpdate(c)
+	case "FeedStatus":
+		h.feedStatus(c, params)
+	default:
+		failure(c, http.StatusBadRequest, action, 8, "E008: Invalid Action")
+	}
+}
+
+func parseFilterTime(v string) (time.Time, bool) {
+	if v == "" {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t.UTC(), true
+}
+
+func (h *Handler) getOrders(c *gin.Context, params url.Values) {
+	createdAfter, hasCreatedAfter := parseFilterTime(params.Get("CreatedAfter"))
+	createdBefore, hasCreatedBefore := parseFilterTime(params.Get("CreatedBefore"))
+	updatedAfter, hasUpdatedAfter := parseFilterTime(params.Get("UpdatedAfter"))
+	status := params.Get("Status")
+	limit, _ := strconv.Atoi(params.Get("Limit"))
+	offset, _ := strconv.Atoi(params.Get("Offset"))
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+
+	h.mu.Lock()
+	matched := make([]*order, 0, len(h.orders))
+	for _, o := range h.orders {
+		if hasCreatedAfter && o.createdAt.Before(createdAfter) {
+			continue
+		}
+		if hasCreatedBefore && o.createdAt.After(createdBefore) {
+			continue
+		}
+		if hasUpdatedAfter && o.updatedAt.Before(updatedAfter) {
+			continue
+		}
+		if status != "" && !contains(o.statuses(), status) {
+			continue
+		}
+		matched = append(matched, o)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].id < matched[j].id })
+
+	total := len(matched)
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	page := make([]map[string]interface{}, 0, end-offset)
+	for _, o := range matched[offset:end] {
+		page = append(page, o.toJSON())
+	}
+	h.mu.Unlock()
+
+	success(c, "GetOrders", gin.H{"TotalCount": strconv.Itoa(total)}, gin.H{"Orders": gin.H{"Order": page}})
+}
+
+func (h *Handler) findOrder(c *gin.Context, action string, params url.Values) *order {
+	id, _ := strconv.Atoi(params.Get("OrderId"))
+	o := h.orders[id]
+	if o == nil {
+		failure(c, http.StatusBadRequest, action, 16, "E016: Invalid Order ID")
+	}
+	return o
+}
+
+func (h *Handler) getOrder(c *gin.Context, params url.Values) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	o := h.findOrder(c, "GetOrder", params)
+	if o == nil {
+		return
+	}
+	// con una sola orden Seller Center devuelve un objeto, no una lista
+	success(c, "GetOrder", nil, gin.H{"Orders": gin.H{"Order": o.toJSON()}})
+}
+
+func (h *Handler) getOrderItems(c *gin.Context, params url.Values) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	o := h.findOrder(c, "GetOrderItems", params)
+	if o == nil {
+		return
+	}
+	success(c, "GetOrderItems", nil, gin.H{"OrderItems": gin.H{"OrderItem": o.items}})
+}
+
+func parseIDs(raw string) []int {
+	raw = strings.Trim(raw, "[] ")
+	var ids []int
+	for _, part := range strings.Split(raw, ",") {
+		if id, err := strconv.Atoi(strings.Trim(part, `" `)); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (h *Handler) itemsByID(ids []int) []*orderItem {
+	wanted := map[int]bool{}
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	var out []*orderItem
+	for _, o := range h.orders {
+		for _, it := range o.items {
+			if wanted[it.OrderItemID] {
+				out = append(out, it)
+			}
+		}
+	}
+	return out
+}
+
+func (h *Handler) setReadyToShip(c *gin.Context, params url.Values) {
+	const action = "SetStatusToReadyToShip"
+	ids := parseIDs(params.Get("OrderItemIds"))
+	if len(ids) == 0 || params.Get("DeliveryType") == "" {
+		failure(c, http.StatusBadRequest, action, 20, "E020: OrderItemIds and DeliveryType are required")
+		return
+	}
+
+	h.mu.Lock()
+	items := h.itemsByID(ids)
+	if len(items) != len(ids) {
+		h.mu.Unlock()
+		failure(c, http.StatusBadRequest, action, 21, "E021: Invalid Order Item ID")
+		return
+	}
+	for _, it := range items {
+		if it.Status != "pending" {
+			h.mu.Unlock()
+			failure(c, http.StatusBadRequest, action, 73, fmt.Sprintf("E073: Order item %d is not pending", it.OrderItemID))
+			return
+		}
+	}
+	now := time.Now().UTC()
+	packageID := fmt.Sprintf("PKG%08d", items[0].OrderID)
+	for _, it := range items {
+		it.Status = "ready_to_ship"
+		it.TrackingCode = fmt.Sprintf("FAL%010d", it.OrderID)
+		it.PackageID = packageID
+		if provider := params.Get("ShippingProvider"); provider != "" {
+			it.ShipmentProvider = provider
+		}
+		it.UpdatedAt = now.Format(falabellaTimeLayout)
+		h.orders[it.OrderID].updatedAt = now
+	}
+	h.mu.Unlock()
+
+	h.logger.Info().Msgf("Falabella mock: %d items listos para despacho (paquete %s)", len(items), packageID)
+	success(c, action, nil, gin.H{"OrderItems": gin.H{"OrderItem": gin.H{"PurchaseOrderId": packageID}}})
+}
+
+func (h *Handler) getDocument(c *gin.Context, params url.Values) {
+	const action = "GetDocument"
+	ids := parseIDs(params.Get("OrderItemIds"))
+
+	h.mu.Lock()
+	items := h.itemsByID(ids)
+	ready := len(items) > 0
+	for _, it := range items {
+		if it.Status == "pending" {
+			ready = false
+		}
+	}
+	h.mu.Unlock()
+
+	if !ready {
+		failure(c, http.StatusBadRequest, action, 32, "E032: Document not available for pending items")
+		return
+	}
+	success(c, action, nil, gin.H{"Documents": gin.H{"Document": gin.H{
+		"DocumentType": params.Get("DocumentType"),
+		"MimeType":     "application/pdf",
+		"File":         base64.StdEncoding.EncodeToString([]byte(mockLabel)),
+	}}})
+}
+
+type productUpdate struct {
+	SellerSku string `xml:"SellerSku"`
+	Quantity  string `xml:"Quantity"`
+	Price     string `xml:"Price"`
+}
+
+func (h *Handler) productUpdate(c *gin.Context) {
+	const action = "ProductUpdate"
+	raw, _ := io.ReadAll(c.Request.Body)
+	var req struct {
+		Products []productUpdate `xml:"Product"`
+	}
+	if err := xml.Unmarshal(raw, &req); err != nil || len(req.Products) == 0 {
+		failure(c, http.StatusBadRequest, action, 1000, "E1000: Internal Application Error (invalid XML)")
+		return
+	}
+
+	h.mu.Lock()
+	id := fmt.Sprintf("mock-feed-%06d", h.nextFeed)
+	h.nextFeed++
+	h.feeds[id] = &feed{id: id, action: action, createdAt: time.Now(), total: len(req.Products), updates: req.Products}
+	h.mu.Unlock()
+
+	h.logger.Info().Msgf("Falabella mock: feed %s con %d productos", id, len(req.Products))
+	success(c, action, gin.H{"RequestId": id}, gin.H{})
+}
+
+// apply aplica el feed cuando termina su tiempo de procesamiento. Debe
+// llamarse con el mutex tomado.
+func (h *Handler) apply(f *feed) {
+	if f.applied || time.Since(f.createdAt) < feedProcessingTime {
+		return
+	}
+	f.applied = true
+	for _, u := range f.updates {
+		p := h.products[u.SellerSku]
+		if p == nil {
+			f.errors = append(f.errors, map[string]interface{}{
+				"Code": "1", "Message": "Seller SKU '" + u.SellerSku + "' not found", "SellerSku": u.SellerSku,
+			})
+			continue
+		}
+		if u.Quantity != "" {
+			p.Quantity, _ = strconv.Atoi(u.Quantity)
+		}
+		if u.Price != "" {
+			p.Price, _ = strconv.ParseFloat(u.Price, 64)
+		}
+		h.logger.Info().Msgf("Falabella mock: %s stock=%d precio=%.2f", p.SellerSku, p.Quantity, p.Price)
+	}
+}
+
+func (h *Handler) feedStatus(c *gin.Context, params url.Values) {
+	const action = "FeedStatus"
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	f := h.feeds[params.Get("FeedID")]
+	if f == nil {
+		failure(c, http.StatusBadRequest, action, 2000, "E2000: Feed not found")
+		return
+	}
+	h.apply(f)
+
+	status, processed := "Processing", 0
+	if f.applied {
+		status, processed = "Finished", f.total
+	}
+	detail := gin.H{
+		"Feed":             f.id,
+		"Status":           status,
+		"Action":           f.action,
+		"CreationDate":     f.createdAt.Format(falabellaTimeLayout),
+		"Source":           "api",
+		"TotalRecords":     strconv.Itoa(f.total),
+		"ProcessedRecords": strconv.Itoa(processed),
+		"FailedRecords":    strconv.Itoa(len(f.errors)),
+	}
+	if len(f.errors) > 0 {
+		detail["FeedErrors"] = gin.H{"Error": f.errors}
+	}
+	success(c, action, nil, gin.H{"FeedDetail": detail})
+}
+
+// handleSimulateOrder crea una orden (o cambia el estado de una existente
+// con ?order_id=&status=) y envia el webhook de Seller Center al target.
+func (h *Handler) handleSimulateOrder(c *gin.Context) {
+	event := "onOrderCreated"
+
+	h.mu.Lock()
+	var o *order
+	if raw := c.Query("order_id"); raw != "" {
+		id, _ := strconv.Atoi(raw)
+		o = h.orders[id]
+		if o == nil {
+			h.mu.Unlock()
+			c.JSON(http.StatusNotFound, gin.H{"message": "orden no encontrada"})
+			return
+		}
+		status := c.DefaultQuery("status", "shipped")
+		for _, it := range o.items {
+			if it.Status != "canceled" {
+				it.Status = status
+			}
+		}
+		o.updatedAt = time.Now().UTC()
+		event = "onOrderItemsStatusChanged"
+	} else {
+		o = h.newOrder(time.Now())
+	}
+	orderID := o.id
+	orderNumber := o.number
+	h.mu.Unlock()
+
+	target := c.Query("target")
+	if target == "" {
+		target = h.webhookURL
+	}
+	if target == "" {
+		c.JSON(http.StatusOK, gin.H{"message": "orden creada sin webhook (no hay target)", "order_id": orderID})
+		return
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"event":   event,
+		"payload": map[string]interface{}{"OrderId": orderID},
+	})
+
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"message": "no se pudo entregar el webhook", "error": err.Error(), "target": target})
+		return
+	}
+	defer resp.Body.Close()
+
+	h.logger.Info().Msgf("Falabella mock: webhook %s de la orden %d enviado a %s (status %d)", event, orderID, target, resp.StatusCode)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":         "webhook enviado",
+		"order_id":        orderID,
+		"order_number":    orderNumber,
+		"event":           event,
+		"target":          target,
+		"response_status": resp.StatusCode,
+	})
+}
+
+func (h *Handler) handleSeedProducts(c *gin.Context) {
+	var body struct {
+		Reset    bool `json:"reset"`
+		Products []struct {
+			SKU   string  `json:"sku"`
+			Name  string  `json:"name"`
+			Price float64 `json:"price"`
+			Stock int     `json:"stock"`
+		} `json:"products"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	created := 0
+
+	h.mu.Lock()
+	if body.Reset {
+		h.products = make(map[string]*product)
+	}
+	for _, p := range body.Products {
+		if p.SKU == "" {
+			continue
+		}
+		h.seedProduct(p.SKU, p.Name, p.Price, p.Stock)
+		created++
+	}
+	total := len(h.products)
+	h.mu.Unlock()
+
+	h.logger.Info().Msgf("Falabella mock: seed con %d productos (total %d)", created, total)
+	c.JSON(http.StatusOK, gin.H{"created": created, "total": total})
+}
+
+func (h *Handler) handleListProducts(c *gin.Context) {
+	h.mu.Lock()
+	items := make([]*product, 0, len(h.products))
+	for _, p := range h.products {
+		items = append(items, p)
+	}
+	h.mu.Unlock()
+
+	sort.Slice(items, func(i, j int) bool { return items[i].SellerSku < items[j].SellerSku })
+	c.JSON(http.StatusOK, gin.H{"items": items, "total_count": len(items)})
+}
+
+func contains(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package magento
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/testing/integrations/magento/internal/handlers"
+	"github.com/secamc93/probability/back/testing/shared/log"
+)
+
+type MagentoIntegration struct {
+	handler *handlers.Handler
+	logger  log.ILogger
+	port    string
+}
+
+func New(logger log.ILogger, port, webhookURL, webhookSecret string) *MagentoIntegration {
+	return &MagentoIntegration{
+		handler: handlers.New(logger, webhookURL, webhookSecret),
+		logger:  logger,
+		port:    port,
+	}
+}
+
+func (s *MagentoIntegration) Start() error {
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	router.Use(func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+		method := c.Request.Method
+		c.Next()
+		s.logger.Info().Msgf("[%s] %s %s - Status: %d - Duration: %v",
+			time.Now().Format("15:04:05"), method, path, c.Writer.Status(), time.Since(start))
+	})
+
+	s.handler.RegisterRoutes(router)
+	return router.Run(":" + s.port)
+}
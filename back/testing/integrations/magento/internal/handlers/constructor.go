@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/testing/shared/log"
+)
+
+const magentoTimeLayout = "2006-01-02 15:04:05"
+
+type product struct {
+	SKU   string  `json:"sku"`
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+type sourceItem struct {
+	SKU        string `json:"sku"`
+	SourceCode string `json:"source_code"`
+	Quantity   int    `json:"quantity"`
+	Status     int    `json:"status"`
+}
+
+type Handler struct {
+	logger log.ILogger
+
+	mu            sync.Mutex
+	products      map[string]*product
+	sourceItems   map[string]*sourceItem
+	orders        map[int]map[string]interface{}
+	nextOrderID   int
+	webhookURL    string
+	webhookSecret string
+}
+
+func New(logger log.ILogger, webhookURL, webhookSecret string) *Handler {
+	h := &Handler{
+		logger:        logger,
+		products:      make(map[string]*product),
+		sourceItems:   make(map[string]*sourceItem),
+		orders:        make(map[int]map[string]interface{}),
+		nextOrderID:   1,
+		webhookURL:    webhookURL,
+		webhookSecret: webhookSecret,
+	}
+	h.seedProduct("MAG-MOCK-001", "Camiseta Mock Magento", 59900, 10)
+	h.seedProduct("MAG-MOCK-002", "Gorra Mock Magento", 35000, 25)
+
+	// Historial de ordenes para probar la paginacion del import
+	base := time.Now().UTC().AddDate(0, 0, -5)
+	for i := 0; i < 3; i++ {
+		h.newOrder("processing", base.Add(time.Duration(i)*time.Hour))
+	}
+	return h
+}
+
+func (h *Handler) seedProduct(sku, name string, price float64, qty int) {
+	h.products[sku] = &product{SKU: sku, Name: name, Price: price}
+	h.sourceItems[sourceKey(sku, "default")] = &sourceItem{SKU: sku, SourceCode: "default", Quantity: qty, Status: 1}
+}
+
+func sourceKey(sku, sourceCode string) string {
+	return sourceCode + ":" + sku
+}
+
+// newOrder crea una orden con la forma de GET /V1/orders/{id}. Debe llamarse
+// con el mutex tomado (o durante la construccion).
+func (h *Handler) newOrder(state string, createdAt time.Time) map[string]interface{} {
+	id := h.nextOrderID
+	h.nextOrderID++
+
+	statusByState := map[string]string{
+		"new":        "pending",
+		"processing": "processing",
+		"complete":   "complete",
+		"canceled":   "canceled",
+		"holded":     "holded",
+	}
+	status := statusByState[state]
+	if status == "" {
+		status = state
+	}
+
+	address := map[string]interface{}{
+		"firstname":  "Cliente",
+		"lastname":   "Mock Magento",
+		"street":     []string{"Calle 100 #15-20", "Oficina 301"},
+		"city":       "Bogota",
+		"region":     "Cundinamarca",
+		"postcode":   "110111",
+		"country_id": "CO",
+		"telephone":  "+573001234567",
+		"email":      "cliente.magento@mock.test",
+	}
+
+	created := createdAt.UTC().Format(magentoTimeLayout)
+	totalPaid := 0.0
+	if state == "processing" || state == "complete" {
+		totalPaid = 134800
+	}
+
+	order := map[string]interface{}{
+		"entity_id":            id,
+		"increment_id":         fmt.Sprintf("%09d", id),
+		"state":                state,
+		"status":               status,
+		"created_at":           created,
+		"updated_at":           created,
+		"order_currency_code":  "COP",
+		"grand_total":          134800,
+		"subtotal":             119800,
+		"tax_amount":           0,
+		"discount_amount":      0,
+		"shipping_amount":      15000,
+		"total_paid":           totalPaid,
+		"customer_email":       "cliente.magento@mock.test",
+		"customer_firstname":   "Cliente",
+		"customer_lastname":    "Mock Magento",
+		"customer_is_guest":    0,
+		"shipping_description": "Flat Rate - Fixed",
+		"billing_address":      address,
+		"payment": map[string]interface{}{
+			"method":        "checkmo",
+			"amount_paid":   totalPaid,
+			"last_trans_id": "",
+		},
+		"items": []map[string]interface{}{
+			{
+				"item_id":         id*10 + 1,
+				"product_id":      1,
+				"product_type":    "simple",
+				"sku":             "MAG-MOCK-001",
+				"name":            "Camiseta Mock Magento",
+				"qty_ordered":     2,
+				"price":           59900,
+				"row_total":       119800,
+				"tax_amount":      0,
+				"discount_amount": 0,
+			},
+		},
+		"extension_attributes": map[string]interface{}{
+			"shipping_assignments": []map[string]interface{}{
+				{"shipping": map[string]interface{}{"address": address, "method": "flatrate_flatrate"}},
+			},
+		},
+	}
+	h.orders[id] = order
+	return order
+}
+
+func (h *Handler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/health", h.handleHealth)
+
+	rest := router.Group("/rest/V1")
+	rest.Use(requireBearer)
+	{
+		rest.GET("/store/storeConfigs", h.handleStoreConfigs)
+		rest.GET("/orders", h.handleListOrders)
+		rest.GET("/orders/:id", h.handleGetOrder)
+		rest.POST("/inventory/source-items", h.handleSaveSourceItems)
+		rest.GET("/inventory/source-items", h.handleListSourceItems)
+	}
+
+	router.POST("/simulate/order", h.handleSimulateOrder)
+	router.POST("/mock/seed-products", h.handleSeedProducts)
+}
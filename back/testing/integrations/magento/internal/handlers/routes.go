@@ -0,0 +1,330 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h *Handler) handleHealth(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "mock": "magento"})
+}
+
+func requireBearer(c *gin.Context) {
+	if !strings.HasPrefix(c.GetHeader("Authorization"), "Bearer ") {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+			"message": "The consumer isn't authorized to access %resources.",
+			"parameters": gin.H{
+				"resources": "Magento_Sales::actions_view",
+			},
+		})
+		return
+	}
+	c.Next()
+}
+
+func (h *Handler) handleStoreConfigs(c *gin.Context) {
+	c.JSON(http.StatusOK, []gin.H{{
+		"id":                            1,
+		"code":                          "default",
+		"website_id":                    1,
+		"locale":                        "es_CO",
+		"base_currency_code":            "COP",
+		"timezone":                      "America/Bogota",
+		"base_url":                      "http://" + c.Request.Host + "/",
+		"base_link_url":                 "http://" + c.Request.Host + "/",
+		"default_display_currency_code": "COP",
+	}})
+}
+
+type searchFilter struct {
+	field     string
+	value     string
+	condition string
+}
+
+// parseFilters lee searchCriteria[filter_groups][i][filters][0]; todos los
+// grupos se combinan con AND, igual que en Magento.
+func parseFilters(c *gin.Context) []searchFilter {
+	var filters []searchFilter
+	for i := 0; ; i++ {
+		prefix := fmt.Sprintf("searchCriteria[filter_groups][%d][filters][0]", i)
+		field := c.Query(prefix + "[field]")
+		if field == "" {
+			return filters
+		}
+		filters = append(filters, searchFilter{
+			field:     field,
+			value:     c.Query(prefix + "[value]"),
+			condition: c.DefaultQuery(prefix+"[condition_type]", "eq"),
+		})
+	}
+}
+
+func matches(order map[string]interface{}, filters []searchFilter) bool {
+	for _, f := range filters {
+		// las fechas van en "2006-01-02 15:04:05", comparables como texto
+		actual := fmt.Sprintf("%v", order[f.field])
+		switch f.condition {
+		case "gteq":
+			if actual < f.value {
+				return false
+			}
+		case "lteq":
+			if actual > f.value {
+				return false
+			}
+		case "gt":
+			if actual <= f.value {
+				return false
+			}
+		case "lt":
+			if actual >= f.value {
+				return false
+			}
+		case "neq":
+			if actual == f.value {
+				return false
+			}
+		default:
+			if actual != f.value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (h *Handler) handleListOrders(c *gin.Context) {
+	filters := parseFilters(c)
+	sortField := c.DefaultQuery("searchCriteria[sortOrders][0][field]", "created_at")
+	pageSize, _ := strconv.Atoi(c.DefaultQuery("searchCriteria[pageSize]", "20"))
+	currentPage, _ := strconv.Atoi(c.DefaultQuery("searchCriteria[currentPage]", "1"))
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+	if currentPage <= 0 {
+		currentPage = 1
+	}
+
+	h.mu.Lock()
+	matched := make([]map[string]interface{}, 0, len(h.orders))
+	for _, order := range h.orders {
+		if matches(order, filters) {
+			matched = append(matched, order)
+		}
+	}
+	h.mu.Unlock()
+
+	sort.Slice(matched, func(i, j int) bool {
+		a, b := fmt.Sprintf("%v", matched[i][sortField]), fmt.Sprintf("%v", matched[j][sortField])
+		if a == b {
+			return matched[i]["entity_id"].(int) < matched[j]["entity_id"].(int)
+		}
+		return a < b
+	})
+
+	total := len(matched)
+	// Magento devuelve la ultima pagina si currentPage se pasa del total
+	lastPage := (total + pageSize - 1) / pageSize
+	if lastPage > 0 && currentPage > lastPage {
+		currentPage = lastPage
+	}
+	start := (currentPage - 1) * pageSize
+	end := start + pageSize
+	if start > total {
+		start = total
+	}
+	if end > total {
+		end = total
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"items": matched[start:end],
+		"search_criteria": gin.H{
+			"page_size":    pageSize,
+			"current_page": currentPage,
+		},
+		"total_count": total,
+	})
+}
+
+func (h *Handler) handleGetOrder(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+
+	h.mu.Lock()
+	order, ok := h.orders[id]
+	h.mu.Unlock()
+
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"message": "The entity that was requested doesn't exist. Verify the entity and try again."})
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
+
+func (h *Handler) handleSaveSourceItems(c *gin.Context) {
+	var body struct {
+		SourceItems []sourceItem `json:"sourceItems"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil || len(body.SourceItems) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Input data is invalid"})
+		return
+	}
+
+	h.mu.Lock()
+	for _, item := range body.SourceItems {
+		if _, ok := h.products[item.SKU]; !ok {
+			h.mu.Unlock()
+			c.JSON(http.StatusBadRequest, gin.H{
+				"message":    "Validation Failed",
+				"parameters": gin.H{"sku": item.SKU},
+			})
+			return
+		}
+	}
+	for _, item := range body.SourceItems {
+		it := item
+		h.sourceItems[sourceKey(it.SKU, it.SourceCode)] = &it
+		h.logger.Info().Msgf("Magento mock: stock %s@%s = %d", it.SKU, it.SourceCode, it.Quantity)
+	}
+	h.mu.Unlock()
+
+	// La API real responde un array vacio
+	c.JSON(http.StatusOK, []interface{}{})
+}
+
+func (h *Handler) handleListSourceItems(c *gin.Context) {
+	h.mu.Lock()
+	items := make([]*sourceItem, 0, len(h.sourceItems))
+	for _, it := range h.sourceItems {
+		items = append(items, it)
+	}
+	h.mu.Unlock()
+
+	sort.Slice(items, func(i, j int) bool {
+		return sourceKey(items[i].SKU, items[i].SourceCode) < sourceKey(items[j].SKU, items[j].SourceCode)
+	})
+	c.JSON(http.StatusOK, gin.H{"items": items, "total_count": len(items)})
+}
+
+// handleSimulateOrder crea una orden (o cambia el estado de una existente
+// con ?order_id=) y envia la notificacion del observer al target.
+func (h *Handler) handleSimulateOrder(c *gin.Context) {
+	state := c.DefaultQuery("state", "new")
+	event := c.DefaultQuery("event", "sales_order_save_after")
+
+	h.mu.Lock()
+	var order map[string]interface{}
+	if raw := c.Query("order_id"); raw != "" {
+		id, _ := strconv.Atoi(raw)
+		order = h.orders[id]
+		if order == nil {
+			h.mu.Unlock()
+			c.JSON(http.StatusNotFound, gin.H{"message": "orden no encontrada"})
+			return
+		}
+		order["state"] = state
+		order["status"] = state
+		order["updated_at"] = time.Now().UTC().Format(magentoTimeLayout)
+	} else {
+		order = h.newOrder(state, time.Now())
+		event = c.DefaultQuery("event", "sales_order_place_after")
+	}
+	orderID := order["entity_id"]
+	incrementID := order["increment_id"]
+	h.mu.Unlock()
+
+	target := c.Query("target")
+	if target == "" {
+		target = h.webhookURL
+	}
+	if target == "" {
+		c.JSON(http.StatusOK, gin.H{"message": "orden creada sin webhook (no hay target)", "order_id": orderID})
+		return
+	}
+
+	body, _ := json.Marshal(map[string]interface{}{
+		"event":        event,
+		"order_id":     orderID,
+		"increment_id": incrementID,
+	})
+
+	req, err := http.NewRequest(http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	secret := c.DefaultQuery("secret", h.webhookSecret)
+	if secret != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		req.Header.Set("X-Magento-Signature", hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	client := &http.Client{Timeout: 15 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"message": "no se pudo entregar el webhook", "error": err.Error(), "target": target})
+		return
+	}
+	defer resp.Body.Close()
+
+	h.logger.Info().Msgf("Magento mock: webhook %s de la orden %v enviado a %s (status %d)", event, orderID, target, resp.StatusCode)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":         "webhook enviado",
+		"order_id":        orderID,
+		"increment_id":    incrementID,
+		"event":           event,
+		"target":          target,
+		"response_status": resp.StatusCode,
+	})
+}
+
+func (h *Handler) handleSeedProducts(c *gin.Context) {
+	var body struct {
+		Reset    bool `json:"reset"`
+		Products []struct {
+			SKU   string  `json:"sku"`
+			Name  string  `json:"name"`
+			Price float64 `json:"price"`
+			Stock int     `json:"stock"`
+		} `json:"products"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	created := 0
+
+	h.mu.Lock()
+	if body.Reset {
+		h.products = make(map[string]*product)
+		h.sourceItems = make(map[string]*sourceItem)
+	}
+	for _, p := range body.Products {
+		if p.SKU == "" {
+			continue
+		}
+		h.seedProduct(p.SKU, p.Name, p.Price, p.Stock)
+		created++
+	}
+	total := len(h.products)
+	h.mu.Unlock()
+
+	h.logger.Info().Msgf("Magento mock: seed con %d productos (total %d)", created, total)
+	c.JSON(http.StatusOK, gin.H{"created": created, "total": total})
+}
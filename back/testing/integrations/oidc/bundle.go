@@ -0,0 +1,51 @@
+package oidc
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/testing/integrations/oidc/internal/handlers"
+	"github.com/secamc93/probability/back/testing/shared/log"
+)
+
+type OIDCProvider struct {
+	handler *handlers.Handler
+	logger  log.ILogger
+	port    string
+}
+
+// New crea el proveedor OIDC de pruebas. Sin issuer se usa
+// http://localhost:<port>; clientID y clientSecret vacios aceptan cualquier
+// cliente.
+func New(logger log.ILogger, port, issuer, clientID, clientSecret string) (*OIDCProvider, error) {
+	if issuer == "" {
+		issuer = "http://localhost:" + port
+	}
+	handler, err := handlers.New(logger, issuer, clientID, clientSecret)
+	if err != nil {
+		return nil, err
+	}
+	return &OIDCProvider{
+		handler: handler,
+		logger:  logger,
+		port:    port,
+	}, nil
+}
+
+func (s *OIDCProvider) Start() error {
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(gin.Recovery())
+
+	router.Use(func(c *gin.Context) {
+		start := time.Now()
+		path := c.Request.URL.Path
+		method := c.Request.Method
+		c.Next()
+		s.logger.Info().Msgf("[%s] %s %s - Status: %d - Duration: %v",
+			time.Now().Format("15:04:05"), method, path, c.Writer.Status(), time.Since(start))
+	})
+
+	s.handler.RegisterRoutes(router)
+	return router.Run(":" + s.port)
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/secamc93/probability/back/testing/shared/log"
+)
+
+// codeTTL es lo que vive un authorization code sin canjear
+const codeTTL = 2 * time.Minute
+
+// authCode guarda lo que se pidio en /authorize hasta que llega el /token
+type authCode struct {
+	clientID      string
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+	email         string
+	name          string
+	groups        []string
+	emailVerified bool
+	expiresAt     time.Time
+}
+
+type Handler struct {
+	logger log.ILogger
+
+	issuer       string
+	clientID     string
+	clientSecret string
+	key          *rsa.PrivateKey
+	keyID        string
+
+	mu    sync.Mutex
+	codes map[string]*authCode
+}
+
+// New genera la llave RSA con la que se firman los id_token. La llave vive
+// en memoria: al reiniciar el mock cambia el kid y central recarga el JWKS.
+func New(logger log.ILogger, issuer, clientID, clientSecret string) (*Handler, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("generando llave RSA: %w", err)
+	}
+	return &Handler{
+		logger:       logger,
+		issuer:       issuer,
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		key:          key,
+		keyID:        fmt.Sprintf("mock-%d", time.Now().Unix()),
+		codes:        make(map[string]*authCode),
+	}, nil
+}
+
+func (h *Handler) RegisterRoutes(router *gin.Engine) {
+	router.GET("/health", h.handleHealth)
+
+	router.GET("/.well-known/openid-configuration", h.handleDiscovery)
+	router.GET("/jwks", h.handleJWKS)
+	router.GET("/authorize", h.handleAuthorize)
+	router.POST("/token", h.handleToken)
+}
@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"html/template"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// loginPage es la pantalla de "login" del IdP: solo pide el email y los
+// grupos que llevara el id_token; los demas parametros viajan ocultos
+var loginPage = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Mock OIDC</title></head>
+<body style="font-family:sans-serif;max-width:420px;margin:40px auto">
+<h2>Mock OIDC - Iniciar sesion</h2>
+<form method="GET" action="/authorize">
+{{range $k, $v := .Params}}<input type="hidden" name="{{$k}}" value="{{$v}}">
+{{end}}<p><label>Email<br><input name="email" type="email" required style="width:100%"></label></p>
+<p><label>Nombre<br><input name="name" style="width:100%"></label></p>
+<p><label>Grupos (separados por coma)<br><input name="groups" style="width:100%" placeholder="admins,ventas"></label></p>
+<p><label>Email verificado <select name="email_verified"><option value="true">si</option><option value="false">no</option></select></label></p>
+<button type="submit">Entrar</button>
+</form>
+</body></html>`))
+
+func (h *Handler) handleHealth(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok", "mock": "oidc"})
+}
+
+func (h *Handler) handleDiscovery(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"issuer":                                h.issuer,
+		"authorization_endpoint":                h.issuer + "/authorize",
+		"token_endpoint":                        h.issuer + "/token",
+		"jwks_uri":                              h.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"scopes_supported":                      []string{"openid", "email", "profile"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post"},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"claims_supported":                      []string{"sub", "email", "email_verified", "name", "groups", "nonce"},
+	})
+}
+
+func (h *Handler) handleJWKS(c *gin.Context) {
+	pub := h.key.PublicKey
+	c.JSON(http.StatusOK, gin.H{"keys": []gin.H{{
+		"kty": "RSA",
+		"use": "sig",
+		"alg": "RS256",
+		"kid": h.keyID,
+		"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+	}}})
+}
+
+// handleAuthorize muestra el formulario de login o, si ya viene el email en
+// la query (p.ej. desde un test), redirige directo con el code. Los
+// parametros email, name, groups y email_verified se pueden pasar para
+// automatizar el flujo sin formulario.
+func (h *Handler) handleAuthorize(c *gin.Context) {
+	q := c.Request.URL.Query()
+
+	if q.Get("response_type") != "code" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unsupported_response_type"})
+		return
+	}
+	if h.clientID != "" && q.Get("client_id") != h.clientID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unauthorized_client"})
+		return
+	}
+	redirectURI, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil || redirectURI.Scheme == "" || redirectURI.Host == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request", "error_description": "redirect_uri invalida"})
+		return
+	}
+	if !strings.Contains(" "+q.Get("scope")+" ", " openid ") {
+		h.redirectError(c, redirectURI, q.Get("state"), "invalid_scope")
+		return
+	}
+	if q.Get("code_challenge") == "" || q.Get("code_challenge_method") != "S256" {
+		h.redirectError(c, redirectURI, q.Get("state"), "invalid_request")
+		return
+	}
+
+	email := strings.TrimSpace(q.Get("email"))
+	if email == "" {
+		if hint := q.Get("login_hint"); strings.Contains(hint, "@") {
+			email = hint
+		}
+	}
+	if email == "" {
+		params := map[string]string{}
+		for _, k := range []string{"response_type", "client_id", "redirect_uri", "scope", "state", "nonce", "code_challenge", "code_challenge_method"} {
+			params[k] = q.Get(k)
+		}
+		c.Header("Content-Type", "text/html; charset=utf-8")
+		_ = loginPage.Execute(c.Writer, gin.H{"Params": params})
+		return
+	}
+
+	var groups []string
+	for _, g := range strings.Split(q.Get("groups"), ",") {
+		if g = strings.TrimSpace(g); g != "" {
+			groups = append(groups, g)
+		}
+	}
+
+	code := randomToken()
+	h.mu.Lock()
+	h.codes[code] = &authCode{
+		clientID:      q.Get("client_id"),
+		redirectURI:   q.Get("redirect_uri"),
+		codeChallenge: q.Get("code_challenge"),
+		nonce:         q.Get("nonce"),
+		email:         strings.ToLower(email),
+		name:          q.Get("name"),
+		groups:        groups,
+		emailVerified: q.Get("email_verified") != "false",
+		expiresAt:     time.Now().Add(codeTTL),
+	}
+	h.mu.Unlock()
+
+	h.logger.Info().Msgf("[OIDC mock] code emitido para %s (grupos: %v)", email, groups)
+
+	values := redirectURI.Query()
+	values.Set("code", code)
+	values.Set("state", q.Get("state"))
+	redirectURI.RawQuery = values.Encode()
+	c.Redirect(http.StatusFound, redirectURI.String())
+}
+
+// handleToken canjea el code: valida el cliente (basic o post), la
+// redirect_uri y el code_verifier PKCE, y firma el id_token con RS256
+func (h *Handler) handleToken(c *gin.Context) {
+	if c.PostForm("grant_type") != "authorization_code" {
+		tokenError(c, http.StatusBadRequest, "unsupported_grant_type", "solo authorization_code")
+		return
+	}
+
+	clientID, clientSecret, ok := c.Request.BasicAuth()
+	if ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+	} else {
+		clientID = c.PostForm("client_id")
+		clientSecret = c.PostForm("client_secret")
+	}
+	if h.clientID != "" && (clientID != h.clientID || subtle.ConstantTimeCompare([]byte(clientSecret), []byte(h.clientSecret)) != 1) {
+		tokenError(c, http.StatusUnauthorized, "invalid_client", "credenciales del cliente invalidas")
+		return
+	}
+
+	h.mu.Lock()
+	code, found := h.codes[c.PostForm("code")]
+	delete(h.codes, c.PostForm("code"))
+	h.mu.Unlock()
+
+	switch {
+	case !found || time.Now().After(code.expiresAt):
+		tokenError(c, http.StatusBadRequest, "invalid_grant", "code invalido o expirado")
+		return
+	case code.clientID != clientID:
+		tokenError(c, http.StatusBadRequest, "invalid_grant", "el code es de otro cliente")
+		return
+	case code.redirectURI != c.PostForm("redirect_uri"):
+		tokenError(c, http.StatusBadRequest, "invalid_grant", "redirect_uri no coincide")
+		return
+	}
+	verifier := c.PostForm("code_verifier")
+	sum := sha256.Sum256([]byte(verifier))
+	if verifier == "" || base64.RawURLEncoding.EncodeToString(sum[:]) != code.codeChallenge {
+		tokenError(c, http.StatusBadRequest, "invalid_grant", "code_verifier no coincide con el code_challenge")
+		return
+	}
+
+	now := time.Now()
+	subject := sha256.Sum256([]byte(code.email))
+	claims := jwt.MapClaims{
+		"iss":            h.issuer,
+		"sub":            "mock-" + hex.EncodeToString(subject[:8]),
+		"aud":            clientID,
+		"iat":            now.Unix(),
+		"exp":            now.Add(time.Hour).Unix(),
+		"email":          code.email,
+		"email_verified": code.emailVerified,
+	}
+	if code.nonce != "" {
+		claims["nonce"] = code.nonce
+	}
+	if code.name != "" {
+		claims["name"] = code.name
+	}
+	if len(code.groups) > 0 {
+		claims["groups"] = code.groups
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = h.keyID
+	idToken, err := token.SignedString(h.key)
+	if err != nil {
+		tokenError(c, http.StatusInternalServerError, "server_error", err.Error())
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"access_token": randomToken(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+func (h *Handler) redirectError(c *gin.Context, redirectURI *url.URL, state, code string) {
+	values := redirectURI.Query()
+	values.Set("error", code)
+	values.Set("state", state)
+	redirectURI.RawQuery = values.Encode()
+	c.Redirect(http.StatusFound, redirectURI.String())
+}
+
+func tokenError(c *gin.Context, status int, code, description string) {
+	c.JSON(status, gin.H{"error": code, "error_description": description})
+}
+
+func randomToken() string {
+	buf := make([]byte, 24)
+	_, _ = rand.Read(buf)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}